@@ -13,15 +13,34 @@ import (
 
 var ConsoleConfig *Config
 
+// DefaultAdminPassword is the shipped bootstrap admin password, an account still using it must change it on first login.
+const DefaultAdminPassword = "G@ppm0ym"
+
 type (
 	// Config -.
 	Config struct {
-		App  `yaml:"app"`
-		HTTP `yaml:"http"`
-		Log  `yaml:"logger"`
-		DB   `yaml:"postgres"`
-		EA   `yaml:"ea"`
-		Auth `yaml:"auth"`
+		App             `yaml:"app"`
+		HTTP            `yaml:"http"`
+		Log             `yaml:"logger"`
+		DB              `yaml:"postgres"`
+		EA              `yaml:"ea"`
+		Auth            `yaml:"auth"`
+		Scheduler       `yaml:"scheduler"`
+		WSMAN           `yaml:"wsman"`
+		Health          `yaml:"health"`
+		Webhooks        `yaml:"webhooks"`
+		Recordings      `yaml:"recordings"`
+		Images          `yaml:"images"`
+		Redirection     `yaml:"redirection"`
+		VNC             `yaml:"vnc"`
+		KVM             `yaml:"kvm"`
+		FeaturePolicies `yaml:"featurePolicies"`
+		Inventory       `yaml:"inventory"`
+		AMTAudit        `yaml:"amtAudit"`
+		EventLog        `yaml:"eventLog"`
+		Alerts          `yaml:"alerts"`
+		SIEM            `yaml:"siem"`
+		CertExpiry      `yaml:"certificateExpiry"`
 	}
 
 	// App -.
@@ -60,15 +79,160 @@ type (
 
 	Auth struct {
 		Disabled bool `yaml:"disabled" env:"AUTH_DISABLED"`
-		// BASIC
+		// BASIC, the admin account is only created on first run when there are no users yet
 		AdminUsername            string        `yaml:"adminUsername" env:"AUTH_ADMIN_USERNAME"`
 		AdminPassword            string        `yaml:"adminPassword" env:"AUTH_ADMIN_PASSWORD"`
+		MaxLoginAttempts         int           `yaml:"maxLoginAttempts" env:"AUTH_MAX_LOGIN_ATTEMPTS"`
+		LockoutDuration          time.Duration `yaml:"lockoutDuration" env:"AUTH_LOCKOUT_DURATION"`
 		JWTKey                   string        `env-required:"true" yaml:"jwtKey" env:"AUTH_JWT_KEY"`
 		JWTExpiration            time.Duration `yaml:"jwtExpiration" env:"AUTH_JWT_EXPIRATION"`
 		RedirectionJWTExpiration time.Duration `yaml:"redirectionJWTExpiration" env:"AUTH_REDIRECTION_JWT_EXPIRATION"`
 		// OAUTH
 		ClientID string `yaml:"clientId" env:"AUTH_CLIENT_ID"`
 		Issuer   string `yaml:"issuer" env:"AUTH_ISSUER"`
+		// RBAC, GroupRoles maps a value of the RolesClaim in an OIDC token to a role
+		RolesClaim string            `yaml:"rolesClaim" env:"AUTH_ROLES_CLAIM"`
+		GroupRoles map[string]string `yaml:"groupRoles" env:"AUTH_GROUP_ROLES"`
+		// TENANCY, TenantClaim names the OIDC claim holding the tenant, the X-Tenant-Id header of one of the
+		// TrustedProxies names it for tokens without one and must agree with it otherwise
+		TenantClaim    string   `yaml:"tenantClaim" env:"AUTH_TENANT_CLAIM"`
+		TrustedProxies []string `yaml:"trustedProxies" env:"AUTH_TRUSTED_PROXIES"`
+	}
+
+	// Scheduler -.
+	Scheduler struct {
+		Interval time.Duration `yaml:"interval" env:"SCHEDULER_INTERVAL"`
+	}
+
+	// WSMAN -.
+	WSMAN struct {
+		Workers        int           `yaml:"workers" env:"WSMAN_WORKERS"`
+		IdleTimeout    time.Duration `yaml:"idleTimeout" env:"WSMAN_IDLE_TIMEOUT"`
+		MaxConnections int           `yaml:"maxConnections" env:"WSMAN_MAX_CONNECTIONS"`
+		AuthWait       time.Duration `yaml:"authWait" env:"WSMAN_AUTH_WAIT"`
+	}
+
+	// Health -.
+	Health struct {
+		PollInterval time.Duration `yaml:"pollInterval" env:"HEALTH_POLL_INTERVAL"`
+		PollRate     int           `yaml:"pollRate" env:"HEALTH_POLL_RATE"`
+		PollWorkers  int           `yaml:"pollWorkers" env:"HEALTH_POLL_WORKERS"`
+		PollJitter   time.Duration `yaml:"pollJitter" env:"HEALTH_POLL_JITTER"`
+	}
+
+	// Webhooks -.
+	Webhooks struct {
+		DeliveryTimeout time.Duration `yaml:"deliveryTimeout" env:"WEBHOOKS_DELIVERY_TIMEOUT"`
+		MaxAttempts     int           `yaml:"maxAttempts" env:"WEBHOOKS_MAX_ATTEMPTS"`
+		RetryBackoff    time.Duration `yaml:"retryBackoff" env:"WEBHOOKS_RETRY_BACKOFF"`
+	}
+
+	// Recordings -.
+	Recordings struct {
+		RecordSessions bool `yaml:"enabled" env:"RECORDINGS_ENABLED"`
+		// RecordingsPath defaults to a recordings directory next to the embedded database
+		RecordingsPath string   `yaml:"path" env:"RECORDINGS_PATH"`
+		RecordModes    []string `yaml:"modes" env:"RECORDINGS_MODES"`
+		// MaxRecordingSize is in bytes, 0 for no limit
+		MaxRecordingSize int64 `yaml:"maxSize" env:"RECORDINGS_MAX_SIZE"`
+	}
+
+	// Images -.
+	Images struct {
+		// ImagesPath defaults to an images directory next to the embedded database
+		ImagesPath string `yaml:"path" env:"IMAGES_PATH"`
+		// MaxImageSize is in bytes, 0 for no limit
+		MaxImageSize int64 `yaml:"maxSize" env:"IMAGES_MAX_SIZE"`
+	}
+
+	// Redirection holds the session policy used for tenants without their own, 0 for no limit.
+	Redirection struct {
+		IdleTimeout          time.Duration `yaml:"idleTimeout" env:"REDIRECTION_IDLE_TIMEOUT"`
+		MaxDuration          time.Duration `yaml:"maxDuration" env:"REDIRECTION_MAX_DURATION"`
+		MaxSessionsPerUser   int           `yaml:"maxSessionsPerUser" env:"REDIRECTION_MAX_SESSIONS_PER_USER"`
+		MaxSessionsPerDevice int           `yaml:"maxSessionsPerDevice" env:"REDIRECTION_MAX_SESSIONS_PER_DEVICE"`
+	}
+
+	// VNC -.
+	VNC struct {
+		// Address the VNC gateway listens on such as ":5900", empty disables it
+		Address     string        `yaml:"address" env:"VNC_ADDRESS"`
+		PasswordTTL time.Duration `yaml:"passwordTTL" env:"VNC_PASSWORD_TTL"`
+	}
+
+	// KVM -.
+	KVM struct {
+		// ThumbnailInterval between two captures of the fleet's screens, 0 disables them
+		ThumbnailInterval time.Duration `yaml:"thumbnailInterval" env:"KVM_THUMBNAIL_INTERVAL"`
+		ThumbnailWidth    int           `yaml:"thumbnailWidth" env:"KVM_THUMBNAIL_WIDTH"`
+		ThumbnailWorkers  int           `yaml:"thumbnailWorkers" env:"KVM_THUMBNAIL_WORKERS"`
+	}
+
+	// FeaturePolicies -.
+	FeaturePolicies struct {
+		// CheckInterval between two checks of the fleet against the feature policies, 0 disables them
+		CheckInterval time.Duration `yaml:"checkInterval" env:"FEATURE_POLICIES_CHECK_INTERVAL"`
+		CheckWorkers  int           `yaml:"checkWorkers" env:"FEATURE_POLICIES_CHECK_WORKERS"`
+	}
+
+	// Inventory -.
+	Inventory struct {
+		// RefreshInterval between two refreshes of the fleet's hardware inventory, 0 disables them
+		RefreshInterval time.Duration `yaml:"refreshInterval" env:"INVENTORY_REFRESH_INTERVAL"`
+		RefreshWorkers  int           `yaml:"refreshWorkers" env:"INVENTORY_REFRESH_WORKERS"`
+	}
+
+	// AMTAudit -.
+	AMTAudit struct {
+		// CollectInterval between two collections of the fleet's AMT audit logs, 0 disables them
+		CollectInterval time.Duration `yaml:"collectInterval" env:"AMT_AUDIT_COLLECT_INTERVAL"`
+		CollectWorkers  int           `yaml:"collectWorkers" env:"AMT_AUDIT_COLLECT_WORKERS"`
+	}
+
+	// EventLog -.
+	EventLog struct {
+		// CollectInterval between two collections of the fleet's AMT event logs, 0 disables them
+		CollectInterval time.Duration `yaml:"collectInterval" env:"EVENT_LOG_COLLECT_INTERVAL"`
+		CollectWorkers  int           `yaml:"collectWorkers" env:"EVENT_LOG_COLLECT_WORKERS"`
+	}
+
+	// Alerts holds the mail relay the smtp notifier sends alerts through, an empty host disables the notifier.
+	Alerts struct {
+		SMTPHost     string   `yaml:"smtpHost" env:"ALERTS_SMTP_HOST"`
+		SMTPPort     int      `yaml:"smtpPort" env:"ALERTS_SMTP_PORT"`
+		SMTPUsername string   `yaml:"smtpUsername" env:"ALERTS_SMTP_USERNAME"`
+		SMTPPassword string   `yaml:"smtpPassword" env:"ALERTS_SMTP_PASSWORD"`
+		SMTPFrom     string   `yaml:"smtpFrom" env:"ALERTS_SMTP_FROM"`
+		SMTPTo       []string `yaml:"smtpTo" env:"ALERTS_SMTP_TO"`
+	}
+
+	// SIEM holds the syslog collector the collected AMT logs are forwarded to, an empty address disables forwarding.
+	SIEM struct {
+		// Network is udp, tcp or tls
+		Network string `yaml:"network" env:"SIEM_NETWORK"`
+		Address string `yaml:"address" env:"SIEM_ADDRESS"`
+		// Format is rfc5424 or cef
+		Format             string `yaml:"format" env:"SIEM_FORMAT"`
+		Facility           int    `yaml:"facility" env:"SIEM_FACILITY"`
+		AppName            string `yaml:"appName" env:"SIEM_APP_NAME"`
+		CAFile             string `yaml:"caFile" env:"SIEM_CA_FILE"`
+		InsecureSkipVerify bool   `yaml:"insecureSkipVerify" env:"SIEM_INSECURE_SKIP_VERIFY"`
+		// Sources are the logs forwarded, audit and event
+		Sources []string `yaml:"sources" env:"SIEM_SOURCES"`
+		// ForwardInterval between two forwardings of the fleet's new records, 0 disables them
+		ForwardInterval time.Duration `yaml:"forwardInterval" env:"SIEM_FORWARD_INTERVAL"`
+		BatchSize       int           `yaml:"batchSize" env:"SIEM_BATCH_SIZE"`
+		Timeout         time.Duration `yaml:"timeout" env:"SIEM_TIMEOUT"`
+	}
+
+	// CertExpiry -.
+	CertExpiry struct {
+		// CheckInterval between two checks of the certificates of the domains, CIRA configs and devices, 0 disables them
+		CheckInterval time.Duration `yaml:"checkInterval" env:"CERT_EXPIRY_CHECK_INTERVAL"`
+		CheckWorkers  int           `yaml:"checkWorkers" env:"CERT_EXPIRY_CHECK_WORKERS"`
+		// WarningThreshold and CriticalThreshold are how long before it expires a certificate is reported
+		WarningThreshold  time.Duration `yaml:"warningThreshold" env:"CERT_EXPIRY_WARNING_THRESHOLD"`
+		CriticalThreshold time.Duration `yaml:"criticalThreshold" env:"CERT_EXPIRY_CRITICAL_THRESHOLD"`
 	}
 )
 
@@ -102,13 +266,101 @@ func NewConfig() (*Config, error) {
 		},
 		Auth: Auth{
 			AdminUsername:            "standalone",
-			AdminPassword:            "G@ppm0ym",
+			AdminPassword:            DefaultAdminPassword,
+			MaxLoginAttempts:         5,
+			LockoutDuration:          15 * time.Minute,
 			JWTKey:                   "your_secret_jwt_key",
 			JWTExpiration:            24 * time.Hour,
 			RedirectionJWTExpiration: 5 * time.Minute,
 			// OAUTH CONFIG, if provided will not use basic auth
-			ClientID: "",
-			Issuer:   "",
+			ClientID:   "",
+			Issuer:     "",
+			RolesClaim: "groups",
+			GroupRoles: map[string]string{},
+			// TENANCY
+			TenantClaim:    "tenant_id",
+			TrustedProxies: []string{},
+		},
+		Scheduler: Scheduler{
+			Interval: 30 * time.Second,
+		},
+		WSMAN: WSMAN{
+			Workers:        10,
+			IdleTimeout:    30 * time.Second,
+			MaxConnections: 500,
+			AuthWait:       3 * time.Second,
+		},
+		Health: Health{
+			PollInterval: 5 * time.Minute,
+			PollRate:     5,
+			PollWorkers:  10,
+			PollJitter:   30 * time.Second,
+		},
+		Webhooks: Webhooks{
+			DeliveryTimeout: 10 * time.Second,
+			MaxAttempts:     5,
+			RetryBackoff:    30 * time.Second,
+		},
+		Recordings: Recordings{
+			RecordSessions:   false,
+			RecordingsPath:   "",
+			RecordModes:      []string{"kvm", "sol"},
+			MaxRecordingSize: 1 << 30,
+		},
+		Images: Images{
+			ImagesPath:   "",
+			MaxImageSize: 16 << 30,
+		},
+		Redirection: Redirection{
+			IdleTimeout:          0,
+			MaxDuration:          0,
+			MaxSessionsPerUser:   0,
+			MaxSessionsPerDevice: 0,
+		},
+		VNC: VNC{
+			Address:     "",
+			PasswordTTL: 5 * time.Minute,
+		},
+		KVM: KVM{
+			ThumbnailInterval: 0,
+			ThumbnailWidth:    320,
+			ThumbnailWorkers:  2,
+		},
+		FeaturePolicies: FeaturePolicies{
+			CheckInterval: 15 * time.Minute,
+			CheckWorkers:  5,
+		},
+		Inventory: Inventory{
+			RefreshInterval: 24 * time.Hour,
+			RefreshWorkers:  5,
+		},
+		AMTAudit: AMTAudit{
+			CollectInterval: time.Hour,
+			CollectWorkers:  5,
+		},
+		EventLog: EventLog{
+			CollectInterval: 15 * time.Minute,
+			CollectWorkers:  5,
+		},
+		Alerts: Alerts{
+			SMTPPort: 25,
+			SMTPFrom: "console@localhost",
+		},
+		SIEM: SIEM{
+			Network:         "udp",
+			Format:          "rfc5424",
+			Facility:        13,
+			AppName:         "console",
+			Sources:         []string{"audit", "event"},
+			ForwardInterval: 5 * time.Minute,
+			BatchSize:       100,
+			Timeout:         10 * time.Second,
+		},
+		CertExpiry: CertExpiry{
+			CheckInterval:     24 * time.Hour,
+			CheckWorkers:      5,
+			WarningThreshold:  30 * 24 * time.Hour,
+			CriticalThreshold: 7 * 24 * time.Hour,
 		},
 	}
 
@@ -2,16 +2,19 @@
 package app
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"net/http"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 	"github.com/gorilla/websocket"
+	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/open-amt-cloud-toolkit/console/config"
 	consolehttp "github.com/open-amt-cloud-toolkit/console/internal/controller/http"
@@ -39,6 +42,30 @@ func Run(cfg *config.Config) {
 	// Use case
 	usecases := usecase.NewUseCases(database, log)
 
+	// The configured admin account is only created on first run
+	if !cfg.Auth.Disabled && cfg.Auth.ClientID == "" {
+		if err := usecases.Users.Bootstrap(context.Background(), cfg.AdminUsername, cfg.AdminPassword); err != nil {
+			log.Error(fmt.Errorf("app - Run - usecases.Users.Bootstrap: %w", err))
+		}
+	}
+
+	prometheus.MustRegister(usecases.Collectors...)
+
+	// Background jobs
+	ctx, cancel := context.WithCancel(context.Background())
+
+	var background sync.WaitGroup
+
+	for _, job := range usecases.Background {
+		background.Add(1)
+
+		go func(job usecase.BackgroundJob) {
+			defer background.Done()
+
+			job.Run(ctx)
+		}(job)
+	}
+
 	if os.Getenv("GIN_MODE") != "debug" {
 		gin.SetMode(gin.ReleaseMode)
 	}
@@ -82,4 +109,7 @@ func Run(cfg *config.Config) {
 	if err != nil {
 		log.Error(fmt.Errorf("app - Run - httpServer.Shutdown: %w", err))
 	}
+
+	cancel()
+	background.Wait()
 }
@@ -0,0 +1,41 @@
+CREATE TABLE IF NOT EXISTS scheduled_jobs(
+  id TEXT NOT NULL,
+  name TEXT NOT NULL,
+  job_type TEXT NOT NULL,
+  schedule_type TEXT NOT NULL,
+  run_at TEXT,
+  cron_expression TEXT,
+  payload TEXT,
+  target_guids TEXT,
+  target_tags TEXT,
+  target_method TEXT,
+  max_retries INTEGER NOT NULL,
+  retry_interval INTEGER NOT NULL,
+  enabled BOOLEAN NOT NULL,
+  next_run TEXT,
+  last_run TEXT,
+  creation_date TEXT, -- TIMESTAMP as TEXT
+  tenant_id TEXT NOT NULL,
+  PRIMARY KEY (id, tenant_id)
+);
+
+CREATE INDEX IF NOT EXISTS scheduled_jobs_next_run_idx ON scheduled_jobs (next_run);
+
+CREATE TABLE IF NOT EXISTS job_runs(
+  id TEXT NOT NULL,
+  job_id TEXT NOT NULL,
+  guid TEXT NOT NULL,
+  attempt INTEGER NOT NULL,
+  status TEXT NOT NULL,
+  return_value INTEGER,
+  error TEXT,
+  retry_at TEXT,
+  started_at TEXT,
+  finished_at TEXT,
+  tenant_id TEXT NOT NULL,
+  FOREIGN KEY (job_id, tenant_id) REFERENCES scheduled_jobs(id, tenant_id) ON DELETE CASCADE,
+  PRIMARY KEY (id, tenant_id)
+);
+
+CREATE INDEX IF NOT EXISTS job_runs_job_id_idx ON job_runs (job_id, tenant_id);
+CREATE INDEX IF NOT EXISTS job_runs_retry_idx ON job_runs (status, retry_at);
@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS role_bindings(
+  subject TEXT NOT NULL,
+  role TEXT NOT NULL,
+  creation_date TEXT, -- TIMESTAMP as TEXT
+  tenant_id TEXT NOT NULL,
+  PRIMARY KEY (subject, role, tenant_id)
+);
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS users(
+  username TEXT NOT NULL,
+  password_hash TEXT NOT NULL,
+  failed_attempts INTEGER NOT NULL DEFAULT 0,
+  locked_until TEXT,
+  must_change_password BOOLEAN NOT NULL DEFAULT FALSE,
+  creation_date TEXT, -- TIMESTAMP as TEXT
+  tenant_id TEXT NOT NULL,
+  PRIMARY KEY (username, tenant_id)
+);
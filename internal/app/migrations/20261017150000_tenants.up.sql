@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS tenants(
+  id TEXT NOT NULL,
+  name TEXT NOT NULL,
+  creation_date TEXT, -- TIMESTAMP as TEXT
+  PRIMARY KEY (id)
+);
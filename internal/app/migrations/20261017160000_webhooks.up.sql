@@ -0,0 +1,31 @@
+CREATE TABLE IF NOT EXISTS webhooks(
+  id TEXT NOT NULL,
+  name TEXT NOT NULL,
+  url TEXT NOT NULL,
+  secret TEXT NOT NULL,
+  event_types TEXT NOT NULL,
+  enabled BOOLEAN NOT NULL,
+  creation_date TEXT, -- TIMESTAMP as TEXT
+  tenant_id TEXT NOT NULL,
+  PRIMARY KEY (id, tenant_id)
+);
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries(
+  id TEXT NOT NULL,
+  webhook_id TEXT NOT NULL,
+  event_id TEXT NOT NULL,
+  event_type TEXT NOT NULL,
+  payload TEXT NOT NULL,
+  attempt INTEGER NOT NULL,
+  status TEXT NOT NULL,
+  status_code INTEGER,
+  error TEXT,
+  retry_at TEXT,
+  created_at TEXT,
+  tenant_id TEXT NOT NULL,
+  FOREIGN KEY (webhook_id, tenant_id) REFERENCES webhooks(id, tenant_id) ON DELETE CASCADE,
+  PRIMARY KEY (id, tenant_id)
+);
+
+CREATE INDEX IF NOT EXISTS webhook_deliveries_webhook_id_idx ON webhook_deliveries (webhook_id, tenant_id);
+CREATE INDEX IF NOT EXISTS webhook_deliveries_retry_idx ON webhook_deliveries (status, retry_at);
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS console_audit;
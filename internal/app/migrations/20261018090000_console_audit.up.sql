@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS console_audit(
+  id TEXT NOT NULL,
+  time TEXT NOT NULL, -- TIMESTAMP as TEXT
+  subject TEXT NOT NULL,
+  method TEXT NOT NULL,
+  route TEXT NOT NULL,
+  path TEXT NOT NULL,
+  target TEXT,
+  summary TEXT,
+  status_code INTEGER NOT NULL,
+  result TEXT NOT NULL,
+  duration_ms INTEGER NOT NULL,
+  client_ip TEXT,
+  tenant_id TEXT NOT NULL,
+  PRIMARY KEY (id, tenant_id)
+);
+
+CREATE INDEX IF NOT EXISTS console_audit_time_idx ON console_audit (tenant_id, time);
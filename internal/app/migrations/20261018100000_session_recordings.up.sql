@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS session_recordings(
+  id TEXT NOT NULL,
+  guid TEXT NOT NULL,
+  hostname TEXT,
+  mode TEXT NOT NULL,
+  subject TEXT,
+  file_name TEXT NOT NULL,
+  size_bytes INTEGER NOT NULL DEFAULT 0,
+  start_time TEXT NOT NULL, -- TIMESTAMP as TEXT
+  end_time TEXT,
+  tenant_id TEXT NOT NULL,
+  PRIMARY KEY (id, tenant_id)
+);
+
+CREATE INDEX IF NOT EXISTS session_recordings_start_idx ON session_recordings (tenant_id, start_time);
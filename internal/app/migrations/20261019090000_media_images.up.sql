@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS media_images(
+  id TEXT NOT NULL,
+  name TEXT NOT NULL,
+  kind TEXT NOT NULL,
+  file_name TEXT NOT NULL,
+  size_bytes INTEGER NOT NULL DEFAULT 0,
+  sha256 TEXT NOT NULL,
+  uploaded_by TEXT,
+  created_at TEXT NOT NULL, -- TIMESTAMP as TEXT
+  tenant_id TEXT NOT NULL,
+  PRIMARY KEY (id, tenant_id),
+  UNIQUE (name, tenant_id)
+);
@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS redirection_policies(
+  tenant_id TEXT NOT NULL,
+  idle_timeout INTEGER NOT NULL DEFAULT 0,
+  max_duration INTEGER NOT NULL DEFAULT 0,
+  max_sessions_per_user INTEGER NOT NULL DEFAULT 0,
+  max_sessions_per_device INTEGER NOT NULL DEFAULT 0,
+  PRIMARY KEY (tenant_id)
+);
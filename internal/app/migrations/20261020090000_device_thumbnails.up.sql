@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS device_thumbnails(
+  guid TEXT NOT NULL,
+  captured_at TEXT NOT NULL, -- TIMESTAMP as TEXT
+  image BYTEA NOT NULL,
+  tenant_id TEXT NOT NULL,
+  PRIMARY KEY (guid, tenant_id)
+);
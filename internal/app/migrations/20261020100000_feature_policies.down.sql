@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS feature_remediations;
+DROP TABLE IF EXISTS feature_drift;
+DROP TABLE IF EXISTS feature_policies;
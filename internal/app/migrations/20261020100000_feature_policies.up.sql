@@ -0,0 +1,38 @@
+CREATE TABLE IF NOT EXISTS feature_policies(
+  name TEXT NOT NULL,
+  scope TEXT NOT NULL,
+  target TEXT NOT NULL,
+  desired TEXT NOT NULL,
+  remediate BOOLEAN NOT NULL,
+  creation_date TEXT, -- TIMESTAMP as TEXT
+  tenant_id TEXT NOT NULL,
+  PRIMARY KEY (name, tenant_id)
+);
+
+CREATE TABLE IF NOT EXISTS feature_drift(
+  guid TEXT NOT NULL,
+  policy_name TEXT NOT NULL,
+  checked_at TEXT NOT NULL,
+  drifted BOOLEAN NOT NULL,
+  fields TEXT,
+  actual TEXT,
+  error TEXT,
+  tenant_id TEXT NOT NULL,
+  PRIMARY KEY (guid, tenant_id)
+);
+
+CREATE INDEX IF NOT EXISTS feature_drift_policy_idx ON feature_drift (policy_name, tenant_id);
+
+CREATE TABLE IF NOT EXISTS feature_remediations(
+  id TEXT NOT NULL,
+  guid TEXT NOT NULL,
+  policy_name TEXT NOT NULL,
+  remediated_at TEXT NOT NULL,
+  before_features TEXT,
+  after_features TEXT,
+  error TEXT,
+  tenant_id TEXT NOT NULL,
+  PRIMARY KEY (id, tenant_id)
+);
+
+CREATE INDEX IF NOT EXISTS feature_remediations_policy_idx ON feature_remediations (policy_name, tenant_id);
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS device_snapshots(
+  id TEXT NOT NULL,
+  guid TEXT NOT NULL,
+  taken_at TEXT NOT NULL, -- TIMESTAMP as TEXT
+  sections TEXT NOT NULL,
+  errors TEXT NOT NULL,
+  tenant_id TEXT NOT NULL,
+  PRIMARY KEY (id, tenant_id)
+);
+
+CREATE INDEX IF NOT EXISTS device_snapshots_guid_idx ON device_snapshots (guid, tenant_id, taken_at);
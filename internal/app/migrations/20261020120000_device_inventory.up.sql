@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS device_inventory(
+  guid TEXT NOT NULL,
+  refreshed_at TEXT NOT NULL, -- TIMESTAMP as TEXT
+  amt_version TEXT NOT NULL,
+  amt_major INTEGER NOT NULL,
+  sku TEXT NOT NULL,
+  bios_manufacturer TEXT NOT NULL,
+  bios_version TEXT NOT NULL,
+  total_memory_bytes BIGINT NOT NULL,
+  inventory TEXT NOT NULL,
+  tenant_id TEXT NOT NULL,
+  PRIMARY KEY (guid, tenant_id)
+);
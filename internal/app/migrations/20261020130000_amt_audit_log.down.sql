@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS amt_audit_cursors;
+DROP TABLE IF EXISTS amt_audit_records;
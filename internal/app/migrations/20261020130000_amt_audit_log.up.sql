@@ -0,0 +1,32 @@
+CREATE TABLE IF NOT EXISTS amt_audit_records(
+  guid TEXT NOT NULL,
+  fingerprint TEXT NOT NULL,
+  audit_app_id INTEGER NOT NULL,
+  audit_app TEXT NOT NULL,
+  event_id INTEGER NOT NULL,
+  event TEXT NOT NULL,
+  initiator_type INTEGER NOT NULL,
+  initiator TEXT NOT NULL,
+  time TEXT NOT NULL, -- TIMESTAMP as TEXT
+  mc_location_type INTEGER NOT NULL,
+  net_address TEXT NOT NULL,
+  extended_data TEXT NOT NULL,
+  description TEXT NOT NULL,
+  collected_at TEXT NOT NULL, -- TIMESTAMP as TEXT
+  tenant_id TEXT NOT NULL,
+  PRIMARY KEY (guid, tenant_id, fingerprint)
+);
+
+CREATE INDEX IF NOT EXISTS amt_audit_records_time_idx ON amt_audit_records (tenant_id, time);
+
+CREATE TABLE IF NOT EXISTS amt_audit_cursors(
+  guid TEXT NOT NULL,
+  last_index INTEGER NOT NULL,
+  last_fingerprint TEXT NOT NULL,
+  last_time TEXT NOT NULL, -- TIMESTAMP as TEXT
+  collected_at TEXT NOT NULL, -- TIMESTAMP as TEXT
+  collected INTEGER NOT NULL,
+  error TEXT NOT NULL,
+  tenant_id TEXT NOT NULL,
+  PRIMARY KEY (guid, tenant_id)
+);
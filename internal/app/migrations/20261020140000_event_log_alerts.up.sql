@@ -0,0 +1,55 @@
+CREATE TABLE IF NOT EXISTS device_events(
+  guid TEXT NOT NULL,
+  fingerprint TEXT NOT NULL,
+  time TEXT NOT NULL, -- TIMESTAMP as TEXT
+  severity TEXT NOT NULL,
+  entity TEXT NOT NULL,
+  entity_instance INTEGER NOT NULL,
+  sensor_type INTEGER NOT NULL,
+  sensor_number INTEGER NOT NULL,
+  event_type INTEGER NOT NULL,
+  event_offset INTEGER NOT NULL,
+  event_source_type INTEGER NOT NULL,
+  device_address INTEGER NOT NULL,
+  event_data TEXT NOT NULL,
+  description TEXT NOT NULL,
+  collected_at TEXT NOT NULL, -- TIMESTAMP as TEXT
+  tenant_id TEXT NOT NULL,
+  PRIMARY KEY (guid, tenant_id, fingerprint)
+);
+
+CREATE INDEX IF NOT EXISTS device_events_time_idx ON device_events (tenant_id, time);
+
+CREATE TABLE IF NOT EXISTS alert_rules(
+  name TEXT NOT NULL,
+  severity TEXT NOT NULL,
+  entity TEXT NOT NULL,
+  guid TEXT NOT NULL,
+  notifiers TEXT NOT NULL,
+  enabled BOOLEAN NOT NULL,
+  creation_date TEXT, -- TIMESTAMP as TEXT
+  tenant_id TEXT NOT NULL,
+  PRIMARY KEY (name, tenant_id)
+);
+
+CREATE TABLE IF NOT EXISTS alerts(
+  id TEXT NOT NULL,
+  rule_name TEXT NOT NULL,
+  guid TEXT NOT NULL,
+  fingerprint TEXT NOT NULL,
+  severity TEXT NOT NULL,
+  entity TEXT NOT NULL,
+  description TEXT NOT NULL,
+  event_time TEXT NOT NULL, -- TIMESTAMP as TEXT
+  raised_at TEXT NOT NULL, -- TIMESTAMP as TEXT
+  status TEXT NOT NULL,
+  acknowledged_by TEXT NOT NULL,
+  acknowledged_at TEXT NOT NULL, -- TIMESTAMP as TEXT
+  closed_by TEXT NOT NULL,
+  closed_at TEXT NOT NULL, -- TIMESTAMP as TEXT
+  tenant_id TEXT NOT NULL,
+  PRIMARY KEY (id, tenant_id),
+  UNIQUE (rule_name, guid, fingerprint, tenant_id)
+);
+
+CREATE INDEX IF NOT EXISTS alerts_status_idx ON alerts (tenant_id, status, raised_at);
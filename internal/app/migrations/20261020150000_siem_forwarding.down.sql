@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS device_events_collected_idx;
+DROP INDEX IF EXISTS amt_audit_records_collected_idx;
+DROP TABLE IF EXISTS siem_cursors;
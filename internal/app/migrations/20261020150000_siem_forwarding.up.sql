@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS siem_cursors(
+  source TEXT NOT NULL,
+  guid TEXT NOT NULL,
+  last_collected_at TEXT NOT NULL, -- TIMESTAMP as TEXT
+  last_time TEXT NOT NULL, -- TIMESTAMP as TEXT
+  last_fingerprint TEXT NOT NULL,
+  forwarded_at TEXT NOT NULL, -- TIMESTAMP as TEXT
+  forwarded INTEGER NOT NULL,
+  error TEXT NOT NULL,
+  tenant_id TEXT NOT NULL,
+  PRIMARY KEY (source, guid, tenant_id)
+);
+
+CREATE INDEX IF NOT EXISTS amt_audit_records_collected_idx ON amt_audit_records (guid, tenant_id, collected_at);
+CREATE INDEX IF NOT EXISTS device_events_collected_idx ON device_events (guid, tenant_id, collected_at);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS tracked_certificates_not_after_idx;
+DROP TABLE IF EXISTS tracked_certificates;
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS tracked_certificates(
+  source TEXT NOT NULL,
+  owner TEXT NOT NULL,
+  fingerprint TEXT NOT NULL,
+  subject TEXT NOT NULL,
+  issuer TEXT NOT NULL,
+  serial_number TEXT NOT NULL,
+  not_before TEXT NOT NULL, -- TIMESTAMP as TEXT
+  not_after TEXT NOT NULL, -- TIMESTAMP as TEXT
+  checked_at TEXT NOT NULL, -- TIMESTAMP as TEXT
+  notified_status TEXT NOT NULL,
+  tenant_id TEXT NOT NULL,
+  PRIMARY KEY (source, owner, fingerprint, tenant_id)
+);
+
+CREATE INDEX IF NOT EXISTS tracked_certificates_not_after_idx ON tracked_certificates (tenant_id, not_after);
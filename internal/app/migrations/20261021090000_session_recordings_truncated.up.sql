@@ -0,0 +1 @@
+ALTER TABLE session_recordings ADD COLUMN truncated BOOLEAN NOT NULL DEFAULT FALSE;
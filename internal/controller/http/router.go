@@ -15,8 +15,10 @@ import (
 	"github.com/open-amt-cloud-toolkit/console/config"
 	v1 "github.com/open-amt-cloud-toolkit/console/internal/controller/http/v1"
 	v2 "github.com/open-amt-cloud-toolkit/console/internal/controller/http/v2"
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
 	"github.com/open-amt-cloud-toolkit/console/internal/usecase"
 	"github.com/open-amt-cloud-toolkit/console/pkg/logger"
+	"github.com/open-amt-cloud-toolkit/console/pkg/tenant"
 )
 
 //go:embed all:ui
@@ -35,8 +37,9 @@ func NewRouter(handler *gin.Engine, l logger.Interface, t usecase.Usecases, cfg
 	handler.Use(gin.Recovery())
 
 	// Public routes
-	login := v1.NewLoginRoute(cfg)
+	login := v1.NewLoginRoute(cfg, t.Roles, t.Users)
 	handler.POST("/api/v1/authorize", login.Login)
+	handler.POST("/api/v1/authorize/password", login.ChangePassword)
 	// Static files
 	// Serve static assets (js, css, images, etc.)
 	// Create subdirectory view of the embedded file system
@@ -78,31 +81,58 @@ func NewRouter(handler *gin.Engine, l logger.Interface, t usecase.Usecases, cfg
 	vr := v1.NewVersionRoute(cfg)
 	handler.GET("/version", vr.LatestReleaseHandler)
 
-	// Protected routes using JWT middleware
+	// Protected routes using JWT middleware, every caller is an admin when auth is disabled and only a
+	// trusted proxy can then scope it to a tenant
 	var protected *gin.RouterGroup
 	if cfg.Auth.Disabled {
-		protected = handler.Group("/api")
+		protected = handler.Group("/api", v1.GrantRoles(dto.RoleAdmin), v1.TrustedTenant(tenant.ParseProxies(cfg.TrustedProxies)))
 	} else {
 		protected = handler.Group("/api", login.JWTAuthMiddleware())
 	}
 
-	// Routers
-	h2 := protected.Group("/v1")
+	protected.Use(v1.Audit(t.ConsoleAudit, l))
+
+	// Routers, each group requires the permission named on it
+	h2 := protected.Group("/v1", v1.RequireMethodPermission(dto.PermissionRead, dto.PermissionOperate))
 	{
-		v1.NewDeviceRoutes(h2, t.Devices, l)
+		v1.NewDeviceRoutes(h2, t.Devices, t.Exporter, l)
+		v1.NewEventRoutes(h2, t.Events, l)
 		v1.NewAmtRoutes(h2, t.Devices, t.AMTExplorer, t.Exporter, l)
+		v1.NewSnapshotRoutes(h2, t.Snapshots, l)
+		v1.NewInventoryRoutes(h2, t.Inventory, t.Exporter, l)
+		v1.NewAMTAuditRoutes(h2, t.AMTAudit, l)
+		v1.NewEventLogRoutes(h2, t.EventLogs, l)
+	}
+
+	hr := protected.Group("/v1", v1.RequirePermission(dto.PermissionRedirect))
+	{
+		v1.NewRedirectionRoutes(hr, t.Devices, t.Images, t.VNC, t.Thumbnails, l)
 	}
 
-	h := protected.Group("/v1/admin")
+	h := protected.Group("/v1/admin", v1.RequirePermission(dto.PermissionAdmin))
 	{
 		v1.NewDomainRoutes(h, t.Domains, l)
 		v1.NewCIRAConfigRoutes(h, t.CIRAConfigs, l)
 		v1.NewProfileRoutes(h, t.Profiles, l)
 		v1.NewWirelessConfigRoutes(h, t.WirelessProfiles, l)
 		v1.NewIEEE8021xConfigRoutes(h, t.IEEE8021xProfiles, l)
+		v1.NewScheduleRoutes(h, t.Schedules, l)
+		v1.NewFeaturePolicyRoutes(h, t.FeaturePolicies, l)
+		v1.NewAlertRuleRoutes(h, t.EventLogs, l)
+		v1.NewWebhookRoutes(h, t.Webhooks, l)
+		v1.NewConsoleAuditRoutes(h, t.ConsoleAudit, t.Exporter, l)
+		v1.NewSIEMRoutes(h, t.SIEM, l)
+		v1.NewCertificateExpiryRoutes(h, t.CertificateExpiry, l)
+		v1.NewRecordingRoutes(h, t.Recordings, l)
+		v1.NewImageRoutes(h, t.Images, l)
+		v1.NewRedirectionSessionRoutes(h, t.Devices, l)
+		v1.NewRedirectionPolicyRoutes(h, t.RedirectionPolicies, l)
+		v1.NewRoleRoutes(h, t.Roles, l)
+		v1.NewUserRoutes(h, t.Users, l)
+		v1.NewTenantRoutes(h, t.Tenants, l)
 	}
 
-	h3 := protected.Group("/v2")
+	h3 := protected.Group("/v2", v1.RequireMethodPermission(dto.PermissionRead, dto.PermissionOperate))
 	{
 		v2.NewAmtRoutes(h3, t.Devices, l)
 	}
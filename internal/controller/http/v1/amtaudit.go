@@ -0,0 +1,147 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/amtaudit"
+	"github.com/open-amt-cloud-toolkit/console/pkg/consoleerrors"
+	"github.com/open-amt-cloud-toolkit/console/pkg/logger"
+)
+
+var ErrValidationAMTAudit = dto.NotValidError{Console: consoleerrors.CreateConsoleError("AMTAuditAPI")}
+
+type amtAuditRoutes struct {
+	t amtaudit.Feature
+	l logger.Interface
+}
+
+func NewAMTAuditRoutes(handler *gin.RouterGroup, t amtaudit.Feature, l logger.Interface) {
+	r := &amtAuditRoutes{t, l}
+
+	h := handler.Group("/amt/audit")
+	{
+		h.GET("", r.get)
+		h.GET("cursors", r.getCursors)
+		h.POST(":guid", r.collect)
+	}
+}
+
+type AMTAuditCountResponse struct {
+	Count int                  `json:"totalCount"`
+	Data  []dto.AMTAuditRecord `json:"data"`
+}
+
+// @Summary     Search AMT Audit Records
+// @Description Search the AMT audit log records collected from the fleet, newest first
+// @ID          amtAuditRecords
+// @Tags  	    amt
+// @Accept      json
+// @Produce     json
+// @Param       guid      query string false "device guid"
+// @Param       auditApp  query string false "audit application, such as Security Admin"
+// @Param       event     query string false "event, such as Provisioning Started"
+// @Param       initiator query string false "user or application that caused the event"
+// @Param       from      query string false "RFC3339 time, inclusive"
+// @Param       to        query string false "RFC3339 time, exclusive"
+// @Success     200 {object} AMTAuditCountResponse
+// @Failure     500 {object} response
+// @Router      /api/v1/amt/audit [get]
+func (r *amtAuditRoutes) get(c *gin.Context) {
+	var odata OData
+	if err := c.ShouldBindQuery(&odata); err != nil {
+		validationErr := ErrValidationAMTAudit.Wrap("get", "ShouldBindQuery", err)
+		ErrorResponse(c, validationErr)
+
+		return
+	}
+
+	var filter dto.AMTAuditFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		validationErr := ErrValidationAMTAudit.Wrap("get", "ShouldBindQuery", err)
+		ErrorResponse(c, validationErr)
+
+		return
+	}
+
+	filter.TenantID = tenantID(c)
+
+	items, err := r.t.Get(c.Request.Context(), filter, odata.Top, odata.Skip)
+	if err != nil {
+		r.l.Error(err, "http - v1 - getAMTAudit")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	if odata.Count {
+		count, err := r.t.GetCount(c.Request.Context(), filter)
+		if err != nil {
+			r.l.Error(err, "http - v1 - getCount")
+			ErrorResponse(c, err)
+
+			return
+		}
+
+		countResponse := AMTAuditCountResponse{
+			Count: count,
+			Data:  items,
+		}
+
+		c.JSON(http.StatusOK, countResponse)
+	} else {
+		c.JSON(http.StatusOK, items)
+	}
+}
+
+// @Summary     Show AMT Audit Collection Cursors
+// @Description Show how far the AMT audit log of each device has been collected and how the last collection ended
+// @ID          amtAuditCursors
+// @Tags  	    amt
+// @Accept      json
+// @Produce     json
+// @Success     200 {object} []dto.AMTAuditCursor
+// @Failure     500 {object} response
+// @Router      /api/v1/amt/audit/cursors [get]
+func (r *amtAuditRoutes) getCursors(c *gin.Context) {
+	var odata OData
+	if err := c.ShouldBindQuery(&odata); err != nil {
+		validationErr := ErrValidationAMTAudit.Wrap("getCursors", "ShouldBindQuery", err)
+		ErrorResponse(c, validationErr)
+
+		return
+	}
+
+	cursors, err := r.t.GetCursors(c.Request.Context(), odata.Top, odata.Skip, tenantID(c))
+	if err != nil {
+		r.l.Error(err, "http - v1 - getAMTAuditCursors")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, cursors)
+}
+
+// @Summary     Collect AMT Audit Log
+// @Description Collect the records a device logged since its last collection without waiting for the schedule
+// @ID          collectAMTAudit
+// @Tags  	    amt
+// @Accept      json
+// @Produce     json
+// @Success     200 {object} dto.AMTAuditCursor
+// @Failure     404 {object} response
+// @Router      /api/v1/amt/audit/{guid} [post]
+func (r *amtAuditRoutes) collect(c *gin.Context) {
+	cursor, err := r.t.Collect(c.Request.Context(), c.Param("guid"), tenantID(c))
+	if err != nil {
+		r.l.Error(err, "http - v1 - collectAMTAudit")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, cursor)
+}
@@ -0,0 +1,156 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	"github.com/open-amt-cloud-toolkit/console/internal/mocks"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/devices"
+	"github.com/open-amt-cloud-toolkit/console/pkg/logger"
+)
+
+func amtAuditTest(t *testing.T) (*mocks.MockAMTAuditFeature, *gin.Engine) {
+	t.Helper()
+
+	mockCtl := gomock.NewController(t)
+
+	log := logger.New("error")
+	feature := mocks.NewMockAMTAuditFeature(mockCtl)
+
+	engine := gin.New()
+	handler := engine.Group("/api/v1")
+
+	NewAMTAuditRoutes(handler, feature, log)
+
+	return feature, engine
+}
+
+var (
+	amtAuditRecord = dto.AMTAuditRecord{
+		GUID:        "guid1",
+		AuditApp:    "Remote Control",
+		Event:       "Performed Power Up",
+		Initiator:   "admin",
+		Time:        time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC),
+		CollectedAt: time.Date(2024, 12, 1, 1, 0, 0, 0, time.UTC),
+	}
+
+	amtAuditCursor = dto.AMTAuditCursor{
+		GUID:        "guid1",
+		LastIndex:   42,
+		LastTime:    time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC),
+		CollectedAt: time.Date(2024, 12, 1, 1, 0, 0, 0, time.UTC),
+		Collected:   3,
+	}
+)
+
+func TestAMTAuditRoutes(t *testing.T) {
+	t.Parallel()
+
+	from := time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		method       string
+		url          string
+		mock         func(feature *mocks.MockAMTAuditFeature)
+		response     interface{}
+		expectedCode int
+	}{
+		{
+			name:   "search records - with count",
+			method: http.MethodGet,
+			url:    "/api/v1/amt/audit?initiator=admin&event=Performed+Power+Up&from=2024-12-01T00:00:00Z&$count=true",
+			mock: func(feature *mocks.MockAMTAuditFeature) {
+				filter := dto.AMTAuditFilter{Initiator: "admin", Event: "Performed Power Up", From: &from}
+				feature.EXPECT().Get(context.Background(), filter, 25, 0).Return([]dto.AMTAuditRecord{amtAuditRecord}, nil)
+				feature.EXPECT().GetCount(context.Background(), filter).Return(1, nil)
+			},
+			response:     AMTAuditCountResponse{Count: 1, Data: []dto.AMTAuditRecord{amtAuditRecord}},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:   "search records of a device",
+			method: http.MethodGet,
+			url:    "/api/v1/amt/audit?guid=guid1&auditApp=Remote+Control&$top=10",
+			mock: func(feature *mocks.MockAMTAuditFeature) {
+				feature.EXPECT().Get(context.Background(), dto.AMTAuditFilter{GUID: "guid1", AuditApp: "Remote Control"}, 10, 0).Return([]dto.AMTAuditRecord{amtAuditRecord}, nil)
+			},
+			response:     []dto.AMTAuditRecord{amtAuditRecord},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "search records - bad time",
+			method:       http.MethodGet,
+			url:          "/api/v1/amt/audit?from=yesterday",
+			mock:         func(_ *mocks.MockAMTAuditFeature) {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:   "get cursors",
+			method: http.MethodGet,
+			url:    "/api/v1/amt/audit/cursors",
+			mock: func(feature *mocks.MockAMTAuditFeature) {
+				feature.EXPECT().GetCursors(context.Background(), 25, 0, "").Return([]dto.AMTAuditCursor{amtAuditCursor}, nil)
+			},
+			response:     []dto.AMTAuditCursor{amtAuditCursor},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:   "collect",
+			method: http.MethodPost,
+			url:    "/api/v1/amt/audit/guid1",
+			mock: func(feature *mocks.MockAMTAuditFeature) {
+				feature.EXPECT().Collect(context.Background(), "guid1", "").Return(&amtAuditCursor, nil)
+			},
+			response:     amtAuditCursor,
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:   "collect - device not found",
+			method: http.MethodPost,
+			url:    "/api/v1/amt/audit/guid2",
+			mock: func(feature *mocks.MockAMTAuditFeature) {
+				feature.EXPECT().Collect(context.Background(), "guid2", "").Return(nil, devices.ErrNotFound)
+			},
+			expectedCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			feature, engine := amtAuditTest(t)
+
+			tc.mock(feature)
+
+			req, err := http.NewRequest(tc.method, tc.url, http.NoBody)
+			if err != nil {
+				t.Fatalf("Couldn't create request: %v\n", err)
+			}
+
+			w := httptest.NewRecorder()
+
+			engine.ServeHTTP(w, req)
+
+			require.Equal(t, tc.expectedCode, w.Code)
+
+			if tc.expectedCode == http.StatusOK {
+				jsonBytes, _ := json.Marshal(tc.response)
+				require.Equal(t, string(jsonBytes), w.Body.String())
+			}
+		})
+	}
+}
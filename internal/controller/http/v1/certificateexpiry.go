@@ -0,0 +1,117 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/certexpiry"
+	"github.com/open-amt-cloud-toolkit/console/pkg/consoleerrors"
+	"github.com/open-amt-cloud-toolkit/console/pkg/logger"
+)
+
+var ErrValidationCertificateExpiry = dto.NotValidError{Console: consoleerrors.CreateConsoleError("CertificateExpiryAPI")}
+
+type certificateExpiryRoutes struct {
+	t certexpiry.Feature
+	l logger.Interface
+}
+
+// NewCertificateExpiryRoutes serves the expirations of the domain, CIRA and device certificates.
+func NewCertificateExpiryRoutes(handler *gin.RouterGroup, t certexpiry.Feature, l logger.Interface) {
+	r := &certificateExpiryRoutes{t, l}
+
+	h := handler.Group("/certificates")
+	{
+		h.GET("expiring", r.getExpiring)
+		h.POST("check/:guid", r.check)
+	}
+}
+
+type TrackedCertificateCountResponse struct {
+	Count int                      `json:"totalCount"`
+	Data  []dto.TrackedCertificate `json:"data"`
+}
+
+// @Summary     Show Expiring Certificates
+// @Description Show the domain provisioning, CIRA MPS root and device certificates that expire within the given days, the first to expire first
+// @ID          expiringCertificates
+// @Tags  	    certificates
+// @Accept      json
+// @Produce     json
+// @Param       source query string false "domain, ciraConfig, deviceTLS or deviceRoot"
+// @Param       owner  query string false "domain profile, CIRA config name or device guid"
+// @Param       days   query int    false "days from now, the warning threshold by default"
+// @Success     200 {object} TrackedCertificateCountResponse
+// @Failure     400 {object} response
+// @Failure     500 {object} response
+// @Router      /api/v1/admin/certificates/expiring [get]
+func (r *certificateExpiryRoutes) getExpiring(c *gin.Context) {
+	var odata OData
+	if err := c.ShouldBindQuery(&odata); err != nil {
+		validationErr := ErrValidationCertificateExpiry.Wrap("getExpiring", "ShouldBindQuery", err)
+		ErrorResponse(c, validationErr)
+
+		return
+	}
+
+	var filter dto.TrackedCertificateFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		validationErr := ErrValidationCertificateExpiry.Wrap("getExpiring", "ShouldBindQuery", err)
+		ErrorResponse(c, validationErr)
+
+		return
+	}
+
+	filter.TenantID = tenantID(c)
+
+	certs, err := r.t.Get(c.Request.Context(), filter, odata.Top, odata.Skip)
+	if err != nil {
+		r.l.Error(err, "http - v1 - getExpiringCertificates")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	if odata.Count {
+		count, err := r.t.GetCount(c.Request.Context(), filter)
+		if err != nil {
+			r.l.Error(err, "http - v1 - getCount")
+			ErrorResponse(c, err)
+
+			return
+		}
+
+		countResponse := TrackedCertificateCountResponse{
+			Count: count,
+			Data:  certs,
+		}
+
+		c.JSON(http.StatusOK, countResponse)
+	} else {
+		c.JSON(http.StatusOK, certs)
+	}
+}
+
+// @Summary     Check Device Certificates
+// @Description Read the TLS and trusted root certificates of a device now instead of waiting for the schedule
+// @ID          checkDeviceCertificates
+// @Tags  	    certificates
+// @Accept      json
+// @Produce     json
+// @Success     200 {object} []dto.TrackedCertificate
+// @Failure     404 {object} response
+// @Failure     500 {object} response
+// @Router      /api/v1/admin/certificates/check/{guid} [post]
+func (r *certificateExpiryRoutes) check(c *gin.Context) {
+	certs, err := r.t.CheckDevice(c.Request.Context(), c.Param("guid"), tenantID(c))
+	if err != nil {
+		r.l.Error(err, "http - v1 - checkDeviceCertificates")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, certs)
+}
@@ -0,0 +1,135 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	"github.com/open-amt-cloud-toolkit/console/internal/mocks"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/certexpiry"
+	"github.com/open-amt-cloud-toolkit/console/pkg/logger"
+)
+
+func certificateExpiryTest(t *testing.T) (*mocks.MockCertExpiryFeature, *gin.Engine) {
+	t.Helper()
+
+	mockCtl := gomock.NewController(t)
+
+	log := logger.New("error")
+	feature := mocks.NewMockCertExpiryFeature(mockCtl)
+
+	engine := gin.New()
+	handler := engine.Group("/api/v1/admin")
+
+	NewCertificateExpiryRoutes(handler, feature, log)
+
+	return feature, engine
+}
+
+var trackedCertificate = dto.TrackedCertificate{
+	Source:            dto.CertificateSourceDeviceTLS,
+	Owner:             "guid1",
+	Subject:           "device",
+	Issuer:            "root",
+	SerialNumber:      "1",
+	SHA256Fingerprint: "ab12",
+	NotBefore:         time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	NotAfter:          time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+	DaysLeft:          5,
+	Status:            dto.CertificateStatusCritical,
+	CheckedAt:         time.Date(2024, 12, 27, 0, 0, 0, 0, time.UTC),
+}
+
+func TestCertificateExpiryRoutes(t *testing.T) {
+	t.Parallel()
+
+	days := 90
+
+	tests := []struct {
+		name         string
+		method       string
+		url          string
+		mock         func(feature *mocks.MockCertExpiryFeature)
+		response     interface{}
+		expectedCode int
+	}{
+		{
+			name:   "get expiring",
+			method: http.MethodGet,
+			url:    "/api/v1/admin/certificates/expiring",
+			mock: func(feature *mocks.MockCertExpiryFeature) {
+				feature.EXPECT().Get(context.Background(), dto.TrackedCertificateFilter{}, 25, 0).Return([]dto.TrackedCertificate{trackedCertificate}, nil)
+			},
+			response:     []dto.TrackedCertificate{trackedCertificate},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:   "get expiring - filtered with count",
+			method: http.MethodGet,
+			url:    "/api/v1/admin/certificates/expiring?source=deviceTLS&days=90&$count=true",
+			mock: func(feature *mocks.MockCertExpiryFeature) {
+				filter := dto.TrackedCertificateFilter{Source: dto.CertificateSourceDeviceTLS, Days: &days}
+
+				feature.EXPECT().Get(context.Background(), filter, 25, 0).Return([]dto.TrackedCertificate{trackedCertificate}, nil)
+				feature.EXPECT().GetCount(context.Background(), filter).Return(1, nil)
+			},
+			response:     TrackedCertificateCountResponse{Count: 1, Data: []dto.TrackedCertificate{trackedCertificate}},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:   "check device",
+			method: http.MethodPost,
+			url:    "/api/v1/admin/certificates/check/guid1",
+			mock: func(feature *mocks.MockCertExpiryFeature) {
+				feature.EXPECT().CheckDevice(context.Background(), "guid1", "").Return([]dto.TrackedCertificate{trackedCertificate}, nil)
+			},
+			response:     []dto.TrackedCertificate{trackedCertificate},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:   "check device - not found",
+			method: http.MethodPost,
+			url:    "/api/v1/admin/certificates/check/guid2",
+			mock: func(feature *mocks.MockCertExpiryFeature) {
+				feature.EXPECT().CheckDevice(context.Background(), "guid2", "").Return(nil, certexpiry.ErrNotFound)
+			},
+			expectedCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			feature, engine := certificateExpiryTest(t)
+
+			tc.mock(feature)
+
+			req, err := http.NewRequest(tc.method, tc.url, http.NoBody)
+			if err != nil {
+				t.Fatalf("Couldn't create request: %v\n", err)
+			}
+
+			w := httptest.NewRecorder()
+
+			engine.ServeHTTP(w, req)
+
+			require.Equal(t, tc.expectedCode, w.Code)
+
+			if tc.expectedCode == http.StatusOK {
+				jsonBytes, _ := json.Marshal(tc.response)
+				require.Equal(t, string(jsonBytes), w.Body.String())
+			}
+		})
+	}
+}
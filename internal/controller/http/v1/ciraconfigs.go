@@ -42,7 +42,7 @@ func (r *ciraConfigRoutes) get(c *gin.Context) {
 		return
 	}
 
-	configs, err := r.cira.Get(c.Request.Context(), odata.Top, odata.Skip, "")
+	configs, err := r.cira.Get(c.Request.Context(), odata.Top, odata.Skip, tenantID(c))
 	if err != nil {
 		r.l.Error(err, "http - CIRA configs - v1 - getCount")
 		ErrorResponse(c, err)
@@ -51,7 +51,7 @@ func (r *ciraConfigRoutes) get(c *gin.Context) {
 	}
 
 	if odata.Count {
-		count, err := r.cira.GetCount(c.Request.Context(), "")
+		count, err := r.cira.GetCount(c.Request.Context(), tenantID(c))
 		if err != nil {
 			r.l.Error(err, "http - CIRA configs - v1 - getCount")
 			ErrorResponse(c, err)
@@ -73,7 +73,7 @@ func (r *ciraConfigRoutes) get(c *gin.Context) {
 func (r *ciraConfigRoutes) getByName(c *gin.Context) {
 	configName := c.Param("ciraConfigName")
 
-	foundConfig, err := r.cira.GetByName(c.Request.Context(), configName, "")
+	foundConfig, err := r.cira.GetByName(c.Request.Context(), configName, tenantID(c))
 	if err != nil {
 		r.l.Error(err, "http - CIRA configs - v1 - getByName")
 		ErrorResponse(c, err)
@@ -93,6 +93,8 @@ func (r *ciraConfigRoutes) insert(c *gin.Context) {
 		return
 	}
 
+	config.TenantID = tenantID(c)
+
 	newCiraConfig, err := r.cira.Insert(c.Request.Context(), &config)
 	if err != nil {
 		r.l.Error(err, "http - CIRA configs - v1 - insert")
@@ -113,6 +115,8 @@ func (r *ciraConfigRoutes) update(c *gin.Context) {
 		return
 	}
 
+	config.TenantID = tenantID(c)
+
 	updatedConfig, err := r.cira.Update(c.Request.Context(), &config)
 	if err != nil {
 		r.l.Error(err, "http - CIRA configs - v1 - update")
@@ -127,7 +131,7 @@ func (r *ciraConfigRoutes) update(c *gin.Context) {
 func (r *ciraConfigRoutes) delete(c *gin.Context) {
 	configName := c.Param("ciraConfigName")
 
-	err := r.cira.Delete(c.Request.Context(), configName, "")
+	err := r.cira.Delete(c.Request.Context(), configName, tenantID(c))
 	if err != nil {
 		r.l.Error(err, "http - CIRA configs - v1 - delete")
 		ErrorResponse(c, err)
@@ -127,11 +127,10 @@ func TestCIRAConfigRoutes(t *testing.T) {
 					AuthMethod:          2,
 					MPSRootCertificate:  "-----BEGIN CERTIFICATE-----\n...",
 					ProxyDetails:        "http://example.com",
-					TenantID:            "abc123",
 					RegeneratePassword:  true,
 					Version:             "1.0.0",
 				}
-				ciraconfig.EXPECT().Insert(context.Background(), ciraconfigTest).Return(ciraconfigTest, nil)
+				ciraconfig.EXPECT().Insert(context.Background(), ciraconfigTest).Return(&responseCIRAConfig, nil)
 			},
 			response:     responseCIRAConfig,
 			requestBody:  requestCIRAConfig,
@@ -153,7 +152,6 @@ func TestCIRAConfigRoutes(t *testing.T) {
 					AuthMethod:          2,
 					MPSRootCertificate:  "-----BEGIN CERTIFICATE-----\n...",
 					ProxyDetails:        "http://example.com",
-					TenantID:            "abc123",
 					RegeneratePassword:  true,
 					Version:             "1.0.0",
 				}
@@ -174,7 +172,6 @@ func TestCIRAConfigRoutes(t *testing.T) {
 					AuthMethod:          2,
 					MPSRootCertificate:  "-----BEGIN CERTIFICATE-----\n...",
 					ProxyDetails:        "http://example.com",
-					TenantID:            "abc123",
 					RegeneratePassword:  true,
 					Version:             "1.0.0",
 				}
@@ -220,11 +217,10 @@ func TestCIRAConfigRoutes(t *testing.T) {
 					AuthMethod:          2,
 					MPSRootCertificate:  "-----BEGIN CERTIFICATE-----\n...",
 					ProxyDetails:        "http://example.com",
-					TenantID:            "abc123",
 					RegeneratePassword:  true,
 					Version:             "1.0.0",
 				}
-				ciraconfig.EXPECT().Update(context.Background(), ciraconfigTest).Return(ciraconfigTest, nil)
+				ciraconfig.EXPECT().Update(context.Background(), ciraconfigTest).Return(&responseCIRAConfig, nil)
 			},
 			response:     responseCIRAConfig,
 			requestBody:  requestCIRAConfig,
@@ -246,7 +242,6 @@ func TestCIRAConfigRoutes(t *testing.T) {
 					AuthMethod:          2,
 					MPSRootCertificate:  "-----BEGIN CERTIFICATE-----\n...",
 					ProxyDetails:        "http://example.com",
-					TenantID:            "abc123",
 					RegeneratePassword:  true,
 					Version:             "1.0.0",
 				}
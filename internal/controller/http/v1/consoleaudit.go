@@ -0,0 +1,264 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/consoleaudit"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/export"
+	"github.com/open-amt-cloud-toolkit/console/pkg/consoleerrors"
+	"github.com/open-amt-cloud-toolkit/console/pkg/logger"
+)
+
+const auditDownloadPageSize = 100
+
+var ErrValidationConsoleAudit = dto.NotValidError{Console: consoleerrors.CreateConsoleError("ConsoleAuditAPI")}
+
+// auditedReads are the GET routes that change something or hand data out, so they are audited like writes.
+var auditedReads = []string{"/export/", "/download", "/transcript", "/frames", "userConsentCode", "/authorize/redirection/"}
+
+// auditTargetParams are the route parameters naming what a request acts on, in order of preference.
+var auditTargetParams = []string{"guid", "profileName", "ciraConfigName", "name", "username", "subject", "id"}
+
+// Audit records every request that changes something, who made it and how it ended in the console audit trail.
+// It has to run after authentication so the subject and tenant are known.
+func Audit(t consoleaudit.Feature, l logger.Interface) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !audited(c) {
+			c.Next()
+
+			return
+		}
+
+		var body []byte
+
+		if c.Request.Body != nil && c.ContentType() == binding.MIMEJSON {
+			var err error
+
+			body, err = io.ReadAll(c.Request.Body)
+			if err != nil {
+				l.Warn("http - v1 - audit - could not read request body: %s", err.Error())
+			}
+
+			c.Request.Body = io.NopCloser(bytes.NewReader(body))
+		}
+
+		start := time.Now()
+
+		c.Next()
+
+		entry := &dto.ConsoleAuditEntry{
+			Time:       start.UTC(),
+			Subject:    callerSubject(c),
+			Method:     c.Request.Method,
+			Route:      c.FullPath(),
+			Path:       c.Request.URL.Path,
+			Target:     auditTarget(c, body),
+			Summary:    string(body),
+			StatusCode: c.Writer.Status(),
+			Result:     auditResult(c.Writer.Status()),
+			DurationMS: time.Since(start).Milliseconds(),
+			ClientIP:   c.ClientIP(),
+			TenantID:   tenantID(c),
+		}
+
+		// the caller may be gone by now, the entry is written regardless
+		if err := t.Record(context.WithoutCancel(c.Request.Context()), entry); err != nil {
+			l.Error(err, "http - v1 - audit")
+		}
+	}
+}
+
+func audited(c *gin.Context) bool {
+	switch c.Request.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+	default:
+		return true
+	}
+
+	route := c.FullPath()
+
+	for _, marker := range auditedReads {
+		if strings.Contains(route, marker) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func auditTarget(c *gin.Context, body []byte) string {
+	for _, param := range auditTargetParams {
+		if value := c.Param(param); value != "" {
+			return value
+		}
+	}
+
+	// inserts and updates carry what they act on in the body
+	fields := map[string]interface{}{}
+	if len(body) == 0 || json.Unmarshal(body, &fields) != nil {
+		return ""
+	}
+
+	for _, field := range auditTargetParams {
+		if value, ok := fields[field].(string); ok && value != "" {
+			return value
+		}
+	}
+
+	return ""
+}
+
+func auditResult(status int) string {
+	switch {
+	case status == http.StatusUnauthorized || status == http.StatusForbidden:
+		return dto.AuditResultDenied
+	case status >= http.StatusBadRequest:
+		return dto.AuditResultFailure
+	}
+
+	return dto.AuditResultSuccess
+}
+
+type consoleAuditRoutes struct {
+	t consoleaudit.Feature
+	e export.Exporter
+	l logger.Interface
+}
+
+func NewConsoleAuditRoutes(handler *gin.RouterGroup, t consoleaudit.Feature, e export.Exporter, l logger.Interface) {
+	r := &consoleAuditRoutes{t, e, l}
+
+	h := handler.Group("/audit")
+	{
+		h.GET("", r.get)
+		h.GET("download", r.download)
+	}
+}
+
+type ConsoleAuditCountResponse struct {
+	Count int                     `json:"totalCount"`
+	Data  []dto.ConsoleAuditEntry `json:"data"`
+}
+
+// @Summary     Show Console Audit Trail
+// @Description Show who did what through the console, newest first. Filter by subject, target and a from/to time range.
+// @ID          consoleAudit
+// @Tags  	    audit
+// @Accept      json
+// @Produce     json
+// @Param       subject query string false "console user"
+// @Param       target  query string false "device guid or configuration name"
+// @Param       from    query string false "RFC3339 time, inclusive"
+// @Param       to      query string false "RFC3339 time, exclusive"
+// @Success     200 {object} ConsoleAuditCountResponse
+// @Failure     500 {object} response
+// @Router      /api/v1/admin/audit [get]
+func (r *consoleAuditRoutes) get(c *gin.Context) {
+	var odata OData
+	if err := c.ShouldBindQuery(&odata); err != nil {
+		validationErr := ErrValidationConsoleAudit.Wrap("get", "ShouldBindQuery", err)
+		ErrorResponse(c, validationErr)
+
+		return
+	}
+
+	filter, ok := r.bindFilter(c, "get")
+	if !ok {
+		return
+	}
+
+	items, err := r.t.Get(c.Request.Context(), filter, odata.Top, odata.Skip)
+	if err != nil {
+		r.l.Error(err, "http - v1 - getConsoleAudit")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	if odata.Count {
+		count, err := r.t.GetCount(c.Request.Context(), filter)
+		if err != nil {
+			r.l.Error(err, "http - v1 - getCount")
+			ErrorResponse(c, err)
+
+			return
+		}
+
+		countResponse := ConsoleAuditCountResponse{
+			Count: count,
+			Data:  items,
+		}
+
+		c.JSON(http.StatusOK, countResponse)
+	} else {
+		c.JSON(http.StatusOK, items)
+	}
+}
+
+// @Summary     Download Console Audit Trail
+// @Description Download the console audit trail matching the filter as CSV, JSON, NDJSON or XLSX
+// @ID          downloadConsoleAudit
+// @Tags  	    audit
+// @Produce     text/csv
+// @Produce     json
+// @Param       subject query string false "console user"
+// @Param       target  query string false "device guid or configuration name"
+// @Param       from    query string false "RFC3339 time, inclusive"
+// @Param       to      query string false "RFC3339 time, exclusive"
+// @Param       format  query string false "csv (default), json, ndjson or xlsx"
+// @Param       columns query []string false "columns to export, all by default"
+// @Success     200 {string} string
+// @Failure     400 {object} response
+// @Failure     500 {object} response
+// @Router      /api/v1/admin/audit/download [get]
+func (r *consoleAuditRoutes) download(c *gin.Context) {
+	filter, ok := r.bindFilter(c, "download")
+	if !ok {
+		return
+	}
+
+	opts, ok := bindExport(c, "downloadConsoleAudit")
+	if !ok {
+		return
+	}
+
+	out, err := r.e.ConsoleAudit(c.Writer, opts)
+	if err != nil {
+		ErrorResponse(c, ErrValidationExport.Wrap("downloadConsoleAudit", "ConsoleAudit", err))
+
+		return
+	}
+
+	skip := 0
+
+	download(c, r.l, "downloadConsoleAudit", "console_audit", opts, out, func() ([]dto.ConsoleAuditEntry, bool, error) {
+		entries, err := r.t.Get(c.Request.Context(), filter, auditDownloadPageSize, skip)
+		skip += auditDownloadPageSize
+
+		return entries, len(entries) == auditDownloadPageSize, err
+	})
+}
+
+func (r *consoleAuditRoutes) bindFilter(c *gin.Context, call string) (dto.ConsoleAuditFilter, bool) {
+	var filter dto.ConsoleAuditFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		validationErr := ErrValidationConsoleAudit.Wrap(call, "ShouldBindQuery", err)
+		ErrorResponse(c, validationErr)
+
+		return filter, false
+	}
+
+	filter.TenantID = tenantID(c)
+
+	return filter, true
+}
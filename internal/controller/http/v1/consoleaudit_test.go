@@ -0,0 +1,278 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	"github.com/open-amt-cloud-toolkit/console/internal/mocks"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/export"
+	"github.com/open-amt-cloud-toolkit/console/pkg/logger"
+)
+
+func TestAuditMiddleware(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		method   string
+		url      string
+		body     string
+		roles    []string
+		expected *dto.ConsoleAuditEntry
+	}{
+		{
+			name:   "power action",
+			method: http.MethodPost,
+			url:    "/api/v1/amt/power/action/guid-1",
+			body:   `{"action":8}`,
+			roles:  []string{dto.RoleOperator},
+			expected: &dto.ConsoleAuditEntry{
+				Subject:    "alice",
+				Method:     http.MethodPost,
+				Route:      "/api/v1/amt/power/action/:guid",
+				Path:       "/api/v1/amt/power/action/guid-1",
+				Target:     "guid-1",
+				Summary:    `{"action":8}`,
+				StatusCode: http.StatusOK,
+				Result:     dto.AuditResultSuccess,
+				ClientIP:   "192.0.2.1",
+				TenantID:   "tenant1",
+			},
+		},
+		{
+			name:   "profile insert without permission",
+			method: http.MethodPost,
+			url:    "/api/v1/admin/profiles",
+			body:   `{"profileName":"profile1","amtPassword":"P@ssw0rd"}`,
+			roles:  []string{dto.RoleOperator},
+			expected: &dto.ConsoleAuditEntry{
+				Subject:    "alice",
+				Method:     http.MethodPost,
+				Route:      "/api/v1/admin/profiles",
+				Path:       "/api/v1/admin/profiles",
+				Target:     "profile1",
+				Summary:    `{"profileName":"profile1","amtPassword":"P@ssw0rd"}`,
+				StatusCode: http.StatusForbidden,
+				Result:     dto.AuditResultDenied,
+				ClientIP:   "192.0.2.1",
+				TenantID:   "tenant1",
+			},
+		},
+		{
+			name:   "export is audited",
+			method: http.MethodGet,
+			url:    "/api/v1/admin/profiles/export/profile1",
+			roles:  []string{dto.RoleAdmin},
+			expected: &dto.ConsoleAuditEntry{
+				Subject:    "alice",
+				Method:     http.MethodGet,
+				Route:      "/api/v1/admin/profiles/export/:name",
+				Path:       "/api/v1/admin/profiles/export/profile1",
+				Target:     "profile1",
+				StatusCode: http.StatusOK,
+				Result:     dto.AuditResultSuccess,
+				ClientIP:   "192.0.2.1",
+				TenantID:   "tenant1",
+			},
+		},
+		{
+			name:   "reads are not audited",
+			method: http.MethodGet,
+			url:    "/api/v1/amt/power/state/guid-1",
+			roles:  []string{dto.RoleOperator},
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockCtl := gomock.NewController(t)
+			audit := mocks.NewMockConsoleAuditFeature(mockCtl)
+
+			if tc.expected != nil {
+				audit.EXPECT().Record(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, e *dto.ConsoleAuditEntry) error {
+						require.False(t, e.Time.IsZero())
+						require.GreaterOrEqual(t, e.DurationMS, int64(0))
+
+						e.Time = time.Time{}
+						e.DurationMS = 0
+
+						require.Equal(t, tc.expected, e)
+
+						return nil
+					})
+			}
+
+			engine := gin.New()
+			protected := engine.Group("/api", func(c *gin.Context) {
+				c.Set(rolesContextKey, tc.roles)
+				setSubject(c, "alice")
+				setTenant(c, "tenant1")
+			}, Audit(audit, logger.New("error")))
+
+			ok := func(c *gin.Context) {
+				// the handler still sees the body the middleware read
+				body := map[string]interface{}{}
+				_ = c.ShouldBindJSON(&body)
+
+				if tc.body != "" {
+					require.NotEmpty(t, body)
+				}
+
+				c.Status(http.StatusOK)
+			}
+
+			operate := protected.Group("/v1", RequireMethodPermission(dto.PermissionRead, dto.PermissionOperate))
+			operate.POST("/amt/power/action/:guid", ok)
+			operate.GET("/amt/power/state/:guid", ok)
+
+			admin := protected.Group("/v1/admin", RequirePermission(dto.PermissionAdmin))
+			admin.POST("/profiles", ok)
+			admin.GET("/profiles/export/:name", ok)
+
+			req := httptest.NewRequest(tc.method, tc.url, strings.NewReader(tc.body))
+			if tc.body != "" {
+				req.Header.Set("Content-Type", "application/json")
+			}
+
+			w := httptest.NewRecorder()
+
+			engine.ServeHTTP(w, req)
+
+			if tc.expected != nil {
+				require.Equal(t, tc.expected.StatusCode, w.Code)
+			}
+		})
+	}
+}
+
+func TestConsoleAuditRoutes(t *testing.T) {
+	t.Parallel()
+
+	entry := dto.ConsoleAuditEntry{
+		ID:         "1",
+		Time:       time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC),
+		Subject:    "alice",
+		Method:     http.MethodDelete,
+		Route:      "/api/v1/devices/cert/:guid",
+		Path:       "/api/v1/devices/cert/guid-1",
+		Target:     "guid-1",
+		StatusCode: http.StatusNoContent,
+		Result:     dto.AuditResultSuccess,
+		TenantID:   "tenant1",
+	}
+
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	filter := dto.ConsoleAuditFilter{Subject: "alice", From: &from, TenantID: "tenant1"}
+
+	tests := []struct {
+		name         string
+		url          string
+		mock         func(audit *mocks.MockConsoleAuditFeature)
+		response     interface{}
+		expectedCode int
+	}{
+		{
+			name: "get entries",
+			url:  "/api/v1/admin/audit?subject=alice&from=2024-01-01T00:00:00Z",
+			mock: func(audit *mocks.MockConsoleAuditFeature) {
+				audit.EXPECT().Get(gomock.Any(), filter, 25, 0).Return([]dto.ConsoleAuditEntry{entry}, nil)
+			},
+			response:     []dto.ConsoleAuditEntry{entry},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "get entries - with count",
+			url:  "/api/v1/admin/audit?subject=alice&from=2024-01-01T00:00:00Z&$top=10&$count=true",
+			mock: func(audit *mocks.MockConsoleAuditFeature) {
+				audit.EXPECT().Get(gomock.Any(), filter, 10, 0).Return([]dto.ConsoleAuditEntry{entry}, nil)
+				audit.EXPECT().GetCount(gomock.Any(), filter).Return(1, nil)
+			},
+			response:     ConsoleAuditCountResponse{Count: 1, Data: []dto.ConsoleAuditEntry{entry}},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "get entries - invalid time",
+			url:          "/api/v1/admin/audit?from=yesterday",
+			mock:         func(_ *mocks.MockConsoleAuditFeature) {},
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockCtl := gomock.NewController(t)
+			audit := mocks.NewMockConsoleAuditFeature(mockCtl)
+
+			tc.mock(audit)
+
+			engine := gin.New()
+			handler := engine.Group("/api/v1/admin", func(c *gin.Context) { setTenant(c, "tenant1") })
+
+			NewConsoleAuditRoutes(handler, audit, export.NewFileExporter(), logger.New("error"))
+
+			req := httptest.NewRequest(http.MethodGet, tc.url, http.NoBody)
+			w := httptest.NewRecorder()
+
+			engine.ServeHTTP(w, req)
+
+			require.Equal(t, tc.expectedCode, w.Code)
+
+			if tc.response != nil {
+				jsonBytes, _ := json.Marshal(tc.response)
+				require.Equal(t, string(jsonBytes), w.Body.String())
+			}
+		})
+	}
+}
+
+func TestConsoleAuditDownload(t *testing.T) {
+	t.Parallel()
+
+	mockCtl := gomock.NewController(t)
+	audit := mocks.NewMockConsoleAuditFeature(mockCtl)
+
+	page := make([]dto.ConsoleAuditEntry, 100)
+	for i := range page {
+		page[i] = dto.ConsoleAuditEntry{Subject: "alice", Method: http.MethodPost}
+	}
+
+	filter := dto.ConsoleAuditFilter{Target: "guid-1", TenantID: "tenant1"}
+
+	audit.EXPECT().Get(gomock.Any(), filter, 100, 0).Return(page, nil)
+	audit.EXPECT().Get(gomock.Any(), filter, 100, 100).Return([]dto.ConsoleAuditEntry{{Subject: "bob", Method: http.MethodDelete}}, nil)
+
+	engine := gin.New()
+	handler := engine.Group("/api/v1/admin", func(c *gin.Context) { setTenant(c, "tenant1") })
+
+	NewConsoleAuditRoutes(handler, audit, export.NewFileExporter(), logger.New("error"))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/audit/download?target=guid-1", http.NoBody)
+	w := httptest.NewRecorder()
+
+	engine.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "text/csv", w.Header().Get("Content-Type"))
+	// a header row and one row per entry
+	require.Equal(t, 102, bytes.Count(w.Body.Bytes(), []byte("\n")))
+}
@@ -1,12 +1,12 @@
 package v1
 
 import (
-	"io"
 	"net/http"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/open-amt-cloud-toolkit/go-wsman-messages/v2/pkg/wsman/amt/auditlog"
+	"github.com/open-amt-cloud-toolkit/go-wsman-messages/v2/pkg/wsman/amt/messagelog"
 
 	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
 	"github.com/open-amt-cloud-toolkit/console/internal/usecase/amtexplorer"
@@ -40,6 +40,8 @@ func NewAmtRoutes(handler *gin.RouterGroup, d devices.Feature, amt amtexplorer.F
 		h.GET("diskInfo/:guid", r.getDiskInfo)
 		h.GET("power/state/:guid", r.getPowerState)
 		h.POST("power/action/:guid", r.powerAction)
+		h.POST("power/bulk", r.bulkPowerAction)
+		h.GET("power/bulk/:id", r.getBulkPowerAction)
 		h.POST("power/bootOptions/:guid", r.setBootOptions)
 		h.POST("power/bootoptions/:guid", r.setBootOptions)
 		h.GET("power/capabilities/:guid", r.getPowerCapabilities)
@@ -50,10 +52,6 @@ func NewAmtRoutes(handler *gin.RouterGroup, d devices.Feature, amt amtexplorer.F
 		h.GET("log/event/:guid/download", r.downloadEventLog)
 		h.GET("generalSettings/:guid", r.getGeneralSettings)
 
-		h.GET("userConsentCode/cancel/:guid", r.cancelUserConsentCode)
-		h.GET("userConsentCode/:guid", r.getUserConsentCode)
-		h.POST("userConsentCode/:guid", r.sendConsentCode)
-
 		h.GET("networkSettings/:guid", r.getNetworkSettings)
 
 		h.GET("explorer", r.getCallList)
@@ -318,6 +316,45 @@ func (r *deviceManagementRoutes) powerAction(c *gin.Context) {
 	c.JSON(http.StatusOK, response)
 }
 
+func (r *deviceManagementRoutes) bulkPowerAction(c *gin.Context) {
+	var req dto.BulkPowerActionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	job, err := r.d.SendBulkPowerAction(c.Request.Context(), req, tenantID(c))
+	if err != nil {
+		r.l.Error(err, "http - v1 - bulkPowerAction")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	if job.Status != dto.BulkJobStatusCompleted {
+		c.JSON(http.StatusAccepted, job)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, job)
+}
+
+func (r *deviceManagementRoutes) getBulkPowerAction(c *gin.Context) {
+	id := c.Param("id")
+
+	job, err := r.d.GetBulkPowerActionJob(c.Request.Context(), id)
+	if err != nil {
+		r.l.Error(err, "http - v1 - getBulkPowerAction")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, job)
+}
+
 func (r *deviceManagementRoutes) getAuditLog(c *gin.Context) {
 	guid := c.Param("guid")
 
@@ -342,49 +379,46 @@ func (r *deviceManagementRoutes) getAuditLog(c *gin.Context) {
 	c.JSON(http.StatusOK, auditLogs)
 }
 
+// @Summary     Download AMT Audit Log
+// @Description Download the AMT audit log of a device as CSV, JSON, NDJSON or XLSX
+// @ID          downloadAuditLog
+// @Tags  	    amt
+// @Produce     text/csv
+// @Produce     json
+// @Param       format  query string false "csv (default), json, ndjson or xlsx"
+// @Param       columns query []string false "columns to export, all by default"
+// @Success     200 {string} string
+// @Failure     400 {object} response
+// @Router      /api/v1/amt/log/audit/{guid}/download [get]
 func (r *deviceManagementRoutes) downloadAuditLog(c *gin.Context) {
 	guid := c.Param("guid")
 
-	var allRecords []auditlog.AuditLogRecord
-
-	startIndex := 1
-
-	for {
-		auditLogs, err := r.d.GetAuditLog(c.Request.Context(), startIndex, guid)
-		if err != nil {
-			r.l.Error(err, "http - v1 - getAuditLog")
-			ErrorResponse(c, err)
-
-			return
-		}
-
-		allRecords = append(allRecords, auditLogs.Records...)
-
-		if len(allRecords) >= auditLogs.TotalCount {
-			break
-		}
-
-		startIndex += len(auditLogs.Records)
+	opts, ok := bindExport(c, "downloadAuditLog")
+	if !ok {
+		return
 	}
 
-	// Convert logs to CSV
-	csvReader, err := r.e.ExportAuditLogsCSV(allRecords)
+	out, err := r.e.AuditLogs(c.Writer, opts)
 	if err != nil {
-		r.l.Error(err, "http - v1 - downloadAuditLog")
-		ErrorResponse(c, err)
+		ErrorResponse(c, ErrValidationExport.Wrap("downloadAuditLog", "AuditLogs", err))
 
 		return
 	}
 
-	// Serve the CSV file
-	c.Header("Content-Disposition", "attachment; filename=audit_logs.csv")
-	c.Header("Content-Type", "text/csv")
+	// AMT numbers the records of its log from 1
+	startIndex, read := 1, 0
 
-	_, err = io.Copy(c.Writer, csvReader)
-	if err != nil {
-		r.l.Error(err, "http - v1 - downloadAuditLog")
-		ErrorResponse(c, err)
-	}
+	download(c, r.l, "downloadAuditLog", "audit_logs", opts, out, func() ([]auditlog.AuditLogRecord, bool, error) {
+		auditLogs, err := r.d.GetAuditLog(c.Request.Context(), startIndex, guid)
+		if err != nil {
+			return nil, false, err
+		}
+
+		startIndex += len(auditLogs.Records)
+		read += len(auditLogs.Records)
+
+		return auditLogs.Records, len(auditLogs.Records) > 0 && read < auditLogs.TotalCount, nil
+	})
 }
 
 func (r *deviceManagementRoutes) getEventLog(c *gin.Context) {
@@ -409,53 +443,45 @@ func (r *deviceManagementRoutes) getEventLog(c *gin.Context) {
 	c.JSON(http.StatusOK, eventLogs)
 }
 
+// @Summary     Download AMT Event Log
+// @Description Download the AMT event log of a device as CSV, JSON, NDJSON or XLSX
+// @ID          downloadEventLog
+// @Tags  	    amt
+// @Produce     text/csv
+// @Produce     json
+// @Param       format  query string false "csv (default), json, ndjson or xlsx"
+// @Param       columns query []string false "columns to export, all by default"
+// @Success     200 {string} string
+// @Failure     400 {object} response
+// @Router      /api/v1/amt/log/event/{guid}/download [get]
 func (r *deviceManagementRoutes) downloadEventLog(c *gin.Context) {
 	guid := c.Param("guid")
 
-	var allEventLogs []dto.EventLog
-
-	startIndex := 0
-
-	// Keep fetching logs until NoMoreRecords is true
-	for {
-		eventLogs, err := r.d.GetEventLog(c.Request.Context(), 0, 0, guid)
-		if err != nil {
-			r.l.Error(err, "http - v1 - getEventLog")
-			ErrorResponse(c, err)
-
-			return
-		}
-
-		// Append the current batch of logs
-		allEventLogs = append(allEventLogs, eventLogs.Records...)
-
-		// Break if no more records
-		if eventLogs.HasMoreRecords {
-			break
-		}
-
-		// Update the startIndex for the next batch
-		startIndex += len(eventLogs.Records)
+	opts, ok := bindExport(c, "downloadEventLog")
+	if !ok {
+		return
 	}
 
-	// Convert logs to CSV
-	csvReader, err := r.e.ExportEventLogsCSV(allEventLogs)
+	out, err := r.e.EventLogs(c.Writer, opts)
 	if err != nil {
-		r.l.Error(err, "http - v1 - downloadEventLog")
-		ErrorResponse(c, err)
+		ErrorResponse(c, ErrValidationExport.Wrap("downloadEventLog", "EventLogs", err))
 
 		return
 	}
 
-	// Serve the CSV file
-	c.Header("Content-Disposition", "attachment; filename=event_logs.csv")
-	c.Header("Content-Type", "text/csv")
+	// AMT numbers the records of its log from 1
+	startIndex := 1
 
-	_, err = io.Copy(c.Writer, csvReader)
-	if err != nil {
-		r.l.Error(err, "http - v1 - downloadEventLog")
-		ErrorResponse(c, err)
-	}
+	download(c, r.l, "downloadEventLog", "event_logs", opts, out, func() ([]dto.EventLog, bool, error) {
+		eventLogs, err := r.d.GetEventLog(c.Request.Context(), startIndex, messagelog.MaxAMTRecords, guid)
+		if err != nil {
+			return nil, false, err
+		}
+
+		startIndex += len(eventLogs.Records)
+
+		return eventLogs.Records, len(eventLogs.Records) > 0 && eventLogs.HasMoreRecords, nil
+	})
 }
 
 func (r *deviceManagementRoutes) setBootOptions(c *gin.Context) {
@@ -521,7 +547,7 @@ func (r *deviceManagementRoutes) executeCall(c *gin.Context) {
 	guid := c.Param("guid")
 	call := c.Param("call")
 
-	result, err := r.a.ExecuteCall(c.Request.Context(), guid, call, "")
+	result, err := r.a.ExecuteCall(c.Request.Context(), guid, call, tenantID(c))
 	if err != nil {
 		r.l.Error(err, "http - explorer - v1 - executeCall")
 		ErrorResponse(c, err)
@@ -8,8 +8,11 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/open-amt-cloud-toolkit/go-wsman-messages/v2/pkg/wsman/amt/auditlog"
+	"github.com/open-amt-cloud-toolkit/go-wsman-messages/v2/pkg/wsman/amt/messagelog"
 	power "github.com/open-amt-cloud-toolkit/go-wsman-messages/v2/pkg/wsman/cim/power"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
@@ -17,6 +20,7 @@ import (
 	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
 	dtov2 "github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v2"
 	"github.com/open-amt-cloud-toolkit/console/internal/mocks"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/export"
 	"github.com/open-amt-cloud-toolkit/console/pkg/logger"
 )
 
@@ -156,6 +160,47 @@ func TestDeviceManagement(t *testing.T) {
 			expectedCode: http.StatusOK,
 			response:     power.PowerActionResponse{ReturnValue: 0},
 		},
+		{
+			name:   "bulkPowerAction - completed job",
+			url:    "/api/v1/amt/power/bulk",
+			method: http.MethodPost,
+			requestBody: dto.BulkPowerActionRequest{
+				Action: 8,
+				Tags:   []string{"lab"},
+			},
+			mock: func(m *mocks.MockDeviceManagementFeature) {
+				m.EXPECT().SendBulkPowerAction(context.Background(), dto.BulkPowerActionRequest{Action: 8, Tags: []string{"lab"}}, "").
+					Return(dto.BulkPowerActionJob{ID: "job-1", Status: dto.BulkJobStatusCompleted}, nil)
+			},
+			expectedCode: http.StatusOK,
+			response:     dto.BulkPowerActionJob{ID: "job-1", Status: dto.BulkJobStatusCompleted},
+		},
+		{
+			name:   "bulkPowerAction - async job accepted",
+			url:    "/api/v1/amt/power/bulk",
+			method: http.MethodPost,
+			requestBody: dto.BulkPowerActionRequest{
+				Action: 8,
+				GUIDs:  []string{"guid-1"},
+				Async:  true,
+			},
+			mock: func(m *mocks.MockDeviceManagementFeature) {
+				m.EXPECT().SendBulkPowerAction(context.Background(), dto.BulkPowerActionRequest{Action: 8, GUIDs: []string{"guid-1"}, Async: true}, "").
+					Return(dto.BulkPowerActionJob{ID: "job-1", Status: dto.BulkJobStatusRunning}, nil)
+			},
+			expectedCode: http.StatusAccepted,
+		},
+		{
+			name:   "getBulkPowerAction - successful retrieval",
+			url:    "/api/v1/amt/power/bulk/job-1",
+			method: http.MethodGet,
+			mock: func(m *mocks.MockDeviceManagementFeature) {
+				m.EXPECT().GetBulkPowerActionJob(context.Background(), "job-1").
+					Return(dto.BulkPowerActionJob{ID: "job-1", Status: dto.BulkJobStatusCompleted}, nil)
+			},
+			expectedCode: http.StatusOK,
+			response:     dto.BulkPowerActionJob{ID: "job-1", Status: dto.BulkJobStatusCompleted},
+		},
 		{
 			name:   "getAuditLog - successful retrieval",
 			url:    "/api/v1/amt/log/audit/valid-guid?startIndex=0",
@@ -288,3 +333,84 @@ func TestDeviceManagement(t *testing.T) {
 		})
 	}
 }
+
+func TestAMTLogDownloads(t *testing.T) {
+	t.Parallel()
+
+	eventTime := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		url          string
+		mock         func(m *mocks.MockDeviceManagementFeature)
+		contentType  string
+		body         string
+		expectedCode int
+	}{
+		{
+			name: "audit log - pages until the total count",
+			url:  "/api/v1/amt/log/audit/valid-guid/download?columns=id,time,event",
+			mock: func(m *mocks.MockDeviceManagementFeature) {
+				m.EXPECT().GetAuditLog(gomock.Any(), 1, "valid-guid").
+					Return(dto.AuditLog{TotalCount: 2, Records: []auditlog.AuditLogRecord{{EventID: 1, Time: eventTime, Event: "Provisioning Started"}}}, nil)
+				m.EXPECT().GetAuditLog(gomock.Any(), 2, "valid-guid").
+					Return(dto.AuditLog{TotalCount: 2, Records: []auditlog.AuditLogRecord{{EventID: 2, Time: eventTime, Event: "Provisioning Completed"}}}, nil)
+			},
+			contentType:  "text/csv",
+			body:         "ID,Time,Event\n1,2025-01-01T10:00:00Z,Provisioning Started\n2,2025-01-01T10:00:00Z,Provisioning Completed\n",
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "event log - pages while the device has more",
+			url:  "/api/v1/amt/log/event/valid-guid/download?format=ndjson&columns=time,source",
+			mock: func(m *mocks.MockDeviceManagementFeature) {
+				m.EXPECT().GetEventLog(gomock.Any(), 1, messagelog.MaxAMTRecords, "valid-guid").
+					Return(dto.EventLogs{Records: []dto.EventLog{{Time: eventTime.String(), Entity: "BIOS"}}, HasMoreRecords: true}, nil)
+				m.EXPECT().GetEventLog(gomock.Any(), 2, messagelog.MaxAMTRecords, "valid-guid").
+					Return(dto.EventLogs{Records: []dto.EventLog{{Time: eventTime.String(), Entity: "Processor"}}}, nil)
+			},
+			contentType:  "application/x-ndjson",
+			body:         `{"time":"2025-01-01T10:00:00Z","source":"BIOS"}` + "\n" + `{"time":"2025-01-01T10:00:00Z","source":"Processor"}` + "\n",
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "event log - device unreachable",
+			url:  "/api/v1/amt/log/event/valid-guid/download",
+			mock: func(m *mocks.MockDeviceManagementFeature) {
+				m.EXPECT().GetEventLog(gomock.Any(), 1, messagelog.MaxAMTRecords, "valid-guid").Return(dto.EventLogs{}, ErrGeneral)
+			},
+			expectedCode: http.StatusInternalServerError,
+		},
+		{
+			name:         "audit log - unknown format",
+			url:          "/api/v1/amt/log/audit/valid-guid/download?format=pdf",
+			mock:         func(_ *mocks.MockDeviceManagementFeature) {},
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			deviceManagement := mocks.NewMockDeviceManagementFeature(gomock.NewController(t))
+			tc.mock(deviceManagement)
+
+			engine := gin.New()
+			NewAmtRoutes(engine.Group("/api/v1"), deviceManagement, nil, export.NewFileExporter(), logger.New("error"))
+
+			w := httptest.NewRecorder()
+
+			engine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tc.url, http.NoBody))
+
+			require.Equal(t, tc.expectedCode, w.Code)
+
+			if tc.expectedCode == http.StatusOK {
+				require.Equal(t, tc.contentType, w.Header().Get("Content-Type"))
+				require.Equal(t, tc.body, w.Body.String())
+			}
+		})
+	}
+}
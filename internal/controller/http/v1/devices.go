@@ -10,27 +10,29 @@ import (
 	"github.com/open-amt-cloud-toolkit/console/config"
 	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
 	"github.com/open-amt-cloud-toolkit/console/internal/usecase/devices"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/export"
 	"github.com/open-amt-cloud-toolkit/console/pkg/consoleerrors"
 	"github.com/open-amt-cloud-toolkit/console/pkg/logger"
 )
 
+const devicesDownloadPageSize = 100
+
 type deviceRoutes struct {
 	t devices.Feature
+	e export.Exporter
 	l logger.Interface
 }
 
 var ErrValidationDevices = dto.NotValidError{Console: consoleerrors.CreateConsoleError("ProfileAPI")}
 
-func NewDeviceRoutes(handler *gin.RouterGroup, t devices.Feature, l logger.Interface) {
-	r := &deviceRoutes{t, l}
-
-	handler.GET("authorize/redirection/:id", r.LoginRedirection)
+func NewDeviceRoutes(handler *gin.RouterGroup, t devices.Feature, e export.Exporter, l logger.Interface) {
+	r := &deviceRoutes{t, e, l}
 
 	h := handler.Group("/devices")
 	{
 		h.GET("", r.get)
 		h.GET("stats", r.getStats)
-		h.GET("redirectstatus/:guid", r.redirectStatus)
+		h.GET("download", r.download)
 		h.GET("cert/:guid", r.getDeviceCertificate)
 		h.POST("cert/:guid", r.pinDeviceCertificate)
 		h.DELETE("cert/:guid", r.deleteDeviceCertificate)
@@ -62,7 +64,7 @@ type DeviceStatResponse struct {
 // @Failure     500 {object} response
 // @Router      /api/v1/devices [get]
 func (dr *deviceRoutes) getStats(c *gin.Context) {
-	count, err := dr.t.GetCount(c.Request.Context(), "")
+	count, err := dr.t.GetCount(c.Request.Context(), tenantID(c))
 	if err != nil {
 		dr.l.Error(err, "http - devices - v1 - getCount")
 		ErrorResponse(c, err)
@@ -70,8 +72,18 @@ func (dr *deviceRoutes) getStats(c *gin.Context) {
 		return
 	}
 
+	connected, err := dr.t.GetConnectedCount(c.Request.Context(), tenantID(c))
+	if err != nil {
+		dr.l.Error(err, "http - devices - v1 - getConnectedCount")
+		ErrorResponse(c, err)
+
+		return
+	}
+
 	countResponse := DeviceStatResponse{
-		TotalCount: count,
+		TotalCount:        count,
+		ConnectedCount:    connected,
+		DisconnectedCount: count - connected,
 	}
 
 	c.JSON(http.StatusOK, countResponse)
@@ -89,17 +101,22 @@ func (dr *deviceRoutes) getStats(c *gin.Context) {
 func (dr *deviceRoutes) LoginRedirection(c *gin.Context) {
 	deviceID := c.Param("id")
 
-	_, err := dr.t.GetByID(c.Request.Context(), deviceID, "")
+	_, err := dr.t.GetByID(c.Request.Context(), deviceID, tenantID(c))
 	if err != nil {
 		dr.l.Error(err, "http - devices - v1 - LoginRedirection")
 		ErrorResponse(c, err)
 
 		return
 	}
-	// Create JWT token
+	// Create JWT token, carrying the caller's roles, tenant and identity so the relay can check and record them
 	expirationTime := time.Now().Add(config.ConsoleConfig.JWTExpiration)
-	claims := jwt.RegisteredClaims{
-		ExpiresAt: jwt.NewNumericDate(expirationTime),
+	claims := AuthClaims{
+		Roles:    callerRoles(c),
+		TenantID: tenantID(c),
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   callerSubject(c),
+			ExpiresAt: jwt.NewNumericDate(expirationTime),
+		},
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
@@ -141,16 +158,16 @@ func (dr *deviceRoutes) get(c *gin.Context) {
 
 	switch {
 	case hostname != "":
-		items, err = dr.getByColumnOrTags(c, "HostName", hostname, odata.Top, odata.Skip, "")
+		items, err = dr.getByColumnOrTags(c, "HostName", hostname, odata.Top, odata.Skip, tenantID(c))
 
 	case friendlyName != "":
-		items, err = dr.getByColumnOrTags(c, "FriendlyName", friendlyName, odata.Top, odata.Skip, "")
+		items, err = dr.getByColumnOrTags(c, "FriendlyName", friendlyName, odata.Top, odata.Skip, tenantID(c))
 
 	case tags != "":
-		items, err = dr.getByColumnOrTags(c, "Tags", tags, odata.Top, odata.Skip, "")
+		items, err = dr.getByColumnOrTags(c, "Tags", tags, odata.Top, odata.Skip, tenantID(c))
 
 	default:
-		items, err = dr.t.Get(c.Request.Context(), odata.Top, odata.Skip, "")
+		items, err = dr.t.Get(c.Request.Context(), odata.Top, odata.Skip, tenantID(c))
 	}
 
 	if err != nil {
@@ -161,7 +178,7 @@ func (dr *deviceRoutes) get(c *gin.Context) {
 	}
 
 	if odata.Count {
-		count, err := dr.t.GetCount(c.Request.Context(), "")
+		count, err := dr.t.GetCount(c.Request.Context(), tenantID(c))
 		if err != nil {
 			dr.l.Error(err, "http - devices - v1 - get")
 			ErrorResponse(c, err)
@@ -180,6 +197,67 @@ func (dr *deviceRoutes) get(c *gin.Context) {
 	}
 }
 
+// @Summary     Download Devices
+// @Description Download the devices matching the filter as CSV, JSON, NDJSON or XLSX, without their credentials
+// @ID          downloadDevices
+// @Tags  	    devices
+// @Produce     text/csv
+// @Produce     json
+// @Param       hostname     query string   false "hostname of the device"
+// @Param       friendlyName query string   false "friendly name of the device"
+// @Param       tags         query string   false "comma separated tags"
+// @Param       method       query string   false "AND or OR, how tags combine"
+// @Param       format       query string   false "csv (default), json, ndjson or xlsx"
+// @Param       columns      query []string false "columns to export, all by default"
+// @Success     200 {string} string
+// @Failure     400 {object} response
+// @Router      /api/v1/devices/download [get]
+func (dr *deviceRoutes) download(c *gin.Context) {
+	opts, ok := bindExport(c, "downloadDevices")
+	if !ok {
+		return
+	}
+
+	out, err := dr.e.Devices(c.Writer, opts)
+	if err != nil {
+		ErrorResponse(c, ErrValidationExport.Wrap("downloadDevices", "Devices", err))
+
+		return
+	}
+
+	tags := c.Query("tags")
+	hostname := c.Query("hostname")
+	friendlyName := c.Query("friendlyName")
+
+	skip := 0
+
+	download(c, dr.l, "downloadDevices", "devices", opts, out, func() ([]dto.Device, bool, error) {
+		var items []dto.Device
+
+		var err error
+
+		// devices looked up by hostname or friendly name come back in one go
+		switch {
+		case hostname != "":
+			items, err = dr.getByColumnOrTags(c, "HostName", hostname, 0, 0, tenantID(c))
+
+			return items, false, err
+		case friendlyName != "":
+			items, err = dr.getByColumnOrTags(c, "FriendlyName", friendlyName, 0, 0, tenantID(c))
+
+			return items, false, err
+		case tags != "":
+			items, err = dr.getByColumnOrTags(c, "Tags", tags, devicesDownloadPageSize, skip, tenantID(c))
+		default:
+			items, err = dr.t.Get(c.Request.Context(), devicesDownloadPageSize, skip, tenantID(c))
+		}
+
+		skip += devicesDownloadPageSize
+
+		return items, len(items) == devicesDownloadPageSize, err
+	})
+}
+
 func (dr *deviceRoutes) getByColumnOrTags(c *gin.Context, column, value string, limit, skip int, tenantID string) ([]dto.Device, error) {
 	var items []dto.Device
 
@@ -189,7 +267,7 @@ func (dr *deviceRoutes) getByColumnOrTags(c *gin.Context, column, value string,
 	if column == "Tags" {
 		items, err = dr.t.GetByTags(ctx, value, c.Query("method"), limit, skip, tenantID)
 	} else {
-		items, err = dr.t.GetByColumn(ctx, column, value, "")
+		items, err = dr.t.GetByColumn(ctx, column, value, tenantID)
 	}
 
 	if err != nil {
@@ -218,7 +296,7 @@ func (dr *deviceRoutes) getByID(c *gin.Context) {
 
 	guid := c.Param("guid")
 
-	item, err := dr.t.GetByID(c.Request.Context(), guid, "")
+	item, err := dr.t.GetByID(c.Request.Context(), guid, tenantID(c))
 	if err != nil {
 		dr.l.Error(err, "http - devices - v1 - get")
 		ErrorResponse(c, err)
@@ -247,6 +325,8 @@ func (dr *deviceRoutes) insert(c *gin.Context) {
 		return
 	}
 
+	device.TenantID = tenantID(c)
+
 	newDevice, err := dr.t.Insert(c.Request.Context(), &device)
 	if err != nil {
 		dr.l.Error(err, "http - devices - v1 - insert")
@@ -275,6 +355,8 @@ func (dr *deviceRoutes) update(c *gin.Context) {
 		return
 	}
 
+	device.TenantID = tenantID(c)
+
 	updatedDevice, err := dr.t.Update(c.Request.Context(), &device)
 	if err != nil {
 		dr.l.Error(err, "http - devices - v1 - update")
@@ -298,7 +380,7 @@ func (dr *deviceRoutes) update(c *gin.Context) {
 func (dr *deviceRoutes) delete(c *gin.Context) {
 	guid := c.Param("guid")
 
-	err := dr.t.Delete(c.Request.Context(), guid, "")
+	err := dr.t.Delete(c.Request.Context(), guid, tenantID(c))
 	if err != nil {
 		dr.l.Error(err, "http - devices - v1 - delete")
 		ErrorResponse(c, err)
@@ -328,7 +410,7 @@ func (dr *deviceRoutes) redirectStatus(c *gin.Context) {
 // @Failure     500 {object} response
 // @Router      /api/v1/devices/tags [get]
 func (dr *deviceRoutes) getTags(c *gin.Context) {
-	tags, err := dr.t.GetDistinctTags(c.Request.Context(), "")
+	tags, err := dr.t.GetDistinctTags(c.Request.Context(), tenantID(c))
 	if err != nil {
 		dr.l.Error(err, "http - devices - v1 - tags")
 		ErrorResponse(c, err)
@@ -358,7 +440,7 @@ func (dr *deviceRoutes) getDeviceCertificate(c *gin.Context) {
 
 	guid := c.Param("guid")
 
-	item, err := dr.t.GetByID(c.Request.Context(), guid, "")
+	item, err := dr.t.GetByID(c.Request.Context(), guid, tenantID(c))
 	if err != nil {
 		dr.l.Error(err, "http - devices - v1 - cert")
 		ErrorResponse(c, err)
@@ -398,7 +480,7 @@ func (dr *deviceRoutes) pinDeviceCertificate(c *gin.Context) {
 
 	guid := c.Param("guid")
 
-	item, err := dr.t.GetByID(c.Request.Context(), guid, "")
+	item, err := dr.t.GetByID(c.Request.Context(), guid, tenantID(c))
 	if err != nil {
 		dr.l.Error(err, "http - devices - v1 - deleteDeviceCertificate - getById")
 		ErrorResponse(c, err)
@@ -438,7 +520,7 @@ func (dr *deviceRoutes) deleteDeviceCertificate(c *gin.Context) {
 
 	guid := c.Param("guid")
 
-	item, err := dr.t.GetByID(c.Request.Context(), guid, "")
+	item, err := dr.t.GetByID(c.Request.Context(), guid, tenantID(c))
 	if err != nil {
 		dr.l.Error(err, "http - devices - v1 - deleteDeviceCertificate - getById")
 		ErrorResponse(c, err)
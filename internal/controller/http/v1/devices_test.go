@@ -16,6 +16,7 @@ import (
 	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
 	"github.com/open-amt-cloud-toolkit/console/internal/mocks"
 	"github.com/open-amt-cloud-toolkit/console/internal/usecase/devices"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/export"
 	"github.com/open-amt-cloud-toolkit/console/pkg/logger"
 )
 
@@ -31,7 +32,7 @@ func devicesTest(t *testing.T) (*mocks.MockDeviceManagementFeature, *gin.Engine)
 	engine := gin.New()
 	handler := engine.Group("/api/v1")
 
-	NewDeviceRoutes(handler, device, log)
+	NewDeviceRoutes(handler, device, export.NewFileExporter(), log)
 
 	return device, engine
 }
@@ -125,7 +126,6 @@ func TestDevicesRoutes(t *testing.T) {
 					GUID:             "guid",
 					MPSUsername:      "mpsusername",
 					Tags:             []string{"tag1", "tag2"},
-					TenantID:         "tenantId",
 					FriendlyName:     "friendlyName",
 					DNSSuffix:        "dnsSuffix",
 					Username:         "admin",
@@ -136,7 +136,7 @@ func TestDevicesRoutes(t *testing.T) {
 					LastSeen:         &timeNow,
 					LastDisconnected: &timeNow,
 				}
-				device.EXPECT().Insert(context.Background(), deviceTest).Return(deviceTest, nil)
+				device.EXPECT().Insert(context.Background(), deviceTest).Return(&responseDevice, nil)
 			},
 			response:     responseDevice,
 			requestBody:  requestDevice,
@@ -154,7 +154,6 @@ func TestDevicesRoutes(t *testing.T) {
 					GUID:             "guid",
 					MPSUsername:      "mpsusername",
 					Tags:             []string{"tag1", "tag2"},
-					TenantID:         "tenantId",
 					FriendlyName:     "friendlyName",
 					DNSSuffix:        "dnsSuffix",
 					Username:         "admin",
@@ -203,7 +202,6 @@ func TestDevicesRoutes(t *testing.T) {
 					GUID:             "guid",
 					MPSUsername:      "mpsusername",
 					Tags:             []string{"tag1", "tag2"},
-					TenantID:         "tenantId",
 					FriendlyName:     "friendlyName",
 					DNSSuffix:        "dnsSuffix",
 					Username:         "admin",
@@ -214,7 +212,7 @@ func TestDevicesRoutes(t *testing.T) {
 					LastSeen:         &timeNow,
 					LastDisconnected: &timeNow,
 				}
-				device.EXPECT().Update(context.Background(), deviceTest).Return(deviceTest, nil)
+				device.EXPECT().Update(context.Background(), deviceTest).Return(&responseDevice, nil)
 			},
 			response:     responseDevice,
 			requestBody:  requestDevice,
@@ -232,7 +230,6 @@ func TestDevicesRoutes(t *testing.T) {
 					GUID:             "guid",
 					MPSUsername:      "mpsusername",
 					Tags:             []string{"tag1", "tag2"},
-					TenantID:         "tenantId",
 					FriendlyName:     "friendlyName",
 					DNSSuffix:        "dnsSuffix",
 					Username:         "admin",
@@ -275,8 +272,9 @@ func TestDevicesRoutes(t *testing.T) {
 			url:    "/api/v1/devices/stats",
 			mock: func(device *mocks.MockDeviceManagementFeature) {
 				device.EXPECT().GetCount(context.Background(), "").Return(5, nil)
+				device.EXPECT().GetConnectedCount(context.Background(), "").Return(3, nil)
 			},
-			response:     DeviceStatResponse{TotalCount: 5},
+			response:     DeviceStatResponse{TotalCount: 5, ConnectedCount: 3, DisconnectedCount: 2},
 			expectedCode: http.StatusOK,
 		},
 	}
@@ -319,3 +317,101 @@ func TestDevicesRoutes(t *testing.T) {
 		})
 	}
 }
+
+func TestDevicesDownload(t *testing.T) {
+	t.Parallel()
+
+	page := make([]dto.Device, devicesDownloadPageSize)
+	for i := range page {
+		page[i] = dto.Device{GUID: "guid", Hostname: "hostname", Username: "admin", Password: "password"}
+	}
+
+	tests := []struct {
+		name         string
+		url          string
+		mock         func(device *mocks.MockDeviceManagementFeature)
+		contentType  string
+		body         string
+		lines        int
+		expectedCode int
+	}{
+		{
+			name: "all devices as csv, a page at a time",
+			url:  "/api/v1/devices/download",
+			mock: func(device *mocks.MockDeviceManagementFeature) {
+				device.EXPECT().Get(context.Background(), 100, 0, "").Return(page, nil)
+				device.EXPECT().Get(context.Background(), 100, 100, "").Return(page[:1], nil)
+			},
+			contentType: "text/csv",
+			// a header row and one row per device
+			lines:        102,
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "tagged devices as ndjson, selected columns",
+			url:  "/api/v1/devices/download?tags=lab&format=ndjson&columns=guid,tags",
+			mock: func(device *mocks.MockDeviceManagementFeature) {
+				device.EXPECT().GetByTags(context.Background(), "lab", "", 100, 0, "").Return([]dto.Device{{GUID: "guid", Tags: []string{"lab"}, Password: "password"}}, nil)
+			},
+			contentType:  "application/x-ndjson",
+			body:         `{"guid":"guid","tags":["lab"]}` + "\n",
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "device by hostname as json",
+			url:  "/api/v1/devices/download?hostname=hostname&format=json&columns=hostname",
+			mock: func(device *mocks.MockDeviceManagementFeature) {
+				device.EXPECT().GetByColumn(context.Background(), "HostName", "hostname", "").Return([]dto.Device{{Hostname: "hostname"}}, nil)
+			},
+			contentType:  "application/json",
+			body:         `[{"hostname":"hostname"}]` + "\n",
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "unknown column",
+			url:          "/api/v1/devices/download?columns=password",
+			mock:         func(_ *mocks.MockDeviceManagementFeature) {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "failure",
+			url:  "/api/v1/devices/download",
+			mock: func(device *mocks.MockDeviceManagementFeature) {
+				device.EXPECT().Get(context.Background(), 100, 0, "").Return(nil, ErrGeneral)
+			},
+			expectedCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			devicesFeature, engine := devicesTest(t)
+
+			tc.mock(devicesFeature)
+
+			req := httptest.NewRequest(http.MethodGet, tc.url, http.NoBody)
+			w := httptest.NewRecorder()
+
+			engine.ServeHTTP(w, req)
+
+			require.Equal(t, tc.expectedCode, w.Code)
+
+			if tc.expectedCode != http.StatusOK {
+				return
+			}
+
+			require.Equal(t, tc.contentType, w.Header().Get("Content-Type"))
+			require.NotContains(t, w.Body.String(), "password")
+
+			if tc.body != "" {
+				require.Equal(t, tc.body, w.Body.String())
+			} else {
+				require.Equal(t, tc.lines, bytes.Count(w.Body.Bytes(), []byte("\n")))
+			}
+		})
+	}
+}
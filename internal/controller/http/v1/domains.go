@@ -54,7 +54,7 @@ func (r *domainRoutes) get(c *gin.Context) {
 		return
 	}
 
-	items, err := r.t.Get(c.Request.Context(), odata.Top, odata.Skip, "")
+	items, err := r.t.Get(c.Request.Context(), odata.Top, odata.Skip, tenantID(c))
 	if err != nil {
 		r.l.Error(err, "http - v1 - getCount")
 		ErrorResponse(c, err)
@@ -63,7 +63,7 @@ func (r *domainRoutes) get(c *gin.Context) {
 	}
 
 	if odata.Count {
-		count, err := r.t.GetCount(c.Request.Context(), "")
+		count, err := r.t.GetCount(c.Request.Context(), tenantID(c))
 		if err != nil {
 			r.l.Error(err, "http - v1 - getCount")
 			ErrorResponse(c, err)
@@ -92,7 +92,7 @@ func (r *domainRoutes) get(c *gin.Context) {
 func (r *domainRoutes) getByName(c *gin.Context) {
 	name := c.Param("name")
 
-	item, err := r.t.GetByName(c.Request.Context(), name, "")
+	item, err := r.t.GetByName(c.Request.Context(), name, tenantID(c))
 	if err != nil {
 		r.l.Error(err, "http - v1 - getByName")
 		ErrorResponse(c, err)
@@ -121,6 +121,8 @@ func (r *domainRoutes) insert(c *gin.Context) {
 		return
 	}
 
+	domain.TenantID = tenantID(c)
+
 	newDomain, err := r.t.Insert(c.Request.Context(), &domain)
 	if err != nil {
 		r.l.Error(err, "http - v1 - insert")
@@ -150,6 +152,8 @@ func (r *domainRoutes) update(c *gin.Context) {
 		return
 	}
 
+	domain.TenantID = tenantID(c)
+
 	updatedDomain, err := r.t.Update(c.Request.Context(), &domain)
 	if err != nil {
 		r.l.Error(err, "http - v1 - update")
@@ -173,7 +177,7 @@ func (r *domainRoutes) update(c *gin.Context) {
 func (r *domainRoutes) delete(c *gin.Context) {
 	name := c.Param("name")
 
-	err := r.t.Delete(c.Request.Context(), name, "")
+	err := r.t.Delete(c.Request.Context(), name, tenantID(c))
 	if err != nil {
 		r.l.Error(err, "http - v1 - delete")
 		ErrorResponse(c, err)
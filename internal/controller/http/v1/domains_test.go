@@ -122,8 +122,8 @@ func TestDomainRoutes(t *testing.T) {
 			method: http.MethodPost,
 			url:    "/api/v1/admin/domains",
 			mock: func(domain *mocks.MockDomainsFeature) {
-				domainTest := &dto.Domain{ProfileName: "newProfile", TenantID: "tenant1", DomainSuffix: "domain.com", ProvisioningCert: "cert", ProvisioningCertStorageFormat: "string", ProvisioningCertPassword: "password"}
-				domain.EXPECT().Insert(context.Background(), domainTest).Return(domainTest, nil)
+				domainTest := &dto.Domain{ProfileName: "newProfile", DomainSuffix: "domain.com", ProvisioningCert: "cert", ProvisioningCertStorageFormat: "string", ProvisioningCertPassword: "password"}
+				domain.EXPECT().Insert(context.Background(), domainTest).Return(&responseDomain, nil)
 			},
 			response:     responseDomain,
 			requestBody:  requestDomain,
@@ -134,7 +134,7 @@ func TestDomainRoutes(t *testing.T) {
 			method: http.MethodPost,
 			url:    "/api/v1/admin/domains",
 			mock: func(domain *mocks.MockDomainsFeature) {
-				domainTest := &dto.Domain{ProfileName: "newProfile", TenantID: "tenant1", DomainSuffix: "domain.com", ProvisioningCert: "cert", ProvisioningCertStorageFormat: "string", ProvisioningCertPassword: "password"}
+				domainTest := &dto.Domain{ProfileName: "newProfile", DomainSuffix: "domain.com", ProvisioningCert: "cert", ProvisioningCertStorageFormat: "string", ProvisioningCertPassword: "password"}
 				domain.EXPECT().Insert(context.Background(), domainTest).Return(nil, domains.ErrDatabase)
 			},
 			response:     domains.ErrDatabase,
@@ -146,7 +146,7 @@ func TestDomainRoutes(t *testing.T) {
 			method: http.MethodPost,
 			url:    "/api/v1/admin/domains",
 			mock: func(domain *mocks.MockDomainsFeature) {
-				domain400Test := &dto.Domain{ProfileName: "p1", TenantID: "t1", DomainSuffix: "domain1.com", ProvisioningCert: "cert1", ProvisioningCertStorageFormat: "string1"}
+				domain400Test := &dto.Domain{ProfileName: "p1", DomainSuffix: "domain1.com", ProvisioningCert: "cert1", ProvisioningCertStorageFormat: "string1"}
 				domain.EXPECT().Insert(context.Background(), domain400Test).Return(nil, domains.ErrDatabase)
 			},
 			response:     domains.ErrDatabase,
@@ -178,8 +178,8 @@ func TestDomainRoutes(t *testing.T) {
 			method: http.MethodPatch,
 			url:    "/api/v1/admin/domains",
 			mock: func(domain *mocks.MockDomainsFeature) {
-				domainTest := &dto.Domain{ProfileName: "newProfile", TenantID: "tenant1", DomainSuffix: "domain.com", ProvisioningCert: "cert", ProvisioningCertStorageFormat: "string", ProvisioningCertPassword: "password"}
-				domain.EXPECT().Update(context.Background(), domainTest).Return(domainTest, nil)
+				domainTest := &dto.Domain{ProfileName: "newProfile", DomainSuffix: "domain.com", ProvisioningCert: "cert", ProvisioningCertStorageFormat: "string", ProvisioningCertPassword: "password"}
+				domain.EXPECT().Update(context.Background(), domainTest).Return(&responseDomain, nil)
 			},
 			response:     responseDomain,
 			requestBody:  requestDomain,
@@ -190,7 +190,7 @@ func TestDomainRoutes(t *testing.T) {
 			method: http.MethodPatch,
 			url:    "/api/v1/admin/domains",
 			mock: func(domain *mocks.MockDomainsFeature) {
-				domainTest := &dto.Domain{ProfileName: "newProfile", TenantID: "tenant1", DomainSuffix: "domain.com", ProvisioningCert: "cert", ProvisioningCertStorageFormat: "string", ProvisioningCertPassword: "password"}
+				domainTest := &dto.Domain{ProfileName: "newProfile", DomainSuffix: "domain.com", ProvisioningCert: "cert", ProvisioningCertStorageFormat: "string", ProvisioningCertPassword: "password"}
 				domain.EXPECT().Update(context.Background(), domainTest).Return(nil, domains.ErrDatabase)
 			},
 			response:     domains.ErrDatabase,
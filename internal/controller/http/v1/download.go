@@ -0,0 +1,80 @@
+package v1
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/export"
+	"github.com/open-amt-cloud-toolkit/console/pkg/consoleerrors"
+	"github.com/open-amt-cloud-toolkit/console/pkg/logger"
+)
+
+var ErrValidationExport = dto.NotValidError{Console: consoleerrors.CreateConsoleError("ExportAPI")}
+
+// exportQuery selects the format and columns of a download. Columns can be repeated or comma separated.
+type exportQuery struct {
+	Format  string   `form:"format"`
+	Columns []string `form:"columns"`
+}
+
+// bindExport reads the export options of a download from the query, responding 400 when they are not valid.
+func bindExport(c *gin.Context, call string) (export.Options, bool) {
+	var q exportQuery
+	if err := c.ShouldBindQuery(&q); err != nil {
+		ErrorResponse(c, ErrValidationExport.Wrap(call, "ShouldBindQuery", err))
+
+		return export.Options{}, false
+	}
+
+	format, err := export.ParseFormat(q.Format)
+	if err != nil {
+		ErrorResponse(c, ErrValidationExport.Wrap(call, "ParseFormat", err))
+
+		return export.Options{}, false
+	}
+
+	return export.Options{Format: format, Columns: splitQuery(q.Columns)}, true
+}
+
+// download streams the pages next returns to the client as the file name, until next reports there are no more.
+// Rows are flushed a page at a time, so an error reading the first page gets an error response while a later
+// one can only cut the file short.
+func download[T any](c *gin.Context, l logger.Interface, call, name string, opts export.Options, out *export.Writer[T], next func() ([]T, bool, error)) {
+	for first := true; ; first = false {
+		rows, more, err := next()
+		if err != nil {
+			l.Error(err, "http - v1 - "+call)
+
+			if first {
+				ErrorResponse(c, err)
+			} else {
+				c.Abort()
+			}
+
+			return
+		}
+
+		if first {
+			c.Header("Content-Disposition", "attachment; filename="+name+opts.Format.Extension())
+			c.Header("Content-Type", opts.Format.ContentType())
+		}
+
+		if err = out.Write(rows...); err != nil {
+			l.Error(err, "http - v1 - "+call)
+			c.Abort()
+
+			return
+		}
+
+		c.Writer.Flush()
+
+		if !more {
+			break
+		}
+	}
+
+	if err := out.Close(); err != nil {
+		l.Error(err, "http - v1 - "+call)
+		c.Abort()
+	}
+}
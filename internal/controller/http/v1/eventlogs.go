@@ -0,0 +1,424 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/eventlogs"
+	"github.com/open-amt-cloud-toolkit/console/pkg/consoleerrors"
+	"github.com/open-amt-cloud-toolkit/console/pkg/logger"
+)
+
+var ErrValidationEventLogs = dto.NotValidError{Console: consoleerrors.CreateConsoleError("EventLogsAPI")}
+
+type eventLogRoutes struct {
+	t eventlogs.Feature
+	l logger.Interface
+}
+
+// NewEventLogRoutes serves the events collected from the fleet and the alerts the rules raised for them.
+func NewEventLogRoutes(handler *gin.RouterGroup, t eventlogs.Feature, l logger.Interface) {
+	r := &eventLogRoutes{t, l}
+
+	h := handler.Group("/amt/events")
+	{
+		h.GET("", r.getEvents)
+		h.POST(":guid", r.collect)
+	}
+
+	a := handler.Group("/alerts")
+	{
+		a.GET("", r.getAlerts)
+		a.GET(":id", r.getAlert)
+		a.POST(":id/acknowledge", r.acknowledge)
+		a.POST(":id/close", r.close)
+	}
+}
+
+// NewAlertRuleRoutes serves the rules that raise alerts.
+func NewAlertRuleRoutes(handler *gin.RouterGroup, t eventlogs.Feature, l logger.Interface) {
+	r := &eventLogRoutes{t, l}
+
+	h := handler.Group("/alertrules")
+	{
+		h.GET("", r.getRules)
+		h.GET(":name", r.getRule)
+		h.POST("", r.insertRule)
+		h.PATCH("", r.updateRule)
+		h.DELETE(":name", r.deleteRule)
+	}
+}
+
+type DeviceEventCountResponse struct {
+	Count int               `json:"totalCount"`
+	Data  []dto.DeviceEvent `json:"data"`
+}
+
+type AlertCountResponse struct {
+	Count int         `json:"totalCount"`
+	Data  []dto.Alert `json:"data"`
+}
+
+type AlertRuleCountResponse struct {
+	Count int             `json:"totalCount"`
+	Data  []dto.AlertRule `json:"data"`
+}
+
+// @Summary     Search AMT Events
+// @Description Search the AMT event log records collected from the fleet, newest first
+// @ID          amtEvents
+// @Tags  	    amt
+// @Accept      json
+// @Produce     json
+// @Param       guid     query string false "device guid"
+// @Param       severity query string false "severity, such as critical"
+// @Param       entity   query string false "entity that logged the event, such as BIOS"
+// @Param       from     query string false "RFC3339 time, inclusive"
+// @Param       to       query string false "RFC3339 time, exclusive"
+// @Success     200 {object} DeviceEventCountResponse
+// @Failure     500 {object} response
+// @Router      /api/v1/amt/events [get]
+func (r *eventLogRoutes) getEvents(c *gin.Context) {
+	var odata OData
+	if err := c.ShouldBindQuery(&odata); err != nil {
+		validationErr := ErrValidationEventLogs.Wrap("getEvents", "ShouldBindQuery", err)
+		ErrorResponse(c, validationErr)
+
+		return
+	}
+
+	var filter dto.DeviceEventFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		validationErr := ErrValidationEventLogs.Wrap("getEvents", "ShouldBindQuery", err)
+		ErrorResponse(c, validationErr)
+
+		return
+	}
+
+	filter.TenantID = tenantID(c)
+
+	items, err := r.t.GetEvents(c.Request.Context(), filter, odata.Top, odata.Skip)
+	if err != nil {
+		r.l.Error(err, "http - v1 - getEvents")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	if odata.Count {
+		count, err := r.t.GetEventCount(c.Request.Context(), filter)
+		if err != nil {
+			r.l.Error(err, "http - v1 - getCount")
+			ErrorResponse(c, err)
+
+			return
+		}
+
+		countResponse := DeviceEventCountResponse{
+			Count: count,
+			Data:  items,
+		}
+
+		c.JSON(http.StatusOK, countResponse)
+	} else {
+		c.JSON(http.StatusOK, items)
+	}
+}
+
+// @Summary     Collect AMT Event Log
+// @Description Collect the new events of a device and raise their alerts without waiting for the schedule
+// @ID          collectAMTEvents
+// @Tags  	    amt
+// @Accept      json
+// @Produce     json
+// @Success     200 {object} dto.EventLogCollection
+// @Failure     404 {object} response
+// @Router      /api/v1/amt/events/{guid} [post]
+func (r *eventLogRoutes) collect(c *gin.Context) {
+	collection, err := r.t.Collect(c.Request.Context(), c.Param("guid"), tenantID(c))
+	if err != nil {
+		r.l.Error(err, "http - v1 - collectEvents")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, collection)
+}
+
+// @Summary     Show Alerts
+// @Description Show the alerts raised by the alert rules, the last raised first
+// @ID          alerts
+// @Tags  	    alerts
+// @Accept      json
+// @Produce     json
+// @Param       status   query string false "open, acknowledged or closed"
+// @Param       guid     query string false "device guid"
+// @Param       ruleName query string false "rule that raised the alert"
+// @Success     200 {object} AlertCountResponse
+// @Failure     500 {object} response
+// @Router      /api/v1/alerts [get]
+func (r *eventLogRoutes) getAlerts(c *gin.Context) {
+	var odata OData
+	if err := c.ShouldBindQuery(&odata); err != nil {
+		validationErr := ErrValidationEventLogs.Wrap("getAlerts", "ShouldBindQuery", err)
+		ErrorResponse(c, validationErr)
+
+		return
+	}
+
+	var filter dto.AlertFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		validationErr := ErrValidationEventLogs.Wrap("getAlerts", "ShouldBindQuery", err)
+		ErrorResponse(c, validationErr)
+
+		return
+	}
+
+	filter.TenantID = tenantID(c)
+
+	items, err := r.t.GetAlerts(c.Request.Context(), filter, odata.Top, odata.Skip)
+	if err != nil {
+		r.l.Error(err, "http - v1 - getAlerts")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	if odata.Count {
+		count, err := r.t.GetAlertCount(c.Request.Context(), filter)
+		if err != nil {
+			r.l.Error(err, "http - v1 - getCount")
+			ErrorResponse(c, err)
+
+			return
+		}
+
+		countResponse := AlertCountResponse{
+			Count: count,
+			Data:  items,
+		}
+
+		c.JSON(http.StatusOK, countResponse)
+	} else {
+		c.JSON(http.StatusOK, items)
+	}
+}
+
+// @Summary     Show Alert
+// @Description Show an alert by id
+// @ID          getAlert
+// @Tags  	    alerts
+// @Accept      json
+// @Produce     json
+// @Success     200 {object} dto.Alert
+// @Failure     404 {object} response
+// @Router      /api/v1/alerts/{id} [get]
+func (r *eventLogRoutes) getAlert(c *gin.Context) {
+	item, err := r.t.GetAlertByID(c.Request.Context(), c.Param("id"), tenantID(c))
+	if err != nil {
+		r.l.Error(err, "http - v1 - getAlert")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, item)
+}
+
+// @Summary     Acknowledge Alert
+// @Description Record that the caller is handling an open alert
+// @ID          acknowledgeAlert
+// @Tags  	    alerts
+// @Accept      json
+// @Produce     json
+// @Success     200 {object} dto.Alert
+// @Failure     400 {object} response
+// @Failure     404 {object} response
+// @Router      /api/v1/alerts/{id}/acknowledge [post]
+func (r *eventLogRoutes) acknowledge(c *gin.Context) {
+	item, err := r.t.Acknowledge(c.Request.Context(), c.Param("id"), tenantID(c))
+	if err != nil {
+		r.l.Error(err, "http - v1 - acknowledgeAlert")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, item)
+}
+
+// @Summary     Close Alert
+// @Description Close an open or acknowledged alert
+// @ID          closeAlert
+// @Tags  	    alerts
+// @Accept      json
+// @Produce     json
+// @Success     200 {object} dto.Alert
+// @Failure     400 {object} response
+// @Failure     404 {object} response
+// @Router      /api/v1/alerts/{id}/close [post]
+func (r *eventLogRoutes) close(c *gin.Context) {
+	item, err := r.t.Close(c.Request.Context(), c.Param("id"), tenantID(c))
+	if err != nil {
+		r.l.Error(err, "http - v1 - closeAlert")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, item)
+}
+
+// @Summary     Show Alert Rules
+// @Description Show all alert rules
+// @ID          alertRules
+// @Tags  	    alertrules
+// @Accept      json
+// @Produce     json
+// @Success     200 {object} AlertRuleCountResponse
+// @Failure     500 {object} response
+// @Router      /api/v1/admin/alertrules [get]
+func (r *eventLogRoutes) getRules(c *gin.Context) {
+	var odata OData
+	if err := c.ShouldBindQuery(&odata); err != nil {
+		validationErr := ErrValidationEventLogs.Wrap("getRules", "ShouldBindQuery", err)
+		ErrorResponse(c, validationErr)
+
+		return
+	}
+
+	items, err := r.t.GetRules(c.Request.Context(), odata.Top, odata.Skip, tenantID(c))
+	if err != nil {
+		r.l.Error(err, "http - v1 - getAlertRules")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	if odata.Count {
+		count, err := r.t.GetRuleCount(c.Request.Context(), tenantID(c))
+		if err != nil {
+			r.l.Error(err, "http - v1 - getCount")
+			ErrorResponse(c, err)
+
+			return
+		}
+
+		countResponse := AlertRuleCountResponse{
+			Count: count,
+			Data:  items,
+		}
+
+		c.JSON(http.StatusOK, countResponse)
+	} else {
+		c.JSON(http.StatusOK, items)
+	}
+}
+
+// @Summary     Show Alert Rule
+// @Description Show an alert rule by name
+// @ID          getAlertRule
+// @Tags  	    alertrules
+// @Accept      json
+// @Produce     json
+// @Success     200 {object} dto.AlertRule
+// @Failure     404 {object} response
+// @Router      /api/v1/admin/alertrules/{name} [get]
+func (r *eventLogRoutes) getRule(c *gin.Context) {
+	item, err := r.t.GetRuleByName(c.Request.Context(), c.Param("name"), tenantID(c))
+	if err != nil {
+		r.l.Error(err, "http - v1 - getAlertRule")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, item)
+}
+
+// @Summary     Add Alert Rule
+// @Description Raise an alert through the named notifiers for the collected events of a severity and entity
+// @ID          insertAlertRule
+// @Tags  	    alertrules
+// @Accept      json
+// @Produce     json
+// @Success     201 {object} dto.AlertRule
+// @Failure     400 {object} response
+// @Router      /api/v1/admin/alertrules [post]
+func (r *eventLogRoutes) insertRule(c *gin.Context) {
+	var rule dto.AlertRule
+	if err := c.ShouldBindJSON(&rule); err != nil {
+		validationErr := ErrValidationEventLogs.Wrap("insertRule", "ShouldBindJSON", err)
+		ErrorResponse(c, validationErr)
+
+		return
+	}
+
+	rule.TenantID = tenantID(c)
+
+	newRule, err := r.t.InsertRule(c.Request.Context(), &rule)
+	if err != nil {
+		r.l.Error(err, "http - v1 - insertAlertRule")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusCreated, newRule)
+}
+
+// @Summary     Edit Alert Rule
+// @Description Edit an alert rule
+// @ID          updateAlertRule
+// @Tags  	    alertrules
+// @Accept      json
+// @Produce     json
+// @Success     200 {object} dto.AlertRule
+// @Failure     400 {object} response
+// @Failure     404 {object} response
+// @Router      /api/v1/admin/alertrules [patch]
+func (r *eventLogRoutes) updateRule(c *gin.Context) {
+	var rule dto.AlertRule
+	if err := c.ShouldBindJSON(&rule); err != nil {
+		validationErr := ErrValidationEventLogs.Wrap("updateRule", "ShouldBindJSON", err)
+		ErrorResponse(c, validationErr)
+
+		return
+	}
+
+	rule.TenantID = tenantID(c)
+
+	updatedRule, err := r.t.UpdateRule(c.Request.Context(), &rule)
+	if err != nil {
+		r.l.Error(err, "http - v1 - updateAlertRule")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, updatedRule)
+}
+
+// @Summary     Remove Alert Rule
+// @Description Remove an alert rule, the alerts it raised are kept
+// @ID          deleteAlertRule
+// @Tags  	    alertrules
+// @Accept      json
+// @Produce     json
+// @Success     204 {object} noContent
+// @Failure     404 {object} response
+// @Router      /api/v1/admin/alertrules/{name} [delete]
+func (r *eventLogRoutes) deleteRule(c *gin.Context) {
+	err := r.t.DeleteRule(c.Request.Context(), c.Param("name"), tenantID(c))
+	if err != nil {
+		r.l.Error(err, "http - v1 - deleteAlertRule")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
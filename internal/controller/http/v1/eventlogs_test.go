@@ -0,0 +1,301 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	"github.com/open-amt-cloud-toolkit/console/internal/mocks"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/devices"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/eventlogs"
+	"github.com/open-amt-cloud-toolkit/console/pkg/logger"
+)
+
+func eventLogsTest(t *testing.T) (*mocks.MockEventLogsFeature, *gin.Engine) {
+	t.Helper()
+
+	mockCtl := gomock.NewController(t)
+
+	log := logger.New("error")
+	feature := mocks.NewMockEventLogsFeature(mockCtl)
+
+	engine := gin.New()
+	handler := engine.Group("/api/v1")
+
+	NewEventLogRoutes(handler, feature, log)
+	NewAlertRuleRoutes(handler.Group("/admin"), feature, log)
+
+	return feature, engine
+}
+
+var (
+	deviceEvent = dto.DeviceEvent{
+		GUID:        "guid1",
+		Time:        time.Date(2024, 12, 1, 11, 0, 0, 0, time.UTC),
+		Severity:    dto.EventSeverityCritical,
+		Entity:      "BIOS",
+		EventData:   []int{64, 7},
+		Description: "System firmware error",
+		CollectedAt: time.Date(2024, 12, 1, 12, 0, 0, 0, time.UTC),
+	}
+
+	eventLogCollection = dto.EventLogCollection{
+		GUID:        "guid1",
+		CollectedAt: time.Date(2024, 12, 1, 12, 0, 0, 0, time.UTC),
+		Read:        42,
+		Collected:   3,
+		Alerts:      1,
+	}
+
+	alert = dto.Alert{
+		ID:          "a1",
+		RuleName:    "bios-critical",
+		GUID:        "guid1",
+		Severity:    dto.EventSeverityCritical,
+		Entity:      "BIOS",
+		Description: "System firmware error",
+		EventTime:   time.Date(2024, 12, 1, 11, 0, 0, 0, time.UTC),
+		RaisedAt:    time.Date(2024, 12, 1, 12, 0, 0, 0, time.UTC),
+		Status:      dto.AlertStatusOpen,
+	}
+
+	alertRule = dto.AlertRule{
+		Name:      "bios-critical",
+		Severity:  dto.EventSeverityCritical,
+		Entity:    "BIOS",
+		Notifiers: []string{"log", "webhook"},
+		Enabled:   true,
+	}
+)
+
+func TestEventLogRoutes(t *testing.T) {
+	t.Parallel()
+
+	from := time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC)
+
+	acknowledged := alert
+	acknowledged.Status = dto.AlertStatusAcknowledged
+
+	tests := []struct {
+		name         string
+		method       string
+		url          string
+		body         string
+		mock         func(feature *mocks.MockEventLogsFeature)
+		response     interface{}
+		expectedCode int
+	}{
+		{
+			name:   "search events - with count",
+			method: http.MethodGet,
+			url:    "/api/v1/amt/events?severity=critical&entity=BIOS&from=2024-12-01T00:00:00Z&$count=true",
+			mock: func(feature *mocks.MockEventLogsFeature) {
+				filter := dto.DeviceEventFilter{Severity: "critical", Entity: "BIOS", From: &from}
+				feature.EXPECT().GetEvents(context.Background(), filter, 25, 0).Return([]dto.DeviceEvent{deviceEvent}, nil)
+				feature.EXPECT().GetEventCount(context.Background(), filter).Return(1, nil)
+			},
+			response:     DeviceEventCountResponse{Count: 1, Data: []dto.DeviceEvent{deviceEvent}},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:   "search events of a device",
+			method: http.MethodGet,
+			url:    "/api/v1/amt/events?guid=guid1&$top=10",
+			mock: func(feature *mocks.MockEventLogsFeature) {
+				feature.EXPECT().GetEvents(context.Background(), dto.DeviceEventFilter{GUID: "guid1"}, 10, 0).Return([]dto.DeviceEvent{deviceEvent}, nil)
+			},
+			response:     []dto.DeviceEvent{deviceEvent},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "search events - bad time",
+			method:       http.MethodGet,
+			url:          "/api/v1/amt/events?to=tomorrow",
+			mock:         func(_ *mocks.MockEventLogsFeature) {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:   "collect",
+			method: http.MethodPost,
+			url:    "/api/v1/amt/events/guid1",
+			mock: func(feature *mocks.MockEventLogsFeature) {
+				feature.EXPECT().Collect(context.Background(), "guid1", "").Return(&eventLogCollection, nil)
+			},
+			response:     eventLogCollection,
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:   "collect - device not found",
+			method: http.MethodPost,
+			url:    "/api/v1/amt/events/guid2",
+			mock: func(feature *mocks.MockEventLogsFeature) {
+				feature.EXPECT().Collect(context.Background(), "guid2", "").Return(nil, devices.ErrNotFound)
+			},
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:   "get open alerts",
+			method: http.MethodGet,
+			url:    "/api/v1/alerts?status=open&guid=guid1",
+			mock: func(feature *mocks.MockEventLogsFeature) {
+				feature.EXPECT().GetAlerts(context.Background(), dto.AlertFilter{Status: "open", GUID: "guid1"}, 25, 0).Return([]dto.Alert{alert}, nil)
+			},
+			response:     []dto.Alert{alert},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:   "get alerts - with count",
+			method: http.MethodGet,
+			url:    "/api/v1/alerts?$count=true",
+			mock: func(feature *mocks.MockEventLogsFeature) {
+				feature.EXPECT().GetAlerts(context.Background(), dto.AlertFilter{}, 25, 0).Return([]dto.Alert{alert}, nil)
+				feature.EXPECT().GetAlertCount(context.Background(), dto.AlertFilter{}).Return(1, nil)
+			},
+			response:     AlertCountResponse{Count: 1, Data: []dto.Alert{alert}},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:   "get alert",
+			method: http.MethodGet,
+			url:    "/api/v1/alerts/a1",
+			mock: func(feature *mocks.MockEventLogsFeature) {
+				feature.EXPECT().GetAlertByID(context.Background(), "a1", "").Return(&alert, nil)
+			},
+			response:     alert,
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:   "acknowledge alert",
+			method: http.MethodPost,
+			url:    "/api/v1/alerts/a1/acknowledge",
+			mock: func(feature *mocks.MockEventLogsFeature) {
+				feature.EXPECT().Acknowledge(context.Background(), "a1", "").Return(&acknowledged, nil)
+			},
+			response:     acknowledged,
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:   "close alert - already closed",
+			method: http.MethodPost,
+			url:    "/api/v1/alerts/a1/close",
+			mock: func(feature *mocks.MockEventLogsFeature) {
+				feature.EXPECT().Close(context.Background(), "a1", "").Return(nil, eventlogs.ErrNotValid)
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:   "close alert - not found",
+			method: http.MethodPost,
+			url:    "/api/v1/alerts/a2/close",
+			mock: func(feature *mocks.MockEventLogsFeature) {
+				feature.EXPECT().Close(context.Background(), "a2", "").Return(nil, eventlogs.ErrNotFound)
+			},
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:   "get rules - with count",
+			method: http.MethodGet,
+			url:    "/api/v1/admin/alertrules?$count=true",
+			mock: func(feature *mocks.MockEventLogsFeature) {
+				feature.EXPECT().GetRules(context.Background(), 25, 0, "").Return([]dto.AlertRule{alertRule}, nil)
+				feature.EXPECT().GetRuleCount(context.Background(), "").Return(1, nil)
+			},
+			response:     AlertRuleCountResponse{Count: 1, Data: []dto.AlertRule{alertRule}},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:   "get rule",
+			method: http.MethodGet,
+			url:    "/api/v1/admin/alertrules/bios-critical",
+			mock: func(feature *mocks.MockEventLogsFeature) {
+				feature.EXPECT().GetRuleByName(context.Background(), "bios-critical", "").Return(&alertRule, nil)
+			},
+			response:     alertRule,
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:   "insert rule",
+			method: http.MethodPost,
+			url:    "/api/v1/admin/alertrules",
+			body:   `{"name":"bios-critical","severity":"critical","entity":"BIOS","notifiers":["log","webhook"],"enabled":true}`,
+			mock: func(feature *mocks.MockEventLogsFeature) {
+				feature.EXPECT().InsertRule(context.Background(), &alertRule).Return(&alertRule, nil)
+			},
+			response:     alertRule,
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name:   "insert rule - unknown notifier",
+			method: http.MethodPost,
+			url:    "/api/v1/admin/alertrules",
+			body:   `{"name":"bios-critical","notifiers":["pigeon"]}`,
+			mock: func(feature *mocks.MockEventLogsFeature) {
+				feature.EXPECT().InsertRule(context.Background(), gomock.Any()).Return(nil, eventlogs.ErrNotValid)
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:   "update rule",
+			method: http.MethodPatch,
+			url:    "/api/v1/admin/alertrules",
+			body:   `{"name":"bios-critical","severity":"critical","entity":"BIOS","notifiers":["log","webhook"],"enabled":true}`,
+			mock: func(feature *mocks.MockEventLogsFeature) {
+				feature.EXPECT().UpdateRule(context.Background(), &alertRule).Return(&alertRule, nil)
+			},
+			response:     alertRule,
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:   "delete rule",
+			method: http.MethodDelete,
+			url:    "/api/v1/admin/alertrules/bios-critical",
+			mock: func(feature *mocks.MockEventLogsFeature) {
+				feature.EXPECT().DeleteRule(context.Background(), "bios-critical", "").Return(nil)
+			},
+			expectedCode: http.StatusNoContent,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			feature, engine := eventLogsTest(t)
+
+			tc.mock(feature)
+
+			var body io.Reader = http.NoBody
+			if tc.body != "" {
+				body = strings.NewReader(tc.body)
+			}
+
+			req, err := http.NewRequest(tc.method, tc.url, body)
+			if err != nil {
+				t.Fatalf("Couldn't create request: %v\n", err)
+			}
+
+			w := httptest.NewRecorder()
+
+			engine.ServeHTTP(w, req)
+
+			require.Equal(t, tc.expectedCode, w.Code)
+
+			if tc.expectedCode == http.StatusOK || tc.expectedCode == http.StatusCreated {
+				jsonBytes, _ := json.Marshal(tc.response)
+				require.Equal(t, string(jsonBytes), w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package v1
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/events"
+	"github.com/open-amt-cloud-toolkit/console/pkg/logger"
+)
+
+// eventsHeartbeat keeps idle streams from being closed by proxies in between.
+const eventsHeartbeat = 15 * time.Second
+
+type eventRoutes struct {
+	t events.Feature
+	l logger.Interface
+}
+
+func NewEventRoutes(handler *gin.RouterGroup, t events.Feature, l logger.Interface) {
+	r := &eventRoutes{t, l}
+
+	handler.GET("/events", r.stream)
+}
+
+// @Summary     Stream device events
+// @Description Server-Sent Events stream of device changes, power and boot actions, feature changes and redirection sessions. Filter with repeated or comma separated guid and tag query parameters.
+// @ID          events
+// @Tags  	    events
+// @Produce     text/event-stream
+// @Param       guid query []string false "device guids"
+// @Param       tag  query []string false "device tags"
+// @Success     200 {object} dto.Event
+// @Router      /api/v1/events [get]
+func (r *eventRoutes) stream(c *gin.Context) {
+	ch, cancel := r.t.Subscribe(dto.EventFilter{
+		TenantID: tenantID(c),
+		GUIDs:    splitQuery(c.QueryArray("guid")),
+		Tags:     splitQuery(c.QueryArray("tag")),
+	})
+	defer cancel()
+
+	heartbeat := time.NewTicker(eventsHeartbeat)
+	defer heartbeat.Stop()
+
+	// the stream is meant to outlive the server's write timeout
+	if err := http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{}); err != nil {
+		r.l.Debug("http - v1 - events - SetWriteDeadline: " + err.Error())
+	}
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case e, ok := <-ch:
+			if !ok {
+				return
+			}
+
+			data, err := json.Marshal(e)
+			if err != nil {
+				r.l.Error(err, "http - v1 - events")
+
+				continue
+			}
+
+			if _, err := fmt.Fprintf(c.Writer, "id: %s\nevent: %s\ndata: %s\n\n", e.ID, e.Type, data); err != nil {
+				return
+			}
+		case <-heartbeat.C:
+			if _, err := io.WriteString(c.Writer, ": ping\n\n"); err != nil {
+				return
+			}
+		}
+
+		c.Writer.Flush()
+	}
+}
+
+func splitQuery(values []string) []string {
+	var result []string
+
+	for _, v := range values {
+		for _, part := range strings.Split(v, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				result = append(result, part)
+			}
+		}
+	}
+
+	return result
+}
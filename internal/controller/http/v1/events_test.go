@@ -0,0 +1,97 @@
+package v1
+
+import (
+	"bufio"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	"github.com/open-amt-cloud-toolkit/console/internal/mocks"
+	"github.com/open-amt-cloud-toolkit/console/pkg/logger"
+)
+
+func TestEventRoutesStream(t *testing.T) {
+	t.Parallel()
+
+	mockCtl := gomock.NewController(t)
+	feature := mocks.NewMockEventsFeature(mockCtl)
+
+	engine := gin.New()
+	handler := engine.Group("/api/v1", func(c *gin.Context) { setTenant(c, "tenant1") })
+
+	NewEventRoutes(handler, feature, logger.New("error"))
+
+	ch := make(chan dto.Event, 1)
+	ch <- dto.Event{
+		ID:       "7",
+		Type:     dto.EventPowerAction,
+		GUID:     "guid-1",
+		TenantID: "tenant1",
+		Tags:     []string{"lab"},
+		Time:     time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC),
+		Data:     dto.PowerActionEvent{Action: 8},
+	}
+
+	close(ch)
+
+	cancelled := false
+
+	feature.EXPECT().
+		Subscribe(dto.EventFilter{TenantID: "tenant1", GUIDs: []string{"guid-1", "guid-2"}, Tags: []string{"lab"}}).
+		Return((<-chan dto.Event)(ch), func() { cancelled = true })
+
+	req, err := http.NewRequest(http.MethodGet, "/api/v1/events?guid=guid-1,guid-2&tag=lab", http.NoBody)
+	require.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "text/event-stream", w.Header().Get("Content-Type"))
+	require.Equal(t, "id: 7\nevent: device.power\n"+
+		`data: {"id":"7","type":"device.power","guid":"guid-1","tags":["lab"],"time":"2026-10-17T12:00:00Z","data":{"action":8,"returnValue":0}}`+
+		"\n\n", w.Body.String())
+	require.True(t, cancelled)
+}
+
+func TestEventRoutesStreamOutlivesWriteTimeout(t *testing.T) {
+	t.Parallel()
+
+	mockCtl := gomock.NewController(t)
+	feature := mocks.NewMockEventsFeature(mockCtl)
+
+	engine := gin.New()
+	handler := engine.Group("/api/v1", func(c *gin.Context) { setTenant(c, "tenant1") })
+
+	NewEventRoutes(handler, feature, logger.New("error"))
+
+	ch := make(chan dto.Event, 1)
+
+	feature.EXPECT().Subscribe(dto.EventFilter{TenantID: "tenant1"}).Return((<-chan dto.Event)(ch), func() {})
+
+	server := httptest.NewUnstartedServer(engine)
+	server.Config.WriteTimeout = 100 * time.Millisecond
+	server.Start()
+
+	defer server.Close()
+
+	res, err := http.Get(server.URL + "/api/v1/events")
+	require.NoError(t, err)
+
+	defer res.Body.Close()
+
+	// published well after the write timeout would have cut the stream
+	time.AfterFunc(300*time.Millisecond, func() {
+		ch <- dto.Event{ID: "1", Type: dto.EventDeviceAdded, TenantID: "tenant1"}
+	})
+
+	line, err := bufio.NewReader(res.Body).ReadString('\n')
+	require.NoError(t, err)
+	require.Equal(t, "id: 1\n", line)
+}
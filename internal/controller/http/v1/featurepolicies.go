@@ -0,0 +1,248 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/featurepolicies"
+	"github.com/open-amt-cloud-toolkit/console/pkg/consoleerrors"
+	"github.com/open-amt-cloud-toolkit/console/pkg/logger"
+)
+
+var ErrValidationFeaturePolicies = dto.NotValidError{Console: consoleerrors.CreateConsoleError("FeaturePoliciesAPI")}
+
+type featurePolicyRoutes struct {
+	t featurepolicies.Feature
+	l logger.Interface
+}
+
+func NewFeaturePolicyRoutes(handler *gin.RouterGroup, t featurepolicies.Feature, l logger.Interface) {
+	r := &featurePolicyRoutes{t, l}
+
+	h := handler.Group("/featurepolicies")
+	{
+		h.GET("", r.get)
+		h.GET(":name", r.getByName)
+		h.GET(":name/drift", r.getDrift)
+		h.GET(":name/remediations", r.getRemediations)
+		h.POST("", r.insert)
+		h.PATCH("", r.update)
+		h.DELETE(":name", r.delete)
+	}
+}
+
+type FeaturePolicyCountResponse struct {
+	Count int                 `json:"totalCount"`
+	Data  []dto.FeaturePolicy `json:"data"`
+}
+
+// @Summary     Show Feature Policies
+// @Description Show all feature policies
+// @ID          featurePolicies
+// @Tags  	    featurepolicies
+// @Accept      json
+// @Produce     json
+// @Success     200 {object} FeaturePolicyCountResponse
+// @Failure     500 {object} response
+// @Router      /api/v1/admin/featurepolicies [get]
+func (r *featurePolicyRoutes) get(c *gin.Context) {
+	var odata OData
+	if err := c.ShouldBindQuery(&odata); err != nil {
+		validationErr := ErrValidationFeaturePolicies.Wrap("get", "ShouldBindQuery", err)
+		ErrorResponse(c, validationErr)
+
+		return
+	}
+
+	items, err := r.t.Get(c.Request.Context(), odata.Top, odata.Skip, tenantID(c))
+	if err != nil {
+		r.l.Error(err, "http - v1 - getFeaturePolicies")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	if odata.Count {
+		count, err := r.t.GetCount(c.Request.Context(), tenantID(c))
+		if err != nil {
+			r.l.Error(err, "http - v1 - getCount")
+			ErrorResponse(c, err)
+
+			return
+		}
+
+		countResponse := FeaturePolicyCountResponse{
+			Count: count,
+			Data:  items,
+		}
+
+		c.JSON(http.StatusOK, countResponse)
+	} else {
+		c.JSON(http.StatusOK, items)
+	}
+}
+
+// @Summary     Show Feature Policy
+// @Description Show a feature policy by name
+// @ID          getFeaturePolicy
+// @Tags  	    featurepolicies
+// @Accept      json
+// @Produce     json
+// @Success     200 {object} dto.FeaturePolicy
+// @Failure     404 {object} response
+// @Router      /api/v1/admin/featurepolicies/:name [get]
+func (r *featurePolicyRoutes) getByName(c *gin.Context) {
+	item, err := r.t.GetByName(c.Request.Context(), c.Param("name"), tenantID(c))
+	if err != nil {
+		r.l.Error(err, "http - v1 - getFeaturePolicy")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, item)
+}
+
+// @Summary     Show Feature Drift
+// @Description Show the last check of every device a feature policy governs, the drifted ones first
+// @ID          getFeatureDrift
+// @Tags  	    featurepolicies
+// @Accept      json
+// @Produce     json
+// @Success     200 {object} []dto.FeatureDrift
+// @Failure     404 {object} response
+// @Router      /api/v1/admin/featurepolicies/:name/drift [get]
+func (r *featurePolicyRoutes) getDrift(c *gin.Context) {
+	var odata OData
+	if err := c.ShouldBindQuery(&odata); err != nil {
+		validationErr := ErrValidationFeaturePolicies.Wrap("getDrift", "ShouldBindQuery", err)
+		ErrorResponse(c, validationErr)
+
+		return
+	}
+
+	drift, err := r.t.GetDrift(c.Request.Context(), c.Param("name"), odata.Top, odata.Skip, tenantID(c))
+	if err != nil {
+		r.l.Error(err, "http - v1 - getFeatureDrift")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, drift)
+}
+
+// @Summary     Show Feature Remediations
+// @Description Show the features a policy set on devices with their values before and after, newest first
+// @ID          getFeatureRemediations
+// @Tags  	    featurepolicies
+// @Accept      json
+// @Produce     json
+// @Success     200 {object} []dto.FeatureRemediation
+// @Failure     404 {object} response
+// @Router      /api/v1/admin/featurepolicies/:name/remediations [get]
+func (r *featurePolicyRoutes) getRemediations(c *gin.Context) {
+	var odata OData
+	if err := c.ShouldBindQuery(&odata); err != nil {
+		validationErr := ErrValidationFeaturePolicies.Wrap("getRemediations", "ShouldBindQuery", err)
+		ErrorResponse(c, validationErr)
+
+		return
+	}
+
+	remediations, err := r.t.GetRemediations(c.Request.Context(), c.Param("name"), odata.Top, odata.Skip, tenantID(c))
+	if err != nil {
+		r.l.Error(err, "http - v1 - getFeatureRemediations")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, remediations)
+}
+
+// @Summary     Add Feature Policy
+// @Description Hold the devices of a tag or profile to a desired user consent, SOL, IDER, KVM and redirection state
+// @ID          insertFeaturePolicy
+// @Tags  	    featurepolicies
+// @Accept      json
+// @Produce     json
+// @Success     201 {object} dto.FeaturePolicy
+// @Failure     400 {object} response
+// @Router      /api/v1/admin/featurepolicies [post]
+func (r *featurePolicyRoutes) insert(c *gin.Context) {
+	var policy dto.FeaturePolicy
+	if err := c.ShouldBindJSON(&policy); err != nil {
+		validationErr := ErrValidationFeaturePolicies.Wrap("insert", "ShouldBindJSON", err)
+		ErrorResponse(c, validationErr)
+
+		return
+	}
+
+	policy.TenantID = tenantID(c)
+
+	newPolicy, err := r.t.Insert(c.Request.Context(), &policy)
+	if err != nil {
+		r.l.Error(err, "http - v1 - insertFeaturePolicy")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusCreated, newPolicy)
+}
+
+// @Summary     Edit Feature Policy
+// @Description Edit a feature policy
+// @ID          updateFeaturePolicy
+// @Tags  	    featurepolicies
+// @Accept      json
+// @Produce     json
+// @Success     200 {object} dto.FeaturePolicy
+// @Failure     400 {object} response
+// @Failure     404 {object} response
+// @Router      /api/v1/admin/featurepolicies [patch]
+func (r *featurePolicyRoutes) update(c *gin.Context) {
+	var policy dto.FeaturePolicy
+	if err := c.ShouldBindJSON(&policy); err != nil {
+		validationErr := ErrValidationFeaturePolicies.Wrap("update", "ShouldBindJSON", err)
+		ErrorResponse(c, validationErr)
+
+		return
+	}
+
+	policy.TenantID = tenantID(c)
+
+	updatedPolicy, err := r.t.Update(c.Request.Context(), &policy)
+	if err != nil {
+		r.l.Error(err, "http - v1 - updateFeaturePolicy")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, updatedPolicy)
+}
+
+// @Summary     Remove Feature Policy
+// @Description Remove a feature policy with its drift and remediation history
+// @ID          deleteFeaturePolicy
+// @Tags  	    featurepolicies
+// @Accept      json
+// @Produce     json
+// @Success     204 {object} noContent
+// @Failure     404 {object} response
+// @Router      /api/v1/admin/featurepolicies/:name [delete]
+func (r *featurePolicyRoutes) delete(c *gin.Context) {
+	err := r.t.Delete(c.Request.Context(), c.Param("name"), tenantID(c))
+	if err != nil {
+		r.l.Error(err, "http - v1 - deleteFeaturePolicy")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
@@ -0,0 +1,217 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	dtov2 "github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v2"
+	"github.com/open-amt-cloud-toolkit/console/internal/mocks"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/featurepolicies"
+	"github.com/open-amt-cloud-toolkit/console/pkg/logger"
+)
+
+func featurePoliciesTest(t *testing.T) (*mocks.MockFeaturePoliciesFeature, *gin.Engine) {
+	t.Helper()
+
+	mockCtl := gomock.NewController(t)
+
+	log := logger.New("error")
+	policies := mocks.NewMockFeaturePoliciesFeature(mockCtl)
+
+	engine := gin.New()
+	handler := engine.Group("/api/v1/admin")
+
+	NewFeaturePolicyRoutes(handler, policies, log)
+
+	return policies, engine
+}
+
+var (
+	enabled = true
+
+	featurePolicy = dto.FeaturePolicy{
+		Name:    "lab-kvm",
+		Scope:   dto.FeaturePolicyScopeTag,
+		Target:  "lab",
+		Desired: dto.DesiredFeatures{EnableKVM: &enabled},
+	}
+
+	featureDrift = dto.FeatureDrift{
+		GUID:       "guid-1",
+		PolicyName: "lab-kvm",
+		Drifted:    true,
+		Fields:     []string{"enableKVM"},
+		Actual:     &dtov2.Features{UserConsent: "all", KVMAvailable: true},
+	}
+
+	featureRemediation = dto.FeatureRemediation{
+		ID:         "remediation-1",
+		GUID:       "guid-1",
+		PolicyName: "lab-kvm",
+		Before:     dtov2.Features{UserConsent: "all", KVMAvailable: true},
+		After:      &dtov2.Features{UserConsent: "all", EnableKVM: true, Redirection: true, KVMAvailable: true},
+	}
+)
+
+func TestFeaturePolicyRoutes(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		method       string
+		url          string
+		mock         func(policies *mocks.MockFeaturePoliciesFeature)
+		response     interface{}
+		requestBody  dto.FeaturePolicy
+		expectedCode int
+	}{
+		{
+			name:   "get all feature policies",
+			method: http.MethodGet,
+			url:    "/api/v1/admin/featurepolicies",
+			mock: func(policies *mocks.MockFeaturePoliciesFeature) {
+				policies.EXPECT().Get(context.Background(), 25, 0, "").Return([]dto.FeaturePolicy{featurePolicy}, nil)
+			},
+			response:     []dto.FeaturePolicy{featurePolicy},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:   "get all feature policies - with count",
+			method: http.MethodGet,
+			url:    "/api/v1/admin/featurepolicies?$top=10&$skip=1&$count=true",
+			mock: func(policies *mocks.MockFeaturePoliciesFeature) {
+				policies.EXPECT().Get(context.Background(), 10, 1, "").Return([]dto.FeaturePolicy{featurePolicy}, nil)
+				policies.EXPECT().GetCount(context.Background(), "").Return(1, nil)
+			},
+			response:     FeaturePolicyCountResponse{Count: 1, Data: []dto.FeaturePolicy{featurePolicy}},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:   "get feature policy by name",
+			method: http.MethodGet,
+			url:    "/api/v1/admin/featurepolicies/lab-kvm",
+			mock: func(policies *mocks.MockFeaturePoliciesFeature) {
+				policies.EXPECT().GetByName(context.Background(), "lab-kvm", "").Return(&featurePolicy, nil)
+			},
+			response:     featurePolicy,
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:   "get feature policy by name - not found",
+			method: http.MethodGet,
+			url:    "/api/v1/admin/featurepolicies/unknown",
+			mock: func(policies *mocks.MockFeaturePoliciesFeature) {
+				policies.EXPECT().GetByName(context.Background(), "unknown", "").Return(nil, featurepolicies.ErrNotFound)
+			},
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:   "get feature drift",
+			method: http.MethodGet,
+			url:    "/api/v1/admin/featurepolicies/lab-kvm/drift",
+			mock: func(policies *mocks.MockFeaturePoliciesFeature) {
+				policies.EXPECT().GetDrift(context.Background(), "lab-kvm", 25, 0, "").Return([]dto.FeatureDrift{featureDrift}, nil)
+			},
+			response:     []dto.FeatureDrift{featureDrift},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:   "get feature remediations",
+			method: http.MethodGet,
+			url:    "/api/v1/admin/featurepolicies/lab-kvm/remediations",
+			mock: func(policies *mocks.MockFeaturePoliciesFeature) {
+				policies.EXPECT().GetRemediations(context.Background(), "lab-kvm", 25, 0, "").Return([]dto.FeatureRemediation{featureRemediation}, nil)
+			},
+			response:     []dto.FeatureRemediation{featureRemediation},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:   "insert feature policy",
+			method: http.MethodPost,
+			url:    "/api/v1/admin/featurepolicies",
+			mock: func(policies *mocks.MockFeaturePoliciesFeature) {
+				policies.EXPECT().Insert(context.Background(), gomock.Any()).Return(&featurePolicy, nil)
+			},
+			requestBody:  featurePolicy,
+			response:     featurePolicy,
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name:   "insert feature policy - not valid",
+			method: http.MethodPost,
+			url:    "/api/v1/admin/featurepolicies",
+			mock: func(policies *mocks.MockFeaturePoliciesFeature) {
+				policies.EXPECT().Insert(context.Background(), gomock.Any()).Return(nil, featurepolicies.ErrNotValid)
+			},
+			requestBody:  featurePolicy,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:   "update feature policy",
+			method: http.MethodPatch,
+			url:    "/api/v1/admin/featurepolicies",
+			mock: func(policies *mocks.MockFeaturePoliciesFeature) {
+				policies.EXPECT().Update(context.Background(), gomock.Any()).Return(&featurePolicy, nil)
+			},
+			requestBody:  featurePolicy,
+			response:     featurePolicy,
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:   "delete feature policy",
+			method: http.MethodDelete,
+			url:    "/api/v1/admin/featurepolicies/lab-kvm",
+			mock: func(policies *mocks.MockFeaturePoliciesFeature) {
+				policies.EXPECT().Delete(context.Background(), "lab-kvm", "").Return(nil)
+			},
+			expectedCode: http.StatusNoContent,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			policyFeature, engine := featurePoliciesTest(t)
+
+			tc.mock(policyFeature)
+
+			var req *http.Request
+
+			var err error
+
+			if tc.method == http.MethodPost || tc.method == http.MethodPatch {
+				reqBody, _ := json.Marshal(tc.requestBody)
+				req, err = http.NewRequest(tc.method, tc.url, bytes.NewBuffer(reqBody))
+			} else {
+				req, err = http.NewRequest(tc.method, tc.url, http.NoBody)
+			}
+
+			if err != nil {
+				t.Fatalf("Couldn't create request: %v\n", err)
+			}
+
+			w := httptest.NewRecorder()
+
+			engine.ServeHTTP(w, req)
+
+			require.Equal(t, tc.expectedCode, w.Code)
+
+			if tc.expectedCode == http.StatusOK || tc.expectedCode == http.StatusCreated {
+				jsonBytes, _ := json.Marshal(tc.response)
+				require.Equal(t, string(jsonBytes), w.Body.String())
+			}
+		})
+	}
+}
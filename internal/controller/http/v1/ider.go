@@ -0,0 +1,46 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// @Summary     Show IDE-R Session
+// @Description Show the image the console serves to the device over IDE-R
+// @ID          getIDER
+// @Tags  	    ider
+// @Produce     json
+// @Success     200 {object} dto.IDERSession
+// @Failure     404 {object} response
+// @Router      /api/v1/amt/ider/{guid} [get]
+func (r *deviceManagementRoutes) getIDER(c *gin.Context) {
+	session, err := r.d.GetIDER(c.Request.Context(), c.Param("guid"))
+	if err != nil {
+		r.l.Error(err, "http - v1 - getIDER")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, session)
+}
+
+// @Summary     Eject Media Image
+// @Description Close the IDE-R session with the device, the image is ejected
+// @ID          closeIDER
+// @Tags  	    ider
+// @Produce     json
+// @Success     204 {object} nil
+// @Failure     404 {object} response
+// @Router      /api/v1/amt/ider/{guid} [delete]
+func (r *deviceManagementRoutes) closeIDER(c *gin.Context) {
+	if err := r.d.CloseIDER(c.Request.Context(), c.Param("guid")); err != nil {
+		r.l.Error(err, "http - v1 - closeIDER")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
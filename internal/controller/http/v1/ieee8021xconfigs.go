@@ -56,7 +56,7 @@ func (r *ieee8021xConfigRoutes) get(c *gin.Context) {
 		return
 	}
 
-	items, err := r.t.Get(c.Request.Context(), odata.Top, odata.Skip, "")
+	items, err := r.t.Get(c.Request.Context(), odata.Top, odata.Skip, tenantID(c))
 	if err != nil {
 		r.l.Error(err, "http - IEEE8021x configs - v1 - getCount")
 		ErrorResponse(c, err)
@@ -65,7 +65,7 @@ func (r *ieee8021xConfigRoutes) get(c *gin.Context) {
 	}
 
 	if odata.Count {
-		count, err := r.t.GetCount(c.Request.Context(), "")
+		count, err := r.t.GetCount(c.Request.Context(), tenantID(c))
 		if err != nil {
 			r.l.Error(err, "http - IEEE8021x configs - v1 - getCount")
 			ErrorResponse(c, err)
@@ -85,7 +85,7 @@ func (r *ieee8021xConfigRoutes) get(c *gin.Context) {
 func (r *ieee8021xConfigRoutes) getByName(c *gin.Context) {
 	configName := c.Param("profileName")
 
-	config, err := r.t.GetByName(c.Request.Context(), configName, "")
+	config, err := r.t.GetByName(c.Request.Context(), configName, tenantID(c))
 	if err != nil {
 		r.l.Error(err, "http - IEEE8021x configs - v1 - getByName")
 		ErrorResponse(c, err)
@@ -105,6 +105,8 @@ func (r *ieee8021xConfigRoutes) insert(c *gin.Context) {
 		return
 	}
 
+	config.TenantID = tenantID(c)
+
 	newConfig, err := r.t.Insert(c.Request.Context(), &config)
 	if err != nil {
 		r.l.Error(err, "http - IEEE8021x configs - v1 - insert")
@@ -125,6 +127,8 @@ func (r *ieee8021xConfigRoutes) update(c *gin.Context) {
 		return
 	}
 
+	config.TenantID = tenantID(c)
+
 	updatedConfig, err := r.t.Update(c.Request.Context(), &config)
 	if err != nil {
 		r.l.Error(err, "http - IEEE8021x configs - v1 - update")
@@ -139,7 +143,7 @@ func (r *ieee8021xConfigRoutes) update(c *gin.Context) {
 func (r *ieee8021xConfigRoutes) delete(c *gin.Context) {
 	configName := c.Param("profileName")
 
-	err := r.t.Delete(c.Request.Context(), configName, "")
+	err := r.t.Delete(c.Request.Context(), configName, tenantID(c))
 	if err != nil {
 		r.l.Error(err, "http - IEEE8021x configs - v1 - delete")
 		ErrorResponse(c, err)
@@ -56,6 +56,15 @@ var ieee8021xconfigTest = dto.IEEE8021xConfig{
 	WiredInterface:         false,
 }
 
+// ieee8021xconfigStored is ieee8021xconfigTest with the tenant of the caller in place of the one in the request body
+var ieee8021xconfigStored = dto.IEEE8021xConfig{
+	ProfileName:            "newprofile",
+	AuthenticationProtocol: 2,
+	PXETimeout:             &pxeTime,
+	Version:                "1.0",
+	WiredInterface:         false,
+}
+
 func TestIEEE8021xConfigsRoutes(t *testing.T) {
 	t.Parallel()
 
@@ -122,7 +131,7 @@ func TestIEEE8021xConfigsRoutes(t *testing.T) {
 			method: http.MethodPost,
 			url:    "/api/v1/admin/ieee8021xconfigs",
 			mock: func(ieeeConfig *mocks.MockIEEE8021xConfigsFeature) {
-				ieeeConfig.EXPECT().Insert(context.Background(), &ieee8021xconfigTest).Return(&ieee8021xconfigTest, nil)
+				ieeeConfig.EXPECT().Insert(context.Background(), &ieee8021xconfigStored).Return(&ieee8021xconfigTest, nil)
 			},
 			response:     ieee8021xconfigTest,
 			requestBody:  ieee8021xconfigTest,
@@ -133,7 +142,7 @@ func TestIEEE8021xConfigsRoutes(t *testing.T) {
 			method: http.MethodPost,
 			url:    "/api/v1/admin/ieee8021xconfigs",
 			mock: func(ieeeConfig *mocks.MockIEEE8021xConfigsFeature) {
-				ieeeConfig.EXPECT().Insert(context.Background(), &ieee8021xconfigTest).Return(nil, ieee8021xconfigs.ErrDatabase)
+				ieeeConfig.EXPECT().Insert(context.Background(), &ieee8021xconfigStored).Return(nil, ieee8021xconfigs.ErrDatabase)
 			},
 			response:     ieee8021xconfigs.ErrDatabase,
 			requestBody:  ieee8021xconfigTest,
@@ -164,7 +173,7 @@ func TestIEEE8021xConfigsRoutes(t *testing.T) {
 			method: http.MethodPatch,
 			url:    "/api/v1/admin/ieee8021xconfigs",
 			mock: func(ieeeConfig *mocks.MockIEEE8021xConfigsFeature) {
-				ieeeConfig.EXPECT().Update(context.Background(), &ieee8021xconfigTest).Return(&ieee8021xconfigTest, nil)
+				ieeeConfig.EXPECT().Update(context.Background(), &ieee8021xconfigStored).Return(&ieee8021xconfigTest, nil)
 			},
 			response:     ieee8021xconfigTest,
 			requestBody:  ieee8021xconfigTest,
@@ -175,7 +184,7 @@ func TestIEEE8021xConfigsRoutes(t *testing.T) {
 			method: http.MethodPatch,
 			url:    "/api/v1/admin/ieee8021xconfigs",
 			mock: func(ieeeConfig *mocks.MockIEEE8021xConfigsFeature) {
-				ieeeConfig.EXPECT().Update(context.Background(), &ieee8021xconfigTest).Return(nil, ieee8021xconfigs.ErrDatabase)
+				ieeeConfig.EXPECT().Update(context.Background(), &ieee8021xconfigStored).Return(nil, ieee8021xconfigs.ErrDatabase)
 			},
 			response:     ieee8021xconfigs.ErrDatabase,
 			requestBody:  ieee8021xconfigTest,
@@ -0,0 +1,234 @@
+package v1
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/images"
+	"github.com/open-amt-cloud-toolkit/console/pkg/consoleerrors"
+	"github.com/open-amt-cloud-toolkit/console/pkg/logger"
+)
+
+var (
+	ErrValidationImages = dto.NotValidError{Console: consoleerrors.CreateConsoleError("ImagesAPI")}
+
+	errNoImageFile = errors.New("the request has no image file")
+)
+
+type imageRoutes struct {
+	t images.Feature
+	l logger.Interface
+}
+
+func NewImageRoutes(handler *gin.RouterGroup, t images.Feature, l logger.Interface) {
+	r := &imageRoutes{t, l}
+
+	h := handler.Group("/images")
+	{
+		h.GET("", r.get)
+		h.GET(":id", r.getByID)
+		h.POST("", r.upload)
+		h.DELETE(":id", r.delete)
+	}
+}
+
+type ImageCountResponse struct {
+	Count int              `json:"totalCount"`
+	Data  []dto.MediaImage `json:"data"`
+}
+
+// @Summary     Show Media Images
+// @Description Show the ISO and IMG images the console can serve to devices over IDE-R
+// @ID          images
+// @Tags  	    images
+// @Accept      json
+// @Produce     json
+// @Success     200 {object} ImageCountResponse
+// @Failure     500 {object} response
+// @Router      /api/v1/admin/images [get]
+func (r *imageRoutes) get(c *gin.Context) {
+	var odata OData
+	if err := c.ShouldBindQuery(&odata); err != nil {
+		validationErr := ErrValidationImages.Wrap("get", "ShouldBindQuery", err)
+		ErrorResponse(c, validationErr)
+
+		return
+	}
+
+	items, err := r.t.Get(c.Request.Context(), odata.Top, odata.Skip, tenantID(c))
+	if err != nil {
+		r.l.Error(err, "http - v1 - getImages")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	if odata.Count {
+		count, err := r.t.GetCount(c.Request.Context(), tenantID(c))
+		if err != nil {
+			r.l.Error(err, "http - v1 - getCount")
+			ErrorResponse(c, err)
+
+			return
+		}
+
+		countResponse := ImageCountResponse{
+			Count: count,
+			Data:  items,
+		}
+
+		c.JSON(http.StatusOK, countResponse)
+	} else {
+		c.JSON(http.StatusOK, items)
+	}
+}
+
+// @Summary     Show Media Image
+// @Description Show a media image by id
+// @ID          getImage
+// @Tags  	    images
+// @Accept      json
+// @Produce     json
+// @Success     200 {object} dto.MediaImage
+// @Failure     404 {object} response
+// @Failure     500 {object} response
+// @Router      /api/v1/admin/images/:id [get]
+func (r *imageRoutes) getByID(c *gin.Context) {
+	item, err := r.t.GetByID(c.Request.Context(), c.Param("id"), tenantID(c))
+	if err != nil {
+		r.l.Error(err, "http - v1 - getImage")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, item)
+}
+
+// @Summary     Upload Media Image
+// @Description Upload an .iso or .img file to the image library. The file is streamed to disk, name overrides the file name it is listed under.
+// @ID          uploadImage
+// @Tags  	    images
+// @Accept      multipart/form-data
+// @Produce     json
+// @Param       file formData file true "image"
+// @Param       name query string false "name of the image"
+// @Success     201 {object} dto.MediaImage
+// @Failure     400 {object} response
+// @Failure     500 {object} response
+// @Router      /api/v1/admin/images [post]
+func (r *imageRoutes) upload(c *gin.Context) {
+	// an image of several gigabytes takes far longer to send than the server's read timeout allows
+	if err := http.NewResponseController(c.Writer).SetReadDeadline(time.Time{}); err != nil {
+		r.l.Debug("http - v1 - uploadImage - SetReadDeadline: " + err.Error())
+	}
+
+	reader, err := c.Request.MultipartReader()
+	if err != nil {
+		validationErr := ErrValidationImages.Wrap("upload", "MultipartReader", err)
+		ErrorResponse(c, validationErr)
+
+		return
+	}
+
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			validationErr := ErrValidationImages.Wrap("upload", "NextPart", err)
+			ErrorResponse(c, validationErr)
+
+			return
+		}
+
+		if part.FileName() == "" {
+			part.Close()
+
+			continue
+		}
+
+		name := c.Query("name")
+		if name == "" {
+			name = part.FileName()
+		}
+
+		item, err := r.t.Upload(c.Request.Context(), name, part, tenantID(c))
+		part.Close()
+
+		if err != nil {
+			r.l.Error(err, "http - v1 - uploadImage")
+			ErrorResponse(c, err)
+
+			return
+		}
+
+		c.JSON(http.StatusCreated, item)
+
+		return
+	}
+
+	ErrorResponse(c, ErrValidationImages.Wrap("upload", "NextPart", errNoImageFile))
+}
+
+// @Summary     Remove Media Image
+// @Description Remove an image that is not mounted on a device and its file
+// @ID          deleteImage
+// @Tags  	    images
+// @Accept      json
+// @Produce     json
+// @Success     204 {object} nil
+// @Failure     400 {object} response
+// @Failure     500 {object} response
+// @Router      /api/v1/admin/images/:id [delete]
+func (r *imageRoutes) delete(c *gin.Context) {
+	err := r.t.Delete(c.Request.Context(), c.Param("id"), tenantID(c))
+	if err != nil {
+		r.l.Error(err, "http - v1 - deleteImage")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+// @Summary     Mount Media Image
+// @Description Serve an image from the library to the device over IDE-R. With boot the device is reset or powered on to boot from the image, the session stays open until it is ejected.
+// @ID          mountIDER
+// @Tags  	    ider
+// @Accept      json
+// @Produce     json
+// @Param       request body dto.IDERMount true "image to mount"
+// @Success     200 {object} dto.IDERSession
+// @Failure     400 {object} response
+// @Failure     403 {object} response
+// @Failure     404 {object} response
+// @Failure     500 {object} response
+// @Router      /api/v1/amt/ider/{guid} [post]
+func (r *imageRoutes) mount(c *gin.Context) {
+	var mount dto.IDERMount
+	if err := c.ShouldBindJSON(&mount); err != nil {
+		validationErr := ErrValidationImages.Wrap("mount", "ShouldBindJSON", err)
+		ErrorResponse(c, validationErr)
+
+		return
+	}
+
+	session, err := r.t.Mount(c.Request.Context(), c.Param("guid"), mount)
+	if err != nil {
+		r.l.Error(err, "http - v1 - mountIDER")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, session)
+}
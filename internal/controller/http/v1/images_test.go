@@ -0,0 +1,294 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	"github.com/open-amt-cloud-toolkit/console/internal/mocks"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/images"
+	"github.com/open-amt-cloud-toolkit/console/pkg/logger"
+)
+
+func imagesTest(t *testing.T, granted ...string) (*mocks.MockImagesFeature, *gin.Engine) {
+	t.Helper()
+
+	mockCtl := gomock.NewController(t)
+
+	log := logger.New("error")
+	image := mocks.NewMockImagesFeature(mockCtl)
+
+	engine := gin.New()
+	handler := engine.Group("/api/v1/admin", func(c *gin.Context) { setTenant(c, "tenant1") })
+
+	NewImageRoutes(handler, image, log)
+	NewRedirectionRoutes(engine.Group("/api/v1", GrantRoles(granted...)), mocks.NewMockDeviceManagementFeature(mockCtl), image, mocks.NewMockVNC(mockCtl), mocks.NewMockThumbnails(mockCtl), log)
+
+	return image, engine
+}
+
+// imageUpload builds a multipart body with a name field before the image file.
+func imageUpload(t *testing.T, fileName, content string) (*bytes.Buffer, string) {
+	t.Helper()
+
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+
+	require.NoError(t, writer.WriteField("description", "install media"))
+
+	if fileName != "" {
+		part, err := writer.CreateFormFile("file", fileName)
+		require.NoError(t, err)
+
+		_, err = part.Write([]byte(content))
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, writer.Close())
+
+	return body, writer.FormDataContentType()
+}
+
+var ubuntuImage = dto.MediaImage{
+	ID:         "image-1",
+	Name:       "ubuntu.iso",
+	Kind:       dto.MediaKindCD,
+	SizeBytes:  4,
+	SHA256:     "aa",
+	UploadedBy: "alice",
+	CreatedAt:  time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC),
+	TenantID:   "tenant1",
+}
+
+func TestImageRoutes(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		roles        []string
+		method       string
+		url          string
+		fileName     string
+		requestBody  interface{}
+		mock         func(image *mocks.MockImagesFeature)
+		response     interface{}
+		expectedCode int
+	}{
+		{
+			name:   "get all images - with count",
+			method: http.MethodGet,
+			url:    "/api/v1/admin/images?$top=10&$skip=0&$count=true",
+			mock: func(image *mocks.MockImagesFeature) {
+				image.EXPECT().Get(gomock.Any(), 10, 0, "tenant1").Return([]dto.MediaImage{ubuntuImage}, nil)
+				image.EXPECT().GetCount(gomock.Any(), "tenant1").Return(1, nil)
+			},
+			response:     ImageCountResponse{Count: 1, Data: []dto.MediaImage{ubuntuImage}},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:   "get image - not found",
+			method: http.MethodGet,
+			url:    "/api/v1/admin/images/image-2",
+			mock: func(image *mocks.MockImagesFeature) {
+				image.EXPECT().GetByID(gomock.Any(), "image-2", "tenant1").Return(nil, images.ErrNotFound)
+			},
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:     "upload image",
+			method:   http.MethodPost,
+			url:      "/api/v1/admin/images",
+			fileName: "ubuntu.iso",
+			mock: func(image *mocks.MockImagesFeature) {
+				image.EXPECT().Upload(gomock.Any(), "ubuntu.iso", gomock.Any(), "tenant1").
+					DoAndReturn(func(_ context.Context, _ string, r io.Reader, _ string) (*dto.MediaImage, error) {
+						content, err := io.ReadAll(r)
+						if err != nil || string(content) != "CD01" {
+							return nil, images.ErrNotValid
+						}
+
+						return &ubuntuImage, nil
+					})
+			},
+			response:     ubuntuImage,
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name:     "upload image - renamed",
+			method:   http.MethodPost,
+			url:      "/api/v1/admin/images?name=installer.iso",
+			fileName: "ubuntu.iso",
+			mock: func(image *mocks.MockImagesFeature) {
+				image.EXPECT().Upload(gomock.Any(), "installer.iso", gomock.Any(), "tenant1").Return(&ubuntuImage, nil)
+			},
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name:         "upload image - no file",
+			method:       http.MethodPost,
+			url:          "/api/v1/admin/images",
+			mock:         func(_ *mocks.MockImagesFeature) {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:   "delete image - mounted",
+			method: http.MethodDelete,
+			url:    "/api/v1/admin/images/image-1",
+			mock: func(image *mocks.MockImagesFeature) {
+				image.EXPECT().Delete(gomock.Any(), "image-1", "tenant1").Return(images.ErrNotValid)
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:        "mount and boot",
+			method:      http.MethodPost,
+			url:         "/api/v1/amt/ider/guid-1",
+			requestBody: dto.IDERMount{ImageID: "image-1", Boot: dto.IDERBootReset},
+			mock: func(image *mocks.MockImagesFeature) {
+				image.EXPECT().Mount(gomock.Any(), "guid-1", dto.IDERMount{ImageID: "image-1", Boot: dto.IDERBootReset}).
+					Return(dto.IDERSession{GUID: "guid-1", State: dto.IDERStateMounted}, nil)
+			},
+			response:     dto.IDERSession{GUID: "guid-1", State: dto.IDERStateMounted},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "mount - redirection role cannot boot",
+			roles:        []string{dto.RoleRedirection},
+			method:       http.MethodPost,
+			url:          "/api/v1/amt/ider/guid-1",
+			requestBody:  dto.IDERMount{ImageID: "image-1", Boot: dto.IDERBootReset},
+			mock:         func(_ *mocks.MockImagesFeature) {},
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name:        "mount - unknown image",
+			method:      http.MethodPost,
+			url:         "/api/v1/amt/ider/guid-1",
+			requestBody: dto.IDERMount{ImageID: "image-2"},
+			mock: func(image *mocks.MockImagesFeature) {
+				image.EXPECT().Mount(gomock.Any(), "guid-1", dto.IDERMount{ImageID: "image-2"}).
+					Return(dto.IDERSession{}, images.ErrNotFound)
+			},
+			expectedCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			roles := tc.roles
+			if roles == nil {
+				roles = []string{dto.RoleOperator}
+			}
+
+			imageFeature, engine := imagesTest(t, roles...)
+
+			tc.mock(imageFeature)
+
+			var (
+				body        io.Reader = http.NoBody
+				contentType string
+			)
+
+			switch {
+			case tc.requestBody != nil:
+				reqBody, _ := json.Marshal(tc.requestBody)
+				body, contentType = bytes.NewBuffer(reqBody), "application/json"
+			case tc.method == http.MethodPost:
+				body, contentType = imageUpload(t, tc.fileName, "CD01")
+			}
+
+			req, err := http.NewRequestWithContext(context.Background(), tc.method, tc.url, body)
+			require.NoError(t, err)
+
+			if contentType != "" {
+				req.Header.Set("Content-Type", contentType)
+			}
+
+			w := httptest.NewRecorder()
+
+			engine.ServeHTTP(w, req)
+
+			require.Equal(t, tc.expectedCode, w.Code)
+
+			if tc.response != nil {
+				jsonBytes, _ := json.Marshal(tc.response)
+				require.Equal(t, string(jsonBytes), w.Body.String())
+			}
+		})
+	}
+}
+
+func TestImageRoutesUploadOutlivesReadTimeout(t *testing.T) {
+	t.Parallel()
+
+	imageFeature, engine := imagesTest(t)
+
+	var received []byte
+
+	imageFeature.EXPECT().Upload(gomock.Any(), "ubuntu.iso", gomock.Any(), "tenant1").
+		DoAndReturn(func(_ context.Context, _ string, r io.Reader, _ string) (*dto.MediaImage, error) {
+			var err error
+
+			received, err = io.ReadAll(r)
+
+			return &ubuntuImage, err
+		})
+
+	server := httptest.NewUnstartedServer(engine)
+	server.Config.ReadTimeout = 100 * time.Millisecond
+	server.Start()
+
+	defer server.Close()
+
+	body, writeBody := io.Pipe()
+	writer := multipart.NewWriter(writeBody)
+
+	// the image arrives in chunks over longer than the read timeout
+	go func() {
+		part, err := writer.CreateFormFile("file", "ubuntu.iso")
+		if err != nil {
+			writeBody.CloseWithError(err)
+
+			return
+		}
+
+		for i := 0; i < 3; i++ {
+			time.Sleep(100 * time.Millisecond)
+
+			if _, err := part.Write([]byte("CD01")); err != nil {
+				writeBody.CloseWithError(err)
+
+				return
+			}
+		}
+
+		writeBody.CloseWithError(writer.Close())
+	}()
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, server.URL+"/api/v1/admin/images", body)
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	res, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+
+	defer res.Body.Close()
+
+	require.Equal(t, http.StatusCreated, res.StatusCode)
+	require.Equal(t, "CD01CD01CD01", string(received))
+}
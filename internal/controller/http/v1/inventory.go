@@ -0,0 +1,214 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/export"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/inventory"
+	"github.com/open-amt-cloud-toolkit/console/pkg/consoleerrors"
+	"github.com/open-amt-cloud-toolkit/console/pkg/logger"
+)
+
+const inventoryDownloadPageSize = 100
+
+var ErrValidationInventory = dto.NotValidError{Console: consoleerrors.CreateConsoleError("InventoryAPI")}
+
+type inventoryRoutes struct {
+	t inventory.Feature
+	e export.Exporter
+	l logger.Interface
+}
+
+func NewInventoryRoutes(handler *gin.RouterGroup, t inventory.Feature, e export.Exporter, l logger.Interface) {
+	r := &inventoryRoutes{t, e, l}
+
+	h := handler.Group("/inventory")
+	{
+		h.GET("", r.get)
+		h.GET("bios", r.getBIOSVersions)
+		h.GET("download", r.download)
+		h.GET(":guid", r.getByGUID)
+		h.POST(":guid", r.refresh)
+	}
+}
+
+type InventoryCountResponse struct {
+	Count int                   `json:"totalCount"`
+	Data  []dto.DeviceInventory `json:"data"`
+}
+
+// @Summary     Show Fleet Inventory
+// @Description Show the hardware and firmware inventory of the devices matching the filter
+// @ID          inventory
+// @Tags  	    inventory
+// @Accept      json
+// @Produce     json
+// @Param       amtBelow      query int    false "AMT major version the devices run below"
+// @Param       memoryBelowGB query int    false "GiB of memory the devices have less than"
+// @Param       biosVersion   query string false "BIOS version the devices run"
+// @Success     200 {object} InventoryCountResponse
+// @Failure     500 {object} response
+// @Router      /api/v1/inventory [get]
+func (r *inventoryRoutes) get(c *gin.Context) {
+	var odata OData
+	if err := c.ShouldBindQuery(&odata); err != nil {
+		validationErr := ErrValidationInventory.Wrap("get", "ShouldBindQuery", err)
+		ErrorResponse(c, validationErr)
+
+		return
+	}
+
+	filter, ok := r.bindFilter(c, "get")
+	if !ok {
+		return
+	}
+
+	items, err := r.t.Get(c.Request.Context(), filter, odata.Top, odata.Skip)
+	if err != nil {
+		r.l.Error(err, "http - v1 - getInventory")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	if odata.Count {
+		count, err := r.t.GetCount(c.Request.Context(), filter)
+		if err != nil {
+			r.l.Error(err, "http - v1 - getCount")
+			ErrorResponse(c, err)
+
+			return
+		}
+
+		countResponse := InventoryCountResponse{
+			Count: count,
+			Data:  items,
+		}
+
+		c.JSON(http.StatusOK, countResponse)
+	} else {
+		c.JSON(http.StatusOK, items)
+	}
+}
+
+// @Summary     Show BIOS Version Distribution
+// @Description Show how many devices run each BIOS version, the most common first
+// @ID          inventoryBIOSVersions
+// @Tags  	    inventory
+// @Accept      json
+// @Produce     json
+// @Success     200 {object} []dto.BIOSVersionCount
+// @Failure     500 {object} response
+// @Router      /api/v1/inventory/bios [get]
+func (r *inventoryRoutes) getBIOSVersions(c *gin.Context) {
+	versions, err := r.t.GetBIOSVersions(c.Request.Context(), tenantID(c))
+	if err != nil {
+		r.l.Error(err, "http - v1 - getBIOSVersions")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, versions)
+}
+
+// @Summary     Download Fleet Inventory
+// @Description Download the inventory of the devices matching the filter as CSV, JSON, NDJSON or XLSX
+// @ID          downloadInventory
+// @Tags  	    inventory
+// @Produce     text/csv
+// @Produce     json
+// @Param       format        query string   false "csv (default), json, ndjson or xlsx"
+// @Param       columns       query []string false "columns to export, all by default"
+// @Param       amtBelow      query int      false "AMT major version the devices run below"
+// @Param       memoryBelowGB query int      false "GiB of memory the devices have less than"
+// @Param       biosVersion   query string   false "BIOS version the devices run"
+// @Success     200 {string} string
+// @Failure     400 {object} response
+// @Router      /api/v1/inventory/download [get]
+func (r *inventoryRoutes) download(c *gin.Context) {
+	opts, ok := bindExport(c, "downloadInventory")
+	if !ok {
+		return
+	}
+
+	filter, ok := r.bindFilter(c, "download")
+	if !ok {
+		return
+	}
+
+	out, err := r.e.Inventory(c.Writer, opts)
+	if err != nil {
+		ErrorResponse(c, ErrValidationExport.Wrap("downloadInventory", "Inventory", err))
+
+		return
+	}
+
+	skip := 0
+
+	download(c, r.l, "downloadInventory", "inventory", opts, out, func() ([]dto.DeviceInventory, bool, error) {
+		items, err := r.t.Get(c.Request.Context(), filter, inventoryDownloadPageSize, skip)
+		skip += inventoryDownloadPageSize
+
+		return items, len(items) == inventoryDownloadPageSize, err
+	})
+}
+
+// @Summary     Show Device Inventory
+// @Description Show the hardware and firmware inventory of a device as of its last refresh
+// @ID          getInventory
+// @Tags  	    inventory
+// @Accept      json
+// @Produce     json
+// @Success     200 {object} dto.DeviceInventory
+// @Failure     404 {object} response
+// @Router      /api/v1/inventory/{guid} [get]
+func (r *inventoryRoutes) getByGUID(c *gin.Context) {
+	item, err := r.t.GetByGUID(c.Request.Context(), c.Param("guid"), tenantID(c))
+	if err != nil {
+		r.l.Error(err, "http - v1 - getInventory")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, item)
+}
+
+// @Summary     Refresh Device Inventory
+// @Description Read the hardware and firmware inventory of a device and store it in place of the previous one
+// @ID          refreshInventory
+// @Tags  	    inventory
+// @Accept      json
+// @Produce     json
+// @Success     200 {object} dto.DeviceInventory
+// @Failure     404 {object} response
+// @Router      /api/v1/inventory/{guid} [post]
+func (r *inventoryRoutes) refresh(c *gin.Context) {
+	item, err := r.t.Refresh(c.Request.Context(), c.Param("guid"), tenantID(c))
+	if err != nil {
+		r.l.Error(err, "http - v1 - refreshInventory")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, item)
+}
+
+func (r *inventoryRoutes) bindFilter(c *gin.Context, call string) (dto.InventoryFilter, bool) {
+	var filter dto.InventoryFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		validationErr := ErrValidationInventory.Wrap(call, "ShouldBindQuery", err)
+		ErrorResponse(c, validationErr)
+
+		return filter, false
+	}
+
+	filter.TenantID = tenantID(c)
+
+	return filter, true
+}
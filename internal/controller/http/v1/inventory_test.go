@@ -0,0 +1,202 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	"github.com/open-amt-cloud-toolkit/console/internal/mocks"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/export"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/inventory"
+	"github.com/open-amt-cloud-toolkit/console/pkg/logger"
+)
+
+func inventoryTest(t *testing.T) (*mocks.MockInventoryFeature, *mocks.MockExporter, *gin.Engine) {
+	t.Helper()
+
+	mockCtl := gomock.NewController(t)
+
+	log := logger.New("error")
+	feature := mocks.NewMockInventoryFeature(mockCtl)
+	exporter := mocks.NewMockExporter(mockCtl)
+
+	engine := gin.New()
+	handler := engine.Group("/api/v1")
+
+	NewInventoryRoutes(handler, feature, exporter, log)
+
+	return feature, exporter, engine
+}
+
+var deviceInventory = dto.DeviceInventory{
+	GUID:             "guid1",
+	RefreshedAt:      time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC),
+	Firmware:         dto.FirmwareInventory{AMTVersion: "15.0.45", AMTMajor: 15, SKU: "16392"},
+	BIOS:             dto.BIOSInventory{Manufacturer: "Intel Corp.", Version: "ADLPFWI1"},
+	TotalMemoryBytes: 8 << 30,
+}
+
+func TestInventoryRoutes(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		method       string
+		url          string
+		mock         func(feature *mocks.MockInventoryFeature, exporter *mocks.MockExporter)
+		response     interface{}
+		body         string
+		expectedCode int
+	}{
+		{
+			name:   "get inventory - AMT below 16 with count",
+			method: http.MethodGet,
+			url:    "/api/v1/inventory?amtBelow=16&$count=true",
+			mock: func(feature *mocks.MockInventoryFeature, _ *mocks.MockExporter) {
+				filter := dto.InventoryFilter{AMTBelow: 16}
+				feature.EXPECT().Get(context.Background(), filter, 25, 0).Return([]dto.DeviceInventory{deviceInventory}, nil)
+				feature.EXPECT().GetCount(context.Background(), filter).Return(1, nil)
+			},
+			response:     InventoryCountResponse{Count: 1, Data: []dto.DeviceInventory{deviceInventory}},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:   "get inventory - memory below 16GB",
+			method: http.MethodGet,
+			url:    "/api/v1/inventory?memoryBelowGB=16&$top=10",
+			mock: func(feature *mocks.MockInventoryFeature, _ *mocks.MockExporter) {
+				feature.EXPECT().Get(context.Background(), dto.InventoryFilter{MemoryBelowGB: 16}, 10, 0).Return([]dto.DeviceInventory{deviceInventory}, nil)
+			},
+			response:     []dto.DeviceInventory{deviceInventory},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:   "get BIOS versions",
+			method: http.MethodGet,
+			url:    "/api/v1/inventory/bios",
+			mock: func(feature *mocks.MockInventoryFeature, _ *mocks.MockExporter) {
+				feature.EXPECT().GetBIOSVersions(context.Background(), "").Return([]dto.BIOSVersionCount{{Manufacturer: "Intel Corp.", Version: "ADLPFWI1", Count: 3}}, nil)
+			},
+			response:     []dto.BIOSVersionCount{{Manufacturer: "Intel Corp.", Version: "ADLPFWI1", Count: 3}},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:   "get device inventory",
+			method: http.MethodGet,
+			url:    "/api/v1/inventory/guid1",
+			mock: func(feature *mocks.MockInventoryFeature, _ *mocks.MockExporter) {
+				feature.EXPECT().GetByGUID(context.Background(), "guid1", "").Return(&deviceInventory, nil)
+			},
+			response:     deviceInventory,
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:   "get device inventory - never refreshed",
+			method: http.MethodGet,
+			url:    "/api/v1/inventory/guid2",
+			mock: func(feature *mocks.MockInventoryFeature, _ *mocks.MockExporter) {
+				feature.EXPECT().GetByGUID(context.Background(), "guid2", "").Return(nil, inventory.ErrNotFound)
+			},
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:   "refresh device inventory",
+			method: http.MethodPost,
+			url:    "/api/v1/inventory/guid1",
+			mock: func(feature *mocks.MockInventoryFeature, _ *mocks.MockExporter) {
+				feature.EXPECT().Refresh(context.Background(), "guid1", "").Return(&deviceInventory, nil)
+			},
+			response:     deviceInventory,
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:   "download inventory - csv",
+			method: http.MethodGet,
+			url:    "/api/v1/inventory/download?biosVersion=ADLPFWI1&columns=guid,biosVersion",
+			mock: func(feature *mocks.MockInventoryFeature, exporter *mocks.MockExporter) {
+				exporter.EXPECT().Inventory(gomock.Any(), export.Options{Format: export.FormatCSV, Columns: []string{"guid", "biosVersion"}}).
+					DoAndReturn(export.NewFileExporter().Inventory)
+				feature.EXPECT().Get(context.Background(), dto.InventoryFilter{BIOSVersion: "ADLPFWI1"}, 100, 0).Return([]dto.DeviceInventory{deviceInventory}, nil)
+			},
+			body:         "GUID,BIOS Version\nguid1,ADLPFWI1\n",
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:   "download inventory - json",
+			method: http.MethodGet,
+			url:    "/api/v1/inventory/download?format=json&columns=guid",
+			mock: func(feature *mocks.MockInventoryFeature, exporter *mocks.MockExporter) {
+				exporter.EXPECT().Inventory(gomock.Any(), export.Options{Format: export.FormatJSON, Columns: []string{"guid"}}).
+					DoAndReturn(export.NewFileExporter().Inventory)
+				feature.EXPECT().Get(context.Background(), dto.InventoryFilter{}, 100, 0).Return(nil, nil)
+			},
+			body:         "[]\n",
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:   "download inventory - unknown column",
+			method: http.MethodGet,
+			url:    "/api/v1/inventory/download?columns=guid&columns=serial",
+			mock: func(_ *mocks.MockInventoryFeature, exporter *mocks.MockExporter) {
+				exporter.EXPECT().Inventory(gomock.Any(), gomock.Any()).DoAndReturn(export.NewFileExporter().Inventory)
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "download inventory - unknown format",
+			method:       http.MethodGet,
+			url:          "/api/v1/inventory/download?format=xml",
+			mock:         func(_ *mocks.MockInventoryFeature, _ *mocks.MockExporter) {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:   "download inventory - failure",
+			method: http.MethodGet,
+			url:    "/api/v1/inventory/download?format=xlsx",
+			mock: func(feature *mocks.MockInventoryFeature, exporter *mocks.MockExporter) {
+				exporter.EXPECT().Inventory(gomock.Any(), gomock.Any()).DoAndReturn(export.NewFileExporter().Inventory)
+				feature.EXPECT().Get(context.Background(), dto.InventoryFilter{}, 100, 0).Return(nil, ErrGeneral)
+			},
+			expectedCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			feature, exporter, engine := inventoryTest(t)
+
+			tc.mock(feature, exporter)
+
+			req, err := http.NewRequest(tc.method, tc.url, http.NoBody)
+			if err != nil {
+				t.Fatalf("Couldn't create request: %v\n", err)
+			}
+
+			w := httptest.NewRecorder()
+
+			engine.ServeHTTP(w, req)
+
+			require.Equal(t, tc.expectedCode, w.Code)
+
+			switch {
+			case tc.body != "":
+				require.Equal(t, tc.body, w.Body.String())
+			case tc.expectedCode == http.StatusOK:
+				jsonBytes, _ := json.Marshal(tc.response)
+				require.Equal(t, string(jsonBytes), w.Body.String())
+			}
+		})
+	}
+}
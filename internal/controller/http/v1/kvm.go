@@ -0,0 +1,59 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/devices"
+	"github.com/open-amt-cloud-toolkit/console/pkg/logger"
+)
+
+type kvmRoutes struct {
+	d  devices.Feature
+	th devices.Thumbnails
+	l  logger.Interface
+}
+
+// @Summary     Capture the Screen of a Device
+// @Description Open a short KVM session with the device and return its screen as a PNG
+// @ID          kvmScreenshot
+// @Tags  	    redirection
+// @Produce     png
+// @Success     200 {file} binary
+// @Failure     400 {object} response
+// @Failure     404 {object} response
+// @Router      /api/v1/amt/kvm/screenshot/{guid} [get]
+func (r *kvmRoutes) screenshot(c *gin.Context) {
+	img, err := r.d.Screenshot(c.Request.Context(), c.Param("guid"))
+	if err != nil {
+		r.l.Error(err, "http - v1 - kvmScreenshot")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.Data(http.StatusOK, "image/png", img)
+}
+
+// @Summary     Get the Thumbnail of a Device
+// @Description Return the last screen of the device the console captured in the background as a PNG
+// @ID          kvmThumbnail
+// @Tags  	    redirection
+// @Produce     png
+// @Success     200 {file} binary
+// @Failure     404 {object} response
+// @Router      /api/v1/amt/kvm/thumbnail/{guid} [get]
+func (r *kvmRoutes) thumbnail(c *gin.Context) {
+	thumbnail, err := r.th.GetThumbnail(c.Request.Context(), c.Param("guid"))
+	if err != nil {
+		r.l.Error(err, "http - v1 - kvmThumbnail")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	c.Header("Last-Modified", thumbnail.CapturedAt.UTC().Format(http.TimeFormat))
+	c.Data(http.StatusOK, "image/png", thumbnail.Image)
+}
@@ -0,0 +1,115 @@
+package v1
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	"github.com/open-amt-cloud-toolkit/console/internal/mocks"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/devices"
+	"github.com/open-amt-cloud-toolkit/console/pkg/logger"
+)
+
+func kvmTest(t *testing.T) (*mocks.MockDeviceManagementFeature, *mocks.MockThumbnails, *gin.Engine) {
+	t.Helper()
+
+	mockCtl := gomock.NewController(t)
+
+	deviceManagement := mocks.NewMockDeviceManagementFeature(mockCtl)
+	thumbnails := mocks.NewMockThumbnails(mockCtl)
+	engine := gin.New()
+
+	NewRedirectionRoutes(engine.Group("/api/v1"), deviceManagement, mocks.NewMockImagesFeature(mockCtl), mocks.NewMockVNC(mockCtl), thumbnails, logger.New("error"))
+
+	return deviceManagement, thumbnails, engine
+}
+
+func TestKVMRoutes(t *testing.T) {
+	t.Parallel()
+
+	png := []byte{0x89, 'P', 'N', 'G'}
+
+	tests := []struct {
+		name         string
+		url          string
+		mock         func(d *mocks.MockDeviceManagementFeature, th *mocks.MockThumbnails)
+		expectedCode int
+		response     []byte
+		lastModified string
+	}{
+		{
+			name: "screenshot - successful",
+			url:  "/api/v1/amt/kvm/screenshot/valid-guid",
+			mock: func(d *mocks.MockDeviceManagementFeature, _ *mocks.MockThumbnails) {
+				d.EXPECT().Screenshot(context.Background(), "valid-guid").Return(png, nil)
+			},
+			expectedCode: http.StatusOK,
+			response:     png,
+		},
+		{
+			name: "screenshot - unknown device",
+			url:  "/api/v1/amt/kvm/screenshot/unknown-guid",
+			mock: func(d *mocks.MockDeviceManagementFeature, _ *mocks.MockThumbnails) {
+				d.EXPECT().Screenshot(context.Background(), "unknown-guid").Return(nil, devices.ErrNotFound)
+			},
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name: "thumbnail - successful",
+			url:  "/api/v1/amt/kvm/thumbnail/valid-guid",
+			mock: func(_ *mocks.MockDeviceManagementFeature, th *mocks.MockThumbnails) {
+				th.EXPECT().GetThumbnail(context.Background(), "valid-guid").Return(dto.DeviceThumbnail{
+					GUID:       "valid-guid",
+					CapturedAt: time.Date(2024, 12, 1, 0, 15, 0, 0, time.UTC),
+					Image:      png,
+				}, nil)
+			},
+			expectedCode: http.StatusOK,
+			response:     png,
+			lastModified: "Sun, 01 Dec 2024 00:15:00 GMT",
+		},
+		{
+			name: "thumbnail - not captured yet",
+			url:  "/api/v1/amt/kvm/thumbnail/valid-guid",
+			mock: func(_ *mocks.MockDeviceManagementFeature, th *mocks.MockThumbnails) {
+				th.EXPECT().GetThumbnail(context.Background(), "valid-guid").Return(dto.DeviceThumbnail{}, devices.ErrNotFound)
+			},
+			expectedCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			deviceManagement, thumbnails, engine := kvmTest(t)
+
+			tc.mock(deviceManagement, thumbnails)
+
+			req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, tc.url, http.NoBody)
+			require.NoError(t, err)
+
+			w := httptest.NewRecorder()
+
+			engine.ServeHTTP(w, req)
+
+			require.Equal(t, tc.expectedCode, w.Code)
+
+			if tc.response != nil {
+				require.Equal(t, "image/png", w.Header().Get("Content-Type"))
+				require.Equal(t, tc.response, w.Body.Bytes())
+			}
+
+			require.Equal(t, tc.lastModified, w.Header().Get("Last-Modified"))
+		})
+	}
+}
@@ -2,6 +2,8 @@ package v1
 
 import (
 	"context"
+	"errors"
+	"net"
 	"net/http"
 	"strings"
 	"time"
@@ -12,7 +14,10 @@ import (
 
 	"github.com/open-amt-cloud-toolkit/console/config"
 	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/roles"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/users"
 	"github.com/open-amt-cloud-toolkit/console/pkg/consoleerrors"
+	"github.com/open-amt-cloud-toolkit/console/pkg/tenant"
 )
 
 var ErrLogin = consoleerrors.CreateConsoleError("LoginHandler")
@@ -20,22 +25,28 @@ var ErrLogin = consoleerrors.CreateConsoleError("LoginHandler")
 type LoginRoute struct {
 	Config   *config.Config
 	Verifier *oidc.IDTokenVerifier
+	Roles    roles.Feature
+	Users    users.Feature
+	Proxies  tenant.Proxies
 }
 
 // NewVersionRoute creates a new version route
-func NewLoginRoute(configData *config.Config) *LoginRoute {
+func NewLoginRoute(configData *config.Config, r roles.Feature, u users.Feature) *LoginRoute {
 	lr := &LoginRoute{
-		Config: configData,
+		Config:  configData,
+		Roles:   r,
+		Users:   u,
+		Proxies: tenant.ParseProxies(configData.TrustedProxies),
 	}
 
-	if config.ConsoleConfig.ClientID != "" {
-		provider, err := oidc.NewProvider(context.Background(), config.ConsoleConfig.Issuer)
+	if configData.ClientID != "" {
+		provider, err := oidc.NewProvider(context.Background(), configData.Issuer)
 		if err != nil {
 			return nil
 		}
 
 		lr.Verifier = provider.Verifier(&oidc.Config{
-			ClientID: config.ConsoleConfig.ClientID,
+			ClientID: configData.ClientID,
 		})
 	}
 
@@ -56,16 +67,31 @@ func (lr LoginRoute) Login(c *gin.Context) {
 }
 
 func (lr LoginRoute) handleBasicAuth(creds dto.Credentials, c *gin.Context) {
-	if creds.Username != lr.Config.AdminUsername || creds.Password != lr.Config.AdminPassword {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid credentials"})
+	tenantID := lr.requestTenant(c, creds.TenantID)
+
+	_, err := lr.Users.Authenticate(c.Request.Context(), creds.Username, creds.Password, tenantID)
+	if err != nil {
+		credentialErrorResponse(c, err)
+
+		return
+	}
+
+	granted, err := lr.Roles.Resolve(c.Request.Context(), []string{creds.Username}, nil, tenantID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "could not resolve roles"})
 
 		return
 	}
 
 	// Create JWT token
-	expirationTime := time.Now().Add(config.ConsoleConfig.JWTExpiration)
-	claims := jwt.RegisteredClaims{
-		ExpiresAt: jwt.NewNumericDate(expirationTime),
+	expirationTime := time.Now().Add(lr.Config.JWTExpiration)
+	claims := AuthClaims{
+		Roles:    granted,
+		TenantID: tenantID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   creds.Username,
+			ExpiresAt: jwt.NewNumericDate(expirationTime),
+		},
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
@@ -80,6 +106,39 @@ func (lr LoginRoute) handleBasicAuth(creds dto.Credentials, c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"token": tokenString})
 }
 
+// ChangePassword lets a user who knows their current password set a new one, this is also how a
+// forced password change is completed before the first login
+func (lr LoginRoute) ChangePassword(c *gin.Context) {
+	var change dto.PasswordChange
+
+	if err := c.ShouldBindJSON(&change); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+
+		return
+	}
+
+	if err := lr.Users.ChangePassword(c.Request.Context(), change, lr.requestTenant(c, change.TenantID)); err != nil {
+		credentialErrorResponse(c, err)
+
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func credentialErrorResponse(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, users.ErrInvalidCredentials):
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid credentials"})
+	case errors.Is(err, users.ErrAccountLocked):
+		c.JSON(http.StatusForbidden, gin.H{"error": "account locked"})
+	case errors.Is(err, users.ErrPasswordChangeRequired):
+		c.JSON(http.StatusForbidden, gin.H{"error": "password change required"})
+	default:
+		ErrorResponse(c, err)
+	}
+}
+
 // JWT Middleware
 func (lr LoginRoute) JWTAuthMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -94,14 +153,34 @@ func (lr LoginRoute) JWTAuthMiddleware() gin.HandlerFunc {
 		}
 
 		// if clientID is set, use the oidc verifier
-		if config.ConsoleConfig.ClientID != "" {
-			_, err := lr.Verifier.Verify(c.Request.Context(), tokenString)
+		if lr.Config.ClientID != "" {
+			subjects, groups, claimedTenant, err := lr.verifyOIDC(c, tokenString)
 			if err != nil {
 				c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid access token"})
 				c.Abort()
+
+				return
+			}
+
+			tenantID, ok := lr.tokenTenant(c, claimedTenant)
+			if !ok {
+				return
 			}
+
+			granted, err := lr.Roles.Resolve(c.Request.Context(), subjects, groups, tenantID)
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "could not resolve roles"})
+				c.Abort()
+
+				return
+			}
+
+			c.Set(rolesContextKey, granted)
+			// the email, when the token has one, reads better in the audit trail than the opaque subject
+			setSubject(c, subjects[len(subjects)-1])
+			setTenant(c, tenantID)
 		} else {
-			claims := &jwt.MapClaims{}
+			claims := &AuthClaims{}
 
 			token, err := jwt.ParseWithClaims(tokenString, claims, func(_ *jwt.Token) (interface{}, error) {
 				return []byte(lr.Config.Auth.JWTKey), nil
@@ -113,8 +192,81 @@ func (lr LoginRoute) JWTAuthMiddleware() gin.HandlerFunc {
 
 				return
 			}
+
+			tenantID, ok := lr.tokenTenant(c, claims.TenantID)
+			if !ok {
+				return
+			}
+
+			c.Set(rolesContextKey, claims.Roles)
+			setSubject(c, claims.Subject)
+			setTenant(c, tenantID)
 		}
 
 		c.Next()
 	}
 }
+
+// requestTenant returns the tenant a request is made for, the X-Tenant-Id header of a trusted proxy takes
+// precedence over the tenant claimed by the caller.
+func (lr LoginRoute) requestTenant(c *gin.Context, claimed string) string {
+	return lr.Proxies.FromRequest(c.Request, net.ParseIP(c.RemoteIP()), claimed)
+}
+
+// tokenTenant returns the tenant a request authenticated by a token is made for. The X-Tenant-Id header of a
+// trusted proxy only names the tenant of a token that has none, a request whose header names another tenant
+// than its token is forbidden.
+func (lr LoginRoute) tokenTenant(c *gin.Context, claimed string) (string, bool) {
+	tenantID, err := lr.Proxies.FromToken(c.Request, net.ParseIP(c.RemoteIP()), claimed)
+	if err != nil {
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		c.Abort()
+
+		return "", false
+	}
+
+	return tenantID, true
+}
+
+// verifyOIDC verifies an OIDC token and returns the names its caller is known by, the groups it belongs to
+// and its tenant.
+func (lr LoginRoute) verifyOIDC(c *gin.Context, tokenString string) (subjects, groups []string, tenantID string, err error) {
+	idToken, err := lr.Verifier.Verify(c.Request.Context(), tokenString)
+	if err != nil {
+		return nil, nil, "", err
+	}
+
+	claims := map[string]interface{}{}
+	if err := idToken.Claims(&claims); err != nil {
+		return nil, nil, "", err
+	}
+
+	tenantID, _ = claims[lr.Config.TenantClaim].(string)
+
+	subjects = []string{idToken.Subject}
+	if email, ok := claims["email"].(string); ok && email != "" {
+		subjects = append(subjects, email)
+	}
+
+	return subjects, claimValues(claims[lr.Config.RolesClaim]), tenantID, nil
+}
+
+// claimValues reads a claim that holds either a single string or a list of strings.
+func claimValues(claim interface{}) []string {
+	switch v := claim.(type) {
+	case string:
+		return []string{v}
+	case []interface{}:
+		values := make([]string, 0, len(v))
+
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				values = append(values, s)
+			}
+		}
+
+		return values
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,267 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/open-amt-cloud-toolkit/console/config"
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	"github.com/open-amt-cloud-toolkit/console/internal/mocks"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/users"
+	"github.com/open-amt-cloud-toolkit/console/pkg/tenant"
+)
+
+func loginTest(t *testing.T) (*mocks.MockUsersFeature, *mocks.MockRolesFeature, *gin.Engine) {
+	t.Helper()
+
+	mockCtl := gomock.NewController(t)
+
+	user := mocks.NewMockUsersFeature(mockCtl)
+	role := mocks.NewMockRolesFeature(mockCtl)
+
+	lr := NewLoginRoute(&config.Config{Auth: config.Auth{JWTKey: "test key", JWTExpiration: time.Hour}}, role, user)
+
+	engine := gin.New()
+	engine.POST("/api/v1/authorize", lr.Login)
+	engine.POST("/api/v1/authorize/password", lr.ChangePassword)
+	engine.GET("/api/v1/roles", lr.JWTAuthMiddleware(), func(c *gin.Context) {
+		c.JSON(http.StatusOK, callerRoles(c))
+	})
+
+	return user, role, engine
+}
+
+func TestLogin(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		mock         func(user *mocks.MockUsersFeature, role *mocks.MockRolesFeature)
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name: "valid credentials",
+			mock: func(user *mocks.MockUsersFeature, role *mocks.MockRolesFeature) {
+				user.EXPECT().Authenticate(context.Background(), "alice", "secret123", "").Return(&dto.User{Username: "alice"}, nil)
+				role.EXPECT().Resolve(context.Background(), []string{"alice"}, nil, "").Return([]string{dto.RoleOperator}, nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "invalid credentials",
+			mock: func(user *mocks.MockUsersFeature, _ *mocks.MockRolesFeature) {
+				user.EXPECT().Authenticate(context.Background(), "alice", "secret123", "").Return(nil, users.ErrInvalidCredentials)
+			},
+			expectedCode: http.StatusUnauthorized,
+			expectedBody: `{"error":"invalid credentials"}`,
+		},
+		{
+			name: "locked account",
+			mock: func(user *mocks.MockUsersFeature, _ *mocks.MockRolesFeature) {
+				user.EXPECT().Authenticate(context.Background(), "alice", "secret123", "").Return(nil, users.ErrAccountLocked)
+			},
+			expectedCode: http.StatusForbidden,
+			expectedBody: `{"error":"account locked"}`,
+		},
+		{
+			name: "password change required",
+			mock: func(user *mocks.MockUsersFeature, _ *mocks.MockRolesFeature) {
+				user.EXPECT().Authenticate(context.Background(), "alice", "secret123", "").Return(&dto.User{Username: "alice", MustChangePassword: &mustChangePassword}, users.ErrPasswordChangeRequired)
+			},
+			expectedCode: http.StatusForbidden,
+			expectedBody: `{"error":"password change required"}`,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			user, role, engine := loginTest(t)
+
+			tc.mock(user, role)
+
+			reqBody, _ := json.Marshal(dto.Credentials{Username: "alice", Password: "secret123"})
+			req, err := http.NewRequest(http.MethodPost, "/api/v1/authorize", bytes.NewBuffer(reqBody))
+			require.NoError(t, err)
+
+			w := httptest.NewRecorder()
+
+			engine.ServeHTTP(w, req)
+
+			require.Equal(t, tc.expectedCode, w.Code)
+
+			if tc.expectedBody != "" {
+				require.Equal(t, tc.expectedBody, w.Body.String())
+
+				return
+			}
+
+			// the issued token carries the roles through the middleware
+			var token struct {
+				Token string `json:"token"`
+			}
+
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &token))
+
+			req, err = http.NewRequest(http.MethodGet, "/api/v1/roles", http.NoBody)
+			require.NoError(t, err)
+			req.Header.Set("Authorization", "Bearer "+token.Token)
+
+			w = httptest.NewRecorder()
+
+			engine.ServeHTTP(w, req)
+
+			require.Equal(t, http.StatusOK, w.Code)
+			require.Equal(t, `["operator"]`, w.Body.String())
+		})
+	}
+}
+
+func TestJWTAuthMiddleware(t *testing.T) {
+	t.Parallel()
+
+	_, _, engine := loginTest(t)
+
+	sign := func(key string) string {
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, AuthClaims{
+			Roles:            []string{dto.RoleAdmin},
+			RegisteredClaims: jwt.RegisteredClaims{ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute))},
+		})
+
+		tokenString, err := token.SignedString([]byte(key))
+		require.NoError(t, err)
+
+		return tokenString
+	}
+
+	for header, expectedCode := range map[string]int{
+		"":                              http.StatusUnauthorized,
+		"Bearer " + sign("another key"): http.StatusUnauthorized,
+		"Bearer " + sign("test key"):    http.StatusOK,
+	} {
+		req, err := http.NewRequest(http.MethodGet, "/api/v1/roles", http.NoBody)
+		require.NoError(t, err)
+		req.Header.Set("Authorization", header)
+
+		w := httptest.NewRecorder()
+
+		engine.ServeHTTP(w, req)
+
+		require.Equal(t, expectedCode, w.Code)
+	}
+}
+
+func TestChangePassword(t *testing.T) {
+	t.Parallel()
+
+	change := dto.PasswordChange{Username: "alice", Password: "G@ppm0ym", NewPassword: "Str0ngP@ssword"}
+
+	tests := []struct {
+		name         string
+		err          error
+		expectedCode int
+	}{
+		{name: "password changed", expectedCode: http.StatusNoContent},
+		{name: "wrong current password", err: users.ErrInvalidCredentials, expectedCode: http.StatusUnauthorized},
+		{name: "new password not valid", err: users.ErrNotValid, expectedCode: http.StatusBadRequest},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			user, _, engine := loginTest(t)
+
+			user.EXPECT().ChangePassword(context.Background(), change, "").Return(tc.err)
+
+			reqBody, _ := json.Marshal(change)
+			req, err := http.NewRequest(http.MethodPost, "/api/v1/authorize/password", bytes.NewBuffer(reqBody))
+			require.NoError(t, err)
+
+			w := httptest.NewRecorder()
+
+			engine.ServeHTTP(w, req)
+
+			require.Equal(t, tc.expectedCode, w.Code)
+		})
+	}
+}
+
+func TestJWTAuthMiddlewareTenant(t *testing.T) {
+	t.Parallel()
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, AuthClaims{
+		Roles:            []string{dto.RoleAdmin},
+		TenantID:         "acme",
+		RegisteredClaims: jwt.RegisteredClaims{Subject: "alice", ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute))},
+	})
+
+	tokenString, err := token.SignedString([]byte("test key"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name           string
+		trustedProxies []string
+		header         string
+		expectedCode   int
+		expected       string
+	}{
+		{name: "tenant from token", expectedCode: http.StatusOK, expected: "acme"},
+		{name: "header from an untrusted address is ignored", header: "globex", expectedCode: http.StatusOK, expected: "acme"},
+		{name: "header from a trusted proxy agrees with the token", trustedProxies: []string{"192.0.2.0/24"}, header: "acme", expectedCode: http.StatusOK, expected: "acme"},
+		{name: "header from a trusted proxy cannot override the token", trustedProxies: []string{"192.0.2.0/24"}, header: "globex", expectedCode: http.StatusForbidden},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			lr := NewLoginRoute(&config.Config{Auth: config.Auth{JWTKey: "test key", TrustedProxies: tc.trustedProxies}}, nil, nil)
+
+			engine := gin.New()
+			engine.GET("/api/v1/tenant", lr.JWTAuthMiddleware(), func(c *gin.Context) {
+				// the tenant reaches the usecases through the request context
+				require.Equal(t, tenantID(c), tenant.FromContext(c.Request.Context()))
+				require.Equal(t, "alice", callerSubject(c))
+
+				c.String(http.StatusOK, tenantID(c))
+			})
+
+			// httptest requests come from 192.0.2.1
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/tenant", http.NoBody)
+			req.Header.Set("Authorization", "Bearer "+tokenString)
+
+			if tc.header != "" {
+				req.Header.Set(tenant.Header, tc.header)
+			}
+
+			w := httptest.NewRecorder()
+
+			engine.ServeHTTP(w, req)
+
+			require.Equal(t, tc.expectedCode, w.Code)
+
+			if tc.expectedCode == http.StatusOK {
+				require.Equal(t, tc.expected, w.Body.String())
+			}
+		})
+	}
+}
@@ -64,7 +64,7 @@ func (r *profileRoutes) get(c *gin.Context) {
 		return
 	}
 
-	items, err := r.t.Get(c.Request.Context(), odata.Top, odata.Skip, "")
+	items, err := r.t.Get(c.Request.Context(), odata.Top, odata.Skip, tenantID(c))
 	if err != nil {
 		r.l.Error(err, "http - v1 - get")
 		ErrorResponse(c, err)
@@ -73,7 +73,7 @@ func (r *profileRoutes) get(c *gin.Context) {
 	}
 
 	if odata.Count {
-		count, err := r.t.GetCount(c.Request.Context(), "")
+		count, err := r.t.GetCount(c.Request.Context(), tenantID(c))
 		if err != nil {
 			r.l.Error(err, "http - v1 - getCount")
 			ErrorResponse(c, err)
@@ -103,7 +103,7 @@ func (r *profileRoutes) get(c *gin.Context) {
 func (r *profileRoutes) getByName(c *gin.Context) {
 	name := c.Param("name")
 
-	item, err := r.t.GetByName(c.Request.Context(), name, "")
+	item, err := r.t.GetByName(c.Request.Context(), name, tenantID(c))
 	if err != nil {
 		r.l.Error(err, "http - v1 - getByName")
 		ErrorResponse(c, err)
@@ -128,7 +128,7 @@ func (r *profileRoutes) export(c *gin.Context) {
 	name := c.Param("name")
 	domainName := c.Query("domainName")
 
-	item, key, err := r.t.Export(c.Request.Context(), name, domainName, "")
+	item, key, err := r.t.Export(c.Request.Context(), name, domainName, tenantID(c))
 	if err != nil {
 		r.l.Error(err, "http - v1 - export")
 		ErrorResponse(c, err)
@@ -165,6 +165,8 @@ func (r *profileRoutes) insert(c *gin.Context) {
 		return
 	}
 
+	profile.TenantID = tenantID(c)
+
 	newProfile, err := r.t.Insert(c.Request.Context(), &profile)
 	if err != nil {
 		r.l.Error(err, "http - v1 - insert")
@@ -195,6 +197,8 @@ func (r *profileRoutes) update(c *gin.Context) {
 		return
 	}
 
+	profile.TenantID = tenantID(c)
+
 	updatedProfile, err := r.t.Update(c.Request.Context(), &profile)
 	if err != nil {
 		r.l.Error(err, "http - v1 - update")
@@ -219,7 +223,7 @@ func (r *profileRoutes) update(c *gin.Context) {
 func (r *profileRoutes) delete(c *gin.Context) {
 	name := c.Param("name")
 
-	err := r.t.Delete(c.Request.Context(), name, "")
+	err := r.t.Delete(c.Request.Context(), name, tenantID(c))
 	if err != nil {
 		r.l.Error(err, "http - v1 - delete")
 		ErrorResponse(c, err)
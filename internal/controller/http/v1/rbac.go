@@ -0,0 +1,105 @@
+package v1
+
+import (
+	"net"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/roles"
+	"github.com/open-amt-cloud-toolkit/console/pkg/subject"
+	"github.com/open-amt-cloud-toolkit/console/pkg/tenant"
+)
+
+const (
+	rolesContextKey   = "roles"
+	tenantContextKey  = "tenantID"
+	subjectContextKey = "subject"
+)
+
+// AuthClaims are the claims of a token issued by the console.
+type AuthClaims struct {
+	Roles    []string `json:"roles,omitempty"`
+	TenantID string   `json:"tenant_id,omitempty"`
+	jwt.RegisteredClaims
+}
+
+// GrantRoles gives every request the given roles, it is used in place of authentication when that is disabled.
+func GrantRoles(granted ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set(rolesContextKey, granted)
+		c.Next()
+	}
+}
+
+// TrustedTenant takes the tenant of every request from the X-Tenant-Id header of trusted proxies, it is used
+// in place of authentication when that is disabled.
+func TrustedTenant(proxies tenant.Proxies) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		setTenant(c, proxies.FromRequest(c.Request, net.ParseIP(c.RemoteIP()), tenant.Default))
+		c.Next()
+	}
+}
+
+// RequirePermission rejects requests whose roles do not grant permission.
+func RequirePermission(permission string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !roles.HasPermission(callerRoles(c), permission) {
+			forbidden(c, permission)
+
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// RequireMethodPermission requires read for GET, HEAD and OPTIONS requests and write for everything else.
+func RequireMethodPermission(read, write string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		permission := write
+
+		switch c.Request.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			permission = read
+		}
+
+		if !roles.HasPermission(callerRoles(c), permission) {
+			forbidden(c, permission)
+
+			return
+		}
+
+		c.Next()
+	}
+}
+
+func callerRoles(c *gin.Context) []string {
+	return c.GetStringSlice(rolesContextKey)
+}
+
+// setTenant scopes the rest of the request to tenantID, the usecases read it back from the request context.
+func setTenant(c *gin.Context, tenantID string) {
+	c.Set(tenantContextKey, tenantID)
+	c.Request = c.Request.WithContext(tenant.NewContext(c.Request.Context(), tenantID))
+}
+
+func tenantID(c *gin.Context) string {
+	return c.GetString(tenantContextKey)
+}
+
+// setSubject records who the authenticated caller is, for the audit trail and the usecases that attribute
+// their work to the caller.
+func setSubject(c *gin.Context, caller string) {
+	c.Set(subjectContextKey, caller)
+	c.Request = c.Request.WithContext(subject.NewContext(c.Request.Context(), caller))
+}
+
+func callerSubject(c *gin.Context) string {
+	return c.GetString(subjectContextKey)
+}
+
+func forbidden(c *gin.Context, permission string) {
+	c.AbortWithStatusJSON(http.StatusForbidden, response{"missing permission: " + permission})
+}
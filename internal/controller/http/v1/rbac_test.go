@@ -0,0 +1,134 @@
+package v1
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+)
+
+func TestRequirePermission(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		roles        []string
+		method       string
+		url          string
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name:         "viewer reads devices",
+			roles:        []string{dto.RoleViewer},
+			method:       http.MethodGet,
+			url:          "/api/v1/amt/power/state/guid-1",
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "viewer cannot send power actions",
+			roles:        []string{dto.RoleViewer},
+			method:       http.MethodPost,
+			url:          "/api/v1/amt/power/action/guid-1",
+			expectedCode: http.StatusForbidden,
+			expectedBody: `{"error":"missing permission: devices:operate"}`,
+		},
+		{
+			name:         "operator sends power actions",
+			roles:        []string{dto.RoleOperator},
+			method:       http.MethodPost,
+			url:          "/api/v1/amt/power/action/guid-1",
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "operator cannot manage the console",
+			roles:        []string{dto.RoleOperator},
+			method:       http.MethodGet,
+			url:          "/api/v1/admin/domains",
+			expectedCode: http.StatusForbidden,
+			expectedBody: `{"error":"missing permission: admin"}`,
+		},
+		{
+			name:         "admin manages the console",
+			roles:        []string{dto.RoleAdmin},
+			method:       http.MethodGet,
+			url:          "/api/v1/admin/domains",
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "redirection role gets a redirection token",
+			roles:        []string{dto.RoleRedirection},
+			method:       http.MethodGet,
+			url:          "/api/v1/authorize/redirection/guid-1",
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "redirection role cannot read devices",
+			roles:        []string{dto.RoleRedirection},
+			method:       http.MethodGet,
+			url:          "/api/v1/amt/power/state/guid-1",
+			expectedCode: http.StatusForbidden,
+			expectedBody: `{"error":"missing permission: devices:read"}`,
+		},
+		{
+			name:         "viewer cannot get a redirection token",
+			roles:        []string{dto.RoleViewer},
+			method:       http.MethodGet,
+			url:          "/api/v1/authorize/redirection/guid-1",
+			expectedCode: http.StatusForbidden,
+			expectedBody: `{"error":"missing permission: redirection"}`,
+		},
+		{
+			name:         "no roles",
+			method:       http.MethodGet,
+			url:          "/api/v1/amt/power/state/guid-1",
+			expectedCode: http.StatusForbidden,
+			expectedBody: `{"error":"missing permission: devices:read"}`,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+
+			engine := gin.New()
+			protected := engine.Group("/api", GrantRoles(tc.roles...))
+			protected.Group("/v1", RequireMethodPermission(dto.PermissionRead, dto.PermissionOperate)).
+				GET("amt/power/state/:guid", ok).
+				POST("amt/power/action/:guid", ok)
+			protected.Group("/v1", RequirePermission(dto.PermissionRedirect)).
+				GET("authorize/redirection/:id", ok)
+			protected.Group("/v1/admin", RequirePermission(dto.PermissionAdmin)).
+				GET("domains", ok)
+
+			req, err := http.NewRequest(tc.method, tc.url, http.NoBody)
+			require.NoError(t, err)
+
+			w := httptest.NewRecorder()
+
+			engine.ServeHTTP(w, req)
+
+			require.Equal(t, tc.expectedCode, w.Code)
+
+			if tc.expectedBody != "" {
+				require.Equal(t, tc.expectedBody, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestClaimValues(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, []string{"amt-admins"}, claimValues("amt-admins"))
+	require.Equal(t, []string{"amt-admins", "helpdesk"}, claimValues([]interface{}{"amt-admins", 1, "helpdesk"}))
+	require.Nil(t, claimValues(nil))
+}
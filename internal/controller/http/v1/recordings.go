@@ -0,0 +1,212 @@
+package v1
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/recordings"
+	"github.com/open-amt-cloud-toolkit/console/pkg/consoleerrors"
+	"github.com/open-amt-cloud-toolkit/console/pkg/logger"
+)
+
+var ErrValidationRecordings = dto.NotValidError{Console: consoleerrors.CreateConsoleError("RecordingsAPI")}
+
+type recordingRoutes struct {
+	t recordings.Feature
+	l logger.Interface
+}
+
+func NewRecordingRoutes(handler *gin.RouterGroup, t recordings.Feature, l logger.Interface) {
+	r := &recordingRoutes{t, l}
+
+	h := handler.Group("/recordings")
+	{
+		h.GET("", r.get)
+		h.GET(":id", r.getByID)
+		h.GET(":id/download", r.download)
+		h.GET(":id/frames", r.getFrames)
+		h.GET(":id/transcript", r.transcript)
+		h.DELETE(":id", r.delete)
+	}
+}
+
+type RecordingCountResponse struct {
+	Count int                    `json:"totalCount"`
+	Data  []dto.SessionRecording `json:"data"`
+}
+
+// @Summary     Show Session Recordings
+// @Description Show the recorded KVM and SOL redirection sessions, newest first
+// @ID          recordings
+// @Tags  	    recordings
+// @Accept      json
+// @Produce     json
+// @Success     200 {object} RecordingCountResponse
+// @Failure     500 {object} response
+// @Router      /api/v1/admin/recordings [get]
+func (r *recordingRoutes) get(c *gin.Context) {
+	var odata OData
+	if err := c.ShouldBindQuery(&odata); err != nil {
+		validationErr := ErrValidationRecordings.Wrap("get", "ShouldBindQuery", err)
+		ErrorResponse(c, validationErr)
+
+		return
+	}
+
+	items, err := r.t.Get(c.Request.Context(), odata.Top, odata.Skip, tenantID(c))
+	if err != nil {
+		r.l.Error(err, "http - v1 - getRecordings")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	if odata.Count {
+		count, err := r.t.GetCount(c.Request.Context(), tenantID(c))
+		if err != nil {
+			r.l.Error(err, "http - v1 - getCount")
+			ErrorResponse(c, err)
+
+			return
+		}
+
+		countResponse := RecordingCountResponse{
+			Count: count,
+			Data:  items,
+		}
+
+		c.JSON(http.StatusOK, countResponse)
+	} else {
+		c.JSON(http.StatusOK, items)
+	}
+}
+
+// @Summary     Show Session Recording
+// @Description Show a session recording by id
+// @ID          getRecording
+// @Tags  	    recordings
+// @Accept      json
+// @Produce     json
+// @Success     200 {object} dto.SessionRecording
+// @Failure     500 {object} response
+// @Router      /api/v1/admin/recordings/:id [get]
+func (r *recordingRoutes) getByID(c *gin.Context) {
+	item, err := r.t.GetByID(c.Request.Context(), c.Param("id"), tenantID(c))
+	if err != nil {
+		r.l.Error(err, "http - v1 - getRecording")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, item)
+}
+
+// @Summary     Download Session Recording
+// @Description Download the recording file: a header describing the session followed by timestamped frames
+// @ID          downloadRecording
+// @Tags  	    recordings
+// @Produce     application/octet-stream
+// @Success     200 {file} file
+// @Failure     500 {object} response
+// @Router      /api/v1/admin/recordings/:id/download [get]
+func (r *recordingRoutes) download(c *gin.Context) {
+	id := c.Param("id")
+
+	file, err := r.t.Download(c.Request.Context(), id, tenantID(c))
+	if err != nil {
+		r.l.Error(err, "http - v1 - downloadRecording")
+		ErrorResponse(c, err)
+
+		return
+	}
+	defer file.Close()
+
+	c.Header("Content-Disposition", "attachment; filename="+id+".rec")
+	c.Header("Content-Type", "application/octet-stream")
+
+	_, err = io.Copy(c.Writer, file)
+	if err != nil {
+		r.l.Error(err, "http - v1 - downloadRecording")
+		ErrorResponse(c, err)
+	}
+}
+
+// @Summary     Replay Session Recording
+// @Description Show the frames of a recording in order with their offset from the start of the session, for a player to replay them
+// @ID          getRecordingFrames
+// @Tags  	    recordings
+// @Accept      json
+// @Produce     json
+// @Param       $top  query int false "number of frames"
+// @Param       $skip query int false "frames to skip"
+// @Success     200 {object} []dto.RecordingFrame
+// @Failure     500 {object} response
+// @Router      /api/v1/admin/recordings/:id/frames [get]
+func (r *recordingRoutes) getFrames(c *gin.Context) {
+	var odata OData
+	if err := c.ShouldBindQuery(&odata); err != nil {
+		validationErr := ErrValidationRecordings.Wrap("getFrames", "ShouldBindQuery", err)
+		ErrorResponse(c, validationErr)
+
+		return
+	}
+
+	frames, err := r.t.GetFrames(c.Request.Context(), c.Param("id"), tenantID(c), odata.Top, odata.Skip)
+	if err != nil {
+		r.l.Error(err, "http - v1 - getRecordingFrames")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, frames)
+}
+
+// @Summary     Export SOL Transcript
+// @Description Export what the console of a recorded SOL session showed as plain text
+// @ID          getRecordingTranscript
+// @Tags  	    recordings
+// @Produce     plain
+// @Success     200 {string} string
+// @Failure     400 {object} response
+// @Failure     500 {object} response
+// @Router      /api/v1/admin/recordings/:id/transcript [get]
+func (r *recordingRoutes) transcript(c *gin.Context) {
+	id := c.Param("id")
+
+	text, err := r.t.Transcript(c.Request.Context(), id, tenantID(c))
+	if err != nil {
+		r.l.Error(err, "http - v1 - getRecordingTranscript")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	c.Header("Content-Disposition", "attachment; filename="+id+".txt")
+	c.String(http.StatusOK, text)
+}
+
+// @Summary     Remove Session Recording
+// @Description Remove a finished recording and its file
+// @ID          deleteRecording
+// @Tags  	    recordings
+// @Accept      json
+// @Produce     json
+// @Success     204 {object} nil
+// @Failure     500 {object} response
+// @Router      /api/v1/admin/recordings/:id [delete]
+func (r *recordingRoutes) delete(c *gin.Context) {
+	err := r.t.Delete(c.Request.Context(), c.Param("id"), tenantID(c))
+	if err != nil {
+		r.l.Error(err, "http - v1 - deleteRecording")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
@@ -0,0 +1,161 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	"github.com/open-amt-cloud-toolkit/console/internal/mocks"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/recordings"
+	"github.com/open-amt-cloud-toolkit/console/pkg/logger"
+)
+
+func recordingsTest(t *testing.T) (*mocks.MockRecordingsFeature, *gin.Engine) {
+	t.Helper()
+
+	mockCtl := gomock.NewController(t)
+
+	log := logger.New("error")
+	recording := mocks.NewMockRecordingsFeature(mockCtl)
+
+	engine := gin.New()
+	handler := engine.Group("/api/v1/admin", func(c *gin.Context) { setTenant(c, "tenant1") })
+
+	NewRecordingRoutes(handler, recording, log)
+
+	return recording, engine
+}
+
+var solRecording = dto.SessionRecording{
+	ID:        "rec-1",
+	GUID:      "guid-1",
+	Mode:      "sol",
+	Subject:   "alice",
+	SizeBytes: 2048,
+	StartTime: time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC),
+	TenantID:  "tenant1",
+}
+
+func TestRecordingRoutes(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		method       string
+		url          string
+		mock         func(recording *mocks.MockRecordingsFeature)
+		response     interface{}
+		body         string
+		expectedCode int
+	}{
+		{
+			name:   "get all recordings - with count",
+			method: http.MethodGet,
+			url:    "/api/v1/admin/recordings?$top=10&$skip=1&$count=true",
+			mock: func(recording *mocks.MockRecordingsFeature) {
+				recording.EXPECT().Get(gomock.Any(), 10, 1, "tenant1").Return([]dto.SessionRecording{solRecording}, nil)
+				recording.EXPECT().GetCount(gomock.Any(), "tenant1").Return(1, nil)
+			},
+			response:     RecordingCountResponse{Count: 1, Data: []dto.SessionRecording{solRecording}},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:   "get recording - not found",
+			method: http.MethodGet,
+			url:    "/api/v1/admin/recordings/rec-2",
+			mock: func(recording *mocks.MockRecordingsFeature) {
+				recording.EXPECT().GetByID(gomock.Any(), "rec-2", "tenant1").Return(nil, recordings.ErrNotFound)
+			},
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:   "download recording",
+			method: http.MethodGet,
+			url:    "/api/v1/admin/recordings/rec-1/download",
+			mock: func(recording *mocks.MockRecordingsFeature) {
+				recording.EXPECT().Download(gomock.Any(), "rec-1", "tenant1").Return(io.NopCloser(strings.NewReader("AMTREC01")), nil)
+			},
+			body:         "AMTREC01",
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:   "get frames",
+			method: http.MethodGet,
+			url:    "/api/v1/admin/recordings/rec-1/frames?$top=2&$skip=4",
+			mock: func(recording *mocks.MockRecordingsFeature) {
+				recording.EXPECT().GetFrames(gomock.Any(), "rec-1", "tenant1", 2, 4).
+					Return([]dto.RecordingFrame{{OffsetMS: 250, Direction: dto.RecordingDirectionDevice, Data: []byte("login:")}}, nil)
+			},
+			response:     []dto.RecordingFrame{{OffsetMS: 250, Direction: dto.RecordingDirectionDevice, Data: []byte("login:")}},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:   "transcript",
+			method: http.MethodGet,
+			url:    "/api/v1/admin/recordings/rec-1/transcript",
+			mock: func(recording *mocks.MockRecordingsFeature) {
+				recording.EXPECT().Transcript(gomock.Any(), "rec-1", "tenant1").Return("login: root\n", nil)
+			},
+			body:         "login: root\n",
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:   "transcript - not a SOL recording",
+			method: http.MethodGet,
+			url:    "/api/v1/admin/recordings/rec-2/transcript",
+			mock: func(recording *mocks.MockRecordingsFeature) {
+				recording.EXPECT().Transcript(gomock.Any(), "rec-2", "tenant1").Return("", recordings.ErrNotValid)
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:   "delete recording",
+			method: http.MethodDelete,
+			url:    "/api/v1/admin/recordings/rec-1",
+			mock: func(recording *mocks.MockRecordingsFeature) {
+				recording.EXPECT().Delete(gomock.Any(), "rec-1", "tenant1").Return(nil)
+			},
+			expectedCode: http.StatusNoContent,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			recordingFeature, engine := recordingsTest(t)
+
+			tc.mock(recordingFeature)
+
+			req, err := http.NewRequestWithContext(context.Background(), tc.method, tc.url, http.NoBody)
+			require.NoError(t, err)
+
+			w := httptest.NewRecorder()
+
+			engine.ServeHTTP(w, req)
+
+			require.Equal(t, tc.expectedCode, w.Code)
+
+			if tc.response != nil {
+				jsonBytes, _ := json.Marshal(tc.response)
+				require.Equal(t, string(jsonBytes), w.Body.String())
+			}
+
+			if tc.body != "" {
+				require.Equal(t, tc.body, w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package v1
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/devices"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/images"
+	"github.com/open-amt-cloud-toolkit/console/pkg/logger"
+)
+
+// NewRedirectionRoutes registers the routes needed to open a KVM, SOL or IDE-R session or a VNC client's KVM,
+// to capture a device's screen, to list the relayed sessions and to drive the SOL and IDE-R sessions the
+// console holds itself. They are kept apart from the device routes so that a redirection-only role can reach
+// them, except for mounting an image, which can also reset or power on the device and so needs operate too.
+func NewRedirectionRoutes(handler *gin.RouterGroup, t devices.Feature, i images.Feature, v devices.VNC, th devices.Thumbnails, l logger.Interface) {
+	dr := &deviceRoutes{t: t, l: l}
+	mr := &deviceManagementRoutes{d: t, l: l}
+	ir := &imageRoutes{i, l}
+	vr := &vncRoutes{v, l}
+	kr := &kvmRoutes{t, th, l}
+
+	handler.GET("authorize/redirection/:id", dr.LoginRedirection)
+	handler.GET("devices/redirectstatus/:guid", dr.redirectStatus)
+	handler.GET("redirection/sessions", mr.getRedirectionSessions)
+
+	h := handler.Group("/amt")
+	{
+		h.GET("userConsentCode/cancel/:guid", mr.cancelUserConsentCode)
+		h.GET("userConsentCode/:guid", mr.getUserConsentCode)
+		h.POST("userConsentCode/:guid", mr.sendConsentCode)
+
+		h.POST("sol/:guid", mr.openSOL)
+		h.GET("sol/:guid", mr.getSOL)
+		h.POST("sol/:guid/keys", mr.sendSOLKeys)
+		h.GET("sol/:guid/screen", mr.getSOLScreen)
+		h.DELETE("sol/:guid", mr.closeSOL)
+
+		h.POST("ider/:guid", RequirePermission(dto.PermissionOperate), ir.mount)
+		h.GET("ider/:guid", mr.getIDER)
+		h.DELETE("ider/:guid", mr.closeIDER)
+
+		h.POST("vnc/:guid", vr.allocate)
+
+		h.GET("kvm/screenshot/:guid", kr.screenshot)
+		h.GET("kvm/thumbnail/:guid", kr.thumbnail)
+	}
+}
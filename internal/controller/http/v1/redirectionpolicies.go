@@ -0,0 +1,102 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/redirectionpolicies"
+	"github.com/open-amt-cloud-toolkit/console/pkg/consoleerrors"
+	"github.com/open-amt-cloud-toolkit/console/pkg/logger"
+)
+
+var ErrValidationRedirectionPolicies = dto.NotValidError{Console: consoleerrors.CreateConsoleError("RedirectionPoliciesAPI")}
+
+type redirectionPolicyRoutes struct {
+	t redirectionpolicies.Feature
+	l logger.Interface
+}
+
+func NewRedirectionPolicyRoutes(handler *gin.RouterGroup, t redirectionpolicies.Feature, l logger.Interface) {
+	r := &redirectionPolicyRoutes{t, l}
+
+	h := handler.Group("/redirection/policy")
+	{
+		h.GET("", r.get)
+		h.PUT("", r.update)
+		h.DELETE("", r.delete)
+	}
+}
+
+// @Summary     Show Redirection Policy
+// @Description Show the idle timeout, maximum duration and concurrent session limits of the tenant's KVM and SOL sessions, the configured defaults when it has no policy of its own
+// @ID          getRedirectionPolicy
+// @Tags  	    redirection
+// @Produce     json
+// @Success     200 {object} dto.RedirectionPolicy
+// @Failure     500 {object} response
+// @Router      /api/v1/admin/redirection/policy [get]
+func (r *redirectionPolicyRoutes) get(c *gin.Context) {
+	policy, err := r.t.Get(c.Request.Context(), tenantID(c))
+	if err != nil {
+		r.l.Error(err, "http - v1 - getRedirectionPolicy")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, policy)
+}
+
+// @Summary     Edit Redirection Policy
+// @Description Set the limits of the tenant's KVM and SOL sessions, 0 means no limit. Sessions over a limit are closed with a close frame whose code says which
+// @ID          updateRedirectionPolicy
+// @Tags  	    redirection
+// @Accept      json
+// @Produce     json
+// @Param       request body dto.RedirectionPolicy true "Redirection policy"
+// @Success     200 {object} dto.RedirectionPolicy
+// @Failure     400 {object} response
+// @Failure     500 {object} response
+// @Router      /api/v1/admin/redirection/policy [put]
+func (r *redirectionPolicyRoutes) update(c *gin.Context) {
+	var policy dto.RedirectionPolicy
+	if err := c.ShouldBindJSON(&policy); err != nil {
+		validationErr := ErrValidationRedirectionPolicies.Wrap("update", "ShouldBindJSON", err)
+		ErrorResponse(c, validationErr)
+
+		return
+	}
+
+	policy.TenantID = tenantID(c)
+
+	updated, err := r.t.Update(c.Request.Context(), &policy)
+	if err != nil {
+		r.l.Error(err, "http - v1 - updateRedirectionPolicy")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, updated)
+}
+
+// @Summary     Remove Redirection Policy
+// @Description Remove the tenant's policy so that the configured defaults apply again
+// @ID          deleteRedirectionPolicy
+// @Tags  	    redirection
+// @Produce     json
+// @Success     204 {object} noContent
+// @Failure     404 {object} response
+// @Router      /api/v1/admin/redirection/policy [delete]
+func (r *redirectionPolicyRoutes) delete(c *gin.Context) {
+	if err := r.t.Delete(c.Request.Context(), tenantID(c)); err != nil {
+		r.l.Error(err, "http - v1 - deleteRedirectionPolicy")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
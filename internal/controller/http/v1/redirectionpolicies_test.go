@@ -0,0 +1,139 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	"github.com/open-amt-cloud-toolkit/console/internal/mocks"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/redirectionpolicies"
+	"github.com/open-amt-cloud-toolkit/console/pkg/logger"
+)
+
+func redirectionPoliciesTest(t *testing.T) (*mocks.MockRedirectionPoliciesFeature, *gin.Engine) {
+	t.Helper()
+
+	mockCtl := gomock.NewController(t)
+
+	policies := mocks.NewMockRedirectionPoliciesFeature(mockCtl)
+
+	engine := gin.New()
+	handler := engine.Group("/api/v1/admin", func(c *gin.Context) { setTenant(c, "tenant1") })
+
+	NewRedirectionPolicyRoutes(handler, policies, logger.New("error"))
+
+	return policies, engine
+}
+
+func TestRedirectionPolicyRoutes(t *testing.T) {
+	t.Parallel()
+
+	policy := dto.RedirectionPolicy{
+		IdleTimeoutSeconds:   900,
+		MaxDurationSeconds:   14400,
+		MaxSessionsPerUser:   2,
+		MaxSessionsPerDevice: 1,
+		TenantID:             "tenant1",
+	}
+
+	tests := []struct {
+		name         string
+		method       string
+		body         interface{}
+		mock         func(m *mocks.MockRedirectionPoliciesFeature)
+		response     interface{}
+		expectedCode int
+	}{
+		{
+			name:   "get policy",
+			method: http.MethodGet,
+			mock: func(m *mocks.MockRedirectionPoliciesFeature) {
+				m.EXPECT().Get(gomock.Any(), "tenant1").Return(policy, nil)
+			},
+			response:     policy,
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:   "get policy - database error",
+			method: http.MethodGet,
+			mock: func(m *mocks.MockRedirectionPoliciesFeature) {
+				m.EXPECT().Get(gomock.Any(), "tenant1").Return(dto.RedirectionPolicy{}, redirectionpolicies.ErrDatabase)
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:   "update policy",
+			method: http.MethodPut,
+			body:   dto.RedirectionPolicy{IdleTimeoutSeconds: 900, MaxDurationSeconds: 14400, MaxSessionsPerUser: 2, MaxSessionsPerDevice: 1, TenantID: "other"},
+			mock: func(m *mocks.MockRedirectionPoliciesFeature) {
+				m.EXPECT().Update(gomock.Any(), &policy).Return(&policy, nil)
+			},
+			response:     policy,
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:   "update policy - negative limit",
+			method: http.MethodPut,
+			body:   dto.RedirectionPolicy{MaxSessionsPerUser: -1},
+			mock: func(m *mocks.MockRedirectionPoliciesFeature) {
+				m.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil, redirectionpolicies.ErrNotValid)
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:   "delete policy",
+			method: http.MethodDelete,
+			mock: func(m *mocks.MockRedirectionPoliciesFeature) {
+				m.EXPECT().Delete(gomock.Any(), "tenant1").Return(nil)
+			},
+			expectedCode: http.StatusNoContent,
+		},
+		{
+			name:   "delete policy - no policy",
+			method: http.MethodDelete,
+			mock: func(m *mocks.MockRedirectionPoliciesFeature) {
+				m.EXPECT().Delete(gomock.Any(), "tenant1").Return(redirectionpolicies.ErrNotFound)
+			},
+			expectedCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			policies, engine := redirectionPoliciesTest(t)
+
+			tc.mock(policies)
+
+			var body []byte
+			if tc.body != nil {
+				body, _ = json.Marshal(tc.body)
+			}
+
+			req, err := http.NewRequestWithContext(context.Background(), tc.method, "/api/v1/admin/redirection/policy", bytes.NewReader(body))
+			require.NoError(t, err)
+
+			w := httptest.NewRecorder()
+
+			engine.ServeHTTP(w, req)
+
+			require.Equal(t, tc.expectedCode, w.Code)
+
+			if tc.response != nil {
+				jsonBytes, _ := json.Marshal(tc.response)
+				require.Equal(t, string(jsonBytes), w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/devices"
+	"github.com/open-amt-cloud-toolkit/console/pkg/logger"
+)
+
+// NewRedirectionSessionRoutes registers the admin routes that manage the sessions relayed between browsers and
+// devices.
+func NewRedirectionSessionRoutes(handler *gin.RouterGroup, t devices.Feature, l logger.Interface) {
+	mr := &deviceManagementRoutes{d: t, l: l}
+
+	handler.DELETE("redirection/sessions/:id", mr.terminateRedirectionSession)
+}
+
+// @Summary     Show Redirection Sessions
+// @Description Show the KVM, SOL and IDE-R sessions relayed between browsers and devices: who opened them from where, when, and how many bytes went each way
+// @ID          getRedirectionSessions
+// @Tags  	    redirection
+// @Produce     json
+// @Success     200 {object} []dto.RedirectionSession
+// @Failure     500 {object} response
+// @Router      /api/v1/redirection/sessions [get]
+func (r *deviceManagementRoutes) getRedirectionSessions(c *gin.Context) {
+	sessions, err := r.d.GetRedirectionSessions(c.Request.Context(), tenantID(c))
+	if err != nil {
+		r.l.Error(err, "http - v1 - getRedirectionSessions")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, sessions)
+}
+
+// @Summary     Terminate Redirection Session
+// @Description Terminate a relayed session, both the browser websocket and the connection to the device are closed
+// @ID          terminateRedirectionSession
+// @Tags  	    redirection
+// @Produce     json
+// @Success     204 {object} nil
+// @Failure     404 {object} response
+// @Router      /api/v1/admin/redirection/sessions/{id} [delete]
+func (r *deviceManagementRoutes) terminateRedirectionSession(c *gin.Context) {
+	if err := r.d.TerminateRedirectionSession(c.Request.Context(), c.Param("id"), tenantID(c)); err != nil {
+		r.l.Error(err, "http - v1 - terminateRedirectionSession")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
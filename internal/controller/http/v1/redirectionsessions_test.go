@@ -0,0 +1,116 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	"github.com/open-amt-cloud-toolkit/console/internal/mocks"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/devices"
+	"github.com/open-amt-cloud-toolkit/console/pkg/logger"
+)
+
+func redirectionSessionsTest(t *testing.T) (*mocks.MockDeviceManagementFeature, *gin.Engine) {
+	t.Helper()
+
+	mockCtl := gomock.NewController(t)
+
+	log := logger.New("error")
+	deviceManagement := mocks.NewMockDeviceManagementFeature(mockCtl)
+
+	engine := gin.New()
+	withTenant := func(c *gin.Context) { setTenant(c, "tenant1") }
+
+	NewRedirectionRoutes(engine.Group("/api/v1", withTenant), deviceManagement, mocks.NewMockImagesFeature(mockCtl), mocks.NewMockVNC(mockCtl), mocks.NewMockThumbnails(mockCtl), log)
+	NewRedirectionSessionRoutes(engine.Group("/api/v1/admin", withTenant), deviceManagement, log)
+
+	return deviceManagement, engine
+}
+
+func TestRedirectionSessionRoutes(t *testing.T) {
+	t.Parallel()
+
+	kvmSession := dto.RedirectionSession{
+		ID:              "session-1",
+		GUID:            "guid-1",
+		Mode:            "kvm",
+		Subject:         "alice",
+		ClientIP:        "10.0.0.5",
+		StartTime:       time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC),
+		BytesToDevice:   2048,
+		BytesFromDevice: 1048576,
+		TenantID:        "tenant1",
+	}
+
+	tests := []struct {
+		name         string
+		method       string
+		url          string
+		mock         func(m *mocks.MockDeviceManagementFeature)
+		response     interface{}
+		expectedCode int
+	}{
+		{
+			name:   "get sessions",
+			method: http.MethodGet,
+			url:    "/api/v1/redirection/sessions",
+			mock: func(m *mocks.MockDeviceManagementFeature) {
+				m.EXPECT().GetRedirectionSessions(gomock.Any(), "tenant1").Return([]dto.RedirectionSession{kvmSession}, nil)
+			},
+			response:     []dto.RedirectionSession{kvmSession},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:   "terminate session",
+			method: http.MethodDelete,
+			url:    "/api/v1/admin/redirection/sessions/session-1",
+			mock: func(m *mocks.MockDeviceManagementFeature) {
+				m.EXPECT().TerminateRedirectionSession(gomock.Any(), "session-1", "tenant1").Return(nil)
+			},
+			expectedCode: http.StatusNoContent,
+		},
+		{
+			name:   "terminate session - not found",
+			method: http.MethodDelete,
+			url:    "/api/v1/admin/redirection/sessions/session-2",
+			mock: func(m *mocks.MockDeviceManagementFeature) {
+				m.EXPECT().TerminateRedirectionSession(gomock.Any(), "session-2", "tenant1").Return(devices.ErrNotFound)
+			},
+			expectedCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			deviceManagement, engine := redirectionSessionsTest(t)
+
+			tc.mock(deviceManagement)
+
+			req, err := http.NewRequestWithContext(context.Background(), tc.method, tc.url, http.NoBody)
+			require.NoError(t, err)
+
+			w := httptest.NewRecorder()
+
+			engine.ServeHTTP(w, req)
+
+			require.Equal(t, tc.expectedCode, w.Code)
+
+			if tc.response != nil {
+				jsonBytes, _ := json.Marshal(tc.response)
+				require.Equal(t, string(jsonBytes), w.Body.String())
+			}
+		})
+	}
+}
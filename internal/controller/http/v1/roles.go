@@ -0,0 +1,133 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/roles"
+	"github.com/open-amt-cloud-toolkit/console/pkg/consoleerrors"
+	"github.com/open-amt-cloud-toolkit/console/pkg/logger"
+)
+
+var ErrValidationRoles = dto.NotValidError{Console: consoleerrors.CreateConsoleError("RolesAPI")}
+
+type roleRoutes struct {
+	t roles.Feature
+	l logger.Interface
+}
+
+func NewRoleRoutes(handler *gin.RouterGroup, t roles.Feature, l logger.Interface) {
+	r := &roleRoutes{t, l}
+
+	h := handler.Group("/roles")
+	{
+		h.GET("", r.get)
+		h.POST("", r.insert)
+		h.DELETE(":subject/:role", r.delete)
+	}
+}
+
+type RoleBindingCountResponse struct {
+	Count int               `json:"totalCount"`
+	Data  []dto.RoleBinding `json:"data"`
+}
+
+// @Summary     Show Role Bindings
+// @Description Show the roles granted to users and OIDC subjects
+// @ID          roles
+// @Tags  	    roles
+// @Accept      json
+// @Produce     json
+// @Success     200 {object} RoleBindingCountResponse
+// @Failure     500 {object} response
+// @Router      /api/v1/admin/roles [get]
+func (r *roleRoutes) get(c *gin.Context) {
+	var odata OData
+	if err := c.ShouldBindQuery(&odata); err != nil {
+		validationErr := ErrValidationRoles.Wrap("get", "ShouldBindQuery", err)
+		ErrorResponse(c, validationErr)
+
+		return
+	}
+
+	items, err := r.t.Get(c.Request.Context(), odata.Top, odata.Skip, tenantID(c))
+	if err != nil {
+		r.l.Error(err, "http - v1 - getRoles")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	if odata.Count {
+		count, err := r.t.GetCount(c.Request.Context(), tenantID(c))
+		if err != nil {
+			r.l.Error(err, "http - v1 - getCount")
+			ErrorResponse(c, err)
+
+			return
+		}
+
+		countResponse := RoleBindingCountResponse{
+			Count: count,
+			Data:  items,
+		}
+
+		c.JSON(http.StatusOK, countResponse)
+	} else {
+		c.JSON(http.StatusOK, items)
+	}
+}
+
+// @Summary     Add Role Binding
+// @Description Grant a role to a user or OIDC subject
+// @ID          insertRole
+// @Tags  	    roles
+// @Accept      json
+// @Produce     json
+// @Success     201 {object} dto.RoleBinding
+// @Failure     500 {object} response
+// @Router      /api/v1/admin/roles [post]
+func (r *roleRoutes) insert(c *gin.Context) {
+	var binding dto.RoleBinding
+	if err := c.ShouldBindJSON(&binding); err != nil {
+		validationErr := ErrValidationRoles.Wrap("insert", "ShouldBindJSON", err)
+		ErrorResponse(c, validationErr)
+
+		return
+	}
+
+	binding.TenantID = tenantID(c)
+
+	newBinding, err := r.t.Insert(c.Request.Context(), &binding)
+	if err != nil {
+		r.l.Error(err, "http - v1 - insert")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusCreated, newBinding)
+}
+
+// @Summary     Remove Role Binding
+// @Description Revoke a role from a user or OIDC subject
+// @ID          deleteRole
+// @Tags  	    roles
+// @Accept      json
+// @Produce     json
+// @Success     204 {object} noContent
+// @Failure     500 {object} response
+// @Router      /api/v1/admin/roles/:subject/:role [delete]
+func (r *roleRoutes) delete(c *gin.Context) {
+	err := r.t.Delete(c.Request.Context(), c.Param("subject"), c.Param("role"), tenantID(c))
+	if err != nil {
+		r.l.Error(err, "http - v1 - delete")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
@@ -0,0 +1,153 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	"github.com/open-amt-cloud-toolkit/console/internal/mocks"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/roles"
+	"github.com/open-amt-cloud-toolkit/console/pkg/logger"
+)
+
+func rolesTest(t *testing.T) (*mocks.MockRolesFeature, *gin.Engine) {
+	t.Helper()
+
+	mockCtl := gomock.NewController(t)
+
+	log := logger.New("error")
+	role := mocks.NewMockRolesFeature(mockCtl)
+
+	engine := gin.New()
+	handler := engine.Group("/api/v1/admin")
+
+	NewRoleRoutes(handler, role, log)
+
+	return role, engine
+}
+
+var roleBinding = dto.RoleBinding{
+	Subject: "jane@example.com",
+	Role:    dto.RoleOperator,
+}
+
+func TestRoleRoutes(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		method       string
+		url          string
+		mock         func(role *mocks.MockRolesFeature)
+		response     interface{}
+		requestBody  dto.RoleBinding
+		expectedCode int
+	}{
+		{
+			name:   "get all role bindings",
+			method: http.MethodGet,
+			url:    "/api/v1/admin/roles",
+			mock: func(role *mocks.MockRolesFeature) {
+				role.EXPECT().Get(context.Background(), 25, 0, "").Return([]dto.RoleBinding{roleBinding}, nil)
+			},
+			response:     []dto.RoleBinding{roleBinding},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:   "get all role bindings - with count",
+			method: http.MethodGet,
+			url:    "/api/v1/admin/roles?$top=10&$skip=1&$count=true",
+			mock: func(role *mocks.MockRolesFeature) {
+				role.EXPECT().Get(context.Background(), 10, 1, "").Return([]dto.RoleBinding{roleBinding}, nil)
+				role.EXPECT().GetCount(context.Background(), "").Return(1, nil)
+			},
+			response:     RoleBindingCountResponse{Count: 1, Data: []dto.RoleBinding{roleBinding}},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:   "insert role binding",
+			method: http.MethodPost,
+			url:    "/api/v1/admin/roles",
+			mock: func(role *mocks.MockRolesFeature) {
+				role.EXPECT().Insert(context.Background(), &roleBinding).Return(&roleBinding, nil)
+			},
+			requestBody:  roleBinding,
+			response:     roleBinding,
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name:   "insert role binding - unknown role",
+			method: http.MethodPost,
+			url:    "/api/v1/admin/roles",
+			mock: func(role *mocks.MockRolesFeature) {
+				role.EXPECT().Insert(context.Background(), gomock.Any()).Return(nil, roles.ErrNotValid)
+			},
+			requestBody:  dto.RoleBinding{Subject: "jane@example.com", Role: "superuser"},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:   "delete role binding",
+			method: http.MethodDelete,
+			url:    "/api/v1/admin/roles/jane@example.com/operator",
+			mock: func(role *mocks.MockRolesFeature) {
+				role.EXPECT().Delete(context.Background(), "jane@example.com", dto.RoleOperator, "").Return(nil)
+			},
+			expectedCode: http.StatusNoContent,
+		},
+		{
+			name:   "delete role binding - not found",
+			method: http.MethodDelete,
+			url:    "/api/v1/admin/roles/jane@example.com/admin",
+			mock: func(role *mocks.MockRolesFeature) {
+				role.EXPECT().Delete(context.Background(), "jane@example.com", dto.RoleAdmin, "").Return(roles.ErrNotFound)
+			},
+			expectedCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			roleFeature, engine := rolesTest(t)
+
+			tc.mock(roleFeature)
+
+			var req *http.Request
+
+			var err error
+
+			if tc.method == http.MethodPost {
+				reqBody, _ := json.Marshal(tc.requestBody)
+				req, err = http.NewRequest(tc.method, tc.url, bytes.NewBuffer(reqBody))
+			} else {
+				req, err = http.NewRequest(tc.method, tc.url, http.NoBody)
+			}
+
+			if err != nil {
+				t.Fatalf("Couldn't create request: %v\n", err)
+			}
+
+			w := httptest.NewRecorder()
+
+			engine.ServeHTTP(w, req)
+
+			require.Equal(t, tc.expectedCode, w.Code)
+
+			if tc.expectedCode == http.StatusOK || tc.expectedCode == http.StatusCreated {
+				jsonBytes, _ := json.Marshal(tc.response)
+				require.Equal(t, string(jsonBytes), w.Body.String())
+			}
+		})
+	}
+}
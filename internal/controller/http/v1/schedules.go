@@ -0,0 +1,223 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/schedules"
+	"github.com/open-amt-cloud-toolkit/console/pkg/consoleerrors"
+	"github.com/open-amt-cloud-toolkit/console/pkg/logger"
+)
+
+var ErrValidationSchedules = dto.NotValidError{Console: consoleerrors.CreateConsoleError("SchedulesAPI")}
+
+type scheduleRoutes struct {
+	t schedules.Feature
+	l logger.Interface
+}
+
+func NewScheduleRoutes(handler *gin.RouterGroup, t schedules.Feature, l logger.Interface) {
+	r := &scheduleRoutes{t, l}
+
+	h := handler.Group("/schedules")
+	{
+		h.GET("", r.get)
+		h.GET(":id", r.getByID)
+		h.GET(":id/runs", r.getRuns)
+		h.POST("", r.insert)
+		h.PATCH("", r.update)
+		h.DELETE(":id", r.delete)
+	}
+}
+
+type ScheduleCountResponse struct {
+	Count int                `json:"totalCount"`
+	Data  []dto.ScheduledJob `json:"data"`
+}
+
+// @Summary     Show Schedules
+// @Description Show all scheduled jobs
+// @ID          schedules
+// @Tags  	    schedules
+// @Accept      json
+// @Produce     json
+// @Success     200 {object} ScheduleCountResponse
+// @Failure     500 {object} response
+// @Router      /api/v1/admin/schedules [get]
+func (r *scheduleRoutes) get(c *gin.Context) {
+	var odata OData
+	if err := c.ShouldBindQuery(&odata); err != nil {
+		validationErr := ErrValidationSchedules.Wrap("get", "ShouldBindQuery", err)
+		ErrorResponse(c, validationErr)
+
+		return
+	}
+
+	items, err := r.t.Get(c.Request.Context(), odata.Top, odata.Skip, tenantID(c))
+	if err != nil {
+		r.l.Error(err, "http - v1 - getSchedules")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	if odata.Count {
+		count, err := r.t.GetCount(c.Request.Context(), tenantID(c))
+		if err != nil {
+			r.l.Error(err, "http - v1 - getCount")
+			ErrorResponse(c, err)
+
+			return
+		}
+
+		countResponse := ScheduleCountResponse{
+			Count: count,
+			Data:  items,
+		}
+
+		c.JSON(http.StatusOK, countResponse)
+	} else {
+		c.JSON(http.StatusOK, items)
+	}
+}
+
+// @Summary     Show Schedule
+// @Description Show a scheduled job by id
+// @ID          getSchedule
+// @Tags  	    schedules
+// @Accept      json
+// @Produce     json
+// @Success     200 {object} dto.ScheduledJob
+// @Failure     500 {object} response
+// @Router      /api/v1/admin/schedules/:id [get]
+func (r *scheduleRoutes) getByID(c *gin.Context) {
+	id := c.Param("id")
+
+	item, err := r.t.GetByID(c.Request.Context(), id, tenantID(c))
+	if err != nil {
+		r.l.Error(err, "http - v1 - getByID")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, item)
+}
+
+// @Summary     Show Schedule Runs
+// @Description Show the per device run history of a scheduled job, newest first
+// @ID          getScheduleRuns
+// @Tags  	    schedules
+// @Accept      json
+// @Produce     json
+// @Success     200 {object} []dto.JobRun
+// @Failure     500 {object} response
+// @Router      /api/v1/admin/schedules/:id/runs [get]
+func (r *scheduleRoutes) getRuns(c *gin.Context) {
+	id := c.Param("id")
+
+	var odata OData
+	if err := c.ShouldBindQuery(&odata); err != nil {
+		validationErr := ErrValidationSchedules.Wrap("getRuns", "ShouldBindQuery", err)
+		ErrorResponse(c, validationErr)
+
+		return
+	}
+
+	runs, err := r.t.GetRuns(c.Request.Context(), id, odata.Top, odata.Skip, tenantID(c))
+	if err != nil {
+		r.l.Error(err, "http - v1 - getRuns")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, runs)
+}
+
+// @Summary     Add Schedule
+// @Description Schedule a power, boot or feature job, once or on a cron expression
+// @ID          insertSchedule
+// @Tags  	    schedules
+// @Accept      json
+// @Produce     json
+// @Success     201 {object} dto.ScheduledJob
+// @Failure     500 {object} response
+// @Router      /api/v1/admin/schedules [post]
+func (r *scheduleRoutes) insert(c *gin.Context) {
+	var job dto.ScheduledJob
+	if err := c.ShouldBindJSON(&job); err != nil {
+		validationErr := ErrValidationSchedules.Wrap("insert", "ShouldBindJSON", err)
+		ErrorResponse(c, validationErr)
+
+		return
+	}
+
+	job.TenantID = tenantID(c)
+
+	newJob, err := r.t.Insert(c.Request.Context(), &job)
+	if err != nil {
+		r.l.Error(err, "http - v1 - insert")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusCreated, newJob)
+}
+
+// @Summary     Edit Schedule
+// @Description Edit a scheduled job
+// @ID          updateSchedule
+// @Tags  	    schedules
+// @Accept      json
+// @Produce     json
+// @Success     200 {object} dto.ScheduledJob
+// @Failure     500 {object} response
+// @Router      /api/v1/admin/schedules [patch]
+func (r *scheduleRoutes) update(c *gin.Context) {
+	var job dto.ScheduledJob
+	if err := c.ShouldBindJSON(&job); err != nil {
+		validationErr := ErrValidationSchedules.Wrap("update", "ShouldBindJSON", err)
+		ErrorResponse(c, validationErr)
+
+		return
+	}
+
+	job.TenantID = tenantID(c)
+
+	updatedJob, err := r.t.Update(c.Request.Context(), &job)
+	if err != nil {
+		r.l.Error(err, "http - v1 - update")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, updatedJob)
+}
+
+// @Summary     Remove Schedule
+// @Description Remove a scheduled job and its run history
+// @ID          deleteSchedule
+// @Tags  	    schedules
+// @Accept      json
+// @Produce     json
+// @Success     204 {object} noContent
+// @Failure     500 {object} response
+// @Router      /api/v1/admin/schedules/:id [delete]
+func (r *scheduleRoutes) delete(c *gin.Context) {
+	id := c.Param("id")
+
+	err := r.t.Delete(c.Request.Context(), id, tenantID(c))
+	if err != nil {
+		r.l.Error(err, "http - v1 - delete")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
@@ -0,0 +1,190 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	"github.com/open-amt-cloud-toolkit/console/internal/mocks"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/schedules"
+	"github.com/open-amt-cloud-toolkit/console/pkg/logger"
+)
+
+func schedulesTest(t *testing.T) (*mocks.MockSchedulesFeature, *gin.Engine) {
+	t.Helper()
+
+	mockCtl := gomock.NewController(t)
+
+	log := logger.New("error")
+	schedule := mocks.NewMockSchedulesFeature(mockCtl)
+
+	engine := gin.New()
+	handler := engine.Group("/api/v1/admin")
+
+	NewScheduleRoutes(handler, schedule, log)
+
+	return schedule, engine
+}
+
+var scheduledJob = dto.ScheduledJob{
+	ID:             "job-1",
+	Name:           "nightly reboot",
+	JobType:        dto.JobTypePower,
+	ScheduleType:   dto.ScheduleTypeCron,
+	CronExpression: "0 2 * * *",
+	PowerAction:    &dto.PowerAction{Action: 10},
+	GUIDs:          []string{"guid-1"},
+	Enabled:        true,
+}
+
+func TestScheduleRoutes(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		method       string
+		url          string
+		mock         func(schedule *mocks.MockSchedulesFeature)
+		response     interface{}
+		requestBody  dto.ScheduledJob
+		expectedCode int
+	}{
+		{
+			name:   "get all schedules",
+			method: http.MethodGet,
+			url:    "/api/v1/admin/schedules",
+			mock: func(schedule *mocks.MockSchedulesFeature) {
+				schedule.EXPECT().Get(context.Background(), 25, 0, "").Return([]dto.ScheduledJob{scheduledJob}, nil)
+			},
+			response:     []dto.ScheduledJob{scheduledJob},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:   "get all schedules - with count",
+			method: http.MethodGet,
+			url:    "/api/v1/admin/schedules?$top=10&$skip=1&$count=true",
+			mock: func(schedule *mocks.MockSchedulesFeature) {
+				schedule.EXPECT().Get(context.Background(), 10, 1, "").Return([]dto.ScheduledJob{scheduledJob}, nil)
+				schedule.EXPECT().GetCount(context.Background(), "").Return(1, nil)
+			},
+			response:     ScheduleCountResponse{Count: 1, Data: []dto.ScheduledJob{scheduledJob}},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:   "get schedule by id",
+			method: http.MethodGet,
+			url:    "/api/v1/admin/schedules/job-1",
+			mock: func(schedule *mocks.MockSchedulesFeature) {
+				schedule.EXPECT().GetByID(context.Background(), "job-1", "").Return(&scheduledJob, nil)
+			},
+			response:     scheduledJob,
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:   "get schedule by id - not found",
+			method: http.MethodGet,
+			url:    "/api/v1/admin/schedules/job-2",
+			mock: func(schedule *mocks.MockSchedulesFeature) {
+				schedule.EXPECT().GetByID(context.Background(), "job-2", "").Return(nil, schedules.ErrNotFound)
+			},
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:   "get schedule runs",
+			method: http.MethodGet,
+			url:    "/api/v1/admin/schedules/job-1/runs",
+			mock: func(schedule *mocks.MockSchedulesFeature) {
+				schedule.EXPECT().GetRuns(context.Background(), "job-1", 25, 0, "").Return([]dto.JobRun{{ID: "run-1", JobID: "job-1", GUID: "guid-1", Attempt: 1, Status: dto.JobRunStatusSuccess}}, nil)
+			},
+			response:     []dto.JobRun{{ID: "run-1", JobID: "job-1", GUID: "guid-1", Attempt: 1, Status: dto.JobRunStatusSuccess}},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:   "insert schedule",
+			method: http.MethodPost,
+			url:    "/api/v1/admin/schedules",
+			mock: func(schedule *mocks.MockSchedulesFeature) {
+				schedule.EXPECT().Insert(context.Background(), gomock.Any()).Return(&scheduledJob, nil)
+			},
+			requestBody:  scheduledJob,
+			response:     scheduledJob,
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name:   "insert schedule - invalid cron expression",
+			method: http.MethodPost,
+			url:    "/api/v1/admin/schedules",
+			mock: func(schedule *mocks.MockSchedulesFeature) {
+				schedule.EXPECT().Insert(context.Background(), gomock.Any()).Return(nil, schedules.ErrNotValid)
+			},
+			requestBody:  scheduledJob,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:   "update schedule",
+			method: http.MethodPatch,
+			url:    "/api/v1/admin/schedules",
+			mock: func(schedule *mocks.MockSchedulesFeature) {
+				schedule.EXPECT().Update(context.Background(), gomock.Any()).Return(&scheduledJob, nil)
+			},
+			requestBody:  scheduledJob,
+			response:     scheduledJob,
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:   "delete schedule",
+			method: http.MethodDelete,
+			url:    "/api/v1/admin/schedules/job-1",
+			mock: func(schedule *mocks.MockSchedulesFeature) {
+				schedule.EXPECT().Delete(context.Background(), "job-1", "").Return(nil)
+			},
+			expectedCode: http.StatusNoContent,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			scheduleFeature, engine := schedulesTest(t)
+
+			tc.mock(scheduleFeature)
+
+			var req *http.Request
+
+			var err error
+
+			if tc.method == http.MethodPost || tc.method == http.MethodPatch {
+				reqBody, _ := json.Marshal(tc.requestBody)
+				req, err = http.NewRequest(tc.method, tc.url, bytes.NewBuffer(reqBody))
+			} else {
+				req, err = http.NewRequest(tc.method, tc.url, http.NoBody)
+			}
+
+			if err != nil {
+				t.Fatalf("Couldn't create request: %v\n", err)
+			}
+
+			w := httptest.NewRecorder()
+
+			engine.ServeHTTP(w, req)
+
+			require.Equal(t, tc.expectedCode, w.Code)
+
+			if tc.expectedCode == http.StatusOK || tc.expectedCode == http.StatusCreated {
+				jsonBytes, _ := json.Marshal(tc.response)
+				require.Equal(t, string(jsonBytes), w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/siem"
+	"github.com/open-amt-cloud-toolkit/console/pkg/consoleerrors"
+	"github.com/open-amt-cloud-toolkit/console/pkg/logger"
+)
+
+var ErrValidationSIEM = dto.NotValidError{Console: consoleerrors.CreateConsoleError("SIEMAPI")}
+
+type siemRoutes struct {
+	t siem.Feature
+	l logger.Interface
+}
+
+// NewSIEMRoutes serves how far the collected AMT logs have been forwarded to the SIEM.
+func NewSIEMRoutes(handler *gin.RouterGroup, t siem.Feature, l logger.Interface) {
+	r := &siemRoutes{t, l}
+
+	h := handler.Group("/siem")
+	{
+		h.GET("cursors", r.getCursors)
+		h.POST("forward/:guid", r.forward)
+	}
+}
+
+type SIEMCursorCountResponse struct {
+	Count int              `json:"totalCount"`
+	Data  []dto.SIEMCursor `json:"data"`
+}
+
+// @Summary     Show SIEM Forwarding Cursors
+// @Description Show how far the AMT audit and event logs of each device have been forwarded to the SIEM and how the last forwarding ended
+// @ID          siemCursors
+// @Tags  	    siem
+// @Accept      json
+// @Produce     json
+// @Success     200 {object} SIEMCursorCountResponse
+// @Failure     500 {object} response
+// @Router      /api/v1/admin/siem/cursors [get]
+func (r *siemRoutes) getCursors(c *gin.Context) {
+	var odata OData
+	if err := c.ShouldBindQuery(&odata); err != nil {
+		validationErr := ErrValidationSIEM.Wrap("getCursors", "ShouldBindQuery", err)
+		ErrorResponse(c, validationErr)
+
+		return
+	}
+
+	cursors, err := r.t.GetCursors(c.Request.Context(), odata.Top, odata.Skip, tenantID(c))
+	if err != nil {
+		r.l.Error(err, "http - v1 - getSIEMCursors")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	if odata.Count {
+		count, err := r.t.GetCursorCount(c.Request.Context(), tenantID(c))
+		if err != nil {
+			r.l.Error(err, "http - v1 - getCount")
+			ErrorResponse(c, err)
+
+			return
+		}
+
+		countResponse := SIEMCursorCountResponse{
+			Count: count,
+			Data:  cursors,
+		}
+
+		c.JSON(http.StatusOK, countResponse)
+	} else {
+		c.JSON(http.StatusOK, cursors)
+	}
+}
+
+// @Summary     Forward AMT Logs to the SIEM
+// @Description Forward the records collected from a device since its last forwarding without waiting for the schedule
+// @ID          forwardSIEM
+// @Tags  	    siem
+// @Accept      json
+// @Produce     json
+// @Success     200 {object} []dto.SIEMCursor
+// @Failure     400 {object} response
+// @Failure     404 {object} response
+// @Router      /api/v1/admin/siem/forward/{guid} [post]
+func (r *siemRoutes) forward(c *gin.Context) {
+	cursors, err := r.t.Forward(c.Request.Context(), c.Param("guid"), tenantID(c))
+	if err != nil {
+		r.l.Error(err, "http - v1 - forwardSIEM")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, cursors)
+}
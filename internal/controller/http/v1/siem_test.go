@@ -0,0 +1,135 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	"github.com/open-amt-cloud-toolkit/console/internal/mocks"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/siem"
+	"github.com/open-amt-cloud-toolkit/console/pkg/logger"
+)
+
+func siemTest(t *testing.T) (*mocks.MockSIEMFeature, *gin.Engine) {
+	t.Helper()
+
+	mockCtl := gomock.NewController(t)
+
+	log := logger.New("error")
+	feature := mocks.NewMockSIEMFeature(mockCtl)
+
+	engine := gin.New()
+	handler := engine.Group("/api/v1/admin")
+
+	NewSIEMRoutes(handler, feature, log)
+
+	return feature, engine
+}
+
+var siemCursor = dto.SIEMCursor{
+	Source:          dto.SIEMSourceAudit,
+	GUID:            "guid1",
+	LastCollectedAt: time.Date(2024, 12, 1, 1, 0, 0, 0, time.UTC),
+	LastTime:        time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC),
+	ForwardedAt:     time.Date(2024, 12, 1, 1, 10, 0, 0, time.UTC),
+	Forwarded:       3,
+}
+
+func TestSIEMRoutes(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		method       string
+		url          string
+		mock         func(feature *mocks.MockSIEMFeature)
+		response     interface{}
+		expectedCode int
+	}{
+		{
+			name:   "get cursors",
+			method: http.MethodGet,
+			url:    "/api/v1/admin/siem/cursors",
+			mock: func(feature *mocks.MockSIEMFeature) {
+				feature.EXPECT().GetCursors(context.Background(), 25, 0, "").Return([]dto.SIEMCursor{siemCursor}, nil)
+			},
+			response:     []dto.SIEMCursor{siemCursor},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:   "get cursors - with count",
+			method: http.MethodGet,
+			url:    "/api/v1/admin/siem/cursors?$top=10&$count=true",
+			mock: func(feature *mocks.MockSIEMFeature) {
+				feature.EXPECT().GetCursors(context.Background(), 10, 0, "").Return([]dto.SIEMCursor{siemCursor}, nil)
+				feature.EXPECT().GetCursorCount(context.Background(), "").Return(1, nil)
+			},
+			response:     SIEMCursorCountResponse{Count: 1, Data: []dto.SIEMCursor{siemCursor}},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:   "forward",
+			method: http.MethodPost,
+			url:    "/api/v1/admin/siem/forward/guid1",
+			mock: func(feature *mocks.MockSIEMFeature) {
+				feature.EXPECT().Forward(context.Background(), "guid1", "").Return([]dto.SIEMCursor{siemCursor}, nil)
+			},
+			response:     []dto.SIEMCursor{siemCursor},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:   "forward - no collector configured",
+			method: http.MethodPost,
+			url:    "/api/v1/admin/siem/forward/guid1",
+			mock: func(feature *mocks.MockSIEMFeature) {
+				feature.EXPECT().Forward(context.Background(), "guid1", "").Return(nil, siem.ErrNotValid)
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:   "forward - device not found",
+			method: http.MethodPost,
+			url:    "/api/v1/admin/siem/forward/guid2",
+			mock: func(feature *mocks.MockSIEMFeature) {
+				feature.EXPECT().Forward(context.Background(), "guid2", "").Return(nil, siem.ErrNotFound)
+			},
+			expectedCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			feature, engine := siemTest(t)
+
+			tc.mock(feature)
+
+			req, err := http.NewRequest(tc.method, tc.url, http.NoBody)
+			if err != nil {
+				t.Fatalf("Couldn't create request: %v\n", err)
+			}
+
+			w := httptest.NewRecorder()
+
+			engine.ServeHTTP(w, req)
+
+			require.Equal(t, tc.expectedCode, w.Code)
+
+			if tc.expectedCode == http.StatusOK {
+				jsonBytes, _ := json.Marshal(tc.response)
+				require.Equal(t, string(jsonBytes), w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,181 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/snapshots"
+	"github.com/open-amt-cloud-toolkit/console/pkg/consoleerrors"
+	"github.com/open-amt-cloud-toolkit/console/pkg/logger"
+)
+
+var ErrValidationSnapshots = dto.NotValidError{Console: consoleerrors.CreateConsoleError("SnapshotsAPI")}
+
+type snapshotRoutes struct {
+	t snapshots.Feature
+	l logger.Interface
+}
+
+func NewSnapshotRoutes(handler *gin.RouterGroup, t snapshots.Feature, l logger.Interface) {
+	r := &snapshotRoutes{t, l}
+
+	h := handler.Group("/amt/snapshots")
+	{
+		h.GET("diff", r.diff)
+		h.POST(":guid", r.take)
+		h.GET(":guid", r.get)
+		h.GET(":guid/:id", r.getByID)
+		h.DELETE(":guid/:id", r.delete)
+	}
+}
+
+type SnapshotCountResponse struct {
+	Count int                  `json:"totalCount"`
+	Data  []dto.DeviceSnapshot `json:"data"`
+}
+
+// @Summary     Take Device Snapshot
+// @Description Read the version, features, network, certificate, TLS, general settings, hardware and boot capabilities of a device and store them as one snapshot
+// @ID          takeSnapshot
+// @Tags  	    snapshots
+// @Accept      json
+// @Produce     json
+// @Success     201 {object} dto.DeviceSnapshot
+// @Failure     404 {object} response
+// @Router      /api/v1/amt/snapshots/{guid} [post]
+func (r *snapshotRoutes) take(c *gin.Context) {
+	snapshot, err := r.t.Take(c.Request.Context(), c.Param("guid"), tenantID(c))
+	if err != nil {
+		r.l.Error(err, "http - v1 - takeSnapshot")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusCreated, snapshot)
+}
+
+// @Summary     Show Device Snapshots
+// @Description Show the snapshots of a device, newest first, without their sections
+// @ID          snapshots
+// @Tags  	    snapshots
+// @Accept      json
+// @Produce     json
+// @Success     200 {object} SnapshotCountResponse
+// @Failure     500 {object} response
+// @Router      /api/v1/amt/snapshots/{guid} [get]
+func (r *snapshotRoutes) get(c *gin.Context) {
+	var odata OData
+	if err := c.ShouldBindQuery(&odata); err != nil {
+		validationErr := ErrValidationSnapshots.Wrap("get", "ShouldBindQuery", err)
+		ErrorResponse(c, validationErr)
+
+		return
+	}
+
+	guid := c.Param("guid")
+
+	items, err := r.t.Get(c.Request.Context(), guid, odata.Top, odata.Skip, tenantID(c))
+	if err != nil {
+		r.l.Error(err, "http - v1 - getSnapshots")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	if odata.Count {
+		count, err := r.t.GetCount(c.Request.Context(), guid, tenantID(c))
+		if err != nil {
+			r.l.Error(err, "http - v1 - getCount")
+			ErrorResponse(c, err)
+
+			return
+		}
+
+		countResponse := SnapshotCountResponse{
+			Count: count,
+			Data:  items,
+		}
+
+		c.JSON(http.StatusOK, countResponse)
+	} else {
+		c.JSON(http.StatusOK, items)
+	}
+}
+
+// @Summary     Show Device Snapshot
+// @Description Show a snapshot of a device with its sections
+// @ID          getSnapshot
+// @Tags  	    snapshots
+// @Accept      json
+// @Produce     json
+// @Success     200 {object} dto.DeviceSnapshot
+// @Failure     404 {object} response
+// @Router      /api/v1/amt/snapshots/{guid}/{id} [get]
+func (r *snapshotRoutes) getByID(c *gin.Context) {
+	snapshot, err := r.t.GetByID(c.Request.Context(), c.Param("guid"), c.Param("id"), tenantID(c))
+	if err != nil {
+		r.l.Error(err, "http - v1 - getSnapshot")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, snapshot)
+}
+
+// @Summary     Compare Snapshots
+// @Description Compare two snapshots, of one device or of two devices, in every section or in the repeated sections given
+// @ID          diffSnapshots
+// @Tags  	    snapshots
+// @Accept      json
+// @Produce     json
+// @Param       left query string true "ID of the snapshot compared from"
+// @Param       right query string true "ID of the snapshot compared to"
+// @Param       sections query []string false "Sections to compare"
+// @Success     200 {object} dto.SnapshotDiff
+// @Failure     400 {object} response
+// @Failure     404 {object} response
+// @Router      /api/v1/amt/snapshots/diff [get]
+func (r *snapshotRoutes) diff(c *gin.Context) {
+	var query dto.SnapshotDiffQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		validationErr := ErrValidationSnapshots.Wrap("diff", "ShouldBindQuery", err)
+		ErrorResponse(c, validationErr)
+
+		return
+	}
+
+	diff, err := r.t.Diff(c.Request.Context(), query.Left, query.Right, query.Sections, tenantID(c))
+	if err != nil {
+		r.l.Error(err, "http - v1 - diffSnapshots")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, diff)
+}
+
+// @Summary     Remove Device Snapshot
+// @Description Remove a snapshot of a device
+// @ID          deleteSnapshot
+// @Tags  	    snapshots
+// @Accept      json
+// @Produce     json
+// @Success     204 {object} noContent
+// @Failure     404 {object} response
+// @Router      /api/v1/amt/snapshots/{guid}/{id} [delete]
+func (r *snapshotRoutes) delete(c *gin.Context) {
+	err := r.t.Delete(c.Request.Context(), c.Param("guid"), c.Param("id"), tenantID(c))
+	if err != nil {
+		r.l.Error(err, "http - v1 - deleteSnapshot")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
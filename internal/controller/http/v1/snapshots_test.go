@@ -0,0 +1,163 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	"github.com/open-amt-cloud-toolkit/console/internal/mocks"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/snapshots"
+	"github.com/open-amt-cloud-toolkit/console/pkg/logger"
+)
+
+func snapshotsTest(t *testing.T) (*mocks.MockSnapshotsFeature, *gin.Engine) {
+	t.Helper()
+
+	mockCtl := gomock.NewController(t)
+
+	log := logger.New("error")
+	feature := mocks.NewMockSnapshotsFeature(mockCtl)
+
+	engine := gin.New()
+	handler := engine.Group("/api/v1")
+
+	NewSnapshotRoutes(handler, feature, log)
+
+	return feature, engine
+}
+
+var (
+	deviceSnapshot = dto.DeviceSnapshot{
+		ID:       "s1",
+		GUID:     "guid1",
+		TakenAt:  time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC),
+		Sections: map[string]json.RawMessage{"version": json.RawMessage(`{"amt":"16.1.25"}`)},
+	}
+
+	snapshotDiff = dto.SnapshotDiff{
+		Left:     dto.DeviceSnapshot{ID: "s1", GUID: "guid1"},
+		Right:    dto.DeviceSnapshot{ID: "s2", GUID: "guid2"},
+		Sections: []string{"version", "features"},
+		Changes: []dto.SnapshotChange{
+			{Section: "version", Path: "amt", Kind: "changed", Left: json.RawMessage(`"16.1.25"`), Right: json.RawMessage(`"16.1.27"`)},
+		},
+	}
+)
+
+func TestSnapshotRoutes(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		method       string
+		url          string
+		mock         func(feature *mocks.MockSnapshotsFeature)
+		response     interface{}
+		expectedCode int
+	}{
+		{
+			name:   "take snapshot",
+			method: http.MethodPost,
+			url:    "/api/v1/amt/snapshots/guid1",
+			mock: func(feature *mocks.MockSnapshotsFeature) {
+				feature.EXPECT().Take(context.Background(), "guid1", "").Return(&deviceSnapshot, nil)
+			},
+			response:     deviceSnapshot,
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name:   "get snapshots - with count",
+			method: http.MethodGet,
+			url:    "/api/v1/amt/snapshots/guid1?$top=10&$count=true",
+			mock: func(feature *mocks.MockSnapshotsFeature) {
+				feature.EXPECT().Get(context.Background(), "guid1", 10, 0, "").Return([]dto.DeviceSnapshot{deviceSnapshot}, nil)
+				feature.EXPECT().GetCount(context.Background(), "guid1", "").Return(1, nil)
+			},
+			response:     SnapshotCountResponse{Count: 1, Data: []dto.DeviceSnapshot{deviceSnapshot}},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:   "get snapshot",
+			method: http.MethodGet,
+			url:    "/api/v1/amt/snapshots/guid1/s1",
+			mock: func(feature *mocks.MockSnapshotsFeature) {
+				feature.EXPECT().GetByID(context.Background(), "guid1", "s1", "").Return(&deviceSnapshot, nil)
+			},
+			response:     deviceSnapshot,
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:   "get snapshot - not found",
+			method: http.MethodGet,
+			url:    "/api/v1/amt/snapshots/guid2/s1",
+			mock: func(feature *mocks.MockSnapshotsFeature) {
+				feature.EXPECT().GetByID(context.Background(), "guid2", "s1", "").Return(nil, snapshots.ErrNotFound)
+			},
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:   "diff snapshots",
+			method: http.MethodGet,
+			url:    "/api/v1/amt/snapshots/diff?left=s1&right=s2&sections=version&sections=features",
+			mock: func(feature *mocks.MockSnapshotsFeature) {
+				feature.EXPECT().Diff(context.Background(), "s1", "s2", []string{"version", "features"}, "").Return(snapshotDiff, nil)
+			},
+			response:     snapshotDiff,
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:   "diff snapshots - unknown section",
+			method: http.MethodGet,
+			url:    "/api/v1/amt/snapshots/diff?left=s1&right=s2&sections=bios",
+			mock: func(feature *mocks.MockSnapshotsFeature) {
+				feature.EXPECT().Diff(context.Background(), "s1", "s2", []string{"bios"}, "").Return(dto.SnapshotDiff{}, snapshots.ErrNotValid)
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:   "delete snapshot",
+			method: http.MethodDelete,
+			url:    "/api/v1/amt/snapshots/guid1/s1",
+			mock: func(feature *mocks.MockSnapshotsFeature) {
+				feature.EXPECT().Delete(context.Background(), "guid1", "s1", "").Return(nil)
+			},
+			expectedCode: http.StatusNoContent,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			feature, engine := snapshotsTest(t)
+
+			tc.mock(feature)
+
+			req, err := http.NewRequest(tc.method, tc.url, http.NoBody)
+			if err != nil {
+				t.Fatalf("Couldn't create request: %v\n", err)
+			}
+
+			w := httptest.NewRecorder()
+
+			engine.ServeHTTP(w, req)
+
+			require.Equal(t, tc.expectedCode, w.Code)
+
+			if tc.expectedCode == http.StatusOK || tc.expectedCode == http.StatusCreated {
+				jsonBytes, _ := json.Marshal(tc.response)
+				require.Equal(t, string(jsonBytes), w.Body.String())
+			}
+		})
+	}
+}
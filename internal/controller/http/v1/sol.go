@@ -0,0 +1,120 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+)
+
+// @Summary     Open SOL Session
+// @Description Open a Serial-over-LAN session that the console holds with the device, or return the one already open. It is closed after ten minutes without use.
+// @ID          openSOL
+// @Tags  	    sol
+// @Produce     json
+// @Success     200 {object} dto.SOLSession
+// @Failure     404 {object} response
+// @Failure     500 {object} response
+// @Router      /api/v1/amt/sol/{guid} [post]
+func (r *deviceManagementRoutes) openSOL(c *gin.Context) {
+	session, err := r.d.OpenSOL(c.Request.Context(), c.Param("guid"))
+	if err != nil {
+		r.l.Error(err, "http - v1 - openSOL")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, session)
+}
+
+// @Summary     Show SOL Session
+// @Description Show the SOL session open with the device
+// @ID          getSOL
+// @Tags  	    sol
+// @Produce     json
+// @Success     200 {object} dto.SOLSession
+// @Failure     404 {object} response
+// @Router      /api/v1/amt/sol/{guid} [get]
+func (r *deviceManagementRoutes) getSOL(c *gin.Context) {
+	session, err := r.d.GetSOL(c.Request.Context(), c.Param("guid"))
+	if err != nil {
+		r.l.Error(err, "http - v1 - getSOL")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, session)
+}
+
+// @Summary     Send SOL Keys
+// @Description Type keys into the SOL session open with the device, escape sequences included
+// @ID          sendSOLKeys
+// @Tags  	    sol
+// @Accept      json
+// @Produce     json
+// @Param       request body dto.SOLKeys true "keys"
+// @Success     204 {object} nil
+// @Failure     400 {object} response
+// @Failure     404 {object} response
+// @Router      /api/v1/amt/sol/{guid}/keys [post]
+func (r *deviceManagementRoutes) sendSOLKeys(c *gin.Context) {
+	var keys dto.SOLKeys
+	if err := c.ShouldBindJSON(&keys); err != nil {
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	err := r.d.SendSOLKeys(c.Request.Context(), c.Param("guid"), keys.Keys)
+	if err != nil {
+		r.l.Error(err, "http - v1 - sendSOLKeys")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+// @Summary     Read SOL Screen
+// @Description Read the terminal screen of the SOL session open with the device
+// @ID          getSOLScreen
+// @Tags  	    sol
+// @Produce     json
+// @Success     200 {object} dto.SOLScreen
+// @Failure     404 {object} response
+// @Router      /api/v1/amt/sol/{guid}/screen [get]
+func (r *deviceManagementRoutes) getSOLScreen(c *gin.Context) {
+	screen, err := r.d.GetSOLScreen(c.Request.Context(), c.Param("guid"))
+	if err != nil {
+		r.l.Error(err, "http - v1 - getSOLScreen")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, screen)
+}
+
+// @Summary     Close SOL Session
+// @Description Close the SOL session open with the device
+// @ID          closeSOL
+// @Tags  	    sol
+// @Produce     json
+// @Success     204 {object} nil
+// @Failure     404 {object} response
+// @Router      /api/v1/amt/sol/{guid} [delete]
+func (r *deviceManagementRoutes) closeSOL(c *gin.Context) {
+	err := r.d.CloseSOL(c.Request.Context(), c.Param("guid"))
+	if err != nil {
+		r.l.Error(err, "http - v1 - closeSOL")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
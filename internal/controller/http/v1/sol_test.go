@@ -0,0 +1,158 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	"github.com/open-amt-cloud-toolkit/console/internal/mocks"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/devices"
+	"github.com/open-amt-cloud-toolkit/console/pkg/logger"
+)
+
+func solTest(t *testing.T) (*mocks.MockDeviceManagementFeature, *gin.Engine) {
+	t.Helper()
+
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+
+	log := logger.New("error")
+	deviceManagement := mocks.NewMockDeviceManagementFeature(mockCtl)
+	engine := gin.New()
+	handler := engine.Group("/api/v1")
+
+	NewRedirectionRoutes(handler, deviceManagement, mocks.NewMockImagesFeature(mockCtl), mocks.NewMockVNC(mockCtl), mocks.NewMockThumbnails(mockCtl), log)
+
+	return deviceManagement, engine
+}
+
+func TestSOLRoutes(t *testing.T) {
+	t.Parallel()
+
+	screen := dto.SOLScreen{Rows: []string{"login:"}, CursorRow: 0, CursorColumn: 7}
+
+	tests := []struct {
+		name         string
+		url          string
+		method       string
+		mock         func(m *mocks.MockDeviceManagementFeature)
+		requestBody  interface{}
+		expectedCode int
+		response     interface{}
+	}{
+		{
+			name:   "openSOL - successful",
+			url:    "/api/v1/amt/sol/valid-guid",
+			method: http.MethodPost,
+			mock: func(m *mocks.MockDeviceManagementFeature) {
+				m.EXPECT().OpenSOL(context.Background(), "valid-guid").
+					Return(dto.SOLSession{GUID: "valid-guid", State: dto.SOLStateConnected}, nil)
+			},
+			expectedCode: http.StatusOK,
+			response:     dto.SOLSession{GUID: "valid-guid", State: dto.SOLStateConnected},
+		},
+		{
+			name:   "openSOL - failed",
+			url:    "/api/v1/amt/sol/valid-guid",
+			method: http.MethodPost,
+			mock: func(m *mocks.MockDeviceManagementFeature) {
+				m.EXPECT().OpenSOL(context.Background(), "valid-guid").
+					Return(dto.SOLSession{}, ErrGeneral)
+			},
+			expectedCode: http.StatusInternalServerError,
+		},
+		{
+			name:   "getSOL - not found",
+			url:    "/api/v1/amt/sol/valid-guid",
+			method: http.MethodGet,
+			mock: func(m *mocks.MockDeviceManagementFeature) {
+				m.EXPECT().GetSOL(context.Background(), "valid-guid").
+					Return(dto.SOLSession{}, devices.ErrNotFound)
+			},
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:        "sendSOLKeys - successful",
+			url:         "/api/v1/amt/sol/valid-guid/keys",
+			method:      http.MethodPost,
+			requestBody: dto.SOLKeys{Keys: "root\r"},
+			mock: func(m *mocks.MockDeviceManagementFeature) {
+				m.EXPECT().SendSOLKeys(context.Background(), "valid-guid", "root\r").Return(nil)
+			},
+			expectedCode: http.StatusNoContent,
+		},
+		{
+			name:        "sendSOLKeys - not connected",
+			url:         "/api/v1/amt/sol/valid-guid/keys",
+			method:      http.MethodPost,
+			requestBody: dto.SOLKeys{Keys: "x"},
+			mock: func(m *mocks.MockDeviceManagementFeature) {
+				m.EXPECT().SendSOLKeys(context.Background(), "valid-guid", "x").
+					Return(devices.ErrNotValid)
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:   "getSOLScreen - successful",
+			url:    "/api/v1/amt/sol/valid-guid/screen",
+			method: http.MethodGet,
+			mock: func(m *mocks.MockDeviceManagementFeature) {
+				m.EXPECT().GetSOLScreen(context.Background(), "valid-guid").Return(screen, nil)
+			},
+			expectedCode: http.StatusOK,
+			response:     screen,
+		},
+		{
+			name:   "closeSOL - successful",
+			url:    "/api/v1/amt/sol/valid-guid",
+			method: http.MethodDelete,
+			mock: func(m *mocks.MockDeviceManagementFeature) {
+				m.EXPECT().CloseSOL(context.Background(), "valid-guid").Return(nil)
+			},
+			expectedCode: http.StatusNoContent,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			deviceManagement, engine := solTest(t)
+
+			tc.mock(deviceManagement)
+
+			var body io.Reader = http.NoBody
+			if tc.requestBody != nil {
+				reqBody, _ := json.Marshal(tc.requestBody)
+				body = bytes.NewBuffer(reqBody)
+			}
+
+			req, err := http.NewRequest(tc.method, tc.url, body)
+			if err != nil {
+				t.Fatalf("Couldn't create request: %v\n", err)
+			}
+
+			w := httptest.NewRecorder()
+
+			engine.ServeHTTP(w, req)
+
+			require.Equal(t, tc.expectedCode, w.Code)
+
+			if tc.expectedCode == http.StatusOK {
+				jsonBytes, _ := json.Marshal(tc.response)
+				require.Equal(t, string(jsonBytes), w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/tenants"
+	"github.com/open-amt-cloud-toolkit/console/pkg/consoleerrors"
+	"github.com/open-amt-cloud-toolkit/console/pkg/logger"
+	"github.com/open-amt-cloud-toolkit/console/pkg/tenant"
+)
+
+var ErrValidationTenants = dto.NotValidError{Console: consoleerrors.CreateConsoleError("TenantsAPI")}
+
+type tenantRoutes struct {
+	t tenants.Feature
+	l logger.Interface
+}
+
+// NewTenantRoutes registers the tenant routes, only admins of the default tenant may use them.
+func NewTenantRoutes(handler *gin.RouterGroup, t tenants.Feature, l logger.Interface) {
+	r := &tenantRoutes{t, l}
+
+	h := handler.Group("/tenants", requireDefaultTenant)
+	{
+		h.GET("", r.get)
+		h.POST("", r.insert)
+	}
+}
+
+type TenantCountResponse struct {
+	Count int          `json:"totalCount"`
+	Data  []dto.Tenant `json:"data"`
+}
+
+func requireDefaultTenant(c *gin.Context) {
+	if tenantID(c) != tenant.Default {
+		forbidden(c, "tenants")
+
+		return
+	}
+
+	c.Next()
+}
+
+// @Summary     Show Tenants
+// @Description Show all tenants
+// @ID          tenants
+// @Tags  	    tenants
+// @Accept      json
+// @Produce     json
+// @Success     200 {object} TenantCountResponse
+// @Failure     500 {object} response
+// @Router      /api/v1/admin/tenants [get]
+func (r *tenantRoutes) get(c *gin.Context) {
+	var odata OData
+	if err := c.ShouldBindQuery(&odata); err != nil {
+		validationErr := ErrValidationTenants.Wrap("get", "ShouldBindQuery", err)
+		ErrorResponse(c, validationErr)
+
+		return
+	}
+
+	items, err := r.t.Get(c.Request.Context(), odata.Top, odata.Skip)
+	if err != nil {
+		r.l.Error(err, "http - v1 - getTenants")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	if odata.Count {
+		count, err := r.t.GetCount(c.Request.Context())
+		if err != nil {
+			r.l.Error(err, "http - v1 - getCount")
+			ErrorResponse(c, err)
+
+			return
+		}
+
+		countResponse := TenantCountResponse{
+			Count: count,
+			Data:  items,
+		}
+
+		c.JSON(http.StatusOK, countResponse)
+	} else {
+		c.JSON(http.StatusOK, items)
+	}
+}
+
+// @Summary     Add Tenant
+// @Description Add a tenant, optionally with its first admin account
+// @ID          insertTenant
+// @Tags  	    tenants
+// @Accept      json
+// @Produce     json
+// @Success     201 {object} dto.Tenant
+// @Failure     500 {object} response
+// @Router      /api/v1/admin/tenants [post]
+func (r *tenantRoutes) insert(c *gin.Context) {
+	var t dto.Tenant
+	if err := c.ShouldBindJSON(&t); err != nil {
+		validationErr := ErrValidationTenants.Wrap("insert", "ShouldBindJSON", err)
+		ErrorResponse(c, validationErr)
+
+		return
+	}
+
+	newTenant, err := r.t.Insert(c.Request.Context(), &t)
+	if err != nil {
+		r.l.Error(err, "http - v1 - insert")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusCreated, newTenant)
+}
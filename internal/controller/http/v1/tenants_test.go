@@ -0,0 +1,141 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	"github.com/open-amt-cloud-toolkit/console/internal/mocks"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/tenants"
+	"github.com/open-amt-cloud-toolkit/console/pkg/logger"
+)
+
+func tenantsTest(t *testing.T, callerTenant string) (*mocks.MockTenantsFeature, *gin.Engine) {
+	t.Helper()
+
+	mockCtl := gomock.NewController(t)
+
+	log := logger.New("error")
+	tenant := mocks.NewMockTenantsFeature(mockCtl)
+
+	engine := gin.New()
+	handler := engine.Group("/api/v1/admin", func(c *gin.Context) { setTenant(c, callerTenant) })
+
+	NewTenantRoutes(handler, tenant, log)
+
+	return tenant, engine
+}
+
+var acmeTenant = dto.Tenant{ID: "acme", Name: "Acme Corp"}
+
+func TestTenantRoutes(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		method       string
+		url          string
+		callerTenant string
+		mock         func(tenant *mocks.MockTenantsFeature)
+		response     interface{}
+		requestBody  dto.Tenant
+		expectedCode int
+	}{
+		{
+			name:   "get all tenants",
+			method: http.MethodGet,
+			url:    "/api/v1/admin/tenants",
+			mock: func(tenant *mocks.MockTenantsFeature) {
+				tenant.EXPECT().Get(gomock.Any(), 25, 0).Return([]dto.Tenant{acmeTenant}, nil)
+			},
+			response:     []dto.Tenant{acmeTenant},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:   "get all tenants - with count",
+			method: http.MethodGet,
+			url:    "/api/v1/admin/tenants?$top=10&$skip=1&$count=true",
+			mock: func(tenant *mocks.MockTenantsFeature) {
+				tenant.EXPECT().Get(gomock.Any(), 10, 1).Return([]dto.Tenant{acmeTenant}, nil)
+				tenant.EXPECT().GetCount(gomock.Any()).Return(1, nil)
+			},
+			response:     TenantCountResponse{Count: 1, Data: []dto.Tenant{acmeTenant}},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:   "insert tenant",
+			method: http.MethodPost,
+			url:    "/api/v1/admin/tenants",
+			mock: func(tenant *mocks.MockTenantsFeature) {
+				tenant.EXPECT().Insert(gomock.Any(), &dto.Tenant{ID: "acme", Name: "Acme Corp", AdminUsername: "acme-admin", AdminPassword: "Str0ngP@ssword"}).
+					Return(&dto.Tenant{ID: "acme", Name: "Acme Corp", AdminUsername: "acme-admin"}, nil)
+			},
+			requestBody:  dto.Tenant{ID: "acme", Name: "Acme Corp", AdminUsername: "acme-admin", AdminPassword: "Str0ngP@ssword"},
+			response:     dto.Tenant{ID: "acme", Name: "Acme Corp", AdminUsername: "acme-admin"},
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name:   "insert tenant - not valid",
+			method: http.MethodPost,
+			url:    "/api/v1/admin/tenants",
+			mock: func(tenant *mocks.MockTenantsFeature) {
+				tenant.EXPECT().Insert(gomock.Any(), gomock.Any()).Return(nil, tenants.ErrNotValid)
+			},
+			requestBody:  dto.Tenant{Name: "Acme Corp"},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "caller of another tenant",
+			method:       http.MethodGet,
+			url:          "/api/v1/admin/tenants",
+			callerTenant: "acme",
+			mock:         func(_ *mocks.MockTenantsFeature) {},
+			response:     response{"missing permission: tenants"},
+			expectedCode: http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			tenantFeature, engine := tenantsTest(t, tc.callerTenant)
+
+			tc.mock(tenantFeature)
+
+			var req *http.Request
+
+			var err error
+
+			if tc.method == http.MethodPost {
+				reqBody, _ := json.Marshal(tc.requestBody)
+				req, err = http.NewRequestWithContext(context.Background(), tc.method, tc.url, bytes.NewBuffer(reqBody))
+			} else {
+				req, err = http.NewRequestWithContext(context.Background(), tc.method, tc.url, http.NoBody)
+			}
+
+			require.NoError(t, err)
+
+			w := httptest.NewRecorder()
+
+			engine.ServeHTTP(w, req)
+
+			require.Equal(t, tc.expectedCode, w.Code)
+
+			if tc.response != nil {
+				jsonBytes, _ := json.Marshal(tc.response)
+				require.Equal(t, string(jsonBytes), w.Body.String())
+			}
+		})
+	}
+}
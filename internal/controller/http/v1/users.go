@@ -0,0 +1,187 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/users"
+	"github.com/open-amt-cloud-toolkit/console/pkg/consoleerrors"
+	"github.com/open-amt-cloud-toolkit/console/pkg/logger"
+)
+
+var ErrValidationUsers = dto.NotValidError{Console: consoleerrors.CreateConsoleError("UsersAPI")}
+
+type userRoutes struct {
+	t users.Feature
+	l logger.Interface
+}
+
+func NewUserRoutes(handler *gin.RouterGroup, t users.Feature, l logger.Interface) {
+	r := &userRoutes{t, l}
+
+	h := handler.Group("/users")
+	{
+		h.GET("", r.get)
+		h.GET(":username", r.getByUsername)
+		h.POST("", r.insert)
+		h.PATCH("", r.update)
+		h.DELETE(":username", r.delete)
+	}
+}
+
+type UserCountResponse struct {
+	Count int        `json:"totalCount"`
+	Data  []dto.User `json:"data"`
+}
+
+// @Summary     Show Users
+// @Description Show all local user accounts
+// @ID          users
+// @Tags  	    users
+// @Accept      json
+// @Produce     json
+// @Success     200 {object} UserCountResponse
+// @Failure     500 {object} response
+// @Router      /api/v1/admin/users [get]
+func (r *userRoutes) get(c *gin.Context) {
+	var odata OData
+	if err := c.ShouldBindQuery(&odata); err != nil {
+		validationErr := ErrValidationUsers.Wrap("get", "ShouldBindQuery", err)
+		ErrorResponse(c, validationErr)
+
+		return
+	}
+
+	items, err := r.t.Get(c.Request.Context(), odata.Top, odata.Skip, tenantID(c))
+	if err != nil {
+		r.l.Error(err, "http - v1 - getUsers")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	if odata.Count {
+		count, err := r.t.GetCount(c.Request.Context(), tenantID(c))
+		if err != nil {
+			r.l.Error(err, "http - v1 - getCount")
+			ErrorResponse(c, err)
+
+			return
+		}
+
+		countResponse := UserCountResponse{
+			Count: count,
+			Data:  items,
+		}
+
+		c.JSON(http.StatusOK, countResponse)
+	} else {
+		c.JSON(http.StatusOK, items)
+	}
+}
+
+// @Summary     Show User
+// @Description Show a local user account by username
+// @ID          getUser
+// @Tags  	    users
+// @Accept      json
+// @Produce     json
+// @Success     200 {object} dto.User
+// @Failure     500 {object} response
+// @Router      /api/v1/admin/users/:username [get]
+func (r *userRoutes) getByUsername(c *gin.Context) {
+	item, err := r.t.GetByUsername(c.Request.Context(), c.Param("username"), tenantID(c))
+	if err != nil {
+		r.l.Error(err, "http - v1 - getByUsername")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, item)
+}
+
+// @Summary     Add User
+// @Description Add a local user account, grant it roles through /api/v1/admin/roles
+// @ID          insertUser
+// @Tags  	    users
+// @Accept      json
+// @Produce     json
+// @Success     201 {object} dto.User
+// @Failure     500 {object} response
+// @Router      /api/v1/admin/users [post]
+func (r *userRoutes) insert(c *gin.Context) {
+	var user dto.User
+	if err := c.ShouldBindJSON(&user); err != nil {
+		validationErr := ErrValidationUsers.Wrap("insert", "ShouldBindJSON", err)
+		ErrorResponse(c, validationErr)
+
+		return
+	}
+
+	user.TenantID = tenantID(c)
+
+	newUser, err := r.t.Insert(c.Request.Context(), &user)
+	if err != nil {
+		r.l.Error(err, "http - v1 - insert")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusCreated, newUser)
+}
+
+// @Summary     Edit User
+// @Description Reset the password of a local user account, force a password change or unlock it
+// @ID          updateUser
+// @Tags  	    users
+// @Accept      json
+// @Produce     json
+// @Success     200 {object} dto.User
+// @Failure     500 {object} response
+// @Router      /api/v1/admin/users [patch]
+func (r *userRoutes) update(c *gin.Context) {
+	var user dto.User
+	if err := c.ShouldBindJSON(&user); err != nil {
+		validationErr := ErrValidationUsers.Wrap("update", "ShouldBindJSON", err)
+		ErrorResponse(c, validationErr)
+
+		return
+	}
+
+	user.TenantID = tenantID(c)
+
+	updatedUser, err := r.t.Update(c.Request.Context(), &user)
+	if err != nil {
+		r.l.Error(err, "http - v1 - update")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, updatedUser)
+}
+
+// @Summary     Remove User
+// @Description Remove a local user account
+// @ID          deleteUser
+// @Tags  	    users
+// @Accept      json
+// @Produce     json
+// @Success     204 {object} noContent
+// @Failure     500 {object} response
+// @Router      /api/v1/admin/users/:username [delete]
+func (r *userRoutes) delete(c *gin.Context) {
+	err := r.t.Delete(c.Request.Context(), c.Param("username"), tenantID(c))
+	if err != nil {
+		r.l.Error(err, "http - v1 - delete")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
@@ -0,0 +1,176 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	"github.com/open-amt-cloud-toolkit/console/internal/mocks"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/users"
+	"github.com/open-amt-cloud-toolkit/console/pkg/logger"
+)
+
+func usersTest(t *testing.T) (*mocks.MockUsersFeature, *gin.Engine) {
+	t.Helper()
+
+	mockCtl := gomock.NewController(t)
+
+	log := logger.New("error")
+	user := mocks.NewMockUsersFeature(mockCtl)
+
+	engine := gin.New()
+	handler := engine.Group("/api/v1/admin")
+
+	NewUserRoutes(handler, user, log)
+
+	return user, engine
+}
+
+var mustChangePassword = true
+
+var localUser = dto.User{
+	Username:           "jane",
+	MustChangePassword: &mustChangePassword,
+}
+
+func TestUserRoutes(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		method       string
+		url          string
+		mock         func(user *mocks.MockUsersFeature)
+		response     interface{}
+		requestBody  dto.User
+		expectedCode int
+	}{
+		{
+			name:   "get all users",
+			method: http.MethodGet,
+			url:    "/api/v1/admin/users",
+			mock: func(user *mocks.MockUsersFeature) {
+				user.EXPECT().Get(context.Background(), 25, 0, "").Return([]dto.User{localUser}, nil)
+			},
+			response:     []dto.User{localUser},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:   "get all users - with count",
+			method: http.MethodGet,
+			url:    "/api/v1/admin/users?$top=10&$skip=1&$count=true",
+			mock: func(user *mocks.MockUsersFeature) {
+				user.EXPECT().Get(context.Background(), 10, 1, "").Return([]dto.User{localUser}, nil)
+				user.EXPECT().GetCount(context.Background(), "").Return(1, nil)
+			},
+			response:     UserCountResponse{Count: 1, Data: []dto.User{localUser}},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:   "get user by username",
+			method: http.MethodGet,
+			url:    "/api/v1/admin/users/jane",
+			mock: func(user *mocks.MockUsersFeature) {
+				user.EXPECT().GetByUsername(context.Background(), "jane", "").Return(&localUser, nil)
+			},
+			response:     localUser,
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:   "get user by username - not found",
+			method: http.MethodGet,
+			url:    "/api/v1/admin/users/john",
+			mock: func(user *mocks.MockUsersFeature) {
+				user.EXPECT().GetByUsername(context.Background(), "john", "").Return(nil, users.ErrNotFound)
+			},
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:   "insert user",
+			method: http.MethodPost,
+			url:    "/api/v1/admin/users",
+			mock: func(user *mocks.MockUsersFeature) {
+				user.EXPECT().Insert(context.Background(), &dto.User{Username: "jane", Password: "Str0ngP@ssword", MustChangePassword: &mustChangePassword}).Return(&localUser, nil)
+			},
+			requestBody:  dto.User{Username: "jane", Password: "Str0ngP@ssword", MustChangePassword: &mustChangePassword},
+			response:     localUser,
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name:   "insert user - password too short",
+			method: http.MethodPost,
+			url:    "/api/v1/admin/users",
+			mock: func(user *mocks.MockUsersFeature) {
+				user.EXPECT().Insert(context.Background(), gomock.Any()).Return(nil, users.ErrNotValid)
+			},
+			requestBody:  dto.User{Username: "jane", Password: "short"},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:   "unlock user",
+			method: http.MethodPatch,
+			url:    "/api/v1/admin/users",
+			mock: func(user *mocks.MockUsersFeature) {
+				user.EXPECT().Update(context.Background(), &dto.User{Username: "jane"}).Return(&dto.User{Username: "jane"}, nil)
+			},
+			requestBody:  dto.User{Username: "jane"},
+			response:     dto.User{Username: "jane"},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:   "delete user",
+			method: http.MethodDelete,
+			url:    "/api/v1/admin/users/jane",
+			mock: func(user *mocks.MockUsersFeature) {
+				user.EXPECT().Delete(context.Background(), "jane", "").Return(nil)
+			},
+			expectedCode: http.StatusNoContent,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			userFeature, engine := usersTest(t)
+
+			tc.mock(userFeature)
+
+			var req *http.Request
+
+			var err error
+
+			if tc.method == http.MethodPost || tc.method == http.MethodPatch {
+				reqBody, _ := json.Marshal(tc.requestBody)
+				req, err = http.NewRequest(tc.method, tc.url, bytes.NewBuffer(reqBody))
+			} else {
+				req, err = http.NewRequest(tc.method, tc.url, http.NoBody)
+			}
+
+			if err != nil {
+				t.Fatalf("Couldn't create request: %v\n", err)
+			}
+
+			w := httptest.NewRecorder()
+
+			engine.ServeHTTP(w, req)
+
+			require.Equal(t, tc.expectedCode, w.Code)
+
+			if tc.expectedCode == http.StatusOK || tc.expectedCode == http.StatusCreated {
+				jsonBytes, _ := json.Marshal(tc.response)
+				require.Equal(t, string(jsonBytes), w.Body.String())
+			}
+		})
+	}
+}
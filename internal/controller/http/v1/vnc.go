@@ -0,0 +1,36 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/devices"
+	"github.com/open-amt-cloud-toolkit/console/pkg/logger"
+)
+
+type vncRoutes struct {
+	v devices.VNC
+	l logger.Interface
+}
+
+// @Summary     Open KVM to a VNC Client
+// @Description Hand out a one-time password a standard VNC client authenticates to the console's VNC gateway with to open the KVM of the device. It works once and expires after a few minutes
+// @ID          allocateVNC
+// @Tags  	    redirection
+// @Produce     json
+// @Success     201 {object} dto.VNCAllocation
+// @Failure     400 {object} response
+// @Failure     404 {object} response
+// @Router      /api/v1/amt/vnc/{guid} [post]
+func (r *vncRoutes) allocate(c *gin.Context) {
+	allocation, err := r.v.AllocateVNC(c.Request.Context(), c.Param("guid"))
+	if err != nil {
+		r.l.Error(err, "http - v1 - allocateVNC")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusCreated, allocation)
+}
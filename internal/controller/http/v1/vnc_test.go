@@ -0,0 +1,103 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	"github.com/open-amt-cloud-toolkit/console/internal/mocks"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/devices"
+	"github.com/open-amt-cloud-toolkit/console/pkg/logger"
+)
+
+func vncTest(t *testing.T) (*mocks.MockVNC, *gin.Engine) {
+	t.Helper()
+
+	mockCtl := gomock.NewController(t)
+
+	vnc := mocks.NewMockVNC(mockCtl)
+	engine := gin.New()
+
+	NewRedirectionRoutes(engine.Group("/api/v1"), mocks.NewMockDeviceManagementFeature(mockCtl), mocks.NewMockImagesFeature(mockCtl), vnc, mocks.NewMockThumbnails(mockCtl), logger.New("error"))
+
+	return vnc, engine
+}
+
+func TestVNCRoutes(t *testing.T) {
+	t.Parallel()
+
+	allocation := dto.VNCAllocation{
+		GUID:      "valid-guid",
+		Address:   ":5900",
+		Password:  "q7Hk2mZp",
+		ExpiresAt: time.Date(2024, 12, 1, 0, 5, 0, 0, time.UTC),
+	}
+
+	tests := []struct {
+		name         string
+		url          string
+		mock         func(m *mocks.MockVNC)
+		expectedCode int
+		response     interface{}
+	}{
+		{
+			name: "allocate - successful",
+			url:  "/api/v1/amt/vnc/valid-guid",
+			mock: func(m *mocks.MockVNC) {
+				m.EXPECT().AllocateVNC(context.Background(), "valid-guid").Return(allocation, nil)
+			},
+			expectedCode: http.StatusCreated,
+			response:     allocation,
+		},
+		{
+			name: "allocate - unknown device",
+			url:  "/api/v1/amt/vnc/unknown-guid",
+			mock: func(m *mocks.MockVNC) {
+				m.EXPECT().AllocateVNC(context.Background(), "unknown-guid").Return(dto.VNCAllocation{}, devices.ErrNotFound)
+			},
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name: "allocate - gateway disabled",
+			url:  "/api/v1/amt/vnc/valid-guid",
+			mock: func(m *mocks.MockVNC) {
+				m.EXPECT().AllocateVNC(context.Background(), "valid-guid").Return(dto.VNCAllocation{}, devices.ErrNotValid)
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			vnc, engine := vncTest(t)
+
+			tc.mock(vnc)
+
+			req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, tc.url, http.NoBody)
+			require.NoError(t, err)
+
+			w := httptest.NewRecorder()
+
+			engine.ServeHTTP(w, req)
+
+			require.Equal(t, tc.expectedCode, w.Code)
+
+			if tc.response != nil {
+				jsonBytes, _ := json.Marshal(tc.response)
+				require.Equal(t, string(jsonBytes), w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,247 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/webhooks"
+	"github.com/open-amt-cloud-toolkit/console/pkg/consoleerrors"
+	"github.com/open-amt-cloud-toolkit/console/pkg/logger"
+)
+
+var ErrValidationWebhooks = dto.NotValidError{Console: consoleerrors.CreateConsoleError("WebhooksAPI")}
+
+type webhookRoutes struct {
+	t webhooks.Feature
+	l logger.Interface
+}
+
+func NewWebhookRoutes(handler *gin.RouterGroup, t webhooks.Feature, l logger.Interface) {
+	r := &webhookRoutes{t, l}
+
+	h := handler.Group("/webhooks")
+	{
+		h.GET("", r.get)
+		h.GET(":id", r.getByID)
+		h.GET(":id/deliveries", r.getDeliveries)
+		h.POST("", r.insert)
+		h.PATCH("", r.update)
+		h.DELETE(":id", r.delete)
+		h.POST(":id/test", r.test)
+	}
+}
+
+type WebhookCountResponse struct {
+	Count int           `json:"totalCount"`
+	Data  []dto.Webhook `json:"data"`
+}
+
+// @Summary     Show Webhooks
+// @Description Show all webhooks, secrets are never returned
+// @ID          webhooks
+// @Tags  	    webhooks
+// @Accept      json
+// @Produce     json
+// @Success     200 {object} WebhookCountResponse
+// @Failure     500 {object} response
+// @Router      /api/v1/admin/webhooks [get]
+func (r *webhookRoutes) get(c *gin.Context) {
+	var odata OData
+	if err := c.ShouldBindQuery(&odata); err != nil {
+		validationErr := ErrValidationWebhooks.Wrap("get", "ShouldBindQuery", err)
+		ErrorResponse(c, validationErr)
+
+		return
+	}
+
+	items, err := r.t.Get(c.Request.Context(), odata.Top, odata.Skip, tenantID(c))
+	if err != nil {
+		r.l.Error(err, "http - v1 - getWebhooks")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	if odata.Count {
+		count, err := r.t.GetCount(c.Request.Context(), tenantID(c))
+		if err != nil {
+			r.l.Error(err, "http - v1 - getCount")
+			ErrorResponse(c, err)
+
+			return
+		}
+
+		countResponse := WebhookCountResponse{
+			Count: count,
+			Data:  items,
+		}
+
+		c.JSON(http.StatusOK, countResponse)
+	} else {
+		c.JSON(http.StatusOK, items)
+	}
+}
+
+// @Summary     Show Webhook
+// @Description Show a webhook by id
+// @ID          getWebhook
+// @Tags  	    webhooks
+// @Accept      json
+// @Produce     json
+// @Success     200 {object} dto.Webhook
+// @Failure     500 {object} response
+// @Router      /api/v1/admin/webhooks/:id [get]
+func (r *webhookRoutes) getByID(c *gin.Context) {
+	id := c.Param("id")
+
+	item, err := r.t.GetByID(c.Request.Context(), id, tenantID(c))
+	if err != nil {
+		r.l.Error(err, "http - v1 - getByID")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, item)
+}
+
+// @Summary     Show Webhook Deliveries
+// @Description Show the delivery log of a webhook, one entry per attempt, newest first
+// @ID          getWebhookDeliveries
+// @Tags  	    webhooks
+// @Accept      json
+// @Produce     json
+// @Success     200 {object} []dto.WebhookDelivery
+// @Failure     500 {object} response
+// @Router      /api/v1/admin/webhooks/:id/deliveries [get]
+func (r *webhookRoutes) getDeliveries(c *gin.Context) {
+	id := c.Param("id")
+
+	var odata OData
+	if err := c.ShouldBindQuery(&odata); err != nil {
+		validationErr := ErrValidationWebhooks.Wrap("getDeliveries", "ShouldBindQuery", err)
+		ErrorResponse(c, validationErr)
+
+		return
+	}
+
+	deliveries, err := r.t.GetDeliveries(c.Request.Context(), id, odata.Top, odata.Skip, tenantID(c))
+	if err != nil {
+		r.l.Error(err, "http - v1 - getDeliveries")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, deliveries)
+}
+
+// @Summary     Add Webhook
+// @Description Add a webhook, the signing secret is generated when left empty and only returned in this response
+// @ID          insertWebhook
+// @Tags  	    webhooks
+// @Accept      json
+// @Produce     json
+// @Success     201 {object} dto.Webhook
+// @Failure     500 {object} response
+// @Router      /api/v1/admin/webhooks [post]
+func (r *webhookRoutes) insert(c *gin.Context) {
+	var webhook dto.Webhook
+	if err := c.ShouldBindJSON(&webhook); err != nil {
+		validationErr := ErrValidationWebhooks.Wrap("insert", "ShouldBindJSON", err)
+		ErrorResponse(c, validationErr)
+
+		return
+	}
+
+	webhook.TenantID = tenantID(c)
+
+	newWebhook, err := r.t.Insert(c.Request.Context(), &webhook)
+	if err != nil {
+		r.l.Error(err, "http - v1 - insert")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusCreated, newWebhook)
+}
+
+// @Summary     Edit Webhook
+// @Description Edit a webhook, an empty secret keeps the current one
+// @ID          updateWebhook
+// @Tags  	    webhooks
+// @Accept      json
+// @Produce     json
+// @Success     200 {object} dto.Webhook
+// @Failure     500 {object} response
+// @Router      /api/v1/admin/webhooks [patch]
+func (r *webhookRoutes) update(c *gin.Context) {
+	var webhook dto.Webhook
+	if err := c.ShouldBindJSON(&webhook); err != nil {
+		validationErr := ErrValidationWebhooks.Wrap("update", "ShouldBindJSON", err)
+		ErrorResponse(c, validationErr)
+
+		return
+	}
+
+	webhook.TenantID = tenantID(c)
+
+	updatedWebhook, err := r.t.Update(c.Request.Context(), &webhook)
+	if err != nil {
+		r.l.Error(err, "http - v1 - update")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, updatedWebhook)
+}
+
+// @Summary     Remove Webhook
+// @Description Remove a webhook and its delivery log
+// @ID          deleteWebhook
+// @Tags  	    webhooks
+// @Accept      json
+// @Produce     json
+// @Success     204 {object} noContent
+// @Failure     500 {object} response
+// @Router      /api/v1/admin/webhooks/:id [delete]
+func (r *webhookRoutes) delete(c *gin.Context) {
+	id := c.Param("id")
+
+	err := r.t.Delete(c.Request.Context(), id, tenantID(c))
+	if err != nil {
+		r.l.Error(err, "http - v1 - delete")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+// @Summary     Test Webhook
+// @Description Send a webhook.ping event to a webhook and return the outcome, a failed ping is logged but not retried
+// @ID          testWebhook
+// @Tags  	    webhooks
+// @Accept      json
+// @Produce     json
+// @Success     200 {object} dto.WebhookDelivery
+// @Failure     500 {object} response
+// @Router      /api/v1/admin/webhooks/:id/test [post]
+func (r *webhookRoutes) test(c *gin.Context) {
+	id := c.Param("id")
+
+	delivery, err := r.t.Test(c.Request.Context(), id, tenantID(c))
+	if err != nil {
+		r.l.Error(err, "http - v1 - test")
+		ErrorResponse(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, delivery)
+}
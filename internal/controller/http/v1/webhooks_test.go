@@ -0,0 +1,204 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	"github.com/open-amt-cloud-toolkit/console/internal/mocks"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/webhooks"
+	"github.com/open-amt-cloud-toolkit/console/pkg/logger"
+)
+
+func webhooksTest(t *testing.T) (*mocks.MockWebhooksFeature, *gin.Engine) {
+	t.Helper()
+
+	mockCtl := gomock.NewController(t)
+
+	log := logger.New("error")
+	webhook := mocks.NewMockWebhooksFeature(mockCtl)
+
+	engine := gin.New()
+	handler := engine.Group("/api/v1/admin", func(c *gin.Context) { setTenant(c, "tenant1") })
+
+	NewWebhookRoutes(handler, webhook, log)
+
+	return webhook, engine
+}
+
+var ticketingWebhook = dto.Webhook{
+	ID:         "hook-1",
+	Name:       "ticketing",
+	URL:        "https://hooks.example.com/console",
+	EventTypes: []string{dto.EventPowerAction},
+	Enabled:    true,
+	TenantID:   "tenant1",
+}
+
+func TestWebhookRoutes(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		method       string
+		url          string
+		mock         func(webhook *mocks.MockWebhooksFeature)
+		response     interface{}
+		requestBody  interface{}
+		expectedCode int
+	}{
+		{
+			name:   "get all webhooks",
+			method: http.MethodGet,
+			url:    "/api/v1/admin/webhooks",
+			mock: func(webhook *mocks.MockWebhooksFeature) {
+				webhook.EXPECT().Get(gomock.Any(), 25, 0, "tenant1").Return([]dto.Webhook{ticketingWebhook}, nil)
+			},
+			response:     []dto.Webhook{ticketingWebhook},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:   "get all webhooks - with count",
+			method: http.MethodGet,
+			url:    "/api/v1/admin/webhooks?$top=10&$skip=1&$count=true",
+			mock: func(webhook *mocks.MockWebhooksFeature) {
+				webhook.EXPECT().Get(gomock.Any(), 10, 1, "tenant1").Return([]dto.Webhook{ticketingWebhook}, nil)
+				webhook.EXPECT().GetCount(gomock.Any(), "tenant1").Return(1, nil)
+			},
+			response:     WebhookCountResponse{Count: 1, Data: []dto.Webhook{ticketingWebhook}},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:   "get webhook - not found",
+			method: http.MethodGet,
+			url:    "/api/v1/admin/webhooks/hook-2",
+			mock: func(webhook *mocks.MockWebhooksFeature) {
+				webhook.EXPECT().GetByID(gomock.Any(), "hook-2", "tenant1").Return(nil, webhooks.ErrNotFound)
+			},
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:   "get deliveries",
+			method: http.MethodGet,
+			url:    "/api/v1/admin/webhooks/hook-1/deliveries?$top=5",
+			mock: func(webhook *mocks.MockWebhooksFeature) {
+				webhook.EXPECT().GetDeliveries(gomock.Any(), "hook-1", 5, 0, "tenant1").
+					Return([]dto.WebhookDelivery{{ID: "delivery-1", WebhookID: "hook-1", Status: dto.DeliveryStatusSuccess}}, nil)
+			},
+			response:     []dto.WebhookDelivery{{ID: "delivery-1", WebhookID: "hook-1", Status: dto.DeliveryStatusSuccess}},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:   "insert webhook",
+			method: http.MethodPost,
+			url:    "/api/v1/admin/webhooks",
+			mock: func(webhook *mocks.MockWebhooksFeature) {
+				requested := ticketingWebhook
+				requested.ID = ""
+
+				created := ticketingWebhook
+				created.Secret = "generated"
+
+				webhook.EXPECT().Insert(gomock.Any(), &requested).Return(&created, nil)
+			},
+			requestBody: dto.Webhook{
+				Name:       ticketingWebhook.Name,
+				URL:        ticketingWebhook.URL,
+				EventTypes: ticketingWebhook.EventTypes,
+				Enabled:    true,
+			},
+			response: func() dto.Webhook {
+				created := ticketingWebhook
+				created.Secret = "generated"
+
+				return created
+			}(),
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name:   "insert webhook - unknown event type",
+			method: http.MethodPost,
+			url:    "/api/v1/admin/webhooks",
+			mock: func(webhook *mocks.MockWebhooksFeature) {
+				webhook.EXPECT().Insert(gomock.Any(), gomock.Any()).Return(nil, webhooks.ErrNotValid)
+			},
+			requestBody:  dto.Webhook{Name: "ticketing", URL: "https://hooks.example.com", EventTypes: []string{"device.exploded"}},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:   "update webhook",
+			method: http.MethodPatch,
+			url:    "/api/v1/admin/webhooks",
+			mock: func(webhook *mocks.MockWebhooksFeature) {
+				webhook.EXPECT().Update(gomock.Any(), &ticketingWebhook).Return(&ticketingWebhook, nil)
+			},
+			requestBody:  ticketingWebhook,
+			response:     ticketingWebhook,
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:   "delete webhook",
+			method: http.MethodDelete,
+			url:    "/api/v1/admin/webhooks/hook-1",
+			mock: func(webhook *mocks.MockWebhooksFeature) {
+				webhook.EXPECT().Delete(gomock.Any(), "hook-1", "tenant1").Return(nil)
+			},
+			expectedCode: http.StatusNoContent,
+		},
+		{
+			name:   "test webhook",
+			method: http.MethodPost,
+			url:    "/api/v1/admin/webhooks/hook-1/test",
+			mock: func(webhook *mocks.MockWebhooksFeature) {
+				webhook.EXPECT().Test(gomock.Any(), "hook-1", "tenant1").
+					Return(&dto.WebhookDelivery{ID: "delivery-1", EventType: dto.EventWebhookPing, Status: dto.DeliveryStatusSuccess, StatusCode: http.StatusOK}, nil)
+			},
+			response:     dto.WebhookDelivery{ID: "delivery-1", EventType: dto.EventWebhookPing, Status: dto.DeliveryStatusSuccess, StatusCode: http.StatusOK},
+			expectedCode: http.StatusOK,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			webhookFeature, engine := webhooksTest(t)
+
+			tc.mock(webhookFeature)
+
+			var req *http.Request
+
+			var err error
+
+			if tc.requestBody != nil {
+				reqBody, _ := json.Marshal(tc.requestBody)
+				req, err = http.NewRequestWithContext(context.Background(), tc.method, tc.url, bytes.NewBuffer(reqBody))
+			} else {
+				req, err = http.NewRequestWithContext(context.Background(), tc.method, tc.url, http.NoBody)
+			}
+
+			require.NoError(t, err)
+
+			w := httptest.NewRecorder()
+
+			engine.ServeHTTP(w, req)
+
+			require.Equal(t, tc.expectedCode, w.Code)
+
+			if tc.response != nil {
+				jsonBytes, _ := json.Marshal(tc.response)
+				require.Equal(t, string(jsonBytes), w.Body.String())
+			}
+		})
+	}
+}
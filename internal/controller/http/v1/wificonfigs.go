@@ -51,7 +51,7 @@ func (r *WirelessConfigRoutes) get(c *gin.Context) {
 		return
 	}
 
-	items, err := r.t.Get(c.Request.Context(), odata.Top, odata.Skip, "")
+	items, err := r.t.Get(c.Request.Context(), odata.Top, odata.Skip, tenantID(c))
 	if err != nil {
 		r.l.Error(err, "http - wireless configs - v1 - getCount")
 		ErrorResponse(c, err)
@@ -60,7 +60,7 @@ func (r *WirelessConfigRoutes) get(c *gin.Context) {
 	}
 
 	if odata.Count {
-		count, err := r.t.GetCount(c.Request.Context(), "")
+		count, err := r.t.GetCount(c.Request.Context(), tenantID(c))
 		if err != nil {
 			r.l.Error(err, "http - wireless configs - v1 - getCount")
 			ErrorResponse(c, err)
@@ -80,7 +80,7 @@ func (r *WirelessConfigRoutes) get(c *gin.Context) {
 func (r *WirelessConfigRoutes) getByName(c *gin.Context) {
 	profileName := c.Param("profileName")
 
-	config, err := r.t.GetByName(c.Request.Context(), profileName, "")
+	config, err := r.t.GetByName(c.Request.Context(), profileName, tenantID(c))
 	if err != nil {
 		r.l.Error(err, "http - wireless configs - v1 - getByName")
 		ErrorResponse(c, err)
@@ -100,6 +100,8 @@ func (r *WirelessConfigRoutes) insert(c *gin.Context) {
 		return
 	}
 
+	config.TenantID = tenantID(c)
+
 	insertedConfig, err := r.t.Insert(c.Request.Context(), &config)
 	if err != nil {
 		r.l.Error(err, "http - wireless configs - v1 - insert")
@@ -121,6 +123,8 @@ func (r *WirelessConfigRoutes) update(c *gin.Context) {
 		return
 	}
 
+	config.TenantID = tenantID(c)
+
 	updatedWirelessConfig, err := r.t.Update(c.Request.Context(), &config)
 	if err != nil {
 		r.l.Error(err, "http - wireless configs - v1 - update")
@@ -135,7 +139,7 @@ func (r *WirelessConfigRoutes) update(c *gin.Context) {
 func (r *WirelessConfigRoutes) delete(c *gin.Context) {
 	configName := c.Param("profileName")
 
-	err := r.t.Delete(c.Request.Context(), configName, "")
+	err := r.t.Delete(c.Request.Context(), configName, tenantID(c))
 	if err != nil {
 		r.l.Error(err, "http - wireless configs - v1 - delete")
 		ErrorResponse(c, err)
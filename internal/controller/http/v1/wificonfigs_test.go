@@ -124,10 +124,9 @@ func TestWiFiConfigRoutes(t *testing.T) {
 					PSKPassphrase:        "examplepassphrase",
 					ProfileName:          "newprofile",
 					LinkPolicy:           []int{1, 2, 3},
-					TenantID:             "tenant1",
 					Version:              "1.0",
 				}
-				wificonfig.EXPECT().Insert(context.Background(), wificonfigTest).Return(wificonfigTest, nil)
+				wificonfig.EXPECT().Insert(context.Background(), wificonfigTest).Return(&responseWiFiConfig, nil)
 			},
 			response:     responseWiFiConfig,
 			requestBody:  requestWiFiConfig,
@@ -146,7 +145,6 @@ func TestWiFiConfigRoutes(t *testing.T) {
 					PSKPassphrase:        "examplepassphrase",
 					ProfileName:          "newprofile",
 					LinkPolicy:           []int{1, 2, 3},
-					TenantID:             "tenant1",
 					Version:              "1.0",
 				}
 				wificonfig.EXPECT().Insert(context.Background(), wificonfigTest).Return(nil, wificonfigs.ErrDatabase)
@@ -168,7 +166,6 @@ func TestWiFiConfigRoutes(t *testing.T) {
 					PSKPassphrase:        "examplepassphrase",
 					ProfileName:          "newprofile",
 					LinkPolicy:           []int{1, 2, 3},
-					TenantID:             "tenant1",
 					Version:              "1.0",
 				}
 				wificonfig.EXPECT().Insert(context.Background(), wificonfigTest).Return(nil, wificonfigs.ErrDatabase)
@@ -210,10 +207,9 @@ func TestWiFiConfigRoutes(t *testing.T) {
 					PSKPassphrase:        "examplepassphrase",
 					ProfileName:          "newprofile",
 					LinkPolicy:           []int{1, 2, 3},
-					TenantID:             "tenant1",
 					Version:              "1.0",
 				}
-				wificonfig.EXPECT().Update(context.Background(), wificonfigTest).Return(wificonfigTest, nil)
+				wificonfig.EXPECT().Update(context.Background(), wificonfigTest).Return(&responseWiFiConfig, nil)
 			},
 			response:     responseWiFiConfig,
 			requestBody:  requestWiFiConfig,
@@ -232,7 +228,6 @@ func TestWiFiConfigRoutes(t *testing.T) {
 					PSKPassphrase:        "examplepassphrase",
 					ProfileName:          "newprofile",
 					LinkPolicy:           []int{1, 2, 3},
-					TenantID:             "tenant1",
 					Version:              "1.0",
 				}
 				wificonfig.EXPECT().Update(context.Background(), wificonfigTest).Return(nil, wificonfigs.ErrDatabase)
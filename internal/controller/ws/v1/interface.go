@@ -10,6 +10,7 @@ import (
 
 	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
 	dtov2 "github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v2"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/devices"
 )
 
 // Upgrader defines the interface for upgrading an HTTP connection to a WebSocket connection.
@@ -27,6 +28,7 @@ type Redirect interface {
 type Feature interface {
 	// Repository/Database Calls
 	GetCount(context.Context, string) (int, error)
+	GetConnectedCount(ctx context.Context, tenantID string) (int, error)
 	Get(ctx context.Context, top, skip int, tenantID string) ([]dto.Device, error)
 	GetByID(ctx context.Context, guid, tenantID string) (*dto.Device, error)
 	GetDistinctTags(ctx context.Context, tenantID string) ([]string, error)
@@ -50,13 +52,28 @@ type Feature interface {
 	GetUserConsentCode(ctx context.Context, guid string) (dto.GetUserConsentMessage, error)
 	SendConsentCode(ctx context.Context, code dto.UserConsentCode, guid string) (dto.UserConsentMessage, error)
 	SendPowerAction(ctx context.Context, guid string, action int) (power.PowerActionResponse, error)
+	SendBulkPowerAction(ctx context.Context, req dto.BulkPowerActionRequest, tenantID string) (dto.BulkPowerActionJob, error)
+	GetBulkPowerActionJob(ctx context.Context, jobID string) (dto.BulkPowerActionJob, error)
 	SetBootOptions(ctx context.Context, guid string, bootSetting dto.BootSetting) (power.PowerActionResponse, error)
 	GetAuditLog(ctx context.Context, startIndex int, guid string) (dto.AuditLog, error)
 	GetEventLog(ctx context.Context, startIndex, maxReadRecords int, guid string) (dto.EventLogs, error)
 	Redirect(ctx context.Context, conn *websocket.Conn, guid, mode string) error
+	GetRedirectionSessions(ctx context.Context, tenantID string) ([]dto.RedirectionSession, error)
+	TerminateRedirectionSession(ctx context.Context, id, tenantID string) error
+	Screenshot(ctx context.Context, guid string) ([]byte, error)
+	OpenSOL(ctx context.Context, guid string) (dto.SOLSession, error)
+	GetSOL(ctx context.Context, guid string) (dto.SOLSession, error)
+	SendSOLKeys(ctx context.Context, guid, keys string) error
+	GetSOLScreen(ctx context.Context, guid string) (dto.SOLScreen, error)
+	SubscribeSOL(ctx context.Context, guid string) (<-chan string, error)
+	CloseSOL(ctx context.Context, guid string) error
+	OpenIDER(ctx context.Context, guid string, media devices.IDERMedia, start string) (dto.IDERSession, error)
+	GetIDER(ctx context.Context, guid string) (dto.IDERSession, error)
+	CloseIDER(ctx context.Context, guid string) error
 	GetNetworkSettings(c context.Context, guid string) (dto.NetworkSettings, error)
 	GetCertificates(c context.Context, guid string) (dto.SecuritySettings, error)
 	GetTLSSettingData(c context.Context, guid string) ([]dto.SettingDataResponse, error)
 	GetDiskInfo(c context.Context, guid string) (interface{}, error)
+	GetInventory(c context.Context, guid string) (dto.DeviceInventory, error)
 	GetDeviceCertificate(c context.Context, guid string) (dto.Certificate, error)
 }
@@ -1,6 +1,8 @@
 package v1
 
 import (
+	"context"
+	"net"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -8,10 +10,22 @@ import (
 	"github.com/gorilla/websocket"
 
 	"github.com/open-amt-cloud-toolkit/console/config"
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
 	"github.com/open-amt-cloud-toolkit/console/internal/usecase/devices"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/roles"
+	"github.com/open-amt-cloud-toolkit/console/pkg/clientip"
 	"github.com/open-amt-cloud-toolkit/console/pkg/logger"
+	"github.com/open-amt-cloud-toolkit/console/pkg/subject"
+	"github.com/open-amt-cloud-toolkit/console/pkg/tenant"
 )
 
+// redirectionClaims are the claims of the token issued by /api/v1/authorize/redirection.
+type redirectionClaims struct {
+	Roles    []string `json:"roles,omitempty"`
+	TenantID string   `json:"tenant_id,omitempty"`
+	jwt.RegisteredClaims
+}
+
 type RedirectRoutes struct {
 	d devices.Feature
 	l logger.Interface
@@ -25,20 +39,28 @@ func RegisterRoutes(r *gin.Engine, l logger.Interface, t devices.Feature, u Upgr
 		u,
 	}
 	r.GET("/relay/webrelay.ashx", rr.websocketHandler)
+	r.GET("/relay/sol", rr.solHandler)
 }
 
-func (r *RedirectRoutes) websocketHandler(c *gin.Context) {
-	tokenString := c.GetHeader("Sec-Websocket-Protocol")
+// authorize checks the redirection token of a relay request. The context it returns scopes the request to the
+// tenant the token was issued for and attributes it to the token's subject and the client's address.
+func (r *RedirectRoutes) authorize(c *gin.Context, tokenString string) (context.Context, bool) {
+	proxies := tenant.ParseProxies(config.ConsoleConfig.TrustedProxies)
+	remoteIP := net.ParseIP(c.RemoteIP())
+
+	// without authentication only a trusted proxy can name the tenant
+	tenantID := proxies.FromRequest(c.Request, remoteIP, tenant.Default)
+	caller := ""
 
 	// validate jwt token in the Sec-Websocket-protocol header
 	if !config.ConsoleConfig.Disabled {
 		if tokenString == "" {
 			http.Error(c.Writer, "request does not contain an access token", http.StatusUnauthorized)
 
-			return
+			return nil, false
 		}
 
-		claims := &jwt.MapClaims{}
+		claims := &redirectionClaims{}
 
 		token, err := jwt.ParseWithClaims(tokenString, claims, func(_ *jwt.Token) (interface{}, error) {
 			return []byte(config.ConsoleConfig.Auth.JWTKey), nil
@@ -47,8 +69,37 @@ func (r *RedirectRoutes) websocketHandler(c *gin.Context) {
 		if err != nil || !token.Valid {
 			http.Error(c.Writer, "invalid access token", http.StatusUnauthorized)
 
-			return
+			return nil, false
+		}
+
+		if !roles.HasPermission(claims.Roles, dto.PermissionRedirect) {
+			http.Error(c.Writer, "missing permission: "+dto.PermissionRedirect, http.StatusForbidden)
+
+			return nil, false
+		}
+
+		// the same rule as the API: a trusted proxy only names the tenant of a token that has none
+		tenantID, err = proxies.FromToken(c.Request, remoteIP, claims.TenantID)
+		if err != nil {
+			http.Error(c.Writer, err.Error(), http.StatusForbidden)
+
+			return nil, false
 		}
+
+		caller = claims.Subject
+	}
+
+	ctx := subject.NewContext(tenant.NewContext(c.Request.Context(), tenantID), caller)
+
+	return clientip.NewContext(ctx, c.ClientIP()), true
+}
+
+func (r *RedirectRoutes) websocketHandler(c *gin.Context) {
+	tokenString := c.GetHeader("Sec-Websocket-Protocol")
+
+	ctx, ok := r.authorize(c, tokenString)
+	if !ok {
+		return
 	}
 
 	upgrader, ok := r.u.(*websocket.Upgrader)
@@ -67,9 +118,89 @@ func (r *RedirectRoutes) websocketHandler(c *gin.Context) {
 
 	r.l.Info("Websocket connection opened")
 
-	err = r.d.Redirect(c, conn, c.Query("host"), c.Query("mode"))
+	err = r.d.Redirect(ctx, conn, c.Query("host"), c.Query("mode"))
 	if err != nil {
 		r.l.Error(err, "http - devices - v1 - redirect")
 		errorResponse(c, http.StatusInternalServerError, "redirect failed")
 	}
 }
+
+// solHandler serves a SOL session that the console terminates as lines of text, for scripts rather than a
+// terminal: every text message received is typed followed by Enter and every line the device prints is sent
+// back as a text message. A session opened for the connection is closed with it.
+func (r *RedirectRoutes) solHandler(c *gin.Context) {
+	tokenString := c.GetHeader("Sec-Websocket-Protocol")
+
+	ctx, ok := r.authorize(c, tokenString)
+	if !ok {
+		return
+	}
+
+	guid := c.Query("host")
+
+	// no session of the device yet, this connection opens one and closes it when done
+	_, err := r.d.GetSOL(ctx, guid)
+	mustOpen := err != nil
+
+	if mustOpen {
+		if _, err := r.d.OpenSOL(ctx, guid); err != nil {
+			r.l.Error(err, "http - devices - v1 - sol")
+			errorResponse(c, http.StatusInternalServerError, "sol failed")
+
+			return
+		}
+
+		defer func() {
+			if err := r.d.CloseSOL(context.WithoutCancel(ctx), guid); err != nil {
+				r.l.Warn("http - devices - v1 - sol - close: " + err.Error())
+			}
+		}()
+	}
+
+	if upgrader, ok := r.u.(*websocket.Upgrader); ok && tokenString != "" {
+		upgrader.Subprotocols = []string{tokenString}
+	}
+
+	conn, err := r.u.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		http.Error(c.Writer, "Could not open websocket connection", http.StatusInternalServerError)
+
+		return
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	lines, err := r.d.SubscribeSOL(ctx, guid)
+	if err != nil {
+		r.l.Error(err, "http - devices - v1 - sol")
+
+		return
+	}
+
+	go func() {
+		defer cancel()
+
+		for {
+			messageType, msg, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+
+			if messageType != websocket.TextMessage {
+				continue
+			}
+
+			if err := r.d.SendSOLKeys(ctx, guid, string(msg)+"\r"); err != nil {
+				r.l.Warn("http - devices - v1 - sol - keys: " + err.Error())
+			}
+		}
+	}()
+
+	for line := range lines {
+		if err := conn.WriteMessage(websocket.TextMessage, []byte(line)); err != nil {
+			return
+		}
+	}
+}
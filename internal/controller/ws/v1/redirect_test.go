@@ -1,18 +1,24 @@
 package v1
 
 import (
+	"context"
 	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/gorilla/websocket"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 
 	"github.com/open-amt-cloud-toolkit/console/config"
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
 	"github.com/open-amt-cloud-toolkit/console/internal/mocks"
+	"github.com/open-amt-cloud-toolkit/console/pkg/subject"
+	"github.com/open-amt-cloud-toolkit/console/pkg/tenant"
 )
 
 var (
@@ -94,3 +100,160 @@ func TestWebSocketHandler(t *testing.T) { //nolint:paralleltest // logging libra
 		})
 	}
 }
+
+func TestWebSocketHandlerPermissions(t *testing.T) { //nolint:paralleltest // shares the global config with TestWebSocketHandler
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	_, _ = config.NewConfig()
+
+	config.ConsoleConfig.Disabled = false
+
+	t.Cleanup(func() { config.ConsoleConfig.Disabled = true })
+
+	sign := func(roles ...string) string {
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, redirectionClaims{
+			Roles:            roles,
+			RegisteredClaims: jwt.RegisteredClaims{ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute))},
+		})
+
+		tokenString, err := token.SignedString([]byte(config.ConsoleConfig.Auth.JWTKey))
+		assert.NoError(t, err)
+
+		return tokenString
+	}
+
+	tests := []struct {
+		name           string
+		token          string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "no token",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "token signed with another key",
+			token:          "eyJhbGciOiJIUzI1NiJ9.e30.ZRrHA1JJJW8opsbCGfG_HACGpVUMN_a9IV7pAx_Zmeo",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "viewer cannot open a session",
+			token:          sign(dto.RoleViewer),
+			expectedStatus: http.StatusForbidden,
+			expectedBody:   "missing permission: redirection\n",
+		},
+	}
+
+	for _, tc := range tests { //nolint:paralleltest // shares the global config with TestWebSocketHandler
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			r := gin.New()
+			RegisterRoutes(r, mocks.NewMockLogger(ctrl), mocks.NewMockFeature(ctrl), mocks.NewMockUpgrader(ctrl))
+
+			req := httptest.NewRequest(http.MethodGet, "/relay/webrelay.ashx?host=someHost&mode=someMode", http.NoBody)
+			if tc.token != "" {
+				req.Header.Set("Sec-Websocket-Protocol", tc.token)
+			}
+
+			w := httptest.NewRecorder()
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expectedStatus, w.Code)
+
+			if tc.expectedBody != "" {
+				assert.Equal(t, tc.expectedBody, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestWebSocketHandlerTenant(t *testing.T) { //nolint:paralleltest // shares the global config with TestWebSocketHandler
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	_, _ = config.NewConfig()
+
+	config.ConsoleConfig.Disabled = false
+
+	t.Cleanup(func() { config.ConsoleConfig.Disabled = true })
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, redirectionClaims{
+		Roles:            []string{dto.RoleRedirection},
+		TenantID:         "acme",
+		RegisteredClaims: jwt.RegisteredClaims{Subject: "alice", ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute))},
+	})
+
+	tokenString, err := token.SignedString([]byte(config.ConsoleConfig.Auth.JWTKey))
+	assert.NoError(t, err)
+
+	mockFeature := mocks.NewMockFeature(ctrl)
+	mockUpgrader := mocks.NewMockUpgrader(ctrl)
+	mockLogger := mocks.NewMockLogger(ctrl)
+
+	mockUpgrader.EXPECT().Upgrade(gomock.Any(), gomock.Any(), nil).Return(&websocket.Conn{}, nil)
+	mockLogger.EXPECT().Debug("failed to cast Upgrader to *websocket.Upgrader")
+	mockLogger.EXPECT().Info("Websocket connection opened")
+	mockFeature.EXPECT().
+		Redirect(gomock.Any(), gomock.Any(), "someHost", "someMode").
+		DoAndReturn(func(ctx context.Context, _ *websocket.Conn, _, _ string) error {
+			// the device is looked up in the tenant of the token, not the one in the header
+			assert.Equal(t, "acme", tenant.FromContext(ctx))
+			assert.Equal(t, "alice", subject.FromContext(ctx))
+
+			return nil
+		})
+
+	r := gin.New()
+	RegisterRoutes(r, mockLogger, mockFeature, mockUpgrader)
+
+	req := httptest.NewRequest(http.MethodGet, "/relay/webrelay.ashx?host=someHost&mode=someMode", http.NoBody)
+	req.Header.Set("Sec-Websocket-Protocol", tokenString)
+	req.Header.Set(tenant.Header, "globex")
+
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestWebSocketHandlerTenantMismatch(t *testing.T) { //nolint:paralleltest // shares the global config with TestWebSocketHandler
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	_, _ = config.NewConfig()
+
+	config.ConsoleConfig.Disabled = false
+	// httptest requests come from 192.0.2.1
+	config.ConsoleConfig.TrustedProxies = []string{"192.0.2.0/24"}
+
+	t.Cleanup(func() {
+		config.ConsoleConfig.Disabled = true
+		config.ConsoleConfig.TrustedProxies = []string{}
+	})
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, redirectionClaims{
+		Roles:            []string{dto.RoleRedirection},
+		TenantID:         "acme",
+		RegisteredClaims: jwt.RegisteredClaims{Subject: "alice", ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute))},
+	})
+
+	tokenString, err := token.SignedString([]byte(config.ConsoleConfig.Auth.JWTKey))
+	assert.NoError(t, err)
+
+	r := gin.New()
+	RegisterRoutes(r, mocks.NewMockLogger(ctrl), mocks.NewMockFeature(ctrl), mocks.NewMockUpgrader(ctrl))
+
+	req := httptest.NewRequest(http.MethodGet, "/relay/webrelay.ashx?host=someHost&mode=someMode", http.NoBody)
+	req.Header.Set("Sec-Websocket-Protocol", tokenString)
+	req.Header.Set(tenant.Header, "globex")
+
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
@@ -0,0 +1,37 @@
+package entity
+
+type AlertRule struct {
+	Name         string
+	Severity     string
+	Entity       string
+	GUID         string
+	Notifiers    string
+	Enabled      bool
+	CreationDate string
+	TenantID     string
+}
+
+type Alert struct {
+	ID             string
+	RuleName       string
+	GUID           string
+	Fingerprint    string
+	Severity       string
+	Entity         string
+	Description    string
+	EventTime      string
+	RaisedAt       string
+	Status         string
+	AcknowledgedBy string
+	AcknowledgedAt string
+	ClosedBy       string
+	ClosedAt       string
+	TenantID       string
+}
+
+type AlertFilter struct {
+	Status   string
+	GUID     string
+	RuleName string
+	TenantID string
+}
@@ -0,0 +1,40 @@
+package entity
+
+type AMTAuditRecord struct {
+	GUID           string
+	Fingerprint    string
+	AuditAppID     int
+	AuditApp       string
+	EventID        int
+	Event          string
+	InitiatorType  int
+	Initiator      string
+	Time           string
+	MCLocationType int
+	NetAddress     string
+	ExtendedData   string
+	Description    string
+	CollectedAt    string
+	TenantID       string
+}
+
+type AMTAuditFilter struct {
+	GUID      string
+	AuditApp  string
+	Event     string
+	Initiator string
+	From      string
+	To        string
+	TenantID  string
+}
+
+type AMTAuditCursor struct {
+	GUID            string
+	LastIndex       int
+	LastFingerprint string
+	LastTime        string
+	CollectedAt     string
+	Collected       int
+	Error           string
+	TenantID        string
+}
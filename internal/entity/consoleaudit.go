@@ -0,0 +1,25 @@
+package entity
+
+type ConsoleAuditEntry struct {
+	ID         string
+	Time       string
+	Subject    string
+	Method     string
+	Route      string
+	Path       string
+	Target     string
+	Summary    string
+	StatusCode int
+	Result     string
+	DurationMS int64
+	ClientIP   string
+	TenantID   string
+}
+
+type ConsoleAuditFilter struct {
+	Subject  string
+	Target   string
+	From     string
+	To       string
+	TenantID string
+}
@@ -0,0 +1,29 @@
+package entity
+
+type DeviceEvent struct {
+	GUID            string
+	Fingerprint     string
+	Time            string
+	Severity        string
+	Entity          string
+	EntityInstance  int
+	SensorType      int
+	SensorNumber    int
+	EventType       int
+	EventOffset     int
+	EventSourceType int
+	DeviceAddress   int
+	EventData       string
+	Description     string
+	CollectedAt     string
+	TenantID        string
+}
+
+type DeviceEventFilter struct {
+	GUID     string
+	Severity string
+	Entity   string
+	From     string
+	To       string
+	TenantID string
+}
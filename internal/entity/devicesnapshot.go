@@ -0,0 +1,10 @@
+package entity
+
+type DeviceSnapshot struct {
+	ID       string
+	GUID     string
+	TakenAt  string
+	Sections string
+	Errors   string
+	TenantID string
+}
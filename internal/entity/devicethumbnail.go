@@ -0,0 +1,8 @@
+package entity
+
+type DeviceThumbnail struct {
+	GUID       string
+	CapturedAt string
+	Image      []byte
+	TenantID   string
+}
@@ -0,0 +1,49 @@
+package dto
+
+import "time"
+
+const (
+	AlertStatusOpen         = "open"
+	AlertStatusAcknowledged = "acknowledged"
+	AlertStatusClosed       = "closed"
+)
+
+// AlertRule raises an alert for every event collected from the fleet that is at least as severe as Severity and
+// comes from Entity, on the device GUID when set. A condition left empty matches every event, and only events
+// that happened after the rule was created raise alerts.
+type AlertRule struct {
+	Name         string    `json:"name" binding:"required" example:"bios-critical"`
+	Severity     string    `json:"severity,omitempty" example:"critical"`
+	Entity       string    `json:"entity,omitempty" example:"BIOS"`
+	GUID         string    `json:"guid,omitempty" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Notifiers    []string  `json:"notifiers" example:"log,webhook"`
+	Enabled      bool      `json:"enabled" example:"true"`
+	CreationDate time.Time `json:"creationDate,omitempty" example:"2024-12-01T00:00:00Z"`
+	TenantID     string    `json:"tenantId" example:"abc123"`
+}
+
+// Alert is raised by a rule for an event of a device, it stays open until acknowledged or closed.
+type Alert struct {
+	ID             string     `json:"id" example:"5f8a4b1e-7c3d-4e2a-9b6f-1a2b3c4d5e6f"`
+	RuleName       string     `json:"ruleName" example:"bios-critical"`
+	GUID           string     `json:"guid" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Severity       string     `json:"severity" example:"critical"`
+	Entity         string     `json:"entity" example:"BIOS"`
+	Description    string     `json:"description" example:"System firmware error"`
+	EventTime      time.Time  `json:"eventTime" example:"2024-12-01T00:00:00Z"`
+	RaisedAt       time.Time  `json:"raisedAt" example:"2024-12-01T00:05:00Z"`
+	Status         string     `json:"status" example:"open"`
+	AcknowledgedBy string     `json:"acknowledgedBy,omitempty" example:"alice"`
+	AcknowledgedAt *time.Time `json:"acknowledgedAt,omitempty" example:"2024-12-01T01:00:00Z"`
+	ClosedBy       string     `json:"closedBy,omitempty" example:"alice"`
+	ClosedAt       *time.Time `json:"closedAt,omitempty" example:"2024-12-01T02:00:00Z"`
+	TenantID       string     `json:"tenantId" example:"abc123"`
+}
+
+// AlertFilter selects alerts, a filter left empty matches every alert.
+type AlertFilter struct {
+	Status   string `form:"status"`
+	GUID     string `form:"guid"`
+	RuleName string `form:"ruleName"`
+	TenantID string `form:"-"`
+}
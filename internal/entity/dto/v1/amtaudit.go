@@ -0,0 +1,43 @@
+package dto
+
+import "time"
+
+// AMTAuditRecord is an AMT audit log record collected from a device, kept after the device's log rolls over.
+type AMTAuditRecord struct {
+	GUID           string    `json:"guid" example:"123e4567-e89b-12d3-a456-426614174000"`
+	AuditAppID     int       `json:"auditAppId" example:"16"`
+	AuditApp       string    `json:"auditApp" example:"Security Admin"`
+	EventID        int       `json:"eventId" example:"0"`
+	Event          string    `json:"event" example:"Provisioning Started"`
+	InitiatorType  int       `json:"initiatorType" example:"0"`
+	Initiator      string    `json:"initiator" example:"admin"`
+	Time           time.Time `json:"time" example:"2024-12-01T00:00:00Z"`
+	MCLocationType int       `json:"mcLocationType" example:"0"`
+	NetAddress     string    `json:"netAddress" example:"10.0.0.5"`
+	ExtendedData   string    `json:"extendedData,omitempty" example:"AAE="`
+	Description    string    `json:"description,omitempty" example:"Remote WSMAN"`
+	CollectedAt    time.Time `json:"collectedAt" example:"2024-12-01T00:05:00Z"`
+	TenantID       string    `json:"tenantId" example:"abc123"`
+}
+
+// AMTAuditFilter selects collected audit records, a filter left empty matches every record.
+type AMTAuditFilter struct {
+	GUID      string     `form:"guid"`
+	AuditApp  string     `form:"auditApp"`
+	Event     string     `form:"event"`
+	Initiator string     `form:"initiator"`
+	From      *time.Time `form:"from" time_format:"2006-01-02T15:04:05Z07:00"`
+	To        *time.Time `form:"to" time_format:"2006-01-02T15:04:05Z07:00"`
+	TenantID  string     `form:"-"`
+}
+
+// AMTAuditCursor is how far the audit log of a device has been collected.
+type AMTAuditCursor struct {
+	GUID        string    `json:"guid" example:"123e4567-e89b-12d3-a456-426614174000"`
+	LastIndex   int       `json:"lastIndex" example:"42"`
+	LastTime    time.Time `json:"lastTime" example:"2024-12-01T00:00:00Z"`
+	CollectedAt time.Time `json:"collectedAt" example:"2024-12-01T00:05:00Z"`
+	Collected   int       `json:"collected" example:"3"`
+	Error       string    `json:"error,omitempty" example:""`
+	TenantID    string    `json:"tenantId" example:"abc123"`
+}
@@ -0,0 +1,40 @@
+package dto
+
+import "time"
+
+const (
+	BulkJobStatusRunning   = "running"
+	BulkJobStatusCompleted = "completed"
+
+	BulkResultStatusPending = "pending"
+	BulkResultStatusSuccess = "success"
+	BulkResultStatusFailed  = "failed"
+)
+
+type BulkPowerActionRequest struct {
+	Action         int      `json:"action" binding:"required" example:"8"`
+	GUIDs          []string `json:"guids,omitempty" binding:"required_without=Tags"`
+	Tags           []string `json:"tags,omitempty" binding:"required_without=GUIDs"`
+	Method         string   `json:"method,omitempty" binding:"omitempty,oneof=AND OR" example:"OR"`
+	MaxConcurrency int      `json:"maxConcurrency,omitempty" binding:"omitempty,min=1,max=50" example:"10"`
+	Async          bool     `json:"async,omitempty" example:"false"`
+}
+
+type BulkPowerActionResult struct {
+	GUID        string `json:"guid"`
+	Status      string `json:"status" example:"success"`
+	ReturnValue int    `json:"returnValue"`
+	Error       string `json:"error,omitempty"`
+}
+
+type BulkPowerActionJob struct {
+	ID          string                  `json:"id"`
+	Action      int                     `json:"action"`
+	Status      string                  `json:"status" example:"completed"`
+	Total       int                     `json:"total"`
+	Succeeded   int                     `json:"succeeded"`
+	Failed      int                     `json:"failed"`
+	CreatedAt   time.Time               `json:"createdAt"`
+	CompletedAt *time.Time              `json:"completedAt,omitempty"`
+	Results     []BulkPowerActionResult `json:"results"`
+}
@@ -0,0 +1,34 @@
+package dto
+
+import "time"
+
+const (
+	AuditResultSuccess = "success"
+	AuditResultDenied  = "denied"
+	AuditResultFailure = "failure"
+)
+
+// ConsoleAuditEntry records one request a console user made, it is unrelated to the audit log kept by AMT.
+type ConsoleAuditEntry struct {
+	ID         string    `json:"id" example:"3c9b1a5e-5d1f-4a2c-9b53-0c8f3a3d2d1e"`
+	Time       time.Time `json:"time" example:"2024-12-01T00:00:00Z"`
+	Subject    string    `json:"subject" example:"standalone"`
+	Method     string    `json:"method" example:"POST"`
+	Route      string    `json:"route" example:"/api/v1/amt/power/action/:guid"`
+	Path       string    `json:"path" example:"/api/v1/amt/power/action/123e4567-e89b-12d3-a456-426614174000"`
+	Target     string    `json:"target,omitempty" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Summary    string    `json:"summary,omitempty" example:"{\"action\":8}"`
+	StatusCode int       `json:"statusCode" example:"200"`
+	Result     string    `json:"result" example:"success"`
+	DurationMS int64     `json:"durationMs" example:"1250"`
+	ClientIP   string    `json:"clientIp,omitempty" example:"10.0.0.5"`
+	TenantID   string    `json:"tenantId" example:"abc123"`
+}
+
+type ConsoleAuditFilter struct {
+	Subject  string     `form:"subject"`
+	Target   string     `form:"target"`
+	From     *time.Time `form:"from" time_format:"2006-01-02T15:04:05Z07:00"`
+	To       *time.Time `form:"to" time_format:"2006-01-02T15:04:05Z07:00"`
+	TenantID string     `form:"-"`
+}
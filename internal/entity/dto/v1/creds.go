@@ -3,4 +3,5 @@ package dto
 type Credentials struct {
 	Username string `json:"username"`
 	Password string `json:"password"`
+	TenantID string `json:"tenantId,omitempty"`
 }
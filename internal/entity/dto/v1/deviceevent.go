@@ -0,0 +1,63 @@
+package dto
+
+import "time"
+
+// Severities of the AMT event log, from the least to the most severe.
+const (
+	EventSeverityUnspecified    = "unspecified"
+	EventSeverityMonitor        = "monitor"
+	EventSeverityInformation    = "information"
+	EventSeverityOK             = "ok"
+	EventSeverityNonCritical    = "non-critical"
+	EventSeverityCritical       = "critical"
+	EventSeverityNonRecoverable = "non-recoverable"
+)
+
+// EventSeverities lists the severities in increasing order.
+var EventSeverities = []string{
+	EventSeverityUnspecified,
+	EventSeverityMonitor,
+	EventSeverityInformation,
+	EventSeverityOK,
+	EventSeverityNonCritical,
+	EventSeverityCritical,
+	EventSeverityNonRecoverable,
+}
+
+// DeviceEvent is an AMT event log record collected from a device, kept after the device's log rolls over.
+type DeviceEvent struct {
+	GUID            string    `json:"guid" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Time            time.Time `json:"time" example:"2024-12-01T00:00:00Z"`
+	Severity        string    `json:"severity" example:"critical"`
+	Entity          string    `json:"entity" example:"BIOS"`
+	EntityInstance  int       `json:"entityInstance" example:"0"`
+	SensorType      int       `json:"sensorType" example:"15"`
+	SensorNumber    int       `json:"sensorNumber" example:"255"`
+	EventType       int       `json:"eventType" example:"111"`
+	EventOffset     int       `json:"eventOffset" example:"2"`
+	EventSourceType int       `json:"eventSourceType" example:"104"`
+	DeviceAddress   int       `json:"deviceAddress" example:"255"`
+	EventData       []int     `json:"eventData" example:"64,7,0,0,0,0,0,0"`
+	Description     string    `json:"description" example:"PCI resource configuration"`
+	CollectedAt     time.Time `json:"collectedAt" example:"2024-12-01T00:05:00Z"`
+	TenantID        string    `json:"tenantId" example:"abc123"`
+}
+
+// DeviceEventFilter selects collected event records, a filter left empty matches every record.
+type DeviceEventFilter struct {
+	GUID     string     `form:"guid"`
+	Severity string     `form:"severity"`
+	Entity   string     `form:"entity"`
+	From     *time.Time `form:"from" time_format:"2006-01-02T15:04:05Z07:00"`
+	To       *time.Time `form:"to" time_format:"2006-01-02T15:04:05Z07:00"`
+	TenantID string     `form:"-"`
+}
+
+// EventLogCollection is the outcome of reading the event log of a device into the store.
+type EventLogCollection struct {
+	GUID        string    `json:"guid" example:"123e4567-e89b-12d3-a456-426614174000"`
+	CollectedAt time.Time `json:"collectedAt" example:"2024-12-01T00:05:00Z"`
+	Read        int       `json:"read" example:"42"`
+	Collected   int       `json:"collected" example:"3"`
+	Alerts      int       `json:"alerts" example:"1"`
+}
@@ -0,0 +1,61 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// The sections of a device snapshot, each the response of the device management call of the same name.
+const (
+	SnapshotSectionVersion          = "version"
+	SnapshotSectionFeatures         = "features"
+	SnapshotSectionNetworkSettings  = "networkSettings"
+	SnapshotSectionCertificates     = "certificates"
+	SnapshotSectionTLSSettingData   = "tlsSettingData"
+	SnapshotSectionGeneralSettings  = "generalSettings"
+	SnapshotSectionHardwareInfo     = "hardwareInfo"
+	SnapshotSectionBootCapabilities = "bootCapabilities"
+)
+
+// DeviceSnapshot is the configuration of a device at a point in time. A section the device could not be read for
+// is left out of Sections and its error kept in Errors.
+type DeviceSnapshot struct {
+	ID       string                     `json:"id" example:"a7c6c1c0-8e1b-4a5b-9a3f-2f5b1e1c2d3e"`
+	GUID     string                     `json:"guid" example:"123e4567-e89b-12d3-a456-426614174000"`
+	TakenAt  time.Time                  `json:"takenAt" example:"2024-12-01T00:00:00Z"`
+	Sections map[string]json.RawMessage `json:"sections,omitempty"`
+	Errors   map[string]string          `json:"errors,omitempty"`
+	TenantID string                     `json:"tenantId" example:"abc123"`
+}
+
+const (
+	SnapshotChangeAdded   = "added"
+	SnapshotChangeRemoved = "removed"
+	SnapshotChangeChanged = "changed"
+)
+
+// SnapshotChange is a value that differs between two snapshots. Path is the dotted path of the value in its
+// section, with list items as [index].
+type SnapshotChange struct {
+	Section string          `json:"section" example:"networkSettings"`
+	Path    string          `json:"path" example:"wired.ipAddress"`
+	Kind    string          `json:"kind" example:"changed"`
+	Left    json.RawMessage `json:"left,omitempty"`
+	Right   json.RawMessage `json:"right,omitempty"`
+}
+
+// SnapshotDiff lists the changes from the Left snapshot to the Right one, in the sections compared.
+type SnapshotDiff struct {
+	Left     DeviceSnapshot   `json:"left"`
+	Right    DeviceSnapshot   `json:"right"`
+	Sections []string         `json:"sections"`
+	Changes  []SnapshotChange `json:"changes"`
+}
+
+// SnapshotDiffQuery selects the snapshots to compare and, optionally, the sections to compare them in, given as
+// repeated sections parameters.
+type SnapshotDiffQuery struct {
+	Left     string   `form:"left" binding:"required"`
+	Right    string   `form:"right" binding:"required"`
+	Sections []string `form:"sections"`
+}
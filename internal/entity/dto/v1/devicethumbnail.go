@@ -0,0 +1,10 @@
+package dto
+
+import "time"
+
+// DeviceThumbnail is the last screen captured of a device, the image is a PNG.
+type DeviceThumbnail struct {
+	GUID       string    `json:"guid" example:"123e4567-e89b-12d3-a456-426614174000"`
+	CapturedAt time.Time `json:"capturedAt" example:"2024-12-01T00:00:00Z"`
+	Image      []byte    `json:"-"`
+}
@@ -0,0 +1,65 @@
+package dto
+
+import "time"
+
+// Event types pushed on /api/v1/events.
+const (
+	EventDeviceAdded         = "device.added"
+	EventDeviceUpdated       = "device.updated"
+	EventDeviceDeleted       = "device.deleted"
+	EventPowerAction         = "device.power"
+	EventBootOptions         = "device.boot"
+	EventFeaturesChanged     = "device.features"
+	EventCertificatePinned   = "device.certificate.pinned"
+	EventCertificateRemoved  = "device.certificate.removed"
+	EventRedirectionOpened   = "redirection.opened"
+	EventRedirectionClosed   = "redirection.closed"
+	EventAlertRaised         = "alert.raised"
+	EventCertificateExpiring = "certificate.expiring"
+	EventCertificateExpired  = "certificate.expired"
+)
+
+// EventTypes lists every event type a subscriber can ask for.
+var EventTypes = []string{
+	EventDeviceAdded,
+	EventDeviceUpdated,
+	EventDeviceDeleted,
+	EventPowerAction,
+	EventBootOptions,
+	EventFeaturesChanged,
+	EventCertificatePinned,
+	EventCertificateRemoved,
+	EventRedirectionOpened,
+	EventRedirectionClosed,
+	EventAlertRaised,
+	EventCertificateExpiring,
+	EventCertificateExpired,
+}
+
+type Event struct {
+	ID       string      `json:"id" example:"42"`
+	Type     string      `json:"type" example:"device.power"`
+	GUID     string      `json:"guid" example:"123e4567-e89b-12d3-a456-426614174000"`
+	TenantID string      `json:"-"`
+	Tags     []string    `json:"tags"`
+	Time     time.Time   `json:"time" example:"2026-10-17T12:00:00Z"`
+	Data     interface{} `json:"data,omitempty"`
+}
+
+// EventFilter selects the events a subscriber receives, an empty list matches everything.
+// AllTenants is for background consumers and ignores TenantID.
+type EventFilter struct {
+	TenantID   string
+	AllTenants bool
+	GUIDs      []string
+	Tags       []string
+}
+
+type PowerActionEvent struct {
+	Action      int `json:"action" example:"8"`
+	ReturnValue int `json:"returnValue" example:"0"`
+}
+
+type RedirectionEvent struct {
+	Mode string `json:"mode" example:"kvm"`
+}
@@ -0,0 +1,58 @@
+package dto
+
+import (
+	"time"
+
+	dtov2 "github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v2"
+)
+
+const (
+	FeaturePolicyScopeTag     = "tag"
+	FeaturePolicyScopeProfile = "profile"
+)
+
+// DesiredFeatures is the state a policy holds devices to, a field left out is not enforced.
+type DesiredFeatures struct {
+	UserConsent *string `json:"userConsent,omitempty" binding:"omitempty,oneof=none kvm all" example:"kvm"`
+	EnableSOL   *bool   `json:"enableSOL,omitempty" example:"true"`
+	EnableIDER  *bool   `json:"enableIDER,omitempty" example:"false"`
+	EnableKVM   *bool   `json:"enableKVM,omitempty" example:"true"`
+	Redirection *bool   `json:"redirection,omitempty" example:"true"`
+}
+
+// FeaturePolicy holds the devices carrying a tag, or the tags of a profile, to a desired feature state. The
+// fields a profile policy leaves out are taken from the profile's own user consent, SOL, IDER and KVM settings.
+// A device matched by several policies follows the tag policies first, then the one first by name.
+type FeaturePolicy struct {
+	Name         string          `json:"name" binding:"required" example:"lab-kvm"`
+	Scope        string          `json:"scope" binding:"required,oneof=tag profile" example:"tag"`
+	Target       string          `json:"target" binding:"required" example:"lab"`
+	Desired      DesiredFeatures `json:"desired"`
+	Remediate    bool            `json:"remediate" example:"false"`
+	CreationDate time.Time       `json:"creationDate,omitempty" example:"2024-12-01T00:00:00Z"`
+	TenantID     string          `json:"tenantId" example:"abc123"`
+}
+
+// FeatureDrift is the outcome of the last check of a device against the policy it follows.
+type FeatureDrift struct {
+	GUID       string          `json:"guid"`
+	PolicyName string          `json:"policyName"`
+	CheckedAt  time.Time       `json:"checkedAt"`
+	Drifted    bool            `json:"drifted"`
+	Fields     []string        `json:"fields,omitempty" example:"enableKVM,userConsent"`
+	Actual     *dtov2.Features `json:"actual,omitempty"`
+	Error      string          `json:"error,omitempty"`
+	TenantID   string          `json:"tenantId"`
+}
+
+// FeatureRemediation records the features of a device before and after a policy set them.
+type FeatureRemediation struct {
+	ID           string          `json:"id"`
+	GUID         string          `json:"guid"`
+	PolicyName   string          `json:"policyName"`
+	RemediatedAt time.Time       `json:"remediatedAt"`
+	Before       dtov2.Features  `json:"before"`
+	After        *dtov2.Features `json:"after,omitempty"`
+	Error        string          `json:"error,omitempty"`
+	TenantID     string          `json:"tenantId"`
+}
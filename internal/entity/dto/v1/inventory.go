@@ -0,0 +1,80 @@
+package dto
+
+import "time"
+
+// DeviceInventory is the hardware and firmware of a device as of its last refresh.
+type DeviceInventory struct {
+	GUID             string               `json:"guid" example:"123e4567-e89b-12d3-a456-426614174000"`
+	RefreshedAt      time.Time            `json:"refreshedAt" example:"2024-12-01T00:00:00Z"`
+	Firmware         FirmwareInventory    `json:"firmware"`
+	BIOS             BIOSInventory        `json:"bios"`
+	Chassis          ComponentInventory   `json:"chassis"`
+	Board            ComponentInventory   `json:"board"`
+	Processors       []ProcessorInventory `json:"processors"`
+	Memory           []MemoryInventory    `json:"memory"`
+	TotalMemoryBytes int64                `json:"totalMemoryBytes" example:"17179869184"`
+	Disks            []DiskInventory      `json:"disks"`
+	TenantID         string               `json:"tenantId" example:"abc123"`
+}
+
+// FirmwareInventory is the AMT firmware of a device.
+type FirmwareInventory struct {
+	AMTVersion  string `json:"amtVersion" example:"16.1.25"`
+	AMTMajor    int    `json:"amtMajor" example:"16"`
+	SKU         string `json:"sku" example:"16392"`
+	BuildNumber string `json:"buildNumber" example:"2049"`
+}
+
+type BIOSInventory struct {
+	Manufacturer string `json:"manufacturer" example:"Intel Corp."`
+	Version      string `json:"version" example:"ADLPFWI1.R00.3084.D89.2303211034"`
+	ReleaseDate  string `json:"releaseDate,omitempty" example:"2023-03-21T00:00:00Z"`
+}
+
+// ComponentInventory identifies a physical part of a device, such as its chassis or its board.
+type ComponentInventory struct {
+	Manufacturer string `json:"manufacturer" example:"Intel Corporation"`
+	Model        string `json:"model" example:"NUC12WSBi7"`
+	SerialNumber string `json:"serialNumber" example:"BTWS12345678"`
+	Version      string `json:"version" example:"M46425-304"`
+}
+
+type ProcessorInventory struct {
+	Name                 string `json:"name" example:"Managed System Processor"`
+	Family               int    `json:"family" example:"198"`
+	Stepping             string `json:"stepping" example:"3"`
+	MaxClockSpeedMHz     int    `json:"maxClockSpeedMHz" example:"4700"`
+	CurrentClockSpeedMHz int    `json:"currentClockSpeedMHz" example:"2100"`
+}
+
+type MemoryInventory struct {
+	BankLabel     string `json:"bankLabel" example:"BANK 0"`
+	Manufacturer  string `json:"manufacturer" example:"Samsung"`
+	PartNumber    string `json:"partNumber" example:"M471A1K43DB1-CWE"`
+	SerialNumber  string `json:"serialNumber" example:"12345678"`
+	CapacityBytes int64  `json:"capacityBytes" example:"8589934592"`
+	SpeedMHz      int    `json:"speedMHz" example:"3200"`
+}
+
+type DiskInventory struct {
+	DeviceID       string `json:"deviceId" example:"MEDIA DEV 0"`
+	Name           string `json:"name" example:"Managed System Media Access Device"`
+	MaxMediaSizeKB uint64 `json:"maxMediaSizeKB" example:"500107862"`
+}
+
+// InventoryFilter selects devices by their inventory, a filter left empty matches every device.
+type InventoryFilter struct {
+	// AMTBelow matches the devices on an AMT major version below it.
+	AMTBelow int `form:"amtBelow" binding:"omitempty,min=1" example:"16"`
+	// MemoryBelowGB matches the devices with less memory than that many GiB.
+	MemoryBelowGB int    `form:"memoryBelowGB" binding:"omitempty,min=1" example:"16"`
+	BIOSVersion   string `form:"biosVersion" example:"ADLPFWI1.R00.3084.D89.2303211034"`
+	TenantID      string `form:"-"`
+}
+
+// BIOSVersionCount is how many devices run a BIOS version.
+type BIOSVersionCount struct {
+	Manufacturer string `json:"manufacturer" example:"Intel Corp."`
+	Version      string `json:"version" example:"ADLPFWI1.R00.3084.D89.2303211034"`
+	Count        int    `json:"count" example:"12"`
+}
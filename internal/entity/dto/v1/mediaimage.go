@@ -0,0 +1,55 @@
+package dto
+
+import "time"
+
+const (
+	// MediaKindCD images are ISO files, IDE-R serves them as a CD-ROM drive.
+	MediaKindCD = "cd"
+	// MediaKindFloppy images are raw IMG files, IDE-R serves them as a floppy or USB drive.
+	MediaKindFloppy = "floppy"
+
+	IDERStateConnecting = "connecting"
+	IDERStateMounted    = "mounted"
+	IDERStateClosed     = "closed"
+
+	IDERBootReset   = "reset"
+	IDERBootPowerOn = "powerOn"
+)
+
+// MediaImage is an ISO or IMG file the console stores to serve to devices over IDE-R.
+type MediaImage struct {
+	ID         string    `json:"id" example:"3c9b1a5e-5d1f-4a2c-9b53-0c8f3a3d2d1e"`
+	Name       string    `json:"name" example:"ubuntu-24.04-live-server-amd64.iso"`
+	Kind       string    `json:"kind" example:"cd"`
+	SizeBytes  int64     `json:"sizeBytes" example:"2773874688"`
+	SHA256     string    `json:"sha256" example:"8762f7e74e4d64d72fceb5f70682e6b069932deedb4949c6975d0f0fe0a91be3"`
+	UploadedBy string    `json:"uploadedBy,omitempty" example:"standalone"`
+	CreatedAt  time.Time `json:"createdAt" example:"2024-12-01T00:00:00Z"`
+	TenantID   string    `json:"tenantId" example:"abc123"`
+}
+
+// IDERMount asks to mount a library image on a device. Boot also sets the device to boot from it and resets
+// or powers it on, without it the image is attached to the running system.
+type IDERMount struct {
+	ImageID string `json:"imageId" binding:"required" example:"3c9b1a5e-5d1f-4a2c-9b53-0c8f3a3d2d1e"`
+	Boot    string `json:"boot,omitempty" binding:"omitempty,oneof=reset powerOn" example:"reset"`
+}
+
+// IDERMedia is the image an IDE-R session serves.
+type IDERMedia struct {
+	ImageID   string `json:"imageId,omitempty" example:"3c9b1a5e-5d1f-4a2c-9b53-0c8f3a3d2d1e"`
+	Name      string `json:"name" example:"ubuntu-24.04-live-server-amd64.iso"`
+	Kind      string `json:"kind" example:"cd"`
+	SizeBytes int64  `json:"sizeBytes" example:"2773874688"`
+}
+
+// IDERSession is an IDE-R session the console holds with a device to serve it an image, it lasts until it is
+// closed or the device ends it.
+type IDERSession struct {
+	GUID      string    `json:"guid" example:"123e4567-e89b-12d3-a456-426614174000"`
+	State     string    `json:"state" example:"mounted"`
+	Media     IDERMedia `json:"media"`
+	Subject   string    `json:"subject,omitempty" example:"standalone"`
+	StartTime time.Time `json:"startTime" example:"2024-12-01T00:00:00Z"`
+	BytesRead int64     `json:"bytesRead" example:"1048576"`
+}
@@ -0,0 +1,30 @@
+package dto
+
+import "time"
+
+const (
+	RecordingDirectionDevice  = "device"
+	RecordingDirectionBrowser = "browser"
+)
+
+// SessionRecording describes a KVM or SOL redirection session recorded to disk, EndTime is unset while the
+// session is still open.
+type SessionRecording struct {
+	ID        string     `json:"id" example:"3c9b1a5e-5d1f-4a2c-9b53-0c8f3a3d2d1e"`
+	GUID      string     `json:"guid" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Hostname  string     `json:"hostname,omitempty" example:"host1"`
+	Mode      string     `json:"mode" example:"sol"`
+	Subject   string     `json:"subject,omitempty" example:"standalone"`
+	SizeBytes int64      `json:"sizeBytes" example:"20480"`
+	StartTime time.Time  `json:"startTime" example:"2024-12-01T00:00:00Z"`
+	EndTime   *time.Time `json:"endTime,omitempty" example:"2024-12-01T00:10:00Z"`
+	Truncated bool       `json:"truncated" example:"false"`
+	TenantID  string     `json:"tenantId" example:"abc123"`
+}
+
+// RecordingFrame is one message of a recorded session, OffsetMS is the time since the session started.
+type RecordingFrame struct {
+	OffsetMS  int64  `json:"offsetMs" example:"1250"`
+	Direction string `json:"direction" example:"device"`
+	Data      []byte `json:"data" example:"KgAAAAAAAAAFAGxvZ2luOg=="`
+}
@@ -0,0 +1,22 @@
+package dto
+
+// Close codes of the websocket frame a relayed session is closed with when the console ends it.
+const (
+	RedirectionCloseTerminated  = 4000
+	RedirectionCloseIdle        = 4001
+	RedirectionCloseDuration    = 4002
+	RedirectionCloseUserLimit   = 4003
+	RedirectionCloseDeviceLimit = 4004
+)
+
+// RedirectionPolicy limits the sessions relayed for a tenant, 0 means no limit. A session is idle while its user
+// gives no input: no keys or pointer moves in KVM, nothing typed in SOL.
+type RedirectionPolicy struct {
+	IdleTimeoutSeconds   int    `json:"idleTimeoutSeconds" binding:"min=0" example:"900"`
+	MaxDurationSeconds   int    `json:"maxDurationSeconds" binding:"min=0" example:"14400"`
+	MaxSessionsPerUser   int    `json:"maxSessionsPerUser" binding:"min=0" example:"2"`
+	MaxSessionsPerDevice int    `json:"maxSessionsPerDevice" binding:"min=0" example:"1"`
+	TenantID             string `json:"tenantId" example:""`
+	// Default is set when the tenant has no policy of its own and the configured defaults apply.
+	Default bool `json:"default" example:"false"`
+}
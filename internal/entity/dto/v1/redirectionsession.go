@@ -0,0 +1,17 @@
+package dto
+
+import "time"
+
+// RedirectionSession is a KVM, SOL or IDE-R session the console relays between a browser and a device.
+type RedirectionSession struct {
+	ID              string    `json:"id" example:"8f14e45f-ceea-467a-9b35-8b7d3f6c2d1a"`
+	GUID            string    `json:"guid" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Hostname        string    `json:"hostname,omitempty" example:"host1.example.com"`
+	Mode            string    `json:"mode" example:"kvm"`
+	Subject         string    `json:"subject,omitempty" example:"standalone"`
+	ClientIP        string    `json:"clientIp,omitempty" example:"10.0.0.5"`
+	StartTime       time.Time `json:"startTime" example:"2024-12-01T00:00:00Z"`
+	BytesToDevice   int64     `json:"bytesToDevice" example:"2048"`
+	BytesFromDevice int64     `json:"bytesFromDevice" example:"1048576"`
+	TenantID        string    `json:"tenantId" example:""`
+}
@@ -0,0 +1,27 @@
+package dto
+
+import "time"
+
+const (
+	RoleViewer      = "viewer"
+	RoleOperator    = "operator"
+	RoleAdmin       = "admin"
+	RoleRedirection = "redirection"
+
+	// PermissionRead allows reading device state.
+	PermissionRead = "devices:read"
+	// PermissionOperate allows changing device state, such as power actions, boot options and features.
+	PermissionOperate = "devices:operate"
+	// PermissionRedirect allows opening KVM, SOL and IDE-R sessions.
+	PermissionRedirect = "redirection"
+	// PermissionAdmin allows managing the console configuration under /api/v1/admin.
+	PermissionAdmin = "admin"
+)
+
+// RoleBinding grants a role to a subject, either a username or an OIDC subject or email.
+type RoleBinding struct {
+	Subject      string    `json:"subject" binding:"required" example:"jane@example.com"`
+	Role         string    `json:"role" binding:"required,oneof=viewer operator admin redirection" example:"operator"`
+	CreationDate time.Time `json:"creationDate,omitempty" example:"2024-12-01T00:00:00Z"`
+	TenantID     string    `json:"tenantId" example:"abc123"`
+}
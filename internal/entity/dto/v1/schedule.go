@@ -0,0 +1,53 @@
+package dto
+
+import "time"
+
+const (
+	JobTypePower    = "power"
+	JobTypeBoot     = "boot"
+	JobTypeFeatures = "features"
+
+	ScheduleTypeOnce = "once"
+	ScheduleTypeCron = "cron"
+
+	JobRunStatusSuccess      = "success"
+	JobRunStatusFailed       = "failed"
+	JobRunStatusRetryPending = "retry_pending"
+	JobRunStatusRetried      = "retried"
+)
+
+type ScheduledJob struct {
+	ID             string       `json:"id" example:"3c9b1a5e-5d1f-4a2c-9b53-0c8f3a3d2d1e"`
+	Name           string       `json:"name" binding:"required" example:"Nightly lab reboot"`
+	JobType        string       `json:"jobType" binding:"required,oneof=power boot features" example:"power"`
+	ScheduleType   string       `json:"scheduleType" binding:"required,oneof=once cron" example:"cron"`
+	RunAt          *time.Time   `json:"runAt,omitempty" binding:"required_if=ScheduleType once" example:"2025-01-01T02:00:00Z"`
+	CronExpression string       `json:"cronExpression,omitempty" binding:"required_if=ScheduleType cron" example:"0 2 * * 6"`
+	PowerAction    *PowerAction `json:"powerAction,omitempty" binding:"required_if=JobType power"`
+	BootSetting    *BootSetting `json:"bootSetting,omitempty" binding:"required_if=JobType boot"`
+	Features       *Features    `json:"features,omitempty" binding:"required_if=JobType features"`
+	GUIDs          []string     `json:"guids,omitempty" binding:"required_without=Tags"`
+	Tags           []string     `json:"tags,omitempty" binding:"required_without=GUIDs"`
+	Method         string       `json:"method,omitempty" binding:"omitempty,oneof=AND OR" example:"OR"`
+	MaxRetries     int          `json:"maxRetries" binding:"min=0,max=10" example:"3"`
+	RetryInterval  int          `json:"retryInterval" binding:"min=0" example:"300"` // seconds between retries of an unreachable device
+	Enabled        bool         `json:"enabled" example:"true"`
+	NextRun        *time.Time   `json:"nextRun,omitempty" example:"2025-01-04T02:00:00Z"`
+	LastRun        *time.Time   `json:"lastRun,omitempty" example:"2024-12-28T02:00:00Z"`
+	CreationDate   time.Time    `json:"creationDate,omitempty" example:"2024-12-01T00:00:00Z"`
+	TenantID       string       `json:"tenantId" example:"abc123"`
+}
+
+type JobRun struct {
+	ID          string     `json:"id"`
+	JobID       string     `json:"jobId"`
+	GUID        string     `json:"guid"`
+	Attempt     int        `json:"attempt" example:"1"`
+	Status      string     `json:"status" example:"success"`
+	ReturnValue int        `json:"returnValue"`
+	Error       string     `json:"error,omitempty"`
+	RetryAt     *time.Time `json:"retryAt,omitempty"`
+	StartedAt   time.Time  `json:"startedAt"`
+	FinishedAt  time.Time  `json:"finishedAt"`
+	TenantID    string     `json:"tenantId"`
+}
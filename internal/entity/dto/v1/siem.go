@@ -0,0 +1,21 @@
+package dto
+
+import "time"
+
+// Logs of a device the SIEM forwarder ships.
+const (
+	SIEMSourceAudit = "audit"
+	SIEMSourceEvent = "event"
+)
+
+// SIEMCursor is how far the collected records of one log of a device have been forwarded to the SIEM.
+type SIEMCursor struct {
+	Source          string    `json:"source" example:"audit"`
+	GUID            string    `json:"guid" example:"123e4567-e89b-12d3-a456-426614174000"`
+	LastCollectedAt time.Time `json:"lastCollectedAt" example:"2024-12-01T00:05:00Z"`
+	LastTime        time.Time `json:"lastTime" example:"2024-12-01T00:00:00Z"`
+	ForwardedAt     time.Time `json:"forwardedAt" example:"2024-12-01T00:10:00Z"`
+	Forwarded       int       `json:"forwarded" example:"3"`
+	Error           string    `json:"error,omitempty" example:""`
+	TenantID        string    `json:"tenantId" example:"abc123"`
+}
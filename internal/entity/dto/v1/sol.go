@@ -0,0 +1,30 @@
+package dto
+
+import "time"
+
+const (
+	SOLStateConnecting = "connecting"
+	SOLStateConnected  = "connected"
+	SOLStateClosed     = "closed"
+)
+
+// SOLSession is a Serial-over-LAN session the console holds open with a device on behalf of API clients.
+type SOLSession struct {
+	GUID      string    `json:"guid" example:"123e4567-e89b-12d3-a456-426614174000"`
+	State     string    `json:"state" example:"connected"`
+	Subject   string    `json:"subject,omitempty" example:"standalone"`
+	StartTime time.Time `json:"startTime" example:"2024-12-01T00:00:00Z"`
+}
+
+// SOLKeys are typed into a SOL session as they are, escape sequences included: "\r" is Enter and "\u001b[B"
+// the down arrow.
+type SOLKeys struct {
+	Keys string `json:"keys" binding:"required" example:"root\r"`
+}
+
+// SOLScreen is the terminal of a SOL session as a VT100 would show it, rows are right trimmed.
+type SOLScreen struct {
+	Rows         []string `json:"rows"`
+	CursorRow    int      `json:"cursorRow" example:"0"`
+	CursorColumn int      `json:"cursorColumn" example:"7"`
+}
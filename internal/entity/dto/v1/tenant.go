@@ -0,0 +1,13 @@
+package dto
+
+import "time"
+
+// Tenant is an isolated set of devices, profiles, domains, users and role bindings. The admin account, when
+// given, is created in the new tenant and has to change its password on first login.
+type Tenant struct {
+	ID            string    `json:"id" binding:"required" example:"abc123"`
+	Name          string    `json:"name" binding:"required" example:"Acme Corp"`
+	AdminUsername string    `json:"adminUsername,omitempty" example:"acme-admin"`
+	AdminPassword string    `json:"adminPassword,omitempty" binding:"omitempty,min=8" example:"Str0ngP@ssword"`
+	CreationDate  time.Time `json:"creationDate,omitempty" example:"2024-12-01T00:00:00Z"`
+}
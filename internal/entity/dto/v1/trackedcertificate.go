@@ -0,0 +1,45 @@
+package dto
+
+import "time"
+
+// Where a tracked certificate was found.
+const (
+	CertificateSourceDomain     = "domain"
+	CertificateSourceCIRAConfig = "ciraConfig"
+	CertificateSourceDeviceTLS  = "deviceTLS"
+	CertificateSourceDeviceRoot = "deviceRoot"
+)
+
+// How close a tracked certificate is to its expiration, from the thresholds of the certificate inventory.
+const (
+	CertificateStatusOK       = "ok"
+	CertificateStatusWarning  = "warning"
+	CertificateStatusCritical = "critical"
+	CertificateStatusExpired  = "expired"
+)
+
+// TrackedCertificate is a certificate the console or a device depends on, as last seen by the certificate
+// inventory. Owner is the domain profile, CIRA config or device GUID it belongs to.
+type TrackedCertificate struct {
+	Source            string    `json:"source" example:"deviceTLS"`
+	Owner             string    `json:"owner" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Subject           string    `json:"subject" example:"iAMT CSME IDevID RCFG"`
+	Issuer            string    `json:"issuer" example:"Intel CSME"`
+	SerialNumber      string    `json:"serialNumber" example:"1234567890"`
+	SHA256Fingerprint string    `json:"sha256Fingerprint" example:"9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"`
+	NotBefore         time.Time `json:"notBefore" example:"2024-01-01T00:00:00Z"`
+	NotAfter          time.Time `json:"notAfter" example:"2025-01-01T00:00:00Z"`
+	DaysLeft          int       `json:"daysLeft" example:"21"`
+	Status            string    `json:"status" example:"warning"`
+	CheckedAt         time.Time `json:"checkedAt" example:"2024-12-10T00:00:00Z"`
+	TenantID          string    `json:"tenantId" example:"abc123"`
+}
+
+// TrackedCertificateFilter selects the tracked certificates expiring within Days, the warning threshold of the
+// inventory when it is not set. Expired certificates always match.
+type TrackedCertificateFilter struct {
+	Source   string `form:"source"`
+	Owner    string `form:"owner"`
+	Days     *int   `form:"days" binding:"omitempty,min=0"`
+	TenantID string `form:"-"`
+}
@@ -0,0 +1,22 @@
+package dto
+
+import "time"
+
+type User struct {
+	Username string `json:"username" binding:"required" example:"jane"`
+	// Password is only accepted, it is never returned
+	Password string `json:"password,omitempty" binding:"omitempty,min=8" example:"Str0ngP@ssword"`
+	// MustChangePassword and Locked are left as they are by an update that omits them
+	MustChangePassword *bool      `json:"mustChangePassword" example:"false"`
+	Locked             *bool      `json:"locked" example:"false"` // set to false to unlock an account
+	LockedUntil        *time.Time `json:"lockedUntil,omitempty" example:"2024-12-01T00:15:00Z"`
+	CreationDate       time.Time  `json:"creationDate,omitempty" example:"2024-12-01T00:00:00Z"`
+	TenantID           string     `json:"tenantId" example:"abc123"`
+}
+
+type PasswordChange struct {
+	Username    string `json:"username" binding:"required" example:"jane"`
+	Password    string `json:"password" binding:"required" example:"G@ppm0ym"`
+	NewPassword string `json:"newPassword" binding:"required,min=8" example:"Str0ngP@ssword"`
+	TenantID    string `json:"tenantId,omitempty" example:"abc123"`
+}
@@ -0,0 +1,12 @@
+package dto
+
+import "time"
+
+// VNCAllocation opens the KVM of a device to an ordinary VNC client: connect to the gateway address and
+// authenticate with the password, which works once and only until it expires.
+type VNCAllocation struct {
+	GUID      string    `json:"guid" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Address   string    `json:"address" example:":5900"`
+	Password  string    `json:"password" example:"q7Hk2mZp"`
+	ExpiresAt time.Time `json:"expiresAt" example:"2024-12-01T00:05:00Z"`
+}
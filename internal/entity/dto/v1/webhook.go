@@ -0,0 +1,44 @@
+package dto
+
+import "time"
+
+const (
+	// DeliveryStatusQueued is a delivery recorded when its event was published and not sent yet,
+	// DeliveryStatusSending one being sent.
+	DeliveryStatusQueued       = "queued"
+	DeliveryStatusSending      = "sending"
+	DeliveryStatusSuccess      = "success"
+	DeliveryStatusFailed       = "failed"
+	DeliveryStatusRetryPending = "retry_pending"
+	DeliveryStatusRetried      = "retried"
+
+	// EventWebhookPing is only sent by the webhook test endpoint.
+	EventWebhookPing = "webhook.ping"
+	// EventAll subscribes a webhook to every event type.
+	EventAll = "*"
+)
+
+type Webhook struct {
+	ID           string    `json:"id" example:"3c9b1a5e-5d1f-4a2c-9b53-0c8f3a3d2d1e"`
+	Name         string    `json:"name" binding:"required" example:"Ticketing"`
+	URL          string    `json:"url" binding:"required,url" example:"https://hooks.example.com/console"`
+	Secret       string    `json:"secret,omitempty" example:"s3cr3t"` // write only, generated when left empty and returned once on creation
+	EventTypes   []string  `json:"eventTypes" binding:"required,min=1" example:"device.power,device.deleted"`
+	Enabled      bool      `json:"enabled" example:"true"`
+	CreationDate time.Time `json:"creationDate,omitempty" example:"2024-12-01T00:00:00Z"`
+	TenantID     string    `json:"tenantId" example:"abc123"`
+}
+
+type WebhookDelivery struct {
+	ID         string     `json:"id"`
+	WebhookID  string     `json:"webhookId"`
+	EventID    string     `json:"eventId"`
+	EventType  string     `json:"eventType" example:"device.power"`
+	Attempt    int        `json:"attempt" example:"1"`
+	Status     string     `json:"status" example:"success"`
+	StatusCode int        `json:"statusCode,omitempty" example:"200"`
+	Error      string     `json:"error,omitempty"`
+	RetryAt    *time.Time `json:"retryAt,omitempty"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	TenantID   string     `json:"tenantId"`
+}
@@ -0,0 +1,33 @@
+package entity
+
+type FeaturePolicy struct {
+	Name         string
+	Scope        string
+	Target       string
+	Desired      string
+	Remediate    bool
+	CreationDate string
+	TenantID     string
+}
+
+type FeatureDrift struct {
+	GUID       string
+	PolicyName string
+	CheckedAt  string
+	Drifted    bool
+	Fields     string
+	Actual     string
+	Error      string
+	TenantID   string
+}
+
+type FeatureRemediation struct {
+	ID           string
+	GUID         string
+	PolicyName   string
+	RemediatedAt string
+	Before       string
+	After        string
+	Error        string
+	TenantID     string
+}
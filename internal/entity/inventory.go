@@ -0,0 +1,27 @@
+package entity
+
+type DeviceInventory struct {
+	GUID             string
+	RefreshedAt      string
+	AMTVersion       string
+	AMTMajor         int
+	SKU              string
+	BIOSManufacturer string
+	BIOSVersion      string
+	TotalMemoryBytes int64
+	Inventory        string
+	TenantID         string
+}
+
+type InventoryFilter struct {
+	AMTBelow         int
+	MemoryBelowBytes int64
+	BIOSVersion      string
+	TenantID         string
+}
+
+type BIOSVersionCount struct {
+	Manufacturer string
+	Version      string
+	Count        int
+}
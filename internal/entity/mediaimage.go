@@ -0,0 +1,13 @@
+package entity
+
+type MediaImage struct {
+	ID         string
+	Name       string
+	Kind       string
+	FileName   string
+	SizeBytes  int64
+	SHA256     string
+	UploadedBy string
+	CreatedAt  string
+	TenantID   string
+}
@@ -0,0 +1,15 @@
+package entity
+
+type SessionRecording struct {
+	ID        string
+	GUID      string
+	Hostname  string
+	Mode      string
+	Subject   string
+	FileName  string
+	SizeBytes int64
+	StartTime string
+	EndTime   string
+	Truncated bool
+	TenantID  string
+}
@@ -0,0 +1,10 @@
+package entity
+
+// RedirectionPolicy holds the limits of a tenant's redirection sessions, durations are in seconds.
+type RedirectionPolicy struct {
+	IdleTimeout          int
+	MaxDuration          int
+	MaxSessionsPerUser   int
+	MaxSessionsPerDevice int
+	TenantID             string
+}
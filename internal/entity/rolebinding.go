@@ -0,0 +1,8 @@
+package entity
+
+type RoleBinding struct {
+	Subject      string
+	Role         string
+	CreationDate string
+	TenantID     string
+}
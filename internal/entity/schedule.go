@@ -0,0 +1,35 @@
+package entity
+
+type ScheduledJob struct {
+	ID             string
+	Name           string
+	JobType        string
+	ScheduleType   string
+	RunAt          string
+	CronExpression string
+	Payload        string
+	TargetGUIDs    string
+	TargetTags     string
+	TargetMethod   string
+	MaxRetries     int
+	RetryInterval  int
+	Enabled        bool
+	NextRun        string
+	LastRun        string
+	CreationDate   string
+	TenantID       string
+}
+
+type JobRun struct {
+	ID          string
+	JobID       string
+	GUID        string
+	Attempt     int
+	Status      string
+	ReturnValue int
+	Error       string
+	RetryAt     string
+	StartedAt   string
+	FinishedAt  string
+	TenantID    string
+}
@@ -0,0 +1,21 @@
+package entity
+
+// LogPosition orders the records collected from a device: by when they were collected, then by their own time
+// and fingerprint.
+type LogPosition struct {
+	CollectedAt string
+	Time        string
+	Fingerprint string
+}
+
+type SIEMCursor struct {
+	Source          string
+	GUID            string
+	LastCollectedAt string
+	LastTime        string
+	LastFingerprint string
+	ForwardedAt     string
+	Forwarded       int
+	Error           string
+	TenantID        string
+}
@@ -0,0 +1,22 @@
+package entity
+
+type TrackedCertificate struct {
+	Source         string
+	Owner          string
+	Fingerprint    string
+	Subject        string
+	Issuer         string
+	SerialNumber   string
+	NotBefore      string
+	NotAfter       string
+	CheckedAt      string
+	NotifiedStatus string
+	TenantID       string
+}
+
+type TrackedCertificateFilter struct {
+	Source         string
+	Owner          string
+	NotAfterBefore string
+	TenantID       string
+}
@@ -0,0 +1,11 @@
+package entity
+
+type User struct {
+	Username           string
+	PasswordHash       string
+	FailedAttempts     int
+	LockedUntil        string
+	MustChangePassword bool
+	CreationDate       string
+	TenantID           string
+}
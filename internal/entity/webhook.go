@@ -0,0 +1,27 @@
+package entity
+
+type Webhook struct {
+	ID           string
+	Name         string
+	URL          string
+	Secret       string
+	EventTypes   string
+	Enabled      bool
+	CreationDate string
+	TenantID     string
+}
+
+type WebhookDelivery struct {
+	ID         string
+	WebhookID  string
+	EventID    string
+	EventType  string
+	Payload    string
+	Attempt    int
+	Status     string
+	StatusCode int
+	Error      string
+	RetryAt    string
+	CreatedAt  string
+	TenantID   string
+}
@@ -0,0 +1,306 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/usecase/amtaudit/interfaces.go
+//
+// Generated by this command:
+//
+//	mockgen -source ./internal/usecase/amtaudit/interfaces.go -package mocks -mock_names Repository=MockAMTAuditRepository,Feature=MockAMTAuditFeature,Devices=MockAMTAuditDevices,Fleet=MockAMTAuditFleet
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/open-amt-cloud-toolkit/console/internal/entity"
+	dto "github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAMTAuditRepository is a mock of Repository interface.
+type MockAMTAuditRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAMTAuditRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockAMTAuditRepositoryMockRecorder is the mock recorder for MockAMTAuditRepository.
+type MockAMTAuditRepositoryMockRecorder struct {
+	mock *MockAMTAuditRepository
+}
+
+// NewMockAMTAuditRepository creates a new mock instance.
+func NewMockAMTAuditRepository(ctrl *gomock.Controller) *MockAMTAuditRepository {
+	mock := &MockAMTAuditRepository{ctrl: ctrl}
+	mock.recorder = &MockAMTAuditRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAMTAuditRepository) EXPECT() *MockAMTAuditRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockAMTAuditRepository) Get(ctx context.Context, filter entity.AMTAuditFilter, top, skip int) ([]entity.AMTAuditRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, filter, top, skip)
+	ret0, _ := ret[0].([]entity.AMTAuditRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockAMTAuditRepositoryMockRecorder) Get(ctx, filter, top, skip any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockAMTAuditRepository)(nil).Get), ctx, filter, top, skip)
+}
+
+// GetCount mocks base method.
+func (m *MockAMTAuditRepository) GetCount(ctx context.Context, filter entity.AMTAuditFilter) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCount", ctx, filter)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCount indicates an expected call of GetCount.
+func (mr *MockAMTAuditRepositoryMockRecorder) GetCount(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCount", reflect.TypeOf((*MockAMTAuditRepository)(nil).GetCount), ctx, filter)
+}
+
+// GetCursor mocks base method.
+func (m *MockAMTAuditRepository) GetCursor(ctx context.Context, guid, tenantID string) (*entity.AMTAuditCursor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCursor", ctx, guid, tenantID)
+	ret0, _ := ret[0].(*entity.AMTAuditCursor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCursor indicates an expected call of GetCursor.
+func (mr *MockAMTAuditRepositoryMockRecorder) GetCursor(ctx, guid, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCursor", reflect.TypeOf((*MockAMTAuditRepository)(nil).GetCursor), ctx, guid, tenantID)
+}
+
+// GetCursors mocks base method.
+func (m *MockAMTAuditRepository) GetCursors(ctx context.Context, top, skip int, tenantID string) ([]entity.AMTAuditCursor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCursors", ctx, top, skip, tenantID)
+	ret0, _ := ret[0].([]entity.AMTAuditCursor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCursors indicates an expected call of GetCursors.
+func (mr *MockAMTAuditRepositoryMockRecorder) GetCursors(ctx, top, skip, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCursors", reflect.TypeOf((*MockAMTAuditRepository)(nil).GetCursors), ctx, top, skip, tenantID)
+}
+
+// Insert mocks base method.
+func (m *MockAMTAuditRepository) Insert(ctx context.Context, records []entity.AMTAuditRecord) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", ctx, records)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockAMTAuditRepositoryMockRecorder) Insert(ctx, records any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockAMTAuditRepository)(nil).Insert), ctx, records)
+}
+
+// UpsertCursor mocks base method.
+func (m *MockAMTAuditRepository) UpsertCursor(ctx context.Context, c *entity.AMTAuditCursor) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertCursor", ctx, c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertCursor indicates an expected call of UpsertCursor.
+func (mr *MockAMTAuditRepositoryMockRecorder) UpsertCursor(ctx, c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertCursor", reflect.TypeOf((*MockAMTAuditRepository)(nil).UpsertCursor), ctx, c)
+}
+
+// MockAMTAuditFeature is a mock of Feature interface.
+type MockAMTAuditFeature struct {
+	ctrl     *gomock.Controller
+	recorder *MockAMTAuditFeatureMockRecorder
+	isgomock struct{}
+}
+
+// MockAMTAuditFeatureMockRecorder is the mock recorder for MockAMTAuditFeature.
+type MockAMTAuditFeatureMockRecorder struct {
+	mock *MockAMTAuditFeature
+}
+
+// NewMockAMTAuditFeature creates a new mock instance.
+func NewMockAMTAuditFeature(ctrl *gomock.Controller) *MockAMTAuditFeature {
+	mock := &MockAMTAuditFeature{ctrl: ctrl}
+	mock.recorder = &MockAMTAuditFeatureMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAMTAuditFeature) EXPECT() *MockAMTAuditFeatureMockRecorder {
+	return m.recorder
+}
+
+// Collect mocks base method.
+func (m *MockAMTAuditFeature) Collect(ctx context.Context, guid, tenantID string) (*dto.AMTAuditCursor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Collect", ctx, guid, tenantID)
+	ret0, _ := ret[0].(*dto.AMTAuditCursor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Collect indicates an expected call of Collect.
+func (mr *MockAMTAuditFeatureMockRecorder) Collect(ctx, guid, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Collect", reflect.TypeOf((*MockAMTAuditFeature)(nil).Collect), ctx, guid, tenantID)
+}
+
+// Get mocks base method.
+func (m *MockAMTAuditFeature) Get(ctx context.Context, filter dto.AMTAuditFilter, top, skip int) ([]dto.AMTAuditRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, filter, top, skip)
+	ret0, _ := ret[0].([]dto.AMTAuditRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockAMTAuditFeatureMockRecorder) Get(ctx, filter, top, skip any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockAMTAuditFeature)(nil).Get), ctx, filter, top, skip)
+}
+
+// GetCount mocks base method.
+func (m *MockAMTAuditFeature) GetCount(ctx context.Context, filter dto.AMTAuditFilter) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCount", ctx, filter)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCount indicates an expected call of GetCount.
+func (mr *MockAMTAuditFeatureMockRecorder) GetCount(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCount", reflect.TypeOf((*MockAMTAuditFeature)(nil).GetCount), ctx, filter)
+}
+
+// GetCursors mocks base method.
+func (m *MockAMTAuditFeature) GetCursors(ctx context.Context, top, skip int, tenantID string) ([]dto.AMTAuditCursor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCursors", ctx, top, skip, tenantID)
+	ret0, _ := ret[0].([]dto.AMTAuditCursor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCursors indicates an expected call of GetCursors.
+func (mr *MockAMTAuditFeatureMockRecorder) GetCursors(ctx, top, skip, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCursors", reflect.TypeOf((*MockAMTAuditFeature)(nil).GetCursors), ctx, top, skip, tenantID)
+}
+
+// Run mocks base method.
+func (m *MockAMTAuditFeature) Run(ctx context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Run", ctx)
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockAMTAuditFeatureMockRecorder) Run(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockAMTAuditFeature)(nil).Run), ctx)
+}
+
+// MockAMTAuditDevices is a mock of Devices interface.
+type MockAMTAuditDevices struct {
+	ctrl     *gomock.Controller
+	recorder *MockAMTAuditDevicesMockRecorder
+	isgomock struct{}
+}
+
+// MockAMTAuditDevicesMockRecorder is the mock recorder for MockAMTAuditDevices.
+type MockAMTAuditDevicesMockRecorder struct {
+	mock *MockAMTAuditDevices
+}
+
+// NewMockAMTAuditDevices creates a new mock instance.
+func NewMockAMTAuditDevices(ctrl *gomock.Controller) *MockAMTAuditDevices {
+	mock := &MockAMTAuditDevices{ctrl: ctrl}
+	mock.recorder = &MockAMTAuditDevicesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAMTAuditDevices) EXPECT() *MockAMTAuditDevicesMockRecorder {
+	return m.recorder
+}
+
+// GetAuditLog mocks base method.
+func (m *MockAMTAuditDevices) GetAuditLog(ctx context.Context, startIndex int, guid string) (dto.AuditLog, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuditLog", ctx, startIndex, guid)
+	ret0, _ := ret[0].(dto.AuditLog)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAuditLog indicates an expected call of GetAuditLog.
+func (mr *MockAMTAuditDevicesMockRecorder) GetAuditLog(ctx, startIndex, guid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuditLog", reflect.TypeOf((*MockAMTAuditDevices)(nil).GetAuditLog), ctx, startIndex, guid)
+}
+
+// MockAMTAuditFleet is a mock of Fleet interface.
+type MockAMTAuditFleet struct {
+	ctrl     *gomock.Controller
+	recorder *MockAMTAuditFleetMockRecorder
+	isgomock struct{}
+}
+
+// MockAMTAuditFleetMockRecorder is the mock recorder for MockAMTAuditFleet.
+type MockAMTAuditFleetMockRecorder struct {
+	mock *MockAMTAuditFleet
+}
+
+// NewMockAMTAuditFleet creates a new mock instance.
+func NewMockAMTAuditFleet(ctrl *gomock.Controller) *MockAMTAuditFleet {
+	mock := &MockAMTAuditFleet{ctrl: ctrl}
+	mock.recorder = &MockAMTAuditFleetMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAMTAuditFleet) EXPECT() *MockAMTAuditFleetMockRecorder {
+	return m.recorder
+}
+
+// GetAllTenants mocks base method.
+func (m *MockAMTAuditFleet) GetAllTenants(ctx context.Context, top, skip int) ([]entity.Device, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllTenants", ctx, top, skip)
+	ret0, _ := ret[0].([]entity.Device)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllTenants indicates an expected call of GetAllTenants.
+func (mr *MockAMTAuditFleetMockRecorder) GetAllTenants(ctx, top, skip any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllTenants", reflect.TypeOf((*MockAMTAuditFleet)(nil).GetAllTenants), ctx, top, skip)
+}
@@ -0,0 +1,420 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/usecase/certexpiry/interfaces.go
+//
+// Generated by this command:
+//
+//	mockgen -source ./internal/usecase/certexpiry/interfaces.go -package mocks -mock_names Repository=MockCertExpiryRepository,Feature=MockCertExpiryFeature,Devices=MockCertExpiryDevices,Fleet=MockCertExpiryFleet,Domains=MockCertExpiryDomains,CIRAConfigs=MockCertExpiryCIRAConfigs,Publisher=MockCertExpiryPublisher
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/open-amt-cloud-toolkit/console/internal/entity"
+	dto "github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCertExpiryRepository is a mock of Repository interface.
+type MockCertExpiryRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCertExpiryRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockCertExpiryRepositoryMockRecorder is the mock recorder for MockCertExpiryRepository.
+type MockCertExpiryRepositoryMockRecorder struct {
+	mock *MockCertExpiryRepository
+}
+
+// NewMockCertExpiryRepository creates a new mock instance.
+func NewMockCertExpiryRepository(ctrl *gomock.Controller) *MockCertExpiryRepository {
+	mock := &MockCertExpiryRepository{ctrl: ctrl}
+	mock.recorder = &MockCertExpiryRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCertExpiryRepository) EXPECT() *MockCertExpiryRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockCertExpiryRepository) Get(ctx context.Context, filter entity.TrackedCertificateFilter, top, skip int) ([]entity.TrackedCertificate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, filter, top, skip)
+	ret0, _ := ret[0].([]entity.TrackedCertificate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockCertExpiryRepositoryMockRecorder) Get(ctx, filter, top, skip any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockCertExpiryRepository)(nil).Get), ctx, filter, top, skip)
+}
+
+// GetByOwner mocks base method.
+func (m *MockCertExpiryRepository) GetByOwner(ctx context.Context, source, owner, tenantID string) ([]entity.TrackedCertificate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByOwner", ctx, source, owner, tenantID)
+	ret0, _ := ret[0].([]entity.TrackedCertificate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByOwner indicates an expected call of GetByOwner.
+func (mr *MockCertExpiryRepositoryMockRecorder) GetByOwner(ctx, source, owner, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByOwner", reflect.TypeOf((*MockCertExpiryRepository)(nil).GetByOwner), ctx, source, owner, tenantID)
+}
+
+// GetCount mocks base method.
+func (m *MockCertExpiryRepository) GetCount(ctx context.Context, filter entity.TrackedCertificateFilter) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCount", ctx, filter)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCount indicates an expected call of GetCount.
+func (mr *MockCertExpiryRepositoryMockRecorder) GetCount(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCount", reflect.TypeOf((*MockCertExpiryRepository)(nil).GetCount), ctx, filter)
+}
+
+// Prune mocks base method.
+func (m *MockCertExpiryRepository) Prune(ctx context.Context, checkedBefore string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Prune", ctx, checkedBefore)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Prune indicates an expected call of Prune.
+func (mr *MockCertExpiryRepositoryMockRecorder) Prune(ctx, checkedBefore any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Prune", reflect.TypeOf((*MockCertExpiryRepository)(nil).Prune), ctx, checkedBefore)
+}
+
+// ReplaceOwner mocks base method.
+func (m *MockCertExpiryRepository) ReplaceOwner(ctx context.Context, source, owner, tenantID string, certs []entity.TrackedCertificate) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceOwner", ctx, source, owner, tenantID, certs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceOwner indicates an expected call of ReplaceOwner.
+func (mr *MockCertExpiryRepositoryMockRecorder) ReplaceOwner(ctx, source, owner, tenantID, certs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceOwner", reflect.TypeOf((*MockCertExpiryRepository)(nil).ReplaceOwner), ctx, source, owner, tenantID, certs)
+}
+
+// MockCertExpiryFeature is a mock of Feature interface.
+type MockCertExpiryFeature struct {
+	ctrl     *gomock.Controller
+	recorder *MockCertExpiryFeatureMockRecorder
+	isgomock struct{}
+}
+
+// MockCertExpiryFeatureMockRecorder is the mock recorder for MockCertExpiryFeature.
+type MockCertExpiryFeatureMockRecorder struct {
+	mock *MockCertExpiryFeature
+}
+
+// NewMockCertExpiryFeature creates a new mock instance.
+func NewMockCertExpiryFeature(ctrl *gomock.Controller) *MockCertExpiryFeature {
+	mock := &MockCertExpiryFeature{ctrl: ctrl}
+	mock.recorder = &MockCertExpiryFeatureMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCertExpiryFeature) EXPECT() *MockCertExpiryFeatureMockRecorder {
+	return m.recorder
+}
+
+// CheckDevice mocks base method.
+func (m *MockCertExpiryFeature) CheckDevice(ctx context.Context, guid, tenantID string) ([]dto.TrackedCertificate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckDevice", ctx, guid, tenantID)
+	ret0, _ := ret[0].([]dto.TrackedCertificate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckDevice indicates an expected call of CheckDevice.
+func (mr *MockCertExpiryFeatureMockRecorder) CheckDevice(ctx, guid, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckDevice", reflect.TypeOf((*MockCertExpiryFeature)(nil).CheckDevice), ctx, guid, tenantID)
+}
+
+// Get mocks base method.
+func (m *MockCertExpiryFeature) Get(ctx context.Context, filter dto.TrackedCertificateFilter, top, skip int) ([]dto.TrackedCertificate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, filter, top, skip)
+	ret0, _ := ret[0].([]dto.TrackedCertificate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockCertExpiryFeatureMockRecorder) Get(ctx, filter, top, skip any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockCertExpiryFeature)(nil).Get), ctx, filter, top, skip)
+}
+
+// GetCount mocks base method.
+func (m *MockCertExpiryFeature) GetCount(ctx context.Context, filter dto.TrackedCertificateFilter) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCount", ctx, filter)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCount indicates an expected call of GetCount.
+func (mr *MockCertExpiryFeatureMockRecorder) GetCount(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCount", reflect.TypeOf((*MockCertExpiryFeature)(nil).GetCount), ctx, filter)
+}
+
+// Run mocks base method.
+func (m *MockCertExpiryFeature) Run(ctx context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Run", ctx)
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockCertExpiryFeatureMockRecorder) Run(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockCertExpiryFeature)(nil).Run), ctx)
+}
+
+// MockCertExpiryDevices is a mock of Devices interface.
+type MockCertExpiryDevices struct {
+	ctrl     *gomock.Controller
+	recorder *MockCertExpiryDevicesMockRecorder
+	isgomock struct{}
+}
+
+// MockCertExpiryDevicesMockRecorder is the mock recorder for MockCertExpiryDevices.
+type MockCertExpiryDevicesMockRecorder struct {
+	mock *MockCertExpiryDevices
+}
+
+// NewMockCertExpiryDevices creates a new mock instance.
+func NewMockCertExpiryDevices(ctrl *gomock.Controller) *MockCertExpiryDevices {
+	mock := &MockCertExpiryDevices{ctrl: ctrl}
+	mock.recorder = &MockCertExpiryDevicesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCertExpiryDevices) EXPECT() *MockCertExpiryDevicesMockRecorder {
+	return m.recorder
+}
+
+// GetCertificates mocks base method.
+func (m *MockCertExpiryDevices) GetCertificates(ctx context.Context, guid string) (dto.SecuritySettings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCertificates", ctx, guid)
+	ret0, _ := ret[0].(dto.SecuritySettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCertificates indicates an expected call of GetCertificates.
+func (mr *MockCertExpiryDevicesMockRecorder) GetCertificates(ctx, guid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCertificates", reflect.TypeOf((*MockCertExpiryDevices)(nil).GetCertificates), ctx, guid)
+}
+
+// GetDeviceCertificate mocks base method.
+func (m *MockCertExpiryDevices) GetDeviceCertificate(ctx context.Context, guid string) (dto.Certificate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeviceCertificate", ctx, guid)
+	ret0, _ := ret[0].(dto.Certificate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeviceCertificate indicates an expected call of GetDeviceCertificate.
+func (mr *MockCertExpiryDevicesMockRecorder) GetDeviceCertificate(ctx, guid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeviceCertificate", reflect.TypeOf((*MockCertExpiryDevices)(nil).GetDeviceCertificate), ctx, guid)
+}
+
+// MockCertExpiryFleet is a mock of Fleet interface.
+type MockCertExpiryFleet struct {
+	ctrl     *gomock.Controller
+	recorder *MockCertExpiryFleetMockRecorder
+	isgomock struct{}
+}
+
+// MockCertExpiryFleetMockRecorder is the mock recorder for MockCertExpiryFleet.
+type MockCertExpiryFleetMockRecorder struct {
+	mock *MockCertExpiryFleet
+}
+
+// NewMockCertExpiryFleet creates a new mock instance.
+func NewMockCertExpiryFleet(ctrl *gomock.Controller) *MockCertExpiryFleet {
+	mock := &MockCertExpiryFleet{ctrl: ctrl}
+	mock.recorder = &MockCertExpiryFleetMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCertExpiryFleet) EXPECT() *MockCertExpiryFleetMockRecorder {
+	return m.recorder
+}
+
+// GetAllTenants mocks base method.
+func (m *MockCertExpiryFleet) GetAllTenants(ctx context.Context, top, skip int) ([]entity.Device, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllTenants", ctx, top, skip)
+	ret0, _ := ret[0].([]entity.Device)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllTenants indicates an expected call of GetAllTenants.
+func (mr *MockCertExpiryFleetMockRecorder) GetAllTenants(ctx, top, skip any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllTenants", reflect.TypeOf((*MockCertExpiryFleet)(nil).GetAllTenants), ctx, top, skip)
+}
+
+// GetByID mocks base method.
+func (m *MockCertExpiryFleet) GetByID(ctx context.Context, guid, tenantID string) (*entity.Device, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, guid, tenantID)
+	ret0, _ := ret[0].(*entity.Device)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockCertExpiryFleetMockRecorder) GetByID(ctx, guid, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockCertExpiryFleet)(nil).GetByID), ctx, guid, tenantID)
+}
+
+// MockCertExpiryDomains is a mock of Domains interface.
+type MockCertExpiryDomains struct {
+	ctrl     *gomock.Controller
+	recorder *MockCertExpiryDomainsMockRecorder
+	isgomock struct{}
+}
+
+// MockCertExpiryDomainsMockRecorder is the mock recorder for MockCertExpiryDomains.
+type MockCertExpiryDomainsMockRecorder struct {
+	mock *MockCertExpiryDomains
+}
+
+// NewMockCertExpiryDomains creates a new mock instance.
+func NewMockCertExpiryDomains(ctrl *gomock.Controller) *MockCertExpiryDomains {
+	mock := &MockCertExpiryDomains{ctrl: ctrl}
+	mock.recorder = &MockCertExpiryDomainsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCertExpiryDomains) EXPECT() *MockCertExpiryDomainsMockRecorder {
+	return m.recorder
+}
+
+// GetAllTenants mocks base method.
+func (m *MockCertExpiryDomains) GetAllTenants(ctx context.Context, top, skip int) ([]entity.Domain, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllTenants", ctx, top, skip)
+	ret0, _ := ret[0].([]entity.Domain)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllTenants indicates an expected call of GetAllTenants.
+func (mr *MockCertExpiryDomainsMockRecorder) GetAllTenants(ctx, top, skip any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllTenants", reflect.TypeOf((*MockCertExpiryDomains)(nil).GetAllTenants), ctx, top, skip)
+}
+
+// MockCertExpiryCIRAConfigs is a mock of CIRAConfigs interface.
+type MockCertExpiryCIRAConfigs struct {
+	ctrl     *gomock.Controller
+	recorder *MockCertExpiryCIRAConfigsMockRecorder
+	isgomock struct{}
+}
+
+// MockCertExpiryCIRAConfigsMockRecorder is the mock recorder for MockCertExpiryCIRAConfigs.
+type MockCertExpiryCIRAConfigsMockRecorder struct {
+	mock *MockCertExpiryCIRAConfigs
+}
+
+// NewMockCertExpiryCIRAConfigs creates a new mock instance.
+func NewMockCertExpiryCIRAConfigs(ctrl *gomock.Controller) *MockCertExpiryCIRAConfigs {
+	mock := &MockCertExpiryCIRAConfigs{ctrl: ctrl}
+	mock.recorder = &MockCertExpiryCIRAConfigsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCertExpiryCIRAConfigs) EXPECT() *MockCertExpiryCIRAConfigsMockRecorder {
+	return m.recorder
+}
+
+// GetAllTenants mocks base method.
+func (m *MockCertExpiryCIRAConfigs) GetAllTenants(ctx context.Context, top, skip int) ([]entity.CIRAConfig, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllTenants", ctx, top, skip)
+	ret0, _ := ret[0].([]entity.CIRAConfig)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllTenants indicates an expected call of GetAllTenants.
+func (mr *MockCertExpiryCIRAConfigsMockRecorder) GetAllTenants(ctx, top, skip any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllTenants", reflect.TypeOf((*MockCertExpiryCIRAConfigs)(nil).GetAllTenants), ctx, top, skip)
+}
+
+// MockCertExpiryPublisher is a mock of Publisher interface.
+type MockCertExpiryPublisher struct {
+	ctrl     *gomock.Controller
+	recorder *MockCertExpiryPublisherMockRecorder
+	isgomock struct{}
+}
+
+// MockCertExpiryPublisherMockRecorder is the mock recorder for MockCertExpiryPublisher.
+type MockCertExpiryPublisherMockRecorder struct {
+	mock *MockCertExpiryPublisher
+}
+
+// NewMockCertExpiryPublisher creates a new mock instance.
+func NewMockCertExpiryPublisher(ctrl *gomock.Controller) *MockCertExpiryPublisher {
+	mock := &MockCertExpiryPublisher{ctrl: ctrl}
+	mock.recorder = &MockCertExpiryPublisherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCertExpiryPublisher) EXPECT() *MockCertExpiryPublisherMockRecorder {
+	return m.recorder
+}
+
+// Publish mocks base method.
+func (m *MockCertExpiryPublisher) Publish(ctx context.Context, e dto.Event) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Publish", ctx, e)
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockCertExpiryPublisherMockRecorder) Publish(ctx, e any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockCertExpiryPublisher)(nil).Publish), ctx, e)
+}
@@ -0,0 +1,155 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/usecase/consoleaudit/interfaces.go
+//
+// Generated by this command:
+//
+//	mockgen -source ./internal/usecase/consoleaudit/interfaces.go -package mocks -mock_names Repository=MockConsoleAuditRepository,Feature=MockConsoleAuditFeature
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/open-amt-cloud-toolkit/console/internal/entity"
+	dto "github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockConsoleAuditRepository is a mock of Repository interface.
+type MockConsoleAuditRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockConsoleAuditRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockConsoleAuditRepositoryMockRecorder is the mock recorder for MockConsoleAuditRepository.
+type MockConsoleAuditRepositoryMockRecorder struct {
+	mock *MockConsoleAuditRepository
+}
+
+// NewMockConsoleAuditRepository creates a new mock instance.
+func NewMockConsoleAuditRepository(ctrl *gomock.Controller) *MockConsoleAuditRepository {
+	mock := &MockConsoleAuditRepository{ctrl: ctrl}
+	mock.recorder = &MockConsoleAuditRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockConsoleAuditRepository) EXPECT() *MockConsoleAuditRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockConsoleAuditRepository) Get(ctx context.Context, filter entity.ConsoleAuditFilter, top, skip int) ([]entity.ConsoleAuditEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, filter, top, skip)
+	ret0, _ := ret[0].([]entity.ConsoleAuditEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockConsoleAuditRepositoryMockRecorder) Get(ctx, filter, top, skip any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockConsoleAuditRepository)(nil).Get), ctx, filter, top, skip)
+}
+
+// GetCount mocks base method.
+func (m *MockConsoleAuditRepository) GetCount(ctx context.Context, filter entity.ConsoleAuditFilter) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCount", ctx, filter)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCount indicates an expected call of GetCount.
+func (mr *MockConsoleAuditRepositoryMockRecorder) GetCount(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCount", reflect.TypeOf((*MockConsoleAuditRepository)(nil).GetCount), ctx, filter)
+}
+
+// Insert mocks base method.
+func (m *MockConsoleAuditRepository) Insert(ctx context.Context, e *entity.ConsoleAuditEntry) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", ctx, e)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockConsoleAuditRepositoryMockRecorder) Insert(ctx, e any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockConsoleAuditRepository)(nil).Insert), ctx, e)
+}
+
+// MockConsoleAuditFeature is a mock of Feature interface.
+type MockConsoleAuditFeature struct {
+	ctrl     *gomock.Controller
+	recorder *MockConsoleAuditFeatureMockRecorder
+	isgomock struct{}
+}
+
+// MockConsoleAuditFeatureMockRecorder is the mock recorder for MockConsoleAuditFeature.
+type MockConsoleAuditFeatureMockRecorder struct {
+	mock *MockConsoleAuditFeature
+}
+
+// NewMockConsoleAuditFeature creates a new mock instance.
+func NewMockConsoleAuditFeature(ctrl *gomock.Controller) *MockConsoleAuditFeature {
+	mock := &MockConsoleAuditFeature{ctrl: ctrl}
+	mock.recorder = &MockConsoleAuditFeatureMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockConsoleAuditFeature) EXPECT() *MockConsoleAuditFeatureMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockConsoleAuditFeature) Get(ctx context.Context, filter dto.ConsoleAuditFilter, top, skip int) ([]dto.ConsoleAuditEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, filter, top, skip)
+	ret0, _ := ret[0].([]dto.ConsoleAuditEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockConsoleAuditFeatureMockRecorder) Get(ctx, filter, top, skip any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockConsoleAuditFeature)(nil).Get), ctx, filter, top, skip)
+}
+
+// GetCount mocks base method.
+func (m *MockConsoleAuditFeature) GetCount(ctx context.Context, filter dto.ConsoleAuditFilter) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCount", ctx, filter)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCount indicates an expected call of GetCount.
+func (mr *MockConsoleAuditFeatureMockRecorder) GetCount(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCount", reflect.TypeOf((*MockConsoleAuditFeature)(nil).GetCount), ctx, filter)
+}
+
+// Record mocks base method.
+func (m *MockConsoleAuditFeature) Record(ctx context.Context, e *dto.ConsoleAuditEntry) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Record", ctx, e)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Record indicates an expected call of Record.
+func (mr *MockConsoleAuditFeatureMockRecorder) Record(ctx, e any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Record", reflect.TypeOf((*MockConsoleAuditFeature)(nil).Record), ctx, e)
+}
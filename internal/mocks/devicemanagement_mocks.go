@@ -3,7 +3,7 @@
 //
 // Generated by this command:
 //
-//	mockgen -source ./internal/usecase/devices/interfaces.go -package mocks -mock_names Repository=MockDeviceManagementRepository,Feature=MockDeviceManagementFeature
+//	mockgen -source ./internal/usecase/devices/interfaces.go -package mocks -mock_names Repository=MockDeviceManagementRepository,Feature=MockDeviceManagementFeature,Recorder=MockRedirectionRecorder,Policies=MockRedirectionPolicies,VNC=MockVNC,ThumbnailRepository=MockThumbnailRepository,Thumbnails=MockThumbnails
 //
 
 // Package mocks is a generated GoMock package.
@@ -12,6 +12,7 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	websocket "github.com/gorilla/websocket"
 	entity "github.com/open-amt-cloud-toolkit/console/internal/entity"
@@ -19,6 +20,7 @@ import (
 	v2 "github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v2"
 	devices "github.com/open-amt-cloud-toolkit/console/internal/usecase/devices"
 	wsman "github.com/open-amt-cloud-toolkit/console/internal/usecase/devices/wsman"
+	recordings "github.com/open-amt-cloud-toolkit/console/internal/usecase/recordings"
 	wsman0 "github.com/open-amt-cloud-toolkit/go-wsman-messages/v2/pkg/wsman"
 	power "github.com/open-amt-cloud-toolkit/go-wsman-messages/v2/pkg/wsman/cim/power"
 	gomock "go.uber.org/mock/gomock"
@@ -74,18 +76,6 @@ func (mr *MockWSMANMockRecorder) SetupWsmanClient(device, isRedirection, logMess
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetupWsmanClient", reflect.TypeOf((*MockWSMAN)(nil).SetupWsmanClient), device, isRedirection, logMessages)
 }
 
-// Worker mocks base method.
-func (m *MockWSMAN) Worker() {
-	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "Worker")
-}
-
-// Worker indicates an expected call of Worker.
-func (mr *MockWSMANMockRecorder) Worker() *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Worker", reflect.TypeOf((*MockWSMAN)(nil).Worker))
-}
-
 // MockWebSocketConn is a mock of WebSocketConn interface.
 type MockWebSocketConn struct {
 	ctrl     *gomock.Controller
@@ -140,6 +130,20 @@ func (mr *MockWebSocketConnMockRecorder) ReadMessage() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadMessage", reflect.TypeOf((*MockWebSocketConn)(nil).ReadMessage))
 }
 
+// WriteControl mocks base method.
+func (m *MockWebSocketConn) WriteControl(messageType int, data []byte, deadline time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WriteControl", messageType, data, deadline)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WriteControl indicates an expected call of WriteControl.
+func (mr *MockWebSocketConnMockRecorder) WriteControl(messageType, data, deadline any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WriteControl", reflect.TypeOf((*MockWebSocketConn)(nil).WriteControl), messageType, data, deadline)
+}
+
 // WriteMessage mocks base method.
 func (m *MockWebSocketConn) WriteMessage(messageType int, data []byte) error {
 	m.ctrl.T.Helper()
@@ -249,6 +253,318 @@ func (mr *MockRedirectionMockRecorder) SetupWsmanClient(device, isRedirection, l
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetupWsmanClient", reflect.TypeOf((*MockRedirection)(nil).SetupWsmanClient), device, isRedirection, logMessages)
 }
 
+// MockPublisher is a mock of Publisher interface.
+type MockPublisher struct {
+	ctrl     *gomock.Controller
+	recorder *MockPublisherMockRecorder
+	isgomock struct{}
+}
+
+// MockPublisherMockRecorder is the mock recorder for MockPublisher.
+type MockPublisherMockRecorder struct {
+	mock *MockPublisher
+}
+
+// NewMockPublisher creates a new mock instance.
+func NewMockPublisher(ctrl *gomock.Controller) *MockPublisher {
+	mock := &MockPublisher{ctrl: ctrl}
+	mock.recorder = &MockPublisherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPublisher) EXPECT() *MockPublisherMockRecorder {
+	return m.recorder
+}
+
+// Publish mocks base method.
+func (m *MockPublisher) Publish(ctx context.Context, e dto.Event) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Publish", ctx, e)
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockPublisherMockRecorder) Publish(ctx, e any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockPublisher)(nil).Publish), ctx, e)
+}
+
+// MockIDERMedia is a mock of IDERMedia interface.
+type MockIDERMedia struct {
+	ctrl     *gomock.Controller
+	recorder *MockIDERMediaMockRecorder
+	isgomock struct{}
+}
+
+// MockIDERMediaMockRecorder is the mock recorder for MockIDERMedia.
+type MockIDERMediaMockRecorder struct {
+	mock *MockIDERMedia
+}
+
+// NewMockIDERMedia creates a new mock instance.
+func NewMockIDERMedia(ctrl *gomock.Controller) *MockIDERMedia {
+	mock := &MockIDERMedia{ctrl: ctrl}
+	mock.recorder = &MockIDERMediaMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIDERMedia) EXPECT() *MockIDERMediaMockRecorder {
+	return m.recorder
+}
+
+// Close mocks base method.
+func (m *MockIDERMedia) Close() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockIDERMediaMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockIDERMedia)(nil).Close))
+}
+
+// Info mocks base method.
+func (m *MockIDERMedia) Info() dto.IDERMedia {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Info")
+	ret0, _ := ret[0].(dto.IDERMedia)
+	return ret0
+}
+
+// Info indicates an expected call of Info.
+func (mr *MockIDERMediaMockRecorder) Info() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Info", reflect.TypeOf((*MockIDERMedia)(nil).Info))
+}
+
+// ReadAt mocks base method.
+func (m *MockIDERMedia) ReadAt(p []byte, off int64) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadAt", p, off)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadAt indicates an expected call of ReadAt.
+func (mr *MockIDERMediaMockRecorder) ReadAt(p, off any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadAt", reflect.TypeOf((*MockIDERMedia)(nil).ReadAt), p, off)
+}
+
+// MockRedirectionRecorder is a mock of Recorder interface.
+type MockRedirectionRecorder struct {
+	ctrl     *gomock.Controller
+	recorder *MockRedirectionRecorderMockRecorder
+	isgomock struct{}
+}
+
+// MockRedirectionRecorderMockRecorder is the mock recorder for MockRedirectionRecorder.
+type MockRedirectionRecorderMockRecorder struct {
+	mock *MockRedirectionRecorder
+}
+
+// NewMockRedirectionRecorder creates a new mock instance.
+func NewMockRedirectionRecorder(ctrl *gomock.Controller) *MockRedirectionRecorder {
+	mock := &MockRedirectionRecorder{ctrl: ctrl}
+	mock.recorder = &MockRedirectionRecorderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRedirectionRecorder) EXPECT() *MockRedirectionRecorderMockRecorder {
+	return m.recorder
+}
+
+// Record mocks base method.
+func (m *MockRedirectionRecorder) Record(ctx context.Context, device entity.Device, mode string) (recordings.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Record", ctx, device, mode)
+	ret0, _ := ret[0].(recordings.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Record indicates an expected call of Record.
+func (mr *MockRedirectionRecorderMockRecorder) Record(ctx, device, mode any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Record", reflect.TypeOf((*MockRedirectionRecorder)(nil).Record), ctx, device, mode)
+}
+
+// MockVNC is a mock of VNC interface.
+type MockVNC struct {
+	ctrl     *gomock.Controller
+	recorder *MockVNCMockRecorder
+	isgomock struct{}
+}
+
+// MockVNCMockRecorder is the mock recorder for MockVNC.
+type MockVNCMockRecorder struct {
+	mock *MockVNC
+}
+
+// NewMockVNC creates a new mock instance.
+func NewMockVNC(ctrl *gomock.Controller) *MockVNC {
+	mock := &MockVNC{ctrl: ctrl}
+	mock.recorder = &MockVNCMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVNC) EXPECT() *MockVNCMockRecorder {
+	return m.recorder
+}
+
+// AllocateVNC mocks base method.
+func (m *MockVNC) AllocateVNC(ctx context.Context, guid string) (dto.VNCAllocation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AllocateVNC", ctx, guid)
+	ret0, _ := ret[0].(dto.VNCAllocation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AllocateVNC indicates an expected call of AllocateVNC.
+func (mr *MockVNCMockRecorder) AllocateVNC(ctx, guid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AllocateVNC", reflect.TypeOf((*MockVNC)(nil).AllocateVNC), ctx, guid)
+}
+
+// MockThumbnailRepository is a mock of ThumbnailRepository interface.
+type MockThumbnailRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockThumbnailRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockThumbnailRepositoryMockRecorder is the mock recorder for MockThumbnailRepository.
+type MockThumbnailRepositoryMockRecorder struct {
+	mock *MockThumbnailRepository
+}
+
+// NewMockThumbnailRepository creates a new mock instance.
+func NewMockThumbnailRepository(ctrl *gomock.Controller) *MockThumbnailRepository {
+	mock := &MockThumbnailRepository{ctrl: ctrl}
+	mock.recorder = &MockThumbnailRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockThumbnailRepository) EXPECT() *MockThumbnailRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockThumbnailRepository) Get(ctx context.Context, guid, tenantID string) (*entity.DeviceThumbnail, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, guid, tenantID)
+	ret0, _ := ret[0].(*entity.DeviceThumbnail)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockThumbnailRepositoryMockRecorder) Get(ctx, guid, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockThumbnailRepository)(nil).Get), ctx, guid, tenantID)
+}
+
+// Upsert mocks base method.
+func (m *MockThumbnailRepository) Upsert(ctx context.Context, t *entity.DeviceThumbnail) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upsert", ctx, t)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Upsert indicates an expected call of Upsert.
+func (mr *MockThumbnailRepositoryMockRecorder) Upsert(ctx, t any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upsert", reflect.TypeOf((*MockThumbnailRepository)(nil).Upsert), ctx, t)
+}
+
+// MockThumbnails is a mock of Thumbnails interface.
+type MockThumbnails struct {
+	ctrl     *gomock.Controller
+	recorder *MockThumbnailsMockRecorder
+	isgomock struct{}
+}
+
+// MockThumbnailsMockRecorder is the mock recorder for MockThumbnails.
+type MockThumbnailsMockRecorder struct {
+	mock *MockThumbnails
+}
+
+// NewMockThumbnails creates a new mock instance.
+func NewMockThumbnails(ctrl *gomock.Controller) *MockThumbnails {
+	mock := &MockThumbnails{ctrl: ctrl}
+	mock.recorder = &MockThumbnailsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockThumbnails) EXPECT() *MockThumbnailsMockRecorder {
+	return m.recorder
+}
+
+// GetThumbnail mocks base method.
+func (m *MockThumbnails) GetThumbnail(ctx context.Context, guid string) (dto.DeviceThumbnail, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetThumbnail", ctx, guid)
+	ret0, _ := ret[0].(dto.DeviceThumbnail)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetThumbnail indicates an expected call of GetThumbnail.
+func (mr *MockThumbnailsMockRecorder) GetThumbnail(ctx, guid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetThumbnail", reflect.TypeOf((*MockThumbnails)(nil).GetThumbnail), ctx, guid)
+}
+
+// MockRedirectionPolicies is a mock of Policies interface.
+type MockRedirectionPolicies struct {
+	ctrl     *gomock.Controller
+	recorder *MockRedirectionPoliciesMockRecorder
+	isgomock struct{}
+}
+
+// MockRedirectionPoliciesMockRecorder is the mock recorder for MockRedirectionPolicies.
+type MockRedirectionPoliciesMockRecorder struct {
+	mock *MockRedirectionPolicies
+}
+
+// NewMockRedirectionPolicies creates a new mock instance.
+func NewMockRedirectionPolicies(ctrl *gomock.Controller) *MockRedirectionPolicies {
+	mock := &MockRedirectionPolicies{ctrl: ctrl}
+	mock.recorder = &MockRedirectionPoliciesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRedirectionPolicies) EXPECT() *MockRedirectionPoliciesMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockRedirectionPolicies) Get(ctx context.Context, tenantID string) (dto.RedirectionPolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, tenantID)
+	ret0, _ := ret[0].(dto.RedirectionPolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockRedirectionPoliciesMockRecorder) Get(ctx, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockRedirectionPolicies)(nil).Get), ctx, tenantID)
+}
+
 // MockDeviceManagementRepository is a mock of Repository interface.
 type MockDeviceManagementRepository struct {
 	ctrl     *gomock.Controller
@@ -303,6 +619,21 @@ func (mr *MockDeviceManagementRepositoryMockRecorder) Get(ctx, top, skip, tenant
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockDeviceManagementRepository)(nil).Get), ctx, top, skip, tenantID)
 }
 
+// GetAllTenants mocks base method.
+func (m *MockDeviceManagementRepository) GetAllTenants(ctx context.Context, top, skip int) ([]entity.Device, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllTenants", ctx, top, skip)
+	ret0, _ := ret[0].([]entity.Device)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllTenants indicates an expected call of GetAllTenants.
+func (mr *MockDeviceManagementRepositoryMockRecorder) GetAllTenants(ctx, top, skip any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllTenants", reflect.TypeOf((*MockDeviceManagementRepository)(nil).GetAllTenants), ctx, top, skip)
+}
+
 // GetByColumn mocks base method.
 func (m *MockDeviceManagementRepository) GetByColumn(ctx context.Context, columnName, queryValue, tenantID string) ([]entity.Device, error) {
 	m.ctrl.T.Helper()
@@ -348,6 +679,21 @@ func (mr *MockDeviceManagementRepositoryMockRecorder) GetByTags(ctx, tags, metho
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByTags", reflect.TypeOf((*MockDeviceManagementRepository)(nil).GetByTags), ctx, tags, method, limit, offset, tenantID)
 }
 
+// GetConnectedCount mocks base method.
+func (m *MockDeviceManagementRepository) GetConnectedCount(ctx context.Context, tenantID string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetConnectedCount", ctx, tenantID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetConnectedCount indicates an expected call of GetConnectedCount.
+func (mr *MockDeviceManagementRepositoryMockRecorder) GetConnectedCount(ctx, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConnectedCount", reflect.TypeOf((*MockDeviceManagementRepository)(nil).GetConnectedCount), ctx, tenantID)
+}
+
 // GetCount mocks base method.
 func (m *MockDeviceManagementRepository) GetCount(arg0 context.Context, arg1 string) (int, error) {
 	m.ctrl.T.Helper()
@@ -408,6 +754,21 @@ func (mr *MockDeviceManagementRepositoryMockRecorder) Update(ctx, d any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockDeviceManagementRepository)(nil).Update), ctx, d)
 }
 
+// UpdateHealth mocks base method.
+func (m *MockDeviceManagementRepository) UpdateHealth(ctx context.Context, d *entity.Device) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateHealth", ctx, d)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateHealth indicates an expected call of UpdateHealth.
+func (mr *MockDeviceManagementRepositoryMockRecorder) UpdateHealth(ctx, d any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateHealth", reflect.TypeOf((*MockDeviceManagementRepository)(nil).UpdateHealth), ctx, d)
+}
+
 // MockDeviceManagementFeature is a mock of Feature interface.
 type MockDeviceManagementFeature struct {
 	ctrl     *gomock.Controller
@@ -447,6 +808,34 @@ func (mr *MockDeviceManagementFeatureMockRecorder) CancelUserConsent(ctx, guid a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelUserConsent", reflect.TypeOf((*MockDeviceManagementFeature)(nil).CancelUserConsent), ctx, guid)
 }
 
+// CloseIDER mocks base method.
+func (m *MockDeviceManagementFeature) CloseIDER(ctx context.Context, guid string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseIDER", ctx, guid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CloseIDER indicates an expected call of CloseIDER.
+func (mr *MockDeviceManagementFeatureMockRecorder) CloseIDER(ctx, guid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseIDER", reflect.TypeOf((*MockDeviceManagementFeature)(nil).CloseIDER), ctx, guid)
+}
+
+// CloseSOL mocks base method.
+func (m *MockDeviceManagementFeature) CloseSOL(ctx context.Context, guid string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseSOL", ctx, guid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CloseSOL indicates an expected call of CloseSOL.
+func (mr *MockDeviceManagementFeatureMockRecorder) CloseSOL(ctx, guid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseSOL", reflect.TypeOf((*MockDeviceManagementFeature)(nil).CloseSOL), ctx, guid)
+}
+
 // CreateAlarmOccurrences mocks base method.
 func (m *MockDeviceManagementFeature) CreateAlarmOccurrences(ctx context.Context, guid string, alarm dto.AlarmClockOccurrenceInput) (dto.AddAlarmOutput, error) {
 	m.ctrl.T.Helper()
@@ -535,6 +924,21 @@ func (mr *MockDeviceManagementFeatureMockRecorder) GetAuditLog(ctx, startIndex,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuditLog", reflect.TypeOf((*MockDeviceManagementFeature)(nil).GetAuditLog), ctx, startIndex, guid)
 }
 
+// GetBulkPowerActionJob mocks base method.
+func (m *MockDeviceManagementFeature) GetBulkPowerActionJob(ctx context.Context, jobID string) (dto.BulkPowerActionJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBulkPowerActionJob", ctx, jobID)
+	ret0, _ := ret[0].(dto.BulkPowerActionJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBulkPowerActionJob indicates an expected call of GetBulkPowerActionJob.
+func (mr *MockDeviceManagementFeatureMockRecorder) GetBulkPowerActionJob(ctx, jobID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBulkPowerActionJob", reflect.TypeOf((*MockDeviceManagementFeature)(nil).GetBulkPowerActionJob), ctx, jobID)
+}
+
 // GetByColumn mocks base method.
 func (m *MockDeviceManagementFeature) GetByColumn(ctx context.Context, columnName, queryValue, tenantID string) ([]dto.Device, error) {
 	m.ctrl.T.Helper()
@@ -595,6 +999,21 @@ func (mr *MockDeviceManagementFeatureMockRecorder) GetCertificates(c, guid any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCertificates", reflect.TypeOf((*MockDeviceManagementFeature)(nil).GetCertificates), c, guid)
 }
 
+// GetConnectedCount mocks base method.
+func (m *MockDeviceManagementFeature) GetConnectedCount(ctx context.Context, tenantID string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetConnectedCount", ctx, tenantID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetConnectedCount indicates an expected call of GetConnectedCount.
+func (mr *MockDeviceManagementFeatureMockRecorder) GetConnectedCount(ctx, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConnectedCount", reflect.TypeOf((*MockDeviceManagementFeature)(nil).GetConnectedCount), ctx, tenantID)
+}
+
 // GetCount mocks base method.
 func (m *MockDeviceManagementFeature) GetCount(arg0 context.Context, arg1 string) (int, error) {
 	m.ctrl.T.Helper()
@@ -716,6 +1135,36 @@ func (mr *MockDeviceManagementFeatureMockRecorder) GetHardwareInfo(ctx, guid any
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHardwareInfo", reflect.TypeOf((*MockDeviceManagementFeature)(nil).GetHardwareInfo), ctx, guid)
 }
 
+// GetIDER mocks base method.
+func (m *MockDeviceManagementFeature) GetIDER(ctx context.Context, guid string) (dto.IDERSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIDER", ctx, guid)
+	ret0, _ := ret[0].(dto.IDERSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIDER indicates an expected call of GetIDER.
+func (mr *MockDeviceManagementFeatureMockRecorder) GetIDER(ctx, guid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIDER", reflect.TypeOf((*MockDeviceManagementFeature)(nil).GetIDER), ctx, guid)
+}
+
+// GetInventory mocks base method.
+func (m *MockDeviceManagementFeature) GetInventory(c context.Context, guid string) (dto.DeviceInventory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInventory", c, guid)
+	ret0, _ := ret[0].(dto.DeviceInventory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInventory indicates an expected call of GetInventory.
+func (mr *MockDeviceManagementFeatureMockRecorder) GetInventory(c, guid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInventory", reflect.TypeOf((*MockDeviceManagementFeature)(nil).GetInventory), c, guid)
+}
+
 // GetNetworkSettings mocks base method.
 func (m *MockDeviceManagementFeature) GetNetworkSettings(c context.Context, guid string) (dto.NetworkSettings, error) {
 	m.ctrl.T.Helper()
@@ -761,6 +1210,51 @@ func (mr *MockDeviceManagementFeatureMockRecorder) GetPowerState(ctx, guid any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPowerState", reflect.TypeOf((*MockDeviceManagementFeature)(nil).GetPowerState), ctx, guid)
 }
 
+// GetRedirectionSessions mocks base method.
+func (m *MockDeviceManagementFeature) GetRedirectionSessions(ctx context.Context, tenantID string) ([]dto.RedirectionSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRedirectionSessions", ctx, tenantID)
+	ret0, _ := ret[0].([]dto.RedirectionSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRedirectionSessions indicates an expected call of GetRedirectionSessions.
+func (mr *MockDeviceManagementFeatureMockRecorder) GetRedirectionSessions(ctx, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRedirectionSessions", reflect.TypeOf((*MockDeviceManagementFeature)(nil).GetRedirectionSessions), ctx, tenantID)
+}
+
+// GetSOL mocks base method.
+func (m *MockDeviceManagementFeature) GetSOL(ctx context.Context, guid string) (dto.SOLSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSOL", ctx, guid)
+	ret0, _ := ret[0].(dto.SOLSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSOL indicates an expected call of GetSOL.
+func (mr *MockDeviceManagementFeatureMockRecorder) GetSOL(ctx, guid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSOL", reflect.TypeOf((*MockDeviceManagementFeature)(nil).GetSOL), ctx, guid)
+}
+
+// GetSOLScreen mocks base method.
+func (m *MockDeviceManagementFeature) GetSOLScreen(ctx context.Context, guid string) (dto.SOLScreen, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSOLScreen", ctx, guid)
+	ret0, _ := ret[0].(dto.SOLScreen)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSOLScreen indicates an expected call of GetSOLScreen.
+func (mr *MockDeviceManagementFeatureMockRecorder) GetSOLScreen(ctx, guid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSOLScreen", reflect.TypeOf((*MockDeviceManagementFeature)(nil).GetSOLScreen), ctx, guid)
+}
+
 // GetTLSSettingData mocks base method.
 func (m *MockDeviceManagementFeature) GetTLSSettingData(c context.Context, guid string) ([]dto.SettingDataResponse, error) {
 	m.ctrl.T.Helper()
@@ -822,6 +1316,36 @@ func (mr *MockDeviceManagementFeatureMockRecorder) Insert(ctx, d any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockDeviceManagementFeature)(nil).Insert), ctx, d)
 }
 
+// OpenIDER mocks base method.
+func (m *MockDeviceManagementFeature) OpenIDER(ctx context.Context, guid string, media devices.IDERMedia, start string) (dto.IDERSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OpenIDER", ctx, guid, media, start)
+	ret0, _ := ret[0].(dto.IDERSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OpenIDER indicates an expected call of OpenIDER.
+func (mr *MockDeviceManagementFeatureMockRecorder) OpenIDER(ctx, guid, media, start any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenIDER", reflect.TypeOf((*MockDeviceManagementFeature)(nil).OpenIDER), ctx, guid, media, start)
+}
+
+// OpenSOL mocks base method.
+func (m *MockDeviceManagementFeature) OpenSOL(ctx context.Context, guid string) (dto.SOLSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OpenSOL", ctx, guid)
+	ret0, _ := ret[0].(dto.SOLSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OpenSOL indicates an expected call of OpenSOL.
+func (mr *MockDeviceManagementFeatureMockRecorder) OpenSOL(ctx, guid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenSOL", reflect.TypeOf((*MockDeviceManagementFeature)(nil).OpenSOL), ctx, guid)
+}
+
 // Redirect mocks base method.
 func (m *MockDeviceManagementFeature) Redirect(ctx context.Context, conn *websocket.Conn, guid, mode string) error {
 	m.ctrl.T.Helper()
@@ -836,6 +1360,36 @@ func (mr *MockDeviceManagementFeatureMockRecorder) Redirect(ctx, conn, guid, mod
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Redirect", reflect.TypeOf((*MockDeviceManagementFeature)(nil).Redirect), ctx, conn, guid, mode)
 }
 
+// Screenshot mocks base method.
+func (m *MockDeviceManagementFeature) Screenshot(ctx context.Context, guid string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Screenshot", ctx, guid)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Screenshot indicates an expected call of Screenshot.
+func (mr *MockDeviceManagementFeatureMockRecorder) Screenshot(ctx, guid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Screenshot", reflect.TypeOf((*MockDeviceManagementFeature)(nil).Screenshot), ctx, guid)
+}
+
+// SendBulkPowerAction mocks base method.
+func (m *MockDeviceManagementFeature) SendBulkPowerAction(ctx context.Context, req dto.BulkPowerActionRequest, tenantID string) (dto.BulkPowerActionJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendBulkPowerAction", ctx, req, tenantID)
+	ret0, _ := ret[0].(dto.BulkPowerActionJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SendBulkPowerAction indicates an expected call of SendBulkPowerAction.
+func (mr *MockDeviceManagementFeatureMockRecorder) SendBulkPowerAction(ctx, req, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendBulkPowerAction", reflect.TypeOf((*MockDeviceManagementFeature)(nil).SendBulkPowerAction), ctx, req, tenantID)
+}
+
 // SendConsentCode mocks base method.
 func (m *MockDeviceManagementFeature) SendConsentCode(ctx context.Context, code dto.UserConsentCode, guid string) (dto.UserConsentMessage, error) {
 	m.ctrl.T.Helper()
@@ -866,6 +1420,20 @@ func (mr *MockDeviceManagementFeatureMockRecorder) SendPowerAction(ctx, guid, ac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendPowerAction", reflect.TypeOf((*MockDeviceManagementFeature)(nil).SendPowerAction), ctx, guid, action)
 }
 
+// SendSOLKeys mocks base method.
+func (m *MockDeviceManagementFeature) SendSOLKeys(ctx context.Context, guid, keys string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendSOLKeys", ctx, guid, keys)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendSOLKeys indicates an expected call of SendSOLKeys.
+func (mr *MockDeviceManagementFeatureMockRecorder) SendSOLKeys(ctx, guid, keys any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendSOLKeys", reflect.TypeOf((*MockDeviceManagementFeature)(nil).SendSOLKeys), ctx, guid, keys)
+}
+
 // SetBootOptions mocks base method.
 func (m *MockDeviceManagementFeature) SetBootOptions(ctx context.Context, guid string, bootSetting dto.BootSetting) (power.PowerActionResponse, error) {
 	m.ctrl.T.Helper()
@@ -897,6 +1465,35 @@ func (mr *MockDeviceManagementFeatureMockRecorder) SetFeatures(ctx, guid, featur
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetFeatures", reflect.TypeOf((*MockDeviceManagementFeature)(nil).SetFeatures), ctx, guid, features)
 }
 
+// SubscribeSOL mocks base method.
+func (m *MockDeviceManagementFeature) SubscribeSOL(ctx context.Context, guid string) (<-chan string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubscribeSOL", ctx, guid)
+	ret0, _ := ret[0].(<-chan string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubscribeSOL indicates an expected call of SubscribeSOL.
+func (mr *MockDeviceManagementFeatureMockRecorder) SubscribeSOL(ctx, guid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeSOL", reflect.TypeOf((*MockDeviceManagementFeature)(nil).SubscribeSOL), ctx, guid)
+}
+
+// TerminateRedirectionSession mocks base method.
+func (m *MockDeviceManagementFeature) TerminateRedirectionSession(ctx context.Context, id, tenantID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TerminateRedirectionSession", ctx, id, tenantID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TerminateRedirectionSession indicates an expected call of TerminateRedirectionSession.
+func (mr *MockDeviceManagementFeatureMockRecorder) TerminateRedirectionSession(ctx, id, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TerminateRedirectionSession", reflect.TypeOf((*MockDeviceManagementFeature)(nil).TerminateRedirectionSession), ctx, id, tenantID)
+}
+
 // Update mocks base method.
 func (m *MockDeviceManagementFeature) Update(ctx context.Context, d *dto.Device) (*dto.Device, error) {
 	m.ctrl.T.Helper()
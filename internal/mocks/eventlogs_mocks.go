@@ -0,0 +1,679 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/usecase/eventlogs/interfaces.go
+//
+// Generated by this command:
+//
+//	mockgen -source ./internal/usecase/eventlogs/interfaces.go -package mocks -mock_names Repository=MockEventLogsRepository,Feature=MockEventLogsFeature,Devices=MockEventLogsDevices,Fleet=MockEventLogsFleet,Notifier=MockEventLogsNotifier,Publisher=MockEventLogsPublisher
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/open-amt-cloud-toolkit/console/internal/entity"
+	dto "github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockEventLogsRepository is a mock of Repository interface.
+type MockEventLogsRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockEventLogsRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockEventLogsRepositoryMockRecorder is the mock recorder for MockEventLogsRepository.
+type MockEventLogsRepositoryMockRecorder struct {
+	mock *MockEventLogsRepository
+}
+
+// NewMockEventLogsRepository creates a new mock instance.
+func NewMockEventLogsRepository(ctrl *gomock.Controller) *MockEventLogsRepository {
+	mock := &MockEventLogsRepository{ctrl: ctrl}
+	mock.recorder = &MockEventLogsRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEventLogsRepository) EXPECT() *MockEventLogsRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteRule mocks base method.
+func (m *MockEventLogsRepository) DeleteRule(ctx context.Context, name, tenantID string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRule", ctx, name, tenantID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteRule indicates an expected call of DeleteRule.
+func (mr *MockEventLogsRepositoryMockRecorder) DeleteRule(ctx, name, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRule", reflect.TypeOf((*MockEventLogsRepository)(nil).DeleteRule), ctx, name, tenantID)
+}
+
+// GetAlertByID mocks base method.
+func (m *MockEventLogsRepository) GetAlertByID(ctx context.Context, id, tenantID string) (*entity.Alert, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAlertByID", ctx, id, tenantID)
+	ret0, _ := ret[0].(*entity.Alert)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAlertByID indicates an expected call of GetAlertByID.
+func (mr *MockEventLogsRepositoryMockRecorder) GetAlertByID(ctx, id, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAlertByID", reflect.TypeOf((*MockEventLogsRepository)(nil).GetAlertByID), ctx, id, tenantID)
+}
+
+// GetAlertCount mocks base method.
+func (m *MockEventLogsRepository) GetAlertCount(ctx context.Context, filter entity.AlertFilter) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAlertCount", ctx, filter)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAlertCount indicates an expected call of GetAlertCount.
+func (mr *MockEventLogsRepositoryMockRecorder) GetAlertCount(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAlertCount", reflect.TypeOf((*MockEventLogsRepository)(nil).GetAlertCount), ctx, filter)
+}
+
+// GetAlerts mocks base method.
+func (m *MockEventLogsRepository) GetAlerts(ctx context.Context, filter entity.AlertFilter, top, skip int) ([]entity.Alert, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAlerts", ctx, filter, top, skip)
+	ret0, _ := ret[0].([]entity.Alert)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAlerts indicates an expected call of GetAlerts.
+func (mr *MockEventLogsRepositoryMockRecorder) GetAlerts(ctx, filter, top, skip any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAlerts", reflect.TypeOf((*MockEventLogsRepository)(nil).GetAlerts), ctx, filter, top, skip)
+}
+
+// GetEnabledRules mocks base method.
+func (m *MockEventLogsRepository) GetEnabledRules(ctx context.Context, tenantID string) ([]entity.AlertRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEnabledRules", ctx, tenantID)
+	ret0, _ := ret[0].([]entity.AlertRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEnabledRules indicates an expected call of GetEnabledRules.
+func (mr *MockEventLogsRepositoryMockRecorder) GetEnabledRules(ctx, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEnabledRules", reflect.TypeOf((*MockEventLogsRepository)(nil).GetEnabledRules), ctx, tenantID)
+}
+
+// GetEventCount mocks base method.
+func (m *MockEventLogsRepository) GetEventCount(ctx context.Context, filter entity.DeviceEventFilter) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEventCount", ctx, filter)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEventCount indicates an expected call of GetEventCount.
+func (mr *MockEventLogsRepositoryMockRecorder) GetEventCount(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEventCount", reflect.TypeOf((*MockEventLogsRepository)(nil).GetEventCount), ctx, filter)
+}
+
+// GetEvents mocks base method.
+func (m *MockEventLogsRepository) GetEvents(ctx context.Context, filter entity.DeviceEventFilter, top, skip int) ([]entity.DeviceEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEvents", ctx, filter, top, skip)
+	ret0, _ := ret[0].([]entity.DeviceEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEvents indicates an expected call of GetEvents.
+func (mr *MockEventLogsRepositoryMockRecorder) GetEvents(ctx, filter, top, skip any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEvents", reflect.TypeOf((*MockEventLogsRepository)(nil).GetEvents), ctx, filter, top, skip)
+}
+
+// GetRuleByName mocks base method.
+func (m *MockEventLogsRepository) GetRuleByName(ctx context.Context, name, tenantID string) (*entity.AlertRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRuleByName", ctx, name, tenantID)
+	ret0, _ := ret[0].(*entity.AlertRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRuleByName indicates an expected call of GetRuleByName.
+func (mr *MockEventLogsRepositoryMockRecorder) GetRuleByName(ctx, name, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRuleByName", reflect.TypeOf((*MockEventLogsRepository)(nil).GetRuleByName), ctx, name, tenantID)
+}
+
+// GetRuleCount mocks base method.
+func (m *MockEventLogsRepository) GetRuleCount(ctx context.Context, tenantID string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRuleCount", ctx, tenantID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRuleCount indicates an expected call of GetRuleCount.
+func (mr *MockEventLogsRepositoryMockRecorder) GetRuleCount(ctx, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRuleCount", reflect.TypeOf((*MockEventLogsRepository)(nil).GetRuleCount), ctx, tenantID)
+}
+
+// GetRules mocks base method.
+func (m *MockEventLogsRepository) GetRules(ctx context.Context, top, skip int, tenantID string) ([]entity.AlertRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRules", ctx, top, skip, tenantID)
+	ret0, _ := ret[0].([]entity.AlertRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRules indicates an expected call of GetRules.
+func (mr *MockEventLogsRepositoryMockRecorder) GetRules(ctx, top, skip, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRules", reflect.TypeOf((*MockEventLogsRepository)(nil).GetRules), ctx, top, skip, tenantID)
+}
+
+// InsertAlert mocks base method.
+func (m *MockEventLogsRepository) InsertAlert(ctx context.Context, a *entity.Alert) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertAlert", ctx, a)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertAlert indicates an expected call of InsertAlert.
+func (mr *MockEventLogsRepositoryMockRecorder) InsertAlert(ctx, a any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertAlert", reflect.TypeOf((*MockEventLogsRepository)(nil).InsertAlert), ctx, a)
+}
+
+// InsertEvents mocks base method.
+func (m *MockEventLogsRepository) InsertEvents(ctx context.Context, events []entity.DeviceEvent) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertEvents", ctx, events)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertEvents indicates an expected call of InsertEvents.
+func (mr *MockEventLogsRepositoryMockRecorder) InsertEvents(ctx, events any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertEvents", reflect.TypeOf((*MockEventLogsRepository)(nil).InsertEvents), ctx, events)
+}
+
+// InsertRule mocks base method.
+func (m *MockEventLogsRepository) InsertRule(ctx context.Context, rule *entity.AlertRule) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertRule", ctx, rule)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertRule indicates an expected call of InsertRule.
+func (mr *MockEventLogsRepositoryMockRecorder) InsertRule(ctx, rule any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertRule", reflect.TypeOf((*MockEventLogsRepository)(nil).InsertRule), ctx, rule)
+}
+
+// UpdateAlertStatus mocks base method.
+func (m *MockEventLogsRepository) UpdateAlertStatus(ctx context.Context, a *entity.Alert, from string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAlertStatus", ctx, a, from)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateAlertStatus indicates an expected call of UpdateAlertStatus.
+func (mr *MockEventLogsRepositoryMockRecorder) UpdateAlertStatus(ctx, a, from any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAlertStatus", reflect.TypeOf((*MockEventLogsRepository)(nil).UpdateAlertStatus), ctx, a, from)
+}
+
+// UpdateRule mocks base method.
+func (m *MockEventLogsRepository) UpdateRule(ctx context.Context, rule *entity.AlertRule) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRule", ctx, rule)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateRule indicates an expected call of UpdateRule.
+func (mr *MockEventLogsRepositoryMockRecorder) UpdateRule(ctx, rule any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRule", reflect.TypeOf((*MockEventLogsRepository)(nil).UpdateRule), ctx, rule)
+}
+
+// MockEventLogsFeature is a mock of Feature interface.
+type MockEventLogsFeature struct {
+	ctrl     *gomock.Controller
+	recorder *MockEventLogsFeatureMockRecorder
+	isgomock struct{}
+}
+
+// MockEventLogsFeatureMockRecorder is the mock recorder for MockEventLogsFeature.
+type MockEventLogsFeatureMockRecorder struct {
+	mock *MockEventLogsFeature
+}
+
+// NewMockEventLogsFeature creates a new mock instance.
+func NewMockEventLogsFeature(ctrl *gomock.Controller) *MockEventLogsFeature {
+	mock := &MockEventLogsFeature{ctrl: ctrl}
+	mock.recorder = &MockEventLogsFeatureMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEventLogsFeature) EXPECT() *MockEventLogsFeatureMockRecorder {
+	return m.recorder
+}
+
+// Acknowledge mocks base method.
+func (m *MockEventLogsFeature) Acknowledge(ctx context.Context, id, tenantID string) (*dto.Alert, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Acknowledge", ctx, id, tenantID)
+	ret0, _ := ret[0].(*dto.Alert)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Acknowledge indicates an expected call of Acknowledge.
+func (mr *MockEventLogsFeatureMockRecorder) Acknowledge(ctx, id, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Acknowledge", reflect.TypeOf((*MockEventLogsFeature)(nil).Acknowledge), ctx, id, tenantID)
+}
+
+// Close mocks base method.
+func (m *MockEventLogsFeature) Close(ctx context.Context, id, tenantID string) (*dto.Alert, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close", ctx, id, tenantID)
+	ret0, _ := ret[0].(*dto.Alert)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockEventLogsFeatureMockRecorder) Close(ctx, id, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockEventLogsFeature)(nil).Close), ctx, id, tenantID)
+}
+
+// Collect mocks base method.
+func (m *MockEventLogsFeature) Collect(ctx context.Context, guid, tenantID string) (*dto.EventLogCollection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Collect", ctx, guid, tenantID)
+	ret0, _ := ret[0].(*dto.EventLogCollection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Collect indicates an expected call of Collect.
+func (mr *MockEventLogsFeatureMockRecorder) Collect(ctx, guid, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Collect", reflect.TypeOf((*MockEventLogsFeature)(nil).Collect), ctx, guid, tenantID)
+}
+
+// DeleteRule mocks base method.
+func (m *MockEventLogsFeature) DeleteRule(ctx context.Context, name, tenantID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRule", ctx, name, tenantID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRule indicates an expected call of DeleteRule.
+func (mr *MockEventLogsFeatureMockRecorder) DeleteRule(ctx, name, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRule", reflect.TypeOf((*MockEventLogsFeature)(nil).DeleteRule), ctx, name, tenantID)
+}
+
+// GetAlertByID mocks base method.
+func (m *MockEventLogsFeature) GetAlertByID(ctx context.Context, id, tenantID string) (*dto.Alert, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAlertByID", ctx, id, tenantID)
+	ret0, _ := ret[0].(*dto.Alert)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAlertByID indicates an expected call of GetAlertByID.
+func (mr *MockEventLogsFeatureMockRecorder) GetAlertByID(ctx, id, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAlertByID", reflect.TypeOf((*MockEventLogsFeature)(nil).GetAlertByID), ctx, id, tenantID)
+}
+
+// GetAlertCount mocks base method.
+func (m *MockEventLogsFeature) GetAlertCount(ctx context.Context, filter dto.AlertFilter) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAlertCount", ctx, filter)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAlertCount indicates an expected call of GetAlertCount.
+func (mr *MockEventLogsFeatureMockRecorder) GetAlertCount(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAlertCount", reflect.TypeOf((*MockEventLogsFeature)(nil).GetAlertCount), ctx, filter)
+}
+
+// GetAlerts mocks base method.
+func (m *MockEventLogsFeature) GetAlerts(ctx context.Context, filter dto.AlertFilter, top, skip int) ([]dto.Alert, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAlerts", ctx, filter, top, skip)
+	ret0, _ := ret[0].([]dto.Alert)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAlerts indicates an expected call of GetAlerts.
+func (mr *MockEventLogsFeatureMockRecorder) GetAlerts(ctx, filter, top, skip any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAlerts", reflect.TypeOf((*MockEventLogsFeature)(nil).GetAlerts), ctx, filter, top, skip)
+}
+
+// GetEventCount mocks base method.
+func (m *MockEventLogsFeature) GetEventCount(ctx context.Context, filter dto.DeviceEventFilter) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEventCount", ctx, filter)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEventCount indicates an expected call of GetEventCount.
+func (mr *MockEventLogsFeatureMockRecorder) GetEventCount(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEventCount", reflect.TypeOf((*MockEventLogsFeature)(nil).GetEventCount), ctx, filter)
+}
+
+// GetEvents mocks base method.
+func (m *MockEventLogsFeature) GetEvents(ctx context.Context, filter dto.DeviceEventFilter, top, skip int) ([]dto.DeviceEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEvents", ctx, filter, top, skip)
+	ret0, _ := ret[0].([]dto.DeviceEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEvents indicates an expected call of GetEvents.
+func (mr *MockEventLogsFeatureMockRecorder) GetEvents(ctx, filter, top, skip any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEvents", reflect.TypeOf((*MockEventLogsFeature)(nil).GetEvents), ctx, filter, top, skip)
+}
+
+// GetRuleByName mocks base method.
+func (m *MockEventLogsFeature) GetRuleByName(ctx context.Context, name, tenantID string) (*dto.AlertRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRuleByName", ctx, name, tenantID)
+	ret0, _ := ret[0].(*dto.AlertRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRuleByName indicates an expected call of GetRuleByName.
+func (mr *MockEventLogsFeatureMockRecorder) GetRuleByName(ctx, name, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRuleByName", reflect.TypeOf((*MockEventLogsFeature)(nil).GetRuleByName), ctx, name, tenantID)
+}
+
+// GetRuleCount mocks base method.
+func (m *MockEventLogsFeature) GetRuleCount(ctx context.Context, tenantID string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRuleCount", ctx, tenantID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRuleCount indicates an expected call of GetRuleCount.
+func (mr *MockEventLogsFeatureMockRecorder) GetRuleCount(ctx, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRuleCount", reflect.TypeOf((*MockEventLogsFeature)(nil).GetRuleCount), ctx, tenantID)
+}
+
+// GetRules mocks base method.
+func (m *MockEventLogsFeature) GetRules(ctx context.Context, top, skip int, tenantID string) ([]dto.AlertRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRules", ctx, top, skip, tenantID)
+	ret0, _ := ret[0].([]dto.AlertRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRules indicates an expected call of GetRules.
+func (mr *MockEventLogsFeatureMockRecorder) GetRules(ctx, top, skip, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRules", reflect.TypeOf((*MockEventLogsFeature)(nil).GetRules), ctx, top, skip, tenantID)
+}
+
+// InsertRule mocks base method.
+func (m *MockEventLogsFeature) InsertRule(ctx context.Context, rule *dto.AlertRule) (*dto.AlertRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertRule", ctx, rule)
+	ret0, _ := ret[0].(*dto.AlertRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertRule indicates an expected call of InsertRule.
+func (mr *MockEventLogsFeatureMockRecorder) InsertRule(ctx, rule any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertRule", reflect.TypeOf((*MockEventLogsFeature)(nil).InsertRule), ctx, rule)
+}
+
+// Run mocks base method.
+func (m *MockEventLogsFeature) Run(ctx context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Run", ctx)
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockEventLogsFeatureMockRecorder) Run(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockEventLogsFeature)(nil).Run), ctx)
+}
+
+// UpdateRule mocks base method.
+func (m *MockEventLogsFeature) UpdateRule(ctx context.Context, rule *dto.AlertRule) (*dto.AlertRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRule", ctx, rule)
+	ret0, _ := ret[0].(*dto.AlertRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateRule indicates an expected call of UpdateRule.
+func (mr *MockEventLogsFeatureMockRecorder) UpdateRule(ctx, rule any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRule", reflect.TypeOf((*MockEventLogsFeature)(nil).UpdateRule), ctx, rule)
+}
+
+// MockEventLogsDevices is a mock of Devices interface.
+type MockEventLogsDevices struct {
+	ctrl     *gomock.Controller
+	recorder *MockEventLogsDevicesMockRecorder
+	isgomock struct{}
+}
+
+// MockEventLogsDevicesMockRecorder is the mock recorder for MockEventLogsDevices.
+type MockEventLogsDevicesMockRecorder struct {
+	mock *MockEventLogsDevices
+}
+
+// NewMockEventLogsDevices creates a new mock instance.
+func NewMockEventLogsDevices(ctrl *gomock.Controller) *MockEventLogsDevices {
+	mock := &MockEventLogsDevices{ctrl: ctrl}
+	mock.recorder = &MockEventLogsDevicesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEventLogsDevices) EXPECT() *MockEventLogsDevicesMockRecorder {
+	return m.recorder
+}
+
+// GetEventLog mocks base method.
+func (m *MockEventLogsDevices) GetEventLog(ctx context.Context, startIndex, maxReadRecords int, guid string) (dto.EventLogs, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEventLog", ctx, startIndex, maxReadRecords, guid)
+	ret0, _ := ret[0].(dto.EventLogs)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEventLog indicates an expected call of GetEventLog.
+func (mr *MockEventLogsDevicesMockRecorder) GetEventLog(ctx, startIndex, maxReadRecords, guid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEventLog", reflect.TypeOf((*MockEventLogsDevices)(nil).GetEventLog), ctx, startIndex, maxReadRecords, guid)
+}
+
+// MockEventLogsFleet is a mock of Fleet interface.
+type MockEventLogsFleet struct {
+	ctrl     *gomock.Controller
+	recorder *MockEventLogsFleetMockRecorder
+	isgomock struct{}
+}
+
+// MockEventLogsFleetMockRecorder is the mock recorder for MockEventLogsFleet.
+type MockEventLogsFleetMockRecorder struct {
+	mock *MockEventLogsFleet
+}
+
+// NewMockEventLogsFleet creates a new mock instance.
+func NewMockEventLogsFleet(ctrl *gomock.Controller) *MockEventLogsFleet {
+	mock := &MockEventLogsFleet{ctrl: ctrl}
+	mock.recorder = &MockEventLogsFleetMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEventLogsFleet) EXPECT() *MockEventLogsFleetMockRecorder {
+	return m.recorder
+}
+
+// GetAllTenants mocks base method.
+func (m *MockEventLogsFleet) GetAllTenants(ctx context.Context, top, skip int) ([]entity.Device, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllTenants", ctx, top, skip)
+	ret0, _ := ret[0].([]entity.Device)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllTenants indicates an expected call of GetAllTenants.
+func (mr *MockEventLogsFleetMockRecorder) GetAllTenants(ctx, top, skip any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllTenants", reflect.TypeOf((*MockEventLogsFleet)(nil).GetAllTenants), ctx, top, skip)
+}
+
+// MockEventLogsNotifier is a mock of Notifier interface.
+type MockEventLogsNotifier struct {
+	ctrl     *gomock.Controller
+	recorder *MockEventLogsNotifierMockRecorder
+	isgomock struct{}
+}
+
+// MockEventLogsNotifierMockRecorder is the mock recorder for MockEventLogsNotifier.
+type MockEventLogsNotifierMockRecorder struct {
+	mock *MockEventLogsNotifier
+}
+
+// NewMockEventLogsNotifier creates a new mock instance.
+func NewMockEventLogsNotifier(ctrl *gomock.Controller) *MockEventLogsNotifier {
+	mock := &MockEventLogsNotifier{ctrl: ctrl}
+	mock.recorder = &MockEventLogsNotifierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEventLogsNotifier) EXPECT() *MockEventLogsNotifierMockRecorder {
+	return m.recorder
+}
+
+// Name mocks base method.
+func (m *MockEventLogsNotifier) Name() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Name")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Name indicates an expected call of Name.
+func (mr *MockEventLogsNotifierMockRecorder) Name() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Name", reflect.TypeOf((*MockEventLogsNotifier)(nil).Name))
+}
+
+// Notify mocks base method.
+func (m *MockEventLogsNotifier) Notify(ctx context.Context, alert dto.Alert) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Notify", ctx, alert)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Notify indicates an expected call of Notify.
+func (mr *MockEventLogsNotifierMockRecorder) Notify(ctx, alert any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Notify", reflect.TypeOf((*MockEventLogsNotifier)(nil).Notify), ctx, alert)
+}
+
+// MockEventLogsPublisher is a mock of Publisher interface.
+type MockEventLogsPublisher struct {
+	ctrl     *gomock.Controller
+	recorder *MockEventLogsPublisherMockRecorder
+	isgomock struct{}
+}
+
+// MockEventLogsPublisherMockRecorder is the mock recorder for MockEventLogsPublisher.
+type MockEventLogsPublisherMockRecorder struct {
+	mock *MockEventLogsPublisher
+}
+
+// NewMockEventLogsPublisher creates a new mock instance.
+func NewMockEventLogsPublisher(ctrl *gomock.Controller) *MockEventLogsPublisher {
+	mock := &MockEventLogsPublisher{ctrl: ctrl}
+	mock.recorder = &MockEventLogsPublisherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEventLogsPublisher) EXPECT() *MockEventLogsPublisherMockRecorder {
+	return m.recorder
+}
+
+// Publish mocks base method.
+func (m *MockEventLogsPublisher) Publish(ctx context.Context, e dto.Event) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Publish", ctx, e)
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockEventLogsPublisherMockRecorder) Publish(ctx, e any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockEventLogsPublisher)(nil).Publish), ctx, e)
+}
@@ -0,0 +1,69 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/usecase/events/interfaces.go
+//
+// Generated by this command:
+//
+//	mockgen -source ./internal/usecase/events/interfaces.go -package mocks -mock_names Feature=MockEventsFeature
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	dto "github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockEventsFeature is a mock of Feature interface.
+type MockEventsFeature struct {
+	ctrl     *gomock.Controller
+	recorder *MockEventsFeatureMockRecorder
+	isgomock struct{}
+}
+
+// MockEventsFeatureMockRecorder is the mock recorder for MockEventsFeature.
+type MockEventsFeatureMockRecorder struct {
+	mock *MockEventsFeature
+}
+
+// NewMockEventsFeature creates a new mock instance.
+func NewMockEventsFeature(ctrl *gomock.Controller) *MockEventsFeature {
+	mock := &MockEventsFeature{ctrl: ctrl}
+	mock.recorder = &MockEventsFeatureMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEventsFeature) EXPECT() *MockEventsFeatureMockRecorder {
+	return m.recorder
+}
+
+// Publish mocks base method.
+func (m *MockEventsFeature) Publish(ctx context.Context, e dto.Event) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Publish", ctx, e)
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockEventsFeatureMockRecorder) Publish(ctx, e any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockEventsFeature)(nil).Publish), ctx, e)
+}
+
+// Subscribe mocks base method.
+func (m *MockEventsFeature) Subscribe(filter dto.EventFilter) (<-chan dto.Event, func()) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Subscribe", filter)
+	ret0, _ := ret[0].(<-chan dto.Event)
+	ret1, _ := ret[1].(func())
+	return ret0, ret1
+}
+
+// Subscribe indicates an expected call of Subscribe.
+func (mr *MockEventsFeatureMockRecorder) Subscribe(filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockEventsFeature)(nil).Subscribe), filter)
+}
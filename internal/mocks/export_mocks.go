@@ -14,6 +14,7 @@ import (
 	reflect "reflect"
 
 	dto "github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	export "github.com/open-amt-cloud-toolkit/console/internal/usecase/export"
 	auditlog "github.com/open-amt-cloud-toolkit/go-wsman-messages/v2/pkg/wsman/amt/auditlog"
 	gomock "go.uber.org/mock/gomock"
 )
@@ -42,32 +43,77 @@ func (m *MockExporter) EXPECT() *MockExporterMockRecorder {
 	return m.recorder
 }
 
-// ExportAuditLogsCSV mocks base method.
-func (m *MockExporter) ExportAuditLogsCSV(logs []auditlog.AuditLogRecord) (io.Reader, error) {
+// AuditLogs mocks base method.
+func (m *MockExporter) AuditLogs(w io.Writer, opts export.Options) (*export.Writer[auditlog.AuditLogRecord], error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ExportAuditLogsCSV", logs)
-	ret0, _ := ret[0].(io.Reader)
+	ret := m.ctrl.Call(m, "AuditLogs", w, opts)
+	ret0, _ := ret[0].(*export.Writer[auditlog.AuditLogRecord])
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ExportAuditLogsCSV indicates an expected call of ExportAuditLogsCSV.
-func (mr *MockExporterMockRecorder) ExportAuditLogsCSV(logs any) *gomock.Call {
+// AuditLogs indicates an expected call of AuditLogs.
+func (mr *MockExporterMockRecorder) AuditLogs(w, opts any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportAuditLogsCSV", reflect.TypeOf((*MockExporter)(nil).ExportAuditLogsCSV), logs)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuditLogs", reflect.TypeOf((*MockExporter)(nil).AuditLogs), w, opts)
 }
 
-// ExportEventLogsCSV mocks base method.
-func (m *MockExporter) ExportEventLogsCSV(logs []dto.EventLog) (io.Reader, error) {
+// ConsoleAudit mocks base method.
+func (m *MockExporter) ConsoleAudit(w io.Writer, opts export.Options) (*export.Writer[dto.ConsoleAuditEntry], error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ExportEventLogsCSV", logs)
-	ret0, _ := ret[0].(io.Reader)
+	ret := m.ctrl.Call(m, "ConsoleAudit", w, opts)
+	ret0, _ := ret[0].(*export.Writer[dto.ConsoleAuditEntry])
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ExportEventLogsCSV indicates an expected call of ExportEventLogsCSV.
-func (mr *MockExporterMockRecorder) ExportEventLogsCSV(logs any) *gomock.Call {
+// ConsoleAudit indicates an expected call of ConsoleAudit.
+func (mr *MockExporterMockRecorder) ConsoleAudit(w, opts any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportEventLogsCSV", reflect.TypeOf((*MockExporter)(nil).ExportEventLogsCSV), logs)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConsoleAudit", reflect.TypeOf((*MockExporter)(nil).ConsoleAudit), w, opts)
+}
+
+// Devices mocks base method.
+func (m *MockExporter) Devices(w io.Writer, opts export.Options) (*export.Writer[dto.Device], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Devices", w, opts)
+	ret0, _ := ret[0].(*export.Writer[dto.Device])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Devices indicates an expected call of Devices.
+func (mr *MockExporterMockRecorder) Devices(w, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Devices", reflect.TypeOf((*MockExporter)(nil).Devices), w, opts)
+}
+
+// EventLogs mocks base method.
+func (m *MockExporter) EventLogs(w io.Writer, opts export.Options) (*export.Writer[dto.EventLog], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EventLogs", w, opts)
+	ret0, _ := ret[0].(*export.Writer[dto.EventLog])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EventLogs indicates an expected call of EventLogs.
+func (mr *MockExporterMockRecorder) EventLogs(w, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EventLogs", reflect.TypeOf((*MockExporter)(nil).EventLogs), w, opts)
+}
+
+// Inventory mocks base method.
+func (m *MockExporter) Inventory(w io.Writer, opts export.Options) (*export.Writer[dto.DeviceInventory], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Inventory", w, opts)
+	ret0, _ := ret[0].(*export.Writer[dto.DeviceInventory])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Inventory indicates an expected call of Inventory.
+func (mr *MockExporterMockRecorder) Inventory(w, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Inventory", reflect.TypeOf((*MockExporter)(nil).Inventory), w, opts)
 }
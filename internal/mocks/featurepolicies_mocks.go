@@ -0,0 +1,486 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/usecase/featurepolicies/interfaces.go
+//
+// Generated by this command:
+//
+//	mockgen -source ./internal/usecase/featurepolicies/interfaces.go -package mocks -mock_names Repository=MockFeaturePoliciesRepository,Feature=MockFeaturePoliciesFeature,Devices=MockFeaturePoliciesDevices,Profiles=MockFeaturePoliciesProfiles
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/open-amt-cloud-toolkit/console/internal/entity"
+	dto "github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	v2 "github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v2"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockFeaturePoliciesRepository is a mock of Repository interface.
+type MockFeaturePoliciesRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockFeaturePoliciesRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockFeaturePoliciesRepositoryMockRecorder is the mock recorder for MockFeaturePoliciesRepository.
+type MockFeaturePoliciesRepositoryMockRecorder struct {
+	mock *MockFeaturePoliciesRepository
+}
+
+// NewMockFeaturePoliciesRepository creates a new mock instance.
+func NewMockFeaturePoliciesRepository(ctrl *gomock.Controller) *MockFeaturePoliciesRepository {
+	mock := &MockFeaturePoliciesRepository{ctrl: ctrl}
+	mock.recorder = &MockFeaturePoliciesRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFeaturePoliciesRepository) EXPECT() *MockFeaturePoliciesRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockFeaturePoliciesRepository) Delete(ctx context.Context, name, tenantID string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, name, tenantID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockFeaturePoliciesRepositoryMockRecorder) Delete(ctx, name, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockFeaturePoliciesRepository)(nil).Delete), ctx, name, tenantID)
+}
+
+// DeleteStaleDrift mocks base method.
+func (m *MockFeaturePoliciesRepository) DeleteStaleDrift(ctx context.Context, checkedAt string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteStaleDrift", ctx, checkedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteStaleDrift indicates an expected call of DeleteStaleDrift.
+func (mr *MockFeaturePoliciesRepositoryMockRecorder) DeleteStaleDrift(ctx, checkedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteStaleDrift", reflect.TypeOf((*MockFeaturePoliciesRepository)(nil).DeleteStaleDrift), ctx, checkedAt)
+}
+
+// Get mocks base method.
+func (m *MockFeaturePoliciesRepository) Get(ctx context.Context, top, skip int, tenantID string) ([]entity.FeaturePolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, top, skip, tenantID)
+	ret0, _ := ret[0].([]entity.FeaturePolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockFeaturePoliciesRepositoryMockRecorder) Get(ctx, top, skip, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockFeaturePoliciesRepository)(nil).Get), ctx, top, skip, tenantID)
+}
+
+// GetAllTenants mocks base method.
+func (m *MockFeaturePoliciesRepository) GetAllTenants(ctx context.Context) ([]entity.FeaturePolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllTenants", ctx)
+	ret0, _ := ret[0].([]entity.FeaturePolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllTenants indicates an expected call of GetAllTenants.
+func (mr *MockFeaturePoliciesRepositoryMockRecorder) GetAllTenants(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllTenants", reflect.TypeOf((*MockFeaturePoliciesRepository)(nil).GetAllTenants), ctx)
+}
+
+// GetByName mocks base method.
+func (m *MockFeaturePoliciesRepository) GetByName(ctx context.Context, name, tenantID string) (*entity.FeaturePolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByName", ctx, name, tenantID)
+	ret0, _ := ret[0].(*entity.FeaturePolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByName indicates an expected call of GetByName.
+func (mr *MockFeaturePoliciesRepositoryMockRecorder) GetByName(ctx, name, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByName", reflect.TypeOf((*MockFeaturePoliciesRepository)(nil).GetByName), ctx, name, tenantID)
+}
+
+// GetCount mocks base method.
+func (m *MockFeaturePoliciesRepository) GetCount(ctx context.Context, tenantID string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCount", ctx, tenantID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCount indicates an expected call of GetCount.
+func (mr *MockFeaturePoliciesRepositoryMockRecorder) GetCount(ctx, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCount", reflect.TypeOf((*MockFeaturePoliciesRepository)(nil).GetCount), ctx, tenantID)
+}
+
+// GetDrift mocks base method.
+func (m *MockFeaturePoliciesRepository) GetDrift(ctx context.Context, policyName string, top, skip int, tenantID string) ([]entity.FeatureDrift, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDrift", ctx, policyName, top, skip, tenantID)
+	ret0, _ := ret[0].([]entity.FeatureDrift)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDrift indicates an expected call of GetDrift.
+func (mr *MockFeaturePoliciesRepositoryMockRecorder) GetDrift(ctx, policyName, top, skip, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDrift", reflect.TypeOf((*MockFeaturePoliciesRepository)(nil).GetDrift), ctx, policyName, top, skip, tenantID)
+}
+
+// GetRemediations mocks base method.
+func (m *MockFeaturePoliciesRepository) GetRemediations(ctx context.Context, policyName string, top, skip int, tenantID string) ([]entity.FeatureRemediation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRemediations", ctx, policyName, top, skip, tenantID)
+	ret0, _ := ret[0].([]entity.FeatureRemediation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRemediations indicates an expected call of GetRemediations.
+func (mr *MockFeaturePoliciesRepositoryMockRecorder) GetRemediations(ctx, policyName, top, skip, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRemediations", reflect.TypeOf((*MockFeaturePoliciesRepository)(nil).GetRemediations), ctx, policyName, top, skip, tenantID)
+}
+
+// Insert mocks base method.
+func (m *MockFeaturePoliciesRepository) Insert(ctx context.Context, p *entity.FeaturePolicy) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", ctx, p)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockFeaturePoliciesRepositoryMockRecorder) Insert(ctx, p any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockFeaturePoliciesRepository)(nil).Insert), ctx, p)
+}
+
+// InsertRemediation mocks base method.
+func (m *MockFeaturePoliciesRepository) InsertRemediation(ctx context.Context, r *entity.FeatureRemediation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertRemediation", ctx, r)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertRemediation indicates an expected call of InsertRemediation.
+func (mr *MockFeaturePoliciesRepositoryMockRecorder) InsertRemediation(ctx, r any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertRemediation", reflect.TypeOf((*MockFeaturePoliciesRepository)(nil).InsertRemediation), ctx, r)
+}
+
+// Update mocks base method.
+func (m *MockFeaturePoliciesRepository) Update(ctx context.Context, p *entity.FeaturePolicy) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, p)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockFeaturePoliciesRepositoryMockRecorder) Update(ctx, p any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockFeaturePoliciesRepository)(nil).Update), ctx, p)
+}
+
+// UpsertDrift mocks base method.
+func (m *MockFeaturePoliciesRepository) UpsertDrift(ctx context.Context, d *entity.FeatureDrift) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertDrift", ctx, d)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertDrift indicates an expected call of UpsertDrift.
+func (mr *MockFeaturePoliciesRepositoryMockRecorder) UpsertDrift(ctx, d any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertDrift", reflect.TypeOf((*MockFeaturePoliciesRepository)(nil).UpsertDrift), ctx, d)
+}
+
+// MockFeaturePoliciesFeature is a mock of Feature interface.
+type MockFeaturePoliciesFeature struct {
+	ctrl     *gomock.Controller
+	recorder *MockFeaturePoliciesFeatureMockRecorder
+	isgomock struct{}
+}
+
+// MockFeaturePoliciesFeatureMockRecorder is the mock recorder for MockFeaturePoliciesFeature.
+type MockFeaturePoliciesFeatureMockRecorder struct {
+	mock *MockFeaturePoliciesFeature
+}
+
+// NewMockFeaturePoliciesFeature creates a new mock instance.
+func NewMockFeaturePoliciesFeature(ctrl *gomock.Controller) *MockFeaturePoliciesFeature {
+	mock := &MockFeaturePoliciesFeature{ctrl: ctrl}
+	mock.recorder = &MockFeaturePoliciesFeatureMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFeaturePoliciesFeature) EXPECT() *MockFeaturePoliciesFeatureMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockFeaturePoliciesFeature) Delete(ctx context.Context, name, tenantID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, name, tenantID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockFeaturePoliciesFeatureMockRecorder) Delete(ctx, name, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockFeaturePoliciesFeature)(nil).Delete), ctx, name, tenantID)
+}
+
+// Get mocks base method.
+func (m *MockFeaturePoliciesFeature) Get(ctx context.Context, top, skip int, tenantID string) ([]dto.FeaturePolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, top, skip, tenantID)
+	ret0, _ := ret[0].([]dto.FeaturePolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockFeaturePoliciesFeatureMockRecorder) Get(ctx, top, skip, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockFeaturePoliciesFeature)(nil).Get), ctx, top, skip, tenantID)
+}
+
+// GetByName mocks base method.
+func (m *MockFeaturePoliciesFeature) GetByName(ctx context.Context, name, tenantID string) (*dto.FeaturePolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByName", ctx, name, tenantID)
+	ret0, _ := ret[0].(*dto.FeaturePolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByName indicates an expected call of GetByName.
+func (mr *MockFeaturePoliciesFeatureMockRecorder) GetByName(ctx, name, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByName", reflect.TypeOf((*MockFeaturePoliciesFeature)(nil).GetByName), ctx, name, tenantID)
+}
+
+// GetCount mocks base method.
+func (m *MockFeaturePoliciesFeature) GetCount(ctx context.Context, tenantID string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCount", ctx, tenantID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCount indicates an expected call of GetCount.
+func (mr *MockFeaturePoliciesFeatureMockRecorder) GetCount(ctx, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCount", reflect.TypeOf((*MockFeaturePoliciesFeature)(nil).GetCount), ctx, tenantID)
+}
+
+// GetDrift mocks base method.
+func (m *MockFeaturePoliciesFeature) GetDrift(ctx context.Context, name string, top, skip int, tenantID string) ([]dto.FeatureDrift, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDrift", ctx, name, top, skip, tenantID)
+	ret0, _ := ret[0].([]dto.FeatureDrift)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDrift indicates an expected call of GetDrift.
+func (mr *MockFeaturePoliciesFeatureMockRecorder) GetDrift(ctx, name, top, skip, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDrift", reflect.TypeOf((*MockFeaturePoliciesFeature)(nil).GetDrift), ctx, name, top, skip, tenantID)
+}
+
+// GetRemediations mocks base method.
+func (m *MockFeaturePoliciesFeature) GetRemediations(ctx context.Context, name string, top, skip int, tenantID string) ([]dto.FeatureRemediation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRemediations", ctx, name, top, skip, tenantID)
+	ret0, _ := ret[0].([]dto.FeatureRemediation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRemediations indicates an expected call of GetRemediations.
+func (mr *MockFeaturePoliciesFeatureMockRecorder) GetRemediations(ctx, name, top, skip, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRemediations", reflect.TypeOf((*MockFeaturePoliciesFeature)(nil).GetRemediations), ctx, name, top, skip, tenantID)
+}
+
+// Insert mocks base method.
+func (m *MockFeaturePoliciesFeature) Insert(ctx context.Context, p *dto.FeaturePolicy) (*dto.FeaturePolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", ctx, p)
+	ret0, _ := ret[0].(*dto.FeaturePolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockFeaturePoliciesFeatureMockRecorder) Insert(ctx, p any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockFeaturePoliciesFeature)(nil).Insert), ctx, p)
+}
+
+// Run mocks base method.
+func (m *MockFeaturePoliciesFeature) Run(ctx context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Run", ctx)
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockFeaturePoliciesFeatureMockRecorder) Run(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockFeaturePoliciesFeature)(nil).Run), ctx)
+}
+
+// Update mocks base method.
+func (m *MockFeaturePoliciesFeature) Update(ctx context.Context, p *dto.FeaturePolicy) (*dto.FeaturePolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, p)
+	ret0, _ := ret[0].(*dto.FeaturePolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockFeaturePoliciesFeatureMockRecorder) Update(ctx, p any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockFeaturePoliciesFeature)(nil).Update), ctx, p)
+}
+
+// MockFeaturePoliciesDevices is a mock of Devices interface.
+type MockFeaturePoliciesDevices struct {
+	ctrl     *gomock.Controller
+	recorder *MockFeaturePoliciesDevicesMockRecorder
+	isgomock struct{}
+}
+
+// MockFeaturePoliciesDevicesMockRecorder is the mock recorder for MockFeaturePoliciesDevices.
+type MockFeaturePoliciesDevicesMockRecorder struct {
+	mock *MockFeaturePoliciesDevices
+}
+
+// NewMockFeaturePoliciesDevices creates a new mock instance.
+func NewMockFeaturePoliciesDevices(ctrl *gomock.Controller) *MockFeaturePoliciesDevices {
+	mock := &MockFeaturePoliciesDevices{ctrl: ctrl}
+	mock.recorder = &MockFeaturePoliciesDevicesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFeaturePoliciesDevices) EXPECT() *MockFeaturePoliciesDevicesMockRecorder {
+	return m.recorder
+}
+
+// GetByTags mocks base method.
+func (m *MockFeaturePoliciesDevices) GetByTags(ctx context.Context, tags, method string, limit, offset int, tenantID string) ([]dto.Device, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByTags", ctx, tags, method, limit, offset, tenantID)
+	ret0, _ := ret[0].([]dto.Device)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByTags indicates an expected call of GetByTags.
+func (mr *MockFeaturePoliciesDevicesMockRecorder) GetByTags(ctx, tags, method, limit, offset, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByTags", reflect.TypeOf((*MockFeaturePoliciesDevices)(nil).GetByTags), ctx, tags, method, limit, offset, tenantID)
+}
+
+// GetFeatures mocks base method.
+func (m *MockFeaturePoliciesDevices) GetFeatures(ctx context.Context, guid string) (dto.Features, v2.Features, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFeatures", ctx, guid)
+	ret0, _ := ret[0].(dto.Features)
+	ret1, _ := ret[1].(v2.Features)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetFeatures indicates an expected call of GetFeatures.
+func (mr *MockFeaturePoliciesDevicesMockRecorder) GetFeatures(ctx, guid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFeatures", reflect.TypeOf((*MockFeaturePoliciesDevices)(nil).GetFeatures), ctx, guid)
+}
+
+// SetFeatures mocks base method.
+func (m *MockFeaturePoliciesDevices) SetFeatures(ctx context.Context, guid string, features dto.Features) (dto.Features, v2.Features, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetFeatures", ctx, guid, features)
+	ret0, _ := ret[0].(dto.Features)
+	ret1, _ := ret[1].(v2.Features)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// SetFeatures indicates an expected call of SetFeatures.
+func (mr *MockFeaturePoliciesDevicesMockRecorder) SetFeatures(ctx, guid, features any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetFeatures", reflect.TypeOf((*MockFeaturePoliciesDevices)(nil).SetFeatures), ctx, guid, features)
+}
+
+// MockFeaturePoliciesProfiles is a mock of Profiles interface.
+type MockFeaturePoliciesProfiles struct {
+	ctrl     *gomock.Controller
+	recorder *MockFeaturePoliciesProfilesMockRecorder
+	isgomock struct{}
+}
+
+// MockFeaturePoliciesProfilesMockRecorder is the mock recorder for MockFeaturePoliciesProfiles.
+type MockFeaturePoliciesProfilesMockRecorder struct {
+	mock *MockFeaturePoliciesProfiles
+}
+
+// NewMockFeaturePoliciesProfiles creates a new mock instance.
+func NewMockFeaturePoliciesProfiles(ctrl *gomock.Controller) *MockFeaturePoliciesProfiles {
+	mock := &MockFeaturePoliciesProfiles{ctrl: ctrl}
+	mock.recorder = &MockFeaturePoliciesProfilesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFeaturePoliciesProfiles) EXPECT() *MockFeaturePoliciesProfilesMockRecorder {
+	return m.recorder
+}
+
+// GetByName mocks base method.
+func (m *MockFeaturePoliciesProfiles) GetByName(ctx context.Context, profileName, tenantID string) (*dto.Profile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByName", ctx, profileName, tenantID)
+	ret0, _ := ret[0].(*dto.Profile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByName indicates an expected call of GetByName.
+func (mr *MockFeaturePoliciesProfilesMockRecorder) GetByName(ctx, profileName, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByName", reflect.TypeOf((*MockFeaturePoliciesProfiles)(nil).GetByName), ctx, profileName, tenantID)
+}
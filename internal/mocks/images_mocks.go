@@ -0,0 +1,301 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/usecase/images/interfaces.go
+//
+// Generated by this command:
+//
+//	mockgen -source ./internal/usecase/images/interfaces.go -package mocks -mock_names Repository=MockImagesRepository,Feature=MockImagesFeature,Devices=MockImagesDevices
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	io "io"
+	reflect "reflect"
+
+	entity "github.com/open-amt-cloud-toolkit/console/internal/entity"
+	dto "github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	devices "github.com/open-amt-cloud-toolkit/console/internal/usecase/devices"
+	power "github.com/open-amt-cloud-toolkit/go-wsman-messages/v2/pkg/wsman/cim/power"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockImagesRepository is a mock of Repository interface.
+type MockImagesRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockImagesRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockImagesRepositoryMockRecorder is the mock recorder for MockImagesRepository.
+type MockImagesRepositoryMockRecorder struct {
+	mock *MockImagesRepository
+}
+
+// NewMockImagesRepository creates a new mock instance.
+func NewMockImagesRepository(ctrl *gomock.Controller) *MockImagesRepository {
+	mock := &MockImagesRepository{ctrl: ctrl}
+	mock.recorder = &MockImagesRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockImagesRepository) EXPECT() *MockImagesRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockImagesRepository) Delete(ctx context.Context, id, tenantID string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id, tenantID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockImagesRepositoryMockRecorder) Delete(ctx, id, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockImagesRepository)(nil).Delete), ctx, id, tenantID)
+}
+
+// Get mocks base method.
+func (m *MockImagesRepository) Get(ctx context.Context, top, skip int, tenantID string) ([]entity.MediaImage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, top, skip, tenantID)
+	ret0, _ := ret[0].([]entity.MediaImage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockImagesRepositoryMockRecorder) Get(ctx, top, skip, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockImagesRepository)(nil).Get), ctx, top, skip, tenantID)
+}
+
+// GetByID mocks base method.
+func (m *MockImagesRepository) GetByID(ctx context.Context, id, tenantID string) (*entity.MediaImage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id, tenantID)
+	ret0, _ := ret[0].(*entity.MediaImage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockImagesRepositoryMockRecorder) GetByID(ctx, id, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockImagesRepository)(nil).GetByID), ctx, id, tenantID)
+}
+
+// GetCount mocks base method.
+func (m *MockImagesRepository) GetCount(ctx context.Context, tenantID string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCount", ctx, tenantID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCount indicates an expected call of GetCount.
+func (mr *MockImagesRepositoryMockRecorder) GetCount(ctx, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCount", reflect.TypeOf((*MockImagesRepository)(nil).GetCount), ctx, tenantID)
+}
+
+// Insert mocks base method.
+func (m_2 *MockImagesRepository) Insert(ctx context.Context, m *entity.MediaImage) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "Insert", ctx, m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockImagesRepositoryMockRecorder) Insert(ctx, m any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockImagesRepository)(nil).Insert), ctx, m)
+}
+
+// MockImagesDevices is a mock of Devices interface.
+type MockImagesDevices struct {
+	ctrl     *gomock.Controller
+	recorder *MockImagesDevicesMockRecorder
+	isgomock struct{}
+}
+
+// MockImagesDevicesMockRecorder is the mock recorder for MockImagesDevices.
+type MockImagesDevicesMockRecorder struct {
+	mock *MockImagesDevices
+}
+
+// NewMockImagesDevices creates a new mock instance.
+func NewMockImagesDevices(ctrl *gomock.Controller) *MockImagesDevices {
+	mock := &MockImagesDevices{ctrl: ctrl}
+	mock.recorder = &MockImagesDevicesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockImagesDevices) EXPECT() *MockImagesDevicesMockRecorder {
+	return m.recorder
+}
+
+// CloseIDER mocks base method.
+func (m *MockImagesDevices) CloseIDER(ctx context.Context, guid string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseIDER", ctx, guid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CloseIDER indicates an expected call of CloseIDER.
+func (mr *MockImagesDevicesMockRecorder) CloseIDER(ctx, guid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseIDER", reflect.TypeOf((*MockImagesDevices)(nil).CloseIDER), ctx, guid)
+}
+
+// OpenIDER mocks base method.
+func (m *MockImagesDevices) OpenIDER(ctx context.Context, guid string, media devices.IDERMedia, start string) (dto.IDERSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OpenIDER", ctx, guid, media, start)
+	ret0, _ := ret[0].(dto.IDERSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OpenIDER indicates an expected call of OpenIDER.
+func (mr *MockImagesDevicesMockRecorder) OpenIDER(ctx, guid, media, start any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenIDER", reflect.TypeOf((*MockImagesDevices)(nil).OpenIDER), ctx, guid, media, start)
+}
+
+// SetBootOptions mocks base method.
+func (m *MockImagesDevices) SetBootOptions(ctx context.Context, guid string, bootSetting dto.BootSetting) (power.PowerActionResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetBootOptions", ctx, guid, bootSetting)
+	ret0, _ := ret[0].(power.PowerActionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetBootOptions indicates an expected call of SetBootOptions.
+func (mr *MockImagesDevicesMockRecorder) SetBootOptions(ctx, guid, bootSetting any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBootOptions", reflect.TypeOf((*MockImagesDevices)(nil).SetBootOptions), ctx, guid, bootSetting)
+}
+
+// MockImagesFeature is a mock of Feature interface.
+type MockImagesFeature struct {
+	ctrl     *gomock.Controller
+	recorder *MockImagesFeatureMockRecorder
+	isgomock struct{}
+}
+
+// MockImagesFeatureMockRecorder is the mock recorder for MockImagesFeature.
+type MockImagesFeatureMockRecorder struct {
+	mock *MockImagesFeature
+}
+
+// NewMockImagesFeature creates a new mock instance.
+func NewMockImagesFeature(ctrl *gomock.Controller) *MockImagesFeature {
+	mock := &MockImagesFeature{ctrl: ctrl}
+	mock.recorder = &MockImagesFeatureMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockImagesFeature) EXPECT() *MockImagesFeatureMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockImagesFeature) Delete(ctx context.Context, id, tenantID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id, tenantID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockImagesFeatureMockRecorder) Delete(ctx, id, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockImagesFeature)(nil).Delete), ctx, id, tenantID)
+}
+
+// Get mocks base method.
+func (m *MockImagesFeature) Get(ctx context.Context, top, skip int, tenantID string) ([]dto.MediaImage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, top, skip, tenantID)
+	ret0, _ := ret[0].([]dto.MediaImage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockImagesFeatureMockRecorder) Get(ctx, top, skip, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockImagesFeature)(nil).Get), ctx, top, skip, tenantID)
+}
+
+// GetByID mocks base method.
+func (m *MockImagesFeature) GetByID(ctx context.Context, id, tenantID string) (*dto.MediaImage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id, tenantID)
+	ret0, _ := ret[0].(*dto.MediaImage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockImagesFeatureMockRecorder) GetByID(ctx, id, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockImagesFeature)(nil).GetByID), ctx, id, tenantID)
+}
+
+// GetCount mocks base method.
+func (m *MockImagesFeature) GetCount(ctx context.Context, tenantID string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCount", ctx, tenantID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCount indicates an expected call of GetCount.
+func (mr *MockImagesFeatureMockRecorder) GetCount(ctx, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCount", reflect.TypeOf((*MockImagesFeature)(nil).GetCount), ctx, tenantID)
+}
+
+// Mount mocks base method.
+func (m *MockImagesFeature) Mount(ctx context.Context, guid string, mount dto.IDERMount) (dto.IDERSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Mount", ctx, guid, mount)
+	ret0, _ := ret[0].(dto.IDERSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Mount indicates an expected call of Mount.
+func (mr *MockImagesFeatureMockRecorder) Mount(ctx, guid, mount any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Mount", reflect.TypeOf((*MockImagesFeature)(nil).Mount), ctx, guid, mount)
+}
+
+// Upload mocks base method.
+func (m *MockImagesFeature) Upload(ctx context.Context, name string, r io.Reader, tenantID string) (*dto.MediaImage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upload", ctx, name, r, tenantID)
+	ret0, _ := ret[0].(*dto.MediaImage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Upload indicates an expected call of Upload.
+func (mr *MockImagesFeatureMockRecorder) Upload(ctx, name, r, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upload", reflect.TypeOf((*MockImagesFeature)(nil).Upload), ctx, name, r, tenantID)
+}
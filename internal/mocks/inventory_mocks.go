@@ -0,0 +1,306 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/usecase/inventory/interfaces.go
+//
+// Generated by this command:
+//
+//	mockgen -source ./internal/usecase/inventory/interfaces.go -package mocks -mock_names Repository=MockInventoryRepository,Feature=MockInventoryFeature,Devices=MockInventoryDevices,Fleet=MockInventoryFleet
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/open-amt-cloud-toolkit/console/internal/entity"
+	dto "github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockInventoryRepository is a mock of Repository interface.
+type MockInventoryRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockInventoryRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockInventoryRepositoryMockRecorder is the mock recorder for MockInventoryRepository.
+type MockInventoryRepositoryMockRecorder struct {
+	mock *MockInventoryRepository
+}
+
+// NewMockInventoryRepository creates a new mock instance.
+func NewMockInventoryRepository(ctrl *gomock.Controller) *MockInventoryRepository {
+	mock := &MockInventoryRepository{ctrl: ctrl}
+	mock.recorder = &MockInventoryRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInventoryRepository) EXPECT() *MockInventoryRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockInventoryRepository) Get(ctx context.Context, filter entity.InventoryFilter, top, skip int) ([]entity.DeviceInventory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, filter, top, skip)
+	ret0, _ := ret[0].([]entity.DeviceInventory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockInventoryRepositoryMockRecorder) Get(ctx, filter, top, skip any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockInventoryRepository)(nil).Get), ctx, filter, top, skip)
+}
+
+// GetBIOSVersions mocks base method.
+func (m *MockInventoryRepository) GetBIOSVersions(ctx context.Context, tenantID string) ([]entity.BIOSVersionCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBIOSVersions", ctx, tenantID)
+	ret0, _ := ret[0].([]entity.BIOSVersionCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBIOSVersions indicates an expected call of GetBIOSVersions.
+func (mr *MockInventoryRepositoryMockRecorder) GetBIOSVersions(ctx, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBIOSVersions", reflect.TypeOf((*MockInventoryRepository)(nil).GetBIOSVersions), ctx, tenantID)
+}
+
+// GetByGUID mocks base method.
+func (m *MockInventoryRepository) GetByGUID(ctx context.Context, guid, tenantID string) (*entity.DeviceInventory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByGUID", ctx, guid, tenantID)
+	ret0, _ := ret[0].(*entity.DeviceInventory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByGUID indicates an expected call of GetByGUID.
+func (mr *MockInventoryRepositoryMockRecorder) GetByGUID(ctx, guid, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByGUID", reflect.TypeOf((*MockInventoryRepository)(nil).GetByGUID), ctx, guid, tenantID)
+}
+
+// GetCount mocks base method.
+func (m *MockInventoryRepository) GetCount(ctx context.Context, filter entity.InventoryFilter) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCount", ctx, filter)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCount indicates an expected call of GetCount.
+func (mr *MockInventoryRepositoryMockRecorder) GetCount(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCount", reflect.TypeOf((*MockInventoryRepository)(nil).GetCount), ctx, filter)
+}
+
+// Upsert mocks base method.
+func (m *MockInventoryRepository) Upsert(ctx context.Context, i *entity.DeviceInventory) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upsert", ctx, i)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Upsert indicates an expected call of Upsert.
+func (mr *MockInventoryRepositoryMockRecorder) Upsert(ctx, i any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upsert", reflect.TypeOf((*MockInventoryRepository)(nil).Upsert), ctx, i)
+}
+
+// MockInventoryFeature is a mock of Feature interface.
+type MockInventoryFeature struct {
+	ctrl     *gomock.Controller
+	recorder *MockInventoryFeatureMockRecorder
+	isgomock struct{}
+}
+
+// MockInventoryFeatureMockRecorder is the mock recorder for MockInventoryFeature.
+type MockInventoryFeatureMockRecorder struct {
+	mock *MockInventoryFeature
+}
+
+// NewMockInventoryFeature creates a new mock instance.
+func NewMockInventoryFeature(ctrl *gomock.Controller) *MockInventoryFeature {
+	mock := &MockInventoryFeature{ctrl: ctrl}
+	mock.recorder = &MockInventoryFeatureMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInventoryFeature) EXPECT() *MockInventoryFeatureMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockInventoryFeature) Get(ctx context.Context, filter dto.InventoryFilter, top, skip int) ([]dto.DeviceInventory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, filter, top, skip)
+	ret0, _ := ret[0].([]dto.DeviceInventory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockInventoryFeatureMockRecorder) Get(ctx, filter, top, skip any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockInventoryFeature)(nil).Get), ctx, filter, top, skip)
+}
+
+// GetBIOSVersions mocks base method.
+func (m *MockInventoryFeature) GetBIOSVersions(ctx context.Context, tenantID string) ([]dto.BIOSVersionCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBIOSVersions", ctx, tenantID)
+	ret0, _ := ret[0].([]dto.BIOSVersionCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBIOSVersions indicates an expected call of GetBIOSVersions.
+func (mr *MockInventoryFeatureMockRecorder) GetBIOSVersions(ctx, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBIOSVersions", reflect.TypeOf((*MockInventoryFeature)(nil).GetBIOSVersions), ctx, tenantID)
+}
+
+// GetByGUID mocks base method.
+func (m *MockInventoryFeature) GetByGUID(ctx context.Context, guid, tenantID string) (*dto.DeviceInventory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByGUID", ctx, guid, tenantID)
+	ret0, _ := ret[0].(*dto.DeviceInventory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByGUID indicates an expected call of GetByGUID.
+func (mr *MockInventoryFeatureMockRecorder) GetByGUID(ctx, guid, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByGUID", reflect.TypeOf((*MockInventoryFeature)(nil).GetByGUID), ctx, guid, tenantID)
+}
+
+// GetCount mocks base method.
+func (m *MockInventoryFeature) GetCount(ctx context.Context, filter dto.InventoryFilter) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCount", ctx, filter)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCount indicates an expected call of GetCount.
+func (mr *MockInventoryFeatureMockRecorder) GetCount(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCount", reflect.TypeOf((*MockInventoryFeature)(nil).GetCount), ctx, filter)
+}
+
+// Refresh mocks base method.
+func (m *MockInventoryFeature) Refresh(ctx context.Context, guid, tenantID string) (*dto.DeviceInventory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Refresh", ctx, guid, tenantID)
+	ret0, _ := ret[0].(*dto.DeviceInventory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Refresh indicates an expected call of Refresh.
+func (mr *MockInventoryFeatureMockRecorder) Refresh(ctx, guid, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refresh", reflect.TypeOf((*MockInventoryFeature)(nil).Refresh), ctx, guid, tenantID)
+}
+
+// Run mocks base method.
+func (m *MockInventoryFeature) Run(ctx context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Run", ctx)
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockInventoryFeatureMockRecorder) Run(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockInventoryFeature)(nil).Run), ctx)
+}
+
+// MockInventoryDevices is a mock of Devices interface.
+type MockInventoryDevices struct {
+	ctrl     *gomock.Controller
+	recorder *MockInventoryDevicesMockRecorder
+	isgomock struct{}
+}
+
+// MockInventoryDevicesMockRecorder is the mock recorder for MockInventoryDevices.
+type MockInventoryDevicesMockRecorder struct {
+	mock *MockInventoryDevices
+}
+
+// NewMockInventoryDevices creates a new mock instance.
+func NewMockInventoryDevices(ctrl *gomock.Controller) *MockInventoryDevices {
+	mock := &MockInventoryDevices{ctrl: ctrl}
+	mock.recorder = &MockInventoryDevicesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInventoryDevices) EXPECT() *MockInventoryDevicesMockRecorder {
+	return m.recorder
+}
+
+// GetInventory mocks base method.
+func (m *MockInventoryDevices) GetInventory(ctx context.Context, guid string) (dto.DeviceInventory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInventory", ctx, guid)
+	ret0, _ := ret[0].(dto.DeviceInventory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInventory indicates an expected call of GetInventory.
+func (mr *MockInventoryDevicesMockRecorder) GetInventory(ctx, guid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInventory", reflect.TypeOf((*MockInventoryDevices)(nil).GetInventory), ctx, guid)
+}
+
+// MockInventoryFleet is a mock of Fleet interface.
+type MockInventoryFleet struct {
+	ctrl     *gomock.Controller
+	recorder *MockInventoryFleetMockRecorder
+	isgomock struct{}
+}
+
+// MockInventoryFleetMockRecorder is the mock recorder for MockInventoryFleet.
+type MockInventoryFleetMockRecorder struct {
+	mock *MockInventoryFleet
+}
+
+// NewMockInventoryFleet creates a new mock instance.
+func NewMockInventoryFleet(ctrl *gomock.Controller) *MockInventoryFleet {
+	mock := &MockInventoryFleet{ctrl: ctrl}
+	mock.recorder = &MockInventoryFleetMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInventoryFleet) EXPECT() *MockInventoryFleetMockRecorder {
+	return m.recorder
+}
+
+// GetAllTenants mocks base method.
+func (m *MockInventoryFleet) GetAllTenants(ctx context.Context, top, skip int) ([]entity.Device, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllTenants", ctx, top, skip)
+	ret0, _ := ret[0].([]entity.Device)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllTenants indicates an expected call of GetAllTenants.
+func (mr *MockInventoryFleetMockRecorder) GetAllTenants(ctx, top, skip any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllTenants", reflect.TypeOf((*MockInventoryFleet)(nil).GetAllTenants), ctx, top, skip)
+}
@@ -0,0 +1,327 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/usecase/recordings/interfaces.go
+//
+// Generated by this command:
+//
+//	mockgen -source ./internal/usecase/recordings/interfaces.go -package mocks -mock_names Repository=MockRecordingsRepository,Feature=MockRecordingsFeature,Session=MockRecordingSession
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	io "io"
+	reflect "reflect"
+
+	entity "github.com/open-amt-cloud-toolkit/console/internal/entity"
+	dto "github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	recordings "github.com/open-amt-cloud-toolkit/console/internal/usecase/recordings"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRecordingsRepository is a mock of Repository interface.
+type MockRecordingsRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRecordingsRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockRecordingsRepositoryMockRecorder is the mock recorder for MockRecordingsRepository.
+type MockRecordingsRepositoryMockRecorder struct {
+	mock *MockRecordingsRepository
+}
+
+// NewMockRecordingsRepository creates a new mock instance.
+func NewMockRecordingsRepository(ctrl *gomock.Controller) *MockRecordingsRepository {
+	mock := &MockRecordingsRepository{ctrl: ctrl}
+	mock.recorder = &MockRecordingsRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRecordingsRepository) EXPECT() *MockRecordingsRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockRecordingsRepository) Delete(ctx context.Context, id, tenantID string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id, tenantID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockRecordingsRepositoryMockRecorder) Delete(ctx, id, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRecordingsRepository)(nil).Delete), ctx, id, tenantID)
+}
+
+// Get mocks base method.
+func (m *MockRecordingsRepository) Get(ctx context.Context, top, skip int, tenantID string) ([]entity.SessionRecording, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, top, skip, tenantID)
+	ret0, _ := ret[0].([]entity.SessionRecording)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockRecordingsRepositoryMockRecorder) Get(ctx, top, skip, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockRecordingsRepository)(nil).Get), ctx, top, skip, tenantID)
+}
+
+// GetByID mocks base method.
+func (m *MockRecordingsRepository) GetByID(ctx context.Context, id, tenantID string) (*entity.SessionRecording, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id, tenantID)
+	ret0, _ := ret[0].(*entity.SessionRecording)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockRecordingsRepositoryMockRecorder) GetByID(ctx, id, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockRecordingsRepository)(nil).GetByID), ctx, id, tenantID)
+}
+
+// GetCount mocks base method.
+func (m *MockRecordingsRepository) GetCount(ctx context.Context, tenantID string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCount", ctx, tenantID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCount indicates an expected call of GetCount.
+func (mr *MockRecordingsRepositoryMockRecorder) GetCount(ctx, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCount", reflect.TypeOf((*MockRecordingsRepository)(nil).GetCount), ctx, tenantID)
+}
+
+// Insert mocks base method.
+func (m *MockRecordingsRepository) Insert(ctx context.Context, s *entity.SessionRecording) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", ctx, s)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockRecordingsRepositoryMockRecorder) Insert(ctx, s any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockRecordingsRepository)(nil).Insert), ctx, s)
+}
+
+// Update mocks base method.
+func (m *MockRecordingsRepository) Update(ctx context.Context, s *entity.SessionRecording) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, s)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockRecordingsRepositoryMockRecorder) Update(ctx, s any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockRecordingsRepository)(nil).Update), ctx, s)
+}
+
+// MockRecordingsFeature is a mock of Feature interface.
+type MockRecordingsFeature struct {
+	ctrl     *gomock.Controller
+	recorder *MockRecordingsFeatureMockRecorder
+	isgomock struct{}
+}
+
+// MockRecordingsFeatureMockRecorder is the mock recorder for MockRecordingsFeature.
+type MockRecordingsFeatureMockRecorder struct {
+	mock *MockRecordingsFeature
+}
+
+// NewMockRecordingsFeature creates a new mock instance.
+func NewMockRecordingsFeature(ctrl *gomock.Controller) *MockRecordingsFeature {
+	mock := &MockRecordingsFeature{ctrl: ctrl}
+	mock.recorder = &MockRecordingsFeatureMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRecordingsFeature) EXPECT() *MockRecordingsFeatureMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockRecordingsFeature) Delete(ctx context.Context, id, tenantID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id, tenantID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockRecordingsFeatureMockRecorder) Delete(ctx, id, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRecordingsFeature)(nil).Delete), ctx, id, tenantID)
+}
+
+// Download mocks base method.
+func (m *MockRecordingsFeature) Download(ctx context.Context, id, tenantID string) (io.ReadCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Download", ctx, id, tenantID)
+	ret0, _ := ret[0].(io.ReadCloser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Download indicates an expected call of Download.
+func (mr *MockRecordingsFeatureMockRecorder) Download(ctx, id, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Download", reflect.TypeOf((*MockRecordingsFeature)(nil).Download), ctx, id, tenantID)
+}
+
+// Get mocks base method.
+func (m *MockRecordingsFeature) Get(ctx context.Context, top, skip int, tenantID string) ([]dto.SessionRecording, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, top, skip, tenantID)
+	ret0, _ := ret[0].([]dto.SessionRecording)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockRecordingsFeatureMockRecorder) Get(ctx, top, skip, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockRecordingsFeature)(nil).Get), ctx, top, skip, tenantID)
+}
+
+// GetByID mocks base method.
+func (m *MockRecordingsFeature) GetByID(ctx context.Context, id, tenantID string) (*dto.SessionRecording, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id, tenantID)
+	ret0, _ := ret[0].(*dto.SessionRecording)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockRecordingsFeatureMockRecorder) GetByID(ctx, id, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockRecordingsFeature)(nil).GetByID), ctx, id, tenantID)
+}
+
+// GetCount mocks base method.
+func (m *MockRecordingsFeature) GetCount(ctx context.Context, tenantID string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCount", ctx, tenantID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCount indicates an expected call of GetCount.
+func (mr *MockRecordingsFeatureMockRecorder) GetCount(ctx, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCount", reflect.TypeOf((*MockRecordingsFeature)(nil).GetCount), ctx, tenantID)
+}
+
+// GetFrames mocks base method.
+func (m *MockRecordingsFeature) GetFrames(ctx context.Context, id, tenantID string, top, skip int) ([]dto.RecordingFrame, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFrames", ctx, id, tenantID, top, skip)
+	ret0, _ := ret[0].([]dto.RecordingFrame)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFrames indicates an expected call of GetFrames.
+func (mr *MockRecordingsFeatureMockRecorder) GetFrames(ctx, id, tenantID, top, skip any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFrames", reflect.TypeOf((*MockRecordingsFeature)(nil).GetFrames), ctx, id, tenantID, top, skip)
+}
+
+// Record mocks base method.
+func (m *MockRecordingsFeature) Record(ctx context.Context, device entity.Device, mode string) (recordings.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Record", ctx, device, mode)
+	ret0, _ := ret[0].(recordings.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Record indicates an expected call of Record.
+func (mr *MockRecordingsFeatureMockRecorder) Record(ctx, device, mode any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Record", reflect.TypeOf((*MockRecordingsFeature)(nil).Record), ctx, device, mode)
+}
+
+// Transcript mocks base method.
+func (m *MockRecordingsFeature) Transcript(ctx context.Context, id, tenantID string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Transcript", ctx, id, tenantID)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Transcript indicates an expected call of Transcript.
+func (mr *MockRecordingsFeatureMockRecorder) Transcript(ctx, id, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Transcript", reflect.TypeOf((*MockRecordingsFeature)(nil).Transcript), ctx, id, tenantID)
+}
+
+// MockRecordingSession is a mock of Session interface.
+type MockRecordingSession struct {
+	ctrl     *gomock.Controller
+	recorder *MockRecordingSessionMockRecorder
+	isgomock struct{}
+}
+
+// MockRecordingSessionMockRecorder is the mock recorder for MockRecordingSession.
+type MockRecordingSessionMockRecorder struct {
+	mock *MockRecordingSession
+}
+
+// NewMockRecordingSession creates a new mock instance.
+func NewMockRecordingSession(ctrl *gomock.Controller) *MockRecordingSession {
+	mock := &MockRecordingSession{ctrl: ctrl}
+	mock.recorder = &MockRecordingSessionMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRecordingSession) EXPECT() *MockRecordingSessionMockRecorder {
+	return m.recorder
+}
+
+// Close mocks base method.
+func (m *MockRecordingSession) Close() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockRecordingSessionMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockRecordingSession)(nil).Close))
+}
+
+// Write mocks base method.
+func (m *MockRecordingSession) Write(direction recordings.Direction, data []byte) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Write", direction, data)
+}
+
+// Write indicates an expected call of Write.
+func (mr *MockRecordingSessionMockRecorder) Write(direction, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Write", reflect.TypeOf((*MockRecordingSession)(nil).Write), direction, data)
+}
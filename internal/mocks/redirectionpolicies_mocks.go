@@ -0,0 +1,155 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/usecase/redirectionpolicies/interfaces.go
+//
+// Generated by this command:
+//
+//	mockgen -source ./internal/usecase/redirectionpolicies/interfaces.go -package mocks -mock_names Repository=MockRedirectionPoliciesRepository,Feature=MockRedirectionPoliciesFeature
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/open-amt-cloud-toolkit/console/internal/entity"
+	dto "github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRedirectionPoliciesRepository is a mock of Repository interface.
+type MockRedirectionPoliciesRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRedirectionPoliciesRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockRedirectionPoliciesRepositoryMockRecorder is the mock recorder for MockRedirectionPoliciesRepository.
+type MockRedirectionPoliciesRepositoryMockRecorder struct {
+	mock *MockRedirectionPoliciesRepository
+}
+
+// NewMockRedirectionPoliciesRepository creates a new mock instance.
+func NewMockRedirectionPoliciesRepository(ctrl *gomock.Controller) *MockRedirectionPoliciesRepository {
+	mock := &MockRedirectionPoliciesRepository{ctrl: ctrl}
+	mock.recorder = &MockRedirectionPoliciesRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRedirectionPoliciesRepository) EXPECT() *MockRedirectionPoliciesRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockRedirectionPoliciesRepository) Delete(ctx context.Context, tenantID string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, tenantID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockRedirectionPoliciesRepositoryMockRecorder) Delete(ctx, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRedirectionPoliciesRepository)(nil).Delete), ctx, tenantID)
+}
+
+// Get mocks base method.
+func (m *MockRedirectionPoliciesRepository) Get(ctx context.Context, tenantID string) (*entity.RedirectionPolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, tenantID)
+	ret0, _ := ret[0].(*entity.RedirectionPolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockRedirectionPoliciesRepositoryMockRecorder) Get(ctx, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockRedirectionPoliciesRepository)(nil).Get), ctx, tenantID)
+}
+
+// Upsert mocks base method.
+func (m *MockRedirectionPoliciesRepository) Upsert(ctx context.Context, p *entity.RedirectionPolicy) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upsert", ctx, p)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Upsert indicates an expected call of Upsert.
+func (mr *MockRedirectionPoliciesRepositoryMockRecorder) Upsert(ctx, p any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upsert", reflect.TypeOf((*MockRedirectionPoliciesRepository)(nil).Upsert), ctx, p)
+}
+
+// MockRedirectionPoliciesFeature is a mock of Feature interface.
+type MockRedirectionPoliciesFeature struct {
+	ctrl     *gomock.Controller
+	recorder *MockRedirectionPoliciesFeatureMockRecorder
+	isgomock struct{}
+}
+
+// MockRedirectionPoliciesFeatureMockRecorder is the mock recorder for MockRedirectionPoliciesFeature.
+type MockRedirectionPoliciesFeatureMockRecorder struct {
+	mock *MockRedirectionPoliciesFeature
+}
+
+// NewMockRedirectionPoliciesFeature creates a new mock instance.
+func NewMockRedirectionPoliciesFeature(ctrl *gomock.Controller) *MockRedirectionPoliciesFeature {
+	mock := &MockRedirectionPoliciesFeature{ctrl: ctrl}
+	mock.recorder = &MockRedirectionPoliciesFeatureMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRedirectionPoliciesFeature) EXPECT() *MockRedirectionPoliciesFeatureMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockRedirectionPoliciesFeature) Delete(ctx context.Context, tenantID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, tenantID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockRedirectionPoliciesFeatureMockRecorder) Delete(ctx, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRedirectionPoliciesFeature)(nil).Delete), ctx, tenantID)
+}
+
+// Get mocks base method.
+func (m *MockRedirectionPoliciesFeature) Get(ctx context.Context, tenantID string) (dto.RedirectionPolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, tenantID)
+	ret0, _ := ret[0].(dto.RedirectionPolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockRedirectionPoliciesFeatureMockRecorder) Get(ctx, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockRedirectionPoliciesFeature)(nil).Get), ctx, tenantID)
+}
+
+// Update mocks base method.
+func (m *MockRedirectionPoliciesFeature) Update(ctx context.Context, d *dto.RedirectionPolicy) (*dto.RedirectionPolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, d)
+	ret0, _ := ret[0].(*dto.RedirectionPolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockRedirectionPoliciesFeatureMockRecorder) Update(ctx, d any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockRedirectionPoliciesFeature)(nil).Update), ctx, d)
+}
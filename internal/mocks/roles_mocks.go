@@ -0,0 +1,215 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/usecase/roles/interfaces.go
+//
+// Generated by this command:
+//
+//	mockgen -source ./internal/usecase/roles/interfaces.go -package mocks -mock_names Repository=MockRolesRepository,Feature=MockRolesFeature
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/open-amt-cloud-toolkit/console/internal/entity"
+	dto "github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRolesRepository is a mock of Repository interface.
+type MockRolesRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRolesRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockRolesRepositoryMockRecorder is the mock recorder for MockRolesRepository.
+type MockRolesRepositoryMockRecorder struct {
+	mock *MockRolesRepository
+}
+
+// NewMockRolesRepository creates a new mock instance.
+func NewMockRolesRepository(ctrl *gomock.Controller) *MockRolesRepository {
+	mock := &MockRolesRepository{ctrl: ctrl}
+	mock.recorder = &MockRolesRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRolesRepository) EXPECT() *MockRolesRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockRolesRepository) Delete(ctx context.Context, subject, role, tenantID string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, subject, role, tenantID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockRolesRepositoryMockRecorder) Delete(ctx, subject, role, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRolesRepository)(nil).Delete), ctx, subject, role, tenantID)
+}
+
+// Get mocks base method.
+func (m *MockRolesRepository) Get(ctx context.Context, top, skip int, tenantID string) ([]entity.RoleBinding, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, top, skip, tenantID)
+	ret0, _ := ret[0].([]entity.RoleBinding)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockRolesRepositoryMockRecorder) Get(ctx, top, skip, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockRolesRepository)(nil).Get), ctx, top, skip, tenantID)
+}
+
+// GetBySubjects mocks base method.
+func (m *MockRolesRepository) GetBySubjects(ctx context.Context, subjects []string, tenantID string) ([]entity.RoleBinding, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBySubjects", ctx, subjects, tenantID)
+	ret0, _ := ret[0].([]entity.RoleBinding)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBySubjects indicates an expected call of GetBySubjects.
+func (mr *MockRolesRepositoryMockRecorder) GetBySubjects(ctx, subjects, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBySubjects", reflect.TypeOf((*MockRolesRepository)(nil).GetBySubjects), ctx, subjects, tenantID)
+}
+
+// GetCount mocks base method.
+func (m *MockRolesRepository) GetCount(ctx context.Context, tenantID string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCount", ctx, tenantID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCount indicates an expected call of GetCount.
+func (mr *MockRolesRepositoryMockRecorder) GetCount(ctx, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCount", reflect.TypeOf((*MockRolesRepository)(nil).GetCount), ctx, tenantID)
+}
+
+// Insert mocks base method.
+func (m *MockRolesRepository) Insert(ctx context.Context, b *entity.RoleBinding) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", ctx, b)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockRolesRepositoryMockRecorder) Insert(ctx, b any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockRolesRepository)(nil).Insert), ctx, b)
+}
+
+// MockRolesFeature is a mock of Feature interface.
+type MockRolesFeature struct {
+	ctrl     *gomock.Controller
+	recorder *MockRolesFeatureMockRecorder
+	isgomock struct{}
+}
+
+// MockRolesFeatureMockRecorder is the mock recorder for MockRolesFeature.
+type MockRolesFeatureMockRecorder struct {
+	mock *MockRolesFeature
+}
+
+// NewMockRolesFeature creates a new mock instance.
+func NewMockRolesFeature(ctrl *gomock.Controller) *MockRolesFeature {
+	mock := &MockRolesFeature{ctrl: ctrl}
+	mock.recorder = &MockRolesFeatureMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRolesFeature) EXPECT() *MockRolesFeatureMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockRolesFeature) Delete(ctx context.Context, subject, role, tenantID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, subject, role, tenantID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockRolesFeatureMockRecorder) Delete(ctx, subject, role, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRolesFeature)(nil).Delete), ctx, subject, role, tenantID)
+}
+
+// Get mocks base method.
+func (m *MockRolesFeature) Get(ctx context.Context, top, skip int, tenantID string) ([]dto.RoleBinding, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, top, skip, tenantID)
+	ret0, _ := ret[0].([]dto.RoleBinding)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockRolesFeatureMockRecorder) Get(ctx, top, skip, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockRolesFeature)(nil).Get), ctx, top, skip, tenantID)
+}
+
+// GetCount mocks base method.
+func (m *MockRolesFeature) GetCount(ctx context.Context, tenantID string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCount", ctx, tenantID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCount indicates an expected call of GetCount.
+func (mr *MockRolesFeatureMockRecorder) GetCount(ctx, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCount", reflect.TypeOf((*MockRolesFeature)(nil).GetCount), ctx, tenantID)
+}
+
+// Insert mocks base method.
+func (m *MockRolesFeature) Insert(ctx context.Context, b *dto.RoleBinding) (*dto.RoleBinding, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", ctx, b)
+	ret0, _ := ret[0].(*dto.RoleBinding)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockRolesFeatureMockRecorder) Insert(ctx, b any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockRolesFeature)(nil).Insert), ctx, b)
+}
+
+// Resolve mocks base method.
+func (m *MockRolesFeature) Resolve(ctx context.Context, subjects, groups []string, tenantID string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Resolve", ctx, subjects, groups, tenantID)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Resolve indicates an expected call of Resolve.
+func (mr *MockRolesFeatureMockRecorder) Resolve(ctx, subjects, groups, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Resolve", reflect.TypeOf((*MockRolesFeature)(nil).Resolve), ctx, subjects, groups, tenantID)
+}
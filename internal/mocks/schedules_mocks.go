@@ -0,0 +1,449 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/usecase/schedules/interfaces.go
+//
+// Generated by this command:
+//
+//	mockgen -source ./internal/usecase/schedules/interfaces.go -package mocks -mock_names Repository=MockSchedulesRepository,Feature=MockSchedulesFeature,Devices=MockSchedulesDevices
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/open-amt-cloud-toolkit/console/internal/entity"
+	dto "github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	v2 "github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v2"
+	power "github.com/open-amt-cloud-toolkit/go-wsman-messages/v2/pkg/wsman/cim/power"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockSchedulesRepository is a mock of Repository interface.
+type MockSchedulesRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockSchedulesRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockSchedulesRepositoryMockRecorder is the mock recorder for MockSchedulesRepository.
+type MockSchedulesRepositoryMockRecorder struct {
+	mock *MockSchedulesRepository
+}
+
+// NewMockSchedulesRepository creates a new mock instance.
+func NewMockSchedulesRepository(ctrl *gomock.Controller) *MockSchedulesRepository {
+	mock := &MockSchedulesRepository{ctrl: ctrl}
+	mock.recorder = &MockSchedulesRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSchedulesRepository) EXPECT() *MockSchedulesRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockSchedulesRepository) Delete(ctx context.Context, id, tenantID string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id, tenantID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockSchedulesRepositoryMockRecorder) Delete(ctx, id, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockSchedulesRepository)(nil).Delete), ctx, id, tenantID)
+}
+
+// Get mocks base method.
+func (m *MockSchedulesRepository) Get(ctx context.Context, top, skip int, tenantID string) ([]entity.ScheduledJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, top, skip, tenantID)
+	ret0, _ := ret[0].([]entity.ScheduledJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockSchedulesRepositoryMockRecorder) Get(ctx, top, skip, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockSchedulesRepository)(nil).Get), ctx, top, skip, tenantID)
+}
+
+// GetByID mocks base method.
+func (m *MockSchedulesRepository) GetByID(ctx context.Context, id, tenantID string) (*entity.ScheduledJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id, tenantID)
+	ret0, _ := ret[0].(*entity.ScheduledJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockSchedulesRepositoryMockRecorder) GetByID(ctx, id, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockSchedulesRepository)(nil).GetByID), ctx, id, tenantID)
+}
+
+// GetCount mocks base method.
+func (m *MockSchedulesRepository) GetCount(ctx context.Context, tenantID string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCount", ctx, tenantID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCount indicates an expected call of GetCount.
+func (mr *MockSchedulesRepositoryMockRecorder) GetCount(ctx, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCount", reflect.TypeOf((*MockSchedulesRepository)(nil).GetCount), ctx, tenantID)
+}
+
+// GetDue mocks base method.
+func (m *MockSchedulesRepository) GetDue(ctx context.Context, now string) ([]entity.ScheduledJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDue", ctx, now)
+	ret0, _ := ret[0].([]entity.ScheduledJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDue indicates an expected call of GetDue.
+func (mr *MockSchedulesRepositoryMockRecorder) GetDue(ctx, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDue", reflect.TypeOf((*MockSchedulesRepository)(nil).GetDue), ctx, now)
+}
+
+// GetDueRetries mocks base method.
+func (m *MockSchedulesRepository) GetDueRetries(ctx context.Context, now string) ([]entity.JobRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDueRetries", ctx, now)
+	ret0, _ := ret[0].([]entity.JobRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDueRetries indicates an expected call of GetDueRetries.
+func (mr *MockSchedulesRepositoryMockRecorder) GetDueRetries(ctx, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDueRetries", reflect.TypeOf((*MockSchedulesRepository)(nil).GetDueRetries), ctx, now)
+}
+
+// GetRuns mocks base method.
+func (m *MockSchedulesRepository) GetRuns(ctx context.Context, jobID string, top, skip int, tenantID string) ([]entity.JobRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRuns", ctx, jobID, top, skip, tenantID)
+	ret0, _ := ret[0].([]entity.JobRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRuns indicates an expected call of GetRuns.
+func (mr *MockSchedulesRepositoryMockRecorder) GetRuns(ctx, jobID, top, skip, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRuns", reflect.TypeOf((*MockSchedulesRepository)(nil).GetRuns), ctx, jobID, top, skip, tenantID)
+}
+
+// Insert mocks base method.
+func (m *MockSchedulesRepository) Insert(ctx context.Context, j *entity.ScheduledJob) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", ctx, j)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockSchedulesRepositoryMockRecorder) Insert(ctx, j any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockSchedulesRepository)(nil).Insert), ctx, j)
+}
+
+// InsertRun mocks base method.
+func (m *MockSchedulesRepository) InsertRun(ctx context.Context, run *entity.JobRun) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertRun", ctx, run)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertRun indicates an expected call of InsertRun.
+func (mr *MockSchedulesRepositoryMockRecorder) InsertRun(ctx, run any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertRun", reflect.TypeOf((*MockSchedulesRepository)(nil).InsertRun), ctx, run)
+}
+
+// Update mocks base method.
+func (m *MockSchedulesRepository) Update(ctx context.Context, j *entity.ScheduledJob) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, j)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockSchedulesRepositoryMockRecorder) Update(ctx, j any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockSchedulesRepository)(nil).Update), ctx, j)
+}
+
+// UpdateRunStatus mocks base method.
+func (m *MockSchedulesRepository) UpdateRunStatus(ctx context.Context, id, tenantID, from, to string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRunStatus", ctx, id, tenantID, from, to)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateRunStatus indicates an expected call of UpdateRunStatus.
+func (mr *MockSchedulesRepositoryMockRecorder) UpdateRunStatus(ctx, id, tenantID, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRunStatus", reflect.TypeOf((*MockSchedulesRepository)(nil).UpdateRunStatus), ctx, id, tenantID, from, to)
+}
+
+// UpdateRunTimes mocks base method.
+func (m *MockSchedulesRepository) UpdateRunTimes(ctx context.Context, id, tenantID, dueRun, lastRun, nextRun string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRunTimes", ctx, id, tenantID, dueRun, lastRun, nextRun)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateRunTimes indicates an expected call of UpdateRunTimes.
+func (mr *MockSchedulesRepositoryMockRecorder) UpdateRunTimes(ctx, id, tenantID, dueRun, lastRun, nextRun any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRunTimes", reflect.TypeOf((*MockSchedulesRepository)(nil).UpdateRunTimes), ctx, id, tenantID, dueRun, lastRun, nextRun)
+}
+
+// MockSchedulesFeature is a mock of Feature interface.
+type MockSchedulesFeature struct {
+	ctrl     *gomock.Controller
+	recorder *MockSchedulesFeatureMockRecorder
+	isgomock struct{}
+}
+
+// MockSchedulesFeatureMockRecorder is the mock recorder for MockSchedulesFeature.
+type MockSchedulesFeatureMockRecorder struct {
+	mock *MockSchedulesFeature
+}
+
+// NewMockSchedulesFeature creates a new mock instance.
+func NewMockSchedulesFeature(ctrl *gomock.Controller) *MockSchedulesFeature {
+	mock := &MockSchedulesFeature{ctrl: ctrl}
+	mock.recorder = &MockSchedulesFeatureMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSchedulesFeature) EXPECT() *MockSchedulesFeatureMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockSchedulesFeature) Delete(ctx context.Context, id, tenantID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id, tenantID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockSchedulesFeatureMockRecorder) Delete(ctx, id, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockSchedulesFeature)(nil).Delete), ctx, id, tenantID)
+}
+
+// Get mocks base method.
+func (m *MockSchedulesFeature) Get(ctx context.Context, top, skip int, tenantID string) ([]dto.ScheduledJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, top, skip, tenantID)
+	ret0, _ := ret[0].([]dto.ScheduledJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockSchedulesFeatureMockRecorder) Get(ctx, top, skip, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockSchedulesFeature)(nil).Get), ctx, top, skip, tenantID)
+}
+
+// GetByID mocks base method.
+func (m *MockSchedulesFeature) GetByID(ctx context.Context, id, tenantID string) (*dto.ScheduledJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id, tenantID)
+	ret0, _ := ret[0].(*dto.ScheduledJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockSchedulesFeatureMockRecorder) GetByID(ctx, id, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockSchedulesFeature)(nil).GetByID), ctx, id, tenantID)
+}
+
+// GetCount mocks base method.
+func (m *MockSchedulesFeature) GetCount(ctx context.Context, tenantID string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCount", ctx, tenantID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCount indicates an expected call of GetCount.
+func (mr *MockSchedulesFeatureMockRecorder) GetCount(ctx, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCount", reflect.TypeOf((*MockSchedulesFeature)(nil).GetCount), ctx, tenantID)
+}
+
+// GetRuns mocks base method.
+func (m *MockSchedulesFeature) GetRuns(ctx context.Context, jobID string, top, skip int, tenantID string) ([]dto.JobRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRuns", ctx, jobID, top, skip, tenantID)
+	ret0, _ := ret[0].([]dto.JobRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRuns indicates an expected call of GetRuns.
+func (mr *MockSchedulesFeatureMockRecorder) GetRuns(ctx, jobID, top, skip, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRuns", reflect.TypeOf((*MockSchedulesFeature)(nil).GetRuns), ctx, jobID, top, skip, tenantID)
+}
+
+// Insert mocks base method.
+func (m *MockSchedulesFeature) Insert(ctx context.Context, j *dto.ScheduledJob) (*dto.ScheduledJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", ctx, j)
+	ret0, _ := ret[0].(*dto.ScheduledJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockSchedulesFeatureMockRecorder) Insert(ctx, j any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockSchedulesFeature)(nil).Insert), ctx, j)
+}
+
+// Run mocks base method.
+func (m *MockSchedulesFeature) Run(ctx context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Run", ctx)
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockSchedulesFeatureMockRecorder) Run(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockSchedulesFeature)(nil).Run), ctx)
+}
+
+// Update mocks base method.
+func (m *MockSchedulesFeature) Update(ctx context.Context, j *dto.ScheduledJob) (*dto.ScheduledJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, j)
+	ret0, _ := ret[0].(*dto.ScheduledJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockSchedulesFeatureMockRecorder) Update(ctx, j any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockSchedulesFeature)(nil).Update), ctx, j)
+}
+
+// MockSchedulesDevices is a mock of Devices interface.
+type MockSchedulesDevices struct {
+	ctrl     *gomock.Controller
+	recorder *MockSchedulesDevicesMockRecorder
+	isgomock struct{}
+}
+
+// MockSchedulesDevicesMockRecorder is the mock recorder for MockSchedulesDevices.
+type MockSchedulesDevicesMockRecorder struct {
+	mock *MockSchedulesDevices
+}
+
+// NewMockSchedulesDevices creates a new mock instance.
+func NewMockSchedulesDevices(ctrl *gomock.Controller) *MockSchedulesDevices {
+	mock := &MockSchedulesDevices{ctrl: ctrl}
+	mock.recorder = &MockSchedulesDevicesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSchedulesDevices) EXPECT() *MockSchedulesDevicesMockRecorder {
+	return m.recorder
+}
+
+// GetByTags mocks base method.
+func (m *MockSchedulesDevices) GetByTags(ctx context.Context, tags, method string, limit, offset int, tenantID string) ([]dto.Device, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByTags", ctx, tags, method, limit, offset, tenantID)
+	ret0, _ := ret[0].([]dto.Device)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByTags indicates an expected call of GetByTags.
+func (mr *MockSchedulesDevicesMockRecorder) GetByTags(ctx, tags, method, limit, offset, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByTags", reflect.TypeOf((*MockSchedulesDevices)(nil).GetByTags), ctx, tags, method, limit, offset, tenantID)
+}
+
+// SendPowerAction mocks base method.
+func (m *MockSchedulesDevices) SendPowerAction(ctx context.Context, guid string, action int) (power.PowerActionResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendPowerAction", ctx, guid, action)
+	ret0, _ := ret[0].(power.PowerActionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SendPowerAction indicates an expected call of SendPowerAction.
+func (mr *MockSchedulesDevicesMockRecorder) SendPowerAction(ctx, guid, action any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendPowerAction", reflect.TypeOf((*MockSchedulesDevices)(nil).SendPowerAction), ctx, guid, action)
+}
+
+// SetBootOptions mocks base method.
+func (m *MockSchedulesDevices) SetBootOptions(ctx context.Context, guid string, bootSetting dto.BootSetting) (power.PowerActionResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetBootOptions", ctx, guid, bootSetting)
+	ret0, _ := ret[0].(power.PowerActionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetBootOptions indicates an expected call of SetBootOptions.
+func (mr *MockSchedulesDevicesMockRecorder) SetBootOptions(ctx, guid, bootSetting any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBootOptions", reflect.TypeOf((*MockSchedulesDevices)(nil).SetBootOptions), ctx, guid, bootSetting)
+}
+
+// SetFeatures mocks base method.
+func (m *MockSchedulesDevices) SetFeatures(ctx context.Context, guid string, features dto.Features) (dto.Features, v2.Features, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetFeatures", ctx, guid, features)
+	ret0, _ := ret[0].(dto.Features)
+	ret1, _ := ret[1].(v2.Features)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// SetFeatures indicates an expected call of SetFeatures.
+func (mr *MockSchedulesDevicesMockRecorder) SetFeatures(ctx, guid, features any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetFeatures", reflect.TypeOf((*MockSchedulesDevices)(nil).SetFeatures), ctx, guid, features)
+}
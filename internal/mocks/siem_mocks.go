@@ -0,0 +1,315 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/usecase/siem/interfaces.go
+//
+// Generated by this command:
+//
+//	mockgen -source ./internal/usecase/siem/interfaces.go -package mocks -mock_names Repository=MockSIEMRepository,Feature=MockSIEMFeature,AuditLogs=MockSIEMAuditLogs,EventLogs=MockSIEMEventLogs,Fleet=MockSIEMFleet
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/open-amt-cloud-toolkit/console/internal/entity"
+	dto "github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockSIEMRepository is a mock of Repository interface.
+type MockSIEMRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockSIEMRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockSIEMRepositoryMockRecorder is the mock recorder for MockSIEMRepository.
+type MockSIEMRepositoryMockRecorder struct {
+	mock *MockSIEMRepository
+}
+
+// NewMockSIEMRepository creates a new mock instance.
+func NewMockSIEMRepository(ctrl *gomock.Controller) *MockSIEMRepository {
+	mock := &MockSIEMRepository{ctrl: ctrl}
+	mock.recorder = &MockSIEMRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSIEMRepository) EXPECT() *MockSIEMRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetCursor mocks base method.
+func (m *MockSIEMRepository) GetCursor(ctx context.Context, source, guid, tenantID string) (*entity.SIEMCursor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCursor", ctx, source, guid, tenantID)
+	ret0, _ := ret[0].(*entity.SIEMCursor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCursor indicates an expected call of GetCursor.
+func (mr *MockSIEMRepositoryMockRecorder) GetCursor(ctx, source, guid, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCursor", reflect.TypeOf((*MockSIEMRepository)(nil).GetCursor), ctx, source, guid, tenantID)
+}
+
+// GetCursorCount mocks base method.
+func (m *MockSIEMRepository) GetCursorCount(ctx context.Context, tenantID string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCursorCount", ctx, tenantID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCursorCount indicates an expected call of GetCursorCount.
+func (mr *MockSIEMRepositoryMockRecorder) GetCursorCount(ctx, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCursorCount", reflect.TypeOf((*MockSIEMRepository)(nil).GetCursorCount), ctx, tenantID)
+}
+
+// GetCursors mocks base method.
+func (m *MockSIEMRepository) GetCursors(ctx context.Context, top, skip int, tenantID string) ([]entity.SIEMCursor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCursors", ctx, top, skip, tenantID)
+	ret0, _ := ret[0].([]entity.SIEMCursor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCursors indicates an expected call of GetCursors.
+func (mr *MockSIEMRepositoryMockRecorder) GetCursors(ctx, top, skip, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCursors", reflect.TypeOf((*MockSIEMRepository)(nil).GetCursors), ctx, top, skip, tenantID)
+}
+
+// UpsertCursor mocks base method.
+func (m *MockSIEMRepository) UpsertCursor(ctx context.Context, c *entity.SIEMCursor) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertCursor", ctx, c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertCursor indicates an expected call of UpsertCursor.
+func (mr *MockSIEMRepositoryMockRecorder) UpsertCursor(ctx, c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertCursor", reflect.TypeOf((*MockSIEMRepository)(nil).UpsertCursor), ctx, c)
+}
+
+// MockSIEMFeature is a mock of Feature interface.
+type MockSIEMFeature struct {
+	ctrl     *gomock.Controller
+	recorder *MockSIEMFeatureMockRecorder
+	isgomock struct{}
+}
+
+// MockSIEMFeatureMockRecorder is the mock recorder for MockSIEMFeature.
+type MockSIEMFeatureMockRecorder struct {
+	mock *MockSIEMFeature
+}
+
+// NewMockSIEMFeature creates a new mock instance.
+func NewMockSIEMFeature(ctrl *gomock.Controller) *MockSIEMFeature {
+	mock := &MockSIEMFeature{ctrl: ctrl}
+	mock.recorder = &MockSIEMFeatureMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSIEMFeature) EXPECT() *MockSIEMFeatureMockRecorder {
+	return m.recorder
+}
+
+// Forward mocks base method.
+func (m *MockSIEMFeature) Forward(ctx context.Context, guid, tenantID string) ([]dto.SIEMCursor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Forward", ctx, guid, tenantID)
+	ret0, _ := ret[0].([]dto.SIEMCursor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Forward indicates an expected call of Forward.
+func (mr *MockSIEMFeatureMockRecorder) Forward(ctx, guid, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Forward", reflect.TypeOf((*MockSIEMFeature)(nil).Forward), ctx, guid, tenantID)
+}
+
+// GetCursorCount mocks base method.
+func (m *MockSIEMFeature) GetCursorCount(ctx context.Context, tenantID string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCursorCount", ctx, tenantID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCursorCount indicates an expected call of GetCursorCount.
+func (mr *MockSIEMFeatureMockRecorder) GetCursorCount(ctx, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCursorCount", reflect.TypeOf((*MockSIEMFeature)(nil).GetCursorCount), ctx, tenantID)
+}
+
+// GetCursors mocks base method.
+func (m *MockSIEMFeature) GetCursors(ctx context.Context, top, skip int, tenantID string) ([]dto.SIEMCursor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCursors", ctx, top, skip, tenantID)
+	ret0, _ := ret[0].([]dto.SIEMCursor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCursors indicates an expected call of GetCursors.
+func (mr *MockSIEMFeatureMockRecorder) GetCursors(ctx, top, skip, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCursors", reflect.TypeOf((*MockSIEMFeature)(nil).GetCursors), ctx, top, skip, tenantID)
+}
+
+// Run mocks base method.
+func (m *MockSIEMFeature) Run(ctx context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Run", ctx)
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockSIEMFeatureMockRecorder) Run(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockSIEMFeature)(nil).Run), ctx)
+}
+
+// MockSIEMAuditLogs is a mock of AuditLogs interface.
+type MockSIEMAuditLogs struct {
+	ctrl     *gomock.Controller
+	recorder *MockSIEMAuditLogsMockRecorder
+	isgomock struct{}
+}
+
+// MockSIEMAuditLogsMockRecorder is the mock recorder for MockSIEMAuditLogs.
+type MockSIEMAuditLogsMockRecorder struct {
+	mock *MockSIEMAuditLogs
+}
+
+// NewMockSIEMAuditLogs creates a new mock instance.
+func NewMockSIEMAuditLogs(ctrl *gomock.Controller) *MockSIEMAuditLogs {
+	mock := &MockSIEMAuditLogs{ctrl: ctrl}
+	mock.recorder = &MockSIEMAuditLogsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSIEMAuditLogs) EXPECT() *MockSIEMAuditLogsMockRecorder {
+	return m.recorder
+}
+
+// GetAfter mocks base method.
+func (m *MockSIEMAuditLogs) GetAfter(ctx context.Context, guid, tenantID string, after entity.LogPosition, collectedBefore string, limit int) ([]entity.AMTAuditRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAfter", ctx, guid, tenantID, after, collectedBefore, limit)
+	ret0, _ := ret[0].([]entity.AMTAuditRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAfter indicates an expected call of GetAfter.
+func (mr *MockSIEMAuditLogsMockRecorder) GetAfter(ctx, guid, tenantID, after, collectedBefore, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAfter", reflect.TypeOf((*MockSIEMAuditLogs)(nil).GetAfter), ctx, guid, tenantID, after, collectedBefore, limit)
+}
+
+// MockSIEMEventLogs is a mock of EventLogs interface.
+type MockSIEMEventLogs struct {
+	ctrl     *gomock.Controller
+	recorder *MockSIEMEventLogsMockRecorder
+	isgomock struct{}
+}
+
+// MockSIEMEventLogsMockRecorder is the mock recorder for MockSIEMEventLogs.
+type MockSIEMEventLogsMockRecorder struct {
+	mock *MockSIEMEventLogs
+}
+
+// NewMockSIEMEventLogs creates a new mock instance.
+func NewMockSIEMEventLogs(ctrl *gomock.Controller) *MockSIEMEventLogs {
+	mock := &MockSIEMEventLogs{ctrl: ctrl}
+	mock.recorder = &MockSIEMEventLogsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSIEMEventLogs) EXPECT() *MockSIEMEventLogsMockRecorder {
+	return m.recorder
+}
+
+// GetEventsAfter mocks base method.
+func (m *MockSIEMEventLogs) GetEventsAfter(ctx context.Context, guid, tenantID string, after entity.LogPosition, collectedBefore string, limit int) ([]entity.DeviceEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEventsAfter", ctx, guid, tenantID, after, collectedBefore, limit)
+	ret0, _ := ret[0].([]entity.DeviceEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEventsAfter indicates an expected call of GetEventsAfter.
+func (mr *MockSIEMEventLogsMockRecorder) GetEventsAfter(ctx, guid, tenantID, after, collectedBefore, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEventsAfter", reflect.TypeOf((*MockSIEMEventLogs)(nil).GetEventsAfter), ctx, guid, tenantID, after, collectedBefore, limit)
+}
+
+// MockSIEMFleet is a mock of Fleet interface.
+type MockSIEMFleet struct {
+	ctrl     *gomock.Controller
+	recorder *MockSIEMFleetMockRecorder
+	isgomock struct{}
+}
+
+// MockSIEMFleetMockRecorder is the mock recorder for MockSIEMFleet.
+type MockSIEMFleetMockRecorder struct {
+	mock *MockSIEMFleet
+}
+
+// NewMockSIEMFleet creates a new mock instance.
+func NewMockSIEMFleet(ctrl *gomock.Controller) *MockSIEMFleet {
+	mock := &MockSIEMFleet{ctrl: ctrl}
+	mock.recorder = &MockSIEMFleetMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSIEMFleet) EXPECT() *MockSIEMFleetMockRecorder {
+	return m.recorder
+}
+
+// GetAllTenants mocks base method.
+func (m *MockSIEMFleet) GetAllTenants(ctx context.Context, top, skip int) ([]entity.Device, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllTenants", ctx, top, skip)
+	ret0, _ := ret[0].([]entity.Device)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllTenants indicates an expected call of GetAllTenants.
+func (mr *MockSIEMFleetMockRecorder) GetAllTenants(ctx, top, skip any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllTenants", reflect.TypeOf((*MockSIEMFleet)(nil).GetAllTenants), ctx, top, skip)
+}
+
+// GetByID mocks base method.
+func (m *MockSIEMFleet) GetByID(ctx context.Context, guid, tenantID string) (*entity.Device, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, guid, tenantID)
+	ret0, _ := ret[0].(*entity.Device)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockSIEMFleetMockRecorder) GetByID(ctx, guid, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockSIEMFleet)(nil).GetByID), ctx, guid, tenantID)
+}
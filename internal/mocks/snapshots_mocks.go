@@ -0,0 +1,392 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/usecase/snapshots/interfaces.go
+//
+// Generated by this command:
+//
+//	mockgen -source ./internal/usecase/snapshots/interfaces.go -package mocks -mock_names Repository=MockSnapshotsRepository,Feature=MockSnapshotsFeature,Devices=MockSnapshotsDevices
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/open-amt-cloud-toolkit/console/internal/entity"
+	dto "github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	v2 "github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v2"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockSnapshotsRepository is a mock of Repository interface.
+type MockSnapshotsRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockSnapshotsRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockSnapshotsRepositoryMockRecorder is the mock recorder for MockSnapshotsRepository.
+type MockSnapshotsRepositoryMockRecorder struct {
+	mock *MockSnapshotsRepository
+}
+
+// NewMockSnapshotsRepository creates a new mock instance.
+func NewMockSnapshotsRepository(ctrl *gomock.Controller) *MockSnapshotsRepository {
+	mock := &MockSnapshotsRepository{ctrl: ctrl}
+	mock.recorder = &MockSnapshotsRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSnapshotsRepository) EXPECT() *MockSnapshotsRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockSnapshotsRepository) Delete(ctx context.Context, guid, id, tenantID string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, guid, id, tenantID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockSnapshotsRepositoryMockRecorder) Delete(ctx, guid, id, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockSnapshotsRepository)(nil).Delete), ctx, guid, id, tenantID)
+}
+
+// Get mocks base method.
+func (m *MockSnapshotsRepository) Get(ctx context.Context, guid string, top, skip int, tenantID string) ([]entity.DeviceSnapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, guid, top, skip, tenantID)
+	ret0, _ := ret[0].([]entity.DeviceSnapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockSnapshotsRepositoryMockRecorder) Get(ctx, guid, top, skip, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockSnapshotsRepository)(nil).Get), ctx, guid, top, skip, tenantID)
+}
+
+// GetByID mocks base method.
+func (m *MockSnapshotsRepository) GetByID(ctx context.Context, id, tenantID string) (*entity.DeviceSnapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id, tenantID)
+	ret0, _ := ret[0].(*entity.DeviceSnapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockSnapshotsRepositoryMockRecorder) GetByID(ctx, id, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockSnapshotsRepository)(nil).GetByID), ctx, id, tenantID)
+}
+
+// GetCount mocks base method.
+func (m *MockSnapshotsRepository) GetCount(ctx context.Context, guid, tenantID string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCount", ctx, guid, tenantID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCount indicates an expected call of GetCount.
+func (mr *MockSnapshotsRepositoryMockRecorder) GetCount(ctx, guid, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCount", reflect.TypeOf((*MockSnapshotsRepository)(nil).GetCount), ctx, guid, tenantID)
+}
+
+// Insert mocks base method.
+func (m *MockSnapshotsRepository) Insert(ctx context.Context, s *entity.DeviceSnapshot) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", ctx, s)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockSnapshotsRepositoryMockRecorder) Insert(ctx, s any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockSnapshotsRepository)(nil).Insert), ctx, s)
+}
+
+// MockSnapshotsFeature is a mock of Feature interface.
+type MockSnapshotsFeature struct {
+	ctrl     *gomock.Controller
+	recorder *MockSnapshotsFeatureMockRecorder
+	isgomock struct{}
+}
+
+// MockSnapshotsFeatureMockRecorder is the mock recorder for MockSnapshotsFeature.
+type MockSnapshotsFeatureMockRecorder struct {
+	mock *MockSnapshotsFeature
+}
+
+// NewMockSnapshotsFeature creates a new mock instance.
+func NewMockSnapshotsFeature(ctrl *gomock.Controller) *MockSnapshotsFeature {
+	mock := &MockSnapshotsFeature{ctrl: ctrl}
+	mock.recorder = &MockSnapshotsFeatureMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSnapshotsFeature) EXPECT() *MockSnapshotsFeatureMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockSnapshotsFeature) Delete(ctx context.Context, guid, id, tenantID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, guid, id, tenantID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockSnapshotsFeatureMockRecorder) Delete(ctx, guid, id, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockSnapshotsFeature)(nil).Delete), ctx, guid, id, tenantID)
+}
+
+// Diff mocks base method.
+func (m *MockSnapshotsFeature) Diff(ctx context.Context, leftID, rightID string, sections []string, tenantID string) (dto.SnapshotDiff, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Diff", ctx, leftID, rightID, sections, tenantID)
+	ret0, _ := ret[0].(dto.SnapshotDiff)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Diff indicates an expected call of Diff.
+func (mr *MockSnapshotsFeatureMockRecorder) Diff(ctx, leftID, rightID, sections, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Diff", reflect.TypeOf((*MockSnapshotsFeature)(nil).Diff), ctx, leftID, rightID, sections, tenantID)
+}
+
+// Get mocks base method.
+func (m *MockSnapshotsFeature) Get(ctx context.Context, guid string, top, skip int, tenantID string) ([]dto.DeviceSnapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, guid, top, skip, tenantID)
+	ret0, _ := ret[0].([]dto.DeviceSnapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockSnapshotsFeatureMockRecorder) Get(ctx, guid, top, skip, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockSnapshotsFeature)(nil).Get), ctx, guid, top, skip, tenantID)
+}
+
+// GetByID mocks base method.
+func (m *MockSnapshotsFeature) GetByID(ctx context.Context, guid, id, tenantID string) (*dto.DeviceSnapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, guid, id, tenantID)
+	ret0, _ := ret[0].(*dto.DeviceSnapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockSnapshotsFeatureMockRecorder) GetByID(ctx, guid, id, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockSnapshotsFeature)(nil).GetByID), ctx, guid, id, tenantID)
+}
+
+// GetCount mocks base method.
+func (m *MockSnapshotsFeature) GetCount(ctx context.Context, guid, tenantID string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCount", ctx, guid, tenantID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCount indicates an expected call of GetCount.
+func (mr *MockSnapshotsFeatureMockRecorder) GetCount(ctx, guid, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCount", reflect.TypeOf((*MockSnapshotsFeature)(nil).GetCount), ctx, guid, tenantID)
+}
+
+// Take mocks base method.
+func (m *MockSnapshotsFeature) Take(ctx context.Context, guid, tenantID string) (*dto.DeviceSnapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Take", ctx, guid, tenantID)
+	ret0, _ := ret[0].(*dto.DeviceSnapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Take indicates an expected call of Take.
+func (mr *MockSnapshotsFeatureMockRecorder) Take(ctx, guid, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Take", reflect.TypeOf((*MockSnapshotsFeature)(nil).Take), ctx, guid, tenantID)
+}
+
+// MockSnapshotsDevices is a mock of Devices interface.
+type MockSnapshotsDevices struct {
+	ctrl     *gomock.Controller
+	recorder *MockSnapshotsDevicesMockRecorder
+	isgomock struct{}
+}
+
+// MockSnapshotsDevicesMockRecorder is the mock recorder for MockSnapshotsDevices.
+type MockSnapshotsDevicesMockRecorder struct {
+	mock *MockSnapshotsDevices
+}
+
+// NewMockSnapshotsDevices creates a new mock instance.
+func NewMockSnapshotsDevices(ctrl *gomock.Controller) *MockSnapshotsDevices {
+	mock := &MockSnapshotsDevices{ctrl: ctrl}
+	mock.recorder = &MockSnapshotsDevicesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSnapshotsDevices) EXPECT() *MockSnapshotsDevicesMockRecorder {
+	return m.recorder
+}
+
+// GetByID mocks base method.
+func (m *MockSnapshotsDevices) GetByID(ctx context.Context, guid, tenantID string) (*dto.Device, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, guid, tenantID)
+	ret0, _ := ret[0].(*dto.Device)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockSnapshotsDevicesMockRecorder) GetByID(ctx, guid, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockSnapshotsDevices)(nil).GetByID), ctx, guid, tenantID)
+}
+
+// GetCertificates mocks base method.
+func (m *MockSnapshotsDevices) GetCertificates(ctx context.Context, guid string) (dto.SecuritySettings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCertificates", ctx, guid)
+	ret0, _ := ret[0].(dto.SecuritySettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCertificates indicates an expected call of GetCertificates.
+func (mr *MockSnapshotsDevicesMockRecorder) GetCertificates(ctx, guid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCertificates", reflect.TypeOf((*MockSnapshotsDevices)(nil).GetCertificates), ctx, guid)
+}
+
+// GetFeatures mocks base method.
+func (m *MockSnapshotsDevices) GetFeatures(ctx context.Context, guid string) (dto.Features, v2.Features, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFeatures", ctx, guid)
+	ret0, _ := ret[0].(dto.Features)
+	ret1, _ := ret[1].(v2.Features)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetFeatures indicates an expected call of GetFeatures.
+func (mr *MockSnapshotsDevicesMockRecorder) GetFeatures(ctx, guid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFeatures", reflect.TypeOf((*MockSnapshotsDevices)(nil).GetFeatures), ctx, guid)
+}
+
+// GetGeneralSettings mocks base method.
+func (m *MockSnapshotsDevices) GetGeneralSettings(ctx context.Context, guid string) (any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGeneralSettings", ctx, guid)
+	ret0, _ := ret[0].(any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGeneralSettings indicates an expected call of GetGeneralSettings.
+func (mr *MockSnapshotsDevicesMockRecorder) GetGeneralSettings(ctx, guid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGeneralSettings", reflect.TypeOf((*MockSnapshotsDevices)(nil).GetGeneralSettings), ctx, guid)
+}
+
+// GetHardwareInfo mocks base method.
+func (m *MockSnapshotsDevices) GetHardwareInfo(ctx context.Context, guid string) (any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHardwareInfo", ctx, guid)
+	ret0, _ := ret[0].(any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHardwareInfo indicates an expected call of GetHardwareInfo.
+func (mr *MockSnapshotsDevicesMockRecorder) GetHardwareInfo(ctx, guid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHardwareInfo", reflect.TypeOf((*MockSnapshotsDevices)(nil).GetHardwareInfo), ctx, guid)
+}
+
+// GetNetworkSettings mocks base method.
+func (m *MockSnapshotsDevices) GetNetworkSettings(ctx context.Context, guid string) (dto.NetworkSettings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNetworkSettings", ctx, guid)
+	ret0, _ := ret[0].(dto.NetworkSettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNetworkSettings indicates an expected call of GetNetworkSettings.
+func (mr *MockSnapshotsDevicesMockRecorder) GetNetworkSettings(ctx, guid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNetworkSettings", reflect.TypeOf((*MockSnapshotsDevices)(nil).GetNetworkSettings), ctx, guid)
+}
+
+// GetPowerCapabilities mocks base method.
+func (m *MockSnapshotsDevices) GetPowerCapabilities(ctx context.Context, guid string) (dto.PowerCapabilities, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPowerCapabilities", ctx, guid)
+	ret0, _ := ret[0].(dto.PowerCapabilities)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPowerCapabilities indicates an expected call of GetPowerCapabilities.
+func (mr *MockSnapshotsDevicesMockRecorder) GetPowerCapabilities(ctx, guid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPowerCapabilities", reflect.TypeOf((*MockSnapshotsDevices)(nil).GetPowerCapabilities), ctx, guid)
+}
+
+// GetTLSSettingData mocks base method.
+func (m *MockSnapshotsDevices) GetTLSSettingData(ctx context.Context, guid string) ([]dto.SettingDataResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTLSSettingData", ctx, guid)
+	ret0, _ := ret[0].([]dto.SettingDataResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTLSSettingData indicates an expected call of GetTLSSettingData.
+func (mr *MockSnapshotsDevicesMockRecorder) GetTLSSettingData(ctx, guid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTLSSettingData", reflect.TypeOf((*MockSnapshotsDevices)(nil).GetTLSSettingData), ctx, guid)
+}
+
+// GetVersion mocks base method.
+func (m *MockSnapshotsDevices) GetVersion(ctx context.Context, guid string) (dto.Version, v2.Version, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVersion", ctx, guid)
+	ret0, _ := ret[0].(dto.Version)
+	ret1, _ := ret[1].(v2.Version)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetVersion indicates an expected call of GetVersion.
+func (mr *MockSnapshotsDevicesMockRecorder) GetVersion(ctx, guid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVersion", reflect.TypeOf((*MockSnapshotsDevices)(nil).GetVersion), ctx, guid)
+}
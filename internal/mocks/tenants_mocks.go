@@ -0,0 +1,234 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/usecase/tenants/interfaces.go
+//
+// Generated by this command:
+//
+//	mockgen -source ./internal/usecase/tenants/interfaces.go -package mocks -mock_names Repository=MockTenantsRepository,Feature=MockTenantsFeature,Users=MockTenantsUsers,Roles=MockTenantsRoles
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/open-amt-cloud-toolkit/console/internal/entity"
+	dto "github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockTenantsRepository is a mock of Repository interface.
+type MockTenantsRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTenantsRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockTenantsRepositoryMockRecorder is the mock recorder for MockTenantsRepository.
+type MockTenantsRepositoryMockRecorder struct {
+	mock *MockTenantsRepository
+}
+
+// NewMockTenantsRepository creates a new mock instance.
+func NewMockTenantsRepository(ctrl *gomock.Controller) *MockTenantsRepository {
+	mock := &MockTenantsRepository{ctrl: ctrl}
+	mock.recorder = &MockTenantsRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTenantsRepository) EXPECT() *MockTenantsRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockTenantsRepository) Get(ctx context.Context, top, skip int) ([]entity.Tenant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, top, skip)
+	ret0, _ := ret[0].([]entity.Tenant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockTenantsRepositoryMockRecorder) Get(ctx, top, skip any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockTenantsRepository)(nil).Get), ctx, top, skip)
+}
+
+// GetCount mocks base method.
+func (m *MockTenantsRepository) GetCount(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCount", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCount indicates an expected call of GetCount.
+func (mr *MockTenantsRepositoryMockRecorder) GetCount(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCount", reflect.TypeOf((*MockTenantsRepository)(nil).GetCount), ctx)
+}
+
+// Insert mocks base method.
+func (m *MockTenantsRepository) Insert(ctx context.Context, t *entity.Tenant) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", ctx, t)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockTenantsRepositoryMockRecorder) Insert(ctx, t any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockTenantsRepository)(nil).Insert), ctx, t)
+}
+
+// MockTenantsFeature is a mock of Feature interface.
+type MockTenantsFeature struct {
+	ctrl     *gomock.Controller
+	recorder *MockTenantsFeatureMockRecorder
+	isgomock struct{}
+}
+
+// MockTenantsFeatureMockRecorder is the mock recorder for MockTenantsFeature.
+type MockTenantsFeatureMockRecorder struct {
+	mock *MockTenantsFeature
+}
+
+// NewMockTenantsFeature creates a new mock instance.
+func NewMockTenantsFeature(ctrl *gomock.Controller) *MockTenantsFeature {
+	mock := &MockTenantsFeature{ctrl: ctrl}
+	mock.recorder = &MockTenantsFeatureMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTenantsFeature) EXPECT() *MockTenantsFeatureMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockTenantsFeature) Get(ctx context.Context, top, skip int) ([]dto.Tenant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, top, skip)
+	ret0, _ := ret[0].([]dto.Tenant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockTenantsFeatureMockRecorder) Get(ctx, top, skip any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockTenantsFeature)(nil).Get), ctx, top, skip)
+}
+
+// GetCount mocks base method.
+func (m *MockTenantsFeature) GetCount(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCount", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCount indicates an expected call of GetCount.
+func (mr *MockTenantsFeatureMockRecorder) GetCount(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCount", reflect.TypeOf((*MockTenantsFeature)(nil).GetCount), ctx)
+}
+
+// Insert mocks base method.
+func (m *MockTenantsFeature) Insert(ctx context.Context, t *dto.Tenant) (*dto.Tenant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", ctx, t)
+	ret0, _ := ret[0].(*dto.Tenant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockTenantsFeatureMockRecorder) Insert(ctx, t any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockTenantsFeature)(nil).Insert), ctx, t)
+}
+
+// MockTenantsUsers is a mock of Users interface.
+type MockTenantsUsers struct {
+	ctrl     *gomock.Controller
+	recorder *MockTenantsUsersMockRecorder
+	isgomock struct{}
+}
+
+// MockTenantsUsersMockRecorder is the mock recorder for MockTenantsUsers.
+type MockTenantsUsersMockRecorder struct {
+	mock *MockTenantsUsers
+}
+
+// NewMockTenantsUsers creates a new mock instance.
+func NewMockTenantsUsers(ctrl *gomock.Controller) *MockTenantsUsers {
+	mock := &MockTenantsUsers{ctrl: ctrl}
+	mock.recorder = &MockTenantsUsersMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTenantsUsers) EXPECT() *MockTenantsUsersMockRecorder {
+	return m.recorder
+}
+
+// Insert mocks base method.
+func (m *MockTenantsUsers) Insert(ctx context.Context, u *dto.User) (*dto.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", ctx, u)
+	ret0, _ := ret[0].(*dto.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockTenantsUsersMockRecorder) Insert(ctx, u any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockTenantsUsers)(nil).Insert), ctx, u)
+}
+
+// MockTenantsRoles is a mock of Roles interface.
+type MockTenantsRoles struct {
+	ctrl     *gomock.Controller
+	recorder *MockTenantsRolesMockRecorder
+	isgomock struct{}
+}
+
+// MockTenantsRolesMockRecorder is the mock recorder for MockTenantsRoles.
+type MockTenantsRolesMockRecorder struct {
+	mock *MockTenantsRoles
+}
+
+// NewMockTenantsRoles creates a new mock instance.
+func NewMockTenantsRoles(ctrl *gomock.Controller) *MockTenantsRoles {
+	mock := &MockTenantsRoles{ctrl: ctrl}
+	mock.recorder = &MockTenantsRolesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTenantsRoles) EXPECT() *MockTenantsRolesMockRecorder {
+	return m.recorder
+}
+
+// Insert mocks base method.
+func (m *MockTenantsRoles) Insert(ctx context.Context, b *dto.RoleBinding) (*dto.RoleBinding, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", ctx, b)
+	ret0, _ := ret[0].(*dto.RoleBinding)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockTenantsRolesMockRecorder) Insert(ctx, b any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockTenantsRoles)(nil).Insert), ctx, b)
+}
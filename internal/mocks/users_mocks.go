@@ -0,0 +1,327 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/usecase/users/interfaces.go
+//
+// Generated by this command:
+//
+//	mockgen -source ./internal/usecase/users/interfaces.go -package mocks -mock_names Repository=MockUsersRepository,Feature=MockUsersFeature,Roles=MockUsersRoles
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/open-amt-cloud-toolkit/console/internal/entity"
+	dto "github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockUsersRepository is a mock of Repository interface.
+type MockUsersRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockUsersRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockUsersRepositoryMockRecorder is the mock recorder for MockUsersRepository.
+type MockUsersRepositoryMockRecorder struct {
+	mock *MockUsersRepository
+}
+
+// NewMockUsersRepository creates a new mock instance.
+func NewMockUsersRepository(ctrl *gomock.Controller) *MockUsersRepository {
+	mock := &MockUsersRepository{ctrl: ctrl}
+	mock.recorder = &MockUsersRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUsersRepository) EXPECT() *MockUsersRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockUsersRepository) Delete(ctx context.Context, username, tenantID string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, username, tenantID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockUsersRepositoryMockRecorder) Delete(ctx, username, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockUsersRepository)(nil).Delete), ctx, username, tenantID)
+}
+
+// Get mocks base method.
+func (m *MockUsersRepository) Get(ctx context.Context, top, skip int, tenantID string) ([]entity.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, top, skip, tenantID)
+	ret0, _ := ret[0].([]entity.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockUsersRepositoryMockRecorder) Get(ctx, top, skip, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockUsersRepository)(nil).Get), ctx, top, skip, tenantID)
+}
+
+// GetByUsername mocks base method.
+func (m *MockUsersRepository) GetByUsername(ctx context.Context, username, tenantID string) (*entity.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByUsername", ctx, username, tenantID)
+	ret0, _ := ret[0].(*entity.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByUsername indicates an expected call of GetByUsername.
+func (mr *MockUsersRepositoryMockRecorder) GetByUsername(ctx, username, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUsername", reflect.TypeOf((*MockUsersRepository)(nil).GetByUsername), ctx, username, tenantID)
+}
+
+// GetCount mocks base method.
+func (m *MockUsersRepository) GetCount(ctx context.Context, tenantID string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCount", ctx, tenantID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCount indicates an expected call of GetCount.
+func (mr *MockUsersRepositoryMockRecorder) GetCount(ctx, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCount", reflect.TypeOf((*MockUsersRepository)(nil).GetCount), ctx, tenantID)
+}
+
+// Insert mocks base method.
+func (m *MockUsersRepository) Insert(ctx context.Context, u *entity.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", ctx, u)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockUsersRepositoryMockRecorder) Insert(ctx, u any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockUsersRepository)(nil).Insert), ctx, u)
+}
+
+// Update mocks base method.
+func (m *MockUsersRepository) Update(ctx context.Context, u *entity.User) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, u)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockUsersRepositoryMockRecorder) Update(ctx, u any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockUsersRepository)(nil).Update), ctx, u)
+}
+
+// MockUsersFeature is a mock of Feature interface.
+type MockUsersFeature struct {
+	ctrl     *gomock.Controller
+	recorder *MockUsersFeatureMockRecorder
+	isgomock struct{}
+}
+
+// MockUsersFeatureMockRecorder is the mock recorder for MockUsersFeature.
+type MockUsersFeatureMockRecorder struct {
+	mock *MockUsersFeature
+}
+
+// NewMockUsersFeature creates a new mock instance.
+func NewMockUsersFeature(ctrl *gomock.Controller) *MockUsersFeature {
+	mock := &MockUsersFeature{ctrl: ctrl}
+	mock.recorder = &MockUsersFeatureMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUsersFeature) EXPECT() *MockUsersFeatureMockRecorder {
+	return m.recorder
+}
+
+// Authenticate mocks base method.
+func (m *MockUsersFeature) Authenticate(ctx context.Context, username, password, tenantID string) (*dto.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Authenticate", ctx, username, password, tenantID)
+	ret0, _ := ret[0].(*dto.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Authenticate indicates an expected call of Authenticate.
+func (mr *MockUsersFeatureMockRecorder) Authenticate(ctx, username, password, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authenticate", reflect.TypeOf((*MockUsersFeature)(nil).Authenticate), ctx, username, password, tenantID)
+}
+
+// Bootstrap mocks base method.
+func (m *MockUsersFeature) Bootstrap(ctx context.Context, username, password string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Bootstrap", ctx, username, password)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Bootstrap indicates an expected call of Bootstrap.
+func (mr *MockUsersFeatureMockRecorder) Bootstrap(ctx, username, password any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Bootstrap", reflect.TypeOf((*MockUsersFeature)(nil).Bootstrap), ctx, username, password)
+}
+
+// ChangePassword mocks base method.
+func (m *MockUsersFeature) ChangePassword(ctx context.Context, change dto.PasswordChange, tenantID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangePassword", ctx, change, tenantID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ChangePassword indicates an expected call of ChangePassword.
+func (mr *MockUsersFeatureMockRecorder) ChangePassword(ctx, change, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangePassword", reflect.TypeOf((*MockUsersFeature)(nil).ChangePassword), ctx, change, tenantID)
+}
+
+// Delete mocks base method.
+func (m *MockUsersFeature) Delete(ctx context.Context, username, tenantID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, username, tenantID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockUsersFeatureMockRecorder) Delete(ctx, username, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockUsersFeature)(nil).Delete), ctx, username, tenantID)
+}
+
+// Get mocks base method.
+func (m *MockUsersFeature) Get(ctx context.Context, top, skip int, tenantID string) ([]dto.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, top, skip, tenantID)
+	ret0, _ := ret[0].([]dto.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockUsersFeatureMockRecorder) Get(ctx, top, skip, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockUsersFeature)(nil).Get), ctx, top, skip, tenantID)
+}
+
+// GetByUsername mocks base method.
+func (m *MockUsersFeature) GetByUsername(ctx context.Context, username, tenantID string) (*dto.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByUsername", ctx, username, tenantID)
+	ret0, _ := ret[0].(*dto.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByUsername indicates an expected call of GetByUsername.
+func (mr *MockUsersFeatureMockRecorder) GetByUsername(ctx, username, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUsername", reflect.TypeOf((*MockUsersFeature)(nil).GetByUsername), ctx, username, tenantID)
+}
+
+// GetCount mocks base method.
+func (m *MockUsersFeature) GetCount(ctx context.Context, tenantID string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCount", ctx, tenantID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCount indicates an expected call of GetCount.
+func (mr *MockUsersFeatureMockRecorder) GetCount(ctx, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCount", reflect.TypeOf((*MockUsersFeature)(nil).GetCount), ctx, tenantID)
+}
+
+// Insert mocks base method.
+func (m *MockUsersFeature) Insert(ctx context.Context, u *dto.User) (*dto.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", ctx, u)
+	ret0, _ := ret[0].(*dto.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockUsersFeatureMockRecorder) Insert(ctx, u any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockUsersFeature)(nil).Insert), ctx, u)
+}
+
+// Update mocks base method.
+func (m *MockUsersFeature) Update(ctx context.Context, u *dto.User) (*dto.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, u)
+	ret0, _ := ret[0].(*dto.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockUsersFeatureMockRecorder) Update(ctx, u any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockUsersFeature)(nil).Update), ctx, u)
+}
+
+// MockUsersRoles is a mock of Roles interface.
+type MockUsersRoles struct {
+	ctrl     *gomock.Controller
+	recorder *MockUsersRolesMockRecorder
+	isgomock struct{}
+}
+
+// MockUsersRolesMockRecorder is the mock recorder for MockUsersRoles.
+type MockUsersRolesMockRecorder struct {
+	mock *MockUsersRoles
+}
+
+// NewMockUsersRoles creates a new mock instance.
+func NewMockUsersRoles(ctrl *gomock.Controller) *MockUsersRoles {
+	mock := &MockUsersRoles{ctrl: ctrl}
+	mock.recorder = &MockUsersRolesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUsersRoles) EXPECT() *MockUsersRolesMockRecorder {
+	return m.recorder
+}
+
+// Insert mocks base method.
+func (m *MockUsersRoles) Insert(ctx context.Context, b *dto.RoleBinding) (*dto.RoleBinding, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", ctx, b)
+	ret0, _ := ret[0].(*dto.RoleBinding)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockUsersRolesMockRecorder) Insert(ctx, b any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockUsersRoles)(nil).Insert), ctx, b)
+}
@@ -0,0 +1,415 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/usecase/webhooks/interfaces.go
+//
+// Generated by this command:
+//
+//	mockgen -source ./internal/usecase/webhooks/interfaces.go -package mocks -mock_names Repository=MockWebhooksRepository,Feature=MockWebhooksFeature,Events=MockWebhooksEvents
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/open-amt-cloud-toolkit/console/internal/entity"
+	dto "github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockWebhooksRepository is a mock of Repository interface.
+type MockWebhooksRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhooksRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockWebhooksRepositoryMockRecorder is the mock recorder for MockWebhooksRepository.
+type MockWebhooksRepositoryMockRecorder struct {
+	mock *MockWebhooksRepository
+}
+
+// NewMockWebhooksRepository creates a new mock instance.
+func NewMockWebhooksRepository(ctrl *gomock.Controller) *MockWebhooksRepository {
+	mock := &MockWebhooksRepository{ctrl: ctrl}
+	mock.recorder = &MockWebhooksRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhooksRepository) EXPECT() *MockWebhooksRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockWebhooksRepository) Delete(ctx context.Context, id, tenantID string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id, tenantID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockWebhooksRepositoryMockRecorder) Delete(ctx, id, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockWebhooksRepository)(nil).Delete), ctx, id, tenantID)
+}
+
+// Get mocks base method.
+func (m *MockWebhooksRepository) Get(ctx context.Context, top, skip int, tenantID string) ([]entity.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, top, skip, tenantID)
+	ret0, _ := ret[0].([]entity.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockWebhooksRepositoryMockRecorder) Get(ctx, top, skip, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockWebhooksRepository)(nil).Get), ctx, top, skip, tenantID)
+}
+
+// GetByID mocks base method.
+func (m *MockWebhooksRepository) GetByID(ctx context.Context, id, tenantID string) (*entity.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id, tenantID)
+	ret0, _ := ret[0].(*entity.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockWebhooksRepositoryMockRecorder) GetByID(ctx, id, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockWebhooksRepository)(nil).GetByID), ctx, id, tenantID)
+}
+
+// GetCount mocks base method.
+func (m *MockWebhooksRepository) GetCount(ctx context.Context, tenantID string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCount", ctx, tenantID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCount indicates an expected call of GetCount.
+func (mr *MockWebhooksRepositoryMockRecorder) GetCount(ctx, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCount", reflect.TypeOf((*MockWebhooksRepository)(nil).GetCount), ctx, tenantID)
+}
+
+// GetDeliveries mocks base method.
+func (m *MockWebhooksRepository) GetDeliveries(ctx context.Context, webhookID string, top, skip int, tenantID string) ([]entity.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeliveries", ctx, webhookID, top, skip, tenantID)
+	ret0, _ := ret[0].([]entity.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeliveries indicates an expected call of GetDeliveries.
+func (mr *MockWebhooksRepositoryMockRecorder) GetDeliveries(ctx, webhookID, top, skip, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeliveries", reflect.TypeOf((*MockWebhooksRepository)(nil).GetDeliveries), ctx, webhookID, top, skip, tenantID)
+}
+
+// GetDueDeliveries mocks base method.
+func (m *MockWebhooksRepository) GetDueDeliveries(ctx context.Context, now string) ([]entity.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDueDeliveries", ctx, now)
+	ret0, _ := ret[0].([]entity.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDueDeliveries indicates an expected call of GetDueDeliveries.
+func (mr *MockWebhooksRepositoryMockRecorder) GetDueDeliveries(ctx, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDueDeliveries", reflect.TypeOf((*MockWebhooksRepository)(nil).GetDueDeliveries), ctx, now)
+}
+
+// GetEnabled mocks base method.
+func (m *MockWebhooksRepository) GetEnabled(ctx context.Context, tenantID string) ([]entity.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEnabled", ctx, tenantID)
+	ret0, _ := ret[0].([]entity.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEnabled indicates an expected call of GetEnabled.
+func (mr *MockWebhooksRepositoryMockRecorder) GetEnabled(ctx, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEnabled", reflect.TypeOf((*MockWebhooksRepository)(nil).GetEnabled), ctx, tenantID)
+}
+
+// Insert mocks base method.
+func (m *MockWebhooksRepository) Insert(ctx context.Context, w *entity.Webhook) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", ctx, w)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockWebhooksRepositoryMockRecorder) Insert(ctx, w any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockWebhooksRepository)(nil).Insert), ctx, w)
+}
+
+// InsertDelivery mocks base method.
+func (m *MockWebhooksRepository) InsertDelivery(ctx context.Context, d *entity.WebhookDelivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertDelivery", ctx, d)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertDelivery indicates an expected call of InsertDelivery.
+func (mr *MockWebhooksRepositoryMockRecorder) InsertDelivery(ctx, d any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertDelivery", reflect.TypeOf((*MockWebhooksRepository)(nil).InsertDelivery), ctx, d)
+}
+
+// Update mocks base method.
+func (m *MockWebhooksRepository) Update(ctx context.Context, w *entity.Webhook) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, w)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockWebhooksRepositoryMockRecorder) Update(ctx, w any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockWebhooksRepository)(nil).Update), ctx, w)
+}
+
+// UpdateDelivery mocks base method.
+func (m *MockWebhooksRepository) UpdateDelivery(ctx context.Context, d *entity.WebhookDelivery) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateDelivery", ctx, d)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateDelivery indicates an expected call of UpdateDelivery.
+func (mr *MockWebhooksRepositoryMockRecorder) UpdateDelivery(ctx, d any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDelivery", reflect.TypeOf((*MockWebhooksRepository)(nil).UpdateDelivery), ctx, d)
+}
+
+// UpdateDeliveryStatus mocks base method.
+func (m *MockWebhooksRepository) UpdateDeliveryStatus(ctx context.Context, id, tenantID, from, to string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateDeliveryStatus", ctx, id, tenantID, from, to)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateDeliveryStatus indicates an expected call of UpdateDeliveryStatus.
+func (mr *MockWebhooksRepositoryMockRecorder) UpdateDeliveryStatus(ctx, id, tenantID, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDeliveryStatus", reflect.TypeOf((*MockWebhooksRepository)(nil).UpdateDeliveryStatus), ctx, id, tenantID, from, to)
+}
+
+// MockWebhooksFeature is a mock of Feature interface.
+type MockWebhooksFeature struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhooksFeatureMockRecorder
+	isgomock struct{}
+}
+
+// MockWebhooksFeatureMockRecorder is the mock recorder for MockWebhooksFeature.
+type MockWebhooksFeatureMockRecorder struct {
+	mock *MockWebhooksFeature
+}
+
+// NewMockWebhooksFeature creates a new mock instance.
+func NewMockWebhooksFeature(ctrl *gomock.Controller) *MockWebhooksFeature {
+	mock := &MockWebhooksFeature{ctrl: ctrl}
+	mock.recorder = &MockWebhooksFeatureMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhooksFeature) EXPECT() *MockWebhooksFeatureMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockWebhooksFeature) Delete(ctx context.Context, id, tenantID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id, tenantID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockWebhooksFeatureMockRecorder) Delete(ctx, id, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockWebhooksFeature)(nil).Delete), ctx, id, tenantID)
+}
+
+// Get mocks base method.
+func (m *MockWebhooksFeature) Get(ctx context.Context, top, skip int, tenantID string) ([]dto.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, top, skip, tenantID)
+	ret0, _ := ret[0].([]dto.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockWebhooksFeatureMockRecorder) Get(ctx, top, skip, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockWebhooksFeature)(nil).Get), ctx, top, skip, tenantID)
+}
+
+// GetByID mocks base method.
+func (m *MockWebhooksFeature) GetByID(ctx context.Context, id, tenantID string) (*dto.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id, tenantID)
+	ret0, _ := ret[0].(*dto.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockWebhooksFeatureMockRecorder) GetByID(ctx, id, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockWebhooksFeature)(nil).GetByID), ctx, id, tenantID)
+}
+
+// GetCount mocks base method.
+func (m *MockWebhooksFeature) GetCount(ctx context.Context, tenantID string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCount", ctx, tenantID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCount indicates an expected call of GetCount.
+func (mr *MockWebhooksFeatureMockRecorder) GetCount(ctx, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCount", reflect.TypeOf((*MockWebhooksFeature)(nil).GetCount), ctx, tenantID)
+}
+
+// GetDeliveries mocks base method.
+func (m *MockWebhooksFeature) GetDeliveries(ctx context.Context, webhookID string, top, skip int, tenantID string) ([]dto.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeliveries", ctx, webhookID, top, skip, tenantID)
+	ret0, _ := ret[0].([]dto.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeliveries indicates an expected call of GetDeliveries.
+func (mr *MockWebhooksFeatureMockRecorder) GetDeliveries(ctx, webhookID, top, skip, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeliveries", reflect.TypeOf((*MockWebhooksFeature)(nil).GetDeliveries), ctx, webhookID, top, skip, tenantID)
+}
+
+// Insert mocks base method.
+func (m *MockWebhooksFeature) Insert(ctx context.Context, w *dto.Webhook) (*dto.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", ctx, w)
+	ret0, _ := ret[0].(*dto.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockWebhooksFeatureMockRecorder) Insert(ctx, w any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockWebhooksFeature)(nil).Insert), ctx, w)
+}
+
+// Run mocks base method.
+func (m *MockWebhooksFeature) Run(ctx context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Run", ctx)
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockWebhooksFeatureMockRecorder) Run(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockWebhooksFeature)(nil).Run), ctx)
+}
+
+// Test mocks base method.
+func (m *MockWebhooksFeature) Test(ctx context.Context, id, tenantID string) (*dto.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Test", ctx, id, tenantID)
+	ret0, _ := ret[0].(*dto.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Test indicates an expected call of Test.
+func (mr *MockWebhooksFeatureMockRecorder) Test(ctx, id, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Test", reflect.TypeOf((*MockWebhooksFeature)(nil).Test), ctx, id, tenantID)
+}
+
+// Update mocks base method.
+func (m *MockWebhooksFeature) Update(ctx context.Context, w *dto.Webhook) (*dto.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, w)
+	ret0, _ := ret[0].(*dto.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockWebhooksFeatureMockRecorder) Update(ctx, w any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockWebhooksFeature)(nil).Update), ctx, w)
+}
+
+// MockWebhooksEvents is a mock of Events interface.
+type MockWebhooksEvents struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhooksEventsMockRecorder
+	isgomock struct{}
+}
+
+// MockWebhooksEventsMockRecorder is the mock recorder for MockWebhooksEvents.
+type MockWebhooksEventsMockRecorder struct {
+	mock *MockWebhooksEvents
+}
+
+// NewMockWebhooksEvents creates a new mock instance.
+func NewMockWebhooksEvents(ctrl *gomock.Controller) *MockWebhooksEvents {
+	mock := &MockWebhooksEvents{ctrl: ctrl}
+	mock.recorder = &MockWebhooksEventsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhooksEvents) EXPECT() *MockWebhooksEventsMockRecorder {
+	return m.recorder
+}
+
+// OnPublish mocks base method.
+func (m *MockWebhooksEvents) OnPublish(fn func(context.Context, dto.Event)) func() {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OnPublish", fn)
+	ret0, _ := ret[0].(func())
+	return ret0
+}
+
+// OnPublish indicates an expected call of OnPublish.
+func (mr *MockWebhooksEventsMockRecorder) OnPublish(fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OnPublish", reflect.TypeOf((*MockWebhooksEvents)(nil).OnPublish), fn)
+}
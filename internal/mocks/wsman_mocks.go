@@ -19,6 +19,7 @@ import (
 	alarmclock "github.com/open-amt-cloud-toolkit/go-wsman-messages/v2/pkg/wsman/amt/alarmclock"
 	auditlog "github.com/open-amt-cloud-toolkit/go-wsman-messages/v2/pkg/wsman/amt/auditlog"
 	boot "github.com/open-amt-cloud-toolkit/go-wsman-messages/v2/pkg/wsman/amt/boot"
+	ethernetport "github.com/open-amt-cloud-toolkit/go-wsman-messages/v2/pkg/wsman/amt/ethernetport"
 	messagelog "github.com/open-amt-cloud-toolkit/go-wsman-messages/v2/pkg/wsman/amt/messagelog"
 	redirection "github.com/open-amt-cloud-toolkit/go-wsman-messages/v2/pkg/wsman/amt/redirection"
 	setupandconfiguration "github.com/open-amt-cloud-toolkit/go-wsman-messages/v2/pkg/wsman/amt/setupandconfiguration"
@@ -268,6 +269,21 @@ func (mr *MockManagementMockRecorder) GetDiskInfo() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDiskInfo", reflect.TypeOf((*MockManagement)(nil).GetDiskInfo))
 }
 
+// GetEthernetPortSettings mocks base method.
+func (m *MockManagement) GetEthernetPortSettings() ([]ethernetport.SettingsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEthernetPortSettings")
+	ret0, _ := ret[0].([]ethernetport.SettingsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEthernetPortSettings indicates an expected call of GetEthernetPortSettings.
+func (mr *MockManagementMockRecorder) GetEthernetPortSettings() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEthernetPortSettings", reflect.TypeOf((*MockManagement)(nil).GetEthernetPortSettings))
+}
+
 // GetEventLog mocks base method.
 func (m *MockManagement) GetEventLog(startIndex, maxReadRecords int) (messagelog.GetRecordsResponse, error) {
 	m.ctrl.T.Helper()
@@ -328,6 +344,21 @@ func (mr *MockManagementMockRecorder) GetIPSOptInService() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIPSOptInService", reflect.TypeOf((*MockManagement)(nil).GetIPSOptInService))
 }
 
+// GetInventory mocks base method.
+func (m *MockManagement) GetInventory() (wsman.Inventory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInventory")
+	ret0, _ := ret[0].(wsman.Inventory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInventory indicates an expected call of GetInventory.
+func (mr *MockManagementMockRecorder) GetInventory() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInventory", reflect.TypeOf((*MockManagement)(nil).GetInventory))
+}
+
 // GetKVMRedirection mocks base method.
 func (m *MockManagement) GetKVMRedirection() (kvm.Response, error) {
 	m.ctrl.T.Helper()
@@ -18,6 +18,7 @@ import (
 	websocket "github.com/gorilla/websocket"
 	dto "github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
 	v2 "github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v2"
+	devices "github.com/open-amt-cloud-toolkit/console/internal/usecase/devices"
 	power "github.com/open-amt-cloud-toolkit/go-wsman-messages/v2/pkg/wsman/cim/power"
 	gomock "go.uber.org/mock/gomock"
 )
@@ -138,6 +139,34 @@ func (mr *MockFeatureMockRecorder) CancelUserConsent(ctx, guid any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelUserConsent", reflect.TypeOf((*MockFeature)(nil).CancelUserConsent), ctx, guid)
 }
 
+// CloseIDER mocks base method.
+func (m *MockFeature) CloseIDER(ctx context.Context, guid string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseIDER", ctx, guid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CloseIDER indicates an expected call of CloseIDER.
+func (mr *MockFeatureMockRecorder) CloseIDER(ctx, guid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseIDER", reflect.TypeOf((*MockFeature)(nil).CloseIDER), ctx, guid)
+}
+
+// CloseSOL mocks base method.
+func (m *MockFeature) CloseSOL(ctx context.Context, guid string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseSOL", ctx, guid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CloseSOL indicates an expected call of CloseSOL.
+func (mr *MockFeatureMockRecorder) CloseSOL(ctx, guid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseSOL", reflect.TypeOf((*MockFeature)(nil).CloseSOL), ctx, guid)
+}
+
 // CreateAlarmOccurrences mocks base method.
 func (m *MockFeature) CreateAlarmOccurrences(ctx context.Context, guid string, alarm dto.AlarmClockOccurrenceInput) (dto.AddAlarmOutput, error) {
 	m.ctrl.T.Helper()
@@ -226,6 +255,21 @@ func (mr *MockFeatureMockRecorder) GetAuditLog(ctx, startIndex, guid any) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuditLog", reflect.TypeOf((*MockFeature)(nil).GetAuditLog), ctx, startIndex, guid)
 }
 
+// GetBulkPowerActionJob mocks base method.
+func (m *MockFeature) GetBulkPowerActionJob(ctx context.Context, jobID string) (dto.BulkPowerActionJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBulkPowerActionJob", ctx, jobID)
+	ret0, _ := ret[0].(dto.BulkPowerActionJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBulkPowerActionJob indicates an expected call of GetBulkPowerActionJob.
+func (mr *MockFeatureMockRecorder) GetBulkPowerActionJob(ctx, jobID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBulkPowerActionJob", reflect.TypeOf((*MockFeature)(nil).GetBulkPowerActionJob), ctx, jobID)
+}
+
 // GetByColumn mocks base method.
 func (m *MockFeature) GetByColumn(ctx context.Context, columnName, queryValue, tenantID string) ([]dto.Device, error) {
 	m.ctrl.T.Helper()
@@ -286,6 +330,21 @@ func (mr *MockFeatureMockRecorder) GetCertificates(c, guid any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCertificates", reflect.TypeOf((*MockFeature)(nil).GetCertificates), c, guid)
 }
 
+// GetConnectedCount mocks base method.
+func (m *MockFeature) GetConnectedCount(ctx context.Context, tenantID string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetConnectedCount", ctx, tenantID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetConnectedCount indicates an expected call of GetConnectedCount.
+func (mr *MockFeatureMockRecorder) GetConnectedCount(ctx, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConnectedCount", reflect.TypeOf((*MockFeature)(nil).GetConnectedCount), ctx, tenantID)
+}
+
 // GetCount mocks base method.
 func (m *MockFeature) GetCount(arg0 context.Context, arg1 string) (int, error) {
 	m.ctrl.T.Helper()
@@ -407,6 +466,36 @@ func (mr *MockFeatureMockRecorder) GetHardwareInfo(ctx, guid any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHardwareInfo", reflect.TypeOf((*MockFeature)(nil).GetHardwareInfo), ctx, guid)
 }
 
+// GetIDER mocks base method.
+func (m *MockFeature) GetIDER(ctx context.Context, guid string) (dto.IDERSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIDER", ctx, guid)
+	ret0, _ := ret[0].(dto.IDERSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIDER indicates an expected call of GetIDER.
+func (mr *MockFeatureMockRecorder) GetIDER(ctx, guid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIDER", reflect.TypeOf((*MockFeature)(nil).GetIDER), ctx, guid)
+}
+
+// GetInventory mocks base method.
+func (m *MockFeature) GetInventory(c context.Context, guid string) (dto.DeviceInventory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInventory", c, guid)
+	ret0, _ := ret[0].(dto.DeviceInventory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInventory indicates an expected call of GetInventory.
+func (mr *MockFeatureMockRecorder) GetInventory(c, guid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInventory", reflect.TypeOf((*MockFeature)(nil).GetInventory), c, guid)
+}
+
 // GetNetworkSettings mocks base method.
 func (m *MockFeature) GetNetworkSettings(c context.Context, guid string) (dto.NetworkSettings, error) {
 	m.ctrl.T.Helper()
@@ -452,6 +541,51 @@ func (mr *MockFeatureMockRecorder) GetPowerState(ctx, guid any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPowerState", reflect.TypeOf((*MockFeature)(nil).GetPowerState), ctx, guid)
 }
 
+// GetRedirectionSessions mocks base method.
+func (m *MockFeature) GetRedirectionSessions(ctx context.Context, tenantID string) ([]dto.RedirectionSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRedirectionSessions", ctx, tenantID)
+	ret0, _ := ret[0].([]dto.RedirectionSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRedirectionSessions indicates an expected call of GetRedirectionSessions.
+func (mr *MockFeatureMockRecorder) GetRedirectionSessions(ctx, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRedirectionSessions", reflect.TypeOf((*MockFeature)(nil).GetRedirectionSessions), ctx, tenantID)
+}
+
+// GetSOL mocks base method.
+func (m *MockFeature) GetSOL(ctx context.Context, guid string) (dto.SOLSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSOL", ctx, guid)
+	ret0, _ := ret[0].(dto.SOLSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSOL indicates an expected call of GetSOL.
+func (mr *MockFeatureMockRecorder) GetSOL(ctx, guid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSOL", reflect.TypeOf((*MockFeature)(nil).GetSOL), ctx, guid)
+}
+
+// GetSOLScreen mocks base method.
+func (m *MockFeature) GetSOLScreen(ctx context.Context, guid string) (dto.SOLScreen, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSOLScreen", ctx, guid)
+	ret0, _ := ret[0].(dto.SOLScreen)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSOLScreen indicates an expected call of GetSOLScreen.
+func (mr *MockFeatureMockRecorder) GetSOLScreen(ctx, guid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSOLScreen", reflect.TypeOf((*MockFeature)(nil).GetSOLScreen), ctx, guid)
+}
+
 // GetTLSSettingData mocks base method.
 func (m *MockFeature) GetTLSSettingData(c context.Context, guid string) ([]dto.SettingDataResponse, error) {
 	m.ctrl.T.Helper()
@@ -513,6 +647,36 @@ func (mr *MockFeatureMockRecorder) Insert(ctx, d any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockFeature)(nil).Insert), ctx, d)
 }
 
+// OpenIDER mocks base method.
+func (m *MockFeature) OpenIDER(ctx context.Context, guid string, media devices.IDERMedia, start string) (dto.IDERSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OpenIDER", ctx, guid, media, start)
+	ret0, _ := ret[0].(dto.IDERSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OpenIDER indicates an expected call of OpenIDER.
+func (mr *MockFeatureMockRecorder) OpenIDER(ctx, guid, media, start any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenIDER", reflect.TypeOf((*MockFeature)(nil).OpenIDER), ctx, guid, media, start)
+}
+
+// OpenSOL mocks base method.
+func (m *MockFeature) OpenSOL(ctx context.Context, guid string) (dto.SOLSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OpenSOL", ctx, guid)
+	ret0, _ := ret[0].(dto.SOLSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OpenSOL indicates an expected call of OpenSOL.
+func (mr *MockFeatureMockRecorder) OpenSOL(ctx, guid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenSOL", reflect.TypeOf((*MockFeature)(nil).OpenSOL), ctx, guid)
+}
+
 // Redirect mocks base method.
 func (m *MockFeature) Redirect(ctx context.Context, conn *websocket.Conn, guid, mode string) error {
 	m.ctrl.T.Helper()
@@ -527,6 +691,36 @@ func (mr *MockFeatureMockRecorder) Redirect(ctx, conn, guid, mode any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Redirect", reflect.TypeOf((*MockFeature)(nil).Redirect), ctx, conn, guid, mode)
 }
 
+// Screenshot mocks base method.
+func (m *MockFeature) Screenshot(ctx context.Context, guid string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Screenshot", ctx, guid)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Screenshot indicates an expected call of Screenshot.
+func (mr *MockFeatureMockRecorder) Screenshot(ctx, guid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Screenshot", reflect.TypeOf((*MockFeature)(nil).Screenshot), ctx, guid)
+}
+
+// SendBulkPowerAction mocks base method.
+func (m *MockFeature) SendBulkPowerAction(ctx context.Context, req dto.BulkPowerActionRequest, tenantID string) (dto.BulkPowerActionJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendBulkPowerAction", ctx, req, tenantID)
+	ret0, _ := ret[0].(dto.BulkPowerActionJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SendBulkPowerAction indicates an expected call of SendBulkPowerAction.
+func (mr *MockFeatureMockRecorder) SendBulkPowerAction(ctx, req, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendBulkPowerAction", reflect.TypeOf((*MockFeature)(nil).SendBulkPowerAction), ctx, req, tenantID)
+}
+
 // SendConsentCode mocks base method.
 func (m *MockFeature) SendConsentCode(ctx context.Context, code dto.UserConsentCode, guid string) (dto.UserConsentMessage, error) {
 	m.ctrl.T.Helper()
@@ -557,6 +751,20 @@ func (mr *MockFeatureMockRecorder) SendPowerAction(ctx, guid, action any) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendPowerAction", reflect.TypeOf((*MockFeature)(nil).SendPowerAction), ctx, guid, action)
 }
 
+// SendSOLKeys mocks base method.
+func (m *MockFeature) SendSOLKeys(ctx context.Context, guid, keys string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendSOLKeys", ctx, guid, keys)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendSOLKeys indicates an expected call of SendSOLKeys.
+func (mr *MockFeatureMockRecorder) SendSOLKeys(ctx, guid, keys any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendSOLKeys", reflect.TypeOf((*MockFeature)(nil).SendSOLKeys), ctx, guid, keys)
+}
+
 // SetBootOptions mocks base method.
 func (m *MockFeature) SetBootOptions(ctx context.Context, guid string, bootSetting dto.BootSetting) (power.PowerActionResponse, error) {
 	m.ctrl.T.Helper()
@@ -588,6 +796,35 @@ func (mr *MockFeatureMockRecorder) SetFeatures(ctx, guid, features any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetFeatures", reflect.TypeOf((*MockFeature)(nil).SetFeatures), ctx, guid, features)
 }
 
+// SubscribeSOL mocks base method.
+func (m *MockFeature) SubscribeSOL(ctx context.Context, guid string) (<-chan string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubscribeSOL", ctx, guid)
+	ret0, _ := ret[0].(<-chan string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubscribeSOL indicates an expected call of SubscribeSOL.
+func (mr *MockFeatureMockRecorder) SubscribeSOL(ctx, guid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeSOL", reflect.TypeOf((*MockFeature)(nil).SubscribeSOL), ctx, guid)
+}
+
+// TerminateRedirectionSession mocks base method.
+func (m *MockFeature) TerminateRedirectionSession(ctx context.Context, id, tenantID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TerminateRedirectionSession", ctx, id, tenantID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TerminateRedirectionSession indicates an expected call of TerminateRedirectionSession.
+func (mr *MockFeatureMockRecorder) TerminateRedirectionSession(ctx, id, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TerminateRedirectionSession", reflect.TypeOf((*MockFeature)(nil).TerminateRedirectionSession), ctx, id, tenantID)
+}
+
 // Update mocks base method.
 func (m *MockFeature) Update(ctx context.Context, d *dto.Device) (*dto.Device, error) {
 	m.ctrl.T.Helper()
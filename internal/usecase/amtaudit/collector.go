@@ -0,0 +1,243 @@
+package amtaudit
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/open-amt-cloud-toolkit/go-wsman-messages/v2/pkg/wsman/amt/auditlog"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity"
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	"github.com/open-amt-cloud-toolkit/console/pkg/tenant"
+)
+
+const fleetPageSize = 100
+
+// Run collects the audit logs of the fleet every interval until ctx is done.
+func (uc *UseCase) Run(ctx context.Context) {
+	if uc.cfg.Interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(uc.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		uc.CollectAll(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// CollectAll collects the new records of every connected device once and waits for the collections to finish.
+func (uc *UseCase) CollectAll(ctx context.Context) {
+	sem := make(chan struct{}, uc.cfg.Workers)
+
+	var wg sync.WaitGroup
+
+	defer wg.Wait()
+
+	for skip := 0; ; skip += fleetPageSize {
+		page, err := uc.fleet.GetAllTenants(ctx, fleetPageSize, skip)
+		if err != nil {
+			uc.log.Error(err, "amtaudit - CollectAll - uc.fleet.GetAllTenants")
+
+			return
+		}
+
+		for i := range page {
+			if !page[i].ConnectionStatus {
+				continue
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case sem <- struct{}{}:
+			}
+
+			wg.Add(1)
+
+			go func(d entity.Device) {
+				defer func() {
+					<-sem
+					wg.Done()
+				}()
+
+				if _, err := uc.Collect(ctx, d.GUID, d.TenantID); err != nil {
+					uc.log.Debug("amtaudit - CollectAll - " + d.GUID + ": " + err.Error())
+				}
+			}(page[i])
+		}
+
+		if len(page) < fleetPageSize {
+			return
+		}
+	}
+}
+
+// Collect stores the records a device logged since its last collection and moves its cursor past them. A
+// collection that fails part way keeps the records read so far and records the error on the cursor.
+func (uc *UseCase) Collect(ctx context.Context, guid, tenantID string) (*dto.AMTAuditCursor, error) {
+	// the device management calls find the device in the tenant of ctx
+	ctx = tenant.NewContext(ctx, tenantID)
+
+	cursor, err := uc.repo.GetCursor(ctx, guid, tenantID)
+	if err != nil {
+		return nil, ErrDatabase.Wrap("Collect", "uc.repo.GetCursor", err)
+	}
+
+	known := cursor != nil
+	if !known {
+		cursor = &entity.AMTAuditCursor{GUID: guid, TenantID: tenantID}
+	}
+
+	now := uc.now()
+
+	cursor.CollectedAt = formatTime(now)
+	cursor.Collected = 0
+	cursor.Error = ""
+
+	collectErr := uc.collect(ctx, cursor, now)
+	if collectErr != nil {
+		// a device that never answered, or does not exist, gets no cursor
+		if !known && cursor.LastIndex == 0 {
+			return nil, collectErr
+		}
+
+		cursor.Error = collectErr.Error()
+	}
+
+	if err := uc.repo.UpsertCursor(ctx, cursor); err != nil {
+		return nil, ErrDatabase.Wrap("Collect", "uc.repo.UpsertCursor", err)
+	}
+
+	if collectErr != nil {
+		return nil, collectErr
+	}
+
+	return cursorToDTO(cursor), nil
+}
+
+// collect reads the log of a device from its cursor on. AMT numbers the records of its log from 1, the oldest
+// first, so when the log wraps the numbers shift: the record at the cursor is checked against the one last seen
+// before anything after it is trusted to be new.
+func (uc *UseCase) collect(ctx context.Context, c *entity.AMTAuditCursor, now time.Time) error {
+	index := max(c.LastIndex, 1)
+
+	records, total, err := uc.read(ctx, c.GUID, index)
+	if err != nil {
+		return err
+	}
+
+	seen := 0
+
+	if c.LastIndex > 0 {
+		if len(records) > 0 && fingerprint(&records[0]) == c.LastFingerprint {
+			seen = 1
+		} else {
+			// the log wrapped or was cleared, read it again from the start and let the records already stored be
+			// skipped by their fingerprint
+			index = 1
+
+			records, total, err = uc.read(ctx, c.GUID, index)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	for {
+		if fresh := records[seen:]; len(fresh) > 0 {
+			batch := make([]entity.AMTAuditRecord, len(fresh))
+			for i := range fresh {
+				batch[i] = *recordToEntity(&fresh[i], c, now)
+			}
+
+			inserted, err := uc.repo.Insert(ctx, batch)
+			if err != nil {
+				return ErrDatabase.Wrap("Collect", "uc.repo.Insert", err)
+			}
+
+			last := &batch[len(batch)-1]
+
+			c.LastIndex = index + len(records) - 1
+			c.LastFingerprint = last.Fingerprint
+			c.LastTime = last.Time
+			c.Collected += inserted
+		}
+
+		index += len(records)
+		seen = 0
+
+		if len(records) == 0 || index > total {
+			return nil
+		}
+
+		records, total, err = uc.read(ctx, c.GUID, index)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// read returns the page of a device's log starting at index, oldest first, and the number of records in the log.
+func (uc *UseCase) read(ctx context.Context, guid string, index int) ([]auditlog.AuditLogRecord, int, error) {
+	page, err := uc.devices.GetAuditLog(ctx, index, guid)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// the records of a page are decoded newest first
+	records := slices.Clone(page.Records)
+	slices.Reverse(records)
+
+	return records, page.TotalCount, nil
+}
+
+// fingerprint identifies a record of a device's log, AMT gives its records no identifier of their own.
+func fingerprint(r *auditlog.AuditLogRecord) string {
+	sum := sha256.Sum256([]byte(strings.Join([]string{
+		strconv.Itoa(r.AuditAppID),
+		strconv.Itoa(r.EventID),
+		strconv.Itoa(int(r.InitiatorType)),
+		r.Initiator,
+		r.Time.UTC().Format(time.RFC3339Nano),
+		strconv.Itoa(int(r.MCLocationType)),
+		r.NetAddress,
+		r.Ex,
+	}, "\x00")))
+
+	return hex.EncodeToString(sum[:])
+}
+
+func recordToEntity(r *auditlog.AuditLogRecord, c *entity.AMTAuditCursor, now time.Time) *entity.AMTAuditRecord {
+	return &entity.AMTAuditRecord{
+		GUID:           c.GUID,
+		Fingerprint:    fingerprint(r),
+		AuditAppID:     r.AuditAppID,
+		AuditApp:       r.AuditApp,
+		EventID:        r.EventID,
+		Event:          r.Event,
+		InitiatorType:  int(r.InitiatorType),
+		Initiator:      r.Initiator,
+		Time:           formatTime(r.Time),
+		MCLocationType: int(r.MCLocationType),
+		NetAddress:     r.NetAddress,
+		ExtendedData:   base64.StdEncoding.EncodeToString([]byte(r.Ex)),
+		Description:    r.ExStr,
+		CollectedAt:    formatTime(now),
+		TenantID:       c.TenantID,
+	}
+}
@@ -0,0 +1,34 @@
+package amtaudit
+
+import (
+	"context"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity"
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+)
+
+type (
+	Repository interface {
+		GetCount(ctx context.Context, filter entity.AMTAuditFilter) (int, error)
+		Get(ctx context.Context, filter entity.AMTAuditFilter, top, skip int) ([]entity.AMTAuditRecord, error)
+		Insert(ctx context.Context, records []entity.AMTAuditRecord) (int, error)
+		GetCursor(ctx context.Context, guid, tenantID string) (*entity.AMTAuditCursor, error)
+		GetCursors(ctx context.Context, top, skip int, tenantID string) ([]entity.AMTAuditCursor, error)
+		UpsertCursor(ctx context.Context, c *entity.AMTAuditCursor) error
+	}
+	Feature interface {
+		Collect(ctx context.Context, guid, tenantID string) (*dto.AMTAuditCursor, error)
+		GetCount(ctx context.Context, filter dto.AMTAuditFilter) (int, error)
+		Get(ctx context.Context, filter dto.AMTAuditFilter, top, skip int) ([]dto.AMTAuditRecord, error)
+		GetCursors(ctx context.Context, top, skip int, tenantID string) ([]dto.AMTAuditCursor, error)
+		Run(ctx context.Context)
+	}
+	// Devices is the part of the device management feature the audit log is read with.
+	Devices interface {
+		GetAuditLog(ctx context.Context, startIndex int, guid string) (dto.AuditLog, error)
+	}
+	// Fleet pages through the devices of every tenant for the periodic collection.
+	Fleet interface {
+		GetAllTenants(ctx context.Context, top, skip int) ([]entity.Device, error)
+	}
+)
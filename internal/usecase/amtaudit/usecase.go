@@ -0,0 +1,155 @@
+package amtaudit
+
+import (
+	"context"
+	"time"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity"
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/sqldb"
+	"github.com/open-amt-cloud-toolkit/console/pkg/consoleerrors"
+	"github.com/open-amt-cloud-toolkit/console/pkg/logger"
+)
+
+// Config controls the periodic collection of the fleet's audit logs.
+type Config struct {
+	// Interval between two collections of the fleet, zero disables them.
+	Interval time.Duration
+	// Workers caps the number of devices read at once.
+	Workers int
+}
+
+// UseCase -.
+type UseCase struct {
+	repo    Repository
+	devices Devices
+	fleet   Fleet
+	log     logger.Interface
+	cfg     Config
+	now     func() time.Time
+}
+
+// New -.
+func New(r Repository, d Devices, f Fleet, log logger.Interface, cfg Config) *UseCase {
+	if cfg.Workers <= 0 {
+		cfg.Workers = 1
+	}
+
+	return &UseCase{
+		repo:    r,
+		devices: d,
+		fleet:   f,
+		log:     log,
+		cfg:     cfg,
+		now:     time.Now,
+	}
+}
+
+var (
+	ErrAMTAuditUseCase = consoleerrors.CreateConsoleError("AMTAuditUseCase")
+	ErrDatabase        = sqldb.DatabaseError{Console: ErrAMTAuditUseCase}
+)
+
+func (uc *UseCase) GetCount(ctx context.Context, filter dto.AMTAuditFilter) (int, error) {
+	count, err := uc.repo.GetCount(ctx, filterToEntity(filter))
+	if err != nil {
+		return 0, ErrDatabase.Wrap("GetCount", "uc.repo.GetCount", err)
+	}
+
+	return count, nil
+}
+
+// Get returns the collected records matching filter, newest first.
+func (uc *UseCase) Get(ctx context.Context, filter dto.AMTAuditFilter, top, skip int) ([]dto.AMTAuditRecord, error) {
+	data, err := uc.repo.Get(ctx, filterToEntity(filter), top, skip)
+	if err != nil {
+		return nil, ErrDatabase.Wrap("Get", "uc.repo.Get", err)
+	}
+
+	d1 := make([]dto.AMTAuditRecord, len(data))
+
+	for i := range data {
+		d1[i] = *recordToDTO(&data[i])
+	}
+
+	return d1, nil
+}
+
+// GetCursors returns how far the audit log of each device of a tenant has been collected.
+func (uc *UseCase) GetCursors(ctx context.Context, top, skip int, tenantID string) ([]dto.AMTAuditCursor, error) {
+	data, err := uc.repo.GetCursors(ctx, top, skip, tenantID)
+	if err != nil {
+		return nil, ErrDatabase.Wrap("GetCursors", "uc.repo.GetCursors", err)
+	}
+
+	d1 := make([]dto.AMTAuditCursor, len(data))
+
+	for i := range data {
+		d1[i] = *cursorToDTO(&data[i])
+	}
+
+	return d1, nil
+}
+
+// convert entity.AMTAuditRecord to dto.AMTAuditRecord.
+func recordToDTO(d *entity.AMTAuditRecord) *dto.AMTAuditRecord {
+	d1 := &dto.AMTAuditRecord{
+		GUID:           d.GUID,
+		AuditAppID:     d.AuditAppID,
+		AuditApp:       d.AuditApp,
+		EventID:        d.EventID,
+		Event:          d.Event,
+		InitiatorType:  d.InitiatorType,
+		Initiator:      d.Initiator,
+		MCLocationType: d.MCLocationType,
+		NetAddress:     d.NetAddress,
+		ExtendedData:   d.ExtendedData,
+		Description:    d.Description,
+		TenantID:       d.TenantID,
+	}
+
+	d1.Time, _ = time.Parse(time.RFC3339, d.Time)
+	d1.CollectedAt, _ = time.Parse(time.RFC3339, d.CollectedAt)
+
+	return d1
+}
+
+// convert entity.AMTAuditCursor to dto.AMTAuditCursor.
+func cursorToDTO(d *entity.AMTAuditCursor) *dto.AMTAuditCursor {
+	d1 := &dto.AMTAuditCursor{
+		GUID:      d.GUID,
+		LastIndex: d.LastIndex,
+		Collected: d.Collected,
+		Error:     d.Error,
+		TenantID:  d.TenantID,
+	}
+
+	d1.LastTime, _ = time.Parse(time.RFC3339, d.LastTime)
+	d1.CollectedAt, _ = time.Parse(time.RFC3339, d.CollectedAt)
+
+	return d1
+}
+
+func filterToEntity(f dto.AMTAuditFilter) entity.AMTAuditFilter {
+	f1 := entity.AMTAuditFilter{
+		GUID:      f.GUID,
+		AuditApp:  f.AuditApp,
+		Event:     f.Event,
+		Initiator: f.Initiator,
+		TenantID:  f.TenantID,
+	}
+
+	if f.From != nil {
+		f1.From = formatTime(*f.From)
+	}
+
+	if f.To != nil {
+		f1.To = formatTime(*f.To)
+	}
+
+	return f1
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
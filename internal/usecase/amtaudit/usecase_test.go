@@ -0,0 +1,278 @@
+package amtaudit_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/open-amt-cloud-toolkit/go-wsman-messages/v2/pkg/wsman/amt/auditlog"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity"
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	"github.com/open-amt-cloud-toolkit/console/internal/mocks"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/amtaudit"
+	"github.com/open-amt-cloud-toolkit/console/pkg/logger"
+	"github.com/open-amt-cloud-toolkit/console/pkg/tenant"
+)
+
+const pageSize = 2
+
+var errUnreachable = errors.New("device unreachable")
+
+type auditTest struct {
+	uc      *amtaudit.UseCase
+	repo    *mocks.MockAMTAuditRepository
+	devices *mocks.MockAMTAuditDevices
+	fleet   *mocks.MockAMTAuditFleet
+}
+
+func initAuditTest(t *testing.T) auditTest {
+	t.Helper()
+
+	mockCtl := gomock.NewController(t)
+	repo := mocks.NewMockAMTAuditRepository(mockCtl)
+	d := mocks.NewMockAMTAuditDevices(mockCtl)
+	f := mocks.NewMockAMTAuditFleet(mockCtl)
+
+	return auditTest{
+		uc:      amtaudit.New(repo, d, f, logger.New("error"), amtaudit.Config{Workers: 2}),
+		repo:    repo,
+		devices: d,
+		fleet:   f,
+	}
+}
+
+// store keeps the records and cursors the use case writes, the way the repository would.
+type store struct {
+	mu      sync.Mutex
+	records map[string]entity.AMTAuditRecord
+	order   []string
+	cursor  *entity.AMTAuditCursor
+}
+
+func expectStore(repo *mocks.MockAMTAuditRepository) *store {
+	s := &store{records: map[string]entity.AMTAuditRecord{}}
+
+	repo.EXPECT().GetCursor(gomock.Any(), "guid1", "tenant1").AnyTimes().DoAndReturn(func(_ context.Context, _, _ string) (*entity.AMTAuditCursor, error) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if s.cursor == nil {
+			return nil, nil
+		}
+
+		c := *s.cursor
+
+		return &c, nil
+	})
+	repo.EXPECT().UpsertCursor(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(func(_ context.Context, c *entity.AMTAuditCursor) error {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		stored := *c
+		s.cursor = &stored
+
+		return nil
+	})
+	repo.EXPECT().Insert(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(func(_ context.Context, records []entity.AMTAuditRecord) (int, error) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		inserted := 0
+
+		for i := range records {
+			if _, ok := s.records[records[i].Fingerprint]; !ok {
+				s.records[records[i].Fingerprint] = records[i]
+				s.order = append(s.order, records[i].Event)
+				inserted++
+			}
+		}
+
+		return inserted, nil
+	})
+
+	return s
+}
+
+func record(n int) auditlog.AuditLogRecord {
+	return auditlog.AuditLogRecord{
+		AuditAppID: 16,
+		EventID:    n,
+		AuditApp:   "Security Admin",
+		Event:      "event " + string(rune('a'+n)),
+		Initiator:  "admin",
+		Time:       time.Date(2024, 12, 1, n, 0, 0, 0, time.UTC),
+	}
+}
+
+func records(from, to int) []auditlog.AuditLogRecord {
+	log := make([]auditlog.AuditLogRecord, 0, to-from+1)
+	for n := from; n <= to; n++ {
+		log = append(log, record(n))
+	}
+
+	return log
+}
+
+// expectLog serves log, oldest first, in pages of pageSize decoded newest first like AMT's.
+func expectLog(d *mocks.MockAMTAuditDevices, log []auditlog.AuditLogRecord, failAt int) *[]int {
+	reads := &[]int{}
+
+	d.EXPECT().GetAuditLog(gomock.Any(), gomock.Any(), "guid1").AnyTimes().DoAndReturn(func(ctx context.Context, startIndex int, _ string) (dto.AuditLog, error) {
+		if tenant.FromContext(ctx) != "tenant1" {
+			return dto.AuditLog{}, errUnreachable
+		}
+
+		*reads = append(*reads, startIndex)
+
+		if startIndex == failAt {
+			return dto.AuditLog{}, errUnreachable
+		}
+
+		start := min(startIndex-1, len(log))
+		page := slices.Clone(log[start:min(start+pageSize, len(log))])
+		slices.Reverse(page)
+
+		return dto.AuditLog{TotalCount: len(log), Records: page}, nil
+	})
+
+	return reads
+}
+
+func TestCollect(t *testing.T) {
+	t.Parallel()
+
+	test := initAuditTest(t)
+	s := expectStore(test.repo)
+
+	reads := expectLog(test.devices, records(1, 5), 0)
+
+	cursor, err := test.uc.Collect(context.Background(), "guid1", "tenant1")
+	require.NoError(t, err)
+	require.Equal(t, []int{1, 3, 5}, *reads)
+	require.Equal(t, 5, cursor.LastIndex)
+	require.Equal(t, 5, cursor.Collected)
+	require.Equal(t, record(5).Time, cursor.LastTime)
+	require.Equal(t, []string{"event b", "event c", "event d", "event e", "event f"}, s.order)
+}
+
+// collectOnce collects log into repo with a device of its own, as an earlier run of the collector would.
+func collectOnce(t *testing.T, repo *mocks.MockAMTAuditRepository, log []auditlog.AuditLogRecord) {
+	t.Helper()
+
+	d := mocks.NewMockAMTAuditDevices(gomock.NewController(t))
+	expectLog(d, log, 0)
+
+	_, err := amtaudit.New(repo, d, nil, logger.New("error"), amtaudit.Config{}).Collect(context.Background(), "guid1", "tenant1")
+	require.NoError(t, err)
+}
+
+func TestCollectResumesFromCursor(t *testing.T) {
+	t.Parallel()
+
+	test := initAuditTest(t)
+	s := expectStore(test.repo)
+
+	collectOnce(t, test.repo, records(1, 5))
+
+	// the device logged two more records since
+	reads := expectLog(test.devices, records(1, 7), 0)
+
+	cursor, err := test.uc.Collect(context.Background(), "guid1", "tenant1")
+	require.NoError(t, err)
+
+	// the record at the cursor is read again to check it is still the one last seen
+	require.Equal(t, []int{5, 7}, *reads)
+	require.Equal(t, 7, cursor.LastIndex)
+	require.Equal(t, 2, cursor.Collected)
+	require.Len(t, s.order, 7)
+}
+
+func TestCollectAfterLogWrapped(t *testing.T) {
+	t.Parallel()
+
+	test := initAuditTest(t)
+	s := expectStore(test.repo)
+
+	collectOnce(t, test.repo, records(1, 5))
+
+	// the device dropped its two oldest records to make room for four new ones
+	reads := expectLog(test.devices, records(3, 9), 0)
+
+	cursor, err := test.uc.Collect(context.Background(), "guid1", "tenant1")
+	require.NoError(t, err)
+	require.Equal(t, []int{5, 1, 3, 5, 7}, *reads)
+	require.Equal(t, 7, cursor.LastIndex)
+	require.Equal(t, 4, cursor.Collected)
+	require.Equal(t, record(9).Time, cursor.LastTime)
+	require.Len(t, s.order, 9)
+}
+
+func TestCollectKeepsProgressOnFailure(t *testing.T) {
+	t.Parallel()
+
+	test := initAuditTest(t)
+	s := expectStore(test.repo)
+
+	expectLog(test.devices, records(1, 5), 3)
+
+	_, err := test.uc.Collect(context.Background(), "guid1", "tenant1")
+	require.ErrorIs(t, err, errUnreachable)
+
+	require.Len(t, s.order, 2)
+	require.Equal(t, 2, s.cursor.LastIndex)
+	require.Equal(t, errUnreachable.Error(), s.cursor.Error)
+}
+
+func TestCollectUnknownDevice(t *testing.T) {
+	t.Parallel()
+
+	test := initAuditTest(t)
+
+	test.repo.EXPECT().GetCursor(gomock.Any(), "guid1", "tenant1").Return(nil, nil)
+	test.devices.EXPECT().GetAuditLog(gomock.Any(), 1, "guid1").Return(dto.AuditLog{}, errUnreachable)
+
+	// no cursor is stored for a device that never answered
+	_, err := test.uc.Collect(context.Background(), "guid1", "tenant1")
+	require.ErrorIs(t, err, errUnreachable)
+}
+
+func TestCollectAll(t *testing.T) {
+	t.Parallel()
+
+	test := initAuditTest(t)
+	s := expectStore(test.repo)
+
+	expectLog(test.devices, records(1, 3), 0)
+
+	test.fleet.EXPECT().GetAllTenants(gomock.Any(), 100, 0).Return([]entity.Device{
+		{GUID: "guid1", TenantID: "tenant1", ConnectionStatus: true},
+		{GUID: "guid2", TenantID: "tenant1"},
+	}, nil)
+
+	test.uc.CollectAll(context.Background())
+
+	// the disconnected device is left for the next collection
+	require.Len(t, s.order, 3)
+}
+
+func TestGet(t *testing.T) {
+	t.Parallel()
+
+	test := initAuditTest(t)
+
+	from := time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC)
+
+	test.repo.EXPECT().Get(gomock.Any(), entity.AMTAuditFilter{Initiator: "admin", From: "2024-12-01T00:00:00Z", TenantID: "tenant1"}, 10, 0).
+		Return([]entity.AMTAuditRecord{{GUID: "guid1", Event: "Provisioning Started", Time: "2024-12-01T01:00:00Z", TenantID: "tenant1"}}, nil)
+
+	items, err := test.uc.Get(context.Background(), dto.AMTAuditFilter{Initiator: "admin", From: &from, TenantID: "tenant1"}, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	require.Equal(t, time.Date(2024, 12, 1, 1, 0, 0, 0, time.UTC), items[0].Time)
+}
@@ -1,9 +1,6 @@
 package amtexplorer
 
 import (
-	"sync"
-	"time"
-
 	"github.com/open-amt-cloud-toolkit/go-wsman-messages/v2/pkg/security"
 	"github.com/open-amt-cloud-toolkit/go-wsman-messages/v2/pkg/wsman"
 	"github.com/open-amt-cloud-toolkit/go-wsman-messages/v2/pkg/wsman/client"
@@ -14,73 +11,50 @@ import (
 	"github.com/open-amt-cloud-toolkit/console/pkg/logger"
 )
 
-var (
-	connections   = make(map[string]*wsmanAPI.ConnectionEntry)
-	connectionsMu sync.Mutex
-	expireAfter   = 5 * time.Minute // Set the expiration duration as needed
-)
-
 type GoWSMANMessages struct {
 	log              logger.Interface
 	safeRequirements security.Cryptor
+	pool             *wsmanAPI.ConnectionPool
 }
 
-func NewGoWSMANMessages(log logger.Interface, safeRequirements security.Cryptor) *GoWSMANMessages {
+func NewGoWSMANMessages(log logger.Interface, safeRequirements security.Cryptor, pool *wsmanAPI.ConnectionPool) *GoWSMANMessages {
 	return &GoWSMANMessages{
 		log:              log,
 		safeRequirements: safeRequirements,
+		pool:             pool,
 	}
 }
 
 func (g GoWSMANMessages) DestroyWsmanClient(device dto.Device) {
-	if entry, ok := connections[device.GUID]; ok {
-		entry.Timer.Stop()
-		removeConnection(device.GUID)
-	}
+	g.pool.Remove(device.GUID)
 }
 
 func (g GoWSMANMessages) SetupWsmanClient(device entity.Device, logAMTMessages bool) AMTExplorer {
-	clientParams := client.Parameters{
-		Target:            device.Hostname,
-		Username:          device.Username,
-		UseDigest:         true,
-		UseTLS:            device.UseTLS,
-		SelfSignedAllowed: device.AllowSelfSigned,
-		LogAMTMessages:    logAMTMessages,
-		IsRedirection:     false,
-	}
-
-	if device.CertHash != nil {
-		clientParams.PinnedCert = *device.CertHash
-	}
+	entry, pending := g.pool.Get(device.GUID, func() *wsmanAPI.ConnectionEntry {
+		clientParams := client.Parameters{
+			Target:            device.Hostname,
+			Username:          device.Username,
+			UseDigest:         true,
+			UseTLS:            device.UseTLS,
+			SelfSignedAllowed: device.AllowSelfSigned,
+			LogAMTMessages:    logAMTMessages,
+			IsRedirection:     false,
+		}
 
-	clientParams.Password, _ = g.safeRequirements.Decrypt(device.Password)
+		if device.CertHash != nil {
+			clientParams.PinnedCert = *device.CertHash
+		}
 
-	connectionsMu.Lock()
-	defer connectionsMu.Unlock()
+		clientParams.Password, _ = g.safeRequirements.Decrypt(device.Password)
 
-	if entry, ok := connections[device.GUID]; ok {
-		entry.Timer.Stop() // Stop the previous timer
-		entry.Timer = time.AfterFunc(expireAfter, func() {
-			removeConnection(device.GUID)
-		})
-	} else {
-		wsmanMsgs := wsman.NewMessages(clientParams)
-		timer := time.AfterFunc(expireAfter, func() {
-			removeConnection(device.GUID)
-		})
-		connections[device.GUID] = &wsmanAPI.ConnectionEntry{
-			WsmanMessages: wsmanMsgs,
-			Timer:         timer,
+		return &wsmanAPI.ConnectionEntry{
+			WsmanMessages: wsman.NewMessages(clientParams),
 		}
-	}
+	})
 
-	return connections[device.GUID]
-}
-
-func removeConnection(guid string) {
-	connectionsMu.Lock()
-	defer connectionsMu.Unlock()
+	if pending {
+		g.pool.WaitForAuth(entry)
+	}
 
-	delete(connections, guid)
+	return entry
 }
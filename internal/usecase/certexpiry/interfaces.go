@@ -0,0 +1,46 @@
+package certexpiry
+
+import (
+	"context"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity"
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+)
+
+type (
+	Repository interface {
+		GetCount(ctx context.Context, filter entity.TrackedCertificateFilter) (int, error)
+		Get(ctx context.Context, filter entity.TrackedCertificateFilter, top, skip int) ([]entity.TrackedCertificate, error)
+		GetByOwner(ctx context.Context, source, owner, tenantID string) ([]entity.TrackedCertificate, error)
+		ReplaceOwner(ctx context.Context, source, owner, tenantID string, certs []entity.TrackedCertificate) error
+		Prune(ctx context.Context, checkedBefore string) (int, error)
+	}
+	Feature interface {
+		GetCount(ctx context.Context, filter dto.TrackedCertificateFilter) (int, error)
+		Get(ctx context.Context, filter dto.TrackedCertificateFilter, top, skip int) ([]dto.TrackedCertificate, error)
+		CheckDevice(ctx context.Context, guid, tenantID string) ([]dto.TrackedCertificate, error)
+		Run(ctx context.Context)
+	}
+	// Devices is the part of the device management feature the certificates of a device are read with.
+	Devices interface {
+		GetDeviceCertificate(ctx context.Context, guid string) (dto.Certificate, error)
+		GetCertificates(ctx context.Context, guid string) (dto.SecuritySettings, error)
+	}
+	// Fleet pages through the devices of every tenant for the periodic check.
+	Fleet interface {
+		GetAllTenants(ctx context.Context, top, skip int) ([]entity.Device, error)
+		GetByID(ctx context.Context, guid, tenantID string) (*entity.Device, error)
+	}
+	// Domains pages through the domain profiles of every tenant.
+	Domains interface {
+		GetAllTenants(ctx context.Context, top, skip int) ([]entity.Domain, error)
+	}
+	// CIRAConfigs pages through the CIRA configs of every tenant.
+	CIRAConfigs interface {
+		GetAllTenants(ctx context.Context, top, skip int) ([]entity.CIRAConfig, error)
+	}
+	// Publisher puts expiration warnings on the events stream the webhooks deliver from.
+	Publisher interface {
+		Publish(ctx context.Context, e dto.Event)
+	}
+)
@@ -0,0 +1,419 @@
+package certexpiry
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"strings"
+	"sync"
+	"time"
+
+	"software.sslmate.com/src/go-pkcs12"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity"
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	"github.com/open-amt-cloud-toolkit/console/pkg/tenant"
+)
+
+const pageSize = 100
+
+// Run checks the certificates every interval until ctx is done.
+func (uc *UseCase) Run(ctx context.Context) {
+	if uc.cfg.Interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(uc.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		uc.CheckAll(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// CheckAll reads the certificates of every domain, CIRA config and connected device once. The certificates of
+// domains and CIRA configs the check did not see anymore, and those of deleted devices, are forgotten afterwards;
+// those of disconnected devices are kept as last seen.
+func (uc *UseCase) CheckAll(ctx context.Context) {
+	started := uc.now()
+
+	complete := uc.checkDomains(ctx, started)
+	complete = uc.checkCIRAConfigs(ctx, started) && complete
+
+	uc.checkDevices(ctx)
+
+	// a partial check would forget certificates it merely did not get to
+	if !complete || ctx.Err() != nil {
+		return
+	}
+
+	if _, err := uc.repo.Prune(ctx, formatTime(started)); err != nil {
+		uc.log.Error(err, "certexpiry - CheckAll - uc.repo.Prune")
+	}
+}
+
+// checkDomains tracks the certificate of every domain and reports whether it tracked them all, one that
+// could not be tracked does not keep the others from being checked.
+func (uc *UseCase) checkDomains(ctx context.Context, now time.Time) bool {
+	complete := true
+
+	for skip := 0; ; skip += pageSize {
+		page, err := uc.domains.GetAllTenants(ctx, pageSize, skip)
+		if err != nil {
+			uc.log.Error(err, "certexpiry - CheckAll - uc.domains.GetAllTenants")
+
+			return false
+		}
+
+		for i := range page {
+			d := &page[i]
+
+			if _, err := uc.track(ctx, dto.CertificateSourceDomain, d.ProfileName, d.TenantID, uc.domainCertificates(d), now); err != nil {
+				uc.log.Error(err, "certexpiry - CheckAll - "+d.ProfileName)
+
+				complete = false
+			}
+		}
+
+		if len(page) < pageSize {
+			return complete
+		}
+	}
+}
+
+// checkCIRAConfigs tracks the MPS root certificates of every CIRA config like checkDomains.
+func (uc *UseCase) checkCIRAConfigs(ctx context.Context, now time.Time) bool {
+	complete := true
+
+	for skip := 0; ; skip += pageSize {
+		page, err := uc.configs.GetAllTenants(ctx, pageSize, skip)
+		if err != nil {
+			uc.log.Error(err, "certexpiry - CheckAll - uc.configs.GetAllTenants")
+
+			return false
+		}
+
+		for i := range page {
+			c := &page[i]
+
+			certs, err := parseCertificates(c.MPSRootCertificate)
+			if err != nil {
+				uc.log.Warn("certexpiry - CheckAll - " + c.ConfigName + ": " + err.Error())
+			}
+
+			if _, err := uc.track(ctx, dto.CertificateSourceCIRAConfig, c.ConfigName, c.TenantID, certs, now); err != nil {
+				uc.log.Error(err, "certexpiry - CheckAll - "+c.ConfigName)
+
+				complete = false
+			}
+		}
+
+		if len(page) < pageSize {
+			return complete
+		}
+	}
+}
+
+func (uc *UseCase) checkDevices(ctx context.Context) {
+	sem := make(chan struct{}, uc.cfg.Workers)
+
+	var wg sync.WaitGroup
+
+	defer wg.Wait()
+
+	for skip := 0; ; skip += pageSize {
+		page, err := uc.fleet.GetAllTenants(ctx, pageSize, skip)
+		if err != nil {
+			uc.log.Error(err, "certexpiry - CheckAll - uc.fleet.GetAllTenants")
+
+			return
+		}
+
+		for i := range page {
+			if !page[i].ConnectionStatus {
+				continue
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case sem <- struct{}{}:
+			}
+
+			wg.Add(1)
+
+			go func(d entity.Device) {
+				defer func() {
+					<-sem
+					wg.Done()
+				}()
+
+				if _, err := uc.CheckDevice(ctx, d.GUID, d.TenantID); err != nil {
+					uc.log.Debug("certexpiry - CheckAll - " + d.GUID + ": " + err.Error())
+				}
+			}(page[i])
+		}
+
+		if len(page) < pageSize {
+			return
+		}
+	}
+}
+
+// CheckDevice reads the TLS certificate and the trusted root certificates of a device and returns them.
+func (uc *UseCase) CheckDevice(ctx context.Context, guid, tenantID string) ([]dto.TrackedCertificate, error) {
+	// the device management calls find the device in the tenant of ctx
+	ctx = tenant.NewContext(ctx, tenantID)
+
+	d, err := uc.fleet.GetByID(ctx, guid, tenantID)
+	if err != nil {
+		return nil, ErrDatabase.Wrap("CheckDevice", "uc.fleet.GetByID", err)
+	}
+
+	if d == nil {
+		return nil, ErrNotFound
+	}
+
+	now := uc.now()
+
+	// a device no longer using TLS has no certificate to track
+	var tlsCerts []entity.TrackedCertificate
+
+	if d.UseTLS {
+		cert, err := uc.devices.GetDeviceCertificate(ctx, guid)
+		if err != nil {
+			return nil, err
+		}
+
+		tlsCerts = append(tlsCerts, entity.TrackedCertificate{
+			Fingerprint:  cert.SHA256Fingerprint,
+			Subject:      cert.CommonName,
+			Issuer:       cert.IssuerName,
+			SerialNumber: cert.SerialNumber,
+			NotBefore:    formatTime(cert.NotBefore),
+			NotAfter:     formatTime(cert.NotAfter),
+		})
+	}
+
+	settings, err := uc.devices.GetCertificates(ctx, guid)
+	if err != nil {
+		return nil, err
+	}
+
+	var roots []entity.TrackedCertificate
+
+	for _, c := range settings.CertificateResponse.Certificates {
+		if !c.TrustedRootCertificate {
+			continue
+		}
+
+		der, err := base64.StdEncoding.DecodeString(c.X509Certificate)
+		if err != nil {
+			uc.log.Warn("certexpiry - CheckDevice - " + guid + ": " + c.InstanceID + ": " + err.Error())
+
+			continue
+		}
+
+		cert, err := x509.ParseCertificate(der)
+		if err != nil {
+			uc.log.Warn("certexpiry - CheckDevice - " + guid + ": " + c.InstanceID + ": " + err.Error())
+
+			continue
+		}
+
+		roots = append(roots, certificateToEntity(cert))
+	}
+
+	tracked, err := uc.track(ctx, dto.CertificateSourceDeviceTLS, guid, tenantID, tlsCerts, now)
+	if err != nil {
+		return nil, err
+	}
+
+	trackedRoots, err := uc.track(ctx, dto.CertificateSourceDeviceRoot, guid, tenantID, roots, now)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(tracked, trackedRoots...), nil
+}
+
+// track makes certs the certificates of an owner and publishes an event for each one whose status got worse
+// since the last event about it, so that every threshold crossed is announced once.
+func (uc *UseCase) track(ctx context.Context, source, owner, tenantID string, certs []entity.TrackedCertificate, now time.Time) ([]dto.TrackedCertificate, error) {
+	previous, err := uc.repo.GetByOwner(ctx, source, owner, tenantID)
+	if err != nil {
+		return nil, ErrDatabase.Wrap("track", "uc.repo.GetByOwner", err)
+	}
+
+	notified := make(map[string]string, len(previous))
+	for i := range previous {
+		notified[previous[i].Fingerprint] = previous[i].NotifiedStatus
+	}
+
+	// an owner can hold the same certificate twice
+	seen := make(map[string]bool, len(certs))
+	current := make([]entity.TrackedCertificate, 0, len(certs))
+	escalated := make([]bool, 0, len(certs))
+
+	for i := range certs {
+		c := certs[i]
+		if seen[c.Fingerprint] {
+			continue
+		}
+
+		seen[c.Fingerprint] = true
+
+		c.Source = source
+		c.Owner = owner
+		c.TenantID = tenantID
+		c.CheckedAt = formatTime(now)
+
+		notAfter, _ := time.Parse(time.RFC3339, c.NotAfter)
+		c.NotifiedStatus = uc.status(notAfter, now)
+
+		current = append(current, c)
+		escalated = append(escalated, statusRank(c.NotifiedStatus) > statusRank(notified[c.Fingerprint]))
+	}
+
+	if err := uc.repo.ReplaceOwner(ctx, source, owner, tenantID, current); err != nil {
+		return nil, ErrDatabase.Wrap("track", "uc.repo.ReplaceOwner", err)
+	}
+
+	d1 := make([]dto.TrackedCertificate, len(current))
+
+	for i := range current {
+		d1[i] = *uc.certificateToDTO(&current[i], now)
+
+		if escalated[i] {
+			uc.notify(ctx, &d1[i])
+		}
+	}
+
+	return d1, nil
+}
+
+func (uc *UseCase) notify(ctx context.Context, c *dto.TrackedCertificate) {
+	e := dto.Event{
+		Type:     dto.EventCertificateExpiring,
+		TenantID: c.TenantID,
+		Data:     *c,
+	}
+
+	if c.Status == dto.CertificateStatusExpired {
+		e.Type = dto.EventCertificateExpired
+	}
+
+	if c.Source == dto.CertificateSourceDeviceTLS || c.Source == dto.CertificateSourceDeviceRoot {
+		e.GUID = c.Owner
+	}
+
+	uc.publisher.Publish(ctx, e)
+}
+
+// domainCertificates reads the provisioning certificate of a domain. One that does not decode anymore is tracked
+// by the expiration date recorded when it was uploaded.
+func (uc *UseCase) domainCertificates(d *entity.Domain) []entity.TrackedCertificate {
+	cert, err := uc.provisioningCertificate(d)
+	if err == nil {
+		return []entity.TrackedCertificate{certificateToEntity(cert)}
+	}
+
+	uc.log.Warn("certexpiry - CheckAll - " + d.ProfileName + ": " + err.Error())
+
+	notAfter, err := time.Parse(time.RFC3339, d.ExpirationDate)
+	if err != nil {
+		return nil
+	}
+
+	return []entity.TrackedCertificate{{NotAfter: formatTime(notAfter)}}
+}
+
+func (uc *UseCase) provisioningCertificate(d *entity.Domain) (*x509.Certificate, error) {
+	password, err := uc.crypto.Decrypt(d.ProvisioningCertPassword)
+	if err != nil {
+		return nil, err
+	}
+
+	pfxData, err := base64.StdEncoding.DecodeString(d.ProvisioningCert)
+	if err != nil {
+		return nil, err
+	}
+
+	_, cert, _, err := pkcs12.DecodeChain(pfxData, password)
+	if err != nil {
+		return nil, err
+	}
+
+	return cert, nil
+}
+
+// parseCertificates reads PEM encoded certificates, or a single base64 encoded DER certificate as RPS stores them.
+func parseCertificates(data string) ([]entity.TrackedCertificate, error) {
+	var der []byte
+
+	rest := []byte(data)
+
+	for {
+		var block *pem.Block
+
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+
+		if block.Type == "CERTIFICATE" {
+			der = append(der, block.Bytes...)
+		}
+	}
+
+	if der == nil {
+		var err error
+
+		der, err = base64.StdEncoding.DecodeString(strings.TrimSpace(data))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	parsed, err := x509.ParseCertificates(der)
+	if err != nil {
+		return nil, err
+	}
+
+	certs := make([]entity.TrackedCertificate, len(parsed))
+	for i, c := range parsed {
+		certs[i] = certificateToEntity(c)
+	}
+
+	return certs, nil
+}
+
+func certificateToEntity(c *x509.Certificate) entity.TrackedCertificate {
+	fingerprint := sha256.Sum256(c.Raw)
+
+	return entity.TrackedCertificate{
+		Fingerprint:  hex.EncodeToString(fingerprint[:]),
+		Subject:      displayName(c.Subject.CommonName, c.Subject.String()),
+		Issuer:       displayName(c.Issuer.CommonName, c.Issuer.String()),
+		SerialNumber: c.SerialNumber.String(),
+		NotBefore:    formatTime(c.NotBefore),
+		NotAfter:     formatTime(c.NotAfter),
+	}
+}
+
+func displayName(commonName, distinguishedName string) string {
+	if commonName != "" {
+		return commonName
+	}
+
+	return distinguishedName
+}
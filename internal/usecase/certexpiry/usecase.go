@@ -0,0 +1,173 @@
+package certexpiry
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/open-amt-cloud-toolkit/go-wsman-messages/v2/pkg/security"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity"
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/sqldb"
+	"github.com/open-amt-cloud-toolkit/console/pkg/consoleerrors"
+	"github.com/open-amt-cloud-toolkit/console/pkg/logger"
+)
+
+const day = 24 * time.Hour
+
+// Config controls the periodic check of the certificates of the console and the fleet.
+type Config struct {
+	// Interval between two checks, zero disables them.
+	Interval time.Duration
+	// Workers caps the number of devices read at once.
+	Workers int
+	// Warning is how long before it expires a certificate is reported, 30 days when zero.
+	Warning time.Duration
+	// Critical is how long before it expires a certificate is reported as critical, 7 days when zero.
+	Critical time.Duration
+}
+
+// UseCase -.
+type UseCase struct {
+	repo      Repository
+	devices   Devices
+	fleet     Fleet
+	domains   Domains
+	configs   CIRAConfigs
+	publisher Publisher
+	crypto    security.Cryptor
+	log       logger.Interface
+	cfg       Config
+	now       func() time.Time
+}
+
+// New -.
+func New(r Repository, d Devices, f Fleet, domains Domains, configs CIRAConfigs, p Publisher, crypto security.Cryptor, log logger.Interface, cfg Config) *UseCase {
+	if cfg.Workers <= 0 {
+		cfg.Workers = 1
+	}
+
+	if cfg.Warning <= 0 {
+		cfg.Warning = 30 * day
+	}
+
+	if cfg.Critical <= 0 {
+		cfg.Critical = 7 * day
+	}
+
+	return &UseCase{
+		repo:      r,
+		devices:   d,
+		fleet:     f,
+		domains:   domains,
+		configs:   configs,
+		publisher: p,
+		crypto:    crypto,
+		log:       log,
+		cfg:       cfg,
+		now:       time.Now,
+	}
+}
+
+var (
+	ErrCertExpiryUseCase = consoleerrors.CreateConsoleError("CertExpiryUseCase")
+	ErrDatabase          = sqldb.DatabaseError{Console: ErrCertExpiryUseCase}
+	ErrNotFound          = sqldb.NotFoundError{Console: ErrCertExpiryUseCase}
+)
+
+func (uc *UseCase) GetCount(ctx context.Context, filter dto.TrackedCertificateFilter) (int, error) {
+	count, err := uc.repo.GetCount(ctx, uc.filterToEntity(filter))
+	if err != nil {
+		return 0, ErrDatabase.Wrap("GetCount", "uc.repo.GetCount", err)
+	}
+
+	return count, nil
+}
+
+// Get returns the tracked certificates that expire within the days of filter, the first to expire first.
+func (uc *UseCase) Get(ctx context.Context, filter dto.TrackedCertificateFilter, top, skip int) ([]dto.TrackedCertificate, error) {
+	data, err := uc.repo.Get(ctx, uc.filterToEntity(filter), top, skip)
+	if err != nil {
+		return nil, ErrDatabase.Wrap("Get", "uc.repo.Get", err)
+	}
+
+	now := uc.now()
+	d1 := make([]dto.TrackedCertificate, len(data))
+
+	for i := range data {
+		d1[i] = *uc.certificateToDTO(&data[i], now)
+	}
+
+	return d1, nil
+}
+
+// status tells how close to its expiration a certificate that expires at notAfter is at now.
+func (uc *UseCase) status(notAfter, now time.Time) string {
+	left := notAfter.Sub(now)
+
+	switch {
+	case left <= 0:
+		return dto.CertificateStatusExpired
+	case left <= uc.cfg.Critical:
+		return dto.CertificateStatusCritical
+	case left <= uc.cfg.Warning:
+		return dto.CertificateStatusWarning
+	default:
+		return dto.CertificateStatusOK
+	}
+}
+
+// statusRank orders the statuses from the least to the most urgent.
+func statusRank(status string) int {
+	switch status {
+	case dto.CertificateStatusWarning:
+		return 1
+	case dto.CertificateStatusCritical:
+		return 2
+	case dto.CertificateStatusExpired:
+		return 3
+	default:
+		return 0
+	}
+}
+
+func (uc *UseCase) filterToEntity(f dto.TrackedCertificateFilter) entity.TrackedCertificateFilter {
+	within := uc.cfg.Warning
+	if f.Days != nil {
+		within = time.Duration(*f.Days) * day
+	}
+
+	return entity.TrackedCertificateFilter{
+		Source:         f.Source,
+		Owner:          f.Owner,
+		NotAfterBefore: formatTime(uc.now().Add(within)),
+		TenantID:       f.TenantID,
+	}
+}
+
+// convert entity.TrackedCertificate to dto.TrackedCertificate.
+func (uc *UseCase) certificateToDTO(d *entity.TrackedCertificate, now time.Time) *dto.TrackedCertificate {
+	d1 := &dto.TrackedCertificate{
+		Source:            d.Source,
+		Owner:             d.Owner,
+		Subject:           d.Subject,
+		Issuer:            d.Issuer,
+		SerialNumber:      d.SerialNumber,
+		SHA256Fingerprint: d.Fingerprint,
+		TenantID:          d.TenantID,
+	}
+
+	d1.NotBefore, _ = time.Parse(time.RFC3339, d.NotBefore)
+	d1.NotAfter, _ = time.Parse(time.RFC3339, d.NotAfter)
+	d1.CheckedAt, _ = time.Parse(time.RFC3339, d.CheckedAt)
+
+	d1.DaysLeft = int(math.Floor(d1.NotAfter.Sub(now).Hours() / 24))
+	d1.Status = uc.status(d1.NotAfter, now)
+
+	return d1
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,415 @@
+package certexpiry_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"software.sslmate.com/src/go-pkcs12"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity"
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	"github.com/open-amt-cloud-toolkit/console/internal/mocks"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/certexpiry"
+	"github.com/open-amt-cloud-toolkit/console/pkg/logger"
+)
+
+const day = 24 * time.Hour
+
+var errDatabase = errors.New("database is locked")
+
+type expiryTest struct {
+	uc        *certexpiry.UseCase
+	repo      *mocks.MockCertExpiryRepository
+	devices   *mocks.MockCertExpiryDevices
+	fleet     *mocks.MockCertExpiryFleet
+	domains   *mocks.MockCertExpiryDomains
+	configs   *mocks.MockCertExpiryCIRAConfigs
+	publisher *mocks.MockCertExpiryPublisher
+}
+
+func initExpiryTest(t *testing.T) expiryTest {
+	t.Helper()
+
+	mockCtl := gomock.NewController(t)
+	repo := mocks.NewMockCertExpiryRepository(mockCtl)
+	d := mocks.NewMockCertExpiryDevices(mockCtl)
+	f := mocks.NewMockCertExpiryFleet(mockCtl)
+	domains := mocks.NewMockCertExpiryDomains(mockCtl)
+	configs := mocks.NewMockCertExpiryCIRAConfigs(mockCtl)
+	p := mocks.NewMockCertExpiryPublisher(mockCtl)
+
+	return expiryTest{
+		uc:        certexpiry.New(repo, d, f, domains, configs, p, mocks.MockCrypto{}, logger.New("error"), certexpiry.Config{Workers: 2}),
+		repo:      repo,
+		devices:   d,
+		fleet:     f,
+		domains:   domains,
+		configs:   configs,
+		publisher: p,
+	}
+}
+
+// newCertificate returns a self signed certificate expiring after validFor, and its key.
+func newCertificate(t *testing.T, commonName string, validFor time.Duration) (*x509.Certificate, *ecdsa.PrivateKey) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-365 * day),
+		NotAfter:     time.Now().Add(validFor),
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return cert, key
+}
+
+// store keeps the certificates the use case tracks, the way the repository would.
+type store struct {
+	mu    sync.Mutex
+	certs map[string][]entity.TrackedCertificate
+}
+
+func expectStore(repo *mocks.MockCertExpiryRepository) *store {
+	s := &store{certs: map[string][]entity.TrackedCertificate{}}
+
+	repo.EXPECT().GetByOwner(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(func(_ context.Context, source, owner, tenantID string) ([]entity.TrackedCertificate, error) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		return s.certs[source+"/"+owner+"/"+tenantID], nil
+	})
+	repo.EXPECT().ReplaceOwner(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(func(_ context.Context, source, owner, tenantID string, certs []entity.TrackedCertificate) error {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		s.certs[source+"/"+owner+"/"+tenantID] = certs
+
+		return nil
+	})
+
+	return s
+}
+
+func (s *store) get(key string) []entity.TrackedCertificate {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.certs[key]
+}
+
+// events records what the use case publishes.
+type events struct {
+	mu     sync.Mutex
+	events []dto.Event
+}
+
+func expectEvents(p *mocks.MockCertExpiryPublisher) *events {
+	e := &events{}
+
+	p.EXPECT().Publish(gomock.Any(), gomock.Any()).AnyTimes().Do(func(_ context.Context, event dto.Event) {
+		e.mu.Lock()
+		defer e.mu.Unlock()
+
+		e.events = append(e.events, event)
+	})
+
+	return e
+}
+
+func (e *events) take() []dto.Event {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	taken := e.events
+	e.events = nil
+
+	return taken
+}
+
+func TestCheckAll(t *testing.T) {
+	t.Parallel()
+
+	test := initExpiryTest(t)
+	s := expectStore(test.repo)
+	published := expectEvents(test.publisher)
+
+	provisioning, key := newCertificate(t, "provisioning", 3*day)
+	pfx, err := pkcs12.Modern.Encode(key, provisioning, nil, "decrypted")
+	require.NoError(t, err)
+
+	mpsRoot, _ := newCertificate(t, "mps root", 20*day)
+	deviceTLS, _ := newCertificate(t, "device", 400*day)
+	deviceRoot, _ := newCertificate(t, "device root", -day)
+
+	test.domains.EXPECT().GetAllTenants(gomock.Any(), 100, 0).Times(2).Return([]entity.Domain{
+		{ProfileName: "domain1", ProvisioningCert: base64.StdEncoding.EncodeToString(pfx), ProvisioningCertPassword: "encrypted", TenantID: "tenant1"},
+		{ProfileName: "domain2", ProvisioningCert: "not a pfx", ExpirationDate: "2020-01-01T00:00:00Z", TenantID: "tenant1"},
+	}, nil)
+	test.configs.EXPECT().GetAllTenants(gomock.Any(), 100, 0).Times(2).Return([]entity.CIRAConfig{
+		{ConfigName: "cira1", MPSRootCertificate: string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: mpsRoot.Raw})), TenantID: "tenant1"},
+		{ConfigName: "cira2", MPSRootCertificate: base64.StdEncoding.EncodeToString(mpsRoot.Raw), TenantID: "tenant2"},
+	}, nil)
+	test.fleet.EXPECT().GetAllTenants(gomock.Any(), 100, 0).Times(2).Return([]entity.Device{
+		{GUID: "guid1", TenantID: "tenant1", ConnectionStatus: true},
+		{GUID: "guid2", TenantID: "tenant1"},
+	}, nil)
+	test.fleet.EXPECT().GetByID(gomock.Any(), "guid1", "tenant1").Times(2).Return(&entity.Device{GUID: "guid1", TenantID: "tenant1", UseTLS: true}, nil)
+	test.devices.EXPECT().GetDeviceCertificate(gomock.Any(), "guid1").Times(2).Return(dto.Certificate{
+		CommonName:        "device",
+		SHA256Fingerprint: "tls",
+		NotBefore:         deviceTLS.NotBefore,
+		NotAfter:          deviceTLS.NotAfter,
+	}, nil)
+	test.devices.EXPECT().GetCertificates(gomock.Any(), "guid1").Times(2).Return(dto.SecuritySettings{
+		CertificateResponse: dto.CertificatePullResponse{Certificates: []dto.RefinedCertificate{
+			{InstanceID: "root", X509Certificate: base64.StdEncoding.EncodeToString(deviceRoot.Raw), TrustedRootCertificate: true},
+			{InstanceID: "tls", X509Certificate: base64.StdEncoding.EncodeToString(deviceTLS.Raw)},
+		}},
+	}, nil)
+	test.repo.EXPECT().Prune(gomock.Any(), gomock.Any()).Times(2).Return(0, nil)
+
+	test.uc.CheckAll(context.Background())
+
+	domain := s.get("domain/domain1/tenant1")
+	require.Len(t, domain, 1)
+	require.Equal(t, "provisioning", domain[0].Subject)
+	require.Equal(t, dto.CertificateStatusCritical, domain[0].NotifiedStatus)
+
+	// the provisioning certificate that no longer decodes is tracked by the date recorded at upload
+	require.Equal(t, []entity.TrackedCertificate{{
+		Source: dto.CertificateSourceDomain, Owner: "domain2", NotAfter: "2020-01-01T00:00:00Z",
+		CheckedAt: domain[0].CheckedAt, NotifiedStatus: dto.CertificateStatusExpired, TenantID: "tenant1",
+	}}, s.get("domain/domain2/tenant1"))
+
+	require.Equal(t, dto.CertificateStatusWarning, s.get("ciraConfig/cira1/tenant1")[0].NotifiedStatus)
+	require.Equal(t, s.get("ciraConfig/cira1/tenant1")[0].Fingerprint, s.get("ciraConfig/cira2/tenant2")[0].Fingerprint)
+	require.Equal(t, dto.CertificateStatusOK, s.get("deviceTLS/guid1/tenant1")[0].NotifiedStatus)
+
+	roots := s.get("deviceRoot/guid1/tenant1")
+	require.Len(t, roots, 1)
+	require.Equal(t, "device root", roots[0].Subject)
+	require.Equal(t, dto.CertificateStatusExpired, roots[0].NotifiedStatus)
+
+	first := published.take()
+	require.Len(t, first, 5)
+
+	types := map[string]int{}
+
+	for _, e := range first {
+		types[e.Type]++
+
+		c, ok := e.Data.(dto.TrackedCertificate)
+		require.True(t, ok)
+
+		if c.Source == dto.CertificateSourceDeviceRoot {
+			require.Equal(t, "guid1", e.GUID)
+			require.Equal(t, dto.EventCertificateExpired, e.Type)
+		}
+	}
+
+	require.Equal(t, map[string]int{dto.EventCertificateExpiring: 3, dto.EventCertificateExpired: 2}, types)
+
+	// every threshold is announced once
+	test.uc.CheckAll(context.Background())
+
+	require.Empty(t, published.take())
+}
+
+func TestCheckAllPartial(t *testing.T) {
+	t.Parallel()
+
+	test := initExpiryTest(t)
+
+	test.domains.EXPECT().GetAllTenants(gomock.Any(), 100, 0).Return(nil, errDatabase)
+	test.configs.EXPECT().GetAllTenants(gomock.Any(), 100, 0).Return([]entity.CIRAConfig{}, nil)
+	test.fleet.EXPECT().GetAllTenants(gomock.Any(), 100, 0).Return([]entity.Device{}, nil)
+
+	// nothing is pruned after a check that did not see every domain
+	test.repo.EXPECT().Prune(gomock.Any(), gomock.Any()).Times(0)
+
+	test.uc.CheckAll(context.Background())
+}
+
+func TestCheckAllContinuesPastAFailure(t *testing.T) {
+	t.Parallel()
+
+	test := initExpiryTest(t)
+
+	mpsRoot, _ := newCertificate(t, "mps root", 20*day)
+	root := base64.StdEncoding.EncodeToString(mpsRoot.Raw)
+
+	test.domains.EXPECT().GetAllTenants(gomock.Any(), 100, 0).Return([]entity.Domain{}, nil)
+	test.configs.EXPECT().GetAllTenants(gomock.Any(), 100, 0).Return([]entity.CIRAConfig{
+		{ConfigName: "cira1", MPSRootCertificate: root, TenantID: "tenant1"},
+		{ConfigName: "cira2", MPSRootCertificate: root, TenantID: "tenant1"},
+	}, nil)
+	test.fleet.EXPECT().GetAllTenants(gomock.Any(), 100, 0).Return([]entity.Device{}, nil)
+	test.publisher.EXPECT().Publish(gomock.Any(), gomock.Any()).AnyTimes()
+
+	test.repo.EXPECT().GetByOwner(gomock.Any(), dto.CertificateSourceCIRAConfig, "cira1", "tenant1").Return(nil, errDatabase)
+	test.repo.EXPECT().GetByOwner(gomock.Any(), dto.CertificateSourceCIRAConfig, "cira2", "tenant1").Return(nil, nil)
+	test.repo.EXPECT().ReplaceOwner(gomock.Any(), dto.CertificateSourceCIRAConfig, "cira2", "tenant1", gomock.Len(1)).Return(nil)
+
+	// the certificates of cira1 were not seen, so none are pruned
+	test.repo.EXPECT().Prune(gomock.Any(), gomock.Any()).Times(0)
+
+	test.uc.CheckAll(context.Background())
+}
+
+func TestCheckDevice(t *testing.T) {
+	t.Parallel()
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+
+		test := initExpiryTest(t)
+
+		test.fleet.EXPECT().GetByID(gomock.Any(), "guid1", "tenant1").Return(nil, nil)
+
+		_, err := test.uc.CheckDevice(context.Background(), "guid1", "tenant1")
+		require.IsType(t, certexpiry.ErrNotFound, err)
+	})
+
+	t.Run("without TLS", func(t *testing.T) {
+		t.Parallel()
+
+		test := initExpiryTest(t)
+		s := expectStore(test.repo)
+
+		s.certs["deviceTLS/guid1/tenant1"] = []entity.TrackedCertificate{{Fingerprint: "old"}}
+
+		test.fleet.EXPECT().GetByID(gomock.Any(), "guid1", "tenant1").Return(&entity.Device{GUID: "guid1", TenantID: "tenant1"}, nil)
+		test.devices.EXPECT().GetCertificates(gomock.Any(), "guid1").Return(dto.SecuritySettings{}, nil)
+
+		got, err := test.uc.CheckDevice(context.Background(), "guid1", "tenant1")
+		require.NoError(t, err)
+		require.Empty(t, got)
+		require.Empty(t, s.get("deviceTLS/guid1/tenant1"))
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+
+		test := initExpiryTest(t)
+
+		test.fleet.EXPECT().GetByID(gomock.Any(), "guid1", "tenant1").Return(&entity.Device{GUID: "guid1", TenantID: "tenant1"}, nil)
+		test.devices.EXPECT().GetCertificates(gomock.Any(), "guid1").Return(dto.SecuritySettings{}, nil)
+		test.repo.EXPECT().GetByOwner(gomock.Any(), dto.CertificateSourceDeviceTLS, "guid1", "tenant1").Return(nil, errDatabase)
+
+		_, err := test.uc.CheckDevice(context.Background(), "guid1", "tenant1")
+		require.IsType(t, certexpiry.ErrDatabase, err)
+	})
+}
+
+func TestGet(t *testing.T) {
+	t.Parallel()
+
+	days := 90
+
+	tests := []struct {
+		name     string
+		filter   dto.TrackedCertificateFilter
+		within   time.Duration
+		notAfter time.Duration
+		status   string
+		daysLeft int
+	}{
+		{
+			name:     "warning threshold by default",
+			filter:   dto.TrackedCertificateFilter{TenantID: "tenant1"},
+			within:   30 * day,
+			notAfter: 20*day + time.Hour,
+			status:   dto.CertificateStatusWarning,
+			daysLeft: 20,
+		},
+		{
+			name:     "days",
+			filter:   dto.TrackedCertificateFilter{Days: &days, TenantID: "tenant1"},
+			within:   90 * day,
+			notAfter: 60*day + time.Hour,
+			status:   dto.CertificateStatusOK,
+			daysLeft: 60,
+		},
+		{
+			name:     "critical",
+			filter:   dto.TrackedCertificateFilter{TenantID: "tenant1"},
+			within:   30 * day,
+			notAfter: 2*day + time.Hour,
+			status:   dto.CertificateStatusCritical,
+			daysLeft: 2,
+		},
+		{
+			name:     "expired",
+			filter:   dto.TrackedCertificateFilter{TenantID: "tenant1"},
+			within:   30 * day,
+			notAfter: -time.Hour,
+			status:   dto.CertificateStatusExpired,
+			daysLeft: -1,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			test := initExpiryTest(t)
+			now := time.Now()
+
+			test.repo.EXPECT().Get(context.Background(), gomock.Any(), 25, 0).DoAndReturn(func(_ context.Context, filter entity.TrackedCertificateFilter, _, _ int) ([]entity.TrackedCertificate, error) {
+				before, err := time.Parse(time.RFC3339, filter.NotAfterBefore)
+				require.NoError(t, err)
+				require.WithinDuration(t, now.Add(tc.within), before, time.Minute)
+				require.Equal(t, "tenant1", filter.TenantID)
+
+				return []entity.TrackedCertificate{{
+					Source:    dto.CertificateSourceDomain,
+					Owner:     "domain1",
+					NotAfter:  now.Add(tc.notAfter).UTC().Format(time.RFC3339),
+					CheckedAt: now.UTC().Format(time.RFC3339),
+					TenantID:  "tenant1",
+				}}, nil
+			})
+
+			got, err := test.uc.Get(context.Background(), tc.filter, 25, 0)
+			require.NoError(t, err)
+			require.Len(t, got, 1)
+			require.Equal(t, tc.status, got[0].Status)
+			require.Equal(t, tc.daysLeft, got[0].DaysLeft)
+		})
+	}
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+
+		test := initExpiryTest(t)
+
+		test.repo.EXPECT().GetCount(context.Background(), gomock.Any()).Return(0, errDatabase)
+
+		_, err := test.uc.GetCount(context.Background(), dto.TrackedCertificateFilter{})
+		require.IsType(t, certexpiry.ErrDatabase, err)
+	})
+}
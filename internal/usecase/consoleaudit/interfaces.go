@@ -0,0 +1,21 @@
+package consoleaudit
+
+import (
+	"context"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity"
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+)
+
+type (
+	Repository interface {
+		GetCount(ctx context.Context, filter entity.ConsoleAuditFilter) (int, error)
+		Get(ctx context.Context, filter entity.ConsoleAuditFilter, top, skip int) ([]entity.ConsoleAuditEntry, error)
+		Insert(ctx context.Context, e *entity.ConsoleAuditEntry) error
+	}
+	Feature interface {
+		Record(ctx context.Context, e *dto.ConsoleAuditEntry) error
+		GetCount(ctx context.Context, filter dto.ConsoleAuditFilter) (int, error)
+		Get(ctx context.Context, filter dto.ConsoleAuditFilter, top, skip int) ([]dto.ConsoleAuditEntry, error)
+	}
+)
@@ -0,0 +1,200 @@
+package consoleaudit
+
+import (
+	"context"
+	"encoding/json"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity"
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/sqldb"
+	"github.com/open-amt-cloud-toolkit/console/pkg/consoleerrors"
+	"github.com/open-amt-cloud-toolkit/console/pkg/logger"
+)
+
+const (
+	maxSummaryLength = 4096
+	redacted         = "[REDACTED]"
+)
+
+// secretField matches the names of request fields whose values must never reach the audit trail,
+// such as amtPassword, provisioningCertPassword, pskPassphrase, secret, a base64 certificate or the
+// consentCode shown on a device's screen.
+var secretField = regexp.MustCompile(`(?i)pass|secret|token|key|cert$|psk|consent|code`)
+
+// UseCase -.
+type UseCase struct {
+	repo Repository
+	log  logger.Interface
+}
+
+// New -.
+func New(r Repository, log logger.Interface) *UseCase {
+	return &UseCase{
+		repo: r,
+		log:  log,
+	}
+}
+
+var (
+	ErrConsoleAuditUseCase = consoleerrors.CreateConsoleError("ConsoleAuditUseCase")
+	ErrDatabase            = sqldb.DatabaseError{Console: ErrConsoleAuditUseCase}
+)
+
+// Record stores e with its summary redacted.
+func (uc *UseCase) Record(ctx context.Context, e *dto.ConsoleAuditEntry) error {
+	d1 := dtoToEntity(e)
+	d1.ID = uuid.New().String()
+	d1.Summary = redact(e.Summary)
+
+	if e.Time.IsZero() {
+		d1.Time = formatTime(time.Now())
+	}
+
+	if err := uc.repo.Insert(ctx, d1); err != nil {
+		return ErrDatabase.Wrap("Record", "uc.repo.Insert", err)
+	}
+
+	return nil
+}
+
+func (uc *UseCase) GetCount(ctx context.Context, filter dto.ConsoleAuditFilter) (int, error) {
+	count, err := uc.repo.GetCount(ctx, filterToEntity(filter))
+	if err != nil {
+		return 0, ErrDatabase.Wrap("GetCount", "uc.repo.GetCount", err)
+	}
+
+	return count, nil
+}
+
+func (uc *UseCase) Get(ctx context.Context, filter dto.ConsoleAuditFilter, top, skip int) ([]dto.ConsoleAuditEntry, error) {
+	data, err := uc.repo.Get(ctx, filterToEntity(filter), top, skip)
+	if err != nil {
+		return nil, ErrDatabase.Wrap("Get", "uc.repo.Get", err)
+	}
+
+	d1 := make([]dto.ConsoleAuditEntry, len(data))
+
+	for i := range data {
+		tmpEntity := data[i] // create a new variable to avoid memory aliasing
+		d1[i] = *entityToDTO(&tmpEntity)
+	}
+
+	return d1, nil
+}
+
+// redact masks the secret fields of a JSON request body, anything else is reduced to its size.
+func redact(summary string) string {
+	if summary == "" {
+		return ""
+	}
+
+	var body interface{}
+	if err := json.Unmarshal([]byte(summary), &body); err != nil {
+		return "[" + strconv.Itoa(len(summary)) + " bytes]"
+	}
+
+	data, err := json.Marshal(redactValue(body))
+	if err != nil {
+		return "[" + strconv.Itoa(len(summary)) + " bytes]"
+	}
+
+	if len(data) > maxSummaryLength {
+		return string(data[:maxSummaryLength]) + "..."
+	}
+
+	return string(data)
+}
+
+func redactValue(v interface{}) interface{} {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		for k, field := range value {
+			if secretField.MatchString(k) {
+				value[k] = redacted
+			} else {
+				value[k] = redactValue(field)
+			}
+		}
+	case []interface{}:
+		for i := range value {
+			value[i] = redactValue(value[i])
+		}
+	}
+
+	return v
+}
+
+// convert dto.ConsoleAuditEntry to entity.ConsoleAuditEntry.
+func dtoToEntity(d *dto.ConsoleAuditEntry) *entity.ConsoleAuditEntry {
+	d1 := &entity.ConsoleAuditEntry{
+		ID:         d.ID,
+		Subject:    d.Subject,
+		Method:     d.Method,
+		Route:      d.Route,
+		Path:       d.Path,
+		Target:     d.Target,
+		Summary:    d.Summary,
+		StatusCode: d.StatusCode,
+		Result:     d.Result,
+		DurationMS: d.DurationMS,
+		ClientIP:   d.ClientIP,
+		TenantID:   d.TenantID,
+	}
+
+	if !d.Time.IsZero() {
+		d1.Time = formatTime(d.Time)
+	}
+
+	return d1
+}
+
+// convert entity.ConsoleAuditEntry to dto.ConsoleAuditEntry.
+func entityToDTO(d *entity.ConsoleAuditEntry) *dto.ConsoleAuditEntry {
+	d1 := &dto.ConsoleAuditEntry{
+		ID:         d.ID,
+		Subject:    d.Subject,
+		Method:     d.Method,
+		Route:      d.Route,
+		Path:       d.Path,
+		Target:     d.Target,
+		Summary:    d.Summary,
+		StatusCode: d.StatusCode,
+		Result:     d.Result,
+		DurationMS: d.DurationMS,
+		ClientIP:   d.ClientIP,
+		TenantID:   d.TenantID,
+	}
+
+	if t, err := time.Parse(time.RFC3339, d.Time); err == nil {
+		d1.Time = t
+	}
+
+	return d1
+}
+
+func filterToEntity(f dto.ConsoleAuditFilter) entity.ConsoleAuditFilter {
+	f1 := entity.ConsoleAuditFilter{
+		Subject:  f.Subject,
+		Target:   f.Target,
+		TenantID: f.TenantID,
+	}
+
+	if f.From != nil {
+		f1.From = formatTime(*f.From)
+	}
+
+	if f.To != nil {
+		f1.To = formatTime(*f.To)
+	}
+
+	return f1
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,114 @@
+package consoleaudit_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity"
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	"github.com/open-amt-cloud-toolkit/console/internal/mocks"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/consoleaudit"
+	"github.com/open-amt-cloud-toolkit/console/pkg/logger"
+)
+
+func TestRecord(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		summary string
+		stored  string
+	}{
+		{
+			name:    "secrets are redacted",
+			summary: `{"profileName":"profile1","amtPassword":"P@ssw0rd","wifiConfigs":[{"profileName":"wifi1","pskPassphrase":"hunter22"}]}`,
+			stored:  `{"amtPassword":"[REDACTED]","profileName":"profile1","wifiConfigs":[{"profileName":"wifi1","pskPassphrase":"[REDACTED]"}]}`,
+		},
+		{
+			name:    "certificates are redacted",
+			summary: `{"profileName":"domain1","provisioningCert":"MIIK...","provisioningCertPassword":"secret","certHash":"abc"}`,
+			stored:  `{"certHash":"abc","profileName":"domain1","provisioningCert":"[REDACTED]","provisioningCertPassword":"[REDACTED]"}`,
+		},
+		{
+			name:    "consent codes are redacted",
+			summary: `{"consentCode":"123456"}`,
+			stored:  `{"consentCode":"[REDACTED]"}`,
+		},
+		{
+			name:    "non json body",
+			summary: "not json",
+			stored:  "[8 bytes]",
+		},
+		{
+			name:    "long body is truncated",
+			summary: `{"description":"` + strings.Repeat("a", 5000) + `"}`,
+			stored:  (`{"description":"` + strings.Repeat("a", 5000))[:4096] + "...",
+		},
+		{
+			name: "no body",
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockCtl := gomock.NewController(t)
+			repo := mocks.NewMockConsoleAuditRepository(mockCtl)
+			useCase := consoleaudit.New(repo, logger.New("error"))
+
+			repo.EXPECT().Insert(context.Background(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, e *entity.ConsoleAuditEntry) error {
+					require.NotEmpty(t, e.ID)
+					require.Equal(t, "2024-01-01T10:00:00Z", e.Time)
+					require.Equal(t, tc.stored, e.Summary)
+
+					return nil
+				})
+
+			err := useCase.Record(context.Background(), &dto.ConsoleAuditEntry{
+				Time:    time.Date(2024, 1, 1, 11, 0, 0, 0, time.FixedZone("CET", 3600)),
+				Subject: "alice",
+				Summary: tc.summary,
+			})
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestGet(t *testing.T) {
+	t.Parallel()
+
+	mockCtl := gomock.NewController(t)
+	repo := mocks.NewMockConsoleAuditRepository(mockCtl)
+	useCase := consoleaudit.New(repo, logger.New("error"))
+
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	repo.EXPECT().Get(context.Background(), entity.ConsoleAuditFilter{Subject: "alice", From: "2024-01-01T00:00:00Z", TenantID: "tenant1"}, 10, 0).
+		Return([]entity.ConsoleAuditEntry{{ID: "1", Time: "2024-01-01T10:00:00Z", Subject: "alice", StatusCode: 204, Result: "success", DurationMS: 12, TenantID: "tenant1"}}, nil)
+
+	res, err := useCase.Get(context.Background(), dto.ConsoleAuditFilter{Subject: "alice", From: &from, TenantID: "tenant1"}, 10, 0)
+	require.NoError(t, err)
+	require.Equal(t, []dto.ConsoleAuditEntry{{
+		ID:         "1",
+		Time:       time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC),
+		Subject:    "alice",
+		StatusCode: 204,
+		Result:     dto.AuditResultSuccess,
+		DurationMS: 12,
+		TenantID:   "tenant1",
+	}}, res)
+
+	repo.EXPECT().GetCount(context.Background(), gomock.Any()).Return(0, consoleaudit.ErrDatabase)
+
+	_, err = useCase.GetCount(context.Background(), dto.ConsoleAuditFilter{})
+	require.IsType(t, consoleaudit.ErrDatabase, err)
+}
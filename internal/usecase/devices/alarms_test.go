@@ -28,13 +28,12 @@ func initAlarmsTest(t *testing.T) (*devices.UseCase, *mocks.MockWSMAN, *mocks.Mo
 	repo := mocks.NewMockDeviceManagementRepository(mockCtl)
 
 	wsmanMock := mocks.NewMockWSMAN(mockCtl)
-	wsmanMock.EXPECT().Worker().Return().AnyTimes()
 
 	management := mocks.NewMockManagement(mockCtl)
 
 	log := logger.New("error")
 
-	u := devices.New(repo, wsmanMock, mocks.NewMockRedirection(mockCtl), log, mocks.MockCrypto{})
+	u := devices.New(repo, wsmanMock, mocks.NewMockRedirection(mockCtl), anyPublisher(mockCtl), nil, nil, log, mocks.MockCrypto{})
 
 	return u, wsmanMock, management, repo
 }
@@ -0,0 +1,408 @@
+package devices
+
+import (
+	"encoding/binary"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+)
+
+// ATAPI packet commands the host of an IDE-R session sends to the emulated drive.
+const (
+	atapiTestUnitReady         = 0x00
+	atapiRequestSense          = 0x03
+	atapiRead6                 = 0x08
+	atapiWrite6                = 0x0A
+	atapiModeSense6            = 0x1A
+	atapiStartStopUnit         = 0x1B
+	atapiPreventAllowRemoval   = 0x1E
+	atapiReadFormatCapacities  = 0x23
+	atapiReadCapacity          = 0x25
+	atapiRead10                = 0x28
+	atapiWrite10               = 0x2A
+	atapiSeek10                = 0x2B
+	atapiSynchronizeCache      = 0x35
+	atapiReadTOC               = 0x43
+	atapiGetConfiguration      = 0x46
+	atapiGetEventStatus        = 0x4A
+	atapiModeSense10           = 0x5A
+	atapiRead12                = 0xA8
+	atapiWrite12               = 0xAA
+	atapiReadCD                = 0xBE
+	atapiCDSectorSize          = 2048
+	atapiFloppySectorSize      = 512
+	atapiCDProfile             = 0x0008
+	atapiLeadOutTrack          = 0xAA
+	atapiDataTrackControl      = 0x14
+	atapiFramesPerSecond       = 75
+	atapiSecondsPerMinute      = 60
+	atapiPregapFrames          = 150
+	atapiModePageErrorRecovery = 0x01
+	atapiModePageFlexibleDisk  = 0x05
+	atapiModePageCapabilities  = 0x2A
+	atapiModePageAll           = 0x3F
+)
+
+// Sense keys and additional sense codes an IDE-R command ends with.
+const (
+	senseNoSense        = 0x00
+	senseNotReady       = 0x02
+	senseMediumError    = 0x03
+	senseIllegalRequest = 0x05
+	senseUnitAttention  = 0x06
+	senseDataProtect    = 0x07
+
+	ascUnrecoveredRead  = 0x11
+	ascInvalidOpcode    = 0x20
+	ascLBAOutOfRange    = 0x21
+	ascInvalidField     = 0x24
+	ascWriteProtected   = 0x27
+	ascMediumChanged    = 0x28
+	ascMediumNotPresent = 0x3A
+)
+
+// The IDE channel devices of an IDE-R session and the geometry reported for floppy images.
+const (
+	iderDeviceMaster     = 0xA0
+	iderDeviceSlave      = 0xB0
+	iderDeviceSlaveFlag  = 0x10
+	floppySectorsPerSide = 18
+	floppyHeads          = 2
+)
+
+// atapiSense is the sense key, additional sense code and qualifier a command failed with.
+type atapiSense [3]byte
+
+// atapiResult is the outcome of a packet command: data for the host, sectors of the media to send it, or the
+// sense telling why the command failed.
+type atapiResult struct {
+	data  []byte
+	read  bool
+	lba   int64
+	count int64
+	sense atapiSense
+}
+
+// atapiDrive emulates the drive of an IDE-R session: a CD-ROM on the slave channel for ISO images or a floppy
+// on the master channel for IMG images. The media is read only, writes fail as write protected.
+type atapiDrive struct {
+	media      IDERMedia
+	device     byte
+	sectorSize int64
+	sectors    int64
+	// attention is set until the host is told that media was inserted.
+	attention bool
+	// newMedia is set until an event status poll reported the media.
+	newMedia bool
+	// sense is what the last failed command reported, for a following request sense.
+	sense atapiSense
+}
+
+func newATAPIDrive(media IDERMedia) *atapiDrive {
+	info := media.Info()
+	d := &atapiDrive{
+		media:      media,
+		device:     iderDeviceSlave,
+		sectorSize: atapiCDSectorSize,
+		attention:  true,
+		newMedia:   true,
+	}
+
+	if info.Kind == dto.MediaKindFloppy {
+		d.device = iderDeviceMaster
+		d.sectorSize = atapiFloppySectorSize
+	}
+
+	// a last partial sector is padded with zeros
+	d.sectors = (info.SizeBytes + d.sectorSize - 1) / d.sectorSize
+
+	return d
+}
+
+func (d *atapiDrive) cd() bool {
+	return d.device == iderDeviceSlave
+}
+
+// command runs the packet command cdb sent to device, the drive on the other channel has no media.
+func (d *atapiDrive) command(device byte, cdb []byte) atapiResult {
+	if device != d.device {
+		if cdb[0] == atapiRequestSense {
+			return atapiResult{data: fixedSense(atapiSense{senseNotReady, ascMediumNotPresent}, int(cdb[4]))}
+		}
+
+		return atapiResult{sense: atapiSense{senseNotReady, ascMediumNotPresent}}
+	}
+
+	result := d.run(cdb)
+	if result.sense[0] != senseNoSense {
+		d.sense = result.sense
+	}
+
+	return result
+}
+
+func (d *atapiDrive) run(cdb []byte) atapiResult {
+	switch cdb[0] {
+	case atapiTestUnitReady:
+		if d.attention {
+			d.attention = false
+
+			return atapiResult{sense: atapiSense{senseUnitAttention, ascMediumChanged}}
+		}
+
+		return atapiResult{}
+	case atapiRequestSense:
+		sense := d.sense
+		d.sense = atapiSense{}
+
+		return atapiResult{data: fixedSense(sense, int(cdb[4]))}
+	case atapiStartStopUnit, atapiPreventAllowRemoval, atapiSeek10, atapiSynchronizeCache:
+		return atapiResult{}
+	case atapiRead6:
+		count := int64(cdb[4])
+		if count == 0 {
+			count = 256
+		}
+
+		return d.read(int64(cdb[1]&0x1F)<<16|int64(cdb[2])<<8|int64(cdb[3]), count)
+	case atapiRead10:
+		return d.read(int64(binary.BigEndian.Uint32(cdb[2:6])), int64(binary.BigEndian.Uint16(cdb[7:9])))
+	case atapiRead12:
+		return d.read(int64(binary.BigEndian.Uint32(cdb[2:6])), int64(binary.BigEndian.Uint32(cdb[6:10])))
+	case atapiReadCD:
+		return d.read(int64(binary.BigEndian.Uint32(cdb[2:6])), int64(cdb[6])<<16|int64(cdb[7])<<8|int64(cdb[8]))
+	case atapiWrite6, atapiWrite10, atapiWrite12:
+		return atapiResult{sense: atapiSense{senseDataProtect, ascWriteProtected}}
+	case atapiReadCapacity:
+		data := binary.BigEndian.AppendUint32(nil, uint32(max(d.sectors-1, 0)))
+
+		return atapiResult{data: binary.BigEndian.AppendUint32(data, uint32(d.sectorSize))}
+	case atapiReadFormatCapacities:
+		return d.formatCapacities(cdb)
+	case atapiModeSense6:
+		return d.modeSense6(cdb)
+	case atapiModeSense10:
+		return d.modeSense10(cdb)
+	case atapiReadTOC:
+		return d.readTOC(cdb)
+	case atapiGetConfiguration:
+		return d.configuration(cdb)
+	case atapiGetEventStatus:
+		return d.eventStatus(cdb)
+	default:
+		return atapiResult{sense: atapiSense{senseIllegalRequest, ascInvalidOpcode}}
+	}
+}
+
+func (d *atapiDrive) read(lba, count int64) atapiResult {
+	if lba < 0 || count < 0 || lba+count > d.sectors {
+		return atapiResult{sense: atapiSense{senseIllegalRequest, ascLBAOutOfRange}}
+	}
+
+	if count == 0 {
+		return atapiResult{}
+	}
+
+	return atapiResult{read: true, lba: lba, count: count}
+}
+
+// formatCapacities lists the one format of the media, floppy drives are asked for it instead of the capacity.
+func (d *atapiDrive) formatCapacities(cdb []byte) atapiResult {
+	if d.cd() {
+		return atapiResult{sense: atapiSense{senseIllegalRequest, ascInvalidOpcode}}
+	}
+
+	// capacity list header, then a formatted media descriptor: blocks and block length
+	data := []byte{0, 0, 0, 8}
+	data = binary.BigEndian.AppendUint32(data, uint32(d.sectors))
+	data = binary.BigEndian.AppendUint32(data, 0x02000000|uint32(d.sectorSize))
+
+	return atapiResult{data: truncate(data, int(binary.BigEndian.Uint16(cdb[7:9])))}
+}
+
+func (d *atapiDrive) mediumType() byte {
+	if d.cd() {
+		// 120mm CD-ROM data only
+		return 0x01
+	}
+
+	return 0x00
+}
+
+func (d *atapiDrive) modeSense6(cdb []byte) atapiResult {
+	pages, ok := d.modePages(cdb[2] & 0x3F)
+	if !ok {
+		return atapiResult{sense: atapiSense{senseIllegalRequest, ascInvalidField}}
+	}
+
+	// the device specific parameter marks the media write protected
+	data := append([]byte{byte(3 + len(pages)), d.mediumType(), 0x80, 0}, pages...)
+
+	return atapiResult{data: truncate(data, int(cdb[4]))}
+}
+
+func (d *atapiDrive) modeSense10(cdb []byte) atapiResult {
+	pages, ok := d.modePages(cdb[2] & 0x3F)
+	if !ok {
+		return atapiResult{sense: atapiSense{senseIllegalRequest, ascInvalidField}}
+	}
+
+	data := binary.BigEndian.AppendUint16(nil, uint16(6+len(pages)))
+	data = append(data, d.mediumType(), 0x80, 0, 0, 0, 0)
+	data = append(data, pages...)
+
+	return atapiResult{data: truncate(data, int(binary.BigEndian.Uint16(cdb[7:9])))}
+}
+
+func (d *atapiDrive) modePages(page byte) ([]byte, bool) {
+	errorRecovery := []byte{atapiModePageErrorRecovery, 0x06, 0x00, 0x05, 0, 0, 0, 0}
+
+	// CD capabilities: reads CD-ROM, lockable and ejectable tray, 4x speed and a 512KB buffer
+	capabilities := []byte{
+		atapiModePageCapabilities, 0x12, 0x00, 0x00, 0x20, 0x00, 0x29, 0x00,
+		0x02, 0xC2, 0x00, 0x02, 0x02, 0x00, 0x02, 0xC2, 0x00, 0x00, 0x00, 0x00,
+	}
+
+	cylinders := d.sectors / (floppySectorsPerSide * floppyHeads)
+	flexibleDisk := []byte{atapiModePageFlexibleDisk, 0x1E, 0x01, 0xF4, floppyHeads, floppySectorsPerSide}
+	flexibleDisk = binary.BigEndian.AppendUint16(flexibleDisk, uint16(d.sectorSize))
+	flexibleDisk = binary.BigEndian.AppendUint16(flexibleDisk, uint16(cylinders))
+	flexibleDisk = append(flexibleDisk, make([]byte, 22)...)
+
+	switch {
+	case page == atapiModePageErrorRecovery:
+		return errorRecovery, true
+	case page == atapiModePageCapabilities && d.cd():
+		return capabilities, true
+	case page == atapiModePageFlexibleDisk && !d.cd():
+		return flexibleDisk, true
+	case page == atapiModePageAll && d.cd():
+		return append(errorRecovery, capabilities...), true
+	case page == atapiModePageAll:
+		return append(errorRecovery, flexibleDisk...), true
+	default:
+		return nil, false
+	}
+}
+
+// readTOC describes the media as a single data track, either the table of contents or the session info.
+func (d *atapiDrive) readTOC(cdb []byte) atapiResult {
+	if !d.cd() {
+		return atapiResult{sense: atapiSense{senseIllegalRequest, ascInvalidOpcode}}
+	}
+
+	msf := cdb[1]&0x02 != 0
+
+	format := cdb[2] & 0x0F
+	if format == 0 {
+		// older hosts put the format in the control byte
+		format = cdb[9] >> 6
+	}
+
+	var data []byte
+
+	switch format {
+	case 0:
+		data = []byte{0, 18, 1, 1, 0, atapiDataTrackControl, 1, 0}
+		data = append(data, tocAddress(0, msf)...)
+		data = append(data, 0, atapiDataTrackControl, atapiLeadOutTrack, 0)
+		data = append(data, tocAddress(d.sectors, msf)...)
+	case 1:
+		data = []byte{0, 10, 1, 1, 0, atapiDataTrackControl, 1, 0}
+		data = append(data, tocAddress(0, msf)...)
+	default:
+		return atapiResult{sense: atapiSense{senseIllegalRequest, ascInvalidField}}
+	}
+
+	return atapiResult{data: truncate(data, int(binary.BigEndian.Uint16(cdb[7:9])))}
+}
+
+// configuration reports the CD-ROM profile and the features a read only drive has.
+func (d *atapiDrive) configuration(cdb []byte) atapiResult {
+	if !d.cd() {
+		return atapiResult{sense: atapiSense{senseIllegalRequest, ascInvalidOpcode}}
+	}
+
+	requestType := cdb[1] & 0x03
+	first := binary.BigEndian.Uint16(cdb[2:4])
+
+	features := []struct {
+		code uint16
+		data []byte
+	}{
+		// profile list: CD-ROM, current
+		{0x0000, []byte{0x03, 0x04, 0x00, 0x08, 0x01, 0x00}},
+		// core: ATAPI interface
+		{0x0001, []byte{0x0B, 0x08, 0, 0, 0, 0x02, 0x01, 0, 0, 0}},
+		// removable medium: lockable and ejectable tray
+		{0x0003, []byte{0x03, 0x04, 0x29, 0, 0, 0}},
+		// random readable: block size and blocking
+		{0x0010, []byte{0x01, 0x08, 0, 0, 0x08, 0, 0, 0x01, 0, 0}},
+	}
+
+	data := []byte{0, 0, 0, 0, 0, 0}
+	data = binary.BigEndian.AppendUint16(data, atapiCDProfile)
+
+	for _, f := range features {
+		// type 2 asks for the one feature, the others for every feature from it on
+		if f.code < first || (requestType == 2 && f.code != first) {
+			continue
+		}
+
+		data = binary.BigEndian.AppendUint16(data, f.code)
+		data = append(data, f.data...)
+	}
+
+	binary.BigEndian.PutUint32(data, uint32(len(data)-4))
+
+	return atapiResult{data: truncate(data, int(binary.BigEndian.Uint16(cdb[7:9])))}
+}
+
+// eventStatus answers a polled media event request, the first one after mounting reports new media.
+func (d *atapiDrive) eventStatus(cdb []byte) atapiResult {
+	if cdb[1]&0x01 == 0 {
+		return atapiResult{sense: atapiSense{senseIllegalRequest, ascInvalidField}}
+	}
+
+	if cdb[4]&0x10 == 0 {
+		// no event available, media is the only class supported
+		return atapiResult{data: truncate([]byte{0, 2, 0x80, 0x10}, int(binary.BigEndian.Uint16(cdb[7:9])))}
+	}
+
+	event := byte(0)
+	if d.newMedia {
+		d.newMedia = false
+		event = 0x02
+	}
+
+	data := []byte{0, 6, 0x04, 0x10, event, 0x02, 0, 0}
+
+	return atapiResult{data: truncate(data, int(binary.BigEndian.Uint16(cdb[7:9])))}
+}
+
+func fixedSense(sense atapiSense, length int) []byte {
+	data := []byte{0x70, 0, sense[0], 0, 0, 0, 0, 10, 0, 0, 0, 0, sense[1], sense[2], 0, 0, 0, 0}
+
+	return truncate(data, length)
+}
+
+// tocAddress is the start of a track as a logical block or as minutes, seconds and frames after the pregap.
+func tocAddress(lba int64, msf bool) []byte {
+	if !msf {
+		return binary.BigEndian.AppendUint32(nil, uint32(lba))
+	}
+
+	frames := lba + atapiPregapFrames
+	framesPerMinute := int64(atapiFramesPerSecond * atapiSecondsPerMinute)
+
+	return []byte{
+		0,
+		byte(frames / framesPerMinute),
+		byte(frames / atapiFramesPerSecond % atapiSecondsPerMinute),
+		byte(frames % atapiFramesPerSecond),
+	}
+}
+
+// truncate cuts data to the allocation length of a command, a zero length asks for nothing.
+func truncate(data []byte, length int) []byte {
+	return data[:min(len(data), length)]
+}
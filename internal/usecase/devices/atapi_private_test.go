@@ -0,0 +1,167 @@
+package devices
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+)
+
+type testMedia struct {
+	*bytes.Reader
+	info dto.IDERMedia
+}
+
+func (m testMedia) Info() dto.IDERMedia { return m.info }
+
+func (m testMedia) Close() error { return nil }
+
+func newTestDrive(kind string, size int64) *atapiDrive {
+	return newATAPIDrive(testMedia{
+		Reader: bytes.NewReader(make([]byte, size)),
+		info:   dto.IDERMedia{ImageID: "1", Name: "image", Kind: kind, SizeBytes: size},
+	})
+}
+
+func TestATAPICommand(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		kind   string
+		size   int64
+		device byte
+		cdb    []byte
+		result atapiResult
+	}{
+		{
+			name:   "read capacity of a partial last sector",
+			kind:   dto.MediaKindCD,
+			size:   3*2048 + 1,
+			device: iderDeviceSlave,
+			cdb:    []byte{atapiReadCapacity, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0},
+			result: atapiResult{data: []byte{0, 0, 0, 3, 0, 0, 0x08, 0}},
+		},
+		{
+			name:   "read 10",
+			kind:   dto.MediaKindCD,
+			size:   8 * 2048,
+			device: iderDeviceSlave,
+			cdb:    []byte{atapiRead10, 0, 0, 0, 0, 2, 0, 0, 4, 0, 0, 0},
+			result: atapiResult{read: true, lba: 2, count: 4},
+		},
+		{
+			name:   "read 10 past the end",
+			kind:   dto.MediaKindCD,
+			size:   8 * 2048,
+			device: iderDeviceSlave,
+			cdb:    []byte{atapiRead10, 0, 0, 0, 0, 6, 0, 0, 4, 0, 0, 0},
+			result: atapiResult{sense: atapiSense{senseIllegalRequest, ascLBAOutOfRange}},
+		},
+		{
+			name:   "read 6 of a floppy",
+			kind:   dto.MediaKindFloppy,
+			size:   2880 * 512,
+			device: iderDeviceMaster,
+			cdb:    []byte{atapiRead6, 0, 0x01, 0x00, 2, 0, 0, 0, 0, 0, 0, 0},
+			result: atapiResult{read: true, lba: 256, count: 2},
+		},
+		{
+			name:   "write is protected",
+			kind:   dto.MediaKindFloppy,
+			size:   2880 * 512,
+			device: iderDeviceMaster,
+			cdb:    []byte{atapiWrite10, 0, 0, 0, 0, 0, 0, 0, 1, 0, 0, 0},
+			result: atapiResult{sense: atapiSense{senseDataProtect, ascWriteProtected}},
+		},
+		{
+			name:   "table of contents",
+			kind:   dto.MediaKindCD,
+			size:   100 * 2048,
+			device: iderDeviceSlave,
+			cdb:    []byte{atapiReadTOC, 0, 0, 0, 0, 0, 0, 0, 20, 0, 0, 0},
+			result: atapiResult{data: []byte{
+				0, 18, 1, 1,
+				0, atapiDataTrackControl, 1, 0, 0, 0, 0, 0,
+				0, atapiDataTrackControl, atapiLeadOutTrack, 0, 0, 0, 0, 100,
+			}},
+		},
+		{
+			name:   "table of contents as minutes, seconds and frames",
+			kind:   dto.MediaKindCD,
+			size:   100 * 2048,
+			device: iderDeviceSlave,
+			cdb:    []byte{atapiReadTOC, 0x02, 0, 0, 0, 0, 0, 0, 20, 0, 0, 0},
+			result: atapiResult{data: []byte{
+				0, 18, 1, 1,
+				0, atapiDataTrackControl, 1, 0, 0, 0, 2, 0,
+				0, atapiDataTrackControl, atapiLeadOutTrack, 0, 0, 0, 3, 25,
+			}},
+		},
+		{
+			name:   "mode sense of the floppy geometry",
+			kind:   dto.MediaKindFloppy,
+			size:   2880 * 512,
+			device: iderDeviceMaster,
+			cdb:    []byte{atapiModeSense6, 0, atapiModePageFlexibleDisk, 0, 12, 0, 0, 0, 0, 0, 0, 0},
+			result: atapiResult{data: []byte{35, 0, 0x80, 0, atapiModePageFlexibleDisk, 0x1E, 0x01, 0xF4, 2, 18, 0x02, 0}},
+		},
+		{
+			name:   "no media on the other channel",
+			kind:   dto.MediaKindCD,
+			size:   2048,
+			device: iderDeviceMaster,
+			cdb:    []byte{atapiTestUnitReady, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0},
+			result: atapiResult{sense: atapiSense{senseNotReady, ascMediumNotPresent}},
+		},
+		{
+			name:   "unknown opcode",
+			kind:   dto.MediaKindCD,
+			size:   2048,
+			device: iderDeviceSlave,
+			cdb:    []byte{0xFF, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0},
+			result: atapiResult{sense: atapiSense{senseIllegalRequest, ascInvalidOpcode}},
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			drive := newTestDrive(tc.kind, tc.size)
+
+			require.Equal(t, tc.result, drive.command(tc.device, tc.cdb))
+		})
+	}
+}
+
+func TestATAPIUnitAttention(t *testing.T) {
+	t.Parallel()
+
+	drive := newTestDrive(dto.MediaKindCD, 2048)
+	testUnitReady := []byte{atapiTestUnitReady, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}
+	requestSense := []byte{atapiRequestSense, 0, 0, 0, 18, 0, 0, 0, 0, 0, 0, 0}
+
+	require.Equal(t, atapiResult{sense: atapiSense{senseUnitAttention, ascMediumChanged}}, drive.command(iderDeviceSlave, testUnitReady))
+
+	sense := drive.command(iderDeviceSlave, requestSense)
+	require.Equal(t, byte(senseUnitAttention), sense.data[2])
+	require.Equal(t, byte(ascMediumChanged), sense.data[12])
+
+	require.Equal(t, atapiResult{}, drive.command(iderDeviceSlave, testUnitReady))
+
+	sense = drive.command(iderDeviceSlave, requestSense)
+	require.Equal(t, byte(senseNoSense), sense.data[2])
+}
+
+func TestTOCAddress(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, []byte{0, 0, 2, 0}, tocAddress(0, true))
+	require.Equal(t, []byte{0, 1, 0, 0}, tocAddress(60*75-150, true))
+	require.Equal(t, []byte{0, 0, 0x01, 0x2C}, tocAddress(300, false))
+}
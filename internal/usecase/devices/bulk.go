@@ -0,0 +1,214 @@
+package devices
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	"github.com/open-amt-cloud-toolkit/console/pkg/tenant"
+)
+
+const (
+	defaultBulkConcurrency = 10
+	bulkTagPageSize        = 100
+	// bulkJobRetention is how long a finished job stays pollable.
+	bulkJobRetention = time.Hour
+)
+
+type bulkJob struct {
+	mu       sync.Mutex
+	job      dto.BulkPowerActionJob
+	done     chan struct{}
+	tenantID string
+}
+
+func (j *bulkJob) snapshot() dto.BulkPowerActionJob {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	s := j.job
+	s.Results = make([]dto.BulkPowerActionResult, len(j.job.Results))
+	copy(s.Results, j.job.Results)
+
+	return s
+}
+
+func (j *bulkJob) setResult(i int, result dto.BulkPowerActionResult) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	j.job.Results[i] = result
+
+	if result.Status == dto.BulkResultStatusSuccess {
+		j.job.Succeeded++
+	} else {
+		j.job.Failed++
+	}
+}
+
+func (j *bulkJob) complete() {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	now := time.Now()
+	j.job.Status = dto.BulkJobStatusCompleted
+	j.job.CompletedAt = &now
+
+	close(j.done)
+}
+
+// SendBulkPowerAction runs a power action against every device selected by GUID or tag expression.
+// Unless the request is async it waits for the job to finish or for ctx to be done, whichever comes first;
+// the job keeps running in the background either way and can be polled with GetBulkPowerActionJob.
+func (uc *UseCase) SendBulkPowerAction(ctx context.Context, req dto.BulkPowerActionRequest, tenantID string) (dto.BulkPowerActionJob, error) {
+	guids, err := uc.resolveBulkTargets(ctx, req, tenantID)
+	if err != nil {
+		return dto.BulkPowerActionJob{}, err
+	}
+
+	job := &bulkJob{
+		job: dto.BulkPowerActionJob{
+			ID:        uuid.New().String(),
+			Action:    req.Action,
+			Status:    dto.BulkJobStatusRunning,
+			Total:     len(guids),
+			CreatedAt: time.Now(),
+			Results:   make([]dto.BulkPowerActionResult, len(guids)),
+		},
+		done:     make(chan struct{}),
+		tenantID: tenantID,
+	}
+
+	for i, guid := range guids {
+		job.job.Results[i] = dto.BulkPowerActionResult{GUID: guid, Status: dto.BulkResultStatusPending}
+	}
+
+	uc.bulkJobsMu.Lock()
+	uc.pruneBulkJobs()
+	uc.bulkJobs[job.job.ID] = job
+	uc.bulkJobsMu.Unlock()
+
+	concurrency := req.MaxConcurrency
+	if concurrency <= 0 {
+		concurrency = defaultBulkConcurrency
+	}
+
+	// the job must outlive the http request that started it, and only reach devices of the tenant that started it
+	go uc.runBulkPowerAction(tenant.NewContext(context.WithoutCancel(ctx), tenantID), job, guids, req.Action, concurrency)
+
+	if req.Async {
+		return job.snapshot(), nil
+	}
+
+	select {
+	case <-job.done:
+	case <-ctx.Done():
+	}
+
+	return job.snapshot(), nil
+}
+
+// GetBulkPowerActionJob returns the current state of a bulk power action job started by the tenant of ctx.
+func (uc *UseCase) GetBulkPowerActionJob(ctx context.Context, jobID string) (dto.BulkPowerActionJob, error) {
+	uc.bulkJobsMu.Lock()
+	job, ok := uc.bulkJobs[jobID]
+	uc.bulkJobsMu.Unlock()
+
+	if !ok || job.tenantID != tenant.FromContext(ctx) {
+		return dto.BulkPowerActionJob{}, ErrNotFound
+	}
+
+	return job.snapshot(), nil
+}
+
+func (uc *UseCase) resolveBulkTargets(ctx context.Context, req dto.BulkPowerActionRequest, tenantID string) ([]string, error) {
+	seen := make(map[string]bool)
+	guids := make([]string, 0, len(req.GUIDs))
+
+	add := func(guid string) {
+		if guid != "" && !seen[guid] {
+			seen[guid] = true
+
+			guids = append(guids, guid)
+		}
+	}
+
+	for _, guid := range req.GUIDs {
+		add(strings.TrimSpace(guid))
+	}
+
+	if len(req.Tags) > 0 {
+		for offset := 0; ; offset += bulkTagPageSize {
+			data, err := uc.repo.GetByTags(ctx, req.Tags, req.Method, bulkTagPageSize, offset, tenantID)
+			if err != nil {
+				return nil, ErrDatabase.Wrap("SendBulkPowerAction", "uc.repo.GetByTags", err)
+			}
+
+			for i := range data {
+				add(data[i].GUID)
+			}
+
+			if len(data) < bulkTagPageSize {
+				break
+			}
+		}
+	}
+
+	if len(guids) == 0 {
+		return nil, ErrNotFound.WrapWithMessage("SendBulkPowerAction", "resolveBulkTargets", "no devices matched the request")
+	}
+
+	return guids, nil
+}
+
+func (uc *UseCase) runBulkPowerAction(ctx context.Context, job *bulkJob, guids []string, action, concurrency int) {
+	sem := make(chan struct{}, concurrency)
+
+	var wg sync.WaitGroup
+
+	for i, guid := range guids {
+		wg.Add(1)
+
+		sem <- struct{}{}
+
+		go func(i int, guid string) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			result := dto.BulkPowerActionResult{GUID: guid, Status: dto.BulkResultStatusSuccess}
+
+			response, err := uc.SendPowerAction(ctx, guid, action)
+
+			result.ReturnValue = int(response.ReturnValue)
+
+			switch {
+			case err != nil:
+				result.Status = dto.BulkResultStatusFailed
+				result.Error = err.Error()
+			case result.ReturnValue != 0:
+				result.Status = dto.BulkResultStatusFailed
+			}
+
+			job.setResult(i, result)
+		}(i, guid)
+	}
+
+	wg.Wait()
+	job.complete()
+}
+
+// pruneBulkJobs drops finished jobs past their retention, callers must hold bulkJobsMu.
+func (uc *UseCase) pruneBulkJobs() {
+	for id, job := range uc.bulkJobs {
+		s := job.snapshot()
+		if s.CompletedAt != nil && time.Since(*s.CompletedAt) > bulkJobRetention {
+			delete(uc.bulkJobs, id)
+		}
+	}
+}
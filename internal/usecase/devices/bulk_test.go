@@ -0,0 +1,186 @@
+package devices_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/open-amt-cloud-toolkit/go-wsman-messages/v2/pkg/wsman/cim/power"
+	"github.com/stretchr/testify/require"
+	gomock "go.uber.org/mock/gomock"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity"
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	"github.com/open-amt-cloud-toolkit/console/internal/mocks"
+	devices "github.com/open-amt-cloud-toolkit/console/internal/usecase/devices"
+	"github.com/open-amt-cloud-toolkit/console/pkg/tenant"
+)
+
+func TestSendBulkPowerAction(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		req       dto.BulkPowerActionRequest
+		manMock   func(*mocks.MockWSMAN, *mocks.MockManagement)
+		repoMock  func(*mocks.MockDeviceManagementRepository)
+		succeeded int
+		failed    int
+		results   map[string]dto.BulkPowerActionResult
+		err       error
+	}{
+		{
+			name: "explicit guids with one failure",
+			req:  dto.BulkPowerActionRequest{Action: 8, GUIDs: []string{"guid-1", "guid-2", "guid-1"}, MaxConcurrency: 2},
+			manMock: func(man *mocks.MockWSMAN, hmm *mocks.MockManagement) {
+				man.EXPECT().SetupWsmanClient(gomock.Any(), false, true).Return(hmm).Times(2)
+				hmm.EXPECT().SendPowerAction(8).Return(power.PowerActionResponse{ReturnValue: 0}, nil)
+				hmm.EXPECT().SendPowerAction(8).Return(power.PowerActionResponse{ReturnValue: 2}, nil)
+			},
+			repoMock: func(repo *mocks.MockDeviceManagementRepository) {
+				repo.EXPECT().GetByID(gomock.Any(), "guid-1", "").Return(&entity.Device{GUID: "guid-1"}, nil)
+				repo.EXPECT().GetByID(gomock.Any(), "guid-2", "").Return(&entity.Device{GUID: "guid-2"}, nil)
+			},
+			succeeded: 1,
+			failed:    1,
+		},
+		{
+			name: "tag expression",
+			req:  dto.BulkPowerActionRequest{Action: 2, Tags: []string{"lab", "floor1"}, Method: "AND"},
+			manMock: func(man *mocks.MockWSMAN, hmm *mocks.MockManagement) {
+				man.EXPECT().SetupWsmanClient(gomock.Any(), false, true).Return(hmm)
+				hmm.EXPECT().SendPowerAction(2).Return(power.PowerActionResponse{ReturnValue: 0}, nil)
+			},
+			repoMock: func(repo *mocks.MockDeviceManagementRepository) {
+				repo.EXPECT().GetByTags(gomock.Any(), []string{"lab", "floor1"}, "AND", 100, 0, "").
+					Return([]entity.Device{{GUID: "guid-3"}}, nil)
+				repo.EXPECT().GetByID(gomock.Any(), "guid-3", "").Return(&entity.Device{GUID: "guid-3"}, nil)
+			},
+			succeeded: 1,
+			results: map[string]dto.BulkPowerActionResult{
+				"guid-3": {GUID: "guid-3", Status: dto.BulkResultStatusSuccess},
+			},
+		},
+		{
+			name: "device lookup fails",
+			req:  dto.BulkPowerActionRequest{Action: 8, GUIDs: []string{"guid-4"}},
+			manMock: func(_ *mocks.MockWSMAN, _ *mocks.MockManagement) {
+			},
+			repoMock: func(repo *mocks.MockDeviceManagementRepository) {
+				repo.EXPECT().GetByID(gomock.Any(), "guid-4", "").Return(nil, ErrGeneral)
+			},
+			failed: 1,
+			results: map[string]dto.BulkPowerActionResult{
+				"guid-4": {GUID: "guid-4", Status: dto.BulkResultStatusFailed, Error: ErrGeneral.Error()},
+			},
+		},
+		{
+			name:    "no devices matched",
+			req:     dto.BulkPowerActionRequest{Action: 8, Tags: []string{"missing"}},
+			manMock: func(_ *mocks.MockWSMAN, _ *mocks.MockManagement) {},
+			repoMock: func(repo *mocks.MockDeviceManagementRepository) {
+				repo.EXPECT().GetByTags(gomock.Any(), []string{"missing"}, "", 100, 0, "").
+					Return([]entity.Device{}, nil)
+			},
+			err: devices.ErrNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			useCase, wsmanMock, management, repo := initPowerTest(t)
+
+			tc.manMock(wsmanMock, management)
+			tc.repoMock(repo)
+
+			job, err := useCase.SendBulkPowerAction(context.Background(), tc.req, "")
+			if tc.err != nil {
+				require.ErrorAs(t, err, &devices.ErrNotFound)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, dto.BulkJobStatusCompleted, job.Status)
+			require.NotNil(t, job.CompletedAt)
+			require.Equal(t, tc.succeeded, job.Succeeded)
+			require.Equal(t, tc.failed, job.Failed)
+			require.Equal(t, tc.succeeded+tc.failed, job.Total)
+
+			for _, result := range job.Results {
+				if expected, ok := tc.results[result.GUID]; ok {
+					require.Equal(t, expected, result)
+				}
+			}
+
+			polled, err := useCase.GetBulkPowerActionJob(context.Background(), job.ID)
+			require.NoError(t, err)
+			require.Equal(t, job, polled)
+		})
+	}
+}
+
+func TestSendBulkPowerActionAsync(t *testing.T) {
+	t.Parallel()
+
+	useCase, wsmanMock, management, repo := initPowerTest(t)
+
+	release := make(chan struct{})
+
+	repo.EXPECT().GetByID(gomock.Any(), "guid-1", "").Return(&entity.Device{GUID: "guid-1"}, nil)
+	wsmanMock.EXPECT().SetupWsmanClient(gomock.Any(), false, true).Return(management)
+	management.EXPECT().SendPowerAction(8).DoAndReturn(func(_ int) (power.PowerActionResponse, error) {
+		<-release
+
+		return power.PowerActionResponse{ReturnValue: 0}, nil
+	})
+
+	job, err := useCase.SendBulkPowerAction(context.Background(), dto.BulkPowerActionRequest{Action: 8, GUIDs: []string{"guid-1"}, Async: true}, "")
+	require.NoError(t, err)
+	require.Equal(t, dto.BulkJobStatusRunning, job.Status)
+	require.Equal(t, dto.BulkResultStatusPending, job.Results[0].Status)
+
+	close(release)
+
+	require.Eventually(t, func() bool {
+		polled, err := useCase.GetBulkPowerActionJob(context.Background(), job.ID)
+
+		return err == nil && polled.Status == dto.BulkJobStatusCompleted && polled.Succeeded == 1
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestGetBulkPowerActionJobNotFound(t *testing.T) {
+	t.Parallel()
+
+	useCase, _, _, _ := initPowerTest(t)
+
+	_, err := useCase.GetBulkPowerActionJob(context.Background(), "unknown")
+	require.ErrorAs(t, err, &devices.ErrNotFound)
+}
+
+func TestBulkPowerActionTenant(t *testing.T) {
+	t.Parallel()
+
+	useCase, wsmanMock, management, repo := initPowerTest(t)
+
+	repo.EXPECT().GetByID(gomock.Any(), "guid-1", "acme").Return(&entity.Device{GUID: "guid-1", TenantID: "acme"}, nil)
+	wsmanMock.EXPECT().SetupWsmanClient(gomock.Any(), false, true).Return(management)
+	management.EXPECT().SendPowerAction(8).Return(power.PowerActionResponse{ReturnValue: 0}, nil)
+
+	ctx := tenant.NewContext(context.Background(), "acme")
+
+	job, err := useCase.SendBulkPowerAction(ctx, dto.BulkPowerActionRequest{Action: 8, GUIDs: []string{"guid-1"}}, "acme")
+	require.NoError(t, err)
+	require.Equal(t, 1, job.Succeeded)
+
+	_, err = useCase.GetBulkPowerActionJob(ctx, job.ID)
+	require.NoError(t, err)
+
+	// another tenant cannot poll the job
+	_, err = useCase.GetBulkPowerActionJob(context.Background(), job.ID)
+	require.ErrorAs(t, err, &devices.ErrNotFound)
+}
@@ -20,6 +20,7 @@ import (
 
 	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
 	"github.com/open-amt-cloud-toolkit/console/internal/usecase/devices/wsman"
+	"github.com/open-amt-cloud-toolkit/console/pkg/tenant"
 )
 
 const (
@@ -150,7 +151,7 @@ func processCertificates(contextItems []credential.CredentialContext, response w
 }
 
 func (uc *UseCase) GetCertificates(c context.Context, guid string) (dto.SecuritySettings, error) {
-	item, err := uc.repo.GetByID(c, guid, "")
+	item, err := uc.repo.GetByID(c, guid, tenant.FromContext(c))
 	if err != nil {
 		return dto.SecuritySettings{}, err
 	}
@@ -244,7 +245,7 @@ func KeysToDTO(r *publicprivate.RefinedPullResponse) dto.KeyPullResponse {
 }
 
 func (uc *UseCase) GetDeviceCertificate(c context.Context, guid string) (dto.Certificate, error) {
-	item, err := uc.repo.GetByID(c, guid, "")
+	item, err := uc.repo.GetByID(c, guid, tenant.FromContext(c))
 	if err != nil {
 		return dto.Certificate{}, err
 	}
@@ -276,6 +277,11 @@ func (uc *UseCase) GetDeviceCertificate(c context.Context, guid string) (dto.Cer
 		certDTOs = append(certDTOs, certDTO)
 	}
 
+	// a device without a TLS certificate, or whose certificate does not parse, has nothing to show
+	if len(certDTOs) == 0 {
+		return dto.Certificate{}, ErrNotFound
+	}
+
 	return certDTOs[0], nil
 }
 
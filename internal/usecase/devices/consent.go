@@ -5,10 +5,11 @@ import (
 	"strconv"
 
 	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	"github.com/open-amt-cloud-toolkit/console/pkg/tenant"
 )
 
 func (uc *UseCase) CancelUserConsent(c context.Context, guid string) (dto.UserConsentMessage, error) {
-	item, err := uc.repo.GetByID(c, guid, "")
+	item, err := uc.repo.GetByID(c, guid, tenant.FromContext(c))
 	if err != nil {
 		return dto.UserConsentMessage{}, err
 	}
@@ -28,7 +29,7 @@ func (uc *UseCase) CancelUserConsent(c context.Context, guid string) (dto.UserCo
 }
 
 func (uc *UseCase) GetUserConsentCode(c context.Context, guid string) (dto.GetUserConsentMessage, error) {
-	item, err := uc.repo.GetByID(c, guid, "")
+	item, err := uc.repo.GetByID(c, guid, tenant.FromContext(c))
 	if err != nil {
 		return dto.GetUserConsentMessage{}, err
 	}
@@ -55,7 +56,7 @@ func (uc *UseCase) GetUserConsentCode(c context.Context, guid string) (dto.GetUs
 }
 
 func (uc *UseCase) SendConsentCode(c context.Context, userConsent dto.UserConsentCode, guid string) (dto.UserConsentMessage, error) {
-	item, err := uc.repo.GetByID(c, guid, "")
+	item, err := uc.repo.GetByID(c, guid, tenant.FromContext(c))
 	if err != nil {
 		return dto.UserConsentMessage{}, err
 	}
@@ -0,0 +1,98 @@
+package devices_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/open-amt-cloud-toolkit/go-wsman-messages/v2/pkg/wsman/cim/power"
+	"github.com/stretchr/testify/require"
+	gomock "go.uber.org/mock/gomock"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity"
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	"github.com/open-amt-cloud-toolkit/console/internal/mocks"
+	devices "github.com/open-amt-cloud-toolkit/console/internal/usecase/devices"
+	"github.com/open-amt-cloud-toolkit/console/pkg/logger"
+	"github.com/open-amt-cloud-toolkit/console/pkg/tenant"
+)
+
+func TestPublishesDeviceEvents(t *testing.T) {
+	t.Parallel()
+
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+
+	repo := mocks.NewMockDeviceManagementRepository(mockCtl)
+	wsmanMock := mocks.NewMockWSMAN(mockCtl)
+	management := mocks.NewMockManagement(mockCtl)
+	publisher := mocks.NewMockPublisher(mockCtl)
+
+	u := devices.New(repo, wsmanMock, mocks.NewMockRedirection(mockCtl), publisher, nil, nil, logger.New("error"), mocks.MockCrypto{})
+
+	device := &entity.Device{GUID: "guid-1", TenantID: "tenant1", Tags: "lab,floor1"}
+	ctx := tenant.NewContext(context.Background(), "tenant1")
+
+	repo.EXPECT().GetByID(ctx, "guid-1", "tenant1").Return(device, nil).Times(2)
+	wsmanMock.EXPECT().SetupWsmanClient(*device, false, true).Return(management)
+	management.EXPECT().SendPowerAction(8).Return(power.PowerActionResponse{ReturnValue: 0}, nil)
+	repo.EXPECT().Delete(ctx, "guid-1", "tenant1").Return(true, nil)
+
+	gomock.InOrder(
+		publisher.EXPECT().Publish(ctx, dto.Event{
+			Type:     dto.EventPowerAction,
+			GUID:     "guid-1",
+			TenantID: "tenant1",
+			Tags:     []string{"lab", "floor1"},
+			Data:     dto.PowerActionEvent{Action: 8},
+		}),
+		publisher.EXPECT().Publish(ctx, dto.Event{
+			Type:     dto.EventDeviceDeleted,
+			GUID:     "guid-1",
+			TenantID: "tenant1",
+			Tags:     []string{"lab", "floor1"},
+		}),
+	)
+
+	_, err := u.SendPowerAction(ctx, "guid-1", 8)
+	require.NoError(t, err)
+
+	require.NoError(t, u.Delete(ctx, "guid-1", "tenant1"))
+}
+
+func TestPublishesCertificateEvents(t *testing.T) {
+	t.Parallel()
+
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+
+	repo := mocks.NewMockDeviceManagementRepository(mockCtl)
+	publisher := mocks.NewMockPublisher(mockCtl)
+
+	wsmanMock := mocks.NewMockWSMAN(mockCtl)
+
+	u := devices.New(repo, wsmanMock, mocks.NewMockRedirection(mockCtl), publisher, nil, nil, logger.New("error"), mocks.MockCrypto{})
+
+	ctx := context.Background()
+	hash := "abc123"
+	unpinned := &entity.Device{GUID: "guid-1", TenantID: "tenant1"}
+	pinned := &entity.Device{GUID: "guid-1", TenantID: "tenant1", CertHash: &hash}
+
+	gomock.InOrder(
+		repo.EXPECT().GetByID(ctx, "guid-1", "tenant1").Return(unpinned, nil),
+		repo.EXPECT().Update(ctx, gomock.Any()).Return(true, nil),
+		repo.EXPECT().GetByID(ctx, "guid-1", "tenant1").Return(pinned, nil),
+	)
+
+	wsmanMock.EXPECT().DestroyWsmanClient(gomock.Any())
+	publisher.EXPECT().Publish(ctx, gomock.Any()).
+		Do(func(_ context.Context, e dto.Event) { require.Equal(t, dto.EventDeviceUpdated, e.Type) })
+	publisher.EXPECT().Publish(ctx, dto.Event{
+		Type:     dto.EventCertificatePinned,
+		GUID:     "guid-1",
+		TenantID: "tenant1",
+		Data:     dto.PinCertificate{SHA256Fingerprint: "abc123"},
+	})
+
+	_, err := u.Update(ctx, &dto.Device{GUID: "guid-1", TenantID: "tenant1", CertHash: "abc123"})
+	require.NoError(t, err)
+}
@@ -13,10 +13,11 @@ import (
 	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
 	dtov2 "github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v2"
 	"github.com/open-amt-cloud-toolkit/console/internal/usecase/devices/wsman"
+	"github.com/open-amt-cloud-toolkit/console/pkg/tenant"
 )
 
 func (uc *UseCase) GetFeatures(c context.Context, guid string) (settingsResults dto.Features, settingsResultsV2 dtov2.Features, err error) {
-	item, err := uc.repo.GetByID(c, guid, "")
+	item, err := uc.repo.GetByID(c, guid, tenant.FromContext(c))
 	if err != nil {
 		return dto.Features{}, dtov2.Features{}, err
 	}
@@ -62,7 +63,7 @@ func (uc *UseCase) GetFeatures(c context.Context, guid string) (settingsResults
 }
 
 func (uc *UseCase) SetFeatures(c context.Context, guid string, features dto.Features) (settingsResults dto.Features, settingsResultsV2 dtov2.Features, err error) {
-	item, err := uc.repo.GetByID(c, guid, "")
+	item, err := uc.repo.GetByID(c, guid, tenant.FromContext(c))
 	if err != nil {
 		return settingsResults, settingsResultsV2, err
 	}
@@ -111,6 +112,8 @@ func (uc *UseCase) SetFeatures(c context.Context, guid string, features dto.Feat
 	settingsResults.UserConsent = features.UserConsent
 	settingsResultsV2.UserConsent = features.UserConsent
 
+	uc.publish(c, dto.EventFeaturesChanged, item, settingsResultsV2)
+
 	return settingsResults, settingsResultsV2, err
 }
 
@@ -0,0 +1,750 @@
+package devices
+
+import (
+	"bufio"
+	"bytes"
+	"compress/zlib"
+	"context"
+	"encoding/binary"
+	"errors"
+	"image"
+	"image/color"
+	"image/draw"
+	"io"
+)
+
+// RFB messages and encodings the framebuffer of a KVM session is read with, see RFC 6143.
+const (
+	rfbSetPixelFormat           = 0
+	rfbSetEncodings             = 2
+	rfbFramebufferUpdateRequest = 3
+
+	rfbFramebufferUpdate   = 0
+	rfbSetColourMapEntries = 1
+	rfbBell                = 2
+	rfbServerCutText       = 3
+
+	rfbEncodingRaw         = 0
+	rfbEncodingCopyRect    = 1
+	rfbEncodingRRE         = 2
+	rfbEncodingHextile     = 5
+	rfbEncodingZRLE        = 16
+	rfbEncodingDesktopSize = -223
+
+	hextileRaw              = 1
+	hextileBackground       = 2
+	hextileForeground       = 4
+	hextileAnySubrects      = 8
+	hextileSubrectsColoured = 16
+	hextileTileSize         = 16
+
+	zrleTileSize   = 64
+	zrleMaxLength  = 64 << 20
+	rfbMaxSize     = 8192
+	rfbMaxTextSize = 1 << 20
+)
+
+var (
+	errRFBMessage  = errors.New("unsupported RFB message")
+	errRFBEncoding = errors.New("unsupported RFB encoding")
+	errRFBRect     = errors.New("RFB rectangle out of the framebuffer")
+	errRFBSize     = errors.New("unsupported RFB framebuffer size")
+)
+
+// rfbEncodings are offered to the device, the most compact first.
+var rfbEncodings = []int32{rfbEncodingZRLE, rfbEncodingHextile, rfbEncodingRRE, rfbEncodingCopyRect, rfbEncodingRaw, rfbEncodingDesktopSize}
+
+// pixelFormat is how pixels are sent, the gateway asks for 16 bit RGB565 which every AMT KVM supports.
+type pixelFormat struct {
+	BitsPerPixel uint8
+	Depth        uint8
+	BigEndian    uint8
+	TrueColour   uint8
+	RedMax       uint16
+	GreenMax     uint16
+	BlueMax      uint16
+	RedShift     uint8
+	GreenShift   uint8
+	BlueShift    uint8
+	_            [3]byte
+}
+
+var kvmPixelFormat = pixelFormat{
+	BitsPerPixel: 16,
+	Depth:        16,
+	TrueColour:   1,
+	RedMax:       31,
+	GreenMax:     63,
+	BlueMax:      31,
+	RedShift:     11,
+	GreenShift:   5,
+}
+
+func (p *pixelFormat) bytesPerPixel() int {
+	return int(p.BitsPerPixel) / 8
+}
+
+// cpixelSize is the size of a ZRLE pixel: 32 bit pixels whose colours fit in three bytes are sent as three.
+func (p *pixelFormat) cpixelSize() (size int, shift bool) {
+	if p.BitsPerPixel != 32 || p.Depth > 24 {
+		return p.bytesPerPixel(), false
+	}
+
+	highest := max(uint32(p.RedMax)<<p.RedShift, uint32(p.GreenMax)<<p.GreenShift, uint32(p.BlueMax)<<p.BlueShift)
+	lowest := min(p.RedShift, p.GreenShift, p.BlueShift)
+
+	switch {
+	case highest < 1<<24:
+		return 3, false
+	case lowest >= 8:
+		return 3, true
+	default:
+		return p.bytesPerPixel(), false
+	}
+}
+
+func (p *pixelFormat) value(b []byte) uint32 {
+	var v uint32
+
+	if p.BigEndian != 0 {
+		for _, c := range b {
+			v = v<<8 | uint32(c)
+		}
+	} else {
+		for i := len(b) - 1; i >= 0; i-- {
+			v = v<<8 | uint32(b[i])
+		}
+	}
+
+	return v
+}
+
+func (p *pixelFormat) color(v uint32) color.RGBA {
+	return color.RGBA{
+		R: channel(v, p.RedShift, p.RedMax),
+		G: channel(v, p.GreenShift, p.GreenMax),
+		B: channel(v, p.BlueShift, p.BlueMax),
+		A: 0xff,
+	}
+}
+
+func channel(v uint32, shift uint8, maxValue uint16) uint8 {
+	if maxValue == 0 {
+		return 0
+	}
+
+	return uint8((v >> shift) & uint32(maxValue) * 0xff / uint32(maxValue)) //nolint:gosec // scaled to 0-255
+}
+
+// framebuffer is the screen of a KVM session as the console draws it from the device's updates.
+type framebuffer struct {
+	r      *bufio.Reader
+	format pixelFormat
+	img    *image.RGBA
+	// the ZRLE rectangles of a session are one zlib stream
+	zlibInput bytes.Buffer
+	zlib      io.ReadCloser
+	scratch   [4]byte
+}
+
+// kvmInit sends the ClientInit of a shared session, so that the device keeps its other clients, reads the
+// ServerInit and asks for the pixel format and encodings the framebuffer decodes.
+func (uc *UseCase) kvmInit(ctx context.Context, dc *DeviceConnection, reader *bufio.Reader) (*framebuffer, error) {
+	if err := uc.kvmSend(ctx, dc, []byte{1}); err != nil {
+		return nil, err
+	}
+
+	var init struct {
+		Width      uint16
+		Height     uint16
+		Format     pixelFormat
+		NameLength uint32
+	}
+
+	if err := binary.Read(reader, binary.BigEndian, &init); err != nil {
+		return nil, err
+	}
+
+	if _, err := io.CopyN(io.Discard, reader, int64(init.NameLength)); err != nil {
+		return nil, err
+	}
+
+	f := &framebuffer{r: reader, format: kvmPixelFormat}
+	if err := f.resize(int(init.Width), int(init.Height)); err != nil {
+		return nil, err
+	}
+
+	var setPixelFormat bytes.Buffer
+
+	setPixelFormat.Write([]byte{rfbSetPixelFormat, 0, 0, 0})
+	_ = binary.Write(&setPixelFormat, binary.BigEndian, kvmPixelFormat)
+
+	if err := uc.kvmSend(ctx, dc, setPixelFormat.Bytes()); err != nil {
+		return nil, err
+	}
+
+	msg := []byte{rfbSetEncodings, 0}
+	msg = binary.BigEndian.AppendUint16(msg, uint16(len(rfbEncodings)))
+
+	for _, encoding := range rfbEncodings {
+		msg = binary.BigEndian.AppendUint32(msg, uint32(encoding)) //nolint:gosec // pseudo encodings are negative
+	}
+
+	return f, uc.kvmSend(ctx, dc, msg)
+}
+
+func (f *framebuffer) resize(width, height int) error {
+	if width <= 0 || height <= 0 || width > rfbMaxSize || height > rfbMaxSize {
+		return errRFBSize
+	}
+
+	// what the device has not drawn yet is black rather than transparent
+	f.img = image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(f.img, f.img.Bounds(), image.Black, image.Point{}, draw.Src)
+
+	return nil
+}
+
+func (f *framebuffer) updateRequest() []byte {
+	bounds := f.img.Bounds()
+
+	msg := []byte{rfbFramebufferUpdateRequest, 0, 0, 0, 0, 0}
+	msg = binary.BigEndian.AppendUint16(msg, uint16(bounds.Dx())) //nolint:gosec // bounded by rfbMaxSize
+
+	return binary.BigEndian.AppendUint16(msg, uint16(bounds.Dy())) //nolint:gosec // bounded by rfbMaxSize
+}
+
+// capture requests the whole screen and reads updates until every pixel of it was drawn once.
+func (f *framebuffer) capture(send func([]byte) error) error {
+	if err := send(f.updateRequest()); err != nil {
+		return err
+	}
+
+	drawn := 0
+
+	for bounds := f.img.Bounds(); drawn < bounds.Dx()*bounds.Dy(); bounds = f.img.Bounds() {
+		msgType, err := f.r.ReadByte()
+		if err != nil {
+			return err
+		}
+
+		switch msgType {
+		case rfbFramebufferUpdate:
+			area, resized, err := f.update()
+			if err != nil {
+				return err
+			}
+
+			drawn += area
+			if resized {
+				drawn = 0
+			}
+
+			if bounds = f.img.Bounds(); drawn < bounds.Dx()*bounds.Dy() {
+				if err := send(f.updateRequest()); err != nil {
+					return err
+				}
+			}
+		case rfbSetColourMapEntries:
+			var entries struct {
+				_     byte
+				First uint16
+				Count uint16
+			}
+
+			if err := binary.Read(f.r, binary.BigEndian, &entries); err != nil {
+				return err
+			}
+
+			if _, err := f.r.Discard(6 * int(entries.Count)); err != nil {
+				return err
+			}
+		case rfbBell:
+		case rfbServerCutText:
+			if err := f.skipText(); err != nil {
+				return err
+			}
+		default:
+			return errRFBMessage
+		}
+	}
+
+	return nil
+}
+
+func (f *framebuffer) skipText() error {
+	var text struct {
+		_      [3]byte
+		Length uint32
+	}
+
+	if err := binary.Read(f.r, binary.BigEndian, &text); err != nil {
+		return err
+	}
+
+	if text.Length > rfbMaxTextSize {
+		return errRFBMessage
+	}
+
+	_, err := f.r.Discard(int(text.Length))
+
+	return err
+}
+
+// update draws the rectangles of a FramebufferUpdate, it returns the area they covered and whether the
+// device resized the screen.
+func (f *framebuffer) update() (area int, resized bool, err error) {
+	var header struct {
+		_     byte
+		Rects uint16
+	}
+
+	if err := binary.Read(f.r, binary.BigEndian, &header); err != nil {
+		return 0, false, err
+	}
+
+	for i := 0; i < int(header.Rects); i++ {
+		var rect struct {
+			X, Y, Width, Height uint16
+			Encoding            int32
+		}
+
+		if err := binary.Read(f.r, binary.BigEndian, &rect); err != nil {
+			return 0, false, err
+		}
+
+		x, y, w, h := int(rect.X), int(rect.Y), int(rect.Width), int(rect.Height)
+
+		if rect.Encoding == rfbEncodingDesktopSize {
+			if err := f.resize(w, h); err != nil {
+				return 0, false, err
+			}
+
+			resized = true
+
+			continue
+		}
+
+		if !image.Rect(x, y, x+w, y+h).In(f.img.Bounds()) {
+			return 0, false, errRFBRect
+		}
+
+		switch rect.Encoding {
+		case rfbEncodingRaw:
+			err = f.raw(x, y, w, h)
+		case rfbEncodingCopyRect:
+			err = f.copyRect(x, y, w, h)
+		case rfbEncodingRRE:
+			err = f.rre(x, y, w, h)
+		case rfbEncodingHextile:
+			err = f.hextile(x, y, w, h)
+		case rfbEncodingZRLE:
+			err = f.zrle(x, y, w, h)
+		default:
+			err = errRFBEncoding
+		}
+
+		if err != nil {
+			return 0, false, err
+		}
+
+		area += w * h
+	}
+
+	return area, resized, nil
+}
+
+func (f *framebuffer) readPixel(r io.Reader) (color.RGBA, error) {
+	b := f.scratch[:f.format.bytesPerPixel()]
+	if _, err := io.ReadFull(r, b); err != nil {
+		return color.RGBA{}, err
+	}
+
+	return f.format.color(f.format.value(b)), nil
+}
+
+func (f *framebuffer) fill(x, y, w, h int, c color.RGBA) {
+	for row := y; row < y+h; row++ {
+		for col := x; col < x+w; col++ {
+			f.img.SetRGBA(col, row, c)
+		}
+	}
+}
+
+func (f *framebuffer) raw(x, y, w, h int) error {
+	size := f.format.bytesPerPixel()
+	row := make([]byte, w*size)
+
+	for j := 0; j < h; j++ {
+		if _, err := io.ReadFull(f.r, row); err != nil {
+			return err
+		}
+
+		for i := 0; i < w; i++ {
+			f.img.SetRGBA(x+i, y+j, f.format.color(f.format.value(row[i*size:(i+1)*size])))
+		}
+	}
+
+	return nil
+}
+
+func (f *framebuffer) copyRect(x, y, w, h int) error {
+	var src struct{ X, Y uint16 }
+
+	if err := binary.Read(f.r, binary.BigEndian, &src); err != nil {
+		return err
+	}
+
+	from := image.Rect(int(src.X), int(src.Y), int(src.X)+w, int(src.Y)+h)
+	if !from.In(f.img.Bounds()) {
+		return errRFBRect
+	}
+
+	// the source can overlap the destination
+	copied := image.NewRGBA(from)
+	for j := from.Min.Y; j < from.Max.Y; j++ {
+		for i := from.Min.X; i < from.Max.X; i++ {
+			copied.SetRGBA(i, j, f.img.RGBAAt(i, j))
+		}
+	}
+
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			f.img.SetRGBA(x+i, y+j, copied.RGBAAt(from.Min.X+i, from.Min.Y+j))
+		}
+	}
+
+	return nil
+}
+
+func (f *framebuffer) rre(x, y, w, h int) error {
+	var count uint32
+	if err := binary.Read(f.r, binary.BigEndian, &count); err != nil {
+		return err
+	}
+
+	background, err := f.readPixel(f.r)
+	if err != nil {
+		return err
+	}
+
+	f.fill(x, y, w, h, background)
+
+	for i := uint32(0); i < count; i++ {
+		c, err := f.readPixel(f.r)
+		if err != nil {
+			return err
+		}
+
+		var sub struct{ X, Y, Width, Height uint16 }
+		if err := binary.Read(f.r, binary.BigEndian, &sub); err != nil {
+			return err
+		}
+
+		if int(sub.X)+int(sub.Width) > w || int(sub.Y)+int(sub.Height) > h {
+			return errRFBRect
+		}
+
+		f.fill(x+int(sub.X), y+int(sub.Y), int(sub.Width), int(sub.Height), c)
+	}
+
+	return nil
+}
+
+func (f *framebuffer) hextile(x, y, w, h int) error {
+	var background, foreground color.RGBA
+
+	for ty := y; ty < y+h; ty += hextileTileSize {
+		for tx := x; tx < x+w; tx += hextileTileSize {
+			tw, th := min(hextileTileSize, x+w-tx), min(hextileTileSize, y+h-ty)
+
+			subencoding, err := f.r.ReadByte()
+			if err != nil {
+				return err
+			}
+
+			if subencoding&hextileRaw != 0 {
+				if err := f.raw(tx, ty, tw, th); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			if subencoding&hextileBackground != 0 {
+				if background, err = f.readPixel(f.r); err != nil {
+					return err
+				}
+			}
+
+			f.fill(tx, ty, tw, th, background)
+
+			if subencoding&hextileForeground != 0 {
+				if foreground, err = f.readPixel(f.r); err != nil {
+					return err
+				}
+			}
+
+			if subencoding&hextileAnySubrects == 0 {
+				continue
+			}
+
+			if err := f.hextileSubrects(tx, ty, tw, th, subencoding&hextileSubrectsColoured != 0, foreground); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (f *framebuffer) hextileSubrects(tx, ty, tw, th int, coloured bool, foreground color.RGBA) error {
+	count, err := f.r.ReadByte()
+	if err != nil {
+		return err
+	}
+
+	for i := 0; i < int(count); i++ {
+		c := foreground
+
+		if coloured {
+			if c, err = f.readPixel(f.r); err != nil {
+				return err
+			}
+		}
+
+		var sub [2]byte
+		if _, err := io.ReadFull(f.r, sub[:]); err != nil {
+			return err
+		}
+
+		sx, sy := int(sub[0]>>4), int(sub[0]&0x0f)
+		sw, sh := int(sub[1]>>4)+1, int(sub[1]&0x0f)+1
+
+		if sx+sw > tw || sy+sh > th {
+			return errRFBRect
+		}
+
+		f.fill(tx+sx, ty+sy, sw, sh, c)
+	}
+
+	return nil
+}
+
+func (f *framebuffer) zrle(x, y, w, h int) error {
+	var length uint32
+	if err := binary.Read(f.r, binary.BigEndian, &length); err != nil {
+		return err
+	}
+
+	if length > zrleMaxLength {
+		return errRFBEncoding
+	}
+
+	if _, err := io.CopyN(&f.zlibInput, f.r, int64(length)); err != nil {
+		return err
+	}
+
+	if f.zlib == nil {
+		z, err := zlib.NewReader(&f.zlibInput)
+		if err != nil {
+			return err
+		}
+
+		f.zlib = z
+	}
+
+	for ty := y; ty < y+h; ty += zrleTileSize {
+		for tx := x; tx < x+w; tx += zrleTileSize {
+			if err := f.zrleTile(tx, ty, min(zrleTileSize, x+w-tx), min(zrleTileSize, y+h-ty)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (f *framebuffer) zrleTile(x, y, w, h int) error {
+	subencoding, err := f.zlibByte()
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case subencoding == 0:
+		for i := 0; i < w*h; i++ {
+			c, err := f.cpixel()
+			if err != nil {
+				return err
+			}
+
+			f.img.SetRGBA(x+i%w, y+i/w, c)
+		}
+	case subencoding == 1:
+		c, err := f.cpixel()
+		if err != nil {
+			return err
+		}
+
+		f.fill(x, y, w, h, c)
+	case subencoding <= 16:
+		return f.zrlePacked(x, y, w, h, int(subencoding))
+	case subencoding == 128:
+		return f.zrleRuns(x, y, w, h, nil)
+	case subencoding >= 130:
+		palette, err := f.palette(int(subencoding) - 128)
+		if err != nil {
+			return err
+		}
+
+		return f.zrleRuns(x, y, w, h, palette)
+	default:
+		return errRFBEncoding
+	}
+
+	return nil
+}
+
+func (f *framebuffer) zrlePacked(x, y, w, h, size int) error {
+	palette, err := f.palette(size)
+	if err != nil {
+		return err
+	}
+
+	bits := 4
+
+	switch {
+	case size == 2:
+		bits = 1
+	case size <= 4:
+		bits = 2
+	}
+
+	row := make([]byte, (w*bits+7)/8)
+	mask := byte(1<<bits - 1)
+
+	for j := 0; j < h; j++ {
+		if _, err := io.ReadFull(f.zlib, row); err != nil {
+			return err
+		}
+
+		for i := 0; i < w; i++ {
+			bit := i * bits
+			index := int(row[bit/8]>>(8-bits-bit%8)) & int(mask)
+
+			if index >= len(palette) {
+				return errRFBEncoding
+			}
+
+			f.img.SetRGBA(x+i, y+j, palette[index])
+		}
+	}
+
+	return nil
+}
+
+// zrleRuns draws the run-length encoded pixels of a tile, their colours come from the palette when there is one.
+func (f *framebuffer) zrleRuns(x, y, w, h int, palette []color.RGBA) error {
+	for i := 0; i < w*h; {
+		var (
+			c   color.RGBA
+			run = 1
+			err error
+		)
+
+		if palette == nil {
+			if c, err = f.cpixel(); err != nil {
+				return err
+			}
+
+			if run, err = f.runLength(); err != nil {
+				return err
+			}
+		} else {
+			index, err := f.zlibByte()
+			if err != nil {
+				return err
+			}
+
+			if int(index&0x7f) >= len(palette) {
+				return errRFBEncoding
+			}
+
+			c = palette[index&0x7f]
+
+			if index&0x80 != 0 {
+				if run, err = f.runLength(); err != nil {
+					return err
+				}
+			}
+		}
+
+		if i+run > w*h {
+			return errRFBEncoding
+		}
+
+		for end := i + run; i < end; i++ {
+			f.img.SetRGBA(x+i%w, y+i/w, c)
+		}
+	}
+
+	return nil
+}
+
+func (f *framebuffer) runLength() (int, error) {
+	run := 1
+
+	for {
+		b, err := f.zlibByte()
+		if err != nil {
+			return 0, err
+		}
+
+		run += int(b)
+
+		if b != 0xff {
+			return run, nil
+		}
+	}
+}
+
+func (f *framebuffer) palette(size int) ([]color.RGBA, error) {
+	palette := make([]color.RGBA, size)
+
+	for i := range palette {
+		c, err := f.cpixel()
+		if err != nil {
+			return nil, err
+		}
+
+		palette[i] = c
+	}
+
+	return palette, nil
+}
+
+func (f *framebuffer) cpixel() (color.RGBA, error) {
+	size, shift := f.format.cpixelSize()
+
+	b := f.scratch[:size]
+	if _, err := io.ReadFull(f.zlib, b); err != nil {
+		return color.RGBA{}, err
+	}
+
+	v := f.format.value(b)
+	if shift {
+		v <<= 8
+	}
+
+	return f.format.color(v), nil
+}
+
+func (f *framebuffer) zlibByte() (byte, error) {
+	b := f.scratch[:1]
+	if _, err := io.ReadFull(f.zlib, b); err != nil {
+		return 0, err
+	}
+
+	return b[0], nil
+}
@@ -0,0 +1,235 @@
+package devices
+
+import (
+	"bufio"
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"image"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	black = 0x0000
+	red   = 0xf800
+	green = 0x07e0
+	blue  = 0x001f
+)
+
+// rgb565 encodes pixels the way the device sends them in kvmPixelFormat.
+func rgb565(pixels ...uint16) []byte {
+	b := make([]byte, 0, 2*len(pixels))
+	for _, p := range pixels {
+		b = binary.LittleEndian.AppendUint16(b, p)
+	}
+
+	return b
+}
+
+func rfbUpdate(rects ...[]byte) []byte {
+	msg := []byte{0}
+	msg = binary.BigEndian.AppendUint16(msg, uint16(len(rects)))
+
+	return append(msg, bytes.Join(rects, nil)...)
+}
+
+func rfbRect(x, y, w, h uint16, encoding int32, payload ...byte) []byte {
+	rect := binary.BigEndian.AppendUint16(nil, x)
+	rect = binary.BigEndian.AppendUint16(rect, y)
+	rect = binary.BigEndian.AppendUint16(rect, w)
+	rect = binary.BigEndian.AppendUint16(rect, h)
+	rect = binary.BigEndian.AppendUint32(rect, uint32(encoding))
+
+	return append(rect, payload...)
+}
+
+// zrleRects compresses the tiles of ZRLE rectangles with one stream flushed after each, as a device does.
+func zrleRects(t *testing.T, tiles ...[]byte) [][]byte {
+	t.Helper()
+
+	var (
+		out    bytes.Buffer
+		rects  [][]byte
+		offset int
+	)
+
+	z := zlib.NewWriter(&out)
+
+	for _, tile := range tiles {
+		_, err := z.Write(tile)
+		require.NoError(t, err)
+		require.NoError(t, z.Flush())
+
+		rects = append(rects, binary.BigEndian.AppendUint32(nil, uint32(out.Len()-offset)))
+		rects[len(rects)-1] = append(rects[len(rects)-1], out.Bytes()[offset:]...)
+		offset = out.Len()
+	}
+
+	return rects
+}
+
+func newTestFramebuffer(t *testing.T, data []byte) *framebuffer {
+	t.Helper()
+
+	f := &framebuffer{r: bufio.NewReader(bytes.NewReader(data)), format: kvmPixelFormat}
+	require.NoError(t, f.resize(4, 2))
+
+	return f
+}
+
+func requirePixels(t *testing.T, f *framebuffer, pixels ...uint16) {
+	t.Helper()
+
+	bounds := f.img.Bounds()
+	for i, p := range pixels {
+		require.Equal(t, kvmPixelFormat.color(uint32(p)), f.img.RGBAAt(i%bounds.Dx(), i/bounds.Dx()), "pixel %d", i)
+	}
+}
+
+func TestFramebufferUpdate(t *testing.T) {
+	t.Parallel()
+
+	zrle := zrleRects(t,
+		// a palette of blue and red packed a bit per pixel
+		append(append([]byte{2}, rgb565(blue, red)...), 0x60, 0x60),
+		// a plain run of eight red pixels
+		append(append([]byte{128}, rgb565(red)...), 7),
+	)
+
+	tests := []struct {
+		name    string
+		setup   func(f *framebuffer)
+		updates [][]byte
+		pixels  []uint16
+		area    int
+		err     error
+	}{
+		{
+			name:    "raw",
+			updates: [][]byte{rfbUpdate(rfbRect(0, 0, 4, 2, rfbEncodingRaw, rgb565(red, green, blue, black, black, blue, green, red)...))},
+			pixels:  []uint16{red, green, blue, black, black, blue, green, red},
+			area:    8,
+		},
+		{
+			name: "copy rect",
+			setup: func(f *framebuffer) {
+				f.img.SetRGBA(0, 0, kvmPixelFormat.color(red))
+				f.img.SetRGBA(1, 0, kvmPixelFormat.color(green))
+			},
+			updates: [][]byte{rfbUpdate(rfbRect(1, 1, 2, 1, rfbEncodingCopyRect, 0, 0, 0, 0))},
+			pixels:  []uint16{red, green, black, black, black, red, green, black},
+			area:    2,
+		},
+		{
+			name: "rre",
+			updates: [][]byte{rfbUpdate(rfbRect(0, 0, 4, 2, rfbEncodingRRE,
+				append(append([]byte{0, 0, 0, 1}, rgb565(blue)...), append(rgb565(red), 0, 1, 0, 0, 0, 2, 0, 2)...)...))},
+			pixels: []uint16{blue, red, red, blue, blue, red, red, blue},
+			area:   8,
+		},
+		{
+			name: "hextile with a foreground",
+			updates: [][]byte{rfbUpdate(rfbRect(0, 0, 4, 2, rfbEncodingHextile,
+				append(append(append([]byte{hextileBackground | hextileForeground | hextileAnySubrects}, rgb565(blue)...), rgb565(red)...), 1, 0x10, 0x11)...))},
+			pixels: []uint16{blue, red, red, blue, blue, red, red, blue},
+			area:   8,
+		},
+		{
+			name: "hextile with coloured subrects",
+			updates: [][]byte{rfbUpdate(rfbRect(0, 0, 4, 2, rfbEncodingHextile,
+				append(append(append([]byte{hextileBackground | hextileAnySubrects | hextileSubrectsColoured}, rgb565(blue)...), 1), append(rgb565(green), 0x30, 0x01)...)...))},
+			pixels: []uint16{blue, blue, blue, green, blue, blue, blue, green},
+			area:   8,
+		},
+		{
+			name:    "zrle palette",
+			updates: [][]byte{rfbUpdate(rfbRect(0, 0, 4, 2, rfbEncodingZRLE, zrle[0]...))},
+			pixels:  []uint16{blue, red, red, blue, blue, red, red, blue},
+			area:    8,
+		},
+		{
+			name: "zrle stream across updates",
+			updates: [][]byte{
+				rfbUpdate(rfbRect(0, 0, 4, 2, rfbEncodingZRLE, zrle[0]...)),
+				rfbUpdate(rfbRect(0, 0, 4, 2, rfbEncodingZRLE, zrle[1]...)),
+			},
+			pixels: []uint16{red, red, red, red, red, red, red, red},
+			area:   8,
+		},
+		{
+			name:    "rectangle out of the screen",
+			updates: [][]byte{rfbUpdate(rfbRect(3, 0, 2, 1, rfbEncodingRaw, rgb565(red, red)...))},
+			err:     errRFBRect,
+		},
+		{
+			name:    "unsupported encoding",
+			updates: [][]byte{rfbUpdate(rfbRect(0, 0, 1, 1, 7))},
+			err:     errRFBEncoding,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			f := newTestFramebuffer(t, bytes.Join(tc.updates, nil))
+			if tc.setup != nil {
+				tc.setup(f)
+			}
+
+			var (
+				area int
+				err  error
+			)
+
+			for range tc.updates {
+				if area, _, err = f.update(); err != nil {
+					break
+				}
+			}
+
+			require.Equal(t, tc.err, err)
+
+			if tc.err == nil {
+				require.Equal(t, tc.area, area)
+				requirePixels(t, f, tc.pixels...)
+			}
+		})
+	}
+}
+
+func TestFramebufferCapture(t *testing.T) {
+	t.Parallel()
+
+	data := bytes.Join([][]byte{
+		{rfbBell},
+		{rfbServerCutText, 0, 0, 0, 0, 0, 0, 2, 'h', 'i'},
+		// the device resizes the screen, then draws it in two updates
+		append([]byte{rfbFramebufferUpdate}, rfbUpdate(rfbRect(0, 0, 2, 1, rfbEncodingDesktopSize))...),
+		append([]byte{rfbFramebufferUpdate}, rfbUpdate(rfbRect(0, 0, 1, 1, rfbEncodingRaw, rgb565(red)...))...),
+		append([]byte{rfbFramebufferUpdate}, rfbUpdate(rfbRect(1, 0, 1, 1, rfbEncodingRaw, rgb565(blue)...))...),
+	}, nil)
+
+	f := newTestFramebuffer(t, data)
+
+	var requests [][]byte
+
+	err := f.capture(func(msg []byte) error {
+		requests = append(requests, msg)
+
+		return nil
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, image.Rect(0, 0, 2, 1), f.img.Bounds())
+	requirePixels(t, f, red, blue)
+	require.Equal(t, [][]byte{
+		{rfbFramebufferUpdateRequest, 0, 0, 0, 0, 0, 0, 4, 0, 2},
+		{rfbFramebufferUpdateRequest, 0, 0, 0, 0, 0, 0, 2, 0, 1},
+		{rfbFramebufferUpdateRequest, 0, 0, 0, 0, 0, 0, 2, 0, 1},
+	}, requests)
+}
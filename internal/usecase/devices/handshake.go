@@ -0,0 +1,79 @@
+package devices
+
+import (
+	"encoding/binary"
+	"errors"
+
+	"github.com/open-amt-cloud-toolkit/go-wsman-messages/v2/pkg/wsman/client"
+)
+
+var (
+	redirectionAuthenticationQuery = []byte{RedirectionCommandsAuthenticateSession, 0, 0, 0, 0, 0, 0, 0, 0}
+	redirectionEndSession          = []byte{RedirectionCommandsEndRedirectionSession, 0, 0, 0}
+
+	errRedirectionRefused = errors.New("the device refused the redirection session")
+	errRedirectionAuth    = errors.New("the device rejected the redirection credentials")
+)
+
+// redirectionHandshake starts and authenticates a redirection session the console holds itself rather than
+// relays for a browser, service is the four character name of the session such as "SOL " or "IDER".
+type redirectionHandshake struct {
+	service    string
+	challenge  *client.AuthChallenge
+	digestSent bool
+}
+
+func (h *redirectionHandshake) start() []byte {
+	return append([]byte{RedirectionCommandsStartRedirectionSession, 0, 0, 0}, h.service...)
+}
+
+// reply answers a start or authenticate reply of the device. The query lists the methods, the first digest
+// attempt fetches the realm and nonce and the second one is answered with the device's credentials.
+// authenticated is set once the device accepted them, the session then goes on with its own messages.
+func (h *redirectionHandshake) reply(msg []byte) (answer []byte, authenticated bool, err error) {
+	if msg[0] == RedirectionCommandsStartRedirectionSessionReply {
+		if msg[1] != StartRedirectionSessionReplyStatusSuccess {
+			return nil, false, errRedirectionRefused
+		}
+
+		return redirectionAuthenticationQuery, false, nil
+	}
+
+	status, authType := msg[1], msg[4]
+
+	switch {
+	case authType == AuthenticationTypeQuery:
+		return handleDigestAuthentication(h.challenge), false, nil
+	case status == AuthenticationStatusSuccess:
+		return nil, true, nil
+	case authType == AuthenticationTypeDigest && status == AuthenticationStatusFail && !h.digestSent:
+		handleAuthenticateSessionReply(msg, h.challenge)
+
+		h.digestSent = true
+
+		return handleDigestAuthentication(h.challenge), false, nil
+	default:
+		return nil, false, errRedirectionAuth
+	}
+}
+
+// handshakeMessageSize returns the size of the start or authenticate reply at the start of msg, 0 when more
+// data is needed to tell. ok is false for any other message.
+func handshakeMessageSize(msg []byte) (size int, ok bool) {
+	switch msg[0] {
+	case RedirectionCommandsStartRedirectionSessionReply:
+		if len(msg) < RedirectSessionLengthBytes {
+			return 0, true
+		}
+
+		return RedirectSessionLengthBytes + int(msg[12]), true
+	case RedirectionCommandsAuthenticateSessionReply:
+		if len(msg) < HeaderByteSize {
+			return 0, true
+		}
+
+		return HeaderByteSize + int(binary.LittleEndian.Uint32(msg[5:9])), true
+	default:
+		return 0, false
+	}
+}
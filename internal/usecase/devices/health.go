@@ -0,0 +1,230 @@
+package devices
+
+import (
+	"context"
+	"encoding/json"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity"
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	"github.com/open-amt-cloud-toolkit/console/pkg/logger"
+)
+
+const healthPageSize = 100
+
+// HealthConfig controls how often and how aggressively devices are probed.
+type HealthConfig struct {
+	// Interval between two polls of the fleet, zero disables the poller.
+	Interval time.Duration
+	// Rate is the number of probes started per second.
+	Rate int
+	// Workers caps the number of probes in flight.
+	Workers int
+	// Jitter is the upper bound of the random delay added before each probe.
+	Jitter time.Duration
+}
+
+// HealthPoller periodically probes every device and records whether it answered,
+// so ConnectionStatus, the last* timestamps and DeviceInfo reflect the fleet.
+type HealthPoller struct {
+	repo   Repository
+	device WSMAN
+	log    logger.Interface
+	cfg    HealthConfig
+	jitter func() time.Duration
+	now    func() time.Time
+}
+
+// NewHealthPoller -.
+func NewHealthPoller(r Repository, d WSMAN, log logger.Interface, cfg HealthConfig) *HealthPoller {
+	if cfg.Rate <= 0 {
+		cfg.Rate = 1
+	}
+
+	if cfg.Workers <= 0 {
+		cfg.Workers = 1
+	}
+
+	p := &HealthPoller{
+		repo:   r,
+		device: d,
+		log:    log,
+		cfg:    cfg,
+		now:    time.Now,
+	}
+
+	p.jitter = func() time.Duration {
+		if p.cfg.Jitter <= 0 {
+			return 0
+		}
+
+		return rand.N(p.cfg.Jitter)
+	}
+
+	return p
+}
+
+// Run polls the fleet every interval until ctx is done.
+func (p *HealthPoller) Run(ctx context.Context) {
+	if p.cfg.Interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(p.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		p.Poll(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Poll probes every device once, paced by the configured rate, and waits for the probes to finish.
+func (p *HealthPoller) Poll(ctx context.Context) {
+	limiter := time.NewTicker(time.Second / time.Duration(p.cfg.Rate))
+	defer limiter.Stop()
+
+	sem := make(chan struct{}, p.cfg.Workers)
+
+	var wg sync.WaitGroup
+
+	defer wg.Wait()
+
+	for skip := 0; ; skip += healthPageSize {
+		page, err := p.repo.GetAllTenants(ctx, healthPageSize, skip)
+		if err != nil {
+			p.log.Error(err, "devices - health - Poll - p.repo.GetAllTenants")
+
+			return
+		}
+
+		for i := range page {
+			select {
+			case <-ctx.Done():
+				return
+			case <-limiter.C:
+			}
+
+			sem <- struct{}{}
+
+			wg.Add(1)
+
+			go func(d entity.Device) {
+				defer func() {
+					<-sem
+					wg.Done()
+				}()
+
+				p.probe(ctx, &d)
+			}(page[i])
+		}
+
+		if len(page) < healthPageSize {
+			return
+		}
+	}
+}
+
+func (p *HealthPoller) probe(ctx context.Context, d *entity.Device) {
+	// spread the probes so devices behind the same gateway are not hit in lockstep
+	if wait := p.jitter(); wait > 0 {
+		timer := time.NewTimer(wait)
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+
+			return
+		case <-timer.C:
+		}
+	}
+
+	// the columns are stored with second precision
+	now := p.now().UTC().Truncate(time.Second)
+	health := *d
+
+	info, err := p.deviceInfo(d, now)
+	if err != nil {
+		p.log.Debug("devices - health - probe - " + d.GUID + ": " + err.Error())
+
+		if d.ConnectionStatus {
+			health.LastDisconnected = &now
+		}
+
+		health.ConnectionStatus = false
+	} else {
+		if !d.ConnectionStatus {
+			health.LastConnected = &now
+		}
+
+		health.ConnectionStatus = true
+		health.LastSeen = &now
+		health.DeviceInfo = info
+	}
+
+	if _, err := p.repo.UpdateHealth(ctx, &health); err != nil {
+		p.log.Error(err, "devices - health - probe - p.repo.UpdateHealth")
+	}
+}
+
+func (p *HealthPoller) deviceInfo(d *entity.Device, now time.Time) (string, error) {
+	// start from what the previous probe learned, so an optional call failing does not wipe a field
+	info := dto.DeviceInfo{}
+	if d.DeviceInfo != "" {
+		_ = json.Unmarshal([]byte(d.DeviceInfo), &info)
+	}
+
+	client := p.device.SetupWsmanClient(*d, false, false)
+
+	softwareIdentity, err := client.GetAMTVersion()
+	if err != nil {
+		return "", err
+	}
+
+	versions := make(map[string]string, len(softwareIdentity))
+	for i := range softwareIdentity {
+		versions[softwareIdentity[i].InstanceID] = softwareIdentity[i].VersionString
+	}
+
+	info.FWVersion = versions["AMT"]
+	info.FWBuild = versions["Build Number"]
+	info.FWSku = versions["Sku"]
+
+	setup, err := client.GetSetupAndConfiguration()
+	if err != nil {
+		return "", err
+	}
+
+	if len(setup) > 0 {
+		info.CurrentMode = setup[0].ProvisioningMode.String()
+	}
+
+	ports, err := client.GetEthernetPortSettings()
+	if err != nil {
+		p.log.Debug("devices - health - deviceInfo - " + d.GUID + ": " + err.Error())
+	}
+
+	for i := range ports {
+		if ports[i].IPAddress != "" {
+			info.IPAddress = ports[i].IPAddress
+
+			break
+		}
+	}
+
+	info.LastUpdated = now
+
+	data, err := json.Marshal(info)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
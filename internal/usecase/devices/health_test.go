@@ -0,0 +1,157 @@
+package devices_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/open-amt-cloud-toolkit/go-wsman-messages/v2/pkg/wsman/amt/ethernetport"
+	"github.com/open-amt-cloud-toolkit/go-wsman-messages/v2/pkg/wsman/amt/setupandconfiguration"
+	"github.com/open-amt-cloud-toolkit/go-wsman-messages/v2/pkg/wsman/cim/software"
+	"github.com/stretchr/testify/require"
+	gomock "go.uber.org/mock/gomock"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity"
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	"github.com/open-amt-cloud-toolkit/console/internal/mocks"
+	devices "github.com/open-amt-cloud-toolkit/console/internal/usecase/devices"
+	"github.com/open-amt-cloud-toolkit/console/pkg/logger"
+)
+
+func TestHealthPollerPoll(t *testing.T) {
+	t.Parallel()
+
+	earlier := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		device  entity.Device
+		manMock func(*mocks.MockManagement)
+		check   func(*testing.T, *entity.Device)
+	}{
+		{
+			name:   "device comes online",
+			device: entity.Device{GUID: "guid-1", TenantID: "tenant1", LastDisconnected: &earlier},
+			manMock: func(hmm *mocks.MockManagement) {
+				hmm.EXPECT().GetAMTVersion().Return([]software.SoftwareIdentity{
+					{InstanceID: "AMT", VersionString: "16.1.25"},
+					{InstanceID: "Build Number", VersionString: "2049"},
+					{InstanceID: "Sku", VersionString: "16392"},
+				}, nil)
+				hmm.EXPECT().GetSetupAndConfiguration().Return([]setupandconfiguration.SetupAndConfigurationServiceResponse{
+					{ProvisioningMode: setupandconfiguration.AdminControlMode},
+				}, nil)
+				hmm.EXPECT().GetEthernetPortSettings().Return([]ethernetport.SettingsResponse{
+					{InstanceID: "Intel(r) AMT Ethernet Port Settings 0", IPAddress: "192.168.1.10"},
+				}, nil)
+			},
+			check: func(t *testing.T, d *entity.Device) {
+				t.Helper()
+
+				require.True(t, d.ConnectionStatus)
+				require.NotNil(t, d.LastSeen)
+				require.Equal(t, d.LastSeen, d.LastConnected)
+				require.Equal(t, &earlier, d.LastDisconnected)
+
+				info := dto.DeviceInfo{}
+				require.NoError(t, json.Unmarshal([]byte(d.DeviceInfo), &info))
+				require.Equal(t, "16.1.25", info.FWVersion)
+				require.Equal(t, "2049", info.FWBuild)
+				require.Equal(t, "16392", info.FWSku)
+				require.Equal(t, "AdminControlMode", info.CurrentMode)
+				require.Equal(t, "192.168.1.10", info.IPAddress)
+				require.Equal(t, *d.LastSeen, info.LastUpdated)
+			},
+		},
+		{
+			name:   "device goes offline",
+			device: entity.Device{GUID: "guid-2", ConnectionStatus: true, LastSeen: &earlier, DeviceInfo: `{"fwVersion":"16.1.25"}`},
+			manMock: func(hmm *mocks.MockManagement) {
+				hmm.EXPECT().GetAMTVersion().Return(nil, ErrGeneral)
+			},
+			check: func(t *testing.T, d *entity.Device) {
+				t.Helper()
+
+				require.False(t, d.ConnectionStatus)
+				require.NotNil(t, d.LastDisconnected)
+				require.Equal(t, &earlier, d.LastSeen)
+				require.Equal(t, `{"fwVersion":"16.1.25"}`, d.DeviceInfo)
+			},
+		},
+		{
+			name:   "device stays offline",
+			device: entity.Device{GUID: "guid-3", LastDisconnected: &earlier},
+			manMock: func(hmm *mocks.MockManagement) {
+				hmm.EXPECT().GetAMTVersion().Return(nil, ErrGeneral)
+			},
+			check: func(t *testing.T, d *entity.Device) {
+				t.Helper()
+
+				require.False(t, d.ConnectionStatus)
+				require.Equal(t, &earlier, d.LastDisconnected)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockCtl := gomock.NewController(t)
+			defer mockCtl.Finish()
+
+			repo := mocks.NewMockDeviceManagementRepository(mockCtl)
+			wsmanMock := mocks.NewMockWSMAN(mockCtl)
+			management := mocks.NewMockManagement(mockCtl)
+
+			repo.EXPECT().GetAllTenants(gomock.Any(), 100, 0).Return([]entity.Device{tc.device}, nil)
+			wsmanMock.EXPECT().SetupWsmanClient(gomock.Any(), false, false).Return(management)
+			tc.manMock(management)
+
+			var updated *entity.Device
+
+			repo.EXPECT().UpdateHealth(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, d *entity.Device) (bool, error) {
+					updated = d
+
+					return true, nil
+				})
+
+			poller := devices.NewHealthPoller(repo, wsmanMock, logger.New("error"), devices.HealthConfig{Rate: 1000, Workers: 2})
+			poller.Poll(context.Background())
+
+			require.NotNil(t, updated)
+			require.Equal(t, tc.device.GUID, updated.GUID)
+			require.Equal(t, tc.device.TenantID, updated.TenantID)
+			tc.check(t, updated)
+		})
+	}
+}
+
+func TestHealthPollerPaging(t *testing.T) {
+	t.Parallel()
+
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+
+	repo := mocks.NewMockDeviceManagementRepository(mockCtl)
+	wsmanMock := mocks.NewMockWSMAN(mockCtl)
+	management := mocks.NewMockManagement(mockCtl)
+
+	page := make([]entity.Device, 100)
+	for i := range page {
+		page[i] = entity.Device{GUID: "guid"}
+	}
+
+	repo.EXPECT().GetAllTenants(gomock.Any(), 100, 0).Return(page, nil)
+	repo.EXPECT().GetAllTenants(gomock.Any(), 100, 100).Return([]entity.Device{{GUID: "last"}}, nil)
+	wsmanMock.EXPECT().SetupWsmanClient(gomock.Any(), false, false).Return(management).Times(101)
+	management.EXPECT().GetAMTVersion().Return(nil, ErrGeneral).Times(101)
+	repo.EXPECT().UpdateHealth(gomock.Any(), gomock.Any()).Return(true, nil).Times(101)
+
+	poller := devices.NewHealthPoller(repo, wsmanMock, logger.New("error"), devices.HealthConfig{Rate: 1000, Workers: 10})
+	poller.Poll(context.Background())
+}
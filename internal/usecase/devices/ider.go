@@ -0,0 +1,585 @@
+package devices
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"io"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/open-amt-cloud-toolkit/go-wsman-messages/v2/pkg/wsman/client"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	"github.com/open-amt-cloud-toolkit/console/pkg/subject"
+	"github.com/open-amt-cloud-toolkit/console/pkg/tenant"
+)
+
+// IDE-R messages of the AMT redirection protocol, after the session is started and authenticated.
+const (
+	RedirectionCommandsIDEROpenSession                = 0x40
+	RedirectionCommandsIDEROpenSessionReply           = 0x41
+	RedirectionCommandsIDERCloseSession               = 0x42
+	RedirectionCommandsIDERCloseSessionReply          = 0x43
+	RedirectionCommandsIDERKeepAlivePing              = 0x44
+	RedirectionCommandsIDERKeepAlivePong              = 0x45
+	RedirectionCommandsIDERResetOccurred              = 0x46
+	RedirectionCommandsIDERResetOccurredResponse      = 0x47
+	RedirectionCommandsIDERDisableEnableFeatures      = 0x48
+	RedirectionCommandsIDERDisableEnableFeaturesReply = 0x49
+	RedirectionCommandsIDERErrorOccurred              = 0x4A
+	RedirectionCommandsIDERHeartbeat                  = 0x4B
+	RedirectionCommandsIDERCommandWritten             = 0x50
+	RedirectionCommandsIDERCommandEndResponse         = 0x51
+	RedirectionCommandsIDERDataFromHost               = 0x53
+	RedirectionCommandsIDERDataToHost                 = 0x54
+
+	// IDERStartNow attaches the image to the running system, IDERStartOnReboot at the next reset so that the
+	// device can boot from it.
+	IDERStartNow      = "now"
+	IDERStartOnReboot = "onReboot"
+
+	iderMode = "ider"
+
+	iderHeaderSize            = 8
+	iderOpenSessionReplySize  = 30
+	iderResetOccurredSize     = 9
+	iderFeaturesReplySize     = 13
+	iderErrorOccurredSize     = 11
+	iderCommandWrittenSize    = 28
+	iderDataFromHostSize      = 14
+	iderMaxReadBuffer         = 8192
+	iderConnectTimeout        = 30 * time.Second
+	iderRXTimeout             = 30000
+	iderHeartbeat             = 20000
+	iderProtocolVersion       = 1
+	iderRegistersAvailable    = 1
+	iderRegistersToggle       = 3
+	iderEnable                = 0x01
+	iderEnableOnReboot        = 0x08
+	iderEnableNow             = 0x18
+	iderAttributeDMA          = 0x01
+	iderAttributeCompleted    = 0x02
+	iderStatusReady           = 0x50
+	iderStatusError           = 0x51
+	iderInterruptReasonStatus = 0x03
+)
+
+var (
+	errIDERMounted     = errors.New("an image is already mounted on the device")
+	errIDERStart       = errors.New("start must be now or onReboot")
+	errIDERProtocol    = errors.New("the device speaks an unknown IDE-R protocol")
+	errIDERNotEnabled  = errors.New("the device did not enable IDE-R, check that redirection is enabled")
+	errIDERClosed      = errors.New("the IDE-R session was closed")
+	errIDERClosedByAMT = errors.New("the device closed the IDE-R session")
+	errIDERTimeout     = errors.New("the device did not complete the IDE-R handshake in time")
+)
+
+// iderSession is an IDE-R session the console holds itself to serve an image to a device, no browser needs to
+// stay connected while the device reads it.
+type iderSession struct {
+	// mu guards info, the protocol state belongs to the listen goroutine.
+	mu sync.Mutex
+	// sendMu keeps the sequence numbers in the order the messages are written.
+	sendMu     sync.Mutex
+	uc         *UseCase
+	conn       *DeviceConnection
+	info       dto.IDERSession
+	tenantID   string
+	enable     uint32
+	handshake  redirectionHandshake
+	sequence   uint32
+	pending    []byte
+	drive      *atapiDrive
+	readBuffer int
+	ready      chan error
+	done       chan struct{}
+	closeOnce  sync.Once
+}
+
+// OpenIDER mounts media on a device of the caller's tenant over IDE-R, start tells when the device sees it.
+// The session owns media from then on and closes it when it ends, also when it could not be opened.
+func (uc *UseCase) OpenIDER(ctx context.Context, guid string, media IDERMedia, start string) (dto.IDERSession, error) {
+	enable := uint32(iderEnable)
+
+	switch start {
+	case IDERStartNow, "":
+		enable |= iderEnableNow
+	case IDERStartOnReboot:
+		enable |= iderEnableOnReboot
+	default:
+		media.Close()
+
+		return dto.IDERSession{}, ErrNotValid.Wrap("OpenIDER", "start", errIDERStart)
+	}
+
+	tenantID := tenant.FromContext(ctx)
+
+	uc.iderSessionsMu.Lock()
+
+	if _, ok := uc.iderSessions[guid]; ok {
+		uc.iderSessionsMu.Unlock()
+		media.Close()
+
+		return dto.IDERSession{}, ErrNotValid.Wrap("OpenIDER", "uc.iderSessions", errIDERMounted)
+	}
+
+	device, err := uc.repo.GetByID(ctx, guid, tenantID)
+	if err != nil || device == nil || device.GUID == "" {
+		uc.iderSessionsMu.Unlock()
+		media.Close()
+
+		if err != nil {
+			return dto.IDERSession{}, err
+		}
+
+		return dto.IDERSession{}, ErrNotFound
+	}
+
+	password, _ := uc.safeRequirements.Decrypt(device.Password)
+
+	s := &iderSession{
+		uc: uc,
+		conn: &DeviceConnection{
+			wsmanMessages: uc.redirection.SetupWsmanClient(*device, true, true),
+			Device:        *device,
+			Mode:          iderMode,
+			Challenge: client.AuthChallenge{
+				Username: device.Username,
+				Password: password,
+			},
+		},
+		info: dto.IDERSession{
+			GUID:      device.GUID,
+			State:     dto.IDERStateConnecting,
+			Media:     media.Info(),
+			Subject:   subject.FromContext(ctx),
+			StartTime: time.Now(),
+		},
+		tenantID:   tenantID,
+		enable:     enable,
+		handshake:  redirectionHandshake{service: "IDER"},
+		drive:      newATAPIDrive(media),
+		readBuffer: iderMaxReadBuffer,
+		ready:      make(chan error, 1),
+		done:       make(chan struct{}),
+	}
+	s.handshake.challenge = &s.conn.Challenge
+
+	// the session is listed while it connects so that a second mount is refused rather than raced
+	uc.iderSessions[guid] = s
+	uc.iderSessionsMu.Unlock()
+
+	if err := s.connect(ctx); err != nil {
+		s.close()
+
+		return dto.IDERSession{}, err
+	}
+
+	uc.publish(ctx, dto.EventRedirectionOpened, device, dto.RedirectionEvent{Mode: iderMode})
+
+	return s.snapshot(), nil
+}
+
+// GetIDER returns the IDE-R session open with a device.
+func (uc *UseCase) GetIDER(ctx context.Context, guid string) (dto.IDERSession, error) {
+	s, err := uc.iderSession(ctx, guid)
+	if err != nil {
+		return dto.IDERSession{}, err
+	}
+
+	return s.snapshot(), nil
+}
+
+// CloseIDER ends the IDE-R session open with a device, which ejects its image.
+func (uc *UseCase) CloseIDER(ctx context.Context, guid string) error {
+	s, err := uc.iderSession(ctx, guid)
+	if err != nil {
+		return err
+	}
+
+	s.close()
+
+	return nil
+}
+
+func (uc *UseCase) iderSession(ctx context.Context, guid string) (*iderSession, error) {
+	uc.iderSessionsMu.Lock()
+	s, ok := uc.iderSessions[guid]
+	uc.iderSessionsMu.Unlock()
+
+	if !ok || s.tenantID != tenant.FromContext(ctx) {
+		return nil, ErrNotFound
+	}
+
+	return s, nil
+}
+
+// connect opens the redirection port and waits for the device to enable the IDE-R registers.
+func (s *iderSession) connect(ctx context.Context) error {
+	if err := s.uc.redirection.RedirectConnect(ctx, s.conn); err != nil {
+		return ErrAMT.Wrap("OpenIDER", "uc.redirection.RedirectConnect", err)
+	}
+
+	go s.listen(context.WithoutCancel(ctx))
+
+	if err := s.sendRaw(ctx, s.handshake.start()); err != nil {
+		return ErrAMT.Wrap("OpenIDER", "uc.redirection.RedirectSend", err)
+	}
+
+	timeout := time.NewTimer(iderConnectTimeout)
+	defer timeout.Stop()
+
+	var err error
+
+	select {
+	case err = <-s.ready:
+	case <-timeout.C:
+		err = errIDERTimeout
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	if err != nil {
+		return ErrAMT.Wrap("OpenIDER", "iderSession.connect", err)
+	}
+
+	s.mu.Lock()
+	s.info.State = dto.IDERStateMounted
+	s.mu.Unlock()
+
+	return nil
+}
+
+func (s *iderSession) listen(ctx context.Context) {
+	for {
+		data, err := s.uc.redirection.RedirectListen(ctx, s.conn)
+		if err != nil {
+			s.fail(err)
+
+			return
+		}
+
+		if err := s.handle(ctx, data); err != nil {
+			s.fail(err)
+
+			return
+		}
+	}
+}
+
+// handle processes the messages in data, a message can be split across reads and a read can hold several.
+func (s *iderSession) handle(ctx context.Context, data []byte) error {
+	s.pending = append(s.pending, data...)
+
+	for len(s.pending) > 0 {
+		size := iderMessageSize(s.pending)
+		if size < 0 {
+			return errors.New("unknown IDE-R message " + strconv.Itoa(int(s.pending[0])))
+		}
+
+		if size == 0 || len(s.pending) < size {
+			return nil
+		}
+
+		msg := s.pending[:size]
+		s.pending = s.pending[size:]
+
+		if err := s.handleMessage(ctx, msg); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *iderSession) handleMessage(ctx context.Context, msg []byte) error {
+	switch msg[0] {
+	case RedirectionCommandsStartRedirectionSessionReply, RedirectionCommandsAuthenticateSessionReply:
+		answer, authenticated, err := s.handshake.reply(msg)
+		if err != nil {
+			return err
+		}
+
+		if authenticated {
+			return s.send(ctx, RedirectionCommandsIDEROpenSession, 0, s.openSession())
+		}
+
+		return s.sendRaw(ctx, answer)
+	case RedirectionCommandsIDEROpenSessionReply:
+		if msg[21] != 0 {
+			return errIDERProtocol
+		}
+
+		if readBuffer := int(binary.LittleEndian.Uint16(msg[16:18])); readBuffer > 0 {
+			s.readBuffer = min(readBuffer, iderMaxReadBuffer)
+		}
+
+		return s.sendEnable(ctx)
+	case RedirectionCommandsIDERDisableEnableFeaturesReply:
+		return s.featuresReply(ctx, msg[8], binary.LittleEndian.Uint32(msg[9:13]))
+	case RedirectionCommandsIDERCloseSessionReply:
+		return errIDERClosedByAMT
+	case RedirectionCommandsIDERKeepAlivePing:
+		return s.send(ctx, RedirectionCommandsIDERKeepAlivePong, 0, nil)
+	case RedirectionCommandsIDERResetOccurred:
+		return s.send(ctx, RedirectionCommandsIDERResetOccurredResponse, 0, nil)
+	case RedirectionCommandsIDERErrorOccurred:
+		s.uc.log.Warn("devices - ider - error " + strconv.Itoa(int(msg[8])) + " from " + s.info.GUID)
+	case RedirectionCommandsIDERCommandWritten:
+		return s.command(ctx, msg)
+	}
+
+	return nil
+}
+
+// openSession asks for the IDE-R session: a receive timeout, no transmit timeout and a heartbeat, in ms.
+func (s *iderSession) openSession() []byte {
+	msg := binary.LittleEndian.AppendUint16(nil, iderRXTimeout)
+	msg = binary.LittleEndian.AppendUint16(msg, 0)
+	msg = binary.LittleEndian.AppendUint16(msg, iderHeartbeat)
+
+	return binary.LittleEndian.AppendUint32(msg, iderProtocolVersion)
+}
+
+func (s *iderSession) sendEnable(ctx context.Context) error {
+	return s.send(ctx, RedirectionCommandsIDERDisableEnableFeatures, 0, binary.LittleEndian.AppendUint32([]byte{iderRegistersToggle}, s.enable))
+}
+
+func (s *iderSession) featuresReply(ctx context.Context, kind byte, value uint32) error {
+	switch kind {
+	case iderRegistersAvailable:
+		if value&1 != 0 {
+			return s.sendEnable(ctx)
+		}
+	case iderRegistersToggle:
+		if value != 1 {
+			s.signal(errIDERNotEnabled)
+
+			return errIDERNotEnabled
+		}
+
+		s.signal(nil)
+	}
+
+	return nil
+}
+
+// command answers an ATAPI packet command of the device's host.
+func (s *iderSession) command(ctx context.Context, msg []byte) error {
+	device := byte(iderDeviceMaster)
+	if msg[14]&iderDeviceSlaveFlag != 0 {
+		device = iderDeviceSlave
+	}
+
+	dma := msg[9]&1 != 0
+	result := s.drive.command(device, msg[16:28])
+
+	switch {
+	case result.sense[0] != senseNoSense:
+		return s.endCommand(ctx, device, result.sense)
+	case result.read:
+		return s.transfer(ctx, device, result.lba, result.count, dma)
+	case len(result.data) > 0:
+		return s.dataToHost(ctx, device, result.data, true, dma)
+	default:
+		return s.endCommand(ctx, device, atapiSense{})
+	}
+}
+
+// transfer sends count sectors of the media from lba, in pieces the device can buffer.
+func (s *iderSession) transfer(ctx context.Context, device byte, lba, count int64, dma bool) error {
+	offset := lba * s.drive.sectorSize
+	remaining := count * s.drive.sectorSize
+	buf := make([]byte, s.readBuffer)
+
+	for remaining > 0 {
+		select {
+		case <-s.done:
+			return errIDERClosed
+		default:
+		}
+
+		chunk := buf[:min(remaining, int64(len(buf)))]
+
+		n, err := s.drive.media.ReadAt(chunk, offset)
+		if err != nil && !errors.Is(err, io.EOF) {
+			s.uc.log.Warn("devices - ider - reading the image for " + s.info.GUID + ": " + err.Error())
+
+			return s.endCommand(ctx, device, atapiSense{senseMediumError, ascUnrecoveredRead})
+		}
+
+		// the padding of a last partial sector
+		clear(chunk[n:])
+
+		remaining -= int64(len(chunk))
+		offset += int64(len(chunk))
+
+		if err := s.dataToHost(ctx, device, chunk, remaining == 0, dma); err != nil {
+			return err
+		}
+
+		s.mu.Lock()
+		s.info.BytesRead += int64(len(chunk))
+		s.mu.Unlock()
+	}
+
+	return nil
+}
+
+// dataToHost sends data for the current command, the last piece also ends the command with a good status.
+func (s *iderSession) dataToHost(ctx context.Context, device byte, data []byte, completed, dma bool) error {
+	length := len(data)
+	pioLength := length
+
+	mode := byte(0xB5)
+	attributes := byte(0)
+
+	if dma {
+		mode = 0xB4
+		pioLength = 0
+		attributes |= iderAttributeDMA
+	}
+
+	msg := []byte{
+		0, byte(length), byte(length >> 8), 0, mode, 0, 2, 0, byte(pioLength), byte(pioLength >> 8), device, 0x58,
+		0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	}
+
+	if completed {
+		attributes |= iderAttributeCompleted
+		msg[12], msg[14], msg[18], msg[19] = 0x85, iderInterruptReasonStatus, device, iderStatusReady
+	}
+
+	return s.send(ctx, RedirectionCommandsIDERDataToHost, attributes, append(msg, data...))
+}
+
+// endCommand ends the current command, with the sense in the error register when it failed.
+func (s *iderSession) endCommand(ctx context.Context, device byte, sense atapiSense) error {
+	msg := []byte{0xC5, 0, iderInterruptReasonStatus, 0, 0, 0, device, iderStatusReady, 0, 0, 0}
+
+	if sense[0] != senseNoSense {
+		msg = []byte{0x87, sense[0] << 4, iderInterruptReasonStatus, 0, 0, 0, device, iderStatusError, sense[0], sense[1], sense[2]}
+	}
+
+	return s.send(ctx, RedirectionCommandsIDERCommandEndResponse, iderAttributeCompleted, msg)
+}
+
+// send writes an IDE-R message with the next sequence number.
+func (s *iderSession) send(ctx context.Context, command, attributes byte, payload []byte) error {
+	s.sendMu.Lock()
+	defer s.sendMu.Unlock()
+
+	msg := binary.LittleEndian.AppendUint32([]byte{command, 0, 0, attributes}, s.sequence)
+	s.sequence++
+
+	return s.uc.redirection.RedirectSend(ctx, s.conn, append(msg, payload...))
+}
+
+// sendRaw writes a message of the redirection protocol itself, which carries no IDE-R sequence number.
+func (s *iderSession) sendRaw(ctx context.Context, msg []byte) error {
+	s.sendMu.Lock()
+	defer s.sendMu.Unlock()
+
+	return s.uc.redirection.RedirectSend(ctx, s.conn, msg)
+}
+
+func (s *iderSession) signal(err error) {
+	select {
+	case s.ready <- err:
+	default:
+	}
+}
+
+func (s *iderSession) snapshot() dto.IDERSession {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.info
+}
+
+func (s *iderSession) fail(err error) {
+	select {
+	case <-s.done:
+		return
+	default:
+	}
+
+	s.signal(err)
+	s.uc.log.Warn("devices - ider - session with " + s.info.GUID + " ended: " + err.Error())
+	s.close()
+}
+
+// close ends the session with the device and closes the media, it is safe to call more than once and from any
+// goroutine.
+func (s *iderSession) close() {
+	s.closeOnce.Do(func() {
+		ctx := tenant.NewContext(context.Background(), s.tenantID)
+
+		s.uc.iderSessionsMu.Lock()
+		if s.uc.iderSessions[s.info.GUID] == s {
+			delete(s.uc.iderSessions, s.info.GUID)
+		}
+		s.uc.iderSessionsMu.Unlock()
+
+		s.mu.Lock()
+		mounted := s.info.State == dto.IDERStateMounted
+		s.info.State = dto.IDERStateClosed
+		s.mu.Unlock()
+
+		if mounted {
+			_ = s.send(ctx, RedirectionCommandsIDERCloseSession, 0, nil)
+			_ = s.sendRaw(ctx, redirectionEndSession)
+		}
+
+		s.signal(errIDERClosed)
+		close(s.done)
+
+		_ = s.uc.redirection.RedirectClose(ctx, s.conn)
+
+		if err := s.drive.media.Close(); err != nil {
+			s.uc.log.Warn("devices - ider - closing the image for " + s.info.GUID + ": " + err.Error())
+		}
+
+		if mounted {
+			s.uc.publish(ctx, dto.EventRedirectionClosed, &s.conn.Device, dto.RedirectionEvent{Mode: iderMode})
+		}
+	})
+}
+
+// iderMessageSize returns the size of the message at the start of msg, 0 when more data is needed to tell
+// and -1 when the message is unknown.
+func iderMessageSize(msg []byte) int {
+	if size, ok := handshakeMessageSize(msg); ok {
+		return size
+	}
+
+	switch msg[0] {
+	case RedirectionCommandsIDEROpenSessionReply:
+		if len(msg) < iderOpenSessionReplySize {
+			return 0
+		}
+
+		return iderOpenSessionReplySize + int(msg[29])
+	case RedirectionCommandsIDERCloseSessionReply, RedirectionCommandsIDERKeepAlivePing,
+		RedirectionCommandsIDERKeepAlivePong, RedirectionCommandsIDERHeartbeat:
+		return iderHeaderSize
+	case RedirectionCommandsIDERResetOccurred:
+		return iderResetOccurredSize
+	case RedirectionCommandsIDERDisableEnableFeaturesReply:
+		return iderFeaturesReplySize
+	case RedirectionCommandsIDERErrorOccurred:
+		return iderErrorOccurredSize
+	case RedirectionCommandsIDERCommandWritten:
+		return iderCommandWrittenSize
+	case RedirectionCommandsIDERDataFromHost:
+		if len(msg) < iderDataFromHostSize {
+			return 0
+		}
+
+		return iderDataFromHostSize + int(binary.LittleEndian.Uint16(msg[9:11]))
+	default:
+		return -1
+	}
+}
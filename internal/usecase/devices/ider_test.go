@@ -0,0 +1,264 @@
+package devices_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/open-amt-cloud-toolkit/go-wsman-messages/v2/pkg/wsman"
+	"github.com/stretchr/testify/require"
+	gomock "go.uber.org/mock/gomock"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity"
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	"github.com/open-amt-cloud-toolkit/console/internal/mocks"
+	devices "github.com/open-amt-cloud-toolkit/console/internal/usecase/devices"
+	"github.com/open-amt-cloud-toolkit/console/pkg/logger"
+	"github.com/open-amt-cloud-toolkit/console/pkg/tenant"
+)
+
+// iderDevice plays the device side of an IDE-R session: it answers the handshake and hands every IDE-R message
+// the console sends after it to the test.
+type iderDevice struct {
+	mu        sync.Mutex
+	enabled   uint32
+	enable    []byte
+	replies   chan []byte
+	sent      chan []byte
+	closeOnce sync.Once
+}
+
+func newIDERDevice(mockRedirection *mocks.MockRedirection, enabled uint32) *iderDevice {
+	d := &iderDevice{
+		enabled: enabled,
+		replies: make(chan []byte, 16),
+		sent:    make(chan []byte, 16),
+	}
+
+	mockRedirection.EXPECT().SetupWsmanClient(gomock.Any(), true, true).Return(wsman.Messages{}).AnyTimes()
+	mockRedirection.EXPECT().RedirectConnect(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	mockRedirection.EXPECT().RedirectSend(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ *devices.DeviceConnection, msg []byte) error {
+			d.receive(msg)
+
+			return nil
+		}).AnyTimes()
+	mockRedirection.EXPECT().RedirectListen(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ *devices.DeviceConnection) ([]byte, error) {
+			data, ok := <-d.replies
+			if !ok {
+				return nil, io.EOF
+			}
+
+			return data, nil
+		}).AnyTimes()
+	mockRedirection.EXPECT().RedirectClose(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ *devices.DeviceConnection) error {
+			d.closeOnce.Do(func() { close(d.replies) })
+
+			return nil
+		}).AnyTimes()
+
+	return d
+}
+
+func (d *iderDevice) receive(msg []byte) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	switch msg[0] {
+	case devices.RedirectionCommandsStartRedirectionSession:
+		d.replies <- []byte{devices.RedirectionCommandsStartRedirectionSessionReply, devices.StartRedirectionSessionReplyStatusSuccess, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}
+	case devices.RedirectionCommandsAuthenticateSession:
+		if msg[4] == devices.AuthenticationTypeQuery {
+			d.replies <- authReply(devices.AuthenticationStatusSuccess, devices.AuthenticationTypeQuery, []byte{devices.AuthenticationTypeDigest})
+		} else {
+			d.replies <- authReply(devices.AuthenticationStatusSuccess, devices.AuthenticationTypeDigest, nil)
+		}
+	case devices.RedirectionCommandsIDEROpenSession:
+		// a read buffer of one CD sector and protocol version 0
+		reply := make([]byte, 30)
+		reply[0] = devices.RedirectionCommandsIDEROpenSessionReply
+		binary.LittleEndian.PutUint16(reply[16:18], 2048)
+		d.replies <- reply
+	case devices.RedirectionCommandsIDERDisableEnableFeatures:
+		d.enable = msg
+		reply := []byte{devices.RedirectionCommandsIDERDisableEnableFeaturesReply, 0, 0, 0, 0, 0, 0, 0, 3}
+		d.replies <- binary.LittleEndian.AppendUint32(reply, d.enabled)
+	case devices.RedirectionCommandsEndRedirectionSession:
+	default:
+		d.sent <- msg
+	}
+}
+
+// iderCommand builds the message with an ATAPI packet command the device's host wrote to the slave channel.
+func iderCommand(cdb ...byte) []byte {
+	msg := make([]byte, 28)
+	msg[0] = devices.RedirectionCommandsIDERCommandWritten
+	msg[14] = 0x10
+	copy(msg[16:], cdb)
+
+	return msg
+}
+
+type iderImage struct {
+	*bytes.Reader
+	closed chan struct{}
+}
+
+func (m iderImage) Info() dto.IDERMedia {
+	return dto.IDERMedia{ImageID: "image-1", Name: "ubuntu.iso", Kind: dto.MediaKindCD, SizeBytes: m.Size()}
+}
+
+func (m iderImage) Close() error {
+	close(m.closed)
+
+	return nil
+}
+
+func newIDERImage() iderImage {
+	content := make([]byte, 4*2048)
+	for i := range content {
+		content[i] = byte(i / 2048)
+	}
+
+	return iderImage{Reader: bytes.NewReader(content), closed: make(chan struct{})}
+}
+
+func initIDERTest(t *testing.T, enabled uint32) (*devices.UseCase, *iderDevice) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+
+	repo := mocks.NewMockDeviceManagementRepository(ctrl)
+	repo.EXPECT().GetByID(gomock.Any(), "device-guid-123", "").Return(&entity.Device{
+		GUID:     "device-guid-123",
+		Username: "admin",
+		Password: "password",
+	}, nil).AnyTimes()
+	repo.EXPECT().GetByID(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+
+	mockRedirection := mocks.NewMockRedirection(ctrl)
+	device := newIDERDevice(mockRedirection, enabled)
+
+	uc := devices.New(repo, mocks.NewMockWSMAN(ctrl), mockRedirection, anyPublisher(ctrl), mocks.NewMockRedirectionRecorder(ctrl), nil, logger.New("error"), mocks.MockCrypto{})
+
+	return uc, device
+}
+
+func receiveIDER(t *testing.T, sent <-chan []byte) []byte {
+	t.Helper()
+
+	select {
+	case msg := <-sent:
+		return msg
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "no IDE-R message from the console")
+
+		return nil
+	}
+}
+
+func TestIDERSession(t *testing.T) {
+	t.Parallel()
+
+	uc, device := initIDERTest(t, 1)
+	ctx := context.Background()
+	image := newIDERImage()
+
+	session, err := uc.OpenIDER(ctx, "device-guid-123", image, devices.IDERStartOnReboot)
+	require.NoError(t, err)
+	require.Equal(t, dto.IDERStateMounted, session.State)
+	require.Equal(t, "ubuntu.iso", session.Media.Name)
+
+	// the open session took sequence number 0, the image is attached at the next reset
+	require.Equal(t, []byte{devices.RedirectionCommandsIDERDisableEnableFeatures, 0, 0, 0, 1, 0, 0, 0, 3, 0x09, 0, 0, 0}, device.enable)
+
+	_, err = uc.OpenIDER(ctx, "device-guid-123", newIDERImage(), devices.IDERStartNow)
+	require.IsType(t, dto.NotValidError{}, err)
+
+	// read two sectors from lba 1, in pieces of the device's read buffer
+	device.replies <- iderCommand(0x28, 0, 0, 0, 0, 1, 0, 0, 2)
+
+	first := receiveIDER(t, device.sent)
+	require.Equal(t, byte(devices.RedirectionCommandsIDERDataToHost), first[0])
+	require.Equal(t, byte(0), first[3])
+	require.Len(t, first, 8+26+2048)
+	require.Equal(t, bytes.Repeat([]byte{1}, 2048), first[34:])
+
+	last := receiveIDER(t, device.sent)
+	require.Equal(t, byte(0x02), last[3])
+	require.Equal(t, byte(0x50), last[8+19])
+	require.Equal(t, bytes.Repeat([]byte{2}, 2048), last[34:])
+
+	// the CD is on the slave channel, the master has no media
+	master := iderCommand(0x00)
+	master[14] = 0
+	device.replies <- master
+
+	end := receiveIDER(t, device.sent)
+	require.Equal(t, []byte{devices.RedirectionCommandsIDERCommandEndResponse, 0, 0, 0x02, 4, 0, 0, 0, 0x87, 0x20, 3, 0, 0, 0, 0xA0, 0x51, 2, 0x3A, 0}, end)
+
+	got, err := uc.GetIDER(ctx, "device-guid-123")
+	require.NoError(t, err)
+	require.Equal(t, int64(4096), got.BytesRead)
+
+	_, err = uc.GetIDER(tenant.NewContext(ctx, "other"), "device-guid-123")
+	require.ErrorIs(t, err, devices.ErrNotFound)
+
+	require.NoError(t, uc.CloseIDER(ctx, "device-guid-123"))
+
+	closeSession := receiveIDER(t, device.sent)
+	require.Equal(t, byte(devices.RedirectionCommandsIDERCloseSession), closeSession[0])
+
+	select {
+	case <-image.closed:
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "the image was not closed")
+	}
+
+	_, err = uc.GetIDER(ctx, "device-guid-123")
+	require.ErrorIs(t, err, devices.ErrNotFound)
+}
+
+func TestOpenIDERFails(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		guid    string
+		start   string
+		enabled uint32
+	}{
+		{name: "unknown device", guid: "unknown-guid", start: devices.IDERStartNow, enabled: 1},
+		{name: "unknown start", guid: "device-guid-123", start: "later", enabled: 1},
+		{name: "not enabled", guid: "device-guid-123", start: devices.IDERStartNow, enabled: 0},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			uc, _ := initIDERTest(t, tc.enabled)
+			image := newIDERImage()
+
+			_, err := uc.OpenIDER(context.Background(), tc.guid, image, tc.start)
+			require.Error(t, err)
+
+			select {
+			case <-image.closed:
+			case <-time.After(5 * time.Second):
+				require.FailNow(t, "the image was not closed")
+			}
+
+			_, err = uc.GetIDER(context.Background(), tc.guid)
+			require.ErrorIs(t, err, devices.ErrNotFound)
+		})
+	}
+}
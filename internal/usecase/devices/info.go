@@ -9,10 +9,11 @@ import (
 
 	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
 	dtov2 "github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v2"
+	"github.com/open-amt-cloud-toolkit/console/pkg/tenant"
 )
 
 func (uc *UseCase) GetVersion(c context.Context, guid string) (v1 dto.Version, v2 dtov2.Version, err error) {
-	item, err := uc.repo.GetByID(c, guid, "")
+	item, err := uc.repo.GetByID(c, guid, tenant.FromContext(c))
 	if err != nil {
 		return v1, v2, err
 	}
@@ -60,7 +61,7 @@ func (uc *UseCase) GetVersion(c context.Context, guid string) (v1 dto.Version, v
 }
 
 func (uc *UseCase) GetHardwareInfo(c context.Context, guid string) (interface{}, error) {
-	item, err := uc.repo.GetByID(c, guid, "")
+	item, err := uc.repo.GetByID(c, guid, tenant.FromContext(c))
 	if err != nil {
 		return nil, err
 	}
@@ -80,7 +81,7 @@ func (uc *UseCase) GetHardwareInfo(c context.Context, guid string) (interface{},
 }
 
 func (uc *UseCase) GetDiskInfo(c context.Context, guid string) (interface{}, error) {
-	item, err := uc.repo.GetByID(c, guid, "")
+	item, err := uc.repo.GetByID(c, guid, tenant.FromContext(c))
 	if err != nil {
 		return nil, err
 	}
@@ -100,7 +101,7 @@ func (uc *UseCase) GetDiskInfo(c context.Context, guid string) (interface{}, err
 }
 
 func (uc *UseCase) GetAuditLog(c context.Context, startIndex int, guid string) (dto.AuditLog, error) {
-	item, err := uc.repo.GetByID(c, guid, "")
+	item, err := uc.repo.GetByID(c, guid, tenant.FromContext(c))
 	if err != nil {
 		return dto.AuditLog{}, err
 	}
@@ -124,7 +125,7 @@ func (uc *UseCase) GetAuditLog(c context.Context, startIndex int, guid string) (
 }
 
 func (uc *UseCase) GetEventLog(c context.Context, startIndex, maxReadRecords int, guid string) (dto.EventLogs, error) {
-	item, err := uc.repo.GetByID(c, guid, "")
+	item, err := uc.repo.GetByID(c, guid, tenant.FromContext(c))
 	if err != nil {
 		return dto.EventLogs{}, err
 	}
@@ -148,22 +149,26 @@ func (uc *UseCase) GetEventLog(c context.Context, startIndex, maxReadRecords int
 		for idx := range eventLogs.RefinedEventData {
 			event := &eventLogs.RefinedEventData[idx]
 			dtoEvent := dto.EventLog{
-				// DeviceAddress:   event.DeviceAddress,
-				// EventSensorType: event.EventSensorType,
-				// EventType:       event.EventType,
-				// EventOffset:     event.EventOffset,
-				// EventSourceType: event.EventSourceType,
-				EventSeverity: event.EventSeverity,
-				// SensorNumber:    event.SensorNumber,
-				Entity: event.Entity,
-				// EntityInstance:  event.EntityInstance,
-				// EventData:       event.EventData,
-				Time: event.TimeStamp.String(),
+				DeviceAddress:   int(event.DeviceAddress),
+				EventSensorType: int(event.EventSensorType),
+				EventType:       int(event.EventType),
+				EventOffset:     int(event.EventOffset),
+				EventSourceType: int(event.EventSourceType),
+				EventSeverity:   event.EventSeverity,
+				SensorNumber:    int(event.SensorNumber),
+				Entity:          event.Entity,
+				EntityInstance:  int(event.EntityInstance),
+				EventData:       make([]int, len(event.EventData)),
+				Time:            event.TimeStamp.String(),
 				// EntityStr:       event.EntityStr,
 				Description: event.Description,
 				// EventTypeDesc:   event.EventTypeDesc,
 			}
 
+			for i, b := range event.EventData {
+				dtoEvent.EventData[i] = int(b)
+			}
+
 			events[idx] = dtoEvent
 		}
 	}
@@ -175,7 +180,7 @@ func (uc *UseCase) GetEventLog(c context.Context, startIndex, maxReadRecords int
 }
 
 func (uc *UseCase) GetGeneralSettings(c context.Context, guid string) (interface{}, error) {
-	item, err := uc.repo.GetByID(c, guid, "")
+	item, err := uc.repo.GetByID(c, guid, tenant.FromContext(c))
 	if err != nil {
 		return nil, err
 	}
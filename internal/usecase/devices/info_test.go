@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/xml"
 	"testing"
+	"time"
 
 	"github.com/open-amt-cloud-toolkit/go-wsman-messages/v2/pkg/wsman/amt/auditlog"
 	"github.com/open-amt-cloud-toolkit/go-wsman-messages/v2/pkg/wsman/amt/messagelog"
@@ -29,13 +30,12 @@ func initInfoTest(t *testing.T) (*devices.UseCase, *mocks.MockWSMAN, *mocks.Mock
 	repo := mocks.NewMockDeviceManagementRepository(mockCtl)
 
 	wsmanMock := mocks.NewMockWSMAN(mockCtl)
-	wsmanMock.EXPECT().Worker().Return().AnyTimes()
 
 	management := mocks.NewMockManagement(mockCtl)
 
 	log := logger.New("error")
 
-	u := devices.New(repo, wsmanMock, mocks.NewMockRedirection(mockCtl), log, mocks.MockCrypto{})
+	u := devices.New(repo, wsmanMock, mocks.NewMockRedirection(mockCtl), anyPublisher(mockCtl), nil, nil, log, mocks.MockCrypto{})
 
 	return u, wsmanMock, management, repo
 }
@@ -444,6 +444,56 @@ func TestGetEventLog(t *testing.T) {
 			res: dto.EventLogs{},
 			err: nil,
 		},
+		{
+			name:   "success - with records",
+			action: 0,
+			manMock: func(man *mocks.MockWSMAN, man2 *mocks.MockManagement) {
+				man.EXPECT().
+					SetupWsmanClient(gomock.Any(), false, true).
+					Return(man2)
+				man2.EXPECT().
+					GetEventLog(1, 10).
+					Return(messagelog.GetRecordsResponse{
+						RefinedEventData: []messagelog.RefinedEventData{{
+							TimeStamp:       time.Unix(1704511587, 0).UTC(),
+							DeviceAddress:   255,
+							Description:     "Authentication failed 10 times. The system may be under attack.",
+							Entity:          "Intel(r) ME",
+							EntityInstance:  97,
+							EventData:       []uint8{170, 10, 0},
+							EventSensorType: 6,
+							EventType:       111,
+							EventOffset:     5,
+							EventSourceType: 104,
+							EventSeverity:   "Critical condition",
+							SensorNumber:    255,
+						}},
+					}, nil)
+			},
+			repoMock: func(repo *mocks.MockDeviceManagementRepository) {
+				repo.EXPECT().
+					GetByID(context.Background(), device.GUID, "").
+					Return(device, nil)
+			},
+			res: dto.EventLogs{
+				Records: []dto.EventLog{{
+					DeviceAddress:   255,
+					EventSensorType: 6,
+					EventType:       111,
+					EventOffset:     5,
+					EventSourceType: 104,
+					EventSeverity:   "Critical condition",
+					SensorNumber:    255,
+					Entity:          "Intel(r) ME",
+					EntityInstance:  97,
+					EventData:       []int{170, 10, 0},
+					Time:            "2024-01-06 03:26:27 +0000 UTC",
+					Description:     "Authentication failed 10 times. The system may be under attack.",
+				}},
+				HasMoreRecords: true,
+			},
+			err: nil,
+		},
 		{
 			name:    "GetById fails",
 			action:  0,
@@ -11,12 +11,22 @@ import (
 	"io"
 	"log"
 	"math"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/gorilla/websocket"
 	"github.com/open-amt-cloud-toolkit/go-wsman-messages/v2/pkg/wsman"
 	"github.com/open-amt-cloud-toolkit/go-wsman-messages/v2/pkg/wsman/client"
 
 	"github.com/open-amt-cloud-toolkit/console/internal/entity"
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/recordings"
+	"github.com/open-amt-cloud-toolkit/console/pkg/clientip"
+	"github.com/open-amt-cloud-toolkit/console/pkg/subject"
+	"github.com/open-amt-cloud-toolkit/console/pkg/tenant"
 )
 
 const (
@@ -33,11 +43,28 @@ type DeviceConnection struct {
 	Direct        bool
 	Mode          string
 	Challenge     client.AuthChallenge
+	// Recording receives every message of the session when sessions of its Mode are recorded.
+	Recording recordings.Session
+	// ID, Subject, ClientIP and StartTime describe a session relayed for a browser, who opened it from where.
+	ID        string
+	Subject   string
+	ClientIP  string
+	StartTime time.Time
+	tenantID  string
+	// the bytes relayed each way, the listen goroutines count them while the API reads them
+	bytesToDevice   atomic.Int64
+	bytesFromDevice atomic.Int64
+	// lastActivity is when the user last gave input, in unix nanoseconds
+	lastActivity atomic.Int64
+	done         chan struct{}
+	closeOnce    sync.Once
+	// vncConn is the client of a session the VNC gateway relays, Conn is nil then.
+	vncConn net.Conn
 }
 
 func (uc *UseCase) Redirect(c context.Context, conn *websocket.Conn, guid, mode string) error {
 	// grab device info from db
-	device, err := uc.repo.GetByID(c, guid, "")
+	device, err := uc.repo.GetByID(c, guid, tenant.FromContext(c))
 	if err != nil {
 		return err
 	}
@@ -46,38 +73,64 @@ func (uc *UseCase) Redirect(c context.Context, conn *websocket.Conn, guid, mode
 		return ErrNotFound
 	}
 
-	key := device.GUID + "-" + mode
 	// setup wsman messages with support for talking on 16994 over tcp
-	var deviceConnection *DeviceConnection
-	if _, ok := uc.redirConnections[key]; ok {
-		deviceConnection = uc.redirConnections[key]
-	} else {
-		wsmanConnection := uc.redirection.SetupWsmanClient(*device, true, true)
-
-		device.Password, _ = uc.safeRequirements.Decrypt(device.Password)
-
-		deviceConnection = &DeviceConnection{
-			Conn:          conn,
-			wsmanMessages: wsmanConnection,
-			Device:        *device,
-			Direct:        false,
-			Mode:          mode,
-			Challenge: client.AuthChallenge{
-				Username: device.Username,
-				Password: device.Password,
-			},
+	wsmanConnection := uc.redirection.SetupWsmanClient(*device, true, true)
+
+	device.Password, _ = uc.safeRequirements.Decrypt(device.Password)
+
+	deviceConnection := &DeviceConnection{
+		Conn:          conn,
+		wsmanMessages: wsmanConnection,
+		Device:        *device,
+		Direct:        false,
+		Mode:          mode,
+		Challenge: client.AuthChallenge{
+			Username: device.Username,
+			Password: device.Password,
+		},
+		ID:        uuid.New().String(),
+		Subject:   subject.FromContext(c),
+		ClientIP:  clientip.FromContext(c),
+		StartTime: time.Now(),
+		tenantID:  tenant.FromContext(c),
+		done:      make(chan struct{}),
+	}
+
+	policy, err := uc.redirectionPolicy(c, deviceConnection.tenantID)
+	if err != nil {
+		return err
+	}
+
+	if code, err := uc.reserveRedirection(deviceConnection, policy); err != nil {
+		if conn != nil {
+			closeBrowser(conn, code, err.Error())
+			_ = conn.Close()
 		}
-		uc.redirConnections[key] = deviceConnection
+
+		return ErrNotValid.Wrap("Redirect", "uc.reserveRedirection", err)
+	}
+
+	deviceConnection.Recording, err = uc.recorder.Record(c, *device, mode)
+	if err != nil {
+		uc.releaseRedirection(deviceConnection)
+
+		return err
 	}
 
 	err = uc.redirection.RedirectConnect(c, deviceConnection)
 	if err != nil {
+		uc.releaseRedirection(deviceConnection)
+		uc.stopRecording(deviceConnection)
+
 		return err
 	}
 
+	uc.publish(c, dto.EventRedirectionOpened, device, dto.RedirectionEvent{Mode: mode})
+
 	// To Do: scoop the errors out of this for logging
 	go uc.ListenToDevice(c, deviceConnection)
 	go uc.ListenToBrowser(c, deviceConnection)
+	go uc.watchRedirection(c, deviceConnection, policy)
 
 	return nil
 }
@@ -85,6 +138,9 @@ func (uc *UseCase) Redirect(c context.Context, conn *websocket.Conn, guid, mode
 func (uc *UseCase) ListenToDevice(c context.Context, deviceConnection *DeviceConnection) {
 	conn := deviceConnection.Conn // This is now of type WebSocketConnInterface
 
+	// the browser is told the session is over once the device ends it
+	defer uc.closeRedirection(c, deviceConnection, 0, "")
+
 	for {
 		data, err := uc.redirection.RedirectListen(c, deviceConnection)
 		if err != nil {
@@ -95,18 +151,19 @@ func (uc *UseCase) ListenToDevice(c context.Context, deviceConnection *DeviceCon
 			continue
 		}
 
+		deviceConnection.bytesFromDevice.Add(int64(len(data)))
+
 		toSend := data
 		if !deviceConnection.Direct {
 			toSend, deviceConnection.Direct = processDeviceData(toSend, &deviceConnection.Challenge)
 		}
 
+		deviceConnection.record(recordings.FromDevice, toSend)
+
 		err = conn.WriteMessage(websocket.BinaryMessage, toSend)
 		if err != nil {
 			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseAbnormalClosure) {
 				_ = fmt.Errorf("interceptor - listenToDevice - websocket closed unexpectedly (writing to browser): %w", err)
-
-				uc.redirection.RedirectClose(c, deviceConnection)
-				delete(uc.redirConnections, deviceConnection.Device.GUID+"-"+deviceConnection.Mode)
 			}
 
 			return
@@ -115,19 +172,34 @@ func (uc *UseCase) ListenToDevice(c context.Context, deviceConnection *DeviceCon
 }
 
 func (uc *UseCase) ListenToBrowser(c context.Context, deviceConnection *DeviceConnection) {
+	defer uc.publish(c, dto.EventRedirectionClosed, &deviceConnection.Device, dto.RedirectionEvent{Mode: deviceConnection.Mode})
+	defer uc.stopRecording(deviceConnection)
+
+	// the browser's RFB client starts its handshake once the session is authenticated
+	var rfb rfbInput
+
 	for {
 		_, msg, err := deviceConnection.Conn.ReadMessage()
 		if err != nil {
 			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseAbnormalClosure) {
 				_ = fmt.Errorf("interceptor - listenToBrowser - websocket closed unexpectedly (reading from browser): %w", err)
-
-				uc.redirection.RedirectClose(c, deviceConnection)
-				delete(uc.redirConnections, deviceConnection.Device.GUID+"-"+deviceConnection.Mode)
 			}
 
+			// the device connection is closed whichever way the browser went, or the session was terminated
+			uc.closeRedirection(c, deviceConnection, 0, "")
+
 			break
 		}
 
+		deviceConnection.bytesToDevice.Add(int64(len(msg)))
+
+		if deviceConnection.Direct && deviceConnection.fromUser(&rfb, msg) {
+			deviceConnection.touch()
+		}
+
+		// what the browser sent is recorded, not the digest the console answers the device's challenge with
+		deviceConnection.record(recordings.FromBrowser, msg)
+
 		toSend := msg
 		if !deviceConnection.Direct {
 			toSend = processBrowserData(msg, &deviceConnection.Challenge)
@@ -140,6 +212,36 @@ func (uc *UseCase) ListenToBrowser(c context.Context, deviceConnection *DeviceCo
 	}
 }
 
+// fromUser reports whether what the browser sent once the session was authenticated is the user's input, only
+// that keeps a session from being idle: a KVM viewer keeps asking for the screen and the device keeps sending it
+// whether anyone is there or not.
+func (dc *DeviceConnection) fromUser(rfb *rfbInput, msg []byte) bool {
+	switch dc.Mode {
+	case recordings.ModeKVM:
+		return rfb.Scan(msg)
+	case recordings.ModeSOL:
+		return len(msg) > 0 && msg[0] == RedirectionCommandsSOLDataToDevice
+	default:
+		return true
+	}
+}
+
+func (dc *DeviceConnection) record(direction recordings.Direction, data []byte) {
+	if dc.Recording != nil && len(data) > 0 {
+		dc.Recording.Write(direction, data)
+	}
+}
+
+func (uc *UseCase) stopRecording(deviceConnection *DeviceConnection) {
+	if deviceConnection.Recording == nil {
+		return
+	}
+
+	if err := deviceConnection.Recording.Close(); err != nil {
+		uc.log.Error(err, "interceptor - stopRecording")
+	}
+}
+
 func processBrowserData(msg []byte, challenge *client.AuthChallenge) []byte {
 	switch msg[0] {
 	case RedirectionCommandsStartRedirectionSession:
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"io"
 	"math"
+	"slices"
 	"strings"
 	"testing"
 
@@ -699,3 +700,68 @@ func TestHandleAuthenticationSession(t *testing.T) {
 		})
 	}
 }
+
+func TestFromUser(t *testing.T) {
+	t.Parallel()
+
+	handshake := []byte("RFB 003.008\n\x01\x01")
+	encodings := []byte{rfbSetEncodings, 0, 0, 2, 0, 0, 0, 16, 0, 0, 0, 0}
+	updateRequest := []byte{rfbFramebufferUpdateRequest, 1, 0, 0, 0, 0, 4, 0, 3, 0}
+	key := []byte{rfbKeyEvent, 1, 0, 0, 0, 0, 0, 0x61}
+	pointer := []byte{rfbPointerEvent, 0, 0, 10, 0, 20}
+	cutText := []byte{rfbClientCutText, 0, 0, 0, 0, 0, 0, 3, 'a', 'b', 'c'}
+
+	tests := []struct {
+		name     string
+		mode     string
+		msgs     [][]byte
+		expected []bool
+	}{
+		{
+			name:     "kvm viewer asking for the screen",
+			mode:     "kvm",
+			msgs:     [][]byte{handshake, encodings, updateRequest, updateRequest},
+			expected: []bool{false, false, false, false},
+		},
+		{
+			name:     "kvm key and pointer events",
+			mode:     "kvm",
+			msgs:     [][]byte{handshake, append(slices.Clone(updateRequest), key...), pointer, cutText},
+			expected: []bool{false, true, true, false},
+		},
+		{
+			name:     "kvm messages split across reads",
+			mode:     "kvm",
+			msgs:     [][]byte{handshake[:5], handshake[5:], encodings[:2], append(slices.Clone(encodings[2:]), updateRequest[:3]...), append(slices.Clone(updateRequest[3:]), key[:1]...), key[1:]},
+			expected: []bool{false, false, false, false, true, false},
+		},
+		{
+			name:     "kvm message unknown to the console",
+			mode:     "kvm",
+			msgs:     [][]byte{handshake, {255, 0, 0}, updateRequest},
+			expected: []bool{false, true, true},
+		},
+		{
+			name:     "sol keys typed",
+			mode:     "sol",
+			msgs:     [][]byte{{RedirectionCommandsSOLDataToDevice, 0, 0, 0, 1, 0, 0, 0, 1, 0, 'a'}, {RedirectionCommandsSOLKeepAlive, 0, 0, 0, 2, 0, 0, 0}},
+			expected: []bool{true, false},
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			dc := &DeviceConnection{Mode: tc.mode}
+
+			var rfb rfbInput
+
+			for i, msg := range tc.msgs {
+				require.Equal(t, tc.expected[i], dc.fromUser(&rfb, msg), "message %d", i)
+			}
+		})
+	}
+}
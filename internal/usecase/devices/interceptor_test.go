@@ -2,7 +2,6 @@ package devices_test
 
 import (
 	"context"
-	"sync"
 	"testing"
 
 	"github.com/gorilla/websocket"
@@ -13,6 +12,7 @@ import (
 	"github.com/open-amt-cloud-toolkit/console/internal/entity"
 	"github.com/open-amt-cloud-toolkit/console/internal/mocks"
 	devices "github.com/open-amt-cloud-toolkit/console/internal/usecase/devices"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/recordings"
 	"github.com/open-amt-cloud-toolkit/console/pkg/logger"
 )
 
@@ -25,35 +25,58 @@ func TestRedirect(t *testing.T) {
 
 	tests := []struct {
 		name        string
-		setup       func(*mocks.MockRedirection, *mocks.MockDeviceManagementRepository, *mocks.MockWSMAN, *sync.WaitGroup)
+		setup       func(*mocks.MockRedirection, *mocks.MockDeviceManagementRepository, *mocks.MockRedirectionRecorder, *mocks.MockRecordingSession)
 		expectedErr error
 	}{
 		{
 			name: "GetByID fail redirection",
-			setup: func(_ *mocks.MockRedirection, mockRepo *mocks.MockDeviceManagementRepository, mockWSMAN *mocks.MockWSMAN, wg *sync.WaitGroup) {
-				mockWSMAN.EXPECT().Worker().Do(func() {
-					defer wg.Done()
-				}).Times(1)
+			setup: func(_ *mocks.MockRedirection, mockRepo *mocks.MockDeviceManagementRepository, _ *mocks.MockRedirectionRecorder, _ *mocks.MockRecordingSession) {
 				mockRepo.EXPECT().GetByID(gomock.Any(), guid, "").Return(nil, ErrGeneral)
 			},
 			expectedErr: ErrGeneral,
 		},
 		{
 			name: "RedirectConnect fail redirection",
-			setup: func(mockRedir *mocks.MockRedirection, mockRepo *mocks.MockDeviceManagementRepository, mockWSMAN *mocks.MockWSMAN, wg *sync.WaitGroup) {
-				mockWSMAN.EXPECT().Worker().Do(func() {
-					defer wg.Done()
-				}).Times(1)
+			setup: func(mockRedir *mocks.MockRedirection, mockRepo *mocks.MockDeviceManagementRepository, mockRecorder *mocks.MockRedirectionRecorder, _ *mocks.MockRecordingSession) {
 				mockRepo.EXPECT().GetByID(gomock.Any(), guid, "").Return(&entity.Device{
 					GUID:     guid,
 					Username: "user",
 					Password: "pass",
 				}, nil)
 				mockRedir.EXPECT().SetupWsmanClient(gomock.Any(), true, true).Return(wsman.Messages{})
+				mockRecorder.EXPECT().Record(gomock.Any(), gomock.Any(), mode).Return(nil, nil)
 				mockRedir.EXPECT().RedirectConnect(gomock.Any(), gomock.Any()).Return(ErrGeneral)
 			},
 			expectedErr: ErrGeneral,
 		},
+		{
+			name: "RedirectConnect fail closes recording",
+			setup: func(mockRedir *mocks.MockRedirection, mockRepo *mocks.MockDeviceManagementRepository, mockRecorder *mocks.MockRedirectionRecorder, mockSession *mocks.MockRecordingSession) {
+				mockRepo.EXPECT().GetByID(gomock.Any(), guid, "").Return(&entity.Device{
+					GUID:     guid,
+					Username: "user",
+					Password: "pass",
+				}, nil)
+				mockRedir.EXPECT().SetupWsmanClient(gomock.Any(), true, true).Return(wsman.Messages{})
+				mockRecorder.EXPECT().Record(gomock.Any(), gomock.Any(), mode).Return(mockSession, nil)
+				mockRedir.EXPECT().RedirectConnect(gomock.Any(), gomock.Any()).Return(ErrGeneral)
+				mockSession.EXPECT().Close().Return(nil)
+			},
+			expectedErr: ErrGeneral,
+		},
+		{
+			name: "Record fail redirection",
+			setup: func(mockRedir *mocks.MockRedirection, mockRepo *mocks.MockDeviceManagementRepository, mockRecorder *mocks.MockRedirectionRecorder, _ *mocks.MockRecordingSession) {
+				mockRepo.EXPECT().GetByID(gomock.Any(), guid, "").Return(&entity.Device{
+					GUID:     guid,
+					Username: "user",
+					Password: "pass",
+				}, nil)
+				mockRedir.EXPECT().SetupWsmanClient(gomock.Any(), true, true).Return(wsman.Messages{})
+				mockRecorder.EXPECT().Record(gomock.Any(), gomock.Any(), mode).Return(nil, ErrGeneral)
+			},
+			expectedErr: ErrGeneral,
+		},
 	}
 
 	for _, tc := range tests {
@@ -67,16 +90,11 @@ func TestRedirect(t *testing.T) {
 			mockRedirection := mocks.NewMockRedirection(ctrl)
 			mockRepo := mocks.NewMockDeviceManagementRepository(ctrl)
 			mockWSMAN := mocks.NewMockWSMAN(ctrl)
+			mockRecorder := mocks.NewMockRedirectionRecorder(ctrl)
 
-			var wg sync.WaitGroup
+			tc.setup(mockRedirection, mockRepo, mockRecorder, mocks.NewMockRecordingSession(ctrl))
 
-			wg.Add(1)
-
-			tc.setup(mockRedirection, mockRepo, mockWSMAN, &wg)
-
-			uc := devices.New(mockRepo, mockWSMAN, mockRedirection, logger.New("test"), mocks.MockCrypto{})
-
-			wg.Wait()
+			uc := devices.New(mockRepo, mockWSMAN, mockRedirection, anyPublisher(ctrl), mockRecorder, nil, logger.New("test"), mocks.MockCrypto{})
 
 			err := uc.Redirect(context.Background(), mockConn, guid, mode)
 
@@ -89,3 +107,35 @@ func TestRedirect(t *testing.T) {
 		})
 	}
 }
+
+func TestListenToBrowserRecords(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+
+	mockRedirection := mocks.NewMockRedirection(ctrl)
+	mockConn := mocks.NewMockWebSocketConn(ctrl)
+	mockSession := mocks.NewMockRecordingSession(ctrl)
+
+	uc := devices.New(mocks.NewMockDeviceManagementRepository(ctrl), mocks.NewMockWSMAN(ctrl), mockRedirection, anyPublisher(ctrl), nil, nil, logger.New("test"), mocks.MockCrypto{})
+
+	deviceConnection := &devices.DeviceConnection{
+		Conn:      mockConn,
+		Device:    entity.Device{GUID: "device-guid-123"},
+		Direct:    true,
+		Mode:      "sol",
+		Recording: mockSession,
+	}
+
+	gomock.InOrder(
+		mockConn.EXPECT().ReadMessage().Return(websocket.BinaryMessage, []byte("key"), nil),
+		mockSession.EXPECT().Write(recordings.FromBrowser, []byte("key")),
+		mockRedirection.EXPECT().RedirectSend(gomock.Any(), deviceConnection, []byte("key")).Return(nil),
+		mockConn.EXPECT().ReadMessage().Return(0, nil, &websocket.CloseError{Code: websocket.CloseGoingAway}),
+		mockConn.EXPECT().Close().Return(nil),
+		mockRedirection.EXPECT().RedirectClose(gomock.Any(), deviceConnection).Return(nil),
+		mockSession.EXPECT().Close().Return(nil),
+	)
+
+	uc.ListenToBrowser(context.Background(), deviceConnection)
+}
@@ -2,6 +2,8 @@ package devices
 
 import (
 	"context"
+	"io"
+	"time"
 
 	"github.com/gorilla/websocket"
 	"github.com/open-amt-cloud-toolkit/go-wsman-messages/v2/pkg/wsman"
@@ -11,18 +13,19 @@ import (
 	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
 	dtov2 "github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v2"
 	wsmanAPI "github.com/open-amt-cloud-toolkit/console/internal/usecase/devices/wsman"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/recordings"
 )
 
 type (
 	WSMAN interface {
 		SetupWsmanClient(device entity.Device, isRedirection, logMessages bool) wsmanAPI.Management
 		DestroyWsmanClient(device dto.Device)
-		Worker()
 	}
 
 	WebSocketConn interface {
 		ReadMessage() (int, []byte, error)
 		WriteMessage(messageType int, data []byte) error
+		WriteControl(messageType int, data []byte, deadline time.Time) error
 		Close() error
 	}
 
@@ -33,6 +36,35 @@ type (
 		RedirectListen(ctx context.Context, deviceConnection *DeviceConnection) ([]byte, error)
 		RedirectSend(ctx context.Context, deviceConnection *DeviceConnection, message []byte) error
 	}
+	Publisher interface {
+		Publish(ctx context.Context, e dto.Event)
+	}
+	// IDERMedia is an image an IDE-R session serves, the session closes it when it ends.
+	IDERMedia interface {
+		io.ReaderAt
+		io.Closer
+		Info() dto.IDERMedia
+	}
+	Recorder interface {
+		Record(ctx context.Context, device entity.Device, mode string) (recordings.Session, error)
+	}
+	// VNC hands out the one-time passwords VNC clients open the KVM of a device with through the gateway.
+	VNC interface {
+		AllocateVNC(ctx context.Context, guid string) (dto.VNCAllocation, error)
+	}
+	// ThumbnailRepository stores the last screen captured of each device.
+	ThumbnailRepository interface {
+		Get(ctx context.Context, guid, tenantID string) (*entity.DeviceThumbnail, error)
+		Upsert(ctx context.Context, t *entity.DeviceThumbnail) error
+	}
+	// Thumbnails serves the screens the thumbnailer captured.
+	Thumbnails interface {
+		GetThumbnail(ctx context.Context, guid string) (dto.DeviceThumbnail, error)
+	}
+	// Policies are the limits the sessions relayed for a tenant are held to.
+	Policies interface {
+		Get(ctx context.Context, tenantID string) (dto.RedirectionPolicy, error)
+	}
 	Repository interface {
 		GetCount(context.Context, string) (int, error)
 		Get(ctx context.Context, top, skip int, tenantID string) ([]entity.Device, error)
@@ -43,10 +75,14 @@ type (
 		Update(ctx context.Context, d *entity.Device) (bool, error)
 		Insert(ctx context.Context, d *entity.Device) (string, error)
 		GetByColumn(ctx context.Context, columnName, queryValue, tenantID string) ([]entity.Device, error)
+		GetConnectedCount(ctx context.Context, tenantID string) (int, error)
+		GetAllTenants(ctx context.Context, top, skip int) ([]entity.Device, error)
+		UpdateHealth(ctx context.Context, d *entity.Device) (bool, error)
 	}
 	Feature interface {
 		// Repository/Database Calls
 		GetCount(context.Context, string) (int, error)
+		GetConnectedCount(ctx context.Context, tenantID string) (int, error)
 		Get(ctx context.Context, top, skip int, tenantID string) ([]dto.Device, error)
 		GetByID(ctx context.Context, guid, tenantID string) (*dto.Device, error)
 		GetDistinctTags(ctx context.Context, tenantID string) ([]string, error)
@@ -70,14 +106,29 @@ type (
 		GetUserConsentCode(ctx context.Context, guid string) (dto.GetUserConsentMessage, error)
 		SendConsentCode(ctx context.Context, code dto.UserConsentCode, guid string) (dto.UserConsentMessage, error)
 		SendPowerAction(ctx context.Context, guid string, action int) (power.PowerActionResponse, error)
+		SendBulkPowerAction(ctx context.Context, req dto.BulkPowerActionRequest, tenantID string) (dto.BulkPowerActionJob, error)
+		GetBulkPowerActionJob(ctx context.Context, jobID string) (dto.BulkPowerActionJob, error)
 		SetBootOptions(ctx context.Context, guid string, bootSetting dto.BootSetting) (power.PowerActionResponse, error)
 		GetAuditLog(ctx context.Context, startIndex int, guid string) (dto.AuditLog, error)
 		GetEventLog(ctx context.Context, startIndex, maxReadRecords int, guid string) (dto.EventLogs, error)
 		Redirect(ctx context.Context, conn *websocket.Conn, guid, mode string) error
+		GetRedirectionSessions(ctx context.Context, tenantID string) ([]dto.RedirectionSession, error)
+		TerminateRedirectionSession(ctx context.Context, id, tenantID string) error
+		Screenshot(ctx context.Context, guid string) ([]byte, error)
+		OpenSOL(ctx context.Context, guid string) (dto.SOLSession, error)
+		GetSOL(ctx context.Context, guid string) (dto.SOLSession, error)
+		SendSOLKeys(ctx context.Context, guid, keys string) error
+		GetSOLScreen(ctx context.Context, guid string) (dto.SOLScreen, error)
+		SubscribeSOL(ctx context.Context, guid string) (<-chan string, error)
+		CloseSOL(ctx context.Context, guid string) error
+		OpenIDER(ctx context.Context, guid string, media IDERMedia, start string) (dto.IDERSession, error)
+		GetIDER(ctx context.Context, guid string) (dto.IDERSession, error)
+		CloseIDER(ctx context.Context, guid string) error
 		GetNetworkSettings(c context.Context, guid string) (dto.NetworkSettings, error)
 		GetCertificates(c context.Context, guid string) (dto.SecuritySettings, error)
 		GetTLSSettingData(c context.Context, guid string) ([]dto.SettingDataResponse, error)
 		GetDiskInfo(c context.Context, guid string) (interface{}, error)
+		GetInventory(c context.Context, guid string) (dto.DeviceInventory, error)
 		GetDeviceCertificate(c context.Context, guid string) (dto.Certificate, error)
 	}
 )
@@ -0,0 +1,137 @@
+package devices
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/devices/wsman"
+	"github.com/open-amt-cloud-toolkit/console/pkg/tenant"
+)
+
+// GetInventory reads the firmware, hardware and disks of a device.
+func (uc *UseCase) GetInventory(c context.Context, guid string) (dto.DeviceInventory, error) {
+	item, err := uc.repo.GetByID(c, guid, tenant.FromContext(c))
+	if err != nil {
+		return dto.DeviceInventory{}, err
+	}
+
+	if item == nil || item.GUID == "" {
+		return dto.DeviceInventory{}, ErrNotFound
+	}
+
+	device := uc.device.SetupWsmanClient(*item, false, true)
+
+	softwareIdentity, err := device.GetAMTVersion()
+	if err != nil {
+		return dto.DeviceInventory{}, err
+	}
+
+	inventory, err := device.GetInventory()
+	if err != nil {
+		return dto.DeviceInventory{}, err
+	}
+
+	version := uc.softwareIdentityEntityToDTOv2(softwareIdentity)
+
+	d1 := inventoryToDTO(&inventory)
+	d1.GUID = item.GUID
+	d1.TenantID = item.TenantID
+	d1.Firmware = dto.FirmwareInventory{
+		AMTVersion:  version.AMT,
+		AMTMajor:    majorVersion(version.AMT),
+		SKU:         version.SKU,
+		BuildNumber: version.BuildNumber,
+	}
+
+	return d1, nil
+}
+
+func inventoryToDTO(inventory *wsman.Inventory) dto.DeviceInventory {
+	hw := &inventory.Hardware
+
+	bios := hw.BiosResult.Body.GetResponse
+	chassis := hw.ChassisResult.Body.PackageResponse
+	card := hw.CardResult.Body.PackageResponse
+	processor := hw.ProcessorResult.Body.PackageResponse
+
+	d1 := dto.DeviceInventory{
+		BIOS: dto.BIOSInventory{
+			Manufacturer: bios.Manufacturer,
+			Version:      bios.Version,
+			ReleaseDate:  bios.ReleaseDate.DateTime,
+		},
+		Chassis: dto.ComponentInventory{
+			Manufacturer: chassis.Manufacturer,
+			Model:        chassis.Model,
+			SerialNumber: chassis.SerialNumber,
+			Version:      chassis.Version,
+		},
+		Board: dto.ComponentInventory{
+			Manufacturer: card.Manufacturer,
+			Model:        card.Model,
+			SerialNumber: card.SerialNumber,
+			Version:      card.Version,
+		},
+		Processors: make([]dto.ProcessorInventory, 0, 1),
+		Memory:     make([]dto.MemoryInventory, 0),
+		Disks:      make([]dto.DiskInventory, 0),
+	}
+
+	// AMT reports a single CIM_Processor, empty when the platform does not expose it
+	if processor.DeviceID != "" || processor.ElementName != "" {
+		d1.Processors = append(d1.Processors, dto.ProcessorInventory{
+			Name:                 processor.ElementName,
+			Family:               processor.Family,
+			Stepping:             processor.Stepping,
+			MaxClockSpeedMHz:     processor.MaxClockSpeed,
+			CurrentClockSpeedMHz: processor.CurrentClockSpeed,
+		})
+	}
+
+	for i := range hw.PhysicalMemoryResult.Body.PullResponse.MemoryItems {
+		memory := &hw.PhysicalMemoryResult.Body.PullResponse.MemoryItems[i]
+
+		// Speed is in nanoseconds unless the module reports its speed in MHz
+		speed := memory.ConfiguredMemoryClockSpeed
+		if speed == 0 && memory.IsSpeedInMhz {
+			speed = memory.MaxMemorySpeed
+		}
+
+		d1.Memory = append(d1.Memory, dto.MemoryInventory{
+			BankLabel:     memory.BankLabel,
+			Manufacturer:  memory.Manufacturer,
+			PartNumber:    memory.PartNumber,
+			SerialNumber:  memory.SerialNumber,
+			CapacityBytes: int64(memory.Capacity),
+			SpeedMHz:      speed,
+		})
+
+		d1.TotalMemoryBytes += int64(memory.Capacity)
+	}
+
+	for i := range inventory.Disks.MediaAccessPullResult.Body.PullResponse.MediaAccessDevices {
+		disk := &inventory.Disks.MediaAccessPullResult.Body.PullResponse.MediaAccessDevices[i]
+
+		d1.Disks = append(d1.Disks, dto.DiskInventory{
+			DeviceID:       disk.DeviceID,
+			Name:           disk.ElementName,
+			MaxMediaSizeKB: disk.MaxMediaSize,
+		})
+	}
+
+	return d1
+}
+
+// majorVersion returns the major of a dotted version, 0 when there is none.
+func majorVersion(version string) int {
+	major, _, _ := strings.Cut(version, ".")
+
+	n, err := strconv.Atoi(major)
+	if err != nil {
+		return 0
+	}
+
+	return n
+}
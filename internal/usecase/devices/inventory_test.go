@@ -0,0 +1,78 @@
+package devices_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/open-amt-cloud-toolkit/go-wsman-messages/v2/pkg/wsman/cim/bios"
+	"github.com/open-amt-cloud-toolkit/go-wsman-messages/v2/pkg/wsman/cim/mediaaccess"
+	"github.com/open-amt-cloud-toolkit/go-wsman-messages/v2/pkg/wsman/cim/physical"
+	"github.com/open-amt-cloud-toolkit/go-wsman-messages/v2/pkg/wsman/cim/processor"
+	"github.com/open-amt-cloud-toolkit/go-wsman-messages/v2/pkg/wsman/cim/software"
+	"github.com/stretchr/testify/require"
+	gomock "go.uber.org/mock/gomock"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity"
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/devices/wsman"
+)
+
+func TestGetInventory(t *testing.T) {
+	t.Parallel()
+
+	device := &entity.Device{
+		GUID:     "device-guid-123",
+		TenantID: "tenant-id-456",
+	}
+
+	inventory := wsman.Inventory{}
+	inventory.Hardware.BiosResult.Body.GetResponse = bios.BiosElement{Manufacturer: "Intel Corp.", Version: "ADLPFWI1"}
+	inventory.Hardware.ProcessorResult.Body.PackageResponse = processor.PackageResponse{DeviceID: "CPU 0", ElementName: "Managed System Processor", MaxClockSpeed: 4700}
+	inventory.Hardware.PhysicalMemoryResult.Body.PullResponse.MemoryItems = []physical.PhysicalMemory{
+		{BankLabel: "BANK 0", Capacity: 8 << 30, ConfiguredMemoryClockSpeed: 3200},
+		{BankLabel: "BANK 1", Capacity: 8 << 30, MaxMemorySpeed: 4800, IsSpeedInMhz: true},
+	}
+	inventory.Disks.MediaAccessPullResult.Body.PullResponse.MediaAccessDevices = []mediaaccess.MediaAccessDevice{
+		{DeviceID: "MEDIA DEV 0", MaxMediaSize: 500107862},
+	}
+
+	useCase, wsmanMock, management, repo := initInfoTest(t)
+
+	repo.EXPECT().GetByID(context.Background(), device.GUID, "").Return(device, nil)
+	wsmanMock.EXPECT().SetupWsmanClient(gomock.Any(), false, true).Return(management)
+	management.EXPECT().GetAMTVersion().Return([]software.SoftwareIdentity{
+		{InstanceID: "AMT", VersionString: "16.1.25"},
+		{InstanceID: "Sku", VersionString: "16392"},
+	}, nil)
+	management.EXPECT().GetInventory().Return(inventory, nil)
+
+	res, err := useCase.GetInventory(context.Background(), device.GUID)
+	require.NoError(t, err)
+
+	require.Equal(t, device.GUID, res.GUID)
+	require.Equal(t, device.TenantID, res.TenantID)
+	require.Equal(t, dto.FirmwareInventory{AMTVersion: "16.1.25", AMTMajor: 16, SKU: "16392"}, res.Firmware)
+	require.Equal(t, "ADLPFWI1", res.BIOS.Version)
+	require.Equal(t, []dto.ProcessorInventory{{Name: "Managed System Processor", MaxClockSpeedMHz: 4700}}, res.Processors)
+	require.Len(t, res.Memory, 2)
+	require.Equal(t, 3200, res.Memory[0].SpeedMHz)
+	require.Equal(t, 4800, res.Memory[1].SpeedMHz)
+	require.Equal(t, int64(16<<30), res.TotalMemoryBytes)
+	require.Equal(t, []dto.DiskInventory{{DeviceID: "MEDIA DEV 0", MaxMediaSizeKB: 500107862}}, res.Disks)
+}
+
+func TestGetInventoryFails(t *testing.T) {
+	t.Parallel()
+
+	device := &entity.Device{GUID: "device-guid-123"}
+
+	useCase, wsmanMock, management, repo := initInfoTest(t)
+
+	repo.EXPECT().GetByID(context.Background(), device.GUID, "").Return(device, nil)
+	wsmanMock.EXPECT().SetupWsmanClient(gomock.Any(), false, true).Return(management)
+	management.EXPECT().GetAMTVersion().Return([]software.SoftwareIdentity{}, nil)
+	management.EXPECT().GetInventory().Return(wsman.Inventory{}, ErrGeneral)
+
+	_, err := useCase.GetInventory(context.Background(), device.GUID)
+	require.ErrorIs(t, err, ErrGeneral)
+}
@@ -0,0 +1,286 @@
+package devices
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"io"
+	"slices"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/recordings"
+)
+
+// RFB messages of the handshake the device starts a KVM session with, see RFC 6143.
+const (
+	rfbVersion      = "RFB 003.008\n"
+	rfbVersionSize  = 12
+	rfbSecurityNone = 1
+	rfbSecurityOK   = 0
+)
+
+// RFB messages a client sends during a session besides the ones in framebuffer.go, see RFC 6143.
+const (
+	rfbKeyEvent      = 4
+	rfbPointerEvent  = 5
+	rfbClientCutText = 6
+
+	rfbPixelFormatSize   = 20
+	rfbUpdateRequestSize = 10
+	rfbKeyEventSize      = 8
+	rfbPointerEventSize  = 6
+	rfbEncodingsHeader   = 4
+	rfbCutTextHeader     = 8
+)
+
+var (
+	errRFBVersion    = errors.New("unsupported RFB version")
+	errRFBSecurity   = errors.New("no supported RFB security type")
+	errRFBRefused    = errors.New("the device refused the RFB session")
+	errKVMUnexpected = errors.New("unexpected message during the redirection handshake")
+)
+
+// kvmHandshake authenticates a KVM session with the device, then takes the client part of the RFB handshake
+// that follows: the device's version is answered with 3.8 and its None security type is chosen, the
+// redirection session being authenticated already.
+func (uc *UseCase) kvmHandshake(ctx context.Context, dc *DeviceConnection, reader *bufio.Reader) error {
+	h := redirectionHandshake{service: "KVMR", challenge: &dc.Challenge}
+
+	if err := uc.kvmSend(ctx, dc, h.start()); err != nil {
+		return err
+	}
+
+	for authenticated := false; !authenticated; {
+		msg, err := readHandshakeMessage(reader)
+		if err != nil {
+			return err
+		}
+
+		var answer []byte
+
+		answer, authenticated, err = h.reply(msg)
+		if err != nil {
+			return err
+		}
+
+		if answer != nil {
+			if err := uc.kvmSend(ctx, dc, answer); err != nil {
+				return err
+			}
+		}
+	}
+
+	version := make([]byte, rfbVersionSize)
+	if _, err := io.ReadFull(reader, version); err != nil {
+		return err
+	}
+
+	if string(version[:4]) != "RFB " {
+		return errRFBVersion
+	}
+
+	if err := uc.kvmSend(ctx, dc, []byte(rfbVersion)); err != nil {
+		return err
+	}
+
+	count, err := reader.ReadByte()
+	if err != nil {
+		return err
+	}
+
+	if count == 0 {
+		return errRFBRefused
+	}
+
+	types := make([]byte, count)
+	if _, err := io.ReadFull(reader, types); err != nil {
+		return err
+	}
+
+	if !slices.Contains(types, rfbSecurityNone) {
+		return errRFBSecurity
+	}
+
+	if err := uc.kvmSend(ctx, dc, []byte{rfbSecurityNone}); err != nil {
+		return err
+	}
+
+	var result uint32
+	if err := binary.Read(reader, binary.BigEndian, &result); err != nil {
+		return err
+	}
+
+	if result != rfbSecurityOK {
+		return errRFBRefused
+	}
+
+	return nil
+}
+
+// kvmSend writes msg to the device, it is recorded as coming from the client.
+func (uc *UseCase) kvmSend(ctx context.Context, dc *DeviceConnection, msg []byte) error {
+	dc.record(recordings.FromBrowser, msg)
+
+	return uc.redirection.RedirectSend(ctx, dc, msg)
+}
+
+// deviceReader reads what the device sends in a redirection session as a stream, whatever the sizes of the
+// reads of the connection.
+type deviceReader struct {
+	ctx     context.Context
+	uc      *UseCase
+	dc      *DeviceConnection
+	pending []byte
+}
+
+func (r *deviceReader) Read(p []byte) (int, error) {
+	for len(r.pending) == 0 {
+		data, err := r.uc.redirection.RedirectListen(r.ctx, r.dc)
+		if err != nil {
+			return 0, err
+		}
+
+		r.dc.record(recordings.FromDevice, data)
+		r.pending = data
+	}
+
+	n := copy(p, r.pending)
+	r.pending = r.pending[n:]
+
+	return n, nil
+}
+
+// readHandshakeMessage reads the next start or authenticate reply of the device.
+func readHandshakeMessage(reader *bufio.Reader) ([]byte, error) {
+	for n := 1; ; n++ {
+		head, err := reader.Peek(n)
+		if err != nil {
+			return nil, err
+		}
+
+		size, ok := handshakeMessageSize(head)
+		if !ok {
+			return nil, errKVMUnexpected
+		}
+
+		if size > 0 {
+			msg := make([]byte, size)
+			_, err := io.ReadFull(reader, msg)
+
+			return msg, err
+		}
+	}
+}
+
+// Steps of the RFB handshake a client goes through before it sends messages.
+const (
+	rfbInputVersion = iota
+	rfbInputSecurity
+	rfbInputClientInit
+	rfbInputMessages
+)
+
+// rfbInput follows the messages an RFB client sends, whatever the sizes of the reads, to tell the user's key and
+// pointer events from the framebuffer update requests a viewer keeps sending on its own. A client sending a
+// message it does not know is taken to be in use from then on.
+type rfbInput struct {
+	step   int
+	header []byte
+	skip   int
+	lost   bool
+}
+
+// Scan reports whether data holds a key or pointer event.
+func (s *rfbInput) Scan(data []byte) bool {
+	input := false
+
+	for len(data) > 0 && !s.lost {
+		if s.skip > 0 {
+			n := min(s.skip, len(data))
+			s.skip -= n
+			data = data[n:]
+
+			continue
+		}
+
+		s.header = append(s.header, data[0])
+		data = data[1:]
+
+		if s.next() {
+			input = true
+		}
+	}
+
+	return input || s.lost
+}
+
+// next moves on once the header holds all it takes to size the current handshake step or message, it reports
+// whether that message is a key or pointer event.
+func (s *rfbInput) next() bool {
+	h := s.header
+
+	switch s.step {
+	case rfbInputVersion:
+		if len(h) < rfbVersionSize {
+			return false
+		}
+
+		// a 3.3 client takes the security type the server chose
+		s.step = rfbInputSecurity
+		if string(h) == "RFB 003.003\n" {
+			s.step = rfbInputClientInit
+		}
+	case rfbInputSecurity:
+		if h[0] == rfbSecurityVNCAuth {
+			s.skip = rfbChallengeSize
+		}
+
+		s.step = rfbInputClientInit
+	case rfbInputClientInit:
+		s.step = rfbInputMessages
+	default:
+		return s.message()
+	}
+
+	s.header = s.header[:0]
+
+	return false
+}
+
+func (s *rfbInput) message() bool {
+	h := s.header
+	size := 0
+	input := false
+
+	switch h[0] {
+	case rfbSetPixelFormat:
+		size = rfbPixelFormatSize
+	case rfbFramebufferUpdateRequest:
+		size = rfbUpdateRequestSize
+	case rfbKeyEvent:
+		size, input = rfbKeyEventSize, true
+	case rfbPointerEvent:
+		size, input = rfbPointerEventSize, true
+	case rfbSetEncodings:
+		if len(h) < rfbEncodingsHeader {
+			return false
+		}
+
+		size = rfbEncodingsHeader + 4*int(binary.BigEndian.Uint16(h[2:4]))
+	case rfbClientCutText:
+		if len(h) < rfbCutTextHeader {
+			return false
+		}
+
+		size = rfbCutTextHeader + int(binary.BigEndian.Uint32(h[4:8]))
+	default:
+		s.lost = true
+
+		return true
+	}
+
+	s.skip = size - len(h)
+	s.header = s.header[:0]
+
+	return input
+}
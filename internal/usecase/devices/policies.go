@@ -0,0 +1,144 @@
+package devices
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+)
+
+// closeWriteWait bounds how long writing the close frame of a session may block.
+const closeWriteWait = time.Second
+
+var (
+	errUserLimit   = errors.New("the user has reached the redirection session limit")
+	errDeviceLimit = errors.New("the device has reached the redirection session limit")
+)
+
+// redirectionPolicy is the policy the sessions of a tenant are held to, no policies means no limits.
+func (uc *UseCase) redirectionPolicy(ctx context.Context, tenantID string) (dto.RedirectionPolicy, error) {
+	if uc.policies == nil {
+		return dto.RedirectionPolicy{}, nil
+	}
+
+	return uc.policies.Get(ctx, tenantID)
+}
+
+// reserveRedirection registers a session before its device connection is opened, so that concurrent requests
+// cannot both take the last slot of a user or a device. The close code is set when a limit is reached, along
+// with an error short enough to be its reason.
+func (uc *UseCase) reserveRedirection(dc *DeviceConnection, policy dto.RedirectionPolicy) (int, error) {
+	uc.redirSessionsMu.Lock()
+	defer uc.redirSessionsMu.Unlock()
+
+	perUser, perDevice := 0, 0
+
+	for _, other := range uc.redirSessions {
+		if other.tenantID != dc.tenantID {
+			continue
+		}
+
+		if dc.Subject != "" && other.Subject == dc.Subject {
+			perUser++
+		}
+
+		if other.Device.GUID == dc.Device.GUID {
+			perDevice++
+		}
+	}
+
+	if policy.MaxSessionsPerUser > 0 && perUser >= policy.MaxSessionsPerUser {
+		return dto.RedirectionCloseUserLimit, errUserLimit
+	}
+
+	if policy.MaxSessionsPerDevice > 0 && perDevice >= policy.MaxSessionsPerDevice {
+		return dto.RedirectionCloseDeviceLimit, errDeviceLimit
+	}
+
+	uc.redirSessions[dc.ID] = dc
+
+	return 0, nil
+}
+
+// releaseRedirection drops the reservation of a session whose device connection could not be opened.
+func (uc *UseCase) releaseRedirection(dc *DeviceConnection) {
+	uc.redirSessionsMu.Lock()
+	defer uc.redirSessionsMu.Unlock()
+
+	delete(uc.redirSessions, dc.ID)
+}
+
+// watchRedirection closes a session once it has been idle or open for longer than the policy allows.
+func (uc *UseCase) watchRedirection(ctx context.Context, dc *DeviceConnection, policy dto.RedirectionPolicy) {
+	idle := time.Duration(policy.IdleTimeoutSeconds) * time.Second
+	maxDuration := time.Duration(policy.MaxDurationSeconds) * time.Second
+
+	if idle <= 0 && maxDuration <= 0 {
+		return
+	}
+
+	timer := time.NewTimer(nextDeadline(dc, idle, maxDuration))
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-dc.done:
+			return
+		case <-timer.C:
+		}
+
+		now := time.Now()
+
+		switch {
+		case maxDuration > 0 && !now.Before(dc.StartTime.Add(maxDuration)):
+			uc.log.Info("devices - redirection - the " + dc.Mode + " session " + dc.ID + " with " + dc.Device.GUID + " reached its maximum duration")
+			uc.closeRedirection(ctx, dc, dto.RedirectionCloseDuration, "maximum session duration reached")
+
+			return
+		case idle > 0 && !now.Before(dc.lastActive().Add(idle)):
+			uc.log.Info("devices - redirection - the " + dc.Mode + " session " + dc.ID + " with " + dc.Device.GUID + " was idle for too long")
+			uc.closeRedirection(ctx, dc, dto.RedirectionCloseIdle, "session idle timeout")
+
+			return
+		}
+
+		timer.Reset(nextDeadline(dc, idle, maxDuration))
+	}
+}
+
+// nextDeadline is how long until the earliest limit of a session could be reached.
+func nextDeadline(dc *DeviceConnection, idle, maxDuration time.Duration) time.Duration {
+	var deadline time.Time
+
+	if maxDuration > 0 {
+		deadline = dc.StartTime.Add(maxDuration)
+	}
+
+	if idle > 0 {
+		if idleDeadline := dc.lastActive().Add(idle); deadline.IsZero() || idleDeadline.Before(deadline) {
+			deadline = idleDeadline
+		}
+	}
+
+	return time.Until(deadline)
+}
+
+func (dc *DeviceConnection) touch() {
+	dc.lastActivity.Store(time.Now().UnixNano())
+}
+
+func (dc *DeviceConnection) lastActive() time.Time {
+	if last := dc.lastActivity.Load(); last != 0 {
+		return time.Unix(0, last)
+	}
+
+	return dc.StartTime
+}
+
+// closeBrowser tells the browser why its session is over before the websocket is closed.
+func closeBrowser(conn WebSocketConn, code int, reason string) {
+	_ = conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(code, reason), time.Now().Add(closeWriteWait))
+}
@@ -11,10 +11,11 @@ import (
 	"github.com/open-amt-cloud-toolkit/go-wsman-messages/v2/pkg/wsman/cim/software"
 
 	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	"github.com/open-amt-cloud-toolkit/console/pkg/tenant"
 )
 
 func (uc *UseCase) SendPowerAction(c context.Context, guid string, action int) (power.PowerActionResponse, error) {
-	item, err := uc.repo.GetByID(c, guid, "")
+	item, err := uc.repo.GetByID(c, guid, tenant.FromContext(c))
 	if err != nil {
 		return power.PowerActionResponse{}, err
 	}
@@ -30,11 +31,13 @@ func (uc *UseCase) SendPowerAction(c context.Context, guid string, action int) (
 		return power.PowerActionResponse{}, err
 	}
 
+	uc.publish(c, dto.EventPowerAction, item, dto.PowerActionEvent{Action: action, ReturnValue: int(response.ReturnValue)})
+
 	return response, nil
 }
 
 func (uc *UseCase) GetPowerState(c context.Context, guid string) (dto.PowerState, error) {
-	item, err := uc.repo.GetByID(c, guid, "")
+	item, err := uc.repo.GetByID(c, guid, tenant.FromContext(c))
 	if err != nil {
 		return dto.PowerState{}, err
 	}
@@ -56,7 +59,7 @@ func (uc *UseCase) GetPowerState(c context.Context, guid string) (dto.PowerState
 }
 
 func (uc *UseCase) GetPowerCapabilities(c context.Context, guid string) (dto.PowerCapabilities, error) {
-	item, err := uc.repo.GetByID(c, guid, "")
+	item, err := uc.repo.GetByID(c, guid, tenant.FromContext(c))
 	if err != nil {
 		return dto.PowerCapabilities{}, err
 	}
@@ -128,7 +131,7 @@ func determinePowerCapabilities(amtversion int, capabilities boot.BootCapabiliti
 }
 
 func (uc *UseCase) SetBootOptions(c context.Context, guid string, bootSetting dto.BootSetting) (power.PowerActionResponse, error) {
-	item, err := uc.repo.GetByID(c, guid, "")
+	item, err := uc.repo.GetByID(c, guid, tenant.FromContext(c))
 	if err != nil {
 		return power.PowerActionResponse{}, err
 	}
@@ -137,6 +140,8 @@ func (uc *UseCase) SetBootOptions(c context.Context, guid string, bootSetting dt
 		return power.PowerActionResponse{}, ErrNotFound
 	}
 
+	requested := bootSetting
+
 	device := uc.device.SetupWsmanClient(*item, false, true)
 
 	bootData, err := device.GetBootData()
@@ -201,6 +206,8 @@ func (uc *UseCase) SetBootOptions(c context.Context, guid string, bootSetting dt
 		return power.PowerActionResponse{}, err
 	}
 
+	uc.publish(c, dto.EventBootOptions, item, requested)
+
 	return powerActionResult, nil
 }
 
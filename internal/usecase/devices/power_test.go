@@ -42,11 +42,10 @@ func initPowerTest(t *testing.T) (*devices.UseCase, *mocks.MockWSMAN, *mocks.Moc
 
 	repo := mocks.NewMockDeviceManagementRepository(mockCtl)
 	wsmanMock := mocks.NewMockWSMAN(mockCtl)
-	wsmanMock.EXPECT().Worker().Return().AnyTimes()
 
 	managementMock := mocks.NewMockManagement(mockCtl)
 	log := logger.New("error")
-	u := devices.New(repo, wsmanMock, mocks.NewMockRedirection(mockCtl), log, mocks.MockCrypto{})
+	u := devices.New(repo, wsmanMock, mocks.NewMockRedirection(mockCtl), anyPublisher(mockCtl), nil, nil, log, mocks.MockCrypto{})
 
 	return u, wsmanMock, managementMock, repo
 }
@@ -7,6 +7,7 @@ import (
 
 	"github.com/google/uuid"
 
+	"github.com/open-amt-cloud-toolkit/console/internal/entity"
 	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
 	"github.com/open-amt-cloud-toolkit/console/internal/usecase/sqldb"
 	"github.com/open-amt-cloud-toolkit/console/pkg/consoleerrors"
@@ -16,6 +17,7 @@ var (
 	ErrDomainsUseCase = consoleerrors.CreateConsoleError("DevicesUseCase")
 	ErrDatabase       = sqldb.DatabaseError{Console: consoleerrors.CreateConsoleError("DevicesUseCase")}
 	ErrNotFound       = sqldb.NotFoundError{Console: consoleerrors.CreateConsoleError("DevicesUseCase")}
+	ErrNotValid       = dto.NotValidError{Console: consoleerrors.CreateConsoleError("DevicesUseCase")}
 )
 
 // History - getting translate history from store.
@@ -28,6 +30,15 @@ func (uc *UseCase) GetCount(ctx context.Context, tenantID string) (int, error) {
 	return count, nil
 }
 
+func (uc *UseCase) GetConnectedCount(ctx context.Context, tenantID string) (int, error) {
+	count, err := uc.repo.GetConnectedCount(ctx, tenantID)
+	if err != nil {
+		return 0, ErrDatabase.Wrap("GetConnectedCount", "uc.repo.GetConnectedCount", err)
+	}
+
+	return count, nil
+}
+
 func (uc *UseCase) Get(ctx context.Context, top, skip int, tenantID string) ([]dto.Device, error) {
 	data, err := uc.repo.Get(ctx, top, skip, tenantID)
 	if err != nil {
@@ -114,21 +125,35 @@ func (uc *UseCase) GetByTags(ctx context.Context, tags, method string, limit, of
 }
 
 func (uc *UseCase) Delete(ctx context.Context, guid, tenantID string) error {
+	// read the device first so subscribers filtering on its tags still see it go
+	item, err := uc.repo.GetByID(ctx, guid, tenantID)
+	if err != nil {
+		return ErrDatabase.Wrap("Delete", "uc.repo.GetByID", err)
+	}
+
 	isSuccessful, err := uc.repo.Delete(ctx, guid, tenantID)
 	if err != nil {
 		return ErrDatabase.Wrap("Delete", "uc.repo.Delete", err)
 	}
 
-	if !isSuccessful {
+	if !isSuccessful || item == nil {
 		return ErrNotFound
 	}
 
+	uc.publish(ctx, dto.EventDeviceDeleted, item, nil)
+
 	return nil
 }
 
 func (uc *UseCase) Update(ctx context.Context, d *dto.Device) (*dto.Device, error) {
 	d1 := uc.dtoToEntity(d)
 
+	// keep the previous pin around to tell certificate changes apart from other edits
+	previous, err := uc.repo.GetByID(ctx, d1.GUID, d1.TenantID)
+	if err != nil {
+		return nil, ErrDatabase.Wrap("Update", "uc.repo.GetByID", err)
+	}
+
 	updated, err := uc.repo.Update(ctx, d1)
 	if err != nil {
 		return nil, ErrDatabase.Wrap("Update", "uc.repo.Update", err)
@@ -148,6 +173,16 @@ func (uc *UseCase) Update(ctx context.Context, d *dto.Device) (*dto.Device, erro
 	// invalidate connection cache
 	uc.device.DestroyWsmanClient(*d2)
 
+	uc.publish(ctx, dto.EventDeviceUpdated, updateDevice, d2)
+
+	if previous != nil && certHash(previous) != d2.CertHash {
+		if d2.CertHash == "" {
+			uc.publish(ctx, dto.EventCertificateRemoved, updateDevice, dto.PinCertificate{SHA256Fingerprint: certHash(previous)})
+		} else {
+			uc.publish(ctx, dto.EventCertificatePinned, updateDevice, dto.PinCertificate{SHA256Fingerprint: d2.CertHash})
+		}
+	}
+
 	return d2, nil
 }
 
@@ -173,5 +208,15 @@ func (uc *UseCase) Insert(ctx context.Context, d *dto.Device) (*dto.Device, erro
 		d2.Tags = []string{}
 	}
 
+	uc.publish(ctx, dto.EventDeviceAdded, newDevice, d2)
+
 	return d2, nil
 }
+
+func certHash(d *entity.Device) string {
+	if d.CertHash == nil {
+		return ""
+	}
+
+	return *d.CertHash
+}
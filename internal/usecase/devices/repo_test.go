@@ -33,14 +33,21 @@ func devicesTest(t *testing.T) (*devices.UseCase, *mocks.MockDeviceManagementRep
 
 	repo := mocks.NewMockDeviceManagementRepository(mockCtl)
 	wsmanMock := mocks.NewMockWSMAN(mockCtl)
-	wsmanMock.EXPECT().Worker().Return().AnyTimes()
 
 	log := logger.New("error")
-	u := devices.New(repo, wsmanMock, mocks.NewMockRedirection(mockCtl), log, mocks.MockCrypto{})
+	u := devices.New(repo, wsmanMock, mocks.NewMockRedirection(mockCtl), anyPublisher(mockCtl), nil, nil, log, mocks.MockCrypto{})
 
 	return u, repo, wsmanMock
 }
 
+// anyPublisher accepts every event, tests asserting on events set their own expectations.
+func anyPublisher(mockCtl *gomock.Controller) *mocks.MockPublisher {
+	publisher := mocks.NewMockPublisher(mockCtl)
+	publisher.EXPECT().Publish(gomock.Any(), gomock.Any()).AnyTimes()
+
+	return publisher
+}
+
 func TestGetCount(t *testing.T) {
 	t.Parallel()
 
@@ -244,6 +251,9 @@ func TestDelete(t *testing.T) {
 			guid:     "guid-123",
 			tenantID: "tenant-id-456",
 			mock: func(repo *mocks.MockDeviceManagementRepository, _ *mocks.MockWSMAN) {
+				repo.EXPECT().
+					GetByID(context.Background(), "guid-123", "tenant-id-456").
+					Return(&entity.Device{GUID: "guid-123", TenantID: "tenant-id-456"}, nil)
 				repo.EXPECT().
 					Delete(context.Background(), "guid-123", "tenant-id-456").
 					Return(true, nil)
@@ -255,6 +265,9 @@ func TestDelete(t *testing.T) {
 			guid:     "guid-456",
 			tenantID: "tenant-id-456",
 			mock: func(repo *mocks.MockDeviceManagementRepository, _ *mocks.MockWSMAN) {
+				repo.EXPECT().
+					GetByID(context.Background(), "guid-456", "tenant-id-456").
+					Return(nil, nil)
 				repo.EXPECT().
 					Delete(context.Background(), "guid-456", "tenant-id-456").
 					Return(false, nil)
@@ -304,6 +317,9 @@ func TestUpdate(t *testing.T) {
 		{
 			name: "successful update",
 			mock: func(repo *mocks.MockDeviceManagementRepository, management *mocks.MockWSMAN) {
+				repo.EXPECT().
+					GetByID(context.Background(), "device-guid-123", "tenant-id-456").
+					Return(device, nil)
 				repo.EXPECT().
 					Update(context.Background(), device).
 					Return(true, nil)
@@ -319,6 +335,9 @@ func TestUpdate(t *testing.T) {
 		{
 			name: "update fails - not found",
 			mock: func(repo *mocks.MockDeviceManagementRepository, _ *mocks.MockWSMAN) {
+				repo.EXPECT().
+					GetByID(context.Background(), "device-guid-123", "tenant-id-456").
+					Return(device, nil)
 				repo.EXPECT().
 					Update(context.Background(), device).
 					Return(false, nil)
@@ -329,6 +348,9 @@ func TestUpdate(t *testing.T) {
 		{
 			name: "update fails - database error",
 			mock: func(repo *mocks.MockDeviceManagementRepository, _ *mocks.MockWSMAN) {
+				repo.EXPECT().
+					GetByID(context.Background(), "device-guid-123", "tenant-id-456").
+					Return(device, nil)
 				repo.EXPECT().
 					Update(context.Background(), device).
 					Return(false, devices.ErrDatabase)
@@ -0,0 +1,113 @@
+package devices
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"image"
+	"image/png"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/open-amt-cloud-toolkit/go-wsman-messages/v2/pkg/wsman/client"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/recordings"
+	"github.com/open-amt-cloud-toolkit/console/pkg/clientip"
+	"github.com/open-amt-cloud-toolkit/console/pkg/subject"
+	"github.com/open-amt-cloud-toolkit/console/pkg/tenant"
+)
+
+// screenshotTimeout bounds a capture, a device that stops sending updates does not hold the session open.
+const screenshotTimeout = 30 * time.Second
+
+// Screenshot captures the screen of a device of the caller's tenant as a PNG.
+func (uc *UseCase) Screenshot(ctx context.Context, guid string) ([]byte, error) {
+	device, err := uc.repo.GetByID(ctx, guid, tenant.FromContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	if device == nil || device.GUID == "" {
+		return nil, ErrNotFound
+	}
+
+	img, err := uc.capture(ctx, *device)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// capture opens a short KVM session with the device and draws its framebuffer once. The session is registered
+// like the ones relayed for a browser, so it is listed, can be terminated and counts against the limits of the
+// redirection policy, but it is not recorded: nobody sees or controls the device through it.
+func (uc *UseCase) capture(ctx context.Context, device entity.Device) (image.Image, error) {
+	password, _ := uc.safeRequirements.Decrypt(device.Password)
+
+	dc := &DeviceConnection{
+		wsmanMessages: uc.redirection.SetupWsmanClient(device, true, true),
+		Device:        device,
+		Mode:          recordings.ModeKVM,
+		Challenge: client.AuthChallenge{
+			Username: device.Username,
+			Password: password,
+		},
+		ID:        uuid.New().String(),
+		Subject:   subject.FromContext(ctx),
+		ClientIP:  clientip.FromContext(ctx),
+		StartTime: time.Now(),
+		tenantID:  device.TenantID,
+		done:      make(chan struct{}),
+	}
+
+	policy, err := uc.redirectionPolicy(ctx, dc.tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := uc.reserveRedirection(dc, policy); err != nil {
+		return nil, ErrNotValid.Wrap("capture", "uc.reserveRedirection", err)
+	}
+
+	if err := uc.redirection.RedirectConnect(ctx, dc); err != nil {
+		uc.releaseRedirection(dc)
+
+		return nil, ErrAMT.Wrap("capture", "uc.redirection.RedirectConnect", err)
+	}
+
+	closeSession := func() {
+		uc.closeRedirection(context.Background(), dc, 0, "")
+	}
+
+	// closing the connection ends the read the capture waits in
+	timer := time.AfterFunc(screenshotTimeout, closeSession)
+
+	defer func() {
+		timer.Stop()
+		closeSession()
+	}()
+
+	reader := bufio.NewReader(&deviceReader{ctx: ctx, uc: uc, dc: dc})
+
+	if err := uc.kvmHandshake(ctx, dc, reader); err != nil {
+		return nil, ErrAMT.Wrap("capture", "uc.kvmHandshake", err)
+	}
+
+	fb, err := uc.kvmInit(ctx, dc, reader)
+	if err != nil {
+		return nil, ErrAMT.Wrap("capture", "uc.kvmInit", err)
+	}
+
+	if err := fb.capture(func(msg []byte) error { return uc.kvmSend(ctx, dc, msg) }); err != nil {
+		return nil, ErrAMT.Wrap("capture", "fb.capture", err)
+	}
+
+	return fb.img, nil
+}
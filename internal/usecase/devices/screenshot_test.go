@@ -0,0 +1,145 @@
+package devices_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	gomock "go.uber.org/mock/gomock"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity"
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	"github.com/open-amt-cloud-toolkit/console/internal/mocks"
+	devices "github.com/open-amt-cloud-toolkit/console/internal/usecase/devices"
+	"github.com/open-amt-cloud-toolkit/console/pkg/logger"
+)
+
+// serveScreen answers the ServerInit and the first update request of a capture with a screen of RGB565
+// pixels drawn in one raw rectangle.
+func (d *kvmDevice) serveScreen(t *testing.T, width, height uint16, pixels ...uint16) {
+	t.Helper()
+
+	next := func() []byte {
+		select {
+		case msg := <-d.sent:
+			return msg
+		case <-time.After(5 * time.Second):
+			require.FailNow(t, "the capture stopped")
+
+			return nil
+		}
+	}
+
+	// ClientInit of a shared session
+	require.Equal(t, []byte{1}, next())
+
+	serverInit := binary.BigEndian.AppendUint16(nil, width)
+	serverInit = binary.BigEndian.AppendUint16(serverInit, height)
+	serverInit = append(serverInit, 32, 24, 0, 1, 0, 255, 0, 255, 0, 255, 16, 8, 0, 0, 0, 0)
+	d.replies <- append(serverInit, 0, 0, 0, 3, 'a', 'm', 't')
+
+	// SetPixelFormat, SetEncodings, then the request of the whole screen
+	require.Equal(t, byte(0), next()[0])
+	require.Equal(t, byte(2), next()[0])
+	require.Equal(t, []byte{3, 0, 0, 0, 0, 0, byte(width >> 8), byte(width), byte(height >> 8), byte(height)}, next())
+
+	update := []byte{0, 0, 0, 1, 0, 0, 0, 0}
+	update = binary.BigEndian.AppendUint16(update, width)
+	update = binary.BigEndian.AppendUint16(update, height)
+	update = append(update, 0, 0, 0, 0)
+
+	for _, p := range pixels {
+		update = binary.LittleEndian.AppendUint16(update, p)
+	}
+
+	d.replies <- update
+}
+
+func TestScreenshot(t *testing.T) {
+	t.Parallel()
+
+	_, uc, device := initVNCTest(t, "")
+
+	go device.serveScreen(t, 2, 1, 0xf800, 0x001f)
+
+	data, err := uc.Screenshot(vncContext(), "device-guid-123")
+	require.NoError(t, err)
+
+	img, err := png.Decode(bytes.NewReader(data))
+	require.NoError(t, err)
+	require.Equal(t, image.Rect(0, 0, 2, 1), img.Bounds())
+	require.Equal(t, color.RGBA{R: 0xff, A: 0xff}, color.RGBAModel.Convert(img.At(0, 0)))
+	require.Equal(t, color.RGBA{B: 0xff, A: 0xff}, color.RGBAModel.Convert(img.At(1, 0)))
+
+	// the session ends with the capture and is released
+	select {
+	case <-device.closed:
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "the device connection was not closed")
+	}
+
+	sessions, err := uc.GetRedirectionSessions(context.Background(), "tenant1")
+	require.NoError(t, err)
+	require.Empty(t, sessions)
+}
+
+func TestScreenshotUnknownDevice(t *testing.T) {
+	t.Parallel()
+
+	_, uc, _ := initVNCTest(t, "")
+
+	_, err := uc.Screenshot(vncContext(), "unknown-guid")
+	require.IsType(t, devices.ErrNotFound, err)
+}
+
+func TestScreenshotCountsAgainstThePolicy(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+
+	repo := mocks.NewMockDeviceManagementRepository(ctrl)
+	repo.EXPECT().GetByID(gomock.Any(), "device-guid-123", "tenant1").Return(&entity.Device{
+		GUID:     "device-guid-123",
+		Username: "admin",
+		Password: "password",
+		TenantID: "tenant1",
+	}, nil).Times(2)
+
+	policies := mocks.NewMockRedirectionPolicies(ctrl)
+	policies.EXPECT().Get(gomock.Any(), "tenant1").Return(dto.RedirectionPolicy{MaxSessionsPerDevice: 1}, nil).Times(2)
+
+	mockRedirection := mocks.NewMockRedirection(ctrl)
+	device := newKVMDevice(mockRedirection)
+
+	uc := devices.New(repo, mocks.NewMockWSMAN(ctrl), mockRedirection, anyPublisher(ctrl), mocks.NewMockRedirectionRecorder(ctrl), policies, logger.New("error"), mocks.MockCrypto{})
+
+	captured := make(chan error, 1)
+
+	go func() {
+		_, err := uc.Screenshot(vncContext(), "device-guid-123")
+		captured <- err
+	}()
+
+	// the capture is listed while it runs
+	sessions := waitForSessions(t, uc, func(s []dto.RedirectionSession) bool { return len(s) == 1 })
+	require.Equal(t, "kvm", sessions[0].Mode)
+	require.Equal(t, "alice", sessions[0].Subject)
+
+	// and takes the only session the device may have
+	_, err := uc.Screenshot(vncContext(), "device-guid-123")
+
+	var notValid dto.NotValidError
+
+	require.ErrorAs(t, err, &notValid)
+
+	device.serveScreen(t, 1, 1, 0)
+	require.NoError(t, <-captured)
+
+	waitForSessions(t, uc, func(s []dto.RedirectionSession) bool { return len(s) == 0 })
+}
@@ -0,0 +1,94 @@
+package devices
+
+import (
+	"context"
+	"sort"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+)
+
+// GetRedirectionSessions lists the sessions relayed between browsers and the devices of a tenant, oldest first.
+func (uc *UseCase) GetRedirectionSessions(_ context.Context, tenantID string) ([]dto.RedirectionSession, error) {
+	uc.redirSessionsMu.Lock()
+
+	sessions := make([]dto.RedirectionSession, 0, len(uc.redirSessions))
+
+	for _, dc := range uc.redirSessions {
+		if dc.tenantID == tenantID {
+			sessions = append(sessions, dc.session())
+		}
+	}
+
+	uc.redirSessionsMu.Unlock()
+
+	sort.Slice(sessions, func(i, j int) bool {
+		if sessions[i].StartTime.Equal(sessions[j].StartTime) {
+			return sessions[i].ID < sessions[j].ID
+		}
+
+		return sessions[i].StartTime.Before(sessions[j].StartTime)
+	})
+
+	return sessions, nil
+}
+
+// TerminateRedirectionSession ends a relayed session: the browser websocket and the device connection are
+// both closed.
+func (uc *UseCase) TerminateRedirectionSession(ctx context.Context, id, tenantID string) error {
+	uc.redirSessionsMu.Lock()
+	dc, ok := uc.redirSessions[id]
+	uc.redirSessionsMu.Unlock()
+
+	if !ok || dc.tenantID != tenantID {
+		return ErrNotFound
+	}
+
+	uc.log.Info("devices - redirection - terminating the " + dc.Mode + " session " + id + " with " + dc.Device.GUID)
+	uc.closeRedirection(ctx, dc, dto.RedirectionCloseTerminated, "session terminated by an administrator")
+
+	return nil
+}
+
+// closeRedirection removes a relayed session and closes both of its ends, it is called by whichever of the
+// listen goroutines, the policy watchdog or a terminate request gets there first. A non-zero code is sent to
+// the browser in a close frame first.
+func (uc *UseCase) closeRedirection(ctx context.Context, dc *DeviceConnection, code int, reason string) {
+	dc.closeOnce.Do(func() {
+		uc.releaseRedirection(dc)
+
+		if dc.done != nil {
+			close(dc.done)
+		}
+
+		if dc.Conn != nil {
+			if code != 0 {
+				closeBrowser(dc.Conn, code, reason)
+			}
+
+			_ = dc.Conn.Close()
+		}
+
+		if dc.vncConn != nil {
+			_ = dc.vncConn.Close()
+		}
+
+		if err := uc.redirection.RedirectClose(ctx, dc); err != nil {
+			uc.log.Warn("devices - redirection - closing the device connection of " + dc.Device.GUID + ": " + err.Error())
+		}
+	})
+}
+
+func (dc *DeviceConnection) session() dto.RedirectionSession {
+	return dto.RedirectionSession{
+		ID:              dc.ID,
+		GUID:            dc.Device.GUID,
+		Hostname:        dc.Device.Hostname,
+		Mode:            dc.Mode,
+		Subject:         dc.Subject,
+		ClientIP:        dc.ClientIP,
+		StartTime:       dc.StartTime,
+		BytesToDevice:   dc.bytesToDevice.Load(),
+		BytesFromDevice: dc.bytesFromDevice.Load(),
+		TenantID:        dc.tenantID,
+	}
+}
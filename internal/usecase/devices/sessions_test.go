@@ -0,0 +1,319 @@
+package devices_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/open-amt-cloud-toolkit/go-wsman-messages/v2/pkg/wsman"
+	"github.com/stretchr/testify/require"
+	gomock "go.uber.org/mock/gomock"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity"
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	"github.com/open-amt-cloud-toolkit/console/internal/mocks"
+	devices "github.com/open-amt-cloud-toolkit/console/internal/usecase/devices"
+	"github.com/open-amt-cloud-toolkit/console/pkg/clientip"
+	"github.com/open-amt-cloud-toolkit/console/pkg/logger"
+	"github.com/open-amt-cloud-toolkit/console/pkg/subject"
+	"github.com/open-amt-cloud-toolkit/console/pkg/tenant"
+)
+
+// relayDevice is the device end of a relayed session: what it replies is read by the console and its closing
+// ends the session.
+type relayDevice struct {
+	replies   chan []byte
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+// initRelayTest serves redirections to the device under the given policy, attempts is how many sessions the
+// browser tries to open, only the first of which reaches the device.
+func initRelayTest(t *testing.T, policy dto.RedirectionPolicy, attempts int) (*devices.UseCase, *relayDevice, func() *websocket.Conn) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	device := &relayDevice{replies: make(chan []byte, 4), closed: make(chan struct{})}
+
+	repo := mocks.NewMockDeviceManagementRepository(ctrl)
+	repo.EXPECT().GetByID(gomock.Any(), "device-guid-123", "tenant1").Return(&entity.Device{
+		GUID:     "device-guid-123",
+		Hostname: "host1",
+		Username: "admin",
+		Password: "password",
+		TenantID: "tenant1",
+	}, nil).Times(attempts)
+
+	policies := mocks.NewMockRedirectionPolicies(ctrl)
+	policies.EXPECT().Get(gomock.Any(), "tenant1").Return(policy, nil).Times(attempts)
+
+	recorder := mocks.NewMockRedirectionRecorder(ctrl)
+	recorder.EXPECT().Record(gomock.Any(), gomock.Any(), "kvm").Return(nil, nil)
+
+	mockRedirection := mocks.NewMockRedirection(ctrl)
+	mockRedirection.EXPECT().SetupWsmanClient(gomock.Any(), true, true).Return(wsman.Messages{}).Times(attempts)
+	mockRedirection.EXPECT().RedirectConnect(gomock.Any(), gomock.Any()).Return(nil)
+	mockRedirection.EXPECT().RedirectSend(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	mockRedirection.EXPECT().RedirectListen(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ *devices.DeviceConnection) ([]byte, error) {
+			select {
+			case data := <-device.replies:
+				return data, nil
+			case <-device.closed:
+				return nil, io.EOF
+			}
+		}).AnyTimes()
+	mockRedirection.EXPECT().RedirectClose(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ *devices.DeviceConnection) error {
+			device.closeOnce.Do(func() { close(device.closed) })
+
+			return nil
+		}).Times(1)
+
+	uc := devices.New(repo, mocks.NewMockWSMAN(ctrl), mockRedirection, anyPublisher(ctrl), recorder, policies, logger.New("error"), mocks.MockCrypto{})
+
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+
+		ctx := tenant.NewContext(context.Background(), "tenant1")
+		ctx = clientip.NewContext(subject.NewContext(ctx, "alice"), "10.0.0.5")
+
+		if err := uc.Redirect(ctx, conn, "device-guid-123", "kvm"); err != nil {
+			conn.Close()
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	dial := func() *websocket.Conn {
+		browser, resp, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+		require.NoError(t, err)
+
+		resp.Body.Close()
+		t.Cleanup(func() { browser.Close() })
+
+		return browser
+	}
+
+	return uc, device, dial
+}
+
+// requireClosedWith reads from the browser until the console closes the session with the given code.
+func requireClosedWith(t *testing.T, browser *websocket.Conn, code int) {
+	t.Helper()
+
+	require.NoError(t, browser.SetReadDeadline(time.Now().Add(5*time.Second)))
+
+	var err error
+	for err == nil {
+		_, _, err = browser.ReadMessage()
+	}
+
+	var closeErr *websocket.CloseError
+
+	require.ErrorAs(t, err, &closeErr)
+	require.Equal(t, code, closeErr.Code)
+}
+
+func requireDeviceClosed(t *testing.T, device *relayDevice) {
+	t.Helper()
+
+	select {
+	case <-device.closed:
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "the device connection was not closed")
+	}
+}
+
+func waitForSessions(t *testing.T, uc *devices.UseCase, done func([]dto.RedirectionSession) bool) []dto.RedirectionSession {
+	t.Helper()
+
+	var sessions []dto.RedirectionSession
+
+	require.Eventually(t, func() bool {
+		var err error
+
+		sessions, err = uc.GetRedirectionSessions(context.Background(), "tenant1")
+		require.NoError(t, err)
+
+		return done(sessions)
+	}, 5*time.Second, 10*time.Millisecond)
+
+	return sessions
+}
+
+func TestTerminateRedirectionSession(t *testing.T) {
+	t.Parallel()
+
+	uc, device, dial := initRelayTest(t, dto.RedirectionPolicy{}, 1)
+	browser := dial()
+
+	require.NoError(t, browser.WriteMessage(websocket.BinaryMessage, []byte{0x10, 0, 0, 0, 'K', 'V', 'M', 'R'}))
+	device.replies <- []byte{0x11, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}
+
+	_, _, err := browser.ReadMessage()
+	require.NoError(t, err)
+
+	sessions := waitForSessions(t, uc, func(s []dto.RedirectionSession) bool {
+		return len(s) == 1 && s[0].BytesToDevice == 8
+	})
+	require.Equal(t, "device-guid-123", sessions[0].GUID)
+	require.Equal(t, "host1", sessions[0].Hostname)
+	require.Equal(t, "kvm", sessions[0].Mode)
+	require.Equal(t, "alice", sessions[0].Subject)
+	require.Equal(t, "10.0.0.5", sessions[0].ClientIP)
+	require.Equal(t, int64(13), sessions[0].BytesFromDevice)
+
+	other, err := uc.GetRedirectionSessions(context.Background(), "tenant2")
+	require.NoError(t, err)
+	require.Empty(t, other)
+
+	err = uc.TerminateRedirectionSession(context.Background(), sessions[0].ID, "tenant2")
+	require.ErrorIs(t, err, devices.ErrNotFound)
+
+	require.NoError(t, uc.TerminateRedirectionSession(context.Background(), sessions[0].ID, "tenant1"))
+
+	requireClosedWith(t, browser, dto.RedirectionCloseTerminated)
+	requireDeviceClosed(t, device)
+
+	waitForSessions(t, uc, func(s []dto.RedirectionSession) bool { return len(s) == 0 })
+
+	err = uc.TerminateRedirectionSession(context.Background(), sessions[0].ID, "tenant1")
+	require.ErrorIs(t, err, devices.ErrNotFound)
+}
+
+func TestRedirectionSessionEndsWithBrowser(t *testing.T) {
+	t.Parallel()
+
+	uc, device, dial := initRelayTest(t, dto.RedirectionPolicy{}, 1)
+	browser := dial()
+
+	waitForSessions(t, uc, func(s []dto.RedirectionSession) bool { return len(s) == 1 })
+
+	require.NoError(t, browser.Close())
+
+	requireDeviceClosed(t, device)
+
+	waitForSessions(t, uc, func(s []dto.RedirectionSession) bool { return len(s) == 0 })
+}
+
+func TestRedirectionSessionLimits(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		policy dto.RedirectionPolicy
+		code   int
+	}{
+		{
+			name:   "per user",
+			policy: dto.RedirectionPolicy{MaxSessionsPerUser: 1},
+			code:   dto.RedirectionCloseUserLimit,
+		},
+		{
+			name:   "per device",
+			policy: dto.RedirectionPolicy{MaxSessionsPerUser: 2, MaxSessionsPerDevice: 1},
+			code:   dto.RedirectionCloseDeviceLimit,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			uc, device, dial := initRelayTest(t, tc.policy, 2)
+			first := dial()
+
+			waitForSessions(t, uc, func(s []dto.RedirectionSession) bool { return len(s) == 1 })
+
+			requireClosedWith(t, dial(), tc.code)
+
+			sessions, err := uc.GetRedirectionSessions(context.Background(), "tenant1")
+			require.NoError(t, err)
+			require.Len(t, sessions, 1)
+
+			require.NoError(t, first.Close())
+			requireDeviceClosed(t, device)
+		})
+	}
+}
+
+func TestRedirectionSessionTimeouts(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		policy dto.RedirectionPolicy
+		code   int
+	}{
+		{
+			name:   "idle",
+			policy: dto.RedirectionPolicy{IdleTimeoutSeconds: 1},
+			code:   dto.RedirectionCloseIdle,
+		},
+		{
+			name:   "maximum duration",
+			policy: dto.RedirectionPolicy{IdleTimeoutSeconds: 60, MaxDurationSeconds: 1},
+			code:   dto.RedirectionCloseDuration,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			uc, device, dial := initRelayTest(t, tc.policy, 1)
+			browser := dial()
+
+			requireClosedWith(t, browser, tc.code)
+			requireDeviceClosed(t, device)
+
+			waitForSessions(t, uc, func(s []dto.RedirectionSession) bool { return len(s) == 0 })
+		})
+	}
+}
+
+func TestRedirectionSessionIdlesWithDeviceTraffic(t *testing.T) {
+	t.Parallel()
+
+	uc, device, dial := initRelayTest(t, dto.RedirectionPolicy{IdleTimeoutSeconds: 1}, 1)
+	browser := dial()
+
+	// the device keeps sending screen updates that nobody is looking at
+	go func() {
+		ticker := time.NewTicker(50 * time.Millisecond)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-device.closed:
+				return
+			case <-ticker.C:
+			}
+
+			select {
+			case device.replies <- []byte{0, 0, 0, 1}:
+			case <-device.closed:
+				return
+			}
+		}
+	}()
+
+	requireClosedWith(t, browser, dto.RedirectionCloseIdle)
+	requireDeviceClosed(t, device)
+
+	waitForSessions(t, uc, func(s []dto.RedirectionSession) bool { return len(s) == 0 })
+}
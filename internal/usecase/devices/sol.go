@@ -0,0 +1,566 @@
+package devices
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/open-amt-cloud-toolkit/go-wsman-messages/v2/pkg/wsman/client"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/recordings"
+	"github.com/open-amt-cloud-toolkit/console/pkg/subject"
+	"github.com/open-amt-cloud-toolkit/console/pkg/tenant"
+)
+
+// Serial-over-LAN messages of the AMT redirection protocol, after the session is started and authenticated.
+const (
+	RedirectionCommandsSOLSettings      = 0x20
+	RedirectionCommandsSOLSettingsReply = 0x21
+	RedirectionCommandsSOLDataToDevice  = 0x28
+	RedirectionCommandsSOLSerialStatus  = 0x29
+	RedirectionCommandsSOLDataToConsole = 0x2A
+	RedirectionCommandsSOLKeepAlive     = 0x2B
+
+	solSettingsReplySize = 23
+	solSerialStatusSize  = 10
+	solDataHeaderSize    = 10
+	solKeepAliveSize     = 8
+
+	// solIdleTimeout closes a session nobody has used or watched for that long.
+	solIdleTimeout    = 10 * time.Minute
+	solConnectTimeout = 30 * time.Second
+	// solPromptDelay is how long output without a line ending waits before it is streamed as a line, so a
+	// prompt such as "login: " reaches line oriented clients.
+	solPromptDelay      = 200 * time.Millisecond
+	solMaxKeysLength    = 1000
+	solSubscriberBuffer = 256
+)
+
+var (
+	errSOLNotConnected = errors.New("the SOL session is not connected yet")
+	errSOLClosed       = errors.New("the SOL session was closed")
+	errSOLTimeout      = errors.New("the device did not complete the SOL handshake in time")
+)
+
+// solSession is a SOL session the console terminates itself: it speaks the redirection protocol to the device
+// and keeps the terminal screen, API clients only send keys and read the screen or the output lines.
+type solSession struct {
+	mu          sync.Mutex
+	uc          *UseCase
+	conn        *DeviceConnection
+	info        dto.SOLSession
+	tenantID    string
+	handshake   redirectionHandshake
+	sequence    uint32
+	pending     []byte
+	screen      *terminal
+	partial     string
+	promptTimer *time.Timer
+	idleTimer   *time.Timer
+	subscribers map[chan string]struct{}
+	ready       chan error
+	done        chan struct{}
+	closeOnce   sync.Once
+}
+
+// OpenSOL starts a SOL session with a device of the caller's tenant, or returns the one already open.
+func (uc *UseCase) OpenSOL(ctx context.Context, guid string) (dto.SOLSession, error) {
+	tenantID := tenant.FromContext(ctx)
+
+	uc.solSessionsMu.Lock()
+
+	if s, ok := uc.solSessions[guid]; ok {
+		uc.solSessionsMu.Unlock()
+
+		if s.tenantID != tenantID {
+			return dto.SOLSession{}, ErrNotFound
+		}
+
+		s.touch()
+
+		return s.snapshot(), nil
+	}
+
+	device, err := uc.repo.GetByID(ctx, guid, tenantID)
+	if err != nil {
+		uc.solSessionsMu.Unlock()
+
+		return dto.SOLSession{}, err
+	}
+
+	if device == nil || device.GUID == "" {
+		uc.solSessionsMu.Unlock()
+
+		return dto.SOLSession{}, ErrNotFound
+	}
+
+	password, _ := uc.safeRequirements.Decrypt(device.Password)
+
+	s := &solSession{
+		uc: uc,
+		conn: &DeviceConnection{
+			wsmanMessages: uc.redirection.SetupWsmanClient(*device, true, true),
+			Device:        *device,
+			Mode:          recordings.ModeSOL,
+			Challenge: client.AuthChallenge{
+				Username: device.Username,
+				Password: password,
+			},
+		},
+		info: dto.SOLSession{
+			GUID:      device.GUID,
+			State:     dto.SOLStateConnecting,
+			Subject:   subject.FromContext(ctx),
+			StartTime: time.Now(),
+		},
+		tenantID:    tenantID,
+		handshake:   redirectionHandshake{service: "SOL "},
+		screen:      newTerminal(),
+		subscribers: make(map[chan string]struct{}),
+		ready:       make(chan error, 1),
+		done:        make(chan struct{}),
+	}
+
+	s.handshake.challenge = &s.conn.Challenge
+
+	// the session is listed while it connects so that a concurrent open finds it instead of starting a second one
+	uc.solSessions[guid] = s
+	uc.solSessionsMu.Unlock()
+
+	if err := s.connect(ctx); err != nil {
+		s.close()
+
+		return dto.SOLSession{}, err
+	}
+
+	uc.publish(ctx, dto.EventRedirectionOpened, device, dto.RedirectionEvent{Mode: recordings.ModeSOL})
+
+	return s.snapshot(), nil
+}
+
+// GetSOL returns the SOL session open with a device.
+func (uc *UseCase) GetSOL(ctx context.Context, guid string) (dto.SOLSession, error) {
+	s, err := uc.solSession(ctx, guid)
+	if err != nil {
+		return dto.SOLSession{}, err
+	}
+
+	return s.snapshot(), nil
+}
+
+// SendSOLKeys types keys into the SOL session open with a device.
+func (uc *UseCase) SendSOLKeys(ctx context.Context, guid, keys string) error {
+	s, err := uc.solSession(ctx, guid)
+	if err != nil {
+		return err
+	}
+
+	s.touch()
+
+	return s.sendKeys(ctx, []byte(keys))
+}
+
+// GetSOLScreen returns the terminal screen of the SOL session open with a device.
+func (uc *UseCase) GetSOLScreen(ctx context.Context, guid string) (dto.SOLScreen, error) {
+	s, err := uc.solSession(ctx, guid)
+	if err != nil {
+		return dto.SOLScreen{}, err
+	}
+
+	s.touch()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.screen.screen(), nil
+}
+
+// SubscribeSOL streams the output of the SOL session open with a device line by line, without control
+// sequences. The channel is closed when ctx is done or the session ends, a subscriber that falls behind
+// misses lines rather than holding up the session.
+func (uc *UseCase) SubscribeSOL(ctx context.Context, guid string) (<-chan string, error) {
+	s, err := uc.solSession(ctx, guid)
+	if err != nil {
+		return nil, err
+	}
+
+	lines := make(chan string, solSubscriberBuffer)
+
+	s.mu.Lock()
+	s.subscribers[lines] = struct{}{}
+	s.mu.Unlock()
+
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-s.done:
+		}
+
+		s.mu.Lock()
+		delete(s.subscribers, lines)
+		close(lines)
+		s.mu.Unlock()
+
+		s.touch()
+	}()
+
+	return lines, nil
+}
+
+// CloseSOL ends the SOL session open with a device.
+func (uc *UseCase) CloseSOL(ctx context.Context, guid string) error {
+	s, err := uc.solSession(ctx, guid)
+	if err != nil {
+		return err
+	}
+
+	s.close()
+
+	return nil
+}
+
+func (uc *UseCase) solSession(ctx context.Context, guid string) (*solSession, error) {
+	uc.solSessionsMu.Lock()
+	s, ok := uc.solSessions[guid]
+	uc.solSessionsMu.Unlock()
+
+	if !ok || s.tenantID != tenant.FromContext(ctx) {
+		return nil, ErrNotFound
+	}
+
+	return s, nil
+}
+
+// connect opens the redirection port and waits for the handshake that listen drives to finish.
+func (s *solSession) connect(ctx context.Context) error {
+	recording, err := s.uc.recorder.Record(ctx, s.conn.Device, recordings.ModeSOL)
+	if err != nil {
+		return err
+	}
+
+	s.conn.Recording = recording
+
+	if err := s.uc.redirection.RedirectConnect(ctx, s.conn); err != nil {
+		return ErrAMT.Wrap("OpenSOL", "uc.redirection.RedirectConnect", err)
+	}
+
+	go s.listen(context.WithoutCancel(ctx))
+
+	s.mu.Lock()
+	err = s.send(ctx, s.handshake.start())
+	s.mu.Unlock()
+
+	if err != nil {
+		return ErrAMT.Wrap("OpenSOL", "uc.redirection.RedirectSend", err)
+	}
+
+	timeout := time.NewTimer(solConnectTimeout)
+	defer timeout.Stop()
+
+	select {
+	case err = <-s.ready:
+	case <-timeout.C:
+		err = errSOLTimeout
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	if err != nil {
+		return ErrAMT.Wrap("OpenSOL", "solSession.connect", err)
+	}
+
+	s.mu.Lock()
+	s.info.State = dto.SOLStateConnected
+	s.idleTimer = time.AfterFunc(solIdleTimeout, s.idle)
+	s.mu.Unlock()
+
+	return nil
+}
+
+func (s *solSession) listen(ctx context.Context) {
+	for {
+		data, err := s.uc.redirection.RedirectListen(ctx, s.conn)
+		if err != nil {
+			s.fail(err)
+
+			return
+		}
+
+		if len(data) == 0 {
+			continue
+		}
+
+		s.conn.record(recordings.FromDevice, data)
+
+		if err := s.handle(ctx, data); err != nil {
+			s.fail(err)
+
+			return
+		}
+	}
+}
+
+// handle processes the messages in data, a message can be split across reads and a read can hold several.
+func (s *solSession) handle(ctx context.Context, data []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.pending = append(s.pending, data...)
+
+	for len(s.pending) > 0 {
+		size := solMessageSize(s.pending)
+		if size < 0 {
+			s.uc.log.Warn("devices - sol - unknown message " + strconv.Itoa(int(s.pending[0])) + " from " + s.info.GUID)
+			s.pending = nil
+
+			return nil
+		}
+
+		if size == 0 || len(s.pending) < size {
+			return nil
+		}
+
+		msg := s.pending[:size]
+		s.pending = s.pending[size:]
+
+		if err := s.handleMessage(ctx, msg); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *solSession) handleMessage(ctx context.Context, msg []byte) error {
+	switch msg[0] {
+	case RedirectionCommandsStartRedirectionSessionReply, RedirectionCommandsAuthenticateSessionReply:
+		answer, authenticated, err := s.handshake.reply(msg)
+		if err != nil {
+			return err
+		}
+
+		if authenticated {
+			answer = s.settings()
+		}
+
+		return s.send(ctx, answer)
+	case RedirectionCommandsSOLSettingsReply:
+		s.signal(nil)
+	case RedirectionCommandsSOLDataToConsole:
+		s.output(msg[solDataHeaderSize:])
+	}
+
+	return nil
+}
+
+// settings are the serial settings Intel AMT expects before it relays data: a 10000 byte transmit buffer,
+// 100ms transmit and receive flush timeouts, a 10s receive timeout and no heartbeat.
+func (s *solSession) settings() []byte {
+	msg := []byte{RedirectionCommandsSOLSettings, 0, 0, 0}
+	msg = binary.LittleEndian.AppendUint32(msg, s.nextSequence())
+
+	for _, setting := range []uint16{10000, 100, 0, 10000, 100, 0} {
+		msg = binary.LittleEndian.AppendUint16(msg, setting)
+	}
+
+	return binary.LittleEndian.AppendUint32(msg, 0)
+}
+
+func (s *solSession) sendKeys(ctx context.Context, keys []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.info.State != dto.SOLStateConnected {
+		return ErrNotValid.Wrap("SendSOLKeys", "solSession.sendKeys", errSOLNotConnected)
+	}
+
+	for len(keys) > 0 {
+		chunk := keys[:min(len(keys), solMaxKeysLength)]
+		keys = keys[len(chunk):]
+
+		msg := []byte{RedirectionCommandsSOLDataToDevice, 0, 0, 0}
+		msg = binary.LittleEndian.AppendUint32(msg, s.nextSequence())
+		msg = binary.LittleEndian.AppendUint16(msg, uint16(len(chunk)))
+		msg = append(msg, chunk...)
+
+		if err := s.send(ctx, msg); err != nil {
+			return ErrAMT.Wrap("SendSOLKeys", "uc.redirection.RedirectSend", err)
+		}
+	}
+
+	return nil
+}
+
+// send writes msg to the device, the caller holds s.mu.
+func (s *solSession) send(ctx context.Context, msg []byte) error {
+	s.conn.record(recordings.FromBrowser, msg)
+
+	return s.uc.redirection.RedirectSend(ctx, s.conn, msg)
+}
+
+func (s *solSession) nextSequence() uint32 {
+	s.sequence++
+
+	return s.sequence
+}
+
+// output renders terminal data and streams the completed lines, the caller holds s.mu.
+func (s *solSession) output(data []byte) {
+	s.screen.write(data)
+
+	lines := strings.Split(s.partial+s.screen.takeText(), "\n")
+	s.partial = lines[len(lines)-1]
+
+	for _, line := range lines[:len(lines)-1] {
+		s.broadcast(line)
+	}
+
+	if s.promptTimer != nil {
+		s.promptTimer.Stop()
+	}
+
+	if s.partial != "" {
+		s.promptTimer = time.AfterFunc(solPromptDelay, s.flushPartial)
+	}
+}
+
+func (s *solSession) flushPartial() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.partial != "" {
+		s.broadcast(s.partial)
+		s.partial = ""
+	}
+}
+
+func (s *solSession) broadcast(line string) {
+	line = strings.ReplaceAll(line, "\r", "")
+
+	for lines := range s.subscribers {
+		select {
+		case lines <- line:
+		default:
+		}
+	}
+}
+
+func (s *solSession) signal(err error) {
+	select {
+	case s.ready <- err:
+	default:
+	}
+}
+
+func (s *solSession) snapshot() dto.SOLSession {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.info
+}
+
+// touch postpones closing the session for being idle.
+func (s *solSession) touch() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.idleTimer != nil {
+		s.idleTimer.Reset(solIdleTimeout)
+	}
+}
+
+func (s *solSession) idle() {
+	s.mu.Lock()
+	watched := len(s.subscribers) > 0
+
+	if watched {
+		s.idleTimer.Reset(solIdleTimeout)
+	}
+	s.mu.Unlock()
+
+	if !watched {
+		s.close()
+	}
+}
+
+func (s *solSession) fail(err error) {
+	select {
+	case <-s.done:
+		return
+	default:
+	}
+
+	s.signal(err)
+	s.uc.log.Warn("devices - sol - session with " + s.info.GUID + " ended: " + err.Error())
+	s.close()
+}
+
+// close ends the session with the device, it is safe to call more than once and from any goroutine.
+func (s *solSession) close() {
+	s.closeOnce.Do(func() {
+		ctx := tenant.NewContext(context.Background(), s.tenantID)
+
+		s.uc.solSessionsMu.Lock()
+		if s.uc.solSessions[s.info.GUID] == s {
+			delete(s.uc.solSessions, s.info.GUID)
+		}
+		s.uc.solSessionsMu.Unlock()
+
+		s.mu.Lock()
+		connected := s.info.State == dto.SOLStateConnected
+		s.info.State = dto.SOLStateClosed
+
+		if connected {
+			_ = s.send(ctx, redirectionEndSession)
+		}
+
+		if s.promptTimer != nil {
+			s.promptTimer.Stop()
+		}
+
+		if s.idleTimer != nil {
+			s.idleTimer.Stop()
+		}
+		s.mu.Unlock()
+
+		s.signal(errSOLClosed)
+		close(s.done)
+
+		_ = s.uc.redirection.RedirectClose(ctx, s.conn)
+		s.uc.stopRecording(s.conn)
+
+		if connected {
+			s.uc.publish(ctx, dto.EventRedirectionClosed, &s.conn.Device, dto.RedirectionEvent{Mode: recordings.ModeSOL})
+		}
+	})
+}
+
+// solMessageSize returns the size of the message at the start of msg, 0 when more data is needed to tell
+// and -1 when the message is unknown.
+func solMessageSize(msg []byte) int {
+	if size, ok := handshakeMessageSize(msg); ok {
+		return size
+	}
+
+	switch msg[0] {
+	case RedirectionCommandsSOLSettingsReply:
+		return solSettingsReplySize
+	case RedirectionCommandsSOLSerialStatus:
+		return solSerialStatusSize
+	case RedirectionCommandsSOLDataToConsole:
+		if len(msg) < solDataHeaderSize {
+			return 0
+		}
+
+		return solDataHeaderSize + int(binary.LittleEndian.Uint16(msg[8:10]))
+	case RedirectionCommandsSOLKeepAlive:
+		return solKeepAliveSize
+	default:
+		return -1
+	}
+}
@@ -0,0 +1,250 @@
+package devices_test
+
+import (
+	"context"
+	"encoding/binary"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/open-amt-cloud-toolkit/go-wsman-messages/v2/pkg/wsman"
+	"github.com/stretchr/testify/require"
+	gomock "go.uber.org/mock/gomock"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity"
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	"github.com/open-amt-cloud-toolkit/console/internal/mocks"
+	devices "github.com/open-amt-cloud-toolkit/console/internal/usecase/devices"
+	"github.com/open-amt-cloud-toolkit/console/pkg/logger"
+	"github.com/open-amt-cloud-toolkit/console/pkg/tenant"
+)
+
+// solDevice plays the device side of the SOL handshake: it answers what the console sends and hands the replies
+// to RedirectListen.
+type solDevice struct {
+	mu        sync.Mutex
+	refuse    bool
+	authSteps int
+	replies   chan []byte
+	sent      chan []byte
+	closeOnce sync.Once
+}
+
+func newSOLDevice(mockRedirection *mocks.MockRedirection) *solDevice {
+	d := &solDevice{
+		replies: make(chan []byte, 16),
+		sent:    make(chan []byte, 16),
+	}
+
+	mockRedirection.EXPECT().SetupWsmanClient(gomock.Any(), true, true).Return(wsman.Messages{}).AnyTimes()
+	mockRedirection.EXPECT().RedirectConnect(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	mockRedirection.EXPECT().RedirectSend(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ *devices.DeviceConnection, msg []byte) error {
+			d.receive(msg)
+
+			return nil
+		}).AnyTimes()
+	mockRedirection.EXPECT().RedirectListen(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ *devices.DeviceConnection) ([]byte, error) {
+			data, ok := <-d.replies
+			if !ok {
+				return nil, io.EOF
+			}
+
+			return data, nil
+		}).AnyTimes()
+	mockRedirection.EXPECT().RedirectClose(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ *devices.DeviceConnection) error {
+			d.closeOnce.Do(func() { close(d.replies) })
+
+			return nil
+		}).AnyTimes()
+
+	return d
+}
+
+func (d *solDevice) receive(msg []byte) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	switch msg[0] {
+	case devices.RedirectionCommandsStartRedirectionSession:
+		status := byte(devices.StartRedirectionSessionReplyStatusSuccess)
+		if d.refuse {
+			status = 1
+		}
+
+		d.replies <- []byte{devices.RedirectionCommandsStartRedirectionSessionReply, status, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}
+	case devices.RedirectionCommandsAuthenticateSession:
+		d.authSteps++
+
+		switch d.authSteps {
+		case 1:
+			d.replies <- authReply(devices.AuthenticationStatusSuccess, devices.AuthenticationTypeQuery, []byte{devices.AuthenticationTypeDigest})
+		case 2:
+			// the reply carries the realm, nonce and qop, split over two reads
+			reply := authReply(devices.AuthenticationStatusFail, devices.AuthenticationTypeDigest, []byte("\x05realm\x05nonce\x04auth"))
+			d.replies <- reply[:5]
+			d.replies <- reply[5:]
+		default:
+			d.replies <- authReply(devices.AuthenticationStatusSuccess, devices.AuthenticationTypeDigest, nil)
+		}
+	case devices.RedirectionCommandsSOLSettings:
+		d.replies <- append([]byte{devices.RedirectionCommandsSOLSettingsReply}, make([]byte, 22)...)
+	case devices.RedirectionCommandsSOLDataToDevice:
+		d.sent <- msg
+	}
+}
+
+func authReply(status, authType byte, data []byte) []byte {
+	msg := []byte{devices.RedirectionCommandsAuthenticateSessionReply, status, 0, 0, authType}
+	msg = binary.LittleEndian.AppendUint32(msg, uint32(len(data)))
+
+	return append(msg, data...)
+}
+
+func solData(text string) []byte {
+	msg := []byte{devices.RedirectionCommandsSOLDataToConsole, 0, 0, 0, 1, 0, 0, 0}
+	msg = binary.LittleEndian.AppendUint16(msg, uint16(len(text)))
+
+	return append(msg, text...)
+}
+
+func initSOLTest(t *testing.T) (*devices.UseCase, *solDevice) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+
+	repo := mocks.NewMockDeviceManagementRepository(ctrl)
+	repo.EXPECT().GetByID(gomock.Any(), "device-guid-123", "").Return(&entity.Device{
+		GUID:     "device-guid-123",
+		Username: "admin",
+		Password: "password",
+	}, nil).AnyTimes()
+	repo.EXPECT().GetByID(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+
+	recorder := mocks.NewMockRedirectionRecorder(ctrl)
+	recorder.EXPECT().Record(gomock.Any(), gomock.Any(), "sol").Return(nil, nil).AnyTimes()
+
+	mockRedirection := mocks.NewMockRedirection(ctrl)
+	device := newSOLDevice(mockRedirection)
+
+	uc := devices.New(repo, mocks.NewMockWSMAN(ctrl), mockRedirection, anyPublisher(ctrl), recorder, nil, logger.New("error"), mocks.MockCrypto{})
+
+	return uc, device
+}
+
+func receiveLine(t *testing.T, lines <-chan string) string {
+	t.Helper()
+
+	select {
+	case line := <-lines:
+		return line
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "no line from the SOL session")
+
+		return ""
+	}
+}
+
+func TestSOLSession(t *testing.T) {
+	t.Parallel()
+
+	uc, device := initSOLTest(t)
+	ctx := context.Background()
+
+	session, err := uc.OpenSOL(ctx, "device-guid-123")
+	require.NoError(t, err)
+	require.Equal(t, dto.SOLStateConnected, session.State)
+	require.Equal(t, "device-guid-123", session.GUID)
+
+	again, err := uc.OpenSOL(ctx, "device-guid-123")
+	require.NoError(t, err)
+	require.Equal(t, session.StartTime, again.StartTime)
+
+	subscribeCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	lines, err := uc.SubscribeSOL(subscribeCtx, "device-guid-123")
+	require.NoError(t, err)
+
+	device.replies <- solData("\x1b[2J\x1b[1;1HWelcome\r\nlog")
+	device.replies <- solData("in: ")
+
+	require.Equal(t, "Welcome", receiveLine(t, lines))
+	require.Equal(t, "login: ", receiveLine(t, lines))
+
+	screen, err := uc.GetSOLScreen(ctx, "device-guid-123")
+	require.NoError(t, err)
+	require.Equal(t, "Welcome", screen.Rows[0])
+	require.Equal(t, "login:", screen.Rows[1])
+	require.Equal(t, 1, screen.CursorRow)
+	require.Equal(t, 7, screen.CursorColumn)
+
+	require.NoError(t, uc.SendSOLKeys(ctx, "device-guid-123", "root\r"))
+
+	select {
+	case msg := <-device.sent:
+		// the settings took sequence number 1
+		require.Equal(t, []byte{devices.RedirectionCommandsSOLDataToDevice, 0, 0, 0, 2, 0, 0, 0, 5, 0, 'r', 'o', 'o', 't', '\r'}, msg)
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "keys were not sent to the device")
+	}
+
+	require.NoError(t, uc.CloseSOL(ctx, "device-guid-123"))
+
+	_, ok := <-lines
+	require.False(t, ok)
+
+	_, err = uc.GetSOL(ctx, "device-guid-123")
+	require.ErrorIs(t, err, devices.ErrNotFound)
+}
+
+func TestSOLSessionOtherTenant(t *testing.T) {
+	t.Parallel()
+
+	uc, _ := initSOLTest(t)
+
+	_, err := uc.OpenSOL(context.Background(), "device-guid-123")
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = uc.CloseSOL(context.Background(), "device-guid-123") })
+
+	other := tenant.NewContext(context.Background(), "other-tenant")
+
+	_, err = uc.GetSOL(other, "device-guid-123")
+	require.ErrorIs(t, err, devices.ErrNotFound)
+
+	err = uc.SendSOLKeys(other, "device-guid-123", "x")
+	require.ErrorIs(t, err, devices.ErrNotFound)
+
+	err = uc.CloseSOL(other, "device-guid-123")
+	require.ErrorIs(t, err, devices.ErrNotFound)
+}
+
+func TestOpenSOLFails(t *testing.T) {
+	t.Parallel()
+
+	t.Run("refused by the device", func(t *testing.T) {
+		t.Parallel()
+
+		uc, device := initSOLTest(t)
+		device.refuse = true
+
+		_, err := uc.OpenSOL(context.Background(), "device-guid-123")
+		require.Error(t, err)
+
+		_, err = uc.GetSOL(context.Background(), "device-guid-123")
+		require.ErrorIs(t, err, devices.ErrNotFound)
+	})
+
+	t.Run("unknown device", func(t *testing.T) {
+		t.Parallel()
+
+		uc, _ := initSOLTest(t)
+
+		_, err := uc.OpenSOL(context.Background(), "unknown-guid")
+		require.ErrorIs(t, err, devices.ErrNotFound)
+	})
+}
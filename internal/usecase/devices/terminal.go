@@ -0,0 +1,255 @@
+package devices
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+)
+
+const (
+	terminalRows    = 25
+	terminalColumns = 80
+	terminalTabStop = 8
+)
+
+type terminalState int
+
+const (
+	terminalText terminalState = iota
+	terminalEscape
+	terminalCSI
+	terminalCharset
+)
+
+// terminal keeps the screen of a SOL session. It understands the subset of VT100 that BIOS setup screens,
+// boot loaders and serial consoles use: cursor movement, erasing and scrolling, attributes are dropped.
+type terminal struct {
+	cells  [terminalRows][terminalColumns]rune
+	row    int
+	column int
+	state  terminalState
+	params []byte
+	// text is what was printed since the last takeText, without control sequences.
+	text strings.Builder
+}
+
+func newTerminal() *terminal {
+	t := &terminal{}
+	t.erase(0, 0, terminalRows-1, terminalColumns-1)
+
+	return t
+}
+
+func (t *terminal) write(data []byte) {
+	for _, b := range data {
+		switch t.state {
+		case terminalText:
+			t.control(b)
+		case terminalEscape:
+			t.escape(b)
+		case terminalCSI:
+			t.csi(b)
+		case terminalCharset:
+			t.state = terminalText
+		}
+	}
+}
+
+func (t *terminal) control(b byte) {
+	switch b {
+	case 0x1b:
+		t.state = terminalEscape
+	case '\r':
+		t.column = 0
+		t.text.WriteByte(b)
+	case '\n':
+		t.lineFeed()
+		t.text.WriteByte(b)
+	case '\b':
+		if t.column > 0 {
+			t.column--
+		}
+	case '\t':
+		t.column = min((t.column/terminalTabStop+1)*terminalTabStop, terminalColumns-1)
+		t.text.WriteByte(b)
+	default:
+		if b < ' ' || b == 0x7f {
+			return
+		}
+
+		if t.column == terminalColumns {
+			t.column = 0
+			t.lineFeed()
+		}
+
+		// anything above ASCII is taken as Latin-1, BIOS line drawing characters have no better rendering
+		r := rune(b)
+		t.cells[t.row][t.column] = r
+		t.column++
+		t.text.WriteRune(r)
+	}
+}
+
+func (t *terminal) escape(b byte) {
+	t.state = terminalText
+
+	switch b {
+	case '[':
+		t.state = terminalCSI
+		t.params = t.params[:0]
+	case '(', ')':
+		t.state = terminalCharset
+	case 'D':
+		t.lineFeed()
+	case 'E':
+		t.column = 0
+		t.lineFeed()
+	case 'M':
+		if t.row > 0 {
+			t.row--
+		}
+	case 'c':
+		t.erase(0, 0, terminalRows-1, terminalColumns-1)
+		t.row, t.column = 0, 0
+	}
+}
+
+func (t *terminal) csi(b byte) {
+	if b >= 0x20 && b <= 0x3f {
+		t.params = append(t.params, b)
+
+		return
+	}
+
+	t.state = terminalText
+	args := t.arguments()
+
+	switch b {
+	case 'H', 'f':
+		t.row = clamp(arg(args, 0, 1)-1, 0, terminalRows-1)
+		t.column = clamp(arg(args, 1, 1)-1, 0, terminalColumns-1)
+	case 'A':
+		t.row = clamp(t.row-arg(args, 0, 1), 0, terminalRows-1)
+	case 'B':
+		t.row = clamp(t.row+arg(args, 0, 1), 0, terminalRows-1)
+	case 'C':
+		t.column = clamp(t.column+arg(args, 0, 1), 0, terminalColumns-1)
+	case 'D':
+		t.column = clamp(t.column-arg(args, 0, 1), 0, terminalColumns-1)
+	case 'G':
+		t.column = clamp(arg(args, 0, 1)-1, 0, terminalColumns-1)
+	case 'd':
+		t.row = clamp(arg(args, 0, 1)-1, 0, terminalRows-1)
+	case 'J':
+		t.eraseDisplay(arg(args, 0, 0))
+	case 'K':
+		t.eraseLine(arg(args, 0, 0))
+	}
+}
+
+func (t *terminal) arguments() []int {
+	params := strings.TrimLeft(string(t.params), "?")
+	if params == "" {
+		return nil
+	}
+
+	fields := strings.Split(params, ";")
+	args := make([]int, len(fields))
+
+	for i, field := range fields {
+		args[i], _ = strconv.Atoi(field)
+	}
+
+	return args
+}
+
+func (t *terminal) eraseDisplay(mode int) {
+	column := min(t.column, terminalColumns-1)
+
+	switch mode {
+	case 0:
+		t.erase(t.row, column, terminalRows-1, terminalColumns-1)
+	case 1:
+		t.erase(0, 0, t.row, column)
+	default:
+		t.erase(0, 0, terminalRows-1, terminalColumns-1)
+	}
+}
+
+func (t *terminal) eraseLine(mode int) {
+	column := min(t.column, terminalColumns-1)
+
+	switch mode {
+	case 0:
+		t.erase(t.row, column, t.row, terminalColumns-1)
+	case 1:
+		t.erase(t.row, 0, t.row, column)
+	default:
+		t.erase(t.row, 0, t.row, terminalColumns-1)
+	}
+}
+
+// erase blanks the cells from one position to another in reading order, both included.
+func (t *terminal) erase(fromRow, fromColumn, toRow, toColumn int) {
+	for row := fromRow; row <= toRow; row++ {
+		first, last := 0, terminalColumns-1
+
+		if row == fromRow {
+			first = fromColumn
+		}
+
+		if row == toRow {
+			last = toColumn
+		}
+
+		for column := first; column <= last; column++ {
+			t.cells[row][column] = ' '
+		}
+	}
+}
+
+func (t *terminal) lineFeed() {
+	if t.row < terminalRows-1 {
+		t.row++
+
+		return
+	}
+
+	copy(t.cells[:], t.cells[1:])
+	t.erase(terminalRows-1, 0, terminalRows-1, terminalColumns-1)
+}
+
+// takeText returns what was printed since the last call.
+func (t *terminal) takeText() string {
+	text := t.text.String()
+	t.text.Reset()
+
+	return text
+}
+
+func (t *terminal) screen() dto.SOLScreen {
+	rows := make([]string, terminalRows)
+
+	for i := range t.cells {
+		rows[i] = strings.TrimRight(string(t.cells[i][:]), " ")
+	}
+
+	return dto.SOLScreen{
+		Rows:         rows,
+		CursorRow:    t.row,
+		CursorColumn: min(t.column, terminalColumns-1),
+	}
+}
+
+func arg(args []int, i, fallback int) int {
+	if i >= len(args) || args[i] == 0 {
+		return fallback
+	}
+
+	return args[i]
+}
+
+func clamp(v, low, high int) int {
+	return max(low, min(v, high))
+}
@@ -0,0 +1,160 @@
+package devices
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTerminalWrite(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		input  string
+		rows   map[int]string
+		row    int
+		column int
+	}{
+		{
+			name:   "plain lines",
+			input:  "hello\r\nworld",
+			rows:   map[int]string{0: "hello", 1: "world"},
+			row:    1,
+			column: 5,
+		},
+		{
+			name:   "cursor position",
+			input:  "\x1b[3;10Hboot",
+			rows:   map[int]string{0: "", 2: "         boot"},
+			row:    2,
+			column: 13,
+		},
+		{
+			name:   "cursor position is clamped",
+			input:  "\x1b[99;99H",
+			row:    terminalRows - 1,
+			column: terminalColumns - 1,
+		},
+		{
+			name:   "relative cursor movement",
+			input:  "\x1b[5;5H\x1b[2A\x1b[3C\x1b[1B\x1b[D",
+			row:    3,
+			column: 6,
+		},
+		{
+			name:   "erase to end of line",
+			input:  "abcdef\x1b[1;3H\x1b[K",
+			rows:   map[int]string{0: "ab"},
+			row:    0,
+			column: 2,
+		},
+		{
+			name:   "erase display",
+			input:  "abc\r\ndef\x1b[2J",
+			rows:   map[int]string{0: "", 1: ""},
+			row:    1,
+			column: 3,
+		},
+		{
+			name:   "attributes and charsets are dropped",
+			input:  "\x1b[1;37;44m\x1b(Bok\x1b[0m",
+			rows:   map[int]string{0: "ok"},
+			row:    0,
+			column: 2,
+		},
+		{
+			name:   "backspace and tab",
+			input:  "ab\bc\td",
+			rows:   map[int]string{0: "ac      d"},
+			row:    0,
+			column: 9,
+		},
+		{
+			name:   "long lines wrap",
+			input:  strings.Repeat("x", terminalColumns+1),
+			rows:   map[int]string{0: strings.Repeat("x", terminalColumns), 1: "x"},
+			row:    1,
+			column: 1,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			term := newTerminal()
+			term.write([]byte(tc.input))
+
+			screen := term.screen()
+
+			require.Len(t, screen.Rows, terminalRows)
+
+			for row, text := range tc.rows {
+				require.Equal(t, text, screen.Rows[row], "row %d", row)
+			}
+
+			require.Equal(t, tc.row, screen.CursorRow)
+			require.Equal(t, tc.column, screen.CursorColumn)
+		})
+	}
+}
+
+func TestTerminalScroll(t *testing.T) {
+	t.Parallel()
+
+	term := newTerminal()
+
+	for i := 0; i <= terminalRows; i++ {
+		term.write([]byte("line " + string(rune('a'+i)) + "\r\n"))
+	}
+
+	screen := term.screen()
+
+	require.Equal(t, "line c", screen.Rows[0])
+	require.Equal(t, "line z", screen.Rows[terminalRows-2])
+	require.Equal(t, "", screen.Rows[terminalRows-1])
+	require.Equal(t, terminalRows-1, screen.CursorRow)
+}
+
+func TestTerminalTakeText(t *testing.T) {
+	t.Parallel()
+
+	term := newTerminal()
+	term.write([]byte("\x1b[2J\x1b[1;1Hlogin: \x1b[1mroot\x1b[0m\r\n"))
+
+	require.Equal(t, "login: root\r\n", term.takeText())
+	require.Equal(t, "", term.takeText())
+}
+
+func TestSOLMessageSize(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		msg  []byte
+		size int
+	}{
+		{"start reply", []byte{RedirectionCommandsStartRedirectionSessionReply, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 2}, 15},
+		{"start reply incomplete", []byte{RedirectionCommandsStartRedirectionSessionReply, 0}, 0},
+		{"authenticate reply", []byte{RedirectionCommandsAuthenticateSessionReply, 0, 0, 0, 4, 3, 0, 0, 0}, 12},
+		{"settings reply", []byte{RedirectionCommandsSOLSettingsReply}, solSettingsReplySize},
+		{"data", []byte{RedirectionCommandsSOLDataToConsole, 0, 0, 0, 1, 0, 0, 0, 5, 0}, 15},
+		{"data incomplete", []byte{RedirectionCommandsSOLDataToConsole, 0, 0}, 0},
+		{"keep alive", []byte{RedirectionCommandsSOLKeepAlive}, solKeepAliveSize},
+		{"unknown", []byte{0xFF}, -1},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tc.size, solMessageSize(tc.msg))
+		})
+	}
+}
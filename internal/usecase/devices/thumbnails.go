@@ -0,0 +1,205 @@
+package devices
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"image/png"
+	"sync"
+	"time"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity"
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	"github.com/open-amt-cloud-toolkit/console/pkg/logger"
+	"github.com/open-amt-cloud-toolkit/console/pkg/tenant"
+)
+
+const (
+	thumbnailPageSize     = 100
+	defaultThumbnailWidth = 320
+)
+
+// ThumbnailConfig controls how often the screens of the fleet are captured.
+type ThumbnailConfig struct {
+	// Interval between two captures of the fleet, zero disables the thumbnailer.
+	Interval time.Duration
+	// Width of the thumbnails, the height keeps the aspect of the screen.
+	Width int
+	// Workers caps the number of captures in flight.
+	Workers int
+}
+
+// Thumbnailer periodically captures the screen of every connected device into a small image the device list
+// shows.
+type Thumbnailer struct {
+	uc   *UseCase
+	repo ThumbnailRepository
+	log  logger.Interface
+	cfg  ThumbnailConfig
+	now  func() time.Time
+}
+
+// NewThumbnailer -.
+func NewThumbnailer(uc *UseCase, repo ThumbnailRepository, log logger.Interface, cfg ThumbnailConfig) *Thumbnailer {
+	if cfg.Width <= 0 {
+		cfg.Width = defaultThumbnailWidth
+	}
+
+	if cfg.Workers <= 0 {
+		cfg.Workers = 1
+	}
+
+	return &Thumbnailer{
+		uc:   uc,
+		repo: repo,
+		log:  log,
+		cfg:  cfg,
+		now:  time.Now,
+	}
+}
+
+// Run captures the fleet every interval until ctx is done.
+func (t *Thumbnailer) Run(ctx context.Context) {
+	if t.cfg.Interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(t.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		t.Capture(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Capture takes a thumbnail of every connected device once and waits for the captures to finish.
+func (t *Thumbnailer) Capture(ctx context.Context) {
+	sem := make(chan struct{}, t.cfg.Workers)
+
+	var wg sync.WaitGroup
+
+	defer wg.Wait()
+
+	for skip := 0; ; skip += thumbnailPageSize {
+		page, err := t.uc.repo.GetAllTenants(ctx, thumbnailPageSize, skip)
+		if err != nil {
+			t.log.Error(err, "devices - thumbnails - Capture - t.uc.repo.GetAllTenants")
+
+			return
+		}
+
+		for i := range page {
+			// a device the health poller could not reach has no screen to capture either
+			if !page[i].ConnectionStatus {
+				continue
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case sem <- struct{}{}:
+			}
+
+			wg.Add(1)
+
+			go func(d entity.Device) {
+				defer func() {
+					<-sem
+					wg.Done()
+				}()
+
+				t.thumbnail(ctx, d)
+			}(page[i])
+		}
+
+		if len(page) < thumbnailPageSize {
+			return
+		}
+	}
+}
+
+func (t *Thumbnailer) thumbnail(ctx context.Context, d entity.Device) {
+	img, err := t.uc.capture(ctx, d)
+	if err != nil {
+		t.log.Debug("devices - thumbnails - thumbnail - " + d.GUID + ": " + err.Error())
+
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, downscale(img, t.cfg.Width)); err != nil {
+		t.log.Error(err, "devices - thumbnails - thumbnail - png.Encode")
+
+		return
+	}
+
+	thumbnail := &entity.DeviceThumbnail{
+		GUID:       d.GUID,
+		CapturedAt: t.now().UTC().Format(time.RFC3339),
+		Image:      buf.Bytes(),
+		TenantID:   d.TenantID,
+	}
+
+	if err := t.repo.Upsert(ctx, thumbnail); err != nil {
+		t.log.Error(err, "devices - thumbnails - thumbnail - t.repo.Upsert")
+	}
+}
+
+// GetThumbnail returns the last thumbnail captured of a device of the caller's tenant.
+func (t *Thumbnailer) GetThumbnail(ctx context.Context, guid string) (dto.DeviceThumbnail, error) {
+	thumbnail, err := t.repo.Get(ctx, guid, tenant.FromContext(ctx))
+	if err != nil {
+		return dto.DeviceThumbnail{}, err
+	}
+
+	if thumbnail == nil {
+		return dto.DeviceThumbnail{}, ErrNotFound
+	}
+
+	capturedAt, _ := time.Parse(time.RFC3339, thumbnail.CapturedAt)
+
+	return dto.DeviceThumbnail{
+		GUID:       thumbnail.GUID,
+		CapturedAt: capturedAt,
+		Image:      thumbnail.Image,
+	}, nil
+}
+
+// downscale shrinks img to width, each pixel being the average of the ones it covers.
+func downscale(img image.Image, width int) image.Image {
+	src := img.Bounds()
+	if src.Dx() <= width {
+		return img
+	}
+
+	height := max(src.Dy()*width/src.Dx(), 1)
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+
+	for y := 0; y < height; y++ {
+		y0, y1 := src.Min.Y+y*src.Dy()/height, src.Min.Y+(y+1)*src.Dy()/height
+
+		for x := 0; x < width; x++ {
+			x0, x1 := src.Min.X+x*src.Dx()/width, src.Min.X+(x+1)*src.Dx()/width
+
+			var r, g, b, n uint32
+
+			for sy := y0; sy < max(y1, y0+1); sy++ {
+				for sx := x0; sx < max(x1, x0+1); sx++ {
+					c := color.RGBAModel.Convert(img.At(sx, sy)).(color.RGBA) //nolint:forcetypeassert // RGBAModel converts to RGBA
+					r, g, b, n = r+uint32(c.R), g+uint32(c.G), b+uint32(c.B), n+1
+				}
+			}
+
+			dst.SetRGBA(x, y, color.RGBA{R: uint8(r / n), G: uint8(g / n), B: uint8(b / n), A: 0xff}) //nolint:gosec // averages of bytes
+		}
+	}
+
+	return dst
+}
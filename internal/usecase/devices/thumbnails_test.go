@@ -0,0 +1,133 @@
+package devices_test
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	gomock "go.uber.org/mock/gomock"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity"
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	"github.com/open-amt-cloud-toolkit/console/internal/mocks"
+	devices "github.com/open-amt-cloud-toolkit/console/internal/usecase/devices"
+	"github.com/open-amt-cloud-toolkit/console/pkg/logger"
+)
+
+func initThumbnailTest(t *testing.T) (*mocks.MockDeviceManagementRepository, *mocks.MockThumbnailRepository, *kvmDevice, *devices.Thumbnailer) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+
+	repo := mocks.NewMockDeviceManagementRepository(ctrl)
+	thumbnails := mocks.NewMockThumbnailRepository(ctrl)
+	mockRedirection := mocks.NewMockRedirection(ctrl)
+	device := newKVMDevice(mockRedirection)
+
+	uc := devices.New(repo, mocks.NewMockWSMAN(ctrl), mockRedirection, anyPublisher(ctrl), mocks.NewMockRedirectionRecorder(ctrl), nil, logger.New("error"), mocks.MockCrypto{})
+	thumbnailer := devices.NewThumbnailer(uc, thumbnails, logger.New("error"), devices.ThumbnailConfig{Width: 2, Workers: 1})
+
+	return repo, thumbnails, device, thumbnailer
+}
+
+func TestThumbnailerCapture(t *testing.T) {
+	t.Parallel()
+
+	repo, thumbnails, device, thumbnailer := initThumbnailTest(t)
+
+	repo.EXPECT().GetAllTenants(gomock.Any(), 100, 0).Return([]entity.Device{
+		{GUID: "device-guid-123", TenantID: "tenant1", Username: "admin", Password: "password", ConnectionStatus: true},
+		// not reachable, so not captured
+		{GUID: "offline", TenantID: "tenant1", ConnectionStatus: false},
+	}, nil)
+
+	var stored *entity.DeviceThumbnail
+
+	thumbnails.EXPECT().Upsert(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, th *entity.DeviceThumbnail) error {
+		stored = th
+
+		return nil
+	})
+
+	go device.serveScreen(t, 4, 2, 0xf800, 0xf800, 0x001f, 0x001f, 0xf800, 0xf800, 0x001f, 0x001f)
+
+	thumbnailer.Capture(context.Background())
+
+	require.NotNil(t, stored)
+	require.Equal(t, "device-guid-123", stored.GUID)
+	require.Equal(t, "tenant1", stored.TenantID)
+
+	_, err := time.Parse(time.RFC3339, stored.CapturedAt)
+	require.NoError(t, err)
+
+	img, err := png.Decode(bytes.NewReader(stored.Image))
+	require.NoError(t, err)
+	require.Equal(t, image.Rect(0, 0, 2, 1), img.Bounds())
+	require.Equal(t, color.RGBA{R: 0xff, A: 0xff}, color.RGBAModel.Convert(img.At(0, 0)))
+	require.Equal(t, color.RGBA{B: 0xff, A: 0xff}, color.RGBAModel.Convert(img.At(1, 0)))
+}
+
+func TestGetThumbnail(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		guid   string
+		mock   func(m *mocks.MockThumbnailRepository)
+		result dto.DeviceThumbnail
+		err    error
+	}{
+		{
+			name: "success",
+			guid: "device-guid-123",
+			mock: func(m *mocks.MockThumbnailRepository) {
+				m.EXPECT().Get(gomock.Any(), "device-guid-123", "tenant1").Return(&entity.DeviceThumbnail{
+					GUID:       "device-guid-123",
+					CapturedAt: "2024-12-01T00:15:00Z",
+					Image:      []byte{0x89, 'P', 'N', 'G'},
+					TenantID:   "tenant1",
+				}, nil)
+			},
+			result: dto.DeviceThumbnail{
+				GUID:       "device-guid-123",
+				CapturedAt: time.Date(2024, 12, 1, 0, 15, 0, 0, time.UTC),
+				Image:      []byte{0x89, 'P', 'N', 'G'},
+			},
+		},
+		{
+			name: "not captured yet",
+			guid: "device-guid-123",
+			mock: func(m *mocks.MockThumbnailRepository) {
+				m.EXPECT().Get(gomock.Any(), "device-guid-123", "tenant1").Return(nil, nil)
+			},
+			err: devices.ErrNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, thumbnails, _, thumbnailer := initThumbnailTest(t)
+
+			tc.mock(thumbnails)
+
+			result, err := thumbnailer.GetThumbnail(vncContext(), tc.guid)
+			if tc.err != nil {
+				require.IsType(t, tc.err, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.result, result)
+		})
+	}
+}
@@ -1,7 +1,10 @@
 package devices
 
 import (
+	"context"
+	"encoding/json"
 	"strings"
+	"sync"
 
 	"github.com/open-amt-cloud-toolkit/go-wsman-messages/v2/pkg/security"
 
@@ -40,7 +43,17 @@ type UseCase struct {
 	repo             Repository
 	device           WSMAN
 	redirection      Redirection
-	redirConnections map[string]*DeviceConnection
+	publisher        Publisher
+	recorder         Recorder
+	policies         Policies
+	redirSessions    map[string]*DeviceConnection
+	redirSessionsMu  sync.Mutex
+	bulkJobs         map[string]*bulkJob
+	bulkJobsMu       sync.Mutex
+	solSessions      map[string]*solSession
+	solSessionsMu    sync.Mutex
+	iderSessions     map[string]*iderSession
+	iderSessionsMu   sync.Mutex
 	log              logger.Interface
 	safeRequirements security.Cryptor
 }
@@ -48,19 +61,21 @@ type UseCase struct {
 var ErrAMT = AMTError{Console: consoleerrors.CreateConsoleError("DevicesUseCase")}
 
 // New -.
-func New(r Repository, d WSMAN, redirection Redirection, log logger.Interface, safeRequirements security.Cryptor) *UseCase {
-	uc := &UseCase{
+func New(r Repository, d WSMAN, redirection Redirection, publisher Publisher, recorder Recorder, policies Policies, log logger.Interface, safeRequirements security.Cryptor) *UseCase {
+	return &UseCase{
 		repo:             r,
 		device:           d,
 		redirection:      redirection,
-		redirConnections: make(map[string]*DeviceConnection),
+		publisher:        publisher,
+		recorder:         recorder,
+		policies:         policies,
+		redirSessions:    make(map[string]*DeviceConnection),
+		bulkJobs:         make(map[string]*bulkJob),
+		solSessions:      make(map[string]*solSession),
+		iderSessions:     make(map[string]*iderSession),
 		log:              log,
 		safeRequirements: safeRequirements,
 	}
-	// start up the worker
-	go d.Worker()
-
-	return uc
 }
 
 // convert dto.Device to entity.Device.
@@ -129,8 +144,7 @@ func (uc *UseCase) entityToDTO(d *entity.Device) *dto.Device {
 		LastConnected:    d.LastConnected,
 		LastSeen:         d.LastSeen,
 		LastDisconnected: d.LastDisconnected,
-		// DeviceInfo:       d.DeviceInfo,
-		Username: d.Username,
+		Username:         d.Username,
 		// Password:        d.Password,
 		UseTLS:          d.UseTLS,
 		AllowSelfSigned: d.AllowSelfSigned,
@@ -140,5 +154,29 @@ func (uc *UseCase) entityToDTO(d *entity.Device) *dto.Device {
 		d1.CertHash = *d.CertHash
 	}
 
+	// deviceinfo is written by the health poller, rows it has not reached yet keep it empty
+	if d.DeviceInfo != "" {
+		info := &dto.DeviceInfo{}
+		if err := json.Unmarshal([]byte(d.DeviceInfo), info); err == nil {
+			d1.DeviceInfo = info
+		}
+	}
+
 	return d1
 }
+
+// publish emits an event about d on the events stream, subscribers filter on its guid and tags.
+func (uc *UseCase) publish(c context.Context, eventType string, d *entity.Device, data interface{}) {
+	var tags []string
+	if d.Tags != "" {
+		tags = strings.Split(d.Tags, ",")
+	}
+
+	uc.publisher.Publish(c, dto.Event{
+		Type:     eventType,
+		GUID:     d.GUID,
+		TenantID: d.TenantID,
+		Tags:     tags,
+		Data:     data,
+	})
+}
@@ -0,0 +1,466 @@
+package devices
+
+import (
+	"bufio"
+	"context"
+	"crypto/des" //nolint:gosec // VNC Authentication is defined with DES, the one-time password is what protects the gateway
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/binary"
+	"errors"
+	"io"
+	"math/big"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/open-amt-cloud-toolkit/go-wsman-messages/v2/pkg/wsman/client"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/recordings"
+	"github.com/open-amt-cloud-toolkit/console/pkg/clientip"
+	"github.com/open-amt-cloud-toolkit/console/pkg/logger"
+	"github.com/open-amt-cloud-toolkit/console/pkg/subject"
+	"github.com/open-amt-cloud-toolkit/console/pkg/tenant"
+)
+
+// RFB messages the gateway speaks to VNC clients before it relays the stream as it is.
+const (
+	rfbSecurityVNCAuth = 2
+	rfbChallengeSize   = 16
+	rfbSecurityFailed  = 1
+
+	vncHandshakeTimeout = 30 * time.Second
+	vncPasswordLength   = 8
+	vncPasswordLetters  = "abcdefghijkmnopqrstuvwxyzABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+	vncBufferSize       = 32 * 1024
+)
+
+var (
+	errVNCDisabled = errors.New("the VNC gateway is not enabled")
+	errVNCAuth     = errors.New("authentication failed")
+)
+
+// VNCConfig -.
+type VNCConfig struct {
+	// Address the gateway listens on, empty disables it.
+	Address string
+	// PasswordTTL is how long a one-time password can be used after it is handed out.
+	PasswordTTL time.Duration
+}
+
+type vncAllocation struct {
+	guid     string
+	tenantID string
+	subject  string
+	expires  time.Time
+}
+
+// VNCGateway exposes the KVM of devices as a standard VNC endpoint. A client authenticates with a one-time
+// password handed out by AllocateVNC, the gateway then opens the redirection session with the device's
+// credentials and relays the RFB stream the device speaks inside it.
+type VNCGateway struct {
+	uc          *UseCase
+	log         logger.Interface
+	cfg         VNCConfig
+	mu          sync.Mutex
+	allocations map[string]vncAllocation
+}
+
+// NewVNCGateway -.
+func NewVNCGateway(uc *UseCase, log logger.Interface, cfg VNCConfig) *VNCGateway {
+	return &VNCGateway{
+		uc:          uc,
+		log:         log,
+		cfg:         cfg,
+		allocations: make(map[string]vncAllocation),
+	}
+}
+
+// AllocateVNC hands out a one-time password to open the KVM of a device of the caller's tenant with.
+func (g *VNCGateway) AllocateVNC(ctx context.Context, guid string) (dto.VNCAllocation, error) {
+	if g.cfg.Address == "" {
+		return dto.VNCAllocation{}, ErrNotValid.Wrap("AllocateVNC", "VNCGateway.AllocateVNC", errVNCDisabled)
+	}
+
+	tenantID := tenant.FromContext(ctx)
+
+	device, err := g.uc.repo.GetByID(ctx, guid, tenantID)
+	if err != nil {
+		return dto.VNCAllocation{}, err
+	}
+
+	if device == nil || device.GUID == "" {
+		return dto.VNCAllocation{}, ErrNotFound
+	}
+
+	password, err := vncPassword()
+	if err != nil {
+		return dto.VNCAllocation{}, err
+	}
+
+	allocation := vncAllocation{
+		guid:     device.GUID,
+		tenantID: tenantID,
+		subject:  subject.FromContext(ctx),
+		expires:  time.Now().Add(g.cfg.PasswordTTL),
+	}
+
+	g.mu.Lock()
+	g.prune()
+	g.allocations[password] = allocation
+	g.mu.Unlock()
+
+	return dto.VNCAllocation{
+		GUID:      device.GUID,
+		Address:   g.cfg.Address,
+		Password:  password,
+		ExpiresAt: allocation.expires,
+	}, nil
+}
+
+// Run listens on the configured address until ctx is done.
+func (g *VNCGateway) Run(ctx context.Context) {
+	if g.cfg.Address == "" {
+		return
+	}
+
+	listener, err := (&net.ListenConfig{}).Listen(ctx, "tcp", g.cfg.Address)
+	if err != nil {
+		g.log.Error(err, "devices - vnc - listen")
+
+		return
+	}
+
+	g.Serve(ctx, listener)
+}
+
+// Serve accepts VNC clients on listener until ctx is done, then closes it.
+func (g *VNCGateway) Serve(ctx context.Context, listener net.Listener) {
+	go func() {
+		<-ctx.Done()
+		listener.Close()
+	}()
+
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if ctx.Err() == nil && !errors.Is(err, net.ErrClosed) {
+				g.log.Error(err, "devices - vnc - accept")
+			}
+
+			return
+		}
+
+		go g.handle(ctx, conn)
+	}
+}
+
+func (g *VNCGateway) handle(ctx context.Context, conn net.Conn) {
+	_ = conn.SetDeadline(time.Now().Add(vncHandshakeTimeout))
+
+	minor, err := rfbServerVersion(conn)
+	if err != nil {
+		conn.Close()
+
+		return
+	}
+
+	allocation, err := g.authenticate(conn, minor)
+	if err != nil {
+		rfbSecurityFailure(conn, minor, err)
+		conn.Close()
+
+		return
+	}
+
+	dc, device, policy, err := g.connect(ctx, conn, allocation)
+	if err != nil {
+		g.log.Warn("devices - vnc - opening the KVM of " + allocation.guid + ": " + err.Error())
+		rfbSecurityFailure(conn, minor, err)
+		conn.Close()
+
+		return
+	}
+
+	_ = conn.SetDeadline(time.Time{})
+
+	if err := binary.Write(conn, binary.BigEndian, uint32(rfbSecurityOK)); err != nil {
+		g.uc.closeRedirection(ctx, dc, 0, "")
+
+		return
+	}
+
+	g.uc.publish(ctx, dto.EventRedirectionOpened, &dc.Device, dto.RedirectionEvent{Mode: dc.Mode})
+
+	go g.uc.watchRedirection(ctx, dc, policy)
+	go g.toDevice(ctx, dc, conn)
+
+	g.fromDevice(ctx, dc, device, conn)
+}
+
+// authenticate runs VNC Authentication with the client and returns the allocation of the password it knew,
+// the password cannot be used again.
+func (g *VNCGateway) authenticate(conn net.Conn, minor int) (vncAllocation, error) {
+	if minor >= 7 {
+		if _, err := conn.Write([]byte{1, rfbSecurityVNCAuth}); err != nil {
+			return vncAllocation{}, err
+		}
+
+		securityType := make([]byte, 1)
+		if _, err := io.ReadFull(conn, securityType); err != nil {
+			return vncAllocation{}, err
+		}
+
+		if securityType[0] != rfbSecurityVNCAuth {
+			return vncAllocation{}, errRFBSecurity
+		}
+	} else if err := binary.Write(conn, binary.BigEndian, uint32(rfbSecurityVNCAuth)); err != nil {
+		return vncAllocation{}, err
+	}
+
+	challenge := make([]byte, rfbChallengeSize)
+	if _, err := rand.Read(challenge); err != nil {
+		return vncAllocation{}, err
+	}
+
+	if _, err := conn.Write(challenge); err != nil {
+		return vncAllocation{}, err
+	}
+
+	response := make([]byte, rfbChallengeSize)
+	if _, err := io.ReadFull(conn, response); err != nil {
+		return vncAllocation{}, err
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.prune()
+
+	for password, allocation := range g.allocations {
+		if subtle.ConstantTimeCompare(vncResponse(password, challenge), response) == 1 {
+			delete(g.allocations, password)
+
+			return allocation, nil
+		}
+	}
+
+	return vncAllocation{}, errVNCAuth
+}
+
+// prune drops the passwords that expired, the caller holds g.mu.
+func (g *VNCGateway) prune() {
+	now := time.Now()
+
+	for password, allocation := range g.allocations {
+		if now.After(allocation.expires) {
+			delete(g.allocations, password)
+		}
+	}
+}
+
+// connect opens the KVM redirection session of the allocated device and completes the RFB handshake with it,
+// the session is registered like the ones relayed for browsers and held to the tenant's policy.
+func (g *VNCGateway) connect(ctx context.Context, conn net.Conn, allocation vncAllocation) (*DeviceConnection, *bufio.Reader, dto.RedirectionPolicy, error) {
+	uc := g.uc
+
+	ctx = tenant.NewContext(subject.NewContext(ctx, allocation.subject), allocation.tenantID)
+
+	if host, _, err := net.SplitHostPort(conn.RemoteAddr().String()); err == nil {
+		ctx = clientip.NewContext(ctx, host)
+	}
+
+	device, err := uc.repo.GetByID(ctx, allocation.guid, allocation.tenantID)
+	if err != nil {
+		return nil, nil, dto.RedirectionPolicy{}, err
+	}
+
+	if device == nil || device.GUID == "" {
+		return nil, nil, dto.RedirectionPolicy{}, ErrNotFound
+	}
+
+	password, _ := uc.safeRequirements.Decrypt(device.Password)
+
+	dc := &DeviceConnection{
+		wsmanMessages: uc.redirection.SetupWsmanClient(*device, true, true),
+		Device:        *device,
+		Mode:          recordings.ModeKVM,
+		Challenge: client.AuthChallenge{
+			Username: device.Username,
+			Password: password,
+		},
+		ID:        uuid.New().String(),
+		Subject:   allocation.subject,
+		ClientIP:  clientip.FromContext(ctx),
+		StartTime: time.Now(),
+		tenantID:  allocation.tenantID,
+		done:      make(chan struct{}),
+		vncConn:   conn,
+	}
+
+	policy, err := uc.redirectionPolicy(ctx, dc.tenantID)
+	if err != nil {
+		return nil, nil, policy, err
+	}
+
+	if _, err := uc.reserveRedirection(dc, policy); err != nil {
+		return nil, nil, policy, err
+	}
+
+	dc.Recording, err = uc.recorder.Record(ctx, *device, dc.Mode)
+	if err != nil {
+		uc.releaseRedirection(dc)
+
+		return nil, nil, policy, err
+	}
+
+	if err := uc.redirection.RedirectConnect(ctx, dc); err != nil {
+		uc.releaseRedirection(dc)
+		uc.stopRecording(dc)
+
+		return nil, nil, policy, ErrAMT.Wrap("VNC", "uc.redirection.RedirectConnect", err)
+	}
+
+	reader := bufio.NewReader(&deviceReader{ctx: ctx, uc: uc, dc: dc})
+
+	if err := uc.kvmHandshake(ctx, dc, reader); err != nil {
+		// the client is still to be told why, so the session is not closed the usual way
+		uc.releaseRedirection(dc)
+		_ = uc.redirection.RedirectClose(ctx, dc)
+		uc.stopRecording(dc)
+
+		return nil, nil, policy, err
+	}
+
+	return dc, reader, policy, nil
+}
+
+// toDevice relays what the VNC client sends, starting with its ClientInit.
+func (g *VNCGateway) toDevice(ctx context.Context, dc *DeviceConnection, conn net.Conn) {
+	buf := make([]byte, vncBufferSize)
+	rfb := rfbInput{step: rfbInputClientInit}
+
+	for {
+		n, err := conn.Read(buf)
+		if err != nil {
+			g.uc.closeRedirection(ctx, dc, 0, "")
+
+			return
+		}
+
+		dc.bytesToDevice.Add(int64(n))
+
+		if rfb.Scan(buf[:n]) {
+			dc.touch()
+		}
+
+		if err := g.uc.kvmSend(ctx, dc, buf[:n]); err != nil {
+			g.uc.closeRedirection(ctx, dc, 0, "")
+
+			return
+		}
+	}
+}
+
+// fromDevice relays what the device sends, starting with its ServerInit, until the session is closed.
+func (g *VNCGateway) fromDevice(ctx context.Context, dc *DeviceConnection, device *bufio.Reader, conn net.Conn) {
+	defer g.uc.publish(ctx, dto.EventRedirectionClosed, &dc.Device, dto.RedirectionEvent{Mode: dc.Mode})
+	defer g.uc.stopRecording(dc)
+	defer g.uc.closeRedirection(ctx, dc, 0, "")
+
+	buf := make([]byte, vncBufferSize)
+
+	for {
+		n, err := device.Read(buf)
+		if err != nil {
+			return
+		}
+
+		dc.bytesFromDevice.Add(int64(n))
+
+		if _, err := conn.Write(buf[:n]); err != nil {
+			return
+		}
+	}
+}
+
+// rfbServerVersion offers RFB 3.8 to the client and returns the minor version it answered with, clients of
+// 3.3 and 3.7 are served as well.
+func rfbServerVersion(conn net.Conn) (int, error) {
+	if _, err := conn.Write([]byte(rfbVersion)); err != nil {
+		return 0, err
+	}
+
+	version := make([]byte, rfbVersionSize)
+	if _, err := io.ReadFull(conn, version); err != nil {
+		return 0, err
+	}
+
+	switch string(version) {
+	case "RFB 003.003\n":
+		return 3, nil
+	case "RFB 003.007\n":
+		return 7, nil
+	case rfbVersion:
+		return 8, nil
+	default:
+		return 0, errRFBVersion
+	}
+}
+
+// rfbSecurityFailure tells the client why it was not let in, only 3.8 clients are sent the reason.
+func rfbSecurityFailure(conn net.Conn, minor int, reason error) {
+	msg := binary.BigEndian.AppendUint32(nil, rfbSecurityFailed)
+
+	if minor >= 8 {
+		msg = binary.BigEndian.AppendUint32(msg, uint32(len(reason.Error()))) //nolint:gosec // error messages are short
+		msg = append(msg, reason.Error()...)
+	}
+
+	_, _ = conn.Write(msg)
+}
+
+// vncResponse is the answer to challenge of a client that knows password: the challenge encrypted with DES,
+// keyed with the password whose bits are mirrored in each byte.
+func vncResponse(password string, challenge []byte) []byte {
+	key := make([]byte, 8)
+	copy(key, password)
+
+	for i, b := range key {
+		var mirrored byte
+
+		for bit := 0; bit < 8; bit++ {
+			if b&(1<<bit) != 0 {
+				mirrored |= 0x80 >> bit
+			}
+		}
+
+		key[i] = mirrored
+	}
+
+	block, _ := des.NewCipher(key) //nolint:gosec // see the import
+	response := make([]byte, len(challenge))
+
+	for i := 0; i+block.BlockSize() <= len(challenge); i += block.BlockSize() {
+		block.Encrypt(response[i:], challenge[i:])
+	}
+
+	return response
+}
+
+func vncPassword() (string, error) {
+	password := make([]byte, vncPasswordLength)
+
+	for i := range password {
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(len(vncPasswordLetters))))
+		if err != nil {
+			return "", err
+		}
+
+		password[i] = vncPasswordLetters[n.Int64()]
+	}
+
+	return string(password), nil
+}
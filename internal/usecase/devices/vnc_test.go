@@ -0,0 +1,332 @@
+package devices_test
+
+import (
+	"context"
+	"crypto/des" //nolint:gosec // VNC Authentication
+	"encoding/binary"
+	"io"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/open-amt-cloud-toolkit/go-wsman-messages/v2/pkg/wsman"
+	"github.com/stretchr/testify/require"
+	gomock "go.uber.org/mock/gomock"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity"
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	"github.com/open-amt-cloud-toolkit/console/internal/mocks"
+	devices "github.com/open-amt-cloud-toolkit/console/internal/usecase/devices"
+	"github.com/open-amt-cloud-toolkit/console/pkg/logger"
+	"github.com/open-amt-cloud-toolkit/console/pkg/subject"
+	"github.com/open-amt-cloud-toolkit/console/pkg/tenant"
+)
+
+// kvmDevice plays the device side of a KVM session: it answers the redirection handshake, then the server
+// part of the RFB handshake, and hands what the client sends after it to the test.
+type kvmDevice struct {
+	mu        sync.Mutex
+	rfb       int
+	replies   chan []byte
+	sent      chan []byte
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+func newKVMDevice(mockRedirection *mocks.MockRedirection) *kvmDevice {
+	d := &kvmDevice{
+		replies: make(chan []byte, 16),
+		sent:    make(chan []byte, 16),
+		closed:  make(chan struct{}),
+	}
+
+	mockRedirection.EXPECT().SetupWsmanClient(gomock.Any(), true, true).Return(wsman.Messages{}).AnyTimes()
+	mockRedirection.EXPECT().RedirectConnect(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	mockRedirection.EXPECT().RedirectSend(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ *devices.DeviceConnection, msg []byte) error {
+			d.receive(msg)
+
+			return nil
+		}).AnyTimes()
+	mockRedirection.EXPECT().RedirectListen(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ *devices.DeviceConnection) ([]byte, error) {
+			select {
+			case data := <-d.replies:
+				return data, nil
+			case <-d.closed:
+				return nil, io.EOF
+			}
+		}).AnyTimes()
+	mockRedirection.EXPECT().RedirectClose(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ *devices.DeviceConnection) error {
+			d.closeOnce.Do(func() { close(d.closed) })
+
+			return nil
+		}).AnyTimes()
+
+	return d
+}
+
+func (d *kvmDevice) receive(msg []byte) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	switch {
+	case d.rfb == 0 && msg[0] == devices.RedirectionCommandsStartRedirectionSession:
+		status := byte(devices.StartRedirectionSessionReplyStatusSuccess)
+		if string(msg[4:8]) != "KVMR" {
+			status = devices.StartRedirectionSessionReplyStatusUnsupported
+		}
+
+		d.replies <- []byte{devices.RedirectionCommandsStartRedirectionSessionReply, status, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}
+	case d.rfb == 0 && msg[0] == devices.RedirectionCommandsAuthenticateSession:
+		if msg[4] == devices.AuthenticationTypeQuery {
+			d.replies <- authReply(devices.AuthenticationStatusSuccess, devices.AuthenticationTypeQuery, []byte{devices.AuthenticationTypeDigest})
+
+			return
+		}
+
+		d.replies <- authReply(devices.AuthenticationStatusSuccess, devices.AuthenticationTypeDigest, nil)
+		d.replies <- []byte("RFB 004.000\n")
+		d.rfb = 1
+	case d.rfb == 1:
+		// the client's version, the device then offers None
+		d.replies <- []byte{1, 1}
+		d.rfb = 2
+	case d.rfb == 2:
+		d.replies <- []byte{0, 0, 0, 0}
+		d.rfb = 3
+	default:
+		d.sent <- msg
+	}
+}
+
+func initVNCTest(t *testing.T, address string) (*devices.VNCGateway, *devices.UseCase, *kvmDevice) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+
+	repo := mocks.NewMockDeviceManagementRepository(ctrl)
+	repo.EXPECT().GetByID(gomock.Any(), "device-guid-123", "tenant1").Return(&entity.Device{
+		GUID:     "device-guid-123",
+		Username: "admin",
+		Password: "password",
+		TenantID: "tenant1",
+	}, nil).AnyTimes()
+	repo.EXPECT().GetByID(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+
+	recorder := mocks.NewMockRedirectionRecorder(ctrl)
+	recorder.EXPECT().Record(gomock.Any(), gomock.Any(), "kvm").Return(nil, nil).AnyTimes()
+
+	mockRedirection := mocks.NewMockRedirection(ctrl)
+	device := newKVMDevice(mockRedirection)
+
+	uc := devices.New(repo, mocks.NewMockWSMAN(ctrl), mockRedirection, anyPublisher(ctrl), recorder, nil, logger.New("error"), mocks.MockCrypto{})
+	gateway := devices.NewVNCGateway(uc, logger.New("error"), devices.VNCConfig{Address: address, PasswordTTL: time.Minute})
+
+	return gateway, uc, device
+}
+
+func serveVNC(t *testing.T, gateway *devices.VNCGateway) string {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	go gateway.Serve(ctx, listener)
+
+	return listener.Addr().String()
+}
+
+// vncLogin runs the RFB 3.8 handshake of a VNC client with VNC Authentication, it returns the security result
+// and the reason of a failure.
+func vncLogin(t *testing.T, address, password string) (net.Conn, uint32, string) {
+	t.Helper()
+
+	conn, err := net.Dial("tcp", address)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	require.NoError(t, conn.SetDeadline(time.Now().Add(5*time.Second)))
+
+	version := make([]byte, 12)
+	_, err = io.ReadFull(conn, version)
+	require.NoError(t, err)
+	require.Equal(t, "RFB 003.008\n", string(version))
+
+	_, err = conn.Write(version)
+	require.NoError(t, err)
+
+	types := make([]byte, 2)
+	_, err = io.ReadFull(conn, types)
+	require.NoError(t, err)
+	require.Equal(t, []byte{1, 2}, types)
+
+	_, err = conn.Write([]byte{2})
+	require.NoError(t, err)
+
+	challenge := make([]byte, 16)
+	_, err = io.ReadFull(conn, challenge)
+	require.NoError(t, err)
+
+	_, err = conn.Write(vncAuthResponse(password, challenge))
+	require.NoError(t, err)
+
+	var result uint32
+	require.NoError(t, binary.Read(conn, binary.BigEndian, &result))
+
+	if result == 0 {
+		return conn, result, ""
+	}
+
+	var length uint32
+	require.NoError(t, binary.Read(conn, binary.BigEndian, &length))
+
+	reason := make([]byte, length)
+	_, err = io.ReadFull(conn, reason)
+	require.NoError(t, err)
+
+	return conn, result, string(reason)
+}
+
+func vncAuthResponse(password string, challenge []byte) []byte {
+	key := make([]byte, 8)
+	copy(key, password)
+
+	for i, b := range key {
+		var mirrored byte
+
+		for bit := 0; bit < 8; bit++ {
+			if b&(1<<bit) != 0 {
+				mirrored |= 0x80 >> bit
+			}
+		}
+
+		key[i] = mirrored
+	}
+
+	block, _ := des.NewCipher(key) //nolint:gosec // VNC Authentication
+	response := make([]byte, 16)
+	block.Encrypt(response[:8], challenge[:8])
+	block.Encrypt(response[8:], challenge[8:])
+
+	return response
+}
+
+func vncContext() context.Context {
+	return subject.NewContext(tenant.NewContext(context.Background(), "tenant1"), "alice")
+}
+
+func TestVNCGateway(t *testing.T) {
+	t.Parallel()
+
+	gateway, uc, device := initVNCTest(t, ":5900")
+	address := serveVNC(t, gateway)
+
+	allocation, err := gateway.AllocateVNC(vncContext(), "device-guid-123")
+	require.NoError(t, err)
+	require.Equal(t, "device-guid-123", allocation.GUID)
+	require.Equal(t, ":5900", allocation.Address)
+	require.Len(t, allocation.Password, 8)
+
+	client, result, _ := vncLogin(t, address, allocation.Password)
+	require.Equal(t, uint32(0), result)
+
+	// ClientInit and ServerInit are relayed as they are
+	_, err = client.Write([]byte{1})
+	require.NoError(t, err)
+
+	select {
+	case msg := <-device.sent:
+		require.Equal(t, []byte{1}, msg)
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "the ClientInit was not relayed")
+	}
+
+	device.replies <- []byte("server-init")
+
+	serverInit := make([]byte, len("server-init"))
+	_, err = io.ReadFull(client, serverInit)
+	require.NoError(t, err)
+	require.Equal(t, "server-init", string(serverInit))
+
+	sessions := waitForSessions(t, uc, func(s []dto.RedirectionSession) bool {
+		return len(s) == 1 && s[0].BytesFromDevice > 0
+	})
+	require.Equal(t, "kvm", sessions[0].Mode)
+	require.Equal(t, "alice", sessions[0].Subject)
+	require.Equal(t, "127.0.0.1", sessions[0].ClientIP)
+
+	// the password worked once
+	_, result, reason := vncLogin(t, address, allocation.Password)
+	require.Equal(t, uint32(1), result)
+	require.Equal(t, "authentication failed", reason)
+
+	require.NoError(t, uc.TerminateRedirectionSession(context.Background(), sessions[0].ID, "tenant1"))
+
+	_, err = client.Read(make([]byte, 1))
+	require.Error(t, err)
+
+	select {
+	case <-device.closed:
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "the device connection was not closed")
+	}
+
+	waitForSessions(t, uc, func(s []dto.RedirectionSession) bool { return len(s) == 0 })
+}
+
+func TestVNCGatewayWrongPassword(t *testing.T) {
+	t.Parallel()
+
+	gateway, _, _ := initVNCTest(t, ":5900")
+	address := serveVNC(t, gateway)
+
+	_, err := gateway.AllocateVNC(vncContext(), "device-guid-123")
+	require.NoError(t, err)
+
+	_, result, reason := vncLogin(t, address, "guessed!")
+	require.Equal(t, uint32(1), result)
+	require.Equal(t, "authentication failed", reason)
+}
+
+func TestAllocateVNC(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		address string
+		guid    string
+		err     error
+	}{
+		{
+			name:    "gateway disabled",
+			address: "",
+			guid:    "device-guid-123",
+			err:     devices.ErrNotValid,
+		},
+		{
+			name:    "unknown device",
+			address: ":5900",
+			guid:    "unknown-guid",
+			err:     devices.ErrNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			gateway, _, _ := initVNCTest(t, tc.address)
+
+			_, err := gateway.AllocateVNC(vncContext(), tc.guid)
+			require.IsType(t, tc.err, err)
+		})
+	}
+}
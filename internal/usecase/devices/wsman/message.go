@@ -2,7 +2,6 @@ package wsman
 
 import (
 	gotls "crypto/tls"
-	"sync"
 	"time"
 
 	"github.com/open-amt-cloud-toolkit/go-wsman-messages/v2/pkg/security"
@@ -63,137 +62,74 @@ const (
 	maxReadRecords   = 390
 )
 
-var (
-	connections         = make(map[string]*ConnectionEntry)
-	connectionsMu       sync.Mutex
-	waitForAuthTickTime = 1 * time.Second
-	queueTickTime       = 500 * time.Millisecond
-	expireAfter         = 30 * time.Second                    // expire the stored connection after 30 seconds
-	waitForAuth         = 3 * time.Second                     // wait for 3 seconds for the connection to authenticate, prevents multiple api calls trying to auth at the same time
-	requestQueue        = make(chan func(), deviceCallBuffer) // Buffered channel to queue requests
-	shutdownSignal      = make(chan struct{})
-)
-
 type ConnectionEntry struct {
 	WsmanMessages wsman.Messages
-	Timer         *time.Timer
 }
 
 type GoWSMANMessages struct {
 	log              logger.Interface
 	safeRequirements security.Cryptor
+	pool             *ConnectionPool
 }
 
-func NewGoWSMANMessages(log logger.Interface, safeRequirements security.Cryptor) *GoWSMANMessages {
+func NewGoWSMANMessages(log logger.Interface, safeRequirements security.Cryptor, pool *ConnectionPool) *GoWSMANMessages {
 	return &GoWSMANMessages{
 		log:              log,
 		safeRequirements: safeRequirements,
+		pool:             pool,
 	}
 }
 
 func (g GoWSMANMessages) DestroyWsmanClient(device dto.Device) {
-	if entry, ok := connections[device.GUID]; ok {
-		entry.Timer.Stop()
-		removeConnection(device.GUID)
-	}
-}
-
-func (g GoWSMANMessages) Worker() {
-	for {
-		select {
-		case request := <-requestQueue:
-			request()
-			time.Sleep(queueTickTime)
-		case <-shutdownSignal:
-			return
-		}
-	}
+	g.pool.Remove(device.GUID)
 }
 
 func (g GoWSMANMessages) SetupWsmanClient(device entity.Device, isRedirection, logAMTMessages bool) Management {
-	resultChan := make(chan *ConnectionEntry)
-	// Queue the request
-	requestQueue <- func() {
-		device.Password, _ = g.safeRequirements.Decrypt(device.Password)
-		resultChan <- g.setupWsmanClientInternal(device, isRedirection, logAMTMessages)
-	}
+	var pending bool
 
-	return <-resultChan
-}
+	// buffered so a request the closed pool runs on this goroutine does not block
+	resultChan := make(chan *ConnectionEntry, 1)
 
-func (g GoWSMANMessages) setupWsmanClientInternal(device entity.Device, isRedirection, logAMTMessages bool) *ConnectionEntry {
-	clientParams := client.Parameters{
-		Target:            device.Hostname,
-		Username:          device.Username,
-		Password:          device.Password,
-		UseDigest:         true,
-		UseTLS:            device.UseTLS,
-		SelfSignedAllowed: device.AllowSelfSigned,
-		LogAMTMessages:    logAMTMessages,
-		IsRedirection:     isRedirection,
-	}
+	g.pool.Submit(func() {
+		device.Password, _ = g.safeRequirements.Decrypt(device.Password)
 
-	if device.CertHash != nil && *device.CertHash != "" {
-		clientParams.PinnedCert = *device.CertHash
-	}
+		var entry *ConnectionEntry
 
-	timer := time.AfterFunc(expireAfter, func() {
-		removeConnection(device.GUID)
+		entry, pending = g.setupWsmanClientInternal(device, isRedirection, logAMTMessages)
+		resultChan <- entry
 	})
 
-	if entry, ok := connections[device.GUID]; ok {
-		if entry.WsmanMessages.Client.IsAuthenticated() {
-			entry.Timer.Stop() // Stop the previous timer
-			entry.Timer = time.AfterFunc(expireAfter, func() {
-				removeConnection(device.GUID)
-			})
-
-			return connections[device.GUID]
-		}
+	entry := <-resultChan
 
-		ticker := time.NewTicker(waitForAuthTickTime)
-
-		defer ticker.Stop()
-
-		timeout := time.After(waitForAuth)
-
-		for {
-			select {
-			case <-ticker.C:
-				if entry.WsmanMessages.Client.IsAuthenticated() {
-					// Your logic when the function check is successful
-					return connections[device.GUID]
-				}
-			case <-timeout:
-				connectionsMu.Lock()
-				connections[device.GUID] = &ConnectionEntry{
-					WsmanMessages: wsman.NewMessages(clientParams),
-					Timer:         timer,
-				}
-				connectionsMu.Unlock()
-
-				return connections[device.GUID]
-			}
-		}
+	// the wait is on the caller rather than a worker, the request fails on its own if the device never answers
+	if pending {
+		g.pool.WaitForAuth(entry)
 	}
 
-	wsmanMsgs := wsman.NewMessages(clientParams)
+	return entry
+}
 
-	connectionsMu.Lock()
-	connections[device.GUID] = &ConnectionEntry{
-		WsmanMessages: wsmanMsgs,
-		Timer:         timer,
-	}
-	connections[device.GUID].WsmanMessages.Client.IsAuthenticated()
-	connectionsMu.Unlock()
+func (g GoWSMANMessages) setupWsmanClientInternal(device entity.Device, isRedirection, logAMTMessages bool) (*ConnectionEntry, bool) {
+	return g.pool.Get(device.GUID, func() *ConnectionEntry {
+		clientParams := client.Parameters{
+			Target:            device.Hostname,
+			Username:          device.Username,
+			Password:          device.Password,
+			UseDigest:         true,
+			UseTLS:            device.UseTLS,
+			SelfSignedAllowed: device.AllowSelfSigned,
+			LogAMTMessages:    logAMTMessages,
+			IsRedirection:     isRedirection,
+		}
 
-	return connections[device.GUID]
-}
+		if device.CertHash != nil && *device.CertHash != "" {
+			clientParams.PinnedCert = *device.CertHash
+		}
 
-func removeConnection(guid string) {
-	connectionsMu.Lock()
-	defer connectionsMu.Unlock()
-	delete(connections, guid)
+		return &ConnectionEntry{
+			WsmanMessages: wsman.NewMessages(clientParams),
+		}
+	})
 }
 
 func (g *ConnectionEntry) GetAMTVersion() ([]software.SoftwareIdentity, error) {
@@ -367,29 +303,56 @@ func (g *ConnectionEntry) hardwarePulls() (PullHWResults, error) {
 	return results, nil
 }
 
-func (g *ConnectionEntry) GetHardwareInfo() (interface{}, error) {
+func (g *ConnectionEntry) hardwareResults() (HWResults, error) {
 	getHWResults, err := g.hardwareGets()
 	if err != nil {
-		return nil, err
+		return HWResults{}, err
 	}
 
 	pullHWResults, err := g.hardwarePulls()
 	if err != nil {
-		return nil, err
+		return HWResults{}, err
 	}
 
-	hwResults := HWResults{
+	return HWResults{
 		ChassisResult:        getHWResults.ChassisResult,
 		ChipResult:           getHWResults.ChipResult,
 		CardResult:           getHWResults.CardResult,
 		PhysicalMemoryResult: pullHWResults.PhysicalMemoryResult,
 		BiosResult:           getHWResults.BiosResult,
 		ProcessorResult:      getHWResults.ProcessorResult,
+	}, nil
+}
+
+func (g *ConnectionEntry) GetHardwareInfo() (interface{}, error) {
+	hwResults, err := g.hardwareResults()
+	if err != nil {
+		return nil, err
 	}
 
 	return createMapInterfaceForHWInfo(hwResults)
 }
 
+// Inventory is the hardware of a device and its disks as AMT reports them.
+type Inventory struct {
+	Hardware HWResults
+	Disks    DiskResults
+}
+
+func (g *ConnectionEntry) GetInventory() (Inventory, error) {
+	hwResults, err := g.hardwareResults()
+	if err != nil {
+		return Inventory{}, err
+	}
+
+	diskResults, err := g.diskResults()
+	if err != nil {
+		return Inventory{}, err
+	}
+
+	return Inventory{Hardware: hwResults, Disks: diskResults}, nil
+}
+
 type GetHWResults struct {
 	ChassisResult   chassis.Response
 	ChipResult      chip.Response
@@ -445,7 +408,7 @@ type DiskResults struct {
 	PPPullResult          physical.Response
 }
 
-func (g *ConnectionEntry) GetDiskInfo() (interface{}, error) {
+func (g *ConnectionEntry) diskResults() (DiskResults, error) {
 	results := DiskResults{}
 
 	var err error
@@ -470,9 +433,13 @@ func (g *ConnectionEntry) GetDiskInfo() (interface{}, error) {
 		return results, err
 	}
 
-	diskResults := DiskResults{
-		MediaAccessPullResult: results.MediaAccessPullResult,
-		PPPullResult:          results.PPPullResult,
+	return results, nil
+}
+
+func (g *ConnectionEntry) GetDiskInfo() (interface{}, error) {
+	diskResults, err := g.diskResults()
+	if err != nil {
+		return diskResults, err
 	}
 
 	return createMapInterfaceForDiskInfo(diskResults)
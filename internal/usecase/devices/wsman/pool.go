@@ -0,0 +1,376 @@
+package wsman
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	defaultPoolWorkers     = 10
+	defaultIdleTimeout     = 30 * time.Second
+	defaultMaxConnections  = 500
+	defaultAuthWait        = 3 * time.Second
+	authPollInterval       = 100 * time.Millisecond
+	minimumJanitorInterval = time.Second
+)
+
+// PoolConfig -.
+type PoolConfig struct {
+	// Name labels the pool's metrics.
+	Name string
+	// Workers is how many connection setups run at the same time.
+	Workers int
+	// IdleTimeout evicts a connection that has not been handed out for this long.
+	IdleTimeout time.Duration
+	// MaxConnections evicts the least recently used connection once the pool grows past it.
+	MaxConnections int
+	// AuthWait is how long a connection may take to authenticate before the next caller replaces it, and how
+	// long a caller waits on a connection another caller is still authenticating. This keeps parallel calls
+	// from all authenticating at once.
+	AuthWait time.Duration
+}
+
+// PoolStats -.
+type PoolStats struct {
+	Connections int    `json:"connections"`
+	Hits        uint64 `json:"hits"`
+	Misses      uint64 `json:"misses"`
+	AuthWaits   uint64 `json:"authWaits"`
+	Evictions   uint64 `json:"evictions"`
+}
+
+type pooledConnection struct {
+	mu       sync.Mutex // serializes setup of one device without blocking the others
+	entry    *ConnectionEntry
+	created  time.Time
+	lastUsed time.Time // guarded by ConnectionPool.mu
+}
+
+// ConnectionPool caches authenticated WSMAN connections per device and runs connection setup on a
+// fixed number of workers. Workers start on first use and stop when the pool is closed.
+type ConnectionPool struct {
+	cfg PoolConfig
+
+	mu          sync.Mutex
+	connections map[string]*pooledConnection
+
+	stateMu  sync.RWMutex
+	started  bool
+	closed   bool
+	requests chan func()
+	closing  chan struct{}
+	wg       sync.WaitGroup
+
+	hits      atomic.Uint64
+	misses    atomic.Uint64
+	authWaits atomic.Uint64
+	evictions atomic.Uint64
+
+	connectionsDesc *prometheus.Desc
+	hitsDesc        *prometheus.Desc
+	missesDesc      *prometheus.Desc
+	authWaitsDesc   *prometheus.Desc
+	evictionsDesc   *prometheus.Desc
+}
+
+// NewConnectionPool -.
+func NewConnectionPool(cfg PoolConfig) *ConnectionPool {
+	if cfg.Name == "" {
+		cfg.Name = "devices"
+	}
+
+	if cfg.Workers <= 0 {
+		cfg.Workers = defaultPoolWorkers
+	}
+
+	if cfg.IdleTimeout <= 0 {
+		cfg.IdleTimeout = defaultIdleTimeout
+	}
+
+	if cfg.MaxConnections <= 0 {
+		cfg.MaxConnections = defaultMaxConnections
+	}
+
+	if cfg.AuthWait <= 0 {
+		cfg.AuthWait = defaultAuthWait
+	}
+
+	labels := prometheus.Labels{"pool": cfg.Name}
+
+	return &ConnectionPool{
+		cfg:             cfg,
+		connections:     make(map[string]*pooledConnection),
+		connectionsDesc: prometheus.NewDesc("console_wsman_pool_connections", "Number of cached WSMAN connections.", nil, labels),
+		hitsDesc:        prometheus.NewDesc("console_wsman_pool_hits_total", "Requests served by a cached WSMAN connection.", nil, labels),
+		missesDesc:      prometheus.NewDesc("console_wsman_pool_misses_total", "Requests that had to create a WSMAN connection.", nil, labels),
+		authWaitsDesc:   prometheus.NewDesc("console_wsman_pool_auth_waits_total", "Requests that waited on a connection still authenticating.", nil, labels),
+		evictionsDesc:   prometheus.NewDesc("console_wsman_pool_evictions_total", "Connections evicted for being idle or over capacity.", nil, labels),
+	}
+}
+
+// Start launches the workers and the idle janitor, it is safe to call more than once.
+func (p *ConnectionPool) Start() {
+	p.stateMu.Lock()
+	defer p.stateMu.Unlock()
+
+	if p.started || p.closed {
+		return
+	}
+
+	p.started = true
+	p.requests = make(chan func(), deviceCallBuffer)
+	p.closing = make(chan struct{})
+
+	p.wg.Add(p.cfg.Workers + 1)
+
+	for i := 0; i < p.cfg.Workers; i++ {
+		go p.worker()
+	}
+
+	go p.janitor()
+}
+
+// Run starts the pool and closes it once ctx is done.
+func (p *ConnectionPool) Run(ctx context.Context) {
+	p.Start()
+
+	<-ctx.Done()
+
+	p.Close()
+}
+
+// Close stops accepting work, lets the workers finish what is already queued and drops every connection.
+func (p *ConnectionPool) Close() {
+	p.stateMu.Lock()
+
+	if p.closed {
+		p.stateMu.Unlock()
+
+		return
+	}
+
+	p.closed = true
+
+	if p.started {
+		close(p.closing)
+	}
+
+	p.stateMu.Unlock()
+
+	p.wg.Wait()
+
+	p.mu.Lock()
+	p.connections = make(map[string]*pooledConnection)
+	p.mu.Unlock()
+}
+
+// Submit runs fn on one of the pool's workers. Once the pool is closed fn runs on the caller instead.
+func (p *ConnectionPool) Submit(fn func()) {
+	p.Start()
+
+	p.stateMu.RLock()
+	defer p.stateMu.RUnlock()
+
+	if p.closed {
+		fn()
+
+		return
+	}
+
+	p.requests <- fn
+}
+
+// Get returns the cached connection for guid, calling connect to create one when there is none or the
+// cached one did not authenticate within AuthWait. It never waits for a device, so one that does not answer
+// holds up neither a worker nor the callers of other devices. A pending connection is one that another
+// caller is still authenticating, its caller should WaitForAuth before using it.
+func (p *ConnectionPool) Get(guid string, connect func() *ConnectionEntry) (entry *ConnectionEntry, pending bool) {
+	p.mu.Lock()
+
+	conn, ok := p.connections[guid]
+	if !ok {
+		conn = &pooledConnection{}
+		p.connections[guid] = conn
+		p.evictOverflow(guid)
+	}
+
+	conn.lastUsed = time.Now()
+
+	p.mu.Unlock()
+
+	conn.mu.Lock()
+	defer conn.mu.Unlock()
+
+	if conn.entry != nil {
+		if isAuthenticated(conn.entry) {
+			p.hits.Add(1)
+
+			return conn.entry, false
+		}
+
+		if time.Since(conn.created) < p.cfg.AuthWait {
+			p.hits.Add(1)
+
+			return conn.entry, true
+		}
+	}
+
+	p.misses.Add(1)
+
+	conn.entry = connect()
+	conn.created = time.Now()
+
+	return conn.entry, false
+}
+
+// WaitForAuth waits up to AuthWait for entry to authenticate and reports whether it did.
+func (p *ConnectionPool) WaitForAuth(entry *ConnectionEntry) bool {
+	if isAuthenticated(entry) {
+		return true
+	}
+
+	p.authWaits.Add(1)
+
+	ticker := time.NewTicker(authPollInterval)
+	defer ticker.Stop()
+
+	timeout := time.NewTimer(p.cfg.AuthWait)
+	defer timeout.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if isAuthenticated(entry) {
+				return true
+			}
+		case <-timeout.C:
+			return false
+		}
+	}
+}
+
+// Remove drops the connection for guid.
+func (p *ConnectionPool) Remove(guid string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	delete(p.connections, guid)
+}
+
+// Stats -.
+func (p *ConnectionPool) Stats() PoolStats {
+	p.mu.Lock()
+	size := len(p.connections)
+	p.mu.Unlock()
+
+	return PoolStats{
+		Connections: size,
+		Hits:        p.hits.Load(),
+		Misses:      p.misses.Load(),
+		AuthWaits:   p.authWaits.Load(),
+		Evictions:   p.evictions.Load(),
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (p *ConnectionPool) Describe(ch chan<- *prometheus.Desc) {
+	ch <- p.connectionsDesc
+	ch <- p.hitsDesc
+	ch <- p.missesDesc
+	ch <- p.authWaitsDesc
+	ch <- p.evictionsDesc
+}
+
+// Collect implements prometheus.Collector.
+func (p *ConnectionPool) Collect(ch chan<- prometheus.Metric) {
+	stats := p.Stats()
+
+	ch <- prometheus.MustNewConstMetric(p.connectionsDesc, prometheus.GaugeValue, float64(stats.Connections))
+	ch <- prometheus.MustNewConstMetric(p.hitsDesc, prometheus.CounterValue, float64(stats.Hits))
+	ch <- prometheus.MustNewConstMetric(p.missesDesc, prometheus.CounterValue, float64(stats.Misses))
+	ch <- prometheus.MustNewConstMetric(p.authWaitsDesc, prometheus.CounterValue, float64(stats.AuthWaits))
+	ch <- prometheus.MustNewConstMetric(p.evictionsDesc, prometheus.CounterValue, float64(stats.Evictions))
+}
+
+func (p *ConnectionPool) worker() {
+	defer p.wg.Done()
+
+	for {
+		select {
+		case fn := <-p.requests:
+			fn()
+		case <-p.closing:
+			// nothing can be queued once closing, drain what is left and stop
+			for {
+				select {
+				case fn := <-p.requests:
+					fn()
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+func (p *ConnectionPool) janitor() {
+	defer p.wg.Done()
+
+	interval := p.cfg.IdleTimeout / 2
+	if interval < minimumJanitorInterval {
+		interval = minimumJanitorInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			p.evictIdle(time.Now())
+		case <-p.closing:
+			return
+		}
+	}
+}
+
+func (p *ConnectionPool) evictIdle(now time.Time) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for guid, conn := range p.connections {
+		if now.Sub(conn.lastUsed) > p.cfg.IdleTimeout {
+			delete(p.connections, guid)
+			p.evictions.Add(1)
+		}
+	}
+}
+
+// evictOverflow drops least recently used connections other than keep until the pool is back
+// within MaxConnections, callers must hold mu.
+func (p *ConnectionPool) evictOverflow(keep string) {
+	for len(p.connections) > p.cfg.MaxConnections {
+		oldest := ""
+
+		for guid, conn := range p.connections {
+			if guid != keep && (oldest == "" || conn.lastUsed.Before(p.connections[oldest].lastUsed)) {
+				oldest = guid
+			}
+		}
+
+		if oldest == "" {
+			return
+		}
+
+		delete(p.connections, oldest)
+		p.evictions.Add(1)
+	}
+}
+
+func isAuthenticated(entry *ConnectionEntry) bool {
+	return entry.WsmanMessages.Client != nil && entry.WsmanMessages.Client.IsAuthenticated()
+}
@@ -0,0 +1,236 @@
+package wsman
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/open-amt-cloud-toolkit/go-wsman-messages/v2/pkg/wsman"
+	"github.com/open-amt-cloud-toolkit/go-wsman-messages/v2/pkg/wsman/client"
+	"github.com/prometheus/client_golang/prometheus"
+	promclient "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeClient struct {
+	client.WSMan
+	authenticated atomic.Bool
+}
+
+func (f *fakeClient) IsAuthenticated() bool {
+	return f.authenticated.Load()
+}
+
+func newEntry(authenticated bool) *ConnectionEntry {
+	c := &fakeClient{}
+	c.authenticated.Store(authenticated)
+
+	return &ConnectionEntry{WsmanMessages: wsman.Messages{Client: c}}
+}
+
+func TestConnectionPoolGet(t *testing.T) {
+	t.Parallel()
+
+	pool := NewConnectionPool(PoolConfig{AuthWait: 50 * time.Millisecond})
+
+	first, _ := pool.Get("guid-1", func() *ConnectionEntry { return newEntry(true) })
+	again, pending := pool.Get("guid-1", func() *ConnectionEntry { return newEntry(true) })
+	require.Same(t, first, again)
+	require.False(t, pending)
+
+	// a connection still authenticating is handed out at once for its caller to wait on
+	stale, _ := pool.Get("guid-2", func() *ConnectionEntry { return newEntry(false) })
+	shared, pending := pool.Get("guid-2", func() *ConnectionEntry { return newEntry(true) })
+	require.Same(t, stale, shared)
+	require.True(t, pending)
+
+	// and replaced once AuthWait runs out
+	time.Sleep(60 * time.Millisecond)
+
+	replaced, pending := pool.Get("guid-2", func() *ConnectionEntry { return newEntry(true) })
+	require.NotSame(t, stale, replaced)
+	require.False(t, pending)
+
+	require.Equal(t, PoolStats{Connections: 2, Hits: 2, Misses: 3}, pool.Stats())
+
+	pool.Remove("guid-1")
+	require.Equal(t, 1, pool.Stats().Connections)
+}
+
+func TestConnectionPoolWaitsForAuth(t *testing.T) {
+	t.Parallel()
+
+	pool := NewConnectionPool(PoolConfig{AuthWait: time.Second})
+
+	entry := newEntry(false)
+	pool.Get("guid-1", func() *ConnectionEntry { return entry })
+
+	go func() {
+		time.Sleep(150 * time.Millisecond)
+		entry.WsmanMessages.Client.(*fakeClient).authenticated.Store(true)
+	}()
+
+	got, pending := pool.Get("guid-1", func() *ConnectionEntry {
+		t.Error("connection should have been reused")
+
+		return newEntry(true)
+	})
+	require.Same(t, entry, got)
+	require.True(t, pending)
+	require.True(t, pool.WaitForAuth(got))
+	require.Equal(t, uint64(1), pool.Stats().AuthWaits)
+}
+
+func TestConnectionPoolDoesNotBlockOnAuth(t *testing.T) {
+	t.Parallel()
+
+	pool := NewConnectionPool(PoolConfig{Workers: 1, AuthWait: time.Minute})
+
+	pool.Get("unreachable", func() *ConnectionEntry { return newEntry(false) })
+
+	// callers of a device that never authenticates do not hold up the single worker for the others
+	var wg sync.WaitGroup
+
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+
+		pool.Submit(func() {
+			defer wg.Done()
+
+			if _, pending := pool.Get("unreachable", func() *ConnectionEntry { return newEntry(false) }); !pending {
+				t.Error("connection should have been handed out while authenticating")
+			}
+		})
+	}
+
+	done := make(chan struct{})
+
+	pool.Submit(func() {
+		pool.Get("reachable", func() *ConnectionEntry { return newEntry(true) })
+		close(done)
+	})
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("a device still authenticating held up the pool")
+	}
+
+	wg.Wait()
+	pool.Close()
+}
+
+func TestConnectionPoolEviction(t *testing.T) {
+	t.Parallel()
+
+	pool := NewConnectionPool(PoolConfig{MaxConnections: 2, IdleTimeout: time.Minute})
+
+	for _, guid := range []string{"guid-1", "guid-2", "guid-3"} {
+		pool.Get(guid, func() *ConnectionEntry { return newEntry(true) })
+	}
+
+	// guid-1 was the least recently used when guid-3 arrived
+	require.Equal(t, 2, pool.Stats().Connections)
+
+	pool.mu.Lock()
+	_, ok := pool.connections["guid-1"]
+	pool.mu.Unlock()
+	require.False(t, ok)
+
+	pool.evictIdle(time.Now().Add(2 * time.Minute))
+
+	require.Equal(t, PoolStats{Connections: 0, Misses: 3, Evictions: 3}, pool.Stats())
+}
+
+func TestConnectionPoolSubmitAndClose(t *testing.T) {
+	t.Parallel()
+
+	pool := NewConnectionPool(PoolConfig{Workers: 4})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	go func() {
+		pool.Run(ctx)
+		close(done)
+	}()
+
+	var (
+		wg      sync.WaitGroup
+		running atomic.Int32
+		peak    atomic.Int32
+	)
+
+	release := make(chan struct{})
+
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+
+		pool.Submit(func() {
+			defer wg.Done()
+
+			n := running.Add(1)
+			for {
+				p := peak.Load()
+				if n <= p || peak.CompareAndSwap(p, n) {
+					break
+				}
+			}
+
+			<-release
+			running.Add(-1)
+		})
+	}
+
+	// all four requests run at once rather than one after the other
+	require.Eventually(t, func() bool { return peak.Load() == 4 }, time.Second, 10*time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	pool.Get("guid-1", func() *ConnectionEntry { return newEntry(true) })
+
+	cancel()
+	<-done
+
+	require.Equal(t, 0, pool.Stats().Connections)
+
+	// work submitted after close still runs, on the caller
+	ran := false
+
+	pool.Submit(func() { ran = true })
+	require.True(t, ran)
+}
+
+func TestConnectionPoolCollect(t *testing.T) {
+	t.Parallel()
+
+	pool := NewConnectionPool(PoolConfig{Name: "test"})
+	pool.Get("guid-1", func() *ConnectionEntry { return newEntry(true) })
+	pool.Get("guid-1", func() *ConnectionEntry { return newEntry(true) })
+
+	ch := make(chan prometheus.Metric, 5)
+	pool.Collect(ch)
+	close(ch)
+
+	values := make(map[string]float64)
+
+	for metric := range ch {
+		m := &promclient.Metric{}
+		require.NoError(t, metric.Write(m))
+		require.Equal(t, "test", m.GetLabel()[0].GetValue())
+
+		switch {
+		case m.Counter != nil:
+			values[metric.Desc().String()] = m.GetCounter().GetValue()
+		case m.Gauge != nil:
+			values[metric.Desc().String()] = m.GetGauge().GetValue()
+		}
+	}
+
+	require.Len(t, values, 5)
+	require.InDelta(t, 1, values[pool.hitsDesc.String()], 0)
+	require.InDelta(t, 1, values[pool.missesDesc.String()], 0)
+	require.InDelta(t, 1, values[pool.connectionsDesc.String()], 0)
+}
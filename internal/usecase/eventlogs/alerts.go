@@ -0,0 +1,213 @@
+package eventlogs
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity"
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	"github.com/open-amt-cloud-toolkit/console/pkg/subject"
+)
+
+var (
+	errNotOpen       = errors.New("only an open alert can be acknowledged")
+	errClosed        = errors.New("alert is already closed")
+	errStatusChanged = errors.New("alert changed status in the meantime")
+)
+
+// raise matches the new events of a device against the enabled rules of its tenant, stores an alert for every
+// match and hands it to the notifiers of the rule. A notifier that fails is logged, the alert is kept.
+func (uc *UseCase) raise(ctx context.Context, events []entity.DeviceEvent, tenantID string) (int, error) {
+	rules, err := uc.repo.GetEnabledRules(ctx, tenantID)
+	if err != nil {
+		return 0, ErrDatabase.Wrap("Collect", "uc.repo.GetEnabledRules", err)
+	}
+
+	raised := 0
+
+	for i := range events {
+		e := &events[i]
+
+		for j := range rules {
+			rule := &rules[j]
+			if !matches(rule, e) {
+				continue
+			}
+
+			a := &entity.Alert{
+				ID:          uuid.New().String(),
+				RuleName:    rule.Name,
+				GUID:        e.GUID,
+				Fingerprint: e.Fingerprint,
+				Severity:    e.Severity,
+				Entity:      e.Entity,
+				Description: e.Description,
+				EventTime:   e.Time,
+				RaisedAt:    formatTime(uc.now()),
+				Status:      dto.AlertStatusOpen,
+				TenantID:    tenantID,
+			}
+
+			inserted, err := uc.repo.InsertAlert(ctx, a)
+			if err != nil {
+				return raised, ErrDatabase.Wrap("Collect", "uc.repo.InsertAlert", err)
+			}
+
+			if !inserted {
+				continue
+			}
+
+			raised++
+
+			uc.notify(ctx, rule, alertToDTO(a))
+		}
+	}
+
+	return raised, nil
+}
+
+func (uc *UseCase) notify(ctx context.Context, rule *entity.AlertRule, a *dto.Alert) {
+	for _, name := range ruleToDTO(rule).Notifiers {
+		n, ok := uc.notifiers[name]
+		if !ok {
+			uc.log.Warn("eventlogs - notify - rule %s names notifier %s which is not configured", rule.Name, name)
+
+			continue
+		}
+
+		if err := n.Notify(ctx, *a); err != nil {
+			uc.log.Warn("eventlogs - notify - %s could not deliver alert %s: %s", name, a.ID, err.Error())
+		}
+	}
+}
+
+func (uc *UseCase) GetAlertCount(ctx context.Context, filter dto.AlertFilter) (int, error) {
+	count, err := uc.repo.GetAlertCount(ctx, alertFilterToEntity(filter))
+	if err != nil {
+		return 0, ErrDatabase.Wrap("GetAlertCount", "uc.repo.GetAlertCount", err)
+	}
+
+	return count, nil
+}
+
+// GetAlerts returns the alerts matching filter, the last raised first.
+func (uc *UseCase) GetAlerts(ctx context.Context, filter dto.AlertFilter, top, skip int) ([]dto.Alert, error) {
+	data, err := uc.repo.GetAlerts(ctx, alertFilterToEntity(filter), top, skip)
+	if err != nil {
+		return nil, ErrDatabase.Wrap("GetAlerts", "uc.repo.GetAlerts", err)
+	}
+
+	d1 := make([]dto.Alert, len(data))
+
+	for i := range data {
+		d1[i] = *alertToDTO(&data[i])
+	}
+
+	return d1, nil
+}
+
+func (uc *UseCase) GetAlertByID(ctx context.Context, id, tenantID string) (*dto.Alert, error) {
+	data, err := uc.repo.GetAlertByID(ctx, id, tenantID)
+	if err != nil {
+		return nil, ErrDatabase.Wrap("GetAlertByID", "uc.repo.GetAlertByID", err)
+	}
+
+	if data == nil {
+		return nil, ErrNotFound
+	}
+
+	return alertToDTO(data), nil
+}
+
+// Acknowledge records that the caller of ctx is handling an open alert.
+func (uc *UseCase) Acknowledge(ctx context.Context, id, tenantID string) (*dto.Alert, error) {
+	return uc.transition(ctx, "Acknowledge", id, tenantID, dto.AlertStatusAcknowledged)
+}
+
+// Close closes an open or acknowledged alert on behalf of the caller of ctx.
+func (uc *UseCase) Close(ctx context.Context, id, tenantID string) (*dto.Alert, error) {
+	return uc.transition(ctx, "Close", id, tenantID, dto.AlertStatusClosed)
+}
+
+func (uc *UseCase) transition(ctx context.Context, call, id, tenantID, to string) (*dto.Alert, error) {
+	a, err := uc.repo.GetAlertByID(ctx, id, tenantID)
+	if err != nil {
+		return nil, ErrDatabase.Wrap(call, "uc.repo.GetAlertByID", err)
+	}
+
+	if a == nil {
+		return nil, ErrNotFound
+	}
+
+	from := a.Status
+	now := formatTime(uc.now())
+
+	switch to {
+	case dto.AlertStatusAcknowledged:
+		if from != dto.AlertStatusOpen {
+			return nil, ErrNotValid.Wrap(call, "uc.transition", errNotOpen)
+		}
+
+		a.AcknowledgedBy = subject.FromContext(ctx)
+		a.AcknowledgedAt = now
+	case dto.AlertStatusClosed:
+		if from == dto.AlertStatusClosed {
+			return nil, ErrNotValid.Wrap(call, "uc.transition", errClosed)
+		}
+
+		a.ClosedBy = subject.FromContext(ctx)
+		a.ClosedAt = now
+	}
+
+	a.Status = to
+
+	updated, err := uc.repo.UpdateAlertStatus(ctx, a, from)
+	if err != nil {
+		return nil, ErrDatabase.Wrap(call, "uc.repo.UpdateAlertStatus", err)
+	}
+
+	if !updated {
+		return nil, ErrNotValid.Wrap(call, "uc.repo.UpdateAlertStatus", errStatusChanged)
+	}
+
+	return alertToDTO(a), nil
+}
+
+// convert entity.Alert to dto.Alert.
+func alertToDTO(d *entity.Alert) *dto.Alert {
+	d1 := &dto.Alert{
+		ID:             d.ID,
+		RuleName:       d.RuleName,
+		GUID:           d.GUID,
+		Severity:       d.Severity,
+		Entity:         d.Entity,
+		Description:    d.Description,
+		Status:         d.Status,
+		AcknowledgedBy: d.AcknowledgedBy,
+		AcknowledgedAt: parseTime(d.AcknowledgedAt),
+		ClosedBy:       d.ClosedBy,
+		ClosedAt:       parseTime(d.ClosedAt),
+		TenantID:       d.TenantID,
+	}
+
+	if eventTime := parseTime(d.EventTime); eventTime != nil {
+		d1.EventTime = *eventTime
+	}
+
+	if raised := parseTime(d.RaisedAt); raised != nil {
+		d1.RaisedAt = *raised
+	}
+
+	return d1
+}
+
+func alertFilterToEntity(f dto.AlertFilter) entity.AlertFilter {
+	return entity.AlertFilter{
+		Status:   f.Status,
+		GUID:     f.GUID,
+		RuleName: f.RuleName,
+		TenantID: f.TenantID,
+	}
+}
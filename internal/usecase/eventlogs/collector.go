@@ -0,0 +1,244 @@
+package eventlogs
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/open-amt-cloud-toolkit/go-wsman-messages/v2/pkg/wsman/amt/messagelog"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity"
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	"github.com/open-amt-cloud-toolkit/console/pkg/tenant"
+)
+
+const (
+	fleetPageSize = 100
+	// eventTimeLayout is how the device management feature formats the time of an event.
+	eventTimeLayout = "2006-01-02 15:04:05.999999999 -0700 MST"
+)
+
+// Run collects the event logs of the fleet every interval until ctx is done.
+func (uc *UseCase) Run(ctx context.Context) {
+	if uc.cfg.Interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(uc.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		uc.CollectAll(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// CollectAll collects the new events of every connected device once and waits for the collections to finish.
+func (uc *UseCase) CollectAll(ctx context.Context) {
+	sem := make(chan struct{}, uc.cfg.Workers)
+
+	var wg sync.WaitGroup
+
+	defer wg.Wait()
+
+	for skip := 0; ; skip += fleetPageSize {
+		page, err := uc.fleet.GetAllTenants(ctx, fleetPageSize, skip)
+		if err != nil {
+			uc.log.Error(err, "eventlogs - CollectAll - uc.fleet.GetAllTenants")
+
+			return
+		}
+
+		for i := range page {
+			if !page[i].ConnectionStatus {
+				continue
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case sem <- struct{}{}:
+			}
+
+			wg.Add(1)
+
+			go func(d entity.Device) {
+				defer func() {
+					<-sem
+					wg.Done()
+				}()
+
+				if _, err := uc.Collect(ctx, d.GUID, d.TenantID); err != nil {
+					uc.log.Debug("eventlogs - CollectAll - " + d.GUID + ": " + err.Error())
+				}
+			}(page[i])
+		}
+
+		if len(page) < fleetPageSize {
+			return
+		}
+	}
+}
+
+// Collect reads the whole event log of a device, stores the events not stored by an earlier collection and
+// raises the alerts of the rules the new events match. The log holds a few hundred events at most and gives them
+// no identifier, so every collection reads all of it and an event is recognized by its fingerprint.
+func (uc *UseCase) Collect(ctx context.Context, guid, tenantID string) (*dto.EventLogCollection, error) {
+	// the device management calls find the device in the tenant of ctx
+	ctx = tenant.NewContext(ctx, tenantID)
+
+	records, err := uc.read(ctx, guid)
+	if err != nil {
+		return nil, err
+	}
+
+	now := uc.now()
+
+	events := make([]entity.DeviceEvent, len(records))
+	for i := range records {
+		events[i] = *eventToEntity(&records[i], guid, tenantID, now)
+	}
+
+	inserted, err := uc.repo.InsertEvents(ctx, events)
+	if err != nil {
+		return nil, ErrDatabase.Wrap("Collect", "uc.repo.InsertEvents", err)
+	}
+
+	collection := &dto.EventLogCollection{
+		GUID:        guid,
+		CollectedAt: now.UTC().Truncate(time.Second),
+		Read:        len(records),
+		Collected:   len(inserted),
+	}
+
+	if len(inserted) == 0 {
+		return collection, nil
+	}
+
+	fresh := make(map[string]bool, len(inserted))
+	for _, f := range inserted {
+		fresh[f] = true
+	}
+
+	newEvents := make([]entity.DeviceEvent, 0, len(inserted))
+
+	for i := range events {
+		if fresh[events[i].Fingerprint] {
+			newEvents = append(newEvents, events[i])
+			// the same event logged twice in one second is stored, and alerted on, once
+			delete(fresh, events[i].Fingerprint)
+		}
+	}
+
+	collection.Alerts, err = uc.raise(ctx, newEvents, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	return collection, nil
+}
+
+// read returns every record of the event log of a device.
+func (uc *UseCase) read(ctx context.Context, guid string) ([]dto.EventLog, error) {
+	var records []dto.EventLog
+
+	// AMT numbers the records of its log from 1
+	for index := 1; ; {
+		page, err := uc.devices.GetEventLog(ctx, index, messagelog.MaxAMTRecords, guid)
+		if err != nil {
+			return nil, err
+		}
+
+		records = append(records, page.Records...)
+
+		if !page.HasMoreRecords || len(page.Records) == 0 {
+			return records, nil
+		}
+
+		index += len(page.Records)
+	}
+}
+
+// fingerprint identifies an event of a device's log, AMT gives its events no identifier of their own.
+func fingerprint(r *dto.EventLog) string {
+	data := make([]string, len(r.EventData))
+	for i, b := range r.EventData {
+		data[i] = strconv.Itoa(b)
+	}
+
+	sum := sha256.Sum256([]byte(strings.Join([]string{
+		r.Time,
+		strconv.Itoa(r.DeviceAddress),
+		strconv.Itoa(r.EventSensorType),
+		strconv.Itoa(r.EventType),
+		strconv.Itoa(r.EventOffset),
+		strconv.Itoa(r.EventSourceType),
+		r.EventSeverity,
+		strconv.Itoa(r.SensorNumber),
+		r.Entity,
+		strconv.Itoa(r.EntityInstance),
+		strings.Join(data, ","),
+	}, "\x00")))
+
+	return hex.EncodeToString(sum[:])
+}
+
+func eventToEntity(r *dto.EventLog, guid, tenantID string, now time.Time) *entity.DeviceEvent {
+	data := make([]byte, len(r.EventData))
+	for i, b := range r.EventData {
+		data[i] = byte(b)
+	}
+
+	e := &entity.DeviceEvent{
+		GUID:            guid,
+		Fingerprint:     fingerprint(r),
+		Time:            r.Time,
+		Severity:        severityOf(r.EventSeverity),
+		Entity:          r.Entity,
+		EntityInstance:  r.EntityInstance,
+		SensorType:      r.EventSensorType,
+		SensorNumber:    r.SensorNumber,
+		EventType:       r.EventType,
+		EventOffset:     r.EventOffset,
+		EventSourceType: r.EventSourceType,
+		DeviceAddress:   r.DeviceAddress,
+		EventData:       hex.EncodeToString(data),
+		Description:     r.Description,
+		CollectedAt:     formatTime(now),
+		TenantID:        tenantID,
+	}
+
+	if t, err := time.Parse(eventTimeLayout, r.Time); err == nil {
+		e.Time = formatTime(t)
+	}
+
+	return e
+}
+
+// amtSeverities maps the severities AMT describes its events with to the ones the console searches and alerts on.
+var amtSeverities = map[string]string{
+	messagelog.EventSeverity[0]:  dto.EventSeverityUnspecified,
+	messagelog.EventSeverity[1]:  dto.EventSeverityMonitor,
+	messagelog.EventSeverity[2]:  dto.EventSeverityInformation,
+	messagelog.EventSeverity[4]:  dto.EventSeverityOK,
+	messagelog.EventSeverity[8]:  dto.EventSeverityNonCritical,
+	messagelog.EventSeverity[16]: dto.EventSeverityCritical,
+	messagelog.EventSeverity[32]: dto.EventSeverityNonRecoverable,
+}
+
+func severityOf(amtSeverity string) string {
+	if severity, ok := amtSeverities[amtSeverity]; ok {
+		return severity
+	}
+
+	return dto.EventSeverityUnspecified
+}
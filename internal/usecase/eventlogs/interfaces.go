@@ -0,0 +1,62 @@
+package eventlogs
+
+import (
+	"context"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity"
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+)
+
+type (
+	Repository interface {
+		GetEventCount(ctx context.Context, filter entity.DeviceEventFilter) (int, error)
+		GetEvents(ctx context.Context, filter entity.DeviceEventFilter, top, skip int) ([]entity.DeviceEvent, error)
+		InsertEvents(ctx context.Context, events []entity.DeviceEvent) ([]string, error)
+		GetRuleCount(ctx context.Context, tenantID string) (int, error)
+		GetRules(ctx context.Context, top, skip int, tenantID string) ([]entity.AlertRule, error)
+		GetEnabledRules(ctx context.Context, tenantID string) ([]entity.AlertRule, error)
+		GetRuleByName(ctx context.Context, name, tenantID string) (*entity.AlertRule, error)
+		DeleteRule(ctx context.Context, name, tenantID string) (bool, error)
+		UpdateRule(ctx context.Context, rule *entity.AlertRule) (bool, error)
+		InsertRule(ctx context.Context, rule *entity.AlertRule) (string, error)
+		InsertAlert(ctx context.Context, a *entity.Alert) (bool, error)
+		GetAlertCount(ctx context.Context, filter entity.AlertFilter) (int, error)
+		GetAlerts(ctx context.Context, filter entity.AlertFilter, top, skip int) ([]entity.Alert, error)
+		GetAlertByID(ctx context.Context, id, tenantID string) (*entity.Alert, error)
+		UpdateAlertStatus(ctx context.Context, a *entity.Alert, from string) (bool, error)
+	}
+	Feature interface {
+		Collect(ctx context.Context, guid, tenantID string) (*dto.EventLogCollection, error)
+		GetEventCount(ctx context.Context, filter dto.DeviceEventFilter) (int, error)
+		GetEvents(ctx context.Context, filter dto.DeviceEventFilter, top, skip int) ([]dto.DeviceEvent, error)
+		GetRuleCount(ctx context.Context, tenantID string) (int, error)
+		GetRules(ctx context.Context, top, skip int, tenantID string) ([]dto.AlertRule, error)
+		GetRuleByName(ctx context.Context, name, tenantID string) (*dto.AlertRule, error)
+		DeleteRule(ctx context.Context, name, tenantID string) error
+		UpdateRule(ctx context.Context, rule *dto.AlertRule) (*dto.AlertRule, error)
+		InsertRule(ctx context.Context, rule *dto.AlertRule) (*dto.AlertRule, error)
+		GetAlertCount(ctx context.Context, filter dto.AlertFilter) (int, error)
+		GetAlerts(ctx context.Context, filter dto.AlertFilter, top, skip int) ([]dto.Alert, error)
+		GetAlertByID(ctx context.Context, id, tenantID string) (*dto.Alert, error)
+		Acknowledge(ctx context.Context, id, tenantID string) (*dto.Alert, error)
+		Close(ctx context.Context, id, tenantID string) (*dto.Alert, error)
+		Run(ctx context.Context)
+	}
+	// Devices is the part of the device management feature the event log is read with.
+	Devices interface {
+		GetEventLog(ctx context.Context, startIndex, maxReadRecords int, guid string) (dto.EventLogs, error)
+	}
+	// Fleet pages through the devices of every tenant for the periodic collection.
+	Fleet interface {
+		GetAllTenants(ctx context.Context, top, skip int) ([]entity.Device, error)
+	}
+	// Notifier delivers the alerts raised by the rules that name it.
+	Notifier interface {
+		Name() string
+		Notify(ctx context.Context, alert dto.Alert) error
+	}
+	// Publisher puts alerts on the events stream the webhooks deliver from.
+	Publisher interface {
+		Publish(ctx context.Context, e dto.Event)
+	}
+)
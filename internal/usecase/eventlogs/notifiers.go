@@ -0,0 +1,169 @@
+package eventlogs
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	"github.com/open-amt-cloud-toolkit/console/pkg/logger"
+)
+
+const (
+	NotifierLog     = "log"
+	NotifierWebhook = "webhook"
+	NotifierSMTP    = "smtp"
+
+	smtpTimeout = 30 * time.Second
+)
+
+// LogNotifier writes alerts to the console's log.
+type LogNotifier struct {
+	log logger.Interface
+}
+
+func NewLogNotifier(log logger.Interface) *LogNotifier {
+	return &LogNotifier{log: log}
+}
+
+func (n *LogNotifier) Name() string { return NotifierLog }
+
+func (n *LogNotifier) Notify(_ context.Context, a dto.Alert) error {
+	n.log.Warn("alert %s raised by rule %s: %s event from %s on %s at %s: %s",
+		a.ID, a.RuleName, a.Severity, a.Entity, a.GUID, a.EventTime.Format(time.RFC3339), a.Description)
+
+	return nil
+}
+
+// WebhookNotifier publishes alerts as alert.raised events, the webhooks subscribed to them deliver them.
+type WebhookNotifier struct {
+	publisher Publisher
+}
+
+func NewWebhookNotifier(p Publisher) *WebhookNotifier {
+	return &WebhookNotifier{publisher: p}
+}
+
+func (n *WebhookNotifier) Name() string { return NotifierWebhook }
+
+func (n *WebhookNotifier) Notify(ctx context.Context, a dto.Alert) error {
+	n.publisher.Publish(ctx, dto.Event{
+		Type:     dto.EventAlertRaised,
+		GUID:     a.GUID,
+		TenantID: a.TenantID,
+		Data:     a,
+	})
+
+	return nil
+}
+
+// SMTPConfig is the mail relay the SMTP notifier sends alerts through.
+type SMTPConfig struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	From     string
+	To       []string
+}
+
+// SMTPNotifier mails alerts. The relay is asked for STARTTLS when it offers it, and credentials are only sent
+// over TLS or to a relay on localhost.
+type SMTPNotifier struct {
+	cfg SMTPConfig
+}
+
+func NewSMTPNotifier(cfg SMTPConfig) *SMTPNotifier {
+	return &SMTPNotifier{cfg: cfg}
+}
+
+func (n *SMTPNotifier) Name() string { return NotifierSMTP }
+
+func (n *SMTPNotifier) Notify(ctx context.Context, a dto.Alert) error {
+	ctx, cancel := context.WithTimeout(ctx, smtpTimeout)
+	defer cancel()
+
+	addr := net.JoinHostPort(n.cfg.Host, strconv.Itoa(n.cfg.Port))
+
+	conn, err := (&net.Dialer{}).DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return err
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	c, err := smtp.NewClient(conn, n.cfg.Host)
+	if err != nil {
+		conn.Close()
+
+		return err
+	}
+
+	defer c.Close()
+
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		if err = c.StartTLS(&tls.Config{ServerName: n.cfg.Host, MinVersion: tls.VersionTLS12}); err != nil {
+			return err
+		}
+	}
+
+	if n.cfg.Username != "" {
+		if err = c.Auth(smtp.PlainAuth("", n.cfg.Username, n.cfg.Password, n.cfg.Host)); err != nil {
+			return err
+		}
+	}
+
+	if err = c.Mail(n.cfg.From); err != nil {
+		return err
+	}
+
+	for _, to := range n.cfg.To {
+		if err = c.Rcpt(to); err != nil {
+			return err
+		}
+	}
+
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+
+	if _, err = w.Write(n.message(&a)); err != nil {
+		return err
+	}
+
+	if err = w.Close(); err != nil {
+		return err
+	}
+
+	return c.Quit()
+}
+
+func (n *SMTPNotifier) message(a *dto.Alert) []byte {
+	var b bytes.Buffer
+
+	fmt.Fprintf(&b, "From: %s\r\n", n.cfg.From)
+	fmt.Fprintf(&b, "To: %s\r\n", strings.Join(n.cfg.To, ", "))
+	fmt.Fprintf(&b, "Subject: [%s] %s event on %s\r\n", a.Severity, a.Entity, a.GUID)
+	fmt.Fprintf(&b, "Date: %s\r\n", a.RaisedAt.Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("\r\n")
+	fmt.Fprintf(&b, "Rule:        %s\r\n", a.RuleName)
+	fmt.Fprintf(&b, "Device:      %s\r\n", a.GUID)
+	fmt.Fprintf(&b, "Severity:    %s\r\n", a.Severity)
+	fmt.Fprintf(&b, "Entity:      %s\r\n", a.Entity)
+	fmt.Fprintf(&b, "Event time:  %s\r\n", a.EventTime.Format(time.RFC3339))
+	fmt.Fprintf(&b, "Description: %s\r\n", a.Description)
+	fmt.Fprintf(&b, "Alert:       %s\r\n", a.ID)
+
+	return b.Bytes()
+}
@@ -0,0 +1,166 @@
+package eventlogs_test
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	"github.com/open-amt-cloud-toolkit/console/internal/mocks"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/eventlogs"
+)
+
+var testAlert = dto.Alert{
+	ID:          "a1",
+	RuleName:    "bios-critical",
+	GUID:        "guid1",
+	Severity:    dto.EventSeverityCritical,
+	Entity:      "BIOS",
+	Description: "System firmware error",
+	EventTime:   time.Date(2024, 12, 1, 11, 0, 0, 0, time.UTC),
+	RaisedAt:    time.Date(2024, 12, 1, 11, 5, 0, 0, time.UTC),
+	Status:      dto.AlertStatusOpen,
+	TenantID:    "tenant1",
+}
+
+// mail is what the fake relay was handed.
+type mail struct {
+	from string
+	to   []string
+	data string
+}
+
+// serveSMTP runs a relay on localhost that speaks just enough SMTP to take one mail.
+func serveSMTP(t *testing.T) (int, <-chan mail) {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	t.Cleanup(func() { ln.Close() })
+
+	mails := make(chan mail, 1)
+
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+
+		defer conn.Close()
+
+		r := bufio.NewReader(conn)
+		reply := func(line string) { _, _ = conn.Write([]byte(line + "\r\n")) }
+
+		var m mail
+
+		reply("220 localhost ESMTP")
+
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+
+			line = strings.TrimRight(line, "\r\n")
+			verb := strings.ToUpper(strings.SplitN(line, " ", 2)[0])
+
+			switch {
+			case verb == "EHLO" || verb == "HELO":
+				reply("250 localhost")
+			case strings.HasPrefix(strings.ToUpper(line), "MAIL FROM:"):
+				m.from = strings.Trim(line[len("MAIL FROM:"):], "<>")
+				reply("250 OK")
+			case strings.HasPrefix(strings.ToUpper(line), "RCPT TO:"):
+				m.to = append(m.to, strings.Trim(line[len("RCPT TO:"):], "<>"))
+				reply("250 OK")
+			case verb == "DATA":
+				reply("354 end with .")
+
+				var data strings.Builder
+
+				for {
+					l, err := r.ReadString('\n')
+					if err != nil || l == ".\r\n" {
+						break
+					}
+
+					data.WriteString(l)
+				}
+
+				m.data = data.String()
+				mails <- m
+
+				reply("250 OK")
+			case verb == "QUIT":
+				reply("221 bye")
+
+				return
+			default:
+				reply("502 not implemented")
+			}
+		}
+	}()
+
+	return ln.Addr().(*net.TCPAddr).Port, mails
+}
+
+func TestSMTPNotifier(t *testing.T) {
+	t.Parallel()
+
+	port, mails := serveSMTP(t)
+
+	n := eventlogs.NewSMTPNotifier(eventlogs.SMTPConfig{
+		Host: "127.0.0.1",
+		Port: port,
+		From: "console@localhost",
+		To:   []string{"oncall@localhost", "lab@localhost"},
+	})
+
+	require.Equal(t, "smtp", n.Name())
+	require.NoError(t, n.Notify(context.Background(), testAlert))
+
+	m := <-mails
+	require.Equal(t, "console@localhost", m.from)
+	require.Equal(t, []string{"oncall@localhost", "lab@localhost"}, m.to)
+	require.Contains(t, m.data, "Subject: [critical] BIOS event on guid1\r\n")
+	require.Contains(t, m.data, "Description: System firmware error\r\n")
+	require.Contains(t, m.data, "Alert:       a1\r\n")
+}
+
+func TestSMTPNotifierUnreachable(t *testing.T) {
+	t.Parallel()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	port := ln.Addr().(*net.TCPAddr).Port
+	ln.Close()
+
+	n := eventlogs.NewSMTPNotifier(eventlogs.SMTPConfig{Host: "127.0.0.1", Port: port, From: "console@localhost", To: []string{"oncall@localhost"}})
+
+	require.Error(t, n.Notify(context.Background(), testAlert))
+}
+
+func TestWebhookNotifier(t *testing.T) {
+	t.Parallel()
+
+	publisher := mocks.NewMockEventLogsPublisher(gomock.NewController(t))
+	publisher.EXPECT().Publish(gomock.Any(), dto.Event{
+		Type:     dto.EventAlertRaised,
+		GUID:     "guid1",
+		TenantID: "tenant1",
+		Data:     testAlert,
+	})
+
+	n := eventlogs.NewWebhookNotifier(publisher)
+
+	require.Equal(t, "webhook", n.Name())
+	require.NoError(t, n.Notify(context.Background(), testAlert))
+}
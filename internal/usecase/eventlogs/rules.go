@@ -0,0 +1,186 @@
+package eventlogs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity"
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+)
+
+var (
+	errRuleName = errors.New("name is required")
+	errSeverity = errors.New("severity must be one of " + strings.Join(dto.EventSeverities, ", "))
+	errNotifier = errors.New("unknown notifier")
+)
+
+func (uc *UseCase) GetRuleCount(ctx context.Context, tenantID string) (int, error) {
+	count, err := uc.repo.GetRuleCount(ctx, tenantID)
+	if err != nil {
+		return 0, ErrDatabase.Wrap("GetRuleCount", "uc.repo.GetRuleCount", err)
+	}
+
+	return count, nil
+}
+
+func (uc *UseCase) GetRules(ctx context.Context, top, skip int, tenantID string) ([]dto.AlertRule, error) {
+	data, err := uc.repo.GetRules(ctx, top, skip, tenantID)
+	if err != nil {
+		return nil, ErrDatabase.Wrap("GetRules", "uc.repo.GetRules", err)
+	}
+
+	d1 := make([]dto.AlertRule, len(data))
+
+	for i := range data {
+		d1[i] = *ruleToDTO(&data[i])
+	}
+
+	return d1, nil
+}
+
+func (uc *UseCase) GetRuleByName(ctx context.Context, name, tenantID string) (*dto.AlertRule, error) {
+	data, err := uc.repo.GetRuleByName(ctx, name, tenantID)
+	if err != nil {
+		return nil, ErrDatabase.Wrap("GetRuleByName", "uc.repo.GetRuleByName", err)
+	}
+
+	if data == nil {
+		return nil, ErrNotFound
+	}
+
+	return ruleToDTO(data), nil
+}
+
+func (uc *UseCase) DeleteRule(ctx context.Context, name, tenantID string) error {
+	isSuccessful, err := uc.repo.DeleteRule(ctx, name, tenantID)
+	if err != nil {
+		return ErrDatabase.Wrap("DeleteRule", "uc.repo.DeleteRule", err)
+	}
+
+	if !isSuccessful {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+func (uc *UseCase) UpdateRule(ctx context.Context, d *dto.AlertRule) (*dto.AlertRule, error) {
+	if err := uc.validate(d); err != nil {
+		return nil, ErrNotValid.Wrap("UpdateRule", "uc.validate", err)
+	}
+
+	updated, err := uc.repo.UpdateRule(ctx, ruleToEntity(d))
+	if err != nil {
+		return nil, ErrDatabase.Wrap("UpdateRule", "uc.repo.UpdateRule", err)
+	}
+
+	if !updated {
+		return nil, ErrNotFound
+	}
+
+	return uc.GetRuleByName(ctx, d.Name, d.TenantID)
+}
+
+// InsertRule adds a rule, it raises alerts for the events that happen from now on.
+func (uc *UseCase) InsertRule(ctx context.Context, d *dto.AlertRule) (*dto.AlertRule, error) {
+	if err := uc.validate(d); err != nil {
+		return nil, ErrNotValid.Wrap("InsertRule", "uc.validate", err)
+	}
+
+	d1 := ruleToEntity(d)
+	d1.CreationDate = formatTime(uc.now())
+
+	name, err := uc.repo.InsertRule(ctx, d1)
+	if err != nil {
+		return nil, ErrDatabase.Wrap("InsertRule", "uc.repo.InsertRule", err)
+	}
+
+	return uc.GetRuleByName(ctx, name, d.TenantID)
+}
+
+// validate normalizes the severity and notifiers of a rule and checks that the notifiers are configured.
+func (uc *UseCase) validate(d *dto.AlertRule) error {
+	if strings.TrimSpace(d.Name) == "" {
+		return errRuleName
+	}
+
+	d.Severity = strings.ToLower(strings.TrimSpace(d.Severity))
+	if d.Severity != "" && !slices.Contains(dto.EventSeverities, d.Severity) {
+		return errSeverity
+	}
+
+	notifiers := make([]string, 0, len(d.Notifiers))
+
+	for _, n := range d.Notifiers {
+		n = strings.ToLower(strings.TrimSpace(n))
+
+		if _, ok := uc.notifiers[n]; !ok {
+			return fmt.Errorf("%w %q", errNotifier, n)
+		}
+
+		if !slices.Contains(notifiers, n) {
+			notifiers = append(notifiers, n)
+		}
+	}
+
+	d.Notifiers = notifiers
+
+	return nil
+}
+
+// matches reports whether rule raises an alert for e: the event is at least as severe as the rule asks, comes from
+// its entity and device, and happened after the rule was created so that adding a rule does not alert on history.
+func matches(rule *entity.AlertRule, e *entity.DeviceEvent) bool {
+	if rule.GUID != "" && rule.GUID != e.GUID {
+		return false
+	}
+
+	if rule.Entity != "" && !strings.EqualFold(rule.Entity, e.Entity) {
+		return false
+	}
+
+	if rule.Severity != "" && slices.Index(dto.EventSeverities, e.Severity) < slices.Index(dto.EventSeverities, rule.Severity) {
+		return false
+	}
+
+	return e.Time >= rule.CreationDate
+}
+
+// convert entity.AlertRule to dto.AlertRule.
+func ruleToDTO(d *entity.AlertRule) *dto.AlertRule {
+	d1 := &dto.AlertRule{
+		Name:      d.Name,
+		Severity:  d.Severity,
+		Entity:    d.Entity,
+		GUID:      d.GUID,
+		Notifiers: []string{},
+		Enabled:   d.Enabled,
+		TenantID:  d.TenantID,
+	}
+
+	if d.Notifiers != "" {
+		d1.Notifiers = strings.Split(d.Notifiers, ",")
+	}
+
+	if created := parseTime(d.CreationDate); created != nil {
+		d1.CreationDate = *created
+	}
+
+	return d1
+}
+
+// convert dto.AlertRule to entity.AlertRule.
+func ruleToEntity(d *dto.AlertRule) *entity.AlertRule {
+	return &entity.AlertRule{
+		Name:      d.Name,
+		Severity:  d.Severity,
+		Entity:    d.Entity,
+		GUID:      d.GUID,
+		Notifiers: strings.Join(d.Notifiers, ","),
+		Enabled:   d.Enabled,
+		TenantID:  d.TenantID,
+	}
+}
@@ -0,0 +1,148 @@
+package eventlogs
+
+import (
+	"context"
+	"encoding/hex"
+	"time"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity"
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/sqldb"
+	"github.com/open-amt-cloud-toolkit/console/pkg/consoleerrors"
+	"github.com/open-amt-cloud-toolkit/console/pkg/logger"
+)
+
+// Config controls the periodic collection of the fleet's event logs.
+type Config struct {
+	// Interval between two collections of the fleet, zero disables them.
+	Interval time.Duration
+	// Workers caps the number of devices read at once.
+	Workers int
+}
+
+// UseCase -.
+type UseCase struct {
+	repo      Repository
+	devices   Devices
+	fleet     Fleet
+	notifiers map[string]Notifier
+	log       logger.Interface
+	cfg       Config
+	now       func() time.Time
+}
+
+// New -. The rules can name any of notifiers to deliver their alerts.
+func New(r Repository, d Devices, f Fleet, log logger.Interface, cfg Config, notifiers ...Notifier) *UseCase {
+	if cfg.Workers <= 0 {
+		cfg.Workers = 1
+	}
+
+	byName := make(map[string]Notifier, len(notifiers))
+	for _, n := range notifiers {
+		byName[n.Name()] = n
+	}
+
+	return &UseCase{
+		repo:      r,
+		devices:   d,
+		fleet:     f,
+		notifiers: byName,
+		log:       log,
+		cfg:       cfg,
+		now:       time.Now,
+	}
+}
+
+var (
+	ErrEventLogsUseCase = consoleerrors.CreateConsoleError("EventLogsUseCase")
+	ErrDatabase         = sqldb.DatabaseError{Console: ErrEventLogsUseCase}
+	ErrNotFound         = sqldb.NotFoundError{Console: ErrEventLogsUseCase}
+	ErrNotValid         = dto.NotValidError{Console: ErrEventLogsUseCase}
+)
+
+func (uc *UseCase) GetEventCount(ctx context.Context, filter dto.DeviceEventFilter) (int, error) {
+	count, err := uc.repo.GetEventCount(ctx, eventFilterToEntity(filter))
+	if err != nil {
+		return 0, ErrDatabase.Wrap("GetEventCount", "uc.repo.GetEventCount", err)
+	}
+
+	return count, nil
+}
+
+// GetEvents returns the collected events matching filter, newest first.
+func (uc *UseCase) GetEvents(ctx context.Context, filter dto.DeviceEventFilter, top, skip int) ([]dto.DeviceEvent, error) {
+	data, err := uc.repo.GetEvents(ctx, eventFilterToEntity(filter), top, skip)
+	if err != nil {
+		return nil, ErrDatabase.Wrap("GetEvents", "uc.repo.GetEvents", err)
+	}
+
+	d1 := make([]dto.DeviceEvent, len(data))
+
+	for i := range data {
+		d1[i] = *eventToDTO(&data[i])
+	}
+
+	return d1, nil
+}
+
+// convert entity.DeviceEvent to dto.DeviceEvent.
+func eventToDTO(d *entity.DeviceEvent) *dto.DeviceEvent {
+	d1 := &dto.DeviceEvent{
+		GUID:            d.GUID,
+		Severity:        d.Severity,
+		Entity:          d.Entity,
+		EntityInstance:  d.EntityInstance,
+		SensorType:      d.SensorType,
+		SensorNumber:    d.SensorNumber,
+		EventType:       d.EventType,
+		EventOffset:     d.EventOffset,
+		EventSourceType: d.EventSourceType,
+		DeviceAddress:   d.DeviceAddress,
+		EventData:       []int{},
+		Description:     d.Description,
+		TenantID:        d.TenantID,
+	}
+
+	if data, err := hex.DecodeString(d.EventData); err == nil {
+		for _, b := range data {
+			d1.EventData = append(d1.EventData, int(b))
+		}
+	}
+
+	d1.Time, _ = time.Parse(time.RFC3339, d.Time)
+	d1.CollectedAt, _ = time.Parse(time.RFC3339, d.CollectedAt)
+
+	return d1
+}
+
+func eventFilterToEntity(f dto.DeviceEventFilter) entity.DeviceEventFilter {
+	f1 := entity.DeviceEventFilter{
+		GUID:     f.GUID,
+		Severity: f.Severity,
+		Entity:   f.Entity,
+		TenantID: f.TenantID,
+	}
+
+	if f.From != nil {
+		f1.From = formatTime(*f.From)
+	}
+
+	if f.To != nil {
+		f1.To = formatTime(*f.To)
+	}
+
+	return f1
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
+
+func parseTime(s string) *time.Time {
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return nil
+	}
+
+	return &t
+}
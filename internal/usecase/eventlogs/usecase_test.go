@@ -0,0 +1,399 @@
+package eventlogs_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity"
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	"github.com/open-amt-cloud-toolkit/console/internal/mocks"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/eventlogs"
+	"github.com/open-amt-cloud-toolkit/console/pkg/logger"
+	"github.com/open-amt-cloud-toolkit/console/pkg/subject"
+	"github.com/open-amt-cloud-toolkit/console/pkg/tenant"
+)
+
+var (
+	errUnreachable = errors.New("device unreachable")
+	errRelay       = errors.New("relay refused")
+)
+
+type eventLogTest struct {
+	uc       *eventlogs.UseCase
+	repo     *mocks.MockEventLogsRepository
+	devices  *mocks.MockEventLogsDevices
+	fleet    *mocks.MockEventLogsFleet
+	notifier *mocks.MockEventLogsNotifier
+}
+
+func initEventLogTest(t *testing.T) eventLogTest {
+	t.Helper()
+
+	mockCtl := gomock.NewController(t)
+	repo := mocks.NewMockEventLogsRepository(mockCtl)
+	d := mocks.NewMockEventLogsDevices(mockCtl)
+	f := mocks.NewMockEventLogsFleet(mockCtl)
+	n := mocks.NewMockEventLogsNotifier(mockCtl)
+
+	n.EXPECT().Name().Return("pager").AnyTimes()
+
+	return eventLogTest{
+		uc:       eventlogs.New(repo, d, f, logger.New("error"), eventlogs.Config{Workers: 2}, eventlogs.NewLogNotifier(logger.New("error")), n),
+		repo:     repo,
+		devices:  d,
+		fleet:    f,
+		notifier: n,
+	}
+}
+
+// the event log of guid1 in two pages, as the device management feature hands it back
+var (
+	monitorBIOS = dto.EventLog{
+		EventSeverity: "Monitor", Entity: "BIOS", EventSensorType: 15, EventType: 111, EventOffset: 2,
+		EventData: []int{64, 7, 0}, Time: "2024-12-01 10:00:00 +0000 UTC", Description: "PCI resource configuration",
+	}
+	criticalBIOS = dto.EventLog{
+		EventSeverity: "Critical condition", Entity: "BIOS", EventSensorType: 15, EventType: 111, EventOffset: 0,
+		Time: "2024-12-01 11:00:00 +0000 UTC", Description: "System firmware error",
+	}
+	criticalME = dto.EventLog{
+		EventSeverity: "Critical condition", Entity: "Intel(r) ME", EventSensorType: 6, EventType: 111, EventOffset: 5,
+		Time: "2024-12-01 12:00:00 +0000 UTC", Description: "Authentication failed 10 times. The system may be under attack.",
+	}
+)
+
+func expectEventLog(d *mocks.MockEventLogsDevices) {
+	gomock.InOrder(
+		d.EXPECT().GetEventLog(gomock.Any(), 1, 390, "guid1").DoAndReturn(func(ctx context.Context, _, _ int, _ string) (dto.EventLogs, error) {
+			if tenant.FromContext(ctx) != "tenant1" {
+				return dto.EventLogs{}, errUnreachable
+			}
+
+			return dto.EventLogs{Records: []dto.EventLog{monitorBIOS, criticalBIOS}, HasMoreRecords: true}, nil
+		}),
+		d.EXPECT().GetEventLog(gomock.Any(), 3, 390, "guid1").
+			Return(dto.EventLogs{Records: []dto.EventLog{criticalME}}, nil),
+	)
+}
+
+// storeAll has the repository take every event it is given as new.
+func storeAll(repo *mocks.MockEventLogsRepository, stored *[]entity.DeviceEvent) {
+	repo.EXPECT().InsertEvents(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, events []entity.DeviceEvent) ([]string, error) {
+		*stored = events
+
+		fingerprints := make([]string, len(events))
+		for i := range events {
+			fingerprints[i] = events[i].Fingerprint
+		}
+
+		return fingerprints, nil
+	})
+}
+
+func TestCollectRaisesAlerts(t *testing.T) {
+	t.Parallel()
+
+	test := initEventLogTest(t)
+
+	expectEventLog(test.devices)
+
+	var stored []entity.DeviceEvent
+
+	storeAll(test.repo, &stored)
+
+	test.repo.EXPECT().GetEnabledRules(gomock.Any(), "tenant1").Return([]entity.AlertRule{
+		{Name: "bios-critical", Severity: "critical", Entity: "bios", Notifiers: "log,pager", CreationDate: "2024-11-30T00:00:00Z", TenantID: "tenant1"},
+		{Name: "other-device", Severity: "monitor", GUID: "guid2", Notifiers: "pager", CreationDate: "2024-11-30T00:00:00Z", TenantID: "tenant1"},
+		{Name: "created-later", Notifiers: "pager", CreationDate: "2024-12-01T11:30:00Z", TenantID: "tenant1"},
+	}, nil)
+
+	var alerts []entity.Alert
+
+	test.repo.EXPECT().InsertAlert(gomock.Any(), gomock.Any()).Times(2).DoAndReturn(func(_ context.Context, a *entity.Alert) (bool, error) {
+		alerts = append(alerts, *a)
+
+		return true, nil
+	})
+
+	var notified []dto.Alert
+
+	test.notifier.EXPECT().Notify(gomock.Any(), gomock.Any()).Times(2).DoAndReturn(func(_ context.Context, a dto.Alert) error {
+		notified = append(notified, a)
+
+		return nil
+	})
+
+	collection, err := test.uc.Collect(context.Background(), "guid1", "tenant1")
+	require.NoError(t, err)
+	require.Equal(t, 3, collection.Read)
+	require.Equal(t, 3, collection.Collected)
+	require.Equal(t, 2, collection.Alerts)
+
+	require.Len(t, stored, 3)
+	require.Equal(t, "2024-12-01T10:00:00Z", stored[0].Time)
+	require.Equal(t, dto.EventSeverityMonitor, stored[0].Severity)
+	require.Equal(t, "400700", stored[0].EventData)
+	require.Equal(t, dto.EventSeverityCritical, stored[2].Severity)
+	require.NotEqual(t, stored[1].Fingerprint, stored[2].Fingerprint)
+
+	// the critical BIOS event matches its rule, the ME event only the rule created before it happened
+	require.Equal(t, "bios-critical", alerts[0].RuleName)
+	require.Equal(t, stored[1].Fingerprint, alerts[0].Fingerprint)
+	require.Equal(t, dto.AlertStatusOpen, alerts[0].Status)
+	require.Equal(t, "created-later", alerts[1].RuleName)
+	require.Equal(t, stored[2].Fingerprint, alerts[1].Fingerprint)
+
+	require.Equal(t, "System firmware error", notified[0].Description)
+	require.Equal(t, "created-later", notified[1].RuleName)
+}
+
+func TestCollectSkipsStoredEvents(t *testing.T) {
+	t.Parallel()
+
+	test := initEventLogTest(t)
+
+	expectEventLog(test.devices)
+
+	// every event was stored by an earlier collection
+	test.repo.EXPECT().InsertEvents(gomock.Any(), gomock.Len(3)).Return(nil, nil)
+
+	collection, err := test.uc.Collect(context.Background(), "guid1", "tenant1")
+	require.NoError(t, err)
+	require.Equal(t, 3, collection.Read)
+	require.Equal(t, 0, collection.Collected)
+	require.Equal(t, 0, collection.Alerts)
+}
+
+func TestCollectKeepsAlertWhenNotifierFails(t *testing.T) {
+	t.Parallel()
+
+	test := initEventLogTest(t)
+
+	expectEventLog(test.devices)
+
+	var stored []entity.DeviceEvent
+
+	storeAll(test.repo, &stored)
+
+	test.repo.EXPECT().GetEnabledRules(gomock.Any(), "tenant1").Return([]entity.AlertRule{
+		{Name: "me", Entity: "Intel(r) ME", Notifiers: "pager", TenantID: "tenant1"},
+	}, nil)
+	test.repo.EXPECT().InsertAlert(gomock.Any(), gomock.Any()).Return(true, nil)
+	test.notifier.EXPECT().Notify(gomock.Any(), gomock.Any()).Return(errRelay)
+
+	collection, err := test.uc.Collect(context.Background(), "guid1", "tenant1")
+	require.NoError(t, err)
+	require.Equal(t, 1, collection.Alerts)
+}
+
+func TestCollectFails(t *testing.T) {
+	t.Parallel()
+
+	test := initEventLogTest(t)
+
+	test.devices.EXPECT().GetEventLog(gomock.Any(), 1, 390, "guid1").Return(dto.EventLogs{}, errUnreachable)
+
+	_, err := test.uc.Collect(context.Background(), "guid1", "tenant1")
+	require.ErrorIs(t, err, errUnreachable)
+}
+
+func TestCollectAll(t *testing.T) {
+	t.Parallel()
+
+	test := initEventLogTest(t)
+
+	test.fleet.EXPECT().GetAllTenants(gomock.Any(), 100, 0).Return([]entity.Device{
+		{GUID: "guid1", TenantID: "tenant1", ConnectionStatus: true},
+		{GUID: "guid2", TenantID: "tenant1", ConnectionStatus: false},
+	}, nil)
+	test.devices.EXPECT().GetEventLog(gomock.Any(), 1, 390, "guid1").Return(dto.EventLogs{Records: []dto.EventLog{monitorBIOS}}, nil)
+	test.repo.EXPECT().InsertEvents(gomock.Any(), gomock.Len(1)).Return(nil, nil)
+
+	test.uc.CollectAll(context.Background())
+}
+
+func TestGetEvents(t *testing.T) {
+	t.Parallel()
+
+	test := initEventLogTest(t)
+
+	from := time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC)
+
+	test.repo.EXPECT().GetEvents(gomock.Any(), entity.DeviceEventFilter{Severity: "critical", From: "2024-12-01T00:00:00Z", TenantID: "tenant1"}, 25, 0).
+		Return([]entity.DeviceEvent{{
+			GUID: "guid1", Time: "2024-12-01T11:00:00Z", Severity: "critical", Entity: "BIOS", EventData: "4007",
+			CollectedAt: "2024-12-01T12:00:00Z", TenantID: "tenant1",
+		}}, nil)
+
+	events, err := test.uc.GetEvents(context.Background(), dto.DeviceEventFilter{Severity: "critical", From: &from, TenantID: "tenant1"}, 25, 0)
+	require.NoError(t, err)
+	require.Equal(t, []dto.DeviceEvent{{
+		GUID:        "guid1",
+		Time:        time.Date(2024, 12, 1, 11, 0, 0, 0, time.UTC),
+		Severity:    "critical",
+		Entity:      "BIOS",
+		EventData:   []int{64, 7},
+		CollectedAt: time.Date(2024, 12, 1, 12, 0, 0, 0, time.UTC),
+		TenantID:    "tenant1",
+	}}, events)
+}
+
+func TestInsertRule(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		rule dto.AlertRule
+		err  error
+	}{
+		{
+			name: "unknown severity",
+			rule: dto.AlertRule{Name: "r", Severity: "dire"},
+			err:  eventlogs.ErrNotValid,
+		},
+		{
+			name: "unknown notifier",
+			rule: dto.AlertRule{Name: "r", Notifiers: []string{"log", "pigeon"}},
+			err:  eventlogs.ErrNotValid,
+		},
+		{
+			name: "no name",
+			rule: dto.AlertRule{Name: " "},
+			err:  eventlogs.ErrNotValid,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			test := initEventLogTest(t)
+
+			_, err := test.uc.InsertRule(context.Background(), &tc.rule)
+			require.ErrorAs(t, err, &tc.err)
+		})
+	}
+
+	t.Run("normalizes the rule", func(t *testing.T) {
+		t.Parallel()
+
+		test := initEventLogTest(t)
+
+		test.repo.EXPECT().InsertRule(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, rule *entity.AlertRule) (string, error) {
+			require.Equal(t, "critical", rule.Severity)
+			require.Equal(t, "log,pager", rule.Notifiers)
+			require.NotEmpty(t, rule.CreationDate)
+
+			return rule.Name, nil
+		})
+		test.repo.EXPECT().GetRuleByName(gomock.Any(), "bios-critical", "tenant1").Return(&entity.AlertRule{
+			Name: "bios-critical", Severity: "critical", Entity: "BIOS", Notifiers: "log,pager", Enabled: true,
+			CreationDate: "2024-12-01T00:00:00Z", TenantID: "tenant1",
+		}, nil)
+
+		rule, err := test.uc.InsertRule(context.Background(), &dto.AlertRule{
+			Name: "bios-critical", Severity: "Critical", Entity: "BIOS", Notifiers: []string{"log", "Pager", "log"}, Enabled: true, TenantID: "tenant1",
+		})
+		require.NoError(t, err)
+		require.Equal(t, &dto.AlertRule{
+			Name: "bios-critical", Severity: "critical", Entity: "BIOS", Notifiers: []string{"log", "pager"}, Enabled: true,
+			CreationDate: time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC), TenantID: "tenant1",
+		}, rule)
+	})
+}
+
+func TestAlertWorkflow(t *testing.T) {
+	t.Parallel()
+
+	ctx := subject.NewContext(context.Background(), "alice")
+
+	open := entity.Alert{ID: "a1", RuleName: "bios-critical", GUID: "guid1", Status: dto.AlertStatusOpen, TenantID: "tenant1"}
+
+	acknowledged := open
+	acknowledged.Status = dto.AlertStatusAcknowledged
+	acknowledged.AcknowledgedBy = "bob"
+	acknowledged.AcknowledgedAt = "2024-12-01T01:00:00Z"
+
+	closed := acknowledged
+	closed.Status = dto.AlertStatusClosed
+
+	t.Run("acknowledge an open alert", func(t *testing.T) {
+		t.Parallel()
+
+		test := initEventLogTest(t)
+
+		stored := open
+		test.repo.EXPECT().GetAlertByID(gomock.Any(), "a1", "tenant1").Return(&stored, nil)
+		test.repo.EXPECT().UpdateAlertStatus(gomock.Any(), gomock.Any(), dto.AlertStatusOpen).Return(true, nil)
+
+		alert, err := test.uc.Acknowledge(ctx, "a1", "tenant1")
+		require.NoError(t, err)
+		require.Equal(t, dto.AlertStatusAcknowledged, alert.Status)
+		require.Equal(t, "alice", alert.AcknowledgedBy)
+		require.NotNil(t, alert.AcknowledgedAt)
+		require.Nil(t, alert.ClosedAt)
+	})
+
+	t.Run("close an acknowledged alert", func(t *testing.T) {
+		t.Parallel()
+
+		test := initEventLogTest(t)
+
+		stored := acknowledged
+		test.repo.EXPECT().GetAlertByID(gomock.Any(), "a1", "tenant1").Return(&stored, nil)
+		test.repo.EXPECT().UpdateAlertStatus(gomock.Any(), gomock.Any(), dto.AlertStatusAcknowledged).Return(true, nil)
+
+		alert, err := test.uc.Close(ctx, "a1", "tenant1")
+		require.NoError(t, err)
+		require.Equal(t, dto.AlertStatusClosed, alert.Status)
+		require.Equal(t, "bob", alert.AcknowledgedBy)
+		require.Equal(t, "alice", alert.ClosedBy)
+		require.NotNil(t, alert.ClosedAt)
+	})
+
+	t.Run("a closed alert stays closed", func(t *testing.T) {
+		t.Parallel()
+
+		test := initEventLogTest(t)
+
+		stored := closed
+		test.repo.EXPECT().GetAlertByID(gomock.Any(), "a1", "tenant1").Return(&stored, nil).Times(2)
+
+		_, err := test.uc.Acknowledge(ctx, "a1", "tenant1")
+		require.ErrorAs(t, err, &eventlogs.ErrNotValid)
+
+		_, err = test.uc.Close(ctx, "a1", "tenant1")
+		require.ErrorAs(t, err, &eventlogs.ErrNotValid)
+	})
+
+	t.Run("an alert acknowledged meanwhile is not acknowledged twice", func(t *testing.T) {
+		t.Parallel()
+
+		test := initEventLogTest(t)
+
+		stored := open
+		test.repo.EXPECT().GetAlertByID(gomock.Any(), "a1", "tenant1").Return(&stored, nil)
+		test.repo.EXPECT().UpdateAlertStatus(gomock.Any(), gomock.Any(), dto.AlertStatusOpen).Return(false, nil)
+
+		_, err := test.uc.Acknowledge(ctx, "a1", "tenant1")
+		require.ErrorAs(t, err, &eventlogs.ErrNotValid)
+	})
+
+	t.Run("unknown alert", func(t *testing.T) {
+		t.Parallel()
+
+		test := initEventLogTest(t)
+
+		test.repo.EXPECT().GetAlertByID(gomock.Any(), "a1", "tenant1").Return(nil, nil)
+
+		_, err := test.uc.Close(ctx, "a1", "tenant1")
+		require.ErrorIs(t, err, eventlogs.ErrNotFound)
+	})
+}
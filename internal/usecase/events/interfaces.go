@@ -0,0 +1,12 @@
+package events
+
+import (
+	"context"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+)
+
+type Feature interface {
+	Publish(ctx context.Context, e dto.Event)
+	Subscribe(filter dto.EventFilter) (<-chan dto.Event, func())
+}
@@ -0,0 +1,156 @@
+package events
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	"github.com/open-amt-cloud-toolkit/console/pkg/logger"
+)
+
+// subscriberBuffer is how many events a slow subscriber may fall behind before events are dropped for it.
+const subscriberBuffer = 64
+
+type subscriber struct {
+	ch     chan dto.Event
+	filter dto.EventFilter
+	guids  map[string]struct{}
+	tags   map[string]struct{}
+}
+
+type hook struct {
+	fn func(ctx context.Context, e dto.Event)
+}
+
+// UseCase is an in-process broker fanning device events out to subscribers.
+type UseCase struct {
+	mu          sync.RWMutex
+	subscribers map[*subscriber]struct{}
+	hooks       map[*hook]struct{}
+	seq         atomic.Uint64
+	log         logger.Interface
+}
+
+// New -.
+func New(log logger.Interface) *UseCase {
+	return &UseCase{
+		subscribers: make(map[*subscriber]struct{}),
+		hooks:       make(map[*hook]struct{}),
+		log:         log,
+	}
+}
+
+// Publish stamps e with an id and time, calls the hooks with it and hands it to every matching subscriber
+// without blocking.
+func (uc *UseCase) Publish(ctx context.Context, e dto.Event) {
+	e.ID = strconv.FormatUint(uc.seq.Add(1), 10)
+
+	if e.Time.IsZero() {
+		e.Time = time.Now().UTC()
+	}
+
+	if e.Tags == nil {
+		e.Tags = []string{}
+	}
+
+	uc.mu.RLock()
+	defer uc.mu.RUnlock()
+
+	for h := range uc.hooks {
+		h.fn(ctx, e)
+	}
+
+	for s := range uc.subscribers {
+		if !s.matches(&e) {
+			continue
+		}
+
+		select {
+		case s.ch <- e:
+		default:
+			uc.log.Warn("events - Publish - subscriber is too slow, dropped event " + e.ID)
+		}
+	}
+}
+
+// OnPublish registers fn to be called with every event as it is published, the returned func unregisters it.
+// Unlike a subscriber, that falls behind and loses events when it is slow, a hook sees every event but holds up
+// the publisher while it runs, so it must be quick.
+func (uc *UseCase) OnPublish(fn func(ctx context.Context, e dto.Event)) func() {
+	h := &hook{fn: fn}
+
+	uc.mu.Lock()
+	uc.hooks[h] = struct{}{}
+	uc.mu.Unlock()
+
+	return func() {
+		uc.mu.Lock()
+		delete(uc.hooks, h)
+		uc.mu.Unlock()
+	}
+}
+
+// Subscribe registers a subscriber, the returned func unregisters it and closes the channel.
+func (uc *UseCase) Subscribe(filter dto.EventFilter) (<-chan dto.Event, func()) {
+	s := &subscriber{
+		ch:     make(chan dto.Event, subscriberBuffer),
+		filter: filter,
+		guids:  toSet(filter.GUIDs),
+		tags:   toSet(filter.Tags),
+	}
+
+	uc.mu.Lock()
+	uc.subscribers[s] = struct{}{}
+	uc.mu.Unlock()
+
+	var once sync.Once
+
+	return s.ch, func() {
+		once.Do(func() {
+			uc.mu.Lock()
+			delete(uc.subscribers, s)
+			uc.mu.Unlock()
+
+			close(s.ch)
+		})
+	}
+}
+
+func (s *subscriber) matches(e *dto.Event) bool {
+	if !s.filter.AllTenants && e.TenantID != s.filter.TenantID {
+		return false
+	}
+
+	if len(s.guids) > 0 {
+		if _, ok := s.guids[e.GUID]; !ok {
+			return false
+		}
+	}
+
+	if len(s.tags) == 0 {
+		return true
+	}
+
+	for _, tag := range e.Tags {
+		if _, ok := s.tags[tag]; ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+func toSet(values []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(values))
+
+	for _, v := range values {
+		if v != "" {
+			set[v] = struct{}{}
+		}
+	}
+
+	return set
+}
@@ -0,0 +1,133 @@
+package events_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/events"
+	"github.com/open-amt-cloud-toolkit/console/pkg/logger"
+)
+
+func TestPublishFilters(t *testing.T) {
+	t.Parallel()
+
+	published := []dto.Event{
+		{Type: dto.EventDeviceAdded, GUID: "guid-1", Tags: []string{"lab"}},
+		{Type: dto.EventPowerAction, GUID: "guid-2", Tags: []string{"floor1", "lab"}},
+		{Type: dto.EventDeviceDeleted, GUID: "guid-3"},
+		{Type: dto.EventDeviceAdded, GUID: "guid-1", TenantID: "tenant2", Tags: []string{"lab"}},
+	}
+
+	tests := []struct {
+		name     string
+		filter   dto.EventFilter
+		expected []string
+	}{
+		{
+			name:     "everything of the tenant",
+			filter:   dto.EventFilter{},
+			expected: []string{"guid-1", "guid-2", "guid-3"},
+		},
+		{
+			name:     "by guid",
+			filter:   dto.EventFilter{GUIDs: []string{"guid-2", "guid-3"}},
+			expected: []string{"guid-2", "guid-3"},
+		},
+		{
+			name:     "by tag",
+			filter:   dto.EventFilter{Tags: []string{"floor1"}},
+			expected: []string{"guid-2"},
+		},
+		{
+			name:     "by guid and tag",
+			filter:   dto.EventFilter{GUIDs: []string{"guid-1", "guid-3"}, Tags: []string{"lab"}},
+			expected: []string{"guid-1"},
+		},
+		{
+			name:     "other tenant",
+			filter:   dto.EventFilter{TenantID: "tenant2"},
+			expected: []string{"guid-1"},
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			uc := events.New(logger.New("error"))
+
+			ch, cancel := uc.Subscribe(tc.filter)
+
+			for i := range published {
+				uc.Publish(context.Background(), published[i])
+			}
+
+			cancel()
+
+			var guids []string
+
+			for e := range ch {
+				require.NotEmpty(t, e.ID)
+				require.False(t, e.Time.IsZero())
+				require.NotNil(t, e.Tags)
+
+				guids = append(guids, e.GUID)
+			}
+
+			require.Equal(t, tc.expected, guids)
+		})
+	}
+}
+
+func TestPublishDropsForSlowSubscriber(t *testing.T) {
+	t.Parallel()
+
+	uc := events.New(logger.New("error"))
+
+	ch, cancel := uc.Subscribe(dto.EventFilter{})
+
+	for i := 0; i < 100; i++ {
+		uc.Publish(context.Background(), dto.Event{Type: dto.EventPowerAction, GUID: "guid-1"})
+	}
+
+	cancel()
+	cancel()
+
+	received := 0
+	for range ch {
+		received++
+	}
+
+	require.Equal(t, 64, received)
+}
+
+func TestOnPublishSeesEveryEvent(t *testing.T) {
+	t.Parallel()
+
+	uc := events.New(logger.New("error"))
+
+	var seen []dto.Event
+
+	remove := uc.OnPublish(func(_ context.Context, e dto.Event) {
+		seen = append(seen, e)
+	})
+
+	for i := 0; i < 100; i++ {
+		uc.Publish(context.Background(), dto.Event{Type: dto.EventPowerAction, GUID: "guid-1", TenantID: "tenant1"})
+	}
+
+	require.Len(t, seen, 100)
+	require.Equal(t, "100", seen[99].ID)
+	require.Equal(t, "tenant1", seen[99].TenantID)
+	require.False(t, seen[99].Time.IsZero())
+
+	remove()
+	uc.Publish(context.Background(), dto.Event{Type: dto.EventPowerAction, GUID: "guid-1"})
+
+	require.Len(t, seen, 100)
+}
@@ -0,0 +1,124 @@
+package export
+
+import (
+	"time"
+
+	"github.com/open-amt-cloud-toolkit/go-wsman-messages/v2/pkg/wsman/amt/auditlog"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+)
+
+// eventTimeLayout is how the event log of a device renders the time of its records.
+const eventTimeLayout = "2006-01-02 15:04:05.999999999 -0700 MST"
+
+// AuditLogColumns are the columns of an export of the AMT audit log of a device.
+var AuditLogColumns = []Column[auditlog.AuditLogRecord]{
+	{"id", "ID", func(r *auditlog.AuditLogRecord) any { return r.EventID }},
+	{"time", "Time", func(r *auditlog.AuditLogRecord) any { return r.Time }},
+	{"auditApp", "Application", func(r *auditlog.AuditLogRecord) any { return r.AuditApp }},
+	{"event", "Event", func(r *auditlog.AuditLogRecord) any { return r.Event }},
+	{"initiator", "Initiator", func(r *auditlog.AuditLogRecord) any { return r.Initiator }},
+	{"netAddress", "Address", func(r *auditlog.AuditLogRecord) any { return r.NetAddress }},
+	{"description", "Description", func(r *auditlog.AuditLogRecord) any { return r.ExStr }},
+}
+
+// EventLogColumns are the columns of an export of the AMT event log of a device.
+var EventLogColumns = []Column[dto.EventLog]{
+	{"time", "Time", func(r *dto.EventLog) any { return eventTime(r.Time) }},
+	{"source", "Source", func(r *dto.EventLog) any { return r.Entity }},
+	{"severity", "Event Severity", func(r *dto.EventLog) any { return r.EventSeverity }},
+	{"description", "Description", func(r *dto.EventLog) any { return r.Description }},
+	{"entityInstance", "Entity Instance", func(r *dto.EventLog) any { return r.EntityInstance }},
+	{"sensorType", "Sensor Type", func(r *dto.EventLog) any { return r.EventSensorType }},
+	{"sensorNumber", "Sensor Number", func(r *dto.EventLog) any { return r.SensorNumber }},
+	{"eventType", "Event Type", func(r *dto.EventLog) any { return r.EventType }},
+	{"eventOffset", "Event Offset", func(r *dto.EventLog) any { return r.EventOffset }},
+	{"sourceType", "Event Source Type", func(r *dto.EventLog) any { return r.EventSourceType }},
+	{"deviceAddress", "Device Address", func(r *dto.EventLog) any { return r.DeviceAddress }},
+	{"eventData", "Event Data", func(r *dto.EventLog) any { return r.EventData }},
+}
+
+// ConsoleAuditColumns are the columns of an export of the console audit trail.
+var ConsoleAuditColumns = []Column[dto.ConsoleAuditEntry]{
+	{"time", "Time", func(e *dto.ConsoleAuditEntry) any { return e.Time }},
+	{"subject", "Subject", func(e *dto.ConsoleAuditEntry) any { return e.Subject }},
+	{"tenantId", "Tenant", func(e *dto.ConsoleAuditEntry) any { return e.TenantID }},
+	{"method", "Method", func(e *dto.ConsoleAuditEntry) any { return e.Method }},
+	{"route", "Route", func(e *dto.ConsoleAuditEntry) any { return e.Route }},
+	{"path", "Path", func(e *dto.ConsoleAuditEntry) any { return e.Path }},
+	{"target", "Target", func(e *dto.ConsoleAuditEntry) any { return e.Target }},
+	{"summary", "Summary", func(e *dto.ConsoleAuditEntry) any { return e.Summary }},
+	{"statusCode", "Status Code", func(e *dto.ConsoleAuditEntry) any { return e.StatusCode }},
+	{"result", "Result", func(e *dto.ConsoleAuditEntry) any { return e.Result }},
+	{"durationMs", "Duration (ms)", func(e *dto.ConsoleAuditEntry) any { return e.DurationMS }},
+	{"clientIp", "Client IP", func(e *dto.ConsoleAuditEntry) any { return e.ClientIP }},
+	{"id", "ID", func(e *dto.ConsoleAuditEntry) any { return e.ID }},
+}
+
+// InventoryColumns are the columns of an export of the fleet inventory, one row per device.
+var InventoryColumns = []Column[dto.DeviceInventory]{
+	{"guid", "GUID", func(i *dto.DeviceInventory) any { return i.GUID }},
+	{"tenantId", "Tenant", func(i *dto.DeviceInventory) any { return i.TenantID }},
+	{"refreshedAt", "Refreshed At", func(i *dto.DeviceInventory) any { return i.RefreshedAt }},
+	{"amtVersion", "AMT Version", func(i *dto.DeviceInventory) any { return i.Firmware.AMTVersion }},
+	{"amtSku", "AMT SKU", func(i *dto.DeviceInventory) any { return i.Firmware.SKU }},
+	{"biosManufacturer", "BIOS Manufacturer", func(i *dto.DeviceInventory) any { return i.BIOS.Manufacturer }},
+	{"biosVersion", "BIOS Version", func(i *dto.DeviceInventory) any { return i.BIOS.Version }},
+	{"chassisManufacturer", "Chassis Manufacturer", func(i *dto.DeviceInventory) any { return i.Chassis.Manufacturer }},
+	{"chassisModel", "Chassis Model", func(i *dto.DeviceInventory) any { return i.Chassis.Model }},
+	{"chassisSerialNumber", "Chassis Serial Number", func(i *dto.DeviceInventory) any { return i.Chassis.SerialNumber }},
+	{"boardManufacturer", "Board Manufacturer", func(i *dto.DeviceInventory) any { return i.Board.Manufacturer }},
+	{"boardModel", "Board Model", func(i *dto.DeviceInventory) any { return i.Board.Model }},
+	{"processors", "Processors", func(i *dto.DeviceInventory) any {
+		names := make([]string, len(i.Processors))
+		for j := range i.Processors {
+			names[j] = i.Processors[j].Name
+		}
+
+		return names
+	}},
+	{"processorCount", "Processor Count", func(i *dto.DeviceInventory) any { return len(i.Processors) }},
+	{"totalMemoryBytes", "Total Memory (bytes)", func(i *dto.DeviceInventory) any { return i.TotalMemoryBytes }},
+	{"memoryModules", "Memory Modules", func(i *dto.DeviceInventory) any { return len(i.Memory) }},
+	{"disks", "Disks", func(i *dto.DeviceInventory) any { return len(i.Disks) }},
+}
+
+// DeviceColumns are the columns of an export of the device list. Credentials are never exported.
+var DeviceColumns = []Column[dto.Device]{
+	{"guid", "GUID", func(d *dto.Device) any { return d.GUID }},
+	{"hostname", "Hostname", func(d *dto.Device) any { return d.Hostname }},
+	{"friendlyName", "Friendly Name", func(d *dto.Device) any { return d.FriendlyName }},
+	{"tenantId", "Tenant", func(d *dto.Device) any { return d.TenantID }},
+	{"tags", "Tags", func(d *dto.Device) any { return d.Tags }},
+	{"connectionStatus", "Connected", func(d *dto.Device) any { return d.ConnectionStatus }},
+	{"mpsInstance", "MPS Instance", func(d *dto.Device) any { return d.MPSInstance }},
+	{"dnsSuffix", "DNS Suffix", func(d *dto.Device) any { return d.DNSSuffix }},
+	{"useTLS", "TLS", func(d *dto.Device) any { return d.UseTLS }},
+	{"allowSelfSigned", "Self-Signed Allowed", func(d *dto.Device) any { return d.AllowSelfSigned }},
+	{"lastConnected", "Last Connected", func(d *dto.Device) any { return d.LastConnected }},
+	{"lastSeen", "Last Seen", func(d *dto.Device) any { return d.LastSeen }},
+	{"lastDisconnected", "Last Disconnected", func(d *dto.Device) any { return d.LastDisconnected }},
+	{"fwVersion", "Firmware Version", func(d *dto.Device) any { return deviceInfo(d).FWVersion }},
+	{"fwBuild", "Firmware Build", func(d *dto.Device) any { return deviceInfo(d).FWBuild }},
+	{"fwSku", "Firmware SKU", func(d *dto.Device) any { return deviceInfo(d).FWSku }},
+	{"currentMode", "Control Mode", func(d *dto.Device) any { return deviceInfo(d).CurrentMode }},
+	{"ipAddress", "IP Address", func(d *dto.Device) any { return deviceInfo(d).IPAddress }},
+}
+
+// eventTime returns the time of an event log record, or the record's text when it is not a time.
+func eventTime(s string) any {
+	t, err := time.Parse(eventTimeLayout, s)
+	if err != nil {
+		return s
+	}
+
+	return t
+}
+
+func deviceInfo(d *dto.Device) *dto.DeviceInfo {
+	if d.DeviceInfo == nil {
+		return &dto.DeviceInfo{}
+	}
+
+	return d.DeviceInfo
+}
@@ -0,0 +1,66 @@
+package export
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Format is the file format of an export.
+type Format string
+
+const (
+	FormatCSV    Format = "csv"
+	FormatJSON   Format = "json"
+	FormatNDJSON Format = "ndjson"
+	FormatXLSX   Format = "xlsx"
+)
+
+// Formats are the formats an export can be written in.
+var Formats = []Format{FormatCSV, FormatJSON, FormatNDJSON, FormatXLSX}
+
+var (
+	ErrUnknownFormat = errors.New("unknown export format")
+	ErrUnknownColumn = errors.New("unknown export column")
+)
+
+// ParseFormat returns the format named s, CSV when s is empty.
+func ParseFormat(s string) (Format, error) {
+	if s == "" {
+		return FormatCSV, nil
+	}
+
+	for _, f := range Formats {
+		if strings.EqualFold(s, string(f)) {
+			return f, nil
+		}
+	}
+
+	return "", fmt.Errorf("%w %q, must be one of csv, json, ndjson or xlsx", ErrUnknownFormat, s)
+}
+
+// ContentType is the media type files of the format are served as.
+func (f Format) ContentType() string {
+	switch f {
+	case FormatJSON:
+		return "application/json"
+	case FormatNDJSON:
+		return "application/x-ndjson"
+	case FormatXLSX:
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	default:
+		return "text/csv"
+	}
+}
+
+// Extension is the file name extension of the format, with its dot.
+func (f Format) Extension() string {
+	return "." + string(f)
+}
+
+// Options select the format of an export and its columns. All columns are exported, in their default order,
+// when none are selected.
+type Options struct {
+	Format  Format
+	Columns []string
+}
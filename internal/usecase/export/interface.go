@@ -9,6 +9,9 @@ import (
 )
 
 type Exporter interface {
-	ExportAuditLogsCSV(logs []auditlog.AuditLogRecord) (io.Reader, error) // Converts logs to CSV and returns a reader
-	ExportEventLogsCSV(logs []dto.EventLog) (io.Reader, error)            // Converts logs to CSV and returns a reader
+	AuditLogs(w io.Writer, opts Options) (*Writer[auditlog.AuditLogRecord], error)  // Streams AMT audit log records to w
+	EventLogs(w io.Writer, opts Options) (*Writer[dto.EventLog], error)             // Streams AMT event log records to w
+	ConsoleAudit(w io.Writer, opts Options) (*Writer[dto.ConsoleAuditEntry], error) // Streams console audit entries to w
+	Inventory(w io.Writer, opts Options) (*Writer[dto.DeviceInventory], error)      // Streams device inventories to w
+	Devices(w io.Writer, opts Options) (*Writer[dto.Device], error)                 // Streams devices to w
 }
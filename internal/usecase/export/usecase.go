@@ -1,9 +1,6 @@
 package export
 
 import (
-	"bytes"
-	"encoding/csv"
-	"fmt"
 	"io"
 
 	"github.com/open-amt-cloud-toolkit/go-wsman-messages/v2/pkg/wsman/amt/auditlog"
@@ -17,49 +14,27 @@ func NewFileExporter() *FileExporter {
 	return &FileExporter{}
 }
 
-func (e *FileExporter) ExportAuditLogsCSV(logs []auditlog.AuditLogRecord) (io.Reader, error) {
-	buffer := &bytes.Buffer{}
-	writer := csv.NewWriter(buffer)
-
-	// Assuming logs is a slice of a specific struct
-	// Convert it to a slice of string slices for CSV rows
-	records := [][]string{{"ID", "Time", "Event", "Description"}} // Replace with actual field names
-	for i := range logs {
-		records = append(records, []string{
-			fmt.Sprintf("%d", logs[i].EventID),
-			logs[i].Time.String(),
-			logs[i].Event,
-			logs[i].ExStr,
-		})
-	}
-
-	if err := writer.WriteAll(records); err != nil {
-		return nil, fmt.Errorf("error writing CSV: %w", err)
-	}
-
-	return buffer, nil
+// AuditLogs returns a writer of AMT audit log records to w.
+func (e *FileExporter) AuditLogs(w io.Writer, opts Options) (*Writer[auditlog.AuditLogRecord], error) {
+	return NewWriter(w, AuditLogColumns, opts)
 }
 
-// ExportEventLogsCSV converts event logs to CSV and returns a reader.
-func (e *FileExporter) ExportEventLogsCSV(logs []dto.EventLog) (io.Reader, error) {
-	buffer := &bytes.Buffer{}
-	writer := csv.NewWriter(buffer)
+// EventLogs returns a writer of AMT event log records to w.
+func (e *FileExporter) EventLogs(w io.Writer, opts Options) (*Writer[dto.EventLog], error) {
+	return NewWriter(w, EventLogColumns, opts)
+}
 
-	// Assuming logs is a slice of a specific struct
-	// Convert it to a slice of string slices for CSV rows
-	records := [][]string{{"Time", "Source", "Event Severity", "Description"}} // Replace with actual field names
-	for i := range logs {
-		records = append(records, []string{
-			logs[i].Time,
-			logs[i].Entity,
-			logs[i].EventSeverity,
-			logs[i].Description,
-		})
-	}
+// ConsoleAudit returns a writer of console audit entries to w.
+func (e *FileExporter) ConsoleAudit(w io.Writer, opts Options) (*Writer[dto.ConsoleAuditEntry], error) {
+	return NewWriter(w, ConsoleAuditColumns, opts)
+}
 
-	if err := writer.WriteAll(records); err != nil {
-		return nil, fmt.Errorf("error writing CSV: %w", err)
-	}
+// Inventory returns a writer of device inventories to w, one row per device.
+func (e *FileExporter) Inventory(w io.Writer, opts Options) (*Writer[dto.DeviceInventory], error) {
+	return NewWriter(w, InventoryColumns, opts)
+}
 
-	return buffer, nil
+// Devices returns a writer of devices to w.
+func (e *FileExporter) Devices(w io.Writer, opts Options) (*Writer[dto.Device], error) {
+	return NewWriter(w, DeviceColumns, opts)
 }
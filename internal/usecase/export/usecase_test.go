@@ -1,122 +1,334 @@
 package export_test
 
 import (
+	"archive/zip"
+	"bytes"
 	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/open-amt-cloud-toolkit/go-wsman-messages/v2/pkg/wsman/amt/auditlog"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
 	"github.com/open-amt-cloud-toolkit/console/internal/usecase/export"
 )
 
-func TestExportAuditLogsCSV(t *testing.T) {
+var auditRecords = []auditlog.AuditLogRecord{
+	{EventID: 1, Time: time.Date(2025, 1, 1, 10, 0, 0, 0, time.FixedZone("CET", 3600)), Event: "Event1", ExStr: "Description1"},
+	{EventID: 2, Time: time.Date(2025, 1, 1, 11, 0, 0, 0, time.UTC), Event: "Event2", ExStr: "Description2"},
+}
+
+func TestParseFormat(t *testing.T) {
+	t.Parallel()
+
+	for in, want := range map[string]export.Format{"": export.FormatCSV, "csv": export.FormatCSV, "JSON": export.FormatJSON, "ndjson": export.FormatNDJSON, "xlsx": export.FormatXLSX} {
+		got, err := export.ParseFormat(in)
+		require.NoError(t, err)
+		require.Equal(t, want, got)
+	}
+
+	_, err := export.ParseFormat("xml")
+	require.ErrorIs(t, err, export.ErrUnknownFormat)
+
+	require.Equal(t, "application/x-ndjson", export.FormatNDJSON.ContentType())
+	require.Equal(t, ".xlsx", export.FormatXLSX.Extension())
+}
+
+func TestExportAuditLogs(t *testing.T) {
 	t.Parallel()
 
-	nowTime := time.Now()
 	tests := []struct {
 		name    string
-		logs    []auditlog.AuditLogRecord
-		want    [][]string
-		wantErr bool
+		opts    export.Options
+		records []auditlog.AuditLogRecord
+		want    string
+		err     error
 	}{
 		{
-			name: "successful export",
-			logs: []auditlog.AuditLogRecord{
-				{EventID: 1, Time: nowTime, Event: "Event1", ExStr: "Description1"},
-				{EventID: 2, Time: nowTime, Event: "Event2", ExStr: "Description2"},
-			},
-			want: [][]string{
-				{"ID", "Time", "Event", "Description"},
-				{"1", nowTime.String(), "Event1", "Description1"},
-				{"2", nowTime.String(), "Event2", "Description2"},
-			},
-			wantErr: false,
+			name:    "csv - selected columns in RFC3339",
+			opts:    export.Options{Format: export.FormatCSV, Columns: []string{"id", "time", "event", "description"}},
+			records: auditRecords,
+			want:    "ID,Time,Event,Description\n1,2025-01-01T09:00:00Z,Event1,Description1\n2,2025-01-01T11:00:00Z,Event2,Description2\n",
+		},
+		{
+			name:    "csv - formulas are quoted",
+			opts:    export.Options{Format: export.FormatCSV, Columns: []string{"id", "event", "description"}},
+			records: []auditlog.AuditLogRecord{{EventID: 3, Event: "+cmd", ExStr: "=1+2"}, {EventID: 4, Event: "@SUM(A1)", ExStr: "-1"}},
+			want:    "ID,Event,Description\n3,'+cmd,'=1+2\n4,'@SUM(A1),'-1\n",
+		},
+		{
+			name: "csv - empty log has a header",
+			opts: export.Options{Columns: []string{"ID", "event"}},
+			want: "ID,Event\n",
+		},
+		{
+			name:    "json",
+			opts:    export.Options{Format: export.FormatJSON, Columns: []string{"id", "time"}},
+			records: auditRecords,
+			want:    `[{"id":1,"time":"2025-01-01T09:00:00Z"},{"id":2,"time":"2025-01-01T11:00:00Z"}]` + "\n",
+		},
+		{
+			name: "json - empty log is an empty array",
+			opts: export.Options{Format: export.FormatJSON},
+			want: "[]\n",
+		},
+		{
+			name:    "ndjson",
+			opts:    export.Options{Format: export.FormatNDJSON, Columns: []string{"event"}},
+			records: auditRecords,
+			want:    `{"event":"Event1"}` + "\n" + `{"event":"Event2"}` + "\n",
+		},
+		{
+			name: "unknown column",
+			opts: export.Options{Columns: []string{"id", "severity"}},
+			err:  export.ErrUnknownColumn,
 		},
 		{
-			name:    "empty logs",
-			logs:    []auditlog.AuditLogRecord{},
-			want:    [][]string{{"ID", "Time", "Event", "Description"}},
-			wantErr: false,
+			name: "unknown format",
+			opts: export.Options{Format: "xml"},
+			err:  export.ErrUnknownFormat,
 		},
 	}
 
 	for _, tc := range tests {
 		tc := tc
+
 		t.Run(tc.name, func(t *testing.T) {
 			t.Parallel()
 
-			exporter := export.NewFileExporter()
-			reader, err := exporter.ExportAuditLogsCSV(tc.logs)
+			var b bytes.Buffer
 
-			if tc.wantErr {
-				assert.Error(t, err)
-			} else {
-				assert.NoError(t, err)
-				assert.NotNil(t, reader)
+			w, err := export.NewFileExporter().AuditLogs(&b, tc.opts)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
 
-				// Read the output and verify the CSV structure
-				csvReader := csv.NewReader(reader)
-				records, err := csvReader.ReadAll()
-				assert.NoError(t, err)
-				assert.Equal(t, tc.want, records)
+				return
 			}
+
+			require.NoError(t, err)
+			require.NoError(t, w.Write(tc.records...))
+			require.NoError(t, w.Close())
+			require.Equal(t, tc.want, b.String())
 		})
 	}
 }
 
-func TestExportEventLogsCSV(t *testing.T) {
+func TestWriterStreams(t *testing.T) {
 	t.Parallel()
 
-	tests := []struct {
-		name    string
-		logs    []dto.EventLog
-		want    [][]string
-		wantErr bool
-	}{
-		{
-			name: "successful export",
-			logs: []dto.EventLog{
-				{Time: "2025-01-01T10:00:00Z", Entity: "Source1", EventSeverity: "High", Description: "Event1 Description"},
-				{Time: "2025-01-02T11:00:00Z", Entity: "Source2", EventSeverity: "Low", Description: "Event2 Description"},
-			},
-			want: [][]string{
-				{"Time", "Source", "Event Severity", "Description"},
-				{"2025-01-01T10:00:00Z", "Source1", "High", "Event1 Description"},
-				{"2025-01-02T11:00:00Z", "Source2", "Low", "Event2 Description"},
-			},
-			wantErr: false,
-		},
-		{
-			name:    "empty logs",
-			logs:    []dto.EventLog{},
-			want:    [][]string{{"Time", "Source", "Event Severity", "Description"}},
-			wantErr: false,
-		},
+	var b bytes.Buffer
+
+	w, err := export.NewFileExporter().AuditLogs(&b, export.Options{Format: export.FormatNDJSON, Columns: []string{"id"}})
+	require.NoError(t, err)
+
+	// nothing is written before the first page, so the caller can still respond with an error
+	require.Zero(t, b.Len())
+
+	require.NoError(t, w.Write(auditRecords[0]))
+	require.Equal(t, "{\"id\":1}\n", b.String())
+
+	require.NoError(t, w.Write(auditRecords[1]))
+	require.NoError(t, w.Close())
+	require.Equal(t, "{\"id\":1}\n{\"id\":2}\n", b.String())
+}
+
+func TestExportEventLogs(t *testing.T) {
+	t.Parallel()
+
+	var b bytes.Buffer
+
+	w, err := export.NewFileExporter().EventLogs(&b, export.Options{Columns: []string{"time", "source", "severity", "description", "eventData"}})
+	require.NoError(t, err)
+
+	require.NoError(t, w.Write(
+		dto.EventLog{Time: "2025-01-01 10:00:00 +0000 UTC", Entity: "Source1", EventSeverity: "High", Description: "Event1 Description", EventData: []int{64, 7}},
+		dto.EventLog{Time: "yesterday", Entity: "Source2", EventSeverity: "Low", Description: "Event2 Description"},
+	))
+	require.NoError(t, w.Close())
+
+	records, err := csv.NewReader(&b).ReadAll()
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{"Time", "Source", "Event Severity", "Description", "Event Data"},
+		{"2025-01-01T10:00:00Z", "Source1", "High", "Event1 Description", "64 7"},
+		{"yesterday", "Source2", "Low", "Event2 Description", ""},
+	}, records)
+}
+
+func TestExportConsoleAudit(t *testing.T) {
+	t.Parallel()
+
+	var b bytes.Buffer
+
+	w, err := export.NewFileExporter().ConsoleAudit(&b, export.Options{})
+	require.NoError(t, err)
+
+	require.NoError(t, w.Write(dto.ConsoleAuditEntry{
+		ID:         "a1",
+		Time:       time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC),
+		Subject:    "alice",
+		Method:     "POST",
+		Route:      "/api/v1/amt/power/action/:guid",
+		Path:       "/api/v1/amt/power/action/guid-1",
+		Target:     "guid-1",
+		Summary:    `{"action":8}`,
+		StatusCode: 200,
+		Result:     dto.AuditResultSuccess,
+		DurationMS: 1250,
+		ClientIP:   "10.0.0.5",
+		TenantID:   "tenant1",
+	}))
+	require.NoError(t, w.Close())
+
+	records, err := csv.NewReader(&b).ReadAll()
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{"Time", "Subject", "Tenant", "Method", "Route", "Path", "Target", "Summary", "Status Code", "Result", "Duration (ms)", "Client IP", "ID"},
+		{"2025-01-01T10:00:00Z", "alice", "tenant1", "POST", "/api/v1/amt/power/action/:guid", "/api/v1/amt/power/action/guid-1", "guid-1", `{"action":8}`, "200", "success", "1250", "10.0.0.5", "a1"},
+	}, records)
+}
+
+func TestExportInventory(t *testing.T) {
+	t.Parallel()
+
+	item := dto.DeviceInventory{
+		GUID:        "guid-1",
+		RefreshedAt: time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC),
+		Firmware:    dto.FirmwareInventory{AMTVersion: "16.1.25", AMTMajor: 16, SKU: "16392"},
+		BIOS:        dto.BIOSInventory{Manufacturer: "Intel Corp.", Version: "ADLPFWI1"},
+		Chassis:     dto.ComponentInventory{Manufacturer: "Intel", Model: "NUC", SerialNumber: "SN1"},
+		Board:       dto.ComponentInventory{Manufacturer: "Intel", Model: "NUC12WSBi7"},
+		Processors:  []dto.ProcessorInventory{{Name: "CPU 0"}, {Name: "CPU 1"}},
+		Memory:      []dto.MemoryInventory{{CapacityBytes: 8 << 30}, {CapacityBytes: 8 << 30}},
+		Disks:       []dto.DiskInventory{{DeviceID: "MEDIA DEV 0"}},
+
+		TotalMemoryBytes: 16 << 30,
+		TenantID:         "tenant1",
 	}
 
-	for _, tc := range tests {
-		tc := tc
-		t.Run(tc.name, func(t *testing.T) {
-			t.Parallel()
+	var b bytes.Buffer
 
-			exporter := export.NewFileExporter()
-			reader, err := exporter.ExportEventLogsCSV(tc.logs)
+	w, err := export.NewFileExporter().Inventory(&b, export.Options{})
+	require.NoError(t, err)
+	require.NoError(t, w.Write(item))
+	require.NoError(t, w.Close())
 
-			if tc.wantErr {
-				assert.Error(t, err)
-			} else {
-				assert.NoError(t, err)
-				assert.NotNil(t, reader)
+	records, err := csv.NewReader(&b).ReadAll()
+	require.NoError(t, err)
+	assert.Len(t, records, 2)
+	assert.Equal(t, []string{
+		"guid-1", "tenant1", "2025-01-01T10:00:00Z", "16.1.25", "16392", "Intel Corp.", "ADLPFWI1",
+		"Intel", "NUC", "SN1", "Intel", "NUC12WSBi7", "CPU 0; CPU 1", "2", "17179869184", "2", "1",
+	}, records[1])
 
-				// Read the output and verify the CSV structure
-				csvReader := csv.NewReader(reader)
-				records, err := csvReader.ReadAll()
-				assert.NoError(t, err)
-				assert.Equal(t, tc.want, records)
-			}
-		})
+	b.Reset()
+
+	w, err = export.NewFileExporter().Inventory(&b, export.Options{Format: export.FormatJSON, Columns: []string{"guid", "processors", "totalMemoryBytes"}})
+	require.NoError(t, err)
+	require.NoError(t, w.Write(item))
+	require.NoError(t, w.Close())
+	assert.JSONEq(t, `[{"guid":"guid-1","processors":["CPU 0","CPU 1"],"totalMemoryBytes":17179869184}]`, b.String())
+}
+
+func TestExportDevices(t *testing.T) {
+	t.Parallel()
+
+	seen := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	var b bytes.Buffer
+
+	w, err := export.NewFileExporter().Devices(&b, export.Options{Format: export.FormatNDJSON})
+	require.NoError(t, err)
+	require.NoError(t, w.Write(
+		dto.Device{GUID: "guid-1", Hostname: "host1", Tags: []string{"lab"}, ConnectionStatus: true, LastSeen: &seen, Password: "P@ssw0rd", DeviceInfo: &dto.DeviceInfo{FWVersion: "16.1.25"}},
+		dto.Device{GUID: "guid-2", Username: "admin", Password: "P@ssw0rd"},
+	))
+	require.NoError(t, w.Close())
+
+	assert.NotContains(t, b.String(), "P@ssw0rd")
+	assert.NotContains(t, b.String(), "admin")
+
+	lines := strings.Split(strings.TrimSpace(b.String()), "\n")
+	require.Len(t, lines, 2)
+
+	var first, second map[string]any
+
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &first))
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &second))
+
+	assert.Equal(t, "host1", first["hostname"])
+	assert.Equal(t, []any{"lab"}, first["tags"])
+	assert.Equal(t, true, first["connectionStatus"])
+	assert.Equal(t, "2025-01-01T10:00:00Z", first["lastSeen"])
+	assert.Equal(t, "16.1.25", first["fwVersion"])
+	assert.Nil(t, second["lastSeen"])
+	assert.Equal(t, "", second["fwVersion"])
+	assert.Equal(t, export.ColumnNames(export.DeviceColumns), keys(t, lines[0]))
+}
+
+func TestExportXLSX(t *testing.T) {
+	t.Parallel()
+
+	var b bytes.Buffer
+
+	w, err := export.NewFileExporter().AuditLogs(&b, export.Options{Format: export.FormatXLSX, Columns: []string{"id", "event", "description"}})
+	require.NoError(t, err)
+	require.NoError(t, w.Write(auditlog.AuditLogRecord{EventID: 7, Event: "Login <failed>", ExStr: "A & B"}))
+	require.NoError(t, w.Close())
+
+	archive, err := zip.NewReader(bytes.NewReader(b.Bytes()), int64(b.Len()))
+	require.NoError(t, err)
+
+	parts := map[string]string{}
+
+	for _, f := range archive.File {
+		r, err := f.Open()
+		require.NoError(t, err)
+
+		content, err := io.ReadAll(r)
+		require.NoError(t, err)
+
+		parts[f.Name] = string(content)
 	}
+
+	require.Contains(t, parts, "[Content_Types].xml")
+	require.Contains(t, parts, "_rels/.rels")
+	require.Contains(t, parts, "xl/workbook.xml")
+	require.Contains(t, parts, "xl/_rels/workbook.xml.rels")
+
+	sheet := parts["xl/worksheets/sheet1.xml"]
+	assert.Contains(t, sheet, `<row r="1"><c r="A1" t="inlineStr"><is><t xml:space="preserve">ID</t></is></c>`)
+	assert.Contains(t, sheet, `<row r="2"><c r="A2"><v>7</v></c><c r="B2" t="inlineStr"><is><t xml:space="preserve">Login &lt;failed&gt;</t></is></c>`)
+	assert.Contains(t, sheet, `A &amp; B`)
+	assert.True(t, strings.HasSuffix(sheet, `</sheetData></worksheet>`))
+}
+
+// keys returns the keys of a JSON object in the order they appear.
+func keys(t *testing.T, object string) []string {
+	t.Helper()
+
+	dec := json.NewDecoder(strings.NewReader(object))
+
+	_, err := dec.Token()
+	require.NoError(t, err)
+
+	var names []string
+
+	for dec.More() {
+		tok, err := dec.Token()
+		require.NoError(t, err)
+
+		names = append(names, tok.(string))
+
+		var skip json.RawMessage
+
+		require.NoError(t, dec.Decode(&skip))
+	}
+
+	return names
 }
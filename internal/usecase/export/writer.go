@@ -0,0 +1,342 @@
+package export
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Column is a field of the records of type T an export can contain.
+type Column[T any] struct {
+	Name   string // key of the field in JSON and the name it is selected by
+	Header string // heading of the field in CSV and XLSX
+	Value  func(*T) any
+}
+
+// ColumnNames returns the names of columns, in order.
+func ColumnNames[T any](columns []Column[T]) []string {
+	names := make([]string, len(columns))
+	for i := range columns {
+		names[i] = columns[i].Name
+	}
+
+	return names
+}
+
+// selectColumns returns the columns named, in the order named, or all columns when none are.
+func selectColumns[T any](columns []Column[T], names []string) ([]Column[T], error) {
+	if len(names) == 0 {
+		return columns, nil
+	}
+
+	selected := make([]Column[T], 0, len(names))
+
+	for _, name := range names {
+		i := -1
+
+		for j := range columns {
+			if strings.EqualFold(columns[j].Name, name) {
+				i = j
+
+				break
+			}
+		}
+
+		if i < 0 {
+			return nil, fmt.Errorf("%w %q, must be one of %s", ErrUnknownColumn, name, strings.Join(ColumnNames(columns), ", "))
+		}
+
+		selected = append(selected, columns[i])
+	}
+
+	return selected, nil
+}
+
+// rowWriter writes the rows of an export in one format.
+type rowWriter interface {
+	begin() error
+	row(values []any) error
+	flush() error
+	end() error
+}
+
+// Writer streams records of type T to an export. Nothing is written until the first call to Write or Close,
+// so a caller can still report an error in place of the export up to then.
+type Writer[T any] struct {
+	out     *bufio.Writer
+	rows    rowWriter
+	columns []Column[T]
+	values  []any
+	started bool
+}
+
+// NewWriter returns a writer of the records to w in the format and with the columns opts select.
+func NewWriter[T any](w io.Writer, columns []Column[T], opts Options) (*Writer[T], error) {
+	format, err := ParseFormat(string(opts.Format))
+	if err != nil {
+		return nil, err
+	}
+
+	selected, err := selectColumns(columns, opts.Columns)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, len(selected))
+	headers := make([]string, len(selected))
+
+	for i := range selected {
+		names[i] = selected[i].Name
+		headers[i] = selected[i].Header
+	}
+
+	out := bufio.NewWriter(w)
+
+	var rows rowWriter
+
+	switch format {
+	case FormatJSON:
+		rows = &jsonRows{out: out, names: names, array: true}
+	case FormatNDJSON:
+		rows = &jsonRows{out: out, names: names}
+	case FormatXLSX:
+		rows = newXLSXRows(out, headers)
+	default:
+		rows = &csvRows{out: csv.NewWriter(out), headers: headers}
+	}
+
+	return &Writer[T]{out: out, rows: rows, columns: selected, values: make([]any, len(selected))}, nil
+}
+
+// Write appends records to the export and flushes them to the underlying writer.
+func (w *Writer[T]) Write(records ...T) error {
+	if err := w.start(); err != nil {
+		return err
+	}
+
+	for i := range records {
+		for j := range w.columns {
+			w.values[j] = w.columns[j].Value(&records[i])
+		}
+
+		if err := w.rows.row(w.values); err != nil {
+			return err
+		}
+	}
+
+	if err := w.rows.flush(); err != nil {
+		return err
+	}
+
+	return w.out.Flush()
+}
+
+// Close completes the export. It does not close the underlying writer.
+func (w *Writer[T]) Close() error {
+	if err := w.start(); err != nil {
+		return err
+	}
+
+	if err := w.rows.end(); err != nil {
+		return err
+	}
+
+	return w.out.Flush()
+}
+
+func (w *Writer[T]) start() error {
+	if w.started {
+		return nil
+	}
+
+	w.started = true
+
+	return w.rows.begin()
+}
+
+// text renders a value for the formats without types of their own. Times are RFC3339 in UTC.
+func text(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case time.Time:
+		if v.IsZero() {
+			return ""
+		}
+
+		return v.UTC().Format(time.RFC3339)
+	case *time.Time:
+		if v == nil {
+			return ""
+		}
+
+		return text(*v)
+	case bool:
+		return strconv.FormatBool(v)
+	case int:
+		return strconv.Itoa(v)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case []string:
+		return strings.Join(v, "; ")
+	case []int:
+		values := make([]string, len(v))
+		for i := range v {
+			values[i] = strconv.Itoa(v[i])
+		}
+
+		return strings.Join(values, " ")
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+type csvRows struct {
+	out     *csv.Writer
+	headers []string
+	record  []string
+}
+
+func (r *csvRows) begin() error {
+	r.record = make([]string, len(r.headers))
+
+	return r.out.Write(r.headers)
+}
+
+func (r *csvRows) row(values []any) error {
+	for i, v := range values {
+		r.record[i] = csvCell(v)
+	}
+
+	return r.out.Write(r.record)
+}
+
+// csvCell is the text of v, quoted with a leading ' when a spreadsheet would otherwise run it as a formula.
+// Numbers are left alone, a negative one is not a formula.
+func csvCell(v any) string {
+	s := text(v)
+
+	switch v.(type) {
+	case int, int64, float64:
+		return s
+	}
+
+	if s != "" && strings.ContainsRune("=+-@\t\r", rune(s[0])) {
+		return "'" + s
+	}
+
+	return s
+}
+
+func (r *csvRows) flush() error {
+	r.out.Flush()
+
+	return r.out.Error()
+}
+
+func (r *csvRows) end() error {
+	return r.flush()
+}
+
+// jsonRows writes each row as an object keyed by column name, either as the elements of one array or one per line.
+type jsonRows struct {
+	out   *bufio.Writer
+	names []string
+	array bool
+	rows  int
+	buf   bytes.Buffer
+}
+
+func (r *jsonRows) begin() error {
+	if r.array {
+		return r.out.WriteByte('[')
+	}
+
+	return nil
+}
+
+func (r *jsonRows) row(values []any) error {
+	r.buf.Reset()
+
+	if r.array && r.rows > 0 {
+		r.buf.WriteByte(',')
+	}
+
+	r.buf.WriteByte('{')
+
+	for i, v := range values {
+		if i > 0 {
+			r.buf.WriteByte(',')
+		}
+
+		key, err := json.Marshal(r.names[i])
+		if err != nil {
+			return err
+		}
+
+		value, err := json.Marshal(jsonValue(v))
+		if err != nil {
+			return err
+		}
+
+		r.buf.Write(key)
+		r.buf.WriteByte(':')
+		r.buf.Write(value)
+	}
+
+	r.buf.WriteByte('}')
+
+	if !r.array {
+		r.buf.WriteByte('\n')
+	}
+
+	r.rows++
+
+	_, err := r.out.Write(r.buf.Bytes())
+
+	return err
+}
+
+func (r *jsonRows) flush() error {
+	return nil
+}
+
+func (r *jsonRows) end() error {
+	if !r.array {
+		return nil
+	}
+
+	_, err := r.out.WriteString("]\n")
+
+	return err
+}
+
+// jsonValue renders times as RFC3339 strings in UTC and missing times as null, other values marshal as they are.
+func jsonValue(v any) any {
+	switch v := v.(type) {
+	case time.Time:
+		if v.IsZero() {
+			return nil
+		}
+
+		return v.UTC().Format(time.RFC3339)
+	case *time.Time:
+		if v == nil {
+			return nil
+		}
+
+		return jsonValue(*v)
+	default:
+		return v
+	}
+}
@@ -0,0 +1,165 @@
+package export
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strconv"
+)
+
+// The parts of a workbook with a single worksheet. Cells hold their strings inline, so the workbook needs no
+// shared string table and the worksheet can be streamed a row at a time.
+const (
+	xlsxContentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+		`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+		`</Types>`
+
+	xlsxRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+		`</Relationships>`
+
+	xlsxWorkbook = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+		`<sheets><sheet name="Export" sheetId="1" r:id="rId1"/></sheets>` +
+		`</workbook>`
+
+	xlsxWorkbookRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+		`</Relationships>`
+
+	xlsxSheetStart = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`
+
+	xlsxSheetEnd = `</sheetData></worksheet>`
+)
+
+// xlsxRows writes an Office Open XML workbook. The header row and text are inline strings, numbers and
+// booleans get cells of their own type.
+type xlsxRows struct {
+	zip     *zip.Writer
+	sheet   io.Writer
+	headers []string
+	rows    int
+	buf     bytes.Buffer
+}
+
+func newXLSXRows(out *bufio.Writer, headers []string) *xlsxRows {
+	return &xlsxRows{zip: zip.NewWriter(out), headers: headers}
+}
+
+func (r *xlsxRows) begin() error {
+	parts := []struct{ name, content string }{
+		{"[Content_Types].xml", xlsxContentTypes},
+		{"_rels/.rels", xlsxRels},
+		{"xl/workbook.xml", xlsxWorkbook},
+		{"xl/_rels/workbook.xml.rels", xlsxWorkbookRels},
+	}
+
+	for _, p := range parts {
+		w, err := r.zip.Create(p.name)
+		if err != nil {
+			return err
+		}
+
+		if _, err = io.WriteString(w, p.content); err != nil {
+			return err
+		}
+	}
+
+	sheet, err := r.zip.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return err
+	}
+
+	r.sheet = sheet
+
+	if _, err = io.WriteString(r.sheet, xlsxSheetStart); err != nil {
+		return err
+	}
+
+	values := make([]any, len(r.headers))
+	for i := range r.headers {
+		values[i] = r.headers[i]
+	}
+
+	return r.row(values)
+}
+
+func (r *xlsxRows) row(values []any) error {
+	r.rows++
+	r.buf.Reset()
+
+	n := strconv.Itoa(r.rows)
+
+	r.buf.WriteString(`<row r="` + n + `">`)
+
+	for i, v := range values {
+		ref := xlsxColumn(i) + n
+
+		switch v := v.(type) {
+		case int:
+			r.buf.WriteString(`<c r="` + ref + `"><v>` + strconv.Itoa(v) + `</v></c>`)
+		case int64:
+			r.buf.WriteString(`<c r="` + ref + `"><v>` + strconv.FormatInt(v, 10) + `</v></c>`)
+		case float64:
+			r.buf.WriteString(`<c r="` + ref + `"><v>` + strconv.FormatFloat(v, 'f', -1, 64) + `</v></c>`)
+		case bool:
+			b := "0"
+			if v {
+				b = "1"
+			}
+
+			r.buf.WriteString(`<c r="` + ref + `" t="b"><v>` + b + `</v></c>`)
+		default:
+			s := text(v)
+			if s == "" {
+				continue
+			}
+
+			r.buf.WriteString(`<c r="` + ref + `" t="inlineStr"><is><t xml:space="preserve">`)
+
+			if err := xml.EscapeText(&r.buf, []byte(s)); err != nil {
+				return err
+			}
+
+			r.buf.WriteString(`</t></is></c>`)
+		}
+	}
+
+	r.buf.WriteString(`</row>`)
+
+	_, err := r.sheet.Write(r.buf.Bytes())
+
+	return err
+}
+
+func (r *xlsxRows) flush() error {
+	return r.zip.Flush()
+}
+
+func (r *xlsxRows) end() error {
+	if _, err := io.WriteString(r.sheet, xlsxSheetEnd); err != nil {
+		return err
+	}
+
+	return r.zip.Close()
+}
+
+// xlsxColumn returns the letters of the i-th column of a worksheet, counted from 0: A to Z, then AA and on.
+func xlsxColumn(i int) string {
+	var letters []byte
+
+	for i++; i > 0; i = (i - 1) / 26 {
+		letters = append([]byte{byte('A' + (i-1)%26)}, letters...)
+	}
+
+	return string(letters)
+}
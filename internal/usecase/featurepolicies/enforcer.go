@@ -0,0 +1,309 @@
+package featurepolicies
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity"
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	dtov2 "github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v2"
+	"github.com/open-amt-cloud-toolkit/console/pkg/tenant"
+)
+
+const tagPageSize = 100
+
+var errProfileNotFound = errors.New("profile not found")
+
+// Run checks the fleet against the policies every interval until ctx is done.
+func (uc *UseCase) Run(ctx context.Context) {
+	if uc.cfg.Interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(uc.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		uc.Check(ctx, time.Now())
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Check compares every device a policy governs with its desired features once, remediating the drift of the
+// policies that ask for it, and waits for the checks to finish.
+func (uc *UseCase) Check(ctx context.Context, now time.Time) {
+	policies, err := uc.repo.GetAllTenants(ctx)
+	if err != nil {
+		uc.log.Error(err, "featurepolicies - Check - uc.repo.GetAllTenants")
+
+		return
+	}
+
+	// a device follows the tag policies before the profile ones, then the policy first by name
+	slices.SortStableFunc(policies, func(a, b entity.FeaturePolicy) int {
+		if a.Scope != b.Scope {
+			if a.Scope == dto.FeaturePolicyScopeTag {
+				return -1
+			}
+
+			return 1
+		}
+
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	governed := make(map[string]bool)
+	complete := true
+	sem := make(chan struct{}, uc.cfg.Workers)
+
+	var wg sync.WaitGroup
+
+	for i := range policies {
+		p := &policies[i]
+
+		desired, guids, err := uc.resolve(ctx, p)
+		if err != nil {
+			uc.log.Error(err, "featurepolicies - Check - uc.resolve - policy "+p.Name)
+
+			complete = false
+
+			continue
+		}
+
+		for _, guid := range guids {
+			if key := p.TenantID + "/" + guid; !governed[key] {
+				governed[key] = true
+
+				select {
+				case <-ctx.Done():
+					wg.Wait()
+
+					return
+				case sem <- struct{}{}:
+				}
+
+				wg.Add(1)
+
+				go func(guid string) {
+					defer func() {
+						<-sem
+						wg.Done()
+					}()
+
+					uc.enforce(ctx, p, desired, guid, now)
+				}(guid)
+			}
+		}
+	}
+
+	wg.Wait()
+
+	// a policy that could not be resolved keeps the drift of its devices until the next check
+	if complete {
+		if err := uc.repo.DeleteStaleDrift(ctx, formatTime(now)); err != nil {
+			uc.log.Error(err, "featurepolicies - Check - uc.repo.DeleteStaleDrift")
+		}
+	}
+}
+
+// resolve works out the desired features of a policy and the devices it governs.
+func (uc *UseCase) resolve(ctx context.Context, p *entity.FeaturePolicy) (dto.DesiredFeatures, []string, error) {
+	desired := dto.DesiredFeatures{}
+	if err := json.Unmarshal([]byte(p.Desired), &desired); err != nil {
+		return desired, nil, err
+	}
+
+	tags := p.Target
+
+	if p.Scope == dto.FeaturePolicyScopeProfile {
+		profile, err := uc.profiles.GetByName(ctx, p.Target, p.TenantID)
+		if err != nil {
+			return desired, nil, err
+		}
+
+		if profile == nil {
+			return desired, nil, errProfileNotFound
+		}
+
+		desired = withProfileDefaults(desired, profile)
+
+		if len(profile.Tags) == 0 {
+			return desired, nil, nil
+		}
+
+		tags = strings.Join(profile.Tags, ",")
+	}
+
+	guids := make([]string, 0)
+
+	for offset := 0; ; offset += tagPageSize {
+		data, err := uc.devices.GetByTags(ctx, tags, "OR", tagPageSize, offset, p.TenantID)
+		if err != nil {
+			return desired, nil, err
+		}
+
+		for i := range data {
+			guids = append(guids, data[i].GUID)
+		}
+
+		if len(data) < tagPageSize {
+			return desired, guids, nil
+		}
+	}
+}
+
+// withProfileDefaults fills the features a profile policy leaves out from the profile's own settings.
+func withProfileDefaults(desired dto.DesiredFeatures, profile *dto.Profile) dto.DesiredFeatures {
+	if desired.UserConsent == nil && profile.UserConsent != "" {
+		consent := strings.ToLower(profile.UserConsent)
+		desired.UserConsent = &consent
+	}
+
+	if desired.EnableSOL == nil {
+		desired.EnableSOL = &profile.SOLEnabled
+	}
+
+	if desired.EnableIDER == nil {
+		desired.EnableIDER = &profile.IDEREnabled
+	}
+
+	if desired.EnableKVM == nil {
+		desired.EnableKVM = &profile.KVMEnabled
+	}
+
+	return desired
+}
+
+// enforce checks a device against its policy and records the outcome, setting its features first when the
+// policy remediates.
+func (uc *UseCase) enforce(ctx context.Context, p *entity.FeaturePolicy, desired dto.DesiredFeatures, guid string, now time.Time) {
+	// the policy may only reach devices of the tenant that defined it
+	ctx = tenant.NewContext(ctx, p.TenantID)
+
+	drift := &entity.FeatureDrift{
+		GUID:       guid,
+		PolicyName: p.Name,
+		CheckedAt:  formatTime(now),
+		TenantID:   p.TenantID,
+	}
+
+	_, actual, err := uc.devices.GetFeatures(ctx, guid)
+	if err != nil {
+		drift.Error = err.Error()
+	} else {
+		fields := compare(desired, &actual)
+
+		if len(fields) > 0 && p.Remediate {
+			after, err := uc.remediate(ctx, p, desired, guid, actual)
+			if err != nil {
+				drift.Error = err.Error()
+			} else {
+				actual = after
+				fields = compare(desired, &actual)
+			}
+		}
+
+		drift.Drifted = len(fields) > 0
+		drift.Fields = strings.Join(fields, ",")
+		drift.Actual = formatFeatures(&actual)
+	}
+
+	if err := uc.repo.UpsertDrift(ctx, drift); err != nil {
+		uc.log.Error(err, "featurepolicies - enforce - uc.repo.UpsertDrift")
+	}
+}
+
+// remediate sets the desired features on a device, keeping those the policy leaves out, and records the
+// features before and after.
+func (uc *UseCase) remediate(ctx context.Context, p *entity.FeaturePolicy, desired dto.DesiredFeatures, guid string, before dtov2.Features) (dtov2.Features, error) {
+	features := dto.Features{
+		UserConsent: before.UserConsent,
+		EnableSOL:   before.EnableSOL,
+		EnableIDER:  before.EnableIDER,
+		EnableKVM:   before.EnableKVM,
+	}
+
+	if desired.UserConsent != nil {
+		features.UserConsent = *desired.UserConsent
+	}
+
+	// turning the listener off turns off every feature that needs it
+	if desired.Redirection != nil && !*desired.Redirection {
+		features.EnableSOL, features.EnableIDER, features.EnableKVM = false, false, false
+	}
+
+	if desired.EnableSOL != nil {
+		features.EnableSOL = *desired.EnableSOL
+	}
+
+	if desired.EnableIDER != nil {
+		features.EnableIDER = *desired.EnableIDER
+	}
+
+	if desired.EnableKVM != nil {
+		features.EnableKVM = *desired.EnableKVM
+	}
+
+	remediation := &entity.FeatureRemediation{
+		ID:           uuid.New().String(),
+		GUID:         guid,
+		PolicyName:   p.Name,
+		RemediatedAt: formatTime(time.Now()),
+		Before:       formatFeatures(&before),
+		TenantID:     p.TenantID,
+	}
+
+	_, after, err := uc.devices.SetFeatures(ctx, guid, features)
+	if err != nil {
+		remediation.Error = err.Error()
+	} else {
+		// SetFeatures does not read the opt-in state back
+		after.OptInState = before.OptInState
+		remediation.After = formatFeatures(&after)
+	}
+
+	if err := uc.repo.InsertRemediation(ctx, remediation); err != nil {
+		uc.log.Error(err, "featurepolicies - remediate - uc.repo.InsertRemediation")
+	}
+
+	return after, err
+}
+
+// compare returns the desired features a device does not have. KVM is not held against a device without it.
+func compare(desired dto.DesiredFeatures, actual *dtov2.Features) []string {
+	fields := make([]string, 0)
+
+	if desired.UserConsent != nil && !strings.EqualFold(*desired.UserConsent, actual.UserConsent) {
+		fields = append(fields, "userConsent")
+	}
+
+	if desired.EnableSOL != nil && *desired.EnableSOL != actual.EnableSOL {
+		fields = append(fields, "enableSOL")
+	}
+
+	if desired.EnableIDER != nil && *desired.EnableIDER != actual.EnableIDER {
+		fields = append(fields, "enableIDER")
+	}
+
+	if desired.EnableKVM != nil && actual.KVMAvailable && *desired.EnableKVM != actual.EnableKVM {
+		fields = append(fields, "enableKVM")
+	}
+
+	if desired.Redirection != nil && *desired.Redirection != actual.Redirection {
+		fields = append(fields, "redirection")
+	}
+
+	return fields
+}
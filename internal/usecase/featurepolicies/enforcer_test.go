@@ -0,0 +1,158 @@
+package featurepolicies_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity"
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	dtov2 "github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v2"
+	"github.com/open-amt-cloud-toolkit/console/pkg/tenant"
+)
+
+var checkedAt = time.Date(2024, 12, 1, 0, 15, 0, 0, time.UTC)
+
+// driftRecorder keeps the drift the checks upsert, which run concurrently.
+type driftRecorder struct {
+	mu      sync.Mutex
+	drift   map[string]*entity.FeatureDrift
+	tenants map[string]string
+}
+
+func newDriftRecorder() *driftRecorder {
+	return &driftRecorder{drift: make(map[string]*entity.FeatureDrift), tenants: make(map[string]string)}
+}
+
+func (r *driftRecorder) upsert(ctx context.Context, d *entity.FeatureDrift) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.drift[d.GUID] = d
+	r.tenants[d.GUID] = tenant.FromContext(ctx)
+
+	return nil
+}
+
+func TestCheckRemediatesDrift(t *testing.T) {
+	t.Parallel()
+
+	pt := initPoliciesTest(t)
+	recorder := newDriftRecorder()
+
+	pt.repo.EXPECT().GetAllTenants(gomock.Any()).Return([]entity.FeaturePolicy{
+		{Name: "lab", Scope: "tag", Target: "lab", Desired: `{"userConsent":"none","enableKVM":true}`, Remediate: true, TenantID: "tenant1"},
+	}, nil)
+	pt.devices.EXPECT().GetByTags(gomock.Any(), "lab", "OR", 100, 0, "tenant1").Return([]dto.Device{{GUID: "guid1"}, {GUID: "guid2"}}, nil)
+
+	// guid1 drifted and is set back, guid2 already follows the policy
+	pt.devices.EXPECT().GetFeatures(gomock.Any(), "guid1").Return(dto.Features{}, dtov2.Features{
+		UserConsent: "all", EnableSOL: true, Redirection: true, OptInState: 2, KVMAvailable: true,
+	}, nil)
+	pt.devices.EXPECT().SetFeatures(gomock.Any(), "guid1", dto.Features{UserConsent: "none", EnableSOL: true, EnableKVM: true}).Return(dto.Features{}, dtov2.Features{
+		UserConsent: "none", EnableSOL: true, EnableKVM: true, Redirection: true, KVMAvailable: true,
+	}, nil)
+	pt.devices.EXPECT().GetFeatures(gomock.Any(), "guid2").Return(dto.Features{}, dtov2.Features{
+		UserConsent: "none", EnableKVM: true, Redirection: true, KVMAvailable: true,
+	}, nil)
+
+	var remediation *entity.FeatureRemediation
+
+	pt.repo.EXPECT().InsertRemediation(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, r *entity.FeatureRemediation) error {
+		remediation = r
+
+		return nil
+	})
+	pt.repo.EXPECT().UpsertDrift(gomock.Any(), gomock.Any()).DoAndReturn(recorder.upsert).Times(2)
+	pt.repo.EXPECT().DeleteStaleDrift(gomock.Any(), "2024-12-01T00:15:00Z").Return(nil)
+
+	pt.uc.Check(context.Background(), checkedAt)
+
+	require.NotNil(t, remediation)
+	require.Equal(t, "guid1", remediation.GUID)
+	require.Equal(t, "lab", remediation.PolicyName)
+	require.Equal(t, `{"userConsent":"all","enableSOL":true,"enableIDER":false,"enableKVM":false,"redirection":true,"optInState":2,"kvmAvailable":true}`, remediation.Before)
+	require.Equal(t, `{"userConsent":"none","enableSOL":true,"enableIDER":false,"enableKVM":true,"redirection":true,"optInState":2,"kvmAvailable":true}`, remediation.After)
+	require.Empty(t, remediation.Error)
+
+	require.False(t, recorder.drift["guid1"].Drifted)
+	require.False(t, recorder.drift["guid2"].Drifted)
+	require.Equal(t, "2024-12-01T00:15:00Z", recorder.drift["guid1"].CheckedAt)
+	require.Equal(t, "tenant1", recorder.tenants["guid1"])
+}
+
+func TestCheckReportsDrift(t *testing.T) {
+	t.Parallel()
+
+	pt := initPoliciesTest(t)
+	recorder := newDriftRecorder()
+
+	pt.repo.EXPECT().GetAllTenants(gomock.Any()).Return([]entity.FeaturePolicy{
+		// the profile policy is listed first but a device follows its tag policy
+		{Name: "office", Scope: "profile", Target: "office", Desired: `{}`, TenantID: "tenant1"},
+		{Name: "lab", Scope: "tag", Target: "lab", Desired: `{"enableSOL":false}`, TenantID: "tenant1"},
+	}, nil)
+	pt.profiles.EXPECT().GetByName(gomock.Any(), "office", "tenant1").Return(&dto.Profile{
+		ProfileName: "office", Tags: []string{"office", "lab"}, UserConsent: "All", SOLEnabled: true, IDEREnabled: true,
+	}, nil)
+	pt.devices.EXPECT().GetByTags(gomock.Any(), "lab", "OR", 100, 0, "tenant1").Return([]dto.Device{{GUID: "guid1"}}, nil)
+	pt.devices.EXPECT().GetByTags(gomock.Any(), "office,lab", "OR", 100, 0, "tenant1").Return([]dto.Device{{GUID: "guid1"}, {GUID: "guid2"}}, nil)
+
+	pt.devices.EXPECT().GetFeatures(gomock.Any(), "guid1").Return(dto.Features{}, dtov2.Features{
+		UserConsent: "all", EnableSOL: true, EnableIDER: true, Redirection: true, KVMAvailable: true,
+	}, nil)
+	// KVM is not held against a device without it
+	pt.devices.EXPECT().GetFeatures(gomock.Any(), "guid2").Return(dto.Features{}, dtov2.Features{
+		UserConsent: "kvm", EnableSOL: true, Redirection: true,
+	}, nil)
+	pt.repo.EXPECT().UpsertDrift(gomock.Any(), gomock.Any()).DoAndReturn(recorder.upsert).Times(2)
+	pt.repo.EXPECT().DeleteStaleDrift(gomock.Any(), "2024-12-01T00:15:00Z").Return(nil)
+
+	pt.uc.Check(context.Background(), checkedAt)
+
+	require.Equal(t, "lab", recorder.drift["guid1"].PolicyName)
+	require.True(t, recorder.drift["guid1"].Drifted)
+	require.Equal(t, "enableSOL", recorder.drift["guid1"].Fields)
+
+	require.Equal(t, "office", recorder.drift["guid2"].PolicyName)
+	require.True(t, recorder.drift["guid2"].Drifted)
+	require.Equal(t, "userConsent,enableIDER", recorder.drift["guid2"].Fields)
+}
+
+func TestCheckKeepsDriftOfUnresolvedPolicy(t *testing.T) {
+	t.Parallel()
+
+	pt := initPoliciesTest(t)
+
+	pt.repo.EXPECT().GetAllTenants(gomock.Any()).Return([]entity.FeaturePolicy{
+		{Name: "office", Scope: "profile", Target: "office", Desired: `{}`, TenantID: "tenant1"},
+	}, nil)
+	pt.profiles.EXPECT().GetByName(gomock.Any(), "office", "tenant1").Return(nil, errDB)
+
+	// no DeleteStaleDrift: the drift of the policy's devices stays until it resolves again
+	pt.uc.Check(context.Background(), checkedAt)
+}
+
+func TestCheckRecordsUnreachableDevice(t *testing.T) {
+	t.Parallel()
+
+	pt := initPoliciesTest(t)
+	recorder := newDriftRecorder()
+
+	pt.repo.EXPECT().GetAllTenants(gomock.Any()).Return([]entity.FeaturePolicy{
+		{Name: "lab", Scope: "tag", Target: "lab", Desired: `{"enableKVM":true}`, Remediate: true, TenantID: "tenant1"},
+	}, nil)
+	pt.devices.EXPECT().GetByTags(gomock.Any(), "lab", "OR", 100, 0, "tenant1").Return([]dto.Device{{GUID: "guid1"}}, nil)
+	pt.devices.EXPECT().GetFeatures(gomock.Any(), "guid1").Return(dto.Features{}, dtov2.Features{}, errDB)
+	pt.repo.EXPECT().UpsertDrift(gomock.Any(), gomock.Any()).DoAndReturn(recorder.upsert)
+	pt.repo.EXPECT().DeleteStaleDrift(gomock.Any(), gomock.Any()).Return(nil)
+
+	pt.uc.Check(context.Background(), checkedAt)
+
+	require.False(t, recorder.drift["guid1"].Drifted)
+	require.Equal(t, errDB.Error(), recorder.drift["guid1"].Error)
+}
@@ -0,0 +1,47 @@
+package featurepolicies
+
+import (
+	"context"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity"
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	dtov2 "github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v2"
+)
+
+type (
+	Repository interface {
+		GetCount(ctx context.Context, tenantID string) (int, error)
+		Get(ctx context.Context, top, skip int, tenantID string) ([]entity.FeaturePolicy, error)
+		GetByName(ctx context.Context, name, tenantID string) (*entity.FeaturePolicy, error)
+		GetAllTenants(ctx context.Context) ([]entity.FeaturePolicy, error)
+		Delete(ctx context.Context, name, tenantID string) (bool, error)
+		Update(ctx context.Context, p *entity.FeaturePolicy) (bool, error)
+		Insert(ctx context.Context, p *entity.FeaturePolicy) (string, error)
+		UpsertDrift(ctx context.Context, d *entity.FeatureDrift) error
+		GetDrift(ctx context.Context, policyName string, top, skip int, tenantID string) ([]entity.FeatureDrift, error)
+		DeleteStaleDrift(ctx context.Context, checkedAt string) error
+		InsertRemediation(ctx context.Context, r *entity.FeatureRemediation) error
+		GetRemediations(ctx context.Context, policyName string, top, skip int, tenantID string) ([]entity.FeatureRemediation, error)
+	}
+	Feature interface {
+		GetCount(ctx context.Context, tenantID string) (int, error)
+		Get(ctx context.Context, top, skip int, tenantID string) ([]dto.FeaturePolicy, error)
+		GetByName(ctx context.Context, name, tenantID string) (*dto.FeaturePolicy, error)
+		Delete(ctx context.Context, name, tenantID string) error
+		Update(ctx context.Context, p *dto.FeaturePolicy) (*dto.FeaturePolicy, error)
+		Insert(ctx context.Context, p *dto.FeaturePolicy) (*dto.FeaturePolicy, error)
+		GetDrift(ctx context.Context, name string, top, skip int, tenantID string) ([]dto.FeatureDrift, error)
+		GetRemediations(ctx context.Context, name string, top, skip int, tenantID string) ([]dto.FeatureRemediation, error)
+		Run(ctx context.Context)
+	}
+	// Devices is the part of the device management feature a policy reads and sets features with.
+	Devices interface {
+		GetByTags(ctx context.Context, tags, method string, limit, offset int, tenantID string) ([]dto.Device, error)
+		GetFeatures(ctx context.Context, guid string) (dto.Features, dtov2.Features, error)
+		SetFeatures(ctx context.Context, guid string, features dto.Features) (dto.Features, dtov2.Features, error)
+	}
+	// Profiles finds the profile a profile policy takes its tags and defaults from.
+	Profiles interface {
+		GetByName(ctx context.Context, profileName, tenantID string) (*dto.Profile, error)
+	}
+)
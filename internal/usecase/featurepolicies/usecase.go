@@ -0,0 +1,353 @@
+package featurepolicies
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity"
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	dtov2 "github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v2"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/sqldb"
+	"github.com/open-amt-cloud-toolkit/console/pkg/consoleerrors"
+	"github.com/open-amt-cloud-toolkit/console/pkg/logger"
+)
+
+// Config controls how often the fleet is checked against the policies.
+type Config struct {
+	// Interval between two checks of the fleet, zero disables them.
+	Interval time.Duration
+	// Workers caps the number of devices checked at once.
+	Workers int
+}
+
+// UseCase -.
+type UseCase struct {
+	repo     Repository
+	devices  Devices
+	profiles Profiles
+	log      logger.Interface
+	cfg      Config
+}
+
+// New -.
+func New(r Repository, d Devices, p Profiles, log logger.Interface, cfg Config) *UseCase {
+	if cfg.Workers <= 0 {
+		cfg.Workers = 1
+	}
+
+	return &UseCase{
+		repo:     r,
+		devices:  d,
+		profiles: p,
+		log:      log,
+		cfg:      cfg,
+	}
+}
+
+var (
+	ErrFeaturePoliciesUseCase = consoleerrors.CreateConsoleError("FeaturePoliciesUseCase")
+	ErrDatabase               = sqldb.DatabaseError{Console: ErrFeaturePoliciesUseCase}
+	ErrNotFound               = sqldb.NotFoundError{Console: ErrFeaturePoliciesUseCase}
+	ErrNotValid               = dto.NotValidError{Console: ErrFeaturePoliciesUseCase}
+
+	errScope           = errors.New("scope must be tag or profile")
+	errTarget          = errors.New("target is required")
+	errUserConsent     = errors.New("userConsent must be none, kvm or all")
+	errNothingDesired  = errors.New("a tag policy needs at least one desired feature")
+	errRedirectionOff  = errors.New("redirection cannot be off while SOL, IDER or KVM is on")
+	errRedirectionNone = errors.New("redirection cannot be on while SOL, IDER and KVM are all off")
+)
+
+func (uc *UseCase) GetCount(ctx context.Context, tenantID string) (int, error) {
+	count, err := uc.repo.GetCount(ctx, tenantID)
+	if err != nil {
+		return 0, ErrDatabase.Wrap("GetCount", "uc.repo.GetCount", err)
+	}
+
+	return count, nil
+}
+
+func (uc *UseCase) Get(ctx context.Context, top, skip int, tenantID string) ([]dto.FeaturePolicy, error) {
+	data, err := uc.repo.Get(ctx, top, skip, tenantID)
+	if err != nil {
+		return nil, ErrDatabase.Wrap("Get", "uc.repo.Get", err)
+	}
+
+	d1 := make([]dto.FeaturePolicy, len(data))
+
+	for i := range data {
+		tmpEntity := data[i] // create a new variable to avoid memory aliasing
+		d1[i] = *uc.entityToDTO(&tmpEntity)
+	}
+
+	return d1, nil
+}
+
+func (uc *UseCase) GetByName(ctx context.Context, name, tenantID string) (*dto.FeaturePolicy, error) {
+	data, err := uc.repo.GetByName(ctx, name, tenantID)
+	if err != nil {
+		return nil, ErrDatabase.Wrap("GetByName", "uc.repo.GetByName", err)
+	}
+
+	if data == nil {
+		return nil, ErrNotFound
+	}
+
+	return uc.entityToDTO(data), nil
+}
+
+func (uc *UseCase) Delete(ctx context.Context, name, tenantID string) error {
+	isSuccessful, err := uc.repo.Delete(ctx, name, tenantID)
+	if err != nil {
+		return ErrDatabase.Wrap("Delete", "uc.repo.Delete", err)
+	}
+
+	if !isSuccessful {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+func (uc *UseCase) Update(ctx context.Context, d *dto.FeaturePolicy) (*dto.FeaturePolicy, error) {
+	d1, err := dtoToEntity(d)
+	if err != nil {
+		return nil, ErrNotValid.Wrap("Update", "dtoToEntity", err)
+	}
+
+	updated, err := uc.repo.Update(ctx, d1)
+	if err != nil {
+		return nil, ErrDatabase.Wrap("Update", "uc.repo.Update", err)
+	}
+
+	if !updated {
+		return nil, ErrNotFound
+	}
+
+	return uc.GetByName(ctx, d.Name, d.TenantID)
+}
+
+func (uc *UseCase) Insert(ctx context.Context, d *dto.FeaturePolicy) (*dto.FeaturePolicy, error) {
+	d1, err := dtoToEntity(d)
+	if err != nil {
+		return nil, ErrNotValid.Wrap("Insert", "dtoToEntity", err)
+	}
+
+	d1.CreationDate = formatTime(time.Now())
+
+	name, err := uc.repo.Insert(ctx, d1)
+	if err != nil {
+		return nil, ErrDatabase.Wrap("Insert", "uc.repo.Insert", err)
+	}
+
+	return uc.GetByName(ctx, name, d.TenantID)
+}
+
+func (uc *UseCase) GetDrift(ctx context.Context, name string, top, skip int, tenantID string) ([]dto.FeatureDrift, error) {
+	if _, err := uc.GetByName(ctx, name, tenantID); err != nil {
+		return nil, err
+	}
+
+	data, err := uc.repo.GetDrift(ctx, name, top, skip, tenantID)
+	if err != nil {
+		return nil, ErrDatabase.Wrap("GetDrift", "uc.repo.GetDrift", err)
+	}
+
+	drift := make([]dto.FeatureDrift, len(data))
+
+	for i := range data {
+		drift[i] = dto.FeatureDrift{
+			GUID:       data[i].GUID,
+			PolicyName: data[i].PolicyName,
+			Drifted:    data[i].Drifted,
+			Fields:     splitList(data[i].Fields),
+			Actual:     parseFeatures(data[i].Actual),
+			Error:      data[i].Error,
+			TenantID:   data[i].TenantID,
+		}
+
+		if checked := parseTime(data[i].CheckedAt); checked != nil {
+			drift[i].CheckedAt = *checked
+		}
+	}
+
+	return drift, nil
+}
+
+func (uc *UseCase) GetRemediations(ctx context.Context, name string, top, skip int, tenantID string) ([]dto.FeatureRemediation, error) {
+	if _, err := uc.GetByName(ctx, name, tenantID); err != nil {
+		return nil, err
+	}
+
+	data, err := uc.repo.GetRemediations(ctx, name, top, skip, tenantID)
+	if err != nil {
+		return nil, ErrDatabase.Wrap("GetRemediations", "uc.repo.GetRemediations", err)
+	}
+
+	remediations := make([]dto.FeatureRemediation, len(data))
+
+	for i := range data {
+		remediations[i] = dto.FeatureRemediation{
+			ID:         data[i].ID,
+			GUID:       data[i].GUID,
+			PolicyName: data[i].PolicyName,
+			After:      parseFeatures(data[i].After),
+			Error:      data[i].Error,
+			TenantID:   data[i].TenantID,
+		}
+
+		if before := parseFeatures(data[i].Before); before != nil {
+			remediations[i].Before = *before
+		}
+
+		if remediated := parseTime(data[i].RemediatedAt); remediated != nil {
+			remediations[i].RemediatedAt = *remediated
+		}
+	}
+
+	return remediations, nil
+}
+
+// validate checks what the binding cannot: the scope, the user consent and that the desired redirection
+// listener agrees with the features that need it.
+func validate(d *dto.FeaturePolicy) error {
+	if d.Scope != dto.FeaturePolicyScopeTag && d.Scope != dto.FeaturePolicyScopeProfile {
+		return errScope
+	}
+
+	if strings.TrimSpace(d.Target) == "" {
+		return errTarget
+	}
+
+	desired := d.Desired
+
+	if desired.UserConsent != nil {
+		consent := strings.ToLower(*desired.UserConsent)
+		if consent != "none" && consent != "kvm" && consent != "all" {
+			return errUserConsent
+		}
+
+		desired.UserConsent = &consent
+	}
+
+	features := []*bool{desired.EnableSOL, desired.EnableIDER, desired.EnableKVM}
+
+	if d.Scope == dto.FeaturePolicyScopeTag && desired.UserConsent == nil && desired.Redirection == nil &&
+		features[0] == nil && features[1] == nil && features[2] == nil {
+		return errNothingDesired
+	}
+
+	if desired.Redirection != nil {
+		anyOn, allOff := false, true
+
+		for _, f := range features {
+			anyOn = anyOn || (f != nil && *f)
+			allOff = allOff && f != nil && !*f
+		}
+
+		if !*desired.Redirection && anyOn {
+			return errRedirectionOff
+		}
+
+		if *desired.Redirection && allOff {
+			return errRedirectionNone
+		}
+	}
+
+	d.Desired = desired
+
+	return nil
+}
+
+// convert dto.FeaturePolicy to entity.FeaturePolicy.
+func dtoToEntity(d *dto.FeaturePolicy) (*entity.FeaturePolicy, error) {
+	if err := validate(d); err != nil {
+		return nil, err
+	}
+
+	desired, err := json.Marshal(d.Desired)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.FeaturePolicy{
+		Name:      d.Name,
+		Scope:     d.Scope,
+		Target:    d.Target,
+		Desired:   string(desired),
+		Remediate: d.Remediate,
+		TenantID:  d.TenantID,
+	}, nil
+}
+
+// convert entity.FeaturePolicy to dto.FeaturePolicy.
+func (uc *UseCase) entityToDTO(d *entity.FeaturePolicy) *dto.FeaturePolicy {
+	d1 := &dto.FeaturePolicy{
+		Name:      d.Name,
+		Scope:     d.Scope,
+		Target:    d.Target,
+		Remediate: d.Remediate,
+		TenantID:  d.TenantID,
+	}
+
+	if created := parseTime(d.CreationDate); created != nil {
+		d1.CreationDate = *created
+	}
+
+	if err := json.Unmarshal([]byte(d.Desired), &d1.Desired); err != nil {
+		uc.log.Warn("featurepolicies - entityToDTO - policy %s has unreadable desired features: %s", d.Name, err.Error())
+	}
+
+	return d1
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
+
+func parseTime(s string) *time.Time {
+	if s == "" {
+		return nil
+	}
+
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return nil
+	}
+
+	return &t
+}
+
+func formatFeatures(f *dtov2.Features) string {
+	if f == nil {
+		return ""
+	}
+
+	data, _ := json.Marshal(f)
+
+	return string(data)
+}
+
+func parseFeatures(s string) *dtov2.Features {
+	if s == "" {
+		return nil
+	}
+
+	f := &dtov2.Features{}
+	if err := json.Unmarshal([]byte(s), f); err != nil {
+		return nil
+	}
+
+	return f
+}
+
+func splitList(s string) []string {
+	if s == "" {
+		return nil
+	}
+
+	return strings.Split(s, ",")
+}
@@ -0,0 +1,268 @@
+package featurepolicies_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity"
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	dtov2 "github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v2"
+	"github.com/open-amt-cloud-toolkit/console/internal/mocks"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/featurepolicies"
+	"github.com/open-amt-cloud-toolkit/console/pkg/logger"
+)
+
+var errDB = errors.New("database error")
+
+type policiesTest struct {
+	uc       *featurepolicies.UseCase
+	repo     *mocks.MockFeaturePoliciesRepository
+	devices  *mocks.MockFeaturePoliciesDevices
+	profiles *mocks.MockFeaturePoliciesProfiles
+}
+
+func initPoliciesTest(t *testing.T) policiesTest {
+	t.Helper()
+
+	mockCtl := gomock.NewController(t)
+
+	pt := policiesTest{
+		repo:     mocks.NewMockFeaturePoliciesRepository(mockCtl),
+		devices:  mocks.NewMockFeaturePoliciesDevices(mockCtl),
+		profiles: mocks.NewMockFeaturePoliciesProfiles(mockCtl),
+	}
+
+	pt.uc = featurepolicies.New(pt.repo, pt.devices, pt.profiles, logger.New("error"), featurepolicies.Config{Workers: 2})
+
+	return pt
+}
+
+func boolPtr(b bool) *bool { return &b }
+
+func stringPtr(s string) *string { return &s }
+
+func TestGetByName(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		mock func(*mocks.MockFeaturePoliciesRepository)
+		res  *dto.FeaturePolicy
+		err  error
+	}{
+		{
+			name: "success",
+			mock: func(repo *mocks.MockFeaturePoliciesRepository) {
+				repo.EXPECT().GetByName(context.Background(), "lab-kvm", "tenant1").Return(&entity.FeaturePolicy{
+					Name:         "lab-kvm",
+					Scope:        "tag",
+					Target:       "lab",
+					Desired:      `{"enableKVM":true}`,
+					Remediate:    true,
+					CreationDate: "2024-12-01T00:00:00Z",
+					TenantID:     "tenant1",
+				}, nil)
+			},
+			res: &dto.FeaturePolicy{
+				Name:         "lab-kvm",
+				Scope:        "tag",
+				Target:       "lab",
+				Desired:      dto.DesiredFeatures{EnableKVM: boolPtr(true)},
+				Remediate:    true,
+				CreationDate: time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC),
+				TenantID:     "tenant1",
+			},
+		},
+		{
+			name: "not found",
+			mock: func(repo *mocks.MockFeaturePoliciesRepository) {
+				repo.EXPECT().GetByName(context.Background(), "lab-kvm", "tenant1").Return(nil, nil)
+			},
+			err: featurepolicies.ErrNotFound,
+		},
+		{
+			name: "database error",
+			mock: func(repo *mocks.MockFeaturePoliciesRepository) {
+				repo.EXPECT().GetByName(context.Background(), "lab-kvm", "tenant1").Return(nil, errDB)
+			},
+			err: featurepolicies.ErrDatabase,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			pt := initPoliciesTest(t)
+
+			tc.mock(pt.repo)
+
+			res, err := pt.uc.GetByName(context.Background(), "lab-kvm", "tenant1")
+			if tc.err != nil {
+				require.IsType(t, tc.err, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.res, res)
+		})
+	}
+}
+
+func TestInsert(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		policy  dto.FeaturePolicy
+		desired string
+		err     error
+	}{
+		{
+			name: "tag policy",
+			policy: dto.FeaturePolicy{
+				Name: "lab", Scope: "tag", Target: "lab",
+				Desired: dto.DesiredFeatures{UserConsent: stringPtr("KVM"), EnableKVM: boolPtr(true), Redirection: boolPtr(true)},
+			},
+			desired: `{"userConsent":"kvm","enableKVM":true,"redirection":true}`,
+		},
+		{
+			name:    "profile policy without desired features",
+			policy:  dto.FeaturePolicy{Name: "office", Scope: "profile", Target: "office"},
+			desired: `{}`,
+		},
+		{
+			name:   "unknown scope",
+			policy: dto.FeaturePolicy{Name: "lab", Scope: "device", Target: "lab", Desired: dto.DesiredFeatures{EnableKVM: boolPtr(true)}},
+			err:    featurepolicies.ErrNotValid,
+		},
+		{
+			name:   "missing target",
+			policy: dto.FeaturePolicy{Name: "lab", Scope: "tag", Desired: dto.DesiredFeatures{EnableKVM: boolPtr(true)}},
+			err:    featurepolicies.ErrNotValid,
+		},
+		{
+			name:   "unknown user consent",
+			policy: dto.FeaturePolicy{Name: "lab", Scope: "tag", Target: "lab", Desired: dto.DesiredFeatures{UserConsent: stringPtr("sometimes")}},
+			err:    featurepolicies.ErrNotValid,
+		},
+		{
+			name:   "tag policy without desired features",
+			policy: dto.FeaturePolicy{Name: "lab", Scope: "tag", Target: "lab"},
+			err:    featurepolicies.ErrNotValid,
+		},
+		{
+			name: "redirection off with kvm on",
+			policy: dto.FeaturePolicy{
+				Name: "lab", Scope: "tag", Target: "lab",
+				Desired: dto.DesiredFeatures{EnableKVM: boolPtr(true), Redirection: boolPtr(false)},
+			},
+			err: featurepolicies.ErrNotValid,
+		},
+		{
+			name: "redirection on with every feature off",
+			policy: dto.FeaturePolicy{
+				Name: "lab", Scope: "tag", Target: "lab",
+				Desired: dto.DesiredFeatures{EnableSOL: boolPtr(false), EnableIDER: boolPtr(false), EnableKVM: boolPtr(false), Redirection: boolPtr(true)},
+			},
+			err: featurepolicies.ErrNotValid,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			pt := initPoliciesTest(t)
+
+			if tc.err == nil {
+				pt.repo.EXPECT().Insert(context.Background(), gomock.Any()).DoAndReturn(func(_ context.Context, p *entity.FeaturePolicy) (string, error) {
+					require.Equal(t, tc.desired, p.Desired)
+					require.NotEmpty(t, p.CreationDate)
+
+					return p.Name, nil
+				})
+				pt.repo.EXPECT().GetByName(context.Background(), tc.policy.Name, "").Return(&entity.FeaturePolicy{
+					Name: tc.policy.Name, Scope: tc.policy.Scope, Target: tc.policy.Target, Desired: tc.desired,
+				}, nil)
+			}
+
+			res, err := pt.uc.Insert(context.Background(), &tc.policy)
+			if tc.err != nil {
+				require.IsType(t, tc.err, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.policy.Name, res.Name)
+		})
+	}
+}
+
+func TestUpdateNotFound(t *testing.T) {
+	t.Parallel()
+
+	pt := initPoliciesTest(t)
+
+	pt.repo.EXPECT().Update(context.Background(), gomock.Any()).Return(false, nil)
+
+	_, err := pt.uc.Update(context.Background(), &dto.FeaturePolicy{Name: "lab", Scope: "tag", Target: "lab", Desired: dto.DesiredFeatures{EnableKVM: boolPtr(true)}})
+	require.IsType(t, featurepolicies.ErrNotFound, err)
+}
+
+func TestDelete(t *testing.T) {
+	t.Parallel()
+
+	pt := initPoliciesTest(t)
+
+	pt.repo.EXPECT().Delete(context.Background(), "lab", "tenant1").Return(true, nil)
+	pt.repo.EXPECT().Delete(context.Background(), "unknown", "tenant1").Return(false, nil)
+
+	require.NoError(t, pt.uc.Delete(context.Background(), "lab", "tenant1"))
+	require.IsType(t, featurepolicies.ErrNotFound, pt.uc.Delete(context.Background(), "unknown", "tenant1"))
+}
+
+func TestGetDrift(t *testing.T) {
+	t.Parallel()
+
+	pt := initPoliciesTest(t)
+
+	pt.repo.EXPECT().GetByName(context.Background(), "lab", "tenant1").Return(&entity.FeaturePolicy{Name: "lab", Desired: `{}`}, nil)
+	pt.repo.EXPECT().GetDrift(context.Background(), "lab", 25, 0, "tenant1").Return([]entity.FeatureDrift{{
+		GUID:       "guid1",
+		PolicyName: "lab",
+		CheckedAt:  "2024-12-01T00:15:00Z",
+		Drifted:    true,
+		Fields:     "userConsent,enableKVM",
+		Actual:     `{"userConsent":"all","kvmAvailable":true}`,
+		TenantID:   "tenant1",
+	}}, nil)
+
+	drift, err := pt.uc.GetDrift(context.Background(), "lab", 25, 0, "tenant1")
+	require.NoError(t, err)
+	require.Equal(t, []dto.FeatureDrift{{
+		GUID:       "guid1",
+		PolicyName: "lab",
+		CheckedAt:  time.Date(2024, 12, 1, 0, 15, 0, 0, time.UTC),
+		Drifted:    true,
+		Fields:     []string{"userConsent", "enableKVM"},
+		Actual:     &dtov2.Features{UserConsent: "all", KVMAvailable: true},
+		TenantID:   "tenant1",
+	}}, drift)
+
+	pt.repo.EXPECT().GetByName(context.Background(), "unknown", "tenant1").Return(nil, nil)
+
+	_, err = pt.uc.GetDrift(context.Background(), "unknown", 25, 0, "tenant1")
+	require.IsType(t, featurepolicies.ErrNotFound, err)
+}
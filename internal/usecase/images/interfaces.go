@@ -0,0 +1,38 @@
+package images
+
+import (
+	"context"
+	"io"
+
+	"github.com/open-amt-cloud-toolkit/go-wsman-messages/v2/pkg/wsman/cim/power"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity"
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/devices"
+)
+
+type (
+	Repository interface {
+		GetCount(ctx context.Context, tenantID string) (int, error)
+		Get(ctx context.Context, top, skip int, tenantID string) ([]entity.MediaImage, error)
+		GetByID(ctx context.Context, id, tenantID string) (*entity.MediaImage, error)
+		Delete(ctx context.Context, id, tenantID string) (bool, error)
+		Insert(ctx context.Context, m *entity.MediaImage) error
+	}
+	// Devices serves images to devices over IDE-R and boots them from it.
+	Devices interface {
+		OpenIDER(ctx context.Context, guid string, media devices.IDERMedia, start string) (dto.IDERSession, error)
+		CloseIDER(ctx context.Context, guid string) error
+		SetBootOptions(ctx context.Context, guid string, bootSetting dto.BootSetting) (power.PowerActionResponse, error)
+	}
+	Feature interface {
+		GetCount(ctx context.Context, tenantID string) (int, error)
+		Get(ctx context.Context, top, skip int, tenantID string) ([]dto.MediaImage, error)
+		GetByID(ctx context.Context, id, tenantID string) (*dto.MediaImage, error)
+		Delete(ctx context.Context, id, tenantID string) error
+		// Upload stores the image read from r under name, its extension tells whether it is a CD or a floppy.
+		Upload(ctx context.Context, name string, r io.Reader, tenantID string) (*dto.MediaImage, error)
+		// Mount serves a library image to a device of the caller's tenant and optionally boots the device from it.
+		Mount(ctx context.Context, guid string, mount dto.IDERMount) (dto.IDERSession, error)
+	}
+)
@@ -0,0 +1,32 @@
+package images
+
+import (
+	"os"
+	"sync"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+)
+
+// media is an image file opened for an IDE-R session, it stays in use until the session closes it.
+type media struct {
+	*os.File
+	info    dto.IDERMedia
+	release func()
+	once    sync.Once
+}
+
+func (m *media) Info() dto.IDERMedia {
+	return m.info
+}
+
+func (m *media) Close() error {
+	var err error
+
+	m.once.Do(func() {
+		err = m.File.Close()
+
+		m.release()
+	})
+
+	return err
+}
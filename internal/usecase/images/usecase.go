@@ -0,0 +1,371 @@
+package images
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity"
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/devices"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/sqldb"
+	"github.com/open-amt-cloud-toolkit/console/pkg/consoleerrors"
+	"github.com/open-amt-cloud-toolkit/console/pkg/logger"
+	"github.com/open-amt-cloud-toolkit/console/pkg/subject"
+	"github.com/open-amt-cloud-toolkit/console/pkg/tenant"
+)
+
+// The boot actions of SetBootOptions that reset or power on a device to boot from IDE-R.
+const (
+	bootFloppyReset   = 200
+	bootFloppyPowerOn = 201
+	bootCDReset       = 202
+	bootCDPowerOn     = 203
+
+	floppySectorSize = 512
+)
+
+// Config controls where images are stored and how large they can be.
+type Config struct {
+	// Path is the directory images are stored in, by default the images directory next to the database.
+	Path string
+	// MaxSize is the largest image in bytes that can be uploaded, 0 for no limit.
+	MaxSize int64
+}
+
+// UseCase -.
+type UseCase struct {
+	repo    Repository
+	devices Devices
+	log     logger.Interface
+	cfg     Config
+	// inUse counts the IDE-R sessions serving each image, an image in use cannot be deleted.
+	inUse   map[string]int
+	inUseMu sync.Mutex
+}
+
+// New -.
+func New(r Repository, d Devices, log logger.Interface, cfg Config) *UseCase {
+	if cfg.Path == "" {
+		if dirname, err := os.UserConfigDir(); err == nil {
+			cfg.Path = filepath.Join(dirname, "device-management-toolkit", "images")
+		}
+	}
+
+	return &UseCase{
+		repo:    r,
+		devices: d,
+		log:     log,
+		cfg:     cfg,
+		inUse:   make(map[string]int),
+	}
+}
+
+var (
+	ErrImagesUseCase = consoleerrors.CreateConsoleError("ImagesUseCase")
+	ErrDatabase      = sqldb.DatabaseError{Console: ErrImagesUseCase}
+	ErrNotFound      = sqldb.NotFoundError{Console: ErrImagesUseCase}
+	ErrNotValid      = dto.NotValidError{Console: ErrImagesUseCase}
+
+	errKind    = errors.New("images must be .iso or .img files")
+	errEmpty   = errors.New("the image is empty")
+	errTooBig  = errors.New("the image is larger than the configured maximum")
+	errSectors = errors.New("IMG images must be a whole number of 512 byte sectors")
+	errInUse   = errors.New("the image is mounted on a device")
+)
+
+func (uc *UseCase) GetCount(ctx context.Context, tenantID string) (int, error) {
+	count, err := uc.repo.GetCount(ctx, tenantID)
+	if err != nil {
+		return 0, ErrDatabase.Wrap("GetCount", "uc.repo.GetCount", err)
+	}
+
+	return count, nil
+}
+
+func (uc *UseCase) Get(ctx context.Context, top, skip int, tenantID string) ([]dto.MediaImage, error) {
+	data, err := uc.repo.Get(ctx, top, skip, tenantID)
+	if err != nil {
+		return nil, ErrDatabase.Wrap("Get", "uc.repo.Get", err)
+	}
+
+	d1 := make([]dto.MediaImage, len(data))
+
+	for i := range data {
+		tmpEntity := data[i] // create a new variable to avoid memory aliasing
+		d1[i] = *entityToDTO(&tmpEntity)
+	}
+
+	return d1, nil
+}
+
+func (uc *UseCase) GetByID(ctx context.Context, id, tenantID string) (*dto.MediaImage, error) {
+	data, err := uc.getByID(ctx, "GetByID", id, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	return entityToDTO(data), nil
+}
+
+// Delete removes an image that is not mounted and its file.
+func (uc *UseCase) Delete(ctx context.Context, id, tenantID string) error {
+	data, err := uc.getByID(ctx, "Delete", id, tenantID)
+	if err != nil {
+		return err
+	}
+
+	uc.inUseMu.Lock()
+	defer uc.inUseMu.Unlock()
+
+	if uc.inUse[id] > 0 {
+		return ErrNotValid.Wrap("Delete", "uc.inUse", errInUse)
+	}
+
+	isSuccessful, err := uc.repo.Delete(ctx, id, tenantID)
+	if err != nil {
+		return ErrDatabase.Wrap("Delete", "uc.repo.Delete", err)
+	}
+
+	if !isSuccessful {
+		return ErrNotFound
+	}
+
+	if err := os.Remove(filepath.Join(uc.cfg.Path, data.FileName)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		uc.log.Warn("images - Delete - could not remove " + data.FileName + ": " + err.Error())
+	}
+
+	return nil
+}
+
+// Upload streams the image to a temporary file while hashing it, it is only added to the library once it is
+// complete and valid.
+func (uc *UseCase) Upload(ctx context.Context, name string, r io.Reader, tenantID string) (*dto.MediaImage, error) {
+	name = filepath.Base(strings.TrimSpace(name))
+
+	kind, ok := mediaKind(name)
+	if !ok {
+		return nil, ErrNotValid.Wrap("Upload", "mediaKind", errKind)
+	}
+
+	if err := os.MkdirAll(uc.cfg.Path, 0o700); err != nil {
+		return nil, ErrImagesUseCase.Wrap("Upload", "os.MkdirAll", err)
+	}
+
+	file, err := os.CreateTemp(uc.cfg.Path, "upload-*")
+	if err != nil {
+		return nil, ErrImagesUseCase.Wrap("Upload", "os.CreateTemp", err)
+	}
+
+	defer os.Remove(file.Name())
+
+	size, sum, err := uc.copy(file, r)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		return nil, ErrImagesUseCase.Wrap("Upload", "uc.copy", err)
+	}
+
+	if err := uc.validate(kind, size); err != nil {
+		return nil, err
+	}
+
+	image := entity.MediaImage{
+		ID:         uuid.New().String(),
+		Name:       name,
+		Kind:       kind,
+		SizeBytes:  size,
+		SHA256:     sum,
+		UploadedBy: subject.FromContext(ctx),
+		CreatedAt:  time.Now().UTC().Format(time.RFC3339),
+		TenantID:   tenantID,
+	}
+	image.FileName = image.ID + strings.ToLower(filepath.Ext(name))
+
+	path := filepath.Join(uc.cfg.Path, image.FileName)
+
+	if err := os.Rename(file.Name(), path); err != nil {
+		return nil, ErrImagesUseCase.Wrap("Upload", "os.Rename", err)
+	}
+
+	if err := uc.repo.Insert(ctx, &image); err != nil {
+		os.Remove(path)
+
+		var notUniqueErr sqldb.NotUniqueError
+		if errors.As(err, &notUniqueErr) {
+			return nil, err
+		}
+
+		return nil, ErrDatabase.Wrap("Upload", "uc.repo.Insert", err)
+	}
+
+	return entityToDTO(&image), nil
+}
+
+// copy writes r to file up to one byte past the maximum size, so that a larger image is told apart.
+func (uc *UseCase) copy(file io.Writer, r io.Reader) (size int64, sum string, err error) {
+	if uc.cfg.MaxSize > 0 {
+		r = io.LimitReader(r, uc.cfg.MaxSize+1)
+	}
+
+	hash := sha256.New()
+
+	size, err = io.Copy(io.MultiWriter(file, hash), r)
+	if err != nil {
+		return 0, "", err
+	}
+
+	return size, hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+func (uc *UseCase) validate(kind string, size int64) error {
+	switch {
+	case size == 0:
+		return ErrNotValid.Wrap("Upload", "size", errEmpty)
+	case uc.cfg.MaxSize > 0 && size > uc.cfg.MaxSize:
+		return ErrNotValid.Wrap("Upload", "size", errTooBig)
+	case kind == dto.MediaKindFloppy && size%floppySectorSize != 0:
+		return ErrNotValid.Wrap("Upload", "size", errSectors)
+	default:
+		return nil
+	}
+}
+
+// Mount opens an IDE-R session serving the image. With Boot the image is attached at the next reset, which
+// the boot options then trigger, a failed boot ejects the image again.
+func (uc *UseCase) Mount(ctx context.Context, guid string, mount dto.IDERMount) (dto.IDERSession, error) {
+	data, err := uc.getByID(ctx, "Mount", mount.ImageID, tenant.FromContext(ctx))
+	if err != nil {
+		return dto.IDERSession{}, err
+	}
+
+	m, err := uc.open(data)
+	if err != nil {
+		return dto.IDERSession{}, err
+	}
+
+	start := devices.IDERStartNow
+	if mount.Boot != "" {
+		start = devices.IDERStartOnReboot
+	}
+
+	session, err := uc.devices.OpenIDER(ctx, guid, m, start)
+	if err != nil {
+		return dto.IDERSession{}, err
+	}
+
+	if mount.Boot == "" {
+		return session, nil
+	}
+
+	if _, err := uc.devices.SetBootOptions(ctx, guid, dto.BootSetting{Action: bootAction(data.Kind, mount.Boot)}); err != nil {
+		if closeErr := uc.devices.CloseIDER(ctx, guid); closeErr != nil {
+			uc.log.Warn("images - Mount - could not eject " + data.Name + " from " + guid + ": " + closeErr.Error())
+		}
+
+		return dto.IDERSession{}, err
+	}
+
+	return session, nil
+}
+
+// open returns the image file as media for an IDE-R session and counts it in use until the session closes it.
+func (uc *UseCase) open(data *entity.MediaImage) (*media, error) {
+	file, err := os.Open(filepath.Join(uc.cfg.Path, filepath.Base(data.FileName)))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, ErrNotFound
+		}
+
+		return nil, ErrImagesUseCase.Wrap("Mount", "os.Open", err)
+	}
+
+	uc.inUseMu.Lock()
+	uc.inUse[data.ID]++
+	uc.inUseMu.Unlock()
+
+	return &media{
+		File: file,
+		info: dto.IDERMedia{
+			ImageID:   data.ID,
+			Name:      data.Name,
+			Kind:      data.Kind,
+			SizeBytes: data.SizeBytes,
+		},
+		release: func() {
+			uc.inUseMu.Lock()
+			defer uc.inUseMu.Unlock()
+
+			if uc.inUse[data.ID]--; uc.inUse[data.ID] <= 0 {
+				delete(uc.inUse, data.ID)
+			}
+		},
+	}, nil
+}
+
+func (uc *UseCase) getByID(ctx context.Context, function, id, tenantID string) (*entity.MediaImage, error) {
+	data, err := uc.repo.GetByID(ctx, id, tenantID)
+	if err != nil {
+		return nil, ErrDatabase.Wrap(function, "uc.repo.GetByID", err)
+	}
+
+	if data == nil {
+		return nil, ErrNotFound
+	}
+
+	return data, nil
+}
+
+func mediaKind(name string) (string, bool) {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".iso":
+		return dto.MediaKindCD, true
+	case ".img":
+		return dto.MediaKindFloppy, true
+	default:
+		return "", false
+	}
+}
+
+func bootAction(kind, boot string) int {
+	switch {
+	case kind == dto.MediaKindFloppy && boot == dto.IDERBootPowerOn:
+		return bootFloppyPowerOn
+	case kind == dto.MediaKindFloppy:
+		return bootFloppyReset
+	case boot == dto.IDERBootPowerOn:
+		return bootCDPowerOn
+	default:
+		return bootCDReset
+	}
+}
+
+// convert entity.MediaImage to dto.MediaImage.
+func entityToDTO(d *entity.MediaImage) *dto.MediaImage {
+	d1 := &dto.MediaImage{
+		ID:         d.ID,
+		Name:       d.Name,
+		Kind:       d.Kind,
+		SizeBytes:  d.SizeBytes,
+		SHA256:     d.SHA256,
+		UploadedBy: d.UploadedBy,
+		TenantID:   d.TenantID,
+	}
+
+	if t, err := time.Parse(time.RFC3339, d.CreatedAt); err == nil {
+		d1.CreatedAt = t
+	}
+
+	return d1
+}
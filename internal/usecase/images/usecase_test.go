@@ -0,0 +1,181 @@
+package images_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/open-amt-cloud-toolkit/go-wsman-messages/v2/pkg/wsman/cim/power"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity"
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	"github.com/open-amt-cloud-toolkit/console/internal/mocks"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/devices"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/images"
+	"github.com/open-amt-cloud-toolkit/console/pkg/logger"
+	"github.com/open-amt-cloud-toolkit/console/pkg/subject"
+	"github.com/open-amt-cloud-toolkit/console/pkg/tenant"
+)
+
+var errBoot = errors.New("boot failed")
+
+func imagesTest(t *testing.T, maxSize int64) (*images.UseCase, *mocks.MockImagesRepository, *mocks.MockImagesDevices, string) {
+	t.Helper()
+
+	mockCtl := gomock.NewController(t)
+	repo := mocks.NewMockImagesRepository(mockCtl)
+	devs := mocks.NewMockImagesDevices(mockCtl)
+	dir := t.TempDir()
+
+	return images.New(repo, devs, logger.New("error"), images.Config{Path: dir, MaxSize: maxSize}), repo, devs, dir
+}
+
+func TestUploadNotValid(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		file    string
+		content []byte
+	}{
+		{name: "unknown extension", file: "setup.exe", content: []byte("MZ")},
+		{name: "empty", file: "empty.iso"},
+		{name: "too big", file: "big.iso", content: make([]byte, 4097)},
+		{name: "partial sector", file: "boot.img", content: make([]byte, 700)},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			useCase, _, _, dir := imagesTest(t, 4096)
+
+			_, err := useCase.Upload(context.Background(), tc.file, bytes.NewReader(tc.content), "tenant1")
+			require.IsType(t, dto.NotValidError{}, err)
+
+			entries, err := os.ReadDir(dir)
+			if !errors.Is(err, os.ErrNotExist) {
+				require.NoError(t, err)
+				require.Empty(t, entries)
+			}
+		})
+	}
+}
+
+func TestUpload(t *testing.T) {
+	t.Parallel()
+
+	useCase, repo, _, dir := imagesTest(t, 0)
+	content := bytes.Repeat([]byte{0xEB}, 1024)
+	sum := sha256.Sum256(content)
+
+	var stored entity.MediaImage
+
+	repo.EXPECT().Insert(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, m *entity.MediaImage) error {
+		stored = *m
+
+		return nil
+	})
+
+	ctx := subject.NewContext(context.Background(), "alice")
+
+	image, err := useCase.Upload(ctx, "uploads/DOS.IMG", bytes.NewReader(content), "tenant1")
+	require.NoError(t, err)
+	require.Equal(t, "DOS.IMG", image.Name)
+	require.Equal(t, dto.MediaKindFloppy, image.Kind)
+	require.Equal(t, int64(1024), image.SizeBytes)
+	require.Equal(t, hex.EncodeToString(sum[:]), image.SHA256)
+	require.Equal(t, "alice", image.UploadedBy)
+	require.Equal(t, image.ID+".img", stored.FileName)
+
+	written, err := os.ReadFile(filepath.Join(dir, stored.FileName))
+	require.NoError(t, err)
+	require.Equal(t, content, written)
+}
+
+func TestMountAndBoot(t *testing.T) {
+	t.Parallel()
+
+	useCase, repo, devs, dir := imagesTest(t, 0)
+	image := &entity.MediaImage{ID: "1", Name: "ubuntu.iso", Kind: dto.MediaKindCD, FileName: "1.iso", SizeBytes: 4, TenantID: "tenant1"}
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, image.FileName), []byte("CD01"), 0o600))
+
+	ctx := tenant.NewContext(context.Background(), "tenant1")
+	session := dto.IDERSession{GUID: "guid-1", State: dto.IDERStateConnecting}
+
+	var mounted devices.IDERMedia
+
+	repo.EXPECT().GetByID(ctx, "1", "tenant1").Return(image, nil).Times(3)
+	devs.EXPECT().OpenIDER(ctx, "guid-1", gomock.Any(), devices.IDERStartOnReboot).
+		DoAndReturn(func(_ context.Context, _ string, m devices.IDERMedia, _ string) (dto.IDERSession, error) {
+			mounted = m
+
+			return session, nil
+		})
+	devs.EXPECT().SetBootOptions(ctx, "guid-1", dto.BootSetting{Action: 203}).Return(power.PowerActionResponse{}, nil)
+
+	got, err := useCase.Mount(ctx, "guid-1", dto.IDERMount{ImageID: "1", Boot: dto.IDERBootPowerOn})
+	require.NoError(t, err)
+	require.Equal(t, session, got)
+	require.Equal(t, dto.IDERMedia{ImageID: "1", Name: "ubuntu.iso", Kind: dto.MediaKindCD, SizeBytes: 4}, mounted.Info())
+
+	data := make([]byte, 2)
+	_, err = mounted.ReadAt(data, 2)
+	require.NoError(t, err)
+	require.Equal(t, []byte("01"), data)
+
+	err = useCase.Delete(ctx, "1", "tenant1")
+	require.IsType(t, dto.NotValidError{}, err)
+
+	require.NoError(t, mounted.Close())
+	require.NoError(t, mounted.Close())
+
+	repo.EXPECT().Delete(ctx, "1", "tenant1").Return(true, nil)
+
+	require.NoError(t, useCase.Delete(ctx, "1", "tenant1"))
+
+	_, err = os.Stat(filepath.Join(dir, image.FileName))
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestMountBootFails(t *testing.T) {
+	t.Parallel()
+
+	useCase, repo, devs, dir := imagesTest(t, 0)
+	image := &entity.MediaImage{ID: "1", Name: "dos.img", Kind: dto.MediaKindFloppy, FileName: "1.img", SizeBytes: 512, TenantID: "tenant1"}
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, image.FileName), make([]byte, 512), 0o600))
+
+	ctx := tenant.NewContext(context.Background(), "tenant1")
+
+	repo.EXPECT().GetByID(ctx, "1", "tenant1").Return(image, nil)
+	devs.EXPECT().OpenIDER(ctx, "guid-1", gomock.Any(), devices.IDERStartOnReboot).Return(dto.IDERSession{GUID: "guid-1"}, nil)
+	devs.EXPECT().SetBootOptions(ctx, "guid-1", dto.BootSetting{Action: 200}).Return(power.PowerActionResponse{}, errBoot)
+	devs.EXPECT().CloseIDER(ctx, "guid-1").Return(nil)
+
+	_, err := useCase.Mount(ctx, "guid-1", dto.IDERMount{ImageID: "1", Boot: dto.IDERBootReset})
+	require.ErrorIs(t, err, errBoot)
+}
+
+func TestMountNotFound(t *testing.T) {
+	t.Parallel()
+
+	useCase, repo, _, _ := imagesTest(t, 0)
+	ctx := tenant.NewContext(context.Background(), "tenant2")
+
+	repo.EXPECT().GetByID(ctx, "1", "tenant2").Return(nil, nil)
+
+	_, err := useCase.Mount(ctx, "guid-1", dto.IDERMount{ImageID: "1"})
+	require.ErrorIs(t, err, images.ErrNotFound)
+}
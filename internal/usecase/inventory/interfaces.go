@@ -0,0 +1,34 @@
+package inventory
+
+import (
+	"context"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity"
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+)
+
+type (
+	Repository interface {
+		GetCount(ctx context.Context, filter entity.InventoryFilter) (int, error)
+		Get(ctx context.Context, filter entity.InventoryFilter, top, skip int) ([]entity.DeviceInventory, error)
+		GetByGUID(ctx context.Context, guid, tenantID string) (*entity.DeviceInventory, error)
+		GetBIOSVersions(ctx context.Context, tenantID string) ([]entity.BIOSVersionCount, error)
+		Upsert(ctx context.Context, i *entity.DeviceInventory) error
+	}
+	Feature interface {
+		Refresh(ctx context.Context, guid, tenantID string) (*dto.DeviceInventory, error)
+		GetByGUID(ctx context.Context, guid, tenantID string) (*dto.DeviceInventory, error)
+		GetCount(ctx context.Context, filter dto.InventoryFilter) (int, error)
+		Get(ctx context.Context, filter dto.InventoryFilter, top, skip int) ([]dto.DeviceInventory, error)
+		GetBIOSVersions(ctx context.Context, tenantID string) ([]dto.BIOSVersionCount, error)
+		Run(ctx context.Context)
+	}
+	// Devices is the part of the device management feature the inventory is read with.
+	Devices interface {
+		GetInventory(ctx context.Context, guid string) (dto.DeviceInventory, error)
+	}
+	// Fleet pages through the devices of every tenant for the periodic refresh.
+	Fleet interface {
+		GetAllTenants(ctx context.Context, top, skip int) ([]entity.Device, error)
+	}
+)
@@ -0,0 +1,261 @@
+package inventory
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity"
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/sqldb"
+	"github.com/open-amt-cloud-toolkit/console/pkg/consoleerrors"
+	"github.com/open-amt-cloud-toolkit/console/pkg/logger"
+	"github.com/open-amt-cloud-toolkit/console/pkg/tenant"
+)
+
+const fleetPageSize = 100
+
+// Config controls the periodic refresh of the fleet's inventory.
+type Config struct {
+	// Interval between two refreshes of the fleet, zero disables them.
+	Interval time.Duration
+	// Workers caps the number of devices read at once.
+	Workers int
+}
+
+// UseCase -.
+type UseCase struct {
+	repo    Repository
+	devices Devices
+	fleet   Fleet
+	log     logger.Interface
+	cfg     Config
+	now     func() time.Time
+}
+
+// New -.
+func New(r Repository, d Devices, f Fleet, log logger.Interface, cfg Config) *UseCase {
+	if cfg.Workers <= 0 {
+		cfg.Workers = 1
+	}
+
+	return &UseCase{
+		repo:    r,
+		devices: d,
+		fleet:   f,
+		log:     log,
+		cfg:     cfg,
+		now:     time.Now,
+	}
+}
+
+var (
+	ErrInventoryUseCase = consoleerrors.CreateConsoleError("InventoryUseCase")
+	ErrDatabase         = sqldb.DatabaseError{Console: ErrInventoryUseCase}
+	ErrNotFound         = sqldb.NotFoundError{Console: ErrInventoryUseCase}
+)
+
+// Refresh reads the inventory of a device and stores it in place of the previous one.
+func (uc *UseCase) Refresh(ctx context.Context, guid, tenantID string) (*dto.DeviceInventory, error) {
+	// the device management calls find the device in the tenant of ctx
+	ctx = tenant.NewContext(ctx, tenantID)
+
+	d, err := uc.devices.GetInventory(ctx, guid)
+	if err != nil {
+		return nil, err
+	}
+
+	d.RefreshedAt = uc.now().UTC().Truncate(time.Second)
+	d.TenantID = tenantID
+
+	d1, err := dtoToEntity(&d)
+	if err != nil {
+		return nil, ErrInventoryUseCase.Wrap("Refresh", "dtoToEntity", err)
+	}
+
+	if err := uc.repo.Upsert(ctx, d1); err != nil {
+		return nil, ErrDatabase.Wrap("Refresh", "uc.repo.Upsert", err)
+	}
+
+	return &d, nil
+}
+
+// GetByGUID returns the inventory stored at the last refresh of a device.
+func (uc *UseCase) GetByGUID(ctx context.Context, guid, tenantID string) (*dto.DeviceInventory, error) {
+	data, err := uc.repo.GetByGUID(ctx, guid, tenantID)
+	if err != nil {
+		return nil, ErrDatabase.Wrap("GetByGUID", "uc.repo.GetByGUID", err)
+	}
+
+	if data == nil {
+		return nil, ErrNotFound
+	}
+
+	d2, err := entityToDTO(data)
+	if err != nil {
+		return nil, ErrInventoryUseCase.Wrap("GetByGUID", "entityToDTO", err)
+	}
+
+	return d2, nil
+}
+
+func (uc *UseCase) GetCount(ctx context.Context, filter dto.InventoryFilter) (int, error) {
+	count, err := uc.repo.GetCount(ctx, filterToEntity(filter))
+	if err != nil {
+		return 0, ErrDatabase.Wrap("GetCount", "uc.repo.GetCount", err)
+	}
+
+	return count, nil
+}
+
+// Get returns the inventory of the devices matching filter.
+func (uc *UseCase) Get(ctx context.Context, filter dto.InventoryFilter, top, skip int) ([]dto.DeviceInventory, error) {
+	data, err := uc.repo.Get(ctx, filterToEntity(filter), top, skip)
+	if err != nil {
+		return nil, ErrDatabase.Wrap("Get", "uc.repo.Get", err)
+	}
+
+	d2 := make([]dto.DeviceInventory, 0, len(data))
+
+	for i := range data {
+		d, err := entityToDTO(&data[i])
+		if err != nil {
+			return nil, ErrInventoryUseCase.Wrap("Get", "entityToDTO", err)
+		}
+
+		d2 = append(d2, *d)
+	}
+
+	return d2, nil
+}
+
+// GetBIOSVersions returns how many devices of a tenant run each BIOS version.
+func (uc *UseCase) GetBIOSVersions(ctx context.Context, tenantID string) ([]dto.BIOSVersionCount, error) {
+	data, err := uc.repo.GetBIOSVersions(ctx, tenantID)
+	if err != nil {
+		return nil, ErrDatabase.Wrap("GetBIOSVersions", "uc.repo.GetBIOSVersions", err)
+	}
+
+	d2 := make([]dto.BIOSVersionCount, 0, len(data))
+	for _, v := range data {
+		d2 = append(d2, dto.BIOSVersionCount{
+			Manufacturer: v.Manufacturer,
+			Version:      v.Version,
+			Count:        v.Count,
+		})
+	}
+
+	return d2, nil
+}
+
+// Run refreshes the inventory of the fleet every interval until ctx is done.
+func (uc *UseCase) Run(ctx context.Context) {
+	if uc.cfg.Interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(uc.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		uc.RefreshAll(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RefreshAll refreshes the inventory of every connected device once and waits for the refreshes to finish. A
+// device that cannot be reached keeps its last inventory.
+func (uc *UseCase) RefreshAll(ctx context.Context) {
+	sem := make(chan struct{}, uc.cfg.Workers)
+
+	var wg sync.WaitGroup
+
+	defer wg.Wait()
+
+	for skip := 0; ; skip += fleetPageSize {
+		page, err := uc.fleet.GetAllTenants(ctx, fleetPageSize, skip)
+		if err != nil {
+			uc.log.Error(err, "inventory - RefreshAll - uc.fleet.GetAllTenants")
+
+			return
+		}
+
+		for i := range page {
+			if !page[i].ConnectionStatus {
+				continue
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case sem <- struct{}{}:
+			}
+
+			wg.Add(1)
+
+			go func(d entity.Device) {
+				defer func() {
+					<-sem
+					wg.Done()
+				}()
+
+				if _, err := uc.Refresh(ctx, d.GUID, d.TenantID); err != nil {
+					uc.log.Debug("inventory - RefreshAll - " + d.GUID + ": " + err.Error())
+				}
+			}(page[i])
+		}
+
+		if len(page) < fleetPageSize {
+			return
+		}
+	}
+}
+
+func filterToEntity(filter dto.InventoryFilter) entity.InventoryFilter {
+	return entity.InventoryFilter{
+		AMTBelow:         filter.AMTBelow,
+		MemoryBelowBytes: int64(filter.MemoryBelowGB) << 30,
+		BIOSVersion:      filter.BIOSVersion,
+		TenantID:         filter.TenantID,
+	}
+}
+
+// dtoToEntity keeps the whole inventory as JSON next to the columns the reports filter and group on.
+func dtoToEntity(d *dto.DeviceInventory) (*entity.DeviceInventory, error) {
+	inventory, err := json.Marshal(d)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.DeviceInventory{
+		GUID:             d.GUID,
+		RefreshedAt:      d.RefreshedAt.UTC().Format(time.RFC3339),
+		AMTVersion:       d.Firmware.AMTVersion,
+		AMTMajor:         d.Firmware.AMTMajor,
+		SKU:              d.Firmware.SKU,
+		BIOSManufacturer: d.BIOS.Manufacturer,
+		BIOSVersion:      d.BIOS.Version,
+		TotalMemoryBytes: d.TotalMemoryBytes,
+		Inventory:        string(inventory),
+		TenantID:         d.TenantID,
+	}, nil
+}
+
+func entityToDTO(d *entity.DeviceInventory) (*dto.DeviceInventory, error) {
+	d2 := &dto.DeviceInventory{}
+	if err := json.Unmarshal([]byte(d.Inventory), d2); err != nil {
+		return nil, err
+	}
+
+	d2.GUID = d.GUID
+	d2.TenantID = d.TenantID
+	d2.RefreshedAt, _ = time.Parse(time.RFC3339, d.RefreshedAt)
+
+	return d2, nil
+}
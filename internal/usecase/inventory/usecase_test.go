@@ -0,0 +1,183 @@
+package inventory_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity"
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	"github.com/open-amt-cloud-toolkit/console/internal/mocks"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/inventory"
+	"github.com/open-amt-cloud-toolkit/console/pkg/logger"
+	"github.com/open-amt-cloud-toolkit/console/pkg/tenant"
+)
+
+var errUnreachable = errors.New("device unreachable")
+
+type inventoryTest struct {
+	uc      *inventory.UseCase
+	repo    *mocks.MockInventoryRepository
+	devices *mocks.MockInventoryDevices
+	fleet   *mocks.MockInventoryFleet
+}
+
+func initInventoryTest(t *testing.T) inventoryTest {
+	t.Helper()
+
+	mockCtl := gomock.NewController(t)
+	repo := mocks.NewMockInventoryRepository(mockCtl)
+	d := mocks.NewMockInventoryDevices(mockCtl)
+	f := mocks.NewMockInventoryFleet(mockCtl)
+
+	return inventoryTest{
+		uc:      inventory.New(repo, d, f, logger.New("error"), inventory.Config{Workers: 2}),
+		repo:    repo,
+		devices: d,
+		fleet:   f,
+	}
+}
+
+var deviceInventory = dto.DeviceInventory{
+	GUID:             "guid1",
+	Firmware:         dto.FirmwareInventory{AMTVersion: "16.1.25", AMTMajor: 16, SKU: "16392"},
+	BIOS:             dto.BIOSInventory{Manufacturer: "Intel Corp.", Version: "ADLPFWI1"},
+	Memory:           []dto.MemoryInventory{{BankLabel: "BANK 0", CapacityBytes: 8 << 30}},
+	TotalMemoryBytes: 8 << 30,
+}
+
+func TestRefresh(t *testing.T) {
+	t.Parallel()
+
+	test := initInventoryTest(t)
+
+	test.devices.EXPECT().GetInventory(gomock.Any(), "guid1").DoAndReturn(func(ctx context.Context, _ string) (dto.DeviceInventory, error) {
+		// the device management calls look the device up in the tenant of ctx
+		require.Equal(t, "tenant1", tenant.FromContext(ctx))
+
+		return deviceInventory, nil
+	})
+
+	var stored *entity.DeviceInventory
+
+	test.repo.EXPECT().Upsert(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, i *entity.DeviceInventory) error {
+		stored = i
+
+		return nil
+	})
+
+	refreshed, err := test.uc.Refresh(context.Background(), "guid1", "tenant1")
+	require.NoError(t, err)
+	require.False(t, refreshed.RefreshedAt.IsZero())
+	require.Equal(t, "tenant1", refreshed.TenantID)
+
+	require.Equal(t, "guid1", stored.GUID)
+	require.Equal(t, 16, stored.AMTMajor)
+	require.Equal(t, "ADLPFWI1", stored.BIOSVersion)
+	require.Equal(t, int64(8<<30), stored.TotalMemoryBytes)
+	require.Equal(t, "tenant1", stored.TenantID)
+
+	// the stored inventory reads back as the refreshed one
+	test.repo.EXPECT().GetByGUID(gomock.Any(), "guid1", "tenant1").Return(stored, nil)
+
+	got, err := test.uc.GetByGUID(context.Background(), "guid1", "tenant1")
+	require.NoError(t, err)
+	require.Equal(t, refreshed, got)
+}
+
+func TestRefreshUnreachable(t *testing.T) {
+	t.Parallel()
+
+	test := initInventoryTest(t)
+
+	test.devices.EXPECT().GetInventory(gomock.Any(), "guid1").Return(dto.DeviceInventory{}, errUnreachable)
+
+	_, err := test.uc.Refresh(context.Background(), "guid1", "tenant1")
+	require.ErrorIs(t, err, errUnreachable)
+}
+
+func TestGetByGUIDNotFound(t *testing.T) {
+	t.Parallel()
+
+	test := initInventoryTest(t)
+
+	test.repo.EXPECT().GetByGUID(gomock.Any(), "guid1", "tenant1").Return(nil, nil)
+
+	_, err := test.uc.GetByGUID(context.Background(), "guid1", "tenant1")
+	require.IsType(t, inventory.ErrNotFound, err)
+}
+
+func TestGet(t *testing.T) {
+	t.Parallel()
+
+	test := initInventoryTest(t)
+
+	filter := entity.InventoryFilter{AMTBelow: 16, MemoryBelowBytes: 16 << 30, TenantID: "tenant1"}
+
+	test.repo.EXPECT().Get(gomock.Any(), filter, 10, 0).Return([]entity.DeviceInventory{
+		{GUID: "guid1", RefreshedAt: "2024-12-01T00:00:00Z", Inventory: `{"bios":{"version":"A"}}`, TenantID: "tenant1"},
+	}, nil)
+	test.repo.EXPECT().GetCount(gomock.Any(), filter).Return(1, nil)
+
+	items, err := test.uc.Get(context.Background(), dto.InventoryFilter{AMTBelow: 16, MemoryBelowGB: 16, TenantID: "tenant1"}, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	require.Equal(t, "guid1", items[0].GUID)
+	require.Equal(t, "A", items[0].BIOS.Version)
+	require.Equal(t, 2024, items[0].RefreshedAt.Year())
+
+	count, err := test.uc.GetCount(context.Background(), dto.InventoryFilter{AMTBelow: 16, MemoryBelowGB: 16, TenantID: "tenant1"})
+	require.NoError(t, err)
+	require.Equal(t, 1, count)
+}
+
+func TestGetBIOSVersions(t *testing.T) {
+	t.Parallel()
+
+	test := initInventoryTest(t)
+
+	test.repo.EXPECT().GetBIOSVersions(gomock.Any(), "tenant1").Return([]entity.BIOSVersionCount{{Manufacturer: "Intel", Version: "A", Count: 2}}, nil)
+
+	versions, err := test.uc.GetBIOSVersions(context.Background(), "tenant1")
+	require.NoError(t, err)
+	require.Equal(t, []dto.BIOSVersionCount{{Manufacturer: "Intel", Version: "A", Count: 2}}, versions)
+}
+
+func TestRefreshAll(t *testing.T) {
+	t.Parallel()
+
+	test := initInventoryTest(t)
+
+	test.fleet.EXPECT().GetAllTenants(gomock.Any(), 100, 0).Return([]entity.Device{
+		{GUID: "guid1", TenantID: "tenant1", ConnectionStatus: true},
+		{GUID: "guid2", TenantID: "tenant2", ConnectionStatus: true},
+		{GUID: "guid3", TenantID: "tenant1"},
+	}, nil)
+
+	var (
+		mu        sync.Mutex
+		refreshed = map[string]string{}
+	)
+
+	test.devices.EXPECT().GetInventory(gomock.Any(), gomock.Any()).Times(2).DoAndReturn(func(ctx context.Context, guid string) (dto.DeviceInventory, error) {
+		mu.Lock()
+		refreshed[guid] = tenant.FromContext(ctx)
+		mu.Unlock()
+
+		if guid == "guid2" {
+			return dto.DeviceInventory{}, errUnreachable
+		}
+
+		return dto.DeviceInventory{GUID: guid}, nil
+	})
+	test.repo.EXPECT().Upsert(gomock.Any(), gomock.Any()).Return(nil)
+
+	test.uc.RefreshAll(context.Background())
+
+	// a disconnected device keeps its last inventory
+	require.Equal(t, map[string]string{"guid1": "tenant1", "guid2": "tenant2"}, refreshed)
+}
@@ -0,0 +1,136 @@
+package recordings
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"io"
+	"time"
+)
+
+// A recording starts with magic and a JSON header describing the session. Each frame that follows is a
+// big endian uint32 of milliseconds since the session started, a Direction byte, a big endian uint32
+// length and that many bytes of data, exactly as they crossed the console.
+const (
+	magic           = "AMTREC01"
+	frameHeaderSize = 9
+	maxHeaderSize   = 64 * 1024
+	maxFrameSize    = 16 * 1024 * 1024
+)
+
+// Direction tells which side of a redirection session sent a frame.
+type Direction byte
+
+const (
+	FromDevice  Direction = 0
+	FromBrowser Direction = 1
+)
+
+var (
+	errNotRecording  = errors.New("not a session recording")
+	errFrameTooLarge = errors.New("frame exceeds the maximum size")
+)
+
+// Frame is one message of a recorded session.
+type Frame struct {
+	Offset    time.Duration
+	Direction Direction
+	Data      []byte
+}
+
+// header lets a recording be understood without the database it was listed in.
+type header struct {
+	ID        string `json:"id"`
+	GUID      string `json:"guid"`
+	Hostname  string `json:"hostname,omitempty"`
+	Mode      string `json:"mode"`
+	Subject   string `json:"subject,omitempty"`
+	StartTime string `json:"startTime"`
+}
+
+// writeHeader writes the start of a recording and returns its size.
+func writeHeader(w io.Writer, h header) (int, error) {
+	data, err := json.Marshal(h)
+	if err != nil {
+		return 0, err
+	}
+
+	buf := make([]byte, 0, len(magic)+4+len(data))
+	buf = append(buf, magic...)
+	buf = binary.BigEndian.AppendUint32(buf, uint32(len(data)))
+	buf = append(buf, data...)
+
+	return w.Write(buf)
+}
+
+func readHeader(r io.Reader) (header, error) {
+	var h header
+
+	buf := make([]byte, len(magic)+4)
+	if _, err := io.ReadFull(r, buf); err != nil {
+		return h, errNotRecording
+	}
+
+	if string(buf[:len(magic)]) != magic {
+		return h, errNotRecording
+	}
+
+	size := binary.BigEndian.Uint32(buf[len(magic):])
+	if size > maxHeaderSize {
+		return h, errNotRecording
+	}
+
+	data := make([]byte, size)
+	if _, err := io.ReadFull(r, data); err != nil {
+		return h, errNotRecording
+	}
+
+	if err := json.Unmarshal(data, &h); err != nil {
+		return h, errNotRecording
+	}
+
+	return h, nil
+}
+
+// writeFrame writes f and returns its size.
+func writeFrame(w io.Writer, f Frame) (int, error) {
+	buf := make([]byte, frameHeaderSize, frameHeaderSize+len(f.Data))
+	binary.BigEndian.PutUint32(buf[0:4], uint32(f.Offset.Milliseconds()))
+	buf[4] = byte(f.Direction)
+	binary.BigEndian.PutUint32(buf[5:9], uint32(len(f.Data)))
+	buf = append(buf, f.Data...)
+
+	return w.Write(buf)
+}
+
+// readFrames calls fn with every frame of r until fn returns an error. A frame cut short, as the last one of a
+// session that is still open or whose console stopped, ends the recording rather than failing it.
+func readFrames(r io.Reader, fn func(Frame) error) error {
+	buf := make([]byte, frameHeaderSize)
+
+	for {
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return nil
+		}
+
+		size := binary.BigEndian.Uint32(buf[5:9])
+		if size > maxFrameSize {
+			return errFrameTooLarge
+		}
+
+		data := make([]byte, size)
+		if _, err := io.ReadFull(r, data); err != nil {
+			return nil
+		}
+
+		f := Frame{
+			Offset:    time.Duration(binary.BigEndian.Uint32(buf[0:4])) * time.Millisecond,
+			Direction: Direction(buf[4]),
+			Data:      data,
+		}
+
+		if err := fn(f); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package recordings
+
+import (
+	"context"
+	"io"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity"
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+)
+
+type (
+	Repository interface {
+		GetCount(ctx context.Context, tenantID string) (int, error)
+		Get(ctx context.Context, top, skip int, tenantID string) ([]entity.SessionRecording, error)
+		GetByID(ctx context.Context, id, tenantID string) (*entity.SessionRecording, error)
+		Delete(ctx context.Context, id, tenantID string) (bool, error)
+		Update(ctx context.Context, s *entity.SessionRecording) (bool, error)
+		Insert(ctx context.Context, s *entity.SessionRecording) error
+	}
+	Feature interface {
+		// Record starts recording a redirection session, it returns nil when sessions of mode are not recorded.
+		Record(ctx context.Context, device entity.Device, mode string) (Session, error)
+		GetCount(ctx context.Context, tenantID string) (int, error)
+		Get(ctx context.Context, top, skip int, tenantID string) ([]dto.SessionRecording, error)
+		GetByID(ctx context.Context, id, tenantID string) (*dto.SessionRecording, error)
+		Delete(ctx context.Context, id, tenantID string) error
+		Download(ctx context.Context, id, tenantID string) (io.ReadCloser, error)
+		GetFrames(ctx context.Context, id, tenantID string, top, skip int) ([]dto.RecordingFrame, error)
+		Transcript(ctx context.Context, id, tenantID string) (string, error)
+	}
+	// Session receives the messages of one redirection session until it is closed.
+	Session interface {
+		Write(direction Direction, data []byte)
+		Close() error
+	}
+)
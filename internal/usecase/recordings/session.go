@@ -0,0 +1,87 @@
+package recordings
+
+import (
+	"bufio"
+	"context"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity"
+)
+
+// session writes the frames of one redirection session to its recording. The device and browser sides of a
+// session are relayed by separate goroutines, so writes are serialized.
+type session struct {
+	mu     sync.Mutex
+	uc     *UseCase
+	ctx    context.Context
+	file   *os.File
+	w      *bufio.Writer
+	start  time.Time
+	rec    entity.SessionRecording
+	size   int64
+	failed bool
+	full   bool
+	closed bool
+}
+
+// Write appends a frame. A recording that cannot be written, or that reached the maximum size, stops recording
+// rather than interrupting the session, this is logged once. One that reached the maximum size is stored as
+// truncated.
+func (s *session) Write(direction Direction, data []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed || s.failed || s.full {
+		return
+	}
+
+	if limit := s.uc.cfg.MaxSize; limit > 0 && s.size+int64(frameHeaderSize+len(data)) > limit {
+		s.full = true
+		s.uc.log.Warn("recordings - session - Write - " + s.rec.ID + " reached the maximum size, the rest of the session is not recorded")
+
+		return
+	}
+
+	n, err := writeFrame(s.w, Frame{Offset: time.Since(s.start), Direction: direction, Data: data})
+	s.size += int64(n)
+
+	if err != nil {
+		s.failed = true
+		s.uc.log.Error(err, "recordings - session - Write - "+s.rec.ID)
+	}
+}
+
+// Close flushes the recording and stores how long and how large it was, it is safe to call more than once.
+func (s *session) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return nil
+	}
+
+	s.closed = true
+
+	flushErr := s.w.Flush()
+	closeErr := s.file.Close()
+
+	s.rec.SizeBytes = s.size
+	s.rec.Truncated = s.full
+	s.rec.EndTime = formatTime(time.Now())
+
+	if _, err := s.uc.repo.Update(s.ctx, &s.rec); err != nil {
+		return ErrDatabase.Wrap("Close", "uc.repo.Update", err)
+	}
+
+	if flushErr != nil {
+		return ErrRecordingsUseCase.Wrap("Close", "w.Flush", flushErr)
+	}
+
+	if closeErr != nil {
+		return ErrRecordingsUseCase.Wrap("Close", "file.Close", closeErr)
+	}
+
+	return nil
+}
@@ -0,0 +1,107 @@
+package recordings
+
+import (
+	"bytes"
+	"encoding/binary"
+	"regexp"
+	"strings"
+)
+
+// Messages an Intel AMT device sends during a SOL session, the transcript only keeps the terminal data.
+const (
+	solStartRedirectionSessionReply = 0x11
+	solAuthenticateSessionReply     = 0x14
+	solSettingsReply                = 0x21
+	solSerialSettings               = 0x29
+	solData                         = 0x2A
+	solKeepAlive                    = 0x2B
+
+	solSettingsReplySize  = 23
+	solSerialSettingsSize = 10
+	solDataHeaderSize     = 10
+	solKeepAliveSize      = 8
+	startReplyHeaderSize  = 13
+	authReplyHeaderSize   = 9
+)
+
+// ansiEscape matches the terminal control sequences of a BIOS or OS console: CSI, OSC, character set and
+// single character escapes.
+var ansiEscape = regexp.MustCompile(`\x1b(\[[0-?]*[ -/]*[@-~]|\][^\x07\x1b]*(\x07|\x1b\\)|[()][0-9A-Za-z]|[@-Z\\-_])`)
+
+// solTranscript collects the terminal output of a SOL session. Device frames are raw reads from the
+// redirection port, so a message can span several frames and a frame can hold several messages.
+type solTranscript struct {
+	pending []byte
+	output  bytes.Buffer
+}
+
+func (t *solTranscript) write(data []byte) {
+	t.pending = append(t.pending, data...)
+
+	for len(t.pending) > 0 {
+		size := solMessageSize(t.pending)
+		if size < 0 {
+			// not a message this parser knows, its length cannot be told so the rest of the read is dropped
+			t.pending = nil
+
+			return
+		}
+
+		if size == 0 || len(t.pending) < size {
+			return
+		}
+
+		if t.pending[0] == solData {
+			t.output.Write(t.pending[solDataHeaderSize:size])
+		}
+
+		t.pending = t.pending[size:]
+	}
+}
+
+// solMessageSize returns the size of the message at the start of msg, 0 when more data is needed to tell
+// and -1 when the message is unknown.
+func solMessageSize(msg []byte) int {
+	switch msg[0] {
+	case solStartRedirectionSessionReply:
+		if len(msg) < startReplyHeaderSize {
+			return 0
+		}
+
+		return startReplyHeaderSize + int(msg[12])
+	case solAuthenticateSessionReply:
+		if len(msg) < authReplyHeaderSize {
+			return 0
+		}
+
+		return authReplyHeaderSize + int(binary.LittleEndian.Uint32(msg[5:9]))
+	case solSettingsReply:
+		return solSettingsReplySize
+	case solSerialSettings:
+		return solSerialSettingsSize
+	case solData:
+		if len(msg) < solDataHeaderSize {
+			return 0
+		}
+
+		return solDataHeaderSize + int(binary.LittleEndian.Uint16(msg[8:10]))
+	case solKeepAlive:
+		return solKeepAliveSize
+	default:
+		return -1
+	}
+}
+
+// text returns the output as plain text, without control sequences and with unix line endings.
+func (t *solTranscript) text() string {
+	text := ansiEscape.ReplaceAllString(t.output.String(), "")
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+
+	return strings.Map(func(r rune) rune {
+		if r == '\n' || r == '\t' || r >= ' ' && r != 0x7f {
+			return r
+		}
+
+		return -1
+	}, text)
+}
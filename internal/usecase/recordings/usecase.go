@@ -0,0 +1,371 @@
+package recordings
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity"
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/sqldb"
+	"github.com/open-amt-cloud-toolkit/console/pkg/consoleerrors"
+	"github.com/open-amt-cloud-toolkit/console/pkg/logger"
+	"github.com/open-amt-cloud-toolkit/console/pkg/subject"
+)
+
+const (
+	ModeKVM = "kvm"
+	ModeSOL = "sol"
+
+	fileExtension = ".rec"
+)
+
+// Config controls which redirection sessions are recorded and where.
+type Config struct {
+	Enabled bool
+	// Path is the directory recordings are written to, by default the recordings directory next to the database.
+	Path string
+	// Modes are the redirection modes that are recorded, such as kvm and sol.
+	Modes []string
+	// MaxSize caps a recording in bytes, the rest of a longer session is not recorded. Zero for no limit.
+	MaxSize int64
+}
+
+// UseCase -.
+type UseCase struct {
+	repo Repository
+	log  logger.Interface
+	cfg  Config
+}
+
+// New -.
+func New(r Repository, log logger.Interface, cfg Config) *UseCase {
+	if cfg.Path == "" {
+		if dirname, err := os.UserConfigDir(); err == nil {
+			cfg.Path = filepath.Join(dirname, "device-management-toolkit", "recordings")
+		}
+	}
+
+	return &UseCase{
+		repo: r,
+		log:  log,
+		cfg:  cfg,
+	}
+}
+
+var (
+	ErrRecordingsUseCase = consoleerrors.CreateConsoleError("RecordingsUseCase")
+	ErrDatabase          = sqldb.DatabaseError{Console: ErrRecordingsUseCase}
+	ErrNotFound          = sqldb.NotFoundError{Console: ErrRecordingsUseCase}
+	ErrNotValid          = dto.NotValidError{Console: ErrRecordingsUseCase}
+
+	errInProgress = errors.New("the session is still being recorded")
+	errNotSOL     = errors.New("only SOL sessions have a transcript")
+)
+
+// Record creates the recording of a session of device in mode, it is attributed to the subject of ctx.
+func (uc *UseCase) Record(ctx context.Context, device entity.Device, mode string) (Session, error) {
+	if !uc.records(mode) {
+		return nil, nil
+	}
+
+	if err := os.MkdirAll(uc.cfg.Path, 0o700); err != nil {
+		return nil, ErrRecordingsUseCase.Wrap("Record", "os.MkdirAll", err)
+	}
+
+	start := time.Now()
+	rec := entity.SessionRecording{
+		ID:        uuid.New().String(),
+		GUID:      device.GUID,
+		Hostname:  device.Hostname,
+		Mode:      strings.ToLower(mode),
+		Subject:   subject.FromContext(ctx),
+		StartTime: formatTime(start),
+		TenantID:  device.TenantID,
+	}
+	rec.FileName = rec.ID + fileExtension
+
+	path := filepath.Join(uc.cfg.Path, rec.FileName)
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, ErrRecordingsUseCase.Wrap("Record", "os.OpenFile", err)
+	}
+
+	w := bufio.NewWriter(file)
+
+	size, err := writeHeader(w, header{
+		ID:        rec.ID,
+		GUID:      rec.GUID,
+		Hostname:  rec.Hostname,
+		Mode:      rec.Mode,
+		Subject:   rec.Subject,
+		StartTime: rec.StartTime,
+	})
+	if err != nil {
+		file.Close()
+		os.Remove(path)
+
+		return nil, ErrRecordingsUseCase.Wrap("Record", "writeHeader", err)
+	}
+
+	if err := uc.repo.Insert(ctx, &rec); err != nil {
+		file.Close()
+		os.Remove(path)
+
+		return nil, ErrDatabase.Wrap("Record", "uc.repo.Insert", err)
+	}
+
+	return &session{
+		uc:    uc,
+		ctx:   context.WithoutCancel(ctx),
+		file:  file,
+		w:     w,
+		start: start,
+		rec:   rec,
+		size:  int64(size),
+	}, nil
+}
+
+func (uc *UseCase) records(mode string) bool {
+	if !uc.cfg.Enabled {
+		return false
+	}
+
+	for _, m := range uc.cfg.Modes {
+		if strings.EqualFold(m, mode) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (uc *UseCase) GetCount(ctx context.Context, tenantID string) (int, error) {
+	count, err := uc.repo.GetCount(ctx, tenantID)
+	if err != nil {
+		return 0, ErrDatabase.Wrap("GetCount", "uc.repo.GetCount", err)
+	}
+
+	return count, nil
+}
+
+func (uc *UseCase) Get(ctx context.Context, top, skip int, tenantID string) ([]dto.SessionRecording, error) {
+	data, err := uc.repo.Get(ctx, top, skip, tenantID)
+	if err != nil {
+		return nil, ErrDatabase.Wrap("Get", "uc.repo.Get", err)
+	}
+
+	d1 := make([]dto.SessionRecording, len(data))
+
+	for i := range data {
+		tmpEntity := data[i] // create a new variable to avoid memory aliasing
+		d1[i] = *entityToDTO(&tmpEntity)
+	}
+
+	return d1, nil
+}
+
+func (uc *UseCase) GetByID(ctx context.Context, id, tenantID string) (*dto.SessionRecording, error) {
+	data, err := uc.getByID(ctx, "GetByID", id, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	return entityToDTO(data), nil
+}
+
+// Delete removes a finished recording and its file.
+func (uc *UseCase) Delete(ctx context.Context, id, tenantID string) error {
+	data, err := uc.getByID(ctx, "Delete", id, tenantID)
+	if err != nil {
+		return err
+	}
+
+	if data.EndTime == "" {
+		return ErrNotValid.Wrap("Delete", "data.EndTime", errInProgress)
+	}
+
+	isSuccessful, err := uc.repo.Delete(ctx, id, tenantID)
+	if err != nil {
+		return ErrDatabase.Wrap("Delete", "uc.repo.Delete", err)
+	}
+
+	if !isSuccessful {
+		return ErrNotFound
+	}
+
+	if err := os.Remove(filepath.Join(uc.cfg.Path, filepath.Base(data.FileName))); err != nil && !errors.Is(err, os.ErrNotExist) {
+		uc.log.Warn("recordings - Delete - could not remove " + data.FileName + ": " + err.Error())
+	}
+
+	return nil
+}
+
+// Download returns the recording file as it was written.
+func (uc *UseCase) Download(ctx context.Context, id, tenantID string) (io.ReadCloser, error) {
+	data, err := uc.getByID(ctx, "Download", id, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.open("Download", data)
+}
+
+// GetFrames returns the frames of a recording in order, for a player to replay them at their offsets.
+func (uc *UseCase) GetFrames(ctx context.Context, id, tenantID string, top, skip int) ([]dto.RecordingFrame, error) {
+	data, err := uc.getByID(ctx, "GetFrames", id, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := uc.open("GetFrames", data)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	frames := make([]dto.RecordingFrame, 0)
+	index := 0
+	errDone := errors.New("done")
+
+	err = readRecording(file, func(f Frame) error {
+		index++
+
+		if index <= skip {
+			return nil
+		}
+
+		if top > 0 && len(frames) == top {
+			return errDone
+		}
+
+		frames = append(frames, frameToDTO(f))
+
+		return nil
+	})
+	if err != nil && !errors.Is(err, errDone) {
+		return nil, ErrRecordingsUseCase.Wrap("GetFrames", "readRecording", err)
+	}
+
+	return frames, nil
+}
+
+// Transcript returns the terminal output of a SOL recording as plain text.
+func (uc *UseCase) Transcript(ctx context.Context, id, tenantID string) (string, error) {
+	data, err := uc.getByID(ctx, "Transcript", id, tenantID)
+	if err != nil {
+		return "", err
+	}
+
+	if data.Mode != ModeSOL {
+		return "", ErrNotValid.Wrap("Transcript", "data.Mode", errNotSOL)
+	}
+
+	file, err := uc.open("Transcript", data)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	transcript := &solTranscript{}
+
+	err = readRecording(file, func(f Frame) error {
+		if f.Direction == FromDevice {
+			transcript.write(f.Data)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return "", ErrRecordingsUseCase.Wrap("Transcript", "readRecording", err)
+	}
+
+	return transcript.text(), nil
+}
+
+func (uc *UseCase) getByID(ctx context.Context, function, id, tenantID string) (*entity.SessionRecording, error) {
+	data, err := uc.repo.GetByID(ctx, id, tenantID)
+	if err != nil {
+		return nil, ErrDatabase.Wrap(function, "uc.repo.GetByID", err)
+	}
+
+	if data == nil {
+		return nil, ErrNotFound
+	}
+
+	return data, nil
+}
+
+// open returns the file of data, a recording whose file is gone is not found.
+func (uc *UseCase) open(function string, data *entity.SessionRecording) (*os.File, error) {
+	file, err := os.Open(filepath.Join(uc.cfg.Path, filepath.Base(data.FileName)))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, ErrNotFound
+		}
+
+		return nil, ErrRecordingsUseCase.Wrap(function, "os.Open", err)
+	}
+
+	return file, nil
+}
+
+// readRecording checks that r is a recording and calls fn with each of its frames.
+func readRecording(r io.Reader, fn func(Frame) error) error {
+	br := bufio.NewReader(r)
+
+	if _, err := readHeader(br); err != nil {
+		return err
+	}
+
+	return readFrames(br, fn)
+}
+
+// convert entity.SessionRecording to dto.SessionRecording.
+func entityToDTO(d *entity.SessionRecording) *dto.SessionRecording {
+	d1 := &dto.SessionRecording{
+		ID:        d.ID,
+		GUID:      d.GUID,
+		Hostname:  d.Hostname,
+		Mode:      d.Mode,
+		Subject:   d.Subject,
+		SizeBytes: d.SizeBytes,
+		Truncated: d.Truncated,
+		TenantID:  d.TenantID,
+	}
+
+	if t, err := time.Parse(time.RFC3339, d.StartTime); err == nil {
+		d1.StartTime = t
+	}
+
+	if t, err := time.Parse(time.RFC3339, d.EndTime); err == nil {
+		d1.EndTime = &t
+	}
+
+	return d1
+}
+
+func frameToDTO(f Frame) dto.RecordingFrame {
+	direction := dto.RecordingDirectionDevice
+	if f.Direction == FromBrowser {
+		direction = dto.RecordingDirectionBrowser
+	}
+
+	return dto.RecordingFrame{
+		OffsetMS:  f.Offset.Milliseconds(),
+		Direction: direction,
+		Data:      f.Data,
+	}
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
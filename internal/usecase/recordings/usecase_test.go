@@ -0,0 +1,241 @@
+package recordings_test
+
+import (
+	"context"
+	"encoding/binary"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity"
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	"github.com/open-amt-cloud-toolkit/console/internal/mocks"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/recordings"
+	"github.com/open-amt-cloud-toolkit/console/pkg/logger"
+	"github.com/open-amt-cloud-toolkit/console/pkg/subject"
+)
+
+var device = entity.Device{GUID: "guid-1", Hostname: "host1", TenantID: "tenant1"}
+
+// solData builds the message an Intel AMT device sends with SOL terminal output.
+func solData(text string) []byte {
+	msg := []byte{0x2A, 0, 0, 0, 1, 0, 0, 0, 0, 0}
+	binary.LittleEndian.PutUint16(msg[8:10], uint16(len(text)))
+
+	return append(msg, text...)
+}
+
+func TestRecordDisabled(t *testing.T) {
+	t.Parallel()
+
+	mockCtl := gomock.NewController(t)
+	repo := mocks.NewMockRecordingsRepository(mockCtl)
+
+	disabled := recordings.New(repo, logger.New("error"), recordings.Config{Path: t.TempDir(), Modes: []string{"sol"}})
+
+	s, err := disabled.Record(context.Background(), device, "sol")
+	require.NoError(t, err)
+	require.Nil(t, s)
+
+	kvmOnly := recordings.New(repo, logger.New("error"), recordings.Config{Enabled: true, Path: t.TempDir(), Modes: []string{"kvm"}})
+
+	s, err = kvmOnly.Record(context.Background(), device, "sol")
+	require.NoError(t, err)
+	require.Nil(t, s)
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	mockCtl := gomock.NewController(t)
+	repo := mocks.NewMockRecordingsRepository(mockCtl)
+	useCase := recordings.New(repo, logger.New("error"), recordings.Config{Enabled: true, Path: dir, Modes: []string{"kvm", "sol"}})
+
+	var stored entity.SessionRecording
+
+	repo.EXPECT().Insert(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, s *entity.SessionRecording) error {
+		stored = *s
+
+		return nil
+	})
+	repo.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, s *entity.SessionRecording) (bool, error) {
+		stored = *s
+
+		return true, nil
+	})
+
+	ctx := subject.NewContext(context.Background(), "alice")
+
+	s, err := useCase.Record(ctx, device, "SOL")
+	require.NoError(t, err)
+	require.NotNil(t, s)
+
+	require.Equal(t, "guid-1", stored.GUID)
+	require.Equal(t, "host1", stored.Hostname)
+	require.Equal(t, "sol", stored.Mode)
+	require.Equal(t, "alice", stored.Subject)
+	require.Equal(t, "tenant1", stored.TenantID)
+	require.Empty(t, stored.EndTime)
+
+	login := append(solData("\x1b[2J\x1b[1;1Hlogin: "), solData("root\r\n")[:4]...)
+
+	s.Write(recordings.FromBrowser, []byte("r"))
+	s.Write(recordings.FromDevice, login)
+	s.Write(recordings.FromDevice, append(solData("root\r\n")[4:], 0x2B, 0, 0, 0, 0, 0, 0, 0))
+	s.Write(recordings.FromDevice, solData("Password:\a \r\n"))
+	require.NoError(t, s.Close())
+	require.NoError(t, s.Close())
+
+	s.Write(recordings.FromDevice, solData("after close"))
+
+	info, err := os.Stat(filepath.Join(dir, stored.FileName))
+	require.NoError(t, err)
+	require.Equal(t, info.Size(), stored.SizeBytes)
+	require.NotEmpty(t, stored.EndTime)
+	require.False(t, stored.Truncated)
+
+	repo.EXPECT().GetByID(gomock.Any(), stored.ID, "tenant1").Return(&stored, nil).Times(4)
+
+	frames, err := useCase.GetFrames(context.Background(), stored.ID, "tenant1", 0, 0)
+	require.NoError(t, err)
+	require.Len(t, frames, 4)
+	require.Equal(t, dto.RecordingFrame{OffsetMS: frames[0].OffsetMS, Direction: dto.RecordingDirectionBrowser, Data: []byte("r")}, frames[0])
+	require.Equal(t, dto.RecordingDirectionDevice, frames[1].Direction)
+	require.Equal(t, login, frames[1].Data)
+
+	frames, err = useCase.GetFrames(context.Background(), stored.ID, "tenant1", 1, 3)
+	require.NoError(t, err)
+	require.Len(t, frames, 1)
+	require.Equal(t, solData("Password:\a \r\n"), frames[0].Data)
+
+	text, err := useCase.Transcript(context.Background(), stored.ID, "tenant1")
+	require.NoError(t, err)
+	require.Equal(t, "login: root\nPassword: \n", text)
+
+	file, err := useCase.Download(context.Background(), stored.ID, "tenant1")
+	require.NoError(t, err)
+
+	data, err := io.ReadAll(file)
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+	require.Len(t, data, int(stored.SizeBytes))
+	require.Equal(t, "AMTREC01", string(data[:8]))
+}
+
+func TestRecordMaxSize(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	mockCtl := gomock.NewController(t)
+	repo := mocks.NewMockRecordingsRepository(mockCtl)
+	useCase := recordings.New(repo, logger.New("error"), recordings.Config{Enabled: true, Path: dir, Modes: []string{"kvm"}, MaxSize: 1024})
+
+	var stored entity.SessionRecording
+
+	repo.EXPECT().Insert(gomock.Any(), gomock.Any()).Return(nil)
+	repo.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, s *entity.SessionRecording) (bool, error) {
+		stored = *s
+
+		return true, nil
+	})
+
+	s, err := useCase.Record(context.Background(), device, "KVM")
+	require.NoError(t, err)
+
+	// later frames are not recorded once one would not fit, even a smaller one
+	s.Write(recordings.FromDevice, make([]byte, 400))
+	s.Write(recordings.FromDevice, make([]byte, 400))
+	s.Write(recordings.FromDevice, make([]byte, 400))
+	s.Write(recordings.FromDevice, make([]byte, 10))
+	require.NoError(t, s.Close())
+
+	require.LessOrEqual(t, stored.SizeBytes, int64(1024))
+	require.True(t, stored.Truncated)
+
+	repo.EXPECT().GetByID(gomock.Any(), stored.ID, "tenant1").Return(&stored, nil)
+
+	frames, err := useCase.GetFrames(context.Background(), stored.ID, "tenant1", 0, 0)
+	require.NoError(t, err)
+	require.Len(t, frames, 2)
+}
+
+func TestTranscriptRequiresSOL(t *testing.T) {
+	t.Parallel()
+
+	mockCtl := gomock.NewController(t)
+	repo := mocks.NewMockRecordingsRepository(mockCtl)
+	useCase := recordings.New(repo, logger.New("error"), recordings.Config{Path: t.TempDir()})
+
+	repo.EXPECT().GetByID(gomock.Any(), "1", "tenant1").Return(&entity.SessionRecording{ID: "1", Mode: "kvm", FileName: "1.rec"}, nil)
+
+	_, err := useCase.Transcript(context.Background(), "1", "tenant1")
+	require.IsType(t, recordings.ErrNotValid, err)
+}
+
+func TestDelete(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		recording *entity.SessionRecording
+		deleted   bool
+		err       error
+	}{
+		{
+			name:      "finished recording",
+			recording: &entity.SessionRecording{ID: "1", FileName: "1.rec", EndTime: "2024-01-01T10:05:00Z"},
+			deleted:   true,
+		},
+		{
+			name:      "file name is kept inside the recordings directory",
+			recording: &entity.SessionRecording{ID: "1", FileName: "../../1.rec", EndTime: "2024-01-01T10:05:00Z"},
+			deleted:   true,
+		},
+		{
+			name:      "recording in progress",
+			recording: &entity.SessionRecording{ID: "1", FileName: "1.rec"},
+			err:       recordings.ErrNotValid,
+		},
+		{
+			name: "not found",
+			err:  recordings.ErrNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			dir := t.TempDir()
+			require.NoError(t, os.WriteFile(filepath.Join(dir, "1.rec"), []byte("AMTREC01"), 0o600))
+
+			mockCtl := gomock.NewController(t)
+			repo := mocks.NewMockRecordingsRepository(mockCtl)
+			useCase := recordings.New(repo, logger.New("error"), recordings.Config{Path: dir})
+
+			repo.EXPECT().GetByID(gomock.Any(), "1", "tenant1").Return(tc.recording, nil)
+
+			if tc.deleted {
+				repo.EXPECT().Delete(gomock.Any(), "1", "tenant1").Return(true, nil)
+			}
+
+			err := useCase.Delete(context.Background(), "1", "tenant1")
+			if tc.err != nil {
+				require.IsType(t, tc.err, err)
+				require.FileExists(t, filepath.Join(dir, "1.rec"))
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.NoFileExists(t, filepath.Join(dir, "1.rec"))
+		})
+	}
+}
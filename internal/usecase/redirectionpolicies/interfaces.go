@@ -0,0 +1,23 @@
+package redirectionpolicies
+
+import (
+	"context"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity"
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+)
+
+type (
+	Repository interface {
+		Get(ctx context.Context, tenantID string) (*entity.RedirectionPolicy, error)
+		Upsert(ctx context.Context, p *entity.RedirectionPolicy) error
+		Delete(ctx context.Context, tenantID string) (bool, error)
+	}
+	Feature interface {
+		// Get returns the policy of a tenant, the configured defaults when it has none of its own.
+		Get(ctx context.Context, tenantID string) (dto.RedirectionPolicy, error)
+		Update(ctx context.Context, d *dto.RedirectionPolicy) (*dto.RedirectionPolicy, error)
+		// Delete removes the policy of a tenant so that the defaults apply to it again.
+		Delete(ctx context.Context, tenantID string) error
+	}
+)
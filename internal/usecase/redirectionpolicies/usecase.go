@@ -0,0 +1,110 @@
+package redirectionpolicies
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity"
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/sqldb"
+	"github.com/open-amt-cloud-toolkit/console/pkg/consoleerrors"
+	"github.com/open-amt-cloud-toolkit/console/pkg/logger"
+)
+
+// Config is the policy of the tenants that have none of their own, zero means no limit.
+type Config struct {
+	IdleTimeout          time.Duration
+	MaxDuration          time.Duration
+	MaxSessionsPerUser   int
+	MaxSessionsPerDevice int
+}
+
+// UseCase -.
+type UseCase struct {
+	repo Repository
+	log  logger.Interface
+	cfg  Config
+}
+
+// New -.
+func New(r Repository, log logger.Interface, cfg Config) *UseCase {
+	return &UseCase{
+		repo: r,
+		log:  log,
+		cfg:  cfg,
+	}
+}
+
+var (
+	ErrRedirectionPoliciesUseCase = consoleerrors.CreateConsoleError("RedirectionPoliciesUseCase")
+	ErrDatabase                   = sqldb.DatabaseError{Console: ErrRedirectionPoliciesUseCase}
+	ErrNotFound                   = sqldb.NotFoundError{Console: ErrRedirectionPoliciesUseCase}
+	ErrNotValid                   = dto.NotValidError{Console: ErrRedirectionPoliciesUseCase}
+
+	errNegative = errors.New("limits cannot be negative, 0 means no limit")
+)
+
+func (uc *UseCase) Get(ctx context.Context, tenantID string) (dto.RedirectionPolicy, error) {
+	data, err := uc.repo.Get(ctx, tenantID)
+	if err != nil {
+		return dto.RedirectionPolicy{}, ErrDatabase.Wrap("Get", "uc.repo.Get", err)
+	}
+
+	if data == nil {
+		return dto.RedirectionPolicy{
+			IdleTimeoutSeconds:   int(uc.cfg.IdleTimeout / time.Second),
+			MaxDurationSeconds:   int(uc.cfg.MaxDuration / time.Second),
+			MaxSessionsPerUser:   uc.cfg.MaxSessionsPerUser,
+			MaxSessionsPerDevice: uc.cfg.MaxSessionsPerDevice,
+			TenantID:             tenantID,
+			Default:              true,
+		}, nil
+	}
+
+	return *entityToDTO(data), nil
+}
+
+func (uc *UseCase) Update(ctx context.Context, d *dto.RedirectionPolicy) (*dto.RedirectionPolicy, error) {
+	if d.IdleTimeoutSeconds < 0 || d.MaxDurationSeconds < 0 || d.MaxSessionsPerUser < 0 || d.MaxSessionsPerDevice < 0 {
+		return nil, ErrNotValid.Wrap("Update", "validate", errNegative)
+	}
+
+	data := &entity.RedirectionPolicy{
+		IdleTimeout:          d.IdleTimeoutSeconds,
+		MaxDuration:          d.MaxDurationSeconds,
+		MaxSessionsPerUser:   d.MaxSessionsPerUser,
+		MaxSessionsPerDevice: d.MaxSessionsPerDevice,
+		TenantID:             d.TenantID,
+	}
+
+	if err := uc.repo.Upsert(ctx, data); err != nil {
+		return nil, ErrDatabase.Wrap("Update", "uc.repo.Upsert", err)
+	}
+
+	return entityToDTO(data), nil
+}
+
+func (uc *UseCase) Delete(ctx context.Context, tenantID string) error {
+	isSuccessful, err := uc.repo.Delete(ctx, tenantID)
+	if err != nil {
+		return ErrDatabase.Wrap("Delete", "uc.repo.Delete", err)
+	}
+
+	if !isSuccessful {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// convert entity.RedirectionPolicy to dto.RedirectionPolicy.
+func entityToDTO(d *entity.RedirectionPolicy) *dto.RedirectionPolicy {
+	return &dto.RedirectionPolicy{
+		IdleTimeoutSeconds:   d.IdleTimeout,
+		MaxDurationSeconds:   d.MaxDuration,
+		MaxSessionsPerUser:   d.MaxSessionsPerUser,
+		MaxSessionsPerDevice: d.MaxSessionsPerDevice,
+		TenantID:             d.TenantID,
+	}
+}
@@ -0,0 +1,211 @@
+package redirectionpolicies_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity"
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	"github.com/open-amt-cloud-toolkit/console/internal/mocks"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/redirectionpolicies"
+	"github.com/open-amt-cloud-toolkit/console/pkg/logger"
+)
+
+var errDB = errors.New("database error")
+
+func initPoliciesTest(t *testing.T) (*redirectionpolicies.UseCase, *mocks.MockRedirectionPoliciesRepository) {
+	t.Helper()
+
+	mockCtl := gomock.NewController(t)
+	repo := mocks.NewMockRedirectionPoliciesRepository(mockCtl)
+	useCase := redirectionpolicies.New(repo, logger.New("error"), redirectionpolicies.Config{
+		IdleTimeout:        15 * time.Minute,
+		MaxSessionsPerUser: 2,
+	})
+
+	return useCase, repo
+}
+
+func TestGet(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		mock func(*mocks.MockRedirectionPoliciesRepository)
+		res  dto.RedirectionPolicy
+		err  error
+	}{
+		{
+			name: "tenant policy",
+			mock: func(repo *mocks.MockRedirectionPoliciesRepository) {
+				repo.EXPECT().Get(context.Background(), "tenant1").Return(&entity.RedirectionPolicy{
+					IdleTimeout:          300,
+					MaxDuration:          3600,
+					MaxSessionsPerDevice: 1,
+					TenantID:             "tenant1",
+				}, nil)
+			},
+			res: dto.RedirectionPolicy{
+				IdleTimeoutSeconds:   300,
+				MaxDurationSeconds:   3600,
+				MaxSessionsPerDevice: 1,
+				TenantID:             "tenant1",
+			},
+		},
+		{
+			name: "configured default",
+			mock: func(repo *mocks.MockRedirectionPoliciesRepository) {
+				repo.EXPECT().Get(context.Background(), "tenant1").Return(nil, nil)
+			},
+			res: dto.RedirectionPolicy{
+				IdleTimeoutSeconds: 900,
+				MaxSessionsPerUser: 2,
+				TenantID:           "tenant1",
+				Default:            true,
+			},
+		},
+		{
+			name: "database error",
+			mock: func(repo *mocks.MockRedirectionPoliciesRepository) {
+				repo.EXPECT().Get(context.Background(), "tenant1").Return(nil, errDB)
+			},
+			err: redirectionpolicies.ErrDatabase,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			useCase, repo := initPoliciesTest(t)
+			tc.mock(repo)
+
+			res, err := useCase.Get(context.Background(), "tenant1")
+			if tc.err != nil {
+				require.IsType(t, tc.err, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.res, res)
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		policy dto.RedirectionPolicy
+		mock   func(*mocks.MockRedirectionPoliciesRepository)
+		err    error
+	}{
+		{
+			name:   "success",
+			policy: dto.RedirectionPolicy{IdleTimeoutSeconds: 600, MaxSessionsPerUser: 1, TenantID: "tenant1"},
+			mock: func(repo *mocks.MockRedirectionPoliciesRepository) {
+				repo.EXPECT().Upsert(context.Background(), &entity.RedirectionPolicy{
+					IdleTimeout:        600,
+					MaxSessionsPerUser: 1,
+					TenantID:           "tenant1",
+				}).Return(nil)
+			},
+		},
+		{
+			name:   "negative limit",
+			policy: dto.RedirectionPolicy{MaxDurationSeconds: -1, TenantID: "tenant1"},
+			mock:   func(_ *mocks.MockRedirectionPoliciesRepository) {},
+			err:    redirectionpolicies.ErrNotValid,
+		},
+		{
+			name:   "database error",
+			policy: dto.RedirectionPolicy{TenantID: "tenant1"},
+			mock: func(repo *mocks.MockRedirectionPoliciesRepository) {
+				repo.EXPECT().Upsert(context.Background(), gomock.Any()).Return(errDB)
+			},
+			err: redirectionpolicies.ErrDatabase,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			useCase, repo := initPoliciesTest(t)
+			tc.mock(repo)
+
+			res, err := useCase.Update(context.Background(), &tc.policy)
+			if tc.err != nil {
+				require.IsType(t, tc.err, err)
+				require.Nil(t, res)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, &tc.policy, res)
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		mock func(*mocks.MockRedirectionPoliciesRepository)
+		err  error
+	}{
+		{
+			name: "success",
+			mock: func(repo *mocks.MockRedirectionPoliciesRepository) {
+				repo.EXPECT().Delete(context.Background(), "tenant1").Return(true, nil)
+			},
+		},
+		{
+			name: "no policy",
+			mock: func(repo *mocks.MockRedirectionPoliciesRepository) {
+				repo.EXPECT().Delete(context.Background(), "tenant1").Return(false, nil)
+			},
+			err: redirectionpolicies.ErrNotFound,
+		},
+		{
+			name: "database error",
+			mock: func(repo *mocks.MockRedirectionPoliciesRepository) {
+				repo.EXPECT().Delete(context.Background(), "tenant1").Return(false, errDB)
+			},
+			err: redirectionpolicies.ErrDatabase,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			useCase, repo := initPoliciesTest(t)
+			tc.mock(repo)
+
+			err := useCase.Delete(context.Background(), "tenant1")
+			if tc.err != nil {
+				require.IsType(t, tc.err, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
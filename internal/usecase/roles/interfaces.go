@@ -0,0 +1,25 @@
+package roles
+
+import (
+	"context"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity"
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+)
+
+type (
+	Repository interface {
+		GetCount(ctx context.Context, tenantID string) (int, error)
+		Get(ctx context.Context, top, skip int, tenantID string) ([]entity.RoleBinding, error)
+		GetBySubjects(ctx context.Context, subjects []string, tenantID string) ([]entity.RoleBinding, error)
+		Insert(ctx context.Context, b *entity.RoleBinding) error
+		Delete(ctx context.Context, subject, role, tenantID string) (bool, error)
+	}
+	Feature interface {
+		GetCount(ctx context.Context, tenantID string) (int, error)
+		Get(ctx context.Context, top, skip int, tenantID string) ([]dto.RoleBinding, error)
+		Insert(ctx context.Context, b *dto.RoleBinding) (*dto.RoleBinding, error)
+		Delete(ctx context.Context, subject, role, tenantID string) error
+		Resolve(ctx context.Context, subjects, groups []string, tenantID string) ([]string, error)
+	}
+)
@@ -0,0 +1,35 @@
+package roles
+
+import "github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+
+var rolePermissions = map[string][]string{
+	dto.RoleViewer:      {dto.PermissionRead},
+	dto.RoleOperator:    {dto.PermissionRead, dto.PermissionOperate, dto.PermissionRedirect},
+	dto.RoleAdmin:       {dto.PermissionRead, dto.PermissionOperate, dto.PermissionRedirect, dto.PermissionAdmin},
+	dto.RoleRedirection: {dto.PermissionRedirect},
+}
+
+// Permissions returns the permissions granted by role, or none for an unknown role.
+func Permissions(role string) []string {
+	return rolePermissions[role]
+}
+
+// IsRole reports whether role is one of the built in roles.
+func IsRole(role string) bool {
+	_, ok := rolePermissions[role]
+
+	return ok
+}
+
+// HasPermission reports whether any of roles grants permission.
+func HasPermission(roles []string, permission string) bool {
+	for _, role := range roles {
+		for _, p := range rolePermissions[role] {
+			if p == permission {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,139 @@
+package roles
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity"
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/sqldb"
+	"github.com/open-amt-cloud-toolkit/console/pkg/consoleerrors"
+	"github.com/open-amt-cloud-toolkit/console/pkg/logger"
+)
+
+// UseCase -.
+type UseCase struct {
+	repo       Repository
+	groupRoles map[string]string
+	log        logger.Interface
+}
+
+// New -. groupRoles maps an OIDC group claim value to the role its members get.
+func New(r Repository, groupRoles map[string]string, log logger.Interface) *UseCase {
+	return &UseCase{
+		repo:       r,
+		groupRoles: groupRoles,
+		log:        log,
+	}
+}
+
+var (
+	ErrRolesUseCase = consoleerrors.CreateConsoleError("RolesUseCase")
+	ErrDatabase     = sqldb.DatabaseError{Console: ErrRolesUseCase}
+	ErrNotFound     = sqldb.NotFoundError{Console: ErrRolesUseCase}
+	ErrNotValid     = dto.NotValidError{Console: ErrRolesUseCase}
+)
+
+func (uc *UseCase) GetCount(ctx context.Context, tenantID string) (int, error) {
+	count, err := uc.repo.GetCount(ctx, tenantID)
+	if err != nil {
+		return 0, ErrDatabase.Wrap("GetCount", "uc.repo.GetCount", err)
+	}
+
+	return count, nil
+}
+
+func (uc *UseCase) Get(ctx context.Context, top, skip int, tenantID string) ([]dto.RoleBinding, error) {
+	data, err := uc.repo.Get(ctx, top, skip, tenantID)
+	if err != nil {
+		return nil, ErrDatabase.Wrap("Get", "uc.repo.Get", err)
+	}
+
+	d1 := make([]dto.RoleBinding, len(data))
+
+	for i := range data {
+		tmpEntity := data[i] // create a new variable to avoid memory aliasing
+		d1[i] = *uc.entityToDTO(&tmpEntity)
+	}
+
+	return d1, nil
+}
+
+func (uc *UseCase) Insert(ctx context.Context, d *dto.RoleBinding) (*dto.RoleBinding, error) {
+	if !IsRole(d.Role) {
+		return nil, ErrNotValid.Wrap("Insert", "IsRole", errors.New("unknown role "+d.Role))
+	}
+
+	d1 := &entity.RoleBinding{
+		Subject:      d.Subject,
+		Role:         d.Role,
+		CreationDate: time.Now().UTC().Format(time.RFC3339),
+		TenantID:     d.TenantID,
+	}
+
+	if err := uc.repo.Insert(ctx, d1); err != nil {
+		return nil, ErrDatabase.Wrap("Insert", "uc.repo.Insert", err)
+	}
+
+	return uc.entityToDTO(d1), nil
+}
+
+func (uc *UseCase) Delete(ctx context.Context, subject, role, tenantID string) error {
+	isSuccessful, err := uc.repo.Delete(ctx, subject, role, tenantID)
+	if err != nil {
+		return ErrDatabase.Wrap("Delete", "uc.repo.Delete", err)
+	}
+
+	if !isSuccessful {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// Resolve returns the sorted roles held by a caller known by any of subjects and belonging to groups,
+// combining the role bindings in the database with the configured group mapping.
+func (uc *UseCase) Resolve(ctx context.Context, subjects, groups []string, tenantID string) ([]string, error) {
+	bindings, err := uc.repo.GetBySubjects(ctx, subjects, tenantID)
+	if err != nil {
+		return nil, ErrDatabase.Wrap("Resolve", "uc.repo.GetBySubjects", err)
+	}
+
+	found := make(map[string]bool)
+
+	for i := range bindings {
+		found[bindings[i].Role] = true
+	}
+
+	for _, group := range groups {
+		if role, ok := uc.groupRoles[group]; ok && IsRole(role) {
+			found[role] = true
+		}
+	}
+
+	result := make([]string, 0, len(found))
+	for role := range found {
+		result = append(result, role)
+	}
+
+	sort.Strings(result)
+
+	return result, nil
+}
+
+func (uc *UseCase) entityToDTO(d *entity.RoleBinding) *dto.RoleBinding {
+	// parse creation date
+	creationDate, err := time.Parse(time.RFC3339, d.CreationDate)
+	if err != nil {
+		uc.log.Warn("roles - entityToDTO - creation date %s could not be parsed", d.CreationDate)
+	}
+
+	return &dto.RoleBinding{
+		Subject:      d.Subject,
+		Role:         d.Role,
+		CreationDate: creationDate,
+		TenantID:     d.TenantID,
+	}
+}
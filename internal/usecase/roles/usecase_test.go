@@ -0,0 +1,136 @@
+package roles_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity"
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	"github.com/open-amt-cloud-toolkit/console/internal/mocks"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/roles"
+	"github.com/open-amt-cloud-toolkit/console/pkg/logger"
+)
+
+func rolesTest(t *testing.T) (*roles.UseCase, *mocks.MockRolesRepository) {
+	t.Helper()
+
+	mockCtl := gomock.NewController(t)
+
+	repo := mocks.NewMockRolesRepository(mockCtl)
+	log := logger.New("error")
+	useCase := roles.New(repo, map[string]string{"amt-admins": dto.RoleAdmin, "helpdesk": dto.RoleRedirection, "typo": "superuser"}, log)
+
+	return useCase, repo
+}
+
+func TestResolve(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		subjects []string
+		groups   []string
+		bindings []entity.RoleBinding
+		res      []string
+		err      error
+	}{
+		{
+			name:     "database bindings only",
+			subjects: []string{"alice"},
+			bindings: []entity.RoleBinding{{Subject: "alice", Role: dto.RoleOperator}},
+			res:      []string{dto.RoleOperator},
+		},
+		{
+			name:     "groups are mapped and merged with bindings",
+			subjects: []string{"sub-1", "bob@example.com"},
+			groups:   []string{"helpdesk", "amt-admins", "unmapped", "typo"},
+			bindings: []entity.RoleBinding{{Subject: "bob@example.com", Role: dto.RoleRedirection}},
+			res:      []string{dto.RoleAdmin, dto.RoleRedirection},
+		},
+		{
+			name:     "no roles",
+			subjects: []string{"carol"},
+			res:      []string{},
+		},
+		{
+			name:     "database error",
+			subjects: []string{"alice"},
+			err:      roles.ErrDatabase,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			useCase, repo := rolesTest(t)
+
+			if tc.err != nil {
+				repo.EXPECT().GetBySubjects(context.Background(), tc.subjects, "").Return(nil, tc.err)
+			} else {
+				repo.EXPECT().GetBySubjects(context.Background(), tc.subjects, "").Return(tc.bindings, nil)
+			}
+
+			res, err := useCase.Resolve(context.Background(), tc.subjects, tc.groups, "")
+			if tc.err != nil {
+				require.IsType(t, tc.err, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.res, res)
+		})
+	}
+}
+
+func TestInsertRoleBinding(t *testing.T) {
+	t.Parallel()
+
+	useCase, repo := rolesTest(t)
+
+	repo.EXPECT().Insert(context.Background(), gomock.Any()).DoAndReturn(func(_ context.Context, b *entity.RoleBinding) error {
+		require.Equal(t, "alice", b.Subject)
+		require.Equal(t, dto.RoleViewer, b.Role)
+		require.NotEmpty(t, b.CreationDate)
+
+		return nil
+	})
+
+	res, err := useCase.Insert(context.Background(), &dto.RoleBinding{Subject: "alice", Role: dto.RoleViewer})
+	require.NoError(t, err)
+	require.Equal(t, "alice", res.Subject)
+	require.False(t, res.CreationDate.IsZero())
+
+	_, err = useCase.Insert(context.Background(), &dto.RoleBinding{Subject: "alice", Role: "superuser"})
+	require.IsType(t, roles.ErrNotValid, err)
+}
+
+func TestDeleteRoleBinding(t *testing.T) {
+	t.Parallel()
+
+	useCase, repo := rolesTest(t)
+
+	repo.EXPECT().Delete(context.Background(), "alice", dto.RoleViewer, "").Return(true, nil)
+	require.NoError(t, useCase.Delete(context.Background(), "alice", dto.RoleViewer, ""))
+
+	repo.EXPECT().Delete(context.Background(), "alice", dto.RoleAdmin, "").Return(false, nil)
+	require.IsType(t, roles.ErrNotFound, useCase.Delete(context.Background(), "alice", dto.RoleAdmin, ""))
+}
+
+func TestHasPermission(t *testing.T) {
+	t.Parallel()
+
+	require.True(t, roles.HasPermission([]string{dto.RoleAdmin}, dto.PermissionAdmin))
+	require.True(t, roles.HasPermission([]string{dto.RoleViewer, dto.RoleOperator}, dto.PermissionOperate))
+	require.False(t, roles.HasPermission([]string{dto.RoleOperator}, dto.PermissionAdmin))
+	require.False(t, roles.HasPermission([]string{dto.RoleViewer}, dto.PermissionRedirect))
+	require.True(t, roles.HasPermission([]string{dto.RoleRedirection}, dto.PermissionRedirect))
+	require.False(t, roles.HasPermission([]string{dto.RoleRedirection}, dto.PermissionRead))
+	require.False(t, roles.HasPermission(nil, dto.PermissionRead))
+}
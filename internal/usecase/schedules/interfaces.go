@@ -0,0 +1,45 @@
+package schedules
+
+import (
+	"context"
+
+	"github.com/open-amt-cloud-toolkit/go-wsman-messages/v2/pkg/wsman/cim/power"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity"
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	dtov2 "github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v2"
+)
+
+type (
+	Repository interface {
+		GetCount(ctx context.Context, tenantID string) (int, error)
+		Get(ctx context.Context, top, skip int, tenantID string) ([]entity.ScheduledJob, error)
+		GetByID(ctx context.Context, id, tenantID string) (*entity.ScheduledJob, error)
+		GetDue(ctx context.Context, now string) ([]entity.ScheduledJob, error)
+		Delete(ctx context.Context, id, tenantID string) (bool, error)
+		Update(ctx context.Context, j *entity.ScheduledJob) (bool, error)
+		UpdateRunTimes(ctx context.Context, id, tenantID, dueRun, lastRun, nextRun string) (bool, error)
+		Insert(ctx context.Context, j *entity.ScheduledJob) (string, error)
+		InsertRun(ctx context.Context, run *entity.JobRun) error
+		GetRuns(ctx context.Context, jobID string, top, skip int, tenantID string) ([]entity.JobRun, error)
+		GetDueRetries(ctx context.Context, now string) ([]entity.JobRun, error)
+		UpdateRunStatus(ctx context.Context, id, tenantID, from, to string) (bool, error)
+	}
+	Feature interface {
+		GetCount(ctx context.Context, tenantID string) (int, error)
+		Get(ctx context.Context, top, skip int, tenantID string) ([]dto.ScheduledJob, error)
+		GetByID(ctx context.Context, id, tenantID string) (*dto.ScheduledJob, error)
+		Delete(ctx context.Context, id, tenantID string) error
+		Update(ctx context.Context, j *dto.ScheduledJob) (*dto.ScheduledJob, error)
+		Insert(ctx context.Context, j *dto.ScheduledJob) (*dto.ScheduledJob, error)
+		GetRuns(ctx context.Context, jobID string, top, skip int, tenantID string) ([]dto.JobRun, error)
+		Run(ctx context.Context)
+	}
+	// Devices is the part of the device management feature a scheduled job can drive.
+	Devices interface {
+		GetByTags(ctx context.Context, tags, method string, limit, offset int, tenantID string) ([]dto.Device, error)
+		SendPowerAction(ctx context.Context, guid string, action int) (power.PowerActionResponse, error)
+		SetBootOptions(ctx context.Context, guid string, bootSetting dto.BootSetting) (power.PowerActionResponse, error)
+		SetFeatures(ctx context.Context, guid string, features dto.Features) (dto.Features, dtov2.Features, error)
+	}
+)
@@ -0,0 +1,238 @@
+package schedules
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity"
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	"github.com/open-amt-cloud-toolkit/console/pkg/tenant"
+)
+
+const (
+	maxConcurrency = 10
+	tagPageSize    = 100
+)
+
+// Run fires due jobs and pending retries every interval until ctx is done.
+func (uc *UseCase) Run(ctx context.Context) {
+	ticker := time.NewTicker(uc.interval)
+	defer ticker.Stop()
+
+	for {
+		uc.ProcessDue(ctx, time.Now())
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ProcessDue fires every job and retry that is due at now and waits for them to finish.
+func (uc *UseCase) ProcessDue(ctx context.Context, now time.Time) {
+	jobs, err := uc.repo.GetDue(ctx, formatTime(now))
+	if err != nil {
+		uc.log.Error(err, "schedules - ProcessDue - uc.repo.GetDue")
+	}
+
+	for i := range jobs {
+		uc.fire(ctx, &jobs[i], now)
+	}
+
+	retries, err := uc.repo.GetDueRetries(ctx, formatTime(now))
+	if err != nil {
+		uc.log.Error(err, "schedules - ProcessDue - uc.repo.GetDueRetries")
+	}
+
+	for i := range retries {
+		uc.retry(ctx, &retries[i], now)
+	}
+}
+
+func (uc *UseCase) fire(ctx context.Context, job *entity.ScheduledJob, now time.Time) {
+	// runs missed while the console was down are skipped, a cron job always moves on to its next occurrence after now
+	next, err := nextRun(job, formatTime(now), now)
+	if err != nil {
+		uc.log.Error(err, "schedules - fire - job "+job.ID)
+	}
+
+	// claim the job before running it so a slow run is not fired again on the next tick, a job that is no
+	// longer due when claimed was fired by another console or changed meanwhile
+	claimed, err := uc.repo.UpdateRunTimes(ctx, job.ID, job.TenantID, job.NextRun, formatTime(now), next)
+	if err != nil {
+		uc.log.Error(err, "schedules - fire - uc.repo.UpdateRunTimes")
+
+		return
+	}
+
+	if !claimed {
+		uc.log.Debug("schedules - fire - job " + job.ID + " was claimed elsewhere")
+
+		return
+	}
+
+	guids, err := uc.resolveTargets(ctx, job)
+	if err != nil {
+		uc.log.Error(err, "schedules - fire - uc.resolveTargets")
+
+		return
+	}
+
+	sem := make(chan struct{}, maxConcurrency)
+
+	var wg sync.WaitGroup
+
+	for _, guid := range guids {
+		wg.Add(1)
+
+		sem <- struct{}{}
+
+		go func(guid string) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			uc.execute(ctx, job, guid, 1, now)
+		}(guid)
+	}
+
+	wg.Wait()
+}
+
+func (uc *UseCase) retry(ctx context.Context, run *entity.JobRun, now time.Time) {
+	claimed, err := uc.repo.UpdateRunStatus(ctx, run.ID, run.TenantID, dto.JobRunStatusRetryPending, dto.JobRunStatusRetried)
+	if err != nil {
+		uc.log.Error(err, "schedules - retry - uc.repo.UpdateRunStatus")
+
+		return
+	}
+
+	if !claimed {
+		uc.log.Debug("schedules - retry - run " + run.ID + " was claimed elsewhere")
+
+		return
+	}
+
+	job, err := uc.repo.GetByID(ctx, run.JobID, run.TenantID)
+	if err != nil {
+		uc.log.Error(err, "schedules - retry - uc.repo.GetByID")
+
+		return
+	}
+
+	if job == nil || !job.Enabled {
+		return
+	}
+
+	uc.execute(ctx, job, run.GUID, run.Attempt+1, now)
+}
+
+func (uc *UseCase) resolveTargets(ctx context.Context, job *entity.ScheduledJob) ([]string, error) {
+	seen := make(map[string]bool)
+	guids := make([]string, 0)
+
+	add := func(guid string) {
+		if guid != "" && !seen[guid] {
+			seen[guid] = true
+
+			guids = append(guids, guid)
+		}
+	}
+
+	for _, guid := range splitList(job.TargetGUIDs) {
+		add(strings.TrimSpace(guid))
+	}
+
+	if job.TargetTags != "" {
+		for offset := 0; ; offset += tagPageSize {
+			data, err := uc.devices.GetByTags(ctx, job.TargetTags, job.TargetMethod, tagPageSize, offset, job.TenantID)
+			if err != nil {
+				return nil, err
+			}
+
+			for i := range data {
+				add(data[i].GUID)
+			}
+
+			if len(data) < tagPageSize {
+				break
+			}
+		}
+	}
+
+	return guids, nil
+}
+
+// execute runs the job against one device and records the outcome. A device that cannot be
+// reached is retried after the job's retry interval until its retries are used up.
+func (uc *UseCase) execute(ctx context.Context, job *entity.ScheduledJob, guid string, attempt int, now time.Time) {
+	run := &entity.JobRun{
+		ID:        uuid.New().String(),
+		JobID:     job.ID,
+		GUID:      guid,
+		Attempt:   attempt,
+		Status:    dto.JobRunStatusSuccess,
+		StartedAt: formatTime(now),
+		TenantID:  job.TenantID,
+	}
+
+	returnValue, err := uc.dispatch(ctx, job, guid)
+
+	run.ReturnValue = returnValue
+	run.FinishedAt = formatTime(time.Now())
+
+	var netErr net.Error
+
+	switch {
+	case err != nil && errors.As(err, &netErr) && attempt <= job.MaxRetries:
+		run.Status = dto.JobRunStatusRetryPending
+		run.Error = err.Error()
+		run.RetryAt = formatTime(now.Add(time.Duration(job.RetryInterval) * time.Second))
+	case err != nil:
+		run.Status = dto.JobRunStatusFailed
+		run.Error = err.Error()
+	case returnValue != 0:
+		run.Status = dto.JobRunStatusFailed
+	}
+
+	if err := uc.repo.InsertRun(ctx, run); err != nil {
+		uc.log.Error(err, "schedules - execute - uc.repo.InsertRun")
+	}
+}
+
+func (uc *UseCase) dispatch(ctx context.Context, job *entity.ScheduledJob, guid string) (int, error) {
+	// the job may only reach devices of the tenant that scheduled it
+	ctx = tenant.NewContext(ctx, job.TenantID)
+
+	p := payload{}
+	if err := json.Unmarshal([]byte(job.Payload), &p); err != nil {
+		return 0, err
+	}
+
+	switch {
+	case job.JobType == dto.JobTypePower && p.PowerAction != nil:
+		response, err := uc.devices.SendPowerAction(ctx, guid, p.PowerAction.Action)
+
+		return int(response.ReturnValue), err
+	case job.JobType == dto.JobTypeBoot && p.BootSetting != nil:
+		response, err := uc.devices.SetBootOptions(ctx, guid, *p.BootSetting)
+
+		return int(response.ReturnValue), err
+	case job.JobType == dto.JobTypeFeatures && p.Features != nil:
+		_, _, err := uc.devices.SetFeatures(ctx, guid, *p.Features)
+
+		return 0, err
+	}
+
+	return 0, ErrNotValid.Wrap("dispatch", "job.Payload", errors.New("payload does not match job type "+job.JobType))
+}
@@ -0,0 +1,193 @@
+package schedules_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/open-amt-cloud-toolkit/go-wsman-messages/v2/pkg/wsman/cim/power"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity"
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	dtov2 "github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v2"
+	"github.com/open-amt-cloud-toolkit/console/internal/mocks"
+)
+
+var errUnreachable = &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}
+
+func TestProcessDue(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 1, 1, 2, 0, 30, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		job      entity.ScheduledJob
+		nextRun  string
+		mock     func(devices *mocks.MockSchedulesDevices)
+		expected map[string]entity.JobRun
+	}{
+		{
+			name: "cron power job moves to next occurrence",
+			job: entity.ScheduledJob{
+				ID: "job-1", JobType: dto.JobTypePower, ScheduleType: dto.ScheduleTypeCron, CronExpression: "0 2 * * *",
+				Payload: `{"powerAction":{"action":10}}`, TargetGUIDs: "guid-1,guid-2", Enabled: true,
+			},
+			nextRun: "2024-01-02T02:00:00Z",
+			mock: func(devices *mocks.MockSchedulesDevices) {
+				devices.EXPECT().SendPowerAction(gomock.Any(), "guid-1", 10).Return(power.PowerActionResponse{ReturnValue: 0}, nil)
+				devices.EXPECT().SendPowerAction(gomock.Any(), "guid-2", 10).Return(power.PowerActionResponse{ReturnValue: 1}, nil)
+			},
+			expected: map[string]entity.JobRun{
+				"guid-1": {GUID: "guid-1", Attempt: 1, Status: dto.JobRunStatusSuccess},
+				"guid-2": {GUID: "guid-2", Attempt: 1, Status: dto.JobRunStatusFailed, ReturnValue: 1},
+			},
+		},
+		{
+			name: "one-off boot job by tag with unreachable device",
+			job: entity.ScheduledJob{
+				ID: "job-2", JobType: dto.JobTypeBoot, ScheduleType: dto.ScheduleTypeOnce, RunAt: "2024-01-01T02:00:00Z",
+				Payload: `{"bootSetting":{"action":400}}`, TargetTags: "lab", TargetMethod: "OR", MaxRetries: 1, RetryInterval: 60, Enabled: true,
+			},
+			nextRun: "",
+			mock: func(devices *mocks.MockSchedulesDevices) {
+				devices.EXPECT().GetByTags(gomock.Any(), "lab", "OR", 100, 0, "").Return([]dto.Device{{GUID: "guid-3"}}, nil)
+				devices.EXPECT().SetBootOptions(gomock.Any(), "guid-3", dto.BootSetting{Action: 400}).Return(power.PowerActionResponse{}, errUnreachable)
+			},
+			expected: map[string]entity.JobRun{
+				"guid-3": {GUID: "guid-3", Attempt: 1, Status: dto.JobRunStatusRetryPending, Error: errUnreachable.Error(), RetryAt: "2024-01-01T02:01:30Z"},
+			},
+		},
+		{
+			name: "features job without retries",
+			job: entity.ScheduledJob{
+				ID: "job-3", JobType: dto.JobTypeFeatures, ScheduleType: dto.ScheduleTypeOnce, RunAt: "2024-01-01T02:00:00Z",
+				Payload: `{"features":{"enableKVM":true}}`, TargetGUIDs: "guid-4", Enabled: true,
+			},
+			mock: func(devices *mocks.MockSchedulesDevices) {
+				devices.EXPECT().SetFeatures(gomock.Any(), "guid-4", dto.Features{EnableKVM: true}).Return(dto.Features{}, dtov2.Features{}, errUnreachable)
+			},
+			expected: map[string]entity.JobRun{
+				"guid-4": {GUID: "guid-4", Attempt: 1, Status: dto.JobRunStatusFailed, Error: errUnreachable.Error()},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			useCase, repo, devices := schedulesTest(t)
+
+			runs := make(chan entity.JobRun, len(tc.expected))
+
+			repo.EXPECT().GetDue(gomock.Any(), "2024-01-01T02:00:30Z").Return([]entity.ScheduledJob{tc.job}, nil)
+			repo.EXPECT().UpdateRunTimes(gomock.Any(), tc.job.ID, "", tc.job.NextRun, "2024-01-01T02:00:30Z", tc.nextRun).Return(true, nil)
+			repo.EXPECT().InsertRun(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, run *entity.JobRun) error {
+				runs <- *run
+
+				return nil
+			}).Times(len(tc.expected))
+			repo.EXPECT().GetDueRetries(gomock.Any(), "2024-01-01T02:00:30Z").Return(nil, nil)
+
+			tc.mock(devices)
+
+			useCase.ProcessDue(context.Background(), now)
+
+			close(runs)
+
+			for run := range runs {
+				require.Equal(t, tc.job.ID, run.JobID)
+				require.Equal(t, "2024-01-01T02:00:30Z", run.StartedAt)
+				require.NotEmpty(t, run.ID)
+				require.NotEmpty(t, run.FinishedAt)
+
+				expected := tc.expected[run.GUID]
+				require.Equal(t, expected.Attempt, run.Attempt)
+				require.Equal(t, expected.Status, run.Status)
+				require.Equal(t, expected.ReturnValue, run.ReturnValue)
+				require.Equal(t, expected.Error, run.Error)
+				require.Equal(t, expected.RetryAt, run.RetryAt)
+			}
+		})
+	}
+}
+
+func TestProcessDueRetries(t *testing.T) {
+	t.Parallel()
+
+	useCase, repo, devices := schedulesTest(t)
+
+	now := time.Date(2024, 1, 1, 2, 5, 0, 0, time.UTC)
+	pending := entity.JobRun{ID: "run-1", JobID: "job-1", GUID: "guid-1", Attempt: 1, Status: dto.JobRunStatusRetryPending}
+
+	repo.EXPECT().GetDue(gomock.Any(), "2024-01-01T02:05:00Z").Return(nil, nil)
+	repo.EXPECT().GetDueRetries(gomock.Any(), "2024-01-01T02:05:00Z").Return([]entity.JobRun{pending}, nil)
+	repo.EXPECT().UpdateRunStatus(gomock.Any(), "run-1", "", dto.JobRunStatusRetryPending, dto.JobRunStatusRetried).Return(true, nil)
+	repo.EXPECT().GetByID(gomock.Any(), "job-1", "").Return(&entity.ScheduledJob{
+		ID: "job-1", JobType: dto.JobTypePower, Payload: `{"powerAction":{"action":8}}`, MaxRetries: 1, RetryInterval: 60, Enabled: true,
+	}, nil)
+	devices.EXPECT().SendPowerAction(gomock.Any(), "guid-1", 8).Return(power.PowerActionResponse{}, errUnreachable)
+	repo.EXPECT().InsertRun(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, run *entity.JobRun) error {
+		// the last allowed attempt is recorded as failed rather than scheduled again
+		require.Equal(t, 2, run.Attempt)
+		require.Equal(t, dto.JobRunStatusFailed, run.Status)
+		require.Empty(t, run.RetryAt)
+
+		return nil
+	})
+
+	useCase.ProcessDue(context.Background(), now)
+}
+
+func TestProcessDueClaimedElsewhere(t *testing.T) {
+	t.Parallel()
+
+	useCase, repo, _ := schedulesTest(t)
+
+	now := time.Date(2024, 1, 1, 2, 5, 0, 0, time.UTC)
+	job := entity.ScheduledJob{
+		ID: "job-1", JobType: dto.JobTypePower, ScheduleType: dto.ScheduleTypeOnce, RunAt: "2024-01-01T02:00:00Z",
+		Payload: `{"powerAction":{"action":8}}`, TargetGUIDs: "guid-1", Enabled: true, NextRun: "2024-01-01T02:00:00Z",
+	}
+	pending := entity.JobRun{ID: "run-1", JobID: "job-1", GUID: "guid-2", Attempt: 1, Status: dto.JobRunStatusRetryPending}
+
+	// another console claimed both first, so neither the job nor the retry touches a device
+	repo.EXPECT().GetDue(gomock.Any(), "2024-01-01T02:05:00Z").Return([]entity.ScheduledJob{job}, nil)
+	repo.EXPECT().UpdateRunTimes(gomock.Any(), "job-1", "", "2024-01-01T02:00:00Z", "2024-01-01T02:05:00Z", "").Return(false, nil)
+	repo.EXPECT().GetDueRetries(gomock.Any(), "2024-01-01T02:05:00Z").Return([]entity.JobRun{pending}, nil)
+	repo.EXPECT().UpdateRunStatus(gomock.Any(), "run-1", "", dto.JobRunStatusRetryPending, dto.JobRunStatusRetried).Return(false, nil)
+
+	useCase.ProcessDue(context.Background(), now)
+}
+
+func TestRunStopsWithContext(t *testing.T) {
+	t.Parallel()
+
+	useCase, repo, _ := schedulesTest(t)
+
+	repo.EXPECT().GetDue(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	repo.EXPECT().GetDueRetries(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	go func() {
+		useCase.Run(ctx)
+		close(done)
+	}()
+
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("scheduler did not stop after cancel")
+	}
+}
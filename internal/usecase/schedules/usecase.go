@@ -0,0 +1,375 @@
+package schedules
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/robfig/cron/v3"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity"
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/sqldb"
+	"github.com/open-amt-cloud-toolkit/console/pkg/consoleerrors"
+	"github.com/open-amt-cloud-toolkit/console/pkg/logger"
+)
+
+const defaultInterval = 30 * time.Second
+
+// UseCase -.
+type UseCase struct {
+	repo     Repository
+	devices  Devices
+	log      logger.Interface
+	interval time.Duration
+}
+
+// New -.
+func New(r Repository, d Devices, log logger.Interface, interval time.Duration) *UseCase {
+	if interval <= 0 {
+		interval = defaultInterval
+	}
+
+	return &UseCase{
+		repo:     r,
+		devices:  d,
+		log:      log,
+		interval: interval,
+	}
+}
+
+var (
+	ErrSchedulesUseCase = consoleerrors.CreateConsoleError("SchedulesUseCase")
+	ErrDatabase         = sqldb.DatabaseError{Console: ErrSchedulesUseCase}
+	ErrNotFound         = sqldb.NotFoundError{Console: ErrSchedulesUseCase}
+	ErrNotValid         = dto.NotValidError{Console: ErrSchedulesUseCase}
+
+	errPowerAction  = errors.New("powerAction is required for power jobs")
+	errBootSetting  = errors.New("bootSetting is required for boot jobs")
+	errFeatures     = errors.New("features is required for features jobs")
+	errJobType      = errors.New("unknown job type")
+	errTargets      = errors.New("guids or tags are required")
+	errRunAt        = errors.New("runAt is required for one-off schedules")
+	errCron         = errors.New("invalid cron expression")
+	errScheduleType = errors.New("unknown schedule type")
+)
+
+// payload holds the action a job performs, stored as JSON alongside the job.
+type payload struct {
+	PowerAction *dto.PowerAction `json:"powerAction,omitempty"`
+	BootSetting *dto.BootSetting `json:"bootSetting,omitempty"`
+	Features    *dto.Features    `json:"features,omitempty"`
+}
+
+func (uc *UseCase) GetCount(ctx context.Context, tenantID string) (int, error) {
+	count, err := uc.repo.GetCount(ctx, tenantID)
+	if err != nil {
+		return 0, ErrDatabase.Wrap("GetCount", "uc.repo.GetCount", err)
+	}
+
+	return count, nil
+}
+
+func (uc *UseCase) Get(ctx context.Context, top, skip int, tenantID string) ([]dto.ScheduledJob, error) {
+	data, err := uc.repo.Get(ctx, top, skip, tenantID)
+	if err != nil {
+		return nil, ErrDatabase.Wrap("Get", "uc.repo.Get", err)
+	}
+
+	d1 := make([]dto.ScheduledJob, len(data))
+
+	for i := range data {
+		tmpEntity := data[i] // create a new variable to avoid memory aliasing
+		d1[i] = *uc.entityToDTO(&tmpEntity)
+	}
+
+	return d1, nil
+}
+
+func (uc *UseCase) GetByID(ctx context.Context, id, tenantID string) (*dto.ScheduledJob, error) {
+	data, err := uc.repo.GetByID(ctx, id, tenantID)
+	if err != nil {
+		return nil, ErrDatabase.Wrap("GetByID", "uc.repo.GetByID", err)
+	}
+
+	if data == nil {
+		return nil, ErrNotFound
+	}
+
+	return uc.entityToDTO(data), nil
+}
+
+func (uc *UseCase) Delete(ctx context.Context, id, tenantID string) error {
+	isSuccessful, err := uc.repo.Delete(ctx, id, tenantID)
+	if err != nil {
+		return ErrDatabase.Wrap("Delete", "uc.repo.Delete", err)
+	}
+
+	if !isSuccessful {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+func (uc *UseCase) Update(ctx context.Context, d *dto.ScheduledJob) (*dto.ScheduledJob, error) {
+	existing, err := uc.repo.GetByID(ctx, d.ID, d.TenantID)
+	if err != nil {
+		return nil, ErrDatabase.Wrap("Update", "uc.repo.GetByID", err)
+	}
+
+	if existing == nil {
+		return nil, ErrNotFound
+	}
+
+	d1, err := uc.dtoToEntity(d)
+	if err != nil {
+		return nil, err
+	}
+
+	d1.NextRun, err = nextRun(d1, existing.LastRun, time.Now())
+	if err != nil {
+		return nil, ErrNotValid.Wrap("Update", "nextRun", err)
+	}
+
+	updated, err := uc.repo.Update(ctx, d1)
+	if err != nil {
+		return nil, ErrDatabase.Wrap("Update", "uc.repo.Update", err)
+	}
+
+	if !updated {
+		return nil, ErrNotFound
+	}
+
+	return uc.GetByID(ctx, d.ID, d.TenantID)
+}
+
+func (uc *UseCase) Insert(ctx context.Context, d *dto.ScheduledJob) (*dto.ScheduledJob, error) {
+	d1, err := uc.dtoToEntity(d)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+
+	d1.ID = uuid.New().String()
+	d1.CreationDate = now.UTC().Format(time.RFC3339)
+
+	d1.NextRun, err = nextRun(d1, "", now)
+	if err != nil {
+		return nil, ErrNotValid.Wrap("Insert", "nextRun", err)
+	}
+
+	id, err := uc.repo.Insert(ctx, d1)
+	if err != nil {
+		return nil, ErrDatabase.Wrap("Insert", "uc.repo.Insert", err)
+	}
+
+	return uc.GetByID(ctx, id, d.TenantID)
+}
+
+func (uc *UseCase) GetRuns(ctx context.Context, jobID string, top, skip int, tenantID string) ([]dto.JobRun, error) {
+	job, err := uc.repo.GetByID(ctx, jobID, tenantID)
+	if err != nil {
+		return nil, ErrDatabase.Wrap("GetRuns", "uc.repo.GetByID", err)
+	}
+
+	if job == nil {
+		return nil, ErrNotFound
+	}
+
+	data, err := uc.repo.GetRuns(ctx, jobID, top, skip, tenantID)
+	if err != nil {
+		return nil, ErrDatabase.Wrap("GetRuns", "uc.repo.GetRuns", err)
+	}
+
+	runs := make([]dto.JobRun, len(data))
+
+	for i := range data {
+		tmpEntity := data[i] // create a new variable to avoid memory aliasing
+		runs[i] = *runToDTO(&tmpEntity)
+	}
+
+	return runs, nil
+}
+
+// nextRun works out when a job should fire next after now, "" means never.
+// A one-off job that has already fired is not rescheduled.
+func nextRun(j *entity.ScheduledJob, lastRun string, now time.Time) (string, error) {
+	if j.ScheduleType == dto.ScheduleTypeOnce {
+		if lastRun != "" && j.RunAt <= lastRun {
+			return "", nil
+		}
+
+		return j.RunAt, nil
+	}
+
+	schedule, err := cron.ParseStandard(j.CronExpression)
+	if err != nil {
+		return "", err
+	}
+
+	return formatTime(schedule.Next(now)), nil
+}
+
+// convert dto.ScheduledJob to entity.ScheduledJob.
+func (uc *UseCase) dtoToEntity(d *dto.ScheduledJob) (*entity.ScheduledJob, error) {
+	p := payload{}
+
+	switch d.JobType {
+	case dto.JobTypePower:
+		if d.PowerAction == nil {
+			return nil, ErrNotValid.Wrap("dtoToEntity", "d.PowerAction", errPowerAction)
+		}
+
+		p.PowerAction = d.PowerAction
+	case dto.JobTypeBoot:
+		if d.BootSetting == nil {
+			return nil, ErrNotValid.Wrap("dtoToEntity", "d.BootSetting", errBootSetting)
+		}
+
+		p.BootSetting = d.BootSetting
+	case dto.JobTypeFeatures:
+		if d.Features == nil {
+			return nil, ErrNotValid.Wrap("dtoToEntity", "d.Features", errFeatures)
+		}
+
+		p.Features = d.Features
+	default:
+		return nil, ErrNotValid.Wrap("dtoToEntity", "d.JobType", fmt.Errorf("%w %s", errJobType, d.JobType))
+	}
+
+	if len(d.GUIDs) == 0 && len(d.Tags) == 0 {
+		return nil, ErrNotValid.Wrap("dtoToEntity", "d.GUIDs", errTargets)
+	}
+
+	d1 := &entity.ScheduledJob{
+		ID:             d.ID,
+		Name:           d.Name,
+		JobType:        d.JobType,
+		ScheduleType:   d.ScheduleType,
+		CronExpression: d.CronExpression,
+		TargetGUIDs:    strings.Join(d.GUIDs, ","),
+		TargetTags:     strings.Join(d.Tags, ","),
+		TargetMethod:   d.Method,
+		MaxRetries:     d.MaxRetries,
+		RetryInterval:  d.RetryInterval,
+		Enabled:        d.Enabled,
+		TenantID:       d.TenantID,
+	}
+
+	switch d.ScheduleType {
+	case dto.ScheduleTypeOnce:
+		if d.RunAt == nil {
+			return nil, ErrNotValid.Wrap("dtoToEntity", "d.RunAt", errRunAt)
+		}
+
+		d1.RunAt = formatTime(*d.RunAt)
+		d1.CronExpression = ""
+	case dto.ScheduleTypeCron:
+		if _, err := cron.ParseStandard(d.CronExpression); err != nil {
+			return nil, ErrNotValid.Wrap("dtoToEntity", "cron.ParseStandard", fmt.Errorf("%w: %w", errCron, err))
+		}
+	default:
+		return nil, ErrNotValid.Wrap("dtoToEntity", "d.ScheduleType", fmt.Errorf("%w %s", errScheduleType, d.ScheduleType))
+	}
+
+	data, err := json.Marshal(p)
+	if err != nil {
+		return nil, ErrNotValid.Wrap("dtoToEntity", "json.Marshal", err)
+	}
+
+	d1.Payload = string(data)
+
+	return d1, nil
+}
+
+// convert entity.ScheduledJob to dto.ScheduledJob.
+func (uc *UseCase) entityToDTO(d *entity.ScheduledJob) *dto.ScheduledJob {
+	d1 := &dto.ScheduledJob{
+		ID:             d.ID,
+		Name:           d.Name,
+		JobType:        d.JobType,
+		ScheduleType:   d.ScheduleType,
+		RunAt:          parseTime(d.RunAt),
+		CronExpression: d.CronExpression,
+		GUIDs:          splitList(d.TargetGUIDs),
+		Tags:           splitList(d.TargetTags),
+		Method:         d.TargetMethod,
+		MaxRetries:     d.MaxRetries,
+		RetryInterval:  d.RetryInterval,
+		Enabled:        d.Enabled,
+		NextRun:        parseTime(d.NextRun),
+		LastRun:        parseTime(d.LastRun),
+		TenantID:       d.TenantID,
+	}
+
+	if created := parseTime(d.CreationDate); created != nil {
+		d1.CreationDate = *created
+	}
+
+	p := payload{}
+	if err := json.Unmarshal([]byte(d.Payload), &p); err != nil {
+		uc.log.Warn("schedules - entityToDTO - job %s has an unreadable payload: %s", d.ID, err.Error())
+	}
+
+	d1.PowerAction = p.PowerAction
+	d1.BootSetting = p.BootSetting
+	d1.Features = p.Features
+
+	return d1
+}
+
+// convert entity.JobRun to dto.JobRun.
+func runToDTO(d *entity.JobRun) *dto.JobRun {
+	d1 := &dto.JobRun{
+		ID:          d.ID,
+		JobID:       d.JobID,
+		GUID:        d.GUID,
+		Attempt:     d.Attempt,
+		Status:      d.Status,
+		ReturnValue: d.ReturnValue,
+		Error:       d.Error,
+		RetryAt:     parseTime(d.RetryAt),
+		TenantID:    d.TenantID,
+	}
+
+	if started := parseTime(d.StartedAt); started != nil {
+		d1.StartedAt = *started
+	}
+
+	if finished := parseTime(d.FinishedAt); finished != nil {
+		d1.FinishedAt = *finished
+	}
+
+	return d1
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
+
+func parseTime(s string) *time.Time {
+	if s == "" {
+		return nil
+	}
+
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return nil
+	}
+
+	return &t
+}
+
+func splitList(s string) []string {
+	if s == "" {
+		return nil
+	}
+
+	return strings.Split(s, ",")
+}
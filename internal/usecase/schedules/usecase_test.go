@@ -0,0 +1,277 @@
+package schedules_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity"
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	"github.com/open-amt-cloud-toolkit/console/internal/mocks"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/schedules"
+	"github.com/open-amt-cloud-toolkit/console/pkg/logger"
+)
+
+func schedulesTest(t *testing.T) (*schedules.UseCase, *mocks.MockSchedulesRepository, *mocks.MockSchedulesDevices) {
+	t.Helper()
+
+	mockCtl := gomock.NewController(t)
+
+	repo := mocks.NewMockSchedulesRepository(mockCtl)
+	devices := mocks.NewMockSchedulesDevices(mockCtl)
+	log := logger.New("error")
+	useCase := schedules.New(repo, devices, log, time.Minute)
+
+	return useCase, repo, devices
+}
+
+func TestGetByID(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		mock func(repo *mocks.MockSchedulesRepository)
+		res  *dto.ScheduledJob
+		err  error
+	}{
+		{
+			name: "successful retrieval",
+			mock: func(repo *mocks.MockSchedulesRepository) {
+				repo.EXPECT().GetByID(context.Background(), "job-1", "").Return(&entity.ScheduledJob{
+					ID:             "job-1",
+					Name:           "nightly reboot",
+					JobType:        dto.JobTypePower,
+					ScheduleType:   dto.ScheduleTypeCron,
+					CronExpression: "0 2 * * *",
+					Payload:        `{"powerAction":{"action":10}}`,
+					TargetGUIDs:    "guid-1,guid-2",
+					Enabled:        true,
+					NextRun:        "2024-01-02T02:00:00Z",
+					CreationDate:   "2024-01-01T00:00:00Z",
+				}, nil)
+			},
+			res: &dto.ScheduledJob{
+				ID:             "job-1",
+				Name:           "nightly reboot",
+				JobType:        dto.JobTypePower,
+				ScheduleType:   dto.ScheduleTypeCron,
+				CronExpression: "0 2 * * *",
+				PowerAction:    &dto.PowerAction{Action: 10},
+				GUIDs:          []string{"guid-1", "guid-2"},
+				Enabled:        true,
+				NextRun:        ptrTime(time.Date(2024, 1, 2, 2, 0, 0, 0, time.UTC)),
+				CreationDate:   time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "not found",
+			mock: func(repo *mocks.MockSchedulesRepository) {
+				repo.EXPECT().GetByID(context.Background(), "job-1", "").Return(nil, nil)
+			},
+			err: schedules.ErrNotFound,
+		},
+		{
+			name: "database error",
+			mock: func(repo *mocks.MockSchedulesRepository) {
+				repo.EXPECT().GetByID(context.Background(), "job-1", "").Return(nil, schedules.ErrDatabase)
+			},
+			err: schedules.ErrDatabase,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			useCase, repo, _ := schedulesTest(t)
+
+			tc.mock(repo)
+
+			res, err := useCase.GetByID(context.Background(), "job-1", "")
+
+			require.Equal(t, tc.res, res)
+			require.IsType(t, tc.err, err)
+		})
+	}
+}
+
+func TestInsert(t *testing.T) {
+	t.Parallel()
+
+	runAt := time.Date(2030, 1, 1, 2, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		input   dto.ScheduledJob
+		mock    func(repo *mocks.MockSchedulesRepository)
+		nextRun string
+		err     error
+		reason  string
+	}{
+		{
+			name: "one-off job",
+			input: dto.ScheduledJob{
+				Name:         "maintenance",
+				JobType:      dto.JobTypeBoot,
+				ScheduleType: dto.ScheduleTypeOnce,
+				RunAt:        &runAt,
+				BootSetting:  &dto.BootSetting{Action: 400},
+				Tags:         []string{"lab"},
+			},
+			nextRun: "2030-01-01T02:00:00Z",
+		},
+		{
+			name: "cron job",
+			input: dto.ScheduledJob{
+				Name:           "nightly",
+				JobType:        dto.JobTypeFeatures,
+				ScheduleType:   dto.ScheduleTypeCron,
+				CronExpression: "@daily",
+				Features:       &dto.Features{EnableKVM: true},
+				GUIDs:          []string{"guid-1"},
+			},
+		},
+		{
+			name: "invalid cron expression",
+			input: dto.ScheduledJob{
+				Name:           "broken",
+				JobType:        dto.JobTypePower,
+				ScheduleType:   dto.ScheduleTypeCron,
+				CronExpression: "every tuesday",
+				PowerAction:    &dto.PowerAction{Action: 8},
+				GUIDs:          []string{"guid-1"},
+			},
+			err:    schedules.ErrNotValid,
+			reason: "invalid cron expression",
+		},
+		{
+			name: "payload missing for job type",
+			input: dto.ScheduledJob{
+				Name:         "no payload",
+				JobType:      dto.JobTypePower,
+				ScheduleType: dto.ScheduleTypeOnce,
+				RunAt:        &runAt,
+				GUIDs:        []string{"guid-1"},
+			},
+			err:    schedules.ErrNotValid,
+			reason: "powerAction is required for power jobs",
+		},
+		{
+			name: "no targets",
+			input: dto.ScheduledJob{
+				Name:         "no targets",
+				JobType:      dto.JobTypePower,
+				ScheduleType: dto.ScheduleTypeOnce,
+				RunAt:        &runAt,
+				PowerAction:  &dto.PowerAction{Action: 8},
+			},
+			err:    schedules.ErrNotValid,
+			reason: "guids or tags are required",
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			useCase, repo, _ := schedulesTest(t)
+
+			var inserted *entity.ScheduledJob
+
+			if tc.err == nil {
+				repo.EXPECT().Insert(context.Background(), gomock.Any()).DoAndReturn(func(_ context.Context, j *entity.ScheduledJob) (string, error) {
+					inserted = j
+
+					return j.ID, nil
+				})
+				repo.EXPECT().GetByID(context.Background(), gomock.Any(), "").DoAndReturn(func(_ context.Context, _, _ string) (*entity.ScheduledJob, error) {
+					return inserted, nil
+				})
+			}
+
+			res, err := useCase.Insert(context.Background(), &tc.input)
+			if tc.err != nil {
+				require.IsType(t, tc.err, err)
+				require.ErrorContains(t, err, tc.reason)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.NotEmpty(t, res.ID)
+			require.NotNil(t, res.NextRun)
+
+			if tc.nextRun != "" {
+				require.Equal(t, tc.nextRun, inserted.NextRun)
+			}
+
+			require.Equal(t, tc.input.Name, res.Name)
+		})
+	}
+}
+
+func TestUpdateOneOffAlreadyRun(t *testing.T) {
+	t.Parallel()
+
+	useCase, repo, _ := schedulesTest(t)
+
+	runAt := time.Date(2024, 1, 1, 2, 0, 0, 0, time.UTC)
+	existing := &entity.ScheduledJob{ID: "job-1", ScheduleType: dto.ScheduleTypeOnce, RunAt: "2024-01-01T02:00:00Z", LastRun: "2024-01-01T02:00:10Z"}
+
+	repo.EXPECT().GetByID(context.Background(), "job-1", "").Return(existing, nil).Times(2)
+	repo.EXPECT().Update(context.Background(), gomock.Any()).DoAndReturn(func(_ context.Context, j *entity.ScheduledJob) (bool, error) {
+		require.Empty(t, j.NextRun)
+
+		return true, nil
+	})
+
+	_, err := useCase.Update(context.Background(), &dto.ScheduledJob{
+		ID:           "job-1",
+		Name:         "renamed",
+		JobType:      dto.JobTypePower,
+		ScheduleType: dto.ScheduleTypeOnce,
+		RunAt:        &runAt,
+		PowerAction:  &dto.PowerAction{Action: 8},
+		GUIDs:        []string{"guid-1"},
+	})
+	require.NoError(t, err)
+}
+
+func TestGetRuns(t *testing.T) {
+	t.Parallel()
+
+	useCase, repo, _ := schedulesTest(t)
+
+	repo.EXPECT().GetByID(context.Background(), "job-1", "").Return(&entity.ScheduledJob{ID: "job-1"}, nil)
+	repo.EXPECT().GetRuns(context.Background(), "job-1", 25, 0, "").Return([]entity.JobRun{
+		{ID: "run-1", JobID: "job-1", GUID: "guid-1", Attempt: 2, Status: dto.JobRunStatusSuccess, StartedAt: "2024-01-01T02:05:00Z", FinishedAt: "2024-01-01T02:05:01Z"},
+	}, nil)
+
+	runs, err := useCase.GetRuns(context.Background(), "job-1", 25, 0, "")
+	require.NoError(t, err)
+	require.Equal(t, []dto.JobRun{{
+		ID:         "run-1",
+		JobID:      "job-1",
+		GUID:       "guid-1",
+		Attempt:    2,
+		Status:     dto.JobRunStatusSuccess,
+		StartedAt:  time.Date(2024, 1, 1, 2, 5, 0, 0, time.UTC),
+		FinishedAt: time.Date(2024, 1, 1, 2, 5, 1, 0, time.UTC),
+	}}, runs)
+
+	repo.EXPECT().GetByID(context.Background(), "job-2", "").Return(nil, nil)
+
+	_, err = useCase.GetRuns(context.Background(), "job-2", 25, 0, "")
+	require.IsType(t, schedules.ErrNotFound, err)
+}
+
+func ptrTime(t time.Time) *time.Time {
+	return &t
+}
@@ -0,0 +1,224 @@
+package siem
+
+import (
+	"context"
+	"time"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity"
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+)
+
+const fleetPageSize = 100
+
+// Run forwards the new records of the fleet every interval until ctx is done.
+func (uc *UseCase) Run(ctx context.Context) {
+	if uc.cfg.Interval <= 0 || uc.cfg.Address == "" {
+		return
+	}
+
+	if err := uc.validate(); err != nil {
+		uc.log.Error(err, "siem - Run - uc.validate")
+
+		return
+	}
+
+	ticker := time.NewTicker(uc.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		uc.ForwardAll(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ForwardAll forwards the new records of every device once, over a single connection to the collector. Devices
+// are forwarded whether connected or not, their records come from what was collected before.
+func (uc *UseCase) ForwardAll(ctx context.Context) {
+	s := newSender(uc.cfg)
+	defer s.Close()
+
+	for skip := 0; ; skip += fleetPageSize {
+		page, err := uc.fleet.GetAllTenants(ctx, fleetPageSize, skip)
+		if err != nil {
+			uc.log.Error(err, "siem - ForwardAll - uc.fleet.GetAllTenants")
+
+			return
+		}
+
+		for i := range page {
+			if ctx.Err() != nil {
+				return
+			}
+
+			if _, err := uc.forward(ctx, s, &page[i]); err != nil {
+				uc.log.Debug("siem - ForwardAll - " + page[i].GUID + ": " + err.Error())
+			}
+		}
+
+		if len(page) < fleetPageSize {
+			return
+		}
+	}
+}
+
+// Forward sends the records of a device collected since its last forwarding to the collector and returns the
+// cursors of its logs.
+func (uc *UseCase) Forward(ctx context.Context, guid, tenantID string) ([]dto.SIEMCursor, error) {
+	if err := uc.validate(); err != nil {
+		return nil, ErrNotValid.Wrap("Forward", "uc.validate", err)
+	}
+
+	d, err := uc.fleet.GetByID(ctx, guid, tenantID)
+	if err != nil {
+		return nil, ErrDatabase.Wrap("Forward", "uc.fleet.GetByID", err)
+	}
+
+	if d == nil {
+		return nil, ErrNotFound
+	}
+
+	s := newSender(uc.cfg)
+	defer s.Close()
+
+	return uc.forward(ctx, s, d)
+}
+
+// forward forwards each log of a device, returning the first error after trying them all.
+func (uc *UseCase) forward(ctx context.Context, s *sender, d *entity.Device) ([]dto.SIEMCursor, error) {
+	now := uc.now()
+	// collections stamp their records before storing them, so only those of finished collections are read
+	collectedBefore := formatTime(now.Add(-settle))
+
+	cursors := []dto.SIEMCursor{}
+
+	var firstErr error
+
+	for _, source := range uc.sources() {
+		cursor, err := uc.forwardSource(ctx, s, d, source, now, collectedBefore)
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+
+		if cursor != nil {
+			cursors = append(cursors, *cursorToDTO(cursor))
+		}
+	}
+
+	return cursors, firstErr
+}
+
+// forwardSource sends the records of one log of a device a batch at a time, moving its cursor past each batch
+// sent. A forwarding that fails part way keeps the cursor of the batches sent and records the error on it.
+func (uc *UseCase) forwardSource(ctx context.Context, s *sender, d *entity.Device, source string, now time.Time, collectedBefore string) (*entity.SIEMCursor, error) {
+	cursor, err := uc.repo.GetCursor(ctx, source, d.GUID, d.TenantID)
+	if err != nil {
+		return nil, ErrDatabase.Wrap("Forward", "uc.repo.GetCursor", err)
+	}
+
+	if cursor == nil {
+		cursor = &entity.SIEMCursor{Source: source, GUID: d.GUID, TenantID: d.TenantID}
+	}
+
+	cursor.ForwardedAt = formatTime(now)
+	cursor.Forwarded = 0
+	cursor.Error = ""
+
+	for {
+		msgs, last, err := uc.batch(ctx, d, cursor, collectedBefore)
+		if err != nil {
+			return cursor, uc.fail(ctx, cursor, ErrDatabase.Wrap("Forward", "uc.batch", err))
+		}
+
+		if len(msgs) > 0 {
+			if err := s.send(ctx, msgs); err != nil {
+				return cursor, uc.fail(ctx, cursor, ErrSIEMUseCase.Wrap("Forward", "s.send", err))
+			}
+
+			cursor.LastCollectedAt = last.CollectedAt
+			cursor.LastTime = last.Time
+			cursor.LastFingerprint = last.Fingerprint
+			cursor.Forwarded += len(msgs)
+		}
+
+		if err := uc.repo.UpsertCursor(ctx, cursor); err != nil {
+			return nil, ErrDatabase.Wrap("Forward", "uc.repo.UpsertCursor", err)
+		}
+
+		if len(msgs) < uc.cfg.BatchSize {
+			return cursor, nil
+		}
+	}
+}
+
+// fail records the error a forwarding stopped on in its cursor and returns it.
+func (uc *UseCase) fail(ctx context.Context, cursor *entity.SIEMCursor, err error) error {
+	cursor.Error = err.Error()
+
+	if upsertErr := uc.repo.UpsertCursor(ctx, cursor); upsertErr != nil {
+		return ErrDatabase.Wrap("Forward", "uc.repo.UpsertCursor", upsertErr)
+	}
+
+	return err
+}
+
+// batch reads and renders the next records of a log of a device after its cursor, returning the position of
+// the last one.
+func (uc *UseCase) batch(ctx context.Context, d *entity.Device, cursor *entity.SIEMCursor, collectedBefore string) ([][]byte, entity.LogPosition, error) {
+	after := entity.LogPosition{
+		CollectedAt: cursor.LastCollectedAt,
+		Time:        cursor.LastTime,
+		Fingerprint: cursor.LastFingerprint,
+	}
+
+	msgs := [][]byte{}
+	last := after
+
+	switch cursor.Source {
+	case dto.SIEMSourceAudit:
+		records, err := uc.audit.GetAfter(ctx, d.GUID, d.TenantID, after, collectedBefore, uc.cfg.BatchSize)
+		if err != nil {
+			return nil, last, err
+		}
+
+		for i := range records {
+			m := auditMessage(d, &records[i])
+			msgs = append(msgs, uc.render(&m))
+			last = entity.LogPosition{CollectedAt: records[i].CollectedAt, Time: records[i].Time, Fingerprint: records[i].Fingerprint}
+		}
+	case dto.SIEMSourceEvent:
+		events, err := uc.events.GetEventsAfter(ctx, d.GUID, d.TenantID, after, collectedBefore, uc.cfg.BatchSize)
+		if err != nil {
+			return nil, last, err
+		}
+
+		for i := range events {
+			m := eventMessage(d, &events[i])
+			msgs = append(msgs, uc.render(&m))
+			last = entity.LogPosition{CollectedAt: events[i].CollectedAt, Time: events[i].Time, Fingerprint: events[i].Fingerprint}
+		}
+	}
+
+	return msgs, last, nil
+}
+
+func (uc *UseCase) render(m *message) []byte {
+	if uc.cfg.Format == FormatCEF {
+		return m.cefMessage(uc.cfg.Facility, uc.cfg.AppName)
+	}
+
+	return m.rfc5424(uc.cfg.Facility, uc.cfg.AppName)
+}
+
+// sources returns the logs forwarded, both when none are configured.
+func (uc *UseCase) sources() []string {
+	if len(uc.cfg.Sources) == 0 {
+		return []string{dto.SIEMSourceAudit, dto.SIEMSourceEvent}
+	}
+
+	return uc.cfg.Sources
+}
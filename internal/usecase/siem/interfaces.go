@@ -0,0 +1,36 @@
+package siem
+
+import (
+	"context"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity"
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+)
+
+type (
+	Repository interface {
+		GetCursorCount(ctx context.Context, tenantID string) (int, error)
+		GetCursor(ctx context.Context, source, guid, tenantID string) (*entity.SIEMCursor, error)
+		GetCursors(ctx context.Context, top, skip int, tenantID string) ([]entity.SIEMCursor, error)
+		UpsertCursor(ctx context.Context, c *entity.SIEMCursor) error
+	}
+	Feature interface {
+		Forward(ctx context.Context, guid, tenantID string) ([]dto.SIEMCursor, error)
+		GetCursorCount(ctx context.Context, tenantID string) (int, error)
+		GetCursors(ctx context.Context, top, skip int, tenantID string) ([]dto.SIEMCursor, error)
+		Run(ctx context.Context)
+	}
+	// AuditLogs is the store of the AMT audit records collected from the fleet.
+	AuditLogs interface {
+		GetAfter(ctx context.Context, guid, tenantID string, after entity.LogPosition, collectedBefore string, limit int) ([]entity.AMTAuditRecord, error)
+	}
+	// EventLogs is the store of the AMT events collected from the fleet.
+	EventLogs interface {
+		GetEventsAfter(ctx context.Context, guid, tenantID string, after entity.LogPosition, collectedBefore string, limit int) ([]entity.DeviceEvent, error)
+	}
+	// Fleet finds the devices whose logs are forwarded.
+	Fleet interface {
+		GetAllTenants(ctx context.Context, top, skip int) ([]entity.Device, error)
+		GetByID(ctx context.Context, guid, tenantID string) (*entity.Device, error)
+	}
+)
@@ -0,0 +1,276 @@
+package siem
+
+import (
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity"
+)
+
+// enterpriseID is Intel's private enterprise number, under which the structured data of the messages is named.
+const enterpriseID = "343"
+
+// Syslog severities (RFC 5424, section 6.2.1).
+const (
+	severityAlert         = 1
+	severityCritical      = 2
+	severityWarning       = 4
+	severityNotice        = 5
+	severityInformational = 6
+)
+
+const (
+	msgIDAudit = "AMTAudit"
+	msgIDEvent = "AMTEvent"
+)
+
+// param is a named value of a message, kept in order.
+type param struct {
+	name, value string
+}
+
+// message is a record of a device's log ready to be rendered in either format.
+type message struct {
+	time     string
+	host     string
+	msgID    string
+	severity int
+	text     string
+	params   []param
+	cef      cefRecord
+}
+
+// cefRecord is the CEF rendering of a record: its header fields and extensions.
+type cefRecord struct {
+	signature  string
+	name       string
+	severity   int
+	extensions []param
+}
+
+// auditMessage maps a record of the audit log of a device.
+func auditMessage(d *entity.Device, r *entity.AMTAuditRecord) message {
+	text := r.AuditApp + ": " + r.Event
+	if r.Description != "" {
+		text += " - " + r.Description
+	}
+
+	ext := deviceExtensions(d, r.Time)
+	ext = append(ext,
+		param{"cs2", r.AuditApp}, param{"cs2Label", "auditApp"},
+		param{"suser", r.Initiator},
+	)
+
+	if net.ParseIP(r.NetAddress) != nil {
+		ext = append(ext, param{"src", r.NetAddress})
+	}
+
+	ext = append(ext, param{"msg", r.Description}, param{"externalId", r.Fingerprint})
+
+	return message{
+		time:     r.Time,
+		host:     host(d),
+		msgID:    msgIDAudit,
+		severity: severityNotice,
+		text:     text,
+		params: []param{
+			{"guid", r.GUID},
+			{"tenantId", r.TenantID},
+			{"auditApp", r.AuditApp},
+			{"auditAppId", strconv.Itoa(r.AuditAppID)},
+			{"event", r.Event},
+			{"eventId", strconv.Itoa(r.EventID)},
+			{"initiator", r.Initiator},
+			{"netAddress", r.NetAddress},
+			{"fingerprint", r.Fingerprint},
+		},
+		cef: cefRecord{
+			signature:  "audit:" + strconv.Itoa(r.AuditAppID) + ":" + strconv.Itoa(r.EventID),
+			name:       r.AuditApp + ": " + r.Event,
+			severity:   3,
+			extensions: ext,
+		},
+	}
+}
+
+// eventMessage maps a record of the event log of a device.
+func eventMessage(d *entity.Device, e *entity.DeviceEvent) message {
+	text := e.Entity + ": " + e.Description
+
+	ext := deviceExtensions(d, e.Time)
+	ext = append(ext,
+		param{"cat", e.Entity},
+		param{"cs2", e.Severity}, param{"cs2Label", "eventSeverity"},
+		param{"cn1", strconv.Itoa(e.SensorType)}, param{"cn1Label", "sensorType"},
+		param{"cn2", strconv.Itoa(e.EventType)}, param{"cn2Label", "eventType"},
+		param{"cn3", strconv.Itoa(e.EventOffset)}, param{"cn3Label", "eventOffset"},
+		param{"msg", e.Description},
+		param{"externalId", e.Fingerprint},
+	)
+
+	syslogSeverity, cefSeverity := eventSeverity(e.Severity)
+
+	return message{
+		time:     e.Time,
+		host:     host(d),
+		msgID:    msgIDEvent,
+		severity: syslogSeverity,
+		text:     text,
+		params: []param{
+			{"guid", e.GUID},
+			{"tenantId", e.TenantID},
+			{"entity", e.Entity},
+			{"entityInstance", strconv.Itoa(e.EntityInstance)},
+			{"severity", e.Severity},
+			{"sensorType", strconv.Itoa(e.SensorType)},
+			{"sensorNumber", strconv.Itoa(e.SensorNumber)},
+			{"eventType", strconv.Itoa(e.EventType)},
+			{"eventOffset", strconv.Itoa(e.EventOffset)},
+			{"eventSourceType", strconv.Itoa(e.EventSourceType)},
+			{"eventData", e.EventData},
+			{"fingerprint", e.Fingerprint},
+		},
+		cef: cefRecord{
+			signature:  "event:" + strconv.Itoa(e.SensorType) + ":" + strconv.Itoa(e.EventType) + ":" + strconv.Itoa(e.EventOffset),
+			name:       text,
+			severity:   cefSeverity,
+			extensions: ext,
+		},
+	}
+}
+
+// eventSeverity maps the severity AMT gives an event to a syslog and a CEF severity.
+func eventSeverity(s string) (syslogSeverity, cefSeverity int) {
+	switch s {
+	case "Non-recoverable condition":
+		return severityAlert, 10
+	case "Critical condition":
+		return severityCritical, 9
+	case "Non-critical condition":
+		return severityWarning, 6
+	case "OK", "Information", "Monitor":
+		return severityInformational, 3
+	default:
+		return severityNotice, 3
+	}
+}
+
+func deviceExtensions(d *entity.Device, recordTime string) []param {
+	ext := []param{}
+
+	if t, err := time.Parse(time.RFC3339, recordTime); err == nil {
+		ext = append(ext, param{"rt", strconv.FormatInt(t.UnixMilli(), 10)})
+	}
+
+	return append(ext,
+		param{"dvchost", host(d)},
+		param{"deviceExternalId", d.GUID},
+		param{"cs1", d.TenantID}, param{"cs1Label", "tenantId"},
+	)
+}
+
+// host names a device in the messages of its records: by its hostname, or its GUID when it has none.
+func host(d *entity.Device) string {
+	if d.Hostname != "" {
+		return d.Hostname
+	}
+
+	return d.GUID
+}
+
+// rfc5424 renders m as an RFC 5424 syslog message.
+func (m *message) rfc5424(facility int, appName string) []byte {
+	var b strings.Builder
+
+	m.header(&b, facility, appName)
+
+	b.WriteString(" [amt@" + enterpriseID)
+
+	for _, p := range m.params {
+		if p.value == "" {
+			continue
+		}
+
+		b.WriteString(" " + p.name + `="` + sdEscaper.Replace(p.value) + `"`)
+	}
+
+	b.WriteString("]")
+
+	if m.text != "" {
+		b.WriteString(" " + m.text)
+	}
+
+	return []byte(b.String())
+}
+
+// cefMessage renders m as a CEF record, carried as the message of an RFC 5424 syslog message.
+func (m *message) cefMessage(facility int, appName string) []byte {
+	var b strings.Builder
+
+	m.header(&b, facility, appName)
+
+	b.WriteString(" - CEF:0|Intel|AMT||")
+	b.WriteString(cefHeaderEscaper.Replace(m.cef.signature) + "|")
+	b.WriteString(cefHeaderEscaper.Replace(m.cef.name) + "|")
+	b.WriteString(strconv.Itoa(m.cef.severity) + "|")
+
+	first := true
+
+	for _, p := range m.cef.extensions {
+		if p.value == "" {
+			continue
+		}
+
+		if !first {
+			b.WriteString(" ")
+		}
+
+		first = false
+
+		b.WriteString(p.name + "=" + cefExtensionEscaper.Replace(p.value))
+	}
+
+	return []byte(b.String())
+}
+
+// header writes PRI VERSION TIMESTAMP HOSTNAME APP-NAME PROCID MSGID.
+func (m *message) header(b *strings.Builder, facility int, appName string) {
+	timestamp := "-"
+	if t, err := time.Parse(time.RFC3339, m.time); err == nil {
+		timestamp = t.UTC().Format(time.RFC3339)
+	}
+
+	b.WriteString("<" + strconv.Itoa(facility*8+m.severity) + ">1 ")
+	b.WriteString(timestamp + " ")
+	b.WriteString(headerField(m.host, 255) + " ")
+	b.WriteString(headerField(appName, 48) + " - ")
+	b.WriteString(m.msgID)
+}
+
+// headerField makes s a syslog header field: printable ASCII without spaces, at most limit long, - when empty.
+func headerField(s string, limit int) string {
+	field := []byte{}
+
+	for i := 0; i < len(s) && len(field) < limit; i++ {
+		c := s[i]
+		if c < '!' || c > '~' {
+			c = '_'
+		}
+
+		field = append(field, c)
+	}
+
+	if len(field) == 0 {
+		return "-"
+	}
+
+	return string(field)
+}
+
+var (
+	sdEscaper           = strings.NewReplacer(`\`, `\\`, `"`, `\"`, `]`, `\]`)
+	cefHeaderEscaper    = strings.NewReplacer(`\`, `\\`, `|`, `\|`, "\r\n", " ", "\n", " ", "\r", " ")
+	cefExtensionEscaper = strings.NewReplacer(`\`, `\\`, `=`, `\=`, "\r\n", `\n`, "\n", `\n`, "\r", `\r`)
+)
@@ -0,0 +1,108 @@
+package siem
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net"
+	"os"
+	"strconv"
+	"time"
+)
+
+var errCAFile = errors.New("no certificate found in the CA file")
+
+// sender ships messages to the collector over one connection, dialed when the first message is sent. Messages
+// go one per datagram over UDP and octet counted (RFC 6587) over TCP and TLS.
+type sender struct {
+	cfg  Config
+	conn net.Conn
+}
+
+func newSender(cfg Config) *sender {
+	return &sender{cfg: cfg}
+}
+
+// send writes the messages in order, dropping the connection when a write fails so the next send dials again.
+func (s *sender) send(ctx context.Context, msgs [][]byte) error {
+	if s.conn == nil {
+		conn, err := s.dial(ctx)
+		if err != nil {
+			return err
+		}
+
+		s.conn = conn
+	}
+
+	for _, msg := range msgs {
+		if s.cfg.Network != NetworkUDP {
+			msg = append([]byte(strconv.Itoa(len(msg))+" "), msg...)
+		}
+
+		if err := s.conn.SetWriteDeadline(time.Now().Add(s.cfg.Timeout)); err != nil {
+			s.Close()
+
+			return err
+		}
+
+		if _, err := s.conn.Write(msg); err != nil {
+			s.Close()
+
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Close closes the connection to the collector, if one was dialed.
+func (s *sender) Close() {
+	if s.conn == nil {
+		return
+	}
+
+	_ = s.conn.Close()
+	s.conn = nil
+}
+
+func (s *sender) dial(ctx context.Context) (net.Conn, error) {
+	dialer := &net.Dialer{Timeout: s.cfg.Timeout}
+
+	switch s.cfg.Network {
+	case NetworkUDP, NetworkTCP:
+		return dialer.DialContext(ctx, s.cfg.Network, s.cfg.Address)
+	default:
+		tlsConfig, err := s.tlsConfig()
+		if err != nil {
+			return nil, err
+		}
+
+		tlsDialer := &tls.Dialer{NetDialer: dialer, Config: tlsConfig}
+
+		return tlsDialer.DialContext(ctx, "tcp", s.cfg.Address)
+	}
+}
+
+func (s *sender) tlsConfig() (*tls.Config, error) {
+	config := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: s.cfg.InsecureSkipVerify, //nolint:gosec // can be set for collectors with self-signed certificates
+	}
+
+	if s.cfg.CAFile == "" {
+		return config, nil
+	}
+
+	pem, err := os.ReadFile(s.cfg.CAFile)
+	if err != nil {
+		return nil, err
+	}
+
+	config.RootCAs = x509.NewCertPool()
+	if !config.RootCAs.AppendCertsFromPEM(pem) {
+		return nil, errCAFile
+	}
+
+	return config, nil
+}
@@ -0,0 +1,188 @@
+package siem
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity"
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/sqldb"
+	"github.com/open-amt-cloud-toolkit/console/pkg/consoleerrors"
+	"github.com/open-amt-cloud-toolkit/console/pkg/logger"
+)
+
+// Networks the collector can be reached over.
+const (
+	NetworkUDP = "udp"
+	NetworkTCP = "tcp"
+	NetworkTLS = "tls"
+)
+
+// Formats the records can be forwarded in. Both are sent as RFC 5424 syslog messages, CEF ones carry the CEF
+// record as their message.
+const (
+	FormatRFC5424 = "rfc5424"
+	FormatCEF     = "cef"
+)
+
+const (
+	defaultBatchSize = 100
+	defaultTimeout   = 10 * time.Second
+	// settle keeps records out of a forwarding pass until the collection that stored them has finished, so
+	// none is stored behind a cursor that already moved past its collection time.
+	settle = 5 * time.Minute
+)
+
+// Config controls where and how the collected AMT logs of the fleet are forwarded.
+type Config struct {
+	// Network is udp, tcp or tls.
+	Network string
+	// Address of the collector as host:port, empty disables forwarding.
+	Address string
+	// Format is rfc5424 or cef.
+	Format string
+	// Facility of the syslog messages.
+	Facility int
+	// AppName the messages are sent by.
+	AppName string
+	// CAFile is a PEM bundle the collector's TLS certificate is verified against, the system roots when empty.
+	CAFile             string
+	InsecureSkipVerify bool
+	// Sources are the logs forwarded, audit and event.
+	Sources []string
+	// Interval between two forwarding passes over the fleet, zero disables them.
+	Interval time.Duration
+	// BatchSize caps the records read and sent at once.
+	BatchSize int
+	// Timeout of connecting to the collector and of each write to it.
+	Timeout time.Duration
+}
+
+// UseCase -.
+type UseCase struct {
+	repo   Repository
+	audit  AuditLogs
+	events EventLogs
+	fleet  Fleet
+	log    logger.Interface
+	cfg    Config
+	now    func() time.Time
+}
+
+// New -.
+func New(r Repository, a AuditLogs, e EventLogs, f Fleet, log logger.Interface, cfg Config) *UseCase {
+	cfg.Network = strings.ToLower(cfg.Network)
+	cfg.Format = strings.ToLower(cfg.Format)
+
+	if cfg.Network == "" {
+		cfg.Network = NetworkUDP
+	}
+
+	if cfg.Format == "" {
+		cfg.Format = FormatRFC5424
+	}
+
+	if cfg.AppName == "" {
+		cfg.AppName = "console"
+	}
+
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = defaultBatchSize
+	}
+
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = defaultTimeout
+	}
+
+	return &UseCase{
+		repo:   r,
+		audit:  a,
+		events: e,
+		fleet:  f,
+		log:    log,
+		cfg:    cfg,
+		now:    time.Now,
+	}
+}
+
+var (
+	ErrSIEMUseCase = consoleerrors.CreateConsoleError("SIEMUseCase")
+	ErrDatabase    = sqldb.DatabaseError{Console: ErrSIEMUseCase}
+	ErrNotFound    = sqldb.NotFoundError{Console: ErrSIEMUseCase}
+	ErrNotValid    = dto.NotValidError{Console: ErrSIEMUseCase}
+
+	errNotConfigured = errors.New("no SIEM collector is configured")
+	errNetwork       = errors.New("network must be udp, tcp or tls")
+	errFormat        = errors.New("format must be rfc5424 or cef")
+	errSource        = errors.New("sources must be audit or event")
+)
+
+func (uc *UseCase) GetCursorCount(ctx context.Context, tenantID string) (int, error) {
+	count, err := uc.repo.GetCursorCount(ctx, tenantID)
+	if err != nil {
+		return 0, ErrDatabase.Wrap("GetCursorCount", "uc.repo.GetCursorCount", err)
+	}
+
+	return count, nil
+}
+
+// GetCursors returns how far the logs of the devices of a tenant have been forwarded.
+func (uc *UseCase) GetCursors(ctx context.Context, top, skip int, tenantID string) ([]dto.SIEMCursor, error) {
+	data, err := uc.repo.GetCursors(ctx, top, skip, tenantID)
+	if err != nil {
+		return nil, ErrDatabase.Wrap("GetCursors", "uc.repo.GetCursors", err)
+	}
+
+	d1 := make([]dto.SIEMCursor, len(data))
+
+	for i := range data {
+		d1[i] = *cursorToDTO(&data[i])
+	}
+
+	return d1, nil
+}
+
+// validate reports the first setting forwarding cannot work with.
+func (uc *UseCase) validate() error {
+	if uc.cfg.Address == "" {
+		return errNotConfigured
+	}
+
+	if uc.cfg.Network != NetworkUDP && uc.cfg.Network != NetworkTCP && uc.cfg.Network != NetworkTLS {
+		return errNetwork
+	}
+
+	if uc.cfg.Format != FormatRFC5424 && uc.cfg.Format != FormatCEF {
+		return errFormat
+	}
+
+	for _, s := range uc.cfg.Sources {
+		if s != dto.SIEMSourceAudit && s != dto.SIEMSourceEvent {
+			return errSource
+		}
+	}
+
+	return nil
+}
+
+func cursorToDTO(d *entity.SIEMCursor) *dto.SIEMCursor {
+	d1 := &dto.SIEMCursor{
+		Source:    d.Source,
+		GUID:      d.GUID,
+		Forwarded: d.Forwarded,
+		Error:     d.Error,
+		TenantID:  d.TenantID,
+	}
+
+	d1.LastCollectedAt, _ = time.Parse(time.RFC3339, d.LastCollectedAt)
+	d1.LastTime, _ = time.Parse(time.RFC3339, d.LastTime)
+	d1.ForwardedAt, _ = time.Parse(time.RFC3339, d.ForwardedAt)
+
+	return d1
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,525 @@
+package siem_test
+
+import (
+	"bufio"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity"
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	"github.com/open-amt-cloud-toolkit/console/internal/mocks"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/siem"
+	"github.com/open-amt-cloud-toolkit/console/pkg/logger"
+)
+
+var device = entity.Device{GUID: "guid1", Hostname: "host one", TenantID: "tenant1"}
+
+type siemTest struct {
+	repo   *mocks.MockSIEMRepository
+	audit  *mocks.MockSIEMAuditLogs
+	events *mocks.MockSIEMEventLogs
+	fleet  *mocks.MockSIEMFleet
+}
+
+func initSIEMTest(t *testing.T) siemTest {
+	t.Helper()
+
+	mockCtl := gomock.NewController(t)
+
+	return siemTest{
+		repo:   mocks.NewMockSIEMRepository(mockCtl),
+		audit:  mocks.NewMockSIEMAuditLogs(mockCtl),
+		events: mocks.NewMockSIEMEventLogs(mockCtl),
+		fleet:  mocks.NewMockSIEMFleet(mockCtl),
+	}
+}
+
+func (test siemTest) useCase(cfg siem.Config) *siem.UseCase {
+	return siem.New(test.repo, test.audit, test.events, test.fleet, logger.New("error"), cfg)
+}
+
+// store keeps the cursors the use case writes and serves the collected logs after a position, the way the
+// repositories would.
+type store struct {
+	mu      sync.Mutex
+	cursors map[string]entity.SIEMCursor
+	audit   []entity.AMTAuditRecord
+	events  []entity.DeviceEvent
+}
+
+func after(p entity.LogPosition, collectedAt, recordTime, fingerprint string) bool {
+	if collectedAt != p.CollectedAt {
+		return collectedAt > p.CollectedAt
+	}
+
+	if recordTime != p.Time {
+		return recordTime > p.Time
+	}
+
+	return fingerprint > p.Fingerprint
+}
+
+func expectStore(test siemTest) *store {
+	s := &store{cursors: map[string]entity.SIEMCursor{}}
+
+	test.repo.EXPECT().GetCursor(gomock.Any(), gomock.Any(), "guid1", "tenant1").AnyTimes().DoAndReturn(func(_ context.Context, source, _, _ string) (*entity.SIEMCursor, error) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		c, ok := s.cursors[source]
+		if !ok {
+			return nil, nil
+		}
+
+		return &c, nil
+	})
+
+	test.repo.EXPECT().UpsertCursor(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(func(_ context.Context, c *entity.SIEMCursor) error {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		s.cursors[c.Source] = *c
+
+		return nil
+	})
+
+	test.audit.EXPECT().GetAfter(gomock.Any(), "guid1", "tenant1", gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().
+		DoAndReturn(func(_ context.Context, _, _ string, p entity.LogPosition, collectedBefore string, limit int) ([]entity.AMTAuditRecord, error) {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+
+			records := []entity.AMTAuditRecord{}
+
+			for _, r := range s.audit {
+				if after(p, r.CollectedAt, r.Time, r.Fingerprint) && r.CollectedAt < collectedBefore && len(records) < limit {
+					records = append(records, r)
+				}
+			}
+
+			return records, nil
+		})
+
+	test.events.EXPECT().GetEventsAfter(gomock.Any(), "guid1", "tenant1", gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().
+		DoAndReturn(func(_ context.Context, _, _ string, p entity.LogPosition, collectedBefore string, limit int) ([]entity.DeviceEvent, error) {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+
+			events := []entity.DeviceEvent{}
+
+			for _, e := range s.events {
+				if after(p, e.CollectedAt, e.Time, e.Fingerprint) && e.CollectedAt < collectedBefore && len(events) < limit {
+					events = append(events, e)
+				}
+			}
+
+			return events, nil
+		})
+
+	test.fleet.EXPECT().GetByID(gomock.Any(), "guid1", "tenant1").AnyTimes().Return(&device, nil)
+
+	return s
+}
+
+func auditRecord(i int) entity.AMTAuditRecord {
+	return entity.AMTAuditRecord{
+		GUID:        "guid1",
+		Fingerprint: "fp" + strconv.Itoa(i),
+		AuditAppID:  16,
+		AuditApp:    "Security Admin",
+		EventID:     i,
+		Event:       "Provisioning Started",
+		Initiator:   `ad"m]in\`,
+		Time:        fmt.Sprintf("2024-12-01T10:%02d:00Z", i),
+		NetAddress:  "192.168.1.5",
+		Description: "event " + strconv.Itoa(i),
+		CollectedAt: "2024-12-01T11:00:00Z",
+		TenantID:    "tenant1",
+	}
+}
+
+func deviceEvent() entity.DeviceEvent {
+	return entity.DeviceEvent{
+		GUID:        "guid1",
+		Fingerprint: "fe1",
+		Time:        "2024-12-01T10:30:00Z",
+		Severity:    "Critical condition",
+		Entity:      "Intel(r) ME",
+		SensorType:  6,
+		EventType:   111,
+		EventOffset: 5,
+		Description: "Authentication failed 10 times. a=b|c",
+		CollectedAt: "2024-12-01T11:00:00Z",
+		TenantID:    "tenant1",
+	}
+}
+
+// listener is a local syslog collector.
+type listener struct {
+	addr     string
+	messages chan string
+}
+
+func (l *listener) next(t *testing.T) string {
+	t.Helper()
+
+	select {
+	case m := <-l.messages:
+		return m
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "no message received")
+
+		return ""
+	}
+}
+
+func listenUDP(t *testing.T) *listener {
+	t.Helper()
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = conn.Close() })
+
+	l := &listener{addr: conn.LocalAddr().String(), messages: make(chan string, 100)}
+
+	go func() {
+		buf := make([]byte, 65536)
+
+		for {
+			n, _, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+
+			l.messages <- string(buf[:n])
+		}
+	}()
+
+	return l
+}
+
+// listenStream accepts connections from ln and splits what they send into octet counted messages.
+func listenStream(t *testing.T, ln net.Listener) *listener {
+	t.Helper()
+
+	t.Cleanup(func() { _ = ln.Close() })
+
+	l := &listener{addr: ln.Addr().String(), messages: make(chan string, 100)}
+
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+
+			go func() {
+				defer conn.Close()
+
+				r := bufio.NewReader(conn)
+
+				for {
+					length, err := r.ReadString(' ')
+					if err != nil {
+						return
+					}
+
+					n, err := strconv.Atoi(length[:len(length)-1])
+					if err != nil {
+						return
+					}
+
+					msg := make([]byte, n)
+					if _, err := io.ReadFull(r, msg); err != nil {
+						return
+					}
+
+					l.messages <- string(msg)
+				}
+			}()
+		}
+	}()
+
+	return l
+}
+
+func listenTCP(t *testing.T) *listener {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	return listenStream(t, ln)
+}
+
+// listenTLS starts a collector with a self-signed certificate, which it writes to a CA file for the forwarder.
+func listenTLS(t *testing.T) (l *listener, caFile string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "collector"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	caFile = filepath.Join(t.TempDir(), "ca.pem")
+	require.NoError(t, os.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+
+	ln, err := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{{Certificate: [][]byte{der}, PrivateKey: key}},
+	})
+	require.NoError(t, err)
+
+	return listenStream(t, ln), caFile
+}
+
+func TestForwardRFC5424(t *testing.T) {
+	t.Parallel()
+
+	test := initSIEMTest(t)
+	s := expectStore(test)
+	s.audit = []entity.AMTAuditRecord{auditRecord(1)}
+	s.events = []entity.DeviceEvent{deviceEvent()}
+
+	l := listenUDP(t)
+	uc := test.useCase(siem.Config{Address: l.addr, Facility: 13})
+
+	cursors, err := uc.Forward(context.Background(), "guid1", "tenant1")
+	require.NoError(t, err)
+	require.Len(t, cursors, 2)
+
+	require.Equal(t, `<109>1 2024-12-01T10:01:00Z host_one console - AMTAudit [amt@343 guid="guid1" tenantId="tenant1" `+
+		`auditApp="Security Admin" auditAppId="16" event="Provisioning Started" eventId="1" initiator="ad\"m\]in\\" `+
+		`netAddress="192.168.1.5" fingerprint="fp1"] Security Admin: Provisioning Started - event 1`, l.next(t))
+
+	require.Equal(t, `<106>1 2024-12-01T10:30:00Z host_one console - AMTEvent [amt@343 guid="guid1" tenantId="tenant1" `+
+		`entity="Intel(r) ME" entityInstance="0" severity="Critical condition" sensorType="6" sensorNumber="0" eventType="111" `+
+		`eventOffset="5" eventSourceType="0" fingerprint="fe1"] Intel(r) ME: Authentication failed 10 times. a=b|c`, l.next(t))
+}
+
+func TestForwardCEFOverTCP(t *testing.T) {
+	t.Parallel()
+
+	test := initSIEMTest(t)
+	s := expectStore(test)
+	s.events = []entity.DeviceEvent{deviceEvent()}
+
+	l := listenTCP(t)
+	uc := test.useCase(siem.Config{Network: "tcp", Address: l.addr, Format: "cef", Facility: 13, Sources: []string{dto.SIEMSourceEvent}})
+
+	cursors, err := uc.Forward(context.Background(), "guid1", "tenant1")
+	require.NoError(t, err)
+	require.Len(t, cursors, 1)
+
+	rt := time.Date(2024, 12, 1, 10, 30, 0, 0, time.UTC).UnixMilli()
+
+	require.Equal(t, `<106>1 2024-12-01T10:30:00Z host_one console - AMTEvent - `+
+		`CEF:0|Intel|AMT||event:6:111:5|Intel(r) ME: Authentication failed 10 times. a=b\|c|9|`+
+		`rt=`+strconv.FormatInt(rt, 10)+` dvchost=host one deviceExternalId=guid1 cs1=tenant1 cs1Label=tenantId cat=Intel(r) ME `+
+		`cs2=Critical condition cs2Label=eventSeverity cn1=6 cn1Label=sensorType cn2=111 cn2Label=eventType cn3=5 cn3Label=eventOffset `+
+		`msg=Authentication failed 10 times. a\=b|c externalId=fe1`, l.next(t))
+}
+
+func TestForwardOverTLS(t *testing.T) {
+	t.Parallel()
+
+	test := initSIEMTest(t)
+	s := expectStore(test)
+	s.audit = []entity.AMTAuditRecord{auditRecord(1), auditRecord(2)}
+
+	l, caFile := listenTLS(t)
+	uc := test.useCase(siem.Config{Network: "tls", Address: l.addr, CAFile: caFile, Sources: []string{dto.SIEMSourceAudit}})
+
+	_, err := uc.Forward(context.Background(), "guid1", "tenant1")
+	require.NoError(t, err)
+
+	require.Contains(t, l.next(t), `fingerprint="fp1"`)
+	require.Contains(t, l.next(t), `fingerprint="fp2"`)
+}
+
+func TestForwardResumesFromCursor(t *testing.T) {
+	t.Parallel()
+
+	test := initSIEMTest(t)
+	s := expectStore(test)
+	s.audit = []entity.AMTAuditRecord{auditRecord(1), auditRecord(2), auditRecord(3)}
+
+	l := listenUDP(t)
+	uc := test.useCase(siem.Config{Address: l.addr, BatchSize: 2, Sources: []string{dto.SIEMSourceAudit}})
+
+	cursors, err := uc.Forward(context.Background(), "guid1", "tenant1")
+	require.NoError(t, err)
+	require.Equal(t, 3, cursors[0].Forwarded)
+	require.Equal(t, time.Date(2024, 12, 1, 10, 3, 0, 0, time.UTC), cursors[0].LastTime)
+
+	for i := 1; i <= 3; i++ {
+		require.Contains(t, l.next(t), `fingerprint="fp`+strconv.Itoa(i)+`"`)
+	}
+
+	// only what was collected since is sent the next time
+	s.mu.Lock()
+	record := auditRecord(4)
+	record.CollectedAt = "2024-12-01T12:00:00Z"
+	s.audit = append(s.audit, record)
+	s.mu.Unlock()
+
+	cursors, err = uc.Forward(context.Background(), "guid1", "tenant1")
+	require.NoError(t, err)
+	require.Equal(t, 1, cursors[0].Forwarded)
+	require.Contains(t, l.next(t), `fingerprint="fp4"`)
+	require.Equal(t, "fp4", s.cursors[dto.SIEMSourceAudit].LastFingerprint)
+}
+
+func TestForwardHoldsRecentCollections(t *testing.T) {
+	t.Parallel()
+
+	test := initSIEMTest(t)
+	s := expectStore(test)
+
+	// a collection of a moment ago may still be storing its records
+	record := auditRecord(1)
+	record.CollectedAt = time.Now().UTC().Format(time.RFC3339)
+	s.audit = []entity.AMTAuditRecord{record}
+
+	l := listenUDP(t)
+	uc := test.useCase(siem.Config{Address: l.addr, Sources: []string{dto.SIEMSourceAudit}})
+
+	cursors, err := uc.Forward(context.Background(), "guid1", "tenant1")
+	require.NoError(t, err)
+	require.Equal(t, 0, cursors[0].Forwarded)
+	require.Empty(t, s.cursors[dto.SIEMSourceAudit].LastFingerprint)
+}
+
+func TestForwardKeepsCursorOnFailure(t *testing.T) {
+	t.Parallel()
+
+	test := initSIEMTest(t)
+	s := expectStore(test)
+	s.audit = []entity.AMTAuditRecord{auditRecord(1)}
+
+	// nothing listens at the address any more
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	addr := ln.Addr().String()
+	require.NoError(t, ln.Close())
+
+	uc := test.useCase(siem.Config{Network: "tcp", Address: addr, Sources: []string{dto.SIEMSourceAudit}})
+
+	_, err = uc.Forward(context.Background(), "guid1", "tenant1")
+	require.IsType(t, &siem.ErrSIEMUseCase, err)
+
+	cursor := s.cursors[dto.SIEMSourceAudit]
+	require.NotEmpty(t, cursor.Error)
+	require.Empty(t, cursor.LastFingerprint)
+	require.Equal(t, 0, cursor.Forwarded)
+}
+
+func TestForwardNotValid(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		cfg  siem.Config
+	}{
+		{"no collector", siem.Config{}},
+		{"unknown network", siem.Config{Address: "127.0.0.1:514", Network: "http"}},
+		{"unknown format", siem.Config{Address: "127.0.0.1:514", Format: "leef"}},
+		{"unknown source", siem.Config{Address: "127.0.0.1:514", Sources: []string{"console"}}},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := initSIEMTest(t).useCase(tc.cfg).Forward(context.Background(), "guid1", "tenant1")
+			require.IsType(t, siem.ErrNotValid, err)
+		})
+	}
+}
+
+func TestForwardUnknownDevice(t *testing.T) {
+	t.Parallel()
+
+	test := initSIEMTest(t)
+
+	test.fleet.EXPECT().GetByID(gomock.Any(), "guid2", "tenant1").Return(nil, nil)
+
+	_, err := test.useCase(siem.Config{Address: "127.0.0.1:514"}).Forward(context.Background(), "guid2", "tenant1")
+	require.ErrorIs(t, err, siem.ErrNotFound)
+}
+
+func TestForwardAll(t *testing.T) {
+	t.Parallel()
+
+	test := initSIEMTest(t)
+	s := expectStore(test)
+	s.audit = []entity.AMTAuditRecord{auditRecord(1)}
+	s.events = []entity.DeviceEvent{deviceEvent()}
+
+	test.fleet.EXPECT().GetAllTenants(gomock.Any(), 100, 0).Return([]entity.Device{device}, nil)
+
+	l := listenTCP(t)
+	test.useCase(siem.Config{Network: "tcp", Address: l.addr}).ForwardAll(context.Background())
+
+	require.Contains(t, l.next(t), "AMTAudit")
+	require.Contains(t, l.next(t), "AMTEvent")
+	require.Len(t, s.cursors, 2)
+}
+
+func TestGetCursors(t *testing.T) {
+	t.Parallel()
+
+	test := initSIEMTest(t)
+
+	test.repo.EXPECT().GetCursors(gomock.Any(), 10, 0, "tenant1").Return([]entity.SIEMCursor{{
+		Source:          dto.SIEMSourceAudit,
+		GUID:            "guid1",
+		LastCollectedAt: "2024-12-01T11:00:00Z",
+		LastTime:        "2024-12-01T10:03:00Z",
+		LastFingerprint: "fp3",
+		ForwardedAt:     "2024-12-01T11:10:00Z",
+		Forwarded:       3,
+		TenantID:        "tenant1",
+	}}, nil)
+
+	cursors, err := test.useCase(siem.Config{}).GetCursors(context.Background(), 10, 0, "tenant1")
+	require.NoError(t, err)
+	require.Len(t, cursors, 1)
+	require.Equal(t, time.Date(2024, 12, 1, 11, 10, 0, 0, time.UTC), cursors[0].ForwardedAt)
+	require.Equal(t, 3, cursors[0].Forwarded)
+}
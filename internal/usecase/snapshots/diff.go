@@ -0,0 +1,125 @@
+package snapshots
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+	"strconv"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+)
+
+// compareSection lists the values that differ between two readings of a section. A section only one snapshot has
+// is a single change of the whole section.
+func compareSection(section string, left, right json.RawMessage) ([]dto.SnapshotChange, error) {
+	switch {
+	case left == nil && right == nil:
+		return nil, nil
+	case left == nil:
+		return []dto.SnapshotChange{{Section: section, Kind: dto.SnapshotChangeAdded, Right: right}}, nil
+	case right == nil:
+		return []dto.SnapshotChange{{Section: section, Kind: dto.SnapshotChangeRemoved, Left: left}}, nil
+	}
+
+	leftValues, err := flatten(left)
+	if err != nil {
+		return nil, err
+	}
+
+	rightValues, err := flatten(right)
+	if err != nil {
+		return nil, err
+	}
+
+	paths := make([]string, 0, len(leftValues)+len(rightValues))
+
+	for path := range leftValues {
+		paths = append(paths, path)
+	}
+
+	for path := range rightValues {
+		if _, ok := leftValues[path]; !ok {
+			paths = append(paths, path)
+		}
+	}
+
+	sort.Strings(paths)
+
+	changes := make([]dto.SnapshotChange, 0)
+
+	for _, path := range paths {
+		l, inLeft := leftValues[path]
+		r, inRight := rightValues[path]
+
+		change := dto.SnapshotChange{Section: section, Path: path, Left: l, Right: r}
+
+		switch {
+		case !inLeft:
+			change.Kind = dto.SnapshotChangeAdded
+		case !inRight:
+			change.Kind = dto.SnapshotChangeRemoved
+		case !bytes.Equal(l, r):
+			change.Kind = dto.SnapshotChangeChanged
+		default:
+			continue
+		}
+
+		changes = append(changes, change)
+	}
+
+	return changes, nil
+}
+
+// flatten maps the dotted path of every scalar, empty object and empty list in a JSON document to its encoding.
+func flatten(data json.RawMessage) (map[string]json.RawMessage, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]json.RawMessage)
+
+	return values, flattenValue("", value, values)
+}
+
+func flattenValue(path string, value interface{}, values map[string]json.RawMessage) error {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if len(v) > 0 {
+			for key, item := range v {
+				itemPath := key
+				if path != "" {
+					itemPath = path + "." + key
+				}
+
+				if err := flattenValue(itemPath, item, values); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		}
+	case []interface{}:
+		if len(v) > 0 {
+			for i, item := range v {
+				if err := flattenValue(path+"["+strconv.Itoa(i)+"]", item, values); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		}
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	values[path] = data
+
+	return nil
+}
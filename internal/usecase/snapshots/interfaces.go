@@ -0,0 +1,39 @@
+package snapshots
+
+import (
+	"context"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity"
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	dtov2 "github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v2"
+)
+
+type (
+	Repository interface {
+		GetCount(ctx context.Context, guid, tenantID string) (int, error)
+		Get(ctx context.Context, guid string, top, skip int, tenantID string) ([]entity.DeviceSnapshot, error)
+		GetByID(ctx context.Context, id, tenantID string) (*entity.DeviceSnapshot, error)
+		Delete(ctx context.Context, guid, id, tenantID string) (bool, error)
+		Insert(ctx context.Context, s *entity.DeviceSnapshot) error
+	}
+	Feature interface {
+		Take(ctx context.Context, guid, tenantID string) (*dto.DeviceSnapshot, error)
+		GetCount(ctx context.Context, guid, tenantID string) (int, error)
+		Get(ctx context.Context, guid string, top, skip int, tenantID string) ([]dto.DeviceSnapshot, error)
+		GetByID(ctx context.Context, guid, id, tenantID string) (*dto.DeviceSnapshot, error)
+		Delete(ctx context.Context, guid, id, tenantID string) error
+		Diff(ctx context.Context, leftID, rightID string, sections []string, tenantID string) (dto.SnapshotDiff, error)
+	}
+	// Devices is the part of the device management feature a snapshot is read with.
+	Devices interface {
+		GetByID(ctx context.Context, guid, tenantID string) (*dto.Device, error)
+		GetVersion(ctx context.Context, guid string) (dto.Version, dtov2.Version, error)
+		GetFeatures(ctx context.Context, guid string) (dto.Features, dtov2.Features, error)
+		GetNetworkSettings(ctx context.Context, guid string) (dto.NetworkSettings, error)
+		GetCertificates(ctx context.Context, guid string) (dto.SecuritySettings, error)
+		GetTLSSettingData(ctx context.Context, guid string) ([]dto.SettingDataResponse, error)
+		GetGeneralSettings(ctx context.Context, guid string) (interface{}, error)
+		GetHardwareInfo(ctx context.Context, guid string) (interface{}, error)
+		GetPowerCapabilities(ctx context.Context, guid string) (dto.PowerCapabilities, error)
+	}
+)
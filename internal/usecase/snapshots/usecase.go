@@ -0,0 +1,314 @@
+package snapshots
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity"
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/sqldb"
+	"github.com/open-amt-cloud-toolkit/console/pkg/consoleerrors"
+	"github.com/open-amt-cloud-toolkit/console/pkg/logger"
+	"github.com/open-amt-cloud-toolkit/console/pkg/tenant"
+)
+
+// UseCase -.
+type UseCase struct {
+	repo    Repository
+	devices Devices
+	log     logger.Interface
+}
+
+// New -.
+func New(r Repository, d Devices, log logger.Interface) *UseCase {
+	return &UseCase{
+		repo:    r,
+		devices: d,
+		log:     log,
+	}
+}
+
+var (
+	ErrSnapshotsUseCase = consoleerrors.CreateConsoleError("SnapshotsUseCase")
+	ErrDatabase         = sqldb.DatabaseError{Console: ErrSnapshotsUseCase}
+	ErrNotFound         = sqldb.NotFoundError{Console: ErrSnapshotsUseCase}
+	ErrNotValid         = dto.NotValidError{Console: ErrSnapshotsUseCase}
+
+	errSection = errors.New("unknown snapshot section")
+)
+
+// Sections are the sections of a snapshot in the order they are read and compared.
+var Sections = []string{
+	dto.SnapshotSectionVersion,
+	dto.SnapshotSectionFeatures,
+	dto.SnapshotSectionNetworkSettings,
+	dto.SnapshotSectionCertificates,
+	dto.SnapshotSectionTLSSettingData,
+	dto.SnapshotSectionGeneralSettings,
+	dto.SnapshotSectionHardwareInfo,
+	dto.SnapshotSectionBootCapabilities,
+}
+
+// Take reads every section of a device and stores them as a snapshot. A section that cannot be read is recorded
+// with its error, the snapshot is only refused when no section could be read.
+func (uc *UseCase) Take(ctx context.Context, guid, tenantID string) (*dto.DeviceSnapshot, error) {
+	// the device management calls find the device in the tenant of ctx
+	ctx = tenant.NewContext(ctx, tenantID)
+
+	if _, err := uc.devices.GetByID(ctx, guid, tenantID); err != nil {
+		return nil, err
+	}
+
+	sections := make(map[string]json.RawMessage, len(Sections))
+	failures := make(map[string]string)
+
+	var firstErr error
+
+	for _, section := range Sections {
+		value, err := uc.read(ctx, guid, section)
+		if err == nil {
+			sections[section], err = json.Marshal(value)
+		}
+
+		if err != nil {
+			uc.log.Warn("snapshots - Take - device %s section %s: %s", guid, section, err.Error())
+
+			failures[section] = err.Error()
+
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+
+	if len(sections) == 0 {
+		return nil, firstErr
+	}
+
+	snapshot := &dto.DeviceSnapshot{
+		ID:       uuid.New().String(),
+		GUID:     guid,
+		TakenAt:  time.Now().UTC().Truncate(time.Second),
+		Sections: sections,
+		Errors:   failures,
+		TenantID: tenantID,
+	}
+
+	d1, err := dtoToEntity(snapshot)
+	if err != nil {
+		return nil, ErrSnapshotsUseCase.Wrap("Take", "dtoToEntity", err)
+	}
+
+	if err := uc.repo.Insert(ctx, d1); err != nil {
+		return nil, ErrDatabase.Wrap("Take", "uc.repo.Insert", err)
+	}
+
+	return snapshot, nil
+}
+
+// read returns the response of the device management call of a section.
+func (uc *UseCase) read(ctx context.Context, guid, section string) (interface{}, error) {
+	switch section {
+	case dto.SnapshotSectionVersion:
+		_, version, err := uc.devices.GetVersion(ctx, guid)
+
+		return version, err
+	case dto.SnapshotSectionFeatures:
+		_, features, err := uc.devices.GetFeatures(ctx, guid)
+
+		return features, err
+	case dto.SnapshotSectionNetworkSettings:
+		return uc.devices.GetNetworkSettings(ctx, guid)
+	case dto.SnapshotSectionCertificates:
+		return uc.devices.GetCertificates(ctx, guid)
+	case dto.SnapshotSectionTLSSettingData:
+		return uc.devices.GetTLSSettingData(ctx, guid)
+	case dto.SnapshotSectionGeneralSettings:
+		return uc.devices.GetGeneralSettings(ctx, guid)
+	case dto.SnapshotSectionHardwareInfo:
+		return uc.devices.GetHardwareInfo(ctx, guid)
+	case dto.SnapshotSectionBootCapabilities:
+		// the power capabilities are what the device reports in its AMT_BootCapabilities
+		return uc.devices.GetPowerCapabilities(ctx, guid)
+	}
+
+	return nil, errSection
+}
+
+func (uc *UseCase) GetCount(ctx context.Context, guid, tenantID string) (int, error) {
+	count, err := uc.repo.GetCount(ctx, guid, tenantID)
+	if err != nil {
+		return 0, ErrDatabase.Wrap("GetCount", "uc.repo.GetCount", err)
+	}
+
+	return count, nil
+}
+
+// Get returns the snapshots of a device newest first, without their sections.
+func (uc *UseCase) Get(ctx context.Context, guid string, top, skip int, tenantID string) ([]dto.DeviceSnapshot, error) {
+	data, err := uc.repo.Get(ctx, guid, top, skip, tenantID)
+	if err != nil {
+		return nil, ErrDatabase.Wrap("Get", "uc.repo.Get", err)
+	}
+
+	d1 := make([]dto.DeviceSnapshot, len(data))
+
+	for i := range data {
+		tmpEntity := data[i] // create a new variable to avoid memory aliasing
+		d1[i] = *uc.entityToDTO(&tmpEntity)
+		d1[i].Sections = nil
+	}
+
+	return d1, nil
+}
+
+// GetByID returns a snapshot of a device.
+func (uc *UseCase) GetByID(ctx context.Context, guid, id, tenantID string) (*dto.DeviceSnapshot, error) {
+	snapshot, err := uc.getByID(ctx, id, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	if snapshot.GUID != guid {
+		return nil, ErrNotFound
+	}
+
+	return snapshot, nil
+}
+
+func (uc *UseCase) getByID(ctx context.Context, id, tenantID string) (*dto.DeviceSnapshot, error) {
+	data, err := uc.repo.GetByID(ctx, id, tenantID)
+	if err != nil {
+		return nil, ErrDatabase.Wrap("GetByID", "uc.repo.GetByID", err)
+	}
+
+	if data == nil {
+		return nil, ErrNotFound
+	}
+
+	return uc.entityToDTO(data), nil
+}
+
+func (uc *UseCase) Delete(ctx context.Context, guid, id, tenantID string) error {
+	isSuccessful, err := uc.repo.Delete(ctx, guid, id, tenantID)
+	if err != nil {
+		return ErrDatabase.Wrap("Delete", "uc.repo.Delete", err)
+	}
+
+	if !isSuccessful {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// Diff compares two snapshots, of one device over time or of two devices, in the sections asked for or in every
+// section when none is.
+func (uc *UseCase) Diff(ctx context.Context, leftID, rightID string, sections []string, tenantID string) (dto.SnapshotDiff, error) {
+	for _, section := range sections {
+		if !isSection(section) {
+			return dto.SnapshotDiff{}, ErrNotValid.Wrap("Diff", "isSection", errSection)
+		}
+	}
+
+	if len(sections) == 0 {
+		sections = Sections
+	}
+
+	left, err := uc.getByID(ctx, leftID, tenantID)
+	if err != nil {
+		return dto.SnapshotDiff{}, err
+	}
+
+	right, err := uc.getByID(ctx, rightID, tenantID)
+	if err != nil {
+		return dto.SnapshotDiff{}, err
+	}
+
+	diff := dto.SnapshotDiff{
+		Sections: sections,
+		Changes:  make([]dto.SnapshotChange, 0),
+	}
+
+	for _, section := range sections {
+		changes, err := compareSection(section, left.Sections[section], right.Sections[section])
+		if err != nil {
+			return dto.SnapshotDiff{}, ErrSnapshotsUseCase.Wrap("Diff", "compareSection", err)
+		}
+
+		diff.Changes = append(diff.Changes, changes...)
+	}
+
+	// the sections are in the changes, the snapshots only say what was compared
+	left.Sections, right.Sections = nil, nil
+	diff.Left, diff.Right = *left, *right
+
+	return diff, nil
+}
+
+func isSection(section string) bool {
+	for _, s := range Sections {
+		if s == section {
+			return true
+		}
+	}
+
+	return false
+}
+
+// convert dto.DeviceSnapshot to entity.DeviceSnapshot.
+func dtoToEntity(d *dto.DeviceSnapshot) (*entity.DeviceSnapshot, error) {
+	sections, err := json.Marshal(d.Sections)
+	if err != nil {
+		return nil, err
+	}
+
+	failures := ""
+
+	if len(d.Errors) > 0 {
+		data, err := json.Marshal(d.Errors)
+		if err != nil {
+			return nil, err
+		}
+
+		failures = string(data)
+	}
+
+	return &entity.DeviceSnapshot{
+		ID:       d.ID,
+		GUID:     d.GUID,
+		TakenAt:  d.TakenAt.UTC().Format(time.RFC3339),
+		Sections: string(sections),
+		Errors:   failures,
+		TenantID: d.TenantID,
+	}, nil
+}
+
+// convert entity.DeviceSnapshot to dto.DeviceSnapshot.
+func (uc *UseCase) entityToDTO(d *entity.DeviceSnapshot) *dto.DeviceSnapshot {
+	d1 := &dto.DeviceSnapshot{
+		ID:       d.ID,
+		GUID:     d.GUID,
+		TenantID: d.TenantID,
+	}
+
+	if taken, err := time.Parse(time.RFC3339, d.TakenAt); err == nil {
+		d1.TakenAt = taken
+	}
+
+	if err := json.Unmarshal([]byte(d.Sections), &d1.Sections); err != nil {
+		uc.log.Warn("snapshots - entityToDTO - snapshot %s has unreadable sections: %s", d.ID, err.Error())
+	}
+
+	if d.Errors != "" {
+		if err := json.Unmarshal([]byte(d.Errors), &d1.Errors); err != nil {
+			uc.log.Warn("snapshots - entityToDTO - snapshot %s has unreadable errors: %s", d.ID, err.Error())
+		}
+	}
+
+	return d1
+}
@@ -0,0 +1,198 @@
+package snapshots_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity"
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	dtov2 "github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v2"
+	"github.com/open-amt-cloud-toolkit/console/internal/mocks"
+	devices "github.com/open-amt-cloud-toolkit/console/internal/usecase/devices"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/snapshots"
+	"github.com/open-amt-cloud-toolkit/console/pkg/logger"
+	"github.com/open-amt-cloud-toolkit/console/pkg/tenant"
+)
+
+var errUnreachable = errors.New("device unreachable")
+
+func initSnapshotsTest(t *testing.T) (*snapshots.UseCase, *mocks.MockSnapshotsRepository, *mocks.MockSnapshotsDevices) {
+	t.Helper()
+
+	mockCtl := gomock.NewController(t)
+	repo := mocks.NewMockSnapshotsRepository(mockCtl)
+	d := mocks.NewMockSnapshotsDevices(mockCtl)
+
+	return snapshots.New(repo, d, logger.New("error")), repo, d
+}
+
+func expectSections(d *mocks.MockSnapshotsDevices, networkErr error) {
+	d.EXPECT().GetVersion(gomock.Any(), "guid1").Return(dto.Version{}, dtov2.Version{AMT: "16.1.25"}, nil)
+	d.EXPECT().GetFeatures(gomock.Any(), "guid1").Return(dto.Features{}, dtov2.Features{UserConsent: "kvm", EnableKVM: true}, nil)
+	d.EXPECT().GetNetworkSettings(gomock.Any(), "guid1").Return(dto.NetworkSettings{}, networkErr)
+	d.EXPECT().GetCertificates(gomock.Any(), "guid1").Return(dto.SecuritySettings{}, nil)
+	d.EXPECT().GetTLSSettingData(gomock.Any(), "guid1").Return([]dto.SettingDataResponse{}, nil)
+	d.EXPECT().GetGeneralSettings(gomock.Any(), "guid1").Return(map[string]interface{}{"hostName": "lab-1"}, nil)
+	d.EXPECT().GetHardwareInfo(gomock.Any(), "guid1").Return(map[string]interface{}{"memory": []int{8, 8}}, nil)
+	d.EXPECT().GetPowerCapabilities(gomock.Any(), "guid1").Return(dto.PowerCapabilities{}, nil)
+}
+
+func TestTake(t *testing.T) {
+	t.Parallel()
+
+	uc, repo, d := initSnapshotsTest(t)
+
+	d.EXPECT().GetByID(gomock.Any(), "guid1", "tenant1").DoAndReturn(func(ctx context.Context, guid, _ string) (*dto.Device, error) {
+		// the device management calls look the device up in the tenant of ctx
+		require.Equal(t, "tenant1", tenant.FromContext(ctx))
+
+		return &dto.Device{GUID: guid}, nil
+	})
+	expectSections(d, errUnreachable)
+
+	var stored *entity.DeviceSnapshot
+
+	repo.EXPECT().Insert(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, s *entity.DeviceSnapshot) error {
+		stored = s
+
+		return nil
+	})
+
+	snapshot, err := uc.Take(context.Background(), "guid1", "tenant1")
+	require.NoError(t, err)
+
+	require.Equal(t, "guid1", snapshot.GUID)
+	require.Len(t, snapshot.Sections, 7)
+	require.NotContains(t, snapshot.Sections, dto.SnapshotSectionNetworkSettings)
+	require.Equal(t, map[string]string{dto.SnapshotSectionNetworkSettings: errUnreachable.Error()}, snapshot.Errors)
+	require.JSONEq(t, `{"hostName":"lab-1"}`, string(snapshot.Sections[dto.SnapshotSectionGeneralSettings]))
+
+	require.Equal(t, snapshot.ID, stored.ID)
+	require.Equal(t, "tenant1", stored.TenantID)
+	require.Equal(t, `{"networkSettings":"device unreachable"}`, stored.Errors)
+}
+
+func TestTakeUnreachable(t *testing.T) {
+	t.Parallel()
+
+	uc, _, d := initSnapshotsTest(t)
+
+	d.EXPECT().GetByID(gomock.Any(), "guid1", "tenant1").Return(&dto.Device{GUID: "guid1"}, nil)
+	d.EXPECT().GetVersion(gomock.Any(), "guid1").Return(dto.Version{}, dtov2.Version{}, errUnreachable)
+	d.EXPECT().GetFeatures(gomock.Any(), "guid1").Return(dto.Features{}, dtov2.Features{}, errUnreachable)
+	d.EXPECT().GetNetworkSettings(gomock.Any(), "guid1").Return(dto.NetworkSettings{}, errUnreachable)
+	d.EXPECT().GetCertificates(gomock.Any(), "guid1").Return(dto.SecuritySettings{}, errUnreachable)
+	d.EXPECT().GetTLSSettingData(gomock.Any(), "guid1").Return(nil, errUnreachable)
+	d.EXPECT().GetGeneralSettings(gomock.Any(), "guid1").Return(nil, errUnreachable)
+	d.EXPECT().GetHardwareInfo(gomock.Any(), "guid1").Return(nil, errUnreachable)
+	d.EXPECT().GetPowerCapabilities(gomock.Any(), "guid1").Return(dto.PowerCapabilities{}, errUnreachable)
+
+	// nothing stored when no section could be read
+	_, err := uc.Take(context.Background(), "guid1", "tenant1")
+	require.ErrorIs(t, err, errUnreachable)
+}
+
+func TestTakeUnknownDevice(t *testing.T) {
+	t.Parallel()
+
+	uc, _, d := initSnapshotsTest(t)
+
+	d.EXPECT().GetByID(gomock.Any(), "guid1", "tenant1").Return(nil, devices.ErrNotFound)
+
+	_, err := uc.Take(context.Background(), "guid1", "tenant1")
+	require.IsType(t, devices.ErrNotFound, err)
+}
+
+func TestGetByID(t *testing.T) {
+	t.Parallel()
+
+	uc, repo, _ := initSnapshotsTest(t)
+
+	repo.EXPECT().GetByID(context.Background(), "s1", "tenant1").Return(&entity.DeviceSnapshot{
+		ID: "s1", GUID: "guid1", TakenAt: "2024-12-01T00:00:00Z", Sections: `{"version":{"amt":"16.1.25"}}`, TenantID: "tenant1",
+	}, nil).Times(2)
+
+	snapshot, err := uc.GetByID(context.Background(), "guid1", "s1", "tenant1")
+	require.NoError(t, err)
+	require.JSONEq(t, `{"amt":"16.1.25"}`, string(snapshot.Sections[dto.SnapshotSectionVersion]))
+	require.Nil(t, snapshot.Errors)
+
+	// a snapshot is only found under its own device
+	_, err = uc.GetByID(context.Background(), "guid2", "s1", "tenant1")
+	require.IsType(t, snapshots.ErrNotFound, err)
+}
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		sections []string
+		changes  []dto.SnapshotChange
+		err      error
+	}{
+		{
+			name: "every section",
+			changes: []dto.SnapshotChange{
+				{Section: "version", Path: "amt", Kind: "changed", Left: json.RawMessage(`"16.1.25"`), Right: json.RawMessage(`"16.1.27"`)},
+				{Section: "networkSettings", Kind: "added", Right: json.RawMessage(`{"wired":{"dhcpEnabled":true}}`)},
+				{Section: "hardwareInfo", Path: "memory[1]", Kind: "removed", Left: json.RawMessage(`8`)},
+				{Section: "hardwareInfo", Path: "memory[1].size", Kind: "added", Right: json.RawMessage(`16`)},
+				{Section: "hardwareInfo", Path: "tags", Kind: "added", Right: json.RawMessage(`[]`)},
+			},
+		},
+		{
+			name:     "one section",
+			sections: []string{"version"},
+			changes: []dto.SnapshotChange{
+				{Section: "version", Path: "amt", Kind: "changed", Left: json.RawMessage(`"16.1.25"`), Right: json.RawMessage(`"16.1.27"`)},
+			},
+		},
+		{
+			name:     "unknown section",
+			sections: []string{"bios"},
+			err:      snapshots.ErrNotValid,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			uc, repo, _ := initSnapshotsTest(t)
+
+			if tc.err == nil {
+				repo.EXPECT().GetByID(context.Background(), "s1", "tenant1").Return(&entity.DeviceSnapshot{
+					ID: "s1", GUID: "guid1", TakenAt: "2024-12-01T00:00:00Z", TenantID: "tenant1",
+					Sections: `{"version":{"amt":"16.1.25","sku":"16392"},"hardwareInfo":{"memory":[8,8]}}`,
+					Errors:   `{"networkSettings":"device unreachable"}`,
+				}, nil)
+				repo.EXPECT().GetByID(context.Background(), "s2", "tenant1").Return(&entity.DeviceSnapshot{
+					ID: "s2", GUID: "guid2", TakenAt: "2024-12-02T00:00:00Z", TenantID: "tenant1",
+					Sections: `{"version":{"sku":"16392","amt":"16.1.27"},"networkSettings":{"wired":{"dhcpEnabled":true}},"hardwareInfo":{"memory":[8,{"size":16}],"tags":[]}}`,
+				}, nil)
+			}
+
+			diff, err := uc.Diff(context.Background(), "s1", "s2", tc.sections, "tenant1")
+			if tc.err != nil {
+				require.IsType(t, tc.err, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.changes, diff.Changes)
+			require.Equal(t, "guid1", diff.Left.GUID)
+			require.Equal(t, "guid2", diff.Right.GUID)
+			require.Nil(t, diff.Left.Sections)
+			require.Equal(t, map[string]string{"networkSettings": "device unreachable"}, diff.Left.Errors)
+		})
+	}
+}
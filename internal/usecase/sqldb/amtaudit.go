@@ -0,0 +1,302 @@
+package sqldb
+
+import (
+	"context"
+
+	"github.com/Masterminds/squirrel"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity"
+	"github.com/open-amt-cloud-toolkit/console/pkg/consoleerrors"
+	"github.com/open-amt-cloud-toolkit/console/pkg/db"
+	"github.com/open-amt-cloud-toolkit/console/pkg/logger"
+)
+
+// AMTAuditRepo -.
+type AMTAuditRepo struct {
+	*db.SQL
+	log logger.Interface
+}
+
+var ErrAMTAuditDatabase = DatabaseError{Console: consoleerrors.CreateConsoleError("AMTAuditRepo")}
+
+var amtAuditRecordColumns = []string{
+	"guid",
+	"fingerprint",
+	"audit_app_id",
+	"audit_app",
+	"event_id",
+	"event",
+	"initiator_type",
+	"initiator",
+	"time",
+	"mc_location_type",
+	"net_address",
+	"extended_data",
+	"description",
+	"collected_at",
+	"tenant_id",
+}
+
+var amtAuditCursorColumns = []string{
+	"guid",
+	"last_index",
+	"last_fingerprint",
+	"last_time",
+	"collected_at",
+	"collected",
+	"error",
+	"tenant_id",
+}
+
+// NewAMTAuditRepo -.
+func NewAMTAuditRepo(database *db.SQL, log logger.Interface) *AMTAuditRepo {
+	return &AMTAuditRepo{database, log}
+}
+
+// GetCount returns the number of records matching filter.
+func (r *AMTAuditRepo) GetCount(_ context.Context, filter entity.AMTAuditFilter) (int, error) {
+	sqlQuery, args, err := r.Builder.
+		Select("COUNT(*)").
+		From("amt_audit_records").
+		Where(amtAuditConditions(filter)).
+		ToSql()
+	if err != nil {
+		return 0, ErrAMTAuditDatabase.Wrap("GetCount", "r.Builder: ", err)
+	}
+
+	var count int
+
+	err = r.Pool.QueryRow(sqlQuery, args...).Scan(&count)
+	if err != nil {
+		return 0, ErrAMTAuditDatabase.Wrap("GetCount", "r.Pool.QueryRow", err)
+	}
+
+	return count, nil
+}
+
+// Get returns the records matching filter, newest first.
+func (r *AMTAuditRepo) Get(_ context.Context, filter entity.AMTAuditFilter, top, skip int) ([]entity.AMTAuditRecord, error) {
+	limitedTop, limitedSkip := limitAndOffset(top, skip)
+
+	sqlQuery, args, err := r.Builder.
+		Select(amtAuditRecordColumns...).
+		From("amt_audit_records").
+		Where(amtAuditConditions(filter)).
+		OrderBy("time DESC", "guid", "fingerprint").
+		Limit(limitedTop).
+		Offset(limitedSkip).
+		ToSql()
+	if err != nil {
+		return nil, ErrAMTAuditDatabase.Wrap("Get", "r.Builder: ", err)
+	}
+
+	return r.queryRecords("Get", sqlQuery, args...)
+}
+
+// GetAfter returns up to limit records of a device collected after a position and before collectedBefore, in the
+// order of their positions.
+func (r *AMTAuditRepo) GetAfter(_ context.Context, guid, tenantID string, after entity.LogPosition, collectedBefore string, limit int) ([]entity.AMTAuditRecord, error) {
+	limitedTop, _ := limitAndOffset(limit, 0)
+
+	sqlQuery, args, err := r.Builder.
+		Select(amtAuditRecordColumns...).
+		From("amt_audit_records").
+		Where("guid = ? AND tenant_id = ?", guid, tenantID).
+		Where(afterPosition(after)).
+		Where(squirrel.Lt{"collected_at": collectedBefore}).
+		OrderBy("collected_at", "time", "fingerprint").
+		Limit(limitedTop).
+		ToSql()
+	if err != nil {
+		return nil, ErrAMTAuditDatabase.Wrap("GetAfter", "r.Builder: ", err)
+	}
+
+	return r.queryRecords("GetAfter", sqlQuery, args...)
+}
+
+// Insert stores the records a device's log has not already given, and returns how many were new.
+func (r *AMTAuditRepo) Insert(_ context.Context, records []entity.AMTAuditRecord) (int, error) {
+	if len(records) == 0 {
+		return 0, nil
+	}
+
+	builder := r.Builder.
+		Insert("amt_audit_records").
+		Columns(amtAuditRecordColumns...)
+
+	for i := range records {
+		a := &records[i]
+		builder = builder.Values(a.GUID, a.Fingerprint, a.AuditAppID, a.AuditApp, a.EventID, a.Event, a.InitiatorType, a.Initiator,
+			a.Time, a.MCLocationType, a.NetAddress, a.ExtendedData, a.Description, a.CollectedAt, a.TenantID)
+	}
+
+	sqlQuery, args, err := builder.
+		Suffix("ON CONFLICT (guid, tenant_id, fingerprint) DO NOTHING").
+		ToSql()
+	if err != nil {
+		return 0, ErrAMTAuditDatabase.Wrap("Insert", "r.Builder", err)
+	}
+
+	res, err := r.Pool.Exec(sqlQuery, args...)
+	if err != nil {
+		return 0, ErrAMTAuditDatabase.Wrap("Insert", "r.Pool.Exec", err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return 0, ErrAMTAuditDatabase.Wrap("Insert", "res.RowsAffected", err)
+	}
+
+	return int(rowsAffected), nil
+}
+
+// GetCursor returns how far the log of a device has been collected, nil when it never was.
+func (r *AMTAuditRepo) GetCursor(_ context.Context, guid, tenantID string) (*entity.AMTAuditCursor, error) {
+	sqlQuery, args, err := r.Builder.
+		Select(amtAuditCursorColumns...).
+		From("amt_audit_cursors").
+		Where("guid = ? AND tenant_id = ?", guid, tenantID).
+		ToSql()
+	if err != nil {
+		return nil, ErrAMTAuditDatabase.Wrap("GetCursor", "r.Builder: ", err)
+	}
+
+	cursors, err := r.queryCursors("GetCursor", sqlQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(cursors) == 0 {
+		return nil, nil
+	}
+
+	return &cursors[0], nil
+}
+
+// GetCursors returns the cursors of the devices of a tenant.
+func (r *AMTAuditRepo) GetCursors(_ context.Context, top, skip int, tenantID string) ([]entity.AMTAuditCursor, error) {
+	limitedTop, limitedSkip := limitAndOffset(top, skip)
+
+	sqlQuery, args, err := r.Builder.
+		Select(amtAuditCursorColumns...).
+		From("amt_audit_cursors").
+		Where("tenant_id = ?", tenantID).
+		OrderBy("guid").
+		Limit(limitedTop).
+		Offset(limitedSkip).
+		ToSql()
+	if err != nil {
+		return nil, ErrAMTAuditDatabase.Wrap("GetCursors", "r.Builder: ", err)
+	}
+
+	return r.queryCursors("GetCursors", sqlQuery, args...)
+}
+
+// UpsertCursor stores the cursor of a device, replacing the previous one.
+func (r *AMTAuditRepo) UpsertCursor(_ context.Context, c *entity.AMTAuditCursor) error {
+	sqlQuery, args, err := r.Builder.
+		Insert("amt_audit_cursors").
+		Columns(amtAuditCursorColumns...).
+		Values(c.GUID, c.LastIndex, c.LastFingerprint, c.LastTime, c.CollectedAt, c.Collected, c.Error, c.TenantID).
+		Suffix("ON CONFLICT (guid, tenant_id) DO UPDATE SET last_index = excluded.last_index, " +
+			"last_fingerprint = excluded.last_fingerprint, last_time = excluded.last_time, " +
+			"collected_at = excluded.collected_at, collected = excluded.collected, error = excluded.error").
+		ToSql()
+	if err != nil {
+		return ErrAMTAuditDatabase.Wrap("UpsertCursor", "r.Builder", err)
+	}
+
+	_, err = r.Pool.Exec(sqlQuery, args...)
+	if err != nil {
+		return ErrAMTAuditDatabase.Wrap("UpsertCursor", "r.Pool.Exec", err)
+	}
+
+	return nil
+}
+
+func (r *AMTAuditRepo) queryRecords(call, sqlQuery string, args ...interface{}) ([]entity.AMTAuditRecord, error) {
+	rows, err := r.Pool.Query(sqlQuery, args...)
+	if err != nil {
+		return nil, ErrAMTAuditDatabase.Wrap(call, "r.Pool.Query", err)
+	}
+
+	defer rows.Close()
+
+	if rows.Err() != nil {
+		return nil, ErrAMTAuditDatabase.Wrap(call, "rows.Err", rows.Err())
+	}
+
+	records := make([]entity.AMTAuditRecord, 0)
+
+	for rows.Next() {
+		a := entity.AMTAuditRecord{}
+
+		err = rows.Scan(&a.GUID, &a.Fingerprint, &a.AuditAppID, &a.AuditApp, &a.EventID, &a.Event, &a.InitiatorType, &a.Initiator,
+			&a.Time, &a.MCLocationType, &a.NetAddress, &a.ExtendedData, &a.Description, &a.CollectedAt, &a.TenantID)
+		if err != nil {
+			return nil, ErrAMTAuditDatabase.Wrap(call, "rows.Scan: ", err)
+		}
+
+		records = append(records, a)
+	}
+
+	return records, nil
+}
+
+func (r *AMTAuditRepo) queryCursors(call, sqlQuery string, args ...interface{}) ([]entity.AMTAuditCursor, error) {
+	rows, err := r.Pool.Query(sqlQuery, args...)
+	if err != nil {
+		return nil, ErrAMTAuditDatabase.Wrap(call, "r.Pool.Query", err)
+	}
+
+	defer rows.Close()
+
+	if rows.Err() != nil {
+		return nil, ErrAMTAuditDatabase.Wrap(call, "rows.Err", rows.Err())
+	}
+
+	cursors := make([]entity.AMTAuditCursor, 0)
+
+	for rows.Next() {
+		c := entity.AMTAuditCursor{}
+
+		err = rows.Scan(&c.GUID, &c.LastIndex, &c.LastFingerprint, &c.LastTime, &c.CollectedAt, &c.Collected, &c.Error, &c.TenantID)
+		if err != nil {
+			return nil, ErrAMTAuditDatabase.Wrap(call, "rows.Scan: ", err)
+		}
+
+		cursors = append(cursors, c)
+	}
+
+	return cursors, nil
+}
+
+func amtAuditConditions(filter entity.AMTAuditFilter) squirrel.And {
+	conditions := squirrel.And{squirrel.Eq{"tenant_id": filter.TenantID}}
+
+	if filter.GUID != "" {
+		conditions = append(conditions, squirrel.Eq{"guid": filter.GUID})
+	}
+
+	if filter.AuditApp != "" {
+		conditions = append(conditions, squirrel.Eq{"audit_app": filter.AuditApp})
+	}
+
+	if filter.Event != "" {
+		conditions = append(conditions, squirrel.Eq{"event": filter.Event})
+	}
+
+	if filter.Initiator != "" {
+		conditions = append(conditions, squirrel.Eq{"initiator": filter.Initiator})
+	}
+
+	if filter.From != "" {
+		conditions = append(conditions, squirrel.GtOrEq{"time": filter.From})
+	}
+
+	if filter.To != "" {
+		conditions = append(conditions, squirrel.Lt{"time": filter.To})
+	}
+
+	return conditions
+}
@@ -0,0 +1,90 @@
+package sqldb_test
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"testing"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity"
+	"github.com/open-amt-cloud-toolkit/console/internal/mocks"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/sqldb"
+	"github.com/open-amt-cloud-toolkit/console/pkg/db"
+)
+
+func TestAMTAuditRepo(t *testing.T) {
+	t.Parallel()
+
+	dbConn, err := sql.Open("sqlite", ":memory:")
+	require.NoError(t, err)
+
+	defer dbConn.Close()
+
+	schema, err := os.ReadFile("../../app/migrations/20261020130000_amt_audit_log.up.sql")
+	require.NoError(t, err)
+
+	_, err = dbConn.Exec(string(schema))
+	require.NoError(t, err)
+
+	repo := sqldb.NewAMTAuditRepo(&db.SQL{
+		Builder:    squirrel.StatementBuilder.PlaceholderFormat(squirrel.Question),
+		Pool:       dbConn,
+		IsEmbedded: true,
+	}, mocks.NewMockLogger(nil))
+
+	ctx := context.Background()
+
+	records := []entity.AMTAuditRecord{
+		{GUID: "guid1", Fingerprint: "f1", AuditApp: "Security Admin", Event: "Provisioning Started", Initiator: "Local", Time: "2024-12-01T00:00:00Z", TenantID: "tenant1"},
+		{GUID: "guid1", Fingerprint: "f2", AuditApp: "Remote Control", Event: "Performed Power Up", Initiator: "admin", Time: "2024-12-02T00:00:00Z", TenantID: "tenant1"},
+		{GUID: "guid2", Fingerprint: "f1", AuditApp: "Remote Control", Event: "Performed Power Up", Initiator: "admin", Time: "2024-12-03T00:00:00Z", TenantID: "tenant1"},
+		{GUID: "guid1", Fingerprint: "f1", AuditApp: "Security Admin", Event: "Provisioning Started", Initiator: "Local", Time: "2024-12-01T00:00:00Z", TenantID: "tenant2"},
+	}
+
+	inserted, err := repo.Insert(ctx, records)
+	require.NoError(t, err)
+	require.Equal(t, 4, inserted)
+
+	// a record read again after the log wrapped is not stored twice
+	inserted, err = repo.Insert(ctx, records[:2])
+	require.NoError(t, err)
+	require.Equal(t, 0, inserted)
+
+	count, err := repo.GetCount(ctx, entity.AMTAuditFilter{TenantID: "tenant1"})
+	require.NoError(t, err)
+	require.Equal(t, 3, count)
+
+	got, err := repo.Get(ctx, entity.AMTAuditFilter{Initiator: "admin", TenantID: "tenant1"}, 0, 0)
+	require.NoError(t, err)
+	require.Equal(t, []entity.AMTAuditRecord{records[2], records[1]}, got)
+
+	got, err = repo.Get(ctx, entity.AMTAuditFilter{GUID: "guid1", Event: "Performed Power Up", TenantID: "tenant1"}, 0, 0)
+	require.NoError(t, err)
+	require.Equal(t, []entity.AMTAuditRecord{records[1]}, got)
+
+	count, err = repo.GetCount(ctx, entity.AMTAuditFilter{AuditApp: "Remote Control", From: "2024-12-02T00:00:00Z", To: "2024-12-03T00:00:00Z", TenantID: "tenant1"})
+	require.NoError(t, err)
+	require.Equal(t, 1, count)
+
+	cursor, err := repo.GetCursor(ctx, "guid1", "tenant1")
+	require.NoError(t, err)
+	require.Nil(t, cursor)
+
+	c := entity.AMTAuditCursor{GUID: "guid1", LastIndex: 2, LastFingerprint: "f2", LastTime: "2024-12-02T00:00:00Z", CollectedAt: "2024-12-02T01:00:00Z", Collected: 2, TenantID: "tenant1"}
+	require.NoError(t, repo.UpsertCursor(ctx, &c))
+
+	c.Collected = 0
+	c.Error = "timeout"
+	require.NoError(t, repo.UpsertCursor(ctx, &c))
+
+	cursor, err = repo.GetCursor(ctx, "guid1", "tenant1")
+	require.NoError(t, err)
+	require.Equal(t, &c, cursor)
+
+	cursors, err := repo.GetCursors(ctx, 0, 0, "tenant2")
+	require.NoError(t, err)
+	require.Empty(t, cursors)
+}
@@ -120,6 +120,58 @@ func (r *CIRARepo) Get(_ context.Context, top, skip int, tenantID string) ([]ent
 	return configs, nil
 }
 
+// GetAllTenants pages through the CIRA configs of every tenant, it is meant for background jobs only.
+func (r *CIRARepo) GetAllTenants(_ context.Context, top, skip int) ([]entity.CIRAConfig, error) {
+	limit, offset := limitAndOffset(top, skip)
+
+	sqlQuery, _, err := r.Builder.
+		Select("cira_config_name",
+			"mps_server_address",
+			"mps_port",
+			"user_name",
+			"password",
+			"common_name",
+			"server_address_format",
+			"auth_method",
+			"mps_root_certificate",
+			"proxydetails",
+			"tenant_id").
+		From("ciraconfigs").
+		OrderBy("tenant_id", "cira_config_name").
+		Limit(limit).
+		Offset(offset).
+		ToSql()
+	if err != nil {
+		return nil, ErrCIRARepoDatabase.Wrap("GetAllTenants", "r.Builder", err)
+	}
+
+	rows, err := r.Pool.Query(sqlQuery)
+	if err != nil {
+		return nil, ErrCIRARepoDatabase.Wrap("GetAllTenants", "r.Pool.Query", err)
+	}
+
+	defer rows.Close()
+
+	if rows.Err() != nil {
+		return nil, ErrCIRARepoDatabase.Wrap("GetAllTenants", "rows.Err", rows.Err())
+	}
+
+	configs := make([]entity.CIRAConfig, 0)
+
+	for rows.Next() {
+		p := entity.CIRAConfig{}
+
+		err = rows.Scan(&p.ConfigName, &p.MPSAddress, &p.MPSPort, &p.Username, &p.Password, &p.CommonName, &p.ServerAddressFormat, &p.AuthMethod, &p.MPSRootCertificate, &p.ProxyDetails, &p.TenantID)
+		if err != nil {
+			return nil, ErrCIRARepoDatabase.Wrap("GetAllTenants", "rows.Scan", err)
+		}
+
+		configs = append(configs, p)
+	}
+
+	return configs, nil
+}
+
 // GetByName -.
 func (r *CIRARepo) GetByName(_ context.Context, configName, tenantID string) (*entity.CIRAConfig, error) {
 	sqlQuery, _, err := r.Builder.
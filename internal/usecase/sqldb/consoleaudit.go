@@ -0,0 +1,146 @@
+package sqldb
+
+import (
+	"context"
+
+	"github.com/Masterminds/squirrel"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity"
+	"github.com/open-amt-cloud-toolkit/console/pkg/consoleerrors"
+	"github.com/open-amt-cloud-toolkit/console/pkg/db"
+	"github.com/open-amt-cloud-toolkit/console/pkg/logger"
+)
+
+// ConsoleAuditRepo -.
+type ConsoleAuditRepo struct {
+	*db.SQL
+	log logger.Interface
+}
+
+var ErrConsoleAuditDatabase = DatabaseError{Console: consoleerrors.CreateConsoleError("ConsoleAuditRepo")}
+
+var consoleAuditColumns = []string{
+	"id",
+	"time",
+	"subject",
+	"method",
+	"route",
+	"path",
+	"target",
+	"summary",
+	"status_code",
+	"result",
+	"duration_ms",
+	"client_ip",
+	"tenant_id",
+}
+
+// NewConsoleAuditRepo -.
+func NewConsoleAuditRepo(database *db.SQL, log logger.Interface) *ConsoleAuditRepo {
+	return &ConsoleAuditRepo{database, log}
+}
+
+// GetCount -.
+func (r *ConsoleAuditRepo) GetCount(_ context.Context, filter entity.ConsoleAuditFilter) (int, error) {
+	sqlQuery, args, err := r.Builder.
+		Select("COUNT(*)").
+		From("console_audit").
+		Where(auditConditions(filter)).
+		ToSql()
+	if err != nil {
+		return 0, ErrConsoleAuditDatabase.Wrap("GetCount", "r.Builder: ", err)
+	}
+
+	var count int
+
+	err = r.Pool.QueryRow(sqlQuery, args...).Scan(&count)
+	if err != nil {
+		return 0, ErrConsoleAuditDatabase.Wrap("GetCount", "r.Pool.QueryRow", err)
+	}
+
+	return count, nil
+}
+
+// Get returns the entries matching filter, newest first.
+func (r *ConsoleAuditRepo) Get(_ context.Context, filter entity.ConsoleAuditFilter, top, skip int) ([]entity.ConsoleAuditEntry, error) {
+	limitedTop, limitedSkip := limitAndOffset(top, skip)
+
+	sqlQuery, args, err := r.Builder.
+		Select(consoleAuditColumns...).
+		From("console_audit").
+		Where(auditConditions(filter)).
+		OrderBy("time DESC", "id").
+		Limit(limitedTop).
+		Offset(limitedSkip).
+		ToSql()
+	if err != nil {
+		return nil, ErrConsoleAuditDatabase.Wrap("Get", "r.Builder: ", err)
+	}
+
+	rows, err := r.Pool.Query(sqlQuery, args...)
+	if err != nil {
+		return nil, ErrConsoleAuditDatabase.Wrap("Get", "r.Pool.Query", err)
+	}
+
+	defer rows.Close()
+
+	if rows.Err() != nil {
+		return nil, ErrConsoleAuditDatabase.Wrap("Get", "rows.Err", rows.Err())
+	}
+
+	entries := make([]entity.ConsoleAuditEntry, 0)
+
+	for rows.Next() {
+		e := entity.ConsoleAuditEntry{}
+
+		err = rows.Scan(&e.ID, &e.Time, &e.Subject, &e.Method, &e.Route, &e.Path, &e.Target, &e.Summary, &e.StatusCode, &e.Result, &e.DurationMS, &e.ClientIP, &e.TenantID)
+		if err != nil {
+			return nil, ErrConsoleAuditDatabase.Wrap("Get", "rows.Scan: ", err)
+		}
+
+		entries = append(entries, e)
+	}
+
+	return entries, nil
+}
+
+// Insert -.
+func (r *ConsoleAuditRepo) Insert(_ context.Context, e *entity.ConsoleAuditEntry) error {
+	sqlQuery, args, err := r.Builder.
+		Insert("console_audit").
+		Columns(consoleAuditColumns...).
+		Values(e.ID, e.Time, e.Subject, e.Method, e.Route, e.Path, e.Target, e.Summary, e.StatusCode, e.Result, e.DurationMS, e.ClientIP, e.TenantID).
+		ToSql()
+	if err != nil {
+		return ErrConsoleAuditDatabase.Wrap("Insert", "r.Builder", err)
+	}
+
+	_, err = r.Pool.Exec(sqlQuery, args...)
+	if err != nil {
+		return ErrConsoleAuditDatabase.Wrap("Insert", "r.Pool.Exec", err)
+	}
+
+	return nil
+}
+
+func auditConditions(filter entity.ConsoleAuditFilter) squirrel.And {
+	conditions := squirrel.And{squirrel.Eq{"tenant_id": filter.TenantID}}
+
+	if filter.Subject != "" {
+		conditions = append(conditions, squirrel.Eq{"subject": filter.Subject})
+	}
+
+	if filter.Target != "" {
+		conditions = append(conditions, squirrel.Eq{"target": filter.Target})
+	}
+
+	if filter.From != "" {
+		conditions = append(conditions, squirrel.GtOrEq{"time": filter.From})
+	}
+
+	if filter.To != "" {
+		conditions = append(conditions, squirrel.Lt{"time": filter.To})
+	}
+
+	return conditions
+}
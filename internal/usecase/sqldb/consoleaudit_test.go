@@ -0,0 +1,81 @@
+package sqldb_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity"
+	"github.com/open-amt-cloud-toolkit/console/internal/mocks"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/sqldb"
+	"github.com/open-amt-cloud-toolkit/console/pkg/db"
+)
+
+const consoleAuditSchema = `
+CREATE TABLE console_audit(
+  id TEXT NOT NULL,
+  time TEXT NOT NULL,
+  subject TEXT NOT NULL,
+  method TEXT NOT NULL,
+  route TEXT NOT NULL,
+  path TEXT NOT NULL,
+  target TEXT,
+  summary TEXT,
+  status_code INTEGER NOT NULL,
+  result TEXT NOT NULL,
+  duration_ms INTEGER NOT NULL,
+  client_ip TEXT,
+  tenant_id TEXT NOT NULL,
+  PRIMARY KEY (id, tenant_id)
+);
+`
+
+func TestConsoleAuditRepo(t *testing.T) {
+	t.Parallel()
+
+	dbConn, err := sql.Open("sqlite", ":memory:")
+	require.NoError(t, err)
+
+	defer dbConn.Close()
+
+	_, err = dbConn.Exec(consoleAuditSchema)
+	require.NoError(t, err)
+
+	repo := sqldb.NewConsoleAuditRepo(&db.SQL{
+		Builder:    squirrel.StatementBuilder.PlaceholderFormat(squirrel.Question),
+		Pool:       dbConn,
+		IsEmbedded: true,
+	}, mocks.NewMockLogger(nil))
+
+	ctx := context.Background()
+
+	entries := []entity.ConsoleAuditEntry{
+		{ID: "1", Time: "2024-01-01T10:00:00Z", Subject: "alice", Method: "POST", Route: "/api/v1/amt/power/action/:guid", Path: "/api/v1/amt/power/action/guid-1", Target: "guid-1", Summary: `{"action":8}`, StatusCode: 200, Result: "success", DurationMS: 1200, ClientIP: "10.0.0.5", TenantID: "tenant1"},
+		{ID: "2", Time: "2024-01-01T11:00:00Z", Subject: "bob", Method: "DELETE", Route: "/api/v1/devices/cert/:guid", Path: "/api/v1/devices/cert/guid-1", Target: "guid-1", StatusCode: 204, Result: "success", TenantID: "tenant1"},
+		{ID: "3", Time: "2024-01-01T12:00:00Z", Subject: "alice", Method: "POST", Route: "/api/v1/admin/profiles", Path: "/api/v1/admin/profiles", Target: "profile1", StatusCode: 403, Result: "denied", TenantID: "tenant1"},
+		{ID: "4", Time: "2024-01-01T12:00:00Z", Subject: "alice", Method: "POST", Route: "/api/v1/admin/profiles", Path: "/api/v1/admin/profiles", StatusCode: 201, Result: "success", TenantID: "tenant2"},
+	}
+
+	for i := range entries {
+		require.NoError(t, repo.Insert(ctx, &entries[i]))
+	}
+
+	got, err := repo.Get(ctx, entity.ConsoleAuditFilter{TenantID: "tenant1"}, 10, 0)
+	require.NoError(t, err)
+	require.Equal(t, []entity.ConsoleAuditEntry{entries[2], entries[1], entries[0]}, got)
+
+	count, err := repo.GetCount(ctx, entity.ConsoleAuditFilter{Subject: "alice", TenantID: "tenant1"})
+	require.NoError(t, err)
+	require.Equal(t, 2, count)
+
+	got, err = repo.Get(ctx, entity.ConsoleAuditFilter{Target: "guid-1", From: "2024-01-01T10:30:00Z", To: "2024-01-01T12:00:00Z", TenantID: "tenant1"}, 10, 0)
+	require.NoError(t, err)
+	require.Equal(t, []entity.ConsoleAuditEntry{entries[1]}, got)
+
+	got, err = repo.Get(ctx, entity.ConsoleAuditFilter{TenantID: "tenant1"}, 1, 1)
+	require.NoError(t, err)
+	require.Equal(t, []entity.ConsoleAuditEntry{entries[1]}, got)
+}
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"errors"
 	"strings"
+	"time"
 
 	"github.com/open-amt-cloud-toolkit/console/internal/entity"
 	"github.com/open-amt-cloud-toolkit/console/pkg/consoleerrors"
@@ -53,6 +54,27 @@ func (r *DeviceRepo) GetCount(_ context.Context, tenantID string) (int, error) {
 	return count, nil
 }
 
+// GetConnectedCount returns how many devices of the tenant answered their last health probe.
+func (r *DeviceRepo) GetConnectedCount(_ context.Context, tenantID string) (int, error) {
+	sqlQuery, args, err := r.Builder.
+		Select("COUNT(*)").
+		From("devices").
+		Where("tenantid = ? AND connectionstatus = ?", tenantID, true).
+		ToSql()
+	if err != nil {
+		return 0, ErrDeviceDatabase.Wrap("GetConnectedCount", "r.Builder: ", err)
+	}
+
+	var count int
+
+	err = r.Pool.QueryRow(sqlQuery, args...).Scan(&count)
+	if err != nil {
+		return 0, ErrDeviceDatabase.Wrap("GetConnectedCount", "r.Pool.QueryRow", err)
+	}
+
+	return count, nil
+}
+
 // Get -.
 func (r *DeviceRepo) Get(_ context.Context, top, skip int, tenantID string) ([]entity.Device, error) {
 	const defaultTop = 100
@@ -81,6 +103,9 @@ func (r *DeviceRepo) Get(_ context.Context, top, skip int, tenantID string) ([]e
 			"tenantid",
 			"friendlyname",
 			"dnssuffix",
+			"lastconnected",
+			"lastseen",
+			"lastdisconnected",
 			"deviceinfo",
 			"username",
 			"password",
@@ -113,11 +138,15 @@ func (r *DeviceRepo) Get(_ context.Context, top, skip int, tenantID string) ([]e
 	for rows.Next() {
 		d := entity.Device{}
 
-		err = rows.Scan(&d.GUID, &d.Hostname, &d.Tags, &d.MPSInstance, &d.ConnectionStatus, &d.MPSUsername, &d.TenantID, &d.FriendlyName, &d.DNSSuffix, &d.DeviceInfo, &d.Username, &d.Password, &d.UseTLS, &d.AllowSelfSigned, &d.CertHash)
+		var lastConnected, lastSeen, lastDisconnected sql.NullString
+
+		err = rows.Scan(&d.GUID, &d.Hostname, &d.Tags, &d.MPSInstance, &d.ConnectionStatus, &d.MPSUsername, &d.TenantID, &d.FriendlyName, &d.DNSSuffix, &lastConnected, &lastSeen, &lastDisconnected, &d.DeviceInfo, &d.Username, &d.Password, &d.UseTLS, &d.AllowSelfSigned, &d.CertHash)
 		if err != nil {
 			return nil, ErrDeviceDatabase.Wrap("Get", "rows.Scan: ", err)
 		}
 
+		setHealthTimes(&d, lastConnected, lastSeen, lastDisconnected)
+
 		devices = append(devices, d)
 	}
 
@@ -137,6 +166,9 @@ func (r *DeviceRepo) GetByID(_ context.Context, guid, tenantID string) (*entity.
 			"tenantid",
 			"friendlyname",
 			"dnssuffix",
+			"lastconnected",
+			"lastseen",
+			"lastdisconnected",
 			"deviceinfo",
 			"username",
 			"password",
@@ -166,11 +198,15 @@ func (r *DeviceRepo) GetByID(_ context.Context, guid, tenantID string) (*entity.
 	for rows.Next() {
 		d := &entity.Device{}
 
-		err = rows.Scan(&d.GUID, &d.Hostname, &d.Tags, &d.MPSInstance, &d.ConnectionStatus, &d.MPSUsername, &d.TenantID, &d.FriendlyName, &d.DNSSuffix, &d.DeviceInfo, &d.Username, &d.Password, &d.UseTLS, &d.AllowSelfSigned, &d.CertHash)
+		var lastConnected, lastSeen, lastDisconnected sql.NullString
+
+		err = rows.Scan(&d.GUID, &d.Hostname, &d.Tags, &d.MPSInstance, &d.ConnectionStatus, &d.MPSUsername, &d.TenantID, &d.FriendlyName, &d.DNSSuffix, &lastConnected, &lastSeen, &lastDisconnected, &d.DeviceInfo, &d.Username, &d.Password, &d.UseTLS, &d.AllowSelfSigned, &d.CertHash)
 		if err != nil {
 			return d, ErrDeviceDatabase.Wrap("Get", "rows.Scan: ", err)
 		}
 
+		setHealthTimes(d, lastConnected, lastSeen, lastDisconnected)
+
 		devices = append(devices, d)
 	}
 
@@ -233,6 +269,9 @@ func (r *DeviceRepo) GetByTags(_ context.Context, tags []string, method string,
 			"tenantid",
 			"friendlyname",
 			"dnssuffix",
+			"lastconnected",
+			"lastseen",
+			"lastdisconnected",
 			"deviceinfo").
 		From("devices")
 
@@ -289,10 +328,14 @@ func (r *DeviceRepo) GetByTags(_ context.Context, tags []string, method string,
 
 	for rows.Next() {
 		var d entity.Device
-		if err := rows.Scan(&d.GUID, &d.Hostname, &d.Tags, &d.MPSInstance, &d.ConnectionStatus, &d.MPSUsername, &d.TenantID, &d.FriendlyName, &d.DNSSuffix, &d.DeviceInfo); err != nil {
+		var lastConnected, lastSeen, lastDisconnected sql.NullString
+
+		if err := rows.Scan(&d.GUID, &d.Hostname, &d.Tags, &d.MPSInstance, &d.ConnectionStatus, &d.MPSUsername, &d.TenantID, &d.FriendlyName, &d.DNSSuffix, &lastConnected, &lastSeen, &lastDisconnected, &d.DeviceInfo); err != nil {
 			return nil, ErrDeviceDatabase.Wrap("GetByTags", "rows.Scan", err)
 		}
 
+		setHealthTimes(&d, lastConnected, lastSeen, lastDisconnected)
+
 		devices = append(devices, d)
 	}
 
@@ -330,12 +373,10 @@ func (r *DeviceRepo) Update(_ context.Context, d *entity.Device) (bool, error) {
 		Set("hostname", d.Hostname).
 		Set("tags", d.Tags).
 		Set("mpsinstance", d.MPSInstance).
-		Set("connectionstatus", d.ConnectionStatus).
 		Set("mpsusername", d.MPSUsername).
 		Set("tenantid", d.TenantID).
 		Set("friendlyname", d.FriendlyName).
 		Set("dnssuffix", d.DNSSuffix).
-		Set("deviceinfo", d.DeviceInfo).
 		Set("username", d.Username).
 		Set("password", d.Password).
 		Set("useTLS", d.UseTLS).
@@ -360,6 +401,34 @@ func (r *DeviceRepo) Update(_ context.Context, d *entity.Device) (bool, error) {
 	return rowsAffected > 0, nil
 }
 
+// UpdateHealth records the outcome of a health probe without touching the rest of the device.
+func (r *DeviceRepo) UpdateHealth(_ context.Context, d *entity.Device) (bool, error) {
+	sqlQuery, args, err := r.Builder.
+		Update("devices").
+		Set("connectionstatus", d.ConnectionStatus).
+		Set("lastconnected", formatHealthTime(d.LastConnected)).
+		Set("lastseen", formatHealthTime(d.LastSeen)).
+		Set("lastdisconnected", formatHealthTime(d.LastDisconnected)).
+		Set("deviceinfo", d.DeviceInfo).
+		Where("guid = ? AND tenantid = ?", d.GUID, d.TenantID).
+		ToSql()
+	if err != nil {
+		return false, ErrDeviceDatabase.Wrap("UpdateHealth", "r.Builder", err)
+	}
+
+	res, err := r.Pool.Exec(sqlQuery, args...)
+	if err != nil {
+		return false, ErrDeviceDatabase.Wrap("UpdateHealth", "r.Pool.Exec", err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return false, ErrDeviceDatabase.Wrap("UpdateHealth", "res.RowsAffected", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
 // Insert -.
 func (r *DeviceRepo) Insert(_ context.Context, d *entity.Device) (string, error) {
 	insertBuilder := r.Builder.
@@ -407,6 +476,9 @@ func (r *DeviceRepo) GetByColumn(_ context.Context, columnName, queryValue, tena
 			"tenantid",
 			"friendlyname",
 			"dnssuffix",
+			"lastconnected",
+			"lastseen",
+			"lastdisconnected",
 			"deviceinfo",
 			"username",
 			"password",
@@ -436,13 +508,109 @@ func (r *DeviceRepo) GetByColumn(_ context.Context, columnName, queryValue, tena
 	for rows.Next() {
 		d := entity.Device{}
 
-		err = rows.Scan(&d.GUID, &d.Hostname, &d.Tags, &d.MPSInstance, &d.ConnectionStatus, &d.MPSUsername, &d.TenantID, &d.FriendlyName, &d.DNSSuffix, &d.DeviceInfo, &d.Username, &d.Password, &d.UseTLS, &d.AllowSelfSigned, &d.CertHash)
+		var lastConnected, lastSeen, lastDisconnected sql.NullString
+
+		err = rows.Scan(&d.GUID, &d.Hostname, &d.Tags, &d.MPSInstance, &d.ConnectionStatus, &d.MPSUsername, &d.TenantID, &d.FriendlyName, &d.DNSSuffix, &lastConnected, &lastSeen, &lastDisconnected, &d.DeviceInfo, &d.Username, &d.Password, &d.UseTLS, &d.AllowSelfSigned, &d.CertHash)
 		if err != nil {
 			return nil, ErrDeviceDatabase.Wrap("Get", "rows.Scan: ", err)
 		}
 
+		setHealthTimes(&d, lastConnected, lastSeen, lastDisconnected)
+
+		devices = append(devices, d)
+	}
+
+	return devices, nil
+}
+
+// GetAllTenants pages through the devices of every tenant, it is meant for background jobs only.
+func (r *DeviceRepo) GetAllTenants(_ context.Context, top, skip int) ([]entity.Device, error) {
+	limit, offset := limitAndOffset(top, skip)
+
+	sqlQuery, _, err := r.Builder.
+		Select(
+			"guid",
+			"hostname",
+			"tags",
+			"mpsinstance",
+			"connectionstatus",
+			"mpsusername",
+			"tenantid",
+			"friendlyname",
+			"dnssuffix",
+			"lastconnected",
+			"lastseen",
+			"lastdisconnected",
+			"deviceinfo",
+			"username",
+			"password",
+			"usetls",
+			"allowselfsigned",
+			"certhash").
+		From("devices").
+		OrderBy("tenantid", "guid").
+		Limit(limit).
+		Offset(offset).
+		ToSql()
+	if err != nil {
+		return nil, ErrDeviceDatabase.Wrap("GetAllTenants", "r.Builder: ", err)
+	}
+
+	rows, err := r.Pool.Query(sqlQuery)
+	if err != nil {
+		return nil, ErrDeviceDatabase.Wrap("GetAllTenants", "r.Pool.Query", err)
+	}
+
+	defer rows.Close()
+
+	if rows.Err() != nil {
+		return nil, ErrDeviceDatabase.Wrap("GetAllTenants", "rows.Err", rows.Err())
+	}
+
+	devices := make([]entity.Device, 0)
+
+	for rows.Next() {
+		d := entity.Device{}
+
+		var lastConnected, lastSeen, lastDisconnected sql.NullString
+
+		err = rows.Scan(&d.GUID, &d.Hostname, &d.Tags, &d.MPSInstance, &d.ConnectionStatus, &d.MPSUsername, &d.TenantID, &d.FriendlyName, &d.DNSSuffix, &lastConnected, &lastSeen, &lastDisconnected, &d.DeviceInfo, &d.Username, &d.Password, &d.UseTLS, &d.AllowSelfSigned, &d.CertHash)
+		if err != nil {
+			return nil, ErrDeviceDatabase.Wrap("GetAllTenants", "rows.Scan: ", err)
+		}
+
+		setHealthTimes(&d, lastConnected, lastSeen, lastDisconnected)
+
 		devices = append(devices, d)
 	}
 
 	return devices, nil
 }
+
+// the last* columns are stored as RFC3339 text and may be NULL for devices that were never probed.
+func setHealthTimes(d *entity.Device, lastConnected, lastSeen, lastDisconnected sql.NullString) {
+	d.LastConnected = parseHealthTime(lastConnected)
+	d.LastSeen = parseHealthTime(lastSeen)
+	d.LastDisconnected = parseHealthTime(lastDisconnected)
+}
+
+func parseHealthTime(s sql.NullString) *time.Time {
+	if !s.Valid || s.String == "" {
+		return nil
+	}
+
+	t, err := time.Parse(time.RFC3339, s.String)
+	if err != nil {
+		return nil
+	}
+
+	return &t
+}
+
+func formatHealthTime(t *time.Time) interface{} {
+	if t == nil {
+		return nil
+	}
+
+	return t.UTC().Format(time.RFC3339)
+}
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/Masterminds/squirrel"
 	"github.com/stretchr/testify/assert"
@@ -79,6 +80,9 @@ func TestDeviceRepo_GetCount(t *testing.T) {
 					tenantid TEXT NOT NULL,
 					friendlyname TEXT NOT NULL DEFAULT '',
 					dnssuffix TEXT NOT NULL DEFAULT '',
+					lastconnected TEXT,
+					lastseen TEXT,
+					lastdisconnected TEXT,
 					deviceinfo TEXT NOT NULL DEFAULT '',
 					username TEXT NOT NULL DEFAULT '',
 					password TEXT NOT NULL DEFAULT '',
@@ -138,6 +142,9 @@ func setupDeviceTable(t *testing.T) *sql.DB {
 			tenantid TEXT NOT NULL,
 			friendlyname TEXT NOT NULL DEFAULT '',
 			dnssuffix TEXT NOT NULL DEFAULT '',
+			lastconnected TEXT,
+			lastseen TEXT,
+			lastdisconnected TEXT,
 			deviceinfo TEXT NOT NULL DEFAULT '',
 			username TEXT NOT NULL DEFAULT '',
 			password TEXT NOT NULL DEFAULT '',
@@ -596,6 +603,9 @@ func TestDeviceRepo_GetByTags(t *testing.T) {
                     tenantid TEXT NOT NULL,
                     friendlyname TEXT NOT NULL DEFAULT '',
                     dnssuffix TEXT NOT NULL DEFAULT '',
+                    lastconnected TEXT,
+                    lastseen TEXT,
+                    lastdisconnected TEXT,
                     deviceinfo TEXT NOT NULL DEFAULT ''
                 );
             `)
@@ -687,6 +697,9 @@ func TestDeviceRepo_Delete(t *testing.T) {
 					tenantid TEXT NOT NULL,
 					friendlyname TEXT NOT NULL DEFAULT '',
 					dnssuffix TEXT NOT NULL DEFAULT '',
+					lastconnected TEXT,
+					lastseen TEXT,
+					lastdisconnected TEXT,
 					deviceinfo TEXT NOT NULL DEFAULT '',
 					username TEXT NOT NULL DEFAULT '',
 					password TEXT NOT NULL DEFAULT '',
@@ -834,6 +847,9 @@ func TestDeviceRepo_Update(t *testing.T) {
 					tenantid TEXT NOT NULL,
 					friendlyname TEXT NOT NULL DEFAULT '',
 					dnssuffix TEXT NOT NULL DEFAULT '',
+					lastconnected TEXT,
+					lastseen TEXT,
+					lastdisconnected TEXT,
 					deviceinfo TEXT NOT NULL DEFAULT '',
 					username TEXT NOT NULL DEFAULT '',
 					password TEXT NOT NULL DEFAULT '',
@@ -1088,6 +1104,9 @@ func TestDeviceRepo_GetByColumn(t *testing.T) {
                     tenantid TEXT NOT NULL,
                     friendlyname TEXT NOT NULL DEFAULT '',
                     dnssuffix TEXT NOT NULL DEFAULT '',
+                    lastconnected TEXT,
+                    lastseen TEXT,
+                    lastdisconnected TEXT,
                     deviceinfo TEXT NOT NULL DEFAULT '',
                     username TEXT NOT NULL DEFAULT '',
                     password TEXT NOT NULL DEFAULT '',
@@ -1122,3 +1141,59 @@ func TestDeviceRepo_GetByColumn(t *testing.T) {
 		})
 	}
 }
+
+func TestDeviceRepo_Health(t *testing.T) {
+	t.Parallel()
+
+	dbConn := setupDeviceTable(t)
+	defer dbConn.Close()
+
+	_, err := dbConn.Exec(`INSERT INTO devices (guid, hostname, tenantid, friendlyname) VALUES ('guid1', 'hostname1', 'tenant1', 'friendly1'), ('guid2', 'hostname2', '', 'friendly2')`)
+	require.NoError(t, err)
+
+	repo := sqldb.NewDeviceRepo(&db.SQL{
+		Builder:    squirrel.StatementBuilder.PlaceholderFormat(squirrel.Question),
+		Pool:       dbConn,
+		IsEmbedded: true,
+	}, mocks.NewMockLogger(nil))
+
+	devices, err := repo.GetAllTenants(context.Background(), 0, 0)
+	require.NoError(t, err)
+	require.Len(t, devices, 2)
+	require.Equal(t, "guid2", devices[0].GUID)
+	require.Nil(t, devices[0].LastSeen)
+
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+
+	updated, err := repo.UpdateHealth(context.Background(), &entity.Device{
+		GUID:             "guid1",
+		TenantID:         "tenant1",
+		ConnectionStatus: true,
+		LastConnected:    &now,
+		LastSeen:         &now,
+		DeviceInfo:       `{"fwVersion":"16.1.25"}`,
+	})
+	require.NoError(t, err)
+	require.True(t, updated)
+
+	updated, err = repo.UpdateHealth(context.Background(), &entity.Device{GUID: "guid1", TenantID: "tenant2"})
+	require.NoError(t, err)
+	require.False(t, updated)
+
+	device, err := repo.GetByID(context.Background(), "guid1", "tenant1")
+	require.NoError(t, err)
+	require.True(t, device.ConnectionStatus)
+	require.Equal(t, now, *device.LastSeen)
+	require.Equal(t, now, *device.LastConnected)
+	require.Nil(t, device.LastDisconnected)
+	require.Equal(t, "friendly1", device.FriendlyName)
+	require.Equal(t, `{"fwVersion":"16.1.25"}`, device.DeviceInfo)
+
+	connected, err := repo.GetConnectedCount(context.Background(), "tenant1")
+	require.NoError(t, err)
+	require.Equal(t, 1, connected)
+
+	connected, err = repo.GetConnectedCount(context.Background(), "")
+	require.NoError(t, err)
+	require.Equal(t, 0, connected)
+}
@@ -0,0 +1,165 @@
+package sqldb
+
+import (
+	"context"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity"
+	"github.com/open-amt-cloud-toolkit/console/pkg/consoleerrors"
+	"github.com/open-amt-cloud-toolkit/console/pkg/db"
+	"github.com/open-amt-cloud-toolkit/console/pkg/logger"
+)
+
+// DeviceSnapshotRepo -.
+type DeviceSnapshotRepo struct {
+	*db.SQL
+	log logger.Interface
+}
+
+var ErrDeviceSnapshotDatabase = DatabaseError{Console: consoleerrors.CreateConsoleError("DeviceSnapshotRepo")}
+
+var deviceSnapshotColumns = []string{
+	"id",
+	"guid",
+	"taken_at",
+	"sections",
+	"errors",
+	"tenant_id",
+}
+
+// NewDeviceSnapshotRepo -.
+func NewDeviceSnapshotRepo(database *db.SQL, log logger.Interface) *DeviceSnapshotRepo {
+	return &DeviceSnapshotRepo{database, log}
+}
+
+// GetCount returns the number of snapshots of a device.
+func (r *DeviceSnapshotRepo) GetCount(_ context.Context, guid, tenantID string) (int, error) {
+	sqlQuery, args, err := r.Builder.
+		Select("COUNT(*)").
+		From("device_snapshots").
+		Where("guid = ? AND tenant_id = ?", guid, tenantID).
+		ToSql()
+	if err != nil {
+		return 0, ErrDeviceSnapshotDatabase.Wrap("GetCount", "r.Builder: ", err)
+	}
+
+	var count int
+
+	err = r.Pool.QueryRow(sqlQuery, args...).Scan(&count)
+	if err != nil {
+		return 0, ErrDeviceSnapshotDatabase.Wrap("GetCount", "r.Pool.QueryRow", err)
+	}
+
+	return count, nil
+}
+
+// Get returns the snapshots of a device, newest first.
+func (r *DeviceSnapshotRepo) Get(_ context.Context, guid string, top, skip int, tenantID string) ([]entity.DeviceSnapshot, error) {
+	limitedTop, limitedSkip := limitAndOffset(top, skip)
+
+	sqlQuery, args, err := r.Builder.
+		Select(deviceSnapshotColumns...).
+		From("device_snapshots").
+		Where("guid = ? AND tenant_id = ?", guid, tenantID).
+		OrderBy("taken_at DESC", "id").
+		Limit(limitedTop).
+		Offset(limitedSkip).
+		ToSql()
+	if err != nil {
+		return nil, ErrDeviceSnapshotDatabase.Wrap("Get", "r.Builder: ", err)
+	}
+
+	return r.querySnapshots("Get", sqlQuery, args...)
+}
+
+// GetByID -.
+func (r *DeviceSnapshotRepo) GetByID(_ context.Context, id, tenantID string) (*entity.DeviceSnapshot, error) {
+	sqlQuery, args, err := r.Builder.
+		Select(deviceSnapshotColumns...).
+		From("device_snapshots").
+		Where("id = ? AND tenant_id = ?", id, tenantID).
+		ToSql()
+	if err != nil {
+		return nil, ErrDeviceSnapshotDatabase.Wrap("GetByID", "r.Builder: ", err)
+	}
+
+	snapshots, err := r.querySnapshots("GetByID", sqlQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(snapshots) == 0 {
+		return nil, nil
+	}
+
+	return &snapshots[0], nil
+}
+
+// Delete removes a snapshot of a device.
+func (r *DeviceSnapshotRepo) Delete(_ context.Context, guid, id, tenantID string) (bool, error) {
+	sqlQuery, args, err := r.Builder.
+		Delete("device_snapshots").
+		Where("id = ? AND guid = ? AND tenant_id = ?", id, guid, tenantID).
+		ToSql()
+	if err != nil {
+		return false, ErrDeviceSnapshotDatabase.Wrap("Delete", "r.Builder", err)
+	}
+
+	res, err := r.Pool.Exec(sqlQuery, args...)
+	if err != nil {
+		return false, ErrDeviceSnapshotDatabase.Wrap("Delete", "r.Pool.Exec", err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return false, ErrDeviceSnapshotDatabase.Wrap("Delete", "res.RowsAffected", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// Insert -.
+func (r *DeviceSnapshotRepo) Insert(_ context.Context, s *entity.DeviceSnapshot) error {
+	sqlQuery, args, err := r.Builder.
+		Insert("device_snapshots").
+		Columns(deviceSnapshotColumns...).
+		Values(s.ID, s.GUID, s.TakenAt, s.Sections, s.Errors, s.TenantID).
+		ToSql()
+	if err != nil {
+		return ErrDeviceSnapshotDatabase.Wrap("Insert", "r.Builder", err)
+	}
+
+	_, err = r.Pool.Exec(sqlQuery, args...)
+	if err != nil {
+		return ErrDeviceSnapshotDatabase.Wrap("Insert", "r.Pool.Exec", err)
+	}
+
+	return nil
+}
+
+func (r *DeviceSnapshotRepo) querySnapshots(call, sqlQuery string, args ...interface{}) ([]entity.DeviceSnapshot, error) {
+	rows, err := r.Pool.Query(sqlQuery, args...)
+	if err != nil {
+		return nil, ErrDeviceSnapshotDatabase.Wrap(call, "r.Pool.Query", err)
+	}
+
+	defer rows.Close()
+
+	if rows.Err() != nil {
+		return nil, ErrDeviceSnapshotDatabase.Wrap(call, "rows.Err", rows.Err())
+	}
+
+	snapshots := make([]entity.DeviceSnapshot, 0)
+
+	for rows.Next() {
+		s := entity.DeviceSnapshot{}
+
+		err = rows.Scan(&s.ID, &s.GUID, &s.TakenAt, &s.Sections, &s.Errors, &s.TenantID)
+		if err != nil {
+			return nil, ErrDeviceSnapshotDatabase.Wrap(call, "rows.Scan: ", err)
+		}
+
+		snapshots = append(snapshots, s)
+	}
+
+	return snapshots, nil
+}
@@ -0,0 +1,83 @@
+package sqldb_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity"
+	"github.com/open-amt-cloud-toolkit/console/internal/mocks"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/sqldb"
+	"github.com/open-amt-cloud-toolkit/console/pkg/db"
+)
+
+const deviceSnapshotSchema = `
+CREATE TABLE device_snapshots(
+  id TEXT NOT NULL,
+  guid TEXT NOT NULL,
+  taken_at TEXT NOT NULL,
+  sections TEXT NOT NULL,
+  errors TEXT NOT NULL,
+  tenant_id TEXT NOT NULL,
+  PRIMARY KEY (id, tenant_id)
+);
+`
+
+func TestDeviceSnapshotRepo(t *testing.T) {
+	t.Parallel()
+
+	dbConn, err := sql.Open("sqlite", ":memory:")
+	require.NoError(t, err)
+
+	defer dbConn.Close()
+
+	_, err = dbConn.Exec(deviceSnapshotSchema)
+	require.NoError(t, err)
+
+	repo := sqldb.NewDeviceSnapshotRepo(&db.SQL{
+		Builder:    squirrel.StatementBuilder.PlaceholderFormat(squirrel.Question),
+		Pool:       dbConn,
+		IsEmbedded: true,
+	}, mocks.NewMockLogger(nil))
+
+	ctx := context.Background()
+
+	older := entity.DeviceSnapshot{ID: "s1", GUID: "guid1", TakenAt: "2024-12-01T00:00:00Z", Sections: `{"version":{}}`, TenantID: "tenant1"}
+	newer := entity.DeviceSnapshot{ID: "s2", GUID: "guid1", TakenAt: "2024-12-02T00:00:00Z", Sections: `{}`, Errors: `{"version":"timeout"}`, TenantID: "tenant1"}
+
+	require.NoError(t, repo.Insert(ctx, &older))
+	require.NoError(t, repo.Insert(ctx, &newer))
+	require.NoError(t, repo.Insert(ctx, &entity.DeviceSnapshot{ID: "s3", GUID: "guid2", TakenAt: "2024-12-01T00:00:00Z", Sections: `{}`, TenantID: "tenant1"}))
+
+	count, err := repo.GetCount(ctx, "guid1", "tenant1")
+	require.NoError(t, err)
+	require.Equal(t, 2, count)
+
+	snapshots, err := repo.Get(ctx, "guid1", 25, 0, "tenant1")
+	require.NoError(t, err)
+	require.Equal(t, []entity.DeviceSnapshot{newer, older}, snapshots)
+
+	snapshot, err := repo.GetByID(ctx, "s1", "tenant1")
+	require.NoError(t, err)
+	require.Equal(t, &older, snapshot)
+
+	snapshot, err = repo.GetByID(ctx, "s1", "tenant2")
+	require.NoError(t, err)
+	require.Nil(t, snapshot)
+
+	// a snapshot is only deleted under its own device
+	deleted, err := repo.Delete(ctx, "guid2", "s1", "tenant1")
+	require.NoError(t, err)
+	require.False(t, deleted)
+
+	deleted, err = repo.Delete(ctx, "guid1", "s1", "tenant1")
+	require.NoError(t, err)
+	require.True(t, deleted)
+
+	count, err = repo.GetCount(ctx, "guid1", "tenant1")
+	require.NoError(t, err)
+	require.Equal(t, 1, count)
+}
@@ -0,0 +1,79 @@
+package sqldb
+
+import (
+	"context"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity"
+	"github.com/open-amt-cloud-toolkit/console/pkg/consoleerrors"
+	"github.com/open-amt-cloud-toolkit/console/pkg/db"
+	"github.com/open-amt-cloud-toolkit/console/pkg/logger"
+)
+
+// DeviceThumbnailRepo -.
+type DeviceThumbnailRepo struct {
+	*db.SQL
+	log logger.Interface
+}
+
+var ErrDeviceThumbnailDatabase = DatabaseError{Console: consoleerrors.CreateConsoleError("DeviceThumbnailRepo")}
+
+// NewDeviceThumbnailRepo -.
+func NewDeviceThumbnailRepo(database *db.SQL, log logger.Interface) *DeviceThumbnailRepo {
+	return &DeviceThumbnailRepo{database, log}
+}
+
+// Get returns the thumbnail of a device, nil when none was captured yet.
+func (r *DeviceThumbnailRepo) Get(_ context.Context, guid, tenantID string) (*entity.DeviceThumbnail, error) {
+	sqlQuery, args, err := r.Builder.
+		Select("guid", "captured_at", "image", "tenant_id").
+		From("device_thumbnails").
+		Where("guid = ? and tenant_id = ?", guid, tenantID).
+		ToSql()
+	if err != nil {
+		return nil, ErrDeviceThumbnailDatabase.Wrap("Get", "r.Builder: ", err)
+	}
+
+	rows, err := r.Pool.Query(sqlQuery, args...)
+	if err != nil {
+		return nil, ErrDeviceThumbnailDatabase.Wrap("Get", "r.Pool.Query", err)
+	}
+
+	defer rows.Close()
+
+	if rows.Err() != nil {
+		return nil, ErrDeviceThumbnailDatabase.Wrap("Get", "rows.Err", rows.Err())
+	}
+
+	if !rows.Next() {
+		return nil, nil
+	}
+
+	t := &entity.DeviceThumbnail{}
+
+	err = rows.Scan(&t.GUID, &t.CapturedAt, &t.Image, &t.TenantID)
+	if err != nil {
+		return nil, ErrDeviceThumbnailDatabase.Wrap("Get", "rows.Scan: ", err)
+	}
+
+	return t, nil
+}
+
+// Upsert stores the thumbnail of a device, replacing the one it had.
+func (r *DeviceThumbnailRepo) Upsert(_ context.Context, t *entity.DeviceThumbnail) error {
+	sqlQuery, args, err := r.Builder.
+		Insert("device_thumbnails").
+		Columns("guid", "captured_at", "image", "tenant_id").
+		Values(t.GUID, t.CapturedAt, t.Image, t.TenantID).
+		Suffix("ON CONFLICT (guid, tenant_id) DO UPDATE SET captured_at = excluded.captured_at, image = excluded.image").
+		ToSql()
+	if err != nil {
+		return ErrDeviceThumbnailDatabase.Wrap("Upsert", "r.Builder", err)
+	}
+
+	_, err = r.Pool.Exec(sqlQuery, args...)
+	if err != nil {
+		return ErrDeviceThumbnailDatabase.Wrap("Upsert", "r.Pool.Exec", err)
+	}
+
+	return nil
+}
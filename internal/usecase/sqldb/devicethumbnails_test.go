@@ -0,0 +1,65 @@
+package sqldb_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity"
+	"github.com/open-amt-cloud-toolkit/console/internal/mocks"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/sqldb"
+	"github.com/open-amt-cloud-toolkit/console/pkg/db"
+)
+
+const deviceThumbnailSchema = `
+CREATE TABLE device_thumbnails(
+  guid TEXT NOT NULL,
+  captured_at TEXT NOT NULL,
+  image BYTEA NOT NULL,
+  tenant_id TEXT NOT NULL,
+  PRIMARY KEY (guid, tenant_id)
+);
+`
+
+func TestDeviceThumbnailRepo(t *testing.T) {
+	t.Parallel()
+
+	dbConn, err := sql.Open("sqlite", ":memory:")
+	require.NoError(t, err)
+
+	defer dbConn.Close()
+
+	_, err = dbConn.Exec(deviceThumbnailSchema)
+	require.NoError(t, err)
+
+	repo := sqldb.NewDeviceThumbnailRepo(&db.SQL{
+		Builder:    squirrel.StatementBuilder.PlaceholderFormat(squirrel.Question),
+		Pool:       dbConn,
+		IsEmbedded: true,
+	}, mocks.NewMockLogger(nil))
+
+	ctx := context.Background()
+
+	thumbnail, err := repo.Get(ctx, "guid1", "tenant1")
+	require.NoError(t, err)
+	require.Nil(t, thumbnail)
+
+	stored := entity.DeviceThumbnail{GUID: "guid1", CapturedAt: "2024-12-01T00:00:00Z", Image: []byte{0x89, 'P', 'N', 'G'}, TenantID: "tenant1"}
+	require.NoError(t, repo.Upsert(ctx, &stored))
+
+	stored.CapturedAt = "2024-12-01T00:15:00Z"
+	stored.Image = []byte{0x89, 'P', 'N', 'G', 0}
+	require.NoError(t, repo.Upsert(ctx, &stored))
+	require.NoError(t, repo.Upsert(ctx, &entity.DeviceThumbnail{GUID: "guid1", CapturedAt: "2024-12-01T00:00:00Z", Image: []byte{1}, TenantID: "tenant2"}))
+
+	thumbnail, err = repo.Get(ctx, "guid1", "tenant1")
+	require.NoError(t, err)
+	require.Equal(t, &stored, thumbnail)
+
+	thumbnail, err = repo.Get(ctx, "guid1", "tenant2")
+	require.NoError(t, err)
+	require.Equal(t, []byte{1}, thumbnail.Image)
+}
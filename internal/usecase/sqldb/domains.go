@@ -116,6 +116,54 @@ func (r *DomainRepo) Get(_ context.Context, top, skip int, tenantID string) ([]e
 	return domains, nil
 }
 
+// GetAllTenants pages through the domains of every tenant, it is meant for background jobs only.
+func (r *DomainRepo) GetAllTenants(_ context.Context, top, skip int) ([]entity.Domain, error) {
+	limit, offset := limitAndOffset(top, skip)
+
+	sqlQuery, _, err := r.Builder.
+		Select("name",
+			"domain_suffix",
+			"provisioning_cert",
+			"provisioning_cert_storage_format",
+			"provisioning_cert_key",
+			"expiration_date",
+			"tenant_id").
+		From("domains").
+		OrderBy("tenant_id", "name").
+		Limit(limit).
+		Offset(offset).
+		ToSql()
+	if err != nil {
+		return nil, ErrDomainDatabase.Wrap("GetAllTenants", "r.Builder: ", err)
+	}
+
+	rows, err := r.Pool.Query(sqlQuery)
+	if err != nil {
+		return nil, ErrDomainDatabase.Wrap("GetAllTenants", "r.Pool.Query", err)
+	}
+
+	defer rows.Close()
+
+	if rows.Err() != nil {
+		return nil, ErrDomainDatabase.Wrap("GetAllTenants", "rows.Err", rows.Err())
+	}
+
+	domains := make([]entity.Domain, 0)
+
+	for rows.Next() {
+		d := entity.Domain{}
+
+		err = rows.Scan(&d.ProfileName, &d.DomainSuffix, &d.ProvisioningCert, &d.ProvisioningCertStorageFormat, &d.ProvisioningCertPassword, &d.ExpirationDate, &d.TenantID)
+		if err != nil {
+			return nil, ErrDomainDatabase.Wrap("GetAllTenants", "rows.Scan: ", err)
+		}
+
+		domains = append(domains, d)
+	}
+
+	return domains, nil
+}
+
 // GetDomainByDomainSuffix -.
 func (r *DomainRepo) GetDomainByDomainSuffix(_ context.Context, domainSuffix, tenantID string) (*entity.Domain, error) {
 	sqlQuery, _, err := r.Builder.
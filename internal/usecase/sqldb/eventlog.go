@@ -0,0 +1,559 @@
+package sqldb
+
+import (
+	"context"
+
+	"github.com/Masterminds/squirrel"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity"
+	"github.com/open-amt-cloud-toolkit/console/pkg/consoleerrors"
+	"github.com/open-amt-cloud-toolkit/console/pkg/db"
+	"github.com/open-amt-cloud-toolkit/console/pkg/logger"
+)
+
+// EventLogRepo -.
+type EventLogRepo struct {
+	*db.SQL
+	log logger.Interface
+}
+
+var (
+	ErrEventLogDatabase   = DatabaseError{Console: consoleerrors.CreateConsoleError("EventLogRepo")}
+	ErrAlertRuleNotUnique = NotUniqueError{Console: consoleerrors.CreateConsoleError("EventLogRepo")}
+)
+
+var deviceEventColumns = []string{
+	"guid",
+	"fingerprint",
+	"time",
+	"severity",
+	"entity",
+	"entity_instance",
+	"sensor_type",
+	"sensor_number",
+	"event_type",
+	"event_offset",
+	"event_source_type",
+	"device_address",
+	"event_data",
+	"description",
+	"collected_at",
+	"tenant_id",
+}
+
+var alertRuleColumns = []string{
+	"name",
+	"severity",
+	"entity",
+	"guid",
+	"notifiers",
+	"enabled",
+	"creation_date",
+	"tenant_id",
+}
+
+var alertColumns = []string{
+	"id",
+	"rule_name",
+	"guid",
+	"fingerprint",
+	"severity",
+	"entity",
+	"description",
+	"event_time",
+	"raised_at",
+	"status",
+	"acknowledged_by",
+	"acknowledged_at",
+	"closed_by",
+	"closed_at",
+	"tenant_id",
+}
+
+// NewEventLogRepo -.
+func NewEventLogRepo(database *db.SQL, log logger.Interface) *EventLogRepo {
+	return &EventLogRepo{database, log}
+}
+
+// GetEventCount returns the number of events matching filter.
+func (r *EventLogRepo) GetEventCount(_ context.Context, filter entity.DeviceEventFilter) (int, error) {
+	sqlQuery, args, err := r.Builder.
+		Select("COUNT(*)").
+		From("device_events").
+		Where(deviceEventConditions(filter)).
+		ToSql()
+	if err != nil {
+		return 0, ErrEventLogDatabase.Wrap("GetEventCount", "r.Builder: ", err)
+	}
+
+	var count int
+
+	err = r.Pool.QueryRow(sqlQuery, args...).Scan(&count)
+	if err != nil {
+		return 0, ErrEventLogDatabase.Wrap("GetEventCount", "r.Pool.QueryRow", err)
+	}
+
+	return count, nil
+}
+
+// GetEvents returns the events matching filter, newest first.
+func (r *EventLogRepo) GetEvents(_ context.Context, filter entity.DeviceEventFilter, top, skip int) ([]entity.DeviceEvent, error) {
+	limitedTop, limitedSkip := limitAndOffset(top, skip)
+
+	sqlQuery, args, err := r.Builder.
+		Select(deviceEventColumns...).
+		From("device_events").
+		Where(deviceEventConditions(filter)).
+		OrderBy("time DESC", "guid", "fingerprint").
+		Limit(limitedTop).
+		Offset(limitedSkip).
+		ToSql()
+	if err != nil {
+		return nil, ErrEventLogDatabase.Wrap("GetEvents", "r.Builder: ", err)
+	}
+
+	return r.queryEvents("GetEvents", sqlQuery, args...)
+}
+
+// GetEventsAfter returns up to limit events of a device collected after a position and before collectedBefore,
+// in the order of their positions.
+func (r *EventLogRepo) GetEventsAfter(_ context.Context, guid, tenantID string, after entity.LogPosition, collectedBefore string, limit int) ([]entity.DeviceEvent, error) {
+	limitedTop, _ := limitAndOffset(limit, 0)
+
+	sqlQuery, args, err := r.Builder.
+		Select(deviceEventColumns...).
+		From("device_events").
+		Where("guid = ? AND tenant_id = ?", guid, tenantID).
+		Where(afterPosition(after)).
+		Where(squirrel.Lt{"collected_at": collectedBefore}).
+		OrderBy("collected_at", "time", "fingerprint").
+		Limit(limitedTop).
+		ToSql()
+	if err != nil {
+		return nil, ErrEventLogDatabase.Wrap("GetEventsAfter", "r.Builder: ", err)
+	}
+
+	return r.queryEvents("GetEventsAfter", sqlQuery, args...)
+}
+
+// InsertEvents stores the events not already stored, and returns the fingerprints of the ones that were new.
+func (r *EventLogRepo) InsertEvents(_ context.Context, events []entity.DeviceEvent) ([]string, error) {
+	if len(events) == 0 {
+		return nil, nil
+	}
+
+	builder := r.Builder.
+		Insert("device_events").
+		Columns(deviceEventColumns...)
+
+	for i := range events {
+		e := &events[i]
+		builder = builder.Values(e.GUID, e.Fingerprint, e.Time, e.Severity, e.Entity, e.EntityInstance, e.SensorType, e.SensorNumber,
+			e.EventType, e.EventOffset, e.EventSourceType, e.DeviceAddress, e.EventData, e.Description, e.CollectedAt, e.TenantID)
+	}
+
+	sqlQuery, args, err := builder.
+		Suffix("ON CONFLICT (guid, tenant_id, fingerprint) DO NOTHING RETURNING fingerprint").
+		ToSql()
+	if err != nil {
+		return nil, ErrEventLogDatabase.Wrap("InsertEvents", "r.Builder", err)
+	}
+
+	rows, err := r.Pool.Query(sqlQuery, args...)
+	if err != nil {
+		return nil, ErrEventLogDatabase.Wrap("InsertEvents", "r.Pool.Query", err)
+	}
+
+	defer rows.Close()
+
+	var inserted []string
+
+	for rows.Next() {
+		var fingerprint string
+
+		if err = rows.Scan(&fingerprint); err != nil {
+			return nil, ErrEventLogDatabase.Wrap("InsertEvents", "rows.Scan: ", err)
+		}
+
+		inserted = append(inserted, fingerprint)
+	}
+
+	if rows.Err() != nil {
+		return nil, ErrEventLogDatabase.Wrap("InsertEvents", "rows.Err", rows.Err())
+	}
+
+	return inserted, nil
+}
+
+// GetRuleCount -.
+func (r *EventLogRepo) GetRuleCount(_ context.Context, tenantID string) (int, error) {
+	sqlQuery, args, err := r.Builder.
+		Select("COUNT(*)").
+		From("alert_rules").
+		Where("tenant_id = ?", tenantID).
+		ToSql()
+	if err != nil {
+		return 0, ErrEventLogDatabase.Wrap("GetRuleCount", "r.Builder: ", err)
+	}
+
+	var count int
+
+	err = r.Pool.QueryRow(sqlQuery, args...).Scan(&count)
+	if err != nil {
+		return 0, ErrEventLogDatabase.Wrap("GetRuleCount", "r.Pool.QueryRow", err)
+	}
+
+	return count, nil
+}
+
+// GetRules -.
+func (r *EventLogRepo) GetRules(_ context.Context, top, skip int, tenantID string) ([]entity.AlertRule, error) {
+	limitedTop, limitedSkip := limitAndOffset(top, skip)
+
+	sqlQuery, args, err := r.Builder.
+		Select(alertRuleColumns...).
+		From("alert_rules").
+		Where("tenant_id = ?", tenantID).
+		OrderBy("name").
+		Limit(limitedTop).
+		Offset(limitedSkip).
+		ToSql()
+	if err != nil {
+		return nil, ErrEventLogDatabase.Wrap("GetRules", "r.Builder: ", err)
+	}
+
+	return r.queryRules("GetRules", sqlQuery, args...)
+}
+
+// GetEnabledRules returns every enabled rule of a tenant, the ones collected events are matched against.
+func (r *EventLogRepo) GetEnabledRules(_ context.Context, tenantID string) ([]entity.AlertRule, error) {
+	sqlQuery, args, err := r.Builder.
+		Select(alertRuleColumns...).
+		From("alert_rules").
+		Where("tenant_id = ? AND enabled = ?", tenantID, true).
+		OrderBy("name").
+		ToSql()
+	if err != nil {
+		return nil, ErrEventLogDatabase.Wrap("GetEnabledRules", "r.Builder: ", err)
+	}
+
+	return r.queryRules("GetEnabledRules", sqlQuery, args...)
+}
+
+// GetRuleByName -.
+func (r *EventLogRepo) GetRuleByName(_ context.Context, name, tenantID string) (*entity.AlertRule, error) {
+	sqlQuery, args, err := r.Builder.
+		Select(alertRuleColumns...).
+		From("alert_rules").
+		Where("name = ? AND tenant_id = ?", name, tenantID).
+		ToSql()
+	if err != nil {
+		return nil, ErrEventLogDatabase.Wrap("GetRuleByName", "r.Builder: ", err)
+	}
+
+	rules, err := r.queryRules("GetRuleByName", sqlQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rules) == 0 {
+		return nil, nil
+	}
+
+	return &rules[0], nil
+}
+
+// DeleteRule removes a rule, the alerts it raised are kept.
+func (r *EventLogRepo) DeleteRule(_ context.Context, name, tenantID string) (bool, error) {
+	sqlQuery, args, err := r.Builder.
+		Delete("alert_rules").
+		Where("name = ? AND tenant_id = ?", name, tenantID).
+		ToSql()
+	if err != nil {
+		return false, ErrEventLogDatabase.Wrap("DeleteRule", "r.Builder", err)
+	}
+
+	return r.execAffected("DeleteRule", sqlQuery, args...)
+}
+
+// UpdateRule -.
+func (r *EventLogRepo) UpdateRule(_ context.Context, rule *entity.AlertRule) (bool, error) {
+	sqlQuery, args, err := r.Builder.
+		Update("alert_rules").
+		Set("severity", rule.Severity).
+		Set("entity", rule.Entity).
+		Set("guid", rule.GUID).
+		Set("notifiers", rule.Notifiers).
+		Set("enabled", rule.Enabled).
+		Where("name = ? AND tenant_id = ?", rule.Name, rule.TenantID).
+		ToSql()
+	if err != nil {
+		return false, ErrEventLogDatabase.Wrap("UpdateRule", "r.Builder", err)
+	}
+
+	return r.execAffected("UpdateRule", sqlQuery, args...)
+}
+
+// InsertRule -.
+func (r *EventLogRepo) InsertRule(_ context.Context, rule *entity.AlertRule) (string, error) {
+	sqlQuery, args, err := r.Builder.
+		Insert("alert_rules").
+		Columns(alertRuleColumns...).
+		Values(rule.Name, rule.Severity, rule.Entity, rule.GUID, rule.Notifiers, rule.Enabled, rule.CreationDate, rule.TenantID).
+		ToSql()
+	if err != nil {
+		return "", ErrEventLogDatabase.Wrap("InsertRule", "r.Builder", err)
+	}
+
+	_, err = r.Pool.Exec(sqlQuery, args...)
+	if err != nil {
+		if db.CheckNotUnique(err) {
+			return "", ErrAlertRuleNotUnique
+		}
+
+		return "", ErrEventLogDatabase.Wrap("InsertRule", "r.Pool.Exec", err)
+	}
+
+	return rule.Name, nil
+}
+
+// InsertAlert stores an alert unless its rule already raised one for the same event, and reports whether it did.
+func (r *EventLogRepo) InsertAlert(_ context.Context, a *entity.Alert) (bool, error) {
+	sqlQuery, args, err := r.Builder.
+		Insert("alerts").
+		Columns(alertColumns...).
+		Values(a.ID, a.RuleName, a.GUID, a.Fingerprint, a.Severity, a.Entity, a.Description, a.EventTime, a.RaisedAt, a.Status,
+			a.AcknowledgedBy, a.AcknowledgedAt, a.ClosedBy, a.ClosedAt, a.TenantID).
+		Suffix("ON CONFLICT (rule_name, guid, fingerprint, tenant_id) DO NOTHING").
+		ToSql()
+	if err != nil {
+		return false, ErrEventLogDatabase.Wrap("InsertAlert", "r.Builder", err)
+	}
+
+	return r.execAffected("InsertAlert", sqlQuery, args...)
+}
+
+// GetAlertCount returns the number of alerts matching filter.
+func (r *EventLogRepo) GetAlertCount(_ context.Context, filter entity.AlertFilter) (int, error) {
+	sqlQuery, args, err := r.Builder.
+		Select("COUNT(*)").
+		From("alerts").
+		Where(alertConditions(filter)).
+		ToSql()
+	if err != nil {
+		return 0, ErrEventLogDatabase.Wrap("GetAlertCount", "r.Builder: ", err)
+	}
+
+	var count int
+
+	err = r.Pool.QueryRow(sqlQuery, args...).Scan(&count)
+	if err != nil {
+		return 0, ErrEventLogDatabase.Wrap("GetAlertCount", "r.Pool.QueryRow", err)
+	}
+
+	return count, nil
+}
+
+// GetAlerts returns the alerts matching filter, the last raised first.
+func (r *EventLogRepo) GetAlerts(_ context.Context, filter entity.AlertFilter, top, skip int) ([]entity.Alert, error) {
+	limitedTop, limitedSkip := limitAndOffset(top, skip)
+
+	sqlQuery, args, err := r.Builder.
+		Select(alertColumns...).
+		From("alerts").
+		Where(alertConditions(filter)).
+		OrderBy("raised_at DESC", "event_time DESC", "id").
+		Limit(limitedTop).
+		Offset(limitedSkip).
+		ToSql()
+	if err != nil {
+		return nil, ErrEventLogDatabase.Wrap("GetAlerts", "r.Builder: ", err)
+	}
+
+	return r.queryAlerts("GetAlerts", sqlQuery, args...)
+}
+
+// GetAlertByID -.
+func (r *EventLogRepo) GetAlertByID(_ context.Context, id, tenantID string) (*entity.Alert, error) {
+	sqlQuery, args, err := r.Builder.
+		Select(alertColumns...).
+		From("alerts").
+		Where("id = ? AND tenant_id = ?", id, tenantID).
+		ToSql()
+	if err != nil {
+		return nil, ErrEventLogDatabase.Wrap("GetAlertByID", "r.Builder: ", err)
+	}
+
+	alerts, err := r.queryAlerts("GetAlertByID", sqlQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(alerts) == 0 {
+		return nil, nil
+	}
+
+	return &alerts[0], nil
+}
+
+// UpdateAlertStatus moves an alert to a new status, provided it is still in status from.
+func (r *EventLogRepo) UpdateAlertStatus(_ context.Context, a *entity.Alert, from string) (bool, error) {
+	sqlQuery, args, err := r.Builder.
+		Update("alerts").
+		Set("status", a.Status).
+		Set("acknowledged_by", a.AcknowledgedBy).
+		Set("acknowledged_at", a.AcknowledgedAt).
+		Set("closed_by", a.ClosedBy).
+		Set("closed_at", a.ClosedAt).
+		Where("id = ? AND tenant_id = ? AND status = ?", a.ID, a.TenantID, from).
+		ToSql()
+	if err != nil {
+		return false, ErrEventLogDatabase.Wrap("UpdateAlertStatus", "r.Builder", err)
+	}
+
+	return r.execAffected("UpdateAlertStatus", sqlQuery, args...)
+}
+
+func (r *EventLogRepo) queryEvents(call, sqlQuery string, args ...interface{}) ([]entity.DeviceEvent, error) {
+	rows, err := r.Pool.Query(sqlQuery, args...)
+	if err != nil {
+		return nil, ErrEventLogDatabase.Wrap(call, "r.Pool.Query", err)
+	}
+
+	defer rows.Close()
+
+	if rows.Err() != nil {
+		return nil, ErrEventLogDatabase.Wrap(call, "rows.Err", rows.Err())
+	}
+
+	events := make([]entity.DeviceEvent, 0)
+
+	for rows.Next() {
+		e := entity.DeviceEvent{}
+
+		err = rows.Scan(&e.GUID, &e.Fingerprint, &e.Time, &e.Severity, &e.Entity, &e.EntityInstance, &e.SensorType, &e.SensorNumber,
+			&e.EventType, &e.EventOffset, &e.EventSourceType, &e.DeviceAddress, &e.EventData, &e.Description, &e.CollectedAt, &e.TenantID)
+		if err != nil {
+			return nil, ErrEventLogDatabase.Wrap(call, "rows.Scan: ", err)
+		}
+
+		events = append(events, e)
+	}
+
+	return events, nil
+}
+
+func (r *EventLogRepo) queryRules(call, sqlQuery string, args ...interface{}) ([]entity.AlertRule, error) {
+	rows, err := r.Pool.Query(sqlQuery, args...)
+	if err != nil {
+		return nil, ErrEventLogDatabase.Wrap(call, "r.Pool.Query", err)
+	}
+
+	defer rows.Close()
+
+	if rows.Err() != nil {
+		return nil, ErrEventLogDatabase.Wrap(call, "rows.Err", rows.Err())
+	}
+
+	rules := make([]entity.AlertRule, 0)
+
+	for rows.Next() {
+		rule := entity.AlertRule{}
+
+		err = rows.Scan(&rule.Name, &rule.Severity, &rule.Entity, &rule.GUID, &rule.Notifiers, &rule.Enabled, &rule.CreationDate, &rule.TenantID)
+		if err != nil {
+			return nil, ErrEventLogDatabase.Wrap(call, "rows.Scan: ", err)
+		}
+
+		rules = append(rules, rule)
+	}
+
+	return rules, nil
+}
+
+func (r *EventLogRepo) queryAlerts(call, sqlQuery string, args ...interface{}) ([]entity.Alert, error) {
+	rows, err := r.Pool.Query(sqlQuery, args...)
+	if err != nil {
+		return nil, ErrEventLogDatabase.Wrap(call, "r.Pool.Query", err)
+	}
+
+	defer rows.Close()
+
+	if rows.Err() != nil {
+		return nil, ErrEventLogDatabase.Wrap(call, "rows.Err", rows.Err())
+	}
+
+	alerts := make([]entity.Alert, 0)
+
+	for rows.Next() {
+		a := entity.Alert{}
+
+		err = rows.Scan(&a.ID, &a.RuleName, &a.GUID, &a.Fingerprint, &a.Severity, &a.Entity, &a.Description, &a.EventTime, &a.RaisedAt,
+			&a.Status, &a.AcknowledgedBy, &a.AcknowledgedAt, &a.ClosedBy, &a.ClosedAt, &a.TenantID)
+		if err != nil {
+			return nil, ErrEventLogDatabase.Wrap(call, "rows.Scan: ", err)
+		}
+
+		alerts = append(alerts, a)
+	}
+
+	return alerts, nil
+}
+
+func (r *EventLogRepo) execAffected(call, sqlQuery string, args ...interface{}) (bool, error) {
+	res, err := r.Pool.Exec(sqlQuery, args...)
+	if err != nil {
+		return false, ErrEventLogDatabase.Wrap(call, "r.Pool.Exec", err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return false, ErrEventLogDatabase.Wrap(call, "res.RowsAffected", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+func deviceEventConditions(filter entity.DeviceEventFilter) squirrel.And {
+	conditions := squirrel.And{squirrel.Eq{"tenant_id": filter.TenantID}}
+
+	if filter.GUID != "" {
+		conditions = append(conditions, squirrel.Eq{"guid": filter.GUID})
+	}
+
+	if filter.Severity != "" {
+		conditions = append(conditions, squirrel.Eq{"severity": filter.Severity})
+	}
+
+	if filter.Entity != "" {
+		conditions = append(conditions, squirrel.Eq{"entity": filter.Entity})
+	}
+
+	if filter.From != "" {
+		conditions = append(conditions, squirrel.GtOrEq{"time": filter.From})
+	}
+
+	if filter.To != "" {
+		conditions = append(conditions, squirrel.Lt{"time": filter.To})
+	}
+
+	return conditions
+}
+
+func alertConditions(filter entity.AlertFilter) squirrel.And {
+	conditions := squirrel.And{squirrel.Eq{"tenant_id": filter.TenantID}}
+
+	if filter.Status != "" {
+		conditions = append(conditions, squirrel.Eq{"status": filter.Status})
+	}
+
+	if filter.GUID != "" {
+		conditions = append(conditions, squirrel.Eq{"guid": filter.GUID})
+	}
+
+	if filter.RuleName != "" {
+		conditions = append(conditions, squirrel.Eq{"rule_name": filter.RuleName})
+	}
+
+	return conditions
+}
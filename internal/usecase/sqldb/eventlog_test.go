@@ -0,0 +1,211 @@
+package sqldb_test
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"testing"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity"
+	"github.com/open-amt-cloud-toolkit/console/internal/mocks"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/sqldb"
+	"github.com/open-amt-cloud-toolkit/console/pkg/db"
+)
+
+func newEventLogRepo(t *testing.T) *sqldb.EventLogRepo {
+	t.Helper()
+
+	dbConn, err := sql.Open("sqlite", ":memory:")
+	require.NoError(t, err)
+
+	t.Cleanup(func() { dbConn.Close() })
+
+	schema, err := os.ReadFile("../../app/migrations/20261020140000_event_log_alerts.up.sql")
+	require.NoError(t, err)
+
+	_, err = dbConn.Exec(string(schema))
+	require.NoError(t, err)
+
+	return sqldb.NewEventLogRepo(&db.SQL{
+		Builder:    squirrel.StatementBuilder.PlaceholderFormat(squirrel.Question),
+		Pool:       dbConn,
+		IsEmbedded: true,
+	}, mocks.NewMockLogger(nil))
+}
+
+func TestEventLogRepoEvents(t *testing.T) {
+	t.Parallel()
+
+	repo := newEventLogRepo(t)
+	ctx := context.Background()
+
+	events := []entity.DeviceEvent{
+		{GUID: "guid1", Fingerprint: "f1", Time: "2024-12-01T00:00:00Z", Severity: "monitor", Entity: "BIOS", EventData: "40070000", TenantID: "tenant1"},
+		{GUID: "guid1", Fingerprint: "f2", Time: "2024-12-02T00:00:00Z", Severity: "critical", Entity: "BIOS", EventData: "", TenantID: "tenant1"},
+		{GUID: "guid2", Fingerprint: "f1", Time: "2024-12-03T00:00:00Z", Severity: "critical", Entity: "Intel(r) ME", TenantID: "tenant1"},
+		{GUID: "guid1", Fingerprint: "f1", Time: "2024-12-01T00:00:00Z", Severity: "monitor", Entity: "BIOS", TenantID: "tenant2"},
+	}
+
+	inserted, err := repo.InsertEvents(ctx, events)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"f1", "f2", "f1", "f1"}, inserted)
+
+	// the events read again on the next poll are not stored twice, only the new one is returned
+	next := append([]entity.DeviceEvent{}, events[:2]...)
+	next = append(next, entity.DeviceEvent{GUID: "guid1", Fingerprint: "f3", Time: "2024-12-04T00:00:00Z", Severity: "ok", Entity: "BIOS", TenantID: "tenant1"})
+
+	inserted, err = repo.InsertEvents(ctx, next)
+	require.NoError(t, err)
+	require.Equal(t, []string{"f3"}, inserted)
+
+	inserted, err = repo.InsertEvents(ctx, nil)
+	require.NoError(t, err)
+	require.Empty(t, inserted)
+
+	count, err := repo.GetEventCount(ctx, entity.DeviceEventFilter{TenantID: "tenant1"})
+	require.NoError(t, err)
+	require.Equal(t, 4, count)
+
+	got, err := repo.GetEvents(ctx, entity.DeviceEventFilter{Severity: "critical", TenantID: "tenant1"}, 0, 0)
+	require.NoError(t, err)
+	require.Equal(t, []entity.DeviceEvent{events[2], events[1]}, got)
+
+	got, err = repo.GetEvents(ctx, entity.DeviceEventFilter{GUID: "guid1", Entity: "BIOS", From: "2024-12-01T00:00:00Z", To: "2024-12-02T00:00:00Z", TenantID: "tenant1"}, 0, 0)
+	require.NoError(t, err)
+	require.Equal(t, []entity.DeviceEvent{events[0]}, got)
+}
+
+func TestEventLogRepoRules(t *testing.T) {
+	t.Parallel()
+
+	repo := newEventLogRepo(t)
+	ctx := context.Background()
+
+	rule := &entity.AlertRule{
+		Name:         "bios-critical",
+		Severity:     "critical",
+		Entity:       "BIOS",
+		Notifiers:    "log,webhook",
+		Enabled:      true,
+		CreationDate: "2024-12-01T00:00:00Z",
+		TenantID:     "tenant1",
+	}
+
+	name, err := repo.InsertRule(ctx, rule)
+	require.NoError(t, err)
+	require.Equal(t, "bios-critical", name)
+
+	_, err = repo.InsertRule(ctx, rule)
+	require.ErrorIs(t, err, sqldb.ErrAlertRuleNotUnique)
+
+	disabled := *rule
+	disabled.Name = "any-monitor"
+	disabled.Severity = "monitor"
+	disabled.Enabled = false
+
+	_, err = repo.InsertRule(ctx, &disabled)
+	require.NoError(t, err)
+
+	count, err := repo.GetRuleCount(ctx, "tenant1")
+	require.NoError(t, err)
+	require.Equal(t, 2, count)
+
+	rules, err := repo.GetRules(ctx, 0, 0, "tenant1")
+	require.NoError(t, err)
+	require.Equal(t, []entity.AlertRule{disabled, *rule}, rules)
+
+	rules, err = repo.GetEnabledRules(ctx, "tenant1")
+	require.NoError(t, err)
+	require.Equal(t, []entity.AlertRule{*rule}, rules)
+
+	rules, err = repo.GetEnabledRules(ctx, "tenant2")
+	require.NoError(t, err)
+	require.Empty(t, rules)
+
+	rule.GUID = "guid1"
+	rule.Notifiers = "smtp"
+
+	updated, err := repo.UpdateRule(ctx, rule)
+	require.NoError(t, err)
+	require.True(t, updated)
+
+	got, err := repo.GetRuleByName(ctx, "bios-critical", "tenant1")
+	require.NoError(t, err)
+	require.Equal(t, rule, got)
+
+	got, err = repo.GetRuleByName(ctx, "bios-critical", "tenant2")
+	require.NoError(t, err)
+	require.Nil(t, got)
+
+	deleted, err := repo.DeleteRule(ctx, "bios-critical", "tenant1")
+	require.NoError(t, err)
+	require.True(t, deleted)
+
+	deleted, err = repo.DeleteRule(ctx, "bios-critical", "tenant1")
+	require.NoError(t, err)
+	require.False(t, deleted)
+}
+
+func TestEventLogRepoAlerts(t *testing.T) {
+	t.Parallel()
+
+	repo := newEventLogRepo(t)
+	ctx := context.Background()
+
+	alerts := []entity.Alert{
+		{ID: "a1", RuleName: "bios-critical", GUID: "guid1", Fingerprint: "f1", Severity: "critical", Entity: "BIOS", EventTime: "2024-12-01T00:00:00Z", RaisedAt: "2024-12-01T00:05:00Z", Status: "open", TenantID: "tenant1"},
+		{ID: "a2", RuleName: "bios-critical", GUID: "guid2", Fingerprint: "f1", Severity: "critical", Entity: "BIOS", EventTime: "2024-12-01T00:00:00Z", RaisedAt: "2024-12-01T00:06:00Z", Status: "open", TenantID: "tenant1"},
+		{ID: "a3", RuleName: "me", GUID: "guid1", Fingerprint: "f1", Severity: "critical", Entity: "BIOS", EventTime: "2024-12-01T00:00:00Z", RaisedAt: "2024-12-01T00:07:00Z", Status: "open", TenantID: "tenant1"},
+	}
+
+	for i := range alerts {
+		inserted, err := repo.InsertAlert(ctx, &alerts[i])
+		require.NoError(t, err)
+		require.True(t, inserted)
+	}
+
+	// a rule raises one alert per event
+	again := alerts[0]
+	again.ID = "a4"
+
+	inserted, err := repo.InsertAlert(ctx, &again)
+	require.NoError(t, err)
+	require.False(t, inserted)
+
+	count, err := repo.GetAlertCount(ctx, entity.AlertFilter{RuleName: "bios-critical", TenantID: "tenant1"})
+	require.NoError(t, err)
+	require.Equal(t, 2, count)
+
+	got, err := repo.GetAlerts(ctx, entity.AlertFilter{GUID: "guid1", TenantID: "tenant1"}, 0, 0)
+	require.NoError(t, err)
+	require.Equal(t, []entity.Alert{alerts[2], alerts[0]}, got)
+
+	acknowledged := alerts[0]
+	acknowledged.Status = "acknowledged"
+	acknowledged.AcknowledgedBy = "alice"
+	acknowledged.AcknowledgedAt = "2024-12-01T01:00:00Z"
+
+	updated, err := repo.UpdateAlertStatus(ctx, &acknowledged, "open")
+	require.NoError(t, err)
+	require.True(t, updated)
+
+	// an alert that moved on in the meantime is left alone
+	updated, err = repo.UpdateAlertStatus(ctx, &acknowledged, "open")
+	require.NoError(t, err)
+	require.False(t, updated)
+
+	alert, err := repo.GetAlertByID(ctx, "a1", "tenant1")
+	require.NoError(t, err)
+	require.Equal(t, &acknowledged, alert)
+
+	got, err = repo.GetAlerts(ctx, entity.AlertFilter{Status: "open", TenantID: "tenant1"}, 0, 0)
+	require.NoError(t, err)
+	require.Equal(t, []entity.Alert{alerts[2], alerts[1]}, got)
+
+	alert, err = repo.GetAlertByID(ctx, "a1", "tenant2")
+	require.NoError(t, err)
+	require.Nil(t, alert)
+}
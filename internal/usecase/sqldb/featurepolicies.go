@@ -0,0 +1,397 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/Masterminds/squirrel"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity"
+	"github.com/open-amt-cloud-toolkit/console/pkg/consoleerrors"
+	"github.com/open-amt-cloud-toolkit/console/pkg/db"
+	"github.com/open-amt-cloud-toolkit/console/pkg/logger"
+)
+
+// FeaturePolicyRepo -.
+type FeaturePolicyRepo struct {
+	*db.SQL
+	log logger.Interface
+}
+
+var (
+	ErrFeaturePolicyDatabase  = DatabaseError{Console: consoleerrors.CreateConsoleError("FeaturePolicyRepo")}
+	ErrFeaturePolicyNotUnique = NotUniqueError{Console: consoleerrors.CreateConsoleError("FeaturePolicyRepo")}
+)
+
+var featurePolicyColumns = []string{
+	"name",
+	"scope",
+	"target",
+	"desired",
+	"remediate",
+	"creation_date",
+	"tenant_id",
+}
+
+var featureDriftColumns = []string{
+	"guid",
+	"policy_name",
+	"checked_at",
+	"drifted",
+	"fields",
+	"actual",
+	"error",
+	"tenant_id",
+}
+
+var featureRemediationColumns = []string{
+	"id",
+	"guid",
+	"policy_name",
+	"remediated_at",
+	"before_features",
+	"after_features",
+	"error",
+	"tenant_id",
+}
+
+// NewFeaturePolicyRepo -.
+func NewFeaturePolicyRepo(database *db.SQL, log logger.Interface) *FeaturePolicyRepo {
+	return &FeaturePolicyRepo{database, log}
+}
+
+// GetCount -.
+func (r *FeaturePolicyRepo) GetCount(_ context.Context, tenantID string) (int, error) {
+	sqlQuery, _, err := r.Builder.
+		Select("COUNT(*) OVER() AS total_count").
+		From("feature_policies").
+		Where("tenant_id = ?", tenantID).
+		ToSql()
+	if err != nil {
+		return 0, ErrFeaturePolicyDatabase.Wrap("GetCount", "r.Builder: ", err)
+	}
+
+	var count int
+
+	err = r.Pool.QueryRow(sqlQuery, tenantID).Scan(&count)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, nil
+		}
+
+		return 0, ErrFeaturePolicyDatabase.Wrap("GetCount", "r.Pool.QueryRow", err)
+	}
+
+	return count, nil
+}
+
+// Get -.
+func (r *FeaturePolicyRepo) Get(_ context.Context, top, skip int, tenantID string) ([]entity.FeaturePolicy, error) {
+	limitedTop, limitedSkip := limitAndOffset(top, skip)
+
+	sqlQuery, args, err := r.Builder.
+		Select(featurePolicyColumns...).
+		From("feature_policies").
+		Where("tenant_id = ?", tenantID).
+		OrderBy("name").
+		Limit(limitedTop).
+		Offset(limitedSkip).
+		ToSql()
+	if err != nil {
+		return nil, ErrFeaturePolicyDatabase.Wrap("Get", "r.Builder: ", err)
+	}
+
+	return r.queryPolicies("Get", sqlQuery, args...)
+}
+
+// GetByName -.
+func (r *FeaturePolicyRepo) GetByName(_ context.Context, name, tenantID string) (*entity.FeaturePolicy, error) {
+	sqlQuery, args, err := r.Builder.
+		Select(featurePolicyColumns...).
+		From("feature_policies").
+		Where("name = ? AND tenant_id = ?", name, tenantID).
+		ToSql()
+	if err != nil {
+		return nil, ErrFeaturePolicyDatabase.Wrap("GetByName", "r.Builder: ", err)
+	}
+
+	policies, err := r.queryPolicies("GetByName", sqlQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(policies) == 0 {
+		return nil, nil
+	}
+
+	return &policies[0], nil
+}
+
+// GetAllTenants returns the policies of every tenant.
+func (r *FeaturePolicyRepo) GetAllTenants(_ context.Context) ([]entity.FeaturePolicy, error) {
+	sqlQuery, args, err := r.Builder.
+		Select(featurePolicyColumns...).
+		From("feature_policies").
+		OrderBy("tenant_id", "name").
+		ToSql()
+	if err != nil {
+		return nil, ErrFeaturePolicyDatabase.Wrap("GetAllTenants", "r.Builder: ", err)
+	}
+
+	return r.queryPolicies("GetAllTenants", sqlQuery, args...)
+}
+
+// Delete removes a policy along with the drift and remediations recorded for it.
+func (r *FeaturePolicyRepo) Delete(_ context.Context, name, tenantID string) (bool, error) {
+	for _, table := range []string{"feature_drift", "feature_remediations"} {
+		sqlQuery, args, err := r.Builder.
+			Delete(table).
+			Where("policy_name = ? AND tenant_id = ?", name, tenantID).
+			ToSql()
+		if err != nil {
+			return false, ErrFeaturePolicyDatabase.Wrap("Delete", "r.Builder", err)
+		}
+
+		if _, err = r.Pool.Exec(sqlQuery, args...); err != nil {
+			return false, ErrFeaturePolicyDatabase.Wrap("Delete", "r.Pool.Exec", err)
+		}
+	}
+
+	sqlQuery, args, err := r.Builder.
+		Delete("feature_policies").
+		Where("name = ? AND tenant_id = ?", name, tenantID).
+		ToSql()
+	if err != nil {
+		return false, ErrFeaturePolicyDatabase.Wrap("Delete", "r.Builder", err)
+	}
+
+	return r.execAffected("Delete", sqlQuery, args...)
+}
+
+// Update -.
+func (r *FeaturePolicyRepo) Update(_ context.Context, p *entity.FeaturePolicy) (bool, error) {
+	sqlQuery, args, err := r.Builder.
+		Update("feature_policies").
+		Set("scope", p.Scope).
+		Set("target", p.Target).
+		Set("desired", p.Desired).
+		Set("remediate", p.Remediate).
+		Where("name = ? AND tenant_id = ?", p.Name, p.TenantID).
+		ToSql()
+	if err != nil {
+		return false, ErrFeaturePolicyDatabase.Wrap("Update", "r.Builder", err)
+	}
+
+	return r.execAffected("Update", sqlQuery, args...)
+}
+
+// Insert -.
+func (r *FeaturePolicyRepo) Insert(_ context.Context, p *entity.FeaturePolicy) (string, error) {
+	sqlQuery, args, err := r.Builder.
+		Insert("feature_policies").
+		Columns(featurePolicyColumns...).
+		Values(p.Name, p.Scope, p.Target, p.Desired, p.Remediate, p.CreationDate, p.TenantID).
+		ToSql()
+	if err != nil {
+		return "", ErrFeaturePolicyDatabase.Wrap("Insert", "r.Builder", err)
+	}
+
+	_, err = r.Pool.Exec(sqlQuery, args...)
+	if err != nil {
+		if db.CheckNotUnique(err) {
+			return "", ErrFeaturePolicyNotUnique
+		}
+
+		return "", ErrFeaturePolicyDatabase.Wrap("Insert", "r.Pool.Exec", err)
+	}
+
+	return p.Name, nil
+}
+
+// UpsertDrift stores the last check of a device, replacing the previous one.
+func (r *FeaturePolicyRepo) UpsertDrift(_ context.Context, d *entity.FeatureDrift) error {
+	sqlQuery, args, err := r.Builder.
+		Insert("feature_drift").
+		Columns(featureDriftColumns...).
+		Values(d.GUID, d.PolicyName, d.CheckedAt, d.Drifted, d.Fields, d.Actual, d.Error, d.TenantID).
+		Suffix("ON CONFLICT (guid, tenant_id) DO UPDATE SET policy_name = excluded.policy_name, checked_at = excluded.checked_at, " +
+			"drifted = excluded.drifted, fields = excluded.fields, actual = excluded.actual, error = excluded.error").
+		ToSql()
+	if err != nil {
+		return ErrFeaturePolicyDatabase.Wrap("UpsertDrift", "r.Builder", err)
+	}
+
+	_, err = r.Pool.Exec(sqlQuery, args...)
+	if err != nil {
+		return ErrFeaturePolicyDatabase.Wrap("UpsertDrift", "r.Pool.Exec", err)
+	}
+
+	return nil
+}
+
+// GetDrift returns the last checks of the devices a policy governs, drifted first.
+func (r *FeaturePolicyRepo) GetDrift(_ context.Context, policyName string, top, skip int, tenantID string) ([]entity.FeatureDrift, error) {
+	limitedTop, limitedSkip := limitAndOffset(top, skip)
+
+	sqlQuery, args, err := r.Builder.
+		Select(featureDriftColumns...).
+		From("feature_drift").
+		Where("policy_name = ? AND tenant_id = ?", policyName, tenantID).
+		OrderBy("drifted DESC", "guid").
+		Limit(limitedTop).
+		Offset(limitedSkip).
+		ToSql()
+	if err != nil {
+		return nil, ErrFeaturePolicyDatabase.Wrap("GetDrift", "r.Builder: ", err)
+	}
+
+	rows, err := r.Pool.Query(sqlQuery, args...)
+	if err != nil {
+		return nil, ErrFeaturePolicyDatabase.Wrap("GetDrift", "r.Pool.Query", err)
+	}
+
+	defer rows.Close()
+
+	if rows.Err() != nil {
+		return nil, ErrFeaturePolicyDatabase.Wrap("GetDrift", "rows.Err", rows.Err())
+	}
+
+	drift := make([]entity.FeatureDrift, 0)
+
+	for rows.Next() {
+		d := entity.FeatureDrift{}
+
+		err = rows.Scan(&d.GUID, &d.PolicyName, &d.CheckedAt, &d.Drifted, &d.Fields, &d.Actual, &d.Error, &d.TenantID)
+		if err != nil {
+			return nil, ErrFeaturePolicyDatabase.Wrap("GetDrift", "rows.Scan: ", err)
+		}
+
+		drift = append(drift, d)
+	}
+
+	return drift, nil
+}
+
+// DeleteStaleDrift removes the checks of every tenant made before checkedAt, the devices no policy governs
+// anymore.
+func (r *FeaturePolicyRepo) DeleteStaleDrift(_ context.Context, checkedAt string) error {
+	sqlQuery, args, err := r.Builder.
+		Delete("feature_drift").
+		Where(squirrel.Lt{"checked_at": checkedAt}).
+		ToSql()
+	if err != nil {
+		return ErrFeaturePolicyDatabase.Wrap("DeleteStaleDrift", "r.Builder", err)
+	}
+
+	_, err = r.Pool.Exec(sqlQuery, args...)
+	if err != nil {
+		return ErrFeaturePolicyDatabase.Wrap("DeleteStaleDrift", "r.Pool.Exec", err)
+	}
+
+	return nil
+}
+
+// InsertRemediation -.
+func (r *FeaturePolicyRepo) InsertRemediation(_ context.Context, rem *entity.FeatureRemediation) error {
+	sqlQuery, args, err := r.Builder.
+		Insert("feature_remediations").
+		Columns(featureRemediationColumns...).
+		Values(rem.ID, rem.GUID, rem.PolicyName, rem.RemediatedAt, rem.Before, rem.After, rem.Error, rem.TenantID).
+		ToSql()
+	if err != nil {
+		return ErrFeaturePolicyDatabase.Wrap("InsertRemediation", "r.Builder", err)
+	}
+
+	_, err = r.Pool.Exec(sqlQuery, args...)
+	if err != nil {
+		return ErrFeaturePolicyDatabase.Wrap("InsertRemediation", "r.Pool.Exec", err)
+	}
+
+	return nil
+}
+
+// GetRemediations returns the remediations of a policy, newest first.
+func (r *FeaturePolicyRepo) GetRemediations(_ context.Context, policyName string, top, skip int, tenantID string) ([]entity.FeatureRemediation, error) {
+	limitedTop, limitedSkip := limitAndOffset(top, skip)
+
+	sqlQuery, args, err := r.Builder.
+		Select(featureRemediationColumns...).
+		From("feature_remediations").
+		Where("policy_name = ? AND tenant_id = ?", policyName, tenantID).
+		OrderBy("remediated_at DESC", "guid").
+		Limit(limitedTop).
+		Offset(limitedSkip).
+		ToSql()
+	if err != nil {
+		return nil, ErrFeaturePolicyDatabase.Wrap("GetRemediations", "r.Builder: ", err)
+	}
+
+	rows, err := r.Pool.Query(sqlQuery, args...)
+	if err != nil {
+		return nil, ErrFeaturePolicyDatabase.Wrap("GetRemediations", "r.Pool.Query", err)
+	}
+
+	defer rows.Close()
+
+	if rows.Err() != nil {
+		return nil, ErrFeaturePolicyDatabase.Wrap("GetRemediations", "rows.Err", rows.Err())
+	}
+
+	remediations := make([]entity.FeatureRemediation, 0)
+
+	for rows.Next() {
+		rem := entity.FeatureRemediation{}
+
+		err = rows.Scan(&rem.ID, &rem.GUID, &rem.PolicyName, &rem.RemediatedAt, &rem.Before, &rem.After, &rem.Error, &rem.TenantID)
+		if err != nil {
+			return nil, ErrFeaturePolicyDatabase.Wrap("GetRemediations", "rows.Scan: ", err)
+		}
+
+		remediations = append(remediations, rem)
+	}
+
+	return remediations, nil
+}
+
+func (r *FeaturePolicyRepo) queryPolicies(call, sqlQuery string, args ...interface{}) ([]entity.FeaturePolicy, error) {
+	rows, err := r.Pool.Query(sqlQuery, args...)
+	if err != nil {
+		return nil, ErrFeaturePolicyDatabase.Wrap(call, "r.Pool.Query", err)
+	}
+
+	defer rows.Close()
+
+	if rows.Err() != nil {
+		return nil, ErrFeaturePolicyDatabase.Wrap(call, "rows.Err", rows.Err())
+	}
+
+	policies := make([]entity.FeaturePolicy, 0)
+
+	for rows.Next() {
+		p := entity.FeaturePolicy{}
+
+		err = rows.Scan(&p.Name, &p.Scope, &p.Target, &p.Desired, &p.Remediate, &p.CreationDate, &p.TenantID)
+		if err != nil {
+			return nil, ErrFeaturePolicyDatabase.Wrap(call, "rows.Scan: ", err)
+		}
+
+		policies = append(policies, p)
+	}
+
+	return policies, nil
+}
+
+func (r *FeaturePolicyRepo) execAffected(call, sqlQuery string, args ...interface{}) (bool, error) {
+	res, err := r.Pool.Exec(sqlQuery, args...)
+	if err != nil {
+		return false, ErrFeaturePolicyDatabase.Wrap(call, "r.Pool.Exec", err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return false, ErrFeaturePolicyDatabase.Wrap(call, "res.RowsAffected", err)
+	}
+
+	return rowsAffected > 0, nil
+}
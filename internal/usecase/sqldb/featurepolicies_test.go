@@ -0,0 +1,156 @@
+package sqldb_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity"
+	"github.com/open-amt-cloud-toolkit/console/internal/mocks"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/sqldb"
+	"github.com/open-amt-cloud-toolkit/console/pkg/db"
+)
+
+const featurePolicySchema = `
+CREATE TABLE IF NOT EXISTS feature_policies(
+  name TEXT NOT NULL,
+  scope TEXT NOT NULL,
+  target TEXT NOT NULL,
+  desired TEXT NOT NULL,
+  remediate BOOLEAN NOT NULL,
+  creation_date TEXT,
+  tenant_id TEXT NOT NULL,
+  PRIMARY KEY (name, tenant_id)
+);
+CREATE TABLE IF NOT EXISTS feature_drift(
+  guid TEXT NOT NULL,
+  policy_name TEXT NOT NULL,
+  checked_at TEXT NOT NULL,
+  drifted BOOLEAN NOT NULL,
+  fields TEXT,
+  actual TEXT,
+  error TEXT,
+  tenant_id TEXT NOT NULL,
+  PRIMARY KEY (guid, tenant_id)
+);
+CREATE INDEX IF NOT EXISTS feature_drift_policy_idx ON feature_drift (policy_name, tenant_id);
+CREATE TABLE IF NOT EXISTS feature_remediations(
+  id TEXT NOT NULL,
+  guid TEXT NOT NULL,
+  policy_name TEXT NOT NULL,
+  remediated_at TEXT NOT NULL,
+  before_features TEXT,
+  after_features TEXT,
+  error TEXT,
+  tenant_id TEXT NOT NULL,
+  PRIMARY KEY (id, tenant_id)
+);
+CREATE INDEX IF NOT EXISTS feature_remediations_policy_idx ON feature_remediations (policy_name, tenant_id);
+`
+
+func TestFeaturePolicyRepo(t *testing.T) {
+	t.Parallel()
+
+	dbConn, err := sql.Open("sqlite", ":memory:")
+	require.NoError(t, err)
+
+	defer dbConn.Close()
+
+	_, err = dbConn.Exec(featurePolicySchema)
+	require.NoError(t, err)
+
+	repo := sqldb.NewFeaturePolicyRepo(&db.SQL{
+		Builder:    squirrel.StatementBuilder.PlaceholderFormat(squirrel.Question),
+		Pool:       dbConn,
+		IsEmbedded: true,
+	}, mocks.NewMockLogger(nil))
+
+	ctx := context.Background()
+
+	lab := entity.FeaturePolicy{Name: "lab", Scope: "tag", Target: "lab", Desired: `{"enableKVM":true}`, Remediate: true, CreationDate: "2024-12-01T00:00:00Z", TenantID: "tenant1"}
+	office := entity.FeaturePolicy{Name: "office", Scope: "profile", Target: "office", Desired: `{}`, CreationDate: "2024-12-01T00:00:00Z", TenantID: "tenant2"}
+
+	name, err := repo.Insert(ctx, &lab)
+	require.NoError(t, err)
+	require.Equal(t, "lab", name)
+
+	_, err = repo.Insert(ctx, &office)
+	require.NoError(t, err)
+
+	_, err = repo.Insert(ctx, &lab)
+	require.IsType(t, sqldb.NotUniqueError{}, err)
+
+	count, err := repo.GetCount(ctx, "tenant1")
+	require.NoError(t, err)
+	require.Equal(t, 1, count)
+
+	policies, err := repo.Get(ctx, 25, 0, "tenant1")
+	require.NoError(t, err)
+	require.Equal(t, []entity.FeaturePolicy{lab}, policies)
+
+	policies, err = repo.GetAllTenants(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []entity.FeaturePolicy{lab, office}, policies)
+
+	lab.Remediate = false
+	lab.Desired = `{"enableKVM":false}`
+
+	updated, err := repo.Update(ctx, &lab)
+	require.NoError(t, err)
+	require.True(t, updated)
+
+	policy, err := repo.GetByName(ctx, "lab", "tenant1")
+	require.NoError(t, err)
+	require.Equal(t, &lab, policy)
+
+	policy, err = repo.GetByName(ctx, "lab", "tenant2")
+	require.NoError(t, err)
+	require.Nil(t, policy)
+
+	// drift is kept per device, the last check replacing the previous one
+	require.NoError(t, repo.UpsertDrift(ctx, &entity.FeatureDrift{GUID: "guid1", PolicyName: "lab", CheckedAt: "2024-12-01T00:00:00Z", Drifted: true, Fields: "enableKVM", TenantID: "tenant1"}))
+	require.NoError(t, repo.UpsertDrift(ctx, &entity.FeatureDrift{GUID: "guid2", PolicyName: "lab", CheckedAt: "2024-12-01T00:00:00Z", TenantID: "tenant1"}))
+
+	checked := entity.FeatureDrift{GUID: "guid2", PolicyName: "lab", CheckedAt: "2024-12-01T00:15:00Z", Drifted: true, Fields: "enableKVM", Actual: `{"enableKVM":true}`, TenantID: "tenant1"}
+	require.NoError(t, repo.UpsertDrift(ctx, &checked))
+
+	drift, err := repo.GetDrift(ctx, "lab", 25, 0, "tenant1")
+	require.NoError(t, err)
+	require.Len(t, drift, 2)
+	require.Equal(t, checked, drift[1])
+
+	require.NoError(t, repo.DeleteStaleDrift(ctx, "2024-12-01T00:15:00Z"))
+
+	drift, err = repo.GetDrift(ctx, "lab", 25, 0, "tenant1")
+	require.NoError(t, err)
+	require.Equal(t, []entity.FeatureDrift{checked}, drift)
+
+	older := entity.FeatureRemediation{ID: "r1", GUID: "guid2", PolicyName: "lab", RemediatedAt: "2024-12-01T00:00:00Z", Before: `{}`, After: `{"enableKVM":true}`, TenantID: "tenant1"}
+	newer := entity.FeatureRemediation{ID: "r2", GUID: "guid2", PolicyName: "lab", RemediatedAt: "2024-12-01T00:15:00Z", Before: `{}`, Error: "unreachable", TenantID: "tenant1"}
+
+	require.NoError(t, repo.InsertRemediation(ctx, &older))
+	require.NoError(t, repo.InsertRemediation(ctx, &newer))
+
+	remediations, err := repo.GetRemediations(ctx, "lab", 25, 0, "tenant1")
+	require.NoError(t, err)
+	require.Equal(t, []entity.FeatureRemediation{newer, older}, remediations)
+
+	deleted, err := repo.Delete(ctx, "lab", "tenant1")
+	require.NoError(t, err)
+	require.True(t, deleted)
+
+	deleted, err = repo.Delete(ctx, "lab", "tenant1")
+	require.NoError(t, err)
+	require.False(t, deleted)
+
+	drift, err = repo.GetDrift(ctx, "lab", 25, 0, "tenant1")
+	require.NoError(t, err)
+	require.Empty(t, drift)
+
+	remediations, err = repo.GetRemediations(ctx, "lab", 25, 0, "tenant1")
+	require.NoError(t, err)
+	require.Empty(t, remediations)
+}
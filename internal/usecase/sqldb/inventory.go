@@ -0,0 +1,208 @@
+package sqldb
+
+import (
+	"context"
+
+	"github.com/Masterminds/squirrel"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity"
+	"github.com/open-amt-cloud-toolkit/console/pkg/consoleerrors"
+	"github.com/open-amt-cloud-toolkit/console/pkg/db"
+	"github.com/open-amt-cloud-toolkit/console/pkg/logger"
+)
+
+// InventoryRepo -.
+type InventoryRepo struct {
+	*db.SQL
+	log logger.Interface
+}
+
+var ErrInventoryDatabase = DatabaseError{Console: consoleerrors.CreateConsoleError("InventoryRepo")}
+
+var inventoryColumns = []string{
+	"guid",
+	"refreshed_at",
+	"amt_version",
+	"amt_major",
+	"sku",
+	"bios_manufacturer",
+	"bios_version",
+	"total_memory_bytes",
+	"inventory",
+	"tenant_id",
+}
+
+// NewInventoryRepo -.
+func NewInventoryRepo(database *db.SQL, log logger.Interface) *InventoryRepo {
+	return &InventoryRepo{database, log}
+}
+
+// GetCount returns the number of devices matching filter.
+func (r *InventoryRepo) GetCount(_ context.Context, filter entity.InventoryFilter) (int, error) {
+	sqlQuery, args, err := r.Builder.
+		Select("COUNT(*)").
+		From("device_inventory").
+		Where(inventoryConditions(filter)).
+		ToSql()
+	if err != nil {
+		return 0, ErrInventoryDatabase.Wrap("GetCount", "r.Builder: ", err)
+	}
+
+	var count int
+
+	err = r.Pool.QueryRow(sqlQuery, args...).Scan(&count)
+	if err != nil {
+		return 0, ErrInventoryDatabase.Wrap("GetCount", "r.Pool.QueryRow", err)
+	}
+
+	return count, nil
+}
+
+// Get returns the inventory of the devices matching filter.
+func (r *InventoryRepo) Get(_ context.Context, filter entity.InventoryFilter, top, skip int) ([]entity.DeviceInventory, error) {
+	limitedTop, limitedSkip := limitAndOffset(top, skip)
+
+	sqlQuery, args, err := r.Builder.
+		Select(inventoryColumns...).
+		From("device_inventory").
+		Where(inventoryConditions(filter)).
+		OrderBy("guid").
+		Limit(limitedTop).
+		Offset(limitedSkip).
+		ToSql()
+	if err != nil {
+		return nil, ErrInventoryDatabase.Wrap("Get", "r.Builder: ", err)
+	}
+
+	return r.queryInventory("Get", sqlQuery, args...)
+}
+
+// GetByGUID -.
+func (r *InventoryRepo) GetByGUID(_ context.Context, guid, tenantID string) (*entity.DeviceInventory, error) {
+	sqlQuery, args, err := r.Builder.
+		Select(inventoryColumns...).
+		From("device_inventory").
+		Where("guid = ? AND tenant_id = ?", guid, tenantID).
+		ToSql()
+	if err != nil {
+		return nil, ErrInventoryDatabase.Wrap("GetByGUID", "r.Builder: ", err)
+	}
+
+	inventory, err := r.queryInventory("GetByGUID", sqlQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(inventory) == 0 {
+		return nil, nil
+	}
+
+	return &inventory[0], nil
+}
+
+// GetBIOSVersions counts the devices of a tenant per BIOS version, the most common first.
+func (r *InventoryRepo) GetBIOSVersions(_ context.Context, tenantID string) ([]entity.BIOSVersionCount, error) {
+	sqlQuery, args, err := r.Builder.
+		Select("bios_manufacturer", "bios_version", "COUNT(*) AS devices").
+		From("device_inventory").
+		Where("tenant_id = ?", tenantID).
+		GroupBy("bios_manufacturer", "bios_version").
+		OrderBy("devices DESC", "bios_manufacturer", "bios_version").
+		ToSql()
+	if err != nil {
+		return nil, ErrInventoryDatabase.Wrap("GetBIOSVersions", "r.Builder: ", err)
+	}
+
+	rows, err := r.Pool.Query(sqlQuery, args...)
+	if err != nil {
+		return nil, ErrInventoryDatabase.Wrap("GetBIOSVersions", "r.Pool.Query", err)
+	}
+
+	defer rows.Close()
+
+	if rows.Err() != nil {
+		return nil, ErrInventoryDatabase.Wrap("GetBIOSVersions", "rows.Err", rows.Err())
+	}
+
+	versions := make([]entity.BIOSVersionCount, 0)
+
+	for rows.Next() {
+		v := entity.BIOSVersionCount{}
+
+		if err = rows.Scan(&v.Manufacturer, &v.Version, &v.Count); err != nil {
+			return nil, ErrInventoryDatabase.Wrap("GetBIOSVersions", "rows.Scan: ", err)
+		}
+
+		versions = append(versions, v)
+	}
+
+	return versions, nil
+}
+
+// Upsert stores the inventory of a device, replacing the previous one.
+func (r *InventoryRepo) Upsert(_ context.Context, i *entity.DeviceInventory) error {
+	sqlQuery, args, err := r.Builder.
+		Insert("device_inventory").
+		Columns(inventoryColumns...).
+		Values(i.GUID, i.RefreshedAt, i.AMTVersion, i.AMTMajor, i.SKU, i.BIOSManufacturer, i.BIOSVersion, i.TotalMemoryBytes, i.Inventory, i.TenantID).
+		Suffix("ON CONFLICT (guid, tenant_id) DO UPDATE SET refreshed_at = excluded.refreshed_at, amt_version = excluded.amt_version, " +
+			"amt_major = excluded.amt_major, sku = excluded.sku, bios_manufacturer = excluded.bios_manufacturer, " +
+			"bios_version = excluded.bios_version, total_memory_bytes = excluded.total_memory_bytes, inventory = excluded.inventory").
+		ToSql()
+	if err != nil {
+		return ErrInventoryDatabase.Wrap("Upsert", "r.Builder", err)
+	}
+
+	_, err = r.Pool.Exec(sqlQuery, args...)
+	if err != nil {
+		return ErrInventoryDatabase.Wrap("Upsert", "r.Pool.Exec", err)
+	}
+
+	return nil
+}
+
+func (r *InventoryRepo) queryInventory(call, sqlQuery string, args ...interface{}) ([]entity.DeviceInventory, error) {
+	rows, err := r.Pool.Query(sqlQuery, args...)
+	if err != nil {
+		return nil, ErrInventoryDatabase.Wrap(call, "r.Pool.Query", err)
+	}
+
+	defer rows.Close()
+
+	if rows.Err() != nil {
+		return nil, ErrInventoryDatabase.Wrap(call, "rows.Err", rows.Err())
+	}
+
+	inventory := make([]entity.DeviceInventory, 0)
+
+	for rows.Next() {
+		i := entity.DeviceInventory{}
+
+		err = rows.Scan(&i.GUID, &i.RefreshedAt, &i.AMTVersion, &i.AMTMajor, &i.SKU, &i.BIOSManufacturer, &i.BIOSVersion, &i.TotalMemoryBytes, &i.Inventory, &i.TenantID)
+		if err != nil {
+			return nil, ErrInventoryDatabase.Wrap(call, "rows.Scan: ", err)
+		}
+
+		inventory = append(inventory, i)
+	}
+
+	return inventory, nil
+}
+
+func inventoryConditions(filter entity.InventoryFilter) squirrel.And {
+	conditions := squirrel.And{squirrel.Eq{"tenant_id": filter.TenantID}}
+
+	if filter.AMTBelow > 0 {
+		conditions = append(conditions, squirrel.Lt{"amt_major": filter.AMTBelow})
+	}
+
+	if filter.MemoryBelowBytes > 0 {
+		conditions = append(conditions, squirrel.Lt{"total_memory_bytes": filter.MemoryBelowBytes})
+	}
+
+	if filter.BIOSVersion != "" {
+		conditions = append(conditions, squirrel.Eq{"bios_version": filter.BIOSVersion})
+	}
+
+	return conditions
+}
@@ -0,0 +1,99 @@
+package sqldb_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity"
+	"github.com/open-amt-cloud-toolkit/console/internal/mocks"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/sqldb"
+	"github.com/open-amt-cloud-toolkit/console/pkg/db"
+)
+
+const inventorySchema = `
+CREATE TABLE device_inventory(
+  guid TEXT NOT NULL,
+  refreshed_at TEXT NOT NULL,
+  amt_version TEXT NOT NULL,
+  amt_major INTEGER NOT NULL,
+  sku TEXT NOT NULL,
+  bios_manufacturer TEXT NOT NULL,
+  bios_version TEXT NOT NULL,
+  total_memory_bytes BIGINT NOT NULL,
+  inventory TEXT NOT NULL,
+  tenant_id TEXT NOT NULL,
+  PRIMARY KEY (guid, tenant_id)
+);
+`
+
+func TestInventoryRepo(t *testing.T) {
+	t.Parallel()
+
+	dbConn, err := sql.Open("sqlite", ":memory:")
+	require.NoError(t, err)
+
+	defer dbConn.Close()
+
+	_, err = dbConn.Exec(inventorySchema)
+	require.NoError(t, err)
+
+	repo := sqldb.NewInventoryRepo(&db.SQL{
+		Builder:    squirrel.StatementBuilder.PlaceholderFormat(squirrel.Question),
+		Pool:       dbConn,
+		IsEmbedded: true,
+	}, mocks.NewMockLogger(nil))
+
+	ctx := context.Background()
+
+	devices := []entity.DeviceInventory{
+		{GUID: "guid1", RefreshedAt: "2024-12-01T00:00:00Z", AMTVersion: "15.0.45", AMTMajor: 15, BIOSManufacturer: "Intel", BIOSVersion: "A", TotalMemoryBytes: 8 << 30, Inventory: `{}`, TenantID: "tenant1"},
+		{GUID: "guid2", RefreshedAt: "2024-12-01T00:00:00Z", AMTVersion: "16.1.25", AMTMajor: 16, BIOSManufacturer: "Intel", BIOSVersion: "A", TotalMemoryBytes: 32 << 30, Inventory: `{}`, TenantID: "tenant1"},
+		{GUID: "guid3", RefreshedAt: "2024-12-01T00:00:00Z", AMTVersion: "16.1.25", AMTMajor: 16, BIOSManufacturer: "Intel", BIOSVersion: "B", TotalMemoryBytes: 16 << 30, Inventory: `{}`, TenantID: "tenant1"},
+		{GUID: "guid1", RefreshedAt: "2024-12-01T00:00:00Z", AMTVersion: "12.0.90", AMTMajor: 12, BIOSManufacturer: "Intel", BIOSVersion: "C", TotalMemoryBytes: 4 << 30, Inventory: `{}`, TenantID: "tenant2"},
+	}
+	for i := range devices {
+		require.NoError(t, repo.Upsert(ctx, &devices[i]))
+	}
+
+	// a refresh replaces the previous inventory of the device
+	refreshed := devices[0]
+	refreshed.RefreshedAt = "2024-12-02T00:00:00Z"
+	refreshed.BIOSVersion = "B"
+	require.NoError(t, repo.Upsert(ctx, &refreshed))
+
+	got, err := repo.GetByGUID(ctx, "guid1", "tenant1")
+	require.NoError(t, err)
+	require.Equal(t, &refreshed, got)
+
+	got, err = repo.GetByGUID(ctx, "guid4", "tenant1")
+	require.NoError(t, err)
+	require.Nil(t, got)
+
+	count, err := repo.GetCount(ctx, entity.InventoryFilter{TenantID: "tenant1"})
+	require.NoError(t, err)
+	require.Equal(t, 3, count)
+
+	below, err := repo.Get(ctx, entity.InventoryFilter{AMTBelow: 16, TenantID: "tenant1"}, 0, 0)
+	require.NoError(t, err)
+	require.Equal(t, []entity.DeviceInventory{refreshed}, below)
+
+	below, err = repo.Get(ctx, entity.InventoryFilter{MemoryBelowBytes: 16 << 30, TenantID: "tenant1"}, 0, 0)
+	require.NoError(t, err)
+	require.Len(t, below, 1)
+	require.Equal(t, "guid1", below[0].GUID)
+
+	count, err = repo.GetCount(ctx, entity.InventoryFilter{BIOSVersion: "B", TenantID: "tenant1"})
+	require.NoError(t, err)
+	require.Equal(t, 2, count)
+
+	versions, err := repo.GetBIOSVersions(ctx, "tenant1")
+	require.NoError(t, err)
+	require.Equal(t, []entity.BIOSVersionCount{
+		{Manufacturer: "Intel", Version: "B", Count: 2},
+		{Manufacturer: "Intel", Version: "A", Count: 1},
+	}, versions)
+}
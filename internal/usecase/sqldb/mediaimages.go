@@ -0,0 +1,175 @@
+package sqldb
+
+import (
+	"context"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity"
+	"github.com/open-amt-cloud-toolkit/console/pkg/consoleerrors"
+	"github.com/open-amt-cloud-toolkit/console/pkg/db"
+	"github.com/open-amt-cloud-toolkit/console/pkg/logger"
+)
+
+// MediaImageRepo -.
+type MediaImageRepo struct {
+	*db.SQL
+	log logger.Interface
+}
+
+var (
+	ErrMediaImageDatabase  = DatabaseError{Console: consoleerrors.CreateConsoleError("MediaImageRepo")}
+	ErrMediaImageNotUnique = NotUniqueError{Console: consoleerrors.CreateConsoleError("MediaImageRepo")}
+)
+
+var mediaImageColumns = []string{
+	"id",
+	"name",
+	"kind",
+	"file_name",
+	"size_bytes",
+	"sha256",
+	"uploaded_by",
+	"created_at",
+	"tenant_id",
+}
+
+// NewMediaImageRepo -.
+func NewMediaImageRepo(database *db.SQL, log logger.Interface) *MediaImageRepo {
+	return &MediaImageRepo{database, log}
+}
+
+// GetCount -.
+func (r *MediaImageRepo) GetCount(_ context.Context, tenantID string) (int, error) {
+	sqlQuery, args, err := r.Builder.
+		Select("COUNT(*)").
+		From("media_images").
+		Where("tenant_id = ?", tenantID).
+		ToSql()
+	if err != nil {
+		return 0, ErrMediaImageDatabase.Wrap("GetCount", "r.Builder: ", err)
+	}
+
+	var count int
+
+	err = r.Pool.QueryRow(sqlQuery, args...).Scan(&count)
+	if err != nil {
+		return 0, ErrMediaImageDatabase.Wrap("GetCount", "r.Pool.QueryRow", err)
+	}
+
+	return count, nil
+}
+
+// Get returns the images of a tenant by name.
+func (r *MediaImageRepo) Get(_ context.Context, top, skip int, tenantID string) ([]entity.MediaImage, error) {
+	limitedTop, limitedSkip := limitAndOffset(top, skip)
+
+	sqlQuery, args, err := r.Builder.
+		Select(mediaImageColumns...).
+		From("media_images").
+		Where("tenant_id = ?", tenantID).
+		OrderBy("name", "id").
+		Limit(limitedTop).
+		Offset(limitedSkip).
+		ToSql()
+	if err != nil {
+		return nil, ErrMediaImageDatabase.Wrap("Get", "r.Builder: ", err)
+	}
+
+	return r.queryImages("Get", sqlQuery, args...)
+}
+
+// GetByID -.
+func (r *MediaImageRepo) GetByID(_ context.Context, id, tenantID string) (*entity.MediaImage, error) {
+	sqlQuery, args, err := r.Builder.
+		Select(mediaImageColumns...).
+		From("media_images").
+		Where("id = ? AND tenant_id = ?", id, tenantID).
+		ToSql()
+	if err != nil {
+		return nil, ErrMediaImageDatabase.Wrap("GetByID", "r.Builder: ", err)
+	}
+
+	images, err := r.queryImages("GetByID", sqlQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(images) == 0 {
+		return nil, nil
+	}
+
+	return &images[0], nil
+}
+
+// Delete -.
+func (r *MediaImageRepo) Delete(_ context.Context, id, tenantID string) (bool, error) {
+	sqlQuery, args, err := r.Builder.
+		Delete("media_images").
+		Where("id = ? AND tenant_id = ?", id, tenantID).
+		ToSql()
+	if err != nil {
+		return false, ErrMediaImageDatabase.Wrap("Delete", "r.Builder", err)
+	}
+
+	res, err := r.Pool.Exec(sqlQuery, args...)
+	if err != nil {
+		return false, ErrMediaImageDatabase.Wrap("Delete", "r.Pool.Exec", err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return false, ErrMediaImageDatabase.Wrap("Delete", "res.RowsAffected", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// Insert -.
+func (r *MediaImageRepo) Insert(_ context.Context, m *entity.MediaImage) error {
+	sqlQuery, args, err := r.Builder.
+		Insert("media_images").
+		Columns(mediaImageColumns...).
+		Values(m.ID, m.Name, m.Kind, m.FileName, m.SizeBytes, m.SHA256, m.UploadedBy, m.CreatedAt, m.TenantID).
+		ToSql()
+	if err != nil {
+		return ErrMediaImageDatabase.Wrap("Insert", "r.Builder", err)
+	}
+
+	_, err = r.Pool.Exec(sqlQuery, args...)
+	if err != nil {
+		if db.CheckNotUnique(err) {
+			return ErrMediaImageNotUnique.Wrap(err.Error())
+		}
+
+		return ErrMediaImageDatabase.Wrap("Insert", "r.Pool.Exec", err)
+	}
+
+	return nil
+}
+
+func (r *MediaImageRepo) queryImages(call, sqlQuery string, args ...interface{}) ([]entity.MediaImage, error) {
+	rows, err := r.Pool.Query(sqlQuery, args...)
+	if err != nil {
+		return nil, ErrMediaImageDatabase.Wrap(call, "r.Pool.Query", err)
+	}
+
+	defer rows.Close()
+
+	if rows.Err() != nil {
+		return nil, ErrMediaImageDatabase.Wrap(call, "rows.Err", rows.Err())
+	}
+
+	images := make([]entity.MediaImage, 0)
+
+	for rows.Next() {
+		m := entity.MediaImage{}
+
+		err = rows.Scan(&m.ID, &m.Name, &m.Kind, &m.FileName, &m.SizeBytes, &m.SHA256, &m.UploadedBy, &m.CreatedAt, &m.TenantID)
+		if err != nil {
+			return nil, ErrMediaImageDatabase.Wrap(call, "rows.Scan: ", err)
+		}
+
+		images = append(images, m)
+	}
+
+	return images, nil
+}
@@ -0,0 +1,91 @@
+package sqldb_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity"
+	"github.com/open-amt-cloud-toolkit/console/internal/mocks"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/sqldb"
+	"github.com/open-amt-cloud-toolkit/console/pkg/db"
+)
+
+const mediaImageSchema = `
+CREATE TABLE media_images(
+  id TEXT NOT NULL,
+  name TEXT NOT NULL,
+  kind TEXT NOT NULL,
+  file_name TEXT NOT NULL,
+  size_bytes INTEGER NOT NULL DEFAULT 0,
+  sha256 TEXT NOT NULL,
+  uploaded_by TEXT,
+  created_at TEXT NOT NULL,
+  tenant_id TEXT NOT NULL,
+  PRIMARY KEY (id, tenant_id),
+  UNIQUE (name, tenant_id)
+);
+`
+
+func TestMediaImageRepo(t *testing.T) {
+	t.Parallel()
+
+	dbConn, err := sql.Open("sqlite", ":memory:")
+	require.NoError(t, err)
+
+	defer dbConn.Close()
+
+	_, err = dbConn.Exec(mediaImageSchema)
+	require.NoError(t, err)
+
+	repo := sqldb.NewMediaImageRepo(&db.SQL{
+		Builder:    squirrel.StatementBuilder.PlaceholderFormat(squirrel.Question),
+		Pool:       dbConn,
+		IsEmbedded: true,
+	}, mocks.NewMockLogger(nil))
+
+	ctx := context.Background()
+
+	images := []entity.MediaImage{
+		{ID: "1", Name: "ubuntu.iso", Kind: "cd", FileName: "1.iso", SizeBytes: 4096, SHA256: "aa", UploadedBy: "alice", CreatedAt: "2024-01-01T10:00:00Z", TenantID: "tenant1"},
+		{ID: "2", Name: "dos.img", Kind: "floppy", FileName: "2.img", SizeBytes: 1024, SHA256: "bb", CreatedAt: "2024-01-01T11:00:00Z", TenantID: "tenant1"},
+		{ID: "3", Name: "ubuntu.iso", Kind: "cd", FileName: "3.iso", SizeBytes: 4096, SHA256: "aa", CreatedAt: "2024-01-01T12:00:00Z", TenantID: "tenant2"},
+	}
+
+	for i := range images {
+		require.NoError(t, repo.Insert(ctx, &images[i]))
+	}
+
+	duplicate := images[0]
+	duplicate.ID = "4"
+
+	err = repo.Insert(ctx, &duplicate)
+	require.ErrorAs(t, err, &sqldb.NotUniqueError{})
+
+	count, err := repo.GetCount(ctx, "tenant1")
+	require.NoError(t, err)
+	require.Equal(t, 2, count)
+
+	got, err := repo.Get(ctx, 10, 0, "tenant1")
+	require.NoError(t, err)
+	require.Equal(t, []entity.MediaImage{images[1], images[0]}, got)
+
+	image, err := repo.GetByID(ctx, "1", "tenant1")
+	require.NoError(t, err)
+	require.Equal(t, &images[0], image)
+
+	image, err = repo.GetByID(ctx, "1", "tenant2")
+	require.NoError(t, err)
+	require.Nil(t, image)
+
+	deleted, err := repo.Delete(ctx, "3", "tenant1")
+	require.NoError(t, err)
+	require.False(t, deleted)
+
+	deleted, err = repo.Delete(ctx, "3", "tenant2")
+	require.NoError(t, err)
+	require.True(t, deleted)
+}
@@ -0,0 +1,190 @@
+package sqldb
+
+import (
+	"context"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity"
+	"github.com/open-amt-cloud-toolkit/console/pkg/consoleerrors"
+	"github.com/open-amt-cloud-toolkit/console/pkg/db"
+	"github.com/open-amt-cloud-toolkit/console/pkg/logger"
+)
+
+// RecordingRepo -.
+type RecordingRepo struct {
+	*db.SQL
+	log logger.Interface
+}
+
+var ErrRecordingDatabase = DatabaseError{Console: consoleerrors.CreateConsoleError("RecordingRepo")}
+
+var recordingColumns = []string{
+	"id",
+	"guid",
+	"hostname",
+	"mode",
+	"subject",
+	"file_name",
+	"size_bytes",
+	"start_time",
+	"end_time",
+	"truncated",
+	"tenant_id",
+}
+
+// NewRecordingRepo -.
+func NewRecordingRepo(database *db.SQL, log logger.Interface) *RecordingRepo {
+	return &RecordingRepo{database, log}
+}
+
+// GetCount -.
+func (r *RecordingRepo) GetCount(_ context.Context, tenantID string) (int, error) {
+	sqlQuery, args, err := r.Builder.
+		Select("COUNT(*)").
+		From("session_recordings").
+		Where("tenant_id = ?", tenantID).
+		ToSql()
+	if err != nil {
+		return 0, ErrRecordingDatabase.Wrap("GetCount", "r.Builder: ", err)
+	}
+
+	var count int
+
+	err = r.Pool.QueryRow(sqlQuery, args...).Scan(&count)
+	if err != nil {
+		return 0, ErrRecordingDatabase.Wrap("GetCount", "r.Pool.QueryRow", err)
+	}
+
+	return count, nil
+}
+
+// Get returns the recordings of a tenant, newest first.
+func (r *RecordingRepo) Get(_ context.Context, top, skip int, tenantID string) ([]entity.SessionRecording, error) {
+	limitedTop, limitedSkip := limitAndOffset(top, skip)
+
+	sqlQuery, args, err := r.Builder.
+		Select(recordingColumns...).
+		From("session_recordings").
+		Where("tenant_id = ?", tenantID).
+		OrderBy("start_time DESC", "id").
+		Limit(limitedTop).
+		Offset(limitedSkip).
+		ToSql()
+	if err != nil {
+		return nil, ErrRecordingDatabase.Wrap("Get", "r.Builder: ", err)
+	}
+
+	return r.queryRecordings("Get", sqlQuery, args...)
+}
+
+// GetByID -.
+func (r *RecordingRepo) GetByID(_ context.Context, id, tenantID string) (*entity.SessionRecording, error) {
+	sqlQuery, args, err := r.Builder.
+		Select(recordingColumns...).
+		From("session_recordings").
+		Where("id = ? AND tenant_id = ?", id, tenantID).
+		ToSql()
+	if err != nil {
+		return nil, ErrRecordingDatabase.Wrap("GetByID", "r.Builder: ", err)
+	}
+
+	recordings, err := r.queryRecordings("GetByID", sqlQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(recordings) == 0 {
+		return nil, nil
+	}
+
+	return &recordings[0], nil
+}
+
+// Delete -.
+func (r *RecordingRepo) Delete(_ context.Context, id, tenantID string) (bool, error) {
+	sqlQuery, args, err := r.Builder.
+		Delete("session_recordings").
+		Where("id = ? AND tenant_id = ?", id, tenantID).
+		ToSql()
+	if err != nil {
+		return false, ErrRecordingDatabase.Wrap("Delete", "r.Builder", err)
+	}
+
+	return r.execAffected("Delete", sqlQuery, args...)
+}
+
+// Update records how a session ended, the rest of a recording never changes.
+func (r *RecordingRepo) Update(_ context.Context, s *entity.SessionRecording) (bool, error) {
+	sqlQuery, args, err := r.Builder.
+		Update("session_recordings").
+		Set("size_bytes", s.SizeBytes).
+		Set("end_time", s.EndTime).
+		Set("truncated", s.Truncated).
+		Where("id = ? AND tenant_id = ?", s.ID, s.TenantID).
+		ToSql()
+	if err != nil {
+		return false, ErrRecordingDatabase.Wrap("Update", "r.Builder", err)
+	}
+
+	return r.execAffected("Update", sqlQuery, args...)
+}
+
+// Insert -.
+func (r *RecordingRepo) Insert(_ context.Context, s *entity.SessionRecording) error {
+	sqlQuery, args, err := r.Builder.
+		Insert("session_recordings").
+		Columns(recordingColumns...).
+		Values(s.ID, s.GUID, s.Hostname, s.Mode, s.Subject, s.FileName, s.SizeBytes, s.StartTime, s.EndTime, s.Truncated, s.TenantID).
+		ToSql()
+	if err != nil {
+		return ErrRecordingDatabase.Wrap("Insert", "r.Builder", err)
+	}
+
+	_, err = r.Pool.Exec(sqlQuery, args...)
+	if err != nil {
+		return ErrRecordingDatabase.Wrap("Insert", "r.Pool.Exec", err)
+	}
+
+	return nil
+}
+
+func (r *RecordingRepo) queryRecordings(call, sqlQuery string, args ...interface{}) ([]entity.SessionRecording, error) {
+	rows, err := r.Pool.Query(sqlQuery, args...)
+	if err != nil {
+		return nil, ErrRecordingDatabase.Wrap(call, "r.Pool.Query", err)
+	}
+
+	defer rows.Close()
+
+	if rows.Err() != nil {
+		return nil, ErrRecordingDatabase.Wrap(call, "rows.Err", rows.Err())
+	}
+
+	recordings := make([]entity.SessionRecording, 0)
+
+	for rows.Next() {
+		s := entity.SessionRecording{}
+
+		err = rows.Scan(&s.ID, &s.GUID, &s.Hostname, &s.Mode, &s.Subject, &s.FileName, &s.SizeBytes, &s.StartTime, &s.EndTime, &s.Truncated, &s.TenantID)
+		if err != nil {
+			return nil, ErrRecordingDatabase.Wrap(call, "rows.Scan: ", err)
+		}
+
+		recordings = append(recordings, s)
+	}
+
+	return recordings, nil
+}
+
+func (r *RecordingRepo) execAffected(call, sqlQuery string, args ...interface{}) (bool, error) {
+	res, err := r.Pool.Exec(sqlQuery, args...)
+	if err != nil {
+		return false, ErrRecordingDatabase.Wrap(call, "r.Pool.Exec", err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return false, ErrRecordingDatabase.Wrap(call, "res.RowsAffected", err)
+	}
+
+	return rowsAffected > 0, nil
+}
@@ -0,0 +1,94 @@
+package sqldb_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity"
+	"github.com/open-amt-cloud-toolkit/console/internal/mocks"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/sqldb"
+	"github.com/open-amt-cloud-toolkit/console/pkg/db"
+)
+
+const recordingSchema = `
+CREATE TABLE session_recordings(
+  id TEXT NOT NULL,
+  guid TEXT NOT NULL,
+  hostname TEXT,
+  mode TEXT NOT NULL,
+  subject TEXT,
+  file_name TEXT NOT NULL,
+  size_bytes INTEGER NOT NULL DEFAULT 0,
+  start_time TEXT NOT NULL,
+  end_time TEXT,
+  truncated BOOLEAN NOT NULL DEFAULT FALSE,
+  tenant_id TEXT NOT NULL,
+  PRIMARY KEY (id, tenant_id)
+);
+`
+
+func TestRecordingRepo(t *testing.T) {
+	t.Parallel()
+
+	dbConn, err := sql.Open("sqlite", ":memory:")
+	require.NoError(t, err)
+
+	defer dbConn.Close()
+
+	_, err = dbConn.Exec(recordingSchema)
+	require.NoError(t, err)
+
+	repo := sqldb.NewRecordingRepo(&db.SQL{
+		Builder:    squirrel.StatementBuilder.PlaceholderFormat(squirrel.Question),
+		Pool:       dbConn,
+		IsEmbedded: true,
+	}, mocks.NewMockLogger(nil))
+
+	ctx := context.Background()
+
+	recordings := []entity.SessionRecording{
+		{ID: "1", GUID: "guid-1", Hostname: "host1", Mode: "sol", Subject: "alice", FileName: "1.rec", StartTime: "2024-01-01T10:00:00Z", TenantID: "tenant1"},
+		{ID: "2", GUID: "guid-2", Hostname: "host2", Mode: "kvm", Subject: "bob", FileName: "2.rec", StartTime: "2024-01-01T11:00:00Z", TenantID: "tenant1"},
+		{ID: "3", GUID: "guid-1", Mode: "sol", FileName: "3.rec", StartTime: "2024-01-01T12:00:00Z", TenantID: "tenant2"},
+	}
+
+	for i := range recordings {
+		require.NoError(t, repo.Insert(ctx, &recordings[i]))
+	}
+
+	count, err := repo.GetCount(ctx, "tenant1")
+	require.NoError(t, err)
+	require.Equal(t, 2, count)
+
+	got, err := repo.Get(ctx, 10, 0, "tenant1")
+	require.NoError(t, err)
+	require.Equal(t, []entity.SessionRecording{recordings[1], recordings[0]}, got)
+
+	recordings[0].SizeBytes = 2048
+	recordings[0].EndTime = "2024-01-01T10:05:00Z"
+	recordings[0].Truncated = true
+
+	updated, err := repo.Update(ctx, &recordings[0])
+	require.NoError(t, err)
+	require.True(t, updated)
+
+	recording, err := repo.GetByID(ctx, "1", "tenant1")
+	require.NoError(t, err)
+	require.Equal(t, &recordings[0], recording)
+
+	recording, err = repo.GetByID(ctx, "1", "tenant2")
+	require.NoError(t, err)
+	require.Nil(t, recording)
+
+	deleted, err := repo.Delete(ctx, "3", "tenant1")
+	require.NoError(t, err)
+	require.False(t, deleted)
+
+	deleted, err = repo.Delete(ctx, "3", "tenant2")
+	require.NoError(t, err)
+	require.True(t, deleted)
+}
@@ -0,0 +1,103 @@
+package sqldb
+
+import (
+	"context"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity"
+	"github.com/open-amt-cloud-toolkit/console/pkg/consoleerrors"
+	"github.com/open-amt-cloud-toolkit/console/pkg/db"
+	"github.com/open-amt-cloud-toolkit/console/pkg/logger"
+)
+
+// RedirectionPolicyRepo -.
+type RedirectionPolicyRepo struct {
+	*db.SQL
+	log logger.Interface
+}
+
+var ErrRedirectionPolicyDatabase = DatabaseError{Console: consoleerrors.CreateConsoleError("RedirectionPolicyRepo")}
+
+// NewRedirectionPolicyRepo -.
+func NewRedirectionPolicyRepo(database *db.SQL, log logger.Interface) *RedirectionPolicyRepo {
+	return &RedirectionPolicyRepo{database, log}
+}
+
+// Get returns the policy of a tenant, nil when it has none.
+func (r *RedirectionPolicyRepo) Get(_ context.Context, tenantID string) (*entity.RedirectionPolicy, error) {
+	sqlQuery, args, err := r.Builder.
+		Select("idle_timeout", "max_duration", "max_sessions_per_user", "max_sessions_per_device", "tenant_id").
+		From("redirection_policies").
+		Where("tenant_id = ?", tenantID).
+		ToSql()
+	if err != nil {
+		return nil, ErrRedirectionPolicyDatabase.Wrap("Get", "r.Builder: ", err)
+	}
+
+	rows, err := r.Pool.Query(sqlQuery, args...)
+	if err != nil {
+		return nil, ErrRedirectionPolicyDatabase.Wrap("Get", "r.Pool.Query", err)
+	}
+
+	defer rows.Close()
+
+	if rows.Err() != nil {
+		return nil, ErrRedirectionPolicyDatabase.Wrap("Get", "rows.Err", rows.Err())
+	}
+
+	if !rows.Next() {
+		return nil, nil
+	}
+
+	p := &entity.RedirectionPolicy{}
+
+	err = rows.Scan(&p.IdleTimeout, &p.MaxDuration, &p.MaxSessionsPerUser, &p.MaxSessionsPerDevice, &p.TenantID)
+	if err != nil {
+		return nil, ErrRedirectionPolicyDatabase.Wrap("Get", "rows.Scan: ", err)
+	}
+
+	return p, nil
+}
+
+// Upsert stores the policy of a tenant, replacing the one it had.
+func (r *RedirectionPolicyRepo) Upsert(_ context.Context, p *entity.RedirectionPolicy) error {
+	sqlQuery, args, err := r.Builder.
+		Insert("redirection_policies").
+		Columns("idle_timeout", "max_duration", "max_sessions_per_user", "max_sessions_per_device", "tenant_id").
+		Values(p.IdleTimeout, p.MaxDuration, p.MaxSessionsPerUser, p.MaxSessionsPerDevice, p.TenantID).
+		Suffix("ON CONFLICT (tenant_id) DO UPDATE SET idle_timeout = excluded.idle_timeout, max_duration = excluded.max_duration, " +
+			"max_sessions_per_user = excluded.max_sessions_per_user, max_sessions_per_device = excluded.max_sessions_per_device").
+		ToSql()
+	if err != nil {
+		return ErrRedirectionPolicyDatabase.Wrap("Upsert", "r.Builder", err)
+	}
+
+	_, err = r.Pool.Exec(sqlQuery, args...)
+	if err != nil {
+		return ErrRedirectionPolicyDatabase.Wrap("Upsert", "r.Pool.Exec", err)
+	}
+
+	return nil
+}
+
+// Delete removes the policy of a tenant, the defaults apply to it again.
+func (r *RedirectionPolicyRepo) Delete(_ context.Context, tenantID string) (bool, error) {
+	sqlQuery, args, err := r.Builder.
+		Delete("redirection_policies").
+		Where("tenant_id = ?", tenantID).
+		ToSql()
+	if err != nil {
+		return false, ErrRedirectionPolicyDatabase.Wrap("Delete", "r.Builder", err)
+	}
+
+	res, err := r.Pool.Exec(sqlQuery, args...)
+	if err != nil {
+		return false, ErrRedirectionPolicyDatabase.Wrap("Delete", "r.Pool.Exec", err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return false, ErrRedirectionPolicyDatabase.Wrap("Delete", "res.RowsAffected", err)
+	}
+
+	return rowsAffected > 0, nil
+}
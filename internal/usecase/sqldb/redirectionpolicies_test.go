@@ -0,0 +1,73 @@
+package sqldb_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity"
+	"github.com/open-amt-cloud-toolkit/console/internal/mocks"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/sqldb"
+	"github.com/open-amt-cloud-toolkit/console/pkg/db"
+)
+
+const redirectionPolicySchema = `
+CREATE TABLE redirection_policies(
+  tenant_id TEXT NOT NULL,
+  idle_timeout INTEGER NOT NULL DEFAULT 0,
+  max_duration INTEGER NOT NULL DEFAULT 0,
+  max_sessions_per_user INTEGER NOT NULL DEFAULT 0,
+  max_sessions_per_device INTEGER NOT NULL DEFAULT 0,
+  PRIMARY KEY (tenant_id)
+);
+`
+
+func TestRedirectionPolicyRepo(t *testing.T) {
+	t.Parallel()
+
+	dbConn, err := sql.Open("sqlite", ":memory:")
+	require.NoError(t, err)
+
+	defer dbConn.Close()
+
+	_, err = dbConn.Exec(redirectionPolicySchema)
+	require.NoError(t, err)
+
+	repo := sqldb.NewRedirectionPolicyRepo(&db.SQL{
+		Builder:    squirrel.StatementBuilder.PlaceholderFormat(squirrel.Question),
+		Pool:       dbConn,
+		IsEmbedded: true,
+	}, mocks.NewMockLogger(nil))
+
+	ctx := context.Background()
+
+	policy, err := repo.Get(ctx, "tenant1")
+	require.NoError(t, err)
+	require.Nil(t, policy)
+
+	stored := entity.RedirectionPolicy{IdleTimeout: 900, MaxDuration: 3600, MaxSessionsPerUser: 2, TenantID: "tenant1"}
+	require.NoError(t, repo.Upsert(ctx, &stored))
+
+	stored.MaxSessionsPerDevice = 1
+	require.NoError(t, repo.Upsert(ctx, &stored))
+	require.NoError(t, repo.Upsert(ctx, &entity.RedirectionPolicy{IdleTimeout: 60, TenantID: "tenant2"}))
+
+	policy, err = repo.Get(ctx, "tenant1")
+	require.NoError(t, err)
+	require.Equal(t, &stored, policy)
+
+	deleted, err := repo.Delete(ctx, "tenant1")
+	require.NoError(t, err)
+	require.True(t, deleted)
+
+	deleted, err = repo.Delete(ctx, "tenant1")
+	require.NoError(t, err)
+	require.False(t, deleted)
+
+	policy, err = repo.Get(ctx, "tenant2")
+	require.NoError(t, err)
+	require.Equal(t, 60, policy.IdleTimeout)
+}
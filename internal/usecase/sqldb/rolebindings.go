@@ -0,0 +1,174 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/Masterminds/squirrel"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity"
+	"github.com/open-amt-cloud-toolkit/console/pkg/consoleerrors"
+	"github.com/open-amt-cloud-toolkit/console/pkg/db"
+	"github.com/open-amt-cloud-toolkit/console/pkg/logger"
+)
+
+// RoleBindingRepo -.
+type RoleBindingRepo struct {
+	*db.SQL
+	log logger.Interface
+}
+
+var (
+	ErrRoleBindingDatabase  = DatabaseError{Console: consoleerrors.CreateConsoleError("RoleBindingRepo")}
+	ErrRoleBindingNotUnique = NotUniqueError{Console: consoleerrors.CreateConsoleError("RoleBindingRepo")}
+)
+
+// NewRoleBindingRepo -.
+func NewRoleBindingRepo(database *db.SQL, log logger.Interface) *RoleBindingRepo {
+	return &RoleBindingRepo{database, log}
+}
+
+// GetCount -.
+func (r *RoleBindingRepo) GetCount(_ context.Context, tenantID string) (int, error) {
+	sqlQuery, _, err := r.Builder.
+		Select("COUNT(*) OVER() AS total_count").
+		From("role_bindings").
+		Where("tenant_id = ?", tenantID).
+		ToSql()
+	if err != nil {
+		return 0, ErrRoleBindingDatabase.Wrap("GetCount", "r.Builder: ", err)
+	}
+
+	var count int
+
+	err = r.Pool.QueryRow(sqlQuery, tenantID).Scan(&count)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, nil
+		}
+
+		return 0, ErrRoleBindingDatabase.Wrap("GetCount", "r.Pool.QueryRow", err)
+	}
+
+	return count, nil
+}
+
+// Get -.
+func (r *RoleBindingRepo) Get(_ context.Context, top, skip int, tenantID string) ([]entity.RoleBinding, error) {
+	limitedTop, limitedSkip := limitAndOffset(top, skip)
+
+	sqlQuery, args, err := r.Builder.
+		Select("subject", "role", "creation_date", "tenant_id").
+		From("role_bindings").
+		Where("tenant_id = ?", tenantID).
+		OrderBy("subject", "role").
+		Limit(limitedTop).
+		Offset(limitedSkip).
+		ToSql()
+	if err != nil {
+		return nil, ErrRoleBindingDatabase.Wrap("Get", "r.Builder: ", err)
+	}
+
+	return r.query("Get", sqlQuery, args...)
+}
+
+// GetBySubjects returns the bindings of any of the given subjects.
+func (r *RoleBindingRepo) GetBySubjects(_ context.Context, subjects []string, tenantID string) ([]entity.RoleBinding, error) {
+	if len(subjects) == 0 {
+		return []entity.RoleBinding{}, nil
+	}
+
+	sqlQuery, args, err := r.Builder.
+		Select("subject", "role", "creation_date", "tenant_id").
+		From("role_bindings").
+		Where(squirrel.And{
+			squirrel.Eq{"subject": subjects},
+			squirrel.Eq{"tenant_id": tenantID},
+		}).
+		OrderBy("subject", "role").
+		ToSql()
+	if err != nil {
+		return nil, ErrRoleBindingDatabase.Wrap("GetBySubjects", "r.Builder: ", err)
+	}
+
+	return r.query("GetBySubjects", sqlQuery, args...)
+}
+
+// Insert -.
+func (r *RoleBindingRepo) Insert(_ context.Context, b *entity.RoleBinding) error {
+	sqlQuery, args, err := r.Builder.
+		Insert("role_bindings").
+		Columns("subject", "role", "creation_date", "tenant_id").
+		Values(b.Subject, b.Role, b.CreationDate, b.TenantID).
+		ToSql()
+	if err != nil {
+		return ErrRoleBindingDatabase.Wrap("Insert", "r.Builder", err)
+	}
+
+	_, err = r.Pool.Exec(sqlQuery, args...)
+	if err != nil {
+		if db.CheckNotUnique(err) {
+			return ErrRoleBindingNotUnique
+		}
+
+		return ErrRoleBindingDatabase.Wrap("Insert", "r.Pool.Exec", err)
+	}
+
+	return nil
+}
+
+// Delete -.
+func (r *RoleBindingRepo) Delete(_ context.Context, subject, role, tenantID string) (bool, error) {
+	sqlQuery, args, err := r.Builder.
+		Delete("role_bindings").
+		Where("subject = ? AND role = ? AND tenant_id = ?", subject, role, tenantID).
+		ToSql()
+	if err != nil {
+		return false, ErrRoleBindingDatabase.Wrap("Delete", "r.Builder", err)
+	}
+
+	res, err := r.Pool.Exec(sqlQuery, args...)
+	if err != nil {
+		return false, ErrRoleBindingDatabase.Wrap("Delete", "r.Pool.Exec", err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return false, ErrRoleBindingDatabase.Wrap("Delete", "res.RowsAffected", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+func (r *RoleBindingRepo) query(call, sqlQuery string, args ...interface{}) ([]entity.RoleBinding, error) {
+	rows, err := r.Pool.Query(sqlQuery, args...)
+	if err != nil {
+		return nil, ErrRoleBindingDatabase.Wrap(call, "r.Pool.Query", err)
+	}
+
+	defer rows.Close()
+
+	if rows.Err() != nil {
+		return nil, ErrRoleBindingDatabase.Wrap(call, "rows.Err", rows.Err())
+	}
+
+	bindings := make([]entity.RoleBinding, 0)
+
+	for rows.Next() {
+		b := entity.RoleBinding{}
+
+		var creationDate sql.NullString
+
+		err = rows.Scan(&b.Subject, &b.Role, &creationDate, &b.TenantID)
+		if err != nil {
+			return nil, ErrRoleBindingDatabase.Wrap(call, "rows.Scan: ", err)
+		}
+
+		b.CreationDate = creationDate.String
+
+		bindings = append(bindings, b)
+	}
+
+	return bindings, nil
+}
@@ -0,0 +1,90 @@
+package sqldb_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity"
+	"github.com/open-amt-cloud-toolkit/console/internal/mocks"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/sqldb"
+	"github.com/open-amt-cloud-toolkit/console/pkg/db"
+)
+
+const roleBindingSchema = `
+CREATE TABLE role_bindings(
+  subject TEXT NOT NULL,
+  role TEXT NOT NULL,
+  creation_date TEXT,
+  tenant_id TEXT NOT NULL,
+  PRIMARY KEY (subject, role, tenant_id)
+);
+`
+
+func setupRoleBindingRepo(t *testing.T) *sqldb.RoleBindingRepo {
+	t.Helper()
+
+	dbConn, err := sql.Open("sqlite", ":memory:")
+	require.NoError(t, err)
+
+	t.Cleanup(func() { dbConn.Close() })
+
+	_, err = dbConn.Exec(roleBindingSchema)
+	require.NoError(t, err)
+
+	sqlConfig := &db.SQL{
+		Builder:    squirrel.StatementBuilder.PlaceholderFormat(squirrel.Question),
+		Pool:       dbConn,
+		IsEmbedded: true,
+	}
+
+	return sqldb.NewRoleBindingRepo(sqlConfig, mocks.NewMockLogger(nil))
+}
+
+func TestRoleBindingRepo(t *testing.T) {
+	t.Parallel()
+
+	repo := setupRoleBindingRepo(t)
+	ctx := context.Background()
+
+	bindings := []entity.RoleBinding{
+		{Subject: "alice", Role: "admin", CreationDate: "2024-01-01T00:00:00Z", TenantID: "tenant1"},
+		{Subject: "bob", Role: "viewer", CreationDate: "2024-01-01T00:00:00Z", TenantID: "tenant1"},
+		{Subject: "bob", Role: "redirection", CreationDate: "2024-01-01T00:00:00Z", TenantID: "tenant1"},
+		{Subject: "bob", Role: "admin", CreationDate: "2024-01-01T00:00:00Z", TenantID: "tenant2"},
+	}
+
+	for i := range bindings {
+		require.NoError(t, repo.Insert(ctx, &bindings[i]))
+	}
+
+	err := repo.Insert(ctx, &bindings[0])
+	require.ErrorAs(t, err, &sqldb.NotUniqueError{})
+
+	count, err := repo.GetCount(ctx, "tenant1")
+	require.NoError(t, err)
+	require.Equal(t, 3, count)
+
+	all, err := repo.Get(ctx, 2, 1, "tenant1")
+	require.NoError(t, err)
+	require.Equal(t, []entity.RoleBinding{bindings[2], bindings[1]}, all)
+
+	bob, err := repo.GetBySubjects(ctx, []string{"bob", "bob@example.com"}, "tenant1")
+	require.NoError(t, err)
+	require.Equal(t, []entity.RoleBinding{bindings[2], bindings[1]}, bob)
+
+	none, err := repo.GetBySubjects(ctx, nil, "tenant1")
+	require.NoError(t, err)
+	require.Empty(t, none)
+
+	deleted, err := repo.Delete(ctx, "bob", "viewer", "tenant1")
+	require.NoError(t, err)
+	require.True(t, deleted)
+
+	deleted, err = repo.Delete(ctx, "bob", "viewer", "tenant1")
+	require.NoError(t, err)
+	require.False(t, deleted)
+}
@@ -0,0 +1,407 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/Masterminds/squirrel"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity"
+	"github.com/open-amt-cloud-toolkit/console/pkg/consoleerrors"
+	"github.com/open-amt-cloud-toolkit/console/pkg/db"
+	"github.com/open-amt-cloud-toolkit/console/pkg/logger"
+)
+
+// ScheduleRepo -.
+type ScheduleRepo struct {
+	*db.SQL
+	log logger.Interface
+}
+
+var (
+	ErrScheduleDatabase  = DatabaseError{Console: consoleerrors.CreateConsoleError("ScheduleRepo")}
+	ErrScheduleNotUnique = NotUniqueError{Console: consoleerrors.CreateConsoleError("ScheduleRepo")}
+)
+
+var scheduledJobColumns = []string{
+	"id",
+	"name",
+	"job_type",
+	"schedule_type",
+	"run_at",
+	"cron_expression",
+	"payload",
+	"target_guids",
+	"target_tags",
+	"target_method",
+	"max_retries",
+	"retry_interval",
+	"enabled",
+	"next_run",
+	"last_run",
+	"creation_date",
+	"tenant_id",
+}
+
+var jobRunColumns = []string{
+	"id",
+	"job_id",
+	"guid",
+	"attempt",
+	"status",
+	"return_value",
+	"error",
+	"retry_at",
+	"started_at",
+	"finished_at",
+	"tenant_id",
+}
+
+// NewScheduleRepo -.
+func NewScheduleRepo(database *db.SQL, log logger.Interface) *ScheduleRepo {
+	return &ScheduleRepo{database, log}
+}
+
+// GetCount -.
+func (r *ScheduleRepo) GetCount(_ context.Context, tenantID string) (int, error) {
+	sqlQuery, _, err := r.Builder.
+		Select("COUNT(*) OVER() AS total_count").
+		From("scheduled_jobs").
+		Where("tenant_id = ?", tenantID).
+		ToSql()
+	if err != nil {
+		return 0, ErrScheduleDatabase.Wrap("GetCount", "r.Builder: ", err)
+	}
+
+	var count int
+
+	err = r.Pool.QueryRow(sqlQuery, tenantID).Scan(&count)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, nil
+		}
+
+		return 0, ErrScheduleDatabase.Wrap("GetCount", "r.Pool.QueryRow", err)
+	}
+
+	return count, nil
+}
+
+// Get -.
+func (r *ScheduleRepo) Get(_ context.Context, top, skip int, tenantID string) ([]entity.ScheduledJob, error) {
+	limitedTop, limitedSkip := limitAndOffset(top, skip)
+
+	sqlQuery, args, err := r.Builder.
+		Select(scheduledJobColumns...).
+		From("scheduled_jobs").
+		Where("tenant_id = ?", tenantID).
+		OrderBy("name").
+		Limit(limitedTop).
+		Offset(limitedSkip).
+		ToSql()
+	if err != nil {
+		return nil, ErrScheduleDatabase.Wrap("Get", "r.Builder: ", err)
+	}
+
+	return r.queryJobs("Get", sqlQuery, args...)
+}
+
+// GetByID -.
+func (r *ScheduleRepo) GetByID(_ context.Context, id, tenantID string) (*entity.ScheduledJob, error) {
+	sqlQuery, args, err := r.Builder.
+		Select(scheduledJobColumns...).
+		From("scheduled_jobs").
+		Where("id = ? AND tenant_id = ?", id, tenantID).
+		ToSql()
+	if err != nil {
+		return nil, ErrScheduleDatabase.Wrap("GetByID", "r.Builder: ", err)
+	}
+
+	jobs, err := r.queryJobs("GetByID", sqlQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(jobs) == 0 {
+		return nil, nil
+	}
+
+	return &jobs[0], nil
+}
+
+// GetDue returns the enabled jobs of every tenant whose next run is at or before now.
+func (r *ScheduleRepo) GetDue(_ context.Context, now string) ([]entity.ScheduledJob, error) {
+	sqlQuery, args, err := r.Builder.
+		Select(scheduledJobColumns...).
+		From("scheduled_jobs").
+		Where(squirrel.And{
+			squirrel.Eq{"enabled": true},
+			squirrel.NotEq{"next_run": ""},
+			squirrel.LtOrEq{"next_run": now},
+		}).
+		OrderBy("next_run").
+		ToSql()
+	if err != nil {
+		return nil, ErrScheduleDatabase.Wrap("GetDue", "r.Builder: ", err)
+	}
+
+	return r.queryJobs("GetDue", sqlQuery, args...)
+}
+
+// Delete -.
+func (r *ScheduleRepo) Delete(_ context.Context, id, tenantID string) (bool, error) {
+	runsQuery, runsArgs, err := r.Builder.
+		Delete("job_runs").
+		Where("job_id = ? AND tenant_id = ?", id, tenantID).
+		ToSql()
+	if err != nil {
+		return false, ErrScheduleDatabase.Wrap("Delete", "r.Builder", err)
+	}
+
+	sqlQuery, args, err := r.Builder.
+		Delete("scheduled_jobs").
+		Where("id = ? AND tenant_id = ?", id, tenantID).
+		ToSql()
+	if err != nil {
+		return false, ErrScheduleDatabase.Wrap("Delete", "r.Builder", err)
+	}
+
+	if _, err = r.Pool.Exec(runsQuery, runsArgs...); err != nil {
+		return false, ErrScheduleDatabase.Wrap("Delete", "r.Pool.Exec", err)
+	}
+
+	res, err := r.Pool.Exec(sqlQuery, args...)
+	if err != nil {
+		return false, ErrScheduleDatabase.Wrap("Delete", "r.Pool.Exec", err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return false, ErrScheduleDatabase.Wrap("Delete", "res.RowsAffected", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// Update -.
+func (r *ScheduleRepo) Update(_ context.Context, j *entity.ScheduledJob) (bool, error) {
+	sqlQuery, args, err := r.Builder.
+		Update("scheduled_jobs").
+		Set("name", j.Name).
+		Set("job_type", j.JobType).
+		Set("schedule_type", j.ScheduleType).
+		Set("run_at", j.RunAt).
+		Set("cron_expression", j.CronExpression).
+		Set("payload", j.Payload).
+		Set("target_guids", j.TargetGUIDs).
+		Set("target_tags", j.TargetTags).
+		Set("target_method", j.TargetMethod).
+		Set("max_retries", j.MaxRetries).
+		Set("retry_interval", j.RetryInterval).
+		Set("enabled", j.Enabled).
+		Set("next_run", j.NextRun).
+		Where("id = ? AND tenant_id = ?", j.ID, j.TenantID).
+		ToSql()
+	if err != nil {
+		return false, ErrScheduleDatabase.Wrap("Update", "r.Builder", err)
+	}
+
+	return r.execAffected("Update", sqlQuery, args...)
+}
+
+// UpdateRunTimes records when a job last fired and when it should fire next. It only updates a job still due
+// at dueRun, so of the consoles sharing a database only the first to claim a run fires it.
+func (r *ScheduleRepo) UpdateRunTimes(_ context.Context, id, tenantID, dueRun, lastRun, nextRun string) (bool, error) {
+	sqlQuery, args, err := r.Builder.
+		Update("scheduled_jobs").
+		Set("last_run", lastRun).
+		Set("next_run", nextRun).
+		Where("id = ? AND tenant_id = ? AND next_run = ?", id, tenantID, dueRun).
+		ToSql()
+	if err != nil {
+		return false, ErrScheduleDatabase.Wrap("UpdateRunTimes", "r.Builder", err)
+	}
+
+	return r.execAffected("UpdateRunTimes", sqlQuery, args...)
+}
+
+// Insert -.
+func (r *ScheduleRepo) Insert(_ context.Context, j *entity.ScheduledJob) (string, error) {
+	sqlQuery, args, err := r.Builder.
+		Insert("scheduled_jobs").
+		Columns(scheduledJobColumns...).
+		Values(j.ID, j.Name, j.JobType, j.ScheduleType, j.RunAt, j.CronExpression, j.Payload, j.TargetGUIDs, j.TargetTags, j.TargetMethod,
+			j.MaxRetries, j.RetryInterval, j.Enabled, j.NextRun, j.LastRun, j.CreationDate, j.TenantID).
+		ToSql()
+	if err != nil {
+		return "", ErrScheduleDatabase.Wrap("Insert", "r.Builder", err)
+	}
+
+	_, err = r.Pool.Exec(sqlQuery, args...)
+	if err != nil {
+		if db.CheckNotUnique(err) {
+			return "", ErrScheduleNotUnique
+		}
+
+		return "", ErrScheduleDatabase.Wrap("Insert", "r.Pool.Exec", err)
+	}
+
+	return j.ID, nil
+}
+
+// InsertRun -.
+func (r *ScheduleRepo) InsertRun(_ context.Context, run *entity.JobRun) error {
+	sqlQuery, args, err := r.Builder.
+		Insert("job_runs").
+		Columns(jobRunColumns...).
+		Values(run.ID, run.JobID, run.GUID, run.Attempt, run.Status, run.ReturnValue, run.Error, run.RetryAt, run.StartedAt, run.FinishedAt, run.TenantID).
+		ToSql()
+	if err != nil {
+		return ErrScheduleDatabase.Wrap("InsertRun", "r.Builder", err)
+	}
+
+	_, err = r.Pool.Exec(sqlQuery, args...)
+	if err != nil {
+		return ErrScheduleDatabase.Wrap("InsertRun", "r.Pool.Exec", err)
+	}
+
+	return nil
+}
+
+// GetRuns returns the run history of a job, newest first.
+func (r *ScheduleRepo) GetRuns(_ context.Context, jobID string, top, skip int, tenantID string) ([]entity.JobRun, error) {
+	limitedTop, limitedSkip := limitAndOffset(top, skip)
+
+	sqlQuery, args, err := r.Builder.
+		Select(jobRunColumns...).
+		From("job_runs").
+		Where("job_id = ? AND tenant_id = ?", jobID, tenantID).
+		OrderBy("started_at DESC", "guid").
+		Limit(limitedTop).
+		Offset(limitedSkip).
+		ToSql()
+	if err != nil {
+		return nil, ErrScheduleDatabase.Wrap("GetRuns", "r.Builder: ", err)
+	}
+
+	return r.queryRuns("GetRuns", sqlQuery, args...)
+}
+
+// GetDueRetries returns the failed runs of every tenant whose retry is at or before now.
+func (r *ScheduleRepo) GetDueRetries(_ context.Context, now string) ([]entity.JobRun, error) {
+	sqlQuery, args, err := r.Builder.
+		Select(jobRunColumns...).
+		From("job_runs").
+		Where(squirrel.And{
+			squirrel.Eq{"status": "retry_pending"},
+			squirrel.LtOrEq{"retry_at": now},
+		}).
+		OrderBy("retry_at").
+		ToSql()
+	if err != nil {
+		return nil, ErrScheduleDatabase.Wrap("GetDueRetries", "r.Builder: ", err)
+	}
+
+	return r.queryRuns("GetDueRetries", sqlQuery, args...)
+}
+
+// UpdateRunStatus changes the status of a run that is still in status from, so that only one console claims a retry.
+func (r *ScheduleRepo) UpdateRunStatus(_ context.Context, id, tenantID, from, to string) (bool, error) {
+	sqlQuery, args, err := r.Builder.
+		Update("job_runs").
+		Set("status", to).
+		Where("id = ? AND tenant_id = ? AND status = ?", id, tenantID, from).
+		ToSql()
+	if err != nil {
+		return false, ErrScheduleDatabase.Wrap("UpdateRunStatus", "r.Builder", err)
+	}
+
+	return r.execAffected("UpdateRunStatus", sqlQuery, args...)
+}
+
+func (r *ScheduleRepo) queryJobs(call, sqlQuery string, args ...interface{}) ([]entity.ScheduledJob, error) {
+	rows, err := r.Pool.Query(sqlQuery, args...)
+	if err != nil {
+		return nil, ErrScheduleDatabase.Wrap(call, "r.Pool.Query", err)
+	}
+
+	defer rows.Close()
+
+	if rows.Err() != nil {
+		return nil, ErrScheduleDatabase.Wrap(call, "rows.Err", rows.Err())
+	}
+
+	jobs := make([]entity.ScheduledJob, 0)
+
+	for rows.Next() {
+		j := entity.ScheduledJob{}
+
+		err = rows.Scan(&j.ID, &j.Name, &j.JobType, &j.ScheduleType, &j.RunAt, &j.CronExpression, &j.Payload, &j.TargetGUIDs, &j.TargetTags, &j.TargetMethod,
+			&j.MaxRetries, &j.RetryInterval, &j.Enabled, &j.NextRun, &j.LastRun, &j.CreationDate, &j.TenantID)
+		if err != nil {
+			return nil, ErrScheduleDatabase.Wrap(call, "rows.Scan: ", err)
+		}
+
+		jobs = append(jobs, j)
+	}
+
+	return jobs, nil
+}
+
+func (r *ScheduleRepo) queryRuns(call, sqlQuery string, args ...interface{}) ([]entity.JobRun, error) {
+	rows, err := r.Pool.Query(sqlQuery, args...)
+	if err != nil {
+		return nil, ErrScheduleDatabase.Wrap(call, "r.Pool.Query", err)
+	}
+
+	defer rows.Close()
+
+	if rows.Err() != nil {
+		return nil, ErrScheduleDatabase.Wrap(call, "rows.Err", rows.Err())
+	}
+
+	runs := make([]entity.JobRun, 0)
+
+	for rows.Next() {
+		run := entity.JobRun{}
+
+		err = rows.Scan(&run.ID, &run.JobID, &run.GUID, &run.Attempt, &run.Status, &run.ReturnValue, &run.Error, &run.RetryAt, &run.StartedAt, &run.FinishedAt, &run.TenantID)
+		if err != nil {
+			return nil, ErrScheduleDatabase.Wrap(call, "rows.Scan: ", err)
+		}
+
+		runs = append(runs, run)
+	}
+
+	return runs, nil
+}
+
+func (r *ScheduleRepo) execAffected(call, sqlQuery string, args ...interface{}) (bool, error) {
+	res, err := r.Pool.Exec(sqlQuery, args...)
+	if err != nil {
+		return false, ErrScheduleDatabase.Wrap(call, "r.Pool.Exec", err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return false, ErrScheduleDatabase.Wrap(call, "res.RowsAffected", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+func limitAndOffset(top, skip int) (limitedTop, limitedSkip uint64) {
+	const defaultTop = 100
+
+	limitedTop = uint64(defaultTop)
+	if top > 0 {
+		limitedTop = uint64(top)
+	}
+
+	if skip > 0 {
+		limitedSkip = uint64(skip)
+	}
+
+	return limitedTop, limitedSkip
+}
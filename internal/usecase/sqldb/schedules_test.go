@@ -0,0 +1,220 @@
+package sqldb_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity"
+	"github.com/open-amt-cloud-toolkit/console/internal/mocks"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/sqldb"
+	"github.com/open-amt-cloud-toolkit/console/pkg/db"
+)
+
+const scheduleSchema = `
+CREATE TABLE scheduled_jobs(
+  id TEXT NOT NULL,
+  name TEXT NOT NULL,
+  job_type TEXT NOT NULL,
+  schedule_type TEXT NOT NULL,
+  run_at TEXT,
+  cron_expression TEXT,
+  payload TEXT,
+  target_guids TEXT,
+  target_tags TEXT,
+  target_method TEXT,
+  max_retries INTEGER NOT NULL,
+  retry_interval INTEGER NOT NULL,
+  enabled BOOLEAN NOT NULL,
+  next_run TEXT,
+  last_run TEXT,
+  creation_date TEXT,
+  tenant_id TEXT NOT NULL,
+  PRIMARY KEY (id, tenant_id)
+);
+CREATE TABLE job_runs(
+  id TEXT NOT NULL,
+  job_id TEXT NOT NULL,
+  guid TEXT NOT NULL,
+  attempt INTEGER NOT NULL,
+  status TEXT NOT NULL,
+  return_value INTEGER,
+  error TEXT,
+  retry_at TEXT,
+  started_at TEXT,
+  finished_at TEXT,
+  tenant_id TEXT NOT NULL,
+  PRIMARY KEY (id, tenant_id)
+);
+`
+
+func setupScheduleRepo(t *testing.T) *sqldb.ScheduleRepo {
+	t.Helper()
+
+	dbConn, err := sql.Open("sqlite", ":memory:")
+	require.NoError(t, err)
+
+	t.Cleanup(func() { dbConn.Close() })
+
+	_, err = dbConn.Exec(scheduleSchema)
+	require.NoError(t, err)
+
+	sqlConfig := &db.SQL{
+		Builder:    squirrel.StatementBuilder.PlaceholderFormat(squirrel.Question),
+		Pool:       dbConn,
+		IsEmbedded: true,
+	}
+
+	return sqldb.NewScheduleRepo(sqlConfig, mocks.NewMockLogger(nil))
+}
+
+func testScheduledJob(id, nextRun string, enabled bool) *entity.ScheduledJob {
+	return &entity.ScheduledJob{
+		ID:            id,
+		Name:          "job " + id,
+		JobType:       "power",
+		ScheduleType:  "cron",
+		Payload:       `{"action":8}`,
+		TargetGUIDs:   "guid-1,guid-2",
+		MaxRetries:    2,
+		RetryInterval: 60,
+		Enabled:       enabled,
+		NextRun:       nextRun,
+		CreationDate:  "2024-01-01T00:00:00Z",
+		TenantID:      "tenant1",
+	}
+}
+
+func TestScheduleRepo_CRUD(t *testing.T) {
+	t.Parallel()
+
+	repo := setupScheduleRepo(t)
+	ctx := context.Background()
+
+	job := testScheduledJob("job-1", "2024-01-02T00:00:00Z", true)
+
+	id, err := repo.Insert(ctx, job)
+	require.NoError(t, err)
+	require.Equal(t, "job-1", id)
+
+	_, err = repo.Insert(ctx, job)
+	require.ErrorAs(t, err, &sqldb.NotUniqueError{})
+
+	count, err := repo.GetCount(ctx, "tenant1")
+	require.NoError(t, err)
+	require.Equal(t, 1, count)
+
+	got, err := repo.GetByID(ctx, "job-1", "tenant1")
+	require.NoError(t, err)
+	require.Equal(t, job, got)
+
+	missing, err := repo.GetByID(ctx, "job-1", "tenant2")
+	require.NoError(t, err)
+	require.Nil(t, missing)
+
+	job.Name = "renamed"
+	job.Enabled = false
+
+	updated, err := repo.Update(ctx, job)
+	require.NoError(t, err)
+	require.True(t, updated)
+
+	jobs, err := repo.Get(ctx, 10, 0, "tenant1")
+	require.NoError(t, err)
+	require.Len(t, jobs, 1)
+	require.Equal(t, "renamed", jobs[0].Name)
+	require.False(t, jobs[0].Enabled)
+
+	require.NoError(t, repo.InsertRun(ctx, &entity.JobRun{ID: "run-1", JobID: "job-1", GUID: "guid-1", Attempt: 1, Status: "success", TenantID: "tenant1"}))
+
+	deleted, err := repo.Delete(ctx, "job-1", "tenant1")
+	require.NoError(t, err)
+	require.True(t, deleted)
+
+	runs, err := repo.GetRuns(ctx, "job-1", 10, 0, "tenant1")
+	require.NoError(t, err)
+	require.Empty(t, runs)
+
+	deleted, err = repo.Delete(ctx, "job-1", "tenant1")
+	require.NoError(t, err)
+	require.False(t, deleted)
+}
+
+func TestScheduleRepo_GetDue(t *testing.T) {
+	t.Parallel()
+
+	repo := setupScheduleRepo(t)
+	ctx := context.Background()
+
+	for _, job := range []*entity.ScheduledJob{
+		testScheduledJob("due", "2024-01-01T10:00:00Z", true),
+		testScheduledJob("later", "2024-01-01T12:00:00Z", true),
+		testScheduledJob("disabled", "2024-01-01T09:00:00Z", false),
+		testScheduledJob("finished", "", true),
+	} {
+		_, err := repo.Insert(ctx, job)
+		require.NoError(t, err)
+	}
+
+	due, err := repo.GetDue(ctx, "2024-01-01T11:00:00Z")
+	require.NoError(t, err)
+	require.Len(t, due, 1)
+	require.Equal(t, "due", due[0].ID)
+
+	updated, err := repo.UpdateRunTimes(ctx, "due", "tenant1", "2024-01-01T10:00:00Z", "2024-01-01T11:00:00Z", "2024-01-02T10:00:00Z")
+	require.NoError(t, err)
+	require.True(t, updated)
+
+	// a second console claiming the same run finds it taken
+	updated, err = repo.UpdateRunTimes(ctx, "due", "tenant1", "2024-01-01T10:00:00Z", "2024-01-01T11:00:00Z", "2024-01-02T10:00:00Z")
+	require.NoError(t, err)
+	require.False(t, updated)
+
+	due, err = repo.GetDue(ctx, "2024-01-01T11:00:00Z")
+	require.NoError(t, err)
+	require.Empty(t, due)
+}
+
+func TestScheduleRepo_Runs(t *testing.T) {
+	t.Parallel()
+
+	repo := setupScheduleRepo(t)
+	ctx := context.Background()
+
+	_, err := repo.Insert(ctx, testScheduledJob("job-1", "", true))
+	require.NoError(t, err)
+
+	runs := []entity.JobRun{
+		{ID: "run-1", JobID: "job-1", GUID: "guid-1", Attempt: 1, Status: "success", StartedAt: "2024-01-01T10:00:00Z", TenantID: "tenant1"},
+		{ID: "run-2", JobID: "job-1", GUID: "guid-2", Attempt: 1, Status: "retry_pending", RetryAt: "2024-01-01T10:05:00Z", StartedAt: "2024-01-01T10:00:01Z", TenantID: "tenant1"},
+		{ID: "run-3", JobID: "job-1", GUID: "guid-3", Attempt: 1, Status: "retry_pending", RetryAt: "2024-01-01T11:00:00Z", StartedAt: "2024-01-01T10:00:02Z", TenantID: "tenant1"},
+	}
+
+	for i := range runs {
+		require.NoError(t, repo.InsertRun(ctx, &runs[i]))
+	}
+
+	history, err := repo.GetRuns(ctx, "job-1", 10, 0, "tenant1")
+	require.NoError(t, err)
+	require.Len(t, history, 3)
+	require.Equal(t, "run-3", history[0].ID)
+
+	retries, err := repo.GetDueRetries(ctx, "2024-01-01T10:30:00Z")
+	require.NoError(t, err)
+	require.Equal(t, []entity.JobRun{runs[1]}, retries)
+
+	updated, err := repo.UpdateRunStatus(ctx, "run-2", "tenant1", "retry_pending", "retried")
+	require.NoError(t, err)
+	require.True(t, updated)
+
+	updated, err = repo.UpdateRunStatus(ctx, "run-2", "tenant1", "retry_pending", "retried")
+	require.NoError(t, err)
+	require.False(t, updated)
+
+	retries, err = repo.GetDueRetries(ctx, "2024-01-01T10:30:00Z")
+	require.NoError(t, err)
+	require.Empty(t, retries)
+}
@@ -0,0 +1,159 @@
+package sqldb
+
+import (
+	"context"
+
+	"github.com/Masterminds/squirrel"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity"
+	"github.com/open-amt-cloud-toolkit/console/pkg/consoleerrors"
+	"github.com/open-amt-cloud-toolkit/console/pkg/db"
+	"github.com/open-amt-cloud-toolkit/console/pkg/logger"
+)
+
+// SIEMRepo -.
+type SIEMRepo struct {
+	*db.SQL
+	log logger.Interface
+}
+
+var ErrSIEMDatabase = DatabaseError{Console: consoleerrors.CreateConsoleError("SIEMRepo")}
+
+var siemCursorColumns = []string{
+	"source",
+	"guid",
+	"last_collected_at",
+	"last_time",
+	"last_fingerprint",
+	"forwarded_at",
+	"forwarded",
+	"error",
+	"tenant_id",
+}
+
+// NewSIEMRepo -.
+func NewSIEMRepo(database *db.SQL, log logger.Interface) *SIEMRepo {
+	return &SIEMRepo{database, log}
+}
+
+// GetCursorCount returns the number of cursors of a tenant.
+func (r *SIEMRepo) GetCursorCount(_ context.Context, tenantID string) (int, error) {
+	sqlQuery, args, err := r.Builder.
+		Select("COUNT(*)").
+		From("siem_cursors").
+		Where("tenant_id = ?", tenantID).
+		ToSql()
+	if err != nil {
+		return 0, ErrSIEMDatabase.Wrap("GetCursorCount", "r.Builder: ", err)
+	}
+
+	var count int
+
+	err = r.Pool.QueryRow(sqlQuery, args...).Scan(&count)
+	if err != nil {
+		return 0, ErrSIEMDatabase.Wrap("GetCursorCount", "r.Pool.QueryRow", err)
+	}
+
+	return count, nil
+}
+
+// GetCursor returns how far a log of a device has been forwarded, nil when none of it was.
+func (r *SIEMRepo) GetCursor(_ context.Context, source, guid, tenantID string) (*entity.SIEMCursor, error) {
+	sqlQuery, args, err := r.Builder.
+		Select(siemCursorColumns...).
+		From("siem_cursors").
+		Where("source = ? AND guid = ? AND tenant_id = ?", source, guid, tenantID).
+		ToSql()
+	if err != nil {
+		return nil, ErrSIEMDatabase.Wrap("GetCursor", "r.Builder: ", err)
+	}
+
+	cursors, err := r.queryCursors("GetCursor", sqlQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(cursors) == 0 {
+		return nil, nil
+	}
+
+	return &cursors[0], nil
+}
+
+// GetCursors returns the cursors of the devices of a tenant.
+func (r *SIEMRepo) GetCursors(_ context.Context, top, skip int, tenantID string) ([]entity.SIEMCursor, error) {
+	limitedTop, limitedSkip := limitAndOffset(top, skip)
+
+	sqlQuery, args, err := r.Builder.
+		Select(siemCursorColumns...).
+		From("siem_cursors").
+		Where("tenant_id = ?", tenantID).
+		OrderBy("guid", "source").
+		Limit(limitedTop).
+		Offset(limitedSkip).
+		ToSql()
+	if err != nil {
+		return nil, ErrSIEMDatabase.Wrap("GetCursors", "r.Builder: ", err)
+	}
+
+	return r.queryCursors("GetCursors", sqlQuery, args...)
+}
+
+// UpsertCursor stores the cursor of a log of a device, replacing the previous one.
+func (r *SIEMRepo) UpsertCursor(_ context.Context, c *entity.SIEMCursor) error {
+	sqlQuery, args, err := r.Builder.
+		Insert("siem_cursors").
+		Columns(siemCursorColumns...).
+		Values(c.Source, c.GUID, c.LastCollectedAt, c.LastTime, c.LastFingerprint, c.ForwardedAt, c.Forwarded, c.Error, c.TenantID).
+		Suffix("ON CONFLICT (source, guid, tenant_id) DO UPDATE SET last_collected_at = excluded.last_collected_at, " +
+			"last_time = excluded.last_time, last_fingerprint = excluded.last_fingerprint, " +
+			"forwarded_at = excluded.forwarded_at, forwarded = excluded.forwarded, error = excluded.error").
+		ToSql()
+	if err != nil {
+		return ErrSIEMDatabase.Wrap("UpsertCursor", "r.Builder", err)
+	}
+
+	_, err = r.Pool.Exec(sqlQuery, args...)
+	if err != nil {
+		return ErrSIEMDatabase.Wrap("UpsertCursor", "r.Pool.Exec", err)
+	}
+
+	return nil
+}
+
+func (r *SIEMRepo) queryCursors(call, sqlQuery string, args ...interface{}) ([]entity.SIEMCursor, error) {
+	rows, err := r.Pool.Query(sqlQuery, args...)
+	if err != nil {
+		return nil, ErrSIEMDatabase.Wrap(call, "r.Pool.Query", err)
+	}
+
+	defer rows.Close()
+
+	if rows.Err() != nil {
+		return nil, ErrSIEMDatabase.Wrap(call, "rows.Err", rows.Err())
+	}
+
+	cursors := make([]entity.SIEMCursor, 0)
+
+	for rows.Next() {
+		c := entity.SIEMCursor{}
+
+		err = rows.Scan(&c.Source, &c.GUID, &c.LastCollectedAt, &c.LastTime, &c.LastFingerprint, &c.ForwardedAt, &c.Forwarded, &c.Error, &c.TenantID)
+		if err != nil {
+			return nil, ErrSIEMDatabase.Wrap(call, "rows.Scan: ", err)
+		}
+
+		cursors = append(cursors, c)
+	}
+
+	return cursors, nil
+}
+
+// afterPosition matches the records of a collected log that come after p.
+func afterPosition(p entity.LogPosition) squirrel.Or {
+	return squirrel.Or{
+		squirrel.Gt{"collected_at": p.CollectedAt},
+		squirrel.And{squirrel.Eq{"collected_at": p.CollectedAt}, squirrel.Gt{"time": p.Time}},
+		squirrel.And{squirrel.Eq{"collected_at": p.CollectedAt}, squirrel.Eq{"time": p.Time}, squirrel.Gt{"fingerprint": p.Fingerprint}},
+	}
+}
@@ -0,0 +1,112 @@
+package sqldb_test
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"testing"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity"
+	"github.com/open-amt-cloud-toolkit/console/internal/mocks"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/sqldb"
+	"github.com/open-amt-cloud-toolkit/console/pkg/db"
+)
+
+func TestSIEMRepo(t *testing.T) {
+	t.Parallel()
+
+	dbConn, err := sql.Open("sqlite", ":memory:")
+	require.NoError(t, err)
+
+	defer dbConn.Close()
+
+	for _, migration := range []string{"20261020130000_amt_audit_log", "20261020140000_event_log_alerts", "20261020150000_siem_forwarding"} {
+		schema, err := os.ReadFile("../../app/migrations/" + migration + ".up.sql")
+		require.NoError(t, err)
+
+		_, err = dbConn.Exec(string(schema))
+		require.NoError(t, err)
+	}
+
+	database := &db.SQL{
+		Builder:    squirrel.StatementBuilder.PlaceholderFormat(squirrel.Question),
+		Pool:       dbConn,
+		IsEmbedded: true,
+	}
+
+	repo := sqldb.NewSIEMRepo(database, mocks.NewMockLogger(nil))
+	audit := sqldb.NewAMTAuditRepo(database, mocks.NewMockLogger(nil))
+	events := sqldb.NewEventLogRepo(database, mocks.NewMockLogger(nil))
+
+	ctx := context.Background()
+
+	records := []entity.AMTAuditRecord{
+		{GUID: "guid1", Fingerprint: "f2", Time: "2024-12-01T00:00:00Z", CollectedAt: "2024-12-01T01:00:00Z", TenantID: "tenant1"},
+		{GUID: "guid1", Fingerprint: "f1", Time: "2024-12-01T00:00:00Z", CollectedAt: "2024-12-01T01:00:00Z", TenantID: "tenant1"},
+		{GUID: "guid1", Fingerprint: "f3", Time: "2024-11-30T00:00:00Z", CollectedAt: "2024-12-01T02:00:00Z", TenantID: "tenant1"},
+		{GUID: "guid1", Fingerprint: "f4", Time: "2024-12-01T03:00:00Z", CollectedAt: "2024-12-01T03:00:00Z", TenantID: "tenant1"},
+		{GUID: "guid2", Fingerprint: "f1", Time: "2024-12-01T00:00:00Z", CollectedAt: "2024-12-01T01:00:00Z", TenantID: "tenant1"},
+	}
+
+	_, err = audit.Insert(ctx, records)
+	require.NoError(t, err)
+
+	// from the start, in the order collected, leaving out what was collected at or after the cutoff
+	got, err := audit.GetAfter(ctx, "guid1", "tenant1", entity.LogPosition{}, "2024-12-01T03:00:00Z", 10)
+	require.NoError(t, err)
+	require.Equal(t, []entity.AMTAuditRecord{records[1], records[0], records[2]}, got)
+
+	got, err = audit.GetAfter(ctx, "guid1", "tenant1", entity.LogPosition{}, "2024-12-01T03:00:00Z", 1)
+	require.NoError(t, err)
+	require.Equal(t, []entity.AMTAuditRecord{records[1]}, got)
+
+	// a record collected later comes after the cursor even when its own time is earlier
+	got, err = audit.GetAfter(ctx, "guid1", "tenant1", entity.LogPosition{CollectedAt: "2024-12-01T01:00:00Z", Time: "2024-12-01T00:00:00Z", Fingerprint: "f1"}, "2024-12-02T00:00:00Z", 10)
+	require.NoError(t, err)
+	require.Equal(t, []entity.AMTAuditRecord{records[0], records[2], records[3]}, got)
+
+	deviceEvents := []entity.DeviceEvent{
+		{GUID: "guid1", Fingerprint: "e1", Time: "2024-12-01T00:00:00Z", Severity: "critical", CollectedAt: "2024-12-01T01:00:00Z", TenantID: "tenant1"},
+		{GUID: "guid1", Fingerprint: "e2", Time: "2024-12-01T00:30:00Z", Severity: "ok", CollectedAt: "2024-12-01T01:00:00Z", TenantID: "tenant1"},
+	}
+
+	_, err = events.InsertEvents(ctx, deviceEvents)
+	require.NoError(t, err)
+
+	gotEvents, err := events.GetEventsAfter(ctx, "guid1", "tenant1", entity.LogPosition{CollectedAt: "2024-12-01T01:00:00Z", Time: "2024-12-01T00:00:00Z", Fingerprint: "e1"}, "2024-12-02T00:00:00Z", 10)
+	require.NoError(t, err)
+	require.Equal(t, []entity.DeviceEvent{deviceEvents[1]}, gotEvents)
+
+	cursor, err := repo.GetCursor(ctx, "audit", "guid1", "tenant1")
+	require.NoError(t, err)
+	require.Nil(t, cursor)
+
+	c := entity.SIEMCursor{Source: "audit", GUID: "guid1", LastCollectedAt: "2024-12-01T01:00:00Z", LastTime: "2024-12-01T00:00:00Z", LastFingerprint: "f2", ForwardedAt: "2024-12-01T01:10:00Z", Forwarded: 2, TenantID: "tenant1"}
+	require.NoError(t, repo.UpsertCursor(ctx, &c))
+
+	c.Forwarded = 0
+	c.Error = "connection refused"
+	require.NoError(t, repo.UpsertCursor(ctx, &c))
+
+	e := entity.SIEMCursor{Source: "event", GUID: "guid1", ForwardedAt: "2024-12-01T01:10:00Z", TenantID: "tenant1"}
+	require.NoError(t, repo.UpsertCursor(ctx, &e))
+
+	cursor, err = repo.GetCursor(ctx, "audit", "guid1", "tenant1")
+	require.NoError(t, err)
+	require.Equal(t, &c, cursor)
+
+	cursors, err := repo.GetCursors(ctx, 0, 0, "tenant1")
+	require.NoError(t, err)
+	require.Equal(t, []entity.SIEMCursor{c, e}, cursors)
+
+	count, err := repo.GetCursorCount(ctx, "tenant1")
+	require.NoError(t, err)
+	require.Equal(t, 2, count)
+
+	cursors, err = repo.GetCursors(ctx, 0, 0, "tenant2")
+	require.NoError(t, err)
+	require.Empty(t, cursors)
+}
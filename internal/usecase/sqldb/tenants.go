@@ -0,0 +1,121 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity"
+	"github.com/open-amt-cloud-toolkit/console/pkg/consoleerrors"
+	"github.com/open-amt-cloud-toolkit/console/pkg/db"
+	"github.com/open-amt-cloud-toolkit/console/pkg/logger"
+)
+
+// TenantRepo -.
+type TenantRepo struct {
+	*db.SQL
+	log logger.Interface
+}
+
+var (
+	ErrTenantDatabase  = DatabaseError{Console: consoleerrors.CreateConsoleError("TenantRepo")}
+	ErrTenantNotUnique = NotUniqueError{Console: consoleerrors.CreateConsoleError("TenantRepo")}
+)
+
+// NewTenantRepo -.
+func NewTenantRepo(database *db.SQL, log logger.Interface) *TenantRepo {
+	return &TenantRepo{database, log}
+}
+
+// GetCount -.
+func (r *TenantRepo) GetCount(_ context.Context) (int, error) {
+	sqlQuery, _, err := r.Builder.
+		Select("COUNT(*) OVER() AS total_count").
+		From("tenants").
+		ToSql()
+	if err != nil {
+		return 0, ErrTenantDatabase.Wrap("GetCount", "r.Builder: ", err)
+	}
+
+	var count int
+
+	err = r.Pool.QueryRow(sqlQuery).Scan(&count)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, nil
+		}
+
+		return 0, ErrTenantDatabase.Wrap("GetCount", "r.Pool.QueryRow", err)
+	}
+
+	return count, nil
+}
+
+// Get -.
+func (r *TenantRepo) Get(_ context.Context, top, skip int) ([]entity.Tenant, error) {
+	limitedTop, limitedSkip := limitAndOffset(top, skip)
+
+	sqlQuery, args, err := r.Builder.
+		Select("id", "name", "creation_date").
+		From("tenants").
+		OrderBy("id").
+		Limit(limitedTop).
+		Offset(limitedSkip).
+		ToSql()
+	if err != nil {
+		return nil, ErrTenantDatabase.Wrap("Get", "r.Builder: ", err)
+	}
+
+	rows, err := r.Pool.Query(sqlQuery, args...)
+	if err != nil {
+		return nil, ErrTenantDatabase.Wrap("Get", "r.Pool.Query", err)
+	}
+
+	defer rows.Close()
+
+	if rows.Err() != nil {
+		return nil, ErrTenantDatabase.Wrap("Get", "rows.Err", rows.Err())
+	}
+
+	tenants := make([]entity.Tenant, 0)
+
+	for rows.Next() {
+		t := entity.Tenant{}
+
+		var creationDate sql.NullString
+
+		err = rows.Scan(&t.ID, &t.Name, &creationDate)
+		if err != nil {
+			return nil, ErrTenantDatabase.Wrap("Get", "rows.Scan: ", err)
+		}
+
+		t.CreationDate = creationDate.String
+
+		tenants = append(tenants, t)
+	}
+
+	return tenants, nil
+}
+
+// Insert -.
+func (r *TenantRepo) Insert(_ context.Context, t *entity.Tenant) error {
+	sqlQuery, args, err := r.Builder.
+		Insert("tenants").
+		Columns("id", "name", "creation_date").
+		Values(t.ID, t.Name, t.CreationDate).
+		ToSql()
+	if err != nil {
+		return ErrTenantDatabase.Wrap("Insert", "r.Builder", err)
+	}
+
+	_, err = r.Pool.Exec(sqlQuery, args...)
+	if err != nil {
+		if db.CheckNotUnique(err) {
+			return ErrTenantNotUnique
+		}
+
+		return ErrTenantDatabase.Wrap("Insert", "r.Pool.Exec", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,79 @@
+package sqldb_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity"
+	"github.com/open-amt-cloud-toolkit/console/internal/mocks"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/sqldb"
+	"github.com/open-amt-cloud-toolkit/console/pkg/db"
+)
+
+const tenantSchema = `
+CREATE TABLE tenants(
+  id TEXT NOT NULL,
+  name TEXT NOT NULL,
+  creation_date TEXT,
+  PRIMARY KEY (id)
+);
+`
+
+func setupTenantRepo(t *testing.T) *sqldb.TenantRepo {
+	t.Helper()
+
+	dbConn, err := sql.Open("sqlite", ":memory:")
+	require.NoError(t, err)
+
+	t.Cleanup(func() { dbConn.Close() })
+
+	_, err = dbConn.Exec(tenantSchema)
+	require.NoError(t, err)
+
+	sqlConfig := &db.SQL{
+		Builder:    squirrel.StatementBuilder.PlaceholderFormat(squirrel.Question),
+		Pool:       dbConn,
+		IsEmbedded: true,
+	}
+
+	return sqldb.NewTenantRepo(sqlConfig, mocks.NewMockLogger(nil))
+}
+
+func TestTenantRepo(t *testing.T) {
+	t.Parallel()
+
+	repo := setupTenantRepo(t)
+	ctx := context.Background()
+
+	count, err := repo.GetCount(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 0, count)
+
+	tenants := []entity.Tenant{
+		{ID: "globex", Name: "Globex", CreationDate: "2024-01-01T00:00:00Z"},
+		{ID: "acme", Name: "Acme Corp", CreationDate: "2024-01-01T00:00:00Z"},
+	}
+
+	for i := range tenants {
+		require.NoError(t, repo.Insert(ctx, &tenants[i]))
+	}
+
+	err = repo.Insert(ctx, &tenants[0])
+	require.ErrorAs(t, err, &sqldb.NotUniqueError{})
+
+	count, err = repo.GetCount(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 2, count)
+
+	all, err := repo.Get(ctx, 25, 0)
+	require.NoError(t, err)
+	require.Equal(t, []entity.Tenant{tenants[1], tenants[0]}, all)
+
+	page, err := repo.Get(ctx, 1, 1)
+	require.NoError(t, err)
+	require.Equal(t, []entity.Tenant{tenants[0]}, page)
+}
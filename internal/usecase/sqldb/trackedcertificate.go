@@ -0,0 +1,219 @@
+package sqldb
+
+import (
+	"context"
+
+	"github.com/Masterminds/squirrel"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity"
+	"github.com/open-amt-cloud-toolkit/console/pkg/consoleerrors"
+	"github.com/open-amt-cloud-toolkit/console/pkg/db"
+	"github.com/open-amt-cloud-toolkit/console/pkg/logger"
+)
+
+// TrackedCertificateRepo -.
+type TrackedCertificateRepo struct {
+	*db.SQL
+	log logger.Interface
+}
+
+var ErrTrackedCertificateDatabase = DatabaseError{Console: consoleerrors.CreateConsoleError("TrackedCertificateRepo")}
+
+var trackedCertificateColumns = []string{
+	"source",
+	"owner",
+	"fingerprint",
+	"subject",
+	"issuer",
+	"serial_number",
+	"not_before",
+	"not_after",
+	"checked_at",
+	"notified_status",
+	"tenant_id",
+}
+
+// deviceCertificateSources are the sources whose owner is the GUID of a device.
+var deviceCertificateSources = []string{"deviceTLS", "deviceRoot"}
+
+// NewTrackedCertificateRepo -.
+func NewTrackedCertificateRepo(database *db.SQL, log logger.Interface) *TrackedCertificateRepo {
+	return &TrackedCertificateRepo{database, log}
+}
+
+// GetCount returns the number of certificates matching filter.
+func (r *TrackedCertificateRepo) GetCount(_ context.Context, filter entity.TrackedCertificateFilter) (int, error) {
+	sqlQuery, args, err := r.Builder.
+		Select("COUNT(*)").
+		From("tracked_certificates").
+		Where(trackedCertificateConditions(filter)).
+		ToSql()
+	if err != nil {
+		return 0, ErrTrackedCertificateDatabase.Wrap("GetCount", "r.Builder: ", err)
+	}
+
+	var count int
+
+	err = r.Pool.QueryRow(sqlQuery, args...).Scan(&count)
+	if err != nil {
+		return 0, ErrTrackedCertificateDatabase.Wrap("GetCount", "r.Pool.QueryRow", err)
+	}
+
+	return count, nil
+}
+
+// Get returns the certificates matching filter, the first to expire first.
+func (r *TrackedCertificateRepo) Get(_ context.Context, filter entity.TrackedCertificateFilter, top, skip int) ([]entity.TrackedCertificate, error) {
+	limitedTop, limitedSkip := limitAndOffset(top, skip)
+
+	sqlQuery, args, err := r.Builder.
+		Select(trackedCertificateColumns...).
+		From("tracked_certificates").
+		Where(trackedCertificateConditions(filter)).
+		OrderBy("not_after", "source", "owner", "fingerprint").
+		Limit(limitedTop).
+		Offset(limitedSkip).
+		ToSql()
+	if err != nil {
+		return nil, ErrTrackedCertificateDatabase.Wrap("Get", "r.Builder: ", err)
+	}
+
+	return r.queryCertificates("Get", sqlQuery, args...)
+}
+
+// GetByOwner returns the certificates last seen on an owner.
+func (r *TrackedCertificateRepo) GetByOwner(_ context.Context, source, owner, tenantID string) ([]entity.TrackedCertificate, error) {
+	sqlQuery, args, err := r.Builder.
+		Select(trackedCertificateColumns...).
+		From("tracked_certificates").
+		Where("source = ? AND owner = ? AND tenant_id = ?", source, owner, tenantID).
+		OrderBy("fingerprint").
+		ToSql()
+	if err != nil {
+		return nil, ErrTrackedCertificateDatabase.Wrap("GetByOwner", "r.Builder: ", err)
+	}
+
+	return r.queryCertificates("GetByOwner", sqlQuery, args...)
+}
+
+// ReplaceOwner makes certs the certificates of an owner, forgetting those it no longer has.
+func (r *TrackedCertificateRepo) ReplaceOwner(_ context.Context, source, owner, tenantID string, certs []entity.TrackedCertificate) error {
+	fingerprints := make([]string, len(certs))
+	for i := range certs {
+		fingerprints[i] = certs[i].Fingerprint
+	}
+
+	sqlQuery, args, err := r.Builder.
+		Delete("tracked_certificates").
+		Where("source = ? AND owner = ? AND tenant_id = ?", source, owner, tenantID).
+		Where(squirrel.NotEq{"fingerprint": fingerprints}).
+		ToSql()
+	if err != nil {
+		return ErrTrackedCertificateDatabase.Wrap("ReplaceOwner", "r.Builder", err)
+	}
+
+	if _, err = r.Pool.Exec(sqlQuery, args...); err != nil {
+		return ErrTrackedCertificateDatabase.Wrap("ReplaceOwner", "r.Pool.Exec", err)
+	}
+
+	for i := range certs {
+		c := &certs[i]
+
+		sqlQuery, args, err = r.Builder.
+			Insert("tracked_certificates").
+			Columns(trackedCertificateColumns...).
+			Values(source, owner, c.Fingerprint, c.Subject, c.Issuer, c.SerialNumber, c.NotBefore, c.NotAfter, c.CheckedAt, c.NotifiedStatus, tenantID).
+			Suffix("ON CONFLICT (source, owner, fingerprint, tenant_id) DO UPDATE SET subject = excluded.subject, " +
+				"issuer = excluded.issuer, serial_number = excluded.serial_number, not_before = excluded.not_before, " +
+				"not_after = excluded.not_after, checked_at = excluded.checked_at, notified_status = excluded.notified_status").
+			ToSql()
+		if err != nil {
+			return ErrTrackedCertificateDatabase.Wrap("ReplaceOwner", "r.Builder", err)
+		}
+
+		if _, err = r.Pool.Exec(sqlQuery, args...); err != nil {
+			return ErrTrackedCertificateDatabase.Wrap("ReplaceOwner", "r.Pool.Exec", err)
+		}
+	}
+
+	return nil
+}
+
+// Prune forgets the certificates of domains and CIRA configs not checked since checkedBefore, which were deleted,
+// and those of devices that are no longer in their tenant.
+func (r *TrackedCertificateRepo) Prune(_ context.Context, checkedBefore string) (int, error) {
+	sqlQuery, args, err := r.Builder.
+		Delete("tracked_certificates").
+		Where(squirrel.Or{
+			squirrel.And{
+				squirrel.NotEq{"source": deviceCertificateSources},
+				squirrel.Lt{"checked_at": checkedBefore},
+			},
+			squirrel.And{
+				squirrel.Eq{"source": deviceCertificateSources},
+				squirrel.Expr("NOT EXISTS (SELECT 1 FROM devices WHERE devices.guid = tracked_certificates.owner AND devices.tenantid = tracked_certificates.tenant_id)"),
+			},
+		}).
+		ToSql()
+	if err != nil {
+		return 0, ErrTrackedCertificateDatabase.Wrap("Prune", "r.Builder", err)
+	}
+
+	res, err := r.Pool.Exec(sqlQuery, args...)
+	if err != nil {
+		return 0, ErrTrackedCertificateDatabase.Wrap("Prune", "r.Pool.Exec", err)
+	}
+
+	pruned, err := res.RowsAffected()
+	if err != nil {
+		return 0, ErrTrackedCertificateDatabase.Wrap("Prune", "res.RowsAffected", err)
+	}
+
+	return int(pruned), nil
+}
+
+func (r *TrackedCertificateRepo) queryCertificates(call, sqlQuery string, args ...interface{}) ([]entity.TrackedCertificate, error) {
+	rows, err := r.Pool.Query(sqlQuery, args...)
+	if err != nil {
+		return nil, ErrTrackedCertificateDatabase.Wrap(call, "r.Pool.Query", err)
+	}
+
+	defer rows.Close()
+
+	if rows.Err() != nil {
+		return nil, ErrTrackedCertificateDatabase.Wrap(call, "rows.Err", rows.Err())
+	}
+
+	certs := make([]entity.TrackedCertificate, 0)
+
+	for rows.Next() {
+		c := entity.TrackedCertificate{}
+
+		err = rows.Scan(&c.Source, &c.Owner, &c.Fingerprint, &c.Subject, &c.Issuer, &c.SerialNumber, &c.NotBefore, &c.NotAfter, &c.CheckedAt, &c.NotifiedStatus, &c.TenantID)
+		if err != nil {
+			return nil, ErrTrackedCertificateDatabase.Wrap(call, "rows.Scan: ", err)
+		}
+
+		certs = append(certs, c)
+	}
+
+	return certs, nil
+}
+
+func trackedCertificateConditions(filter entity.TrackedCertificateFilter) squirrel.And {
+	conditions := squirrel.And{squirrel.Eq{"tenant_id": filter.TenantID}}
+
+	if filter.Source != "" {
+		conditions = append(conditions, squirrel.Eq{"source": filter.Source})
+	}
+
+	if filter.Owner != "" {
+		conditions = append(conditions, squirrel.Eq{"owner": filter.Owner})
+	}
+
+	if filter.NotAfterBefore != "" {
+		conditions = append(conditions, squirrel.Lt{"not_after": filter.NotAfterBefore})
+	}
+
+	return conditions
+}
@@ -0,0 +1,139 @@
+package sqldb_test
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"testing"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity"
+	"github.com/open-amt-cloud-toolkit/console/internal/mocks"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/sqldb"
+	"github.com/open-amt-cloud-toolkit/console/pkg/db"
+)
+
+func TestTrackedCertificateRepo(t *testing.T) {
+	t.Parallel()
+
+	dbConn, err := sql.Open("sqlite", ":memory:")
+	require.NoError(t, err)
+
+	defer dbConn.Close()
+
+	_, err = dbConn.Exec(schema)
+	require.NoError(t, err)
+
+	migration, err := os.ReadFile("../../app/migrations/20261020160000_certificate_inventory.up.sql")
+	require.NoError(t, err)
+
+	_, err = dbConn.Exec(string(migration))
+	require.NoError(t, err)
+
+	database := &db.SQL{
+		Builder:    squirrel.StatementBuilder.PlaceholderFormat(squirrel.Question),
+		Pool:       dbConn,
+		IsEmbedded: true,
+	}
+
+	repo := sqldb.NewTrackedCertificateRepo(database, mocks.NewMockLogger(nil))
+	devices := sqldb.NewDeviceRepo(database, mocks.NewMockLogger(nil))
+
+	ctx := context.Background()
+
+	_, err = devices.Insert(ctx, &entity.Device{GUID: "guid1", TenantID: "tenant1"})
+	require.NoError(t, err)
+
+	tls := entity.TrackedCertificate{Fingerprint: "f1", Subject: "device", NotAfter: "2025-03-01T00:00:00Z", CheckedAt: "2024-12-01T00:00:00Z", NotifiedStatus: "ok"}
+	root := entity.TrackedCertificate{Fingerprint: "f2", Subject: "root", NotAfter: "2030-01-01T00:00:00Z", CheckedAt: "2024-12-01T00:00:00Z", NotifiedStatus: "ok"}
+	domain := entity.TrackedCertificate{Fingerprint: "f3", Subject: "provisioning", NotAfter: "2024-12-20T00:00:00Z", CheckedAt: "2024-12-01T00:00:00Z", NotifiedStatus: "critical"}
+
+	require.NoError(t, repo.ReplaceOwner(ctx, "deviceTLS", "guid1", "tenant1", []entity.TrackedCertificate{tls}))
+	require.NoError(t, repo.ReplaceOwner(ctx, "deviceRoot", "guid1", "tenant1", []entity.TrackedCertificate{root}))
+	require.NoError(t, repo.ReplaceOwner(ctx, "domain", "profile1", "tenant1", []entity.TrackedCertificate{domain}))
+	require.NoError(t, repo.ReplaceOwner(ctx, "deviceTLS", "guid2", "tenant1", []entity.TrackedCertificate{tls}))
+
+	// the first to expire come first, the other tenants are left out
+	got, err := repo.Get(ctx, entity.TrackedCertificateFilter{NotAfterBefore: "2026-01-01T00:00:00Z", TenantID: "tenant1"}, 0, 0)
+	require.NoError(t, err)
+	require.Len(t, got, 3)
+	require.Equal(t, "profile1", got[0].Owner)
+	require.Equal(t, "critical", got[0].NotifiedStatus)
+	require.Equal(t, "guid1", got[1].Owner)
+	require.Equal(t, "guid2", got[2].Owner)
+
+	count, err := repo.GetCount(ctx, entity.TrackedCertificateFilter{Source: "deviceTLS", TenantID: "tenant1"})
+	require.NoError(t, err)
+	require.Equal(t, 2, count)
+
+	count, err = repo.GetCount(ctx, entity.TrackedCertificateFilter{TenantID: "tenant2"})
+	require.NoError(t, err)
+	require.Equal(t, 0, count)
+
+	// a renewed certificate replaces the one the device no longer has
+	renewed := tls
+	renewed.Fingerprint = "f4"
+	renewed.NotAfter = "2026-03-01T00:00:00Z"
+
+	require.NoError(t, repo.ReplaceOwner(ctx, "deviceTLS", "guid1", "tenant1", []entity.TrackedCertificate{renewed}))
+
+	got, err = repo.GetByOwner(ctx, "deviceTLS", "guid1", "tenant1")
+	require.NoError(t, err)
+	require.Equal(t, []entity.TrackedCertificate{{
+		Source: "deviceTLS", Owner: "guid1", Fingerprint: "f4", Subject: "device", NotAfter: "2026-03-01T00:00:00Z",
+		CheckedAt: "2024-12-01T00:00:00Z", NotifiedStatus: "ok", TenantID: "tenant1",
+	}}, got)
+
+	// the domain was not seen by the last check and guid2 is not a device
+	pruned, err := repo.Prune(ctx, "2024-12-02T00:00:00Z")
+	require.NoError(t, err)
+	require.Equal(t, 2, pruned)
+
+	count, err = repo.GetCount(ctx, entity.TrackedCertificateFilter{TenantID: "tenant1"})
+	require.NoError(t, err)
+	require.Equal(t, 2, count)
+}
+
+func TestGetAllTenantsConfigs(t *testing.T) {
+	t.Parallel()
+
+	dbConn, err := sql.Open("sqlite", ":memory:")
+	require.NoError(t, err)
+
+	defer dbConn.Close()
+
+	_, err = dbConn.Exec(schema)
+	require.NoError(t, err)
+
+	database := &db.SQL{
+		Builder:    squirrel.StatementBuilder.PlaceholderFormat(squirrel.Question),
+		Pool:       dbConn,
+		IsEmbedded: true,
+	}
+
+	ctx := context.Background()
+
+	domains := sqldb.NewDomainRepo(database, mocks.NewMockLogger(nil))
+	configs := sqldb.NewCIRARepo(database, mocks.NewMockLogger(nil))
+
+	for _, tenantID := range []string{"tenant2", "tenant1"} {
+		_, err = domains.Insert(ctx, &entity.Domain{ProfileName: "domain", DomainSuffix: tenantID + ".com", TenantID: tenantID})
+		require.NoError(t, err)
+
+		_, err = configs.Insert(ctx, &entity.CIRAConfig{ConfigName: "cira", MPSRootCertificate: "cert", TenantID: tenantID})
+		require.NoError(t, err)
+	}
+
+	gotDomains, err := domains.GetAllTenants(ctx, 0, 0)
+	require.NoError(t, err)
+	require.Len(t, gotDomains, 2)
+	require.Equal(t, "tenant1", gotDomains[0].TenantID)
+
+	gotConfigs, err := configs.GetAllTenants(ctx, 1, 1)
+	require.NoError(t, err)
+	require.Len(t, gotConfigs, 1)
+	require.Equal(t, "tenant2", gotConfigs[0].TenantID)
+	require.Equal(t, "cert", gotConfigs[0].MPSRootCertificate)
+}
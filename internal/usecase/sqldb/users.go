@@ -0,0 +1,211 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity"
+	"github.com/open-amt-cloud-toolkit/console/pkg/consoleerrors"
+	"github.com/open-amt-cloud-toolkit/console/pkg/db"
+	"github.com/open-amt-cloud-toolkit/console/pkg/logger"
+)
+
+// UserRepo -.
+type UserRepo struct {
+	*db.SQL
+	log logger.Interface
+}
+
+var (
+	ErrUserDatabase  = DatabaseError{Console: consoleerrors.CreateConsoleError("UserRepo")}
+	ErrUserNotUnique = NotUniqueError{Console: consoleerrors.CreateConsoleError("UserRepo")}
+)
+
+var userColumns = []string{
+	"username",
+	"password_hash",
+	"failed_attempts",
+	"locked_until",
+	"must_change_password",
+	"creation_date",
+	"tenant_id",
+}
+
+// NewUserRepo -.
+func NewUserRepo(database *db.SQL, log logger.Interface) *UserRepo {
+	return &UserRepo{database, log}
+}
+
+// GetCount -.
+func (r *UserRepo) GetCount(_ context.Context, tenantID string) (int, error) {
+	sqlQuery, _, err := r.Builder.
+		Select("COUNT(*) OVER() AS total_count").
+		From("users").
+		Where("tenant_id = ?", tenantID).
+		ToSql()
+	if err != nil {
+		return 0, ErrUserDatabase.Wrap("GetCount", "r.Builder: ", err)
+	}
+
+	var count int
+
+	err = r.Pool.QueryRow(sqlQuery, tenantID).Scan(&count)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, nil
+		}
+
+		return 0, ErrUserDatabase.Wrap("GetCount", "r.Pool.QueryRow", err)
+	}
+
+	return count, nil
+}
+
+// Get -.
+func (r *UserRepo) Get(_ context.Context, top, skip int, tenantID string) ([]entity.User, error) {
+	limitedTop, limitedSkip := limitAndOffset(top, skip)
+
+	sqlQuery, args, err := r.Builder.
+		Select(userColumns...).
+		From("users").
+		Where("tenant_id = ?", tenantID).
+		OrderBy("username").
+		Limit(limitedTop).
+		Offset(limitedSkip).
+		ToSql()
+	if err != nil {
+		return nil, ErrUserDatabase.Wrap("Get", "r.Builder: ", err)
+	}
+
+	return r.query("Get", sqlQuery, args...)
+}
+
+// GetByUsername -.
+func (r *UserRepo) GetByUsername(_ context.Context, username, tenantID string) (*entity.User, error) {
+	sqlQuery, args, err := r.Builder.
+		Select(userColumns...).
+		From("users").
+		Where("username = ? AND tenant_id = ?", username, tenantID).
+		ToSql()
+	if err != nil {
+		return nil, ErrUserDatabase.Wrap("GetByUsername", "r.Builder: ", err)
+	}
+
+	users, err := r.query("GetByUsername", sqlQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(users) == 0 {
+		return nil, nil
+	}
+
+	return &users[0], nil
+}
+
+// Insert -.
+func (r *UserRepo) Insert(_ context.Context, u *entity.User) error {
+	sqlQuery, args, err := r.Builder.
+		Insert("users").
+		Columns(userColumns...).
+		Values(u.Username, u.PasswordHash, u.FailedAttempts, u.LockedUntil, u.MustChangePassword, u.CreationDate, u.TenantID).
+		ToSql()
+	if err != nil {
+		return ErrUserDatabase.Wrap("Insert", "r.Builder", err)
+	}
+
+	_, err = r.Pool.Exec(sqlQuery, args...)
+	if err != nil {
+		if db.CheckNotUnique(err) {
+			return ErrUserNotUnique
+		}
+
+		return ErrUserDatabase.Wrap("Insert", "r.Pool.Exec", err)
+	}
+
+	return nil
+}
+
+// Update -.
+func (r *UserRepo) Update(_ context.Context, u *entity.User) (bool, error) {
+	sqlQuery, args, err := r.Builder.
+		Update("users").
+		Set("password_hash", u.PasswordHash).
+		Set("failed_attempts", u.FailedAttempts).
+		Set("locked_until", u.LockedUntil).
+		Set("must_change_password", u.MustChangePassword).
+		Where("username = ? AND tenant_id = ?", u.Username, u.TenantID).
+		ToSql()
+	if err != nil {
+		return false, ErrUserDatabase.Wrap("Update", "r.Builder", err)
+	}
+
+	res, err := r.Pool.Exec(sqlQuery, args...)
+	if err != nil {
+		return false, ErrUserDatabase.Wrap("Update", "r.Pool.Exec", err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return false, ErrUserDatabase.Wrap("Update", "res.RowsAffected", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// Delete -.
+func (r *UserRepo) Delete(_ context.Context, username, tenantID string) (bool, error) {
+	sqlQuery, args, err := r.Builder.
+		Delete("users").
+		Where("username = ? AND tenant_id = ?", username, tenantID).
+		ToSql()
+	if err != nil {
+		return false, ErrUserDatabase.Wrap("Delete", "r.Builder", err)
+	}
+
+	res, err := r.Pool.Exec(sqlQuery, args...)
+	if err != nil {
+		return false, ErrUserDatabase.Wrap("Delete", "r.Pool.Exec", err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return false, ErrUserDatabase.Wrap("Delete", "res.RowsAffected", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+func (r *UserRepo) query(call, sqlQuery string, args ...interface{}) ([]entity.User, error) {
+	rows, err := r.Pool.Query(sqlQuery, args...)
+	if err != nil {
+		return nil, ErrUserDatabase.Wrap(call, "r.Pool.Query", err)
+	}
+
+	defer rows.Close()
+
+	if rows.Err() != nil {
+		return nil, ErrUserDatabase.Wrap(call, "rows.Err", rows.Err())
+	}
+
+	users := make([]entity.User, 0)
+
+	for rows.Next() {
+		u := entity.User{}
+
+		var lockedUntil, creationDate sql.NullString
+
+		err = rows.Scan(&u.Username, &u.PasswordHash, &u.FailedAttempts, &lockedUntil, &u.MustChangePassword, &creationDate, &u.TenantID)
+		if err != nil {
+			return nil, ErrUserDatabase.Wrap(call, "rows.Scan: ", err)
+		}
+
+		u.LockedUntil = lockedUntil.String
+		u.CreationDate = creationDate.String
+
+		users = append(users, u)
+	}
+
+	return users, nil
+}
@@ -0,0 +1,100 @@
+package sqldb_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity"
+	"github.com/open-amt-cloud-toolkit/console/internal/mocks"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/sqldb"
+	"github.com/open-amt-cloud-toolkit/console/pkg/db"
+)
+
+const userSchema = `
+CREATE TABLE users(
+  username TEXT NOT NULL,
+  password_hash TEXT NOT NULL,
+  failed_attempts INTEGER NOT NULL DEFAULT 0,
+  locked_until TEXT,
+  must_change_password BOOLEAN NOT NULL DEFAULT FALSE,
+  creation_date TEXT,
+  tenant_id TEXT NOT NULL,
+  PRIMARY KEY (username, tenant_id)
+);
+`
+
+func setupUserRepo(t *testing.T) *sqldb.UserRepo {
+	t.Helper()
+
+	dbConn, err := sql.Open("sqlite", ":memory:")
+	require.NoError(t, err)
+
+	t.Cleanup(func() { dbConn.Close() })
+
+	_, err = dbConn.Exec(userSchema)
+	require.NoError(t, err)
+
+	sqlConfig := &db.SQL{
+		Builder:    squirrel.StatementBuilder.PlaceholderFormat(squirrel.Question),
+		Pool:       dbConn,
+		IsEmbedded: true,
+	}
+
+	return sqldb.NewUserRepo(sqlConfig, mocks.NewMockLogger(nil))
+}
+
+func TestUserRepo(t *testing.T) {
+	t.Parallel()
+
+	repo := setupUserRepo(t)
+	ctx := context.Background()
+
+	user := &entity.User{
+		Username:           "alice",
+		PasswordHash:       "$2a$10$hash",
+		MustChangePassword: true,
+		CreationDate:       "2024-01-01T00:00:00Z",
+		TenantID:           "tenant1",
+	}
+
+	require.NoError(t, repo.Insert(ctx, user))
+
+	err := repo.Insert(ctx, user)
+	require.ErrorAs(t, err, &sqldb.NotUniqueError{})
+
+	count, err := repo.GetCount(ctx, "tenant1")
+	require.NoError(t, err)
+	require.Equal(t, 1, count)
+
+	got, err := repo.GetByUsername(ctx, "alice", "tenant1")
+	require.NoError(t, err)
+	require.Equal(t, user, got)
+
+	missing, err := repo.GetByUsername(ctx, "alice", "tenant2")
+	require.NoError(t, err)
+	require.Nil(t, missing)
+
+	user.FailedAttempts = 3
+	user.LockedUntil = "2024-01-01T00:15:00Z"
+	user.MustChangePassword = false
+
+	updated, err := repo.Update(ctx, user)
+	require.NoError(t, err)
+	require.True(t, updated)
+
+	users, err := repo.Get(ctx, 10, 0, "tenant1")
+	require.NoError(t, err)
+	require.Equal(t, []entity.User{*user}, users)
+
+	deleted, err := repo.Delete(ctx, "alice", "tenant1")
+	require.NoError(t, err)
+	require.True(t, deleted)
+
+	updated, err = repo.Update(ctx, user)
+	require.NoError(t, err)
+	require.False(t, updated)
+}
@@ -0,0 +1,365 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/Masterminds/squirrel"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity"
+	"github.com/open-amt-cloud-toolkit/console/pkg/consoleerrors"
+	"github.com/open-amt-cloud-toolkit/console/pkg/db"
+	"github.com/open-amt-cloud-toolkit/console/pkg/logger"
+)
+
+// WebhookRepo -.
+type WebhookRepo struct {
+	*db.SQL
+	log logger.Interface
+}
+
+var (
+	ErrWebhookDatabase  = DatabaseError{Console: consoleerrors.CreateConsoleError("WebhookRepo")}
+	ErrWebhookNotUnique = NotUniqueError{Console: consoleerrors.CreateConsoleError("WebhookRepo")}
+)
+
+var webhookColumns = []string{
+	"id",
+	"name",
+	"url",
+	"secret",
+	"event_types",
+	"enabled",
+	"creation_date",
+	"tenant_id",
+}
+
+var webhookDeliveryColumns = []string{
+	"id",
+	"webhook_id",
+	"event_id",
+	"event_type",
+	"payload",
+	"attempt",
+	"status",
+	"status_code",
+	"error",
+	"retry_at",
+	"created_at",
+	"tenant_id",
+}
+
+// NewWebhookRepo -.
+func NewWebhookRepo(database *db.SQL, log logger.Interface) *WebhookRepo {
+	return &WebhookRepo{database, log}
+}
+
+// GetCount -.
+func (r *WebhookRepo) GetCount(_ context.Context, tenantID string) (int, error) {
+	sqlQuery, _, err := r.Builder.
+		Select("COUNT(*) OVER() AS total_count").
+		From("webhooks").
+		Where("tenant_id = ?", tenantID).
+		ToSql()
+	if err != nil {
+		return 0, ErrWebhookDatabase.Wrap("GetCount", "r.Builder: ", err)
+	}
+
+	var count int
+
+	err = r.Pool.QueryRow(sqlQuery, tenantID).Scan(&count)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, nil
+		}
+
+		return 0, ErrWebhookDatabase.Wrap("GetCount", "r.Pool.QueryRow", err)
+	}
+
+	return count, nil
+}
+
+// Get -.
+func (r *WebhookRepo) Get(_ context.Context, top, skip int, tenantID string) ([]entity.Webhook, error) {
+	limitedTop, limitedSkip := limitAndOffset(top, skip)
+
+	sqlQuery, args, err := r.Builder.
+		Select(webhookColumns...).
+		From("webhooks").
+		Where("tenant_id = ?", tenantID).
+		OrderBy("name").
+		Limit(limitedTop).
+		Offset(limitedSkip).
+		ToSql()
+	if err != nil {
+		return nil, ErrWebhookDatabase.Wrap("Get", "r.Builder: ", err)
+	}
+
+	return r.queryWebhooks("Get", sqlQuery, args...)
+}
+
+// GetByID -.
+func (r *WebhookRepo) GetByID(_ context.Context, id, tenantID string) (*entity.Webhook, error) {
+	sqlQuery, args, err := r.Builder.
+		Select(webhookColumns...).
+		From("webhooks").
+		Where("id = ? AND tenant_id = ?", id, tenantID).
+		ToSql()
+	if err != nil {
+		return nil, ErrWebhookDatabase.Wrap("GetByID", "r.Builder: ", err)
+	}
+
+	webhooks, err := r.queryWebhooks("GetByID", sqlQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(webhooks) == 0 {
+		return nil, nil
+	}
+
+	return &webhooks[0], nil
+}
+
+// GetEnabled returns the enabled webhooks of a tenant.
+func (r *WebhookRepo) GetEnabled(_ context.Context, tenantID string) ([]entity.Webhook, error) {
+	sqlQuery, args, err := r.Builder.
+		Select(webhookColumns...).
+		From("webhooks").
+		Where(squirrel.And{
+			squirrel.Eq{"tenant_id": tenantID},
+			squirrel.Eq{"enabled": true},
+		}).
+		OrderBy("name").
+		ToSql()
+	if err != nil {
+		return nil, ErrWebhookDatabase.Wrap("GetEnabled", "r.Builder: ", err)
+	}
+
+	return r.queryWebhooks("GetEnabled", sqlQuery, args...)
+}
+
+// Delete -.
+func (r *WebhookRepo) Delete(_ context.Context, id, tenantID string) (bool, error) {
+	deliveriesQuery, deliveriesArgs, err := r.Builder.
+		Delete("webhook_deliveries").
+		Where("webhook_id = ? AND tenant_id = ?", id, tenantID).
+		ToSql()
+	if err != nil {
+		return false, ErrWebhookDatabase.Wrap("Delete", "r.Builder", err)
+	}
+
+	sqlQuery, args, err := r.Builder.
+		Delete("webhooks").
+		Where("id = ? AND tenant_id = ?", id, tenantID).
+		ToSql()
+	if err != nil {
+		return false, ErrWebhookDatabase.Wrap("Delete", "r.Builder", err)
+	}
+
+	if _, err = r.Pool.Exec(deliveriesQuery, deliveriesArgs...); err != nil {
+		return false, ErrWebhookDatabase.Wrap("Delete", "r.Pool.Exec", err)
+	}
+
+	return r.execAffected("Delete", sqlQuery, args...)
+}
+
+// Update -.
+func (r *WebhookRepo) Update(_ context.Context, w *entity.Webhook) (bool, error) {
+	sqlQuery, args, err := r.Builder.
+		Update("webhooks").
+		Set("name", w.Name).
+		Set("url", w.URL).
+		Set("secret", w.Secret).
+		Set("event_types", w.EventTypes).
+		Set("enabled", w.Enabled).
+		Where("id = ? AND tenant_id = ?", w.ID, w.TenantID).
+		ToSql()
+	if err != nil {
+		return false, ErrWebhookDatabase.Wrap("Update", "r.Builder", err)
+	}
+
+	return r.execAffected("Update", sqlQuery, args...)
+}
+
+// Insert -.
+func (r *WebhookRepo) Insert(_ context.Context, w *entity.Webhook) (string, error) {
+	sqlQuery, args, err := r.Builder.
+		Insert("webhooks").
+		Columns(webhookColumns...).
+		Values(w.ID, w.Name, w.URL, w.Secret, w.EventTypes, w.Enabled, w.CreationDate, w.TenantID).
+		ToSql()
+	if err != nil {
+		return "", ErrWebhookDatabase.Wrap("Insert", "r.Builder", err)
+	}
+
+	_, err = r.Pool.Exec(sqlQuery, args...)
+	if err != nil {
+		if db.CheckNotUnique(err) {
+			return "", ErrWebhookNotUnique
+		}
+
+		return "", ErrWebhookDatabase.Wrap("Insert", "r.Pool.Exec", err)
+	}
+
+	return w.ID, nil
+}
+
+// InsertDelivery -.
+func (r *WebhookRepo) InsertDelivery(_ context.Context, d *entity.WebhookDelivery) error {
+	sqlQuery, args, err := r.Builder.
+		Insert("webhook_deliveries").
+		Columns(webhookDeliveryColumns...).
+		Values(d.ID, d.WebhookID, d.EventID, d.EventType, d.Payload, d.Attempt, d.Status, d.StatusCode, d.Error, d.RetryAt, d.CreatedAt, d.TenantID).
+		ToSql()
+	if err != nil {
+		return ErrWebhookDatabase.Wrap("InsertDelivery", "r.Builder", err)
+	}
+
+	_, err = r.Pool.Exec(sqlQuery, args...)
+	if err != nil {
+		return ErrWebhookDatabase.Wrap("InsertDelivery", "r.Pool.Exec", err)
+	}
+
+	return nil
+}
+
+// GetDeliveries returns the delivery log of a webhook, newest first.
+func (r *WebhookRepo) GetDeliveries(_ context.Context, webhookID string, top, skip int, tenantID string) ([]entity.WebhookDelivery, error) {
+	limitedTop, limitedSkip := limitAndOffset(top, skip)
+
+	sqlQuery, args, err := r.Builder.
+		Select(webhookDeliveryColumns...).
+		From("webhook_deliveries").
+		Where("webhook_id = ? AND tenant_id = ?", webhookID, tenantID).
+		OrderBy("created_at DESC", "attempt DESC").
+		Limit(limitedTop).
+		Offset(limitedSkip).
+		ToSql()
+	if err != nil {
+		return nil, ErrWebhookDatabase.Wrap("GetDeliveries", "r.Builder: ", err)
+	}
+
+	return r.queryDeliveries("GetDeliveries", sqlQuery, args...)
+}
+
+// GetDueDeliveries returns the queued deliveries and the failed ones whose retry is at or before now, of every tenant.
+func (r *WebhookRepo) GetDueDeliveries(_ context.Context, now string) ([]entity.WebhookDelivery, error) {
+	sqlQuery, args, err := r.Builder.
+		Select(webhookDeliveryColumns...).
+		From("webhook_deliveries").
+		Where(squirrel.And{
+			squirrel.Eq{"status": []string{"queued", "retry_pending"}},
+			squirrel.LtOrEq{"retry_at": now},
+		}).
+		OrderBy("retry_at").
+		ToSql()
+	if err != nil {
+		return nil, ErrWebhookDatabase.Wrap("GetDueDeliveries", "r.Builder: ", err)
+	}
+
+	return r.queryDeliveries("GetDueDeliveries", sqlQuery, args...)
+}
+
+// UpdateDeliveryStatus changes the status of a delivery that is still in status from, so that only one console
+// claims it.
+func (r *WebhookRepo) UpdateDeliveryStatus(_ context.Context, id, tenantID, from, to string) (bool, error) {
+	sqlQuery, args, err := r.Builder.
+		Update("webhook_deliveries").
+		Set("status", to).
+		Where("id = ? AND tenant_id = ? AND status = ?", id, tenantID, from).
+		ToSql()
+	if err != nil {
+		return false, ErrWebhookDatabase.Wrap("UpdateDeliveryStatus", "r.Builder", err)
+	}
+
+	return r.execAffected("UpdateDeliveryStatus", sqlQuery, args...)
+}
+
+// UpdateDelivery records the outcome of an attempt.
+func (r *WebhookRepo) UpdateDelivery(_ context.Context, d *entity.WebhookDelivery) (bool, error) {
+	sqlQuery, args, err := r.Builder.
+		Update("webhook_deliveries").
+		Set("status", d.Status).
+		Set("status_code", d.StatusCode).
+		Set("error", d.Error).
+		Set("retry_at", d.RetryAt).
+		Where("id = ? AND tenant_id = ?", d.ID, d.TenantID).
+		ToSql()
+	if err != nil {
+		return false, ErrWebhookDatabase.Wrap("UpdateDelivery", "r.Builder", err)
+	}
+
+	return r.execAffected("UpdateDelivery", sqlQuery, args...)
+}
+
+func (r *WebhookRepo) queryWebhooks(call, sqlQuery string, args ...interface{}) ([]entity.Webhook, error) {
+	rows, err := r.Pool.Query(sqlQuery, args...)
+	if err != nil {
+		return nil, ErrWebhookDatabase.Wrap(call, "r.Pool.Query", err)
+	}
+
+	defer rows.Close()
+
+	if rows.Err() != nil {
+		return nil, ErrWebhookDatabase.Wrap(call, "rows.Err", rows.Err())
+	}
+
+	webhooks := make([]entity.Webhook, 0)
+
+	for rows.Next() {
+		w := entity.Webhook{}
+
+		err = rows.Scan(&w.ID, &w.Name, &w.URL, &w.Secret, &w.EventTypes, &w.Enabled, &w.CreationDate, &w.TenantID)
+		if err != nil {
+			return nil, ErrWebhookDatabase.Wrap(call, "rows.Scan: ", err)
+		}
+
+		webhooks = append(webhooks, w)
+	}
+
+	return webhooks, nil
+}
+
+func (r *WebhookRepo) queryDeliveries(call, sqlQuery string, args ...interface{}) ([]entity.WebhookDelivery, error) {
+	rows, err := r.Pool.Query(sqlQuery, args...)
+	if err != nil {
+		return nil, ErrWebhookDatabase.Wrap(call, "r.Pool.Query", err)
+	}
+
+	defer rows.Close()
+
+	if rows.Err() != nil {
+		return nil, ErrWebhookDatabase.Wrap(call, "rows.Err", rows.Err())
+	}
+
+	deliveries := make([]entity.WebhookDelivery, 0)
+
+	for rows.Next() {
+		d := entity.WebhookDelivery{}
+
+		err = rows.Scan(&d.ID, &d.WebhookID, &d.EventID, &d.EventType, &d.Payload, &d.Attempt, &d.Status, &d.StatusCode, &d.Error, &d.RetryAt, &d.CreatedAt, &d.TenantID)
+		if err != nil {
+			return nil, ErrWebhookDatabase.Wrap(call, "rows.Scan: ", err)
+		}
+
+		deliveries = append(deliveries, d)
+	}
+
+	return deliveries, nil
+}
+
+func (r *WebhookRepo) execAffected(call, sqlQuery string, args ...interface{}) (bool, error) {
+	res, err := r.Pool.Exec(sqlQuery, args...)
+	if err != nil {
+		return false, ErrWebhookDatabase.Wrap(call, "r.Pool.Exec", err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return false, ErrWebhookDatabase.Wrap(call, "res.RowsAffected", err)
+	}
+
+	return rowsAffected > 0, nil
+}
@@ -0,0 +1,200 @@
+package sqldb_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity"
+	"github.com/open-amt-cloud-toolkit/console/internal/mocks"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/sqldb"
+	"github.com/open-amt-cloud-toolkit/console/pkg/db"
+)
+
+const webhookSchema = `
+CREATE TABLE webhooks(
+  id TEXT NOT NULL,
+  name TEXT NOT NULL,
+  url TEXT NOT NULL,
+  secret TEXT NOT NULL,
+  event_types TEXT NOT NULL,
+  enabled BOOLEAN NOT NULL,
+  creation_date TEXT,
+  tenant_id TEXT NOT NULL,
+  PRIMARY KEY (id, tenant_id)
+);
+CREATE TABLE webhook_deliveries(
+  id TEXT NOT NULL,
+  webhook_id TEXT NOT NULL,
+  event_id TEXT NOT NULL,
+  event_type TEXT NOT NULL,
+  payload TEXT NOT NULL,
+  attempt INTEGER NOT NULL,
+  status TEXT NOT NULL,
+  status_code INTEGER,
+  error TEXT,
+  retry_at TEXT,
+  created_at TEXT,
+  tenant_id TEXT NOT NULL,
+  PRIMARY KEY (id, tenant_id)
+);
+`
+
+func setupWebhookRepo(t *testing.T) *sqldb.WebhookRepo {
+	t.Helper()
+
+	dbConn, err := sql.Open("sqlite", ":memory:")
+	require.NoError(t, err)
+
+	t.Cleanup(func() { dbConn.Close() })
+
+	_, err = dbConn.Exec(webhookSchema)
+	require.NoError(t, err)
+
+	sqlConfig := &db.SQL{
+		Builder:    squirrel.StatementBuilder.PlaceholderFormat(squirrel.Question),
+		Pool:       dbConn,
+		IsEmbedded: true,
+	}
+
+	return sqldb.NewWebhookRepo(sqlConfig, mocks.NewMockLogger(nil))
+}
+
+func testWebhook(id string, enabled bool) *entity.Webhook {
+	return &entity.Webhook{
+		ID:           id,
+		Name:         "hook " + id,
+		URL:          "https://hooks.example.com/" + id,
+		Secret:       "encrypted",
+		EventTypes:   "device.power,device.deleted",
+		Enabled:      enabled,
+		CreationDate: "2024-01-01T00:00:00Z",
+		TenantID:     "tenant1",
+	}
+}
+
+func TestWebhookRepo_CRUD(t *testing.T) {
+	t.Parallel()
+
+	repo := setupWebhookRepo(t)
+	ctx := context.Background()
+
+	hook := testWebhook("hook-1", true)
+
+	id, err := repo.Insert(ctx, hook)
+	require.NoError(t, err)
+	require.Equal(t, "hook-1", id)
+
+	_, err = repo.Insert(ctx, hook)
+	require.ErrorAs(t, err, &sqldb.NotUniqueError{})
+
+	_, err = repo.Insert(ctx, testWebhook("hook-2", false))
+	require.NoError(t, err)
+
+	count, err := repo.GetCount(ctx, "tenant1")
+	require.NoError(t, err)
+	require.Equal(t, 2, count)
+
+	got, err := repo.GetByID(ctx, "hook-1", "tenant1")
+	require.NoError(t, err)
+	require.Equal(t, hook, got)
+
+	missing, err := repo.GetByID(ctx, "hook-1", "tenant2")
+	require.NoError(t, err)
+	require.Nil(t, missing)
+
+	enabled, err := repo.GetEnabled(ctx, "tenant1")
+	require.NoError(t, err)
+	require.Equal(t, []entity.Webhook{*hook}, enabled)
+
+	hook.Name = "renamed"
+	hook.EventTypes = "*"
+
+	updated, err := repo.Update(ctx, hook)
+	require.NoError(t, err)
+	require.True(t, updated)
+
+	hooks, err := repo.Get(ctx, 10, 0, "tenant1")
+	require.NoError(t, err)
+	require.Len(t, hooks, 2)
+	require.Equal(t, "renamed", hooks[1].Name)
+	require.Equal(t, "*", hooks[1].EventTypes)
+
+	require.NoError(t, repo.InsertDelivery(ctx, &entity.WebhookDelivery{ID: "delivery-1", WebhookID: "hook-1", EventID: "event-1", EventType: "device.power", Payload: "{}", Attempt: 1, Status: "success", TenantID: "tenant1"}))
+
+	deleted, err := repo.Delete(ctx, "hook-1", "tenant1")
+	require.NoError(t, err)
+	require.True(t, deleted)
+
+	deliveries, err := repo.GetDeliveries(ctx, "hook-1", 10, 0, "tenant1")
+	require.NoError(t, err)
+	require.Empty(t, deliveries)
+
+	deleted, err = repo.Delete(ctx, "hook-1", "tenant1")
+	require.NoError(t, err)
+	require.False(t, deleted)
+}
+
+func TestWebhookRepo_Deliveries(t *testing.T) {
+	t.Parallel()
+
+	repo := setupWebhookRepo(t)
+	ctx := context.Background()
+
+	_, err := repo.Insert(ctx, testWebhook("hook-1", true))
+	require.NoError(t, err)
+
+	deliveries := []entity.WebhookDelivery{
+		{ID: "delivery-1", WebhookID: "hook-1", EventID: "event-1", EventType: "device.power", Payload: "{}", Attempt: 1, Status: "success", StatusCode: 200, CreatedAt: "2024-01-01T10:00:00Z", TenantID: "tenant1"},
+		{ID: "delivery-2", WebhookID: "hook-1", EventID: "event-2", EventType: "device.power", Payload: "{}", Attempt: 1, Status: "retry_pending", StatusCode: 500, RetryAt: "2024-01-01T10:05:00Z", CreatedAt: "2024-01-01T10:00:01Z", TenantID: "tenant1"},
+		{ID: "delivery-3", WebhookID: "hook-1", EventID: "event-3", EventType: "device.power", Payload: "{}", Attempt: 1, Status: "retry_pending", Error: "connection refused", RetryAt: "2024-01-01T11:00:00Z", CreatedAt: "2024-01-01T10:00:02Z", TenantID: "tenant1"},
+	}
+
+	for i := range deliveries {
+		require.NoError(t, repo.InsertDelivery(ctx, &deliveries[i]))
+	}
+
+	history, err := repo.GetDeliveries(ctx, "hook-1", 10, 0, "tenant1")
+	require.NoError(t, err)
+	require.Len(t, history, 3)
+	require.Equal(t, "delivery-3", history[0].ID)
+
+	due, err := repo.GetDueDeliveries(ctx, "2024-01-01T10:30:00Z")
+	require.NoError(t, err)
+	require.Equal(t, []entity.WebhookDelivery{deliveries[1]}, due)
+
+	updated, err := repo.UpdateDeliveryStatus(ctx, "delivery-2", "tenant1", "retry_pending", "retried")
+	require.NoError(t, err)
+	require.True(t, updated)
+
+	// a second console loses the claim
+	updated, err = repo.UpdateDeliveryStatus(ctx, "delivery-2", "tenant1", "retry_pending", "retried")
+	require.NoError(t, err)
+	require.False(t, updated)
+
+	due, err = repo.GetDueDeliveries(ctx, "2024-01-01T10:30:00Z")
+	require.NoError(t, err)
+	require.Empty(t, due)
+
+	queued := entity.WebhookDelivery{ID: "delivery-4", WebhookID: "hook-1", EventID: "event-4", EventType: "device.power", Payload: "{}", Attempt: 1, Status: "queued", RetryAt: "2024-01-01T10:10:00Z", CreatedAt: "2024-01-01T10:10:00Z", TenantID: "tenant1"}
+	require.NoError(t, repo.InsertDelivery(ctx, &queued))
+
+	due, err = repo.GetDueDeliveries(ctx, "2024-01-01T10:30:00Z")
+	require.NoError(t, err)
+	require.Equal(t, []entity.WebhookDelivery{queued}, due)
+
+	queued.Status = "success"
+	queued.StatusCode = 200
+	queued.RetryAt = ""
+
+	updated, err = repo.UpdateDelivery(ctx, &queued)
+	require.NoError(t, err)
+	require.True(t, updated)
+
+	history, err = repo.GetDeliveries(ctx, "hook-1", 1, 0, "tenant1")
+	require.NoError(t, err)
+	require.Equal(t, []entity.WebhookDelivery{queued}, history)
+}
@@ -0,0 +1,29 @@
+package tenants
+
+import (
+	"context"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity"
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+)
+
+type (
+	Repository interface {
+		GetCount(ctx context.Context) (int, error)
+		Get(ctx context.Context, top, skip int) ([]entity.Tenant, error)
+		Insert(ctx context.Context, t *entity.Tenant) error
+	}
+	Feature interface {
+		GetCount(ctx context.Context) (int, error)
+		Get(ctx context.Context, top, skip int) ([]dto.Tenant, error)
+		Insert(ctx context.Context, t *dto.Tenant) (*dto.Tenant, error)
+	}
+	// Users is the part of the users feature used to create the admin account of a new tenant.
+	Users interface {
+		Insert(ctx context.Context, u *dto.User) (*dto.User, error)
+	}
+	// Roles is the part of the roles feature used to grant the admin account of a new tenant its role.
+	Roles interface {
+		Insert(ctx context.Context, b *dto.RoleBinding) (*dto.RoleBinding, error)
+	}
+)
@@ -0,0 +1,143 @@
+package tenants
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity"
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/sqldb"
+	"github.com/open-amt-cloud-toolkit/console/pkg/consoleerrors"
+	"github.com/open-amt-cloud-toolkit/console/pkg/logger"
+	"github.com/open-amt-cloud-toolkit/console/pkg/tenant"
+)
+
+const minPasswordLength = 8
+
+// UseCase -.
+type UseCase struct {
+	repo  Repository
+	users Users
+	roles Roles
+	log   logger.Interface
+}
+
+// New -.
+func New(r Repository, users Users, roles Roles, log logger.Interface) *UseCase {
+	return &UseCase{
+		repo:  r,
+		users: users,
+		roles: roles,
+		log:   log,
+	}
+}
+
+var (
+	ErrTenantsUseCase = consoleerrors.CreateConsoleError("TenantsUseCase")
+	ErrDatabase       = sqldb.DatabaseError{Console: ErrTenantsUseCase}
+	ErrNotValid       = dto.NotValidError{Console: ErrTenantsUseCase}
+)
+
+func (uc *UseCase) GetCount(ctx context.Context) (int, error) {
+	count, err := uc.repo.GetCount(ctx)
+	if err != nil {
+		return 0, ErrDatabase.Wrap("GetCount", "uc.repo.GetCount", err)
+	}
+
+	return count, nil
+}
+
+func (uc *UseCase) Get(ctx context.Context, top, skip int) ([]dto.Tenant, error) {
+	data, err := uc.repo.Get(ctx, top, skip)
+	if err != nil {
+		return nil, ErrDatabase.Wrap("Get", "uc.repo.Get", err)
+	}
+
+	d1 := make([]dto.Tenant, len(data))
+
+	for i := range data {
+		tmpEntity := data[i] // create a new variable to avoid memory aliasing
+		d1[i] = *uc.entityToDTO(&tmpEntity)
+	}
+
+	return d1, nil
+}
+
+// Insert creates a tenant and, when an admin username is given, its first admin account.
+func (uc *UseCase) Insert(ctx context.Context, d *dto.Tenant) (*dto.Tenant, error) {
+	if err := validate(d); err != nil {
+		return nil, ErrNotValid.Wrap("Insert", "validate", err)
+	}
+
+	d1 := &entity.Tenant{
+		ID:           d.ID,
+		Name:         d.Name,
+		CreationDate: time.Now().UTC().Format(time.RFC3339),
+	}
+
+	if err := uc.repo.Insert(ctx, d1); err != nil {
+		return nil, ErrDatabase.Wrap("Insert", "uc.repo.Insert", err)
+	}
+
+	result := uc.entityToDTO(d1)
+
+	if d.AdminUsername == "" {
+		return result, nil
+	}
+
+	// the first admin of a tenant picks their own password
+	mustChange := true
+
+	if _, err := uc.users.Insert(ctx, &dto.User{
+		Username:           d.AdminUsername,
+		Password:           d.AdminPassword,
+		MustChangePassword: &mustChange,
+		TenantID:           d.ID,
+	}); err != nil {
+		return nil, err
+	}
+
+	if _, err := uc.roles.Insert(ctx, &dto.RoleBinding{Subject: d.AdminUsername, Role: dto.RoleAdmin, TenantID: d.ID}); err != nil {
+		return nil, err
+	}
+
+	uc.log.Info("tenants - Insert - created tenant " + d.ID + " with admin account " + d.AdminUsername)
+
+	result.AdminUsername = d.AdminUsername
+
+	return result, nil
+}
+
+// validate checks everything up front so that a tenant is not left behind without the admin it was asked for.
+func validate(d *dto.Tenant) error {
+	switch {
+	case strings.TrimSpace(d.ID) == tenant.Default:
+		return errors.New("tenant id is required")
+	case d.ID != strings.TrimSpace(d.ID):
+		return errors.New("tenant id must not start or end with spaces")
+	case d.Name == "":
+		return errors.New("tenant name is required")
+	case d.AdminUsername == "" && d.AdminPassword != "":
+		return errors.New("an admin password needs an admin username")
+	case d.AdminUsername != "" && len(d.AdminPassword) < minPasswordLength:
+		return errors.New("admin password must be at least 8 characters")
+	}
+
+	return nil
+}
+
+func (uc *UseCase) entityToDTO(d *entity.Tenant) *dto.Tenant {
+	// parse creation date
+	creationDate, err := time.Parse(time.RFC3339, d.CreationDate)
+	if err != nil {
+		uc.log.Warn("tenants - entityToDTO - creation date %s could not be parsed", d.CreationDate)
+	}
+
+	return &dto.Tenant{
+		ID:           d.ID,
+		Name:         d.Name,
+		CreationDate: creationDate,
+	}
+}
@@ -0,0 +1,128 @@
+package tenants_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity"
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	"github.com/open-amt-cloud-toolkit/console/internal/mocks"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/tenants"
+	"github.com/open-amt-cloud-toolkit/console/pkg/logger"
+)
+
+type testMocks struct {
+	repo  *mocks.MockTenantsRepository
+	users *mocks.MockTenantsUsers
+	roles *mocks.MockTenantsRoles
+}
+
+func tenantsTest(t *testing.T) (*tenants.UseCase, testMocks) {
+	t.Helper()
+
+	mockCtl := gomock.NewController(t)
+
+	m := testMocks{
+		repo:  mocks.NewMockTenantsRepository(mockCtl),
+		users: mocks.NewMockTenantsUsers(mockCtl),
+		roles: mocks.NewMockTenantsRoles(mockCtl),
+	}
+
+	return tenants.New(m.repo, m.users, m.roles, logger.New("error")), m
+}
+
+func TestGet(t *testing.T) {
+	t.Parallel()
+
+	useCase, m := tenantsTest(t)
+
+	m.repo.EXPECT().Get(context.Background(), 25, 0).Return([]entity.Tenant{{ID: "acme", Name: "Acme Corp", CreationDate: "2024-01-01T00:00:00Z"}}, nil)
+
+	res, err := useCase.Get(context.Background(), 25, 0)
+	require.NoError(t, err)
+	require.Len(t, res, 1)
+	require.Equal(t, "acme", res[0].ID)
+	require.Equal(t, 2024, res[0].CreationDate.Year())
+}
+
+func TestInsert(t *testing.T) {
+	t.Parallel()
+
+	mustChange := true
+
+	tests := []struct {
+		name   string
+		tenant dto.Tenant
+		mock   func(m testMocks)
+		err    error
+	}{
+		{
+			name:   "tenant only",
+			tenant: dto.Tenant{ID: "acme", Name: "Acme Corp"},
+			mock: func(m testMocks) {
+				m.repo.EXPECT().Insert(context.Background(), gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name:   "tenant with admin",
+			tenant: dto.Tenant{ID: "acme", Name: "Acme Corp", AdminUsername: "acme-admin", AdminPassword: "Str0ngP@ssword"},
+			mock: func(m testMocks) {
+				m.repo.EXPECT().Insert(context.Background(), gomock.Any()).Return(nil)
+				m.users.EXPECT().Insert(context.Background(), &dto.User{
+					Username:           "acme-admin",
+					Password:           "Str0ngP@ssword",
+					MustChangePassword: &mustChange,
+					TenantID:           "acme",
+				}).Return(&dto.User{Username: "acme-admin"}, nil)
+				m.roles.EXPECT().Insert(context.Background(), &dto.RoleBinding{Subject: "acme-admin", Role: dto.RoleAdmin, TenantID: "acme"}).Return(nil, nil)
+			},
+		},
+		{
+			name:   "default tenant",
+			tenant: dto.Tenant{ID: " ", Name: "Default"},
+			mock:   func(_ testMocks) {},
+			err:    tenants.ErrNotValid,
+		},
+		{
+			name:   "admin password too short",
+			tenant: dto.Tenant{ID: "acme", Name: "Acme Corp", AdminUsername: "acme-admin", AdminPassword: "short"},
+			mock:   func(_ testMocks) {},
+			err:    tenants.ErrNotValid,
+		},
+		{
+			name:   "already exists",
+			tenant: dto.Tenant{ID: "acme", Name: "Acme Corp", AdminUsername: "acme-admin", AdminPassword: "Str0ngP@ssword"},
+			mock: func(m testMocks) {
+				m.repo.EXPECT().Insert(context.Background(), gomock.Any()).Return(tenants.ErrDatabase)
+			},
+			err: tenants.ErrDatabase,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			useCase, m := tenantsTest(t)
+
+			tc.mock(m)
+
+			res, err := useCase.Insert(context.Background(), &tc.tenant)
+			if tc.err != nil {
+				require.IsType(t, tc.err, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.tenant.ID, res.ID)
+			require.Equal(t, tc.tenant.AdminUsername, res.AdminUsername)
+			require.Empty(t, res.AdminPassword)
+		})
+	}
+}
@@ -1,19 +1,40 @@
 package usecase
 
 import (
+	"context"
+	"time"
+
 	"github.com/open-amt-cloud-toolkit/go-wsman-messages/v2/pkg/security"
+	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/open-amt-cloud-toolkit/console/config"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/amtaudit"
 	"github.com/open-amt-cloud-toolkit/console/internal/usecase/amtexplorer"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/certexpiry"
 	"github.com/open-amt-cloud-toolkit/console/internal/usecase/ciraconfigs"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/consoleaudit"
 	"github.com/open-amt-cloud-toolkit/console/internal/usecase/devices"
 	"github.com/open-amt-cloud-toolkit/console/internal/usecase/devices/wsman"
 	"github.com/open-amt-cloud-toolkit/console/internal/usecase/domains"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/eventlogs"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/events"
 	"github.com/open-amt-cloud-toolkit/console/internal/usecase/export"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/featurepolicies"
 	"github.com/open-amt-cloud-toolkit/console/internal/usecase/ieee8021xconfigs"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/images"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/inventory"
 	"github.com/open-amt-cloud-toolkit/console/internal/usecase/profiles"
 	"github.com/open-amt-cloud-toolkit/console/internal/usecase/profilewificonfigs"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/recordings"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/redirectionpolicies"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/roles"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/schedules"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/siem"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/snapshots"
 	"github.com/open-amt-cloud-toolkit/console/internal/usecase/sqldb"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/tenants"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/users"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/webhooks"
 	"github.com/open-amt-cloud-toolkit/console/internal/usecase/wificonfigs"
 	"github.com/open-amt-cloud-toolkit/console/pkg/db"
 	"github.com/open-amt-cloud-toolkit/console/pkg/logger"
@@ -21,15 +42,43 @@ import (
 
 // Usecases -.
 type Usecases struct {
-	Devices            devices.Feature
-	Domains            domains.Feature
-	AMTExplorer        amtexplorer.Feature
-	Profiles           profiles.Feature
-	ProfileWiFiConfigs profilewificonfigs.Feature
-	IEEE8021xProfiles  ieee8021xconfigs.Feature
-	CIRAConfigs        ciraconfigs.Feature
-	WirelessProfiles   wificonfigs.Feature
-	Exporter           export.Exporter
+	Devices             devices.Feature
+	Domains             domains.Feature
+	AMTExplorer         amtexplorer.Feature
+	Profiles            profiles.Feature
+	ProfileWiFiConfigs  profilewificonfigs.Feature
+	IEEE8021xProfiles   ieee8021xconfigs.Feature
+	CIRAConfigs         ciraconfigs.Feature
+	WirelessProfiles    wificonfigs.Feature
+	Exporter            export.Exporter
+	Schedules           schedules.Feature
+	Roles               roles.Feature
+	Users               users.Feature
+	Tenants             tenants.Feature
+	Events              events.Feature
+	Webhooks            webhooks.Feature
+	ConsoleAudit        consoleaudit.Feature
+	Recordings          recordings.Feature
+	Images              images.Feature
+	RedirectionPolicies redirectionpolicies.Feature
+	VNC                 devices.VNC
+	Thumbnails          devices.Thumbnails
+	FeaturePolicies     featurepolicies.Feature
+	Snapshots           snapshots.Feature
+	Inventory           inventory.Feature
+	AMTAudit            amtaudit.Feature
+	EventLogs           eventlogs.Feature
+	SIEM                siem.Feature
+	CertificateExpiry   certexpiry.Feature
+	// Background is started with the application and stopped on shutdown.
+	Background []BackgroundJob
+	// Collectors are registered with the metrics endpoint.
+	Collectors []prometheus.Collector
+}
+
+// BackgroundJob is a long running task that runs until ctx is done.
+type BackgroundJob interface {
+	Run(ctx context.Context)
 }
 
 // New -.
@@ -41,8 +90,22 @@ func NewUseCases(database *db.SQL, log logger.Interface) *Usecases {
 	safeRequirements := security.Crypto{
 		EncryptionKey: key,
 	}
-	wsman1 := wsman.NewGoWSMANMessages(log, safeRequirements)
-	wsman2 := amtexplorer.NewGoWSMANMessages(log, safeRequirements)
+	devicePool := wsman.NewConnectionPool(wsman.PoolConfig{
+		Name:           "devices",
+		Workers:        config.ConsoleConfig.WSMAN.Workers,
+		IdleTimeout:    config.ConsoleConfig.WSMAN.IdleTimeout,
+		MaxConnections: config.ConsoleConfig.WSMAN.MaxConnections,
+		AuthWait:       config.ConsoleConfig.WSMAN.AuthWait,
+	})
+	explorerPool := wsman.NewConnectionPool(wsman.PoolConfig{
+		Name:           "amtexplorer",
+		Workers:        1,
+		IdleTimeout:    5 * time.Minute,
+		MaxConnections: config.ConsoleConfig.WSMAN.MaxConnections,
+		AuthWait:       config.ConsoleConfig.WSMAN.AuthWait,
+	})
+	wsman1 := wsman.NewGoWSMANMessages(log, safeRequirements, devicePool)
+	wsman2 := amtexplorer.NewGoWSMANMessages(log, safeRequirements, explorerPool)
 	domainRepo := sqldb.NewDomainRepo(database, log)
 	deviceRepo := sqldb.NewDeviceRepo(database, log)
 	ciraRepo := sqldb.NewCIRARepo(database, log)
@@ -50,16 +113,125 @@ func NewUseCases(database *db.SQL, log logger.Interface) *Usecases {
 
 	domains1 := domains.New(domainRepo, log, safeRequirements)
 	wificonfig := wificonfigs.New(wifiConfigRepo, ieee, log, safeRequirements)
+	events1 := events.New(log)
+	recordings1 := recordings.New(sqldb.NewRecordingRepo(database, log), log, recordings.Config{
+		Enabled: config.ConsoleConfig.Recordings.RecordSessions,
+		Path:    config.ConsoleConfig.Recordings.RecordingsPath,
+		Modes:   config.ConsoleConfig.Recordings.RecordModes,
+		MaxSize: config.ConsoleConfig.Recordings.MaxRecordingSize,
+	})
+	policies := redirectionpolicies.New(sqldb.NewRedirectionPolicyRepo(database, log), log, redirectionpolicies.Config{
+		IdleTimeout:          config.ConsoleConfig.Redirection.IdleTimeout,
+		MaxDuration:          config.ConsoleConfig.Redirection.MaxDuration,
+		MaxSessionsPerUser:   config.ConsoleConfig.Redirection.MaxSessionsPerUser,
+		MaxSessionsPerDevice: config.ConsoleConfig.Redirection.MaxSessionsPerDevice,
+	})
+	devices1 := devices.New(deviceRepo, wsman1, devices.NewRedirector(safeRequirements), events1, recordings1, policies, log, safeRequirements)
+	roles1 := roles.New(sqldb.NewRoleBindingRepo(database, log), config.ConsoleConfig.GroupRoles, log)
+	users1 := users.New(sqldb.NewUserRepo(database, log), roles1, log, config.ConsoleConfig.MaxLoginAttempts, config.ConsoleConfig.LockoutDuration)
+	health := devices.NewHealthPoller(deviceRepo, wsman1, log, devices.HealthConfig{
+		Interval: config.ConsoleConfig.Health.PollInterval,
+		Rate:     config.ConsoleConfig.Health.PollRate,
+		Workers:  config.ConsoleConfig.Health.PollWorkers,
+		Jitter:   config.ConsoleConfig.Health.PollJitter,
+	})
+	webhooks1 := webhooks.New(sqldb.NewWebhookRepo(database, log), events1, log, safeRequirements, webhooks.Config{
+		DeliveryTimeout: config.ConsoleConfig.Webhooks.DeliveryTimeout,
+		MaxAttempts:     config.ConsoleConfig.Webhooks.MaxAttempts,
+		RetryBackoff:    config.ConsoleConfig.Webhooks.RetryBackoff,
+	})
+	vnc := devices.NewVNCGateway(devices1, log, devices.VNCConfig{
+		Address:     config.ConsoleConfig.VNC.Address,
+		PasswordTTL: config.ConsoleConfig.VNC.PasswordTTL,
+	})
+	thumbnails := devices.NewThumbnailer(devices1, sqldb.NewDeviceThumbnailRepo(database, log), log, devices.ThumbnailConfig{
+		Interval: config.ConsoleConfig.KVM.ThumbnailInterval,
+		Width:    config.ConsoleConfig.KVM.ThumbnailWidth,
+		Workers:  config.ConsoleConfig.KVM.ThumbnailWorkers,
+	})
+	profiles1 := profiles.New(profileRepo, wifiConfigRepo, pwc, ieee, log, domainRepo, safeRequirements)
+	featurePolicies := featurepolicies.New(sqldb.NewFeaturePolicyRepo(database, log), devices1, profiles1, log, featurepolicies.Config{
+		Interval: config.ConsoleConfig.FeaturePolicies.CheckInterval,
+		Workers:  config.ConsoleConfig.FeaturePolicies.CheckWorkers,
+	})
+	inventory1 := inventory.New(sqldb.NewInventoryRepo(database, log), devices1, deviceRepo, log, inventory.Config{
+		Interval: config.ConsoleConfig.Inventory.RefreshInterval,
+		Workers:  config.ConsoleConfig.Inventory.RefreshWorkers,
+	})
+	amtAudit := amtaudit.New(sqldb.NewAMTAuditRepo(database, log), devices1, deviceRepo, log, amtaudit.Config{
+		Interval: config.ConsoleConfig.AMTAudit.CollectInterval,
+		Workers:  config.ConsoleConfig.AMTAudit.CollectWorkers,
+	})
+	notifiers := []eventlogs.Notifier{eventlogs.NewLogNotifier(log), eventlogs.NewWebhookNotifier(events1)}
+	if config.ConsoleConfig.Alerts.SMTPHost != "" {
+		notifiers = append(notifiers, eventlogs.NewSMTPNotifier(eventlogs.SMTPConfig{
+			Host:     config.ConsoleConfig.Alerts.SMTPHost,
+			Port:     config.ConsoleConfig.Alerts.SMTPPort,
+			Username: config.ConsoleConfig.Alerts.SMTPUsername,
+			Password: config.ConsoleConfig.Alerts.SMTPPassword,
+			From:     config.ConsoleConfig.Alerts.SMTPFrom,
+			To:       config.ConsoleConfig.Alerts.SMTPTo,
+		}))
+	}
+
+	eventLogs := eventlogs.New(sqldb.NewEventLogRepo(database, log), devices1, deviceRepo, log, eventlogs.Config{
+		Interval: config.ConsoleConfig.EventLog.CollectInterval,
+		Workers:  config.ConsoleConfig.EventLog.CollectWorkers,
+	}, notifiers...)
+	siem1 := siem.New(sqldb.NewSIEMRepo(database, log), sqldb.NewAMTAuditRepo(database, log), sqldb.NewEventLogRepo(database, log), deviceRepo, log, siem.Config{
+		Network:            config.ConsoleConfig.SIEM.Network,
+		Address:            config.ConsoleConfig.SIEM.Address,
+		Format:             config.ConsoleConfig.SIEM.Format,
+		Facility:           config.ConsoleConfig.SIEM.Facility,
+		AppName:            config.ConsoleConfig.SIEM.AppName,
+		CAFile:             config.ConsoleConfig.SIEM.CAFile,
+		InsecureSkipVerify: config.ConsoleConfig.SIEM.InsecureSkipVerify,
+		Sources:            config.ConsoleConfig.SIEM.Sources,
+		Interval:           config.ConsoleConfig.SIEM.ForwardInterval,
+		BatchSize:          config.ConsoleConfig.SIEM.BatchSize,
+		Timeout:            config.ConsoleConfig.SIEM.Timeout,
+	})
+	certExpiry := certexpiry.New(sqldb.NewTrackedCertificateRepo(database, log), devices1, deviceRepo, domainRepo, ciraRepo, events1, safeRequirements, log, certexpiry.Config{
+		Interval: config.ConsoleConfig.CertExpiry.CheckInterval,
+		Workers:  config.ConsoleConfig.CertExpiry.CheckWorkers,
+		Warning:  config.ConsoleConfig.CertExpiry.WarningThreshold,
+		Critical: config.ConsoleConfig.CertExpiry.CriticalThreshold,
+	})
+	schedules1 := schedules.New(sqldb.NewScheduleRepo(database, log), devices1, log, config.ConsoleConfig.Scheduler.Interval)
 
 	return &Usecases{
 		Domains:            domains1,
-		Devices:            devices.New(deviceRepo, wsman1, devices.NewRedirector(safeRequirements), log, safeRequirements),
+		Devices:            devices1,
 		AMTExplorer:        amtexplorer.New(deviceRepo, wsman2, log, safeRequirements),
-		Profiles:           profiles.New(profileRepo, wifiConfigRepo, pwc, ieee, log, domainRepo, safeRequirements),
+		Profiles:           profiles1,
 		IEEE8021xProfiles:  ieee,
 		CIRAConfigs:        ciraconfigs.New(ciraRepo, log, safeRequirements),
 		WirelessProfiles:   wificonfig,
 		ProfileWiFiConfigs: pwc,
 		Exporter:           export.NewFileExporter(),
+		Schedules:          schedules1,
+		Roles:              roles1,
+		Users:              users1,
+		Tenants:            tenants.New(sqldb.NewTenantRepo(database, log), users1, roles1, log),
+		Events:             events1,
+		Webhooks:           webhooks1,
+		ConsoleAudit:       consoleaudit.New(sqldb.NewConsoleAuditRepo(database, log), log),
+		Recordings:         recordings1,
+		Images: images.New(sqldb.NewMediaImageRepo(database, log), devices1, log, images.Config{
+			Path:    config.ConsoleConfig.Images.ImagesPath,
+			MaxSize: config.ConsoleConfig.Images.MaxImageSize,
+		}),
+		RedirectionPolicies: policies,
+		VNC:                 vnc,
+		Thumbnails:          thumbnails,
+		FeaturePolicies:     featurePolicies,
+		Snapshots:           snapshots.New(sqldb.NewDeviceSnapshotRepo(database, log), devices1, log),
+		Inventory:           inventory1,
+		AMTAudit:            amtAudit,
+		EventLogs:           eventLogs,
+		SIEM:                siem1,
+		CertificateExpiry:   certExpiry,
+		Background:          []BackgroundJob{schedules1, webhooks1, health, vnc, thumbnails, featurePolicies, inventory1, amtAudit, eventLogs, siem1, certExpiry, devicePool, explorerPool},
+		Collectors:          []prometheus.Collector{devicePool, explorerPool},
 	}
 }
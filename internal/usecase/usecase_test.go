@@ -15,9 +15,12 @@ import (
 	"github.com/open-amt-cloud-toolkit/console/internal/usecase/devices"
 	"github.com/open-amt-cloud-toolkit/console/internal/usecase/devices/wsman"
 	"github.com/open-amt-cloud-toolkit/console/internal/usecase/domains"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/events"
 	"github.com/open-amt-cloud-toolkit/console/internal/usecase/ieee8021xconfigs"
 	"github.com/open-amt-cloud-toolkit/console/internal/usecase/profiles"
 	"github.com/open-amt-cloud-toolkit/console/internal/usecase/profilewificonfigs"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/recordings"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/redirectionpolicies"
 	"github.com/open-amt-cloud-toolkit/console/internal/usecase/sqldb"
 	"github.com/open-amt-cloud-toolkit/console/internal/usecase/wificonfigs"
 	"github.com/open-amt-cloud-toolkit/console/pkg/db"
@@ -60,7 +63,7 @@ func TestUsecases(t *testing.T) {
 			},
 			expectedResult: &Usecases{
 				Domains: domains.New(sqldb.NewDomainRepo(&db.SQL{}, mocks.NewMockLogger(nil)), mocks.NewMockLogger(nil), safeRequirements),
-				Devices: devices.New(sqldb.NewDeviceRepo(&db.SQL{}, mocks.NewMockLogger(nil)), wsman.NewGoWSMANMessages(mocks.NewMockLogger(nil), safeRequirements), devices.NewRedirector(safeRequirements), mocks.NewMockLogger(nil), safeRequirements),
+				Devices: devices.New(sqldb.NewDeviceRepo(&db.SQL{}, mocks.NewMockLogger(nil)), wsman.NewGoWSMANMessages(mocks.NewMockLogger(nil), safeRequirements, wsman.NewConnectionPool(wsman.PoolConfig{Name: "devices"})), devices.NewRedirector(safeRequirements), events.New(mocks.NewMockLogger(nil)), recordings.New(sqldb.NewRecordingRepo(&db.SQL{}, mocks.NewMockLogger(nil)), mocks.NewMockLogger(nil), recordings.Config{}), redirectionpolicies.New(sqldb.NewRedirectionPolicyRepo(&db.SQL{}, mocks.NewMockLogger(nil)), mocks.NewMockLogger(nil), redirectionpolicies.Config{}), mocks.NewMockLogger(nil), safeRequirements),
 				Profiles: profiles.New(
 					sqldb.NewProfileRepo(&db.SQL{}, mocks.NewMockLogger(nil)),
 					sqldb.NewWirelessRepo(&db.SQL{}, mocks.NewMockLogger(nil)),
@@ -93,6 +96,25 @@ func TestUsecases(t *testing.T) {
 			assert.NotNil(t, uc.IEEE8021xProfiles)
 			assert.NotNil(t, uc.CIRAConfigs)
 			assert.NotNil(t, uc.WirelessProfiles)
+			assert.NotNil(t, uc.Schedules)
+			assert.NotNil(t, uc.Roles)
+			assert.NotNil(t, uc.Users)
+			assert.NotNil(t, uc.Tenants)
+			assert.NotNil(t, uc.Events)
+			assert.NotNil(t, uc.Webhooks)
+			assert.NotNil(t, uc.ConsoleAudit)
+			assert.NotNil(t, uc.Recordings)
+			assert.NotNil(t, uc.Images)
+			assert.NotNil(t, uc.RedirectionPolicies)
+			assert.NotNil(t, uc.VNC)
+			assert.NotNil(t, uc.Thumbnails)
+			assert.NotNil(t, uc.FeaturePolicies)
+			assert.NotNil(t, uc.Snapshots)
+			assert.NotNil(t, uc.Inventory)
+			assert.NotNil(t, uc.AMTAudit)
+			assert.NotNil(t, uc.EventLogs)
+			assert.NotNil(t, uc.SIEM)
+			assert.NotNil(t, uc.CertificateExpiry)
 
 			assert.Equal(t, tc.expectedResult.Domains, uc.Domains)
 			assert.Equal(t, tc.expectedResult.Devices, uc.Devices)
@@ -0,0 +1,34 @@
+package users
+
+import (
+	"context"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity"
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+)
+
+type (
+	Repository interface {
+		GetCount(ctx context.Context, tenantID string) (int, error)
+		Get(ctx context.Context, top, skip int, tenantID string) ([]entity.User, error)
+		GetByUsername(ctx context.Context, username, tenantID string) (*entity.User, error)
+		Insert(ctx context.Context, u *entity.User) error
+		Update(ctx context.Context, u *entity.User) (bool, error)
+		Delete(ctx context.Context, username, tenantID string) (bool, error)
+	}
+	Feature interface {
+		GetCount(ctx context.Context, tenantID string) (int, error)
+		Get(ctx context.Context, top, skip int, tenantID string) ([]dto.User, error)
+		GetByUsername(ctx context.Context, username, tenantID string) (*dto.User, error)
+		Insert(ctx context.Context, u *dto.User) (*dto.User, error)
+		Update(ctx context.Context, u *dto.User) (*dto.User, error)
+		Delete(ctx context.Context, username, tenantID string) error
+		Authenticate(ctx context.Context, username, password, tenantID string) (*dto.User, error)
+		ChangePassword(ctx context.Context, change dto.PasswordChange, tenantID string) error
+		Bootstrap(ctx context.Context, username, password string) error
+	}
+	// Roles is the part of the roles feature used to grant the bootstrap admin its role.
+	Roles interface {
+		Insert(ctx context.Context, b *dto.RoleBinding) (*dto.RoleBinding, error)
+	}
+)
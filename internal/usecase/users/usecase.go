@@ -0,0 +1,367 @@
+package users
+
+import (
+	"context"
+	"errors"
+	"hash/fnv"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/open-amt-cloud-toolkit/console/config"
+	"github.com/open-amt-cloud-toolkit/console/internal/entity"
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/sqldb"
+	"github.com/open-amt-cloud-toolkit/console/pkg/consoleerrors"
+	"github.com/open-amt-cloud-toolkit/console/pkg/logger"
+)
+
+const (
+	defaultMaxAttempts     = 5
+	defaultLockoutDuration = 15 * time.Minute
+	minPasswordLength      = 8
+	verifyLocks            = 64
+)
+
+// UseCase -.
+type UseCase struct {
+	repo        Repository
+	roles       Roles
+	log         logger.Interface
+	maxAttempts int
+	lockout     time.Duration
+	// verifying serializes the logins of a user so that concurrent guesses are all counted towards the
+	// lockout, users share a lock by hash to keep their number bounded
+	verifying [verifyLocks]sync.Mutex
+}
+
+// New -. An account is locked for lockout after maxAttempts failed logins in a row.
+func New(r Repository, roles Roles, log logger.Interface, maxAttempts int, lockout time.Duration) *UseCase {
+	if maxAttempts <= 0 {
+		maxAttempts = defaultMaxAttempts
+	}
+
+	if lockout <= 0 {
+		lockout = defaultLockoutDuration
+	}
+
+	return &UseCase{
+		repo:        r,
+		roles:       roles,
+		log:         log,
+		maxAttempts: maxAttempts,
+		lockout:     lockout,
+	}
+}
+
+var (
+	ErrUsersUseCase = consoleerrors.CreateConsoleError("UsersUseCase")
+	ErrDatabase     = sqldb.DatabaseError{Console: ErrUsersUseCase}
+	ErrNotFound     = sqldb.NotFoundError{Console: ErrUsersUseCase}
+	ErrNotValid     = dto.NotValidError{Console: ErrUsersUseCase}
+
+	ErrInvalidCredentials     = errors.New("invalid credentials")
+	ErrAccountLocked          = errors.New("account locked")
+	ErrPasswordChangeRequired = errors.New("password change required")
+)
+
+var (
+	// dummyHash is compared against when a username is unknown so that the response time does not reveal it.
+	dummyHash     []byte
+	dummyHashOnce sync.Once
+)
+
+func (uc *UseCase) GetCount(ctx context.Context, tenantID string) (int, error) {
+	count, err := uc.repo.GetCount(ctx, tenantID)
+	if err != nil {
+		return 0, ErrDatabase.Wrap("GetCount", "uc.repo.GetCount", err)
+	}
+
+	return count, nil
+}
+
+func (uc *UseCase) Get(ctx context.Context, top, skip int, tenantID string) ([]dto.User, error) {
+	data, err := uc.repo.Get(ctx, top, skip, tenantID)
+	if err != nil {
+		return nil, ErrDatabase.Wrap("Get", "uc.repo.Get", err)
+	}
+
+	d1 := make([]dto.User, len(data))
+
+	for i := range data {
+		tmpEntity := data[i] // create a new variable to avoid memory aliasing
+		d1[i] = *uc.entityToDTO(&tmpEntity)
+	}
+
+	return d1, nil
+}
+
+func (uc *UseCase) GetByUsername(ctx context.Context, username, tenantID string) (*dto.User, error) {
+	data, err := uc.repo.GetByUsername(ctx, username, tenantID)
+	if err != nil {
+		return nil, ErrDatabase.Wrap("GetByUsername", "uc.repo.GetByUsername", err)
+	}
+
+	if data == nil {
+		return nil, ErrNotFound
+	}
+
+	return uc.entityToDTO(data), nil
+}
+
+func (uc *UseCase) Insert(ctx context.Context, d *dto.User) (*dto.User, error) {
+	hash, err := hashPassword(d.Password)
+	if err != nil {
+		return nil, ErrNotValid.Wrap("Insert", "hashPassword", err)
+	}
+
+	d1 := &entity.User{
+		Username:           d.Username,
+		PasswordHash:       hash,
+		MustChangePassword: d.MustChangePassword != nil && *d.MustChangePassword,
+		CreationDate:       time.Now().UTC().Format(time.RFC3339),
+		TenantID:           d.TenantID,
+	}
+
+	if err := uc.repo.Insert(ctx, d1); err != nil {
+		return nil, ErrDatabase.Wrap("Insert", "uc.repo.Insert", err)
+	}
+
+	return uc.entityToDTO(d1), nil
+}
+
+// Update sets a new password when one is given, the forced password change flag when it is given, and unlocks
+// the account when Locked is false.
+func (uc *UseCase) Update(ctx context.Context, d *dto.User) (*dto.User, error) {
+	existing, err := uc.repo.GetByUsername(ctx, d.Username, d.TenantID)
+	if err != nil {
+		return nil, ErrDatabase.Wrap("Update", "uc.repo.GetByUsername", err)
+	}
+
+	if existing == nil {
+		return nil, ErrNotFound
+	}
+
+	if d.Password != "" {
+		existing.PasswordHash, err = hashPassword(d.Password)
+		if err != nil {
+			return nil, ErrNotValid.Wrap("Update", "hashPassword", err)
+		}
+	}
+
+	if d.MustChangePassword != nil {
+		existing.MustChangePassword = *d.MustChangePassword
+	}
+
+	if d.Locked != nil && !*d.Locked {
+		existing.FailedAttempts = 0
+		existing.LockedUntil = ""
+	}
+
+	if _, err := uc.repo.Update(ctx, existing); err != nil {
+		return nil, ErrDatabase.Wrap("Update", "uc.repo.Update", err)
+	}
+
+	return uc.entityToDTO(existing), nil
+}
+
+func (uc *UseCase) Delete(ctx context.Context, username, tenantID string) error {
+	isSuccessful, err := uc.repo.Delete(ctx, username, tenantID)
+	if err != nil {
+		return ErrDatabase.Wrap("Delete", "uc.repo.Delete", err)
+	}
+
+	if !isSuccessful {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// Authenticate checks a username and password. It returns ErrPasswordChangeRequired, along with the user,
+// when the password is right but has to be changed before the user may log in.
+func (uc *UseCase) Authenticate(ctx context.Context, username, password, tenantID string) (*dto.User, error) {
+	user, err := uc.verify(ctx, username, password, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.MustChangePassword {
+		return uc.entityToDTO(user), ErrPasswordChangeRequired
+	}
+
+	return uc.entityToDTO(user), nil
+}
+
+// ChangePassword replaces the password of a user who knows the current one and clears a forced change.
+func (uc *UseCase) ChangePassword(ctx context.Context, change dto.PasswordChange, tenantID string) error {
+	user, err := uc.verify(ctx, change.Username, change.Password, tenantID)
+	if err != nil {
+		return err
+	}
+
+	if change.NewPassword == change.Password {
+		return ErrNotValid.Wrap("ChangePassword", "change.NewPassword", errors.New("the new password must differ from the current one"))
+	}
+
+	user.PasswordHash, err = hashPassword(change.NewPassword)
+	if err != nil {
+		return ErrNotValid.Wrap("ChangePassword", "hashPassword", err)
+	}
+
+	user.MustChangePassword = false
+
+	if _, err := uc.repo.Update(ctx, user); err != nil {
+		return ErrDatabase.Wrap("ChangePassword", "uc.repo.Update", err)
+	}
+
+	return nil
+}
+
+// Bootstrap creates the configured admin account on first run, while there are no users yet. An admin
+// created with the shipped default password has to change it on first login.
+func (uc *UseCase) Bootstrap(ctx context.Context, username, password string) error {
+	count, err := uc.repo.GetCount(ctx, "")
+	if err != nil {
+		return ErrDatabase.Wrap("Bootstrap", "uc.repo.GetCount", err)
+	}
+
+	if count > 0 || username == "" {
+		return nil
+	}
+
+	mustChange := password == config.DefaultAdminPassword
+
+	if _, err := uc.Insert(ctx, &dto.User{
+		Username:           username,
+		Password:           password,
+		MustChangePassword: &mustChange,
+	}); err != nil {
+		return err
+	}
+
+	// the binding may already have been granted before the account existed
+	var notUniqueErr sqldb.NotUniqueError
+	if _, err := uc.roles.Insert(ctx, &dto.RoleBinding{Subject: username, Role: dto.RoleAdmin}); err != nil && !errors.As(err, &notUniqueErr) {
+		return err
+	}
+
+	uc.log.Info("users - Bootstrap - created admin account " + username)
+
+	return nil
+}
+
+// verify checks the password of a user, counting failures towards a lockout and clearing them on success.
+func (uc *UseCase) verify(ctx context.Context, username, password, tenantID string) (*entity.User, error) {
+	lock := uc.verifyLock(username, tenantID)
+
+	lock.Lock()
+	defer lock.Unlock()
+
+	user, err := uc.repo.GetByUsername(ctx, username, tenantID)
+	if err != nil {
+		return nil, ErrDatabase.Wrap("verify", "uc.repo.GetByUsername", err)
+	}
+
+	if user == nil {
+		dummyHashOnce.Do(func() {
+			dummyHash, _ = bcrypt.GenerateFromPassword([]byte("not a password"), bcrypt.DefaultCost)
+		})
+
+		_ = bcrypt.CompareHashAndPassword(dummyHash, []byte(password))
+
+		return nil, ErrInvalidCredentials
+	}
+
+	now := time.Now()
+
+	if lockedUntil := parseTime(user.LockedUntil); lockedUntil != nil && now.Before(*lockedUntil) {
+		return nil, ErrAccountLocked
+	}
+
+	if bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)) != nil {
+		user.FailedAttempts++
+		user.LockedUntil = ""
+
+		lockErr := ErrInvalidCredentials
+		if user.FailedAttempts >= uc.maxAttempts {
+			user.FailedAttempts = 0
+			user.LockedUntil = now.Add(uc.lockout).UTC().Format(time.RFC3339)
+			lockErr = ErrAccountLocked
+
+			uc.log.Warn("users - verify - account %s locked after %d failed logins", username, uc.maxAttempts)
+		}
+
+		if _, err := uc.repo.Update(ctx, user); err != nil {
+			return nil, ErrDatabase.Wrap("verify", "uc.repo.Update", err)
+		}
+
+		return nil, lockErr
+	}
+
+	if user.FailedAttempts > 0 || user.LockedUntil != "" {
+		user.FailedAttempts = 0
+		user.LockedUntil = ""
+
+		if _, err := uc.repo.Update(ctx, user); err != nil {
+			return nil, ErrDatabase.Wrap("verify", "uc.repo.Update", err)
+		}
+	}
+
+	return user, nil
+}
+
+func (uc *UseCase) entityToDTO(d *entity.User) *dto.User {
+	// parse creation date
+	creationDate, err := time.Parse(time.RFC3339, d.CreationDate)
+	if err != nil {
+		uc.log.Warn("users - entityToDTO - creation date %s could not be parsed", d.CreationDate)
+	}
+
+	lockedUntil := parseTime(d.LockedUntil)
+	mustChange := d.MustChangePassword
+	locked := lockedUntil != nil && time.Now().Before(*lockedUntil)
+
+	return &dto.User{
+		Username:           d.Username,
+		MustChangePassword: &mustChange,
+		Locked:             &locked,
+		LockedUntil:        lockedUntil,
+		CreationDate:       creationDate,
+		TenantID:           d.TenantID,
+	}
+}
+
+func (uc *UseCase) verifyLock(username, tenantID string) *sync.Mutex {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(tenantID + "/" + username))
+
+	return &uc.verifying[h.Sum32()%verifyLocks]
+}
+
+func hashPassword(password string) (string, error) {
+	if len(password) < minPasswordLength {
+		return "", errors.New("password must be at least 8 characters")
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+
+	return string(hash), nil
+}
+
+func parseTime(s string) *time.Time {
+	if s == "" {
+		return nil
+	}
+
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return nil
+	}
+
+	return &t
+}
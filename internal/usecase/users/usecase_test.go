@@ -0,0 +1,300 @@
+package users_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/open-amt-cloud-toolkit/console/config"
+	"github.com/open-amt-cloud-toolkit/console/internal/entity"
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	"github.com/open-amt-cloud-toolkit/console/internal/mocks"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/users"
+	"github.com/open-amt-cloud-toolkit/console/pkg/logger"
+)
+
+func usersTest(t *testing.T) (*users.UseCase, *mocks.MockUsersRepository, *mocks.MockUsersRoles) {
+	t.Helper()
+
+	mockCtl := gomock.NewController(t)
+
+	repo := mocks.NewMockUsersRepository(mockCtl)
+	roles := mocks.NewMockUsersRoles(mockCtl)
+	log := logger.New("error")
+	useCase := users.New(repo, roles, log, 3, time.Minute)
+
+	return useCase, repo, roles
+}
+
+func hash(t *testing.T, password string) string {
+	t.Helper()
+
+	h, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.MinCost)
+	require.NoError(t, err)
+
+	return string(h)
+}
+
+func TestAuthenticate(t *testing.T) {
+	t.Parallel()
+
+	passwordHash := hash(t, "correct horse")
+	future := time.Now().Add(time.Minute).UTC().Format(time.RFC3339)
+	past := time.Now().Add(-time.Minute).UTC().Format(time.RFC3339)
+
+	tests := []struct {
+		name     string
+		password string
+		user     *entity.User
+		updated  *entity.User
+		err      error
+	}{
+		{
+			name:     "valid password",
+			password: "correct horse",
+			user:     &entity.User{Username: "alice", PasswordHash: passwordHash},
+		},
+		{
+			name:     "valid password clears failures and an expired lock",
+			password: "correct horse",
+			user:     &entity.User{Username: "alice", PasswordHash: passwordHash, FailedAttempts: 2, LockedUntil: past},
+			updated:  &entity.User{Username: "alice", PasswordHash: passwordHash},
+		},
+		{
+			name:     "wrong password counts a failure",
+			password: "wrong",
+			user:     &entity.User{Username: "alice", PasswordHash: passwordHash, FailedAttempts: 1},
+			updated:  &entity.User{Username: "alice", PasswordHash: passwordHash, FailedAttempts: 2},
+			err:      users.ErrInvalidCredentials,
+		},
+		{
+			name:     "last allowed failure locks the account",
+			password: "wrong",
+			user:     &entity.User{Username: "alice", PasswordHash: passwordHash, FailedAttempts: 2},
+			err:      users.ErrAccountLocked,
+		},
+		{
+			name:     "locked account rejects even the right password",
+			password: "correct horse",
+			user:     &entity.User{Username: "alice", PasswordHash: passwordHash, LockedUntil: future},
+			err:      users.ErrAccountLocked,
+		},
+		{
+			name:     "unknown user",
+			password: "correct horse",
+			err:      users.ErrInvalidCredentials,
+		},
+		{
+			name:     "forced password change",
+			password: "correct horse",
+			user:     &entity.User{Username: "alice", PasswordHash: passwordHash, MustChangePassword: true},
+			err:      users.ErrPasswordChangeRequired,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			useCase, repo, _ := usersTest(t)
+
+			repo.EXPECT().GetByUsername(context.Background(), "alice", "").Return(tc.user, nil)
+
+			if tc.updated != nil {
+				repo.EXPECT().Update(context.Background(), tc.updated).Return(true, nil)
+			}
+
+			if tc.name == "last allowed failure locks the account" {
+				repo.EXPECT().Update(context.Background(), gomock.Any()).DoAndReturn(func(_ context.Context, u *entity.User) (bool, error) {
+					require.Equal(t, 0, u.FailedAttempts)
+					require.NotEmpty(t, u.LockedUntil)
+
+					return true, nil
+				})
+			}
+
+			res, err := useCase.Authenticate(context.Background(), "alice", tc.password, "")
+			require.ErrorIs(t, err, tc.err)
+
+			if tc.err == nil {
+				require.Equal(t, "alice", res.Username)
+			}
+		})
+	}
+}
+
+func TestAuthenticateConcurrentFailures(t *testing.T) {
+	t.Parallel()
+
+	useCase, repo, _ := usersTest(t)
+
+	var mu sync.Mutex
+
+	stored := entity.User{Username: "alice", PasswordHash: hash(t, "correct horse")}
+
+	repo.EXPECT().GetByUsername(gomock.Any(), "alice", "").DoAndReturn(func(_ context.Context, _, _ string) (*entity.User, error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		u := stored
+
+		return &u, nil
+	}).AnyTimes()
+	repo.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, u *entity.User) (bool, error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		stored = *u
+
+		return true, nil
+	}).AnyTimes()
+
+	errs := make([]error, 6)
+
+	var wg sync.WaitGroup
+
+	for i := range errs {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+
+			_, errs[i] = useCase.Authenticate(context.Background(), "alice", "wrong", "")
+		}(i)
+	}
+
+	wg.Wait()
+
+	// every failure is counted, so the third one locks the account and the rest find it locked
+	invalid := 0
+
+	for _, err := range errs {
+		if errors.Is(err, users.ErrInvalidCredentials) {
+			invalid++
+		} else {
+			require.ErrorIs(t, err, users.ErrAccountLocked)
+		}
+	}
+
+	require.Equal(t, 2, invalid)
+	require.NotEmpty(t, stored.LockedUntil)
+}
+
+func TestChangePassword(t *testing.T) {
+	t.Parallel()
+
+	useCase, repo, _ := usersTest(t)
+
+	user := &entity.User{Username: "alice", PasswordHash: hash(t, "G@ppm0ym"), MustChangePassword: true}
+
+	repo.EXPECT().GetByUsername(context.Background(), "alice", "").Return(user, nil).Times(2)
+	repo.EXPECT().Update(context.Background(), gomock.Any()).DoAndReturn(func(_ context.Context, u *entity.User) (bool, error) {
+		require.False(t, u.MustChangePassword)
+		require.NoError(t, bcrypt.CompareHashAndPassword([]byte(u.PasswordHash), []byte("Str0ngP@ssword")))
+
+		return true, nil
+	})
+
+	err := useCase.ChangePassword(context.Background(), dto.PasswordChange{Username: "alice", Password: "G@ppm0ym", NewPassword: "G@ppm0ym"}, "")
+	require.IsType(t, users.ErrNotValid, err)
+
+	err = useCase.ChangePassword(context.Background(), dto.PasswordChange{Username: "alice", Password: "G@ppm0ym", NewPassword: "Str0ngP@ssword"}, "")
+	require.NoError(t, err)
+}
+
+func TestInsertUser(t *testing.T) {
+	t.Parallel()
+
+	useCase, repo, _ := usersTest(t)
+
+	_, err := useCase.Insert(context.Background(), &dto.User{Username: "bob", Password: "short"})
+	require.IsType(t, users.ErrNotValid, err)
+
+	repo.EXPECT().Insert(context.Background(), gomock.Any()).DoAndReturn(func(_ context.Context, u *entity.User) error {
+		require.NotEqual(t, "Str0ngP@ssword", u.PasswordHash)
+		require.NoError(t, bcrypt.CompareHashAndPassword([]byte(u.PasswordHash), []byte("Str0ngP@ssword")))
+
+		return nil
+	})
+
+	res, err := useCase.Insert(context.Background(), &dto.User{Username: "bob", Password: "Str0ngP@ssword"})
+	require.NoError(t, err)
+	require.Empty(t, res.Password)
+}
+
+func TestUpdateUnlocks(t *testing.T) {
+	t.Parallel()
+
+	useCase, repo, _ := usersTest(t)
+
+	lockedUntil := time.Now().Add(time.Minute).UTC().Format(time.RFC3339)
+	mustChange, unlocked := true, false
+
+	repo.EXPECT().GetByUsername(context.Background(), "alice", "").Return(&entity.User{Username: "alice", PasswordHash: "hash", FailedAttempts: 2, LockedUntil: lockedUntil}, nil)
+	repo.EXPECT().Update(context.Background(), &entity.User{Username: "alice", PasswordHash: "hash", MustChangePassword: true}).Return(true, nil)
+
+	res, err := useCase.Update(context.Background(), &dto.User{Username: "alice", MustChangePassword: &mustChange, Locked: &unlocked})
+	require.NoError(t, err)
+	require.False(t, *res.Locked)
+
+	// an update that leaves the flags out keeps the lock and the forced password change
+	repo.EXPECT().GetByUsername(context.Background(), "bob", "").Return(&entity.User{Username: "bob", PasswordHash: "hash", FailedAttempts: 2, LockedUntil: lockedUntil, MustChangePassword: true}, nil)
+	repo.EXPECT().Update(context.Background(), &entity.User{Username: "bob", PasswordHash: "hash", FailedAttempts: 2, LockedUntil: lockedUntil, MustChangePassword: true}).Return(true, nil)
+
+	res, err = useCase.Update(context.Background(), &dto.User{Username: "bob"})
+	require.NoError(t, err)
+	require.True(t, *res.Locked)
+	require.True(t, *res.MustChangePassword)
+
+	repo.EXPECT().GetByUsername(context.Background(), "carol", "").Return(nil, nil)
+
+	_, err = useCase.Update(context.Background(), &dto.User{Username: "carol"})
+	require.IsType(t, users.ErrNotFound, err)
+}
+
+func TestBootstrap(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		count      int
+		password   string
+		mustChange bool
+	}{
+		{name: "default password must be changed", password: config.DefaultAdminPassword, mustChange: true},
+		{name: "custom password", password: "Str0ngP@ssword"},
+		{name: "users already exist", count: 1},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			useCase, repo, roles := usersTest(t)
+
+			repo.EXPECT().GetCount(context.Background(), "").Return(tc.count, nil)
+
+			if tc.count == 0 {
+				repo.EXPECT().Insert(context.Background(), gomock.Any()).DoAndReturn(func(_ context.Context, u *entity.User) error {
+					require.Equal(t, "standalone", u.Username)
+					require.Equal(t, tc.mustChange, u.MustChangePassword)
+
+					return nil
+				})
+				roles.EXPECT().Insert(context.Background(), &dto.RoleBinding{Subject: "standalone", Role: dto.RoleAdmin}).Return(nil, nil)
+			}
+
+			require.NoError(t, useCase.Bootstrap(context.Background(), "standalone", tc.password))
+		})
+	}
+}
@@ -0,0 +1,377 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity"
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+)
+
+const (
+	pollInterval   = 5 * time.Second
+	maxConcurrency = 10
+	maxErrorLength = 512
+)
+
+// Headers sent with every delivery. The signature is the hex HMAC-SHA256 of "<timestamp>.<body>"
+// keyed with the webhook's secret, receivers should also reject stale timestamps to stop replays.
+const (
+	HeaderEvent     = "X-Console-Event"
+	HeaderEventID   = "X-Console-Event-Id"
+	HeaderDelivery  = "X-Console-Delivery"
+	HeaderTimestamp = "X-Console-Timestamp"
+	HeaderSignature = "X-Console-Signature"
+)
+
+// Run queues the deliveries of every event as it is published and sends them, and the pending retries, until
+// ctx is done, up to maxConcurrency at once. Events never wait on the sending: Dispatch writes their deliveries
+// to the database from the broker, so none is lost to slow receivers or a restart, and wakes Run to send them.
+func (uc *UseCase) Run(ctx context.Context) {
+	stop := uc.events.OnPublish(uc.Dispatch)
+	defer stop()
+
+	due := make(chan entity.WebhookDelivery)
+
+	var wg sync.WaitGroup
+
+	defer wg.Wait()
+
+	for i := 0; i < maxConcurrency; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for d := range due {
+				uc.send(ctx, &d, time.Now())
+			}
+		}()
+	}
+
+	defer close(due)
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		deliveries, err := uc.repo.GetDueDeliveries(ctx, formatTime(time.Now()))
+		if err != nil {
+			uc.log.Error(err, "webhooks - Run - uc.repo.GetDueDeliveries")
+		}
+
+		for i := range deliveries {
+			select {
+			case <-ctx.Done():
+				return
+			case due <- deliveries[i]:
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-uc.wake:
+		}
+	}
+}
+
+// Dispatch queues a delivery of e for every enabled webhook of its tenant subscribed to its type. It runs as e
+// is published, the deliveries are sent by Run.
+func (uc *UseCase) Dispatch(ctx context.Context, e dto.Event) {
+	webhooks, err := uc.repo.GetEnabled(ctx, e.TenantID)
+	if err != nil {
+		uc.log.Error(err, "webhooks - Dispatch - uc.repo.GetEnabled")
+
+		return
+	}
+
+	var targets []entity.Webhook
+
+	for i := range webhooks {
+		if subscribed(&webhooks[i], e.Type) {
+			targets = append(targets, webhooks[i])
+		}
+	}
+
+	if len(targets) == 0 {
+		return
+	}
+
+	// the broker's ids restart with the process, receivers get one that is safe to deduplicate on
+	e.ID = uuid.New().String()
+
+	payload, err := json.Marshal(e)
+	if err != nil {
+		uc.log.Error(err, "webhooks - Dispatch - json.Marshal")
+
+		return
+	}
+
+	now := formatTime(time.Now())
+
+	for i := range targets {
+		d := &entity.WebhookDelivery{
+			ID:        uuid.New().String(),
+			WebhookID: targets[i].ID,
+			EventID:   e.ID,
+			EventType: e.Type,
+			Payload:   string(payload),
+			Attempt:   1,
+			Status:    dto.DeliveryStatusQueued,
+			RetryAt:   now,
+			CreatedAt: now,
+			TenantID:  targets[i].TenantID,
+		}
+
+		if err := uc.repo.InsertDelivery(ctx, d); err != nil {
+			uc.log.Error(err, "webhooks - Dispatch - uc.repo.InsertDelivery")
+		}
+	}
+
+	select {
+	case uc.wake <- struct{}{}:
+	default:
+	}
+}
+
+// ProcessDue sends every delivery that is queued or whose retry is due at now.
+func (uc *UseCase) ProcessDue(ctx context.Context, now time.Time) {
+	deliveries, err := uc.repo.GetDueDeliveries(ctx, formatTime(now))
+	if err != nil {
+		uc.log.Error(err, "webhooks - ProcessDue - uc.repo.GetDueDeliveries")
+
+		return
+	}
+
+	for i := range deliveries {
+		uc.send(ctx, &deliveries[i], now)
+	}
+}
+
+// send makes the next attempt of a due delivery. The delivery is claimed first, so that of the consoles sharing
+// a database only one sends it and a slow receiver is not sent it again on the next poll.
+func (uc *UseCase) send(ctx context.Context, d *entity.WebhookDelivery, now time.Time) {
+	if d.Status == dto.DeliveryStatusQueued {
+		uc.sendQueued(ctx, d, now)
+
+		return
+	}
+
+	uc.retry(ctx, d, now)
+}
+
+// sendQueued makes the first attempt of a delivery and records its outcome on it. A console stopped while
+// sending leaves it as sending.
+func (uc *UseCase) sendQueued(ctx context.Context, d *entity.WebhookDelivery, now time.Time) {
+	claimed, err := uc.repo.UpdateDeliveryStatus(ctx, d.ID, d.TenantID, dto.DeliveryStatusQueued, dto.DeliveryStatusSending)
+	if err != nil {
+		uc.log.Error(err, "webhooks - sendQueued - uc.repo.UpdateDeliveryStatus")
+
+		return
+	}
+
+	if !claimed {
+		uc.log.Debug("webhooks - sendQueued - delivery " + d.ID + " was claimed elsewhere")
+
+		return
+	}
+
+	w, err := uc.repo.GetByID(ctx, d.WebhookID, d.TenantID)
+	if err != nil {
+		uc.log.Error(err, "webhooks - sendQueued - uc.repo.GetByID")
+
+		return
+	}
+
+	if w == nil || !w.Enabled {
+		d.Status = dto.DeliveryStatusFailed
+		d.Error = "webhook was disabled"
+		d.RetryAt = ""
+	} else {
+		uc.attempt(ctx, w, d, uc.cfg.MaxAttempts, now)
+	}
+
+	if _, err := uc.repo.UpdateDelivery(ctx, d); err != nil {
+		uc.log.Error(err, "webhooks - sendQueued - uc.repo.UpdateDelivery")
+	}
+}
+
+func (uc *UseCase) retry(ctx context.Context, previous *entity.WebhookDelivery, now time.Time) {
+	claimed, err := uc.repo.UpdateDeliveryStatus(ctx, previous.ID, previous.TenantID, dto.DeliveryStatusRetryPending, dto.DeliveryStatusRetried)
+	if err != nil {
+		uc.log.Error(err, "webhooks - retry - uc.repo.UpdateDeliveryStatus")
+
+		return
+	}
+
+	if !claimed {
+		uc.log.Debug("webhooks - retry - delivery " + previous.ID + " was claimed elsewhere")
+
+		return
+	}
+
+	w, err := uc.repo.GetByID(ctx, previous.WebhookID, previous.TenantID)
+	if err != nil {
+		uc.log.Error(err, "webhooks - retry - uc.repo.GetByID")
+
+		return
+	}
+
+	if w == nil || !w.Enabled {
+		return
+	}
+
+	uc.deliver(ctx, w, &entity.WebhookDelivery{
+		WebhookID: previous.WebhookID,
+		EventID:   previous.EventID,
+		EventType: previous.EventType,
+		Payload:   previous.Payload,
+		Attempt:   previous.Attempt + 1,
+		TenantID:  previous.TenantID,
+	}, uc.cfg.MaxAttempts, now)
+}
+
+// Test sends a ping event to a webhook and returns the outcome, a failed ping is not retried.
+func (uc *UseCase) Test(ctx context.Context, id, tenantID string) (*dto.WebhookDelivery, error) {
+	w, err := uc.repo.GetByID(ctx, id, tenantID)
+	if err != nil {
+		return nil, ErrDatabase.Wrap("Test", "uc.repo.GetByID", err)
+	}
+
+	if w == nil {
+		return nil, ErrNotFound
+	}
+
+	e := dto.Event{
+		ID:   uuid.New().String(),
+		Type: dto.EventWebhookPing,
+		Tags: []string{},
+		Time: time.Now().UTC(),
+	}
+
+	payload, err := json.Marshal(e)
+	if err != nil {
+		return nil, ErrWebhooksUseCase.Wrap("Test", "json.Marshal", err)
+	}
+
+	delivery := &entity.WebhookDelivery{
+		WebhookID: w.ID,
+		EventID:   e.ID,
+		EventType: e.Type,
+		Payload:   string(payload),
+		Attempt:   1,
+		TenantID:  w.TenantID,
+	}
+
+	uc.deliver(ctx, w, delivery, 1, time.Now())
+
+	return deliveryToDTO(delivery), nil
+}
+
+// deliver makes an attempt that is recorded as a delivery of its own.
+func (uc *UseCase) deliver(ctx context.Context, w *entity.Webhook, d *entity.WebhookDelivery, maxAttempts int, now time.Time) {
+	d.ID = uuid.New().String()
+	d.CreatedAt = formatTime(now)
+
+	uc.attempt(ctx, w, d, maxAttempts, now)
+
+	if err := uc.repo.InsertDelivery(ctx, d); err != nil {
+		uc.log.Error(err, "webhooks - deliver - uc.repo.InsertDelivery")
+	}
+}
+
+// attempt posts the payload to the webhook and sets the outcome on d. A failed attempt is scheduled
+// again after an exponential backoff until maxAttempts is reached.
+func (uc *UseCase) attempt(ctx context.Context, w *entity.Webhook, d *entity.WebhookDelivery, maxAttempts int, now time.Time) {
+	d.Status = dto.DeliveryStatusSuccess
+	d.RetryAt = ""
+
+	statusCode, err := uc.post(ctx, w, d)
+
+	d.StatusCode = statusCode
+
+	if err != nil {
+		d.Error = truncate(err.Error())
+	}
+
+	if err != nil || statusCode < http.StatusOK || statusCode >= http.StatusMultipleChoices {
+		d.Status = dto.DeliveryStatusFailed
+
+		if d.Attempt < maxAttempts {
+			d.Status = dto.DeliveryStatusRetryPending
+			d.RetryAt = formatTime(now.Add(uc.cfg.RetryBackoff << (d.Attempt - 1)))
+		}
+	}
+}
+
+func (uc *UseCase) post(ctx context.Context, w *entity.Webhook, d *entity.WebhookDelivery) (int, error) {
+	secret, err := uc.safeRequirements.Decrypt(w.Secret)
+	if err != nil {
+		return 0, err
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.URL, bytes.NewBufferString(d.Payload))
+	if err != nil {
+		return 0, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "console-webhooks")
+	req.Header.Set(HeaderEvent, d.EventType)
+	req.Header.Set(HeaderEventID, d.EventID)
+	req.Header.Set(HeaderDelivery, d.ID)
+	req.Header.Set(HeaderTimestamp, timestamp)
+	req.Header.Set(HeaderSignature, "sha256="+Sign(secret, timestamp, []byte(d.Payload)))
+
+	resp, err := uc.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+
+	defer resp.Body.Close()
+
+	return resp.StatusCode, nil
+}
+
+// Sign returns the hex encoded signature a receiver expects in the X-Console-Signature header.
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func subscribed(w *entity.Webhook, eventType string) bool {
+	for _, t := range strings.Split(w.EventTypes, ",") {
+		if t == dto.EventAll || t == eventType {
+			return true
+		}
+	}
+
+	return false
+}
+
+func truncate(s string) string {
+	if len(s) > maxErrorLength {
+		return s[:maxErrorLength]
+	}
+
+	return s
+}
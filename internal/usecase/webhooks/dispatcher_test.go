@@ -0,0 +1,474 @@
+package webhooks_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity"
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	"github.com/open-amt-cloud-toolkit/console/internal/mocks"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/webhooks"
+	"github.com/open-amt-cloud-toolkit/console/pkg/logger"
+)
+
+// receiver is a local stand-in for a webhook endpoint that answers with the next queued status.
+type receiver struct {
+	mu       sync.Mutex
+	statuses []int
+	requests []*http.Request
+	bodies   [][]byte
+}
+
+func (rc *receiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	rc.requests = append(rc.requests, r)
+	rc.bodies = append(rc.bodies, body)
+
+	status := http.StatusOK
+	if len(rc.statuses) > 0 {
+		status = rc.statuses[0]
+		rc.statuses = rc.statuses[1:]
+	}
+
+	w.WriteHeader(status)
+}
+
+func (rc *receiver) count() int {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	return len(rc.requests)
+}
+
+// outbox stands in for the webhook_deliveries table.
+type outbox struct {
+	mu         sync.Mutex
+	deliveries []*entity.WebhookDelivery
+}
+
+func newOutbox(repo *mocks.MockWebhooksRepository) *outbox {
+	o := &outbox{}
+
+	repo.EXPECT().InsertDelivery(gomock.Any(), gomock.Any()).DoAndReturn(o.insert).AnyTimes()
+	repo.EXPECT().GetDueDeliveries(gomock.Any(), gomock.Any()).DoAndReturn(o.due).AnyTimes()
+	repo.EXPECT().UpdateDeliveryStatus(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(o.updateStatus).AnyTimes()
+	repo.EXPECT().UpdateDelivery(gomock.Any(), gomock.Any()).DoAndReturn(o.update).AnyTimes()
+
+	return o
+}
+
+func (o *outbox) insert(_ context.Context, d *entity.WebhookDelivery) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	saved := *d
+	o.deliveries = append(o.deliveries, &saved)
+
+	return nil
+}
+
+func (o *outbox) due(_ context.Context, now string) ([]entity.WebhookDelivery, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	var due []entity.WebhookDelivery
+
+	for _, d := range o.deliveries {
+		if (d.Status == dto.DeliveryStatusQueued || d.Status == dto.DeliveryStatusRetryPending) && d.RetryAt <= now {
+			due = append(due, *d)
+		}
+	}
+
+	return due, nil
+}
+
+func (o *outbox) updateStatus(_ context.Context, id, _, from, to string) (bool, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	for _, d := range o.deliveries {
+		if d.ID == id && d.Status == from {
+			d.Status = to
+
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (o *outbox) update(_ context.Context, d *entity.WebhookDelivery) (bool, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	for i := range o.deliveries {
+		if o.deliveries[i].ID == d.ID {
+			saved := *d
+			o.deliveries[i] = &saved
+
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (o *outbox) all() []entity.WebhookDelivery {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	all := make([]entity.WebhookDelivery, len(o.deliveries))
+	for i, d := range o.deliveries {
+		all[i] = *d
+	}
+
+	return all
+}
+
+func TestDispatch(t *testing.T) {
+	t.Parallel()
+
+	rc := &receiver{}
+	server := httptest.NewServer(rc)
+	defer server.Close()
+
+	useCase, repo := webhooksTest(t)
+	deliveries := newOutbox(repo)
+
+	hook := &entity.Webhook{ID: "hook-1", URL: server.URL, Secret: "encrypted", EventTypes: "device.power", Enabled: true, TenantID: "tenant1"}
+
+	repo.EXPECT().GetEnabled(context.Background(), "tenant1").Return([]entity.Webhook{
+		*hook,
+		{ID: "hook-2", URL: server.URL, Secret: "encrypted", EventTypes: "device.deleted", Enabled: true, TenantID: "tenant1"},
+	}, nil)
+
+	useCase.Dispatch(context.Background(), dto.Event{
+		ID:       "1",
+		Type:     dto.EventPowerAction,
+		GUID:     "guid-1",
+		TenantID: "tenant1",
+		Tags:     []string{},
+		Data:     dto.PowerActionEvent{Action: 8},
+	})
+
+	// the delivery is queued, not sent, while the event is published
+	queued := deliveries.all()
+	require.Len(t, queued, 1)
+	require.Equal(t, dto.DeliveryStatusQueued, queued[0].Status)
+	require.Equal(t, "hook-1", queued[0].WebhookID)
+	require.Empty(t, rc.requests)
+
+	repo.EXPECT().GetByID(context.Background(), "hook-1", "tenant1").Return(hook, nil)
+
+	useCase.ProcessDue(context.Background(), time.Now())
+
+	require.Len(t, rc.requests, 1)
+
+	delivery := deliveries.all()[0]
+	req := rc.requests[0]
+	timestamp := req.Header.Get(webhooks.HeaderTimestamp)
+
+	require.Equal(t, dto.EventPowerAction, req.Header.Get(webhooks.HeaderEvent))
+	require.Equal(t, delivery.ID, req.Header.Get(webhooks.HeaderDelivery))
+	require.Equal(t, delivery.EventID, req.Header.Get(webhooks.HeaderEventID))
+	// MockCrypto decrypts every secret to "decrypted"
+	require.Equal(t, "sha256="+webhooks.Sign("decrypted", timestamp, rc.bodies[0]), req.Header.Get(webhooks.HeaderSignature))
+
+	e := dto.Event{}
+	require.NoError(t, json.Unmarshal(rc.bodies[0], &e))
+	require.Equal(t, delivery.EventID, e.ID)
+	require.Equal(t, "guid-1", e.GUID)
+
+	require.Equal(t, 1, delivery.Attempt)
+	require.Equal(t, dto.DeliveryStatusSuccess, delivery.Status)
+	require.Equal(t, http.StatusOK, delivery.StatusCode)
+	require.Empty(t, delivery.RetryAt)
+
+	// once sent it is not due again
+	useCase.ProcessDue(context.Background(), time.Now().Add(time.Hour))
+
+	require.Len(t, rc.requests, 1)
+}
+
+func TestDispatchRetries(t *testing.T) {
+	t.Parallel()
+
+	rc := &receiver{statuses: []int{http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable}}
+	server := httptest.NewServer(rc)
+	defer server.Close()
+
+	useCase, repo := webhooksTest(t)
+	deliveries := newOutbox(repo)
+
+	hook := &entity.Webhook{ID: "hook-1", URL: server.URL, Secret: "encrypted", EventTypes: "*", Enabled: true, TenantID: "tenant1"}
+
+	repo.EXPECT().GetEnabled(context.Background(), "tenant1").Return([]entity.Webhook{*hook}, nil)
+	repo.EXPECT().GetByID(context.Background(), "hook-1", "tenant1").Return(hook, nil).Times(3)
+
+	useCase.Dispatch(context.Background(), dto.Event{Type: dto.EventDeviceDeleted, GUID: "guid-1", TenantID: "tenant1"})
+
+	now := time.Now().UTC().Truncate(time.Second)
+
+	useCase.ProcessDue(context.Background(), now)
+
+	all := deliveries.all()
+	require.Len(t, all, 1)
+	require.Equal(t, dto.DeliveryStatusRetryPending, all[0].Status)
+	require.Equal(t, http.StatusInternalServerError, all[0].StatusCode)
+	// the backoff doubles with every attempt
+	require.Equal(t, now.Add(time.Minute).Format(time.RFC3339), all[0].RetryAt)
+
+	// not due yet
+	useCase.ProcessDue(context.Background(), now.Add(time.Second))
+	require.Len(t, deliveries.all(), 1)
+
+	for attempt := 2; attempt <= 3; attempt++ {
+		later := now.Add(time.Hour)
+
+		useCase.ProcessDue(context.Background(), later)
+
+		all = deliveries.all()
+		require.Len(t, all, attempt)
+		require.Equal(t, dto.DeliveryStatusRetried, all[attempt-2].Status)
+		require.Equal(t, attempt, all[attempt-1].Attempt)
+		require.Equal(t, all[0].EventID, all[attempt-1].EventID)
+		require.Equal(t, all[0].Payload, all[attempt-1].Payload)
+
+		now = later
+	}
+
+	require.Equal(t, now.Add(-time.Hour).Add(2*time.Minute).Format(time.RFC3339), all[1].RetryAt)
+	require.Equal(t, dto.DeliveryStatusFailed, all[2].Status)
+	require.Empty(t, all[2].RetryAt)
+	require.Len(t, rc.requests, 3)
+}
+
+func TestProcessDue(t *testing.T) {
+	t.Parallel()
+
+	rc := &receiver{}
+	server := httptest.NewServer(rc)
+	defer server.Close()
+
+	queued := entity.WebhookDelivery{ID: "delivery-1", WebhookID: "hook-1", EventID: "event-1", EventType: dto.EventDeviceAdded, Payload: "{}", Attempt: 1, Status: dto.DeliveryStatusQueued, TenantID: "tenant1"}
+
+	tests := []struct {
+		name     string
+		mock     func(repo *mocks.MockWebhooksRepository)
+		requests int
+	}{
+		{
+			name: "claimed elsewhere",
+			mock: func(repo *mocks.MockWebhooksRepository) {
+				repo.EXPECT().UpdateDeliveryStatus(context.Background(), "delivery-1", "tenant1", dto.DeliveryStatusQueued, dto.DeliveryStatusSending).Return(false, nil)
+			},
+		},
+		{
+			name: "webhook disabled since",
+			mock: func(repo *mocks.MockWebhooksRepository) {
+				repo.EXPECT().UpdateDeliveryStatus(context.Background(), "delivery-1", "tenant1", dto.DeliveryStatusQueued, dto.DeliveryStatusSending).Return(true, nil)
+				repo.EXPECT().GetByID(context.Background(), "hook-1", "tenant1").Return(&entity.Webhook{ID: "hook-1", URL: server.URL, TenantID: "tenant1"}, nil)
+				repo.EXPECT().UpdateDelivery(context.Background(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, d *entity.WebhookDelivery) (bool, error) {
+						require.Equal(t, dto.DeliveryStatusFailed, d.Status)
+
+						return true, nil
+					})
+			},
+		},
+		{
+			name: "sent",
+			mock: func(repo *mocks.MockWebhooksRepository) {
+				repo.EXPECT().UpdateDeliveryStatus(context.Background(), "delivery-1", "tenant1", dto.DeliveryStatusQueued, dto.DeliveryStatusSending).Return(true, nil)
+				repo.EXPECT().GetByID(context.Background(), "hook-1", "tenant1").Return(&entity.Webhook{ID: "hook-1", URL: server.URL, Enabled: true, TenantID: "tenant1"}, nil)
+				repo.EXPECT().UpdateDelivery(context.Background(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, d *entity.WebhookDelivery) (bool, error) {
+						require.Equal(t, dto.DeliveryStatusSuccess, d.Status)
+
+						return true, nil
+					})
+			},
+			requests: 1,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			useCase, repo := webhooksTest(t)
+
+			before := rc.count()
+
+			repo.EXPECT().GetDueDeliveries(context.Background(), gomock.Any()).Return([]entity.WebhookDelivery{queued}, nil)
+			tc.mock(repo)
+
+			useCase.ProcessDue(context.Background(), time.Now())
+
+			require.Equal(t, before+tc.requests, rc.count())
+		})
+	}
+}
+
+func TestTest(t *testing.T) {
+	t.Parallel()
+
+	rc := &receiver{statuses: []int{http.StatusNotFound}}
+	server := httptest.NewServer(rc)
+	defer server.Close()
+
+	useCase, repo := webhooksTest(t)
+
+	repo.EXPECT().GetByID(context.Background(), "hook-1", "tenant1").
+		Return(&entity.Webhook{ID: "hook-1", URL: server.URL, Secret: "encrypted", EventTypes: "device.power", TenantID: "tenant1"}, nil)
+	repo.EXPECT().InsertDelivery(context.Background(), gomock.Any()).Return(nil)
+
+	res, err := useCase.Test(context.Background(), "hook-1", "tenant1")
+	require.NoError(t, err)
+	require.Equal(t, dto.EventWebhookPing, res.EventType)
+	require.Equal(t, dto.DeliveryStatusFailed, res.Status)
+	require.Equal(t, http.StatusNotFound, res.StatusCode)
+	require.Nil(t, res.RetryAt)
+	require.Len(t, rc.requests, 1)
+	require.Equal(t, dto.EventWebhookPing, rc.requests[0].Header.Get(webhooks.HeaderEvent))
+}
+
+func TestRunDeliversPublishedEvents(t *testing.T) {
+	t.Parallel()
+
+	mockCtl := gomock.NewController(t)
+
+	rc := &receiver{}
+	server := httptest.NewServer(rc)
+	defer server.Close()
+
+	repo := mocks.NewMockWebhooksRepository(mockCtl)
+	events := mocks.NewMockWebhooksEvents(mockCtl)
+	useCase := webhooks.New(repo, events, logger.New("error"), mocks.MockCrypto{}, webhooks.Config{MaxAttempts: 1})
+
+	var publish func(ctx context.Context, e dto.Event)
+
+	registered := make(chan struct{})
+
+	events.EXPECT().OnPublish(gomock.Any()).DoAndReturn(func(fn func(ctx context.Context, e dto.Event)) func() {
+		publish = fn
+		close(registered)
+
+		return func() {}
+	})
+
+	deliveries := newOutbox(repo)
+
+	hook := &entity.Webhook{ID: "hook-1", URL: server.URL, EventTypes: "*", Enabled: true, TenantID: "tenant2"}
+
+	repo.EXPECT().GetEnabled(gomock.Any(), "tenant2").Return([]entity.Webhook{*hook}, nil).AnyTimes()
+	repo.EXPECT().GetByID(gomock.Any(), "hook-1", "tenant2").Return(hook, nil).AnyTimes()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	go func() {
+		useCase.Run(ctx)
+		close(done)
+	}()
+
+	<-registered
+
+	// far more events than the broker buffers for a subscriber, published faster than they are sent
+	const published = 100
+
+	for i := 0; i < published; i++ {
+		publish(context.Background(), dto.Event{Type: dto.EventDeviceAdded, GUID: "guid-1", TenantID: "tenant2"})
+	}
+
+	require.Eventually(t, func() bool {
+		all := deliveries.all()
+		for i := range all {
+			if all[i].Status != dto.DeliveryStatusSuccess {
+				return false
+			}
+		}
+
+		return len(all) == published
+	}, 5*time.Second, 10*time.Millisecond)
+
+	cancel()
+	<-done
+
+	require.Equal(t, published, rc.count())
+}
+
+func TestRunDeliversPastASlowReceiver(t *testing.T) {
+	t.Parallel()
+
+	mockCtl := gomock.NewController(t)
+
+	release := make(chan struct{})
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		<-release
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	defer slow.Close()
+	defer close(release)
+
+	rc := &receiver{}
+	server := httptest.NewServer(rc)
+	defer server.Close()
+
+	repo := mocks.NewMockWebhooksRepository(mockCtl)
+	events := mocks.NewMockWebhooksEvents(mockCtl)
+	useCase := webhooks.New(repo, events, logger.New("error"), mocks.MockCrypto{}, webhooks.Config{MaxAttempts: 1})
+
+	var publish func(ctx context.Context, e dto.Event)
+
+	registered := make(chan struct{})
+
+	events.EXPECT().OnPublish(gomock.Any()).DoAndReturn(func(fn func(ctx context.Context, e dto.Event)) func() {
+		publish = fn
+		close(registered)
+
+		return func() {}
+	})
+
+	newOutbox(repo)
+
+	slowHook := &entity.Webhook{ID: "hook-1", URL: slow.URL, EventTypes: "*", Enabled: true, TenantID: "tenant1"}
+	hook := &entity.Webhook{ID: "hook-2", URL: server.URL, EventTypes: "*", Enabled: true, TenantID: "tenant2"}
+
+	repo.EXPECT().GetEnabled(gomock.Any(), "tenant1").Return([]entity.Webhook{*slowHook}, nil)
+	repo.EXPECT().GetEnabled(gomock.Any(), "tenant2").Return([]entity.Webhook{*hook}, nil)
+	repo.EXPECT().GetByID(gomock.Any(), "hook-1", "tenant1").Return(slowHook, nil)
+	repo.EXPECT().GetByID(gomock.Any(), "hook-2", "tenant2").Return(hook, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	go func() {
+		useCase.Run(ctx)
+		close(done)
+	}()
+
+	<-registered
+
+	publish(context.Background(), dto.Event{Type: dto.EventDeviceAdded, GUID: "guid-1", TenantID: "tenant1"})
+	publish(context.Background(), dto.Event{Type: dto.EventDeviceAdded, GUID: "guid-2", TenantID: "tenant2"})
+
+	require.Eventually(t, func() bool {
+		return rc.count() == 1
+	}, 5*time.Second, 10*time.Millisecond, "event was held up by the slow receiver")
+
+	cancel()
+	<-done
+}
@@ -0,0 +1,40 @@
+package webhooks
+
+import (
+	"context"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity"
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+)
+
+type (
+	Repository interface {
+		GetCount(ctx context.Context, tenantID string) (int, error)
+		Get(ctx context.Context, top, skip int, tenantID string) ([]entity.Webhook, error)
+		GetByID(ctx context.Context, id, tenantID string) (*entity.Webhook, error)
+		GetEnabled(ctx context.Context, tenantID string) ([]entity.Webhook, error)
+		Delete(ctx context.Context, id, tenantID string) (bool, error)
+		Update(ctx context.Context, w *entity.Webhook) (bool, error)
+		Insert(ctx context.Context, w *entity.Webhook) (string, error)
+		InsertDelivery(ctx context.Context, d *entity.WebhookDelivery) error
+		GetDeliveries(ctx context.Context, webhookID string, top, skip int, tenantID string) ([]entity.WebhookDelivery, error)
+		GetDueDeliveries(ctx context.Context, now string) ([]entity.WebhookDelivery, error)
+		UpdateDeliveryStatus(ctx context.Context, id, tenantID, from, to string) (bool, error)
+		UpdateDelivery(ctx context.Context, d *entity.WebhookDelivery) (bool, error)
+	}
+	Feature interface {
+		GetCount(ctx context.Context, tenantID string) (int, error)
+		Get(ctx context.Context, top, skip int, tenantID string) ([]dto.Webhook, error)
+		GetByID(ctx context.Context, id, tenantID string) (*dto.Webhook, error)
+		Delete(ctx context.Context, id, tenantID string) error
+		Update(ctx context.Context, w *dto.Webhook) (*dto.Webhook, error)
+		Insert(ctx context.Context, w *dto.Webhook) (*dto.Webhook, error)
+		GetDeliveries(ctx context.Context, webhookID string, top, skip int, tenantID string) ([]dto.WebhookDelivery, error)
+		Test(ctx context.Context, id, tenantID string) (*dto.WebhookDelivery, error)
+		Run(ctx context.Context)
+	}
+	// Events is the broker whose events webhooks are delivered, it calls the hook as each one is published.
+	Events interface {
+		OnPublish(fn func(ctx context.Context, e dto.Event)) func()
+	}
+)
@@ -0,0 +1,355 @@
+package webhooks
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/open-amt-cloud-toolkit/go-wsman-messages/v2/pkg/security"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity"
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/sqldb"
+	"github.com/open-amt-cloud-toolkit/console/pkg/consoleerrors"
+	"github.com/open-amt-cloud-toolkit/console/pkg/logger"
+)
+
+const (
+	defaultDeliveryTimeout = 10 * time.Second
+	defaultRetryBackoff    = 30 * time.Second
+	secretLength           = 32
+)
+
+// Config controls how deliveries are sent and retried.
+type Config struct {
+	// DeliveryTimeout bounds a single POST to a webhook.
+	DeliveryTimeout time.Duration
+	// MaxAttempts is the number of times a delivery is tried before it is marked failed.
+	MaxAttempts int
+	// RetryBackoff is the delay before the first retry, it doubles with every further attempt.
+	RetryBackoff time.Duration
+}
+
+// UseCase -.
+type UseCase struct {
+	repo             Repository
+	events           Events
+	log              logger.Interface
+	safeRequirements security.Cryptor
+	client           *http.Client
+	cfg              Config
+	wake             chan struct{}
+}
+
+// New -.
+func New(r Repository, e Events, log logger.Interface, safeRequirements security.Cryptor, cfg Config) *UseCase {
+	if cfg.DeliveryTimeout <= 0 {
+		cfg.DeliveryTimeout = defaultDeliveryTimeout
+	}
+
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = 1
+	}
+
+	if cfg.RetryBackoff <= 0 {
+		cfg.RetryBackoff = defaultRetryBackoff
+	}
+
+	return &UseCase{
+		repo:             r,
+		events:           e,
+		log:              log,
+		safeRequirements: safeRequirements,
+		client: &http.Client{
+			Timeout: cfg.DeliveryTimeout,
+			// a redirect is reported as the delivery's outcome rather than followed to a host nobody configured
+			CheckRedirect: func(_ *http.Request, _ []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		cfg:  cfg,
+		wake: make(chan struct{}, 1),
+	}
+}
+
+var (
+	ErrWebhooksUseCase = consoleerrors.CreateConsoleError("WebhooksUseCase")
+	ErrDatabase        = sqldb.DatabaseError{Console: ErrWebhooksUseCase}
+	ErrNotFound        = sqldb.NotFoundError{Console: ErrWebhooksUseCase}
+	ErrNotValid        = dto.NotValidError{Console: ErrWebhooksUseCase}
+
+	errEventType = errors.New("unknown event type")
+)
+
+func (uc *UseCase) GetCount(ctx context.Context, tenantID string) (int, error) {
+	count, err := uc.repo.GetCount(ctx, tenantID)
+	if err != nil {
+		return 0, ErrDatabase.Wrap("GetCount", "uc.repo.GetCount", err)
+	}
+
+	return count, nil
+}
+
+func (uc *UseCase) Get(ctx context.Context, top, skip int, tenantID string) ([]dto.Webhook, error) {
+	data, err := uc.repo.Get(ctx, top, skip, tenantID)
+	if err != nil {
+		return nil, ErrDatabase.Wrap("Get", "uc.repo.Get", err)
+	}
+
+	d1 := make([]dto.Webhook, len(data))
+
+	for i := range data {
+		tmpEntity := data[i] // create a new variable to avoid memory aliasing
+		d1[i] = *uc.entityToDTO(&tmpEntity)
+	}
+
+	return d1, nil
+}
+
+func (uc *UseCase) GetByID(ctx context.Context, id, tenantID string) (*dto.Webhook, error) {
+	data, err := uc.repo.GetByID(ctx, id, tenantID)
+	if err != nil {
+		return nil, ErrDatabase.Wrap("GetByID", "uc.repo.GetByID", err)
+	}
+
+	if data == nil {
+		return nil, ErrNotFound
+	}
+
+	return uc.entityToDTO(data), nil
+}
+
+func (uc *UseCase) Delete(ctx context.Context, id, tenantID string) error {
+	isSuccessful, err := uc.repo.Delete(ctx, id, tenantID)
+	if err != nil {
+		return ErrDatabase.Wrap("Delete", "uc.repo.Delete", err)
+	}
+
+	if !isSuccessful {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+func (uc *UseCase) Update(ctx context.Context, d *dto.Webhook) (*dto.Webhook, error) {
+	existing, err := uc.repo.GetByID(ctx, d.ID, d.TenantID)
+	if err != nil {
+		return nil, ErrDatabase.Wrap("Update", "uc.repo.GetByID", err)
+	}
+
+	if existing == nil {
+		return nil, ErrNotFound
+	}
+
+	d1, err := uc.dtoToEntity(d)
+	if err != nil {
+		return nil, err
+	}
+
+	// an update without a secret keeps the one the receiver already verifies against
+	if d.Secret == "" {
+		d1.Secret = existing.Secret
+	}
+
+	updated, err := uc.repo.Update(ctx, d1)
+	if err != nil {
+		return nil, ErrDatabase.Wrap("Update", "uc.repo.Update", err)
+	}
+
+	if !updated {
+		return nil, ErrNotFound
+	}
+
+	return uc.GetByID(ctx, d.ID, d.TenantID)
+}
+
+// Insert creates a webhook, the response is the only place its secret is ever returned.
+func (uc *UseCase) Insert(ctx context.Context, d *dto.Webhook) (*dto.Webhook, error) {
+	secret := d.Secret
+	if secret == "" {
+		generated, err := generateSecret()
+		if err != nil {
+			return nil, ErrWebhooksUseCase.Wrap("Insert", "generateSecret", err)
+		}
+
+		secret = generated
+	}
+
+	d2 := *d
+	d2.Secret = secret
+
+	d1, err := uc.dtoToEntity(&d2)
+	if err != nil {
+		return nil, err
+	}
+
+	d1.ID = uuid.New().String()
+	d1.CreationDate = formatTime(time.Now())
+
+	id, err := uc.repo.Insert(ctx, d1)
+	if err != nil {
+		return nil, ErrDatabase.Wrap("Insert", "uc.repo.Insert", err)
+	}
+
+	created, err := uc.GetByID(ctx, id, d.TenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	created.Secret = secret
+
+	return created, nil
+}
+
+func (uc *UseCase) GetDeliveries(ctx context.Context, webhookID string, top, skip int, tenantID string) ([]dto.WebhookDelivery, error) {
+	webhook, err := uc.repo.GetByID(ctx, webhookID, tenantID)
+	if err != nil {
+		return nil, ErrDatabase.Wrap("GetDeliveries", "uc.repo.GetByID", err)
+	}
+
+	if webhook == nil {
+		return nil, ErrNotFound
+	}
+
+	data, err := uc.repo.GetDeliveries(ctx, webhookID, top, skip, tenantID)
+	if err != nil {
+		return nil, ErrDatabase.Wrap("GetDeliveries", "uc.repo.GetDeliveries", err)
+	}
+
+	deliveries := make([]dto.WebhookDelivery, len(data))
+
+	for i := range data {
+		tmpEntity := data[i] // create a new variable to avoid memory aliasing
+		deliveries[i] = *deliveryToDTO(&tmpEntity)
+	}
+
+	return deliveries, nil
+}
+
+// convert dto.Webhook to entity.Webhook.
+func (uc *UseCase) dtoToEntity(d *dto.Webhook) (*entity.Webhook, error) {
+	for _, eventType := range d.EventTypes {
+		if !validEventType(eventType) {
+			return nil, ErrNotValid.Wrap("dtoToEntity", "validEventType", fmt.Errorf("%w %s", errEventType, eventType))
+		}
+	}
+
+	d1 := &entity.Webhook{
+		ID:         d.ID,
+		Name:       d.Name,
+		URL:        d.URL,
+		EventTypes: strings.Join(d.EventTypes, ","),
+		Enabled:    d.Enabled,
+		TenantID:   d.TenantID,
+	}
+
+	if d.Secret != "" {
+		secret, err := uc.safeRequirements.Encrypt(d.Secret)
+		if err != nil {
+			return nil, ErrWebhooksUseCase.Wrap("dtoToEntity", "uc.safeRequirements.Encrypt", err)
+		}
+
+		d1.Secret = secret
+	}
+
+	return d1, nil
+}
+
+// convert entity.Webhook to dto.Webhook, the secret never leaves the usecase.
+func (uc *UseCase) entityToDTO(d *entity.Webhook) *dto.Webhook {
+	d1 := &dto.Webhook{
+		ID:         d.ID,
+		Name:       d.Name,
+		URL:        d.URL,
+		EventTypes: splitList(d.EventTypes),
+		Enabled:    d.Enabled,
+		TenantID:   d.TenantID,
+	}
+
+	if created := parseTime(d.CreationDate); created != nil {
+		d1.CreationDate = *created
+	}
+
+	return d1
+}
+
+// convert entity.WebhookDelivery to dto.WebhookDelivery.
+func deliveryToDTO(d *entity.WebhookDelivery) *dto.WebhookDelivery {
+	d1 := &dto.WebhookDelivery{
+		ID:         d.ID,
+		WebhookID:  d.WebhookID,
+		EventID:    d.EventID,
+		EventType:  d.EventType,
+		Attempt:    d.Attempt,
+		Status:     d.Status,
+		StatusCode: d.StatusCode,
+		Error:      d.Error,
+		TenantID:   d.TenantID,
+	}
+
+	// a queued delivery is sent as soon as it can be, its retry_at only orders the queue
+	if d.Status != dto.DeliveryStatusQueued {
+		d1.RetryAt = parseTime(d.RetryAt)
+	}
+
+	if created := parseTime(d.CreatedAt); created != nil {
+		d1.CreatedAt = *created
+	}
+
+	return d1
+}
+
+func validEventType(eventType string) bool {
+	if eventType == dto.EventAll {
+		return true
+	}
+
+	for _, known := range dto.EventTypes {
+		if eventType == known {
+			return true
+		}
+	}
+
+	return false
+}
+
+func generateSecret() (string, error) {
+	b := make([]byte, secretLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
+
+func parseTime(s string) *time.Time {
+	if s == "" {
+		return nil
+	}
+
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return nil
+	}
+
+	return &t
+}
+
+func splitList(s string) []string {
+	if s == "" {
+		return nil
+	}
+
+	return strings.Split(s, ",")
+}
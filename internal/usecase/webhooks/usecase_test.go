@@ -0,0 +1,207 @@
+package webhooks_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/open-amt-cloud-toolkit/console/internal/entity"
+	"github.com/open-amt-cloud-toolkit/console/internal/entity/dto/v1"
+	"github.com/open-amt-cloud-toolkit/console/internal/mocks"
+	"github.com/open-amt-cloud-toolkit/console/internal/usecase/webhooks"
+	"github.com/open-amt-cloud-toolkit/console/pkg/logger"
+)
+
+func webhooksTest(t *testing.T) (*webhooks.UseCase, *mocks.MockWebhooksRepository) {
+	t.Helper()
+
+	mockCtl := gomock.NewController(t)
+
+	repo := mocks.NewMockWebhooksRepository(mockCtl)
+	events := mocks.NewMockWebhooksEvents(mockCtl)
+	log := logger.New("error")
+	useCase := webhooks.New(repo, events, log, mocks.MockCrypto{}, webhooks.Config{
+		DeliveryTimeout: time.Second,
+		MaxAttempts:     3,
+		RetryBackoff:    time.Minute,
+	})
+
+	return useCase, repo
+}
+
+func TestGetByID(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		mock func(repo *mocks.MockWebhooksRepository)
+		res  *dto.Webhook
+		err  error
+	}{
+		{
+			name: "secret is not returned",
+			mock: func(repo *mocks.MockWebhooksRepository) {
+				repo.EXPECT().GetByID(context.Background(), "hook-1", "").Return(&entity.Webhook{
+					ID:           "hook-1",
+					Name:         "ticketing",
+					URL:          "https://hooks.example.com",
+					Secret:       "encrypted",
+					EventTypes:   "device.power,device.deleted",
+					Enabled:      true,
+					CreationDate: "2024-01-01T00:00:00Z",
+				}, nil)
+			},
+			res: &dto.Webhook{
+				ID:           "hook-1",
+				Name:         "ticketing",
+				URL:          "https://hooks.example.com",
+				EventTypes:   []string{dto.EventPowerAction, dto.EventDeviceDeleted},
+				Enabled:      true,
+				CreationDate: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "not found",
+			mock: func(repo *mocks.MockWebhooksRepository) {
+				repo.EXPECT().GetByID(context.Background(), "hook-1", "").Return(nil, nil)
+			},
+			err: webhooks.ErrNotFound,
+		},
+		{
+			name: "database error",
+			mock: func(repo *mocks.MockWebhooksRepository) {
+				repo.EXPECT().GetByID(context.Background(), "hook-1", "").Return(nil, webhooks.ErrDatabase)
+			},
+			err: webhooks.ErrDatabase,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			useCase, repo := webhooksTest(t)
+
+			tc.mock(repo)
+
+			res, err := useCase.GetByID(context.Background(), "hook-1", "")
+
+			require.Equal(t, tc.res, res)
+			require.IsType(t, tc.err, err)
+		})
+	}
+}
+
+func TestInsert(t *testing.T) {
+	t.Parallel()
+
+	t.Run("generates a secret and returns it once", func(t *testing.T) {
+		t.Parallel()
+
+		useCase, repo := webhooksTest(t)
+
+		var inserted *entity.Webhook
+
+		repo.EXPECT().Insert(context.Background(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, w *entity.Webhook) (string, error) {
+				inserted = w
+
+				return w.ID, nil
+			})
+		repo.EXPECT().GetByID(context.Background(), gomock.Any(), "tenant1").
+			DoAndReturn(func(_ context.Context, _, _ string) (*entity.Webhook, error) {
+				return inserted, nil
+			})
+
+		res, err := useCase.Insert(context.Background(), &dto.Webhook{
+			Name:       "ticketing",
+			URL:        "https://hooks.example.com",
+			EventTypes: []string{dto.EventAll},
+			Enabled:    true,
+			TenantID:   "tenant1",
+		})
+		require.NoError(t, err)
+		require.Len(t, res.Secret, 64)
+		require.Equal(t, "encrypted", inserted.Secret)
+		require.Equal(t, "*", inserted.EventTypes)
+		require.NotEmpty(t, inserted.ID)
+	})
+
+	t.Run("unknown event type", func(t *testing.T) {
+		t.Parallel()
+
+		useCase, _ := webhooksTest(t)
+
+		_, err := useCase.Insert(context.Background(), &dto.Webhook{
+			Name:       "ticketing",
+			URL:        "https://hooks.example.com",
+			EventTypes: []string{"device.exploded"},
+		})
+		require.ErrorAs(t, err, &dto.NotValidError{})
+		require.ErrorContains(t, err, "unknown event type device.exploded")
+	})
+}
+
+func TestUpdateKeepsSecret(t *testing.T) {
+	t.Parallel()
+
+	useCase, repo := webhooksTest(t)
+
+	existing := &entity.Webhook{ID: "hook-1", Secret: "previous", EventTypes: "device.power", TenantID: "tenant1"}
+
+	repo.EXPECT().GetByID(context.Background(), "hook-1", "tenant1").Return(existing, nil).Times(2)
+	repo.EXPECT().Update(context.Background(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, w *entity.Webhook) (bool, error) {
+			require.Equal(t, "previous", w.Secret)
+			require.Equal(t, "device.power,device.deleted", w.EventTypes)
+
+			return true, nil
+		})
+
+	_, err := useCase.Update(context.Background(), &dto.Webhook{
+		ID:         "hook-1",
+		Name:       "ticketing",
+		URL:        "https://hooks.example.com",
+		EventTypes: []string{dto.EventPowerAction, dto.EventDeviceDeleted},
+		TenantID:   "tenant1",
+	})
+	require.NoError(t, err)
+}
+
+func TestGetDeliveries(t *testing.T) {
+	t.Parallel()
+
+	useCase, repo := webhooksTest(t)
+
+	repo.EXPECT().GetByID(context.Background(), "hook-1", "").Return(&entity.Webhook{ID: "hook-1"}, nil)
+	repo.EXPECT().GetDeliveries(context.Background(), "hook-1", 10, 0, "").Return([]entity.WebhookDelivery{
+		{ID: "delivery-1", WebhookID: "hook-1", EventID: "event-1", EventType: "device.power", Attempt: 1, Status: "retry_pending", StatusCode: 500, RetryAt: "2024-01-01T10:05:00Z", CreatedAt: "2024-01-01T10:00:00Z"},
+	}, nil)
+
+	res, err := useCase.GetDeliveries(context.Background(), "hook-1", 10, 0, "")
+	require.NoError(t, err)
+
+	retryAt := time.Date(2024, 1, 1, 10, 5, 0, 0, time.UTC)
+
+	require.Equal(t, []dto.WebhookDelivery{{
+		ID:         "delivery-1",
+		WebhookID:  "hook-1",
+		EventID:    "event-1",
+		EventType:  "device.power",
+		Attempt:    1,
+		Status:     dto.DeliveryStatusRetryPending,
+		StatusCode: 500,
+		RetryAt:    &retryAt,
+		CreatedAt:  time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC),
+	}}, res)
+
+	repo.EXPECT().GetByID(context.Background(), "missing", "").Return(nil, nil)
+
+	_, err = useCase.GetDeliveries(context.Background(), "missing", 10, 0, "")
+	require.IsType(t, webhooks.ErrNotFound, err)
+}
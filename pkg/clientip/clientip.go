@@ -0,0 +1,18 @@
+// Package clientip carries the address a request came from through a context.Context.
+package clientip
+
+import "context"
+
+type contextKey struct{}
+
+// NewContext returns a copy of ctx that carries ip.
+func NewContext(ctx context.Context, ip string) context.Context {
+	return context.WithValue(ctx, contextKey{}, ip)
+}
+
+// FromContext returns the address carried by ctx, or an empty string when it is unknown.
+func FromContext(ctx context.Context) string {
+	ip, _ := ctx.Value(contextKey{}).(string)
+
+	return ip
+}
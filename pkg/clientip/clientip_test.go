@@ -0,0 +1,17 @@
+package clientip_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-amt-cloud-toolkit/console/pkg/clientip"
+)
+
+func TestContext(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, "", clientip.FromContext(context.Background()))
+	require.Equal(t, "10.0.0.5", clientip.FromContext(clientip.NewContext(context.Background(), "10.0.0.5")))
+}
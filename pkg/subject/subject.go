@@ -0,0 +1,18 @@
+// Package subject carries the authenticated caller of a request through a context.Context.
+package subject
+
+import "context"
+
+type contextKey struct{}
+
+// NewContext returns a copy of ctx that carries subject.
+func NewContext(ctx context.Context, subject string) context.Context {
+	return context.WithValue(ctx, contextKey{}, subject)
+}
+
+// FromContext returns the subject carried by ctx, or an empty string when the caller is unknown.
+func FromContext(ctx context.Context) string {
+	subject, _ := ctx.Value(contextKey{}).(string)
+
+	return subject
+}
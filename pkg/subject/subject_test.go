@@ -0,0 +1,17 @@
+package subject_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-amt-cloud-toolkit/console/pkg/subject"
+)
+
+func TestContext(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, "", subject.FromContext(context.Background()))
+	require.Equal(t, "alice", subject.FromContext(subject.NewContext(context.Background(), "alice")))
+}
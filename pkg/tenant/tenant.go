@@ -0,0 +1,113 @@
+// Package tenant carries the tenant of a request through a context.Context.
+package tenant
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"strings"
+)
+
+type contextKey struct{}
+
+// Default is the tenant of callers that do not name one, it is also the tenant that administers the others.
+const Default = ""
+
+// NewContext returns a copy of ctx that carries tenantID.
+func NewContext(ctx context.Context, tenantID string) context.Context {
+	return context.WithValue(ctx, contextKey{}, tenantID)
+}
+
+// FromContext returns the tenant carried by ctx, or Default when there is none.
+func FromContext(ctx context.Context) string {
+	tenantID, _ := ctx.Value(contextKey{}).(string)
+
+	return tenantID
+}
+
+// Header names the tenant of a request forwarded by a trusted proxy.
+const Header = "X-Tenant-Id"
+
+// Proxies are the networks whose Header is trusted.
+type Proxies []*net.IPNet
+
+// ParseProxies reads a list of IP addresses and CIDR networks. Entries that are neither are skipped, so a
+// typo trusts nothing rather than everything.
+func ParseProxies(list []string) Proxies {
+	proxies := make(Proxies, 0, len(list))
+
+	for _, entry := range list {
+		entry = strings.TrimSpace(entry)
+
+		if !strings.Contains(entry, "/") {
+			ip := net.ParseIP(entry)
+			if ip == nil {
+				continue
+			}
+
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 8*net.IPv4len
+			}
+
+			proxies = append(proxies, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+
+			continue
+		}
+
+		if _, network, err := net.ParseCIDR(entry); err == nil {
+			proxies = append(proxies, network)
+		}
+	}
+
+	return proxies
+}
+
+// Contains reports whether ip belongs to one of the proxies.
+func (p Proxies) Contains(ip net.IP) bool {
+	if ip == nil {
+		return false
+	}
+
+	for _, network := range p {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ErrMismatch is returned for a request whose Header names another tenant than its token.
+var ErrMismatch = errors.New("tenant header does not match the tenant of the token")
+
+// FromRequest returns the tenant named by the Header of r when remoteIP is a trusted proxy, and claimed otherwise.
+// The tenant claimed by an unauthenticated request, such as a login, is no more than a hint the proxy overrides.
+func (p Proxies) FromRequest(r *http.Request, remoteIP net.IP, claimed string) string {
+	if header := r.Header.Get(Header); header != "" && p.Contains(remoteIP) {
+		return header
+	}
+
+	return claimed
+}
+
+// FromToken returns the tenant of r when it is authenticated by a token issued for claimed. The tenant of a token
+// is never overridden: the Header of a trusted proxy only names the tenant of a token that has none, and naming
+// another tenant than the token's is ErrMismatch.
+func (p Proxies) FromToken(r *http.Request, remoteIP net.IP, claimed string) (string, error) {
+	header := r.Header.Get(Header)
+	if header == "" || !p.Contains(remoteIP) {
+		return claimed, nil
+	}
+
+	if claimed == Default {
+		return header, nil
+	}
+
+	if header != claimed {
+		return "", ErrMismatch
+	}
+
+	return claimed, nil
+}
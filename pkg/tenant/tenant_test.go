@@ -0,0 +1,94 @@
+package tenant_test
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-amt-cloud-toolkit/console/pkg/tenant"
+)
+
+func TestContext(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, tenant.Default, tenant.FromContext(context.Background()))
+	require.Equal(t, "acme", tenant.FromContext(tenant.NewContext(context.Background(), "acme")))
+}
+
+func TestProxies(t *testing.T) {
+	t.Parallel()
+
+	proxies := tenant.ParseProxies([]string{"10.0.0.0/8", " 192.168.1.5 ", "::1", "not an address"})
+
+	require.Len(t, proxies, 3)
+
+	tests := []struct {
+		name     string
+		remoteIP string
+		header   string
+		expected string
+	}{
+		{name: "trusted network", remoteIP: "10.1.2.3", header: "acme", expected: "acme"},
+		{name: "trusted address", remoteIP: "192.168.1.5", header: "acme", expected: "acme"},
+		{name: "trusted ipv6 address", remoteIP: "::1", header: "acme", expected: "acme"},
+		{name: "untrusted address", remoteIP: "192.168.1.6", header: "acme", expected: "claimed"},
+		{name: "no header", remoteIP: "10.1.2.3", expected: "claimed"},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+			if tc.header != "" {
+				req.Header.Set(tenant.Header, tc.header)
+			}
+
+			require.Equal(t, tc.expected, proxies.FromRequest(req, net.ParseIP(tc.remoteIP), "claimed"))
+		})
+	}
+}
+
+func TestFromToken(t *testing.T) {
+	t.Parallel()
+
+	proxies := tenant.ParseProxies([]string{"10.0.0.0/8"})
+
+	tests := []struct {
+		name     string
+		remoteIP string
+		header   string
+		claimed  string
+		expected string
+		err      error
+	}{
+		{name: "token tenant", remoteIP: "10.1.2.3", claimed: "acme", expected: "acme"},
+		{name: "header agrees with the token", remoteIP: "10.1.2.3", header: "acme", claimed: "acme", expected: "acme"},
+		{name: "header names the tenant of a token without one", remoteIP: "10.1.2.3", header: "acme", expected: "acme"},
+		{name: "header cannot override the token", remoteIP: "10.1.2.3", header: "globex", claimed: "acme", err: tenant.ErrMismatch},
+		{name: "untrusted header is ignored", remoteIP: "192.168.1.6", header: "globex", claimed: "acme", expected: "acme"},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+			if tc.header != "" {
+				req.Header.Set(tenant.Header, tc.header)
+			}
+
+			tenantID, err := proxies.FromToken(req, net.ParseIP(tc.remoteIP), tc.claimed)
+			require.ErrorIs(t, err, tc.err)
+			require.Equal(t, tc.expected, tenantID)
+		})
+	}
+}